@@ -26,7 +26,9 @@
 // Receivers are responsible for obtaining a client.Info from the current
 // context and enhancing the client.Info with the net.Addr from the peer,
 // storing a new client.Info into the context that it passes down. For HTTP
-// requests, the net.Addr is typically the IP address of the client.
+// requests, the net.Addr is typically the IP address of the client. When the
+// client presents a TLS certificate verified by the receiver, its identity is
+// stored as the client.TLSIdentity.
 //
 // Typically, however, receivers would delegate this processing to helpers such
 // as the confighttp or configgrpc packages: both contain interceptors that will
@@ -90,6 +92,7 @@ package client // import "go.opentelemetry.io/collector/client"
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 )
 
@@ -110,6 +113,41 @@ type Info struct {
 	// Metadata is the request metadata from the client connecting to this connector.
 	// Experimental: *NOTE* this structure is subject to change or removal in the future.
 	Metadata Metadata
+
+	// TLS is the identity of the client certificate verified by the receiver when mTLS is enabled,
+	// nil otherwise. Available for receivers making use of confighttp.ToServer and configgrpc.ToServerOption.
+	TLS *TLSIdentity
+}
+
+// TLSIdentity is the identity of a verified TLS client certificate, allowing to attribute the telemetry
+// to workloads, e.g. with the SPIFFE ID "spiffe://cluster.local/ns/default/sa/app" of the URIs.
+type TLSIdentity struct {
+	// CommonName is the common name of the subject of the certificate.
+	CommonName string
+	// DNSNames are the DNS names of the subject alternative names of the certificate.
+	DNSNames []string
+	// URIs are the URIs of the subject alternative names of the certificate.
+	URIs []string
+	// EmailAddresses are the email addresses of the subject alternative names of the certificate.
+	EmailAddresses []string
+}
+
+// NewTLSIdentity returns the identity of the client certificate of the TLS connection,
+// nil if the client did not present a certificate verified by the server.
+func NewTLSIdentity(state tls.ConnectionState) *TLSIdentity {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	cert := state.VerifiedChains[0][0]
+	id := &TLSIdentity{
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+	}
+	return id
 }
 
 // Metadata is an immutable map, meant to contain request metadata.
@@ -131,6 +169,16 @@ type AuthData interface {
 	GetAttributeNames() []string
 }
 
+// Conventional names of the AuthData attributes holding the resolved identity of the client. Authenticators
+// resolving a subject or a tenant should use these names, so that components such as the OTLP receiver can
+// attribute the telemetry to them without depending on a specific authenticator.
+const (
+	// AuthAttributeSubject is the name of the string attribute holding the authenticated subject, e.g. the username.
+	AuthAttributeSubject = "subject"
+	// AuthAttributeTenant is the name of the string attribute holding the tenant the subject belongs to.
+	AuthAttributeTenant = "tenant"
+)
+
 const MetadataHostName = "Host"
 
 // NewContext takes an existing context and derives a new context with the
@@ -18,7 +18,11 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -99,3 +103,41 @@ func TestMetadata(t *testing.T) {
 
 	assert.Empty(t, md.Get("non-existent-key"))
 }
+
+func TestNewTLSIdentity(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "app"},
+		DNSNames:       []string{"app.default.svc"},
+		URIs:           []*url.URL{{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/default/sa/app"}},
+		EmailAddresses: []string{"app@example.com"},
+	}
+	testCases := []struct {
+		desc     string
+		state    tls.ConnectionState
+		expected *TLSIdentity
+	}{
+		{
+			desc:  "no client certificate",
+			state: tls.ConnectionState{},
+		},
+		{
+			desc:  "unverified client certificate",
+			state: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+		},
+		{
+			desc:  "verified client certificate",
+			state: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert}}},
+			expected: &TLSIdentity{
+				CommonName:     "app",
+				DNSNames:       []string{"app.default.svc"},
+				URIs:           []string{"spiffe://cluster.local/ns/default/sa/app"},
+				EmailAddresses: []string{"app@example.com"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, NewTLSIdentity(tC.state))
+		})
+	}
+}
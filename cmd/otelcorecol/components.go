@@ -8,6 +8,10 @@ import (
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
+	debuguiextension "go.opentelemetry.io/collector/extension/debuguiextension"
+	loglevelextension "go.opentelemetry.io/collector/extension/loglevelextension"
+	memorylimiterextension "go.opentelemetry.io/collector/extension/memorylimiterextension"
+	pprofextension "go.opentelemetry.io/collector/extension/pprofextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	batchprocessor "go.opentelemetry.io/collector/processor/batchprocessor"
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
@@ -20,6 +24,10 @@ func components() (component.Factories, error) {
 
 	factories.Extensions, err = component.MakeExtensionFactoryMap(
 		ballastextension.NewFactory(),
+		debuguiextension.NewFactory(),
+		loglevelextension.NewFactory(),
+		memorylimiterextension.NewFactory(),
+		pprofextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
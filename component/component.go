@@ -169,3 +169,15 @@ func (bf baseFactory) StabilityLevel(dt config.DataType) StabilityLevel {
 	}
 	return StabilityLevelUndefined
 }
+
+// SupportedDataTypes returns the data types, in traces, metrics, logs order, for which a
+// create func was configured. The stability level is recorded for all of them, even when undefined.
+func (bf baseFactory) SupportedDataTypes() []config.DataType {
+	var ret []config.DataType
+	for _, dt := range []config.DataType{config.TracesDataType, config.MetricsDataType, config.LogsDataType} {
+		if _, ok := bf.stability[dt]; ok {
+			ret = append(ret, dt)
+		}
+	}
+	return ret
+}
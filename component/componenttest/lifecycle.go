@@ -0,0 +1,356 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest // import "go.opentelemetry.io/collector/component/componenttest"
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+// lifecycleCallTimeout is the time after which a component call is considered hanging.
+const lifecycleCallTimeout = 10 * time.Second
+
+// LifecycleOption configures CheckLifecycle.
+type LifecycleOption func(*lifecycleSettings)
+
+type lifecycleSettings struct {
+	cfg  interface{}
+	host component.Host
+}
+
+// WithLifecycleConfig makes CheckLifecycle create the components with the configuration instead of the
+// default configuration of the factory, e.g. to set a free port. It must be a config.Receiver, config.Processor,
+// config.Exporter or config.Extension, as created by the factory.
+func WithLifecycleConfig(cfg interface{}) LifecycleOption {
+	return func(ls *lifecycleSettings) {
+		ls.cfg = cfg
+	}
+}
+
+// WithLifecycleHost makes CheckLifecycle start the components with the host instead of NewNopHost,
+// e.g. to provide the extensions they depend on.
+func WithLifecycleHost(host component.Host) LifecycleOption {
+	return func(ls *lifecycleSettings) {
+		ls.host = host
+	}
+}
+
+// lifecycleInstance is a component under test.
+type lifecycleInstance struct {
+	component.Component
+	// consume sends data to the processor or exporter, nil for the receivers and extensions.
+	consume func(ctx context.Context) error
+	// produced returns the number of items produced by the receiver or processor, nil otherwise.
+	produced func() int
+}
+
+// lifecycleTarget creates the components under test for a data type, or the extensions.
+type lifecycleTarget struct {
+	name   string
+	create func() (lifecycleInstance, error)
+}
+
+// CheckLifecycle checks that the receivers, processors, exporters or extensions created by the factory
+// follow the lifecycle described by component.Component, for each data type they support:
+//   - they are created, started and shut down without error, twice in a row with the same configuration,
+//   - they do not produce data after being shut down,
+//   - the consume calls, including with a cancelled context and after shutdown, return,
+//   - a second shutdown returns.
+//
+// Every call must return within 10 seconds without panicking. The errors of the consume calls, and of the
+// calls after shutdown, are not checked. Components can claim conformance by running:
+//
+//	func TestLifecycle(t *testing.T) {
+//		componenttest.CheckLifecycle(t, NewFactory())
+//	}
+func CheckLifecycle(t *testing.T, factory component.Factory, opts ...LifecycleOption) {
+	ls := &lifecycleSettings{host: NewNopHost()}
+	for _, opt := range opts {
+		opt(ls)
+	}
+	targets := lifecycleTargets(t, factory, ls.cfg)
+	for _, target := range targets {
+		t.Run(target.name, func(t *testing.T) {
+			checkTargetLifecycle(t, target, ls.host)
+		})
+	}
+}
+
+func checkTargetLifecycle(t *testing.T, target lifecycleTarget, host component.Host) {
+	// The lifecycle may be repeated with the same configuration.
+	for run := 0; run < 2; run++ {
+		comp, err := target.create()
+		if run == 0 && errors.Is(err, component.ErrDataTypeIsNotSupported) {
+			t.Skip("data type not supported")
+		}
+		require.NoError(t, err)
+		require.NotNil(t, comp.Component)
+		consume := comp.consume
+
+		require.NoError(t, callWithin(t, "Start", func() error {
+			return comp.Start(context.Background(), host)
+		}))
+		if consume != nil {
+			_ = callWithin(t, "consume", func() error {
+				return consume(context.Background())
+			})
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_ = callWithin(t, "consume with a cancelled context", func() error {
+				return consume(ctx)
+			})
+		}
+		require.NoError(t, callWithin(t, "Shutdown", func() error {
+			return comp.Shutdown(context.Background())
+		}))
+		if comp.produced != nil {
+			produced := comp.produced()
+			<-time.After(50 * time.Millisecond)
+			assert.Equal(t, produced, comp.produced(), "data produced after shutdown")
+		}
+		if consume != nil {
+			_ = callWithin(t, "consume after shutdown", func() error {
+				return consume(context.Background())
+			})
+		}
+		_ = callWithin(t, "second Shutdown", func() error {
+			return comp.Shutdown(context.Background())
+		})
+	}
+}
+
+// callWithin calls f, failing the test if f panics or does not return within lifecycleCallTimeout.
+func callWithin(t *testing.T, name string, f func() error) error {
+	errC := make(chan error, 1)
+	panicC := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				panicC <- r
+			}
+		}()
+		errC <- f()
+	}()
+	timer := time.NewTimer(lifecycleCallTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-errC:
+		return err
+	case r := <-panicC:
+		t.Fatalf("%s panicked: %v", name, r)
+	case <-timer.C:
+		t.Fatalf("%s did not return within %v", name, lifecycleCallTimeout)
+	}
+	return nil
+}
+
+// lifecycleTargets returns the targets of the components created by the factory.
+func lifecycleTargets(t *testing.T, factory component.Factory, cfg interface{}) []lifecycleTarget {
+	switch f := factory.(type) {
+	case component.ReceiverFactory:
+		if cfg == nil {
+			cfg = f.CreateDefaultConfig()
+		}
+		rCfg, ok := cfg.(config.Receiver)
+		require.True(t, ok, "the configuration is not a config.Receiver")
+		return receiverTargets(f, rCfg)
+	case component.ProcessorFactory:
+		if cfg == nil {
+			cfg = f.CreateDefaultConfig()
+		}
+		pCfg, ok := cfg.(config.Processor)
+		require.True(t, ok, "the configuration is not a config.Processor")
+		return processorTargets(f, pCfg)
+	case component.ExporterFactory:
+		if cfg == nil {
+			cfg = f.CreateDefaultConfig()
+		}
+		eCfg, ok := cfg.(config.Exporter)
+		require.True(t, ok, "the configuration is not a config.Exporter")
+		return exporterTargets(f, eCfg)
+	case component.ExtensionFactory:
+		if cfg == nil {
+			cfg = f.CreateDefaultConfig()
+		}
+		eCfg, ok := cfg.(config.Extension)
+		require.True(t, ok, "the configuration is not a config.Extension")
+		return []lifecycleTarget{{
+			name: "extension",
+			create: func() (lifecycleInstance, error) {
+				ext, err := f.CreateExtension(context.Background(), NewNopExtensionCreateSettings(), eCfg)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{Component: ext}, nil
+			},
+		}}
+	}
+	t.Fatalf("unsupported factory %T", factory)
+	return nil
+}
+
+func receiverTargets(f component.ReceiverFactory, cfg config.Receiver) []lifecycleTarget {
+	return []lifecycleTarget{
+		{
+			name: string(config.TracesDataType),
+			create: func() (lifecycleInstance, error) {
+				sink := new(consumertest.TracesSink)
+				r, err := f.CreateTracesReceiver(context.Background(), NewNopReceiverCreateSettings(), cfg, sink)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{Component: r, produced: sink.SpanCount}, nil
+			},
+		},
+		{
+			name: string(config.MetricsDataType),
+			create: func() (lifecycleInstance, error) {
+				sink := new(consumertest.MetricsSink)
+				r, err := f.CreateMetricsReceiver(context.Background(), NewNopReceiverCreateSettings(), cfg, sink)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{Component: r, produced: sink.DataPointCount}, nil
+			},
+		},
+		{
+			name: string(config.LogsDataType),
+			create: func() (lifecycleInstance, error) {
+				sink := new(consumertest.LogsSink)
+				r, err := f.CreateLogsReceiver(context.Background(), NewNopReceiverCreateSettings(), cfg, sink)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{Component: r, produced: sink.LogRecordCount}, nil
+			},
+		},
+	}
+}
+
+func processorTargets(f component.ProcessorFactory, cfg config.Processor) []lifecycleTarget {
+	return []lifecycleTarget{
+		{
+			name: string(config.TracesDataType),
+			create: func() (lifecycleInstance, error) {
+				sink := new(consumertest.TracesSink)
+				p, err := f.CreateTracesProcessor(context.Background(), NewNopProcessorCreateSettings(), cfg, sink)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{
+					Component: p,
+					consume: func(ctx context.Context) error {
+						return p.ConsumeTraces(ctx, testdata.GenerateTraces(2))
+					},
+					produced: sink.SpanCount,
+				}, nil
+			},
+		},
+		{
+			name: string(config.MetricsDataType),
+			create: func() (lifecycleInstance, error) {
+				sink := new(consumertest.MetricsSink)
+				p, err := f.CreateMetricsProcessor(context.Background(), NewNopProcessorCreateSettings(), cfg, sink)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{
+					Component: p,
+					consume: func(ctx context.Context) error {
+						return p.ConsumeMetrics(ctx, testdata.GenerateMetrics(2))
+					},
+					produced: sink.DataPointCount,
+				}, nil
+			},
+		},
+		{
+			name: string(config.LogsDataType),
+			create: func() (lifecycleInstance, error) {
+				sink := new(consumertest.LogsSink)
+				p, err := f.CreateLogsProcessor(context.Background(), NewNopProcessorCreateSettings(), cfg, sink)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{
+					Component: p,
+					consume: func(ctx context.Context) error {
+						return p.ConsumeLogs(ctx, testdata.GenerateLogs(2))
+					},
+					produced: sink.LogRecordCount,
+				}, nil
+			},
+		},
+	}
+}
+
+func exporterTargets(f component.ExporterFactory, cfg config.Exporter) []lifecycleTarget {
+	return []lifecycleTarget{
+		{
+			name: string(config.TracesDataType),
+			create: func() (lifecycleInstance, error) {
+				e, err := f.CreateTracesExporter(context.Background(), NewNopExporterCreateSettings(), cfg)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{
+					Component: e,
+					consume: func(ctx context.Context) error {
+						return e.ConsumeTraces(ctx, testdata.GenerateTraces(2))
+					},
+				}, nil
+			},
+		},
+		{
+			name: string(config.MetricsDataType),
+			create: func() (lifecycleInstance, error) {
+				e, err := f.CreateMetricsExporter(context.Background(), NewNopExporterCreateSettings(), cfg)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{
+					Component: e,
+					consume: func(ctx context.Context) error {
+						return e.ConsumeMetrics(ctx, testdata.GenerateMetrics(2))
+					},
+				}, nil
+			},
+		},
+		{
+			name: string(config.LogsDataType),
+			create: func() (lifecycleInstance, error) {
+				e, err := f.CreateLogsExporter(context.Background(), NewNopExporterCreateSettings(), cfg)
+				if err != nil {
+					return lifecycleInstance{}, err
+				}
+				return lifecycleInstance{
+					Component: e,
+					consume: func(ctx context.Context) error {
+						return e.ConsumeLogs(ctx, testdata.GenerateLogs(2))
+					},
+				}, nil
+			},
+		},
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest
+
+import (
+	"testing"
+)
+
+func TestCheckLifecycle(t *testing.T) {
+	t.Run("receiver", func(t *testing.T) {
+		CheckLifecycle(t, NewNopReceiverFactory())
+	})
+	t.Run("processor", func(t *testing.T) {
+		CheckLifecycle(t, NewNopProcessorFactory(), WithLifecycleConfig(NewNopProcessorFactory().CreateDefaultConfig()))
+	})
+	t.Run("exporter", func(t *testing.T) {
+		CheckLifecycle(t, NewNopExporterFactory(), WithLifecycleHost(NewNopHost()))
+	})
+	t.Run("extension", func(t *testing.T) {
+		CheckLifecycle(t, NewNopExtensionFactory())
+	})
+}
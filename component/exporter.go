@@ -68,6 +68,9 @@ type ExporterFactory interface {
 	// tests of any implementation of the Factory interface.
 	CreateDefaultConfig() config.Exporter
 
+	// SupportedDataTypes returns the data types for which a Exporter can be created by this factory.
+	SupportedDataTypes() []config.DataType
+
 	// CreateTracesExporter creates a trace exporter based on this config.
 	// If the exporter type does not support tracing or if the config is not valid,
 	// an error will be returned instead.
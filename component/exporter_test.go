@@ -31,6 +31,7 @@ func TestNewExporterFactory(t *testing.T) {
 		func() config.Exporter { return &defaultCfg })
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.Empty(t, factory.SupportedDataTypes())
 	_, err := factory.CreateTracesExporter(context.Background(), ExporterCreateSettings{}, &defaultCfg)
 	assert.Error(t, err)
 	_, err = factory.CreateMetricsExporter(context.Background(), ExporterCreateSettings{}, &defaultCfg)
@@ -50,6 +51,7 @@ func TestNewExporterFactory_WithOptions(t *testing.T) {
 		WithLogsExporter(createLogsExporter))
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.Equal(t, []config.DataType{config.TracesDataType, config.MetricsDataType, config.LogsDataType}, factory.SupportedDataTypes())
 
 	_, err := factory.CreateTracesExporter(context.Background(), ExporterCreateSettings{}, &defaultCfg)
 	assert.NoError(t, err)
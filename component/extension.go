@@ -18,6 +18,9 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 // Extension is the interface for objects hosted by the OpenTelemetry Collector that
@@ -44,6 +47,45 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// PipelineDataObserver is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions observing the data flowing through
+// the pipelines without modifying them, e.g.: a debug tap streaming the recent telemetry.
+type PipelineDataObserver interface {
+	// DataObserver returns the DataObserver of the data flowing through the given point,
+	// or nil if the Extension does not observe this point. It is called when the pipelines
+	// are built, once for the output of each receiver and the input of each exporter of
+	// every pipeline.
+	DataObserver(point ObservationPoint) DataObserver
+}
+
+// ObservationPoint identifies a point of a pipeline where the data can be observed.
+type ObservationPoint struct {
+	// Pipeline is the ID of the pipeline, its type being the type of the observed data.
+	Pipeline config.ComponentID
+	// Kind is KindReceiver for the output of a receiver, KindExporter for the input of an exporter.
+	Kind Kind
+	// Component is the ID of the receiver or the exporter.
+	Component config.ComponentID
+}
+
+// DataObserver receives sampled copies of the data flowing through an ObservationPoint.
+// Its methods are called synchronously by the pipeline, for every batch of data, so
+// they must not block.
+type DataObserver interface {
+	// Sample returns whether the current batch of data is observed. The batch is only
+	// copied when it is observed.
+	Sample() bool
+
+	// ObserveTraces receives a copy of the sampled traces, owned by the DataObserver.
+	ObserveTraces(ctx context.Context, td ptrace.Traces)
+
+	// ObserveMetrics receives a copy of the sampled metrics, owned by the DataObserver.
+	ObserveMetrics(ctx context.Context, md pmetric.Metrics)
+
+	// ObserveLogs receives a copy of the sampled logs, owned by the DataObserver.
+	ObserveLogs(ctx context.Context, ld plog.Logs)
+}
+
 // ExtensionCreateSettings is passed to ExtensionFactory.Create* functions.
 type ExtensionCreateSettings struct {
 	TelemetrySettings
@@ -83,21 +125,40 @@ type ExtensionFactory interface {
 
 	// CreateExtension creates an extension based on the given config.
 	CreateExtension(ctx context.Context, set ExtensionCreateSettings, cfg config.Extension) (Extension, error)
+
+	// ExtensionStability gets the stability level of the Extension.
+	ExtensionStability() StabilityLevel
 }
 
 type extensionFactory struct {
 	baseFactory
 	ExtensionCreateDefaultConfigFunc
 	CreateExtensionFunc
+	extensionStability StabilityLevel
+}
+
+func (ef *extensionFactory) ExtensionStability() StabilityLevel {
+	return ef.extensionStability
 }
 
+// NewExtensionFactory returns a new ExtensionFactory with an "undefined" stability level.
 func NewExtensionFactory(
 	cfgType config.Type,
 	createDefaultConfig ExtensionCreateDefaultConfigFunc,
 	createServiceExtension CreateExtensionFunc) ExtensionFactory {
+	return NewExtensionFactoryWithStabilityLevel(cfgType, createDefaultConfig, createServiceExtension, StabilityLevelUndefined)
+}
+
+// NewExtensionFactoryWithStabilityLevel returns a new ExtensionFactory with the stability level.
+func NewExtensionFactoryWithStabilityLevel(
+	cfgType config.Type,
+	createDefaultConfig ExtensionCreateDefaultConfigFunc,
+	createServiceExtension CreateExtensionFunc,
+	sl StabilityLevel) ExtensionFactory {
 	return &extensionFactory{
 		baseFactory:                      baseFactory{cfgType: cfgType},
 		ExtensionCreateDefaultConfigFunc: createDefaultConfig,
 		CreateExtensionFunc:              createServiceExtension,
+		extensionStability:               sl,
 	}
 }
@@ -45,3 +45,18 @@ func TestNewExtensionFactory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Same(t, nopExtensionInstance, ext)
 }
+
+func TestNewExtensionFactoryWithStabilityLevel(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewExtensionSettings(config.NewComponentID(typeStr))
+	createExtension := func(ctx context.Context, settings ExtensionCreateSettings, extension config.Extension) (Extension, error) {
+		return new(nopExtension), nil
+	}
+
+	factory := NewExtensionFactory(typeStr, func() config.Extension { return &defaultCfg }, createExtension)
+	assert.Equal(t, StabilityLevel(StabilityLevelUndefined), factory.ExtensionStability())
+
+	factory = NewExtensionFactoryWithStabilityLevel(typeStr, func() config.Extension { return &defaultCfg }, createExtension, StabilityLevelBeta)
+	assert.EqualValues(t, typeStr, factory.Type())
+	assert.Equal(t, StabilityLevel(StabilityLevelBeta), factory.ExtensionStability())
+}
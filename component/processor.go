@@ -69,6 +69,9 @@ type ProcessorFactory interface {
 	// tests of any implementation of the Factory interface.
 	CreateDefaultConfig() config.Processor
 
+	// SupportedDataTypes returns the data types for which a Processor can be created by this factory.
+	SupportedDataTypes() []config.DataType
+
 	// CreateTracesProcessor creates a trace processor based on this config.
 	// If the processor type does not support tracing or if the config is not valid,
 	// an error will be returned instead.
@@ -32,6 +32,7 @@ func TestNewProcessorFactory(t *testing.T) {
 		func() config.Processor { return &defaultCfg })
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.Empty(t, factory.SupportedDataTypes())
 	_, err := factory.CreateTracesProcessor(context.Background(), ProcessorCreateSettings{}, &defaultCfg, nil)
 	assert.Error(t, err)
 	_, err = factory.CreateMetricsProcessor(context.Background(), ProcessorCreateSettings{}, &defaultCfg, nil)
@@ -51,6 +52,7 @@ func TestNewProcessorFactory_WithOptions(t *testing.T) {
 		WithLogsProcessor(createLogsProcessor))
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.Equal(t, []config.DataType{config.TracesDataType, config.MetricsDataType, config.LogsDataType}, factory.SupportedDataTypes())
 
 	_, err := factory.CreateTracesProcessor(context.Background(), ProcessorCreateSettings{}, &defaultCfg, nil)
 	assert.NoError(t, err)
@@ -113,6 +113,9 @@ type ReceiverFactory interface {
 	// tests of any implementation of the Factory interface.
 	CreateDefaultConfig() config.Receiver
 
+	// SupportedDataTypes returns the data types for which a Receiver can be created by this factory.
+	SupportedDataTypes() []config.DataType
+
 	// CreateTracesReceiver creates a trace receiver based on this config.
 	// If the receiver type does not support tracing or if the config is not valid
 	// an error will be returned instead.
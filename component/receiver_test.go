@@ -32,6 +32,7 @@ func TestNewReceiverFactory(t *testing.T) {
 		func() config.Receiver { return &defaultCfg })
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.Empty(t, factory.SupportedDataTypes())
 	_, err := factory.CreateTracesReceiver(context.Background(), ReceiverCreateSettings{}, &defaultCfg, nil)
 	assert.Error(t, err)
 	_, err = factory.CreateMetricsReceiver(context.Background(), ReceiverCreateSettings{}, &defaultCfg, nil)
@@ -51,6 +52,7 @@ func TestNewReceiverFactory_WithOptions(t *testing.T) {
 		WithLogsReceiver(createLogsReceiver))
 	assert.EqualValues(t, typeStr, factory.Type())
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	assert.Equal(t, []config.DataType{config.TracesDataType, config.MetricsDataType, config.LogsDataType}, factory.SupportedDataTypes())
 
 	_, err := factory.CreateTracesReceiver(context.Background(), ReceiverCreateSettings{}, &defaultCfg, nil)
 	assert.NoError(t, err)
@@ -15,13 +15,21 @@
 package configauth // import "go.opentelemetry.io/collector/config/configauth"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 )
 
+// AnonymousSubject is the subject of the requests accepted without being authenticated, when allowed
+// by Authentication.AllowAnonymous.
+const AnonymousSubject = "anonymous"
+
 var (
 	errAuthenticatorNotFound  = errors.New("authenticator not found")
 	errNotClientAuthenticator = errors.New("requested authenticator is not a client authenticator")
@@ -32,19 +40,93 @@ var (
 type Authentication struct {
 	// AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.
 	AuthenticatorID config.ComponentID `mapstructure:"authenticator"`
+
+	// Authenticators specifies the names of extensions evaluated in order, after AuthenticatorID if set, to
+	// authenticate the incoming requests: the first one authenticating a request wins. Only used by servers.
+	Authenticators []config.ComponentID `mapstructure:"authenticators"`
+
+	// AllowAnonymous accepts the incoming requests which none of the authenticators authenticates, their
+	// client.Info.Auth having the subject AnonymousSubject. Only used by servers.
+	AllowAnonymous bool `mapstructure:"allow_anonymous"`
+}
+
+// ExtensionIDs returns the IDs of the authenticator extensions, AuthenticatorID first if set.
+func (a Authentication) ExtensionIDs() []config.ComponentID {
+	var ids []config.ComponentID
+	if a.AuthenticatorID != (config.ComponentID{}) {
+		ids = append(ids, a.AuthenticatorID)
+	}
+	return append(ids, a.Authenticators...)
 }
 
 // GetServerAuthenticator attempts to select the appropriate ServerAuthenticator from the list of extensions,
 // based on the requested extension name. If an authenticator is not found, an error is returned.
+// When several authenticators are requested, or anonymous requests are allowed, the returned ServerAuthenticator
+// chains them, and is not meant to be started or shutdown since the service manages the extensions lifecycle.
 func (a Authentication) GetServerAuthenticator(extensions map[config.ComponentID]component.Extension) (ServerAuthenticator, error) {
-	if ext, found := extensions[a.AuthenticatorID]; found {
+	if len(a.Authenticators) == 0 && !a.AllowAnonymous {
+		return getServerAuthenticator(extensions, a.AuthenticatorID)
+	}
+
+	ids := a.Authenticators
+	if a.AuthenticatorID != (config.ComponentID{}) {
+		ids = append([]config.ComponentID{a.AuthenticatorID}, ids...)
+	}
+	auths := make([]ServerAuthenticator, 0, len(ids))
+	for _, id := range ids {
+		auth, err := getServerAuthenticator(extensions, id)
+		if err != nil {
+			return nil, err
+		}
+		auths = append(auths, auth)
+	}
+	return NewServerAuthenticator(WithAuthenticate(chainAuthenticate(auths, a.AllowAnonymous))), nil
+}
+
+func getServerAuthenticator(extensions map[config.ComponentID]component.Extension, id config.ComponentID) (ServerAuthenticator, error) {
+	if ext, found := extensions[id]; found {
 		if auth, ok := ext.(ServerAuthenticator); ok {
 			return auth, nil
 		}
 		return nil, errNotServerAuthenticator
 	}
 
-	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", a.AuthenticatorID, errAuthenticatorNotFound)
+	return nil, fmt.Errorf("failed to resolve authenticator %q: %w", id, errAuthenticatorNotFound)
+}
+
+// chainAuthenticate returns an AuthenticateFunc trying the authenticators in order, returning the context of the
+// first one succeeding. Otherwise, the request is anonymous if allowed, or rejected with the errors of all the authenticators.
+func chainAuthenticate(auths []ServerAuthenticator, allowAnonymous bool) AuthenticateFunc {
+	return func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		var errs error
+		for _, auth := range auths {
+			authCtx, err := auth.Authenticate(ctx, headers)
+			if err == nil {
+				return authCtx, nil
+			}
+			errs = multierr.Append(errs, err)
+		}
+		if allowAnonymous {
+			info := client.FromContext(ctx)
+			info.Auth = anonymousAuthData{}
+			return client.NewContext(ctx, info), nil
+		}
+		return ctx, errs
+	}
+}
+
+// anonymousAuthData is the client.AuthData of the anonymous requests.
+type anonymousAuthData struct{}
+
+func (anonymousAuthData) GetAttribute(name string) interface{} {
+	if name == client.AuthAttributeSubject {
+		return AnonymousSubject
+	}
+	return nil
+}
+
+func (anonymousAuthData) GetAttributeNames() []string {
+	return []string{client.AuthAttributeSubject}
 }
 
 // GetClientAuthenticator attempts to select the appropriate ClientAuthenticator from the list of extensions,
@@ -15,10 +15,14 @@
 package configauth
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 )
@@ -74,6 +78,133 @@ func TestGetServerAuthenticatorFails(t *testing.T) {
 	assert.Nil(t, authenticator)
 }
 
+// headerAuthenticator authenticates the requests with the given header, as the subject.
+func headerAuthenticator(header string) ServerAuthenticator {
+	return NewServerAuthenticator(WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		if len(headers[header]) == 0 {
+			return ctx, errors.New("missing " + header)
+		}
+		return client.NewContext(ctx, client.Info{Auth: subjectAuthData(headers[header][0])}), nil
+	}))
+}
+
+type subjectAuthData string
+
+func (s subjectAuthData) GetAttribute(name string) interface{} {
+	if name == client.AuthAttributeSubject {
+		return string(s)
+	}
+	return nil
+}
+
+func (s subjectAuthData) GetAttributeNames() []string {
+	return []string{client.AuthAttributeSubject}
+}
+
+func TestGetServerAuthenticatorChain(t *testing.T) {
+	ext := map[config.ComponentID]component.Extension{
+		config.NewComponentID("oidc"):   headerAuthenticator("token"),
+		config.NewComponentID("apikey"): headerAuthenticator("api-key"),
+	}
+	subject := func(ctx context.Context) interface{} {
+		return client.FromContext(ctx).Auth.GetAttribute(client.AuthAttributeSubject)
+	}
+
+	testCases := []struct {
+		desc            string
+		cfg             Authentication
+		headers         map[string][]string
+		expectedSubject string
+		expectedErr     string
+	}{
+		{
+			desc:            "first authenticator",
+			cfg:             Authentication{Authenticators: []config.ComponentID{config.NewComponentID("oidc"), config.NewComponentID("apikey")}},
+			headers:         map[string][]string{"token": {"alice"}, "api-key": {"bob"}},
+			expectedSubject: "alice",
+		},
+		{
+			desc:            "fallback to the next authenticator",
+			cfg:             Authentication{Authenticators: []config.ComponentID{config.NewComponentID("oidc"), config.NewComponentID("apikey")}},
+			headers:         map[string][]string{"api-key": {"bob"}},
+			expectedSubject: "bob",
+		},
+		{
+			desc:            "authenticator evaluated before the authenticators",
+			cfg:             Authentication{AuthenticatorID: config.NewComponentID("apikey"), Authenticators: []config.ComponentID{config.NewComponentID("oidc")}},
+			headers:         map[string][]string{"token": {"alice"}, "api-key": {"bob"}},
+			expectedSubject: "bob",
+		},
+		{
+			desc:        "no authenticator succeeds",
+			cfg:         Authentication{Authenticators: []config.ComponentID{config.NewComponentID("oidc"), config.NewComponentID("apikey")}},
+			expectedErr: "missing token; missing api-key",
+		},
+		{
+			desc:            "anonymous",
+			cfg:             Authentication{Authenticators: []config.ComponentID{config.NewComponentID("oidc")}, AllowAnonymous: true},
+			expectedSubject: AnonymousSubject,
+		},
+		{
+			desc:            "authenticated despite allowing anonymous",
+			cfg:             Authentication{AuthenticatorID: config.NewComponentID("oidc"), AllowAnonymous: true},
+			headers:         map[string][]string{"token": {"alice"}},
+			expectedSubject: "alice",
+		},
+		{
+			desc:            "only anonymous",
+			cfg:             Authentication{AllowAnonymous: true},
+			headers:         map[string][]string{"token": {"alice"}},
+			expectedSubject: AnonymousSubject,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			authenticator, err := tC.cfg.GetServerAuthenticator(ext)
+			require.NoError(t, err)
+
+			ctx, err := authenticator.Authenticate(context.Background(), tC.headers)
+			if tC.expectedErr != "" {
+				assert.EqualError(t, err, tC.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.expectedSubject, subject(ctx))
+		})
+	}
+}
+
+func TestGetServerAuthenticatorChainFails(t *testing.T) {
+	ext := map[config.ComponentID]component.Extension{
+		config.NewComponentID("oidc"):   headerAuthenticator("token"),
+		config.NewComponentID("client"): &MockClientAuthenticator{},
+	}
+
+	cfg := &Authentication{Authenticators: []config.ComponentID{config.NewComponentID("oidc"), config.NewComponentID("does-not-exist")}}
+	authenticator, err := cfg.GetServerAuthenticator(ext)
+	assert.ErrorIs(t, err, errAuthenticatorNotFound)
+	assert.Nil(t, authenticator)
+
+	cfg = &Authentication{Authenticators: []config.ComponentID{config.NewComponentID("oidc"), config.NewComponentID("client")}, AllowAnonymous: true}
+	authenticator, err = cfg.GetServerAuthenticator(ext)
+	assert.ErrorIs(t, err, errNotServerAuthenticator)
+	assert.Nil(t, authenticator)
+}
+
+func TestAnonymousKeepsClientInfo(t *testing.T) {
+	authenticator, err := Authentication{AllowAnonymous: true}.GetServerAuthenticator(nil)
+	require.NoError(t, err)
+
+	ctx := client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(map[string][]string{"key": {"value"}})})
+	ctx, err = authenticator.Authenticate(ctx, nil)
+	require.NoError(t, err)
+	info := client.FromContext(ctx)
+	assert.Equal(t, []string{"value"}, info.Metadata.Get("key"))
+	assert.Equal(t, []string{client.AuthAttributeSubject}, info.Auth.GetAttributeNames())
+	assert.Equal(t, AnonymousSubject, info.Auth.GetAttribute(client.AuthAttributeSubject))
+	assert.Nil(t, info.Auth.GetAttribute(client.AuthAttributeTenant))
+}
+
 func TestGetClientAuthenticator(t *testing.T) {
 	testCases := []struct {
 		desc          string
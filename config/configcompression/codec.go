@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcompression // import "go.opentelemetry.io/collector/config/configcompression"
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec compresses and decompresses the data of a compression type, e.g. the bodies of the
+// HTTP requests whose Content-Encoding is the name of the compression type.
+type Codec interface {
+	// NewWriter returns a writer compressing the data written to w with the params,
+	// checked by ValidateParams. Closing the writer flushes the compressed data.
+	NewWriter(w io.Writer, params CompressionParams) (io.WriteCloser, error)
+
+	// NewReader returns a reader decompressing the data read from r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+
+	// ValidateParams checks that the params are supported.
+	ValidateParams(params CompressionParams) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[CompressionType]Codec{
+		Gzip:    gzipCodec{},
+		Zlib:    zlibCodec{name: Zlib},
+		Deflate: zlibCodec{name: Deflate},
+		Snappy:  snappyCodec{},
+		Zstd:    &zstdCodec{},
+	}
+)
+
+// RegisterCodec registers the codec of the compression type, replacing the codec registered
+// with the same name, if any. The registered compression types are valid in the configuration
+// of the components, e.g. distributions register the codecs of "lz4" or "br" in an init func.
+func RegisterCodec(compressionType CompressionType, codec Codec) error {
+	if !IsCompressed(compressionType) {
+		return fmt.Errorf("cannot register a codec for %q", compressionType)
+	}
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[compressionType] = codec
+	return nil
+}
+
+// GetCodec returns the codec registered for the compression type.
+func GetCodec(compressionType CompressionType) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[compressionType]
+	return codec, ok
+}
+
+// RegisteredTypes returns the sorted compression types of the registered codecs.
+func RegisteredTypes() []CompressionType {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	types := make([]CompressionType, 0, len(codecs))
+	for typ := range codecs {
+		types = append(types, typ)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// validateLevel checks the compression level is zero, for the default level, or between 1 and maxLevel.
+func validateLevel(compressionType CompressionType, level int, maxLevel int) error {
+	if level != 0 && (level < 1 || level > maxLevel) {
+		return fmt.Errorf("unsupported compression level %d for %q, must be between 1 and %d", level, compressionType, maxLevel)
+	}
+	return nil
+}
+
+// validateNoParams checks the params are not set, for the compression types not supporting them.
+func validateNoParams(compressionType CompressionType, params CompressionParams) error {
+	if params.Level != 0 {
+		return fmt.Errorf("compression level is not supported for %q", compressionType)
+	}
+	return validateNoWindowSize(compressionType, params)
+}
+
+func validateNoWindowSize(compressionType CompressionType, params CompressionParams) error {
+	if params.WindowSize != 0 {
+		return fmt.Errorf("window size is not supported for %q", compressionType)
+	}
+	return nil
+}
+
+var (
+	// gzipWriterPools reuse gzip writers, a gzip.Writer holds several hundred KB of internal
+	// state which otherwise is allocated per request. There is one pool per compression level,
+	// index 0 being the default level, since resetting a gzip.Writer keeps its level.
+	gzipWriterPools [gzip.BestCompression + 1]sync.Pool
+	// gzipReaderPool reuses gzip readers. It has no New func since a gzip.Reader
+	// can only be created from a reader with a valid gzip header.
+	gzipReaderPool = &sync.Pool{}
+)
+
+type gzipCodec struct{}
+
+func (gzipCodec) NewWriter(w io.Writer, params CompressionParams) (io.WriteCloser, error) {
+	return newPooledGzipWriter(w, params.Level), nil
+}
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return newPooledGzipReader(r)
+}
+
+func (gzipCodec) ValidateParams(params CompressionParams) error {
+	if err := validateLevel(Gzip, params.Level, gzip.BestCompression); err != nil {
+		return err
+	}
+	return validateNoWindowSize(Gzip, params)
+}
+
+// pooledGzipWriter returns the wrapped gzip.Writer to the pool of its level when closed.
+type pooledGzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+// newPooledGzipWriter returns a pooled gzip.Writer with the given level, zero being the default level.
+// The level must have been validated by CompressionParams.
+func newPooledGzipWriter(w io.Writer, level int) *pooledGzipWriter {
+	pool := &gzipWriterPools[level]
+	if gw, ok := pool.Get().(*gzip.Writer); ok {
+		gw.Reset(w)
+		return &pooledGzipWriter{Writer: gw, pool: pool}
+	}
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	// The error is only returned for invalid levels.
+	gw, _ := gzip.NewWriterLevel(w, level)
+	return &pooledGzipWriter{Writer: gw, pool: pool}
+}
+
+// Close flushes the compressed data and returns the gzip.Writer to the pool, only once
+// for the writers closed several times.
+func (pw *pooledGzipWriter) Close() error {
+	if pw.Writer == nil {
+		return nil
+	}
+	err := pw.Writer.Close()
+	// Release the reference to the underlying writer before returning to the pool.
+	pw.Writer.Reset(nil)
+	pw.pool.Put(pw.Writer)
+	pw.Writer = nil
+	return err
+}
+
+// pooledGzipReader returns the wrapped gzip.Reader to gzipReaderPool when closed.
+type pooledGzipReader struct {
+	*gzip.Reader
+}
+
+func newPooledGzipReader(r io.Reader) (*pooledGzipReader, error) {
+	if gr, ok := gzipReaderPool.Get().(*gzip.Reader); ok {
+		if err := gr.Reset(r); err != nil {
+			gzipReaderPool.Put(gr)
+			return nil, err
+		}
+		return &pooledGzipReader{Reader: gr}, nil
+	}
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &pooledGzipReader{Reader: gr}, nil
+}
+
+// Close returns the gzip.Reader to the pool, only once for the readers closed several times,
+// e.g. the request bodies closed by both the decompression handler and the receivers.
+func (pr *pooledGzipReader) Close() error {
+	if pr.Reader == nil {
+		return nil
+	}
+	err := pr.Reader.Close()
+	gzipReaderPool.Put(pr.Reader)
+	pr.Reader = nil
+	return err
+}
+
+// zlibCodec is the codec of zlib, and of deflate whose HTTP content encoding is zlib.
+type zlibCodec struct {
+	name CompressionType
+}
+
+func (zlibCodec) NewWriter(w io.Writer, params CompressionParams) (io.WriteCloser, error) {
+	level := zlib.DefaultCompression
+	if params.Level != 0 {
+		level = params.Level
+	}
+	return zlib.NewWriterLevel(w, level)
+}
+
+func (zlibCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+func (c zlibCodec) ValidateParams(params CompressionParams) error {
+	if err := validateLevel(c.name, params.Level, zlib.BestCompression); err != nil {
+		return err
+	}
+	return validateNoWindowSize(c.name, params)
+}
+
+type snappyCodec struct{}
+
+func (snappyCodec) NewWriter(w io.Writer, _ CompressionParams) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+func (snappyCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(snappy.NewReader(r)), nil
+}
+
+func (snappyCodec) ValidateParams(params CompressionParams) error {
+	return validateNoParams(Snappy, params)
+}
+
+// zstdCodec pools the encoders per params, zstd encoders allocating their internal state
+// per compression level and window size.
+type zstdCodec struct {
+	encoderPools sync.Map // CompressionParams -> *sync.Pool
+}
+
+func (c *zstdCodec) NewWriter(w io.Writer, params CompressionParams) (io.WriteCloser, error) {
+	p, _ := c.encoderPools.LoadOrStore(params, &sync.Pool{})
+	pool := p.(*sync.Pool)
+	if enc, ok := pool.Get().(*zstd.Encoder); ok {
+		enc.Reset(w)
+		return &pooledZstdWriter{Encoder: enc, pool: pool}, nil
+	}
+
+	// The encoders compress a single stream at a time.
+	opts := []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+	if params.Level != 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(params.Level)))
+	}
+	if params.WindowSize != 0 {
+		opts = append(opts, zstd.WithWindowSize(params.WindowSize))
+	}
+	enc, err := zstd.NewWriter(w, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pooledZstdWriter{Encoder: enc, pool: pool}, nil
+}
+
+func (c *zstdCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return dec.IOReadCloser(), nil
+}
+
+func (c *zstdCodec) ValidateParams(params CompressionParams) error {
+	if err := validateLevel(Zstd, params.Level, 22); err != nil {
+		return err
+	}
+	if params.WindowSize != 0 && (params.WindowSize < zstdMinWindowSize || params.WindowSize > zstdMaxWindowSize || params.WindowSize&(params.WindowSize-1) != 0) {
+		return fmt.Errorf("unsupported window size %d, must be a power of two between %d and %d", params.WindowSize, zstdMinWindowSize, zstdMaxWindowSize)
+	}
+	return nil
+}
+
+// pooledZstdWriter returns the wrapped zstd.Encoder to its pool when closed.
+type pooledZstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+// Close flushes the compressed data and returns the zstd.Encoder to the pool, only once
+// for the writers closed several times.
+func (pw *pooledZstdWriter) Close() error {
+	if pw.Encoder == nil {
+		return nil
+	}
+	err := pw.Encoder.Close()
+	// Release the reference to the underlying writer before returning to the pool.
+	pw.Encoder.Reset(nil)
+	pw.pool.Put(pw.Encoder)
+	pw.Encoder = nil
+	return err
+}
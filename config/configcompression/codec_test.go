@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcompression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	testBody := []byte("uncompressed_text")
+	tests := []struct {
+		compressionType CompressionType
+		params          CompressionParams
+	}{
+		{compressionType: Gzip},
+		{compressionType: Gzip, params: CompressionParams{Level: 9}},
+		{compressionType: Zlib},
+		{compressionType: Zlib, params: CompressionParams{Level: 1}},
+		{compressionType: Deflate},
+		{compressionType: Snappy},
+		{compressionType: Zstd},
+		{compressionType: Zstd, params: CompressionParams{Level: 19, WindowSize: 1 << 20}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.compressionType), func(t *testing.T) {
+			codec, ok := GetCodec(tt.compressionType)
+			require.True(t, ok)
+			require.NoError(t, codec.ValidateParams(tt.params))
+
+			// Several times to reuse the pooled writers and readers.
+			for i := 0; i < 3; i++ {
+				buf := &bytes.Buffer{}
+				w, err := codec.NewWriter(buf, tt.params)
+				require.NoError(t, err)
+				_, err = w.Write(testBody)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+
+				r, err := codec.NewReader(buf)
+				require.NoError(t, err)
+				body, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				require.NoError(t, r.Close())
+				assert.Equal(t, testBody, body)
+			}
+		})
+	}
+}
+
+func TestPooledGzipReaderInvalidHeader(t *testing.T) {
+	// Put a reader in the pool so the Reset path is exercised.
+	buf := &bytes.Buffer{}
+	w := newPooledGzipWriter(buf, 0)
+	_, err := w.Write([]byte("uncompressed_text"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	r, err := newPooledGzipReader(buf)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	_, err = newPooledGzipReader(bytes.NewBufferString("not gzip"))
+	assert.Error(t, err)
+}
+
+func TestPooledCloseTwice(t *testing.T) {
+	// Closing twice must return the pooled reader or writer to its pool once, otherwise
+	// two later requests would share it.
+	testBody := []byte("uncompressed_text")
+	assertPooledOnce := func(t *testing.T, pool *sync.Pool) {
+		first, second := pool.Get(), pool.Get()
+		assert.False(t, first != nil && first == second)
+	}
+
+	t.Run("gzip_writer", func(t *testing.T) {
+		w := newPooledGzipWriter(&bytes.Buffer{}, gzip.BestSpeed)
+		_, err := w.Write(testBody)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, w.Close())
+		assertPooledOnce(t, &gzipWriterPools[gzip.BestSpeed])
+	})
+
+	t.Run("gzip_reader", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		w := newPooledGzipWriter(buf, 0)
+		_, err := w.Write(testBody)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		r, err := newPooledGzipReader(buf)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		require.NoError(t, r.Close())
+		assertPooledOnce(t, gzipReaderPool)
+	})
+
+	t.Run("zstd_writer", func(t *testing.T) {
+		codec := &zstdCodec{}
+		w, err := codec.NewWriter(&bytes.Buffer{}, CompressionParams{})
+		require.NoError(t, err)
+		_, err = w.Write(testBody)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, w.Close())
+		pool, ok := codec.encoderPools.Load(CompressionParams{})
+		require.True(t, ok)
+		assertPooledOnce(t, pool.(*sync.Pool))
+	})
+}
+
+type nopCodec struct{}
+
+func (nopCodec) NewWriter(w io.Writer, _ CompressionParams) (io.WriteCloser, error) {
+	return nopWriteCloser{Writer: w}, nil
+}
+
+func (nopCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(r), nil
+}
+
+func (nopCodec) ValidateParams(params CompressionParams) error {
+	return validateNoParams("nop", params)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestRegisterCodec(t *testing.T) {
+	const nop CompressionType = "nop"
+	var ct CompressionType
+	assert.Error(t, ct.UnmarshalText([]byte(nop)))
+
+	require.NoError(t, RegisterCodec(nop, nopCodec{}))
+	t.Cleanup(func() {
+		codecsMu.Lock()
+		delete(codecs, nop)
+		codecsMu.Unlock()
+	})
+
+	codec, ok := GetCodec(nop)
+	require.True(t, ok)
+	assert.Equal(t, nopCodec{}, codec)
+	assert.Contains(t, RegisteredTypes(), nop)
+
+	require.NoError(t, ct.UnmarshalText([]byte(nop)))
+	assert.Equal(t, nop, ct)
+	assert.NoError(t, CompressionParams{}.Validate(nop))
+	assert.EqualError(t, CompressionParams{Level: 1}.Validate(nop), `compression level is not supported for "nop"`)
+}
+
+func TestRegisterCodecUncompressed(t *testing.T) {
+	assert.EqualError(t, RegisterCodec(none, nopCodec{}), `cannot register a codec for "none"`)
+	assert.EqualError(t, RegisterCodec(empty, nopCodec{}), `cannot register a codec for ""`)
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	assert.Equal(t, []CompressionType{Deflate, Gzip, Snappy, Zlib, Zstd}, RegisteredTypes())
+}
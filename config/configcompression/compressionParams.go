@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcompression // import "go.opentelemetry.io/collector/config/configcompression"
+
+const (
+	// zstdMinWindowSize and zstdMaxWindowSize are the bounds of the zstd window sizes supported by the encoder.
+	zstdMinWindowSize = 1 << 10
+	zstdMaxWindowSize = 1 << 29
+)
+
+// CompressionParams tunes the compression, the default level of some compression types being too CPU-heavy
+// for high-throughput deployments.
+type CompressionParams struct {
+	// Level is the compression level, from 1 (fastest) to 9 (best compression) for gzip, zlib and deflate,
+	// and from 1 to 22 for zstd. Snappy does not support compression levels. Zero uses the default level.
+	Level int `mapstructure:"level"`
+	// WindowSize is the zstd window size in bytes, a power of two between 1KiB and 512MiB, smaller windows
+	// use less memory. Only supported by zstd. Zero uses the default window size.
+	WindowSize int `mapstructure:"window_size"`
+}
+
+// Validate checks that the CompressionParams are supported by the given compression type, see Codec.
+func (p CompressionParams) Validate(compressionType CompressionType) error {
+	codec, ok := GetCodec(compressionType)
+	if !ok {
+		return validateNoParams(compressionType, p)
+	}
+	return codec.ValidateParams(p)
+}
@@ -0,0 +1,96 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcompression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressionParamsValidate(t *testing.T) {
+	tests := []struct {
+		name            string
+		compressionType CompressionType
+		params          CompressionParams
+		expectedErr     string
+	}{
+		{
+			name:            "Default",
+			compressionType: Snappy,
+		},
+		{
+			name:            "GzipLevel",
+			compressionType: Gzip,
+			params:          CompressionParams{Level: 1},
+		},
+		{
+			name:            "GzipInvalidLevel",
+			compressionType: Gzip,
+			params:          CompressionParams{Level: 10},
+			expectedErr:     `unsupported compression level 10 for "gzip", must be between 1 and 9`,
+		},
+		{
+			name:            "DeflateLevel",
+			compressionType: Deflate,
+			params:          CompressionParams{Level: 9},
+		},
+		{
+			name:            "ZstdLevelAndWindowSize",
+			compressionType: Zstd,
+			params:          CompressionParams{Level: 22, WindowSize: 1 << 20},
+		},
+		{
+			name:            "ZstdInvalidLevel",
+			compressionType: Zstd,
+			params:          CompressionParams{Level: -1},
+			expectedErr:     `unsupported compression level -1 for "zstd", must be between 1 and 22`,
+		},
+		{
+			name:            "ZstdWindowSizeNotPowerOfTwo",
+			compressionType: Zstd,
+			params:          CompressionParams{WindowSize: 3000},
+			expectedErr:     "unsupported window size 3000, must be a power of two between 1024 and 536870912",
+		},
+		{
+			name:            "ZstdWindowSizeTooSmall",
+			compressionType: Zstd,
+			params:          CompressionParams{WindowSize: 512},
+			expectedErr:     "unsupported window size 512, must be a power of two between 1024 and 536870912",
+		},
+		{
+			name:            "SnappyLevel",
+			compressionType: Snappy,
+			params:          CompressionParams{Level: 1},
+			expectedErr:     `compression level is not supported for "snappy"`,
+		},
+		{
+			name:            "GzipWindowSize",
+			compressionType: Gzip,
+			params:          CompressionParams{WindowSize: 1 << 16},
+			expectedErr:     `window size is not supported for "gzip"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate(tt.compressionType)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
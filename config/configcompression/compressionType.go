@@ -32,18 +32,12 @@ func IsCompressed(compressionType CompressionType) bool {
 	return compressionType != empty && compressionType != none
 }
 
+// UnmarshalText accepts the compression types of the registered codecs, see RegisterCodec, and "none".
 func (ct *CompressionType) UnmarshalText(in []byte) error {
-	switch typ := CompressionType(in); typ {
-	case Gzip,
-		Zlib,
-		Deflate,
-		Snappy,
-		Zstd,
-		none,
-		empty:
+	typ := CompressionType(in)
+	if _, ok := GetCodec(typ); ok || !IsCompressed(typ) {
 		*ct = typ
 		return nil
-	default:
-		return fmt.Errorf("unsupported compression type %q", typ)
 	}
+	return fmt.Errorf("unsupported compression type %q", typ)
 }
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcompression // import "go.opentelemetry.io/collector/config/configcompression"
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NegotiateEncoding returns the compression type of the supported ones preferred by the client,
+// according to the value of its Accept-Encoding header, e.g. "zstd, gzip;q=0.5", or "none" if
+// the client accepts none of them. The supported types are in the order of preference of the
+// server, used for the types with the same quality value, e.g. for "*".
+func NegotiateEncoding(acceptEncoding string, supported []CompressionType) CompressionType {
+	qualities := map[string]float64{}
+	wildcard := -1.0
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(item, ";")
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			name, value := splitParam(param)
+			if name != "q" {
+				continue
+			}
+			var err error
+			if q, err = strconv.ParseFloat(value, 64); err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+		}
+		if coding == "*" {
+			wildcard = q
+			continue
+		}
+		qualities[coding] = q
+	}
+
+	best, bestQ := none, 0.0
+	for _, typ := range supported {
+		q, ok := qualities[strings.ToLower(string(typ))]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = typ, q
+		}
+	}
+	return best
+}
+
+// AcceptEncoding returns the value of the Accept-Encoding header listing the compression types,
+// e.g. "gzip, zstd", to advertise the supported types, such as RegisteredTypes.
+func AcceptEncoding(types []CompressionType) string {
+	items := make([]string, 0, len(types))
+	for _, typ := range types {
+		if IsCompressed(typ) {
+			items = append(items, string(typ))
+		}
+	}
+	return strings.Join(items, ", ")
+}
+
+func splitParam(param string) (string, string) {
+	items := strings.SplitN(param, "=", 2)
+	if len(items) != 2 {
+		return strings.TrimSpace(items[0]), ""
+	}
+	return strings.ToLower(strings.TrimSpace(items[0])), strings.TrimSpace(items[1])
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configcompression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	supported := []CompressionType{Zstd, Gzip, Snappy}
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           CompressionType
+	}{
+		{name: "Empty", acceptEncoding: "", want: none},
+		{name: "Single", acceptEncoding: "gzip", want: Gzip},
+		{name: "UpperCase", acceptEncoding: "GZIP", want: Gzip},
+		{name: "ServerPreference", acceptEncoding: "gzip, zstd", want: Zstd},
+		{name: "QualityValues", acceptEncoding: "zstd;q=0.5, gzip;q=0.8", want: Gzip},
+		{name: "Excluded", acceptEncoding: "zstd;q=0, gzip", want: Gzip},
+		{name: "Wildcard", acceptEncoding: "*", want: Zstd},
+		{name: "WildcardExcluded", acceptEncoding: "*;q=0, snappy", want: Snappy},
+		{name: "Unsupported", acceptEncoding: "br, identity", want: none},
+		{name: "InvalidQuality", acceptEncoding: "zstd;q=abc, gzip;q=0.1", want: Gzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NegotiateEncoding(tt.acceptEncoding, supported))
+		})
+	}
+}
+
+func TestAcceptEncoding(t *testing.T) {
+	assert.Equal(t, "gzip, zstd", AcceptEncoding([]CompressionType{Gzip, none, Zstd, empty}))
+	assert.Equal(t, "", AcceptEncoding(nil))
+}
@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/serviceconfig"
+	"google.golang.org/grpc/status"
+)
+
+// OutlierEjectionBalancerName is the name of the balancer sending the RPCs to the endpoints weighted by
+// the inverse of their latency, and temporarily ejecting the endpoints failing too many RPCs.
+const OutlierEjectionBalancerName = "outlier_ejection"
+
+const (
+	defaultEjectionInterval = 10 * time.Second
+	defaultMaxErrorRate     = 0.5
+	defaultMinRequests      = 10
+	defaultBaseEjectionTime = 30 * time.Second
+	// maxEjectionTimeFactor caps the ejection time of the endpoints ejected repeatedly.
+	maxEjectionTimeFactor = 10
+	// latencyDecay is the weight of the previous average latency of an endpoint in its exponentially
+	// weighted moving average.
+	latencyDecay = 0.9
+)
+
+// OutlierEjectionSettings defines the settings of the outlier_ejection balancer.
+type OutlierEjectionSettings struct {
+	// Interval is the duration of the windows over which the error rates of the endpoints are computed.
+	// Defaults to 10s if 0.
+	Interval time.Duration `mapstructure:"interval" json:"interval"`
+
+	// MaxErrorRate is the ratio of the failed RPCs of an endpoint in a window above which it is ejected.
+	// Defaults to 0.5 if 0.
+	MaxErrorRate float64 `mapstructure:"max_error_rate" json:"max_error_rate"`
+
+	// MinRequests is the minimum number of RPCs sent to an endpoint in a window for it to be ejected.
+	// Defaults to 10 if 0.
+	MinRequests int `mapstructure:"min_requests" json:"min_requests"`
+
+	// BaseEjectionTime is the time an endpoint is ejected for, multiplied by the number of consecutive
+	// ejections of the endpoint, up to 10. Defaults to 30s if 0.
+	BaseEjectionTime time.Duration `mapstructure:"base_ejection_time" json:"base_ejection_time"`
+}
+
+func (oes OutlierEjectionSettings) withDefaults() OutlierEjectionSettings {
+	if oes.Interval <= 0 {
+		oes.Interval = defaultEjectionInterval
+	}
+	if oes.MaxErrorRate <= 0 {
+		oes.MaxErrorRate = defaultMaxErrorRate
+	}
+	if oes.MinRequests <= 0 {
+		oes.MinRequests = defaultMinRequests
+	}
+	if oes.BaseEjectionTime <= 0 {
+		oes.BaseEjectionTime = defaultBaseEjectionTime
+	}
+	return oes
+}
+
+// serviceConfig returns the gRPC service config selecting the outlier_ejection balancer with the settings.
+func (oes OutlierEjectionSettings) serviceConfig() (string, error) {
+	cfg, err := json.Marshal(map[string]interface{}{
+		"loadBalancingConfig": []map[string]interface{}{{OutlierEjectionBalancerName: oes}},
+	})
+	return string(cfg), err
+}
+
+func init() {
+	balancer.Register(outlierEjectionBuilder{})
+}
+
+type outlierEjectionConfig struct {
+	serviceconfig.LoadBalancingConfig
+	OutlierEjectionSettings
+}
+
+// outlierEjectionBuilder builds a base balancer per ClientConn, with its own statistics of the endpoints.
+type outlierEjectionBuilder struct{}
+
+func (outlierEjectionBuilder) Name() string {
+	return OutlierEjectionBalancerName
+}
+
+func (outlierEjectionBuilder) ParseConfig(js json.RawMessage) (serviceconfig.LoadBalancingConfig, error) {
+	cfg := &outlierEjectionConfig{}
+	if err := json.Unmarshal(js, &cfg.OutlierEjectionSettings); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", OutlierEjectionBalancerName, err)
+	}
+	return cfg, nil
+}
+
+func (outlierEjectionBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	pb := &outlierEjectionPickerBuilder{
+		settings:  OutlierEjectionSettings{}.withDefaults(),
+		endpoints: map[string]*endpointStats{},
+	}
+	return &outlierEjectionBalancer{
+		Balancer: base.NewBalancerBuilder(OutlierEjectionBalancerName, pb, base.Config{}).Build(cc, opts),
+		pb:       pb,
+	}
+}
+
+// outlierEjectionBalancer passes the settings of the config of the ClientConn to the picker builder.
+type outlierEjectionBalancer struct {
+	balancer.Balancer
+	pb *outlierEjectionPickerBuilder
+}
+
+func (b *outlierEjectionBalancer) UpdateClientConnState(s balancer.ClientConnState) error {
+	if cfg, ok := s.BalancerConfig.(*outlierEjectionConfig); ok {
+		b.pb.setSettings(cfg.OutlierEjectionSettings.withDefaults())
+	}
+	return b.Balancer.UpdateClientConnState(s)
+}
+
+type outlierEjectionPickerBuilder struct {
+	mu        sync.Mutex
+	settings  OutlierEjectionSettings
+	endpoints map[string]*endpointStats
+}
+
+func (pb *outlierEjectionPickerBuilder) setSettings(settings OutlierEjectionSettings) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	pb.settings = settings
+	for _, stats := range pb.endpoints {
+		stats.setSettings(settings)
+	}
+}
+
+func (pb *outlierEjectionPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	p := &outlierEjectionPicker{}
+	ready := make(map[string]struct{}, len(info.ReadySCs))
+	for sc, scInfo := range info.ReadySCs {
+		addr := scInfo.Address.Addr
+		ready[addr] = struct{}{}
+		stats, ok := pb.endpoints[addr]
+		if !ok {
+			stats = newEndpointStats(pb.settings)
+			pb.endpoints[addr] = stats
+		}
+		p.subConns = append(p.subConns, sc)
+		p.stats = append(p.stats, stats)
+	}
+	// Forget the endpoints removed by the resolver, or no longer ready.
+	for addr := range pb.endpoints {
+		if _, ok := ready[addr]; !ok {
+			delete(pb.endpoints, addr)
+		}
+	}
+	return p
+}
+
+// outlierEjectionPicker picks the endpoints not ejected, or all the endpoints if they are all ejected,
+// randomly, weighted by the inverse of their average latency.
+type outlierEjectionPicker struct {
+	subConns []balancer.SubConn
+	stats    []*endpointStats
+}
+
+func (p *outlierEjectionPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	now := time.Now()
+	candidates := make([]int, 0, len(p.subConns))
+	for i, stats := range p.stats {
+		if !stats.ejected(now) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		for i := range p.stats {
+			candidates = append(candidates, i)
+		}
+	}
+
+	i := p.pickWeighted(candidates)
+	stats := p.stats[i]
+	return balancer.PickResult{
+		SubConn: p.subConns[i],
+		Done: func(info balancer.DoneInfo) {
+			stats.record(time.Now(), time.Since(now), isEndpointFailure(info.Err))
+		},
+	}, nil
+}
+
+// pickWeighted picks one of the candidates with a probability proportional to the inverse of its average
+// latency, the endpoints without latency yet getting the average latency of the others.
+func (p *outlierEjectionPicker) pickWeighted(candidates []int) int {
+	latencies := make([]float64, len(candidates))
+	var sum float64
+	var known int
+	for j, i := range candidates {
+		latencies[j] = p.stats[i].averageLatency()
+		if latencies[j] > 0 {
+			sum += latencies[j]
+			known++
+		}
+	}
+	if known == 0 {
+		return candidates[rand.Intn(len(candidates))] // #nosec G404 -- not used for security
+	}
+	weights := make([]float64, len(candidates))
+	var total float64
+	for j, latency := range latencies {
+		if latency <= 0 {
+			latency = sum / float64(known)
+		}
+		weights[j] = 1 / latency
+		total += weights[j]
+	}
+	r := rand.Float64() * total // #nosec G404 -- not used for security
+	for j, weight := range weights {
+		if r < weight {
+			return candidates[j]
+		}
+		r -= weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+// isEndpointFailure returns whether the error of an RPC is caused by the endpoint, rather than by the request.
+func isEndpointFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// endpointStats tracks the latency and the error rate of the RPCs sent to an endpoint.
+type endpointStats struct {
+	mu       sync.Mutex
+	settings OutlierEjectionSettings
+	// latency is the exponentially weighted moving average of the latency in seconds, 0 if unknown.
+	latency float64
+
+	windowStart time.Time
+	requests    int
+	failures    int
+
+	ejectedUntil time.Time
+	// ejections is the number of consecutive ejections, reset by a window without ejection.
+	ejections int
+}
+
+func newEndpointStats(settings OutlierEjectionSettings) *endpointStats {
+	return &endpointStats{settings: settings}
+}
+
+func (s *endpointStats) setSettings(settings OutlierEjectionSettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+}
+
+func (s *endpointStats) averageLatency() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latency
+}
+
+func (s *endpointStats) ejected(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Before(s.ejectedUntil)
+}
+
+// record records the result of an RPC, ejecting the endpoint if the error rate of the window is above
+// the limit.
+func (s *endpointStats) record(now time.Time, latency time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !failed {
+		if s.latency == 0 {
+			s.latency = latency.Seconds()
+		} else {
+			s.latency = latencyDecay*s.latency + (1-latencyDecay)*latency.Seconds()
+		}
+	}
+
+	if now.Sub(s.windowStart) >= s.settings.Interval {
+		if s.requests >= s.settings.MinRequests && !now.Before(s.ejectedUntil) {
+			// The last complete window did not eject the endpoint.
+			s.ejections = 0
+		}
+		s.windowStart, s.requests, s.failures = now, 0, 0
+	}
+	s.requests++
+	if failed {
+		s.failures++
+	}
+
+	if s.requests >= s.settings.MinRequests && float64(s.failures)/float64(s.requests) > s.settings.MaxErrorRate {
+		if s.ejections < maxEjectionTimeFactor {
+			s.ejections++
+		}
+		s.ejectedUntil = now.Add(time.Duration(s.ejections) * s.settings.BaseEjectionTime)
+		s.windowStart, s.requests, s.failures = now, 0, 0
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestEndpointStatsEjection(t *testing.T) {
+	stats := newEndpointStats(OutlierEjectionSettings{MinRequests: 4, MaxErrorRate: 0.5}.withDefaults())
+	now := time.Now()
+
+	// 2 failures out of 4 requests is not above the max error rate.
+	for i := 0; i < 4; i++ {
+		stats.record(now, time.Millisecond, i%2 == 0)
+	}
+	assert.False(t, stats.ejected(now))
+
+	stats.record(now, time.Millisecond, true)
+	assert.True(t, stats.ejected(now))
+	assert.True(t, stats.ejected(now.Add(defaultBaseEjectionTime-time.Second)))
+	assert.False(t, stats.ejected(now.Add(defaultBaseEjectionTime)))
+
+	// A second consecutive ejection lasts twice as long.
+	now = now.Add(defaultBaseEjectionTime)
+	for i := 0; i < 4; i++ {
+		stats.record(now, time.Millisecond, true)
+	}
+	assert.True(t, stats.ejected(now.Add(2*defaultBaseEjectionTime-time.Second)))
+	assert.False(t, stats.ejected(now.Add(2*defaultBaseEjectionTime)))
+
+	// A complete window without ejection resets the ejection time.
+	now = now.Add(2 * defaultBaseEjectionTime)
+	for i := 0; i < 4; i++ {
+		stats.record(now, time.Millisecond, false)
+	}
+	now = now.Add(defaultEjectionInterval)
+	for i := 0; i < 4; i++ {
+		stats.record(now, time.Millisecond, true)
+	}
+	assert.True(t, stats.ejected(now))
+	assert.False(t, stats.ejected(now.Add(defaultBaseEjectionTime)))
+}
+
+func TestEndpointStatsLatency(t *testing.T) {
+	stats := newEndpointStats(OutlierEjectionSettings{}.withDefaults())
+	assert.Equal(t, 0.0, stats.averageLatency())
+
+	stats.record(time.Now(), time.Second, false)
+	assert.Equal(t, 1.0, stats.averageLatency())
+	stats.record(time.Now(), 2*time.Second, false)
+	assert.InDelta(t, 1.1, stats.averageLatency(), 1e-9)
+	// The latency of the failed RPCs is ignored.
+	stats.record(time.Now(), time.Minute, true)
+	assert.InDelta(t, 1.1, stats.averageLatency(), 1e-9)
+}
+
+type fakeSubConn struct {
+	balancer.SubConn
+	addr string
+}
+
+func newTestPicker(t *testing.T, addrs ...string) (balancer.Picker, *outlierEjectionPickerBuilder) {
+	pb := &outlierEjectionPickerBuilder{
+		settings:  OutlierEjectionSettings{}.withDefaults(),
+		endpoints: map[string]*endpointStats{},
+	}
+	info := base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{}}
+	for _, addr := range addrs {
+		info.ReadySCs[&fakeSubConn{addr: addr}] = base.SubConnInfo{Address: resolver.Address{Addr: addr}}
+	}
+	p := pb.Build(info)
+	require.IsType(t, &outlierEjectionPicker{}, p)
+	return p, pb
+}
+
+func pickCounts(t *testing.T, p balancer.Picker, n int) map[string]int {
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		res, err := p.Pick(balancer.PickInfo{})
+		require.NoError(t, err)
+		counts[res.SubConn.(*fakeSubConn).addr]++
+	}
+	return counts
+}
+
+func TestOutlierEjectionPicker(t *testing.T) {
+	p, pb := newTestPicker(t, "a", "b")
+
+	// The ejected endpoints are not picked.
+	pb.endpoints["a"].ejectedUntil = time.Now().Add(time.Minute)
+	assert.Equal(t, map[string]int{"b": 100}, pickCounts(t, p, 100))
+
+	// All the endpoints are picked if they are all ejected.
+	pb.endpoints["b"].ejectedUntil = time.Now().Add(time.Minute)
+	counts := pickCounts(t, p, 1000)
+	assert.Greater(t, counts["a"], 0)
+	assert.Greater(t, counts["b"], 0)
+
+	// The endpoints are weighted by the inverse of their latency.
+	pb.endpoints["a"].ejectedUntil = time.Time{}
+	pb.endpoints["b"].ejectedUntil = time.Time{}
+	pb.endpoints["a"].latency = 0.01
+	pb.endpoints["b"].latency = 0.09
+	counts = pickCounts(t, p, 10000)
+	assert.InDelta(t, 9000, counts["a"], 500)
+
+	// The Done callback records the result of the RPC.
+	res, err := p.Pick(balancer.PickInfo{})
+	require.NoError(t, err)
+	res.Done(balancer.DoneInfo{Err: status.Error(codes.Unavailable, "unavailable")})
+	stats := pb.endpoints[res.SubConn.(*fakeSubConn).addr]
+	assert.Equal(t, 1, stats.failures)
+
+	// The endpoints no longer ready are forgotten.
+	pb.Build(base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{
+		&fakeSubConn{addr: "b"}: {Address: resolver.Address{Addr: "b"}},
+	}})
+	assert.Len(t, pb.endpoints, 1)
+	assert.Contains(t, pb.endpoints, "b")
+}
+
+func TestIsEndpointFailure(t *testing.T) {
+	assert.False(t, isEndpointFailure(nil))
+	assert.True(t, isEndpointFailure(status.Error(codes.Unavailable, "")))
+	assert.True(t, isEndpointFailure(status.Error(codes.DeadlineExceeded, "")))
+	assert.True(t, isEndpointFailure(errors.New("unknown")))
+	assert.False(t, isEndpointFailure(status.Error(codes.InvalidArgument, "")))
+	assert.False(t, isEndpointFailure(status.Error(codes.Canceled, "")))
+}
+
+func TestOutlierEjectionParseConfig(t *testing.T) {
+	settings := OutlierEjectionSettings{Interval: time.Minute, MaxErrorRate: 0.2, MinRequests: 5, BaseEjectionTime: time.Second}
+	sc, err := settings.serviceConfig()
+	require.NoError(t, err)
+	assert.Contains(t, sc, `"loadBalancingConfig":[{"outlier_ejection":{`)
+
+	cfg, err := outlierEjectionBuilder{}.ParseConfig([]byte(`{"interval":60000000000,"max_error_rate":0.2,"min_requests":5,"base_ejection_time":1000000000}`))
+	require.NoError(t, err)
+	assert.Equal(t, settings, cfg.(*outlierEjectionConfig).OutlierEjectionSettings)
+
+	_, err = outlierEjectionBuilder{}.ParseConfig([]byte(`{"interval":"1s"}`))
+	assert.Error(t, err)
+}
+
+type failingTraceServer struct {
+	requests *atomic.Int64
+	err      error
+}
+
+func (fts *failingTraceServer) Export(context.Context, ptraceotlp.Request) (ptraceotlp.Response, error) {
+	fts.requests.Inc()
+	return ptraceotlp.NewResponse(), fts.err
+}
+
+func startFailingTraceServer(t *testing.T, err error) (string, *failingTraceServer) {
+	ln, lerr := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, lerr)
+	srv := grpc.NewServer()
+	fts := &failingTraceServer{requests: atomic.NewInt64(0), err: err}
+	ptraceotlp.RegisterServer(srv, fts)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(srv.Stop)
+	return ln.Addr().String(), fts
+}
+
+func TestOutlierEjectionBalancer(t *testing.T) {
+	healthyAddr, healthy := startFailingTraceServer(t, nil)
+	failingAddr, failing := startFailingTraceServer(t, status.Error(codes.Unavailable, "overloaded"))
+
+	r := manual.NewBuilderWithScheme("outlier")
+	r.InitialState(resolver.State{Addresses: []resolver.Address{{Addr: healthyAddr}, {Addr: failingAddr}}})
+
+	gcs := &GRPCClientSettings{
+		Endpoint:        "outlier:///backends",
+		TLSSetting:      configtls.TLSClientSetting{Insecure: true},
+		BalancerName:    OutlierEjectionBalancerName,
+		OutlierEjection: OutlierEjectionSettings{MinRequests: 5},
+	}
+	opts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	conn, err := grpc.Dial(gcs.Endpoint, append(opts, grpc.WithResolvers(r))...)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+	client := ptraceotlp.NewClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for i := 0; i < 200; i++ {
+		_, _ = client.Export(ctx, ptraceotlp.NewRequest(), grpc.WaitForReady(true))
+	}
+	// The failing endpoint is ejected after at most 5 failures.
+	assert.LessOrEqual(t, failing.requests.Load(), int64(10))
+	assert.GreaterOrEqual(t, healthy.requests.Load(), int64(190))
+}
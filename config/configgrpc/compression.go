@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+// paramsCompressor is a grpc.Compressor compressing with the configured CompressionParams.
+// The compressors registered in the grpc encoding package share a single level for the whole
+// process, so a compressor per client connection is used when the params are set.
+type paramsCompressor struct {
+	name   string
+	codec  configcompression.Codec
+	params configcompression.CompressionParams
+}
+
+var _ grpc.Compressor = (*paramsCompressor)(nil) //nolint:staticcheck // SA1019 grpc.WithCompressor is the only per connection option.
+
+// newParamsCompressor returns a compressor for the compression type with the given params,
+// which must have been validated by configcompression.CompressionParams. The writers are
+// pooled by the codec of the compression type.
+func newParamsCompressor(compressionType configcompression.CompressionType, params configcompression.CompressionParams) (*paramsCompressor, error) {
+	switch compressionType {
+	case configcompression.Gzip, configcompression.Zstd:
+	default:
+		return nil, fmt.Errorf("compression params are not supported for %q", compressionType)
+	}
+	codec, ok := configcompression.GetCodec(compressionType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported compression type %q", compressionType)
+	}
+	return &paramsCompressor{name: string(compressionType), codec: codec, params: params}, nil
+}
+
+// Do compresses p into w.
+func (c *paramsCompressor) Do(w io.Writer, p []byte) error {
+	cw, err := c.codec.NewWriter(w, c.params)
+	if err != nil {
+		return err
+	}
+	if _, err = cw.Write(p); err != nil {
+		_ = cw.Close()
+		return err
+	}
+	return cw.Close()
+}
+
+// Type returns the name of the compression, sent in the grpc-encoding header.
+func (c *paramsCompressor) Type() string {
+	return c.name
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestParamsCompressor(t *testing.T) {
+	tests := []struct {
+		name            string
+		compressionType configcompression.CompressionType
+		params          configcompression.CompressionParams
+	}{
+		{
+			name:            "GzipLevel",
+			compressionType: configcompression.Gzip,
+			params:          configcompression.CompressionParams{Level: 1},
+		},
+		{
+			name:            "ZstdLevel",
+			compressionType: configcompression.Zstd,
+			params:          configcompression.CompressionParams{Level: 1},
+		},
+		{
+			name:            "ZstdWindowSize",
+			compressionType: configcompression.Zstd,
+			params:          configcompression.CompressionParams{WindowSize: 1 << 16},
+		},
+	}
+	testBody := bytes.Repeat([]byte("uncompressed_text"), 1024)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, err := newParamsCompressor(tt.compressionType, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, string(tt.compressionType), cp.Type())
+			// The registered compressor of the same name must be able to decompress, the writers being reused.
+			dc := encoding.GetCompressor(cp.Type())
+			require.NotNil(t, dc)
+			for i := 0; i < 3; i++ {
+				buf := &bytes.Buffer{}
+				require.NoError(t, cp.Do(buf, testBody))
+				r, err := dc.Decompress(buf)
+				require.NoError(t, err)
+				body, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, testBody, body)
+			}
+		})
+	}
+}
+
+func TestParamsCompressorUnsupported(t *testing.T) {
+	_, err := newParamsCompressor(configcompression.Snappy, configcompression.CompressionParams{Level: 1})
+	assert.EqualError(t, err, `compression params are not supported for "snappy"`)
+}
+
+type testCompressor struct{}
+
+func (testCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (testCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (testCompressor) Name() string {
+	return "testcompressor"
+}
+
+func TestGetGRPCCompressionNameRegistered(t *testing.T) {
+	_, err := getGRPCCompressionName("testcompressor")
+	assert.EqualError(t, err, `unsupported compression type "testcompressor"`)
+
+	encoding.RegisterCompressor(testCompressor{})
+	name, err := getGRPCCompressionName("testcompressor")
+	require.NoError(t, err)
+	assert.Equal(t, "testcompressor", name)
+}
+
+func TestExportWithCompressionParams(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	opts, err := gss.ToServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	s := grpc.NewServer(opts...)
+	srv := &recordingTraceServer{}
+	ptraceotlp.RegisterServer(s, srv)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Stop()
+
+	gcs := &GRPCClientSettings{
+		Endpoint:          ln.Addr().String(),
+		Compression:       configcompression.Zstd,
+		CompressionParams: configcompression.CompressionParams{Level: 1, WindowSize: 1 << 16},
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	clientOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	grpcClientConn, err := grpc.Dial(gcs.Endpoint, clientOpts...)
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	td := testdata.GenerateTraces(10)
+	_, err = ptraceotlp.NewClient(grpcClientConn).Export(ctx, ptraceotlp.NewRequestFromTraces(td), grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Equal(t, td, srv.traces)
+}
+
+type recordingTraceServer struct {
+	traces ptrace.Traces
+}
+
+func (rts *recordingTraceServer) Export(_ context.Context, req ptraceotlp.Request) (ptraceotlp.Response, error) {
+	rts.traces = req.Traces()
+	return ptraceotlp.NewResponse(), nil
+}
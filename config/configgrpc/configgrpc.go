@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
 	"time"
 
@@ -29,25 +30,32 @@ import (
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
 var errMetadataNotFound = errors.New("no request metadata found")
 
 // Allowed balancer names to be set in grpclb_policy to discover the servers.
-var allowedBalancerNames = []string{roundrobin.Name, grpc.PickFirstBalancerName}
+var allowedBalancerNames = []string{roundrobin.Name, grpc.PickFirstBalancerName, OutlierEjectionBalancerName}
 
 // KeepaliveClientConfig exposes the keepalive.ClientParameters to be used by the exporter.
 // Refer to the original data-structure for the meaning of each parameter:
@@ -68,6 +76,9 @@ type GRPCClientSettings struct {
 	// The compression key for supported compression types within collector.
 	Compression configcompression.CompressionType `mapstructure:"compression"`
 
+	// CompressionParams tunes the compression level and, for zstd, the window size.
+	CompressionParams configcompression.CompressionParams `mapstructure:"compression_params"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
 
@@ -88,14 +99,24 @@ type GRPCClientSettings struct {
 	WaitForReady bool `mapstructure:"wait_for_ready"`
 
 	// The headers associated with gRPC requests.
-	Headers map[string]string `mapstructure:"headers"`
+	Headers map[string]configopaque.String `mapstructure:"headers"`
 
 	// Sets the balancer in grpclb_policy to discover the servers. Default is pick_first.
 	// https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md
 	BalancerName string `mapstructure:"balancer_name"`
 
+	// OutlierEjection configures the outlier_ejection balancer, used if BalancerName is outlier_ejection.
+	OutlierEjection OutlierEjectionSettings `mapstructure:"outlier_ejection"`
+
 	// Auth configuration for outgoing RPCs.
 	Auth *configauth.Authentication `mapstructure:"auth"`
+
+	// ForwardMetadata lists the keys of the client.Info metadata, usually collected by the receivers
+	// with include_metadata, added to the metadata of the outgoing RPCs, e.g. to route per tenant.
+	ForwardMetadata []string `mapstructure:"forward_metadata"`
+
+	// Proxy the connections are tunneled through, instead of connecting directly to the endpoint.
+	Proxy *ProxySettings `mapstructure:"proxy"`
 }
 
 // KeepaliveServerConfig is the configuration for keepalive.
@@ -153,6 +174,10 @@ type GRPCServerSettings struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
+	// MemoryLimiter is the ID of the memory_limiter extension refusing the incoming RPCs, before their
+	// messages are read, while the memory usage is above its limits. If nil the RPCs are not limited.
+	MemoryLimiter *config.ComponentID `mapstructure:"memory_limiter"`
+
 	// Include propagates the incoming connection's metadata to downstream consumers.
 	// Experimental: *NOTE* this option is subject to change or removal in the future.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
@@ -170,6 +195,14 @@ func (gcs *GRPCClientSettings) SanitizedEndpoint() string {
 	}
 }
 
+// ExtensionDependencies returns the IDs of the authenticator extension of the client.
+func (gcs *GRPCClientSettings) ExtensionDependencies() []config.ComponentID {
+	if gcs.Auth == nil {
+		return nil
+	}
+	return gcs.Auth.ExtensionIDs()
+}
+
 func (gcs *GRPCClientSettings) isSchemeHTTP() bool {
 	return strings.HasPrefix(gcs.Endpoint, "http://")
 }
@@ -182,11 +215,22 @@ func (gcs *GRPCClientSettings) isSchemeHTTPS() bool {
 func (gcs *GRPCClientSettings) ToDialOptions(host component.Host, settings component.TelemetrySettings) ([]grpc.DialOption, error) {
 	var opts []grpc.DialOption
 	if configcompression.IsCompressed(gcs.Compression) {
-		cp, err := getGRPCCompressionName(gcs.Compression)
-		if err != nil {
+		if err := gcs.CompressionParams.Validate(gcs.Compression); err != nil {
 			return nil, err
 		}
-		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(cp)))
+		if gcs.CompressionParams != (configcompression.CompressionParams{}) {
+			cp, err := newParamsCompressor(gcs.Compression, gcs.CompressionParams)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, grpc.WithCompressor(cp)) //nolint:staticcheck // SA1019 no per connection alternative.
+		} else {
+			cp, err := getGRPCCompressionName(gcs.Compression)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(cp)))
+		}
 	}
 
 	tlsCfg, err := gcs.TLSSetting.LoadTLSConfig()
@@ -235,12 +279,33 @@ func (gcs *GRPCClientSettings) ToDialOptions(host component.Host, settings compo
 		opts = append(opts, grpc.WithPerRPCCredentials(perRPCCredentials))
 	}
 
+	if gcs.Proxy != nil {
+		dialer, derr := gcs.Proxy.contextDialer()
+		if derr != nil {
+			return nil, derr
+		}
+		opts = append(opts, grpc.WithContextDialer(dialer))
+	}
+
 	if gcs.BalancerName != "" {
 		valid := validateBalancerName(gcs.BalancerName)
 		if !valid {
 			return nil, fmt.Errorf("invalid balancer_name: %s", gcs.BalancerName)
 		}
-		opts = append(opts, grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, gcs.BalancerName)))
+		if gcs.BalancerName == OutlierEjectionBalancerName {
+			serviceConfig, serr := gcs.OutlierEjection.serviceConfig()
+			if serr != nil {
+				return nil, serr
+			}
+			opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfig))
+		} else {
+			opts = append(opts, grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, gcs.BalancerName)))
+		}
+	}
+
+	if len(gcs.ForwardMetadata) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(forwardMetadataUnaryInterceptor(gcs.ForwardMetadata)))
+		opts = append(opts, grpc.WithChainStreamInterceptor(forwardMetadataStreamInterceptor(gcs.ForwardMetadata)))
 	}
 
 	otelOpts := []otelgrpc.Option{
@@ -257,6 +322,42 @@ func (gcs *GRPCClientSettings) ToDialOptions(host component.Host, settings compo
 	return opts, nil
 }
 
+func forwardMetadataUnaryInterceptor(keys []string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(contextWithForwardedMetadata(ctx, keys), method, req, reply, cc, opts...)
+	}
+}
+
+func forwardMetadataStreamInterceptor(keys []string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(contextWithForwardedMetadata(ctx, keys), desc, cc, method, opts...)
+	}
+}
+
+// contextWithForwardedMetadata appends the values of the given keys of the client.Info metadata to the outgoing
+// metadata of the context. The keys are looked up as given, lower-cased as in the metadata collected by the gRPC
+// receivers, and canonicalized as in the metadata collected by the HTTP receivers.
+func contextWithForwardedMetadata(ctx context.Context, keys []string) context.Context {
+	md := client.FromContext(ctx).Metadata
+	var kv []string
+	for _, key := range keys {
+		vals := md.Get(key)
+		if len(vals) == 0 {
+			vals = md.Get(strings.ToLower(key))
+		}
+		if len(vals) == 0 {
+			vals = md.Get(http.CanonicalHeaderKey(key))
+		}
+		for _, val := range vals {
+			kv = append(kv, key, val)
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
 func validateBalancerName(balancerName string) bool {
 	for _, item := range allowedBalancerNames {
 		if item == balancerName {
@@ -266,6 +367,18 @@ func validateBalancerName(balancerName string) bool {
 	return false
 }
 
+// ExtensionDependencies returns the IDs of the authenticator and memory limiter extensions of the server.
+func (gss *GRPCServerSettings) ExtensionDependencies() []config.ComponentID {
+	var ids []config.ComponentID
+	if gss.Auth != nil {
+		ids = append(ids, gss.Auth.ExtensionIDs()...)
+	}
+	if gss.MemoryLimiter != nil {
+		ids = append(ids, *gss.MemoryLimiter)
+	}
+	return ids
+}
+
 // ToListener returns the net.Listener constructed from the settings.
 func (gss *GRPCServerSettings) ToListener() (net.Listener, error) {
 	return gss.NetAddr.Listen()
@@ -327,6 +440,14 @@ func (gss *GRPCServerSettings) ToServerOption(host component.Host, settings comp
 		}
 	}
 
+	if gss.MemoryLimiter != nil {
+		limiter, err := memorylimiter.GetLimiter(host.GetExtensions(), *gss.MemoryLimiter)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.InTapHandle(memoryLimiterTapHandle(limiter)))
+	}
+
 	var uInterceptors []grpc.UnaryServerInterceptor
 	var sInterceptors []grpc.StreamServerInterceptor
 
@@ -364,6 +485,18 @@ func (gss *GRPCServerSettings) ToServerOption(host component.Host, settings comp
 	return opts, nil
 }
 
+// memoryLimiterTapHandle refuses the incoming RPCs while the limiter must refuse data, the tap handle
+// being called when the headers of the RPCs are received, before their messages are read and decoded.
+func memoryLimiterTapHandle(limiter memorylimiter.Limiter) tap.ServerInHandle {
+	return func(ctx context.Context, _ *tap.Info) (context.Context, error) {
+		if limiter.MustRefuse() {
+			// Unavailable is retryable, the clients retrying once the memory is released.
+			return nil, status.Error(codes.Unavailable, "data refused due to high memory usage")
+		}
+		return ctx, nil
+	}
+}
+
 // getGRPCCompressionName returns compression name registered in grpc.
 func getGRPCCompressionName(compressionType configcompression.CompressionType) (string, error) {
 	switch compressionType {
@@ -374,6 +507,10 @@ func getGRPCCompressionName(compressionType configcompression.CompressionType) (
 	case configcompression.Zstd:
 		return zstd.Name, nil
 	default:
+		// Compressors of other types registered in the grpc encoding package, e.g. by a distribution.
+		if encoding.GetCompressor(string(compressionType)) != nil {
+			return string(compressionType), nil
+		}
 		return "", fmt.Errorf("unsupported compression type %q", compressionType)
 	}
 }
@@ -392,12 +529,15 @@ func enhanceStreamWithClientInformation(includeMetadata bool) func(srv interface
 	}
 }
 
-// contextWithClient attempts to add the peer address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
+// contextWithClient attempts to add the peer address and TLS identity to the client.Info from the context.
+// When no client.Info exists in the context, one is created.
 func contextWithClient(ctx context.Context, includeMetadata bool) context.Context {
 	cl := client.FromContext(ctx)
 	if p, ok := peer.FromContext(ctx); ok {
 		cl.Addr = p.Addr
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			cl.TLS = client.NewTLSIdentity(tlsInfo.State)
+		}
 	}
 	if includeMetadata {
 		if md, ok := metadata.FromIncomingContext(ctx); ok {
@@ -30,6 +30,7 @@ import (
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -75,6 +76,52 @@ func BenchmarkCompressors(b *testing.B) {
 	}
 }
 
+func BenchmarkParamsCompressors(b *testing.B) {
+	payloads := setupTestPayloads()
+
+	compressionParams := []struct {
+		compressionType configcompression.CompressionType
+		params          configcompression.CompressionParams
+	}{
+		{compressionType: configcompression.Gzip, params: configcompression.CompressionParams{Level: 1}},
+		{compressionType: configcompression.Gzip, params: configcompression.CompressionParams{Level: 9}},
+		{compressionType: configcompression.Zstd, params: configcompression.CompressionParams{Level: 1}},
+		{compressionType: configcompression.Zstd, params: configcompression.CompressionParams{Level: 1, WindowSize: 1 << 16}},
+		{compressionType: configcompression.Zstd, params: configcompression.CompressionParams{Level: 11}},
+	}
+
+	for _, payload := range payloads {
+		messageBytes, err := payload.marshaler.marshal(payload.message)
+		if err != nil {
+			b.Errorf("marshal(_) returned an error")
+		}
+		for _, cp := range compressionParams {
+			compressor, err := newParamsCompressor(cp.compressionType, cp.params)
+			if err != nil {
+				b.Fatalf("newParamsCompressor(_) returned an error: %v", err)
+			}
+			compressedBytes := &bytes.Buffer{}
+			if err = compressor.Do(compressedBytes, messageBytes); err != nil {
+				b.Errorf("Do(_) returned an error")
+			}
+
+			name := fmt.Sprintf("%v/raw_bytes_%v/compressed_bytes_%v/compressor_%v/level_%v/window_size_%v",
+				payload.name, len(messageBytes), compressedBytes.Len(), compressor.Type(), cp.params.Level, cp.params.WindowSize)
+
+			b.Run(name, func(b *testing.B) {
+				buf := &bytes.Buffer{}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					buf.Reset()
+					if err := compressor.Do(buf, messageBytes); err != nil {
+						b.Errorf("Do(_) returned an error")
+					}
+				}
+			})
+		}
+	}
+}
+
 func compress(compressor encoding.Compressor, in []byte) ([]byte, error) {
 	if compressor == nil {
 		return nil, nil
@@ -16,6 +16,9 @@ package configgrpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"io/ioutil"
 	"net"
@@ -27,9 +30,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -38,6 +45,7 @@ import (
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
@@ -71,7 +79,7 @@ func TestAllGrpcClientSettings(t *testing.T) {
 		{
 			name: "test all with gzip compression",
 			settings: GRPCClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"test": "test",
 				},
 				Endpoint:    "localhost:1234",
@@ -99,7 +107,7 @@ func TestAllGrpcClientSettings(t *testing.T) {
 		{
 			name: "test all with snappy compression",
 			settings: GRPCClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"test": "test",
 				},
 				Endpoint:    "localhost:1234",
@@ -127,7 +135,7 @@ func TestAllGrpcClientSettings(t *testing.T) {
 		{
 			name: "test all with zstd compression",
 			settings: GRPCClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"test": "test",
 				},
 				Endpoint:    "localhost:1234",
@@ -152,6 +160,35 @@ func TestAllGrpcClientSettings(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "test all with zstd compression params",
+			settings: GRPCClientSettings{
+				Headers: map[string]configopaque.String{
+					"test": "test",
+				},
+				Endpoint:          "localhost:1234",
+				Compression:       configcompression.Zstd,
+				CompressionParams: configcompression.CompressionParams{Level: 1, WindowSize: 1 << 16},
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: false,
+				},
+				Keepalive: &KeepaliveClientConfig{
+					Time:                time.Second,
+					Timeout:             time.Second,
+					PermitWithoutStream: true,
+				},
+				ReadBufferSize:  1024,
+				WriteBufferSize: 1024,
+				WaitForReady:    true,
+				BalancerName:    "round_robin",
+				Auth:            &configauth.Authentication{AuthenticatorID: config.NewComponentID("testauth")},
+			},
+			host: &mockHost{
+				ext: map[config.ComponentID]component.Extension{
+					config.NewComponentID("testauth"): &configauth.MockClientAuthenticator{},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -275,7 +312,7 @@ func TestGRPCClientSettingsError(t *testing.T) {
 		{
 			err: "invalid balancer_name: test",
 			settings: GRPCClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"test": "test",
 				},
 				Endpoint:    "localhost:1234",
@@ -332,6 +369,18 @@ func TestGRPCClientSettingsError(t *testing.T) {
 			},
 			host: &mockHost{},
 		},
+		{
+			err: "unsupported compression level 23 for \"zstd\", must be between 1 and 22",
+			settings: GRPCClientSettings{
+				Endpoint: "localhost:1234",
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				Compression:       "zstd",
+				CompressionParams: configcompression.CompressionParams{Level: 23},
+			},
+			host: &mockHost{},
+		},
 		{
 			err: "unsupported compression type \"bad\"",
 			settings: GRPCClientSettings{
@@ -416,6 +465,16 @@ func TestGRPCServerSettingsError(t *testing.T) {
 				},
 			},
 		},
+		{
+			err: "^failed to resolve memory limiter \"memory_limiter\": memory limiter not found",
+			settings: GRPCServerSettings{
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "127.0.0.1:1234",
+					Transport: "tcp",
+				},
+				MemoryLimiter: &memoryLimiterID,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -736,6 +795,42 @@ func TestContextWithClient(t *testing.T) {
 				Metadata: client.NewMetadata(map[string][]string{"test-metadata-key": {"test-value"}, ":authority": {"localhost:55443"}, "Host": {"localhost:55443"}}),
 			},
 		},
+		{
+			desc: "peer with verified TLS client certificate",
+			input: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.IPAddr{
+					IP: net.IPv4(1, 2, 3, 4),
+				},
+				AuthInfo: credentials.TLSInfo{
+					State: tls.ConnectionState{
+						VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "app"}, DNSNames: []string{"app.local"}}}},
+					},
+				},
+			}),
+			expected: client.Info{
+				Addr: &net.IPAddr{
+					IP: net.IPv4(1, 2, 3, 4),
+				},
+				TLS: &client.TLSIdentity{
+					CommonName: "app",
+					DNSNames:   []string{"app.local"},
+				},
+			},
+		},
+		{
+			desc: "peer with TLS, no client certificate",
+			input: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.IPAddr{
+					IP: net.IPv4(1, 2, 3, 4),
+				},
+				AuthInfo: credentials.TLSInfo{},
+			}),
+			expected: client.Info{
+				Addr: &net.IPAddr{
+					IP: net.IPv4(1, 2, 3, 4),
+				},
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -862,6 +957,141 @@ func TestClientInfoInterceptors(t *testing.T) {
 	}
 }
 
+func TestForwardMetadata(t *testing.T) {
+	mock := &grpcTraceServer{}
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		IncludeMetadata: true,
+	}
+	opts, err := gss.ToServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	srv := grpc.NewServer(opts...)
+	ptraceotlp.RegisterServer(srv, mock)
+	defer srv.Stop()
+
+	l, err := gss.ToListener()
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	gcs := &GRPCClientSettings{
+		Endpoint: l.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		ForwardMetadata: []string{"x-tenant", "x-region", "x-missing"},
+	}
+	clientOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	grpcClientConn, err := grpc.Dial(gcs.Endpoint, clientOpts...)
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+
+	// The metadata as collected by an HTTP receiver, with canonicalized keys.
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{
+			"X-Tenant":   {"acme"},
+			"X-Region":   {"eu", "us"},
+			"X-Internal": {"secret"},
+		}),
+	})
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	_, err = ptraceotlp.NewClient(grpcClientConn).Export(ctx, ptraceotlp.NewRequest())
+	require.NoError(t, err)
+
+	md := client.FromContext(mock.recordedContext).Metadata
+	assert.Equal(t, []string{"acme"}, md.Get("x-tenant"))
+	assert.Equal(t, []string{"eu", "us"}, md.Get("x-region"))
+	assert.Empty(t, md.Get("x-missing"))
+	assert.Empty(t, md.Get("x-internal"))
+}
+
+func TestContextWithForwardedMetadata(t *testing.T) {
+	ctx := contextWithForwardedMetadata(context.Background(), []string{"x-tenant"})
+	_, ok := metadata.FromOutgoingContext(ctx)
+	assert.False(t, ok, "no outgoing metadata without client.Info metadata")
+
+	// The metadata as collected by a gRPC receiver, with lower-cased keys.
+	ctx = client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme"}}),
+	})
+	ctx = metadata.AppendToOutgoingContext(ctx, "existing", "value")
+	md, ok := metadata.FromOutgoingContext(contextWithForwardedMetadata(ctx, []string{"X-Tenant"}))
+	require.True(t, ok)
+	assert.Equal(t, []string{"acme"}, md.Get("x-tenant"))
+	assert.Equal(t, []string{"value"}, md.Get("existing"))
+}
+
+var memoryLimiterID = config.NewComponentID("memory_limiter")
+
+type mockMemoryLimiter struct {
+	component.Extension
+	refuse *atomic.Bool
+}
+
+func (ml *mockMemoryLimiter) MustRefuse() bool {
+	return ml.refuse.Load()
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	limiter := &mockMemoryLimiter{refuse: atomic.NewBool(false)}
+	host := &mockHost{ext: map[config.ComponentID]component.Extension{memoryLimiterID: limiter}}
+	mock := &grpcTraceServer{}
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		MemoryLimiter: &memoryLimiterID,
+	}
+	opts, err := gss.ToServerOption(host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	srv := grpc.NewServer(opts...)
+	ptraceotlp.RegisterServer(srv, mock)
+	defer srv.Stop()
+
+	l, err := gss.ToListener()
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	gcs := &GRPCClientSettings{
+		Endpoint: l.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	clientOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	grpcClientConn, err := grpc.Dial(gcs.Endpoint, clientOpts...)
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+	traceClient := ptraceotlp.NewClient(grpcClientConn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = traceClient.Export(ctx, ptraceotlp.NewRequest())
+	require.NoError(t, err)
+
+	// The RPCs are refused without reaching the server while the limiter refuses data.
+	mock.recordedContext = nil
+	limiter.refuse.Store(true)
+	_, err = traceClient.Export(ctx, ptraceotlp.NewRequest())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Nil(t, mock.recordedContext)
+
+	limiter.refuse.Store(false)
+	_, err = traceClient.Export(ctx, ptraceotlp.NewRequest())
+	require.NoError(t, err)
+	assert.NotNil(t, mock.recordedContext)
+}
+
 func TestDefaultUnaryInterceptorAuthSucceeded(t *testing.T) {
 	// prepare
 	handlerCalled := false
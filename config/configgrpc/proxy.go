@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// ProxySettings defines the proxy the gRPC connections are tunneled through.
+type ProxySettings struct {
+	// Endpoint is the URL of the proxy, with the "http" scheme for an HTTP CONNECT proxy,
+	// or the "socks5" scheme for a SOCKS5 proxy, e.g. "http://proxy.example.com:3128".
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Username authenticating to the proxy, with the basic scheme for HTTP CONNECT proxies.
+	Username string `mapstructure:"username"`
+
+	// Password authenticating to the proxy with the Username.
+	Password configopaque.String `mapstructure:"password"`
+}
+
+// contextDialer returns the function dialing the gRPC servers through the proxy.
+func (ps *ProxySettings) contextDialer() (func(context.Context, string) (net.Conn, error), error) {
+	proxyURL, err := url.Parse(ps.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy endpoint: %w", err)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy endpoint %q: missing host", ps.Endpoint)
+	}
+
+	switch proxyURL.Scheme {
+	case "http":
+		return func(ctx context.Context, addr string) (net.Conn, error) {
+			return ps.dialHTTPConnect(ctx, proxyURL.Host, addr)
+		}, nil
+	case "socks5":
+		var auth *proxy.Auth
+		if ps.Username != "" {
+			auth = &proxy.Auth{User: ps.Username, Password: string(ps.Password)}
+		}
+		dialer, err := proxy.SOCKS5("tcp", proxyURL.Host, auth, &net.Dialer{})
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported proxy scheme %q, must be http or socks5", proxyURL.Scheme)
+}
+
+// dialHTTPConnect connects to addr through the tunnel established with an HTTP CONNECT request to the proxy.
+func (ps *ProxySettings) dialHTTPConnect(ctx context.Context, proxyAddr string, addr string) (net.Conn, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if ps.Username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(ps.Username + ":" + string(ps.Password)))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err = req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to write the CONNECT request to the proxy: %w", err)
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to read the CONNECT response of the proxy: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, errors.New("proxy refused the CONNECT request: " + resp.Status)
+	}
+	// The proxy may have sent the first bytes of the tunneled connection with the response.
+	return &bufferedConn{Conn: conn, r: r}, nil
+}
+
+// bufferedConn is a net.Conn reading from a bufio.Reader of the connection.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// tunnel copies the data between the two connections until one of them is closed.
+func tunnel(a, b net.Conn) {
+	go func() {
+		_, _ = io.Copy(a, b)
+		_ = a.Close()
+	}()
+	_, _ = io.Copy(b, a)
+	_ = b.Close()
+}
+
+// startHTTPConnectProxy starts an HTTP CONNECT proxy accepting the given Proxy-Authorization, if not empty,
+// and returns its address and the number of tunnels it established.
+func startHTTPConnectProxy(t *testing.T, authorization string) (string, *atomic.Int64) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	tunnels := atomic.NewInt64(0)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil || req.Method != http.MethodConnect {
+					_ = conn.Close()
+					return
+				}
+				if authorization != "" && req.Header.Get("Proxy-Authorization") != authorization {
+					_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+					_ = conn.Close()
+					return
+				}
+				target, err := net.Dial("tcp", req.Host)
+				if err != nil {
+					_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+					_ = conn.Close()
+					return
+				}
+				tunnels.Inc()
+				_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+				tunnel(conn, target)
+			}()
+		}
+	}()
+	return ln.Addr().String(), tunnels
+}
+
+// startSOCKS5Proxy starts a SOCKS5 proxy authenticating the clients with the username and password, and
+// returns its address and the number of tunnels it established.
+func startSOCKS5Proxy(t *testing.T, username, password string) (string, *atomic.Int64) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	tunnels := atomic.NewInt64(0)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				if target := socks5Handshake(conn, username, password); target != nil {
+					tunnels.Inc()
+					tunnel(conn, target)
+					return
+				}
+				_ = conn.Close()
+			}()
+		}
+	}()
+	return ln.Addr().String(), tunnels
+}
+
+// socks5Handshake negotiates the username/password authentication and the CONNECT command of RFC 1928 and
+// RFC 1929, and returns the connection to the requested address, or nil on failure.
+func socks5Handshake(conn net.Conn, username, password string) net.Conn {
+	r := bufio.NewReader(conn)
+	readBytes := func(n int) []byte {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil
+		}
+		return b
+	}
+	// Greeting with the authentication methods, requiring username/password.
+	header := readBytes(2)
+	if header == nil || readBytes(int(header[1])) == nil {
+		return nil
+	}
+	if _, err := conn.Write([]byte{5, 2}); err != nil {
+		return nil
+	}
+	header = readBytes(2)
+	if header == nil {
+		return nil
+	}
+	user := readBytes(int(header[1]))
+	passLen := readBytes(1)
+	if user == nil || passLen == nil {
+		return nil
+	}
+	pass := readBytes(int(passLen[0]))
+	if string(user) != username || string(pass) != password {
+		_, _ = conn.Write([]byte{1, 1})
+		return nil
+	}
+	if _, err := conn.Write([]byte{1, 0}); err != nil {
+		return nil
+	}
+	// CONNECT request to an IPv4 address, or to a domain name.
+	req := readBytes(4)
+	if req == nil {
+		return nil
+	}
+	var host string
+	switch req[3] {
+	case 1:
+		host = net.IP(readBytes(4)).String()
+	case 3:
+		host = string(readBytes(int(readBytes(1)[0])))
+	default:
+		return nil
+	}
+	port := readBytes(2)
+	target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port[0])<<8|int(port[1]))))
+	if err != nil {
+		_, _ = conn.Write([]byte{5, 1, 0, 1, 0, 0, 0, 0, 0, 0})
+		return nil
+	}
+	if _, err = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}); err != nil {
+		_ = target.Close()
+		return nil
+	}
+	return target
+}
+
+// startTraceServer starts a gRPC server of the traces and returns its address.
+func startTraceServer(t *testing.T) string {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+	}
+	opts, err := gss.ToServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	srv := grpc.NewServer(opts...)
+	ptraceotlp.RegisterServer(srv, &grpcTraceServer{})
+	t.Cleanup(srv.Stop)
+
+	l, err := gss.ToListener()
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	return l.Addr().String()
+}
+
+func exportThroughProxy(t *testing.T, endpoint string, proxy *ProxySettings) error {
+	gcs := &GRPCClientSettings{
+		Endpoint: endpoint,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Proxy: proxy,
+	}
+	opts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	conn, err := grpc.Dial(gcs.Endpoint, opts...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = ptraceotlp.NewClient(conn).Export(ctx, ptraceotlp.NewRequest())
+	return err
+}
+
+func TestProxyHTTPConnect(t *testing.T) {
+	endpoint := startTraceServer(t)
+	proxyAddr, tunnels := startHTTPConnectProxy(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:secret")))
+
+	require.NoError(t, exportThroughProxy(t, endpoint, &ProxySettings{
+		Endpoint: "http://" + proxyAddr,
+		Username: "user",
+		Password: "secret",
+	}))
+	assert.Equal(t, int64(1), tunnels.Load())
+
+	// Refused with the wrong credentials.
+	assert.Error(t, exportThroughProxy(t, endpoint, &ProxySettings{
+		Endpoint: "http://" + proxyAddr,
+		Username: "user",
+		Password: "wrong",
+	}))
+	assert.Equal(t, int64(1), tunnels.Load())
+}
+
+func TestProxySOCKS5(t *testing.T) {
+	endpoint := startTraceServer(t)
+	proxyAddr, tunnels := startSOCKS5Proxy(t, "user", "secret")
+
+	require.NoError(t, exportThroughProxy(t, endpoint, &ProxySettings{
+		Endpoint: "socks5://" + proxyAddr,
+		Username: "user",
+		Password: "secret",
+	}))
+	assert.Equal(t, int64(1), tunnels.Load())
+
+	assert.Error(t, exportThroughProxy(t, endpoint, &ProxySettings{
+		Endpoint: "socks5://" + proxyAddr,
+		Username: "user",
+		Password: "wrong",
+	}))
+	assert.Equal(t, int64(1), tunnels.Load())
+}
+
+func TestProxySettingsError(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		err      string
+	}{
+		{
+			endpoint: "https://proxy.example.com:3128",
+			err:      `unsupported proxy scheme "https", must be http or socks5`,
+		},
+		{
+			endpoint: "proxy.example.com",
+			err:      `invalid proxy endpoint "proxy.example.com": missing host`,
+		},
+		{
+			endpoint: "http://proxy.example.com:port",
+			err:      `invalid proxy endpoint: parse "http://proxy.example.com:port": invalid port ":port" after host`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.endpoint, func(t *testing.T) {
+			gcs := &GRPCClientSettings{
+				Endpoint: "localhost:4317",
+				Proxy:    &ProxySettings{Endpoint: test.endpoint},
+			}
+			_, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
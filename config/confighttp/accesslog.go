@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AccessLogSettings configures the structured logging of the requests received by the server.
+type AccessLogSettings struct {
+	// SamplingRatio is the fraction of the requests logged, between 0 and 1. If unset all the requests are logged,
+	// lower values limit the volume of logs produced by servers receiving many requests.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+}
+
+// Validate checks that the access log settings are valid.
+func (als *AccessLogSettings) Validate() error {
+	if als.SamplingRatio < 0 || als.SamplingRatio > 1 {
+		return fmt.Errorf("invalid access log sampling ratio %v, must be between 0 and 1", als.SamplingRatio)
+	}
+	return nil
+}
+
+// accessLogHandler logs a sample of the requests served by the next handler.
+type accessLogHandler struct {
+	next   http.Handler
+	logger *zap.Logger
+	ratio  float64
+	// count is the number of requests received, it determines which requests are sampled.
+	count uint64
+}
+
+func newAccessLogHandler(next http.Handler, logger *zap.Logger, als *AccessLogSettings) *accessLogHandler {
+	ratio := als.SamplingRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+	return &accessLogHandler{next: next, logger: logger, ratio: ratio}
+}
+
+// sampled returns true if the nth request must be logged, the requests being logged
+// evenly at the sampling ratio.
+func (h *accessLogHandler) sampled(n uint64) bool {
+	return uint64(float64(n)*h.ratio) != uint64(float64(n-1)*h.ratio)
+}
+
+func (h *accessLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.sampled(atomic.AddUint64(&h.count, 1)) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	start := time.Now()
+	rw := &accessLogResponseWriter{ResponseWriter: w, status: http.StatusOK}
+	h.next.ServeHTTP(rw, r)
+
+	clientIP := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		clientIP = host
+	}
+	h.logger.Info("HTTP request",
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+		zap.Int("status", rw.status),
+		zap.Int64("size", rw.size),
+		zap.Duration("duration", time.Since(start)),
+		zap.String("client_ip", clientIP),
+		zap.String("user_agent", r.UserAgent()),
+	)
+}
+
+// accessLogResponseWriter records the status code and the size of the response.
+type accessLogResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	size        int64
+	wroteHeader bool
+}
+
+func (rw *accessLogResponseWriter) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *accessLogResponseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(b)
+	rw.size += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher when the wrapped http.ResponseWriter supports it.
+func (rw *accessLogResponseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestAccessLogSettingsValidate(t *testing.T) {
+	assert.NoError(t, (&AccessLogSettings{}).Validate())
+	assert.NoError(t, (&AccessLogSettings{SamplingRatio: 0.5}).Validate())
+	assert.NoError(t, (&AccessLogSettings{SamplingRatio: 1}).Validate())
+	assert.Error(t, (&AccessLogSettings{SamplingRatio: -0.1}).Validate())
+	assert.Error(t, (&AccessLogSettings{SamplingRatio: 1.1}).Validate())
+}
+
+func TestAccessLog(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	set := componenttest.NewNopTelemetrySettings()
+	set.Logger = zap.New(core)
+
+	hss := &HTTPServerSettings{
+		Endpoint:  "localhost:0",
+		AccessLog: &AccessLogSettings{},
+	}
+	srv, err := hss.ToServer(componenttest.NewNopHost(), set, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("accepted"))
+	}))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+	req.RemoteAddr = "1.2.3.4:5678"
+	req.Header.Set("User-Agent", "test-client")
+	srv.Handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "HTTP request", entry.Message)
+	fields := entry.ContextMap()
+	assert.Equal(t, http.MethodPost, fields["method"])
+	assert.Equal(t, "/v1/traces", fields["path"])
+	assert.EqualValues(t, http.StatusAccepted, fields["status"])
+	assert.EqualValues(t, len("accepted"), fields["size"])
+	assert.Contains(t, fields, "duration")
+	assert.Equal(t, "1.2.3.4", fields["client_ip"])
+	assert.Equal(t, "test-client", fields["user_agent"])
+}
+
+func TestAccessLogDefaultStatus(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	h := newAccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), zap.New(core), &AccessLogSettings{})
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, 1, logs.Len())
+	assert.EqualValues(t, http.StatusOK, logs.All()[0].ContextMap()["status"])
+	assert.EqualValues(t, 0, logs.All()[0].ContextMap()["size"])
+}
+
+func TestAccessLogSampling(t *testing.T) {
+	testCases := []struct {
+		ratio    float64
+		expected int
+	}{
+		{ratio: 0, expected: 100},
+		{ratio: 1, expected: 100},
+		{ratio: 0.5, expected: 50},
+		{ratio: 0.01, expected: 1},
+		{ratio: 0.001, expected: 0},
+	}
+	for _, tc := range testCases {
+		core, logs := observer.New(zapcore.InfoLevel)
+		handlerCalls := 0
+		h := newAccessLogHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlerCalls++
+		}), zap.New(core), &AccessLogSettings{SamplingRatio: tc.ratio})
+
+		for i := 0; i < 100; i++ {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}
+		// All the requests are served, only the sampled ones are logged.
+		assert.Equal(t, 100, handlerCalls)
+		assert.Equal(t, tc.expected, logs.Len(), "ratio %v", tc.ratio)
+	}
+}
+
+func TestAccessLogInvalidSettings(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:  "localhost:0",
+		AccessLog: &AccessLogSettings{SamplingRatio: 2},
+	}
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	assert.Error(t, err)
+	assert.Nil(t, srv)
+}
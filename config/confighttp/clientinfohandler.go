@@ -33,14 +33,14 @@ type clientInfoHandler struct {
 }
 
 // ServeHTTP intercepts incoming HTTP requests, replacing the request's context with one that contains
-// a client.Info containing the client's IP address.
+// a client.Info containing the client's IP address and TLS identity.
 func (h *clientInfoHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	req = req.WithContext(contextWithClient(req, h.includeMetadata))
 	h.next.ServeHTTP(w, req)
 }
 
-// contextWithClient attempts to add the client IP address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
+// contextWithClient attempts to add the client IP address and TLS identity to the client.Info from the context.
+// When no client.Info exists in the context, one is created.
 func contextWithClient(req *http.Request, includeMetadata bool) context.Context {
 	cl := client.FromContext(req.Context())
 
@@ -49,6 +49,10 @@ func contextWithClient(req *http.Request, includeMetadata bool) context.Context
 		cl.Addr = ip
 	}
 
+	if req.TLS != nil {
+		cl.TLS = client.NewTLSIdentity(*req.TLS)
+	}
+
 	if includeMetadata {
 		md := req.Header.Clone()
 		if len(md.Get(client.MetadataHostName)) == 0 && req.Host != "" {
@@ -15,10 +15,16 @@
 package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
 )
 
 func TestParseIP(t *testing.T) {
@@ -58,3 +64,37 @@ func TestParseIP(t *testing.T) {
 		})
 	}
 }
+
+func TestContextWithClientTLS(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		state    *tls.ConnectionState
+		expected *client.TLSIdentity
+	}{
+		{
+			desc: "no TLS",
+		},
+		{
+			desc:  "TLS, no client certificate",
+			state: &tls.ConnectionState{},
+		},
+		{
+			desc: "verified client certificate",
+			state: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "app"}, DNSNames: []string{"app.local"}}}},
+			},
+			expected: &client.TLSIdentity{
+				CommonName: "app",
+				DNSNames:   []string{"app.local"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			req.TLS = tC.state
+			cl := client.FromContext(contextWithClient(req, false))
+			assert.Equal(t, tC.expected, cl.TLS)
+		})
+	}
+}
@@ -18,53 +18,41 @@ package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
 
 import (
 	"bytes"
-	"compress/gzip"
-	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 
-	"github.com/golang/snappy"
-	"github.com/klauspost/compress/zstd"
-
 	"go.opentelemetry.io/collector/config/configcompression"
 )
 
+var errUnsupportedEncoding = errors.New("unsupported content encoding")
+
 type compressRoundTripper struct {
 	RoundTripper    http.RoundTripper
 	compressionType configcompression.CompressionType
 	writer          func(*bytes.Buffer) (io.WriteCloser, error)
 }
 
-func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.CompressionType) *compressRoundTripper {
+func newCompressRoundTripper(rt http.RoundTripper, compressionType configcompression.CompressionType, params configcompression.CompressionParams) *compressRoundTripper {
 	return &compressRoundTripper{
 		RoundTripper:    rt,
 		compressionType: compressionType,
-		writer:          writerFactory(compressionType),
+		writer:          writerFactory(compressionType, params),
 	}
 }
 
-// writerFactory defines writer field in CompressRoundTripper.
+// writerFactory defines writer field in CompressRoundTripper, with the codec registered for the compression type.
 // The validity of input is already checked when NewCompressRoundTripper was called in confighttp,
-func writerFactory(compressionType configcompression.CompressionType) func(*bytes.Buffer) (io.WriteCloser, error) {
-	switch compressionType {
-	case configcompression.Gzip:
-		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
-			return gzip.NewWriter(buf), nil
-		}
-	case configcompression.Snappy:
-		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
-			return snappy.NewBufferedWriter(buf), nil
-		}
-	case configcompression.Zstd:
-		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
-			return zstd.NewWriter(buf)
-		}
-	case configcompression.Zlib, configcompression.Deflate:
-		return func(buf *bytes.Buffer) (io.WriteCloser, error) {
-			return zlib.NewWriter(buf), nil
-		}
+func writerFactory(compressionType configcompression.CompressionType, params configcompression.CompressionParams) func(*bytes.Buffer) (io.WriteCloser, error) {
+	codec, ok := configcompression.GetCodec(compressionType)
+	if !ok {
+		return nil
+	}
+	return func(buf *bytes.Buffer) (io.WriteCloser, error) {
+		return codec.NewWriter(buf, params)
 	}
-	return nil
 }
 
 func (r *compressRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -115,6 +103,7 @@ type errorHandler func(w http.ResponseWriter, r *http.Request, errorMsg string,
 
 type decompressor struct {
 	errorHandler
+	maxDecompressedBodySize int64
 }
 
 type decompressorOption func(d *decompressor)
@@ -125,10 +114,18 @@ func withErrorHandlerForDecompressor(e errorHandler) decompressorOption {
 	}
 }
 
+// withMaxDecompressedBodySize limits the size of the decompressed request bodies,
+// zero or negative values disable the limit.
+func withMaxDecompressedBodySize(maxSize int64) decompressorOption {
+	return func(d *decompressor) {
+		d.maxDecompressedBodySize = maxSize
+	}
+}
+
 // httpContentDecompressor offloads the task of handling compressed HTTP requests
 // by identifying the compression format in the "Content-Encoding" header and re-writing
 // request body so that the handlers further in the chain can work on decompressed data.
-// It supports gzip and deflate/zlib compression.
+// It supports the compression types of the codecs registered in configcompression.
 func httpContentDecompressor(h http.Handler, opts ...decompressorOption) http.Handler {
 	d := &decompressor{}
 	for _, o := range opts {
@@ -142,7 +139,14 @@ func httpContentDecompressor(h http.Handler, opts ...decompressorOption) http.Ha
 
 func (d *decompressor) wrap(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		newBody, err := newBodyReader(r)
+		counter := &countingReader{Reader: r.Body}
+		newBody, err := newBodyReader(r, counter)
+		if errors.Is(err, errUnsupportedEncoding) {
+			// Advertise the supported encodings, see RFC 7694.
+			w.Header().Set("Accept-Encoding", configcompression.AcceptEncoding(configcompression.RegisteredTypes()))
+			d.errorHandler(w, r, fmt.Sprintf("%v %q", err, r.Header.Get("Content-Encoding")), http.StatusUnsupportedMediaType)
+			return
+		}
 		if err != nil {
 			d.errorHandler(w, r, err.Error(), http.StatusBadRequest)
 			return
@@ -154,29 +158,56 @@ func (d *decompressor) wrap(h http.Handler) http.Handler {
 			r.Header.Del("Content-Encoding")
 			// "Content-Length" is set to -1 as the size of the decompressed body is unknown.
 			r.Header.Del("Content-Length")
+			r = r.WithContext(context.WithValue(r.Context(), compressedSizeKey{}, counter))
 			r.ContentLength = -1
 			r.Body = newBody
+			if d.maxDecompressedBodySize > 0 {
+				r.Body = newLimitedReadCloser(newBody, d.maxDecompressedBodySize,
+					requestBodyTooLargeError(fmt.Sprintf("decompressed request body exceeds the limit of %d bytes", d.maxDecompressedBodySize)))
+			}
 		}
 		h.ServeHTTP(w, r)
 	})
 }
 
-func newBodyReader(r *http.Request) (io.ReadCloser, error) {
-	switch r.Header.Get("Content-Encoding") {
-	case "gzip":
-		gr, err := gzip.NewReader(r.Body)
-		if err != nil {
-			return nil, err
-		}
-		return gr, nil
-	case "deflate", "zlib":
-		zr, err := zlib.NewReader(r.Body)
-		if err != nil {
-			return nil, err
-		}
-		return zr, nil
+// newBodyReader returns the reader decompressing the body of the request read from the reader with the
+// codec registered for its Content-Encoding, or nil if the body is not compressed.
+func newBodyReader(r *http.Request, body io.Reader) (io.ReadCloser, error) {
+	encoding := r.Header.Get("Content-Encoding")
+	if encoding == "" || encoding == "identity" {
+		return nil, nil
+	}
+	codec, ok := configcompression.GetCodec(configcompression.CompressionType(encoding))
+	if !ok {
+		return nil, errUnsupportedEncoding
+	}
+	return codec.NewReader(body)
+}
+
+// compressedSizeKey is the context key of the countingReader of the compressed request bodies.
+type compressedSizeKey struct{}
+
+// countingReader counts the bytes read from a compressed request body.
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.Reader.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// CompressedBodySize returns the number of bytes read so far from the compressed body of the request
+// with the context, which is the size of the compressed body once the request body is fully read. It
+// returns false if the request body was not decompressed by the server of the HTTPServerSettings.
+func CompressedBodySize(ctx context.Context) (int64, bool) {
+	cr, ok := ctx.Value(compressedSizeKey{}).(*countingReader)
+	if !ok {
+		return 0, false
 	}
-	return nil, nil
+	return cr.n, true
 }
 
 // defaultErrorHandler writes the error message in plain text.
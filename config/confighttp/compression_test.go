@@ -19,9 +19,11 @@ import (
 	"compress/gzip"
 	"compress/zlib"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -120,7 +122,7 @@ func TestHTTPClientCompression(t *testing.T) {
 
 			client := http.Client{}
 			if configcompression.IsCompressed(tt.encoding) {
-				client.Transport = newCompressRoundTripper(http.DefaultTransport, tt.encoding)
+				client.Transport = newCompressRoundTripper(http.DefaultTransport, tt.encoding, configcompression.CompressionParams{})
 			}
 			res, err := client.Do(req)
 			if tt.shouldError {
@@ -170,6 +172,39 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 			},
 			respCode: 200,
 		},
+		{
+			name:     "ValidSnappy",
+			encoding: "snappy",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressSnappy(testBody)
+			},
+			respCode: 200,
+		},
+		{
+			name:     "ValidZstd",
+			encoding: "zstd",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return compressZstd(testBody)
+			},
+			respCode: 200,
+		},
+		{
+			name:     "Identity",
+			encoding: "identity",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return bytes.NewBuffer(testBody), nil
+			},
+			respCode: 200,
+		},
+		{
+			name:     "UnsupportedEncoding",
+			encoding: "br",
+			reqBodyFunc: func() (*bytes.Buffer, error) {
+				return bytes.NewBuffer(testBody), nil
+			},
+			respCode: 415,
+			respBody: "unsupported content encoding \"br\"\n",
+		},
 		{
 			name:     "InvalidGzip",
 			encoding: "gzip",
@@ -233,6 +268,18 @@ func TestHTTPContentDecompressionHandler(t *testing.T) {
 	}
 }
 
+func TestHTTPContentDecompressionHandlerAcceptEncoding(t *testing.T) {
+	handler := httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("body"))
+	req.Header.Set("Content-Encoding", "br")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	assert.Equal(t, "deflate, gzip, snappy, zlib, zstd", rec.Header().Get("Accept-Encoding"))
+}
+
 func compressGzip(body []byte) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
 
@@ -288,3 +335,215 @@ func compressZstd(body []byte) (*bytes.Buffer, error) {
 
 	return &buf, nil
 }
+
+func TestHTTPContentDecompressionHandlerMaxDecompressedBodySize(t *testing.T) {
+	// A small compressed body that expands above the limit.
+	testBody := bytes.Repeat([]byte("a"), 10*1024)
+	tests := []struct {
+		name    string
+		maxSize int64
+		wantErr bool
+	}{
+		{
+			name:    "BelowLimit",
+			maxSize: int64(len(testBody)),
+		},
+		{
+			name:    "AboveLimit",
+			maxSize: int64(len(testBody)) - 1,
+			wantErr: true,
+		},
+		{
+			name:    "NoLimit",
+			maxSize: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readErr error
+			var body []byte
+			handler := httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, readErr = ioutil.ReadAll(r.Body)
+				w.WriteHeader(200)
+			}), withMaxDecompressedBodySize(tt.maxSize))
+
+			reqBody, err := compressGzip(testBody)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "http://localhost", reqBody)
+			req.Header.Set("Content-Encoding", "gzip")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tt.wantErr {
+				assert.EqualError(t, readErr, fmt.Sprintf("decompressed request body exceeds the limit of %d bytes", tt.maxSize))
+				assert.Len(t, body, int(tt.maxSize))
+				return
+			}
+			assert.NoError(t, readErr)
+			assert.Equal(t, testBody, body)
+		})
+	}
+}
+
+func TestCompressedBodySize(t *testing.T) {
+	testBody := bytes.Repeat([]byte("a"), 10*1024)
+	var size int64
+	var compressed bool
+	handler := httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = ioutil.ReadAll(r.Body)
+		size, compressed = CompressedBodySize(r.Context())
+		w.WriteHeader(200)
+	}))
+
+	reqBody, err := compressGzip(testBody)
+	require.NoError(t, err)
+	compressedLen := reqBody.Len()
+	req := httptest.NewRequest(http.MethodPost, "http://localhost", reqBody)
+	req.Header.Set("Content-Encoding", "gzip")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, compressed)
+	assert.Equal(t, int64(compressedLen), size)
+
+	req = httptest.NewRequest(http.MethodPost, "http://localhost", bytes.NewReader(testBody))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.False(t, compressed)
+}
+
+func TestCompressRoundTripperParams(t *testing.T) {
+	testBody := bytes.Repeat([]byte("uncompressed_text"), 1024)
+	tests := []struct {
+		name            string
+		compressionType configcompression.CompressionType
+		params          configcompression.CompressionParams
+	}{
+		{
+			name:            "GzipLevel",
+			compressionType: configcompression.Gzip,
+			params:          configcompression.CompressionParams{Level: 1},
+		},
+		{
+			name:            "ZlibLevel",
+			compressionType: configcompression.Zlib,
+			params:          configcompression.CompressionParams{Level: 9},
+		},
+		{
+			name:            "ZstdLevel",
+			compressionType: configcompression.Zstd,
+			params:          configcompression.CompressionParams{Level: 1},
+		},
+		{
+			name:            "ZstdLevelAndWindowSize",
+			compressionType: configcompression.Zstd,
+			params:          configcompression.CompressionParams{Level: 3, WindowSize: 1 << 16},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.params.Validate(tt.compressionType))
+			capture := &captureRoundTripper{}
+			rt := newCompressRoundTripper(capture, tt.compressionType, tt.params)
+			// Send twice as the writers are reused across requests.
+			for i := 0; i < 2; i++ {
+				req, err := http.NewRequest(http.MethodPost, "http://localhost", bytes.NewReader(testBody))
+				require.NoError(t, err)
+				_, err = rt.RoundTrip(req)
+				require.NoError(t, err)
+				assert.Equal(t, string(tt.compressionType), capture.encoding)
+
+				var r io.Reader
+				switch tt.compressionType {
+				case configcompression.Gzip:
+					r, err = gzip.NewReader(bytes.NewReader(capture.body))
+				case configcompression.Zlib:
+					r, err = zlib.NewReader(bytes.NewReader(capture.body))
+				case configcompression.Zstd:
+					r, err = zstd.NewReader(bytes.NewReader(capture.body))
+				}
+				require.NoError(t, err)
+				body, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, testBody, body)
+			}
+		})
+	}
+}
+
+// captureRoundTripper records the body and encoding of the request instead of sending it.
+type captureRoundTripper struct {
+	body     []byte
+	encoding string
+}
+
+func (rt *captureRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var err error
+	rt.body, err = ioutil.ReadAll(req.Body)
+	rt.encoding = req.Header.Get(headerContentEncoding)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, err
+}
+
+type noopRoundTripper struct{}
+
+func (noopRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, _ = io.Copy(ioutil.Discard, req.Body)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func BenchmarkCompressRoundTripperGzip(b *testing.B) {
+	body := bytes.Repeat([]byte("uncompressed_text"), 1024)
+	rt := newCompressRoundTripper(noopRoundTripper{}, configcompression.Gzip, configcompression.CompressionParams{})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req, err := http.NewRequest("POST", "http://localhost", bytes.NewReader(body))
+		require.NoError(b, err)
+		_, err = rt.RoundTrip(req)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkCompressRoundTripper(b *testing.B) {
+	body := bytes.Repeat([]byte("uncompressed_text"), 1024)
+	tests := []struct {
+		name            string
+		compressionType configcompression.CompressionType
+		params          configcompression.CompressionParams
+	}{
+		{name: "gzip/default", compressionType: configcompression.Gzip},
+		{name: "gzip/level_1", compressionType: configcompression.Gzip, params: configcompression.CompressionParams{Level: 1}},
+		{name: "gzip/level_9", compressionType: configcompression.Gzip, params: configcompression.CompressionParams{Level: 9}},
+		{name: "zlib/level_1", compressionType: configcompression.Zlib, params: configcompression.CompressionParams{Level: 1}},
+		{name: "zstd/default", compressionType: configcompression.Zstd},
+		{name: "zstd/level_1", compressionType: configcompression.Zstd, params: configcompression.CompressionParams{Level: 1}},
+		{name: "zstd/level_1_window_64KiB", compressionType: configcompression.Zstd, params: configcompression.CompressionParams{Level: 1, WindowSize: 1 << 16}},
+		{name: "zstd/level_11", compressionType: configcompression.Zstd, params: configcompression.CompressionParams{Level: 11}},
+		{name: "snappy", compressionType: configcompression.Snappy},
+	}
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			rt := newCompressRoundTripper(noopRoundTripper{}, tt.compressionType, tt.params)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				req, err := http.NewRequest("POST", "http://localhost", bytes.NewReader(body))
+				require.NoError(b, err)
+				_, err = rt.RoundTrip(req)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func BenchmarkHTTPContentDecompressorGzip(b *testing.B) {
+	buf, err := compressGzip(bytes.Repeat([]byte("uncompressed_text"), 1024))
+	require.NoError(b, err)
+	compressed := buf.Bytes()
+	handler := httpContentDecompressor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+	}))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader(compressed))
+		req.Header.Set("Content-Encoding", "gzip")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
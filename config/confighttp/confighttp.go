@@ -17,6 +17,8 @@ package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"time"
@@ -30,14 +32,17 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
 )
 
 const headerContentEncoding = "Content-Encoding"
 
 // HTTPClientSettings defines settings for creating an HTTP client.
 type HTTPClientSettings struct {
-	// The target URL to send data to (e.g.: http://some.url:9411/v1/traces).
+	// The target URL to send data to (e.g.: http://some.url:9411/v1/traces), or the path of the
+	// Unix socket of the server prefixed by the unix scheme (e.g.: unix:///var/run/otelcol.sock).
 	Endpoint string `mapstructure:"endpoint"`
 
 	// TLSSetting struct exposes TLS client configuration.
@@ -54,7 +59,7 @@ type HTTPClientSettings struct {
 
 	// Additional headers attached to each HTTP request sent by the client.
 	// Existing header values are overwritten if collision happens.
-	Headers map[string]string `mapstructure:"headers"`
+	Headers map[string]configopaque.String `mapstructure:"headers"`
 
 	// Custom Round Tripper to allow for individual components to intercept HTTP requests
 	CustomRoundTripper func(next http.RoundTripper) (http.RoundTripper, error)
@@ -65,6 +70,9 @@ type HTTPClientSettings struct {
 	// The compression key for supported compression types within collector.
 	Compression configcompression.CompressionType `mapstructure:"compression"`
 
+	// CompressionParams tunes the compression level and, for zstd, the window size.
+	CompressionParams configcompression.CompressionParams `mapstructure:"compression_params"`
+
 	// MaxIdleConns is used to set a limit to the maximum idle HTTP connections the client can keep open.
 	// There's an already set value, and we want to override it only if an explicit value provided
 	MaxIdleConns *int `mapstructure:"max_idle_conns"`
@@ -81,10 +89,19 @@ type HTTPClientSettings struct {
 	// IdleConnTimeout is the maximum amount of time a connection will remain open before closing itself.
 	// There's an already set value, and we want to override it only if an explicit value provided
 	IdleConnTimeout *time.Duration `mapstructure:"idle_conn_timeout"`
+
+	// MaxResponseBodySize sets the maximum response body size in bytes, after decompression.
+	// Reading a larger response body fails. If zero or negative the response body size is not limited.
+	MaxResponseBodySize int64 `mapstructure:"max_response_body_size"`
+
+	// TransportRetries is the maximum number of retries of the idempotent requests failed at the transport level
+	// before receiving a response, e.g. on a connection closed by the server at the end of its keep-alive timeout.
+	// The requests are idempotent if their method is, or if they have an Idempotency-Key header. Zero disables them.
+	TransportRetries int `mapstructure:"transport_retries"`
 }
 
 // NewDefaultHTTPClientSettings returns HTTPClientSettings type object with
-// the default values of 'MaxIdleConns' and 'IdleConnTimeout'.
+// the default values of 'MaxIdleConns', 'IdleConnTimeout' and 'TransportRetries'.
 // Other config options are not added as they are initialized with 'zero value' by GoLang as default.
 // We encourage to use this function to create an object of HTTPClientSettings.
 func NewDefaultHTTPClientSettings() HTTPClientSettings {
@@ -93,8 +110,9 @@ func NewDefaultHTTPClientSettings() HTTPClientSettings {
 	idleConnTimeout := 90 * time.Second
 
 	return HTTPClientSettings{
-		MaxIdleConns:    &maxIdleConns,
-		IdleConnTimeout: &idleConnTimeout,
+		MaxIdleConns:     &maxIdleConns,
+		IdleConnTimeout:  &idleConnTimeout,
+		TransportRetries: 1,
 	}
 }
 
@@ -132,13 +150,28 @@ func (hcs *HTTPClientSettings) ToClient(ext map[config.ComponentID]component.Ext
 		transport.IdleConnTimeout = *hcs.IdleConnTimeout
 	}
 
+	if isUnixSocketEndpoint(hcs.Endpoint) {
+		if err = registerUnixSocket(transport, hcs.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+
 	clientTransport := (http.RoundTripper)(transport)
+	if hcs.TransportRetries > 0 {
+		clientTransport = newTransportRetryRoundTripper(clientTransport, hcs.TransportRetries)
+	}
 	if len(hcs.Headers) > 0 {
 		clientTransport = &headerRoundTripper{
-			transport: transport,
+			transport: clientTransport,
 			headers:   hcs.Headers,
 		}
 	}
+	if hcs.MaxResponseBodySize > 0 {
+		clientTransport = &maxResponseBodySizeRoundTripper{
+			transport:   clientTransport,
+			maxBodySize: hcs.MaxResponseBodySize,
+		}
+	}
 	// wrapping http transport with otelhttp transport to enable otel instrumenetation
 	if settings.TracerProvider != nil && settings.MeterProvider != nil {
 		clientTransport = otelhttp.NewTransport(
@@ -152,7 +185,10 @@ func (hcs *HTTPClientSettings) ToClient(ext map[config.ComponentID]component.Ext
 	// Compress the body using specified compression methods if non-empty string is provided.
 	// Supporting gzip, zlib, deflate, snappy, and zstd; none is treated as uncompressed.
 	if configcompression.IsCompressed(hcs.Compression) {
-		clientTransport = newCompressRoundTripper(clientTransport, hcs.Compression)
+		if err = hcs.CompressionParams.Validate(hcs.Compression); err != nil {
+			return nil, err
+		}
+		clientTransport = newCompressRoundTripper(clientTransport, hcs.Compression, hcs.CompressionParams)
 	}
 
 	if hcs.Auth != nil {
@@ -184,6 +220,14 @@ func (hcs *HTTPClientSettings) ToClient(ext map[config.ComponentID]component.Ext
 	}, nil
 }
 
+// ExtensionDependencies returns the IDs of the authenticator extension of the client.
+func (hcs *HTTPClientSettings) ExtensionDependencies() []config.ComponentID {
+	if hcs.Auth == nil {
+		return nil
+	}
+	return hcs.Auth.ExtensionIDs()
+}
+
 // ToClientWithHost creates an HTTP client.
 func (hcs *HTTPClientSettings) ToClientWithHost(host component.Host, settings component.TelemetrySettings) (*http.Client, error) {
 	return hcs.ToClient(host.GetExtensions(), settings)
@@ -192,21 +236,88 @@ func (hcs *HTTPClientSettings) ToClientWithHost(host component.Host, settings co
 // Custom RoundTripper that adds headers.
 type headerRoundTripper struct {
 	transport http.RoundTripper
-	headers   map[string]string
+	headers   map[string]configopaque.String
 }
 
 // RoundTrip is a custom RoundTripper that adds headers to the request.
 func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	for k, v := range interceptor.headers {
-		req.Header.Set(k, v)
+		req.Header.Set(k, string(v))
 	}
 	// Send the request to next transport.
 	return interceptor.transport.RoundTrip(req)
 }
 
+// Custom RoundTripper that limits the size of the response bodies.
+type maxResponseBodySizeRoundTripper struct {
+	transport   http.RoundTripper
+	maxBodySize int64
+}
+
+// RoundTrip is a custom RoundTripper that fails to read response bodies larger than the limit.
+func (interceptor *maxResponseBodySizeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := interceptor.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength > interceptor.maxBodySize {
+		resp.Body.Close()
+		return nil, fmt.Errorf("response body size %d exceeds the limit of %d bytes", resp.ContentLength, interceptor.maxBodySize)
+	}
+	resp.Body = newLimitedReadCloser(resp.Body, interceptor.maxBodySize,
+		fmt.Errorf("response body exceeds the limit of %d bytes", interceptor.maxBodySize))
+	return resp, nil
+}
+
+// ErrRequestBodyTooLarge is wrapped by the errors reading the request bodies exceeding the MaxRequestBodySize
+// or the MaxDecompressedRequestBodySize of the server, for the handlers to respond with a 413 status code.
+var ErrRequestBodyTooLarge = errors.New("request body too large")
+
+// requestBodyTooLargeError is the error of a request body exceeding a limit, matching ErrRequestBodyTooLarge.
+type requestBodyTooLargeError string
+
+func (e requestBodyTooLargeError) Error() string {
+	return string(e)
+}
+
+func (e requestBodyTooLargeError) Is(target error) bool {
+	return target == ErrRequestBodyTooLarge
+}
+
+// limitedReadCloser reads from the wrapped io.ReadCloser and fails with the given error
+// if more than the given number of bytes are available.
+type limitedReadCloser struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func newLimitedReadCloser(rc io.ReadCloser, limit int64, err error) *limitedReadCloser {
+	return &limitedReadCloser{ReadCloser: rc, remaining: limit, err: err}
+}
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, l.err
+	}
+	// Read one more byte than remaining to detect bodies exceeding the limit.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.ReadCloser.Read(p)
+	if int64(n) <= l.remaining {
+		l.remaining -= int64(n)
+		return n, err
+	}
+	n = int(l.remaining)
+	l.remaining = -1
+	return n, l.err
+}
+
 // HTTPServerSettings defines settings for creating an HTTP server.
 type HTTPServerSettings struct {
-	// Endpoint configures the listening address for the server.
+	// Endpoint configures the listening address for the server, or the path of its Unix socket
+	// when it starts with unix://, e.g. unix:///var/run/otelcol.sock.
 	Endpoint string `mapstructure:"endpoint"`
 
 	// TLSSetting struct exposes TLS client configuration.
@@ -218,17 +329,57 @@ type HTTPServerSettings struct {
 	// Auth for this receiver
 	Auth *configauth.Authentication `mapstructure:"auth"`
 
-	// MaxRequestBodySize sets the maximum request body size in bytes
+	// MemoryLimiter is the ID of the memory_limiter extension refusing the incoming requests, before their
+	// bodies are read, while the memory usage is above its limits. If nil the requests are not limited.
+	MemoryLimiter *config.ComponentID `mapstructure:"memory_limiter"`
+
+	// MaxRequestBodySize sets the maximum request body size in bytes. Reading a larger body fails with an
+	// error wrapping ErrRequestBodyTooLarge, without reading it when its Content-Length exceeds the limit.
 	MaxRequestBodySize int64 `mapstructure:"max_request_body_size"`
 
+	// MaxDecompressedRequestBodySize sets the maximum request body size in bytes after decompression,
+	// protecting against small compressed requests expanding to huge bodies. If zero or negative
+	// the decompressed request body size is not limited.
+	MaxDecompressedRequestBodySize int64 `mapstructure:"max_decompressed_request_body_size"`
+
 	// IncludeMetadata propagates the client metadata from the incoming requests to the downstream consumers
 	// Experimental: *NOTE* this option is subject to change or removal in the future.
 	IncludeMetadata bool `mapstructure:"include_metadata"`
+
+	// AccessLog enables the structured logging of a sample of the requests received by the server,
+	// to debug misbehaving clients. If nil the requests are not logged.
+	AccessLog *AccessLogSettings `mapstructure:"access_log"`
+
+	// Middlewares are the IDs of the ServerMiddleware extensions wrapping the handler of the server,
+	// the first one being the outermost one.
+	Middlewares []config.ComponentID `mapstructure:"middlewares"`
+
+	// Keepalive limits the age and the number of requests of the connections accepted by the server.
+	// If nil the connections are kept open until the clients close them.
+	Keepalive *KeepaliveServerSettings `mapstructure:"keepalive"`
+}
+
+// ExtensionDependencies returns the IDs of the authenticator, memory limiter and middleware extensions of the server.
+func (hss *HTTPServerSettings) ExtensionDependencies() []config.ComponentID {
+	var ids []config.ComponentID
+	if hss.Auth != nil {
+		ids = append(ids, hss.Auth.ExtensionIDs()...)
+	}
+	if hss.MemoryLimiter != nil {
+		ids = append(ids, *hss.MemoryLimiter)
+	}
+	return append(ids, hss.Middlewares...)
 }
 
 // ToListener creates a net.Listener.
 func (hss *HTTPServerSettings) ToListener() (net.Listener, error) {
-	listener, err := net.Listen("tcp", hss.Endpoint)
+	var listener net.Listener
+	var err error
+	if isUnixSocketEndpoint(hss.Endpoint) {
+		listener, err = listenUnixSocket(hss.Endpoint)
+	} else {
+		listener, err = net.Listen("tcp", hss.Endpoint)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -270,9 +421,21 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 		o(serverOpts)
 	}
 
+	if hss.AccessLog != nil {
+		if err := hss.AccessLog.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if hss.Keepalive != nil {
+		if err := hss.Keepalive.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	handler = httpContentDecompressor(
 		handler,
 		withErrorHandlerForDecompressor(serverOpts.errorHandler),
+		withMaxDecompressedBodySize(hss.MaxDecompressedRequestBodySize),
 	)
 
 	if hss.MaxRequestBodySize > 0 {
@@ -288,6 +451,16 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 		handler = authInterceptor(handler, authenticator.Authenticate)
 	}
 
+	// The memory limiter refuses the requests before they are authenticated and their bodies read.
+	if hss.MemoryLimiter != nil {
+		limiter, err := memorylimiter.GetLimiter(host.GetExtensions(), *hss.MemoryLimiter)
+		if err != nil {
+			return nil, err
+		}
+
+		handler = memoryLimiterInterceptor(handler, limiter)
+	}
+
 	if hss.CORS != nil && len(hss.CORS.AllowedOrigins) > 0 {
 		co := cors.Options{
 			AllowedOrigins:   hss.CORS.AllowedOrigins,
@@ -299,6 +472,13 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 	}
 	// TODO: emit a warning when non-empty CorsHeaders and empty CorsOrigins.
 
+	if len(hss.Middlewares) > 0 {
+		var err error
+		if handler, err = wrapMiddlewares(handler, host.GetExtensions(), hss.Middlewares); err != nil {
+			return nil, err
+		}
+	}
+
 	// Enable OpenTelemetry observability plugin.
 	// TODO: Consider to use component ID string as prefix for all the operations.
 	handler = otelhttp.NewHandler(
@@ -318,9 +498,23 @@ func (hss *HTTPServerSettings) ToServer(host component.Host, settings component.
 		includeMetadata: hss.IncludeMetadata,
 	}
 
-	return &http.Server{
+	// The keepalive handler counts all the requests of the connections, including the refused ones.
+	if hss.Keepalive != nil {
+		handler = &keepaliveHandler{next: handler, settings: hss.Keepalive}
+	}
+
+	// The access log handler is the outermost one to measure the total duration of the requests.
+	if hss.AccessLog != nil {
+		handler = newAccessLogHandler(handler, settings.Logger, hss.AccessLog)
+	}
+
+	server := &http.Server{
 		Handler: handler,
-	}, nil
+	}
+	if hss.Keepalive != nil {
+		server.ConnContext = keepaliveConnContext
+	}
+	return server, nil
 }
 
 // CORSSettings configures a receiver for HTTP cross-origin resource sharing (CORS).
@@ -357,9 +551,29 @@ func authInterceptor(next http.Handler, authenticate configauth.AuthenticateFunc
 	})
 }
 
+func memoryLimiterInterceptor(next http.Handler, limiter memorylimiter.Limiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limiter.MustRefuse() {
+			// Service Unavailable is retryable, the clients retrying once the memory is released.
+			http.Error(w, "data refused due to high memory usage", http.StatusServiceUnavailable)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func maxRequestBodySizeInterceptor(next http.Handler, maxRecvSize int64) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		r.Body = http.MaxBytesReader(w, r.Body, maxRecvSize)
+		body := newLimitedReadCloser(r.Body, maxRecvSize,
+			requestBodyTooLargeError(fmt.Sprintf("request body exceeds the limit of %d bytes", maxRecvSize)))
+		if r.ContentLength > maxRecvSize {
+			// Fail the first read instead of reading a body known to exceed the limit.
+			body.remaining = -1
+			// The unread body is not drained, the connection cannot be reused.
+			w.Header().Set("Connection", "close")
+		}
+		r.Body = body
 		next.ServeHTTP(w, r)
 	})
 }
@@ -15,6 +15,7 @@
 package confighttp
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -24,17 +25,24 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -117,6 +125,25 @@ func TestAllHTTPClientSettings(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "all_valid_settings_with_zstd_compression_params",
+			settings: HTTPClientSettings{
+				Endpoint: "localhost:1234",
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: false,
+				},
+				ReadBufferSize:      1024,
+				WriteBufferSize:     512,
+				MaxIdleConns:        &maxIdleConns,
+				MaxIdleConnsPerHost: &maxIdleConnsPerHost,
+				MaxConnsPerHost:     &maxConnsPerHost,
+				IdleConnTimeout:     &idleConnTimeout,
+				CustomRoundTripper:  func(next http.RoundTripper) (http.RoundTripper, error) { return next, nil },
+				Compression:         "zstd",
+				CompressionParams:   configcompression.CompressionParams{Level: 1, WindowSize: 1 << 16},
+			},
+			shouldError: false,
+		},
 		{
 			name: "error_round_tripper_returned",
 			settings: HTTPClientSettings{
@@ -151,7 +178,7 @@ func TestAllHTTPClientSettings(t *testing.T) {
 				assert.EqualValues(t, 45, transport.MaxConnsPerHost)
 				assert.EqualValues(t, 30*time.Second, transport.IdleConnTimeout)
 			case *compressRoundTripper:
-				assert.EqualValues(t, "gzip", transport.compressionType)
+				assert.EqualValues(t, test.settings.Compression, transport.compressionType)
 			}
 		})
 	}
@@ -206,6 +233,7 @@ func TestDefaultHTTPClientSettings(t *testing.T) {
 	httpClientSettings := NewDefaultHTTPClientSettings()
 	assert.EqualValues(t, 100, *httpClientSettings.MaxIdleConns)
 	assert.EqualValues(t, 90*time.Second, *httpClientSettings.IdleConnTimeout)
+	assert.Equal(t, 1, httpClientSettings.TransportRetries)
 }
 
 func TestHTTPClientSettingsError(t *testing.T) {
@@ -249,6 +277,14 @@ func TestHTTPClientSettingsError(t *testing.T) {
 				Auth:     &configauth.Authentication{AuthenticatorID: config.NewComponentID("dummy")},
 			},
 		},
+		{
+			err: "unsupported compression level 10 for \"gzip\", must be between 1 and 9",
+			settings: HTTPClientSettings{
+				Endpoint:          "localhost:1234",
+				Compression:       configcompression.Gzip,
+				CompressionParams: configcompression.CompressionParams{Level: 10},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -806,7 +842,7 @@ func TestHttpHeaders(t *testing.T) {
 				ReadBufferSize:  0,
 				WriteBufferSize: 0,
 				Timeout:         0,
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"header1": "value1",
 				},
 			}
@@ -819,6 +855,37 @@ func TestHttpHeaders(t *testing.T) {
 	}
 }
 
+func TestHttpClientTraceContextPropagation(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(prev)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	set := componenttest.NewNopTelemetrySettings()
+	set.TracerProvider = sdktrace.NewTracerProvider()
+	setting := HTTPClientSettings{Endpoint: server.URL}
+	client, err := setting.ToClientWithHost(componenttest.NewNopHost(), set)
+	require.NoError(t, err)
+
+	ctx, span := set.TracerProvider.Tracer("test").Start(context.Background(), "export")
+	defer span.End()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, setting.Endpoint, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// The client span created by the transport is a child of the export span.
+	require.NotEmpty(t, traceparent)
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -957,6 +1024,84 @@ func TestFailedServerAuth(t *testing.T) {
 	assert.Equal(t, response.Result().Status, fmt.Sprintf("%v %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)))
 }
 
+type mockMemoryLimiter struct {
+	component.Extension
+	refuse bool
+}
+
+func (ml *mockMemoryLimiter) MustRefuse() bool {
+	return ml.refuse
+}
+
+func TestServerMemoryLimiter(t *testing.T) {
+	limiterID := config.NewComponentID("memory_limiter")
+	limiter := &mockMemoryLimiter{}
+	hss := HTTPServerSettings{
+		MemoryLimiter: &limiterID,
+	}
+	host := &mockHost{
+		ext: map[config.ComponentID]component.Extension{limiterID: limiter},
+	}
+
+	handlerCalled := false
+	srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+	}))
+	require.NoError(t, err)
+
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest("POST", "/", strings.NewReader("body")))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.True(t, handlerCalled)
+
+	// The requests are refused without calling the handler while the limiter refuses data.
+	handlerCalled = false
+	limiter.refuse = true
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest("POST", "/", strings.NewReader("body")))
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.False(t, handlerCalled)
+}
+
+func TestInvalidServerMemoryLimiter(t *testing.T) {
+	limiterID := config.NewComponentID("memory_limiter")
+	hss := HTTPServerSettings{
+		MemoryLimiter: &limiterID,
+	}
+
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	require.Error(t, err)
+	require.Nil(t, srv)
+}
+
+func TestServerMaxRequestBodySize(t *testing.T) {
+	hss := HTTPServerSettings{
+		MaxRequestBodySize: 4,
+	}
+
+	var readErr error
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = ioutil.ReadAll(r.Body)
+	}))
+	require.NoError(t, err)
+
+	srv.Handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", strings.NewReader("body")))
+	assert.NoError(t, readErr)
+
+	// The bodies of unknown length fail once the limit is exceeded.
+	req := httptest.NewRequest("POST", "/", strings.NewReader("bodies"))
+	req.ContentLength = -1
+	srv.Handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.ErrorIs(t, readErr, ErrRequestBodyTooLarge)
+	assert.EqualError(t, readErr, "request body exceeds the limit of 4 bytes")
+
+	// The bodies whose length exceeds the limit fail without being read, closing the connection.
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest("POST", "/", strings.NewReader("bodies")))
+	assert.ErrorIs(t, readErr, ErrRequestBodyTooLarge)
+	assert.Equal(t, "close", response.Header().Get("Connection"))
+}
+
 type mockHost struct {
 	component.Host
 	ext map[config.ComponentID]component.Extension
@@ -1071,3 +1216,89 @@ func BenchmarkHttpRequest(b *testing.B) {
 		})
 	}
 }
+
+func TestMaxResponseBodySize(t *testing.T) {
+	respBody := bytes.Repeat([]byte("a"), 1024)
+	tests := []struct {
+		name              string
+		maxSize           int64
+		withContentLength bool
+		wantErr           string
+	}{
+		{
+			name:              "BelowLimit",
+			maxSize:           int64(len(respBody)),
+			withContentLength: true,
+		},
+		{
+			name:              "AboveLimitWithContentLength",
+			maxSize:           int64(len(respBody)) - 1,
+			withContentLength: true,
+			wantErr:           "response body size 1024 exceeds the limit of 1023 bytes",
+		},
+		{
+			name:    "AboveLimitWithoutContentLength",
+			maxSize: int64(len(respBody)) - 1,
+			wantErr: "response body exceeds the limit of 1023 bytes",
+		},
+		{
+			name:    "NoLimit",
+			maxSize: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.withContentLength {
+					w.Header().Set("Content-Length", strconv.Itoa(len(respBody)))
+				} else {
+					// Flush the headers to force chunked encoding.
+					w.(http.Flusher).Flush()
+				}
+				_, _ = w.Write(respBody)
+			}))
+			defer server.Close()
+
+			hcs := &HTTPClientSettings{
+				Endpoint:            server.URL,
+				MaxResponseBodySize: tt.maxSize,
+			}
+			client, err := hcs.ToClientWithHost(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, respBody, body)
+		})
+	}
+}
+
+func TestLimitedReadCloser(t *testing.T) {
+	errTooLarge := errors.New("too large")
+	r := newLimitedReadCloser(ioutil.NopCloser(strings.NewReader("0123456789")), 4, errTooLarge)
+	buf := make([]byte, 3)
+	n, err := r.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "012", string(buf[:n]))
+	n, err = r.Read(buf)
+	assert.Equal(t, errTooLarge, err)
+	assert.Equal(t, "3", string(buf[:n]))
+	n, err = r.Read(buf)
+	assert.Equal(t, errTooLarge, err)
+	assert.Equal(t, 0, n)
+
+	r = newLimitedReadCloser(ioutil.NopCloser(strings.NewReader("0123")), 4, errTooLarge)
+	body, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123", string(body))
+}
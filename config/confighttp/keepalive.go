@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// KeepaliveServerSettings configures the lifecycle of the connections accepted by the server, e.g. to
+// rebalance the long-lived connections of the clients across the replicas behind a load balancer.
+// A connection is closed once the response to the request reaching one of the limits is sent, with the
+// `Connection: close` header for HTTP/1.1 and a GOAWAY frame for HTTP/2, the clients opening a new
+// connection for their next requests.
+type KeepaliveServerSettings struct {
+	// MaxConnectionAge is the duration after which the connections are closed. If zero the age of the
+	// connections is not limited.
+	MaxConnectionAge time.Duration `mapstructure:"max_connection_age"`
+
+	// MaxRequestsPerConnection is the number of requests after which the connections are closed. If zero
+	// the number of requests per connection is not limited.
+	MaxRequestsPerConnection int64 `mapstructure:"max_requests_per_connection"`
+}
+
+// Validate checks that the keepalive settings are valid.
+func (ks *KeepaliveServerSettings) Validate() error {
+	if ks.MaxConnectionAge < 0 {
+		return errors.New("keepalive max_connection_age must not be negative")
+	}
+	if ks.MaxRequestsPerConnection < 0 {
+		return errors.New("keepalive max_requests_per_connection must not be negative")
+	}
+	return nil
+}
+
+type keepaliveConnKey struct{}
+
+// keepaliveConn is the state of a connection tracked by the keepaliveHandler.
+type keepaliveConn struct {
+	accepted time.Time
+	// requests is the number of requests received on the connection.
+	requests int64
+}
+
+// keepaliveConnContext is the http.Server ConnContext adding the state of the connection to the
+// context of its requests.
+func keepaliveConnContext(ctx context.Context, _ net.Conn) context.Context {
+	return context.WithValue(ctx, keepaliveConnKey{}, &keepaliveConn{accepted: time.Now()})
+}
+
+// keepaliveHandler closes the connections reaching the limits of the settings after responding.
+type keepaliveHandler struct {
+	next     http.Handler
+	settings *KeepaliveServerSettings
+}
+
+func (h *keepaliveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if conn, ok := r.Context().Value(keepaliveConnKey{}).(*keepaliveConn); ok && h.mustClose(conn) {
+		w.Header().Set("Connection", "close")
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *keepaliveHandler) mustClose(conn *keepaliveConn) bool {
+	requests := atomic.AddInt64(&conn.requests, 1)
+	if h.settings.MaxRequestsPerConnection > 0 && requests >= h.settings.MaxRequestsPerConnection {
+		return true
+	}
+	return h.settings.MaxConnectionAge > 0 && time.Since(conn.accepted) >= h.settings.MaxConnectionAge
+}
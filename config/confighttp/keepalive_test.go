@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestKeepaliveServerSettingsValidate(t *testing.T) {
+	assert.NoError(t, (&KeepaliveServerSettings{}).Validate())
+	assert.NoError(t, (&KeepaliveServerSettings{MaxConnectionAge: time.Minute, MaxRequestsPerConnection: 100}).Validate())
+	assert.EqualError(t, (&KeepaliveServerSettings{MaxConnectionAge: -time.Second}).Validate(),
+		"keepalive max_connection_age must not be negative")
+	assert.EqualError(t, (&KeepaliveServerSettings{MaxRequestsPerConnection: -1}).Validate(),
+		"keepalive max_requests_per_connection must not be negative")
+}
+
+func TestKeepaliveMaxRequestsPerConnection(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:  "localhost:0",
+		Keepalive: &KeepaliveServerSettings{MaxRequestsPerConnection: 2},
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer func() {
+		assert.NoError(t, srv.Close())
+	}()
+
+	client := &http.Client{}
+	var closed []bool
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get("http://" + ln.Addr().String())
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		closed = append(closed, resp.Close)
+	}
+	// The second request closes the connection, the third one being sent on a new connection.
+	assert.Equal(t, []bool{false, true, false}, closed)
+}
+
+func TestKeepaliveMaxConnectionAge(t *testing.T) {
+	h := &keepaliveHandler{
+		next:     http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		settings: &KeepaliveServerSettings{MaxConnectionAge: time.Minute},
+	}
+
+	for _, tc := range []struct {
+		age      time.Duration
+		expected string
+	}{
+		{age: time.Second, expected: ""},
+		{age: time.Hour, expected: "close"},
+	} {
+		ctx := context.WithValue(context.Background(), keepaliveConnKey{}, &keepaliveConn{accepted: time.Now().Add(-tc.age)})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+		assert.Equal(t, tc.expected, rec.Header().Get("Connection"), "age %v", tc.age)
+	}
+}
+
+func TestKeepaliveInvalidSettings(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint:  "localhost:0",
+		Keepalive: &KeepaliveServerSettings{MaxRequestsPerConnection: -1},
+	}
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	assert.Error(t, err)
+	assert.Nil(t, srv)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+var (
+	errMiddlewareNotFound  = errors.New("middleware not found")
+	errNotServerMiddleware = errors.New("requested extension is not a server middleware")
+)
+
+// ServerMiddleware is an extension wrapping the handlers of the HTTP servers created by
+// HTTPServerSettings.ToServer, for the cross-cutting concerns of the receivers, e.g. injecting
+// request IDs, filtering the requests or authenticating them with a custom scheme. The
+// middlewares are applied to the servers listing the ID of the extension in their
+// HTTPServerSettings.Middlewares, the first one listed being the outermost one.
+//
+// The middlewares see the requests after the client.Info is added to their context and
+// their span started, and before the CORS, memory limiter and authentication handlers.
+type ServerMiddleware interface {
+	component.Extension
+
+	// WrapHandler returns the handler of the requests wrapping the next handler of the server.
+	// It is called once per server when the server is created, before the extension is started.
+	WrapHandler(next http.Handler) (http.Handler, error)
+}
+
+// wrapMiddlewares wraps the handler with the ServerMiddleware extensions with the IDs, the first
+// one being the outermost one.
+func wrapMiddlewares(handler http.Handler, extensions map[config.ComponentID]component.Extension, ids []config.ComponentID) (http.Handler, error) {
+	for i := len(ids) - 1; i >= 0; i-- {
+		ext, found := extensions[ids[i]]
+		if !found {
+			return nil, fmt.Errorf("failed to resolve middleware %q: %w", ids[i], errMiddlewareNotFound)
+		}
+		middleware, ok := ext.(ServerMiddleware)
+		if !ok {
+			return nil, fmt.Errorf("failed to resolve middleware %q: %w", ids[i], errNotServerMiddleware)
+		}
+		var err error
+		if handler, err = middleware.WrapHandler(handler); err != nil {
+			return nil, fmt.Errorf("failed to wrap the handler with middleware %q: %w", ids[i], err)
+		}
+	}
+	return handler, nil
+}
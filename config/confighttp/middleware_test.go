@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+)
+
+type mockMiddleware struct {
+	component.Extension
+	name string
+	err  error
+}
+
+func (m *mockMiddleware) WrapHandler(next http.Handler) (http.Handler, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Reject") == m.name {
+			http.Error(w, "rejected by "+m.name, http.StatusForbidden)
+			return
+		}
+		// The client.Info is available to the middlewares.
+		if client.FromContext(r.Context()).Addr != nil {
+			r.Header.Add("X-Middlewares", m.name)
+		}
+		next.ServeHTTP(w, r)
+	}), nil
+}
+
+func TestServerMiddlewares(t *testing.T) {
+	hss := HTTPServerSettings{
+		Middlewares: []config.ComponentID{config.NewComponentID("first"), config.NewComponentID("second")},
+	}
+	host := &mockHost{
+		ext: map[config.ComponentID]component.Extension{
+			config.NewComponentID("first"):  &mockMiddleware{name: "first"},
+			config.NewComponentID("second"): &mockMiddleware{name: "second"},
+		},
+	}
+
+	var middlewares []string
+	srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middlewares = r.Header.Values("X-Middlewares")
+	}))
+	require.NoError(t, err)
+
+	response := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, httptest.NewRequest("POST", "/", strings.NewReader("body")))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"first", "second"}, middlewares)
+
+	// The middlewares can stop the requests.
+	middlewares = nil
+	req := httptest.NewRequest("POST", "/", strings.NewReader("body"))
+	req.Header.Set("X-Reject", "second")
+	response = httptest.NewRecorder()
+	srv.Handler.ServeHTTP(response, req)
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Equal(t, "rejected by second\n", response.Body.String())
+	assert.Nil(t, middlewares)
+}
+
+func TestInvalidServerMiddlewares(t *testing.T) {
+	id := config.NewComponentID("middleware")
+	tests := []struct {
+		name     string
+		ext      component.Extension
+		expected string
+	}{
+		{
+			name:     "not_found",
+			expected: `failed to resolve middleware "middleware": middleware not found`,
+		},
+		{
+			name:     "not_middleware",
+			ext:      &mockMemoryLimiter{},
+			expected: `failed to resolve middleware "middleware": requested extension is not a server middleware`,
+		},
+		{
+			name:     "wrap_error",
+			ext:      &mockMiddleware{err: errors.New("my error")},
+			expected: `failed to wrap the handler with middleware "middleware": my error`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := &mockHost{ext: map[config.ComponentID]component.Extension{}}
+			if tt.ext != nil {
+				host.ext[id] = tt.ext
+			}
+			hss := HTTPServerSettings{Middlewares: []config.ComponentID{id}}
+			srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+			assert.EqualError(t, err, tt.expected)
+			assert.Nil(t, srv)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// idempotencyKeyHeaders are the headers marking a request as idempotent whatever its method,
+// as for the retries of the http.Transport.
+var idempotencyKeyHeaders = []string{"Idempotency-Key", "X-Idempotency-Key"}
+
+// transportRetryRoundTripper retries the idempotent requests failed at the transport level before
+// receiving a response, e.g. sent on a connection concurrently closed by the server at the end of
+// its keep-alive timeout, which the http.Transport only retries on the reused connections.
+type transportRetryRoundTripper struct {
+	transport  http.RoundTripper
+	maxRetries int
+}
+
+func newTransportRetryRoundTripper(transport http.RoundTripper, maxRetries int) *transportRetryRoundTripper {
+	return &transportRetryRoundTripper{transport: transport, maxRetries: maxRetries}
+}
+
+// RoundTrip is a custom RoundTripper retrying the transport-level failures of the idempotent requests.
+func (rt *transportRetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.transport.RoundTrip(req)
+	for retries := 0; err != nil && retries < rt.maxRetries && isRetryableRequest(req) && isTransportFailure(err); retries++ {
+		if req.Context().Err() != nil {
+			break
+		}
+		retryReq := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, err
+			}
+			retryReq.Body = body
+		}
+		resp, err = rt.transport.RoundTrip(retryReq)
+	}
+	return resp, err
+}
+
+// isRetryableRequest returns whether the request is idempotent and its body can be sent again.
+func isRetryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	for _, h := range idempotencyKeyHeaders {
+		if _, ok := req.Header[h]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransportFailure returns whether the error is a connection closed or reset before the response.
+func isTransportFailure(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) ||
+		// The error of the http.Transport for the requests sent on a connection closed while idle is not exported.
+		strings.Contains(err.Error(), "server closed idle connection")
+}
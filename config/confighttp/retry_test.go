@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+// newFlakyServer returns a server closing the connections of the given number of first requests without a response.
+func newFlakyServer(t *testing.T, failures int32, bodies *[]string) (*httptest.Server, *int32) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		*bodies = append(*bodies, string(body))
+		if atomic.AddInt32(&requests, 1) <= failures {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestTransportRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		header           string
+		transportRetries int
+		failures         int32
+		expectedErr      bool
+		expectedRequests int32
+	}{
+		{
+			name:             "post_idempotency_key",
+			method:           http.MethodPost,
+			header:           "Idempotency-Key",
+			transportRetries: 1,
+			failures:         1,
+			expectedRequests: 2,
+		},
+		{
+			name:             "put",
+			method:           http.MethodPut,
+			transportRetries: 2,
+			failures:         2,
+			expectedRequests: 3,
+		},
+		{
+			name:             "exhausted",
+			method:           http.MethodPut,
+			transportRetries: 1,
+			failures:         2,
+			expectedErr:      true,
+			expectedRequests: 2,
+		},
+		{
+			name:             "post",
+			method:           http.MethodPost,
+			transportRetries: 1,
+			failures:         1,
+			expectedErr:      true,
+			expectedRequests: 1,
+		},
+		{
+			name:             "disabled",
+			method:           http.MethodPut,
+			failures:         1,
+			expectedErr:      true,
+			expectedRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			srv, requests := newFlakyServer(t, tt.failures, &bodies)
+			hcs := HTTPClientSettings{Endpoint: srv.URL, TransportRetries: tt.transportRetries}
+			client, err := hcs.ToClientWithHost(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, srv.URL, bytes.NewReader([]byte("payload")))
+			require.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set(tt.header, "key")
+			}
+			resp, err := client.Do(req)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				require.NoError(t, resp.Body.Close())
+			}
+			assert.Equal(t, tt.expectedRequests, atomic.LoadInt32(requests))
+			for _, body := range bodies {
+				assert.Equal(t, "payload", body)
+			}
+		})
+	}
+}
+
+func TestIsTransportFailure(t *testing.T) {
+	assert.True(t, isTransportFailure(io.EOF))
+	assert.True(t, isTransportFailure(io.ErrUnexpectedEOF))
+	assert.True(t, isTransportFailure(syscall.ECONNRESET))
+	assert.True(t, isTransportFailure(errors.New("http: server closed idle connection")))
+	assert.False(t, isTransportFailure(syscall.ECONNREFUSED))
+	assert.False(t, isTransportFailure(context.DeadlineExceeded))
+}
+
+func TestIsRetryableRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	assert.True(t, isRetryableRequest(req))
+
+	req, err = http.NewRequest(http.MethodPost, "http://localhost", bytes.NewReader(nil))
+	require.NoError(t, err)
+	assert.False(t, isRetryableRequest(req))
+	req.Header.Set("X-Idempotency-Key", "key")
+	assert.True(t, isRetryableRequest(req))
+
+	// The body can't be sent again.
+	req, err = http.NewRequest(http.MethodPut, "http://localhost", io.NopCloser(bytes.NewReader([]byte("payload"))))
+	require.NoError(t, err)
+	assert.False(t, isRetryableRequest(req))
+}
@@ -0,0 +1,101 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// unixScheme is the scheme of the endpoints of the servers listening on a Unix socket,
+// e.g. unix:///var/run/otelcol.sock, the path of the endpoint being the socket path.
+// It is supported by both the client and the server endpoints.
+const unixScheme = "unix"
+
+// isUnixSocketEndpoint returns whether the endpoint is the one of a Unix socket.
+func isUnixSocketEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, unixScheme+"://")
+}
+
+// unixSocketPath returns the path of the Unix socket of the endpoint.
+func unixSocketPath(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+		return "", fmt.Errorf("unix socket endpoint %q must be of the form unix:///path/to/socket", endpoint)
+	}
+	return strings.TrimSuffix(u.Path, "/"), nil
+}
+
+// listenUnixSocket listens on the Unix socket of the endpoint. The socket file is removed when
+// the listener is closed.
+func listenUnixSocket(endpoint string) (net.Listener, error) {
+	socketPath, err := unixSocketPath(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return net.Listen("unix", socketPath)
+}
+
+// registerUnixSocket registers the unix scheme on the transport, sending the requests to the URLs
+// starting with the endpoint over its Unix socket.
+func registerUnixSocket(transport *http.Transport, endpoint string) error {
+	socketPath, err := unixSocketPath(endpoint)
+	if err != nil {
+		return err
+	}
+	unixTransport := transport.Clone()
+	unixTransport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socketPath)
+	}
+	transport.RegisterProtocol(unixScheme, &unixSocketRoundTripper{socketPath: socketPath, transport: unixTransport})
+	return nil
+}
+
+// unixSocketRoundTripper sends the requests of the unix URLs as plain HTTP requests over the socket,
+// the remainder of the URL path after the socket path being the path of the HTTP request, e.g.
+// unix:///var/run/otelcol.sock/v1/traces is sent to /v1/traces.
+type unixSocketRoundTripper struct {
+	socketPath string
+	transport  http.RoundTripper
+}
+
+func (rt *unixSocketRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(req.URL.Path, rt.socketPath)
+	if req.URL.Host != "" || len(path) == len(req.URL.Path) || (path != "" && !strings.HasPrefix(path, "/")) {
+		return nil, fmt.Errorf("URL %q does not start with the unix socket endpoint unix://%s", req.URL, rt.socketPath)
+	}
+	if path == "" {
+		path = "/"
+	}
+	u := *req.URL
+	u.Scheme = "http"
+	// The host is only used for the Host header, the connection being established over the socket.
+	u.Host = "localhost"
+	u.Path = path
+	u.RawPath = ""
+
+	r := req.Clone(req.Context())
+	r.URL = &u
+	r.Host = u.Host
+	return rt.transport.RoundTrip(r)
+}
@@ -0,0 +1,136 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestHTTPClientUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	tmpfile, err := ioutil.TempFile("", "sock")
+	require.NoError(t, err)
+	require.NoError(t, tmpfile.Close())
+	socket := tmpfile.Name()
+	require.NoError(t, os.Remove(socket))
+
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Path", r.URL.Path)
+		w.Header().Set("X-Host", r.Host)
+		w.Header().Set("X-Header", r.Header.Get("header"))
+		_, _ = w.Write(body)
+	})}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(func() { require.NoError(t, srv.Close()) })
+
+	hcs := &HTTPClientSettings{
+		Endpoint: "unix://" + socket,
+		Headers:  map[string]configopaque.String{"header": "value"},
+	}
+	client, err := hcs.ToClient(nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	resp, err := client.Post(hcs.Endpoint+"/v1/traces", "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "payload", string(body))
+	assert.Equal(t, "/v1/traces", resp.Header.Get("X-Path"))
+	assert.Equal(t, "localhost", resp.Header.Get("X-Host"))
+	assert.Equal(t, "value", resp.Header.Get("X-Header"))
+
+	resp, err = client.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "/", resp.Header.Get("X-Path"))
+
+	// The URLs of other sockets are rejected.
+	_, err = client.Get("unix://" + socket + "x/v1/traces")
+	assert.ErrorContains(t, err, "does not start with the unix socket endpoint")
+
+	// The other schemes are not sent over the socket.
+	_, err = client.Get("http://" + ln.Addr().String())
+	assert.Error(t, err)
+}
+
+func TestHTTPClientInvalidUnixSocket(t *testing.T) {
+	hcs := &HTTPClientSettings{Endpoint: "unix://host/otelcol.sock"}
+	_, err := hcs.ToClient(nil, componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, `unix socket endpoint "unix://host/otelcol.sock" must be of the form unix:///path/to/socket`)
+}
+
+func TestHTTPServerUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	tmpfile, err := ioutil.TempFile("", "sock")
+	require.NoError(t, err)
+	require.NoError(t, tmpfile.Close())
+	socket := tmpfile.Name()
+	require.NoError(t, os.Remove(socket))
+
+	hss := &HTTPServerSettings{Endpoint: "unix://" + socket}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.Path)
+	}))
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+
+	// The client and the server endpoints are the same.
+	hcs := &HTTPClientSettings{Endpoint: hss.Endpoint}
+	client, err := hcs.ToClient(nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	resp, err := client.Get(hcs.Endpoint + "/v1/traces")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/v1/traces", resp.Header.Get("X-Path"))
+
+	// The socket file is removed when the server is closed.
+	require.NoError(t, srv.Close())
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestHTTPServerInvalidUnixSocket(t *testing.T) {
+	hss := &HTTPServerSettings{Endpoint: "unix://host/otelcol.sock"}
+	_, err := hss.ToListener()
+	assert.EqualError(t, err, `unix socket endpoint "unix://host/otelcol.sock" must be of the form unix:///path/to/socket`)
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configopaque // import "go.opentelemetry.io/collector/config/configopaque"
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// String is a string that is sensitive and must not be printed: use it in the configuration for
+// values like headers, authentication tokens or passwords. It marshals to "[REDACTED]" in YAML and JSON,
+// and formats as "[REDACTED]", convert it to a string to get its actual value.
+type String string
+
+const maskedString = "[REDACTED]"
+
+var (
+	_ encoding.TextMarshaler = String("")
+	_ fmt.Stringer           = String("")
+	_ fmt.GoStringer         = String("")
+)
+
+// MarshalText marshals the String as "[REDACTED]".
+func (s String) MarshalText() ([]byte, error) {
+	return []byte(maskedString), nil
+}
+
+// String formats the String as "[REDACTED]".
+func (s String) String() string {
+	return maskedString
+}
+
+// GoString formats the String as "[REDACTED]", including with the %#v verb.
+func (s String) GoString() string {
+	return fmt.Sprintf("%q", maskedString)
+}
+
+// MapToStrings converts a map of String to a map of string, for the APIs requiring the actual values.
+func MapToStrings(m map[string]String) map[string]string {
+	if m == nil {
+		return nil
+	}
+	strs := make(map[string]string, len(m))
+	for k, v := range m {
+		strs[k] = string(v)
+	}
+	return strs
+}
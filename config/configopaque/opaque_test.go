@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configopaque
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type testConfig struct {
+	Endpoint string            `json:"endpoint" yaml:"endpoint"`
+	Token    String            `json:"token" yaml:"token"`
+	Headers  map[string]String `json:"headers" yaml:"headers"`
+}
+
+func TestStringMarshal(t *testing.T) {
+	cfg := testConfig{
+		Endpoint: "localhost:4317",
+		Token:    "secret",
+		Headers:  map[string]String{"authorization": "Bearer secret"},
+	}
+
+	jsonBytes, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"endpoint":"localhost:4317","token":"[REDACTED]","headers":{"authorization":"[REDACTED]"}}`, string(jsonBytes))
+
+	yamlBytes, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(yamlBytes), "secret")
+	assert.Contains(t, string(yamlBytes), "token: '[REDACTED]'")
+}
+
+func TestStringFormat(t *testing.T) {
+	cfg := testConfig{
+		Token:   "secret",
+		Headers: map[string]String{"authorization": "Bearer secret"},
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		assert.NotContains(t, fmt.Sprintf(format, cfg), "secret", format)
+	}
+	assert.NotContains(t, fmt.Errorf("invalid token %v", cfg.Token).Error(), "secret")
+	assert.Equal(t, "[REDACTED]", errors.New(cfg.Token.String()).Error())
+	assert.Equal(t, "secret", string(cfg.Token))
+}
+
+func TestMapToStrings(t *testing.T) {
+	assert.Nil(t, MapToStrings(nil))
+	assert.Equal(t, map[string]string{"key": "value"}, MapToStrings(map[string]String{"key": "value"}))
+}
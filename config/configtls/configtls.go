@@ -40,6 +40,11 @@ type TLSSetting struct {
 	// Path to the TLS key to use for TLS required connections. (optional)
 	KeyFile string `mapstructure:"key_file"`
 
+	// KeyURI identifies a TLS key held by a KeyProvider instead of a file, e.g. in a PKCS#11 token
+	// or a KMS. The URI scheme selects the KeyProvider, see RegisterKeyProvider. Cannot be used
+	// with KeyFile. (optional)
+	KeyURI string `mapstructure:"key_uri"`
+
 	// MinVersion sets the minimum TLS version that is acceptable.
 	// If not set, refer to crypto/tls for defaults. (optional)
 	MinVersion string `mapstructure:"min_version"`
@@ -48,11 +53,27 @@ type TLSSetting struct {
 	// If not set, refer to crypto/tls for defaults. (optional)
 	MaxVersion string `mapstructure:"max_version"`
 
+	// CipherSuites sets the cipher suites allowed with TLS 1.2 and lower, by their names in crypto/tls,
+	// e.g. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256". The insecure cipher suites are refused.
+	// If not set, refer to crypto/tls for defaults. (optional)
+	CipherSuites []string `mapstructure:"cipher_suites"`
+
+	// Preset sets the TLS versions and cipher suites to the ones of a named security profile:
+	// "modern", "intermediate" or "fips". MinVersion, MaxVersion and CipherSuites may further
+	// restrict them, but not allow versions or cipher suites excluded by the preset. (optional)
+	Preset string `mapstructure:"preset"`
+
 	// ReloadInterval specifies the duration after which the certificate will be reloaded
 	// If not set, it will never be reloaded (optional)
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
 }
 
+// Validate checks that the TLS versions, cipher suites and preset can be negotiated together.
+func (c TLSSetting) Validate() error {
+	_, err := c.tlsParameters()
+	return err
+}
+
 // TLSClientSetting contains TLS configurations that are specific to client
 // connections in addition to the common configurations. This should be used by
 // components configuring TLS client connections.
@@ -90,6 +111,10 @@ type TLSServerSetting struct {
 	// This sets the ClientCAs and ClientAuth to RequireAndVerifyClientCert in the TLSConfig. Please refer to
 	// https://godoc.org/crypto/tls#Config for more information. (optional)
 	ClientCAFile string `mapstructure:"client_ca_file"`
+
+	// Revocation configures the checking of the revocation of the client certificates verified
+	// with the ClientCAFile. (optional)
+	Revocation RevocationSettings `mapstructure:"revocation"`
 }
 
 // certReloader is a wrapper object for certificate reloading
@@ -100,6 +125,8 @@ type certReloader struct {
 	CertFile string
 	// Path to the TLS key
 	KeyFile string
+	// URI of the TLS key held by a KeyProvider
+	KeyURI string
 	// ReloadInterval specifies the duration after which the certificate will be reloaded
 	// If not set, it will never be reloaded (optional)
 	ReloadInterval time.Duration
@@ -108,14 +135,15 @@ type certReloader struct {
 	lock           sync.RWMutex
 }
 
-func newCertReloader(certFile, keyFile string, reloadInterval time.Duration) (*certReloader, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+func newCertReloader(certFile, keyFile, keyURI string, reloadInterval time.Duration) (*certReloader, error) {
+	cert, err := loadKeyPair(certFile, keyFile, keyURI)
 	if err != nil {
 		return nil, err
 	}
 	return &certReloader{
 		CertFile:       certFile,
 		KeyFile:        keyFile,
+		KeyURI:         keyURI,
 		ReloadInterval: reloadInterval,
 		nextReload:     time.Now().Add(reloadInterval),
 		cert:           &cert,
@@ -133,7 +161,7 @@ func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
 		r.lock.RUnlock()
 		r.lock.Lock()
 		defer r.lock.Unlock()
-		cert, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
+		cert, err := loadKeyPair(r.CertFile, r.KeyFile, r.KeyURI)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
 		}
@@ -160,15 +188,19 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 		}
 	}
 
-	if (c.CertFile == "" && c.KeyFile != "") || (c.CertFile != "" && c.KeyFile == "") {
+	if c.KeyFile != "" && c.KeyURI != "" {
+		return nil, errors.New("for auth via TLS, the key must be supplied either as a file or as a key provider URI, not both")
+	}
+	hasKey := c.KeyFile != "" || c.KeyURI != ""
+	if (c.CertFile == "" && hasKey) || (c.CertFile != "" && !hasKey) {
 		return nil, errors.New("for auth via TLS, either both certificate and key must be supplied, or neither")
 	}
 
 	var getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
 	var getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
-	if c.CertFile != "" && c.KeyFile != "" {
+	if c.CertFile != "" && hasKey {
 		var certReloader *certReloader
-		certReloader, err = newCertReloader(c.CertFile, c.KeyFile, c.ReloadInterval)
+		certReloader, err = newCertReloader(c.CertFile, c.KeyFile, c.KeyURI, c.ReloadInterval)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
 		}
@@ -176,21 +208,18 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 		getClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) { return certReloader.GetCertificate() }
 	}
 
-	minTLS, err := convertVersion(c.MinVersion)
+	params, err := c.tlsParameters()
 	if err != nil {
-		return nil, fmt.Errorf("invalid TLS min_version: %w", err)
-	}
-	maxTLS, err := convertVersion(c.MaxVersion)
-	if err != nil {
-		return nil, fmt.Errorf("invalid TLS max_version: %w", err)
+		return nil, err
 	}
 
 	return &tls.Config{
 		RootCAs:              certPool,
 		GetCertificate:       getCertificate,
 		GetClientCertificate: getClientCertificate,
-		MinVersion:           minTLS,
-		MaxVersion:           maxTLS,
+		MinVersion:           params.minVersion,
+		MaxVersion:           params.maxVersion,
+		CipherSuites:         params.cipherSuites,
 	}, nil
 }
 
@@ -236,6 +265,16 @@ func (c TLSServerSetting) LoadTLSConfig() (*tls.Config, error) {
 		tlsCfg.ClientCAs = certPool
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
 	}
+	if c.Revocation.enabled() {
+		if c.ClientCAFile == "" {
+			return nil, errors.New("failed to load TLS config: checking the revocation of the client certificates requires client_ca_file")
+		}
+		checker, err := newRevocationChecker(c.Revocation, c.ReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		tlsCfg.VerifyPeerCertificate = checker.verifyPeerCertificate
+	}
 	return tlsCfg, nil
 }
 
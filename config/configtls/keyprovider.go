@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// KeyProvider provides TLS keys which are not stored in files, e.g. keys held by a PKCS#11 token
+// or a KMS which cannot be exported. The keys are referenced by the key_uri setting, the URI scheme
+// selecting the KeyProvider registered with RegisterKeyProvider.
+type KeyProvider interface {
+	// Signer returns the crypto.Signer of the key identified by the URI, used to sign the TLS
+	// handshakes without exposing the key. Its public key must match the certificate.
+	Signer(uri string) (crypto.Signer, error)
+}
+
+// KeyProviderFunc is a helper function that is similar to KeyProvider.Signer,
+// allowing to provide custom signers without defining a type.
+type KeyProviderFunc func(uri string) (crypto.Signer, error)
+
+// Signer calls f(uri).
+func (f KeyProviderFunc) Signer(uri string) (crypto.Signer, error) {
+	return f(uri)
+}
+
+var (
+	keyProvidersMu sync.RWMutex
+	keyProviders   = map[string]KeyProvider{}
+)
+
+// RegisterKeyProvider registers the KeyProvider of the keys with the given URI scheme, e.g. "pkcs11"
+// for "pkcs11:token=collector;object=tls". It must be called before the TLS configurations are loaded,
+// typically when the collector distribution is initialized.
+func RegisterKeyProvider(scheme string, provider KeyProvider) error {
+	if scheme == "" {
+		return errors.New("key provider scheme must not be empty")
+	}
+	keyProvidersMu.Lock()
+	defer keyProvidersMu.Unlock()
+	if _, ok := keyProviders[scheme]; ok {
+		return fmt.Errorf("key provider for scheme %q is already registered", scheme)
+	}
+	keyProviders[scheme] = provider
+	return nil
+}
+
+func getKeyProvider(keyURI string) (KeyProvider, error) {
+	idx := strings.Index(keyURI, ":")
+	if idx <= 0 {
+		return nil, fmt.Errorf("invalid key URI %q, must start with the scheme of a key provider", keyURI)
+	}
+	scheme := keyURI[:idx]
+	keyProvidersMu.RLock()
+	defer keyProvidersMu.RUnlock()
+	provider, ok := keyProviders[scheme]
+	if !ok {
+		return nil, fmt.Errorf("no key provider registered for scheme %q", scheme)
+	}
+	return provider, nil
+}
+
+// loadKeyPair loads the certificate with its key from a file, or from the KeyProvider of the keyURI if set.
+func loadKeyPair(certFile, keyFile, keyURI string) (tls.Certificate, error) {
+	if keyURI == "" {
+		return tls.LoadX509KeyPair(certFile, keyFile)
+	}
+
+	certPEM, err := ioutil.ReadFile(filepath.Clean(certFile))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	var cert tls.Certificate
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			cert.Certificate = append(cert.Certificate, block.Bytes)
+		}
+	}
+	if len(cert.Certificate) == 0 {
+		return tls.Certificate{}, fmt.Errorf("failed to find any certificate in %s", certFile)
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	provider, err := getKeyProvider(keyURI)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	signer, err := provider.Signer(keyURI)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to get the signer of key %q: %w", keyURI, err)
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(cert.Leaf.PublicKey) {
+		return tls.Certificate{}, fmt.Errorf("the public key of key %q does not match the certificate", keyURI)
+	}
+	cert.PrivateKey = signer
+	return cert, nil
+}
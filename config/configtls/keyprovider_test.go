@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateCertificate writes a self-signed certificate of a new key to a temporary file.
+func generateCertificate(t *testing.T) (string, *x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return certFile, cert, key
+}
+
+// testSigner hides the key, as a non-exportable key of a PKCS#11 token or a KMS.
+type testSigner struct {
+	key   crypto.Signer
+	signs int
+}
+
+func (s *testSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *testSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.signs++
+	return s.key.Sign(rand, digest, opts)
+}
+
+func TestKeyProvider(t *testing.T) {
+	certFile, cert, key := generateCertificate(t)
+	signer := &testSigner{key: key}
+	var requestedURI string
+	require.NoError(t, RegisterKeyProvider("test-kp", KeyProviderFunc(func(uri string) (crypto.Signer, error) {
+		requestedURI = uri
+		return signer, nil
+	})))
+
+	tlsSetting := TLSServerSetting{
+		TLSSetting: TLSSetting{
+			CertFile: certFile,
+			KeyURI:   "test-kp:object=tls",
+		},
+	}
+	serverCfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "test-kp:object=tls", requestedURI)
+
+	tlsCert, err := serverCfg.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, cert, tlsCert.Leaf)
+	assert.Same(t, signer, tlsCert.PrivateKey)
+
+	// The handshake is signed by the signer of the key provider.
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- tls.Server(serverConn, serverCfg).Handshake()
+	}()
+	require.NoError(t, tls.Client(clientConn, &tls.Config{RootCAs: roots, ServerName: "localhost"}).Handshake())
+	require.NoError(t, <-errCh)
+	assert.Equal(t, 1, signer.signs)
+}
+
+func TestKeyProviderErrors(t *testing.T) {
+	certFile, _, _ := generateCertificate(t)
+	_, _, otherKey := generateCertificate(t)
+	require.NoError(t, RegisterKeyProvider("test-kp-mismatch", KeyProviderFunc(func(uri string) (crypto.Signer, error) {
+		return otherKey, nil
+	})))
+	require.NoError(t, RegisterKeyProvider("test-kp-error", KeyProviderFunc(func(uri string) (crypto.Signer, error) {
+		return nil, errors.New("token not found")
+	})))
+
+	tests := []struct {
+		name    string
+		setting TLSSetting
+		errText string
+	}{
+		{
+			name:    "key file and key URI",
+			setting: TLSSetting{CertFile: certFile, KeyFile: "key.pem", KeyURI: "test-kp-error:object=tls"},
+			errText: "either as a file or as a key provider URI",
+		},
+		{
+			name:    "key URI without certificate",
+			setting: TLSSetting{KeyURI: "test-kp-error:object=tls"},
+			errText: "either both certificate and key must be supplied",
+		},
+		{
+			name:    "key URI without scheme",
+			setting: TLSSetting{CertFile: certFile, KeyURI: "object=tls"},
+			errText: "must start with the scheme of a key provider",
+		},
+		{
+			name:    "unregistered scheme",
+			setting: TLSSetting{CertFile: certFile, KeyURI: "unknown:object=tls"},
+			errText: `no key provider registered for scheme "unknown"`,
+		},
+		{
+			name:    "key provider error",
+			setting: TLSSetting{CertFile: certFile, KeyURI: "test-kp-error:object=tls"},
+			errText: "token not found",
+		},
+		{
+			name:    "mismatched key",
+			setting: TLSSetting{CertFile: certFile, KeyURI: "test-kp-mismatch:object=tls"},
+			errText: "does not match the certificate",
+		},
+		{
+			name:    "missing certificate",
+			setting: TLSSetting{CertFile: filepath.Join(t.TempDir(), "missing.pem"), KeyURI: "test-kp-mismatch:object=tls"},
+			errText: "missing.pem",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.setting.loadTLSConfig()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.errText)
+		})
+	}
+}
+
+func TestRegisterKeyProvider(t *testing.T) {
+	kp := KeyProviderFunc(func(uri string) (crypto.Signer, error) { return nil, nil })
+	assert.Error(t, RegisterKeyProvider("", kp))
+	require.NoError(t, RegisterKeyProvider("test-kp-register", kp))
+	assert.Error(t, RegisterKeyProvider("test-kp-register", kp))
+}
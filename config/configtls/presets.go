@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+)
+
+// tlsPreset is a named set of TLS versions and cipher suites.
+type tlsPreset struct {
+	minVersion uint16
+	// maxVersion is 0 when the preset does not limit the maximum version.
+	maxVersion uint16
+	// cipherSuites are the suites allowed with TLS 1.2 and lower, empty when the preset only allows
+	// TLS 1.3 whose cipher suites are not configurable.
+	cipherSuites []uint16
+}
+
+// tlsPresets are the presets of the preset setting:
+//   - modern only allows TLS 1.3,
+//   - intermediate allows TLS 1.2 and 1.3, with the forward secret AEAD cipher suites for TLS 1.2,
+//   - fips only allows TLS 1.2 with the AES-GCM cipher suites approved by FIPS 140-2, the TLS 1.3
+//     cipher suites, including ChaCha20-Poly1305, not being configurable. It restricts the negotiated
+//     parameters, the Go cryptographic module itself not being FIPS validated.
+var tlsPresets = map[string]tlsPreset{
+	"modern": {
+		minVersion: tls.VersionTLS13,
+	},
+	"intermediate": {
+		minVersion: tls.VersionTLS12,
+		cipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+	},
+	"fips": {
+		minVersion: tls.VersionTLS12,
+		maxVersion: tls.VersionTLS12,
+		cipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		},
+	},
+}
+
+// tlsParameters are the TLS versions and cipher suites of a TLSSetting, 0 and nil leaving the
+// defaults of crypto/tls.
+type tlsParameters struct {
+	minVersion   uint16
+	maxVersion   uint16
+	cipherSuites []uint16
+}
+
+// tlsParameters returns the TLS versions and cipher suites of the preset, overridden by the
+// versions and cipher suites of the setting, failing if they cannot be negotiated together or
+// weaken the preset.
+func (c TLSSetting) tlsParameters() (tlsParameters, error) {
+	var params tlsParameters
+	var err error
+	if params.minVersion, err = convertVersion(c.MinVersion); err != nil {
+		return params, fmt.Errorf("invalid TLS min_version: %w", err)
+	}
+	if params.maxVersion, err = convertVersion(c.MaxVersion); err != nil {
+		return params, fmt.Errorf("invalid TLS max_version: %w", err)
+	}
+	if params.cipherSuites, err = convertCipherSuites(c.CipherSuites); err != nil {
+		return params, fmt.Errorf("invalid TLS cipher_suites: %w", err)
+	}
+
+	if c.Preset != "" {
+		preset, ok := tlsPresets[c.Preset]
+		if !ok {
+			return params, fmt.Errorf("invalid TLS preset: unsupported preset %q", c.Preset)
+		}
+		if err = preset.apply(&params); err != nil {
+			return params, fmt.Errorf("invalid TLS settings for the %q preset: %w", c.Preset, err)
+		}
+	}
+
+	if err = params.validate(); err != nil {
+		return params, fmt.Errorf("invalid TLS settings: %w", err)
+	}
+	return params, nil
+}
+
+// apply sets the unset parameters to the ones of the preset, failing if the set ones allow
+// versions or cipher suites not allowed by the preset.
+func (p tlsPreset) apply(params *tlsParameters) error {
+	switch {
+	case params.minVersion == 0:
+		params.minVersion = p.minVersion
+	case params.minVersion < p.minVersion:
+		return fmt.Errorf("min_version %s is lower than %s", versionName(params.minVersion), versionName(p.minVersion))
+	}
+	switch {
+	case p.maxVersion == 0:
+	case params.maxVersion == 0:
+		params.maxVersion = p.maxVersion
+	case params.maxVersion > p.maxVersion:
+		return fmt.Errorf("max_version %s is higher than %s", versionName(params.maxVersion), versionName(p.maxVersion))
+	}
+	if len(params.cipherSuites) == 0 {
+		params.cipherSuites = p.cipherSuites
+		return nil
+	}
+	for _, id := range params.cipherSuites {
+		if !containsCipherSuite(p.cipherSuites, id) {
+			return fmt.Errorf("cipher suite %s is not allowed", tls.CipherSuiteName(id))
+		}
+	}
+	return nil
+}
+
+// validate checks that the versions and the cipher suites can be negotiated together.
+func (p tlsParameters) validate() error {
+	minVersion, maxVersion := p.minVersion, p.maxVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS10
+	}
+	if maxVersion == 0 {
+		maxVersion = tls.VersionTLS13
+	}
+	if minVersion > maxVersion {
+		return fmt.Errorf("min_version %s is higher than max_version %s", versionName(minVersion), versionName(maxVersion))
+	}
+	if len(p.cipherSuites) == 0 {
+		return nil
+	}
+	if minVersion == tls.VersionTLS13 {
+		return errors.New("cipher_suites cannot be set with TLS 1.3 only, its cipher suites not being configurable")
+	}
+	for _, id := range p.cipherSuites {
+		if !supportsVersions(id, minVersion, maxVersion) {
+			return fmt.Errorf("cipher suite %s is not supported by TLS %s to %s", tls.CipherSuiteName(id), versionName(minVersion), versionName(maxVersion))
+		}
+	}
+	return nil
+}
+
+// convertCipherSuites returns the IDs of the cipher suites with the names, refusing the insecure ones.
+func convertCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		cs := lookupCipherSuite(name)
+		switch {
+		case cs == nil:
+			return nil, fmt.Errorf("unsupported cipher suite: %q", name)
+		case cs.Insecure:
+			return nil, fmt.Errorf("insecure cipher suite: %q", name)
+		}
+		ids = append(ids, cs.ID)
+	}
+	return ids, nil
+}
+
+func lookupCipherSuite(name string) *tls.CipherSuite {
+	for _, cs := range tls.CipherSuites() {
+		if cs.Name == name {
+			return cs
+		}
+	}
+	for _, cs := range tls.InsecureCipherSuites() {
+		if cs.Name == name {
+			return cs
+		}
+	}
+	return nil
+}
+
+// supportsVersions returns whether the cipher suite can be negotiated with one of the versions
+// between minVersion and maxVersion.
+func supportsVersions(id uint16, minVersion, maxVersion uint16) bool {
+	for _, cs := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		if cs.ID != id {
+			continue
+		}
+		for _, v := range cs.SupportedVersions {
+			if v >= minVersion && v <= maxVersion {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsCipherSuite(ids []uint16, id uint16) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// versionName returns the name of the TLS version in the configuration.
+func versionName(v uint16) string {
+	for name, version := range tlsVersions {
+		if version == v {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%04x", v)
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSPresets(t *testing.T) {
+	tests := []struct {
+		name         string
+		setting      TLSSetting
+		minVersion   uint16
+		maxVersion   uint16
+		cipherSuites []uint16
+	}{
+		{
+			name:       "modern",
+			setting:    TLSSetting{Preset: "modern"},
+			minVersion: tls.VersionTLS13,
+		},
+		{
+			name:         "intermediate",
+			setting:      TLSSetting{Preset: "intermediate"},
+			minVersion:   tls.VersionTLS12,
+			cipherSuites: tlsPresets["intermediate"].cipherSuites,
+		},
+		{
+			name:         "fips",
+			setting:      TLSSetting{Preset: "fips"},
+			minVersion:   tls.VersionTLS12,
+			maxVersion:   tls.VersionTLS12,
+			cipherSuites: tlsPresets["fips"].cipherSuites,
+		},
+		{
+			name: "restricted_intermediate",
+			setting: TLSSetting{
+				Preset:       "intermediate",
+				MaxVersion:   "1.2",
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			},
+			minVersion:   tls.VersionTLS12,
+			maxVersion:   tls.VersionTLS12,
+			cipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+		},
+		{
+			name:         "cipher_suites",
+			setting:      TLSSetting{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}},
+			cipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.setting.Validate())
+			cfg, err := tt.setting.loadTLSConfig()
+			require.NoError(t, err)
+			assert.Equal(t, tt.minVersion, cfg.MinVersion)
+			assert.Equal(t, tt.maxVersion, cfg.MaxVersion)
+			assert.Equal(t, tt.cipherSuites, cfg.CipherSuites)
+		})
+	}
+}
+
+func TestTLSPresetsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		setting  TLSSetting
+		expected string
+	}{
+		{
+			name:     "unknown_preset",
+			setting:  TLSSetting{Preset: "legacy"},
+			expected: `invalid TLS preset: unsupported preset "legacy"`,
+		},
+		{
+			name:     "unknown_cipher_suite",
+			setting:  TLSSetting{CipherSuites: []string{"TLS_UNKNOWN"}},
+			expected: `invalid TLS cipher_suites: unsupported cipher suite: "TLS_UNKNOWN"`,
+		},
+		{
+			name:     "insecure_cipher_suite",
+			setting:  TLSSetting{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+			expected: `invalid TLS cipher_suites: insecure cipher suite: "TLS_RSA_WITH_RC4_128_SHA"`,
+		},
+		{
+			name:     "weaker_min_version",
+			setting:  TLSSetting{Preset: "intermediate", MinVersion: "1.1"},
+			expected: `invalid TLS settings for the "intermediate" preset: min_version 1.1 is lower than 1.2`,
+		},
+		{
+			name:     "higher_max_version",
+			setting:  TLSSetting{Preset: "fips", MaxVersion: "1.3"},
+			expected: `invalid TLS settings for the "fips" preset: max_version 1.3 is higher than 1.2`,
+		},
+		{
+			name:     "cipher_suite_outside_preset",
+			setting:  TLSSetting{Preset: "fips", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}},
+			expected: `invalid TLS settings for the "fips" preset: cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256 is not allowed`,
+		},
+		{
+			name:     "cipher_suites_with_tls13",
+			setting:  TLSSetting{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			expected: `invalid TLS settings: cipher_suites cannot be set with TLS 1.3 only, its cipher suites not being configurable`,
+		},
+		{
+			name:     "cipher_suites_with_modern",
+			setting:  TLSSetting{Preset: "modern", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			expected: `invalid TLS settings for the "modern" preset: cipher suite TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 is not allowed`,
+		},
+		{
+			name:     "unsupported_cipher_suite_version",
+			setting:  TLSSetting{MaxVersion: "1.1", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			expected: `invalid TLS settings: cipher suite TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 is not supported by TLS 1.0 to 1.1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.setting.Validate(), tt.expected)
+			_, err := tt.setting.loadTLSConfig()
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
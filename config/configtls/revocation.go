@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const defaultOCSPTimeout = 5 * time.Second
+
+// RevocationSettings configures the checking of the revocation of the client certificates.
+// The certificates whose issuer has no CRL in CRLFiles and no OCSP responder are not checked.
+type RevocationSettings struct {
+	// CRLFiles are the paths of the certificate revocation lists, PEM or DER encoded, of the issuers of the
+	// client certificates. They are reloaded with the ReloadInterval of the certificate. (optional)
+	CRLFiles []string `mapstructure:"crl_files"`
+
+	// OCSP enables checking the client certificates with the OCSP responders listed in the certificates.
+	// The responses are cached until their next update. (optional)
+	OCSP bool `mapstructure:"ocsp"`
+
+	// OCSPTimeout is the time limit of the OCSP requests, 5s if not set. (optional)
+	OCSPTimeout time.Duration `mapstructure:"ocsp_timeout"`
+
+	// SoftFail accepts the client certificates whose revocation status is unknown, because the CRL of
+	// their issuer expired or the OCSP responder cannot be reached, instead of rejecting them. (optional)
+	SoftFail bool `mapstructure:"soft_fail"`
+}
+
+func (r RevocationSettings) enabled() bool {
+	return len(r.CRLFiles) != 0 || r.OCSP
+}
+
+// revocationChecker checks that the certificates of the verified client chains are not revoked.
+type revocationChecker struct {
+	settings       RevocationSettings
+	reloadInterval time.Duration
+	client         *http.Client
+
+	lock       sync.RWMutex
+	nextReload time.Time
+	// crls are the revocation lists by issuer name.
+	crls map[string][]*pkix.CertificateList
+
+	ocspLock  sync.Mutex
+	ocspCache map[string]*ocsp.Response
+}
+
+func newRevocationChecker(settings RevocationSettings, reloadInterval time.Duration) (*revocationChecker, error) {
+	timeout := settings.OCSPTimeout
+	if timeout == 0 {
+		timeout = defaultOCSPTimeout
+	}
+	rc := &revocationChecker{
+		settings:       settings,
+		reloadInterval: reloadInterval,
+		client:         &http.Client{Timeout: timeout},
+		ocspCache:      map[string]*ocsp.Response{},
+	}
+	crls, err := loadCRLs(settings.CRLFiles)
+	if err != nil {
+		return nil, err
+	}
+	rc.crls = crls
+	rc.nextReload = time.Now().Add(reloadInterval)
+	return rc, nil
+}
+
+func loadCRLs(files []string) (map[string][]*pkix.CertificateList, error) {
+	crls := map[string][]*pkix.CertificateList{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CRL %s: %w", file, err)
+		}
+		ders := [][]byte{data}
+		if bytes.Contains(data, []byte("-----BEGIN")) {
+			ders = nil
+			for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+				if block.Type == "X509 CRL" {
+					ders = append(ders, block.Bytes)
+				}
+			}
+		}
+		if len(ders) == 0 {
+			return nil, fmt.Errorf("failed to parse CRL %s: no X509 CRL found", file)
+		}
+		for _, der := range ders {
+			//nolint:staticcheck // x509.ParseRevocationList requires go1.19.
+			crl, err := x509.ParseDERCRL(der)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse CRL %s: %w", file, err)
+			}
+			issuer := crl.TBSCertList.Issuer.String()
+			crls[issuer] = append(crls[issuer], crl)
+		}
+	}
+	return crls, nil
+}
+
+// getCRLs returns the revocation lists, reloading them when the reload interval elapsed.
+func (rc *revocationChecker) getCRLs() (map[string][]*pkix.CertificateList, error) {
+	now := time.Now()
+	rc.lock.RLock()
+	if rc.reloadInterval != 0 && rc.nextReload.Before(now) {
+		rc.lock.RUnlock()
+		rc.lock.Lock()
+		defer rc.lock.Unlock()
+		crls, err := loadCRLs(rc.settings.CRLFiles)
+		if err != nil {
+			return nil, err
+		}
+		rc.crls = crls
+		rc.nextReload = now.Add(rc.reloadInterval)
+		return rc.crls, nil
+	}
+	defer rc.lock.RUnlock()
+	return rc.crls, nil
+}
+
+// verifyPeerCertificate implements tls.Config.VerifyPeerCertificate, accepting the connection if
+// one of the verified chains has no revoked certificate.
+func (rc *revocationChecker) verifyPeerCertificate(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if len(verifiedChains) == 0 {
+		return nil
+	}
+	crls, err := rc.getCRLs()
+	if err != nil {
+		return fmt.Errorf("failed to reload CRLs: %w", err)
+	}
+	var errs error
+	for _, chain := range verifiedChains {
+		if errs = rc.checkChain(chain, crls); errs == nil {
+			return nil
+		}
+	}
+	return errs
+}
+
+func (rc *revocationChecker) checkChain(chain []*x509.Certificate, crls map[string][]*pkix.CertificateList) error {
+	// The root of the chain is trusted as is.
+	for i := 0; i+1 < len(chain); i++ {
+		cert, issuer := chain[i], chain[i+1]
+		if err := rc.checkCRLs(cert, issuer, crls); err != nil {
+			return err
+		}
+		if rc.settings.OCSP {
+			if err := rc.checkOCSP(cert, issuer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (rc *revocationChecker) checkCRLs(cert, issuer *x509.Certificate, crls map[string][]*pkix.CertificateList) error {
+	for _, crl := range crls[issuer.Subject.ToRDNSequence().String()] {
+		//nolint:staticcheck // x509.RevocationList.CheckSignatureFrom requires go1.19.
+		if issuer.CheckCRLSignature(crl) != nil {
+			continue
+		}
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("certificate %q is revoked", cert.Subject)
+			}
+		}
+		if crl.HasExpired(time.Now()) && !rc.settings.SoftFail {
+			return fmt.Errorf("the CRL of the issuer %q of the certificate %q expired", issuer.Subject, cert.Subject)
+		}
+	}
+	return nil
+}
+
+func (rc *revocationChecker) checkOCSP(cert, issuer *x509.Certificate) error {
+	if len(cert.OCSPServer) == 0 {
+		return nil
+	}
+	resp, err := rc.ocspResponse(cert, issuer)
+	if err != nil {
+		if rc.settings.SoftFail {
+			return nil
+		}
+		return fmt.Errorf("failed to check the revocation of the certificate %q: %w", cert.Subject, err)
+	}
+	switch resp.Status {
+	case ocsp.Good:
+		return nil
+	case ocsp.Revoked:
+		return fmt.Errorf("certificate %q is revoked", cert.Subject)
+	default:
+		if rc.settings.SoftFail {
+			return nil
+		}
+		return fmt.Errorf("the revocation status of the certificate %q is unknown", cert.Subject)
+	}
+}
+
+// ocspResponse returns the cached response for the certificate, or queries its OCSP responders.
+func (rc *revocationChecker) ocspResponse(cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	key := string(issuer.RawSubject) + "/" + cert.SerialNumber.String()
+	now := time.Now()
+	rc.ocspLock.Lock()
+	cached, ok := rc.ocspCache[key]
+	rc.ocspLock.Unlock()
+	if ok && now.Before(cached.NextUpdate) {
+		return cached, nil
+	}
+
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+	var errs error
+	for _, server := range cert.OCSPServer {
+		var resp *ocsp.Response
+		if resp, err = rc.queryOCSP(server, req, issuer); err != nil {
+			errs = err
+			continue
+		}
+		if !resp.NextUpdate.IsZero() {
+			rc.ocspLock.Lock()
+			rc.ocspCache[key] = resp
+			rc.ocspLock.Unlock()
+		}
+		return resp, nil
+	}
+	return nil, errs
+}
+
+func (rc *revocationChecker) queryOCSP(server string, req []byte, issuer *x509.Certificate) (*ocsp.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rc.client.Timeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpResp, err := rc.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder %s returned %s", server, httpResp.Status)
+	}
+	body, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ocsp.ParseResponse(body, issuer)
+}
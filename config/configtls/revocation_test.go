@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCA(t *testing.T) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) issue(t *testing.T, serial int64, ocspServer string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if ocspServer != "" {
+		tmpl.OCSPServer = []string{ocspServer}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func (ca testCA) writeCRL(t *testing.T, nextUpdate time.Time, revoked ...int64) string {
+	var revokedCerts []pkix.RevokedCertificate
+	for _, serial := range revoked {
+		revokedCerts = append(revokedCerts, pkix.RevokedCertificate{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(1),
+		ThisUpdate:          time.Now().Add(-time.Hour),
+		NextUpdate:          nextUpdate,
+		RevokedCertificates: revokedCerts,
+	}, ca.cert, ca.key)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "ca.crl")
+	require.NoError(t, ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}), 0600))
+	return file
+}
+
+func TestRevocationCRL(t *testing.T) {
+	ca := newTestCA(t)
+	good := ca.issue(t, 2, "")
+	revoked := ca.issue(t, 3, "")
+
+	rc, err := newRevocationChecker(RevocationSettings{CRLFiles: []string{ca.writeCRL(t, time.Now().Add(time.Hour), 3)}}, 0)
+	require.NoError(t, err)
+	assert.NoError(t, rc.verifyPeerCertificate(nil, [][]*x509.Certificate{{good, ca.cert}}))
+	assert.EqualError(t, rc.verifyPeerCertificate(nil, [][]*x509.Certificate{{revoked, ca.cert}}), `certificate "CN=client" is revoked`)
+	// The certificates of other issuers are not checked.
+	other := newTestCA(t)
+	assert.NoError(t, rc.verifyPeerCertificate(nil, [][]*x509.Certificate{{other.issue(t, 3, ""), other.cert}}))
+}
+
+func TestRevocationExpiredCRL(t *testing.T) {
+	ca := newTestCA(t)
+	crl := ca.writeCRL(t, time.Now().Add(-time.Minute))
+	chain := [][]*x509.Certificate{{ca.issue(t, 2, ""), ca.cert}}
+
+	rc, err := newRevocationChecker(RevocationSettings{CRLFiles: []string{crl}}, 0)
+	require.NoError(t, err)
+	assert.Error(t, rc.verifyPeerCertificate(nil, chain))
+
+	rc, err = newRevocationChecker(RevocationSettings{CRLFiles: []string{crl}, SoftFail: true}, 0)
+	require.NoError(t, err)
+	assert.NoError(t, rc.verifyPeerCertificate(nil, chain))
+}
+
+func TestRevocationCRLReload(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.issue(t, 2, "")
+	crl := ca.writeCRL(t, time.Now().Add(time.Hour))
+	rc, err := newRevocationChecker(RevocationSettings{CRLFiles: []string{crl}}, time.Millisecond)
+	require.NoError(t, err)
+	assert.NoError(t, rc.verifyPeerCertificate(nil, [][]*x509.Certificate{{cert, ca.cert}}))
+
+	require.NoError(t, os.Rename(ca.writeCRL(t, time.Now().Add(time.Hour), 2), crl))
+	time.Sleep(2 * time.Millisecond)
+	assert.Error(t, rc.verifyPeerCertificate(nil, [][]*x509.Certificate{{cert, ca.cert}}))
+}
+
+func TestRevocationCRLError(t *testing.T) {
+	_, err := newRevocationChecker(RevocationSettings{CRLFiles: []string{"doesnt/exist"}}, 0)
+	assert.Error(t, err)
+	_, err = newRevocationChecker(RevocationSettings{CRLFiles: []string{filepath.Join("testdata", "ca-1.crt")}}, 0)
+	assert.Error(t, err)
+}
+
+func TestRevocationOCSP(t *testing.T) {
+	ca := newTestCA(t)
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+		status := ocsp.Good
+		if req.SerialNumber.Int64() == 3 {
+			status = ocsp.Revoked
+		}
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Minute),
+		}, ca.key)
+		require.NoError(t, err)
+		_, _ = w.Write(resp)
+	}))
+	defer srv.Close()
+
+	rc, err := newRevocationChecker(RevocationSettings{OCSP: true}, 0)
+	require.NoError(t, err)
+	good := [][]*x509.Certificate{{ca.issue(t, 2, srv.URL), ca.cert}}
+	assert.NoError(t, rc.verifyPeerCertificate(nil, good))
+	assert.NoError(t, rc.verifyPeerCertificate(nil, good))
+	assert.Equal(t, 1, requests, "the OCSP response is cached")
+	assert.Error(t, rc.verifyPeerCertificate(nil, [][]*x509.Certificate{{ca.issue(t, 3, srv.URL), ca.cert}}))
+}
+
+func TestRevocationOCSPUnreachable(t *testing.T) {
+	ca := newTestCA(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	chain := [][]*x509.Certificate{{ca.issue(t, 2, srv.URL), ca.cert}}
+
+	rc, err := newRevocationChecker(RevocationSettings{OCSP: true}, 0)
+	require.NoError(t, err)
+	assert.Error(t, rc.verifyPeerCertificate(nil, chain))
+
+	rc, err = newRevocationChecker(RevocationSettings{OCSP: true, SoftFail: true}, 0)
+	require.NoError(t, err)
+	assert.NoError(t, rc.verifyPeerCertificate(nil, chain))
+}
+
+func TestLoadTLSServerConfigRevocation(t *testing.T) {
+	tlsSetting := TLSServerSetting{Revocation: RevocationSettings{OCSP: true}}
+	_, err := tlsSetting.LoadTLSConfig()
+	assert.Error(t, err)
+
+	tlsSetting.ClientCAFile = filepath.Join("testdata", "ca-1.crt")
+	tlsCfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.NotNil(t, tlsCfg.VerifyPeerCertificate)
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config // import "go.opentelemetry.io/collector/config"
+
+// ExtensionDependent is an optional interface of the configurations of the components requiring extensions,
+// e.g. authenticators or storages. The service refuses the configurations of the components depending on
+// extensions which are not enabled in the service, and starts the extensions after the extensions they
+// depend on, instead of the components failing when they look up the extensions.
+type ExtensionDependent interface {
+	// ExtensionDependencies returns the IDs of the extensions required by the component.
+	ExtensionDependencies() []ComponentID
+}
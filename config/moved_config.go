@@ -18,7 +18,9 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/collector/service/recovery"
 	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/watchdog"
 )
 
 var (
@@ -97,12 +99,31 @@ func (cfg *Config) Validate() error {
 }
 
 func (cfg *Config) validateService() error {
+	if err := cfg.Service.Telemetry.Validate(); err != nil {
+		return fmt.Errorf("service telemetry has invalid configuration: %w", err)
+	}
+
+	if err := cfg.Service.Watchdog.Validate(); err != nil {
+		return fmt.Errorf("service watchdog has invalid configuration: %w", err)
+	}
+
+	if err := cfg.Service.Recovery.Validate(); err != nil {
+		return fmt.Errorf("service recovery has invalid configuration: %w", err)
+	}
+
 	// Check that all enabled extensions in the service are configured.
+	enabled := make(map[ComponentID]bool, len(cfg.Service.Extensions))
 	for _, ref := range cfg.Service.Extensions {
 		// Check that the name referenced in the Service extensions exists in the top-level extensions.
 		if cfg.Extensions[ref] == nil {
 			return fmt.Errorf("service references extension %q which does not exist", ref)
 		}
+		enabled[ref] = true
+	}
+	for _, ref := range cfg.Service.Extensions {
+		if err := validateDependencies("extension", ref, cfg.Extensions[ref], enabled); err != nil {
+			return err
+		}
 	}
 
 	// Must have at least one pipeline.
@@ -124,6 +145,9 @@ func (cfg *Config) validateService() error {
 			if cfg.Receivers[ref] == nil {
 				return fmt.Errorf("pipeline %q references receiver %q which does not exist", pipelineID, ref)
 			}
+			if err := validateDependencies("receiver", ref, cfg.Receivers[ref], enabled); err != nil {
+				return err
+			}
 		}
 
 		// Validate pipeline processor name references.
@@ -132,6 +156,9 @@ func (cfg *Config) validateService() error {
 			if cfg.Processors[ref] == nil {
 				return fmt.Errorf("pipeline %q references processor %q which does not exist", pipelineID, ref)
 			}
+			if err := validateDependencies("processor", ref, cfg.Processors[ref], enabled); err != nil {
+				return err
+			}
 		}
 
 		// Validate pipeline has at least one exporter.
@@ -145,6 +172,24 @@ func (cfg *Config) validateService() error {
 			if cfg.Exporters[ref] == nil {
 				return fmt.Errorf("pipeline %q references exporter %q which does not exist", pipelineID, ref)
 			}
+			if err := validateDependencies("exporter", ref, cfg.Exporters[ref], enabled); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateDependencies checks that the extensions the component depends on, if its configuration implements
+// ExtensionDependent, are enabled in the service.
+func validateDependencies(kind string, id ComponentID, cfg interface{}, enabled map[ComponentID]bool) error {
+	dependent, ok := cfg.(ExtensionDependent)
+	if !ok {
+		return nil
+	}
+	for _, dep := range dependent.ExtensionDependencies() {
+		if !enabled[dep] {
+			return fmt.Errorf("%s %q depends on extension %q which is not enabled in the service", kind, id, dep)
 		}
 	}
 	return nil
@@ -161,6 +206,12 @@ type Service struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines map[ComponentID]*Pipeline `mapstructure:"pipelines"`
+
+	// Watchdog is the configuration of the watchdog restarting the wedged components of the pipelines.
+	Watchdog watchdog.Config `mapstructure:"watchdog"`
+
+	// Recovery is the configuration of the recovery from the panics of the components of the pipelines.
+	Recovery recovery.Config `mapstructure:"recovery"`
 }
 
 // Pipeline defines a single pipeline.
@@ -169,6 +220,10 @@ type Pipeline struct {
 	Receivers  []ComponentID `mapstructure:"receivers"`
 	Processors []ComponentID `mapstructure:"processors"`
 	Exporters  []ComponentID `mapstructure:"exporters"`
+
+	// Defaults are the default sending_queue, retry_on_failure and timeout settings of the exporters of the
+	// pipeline supporting them, overridden by the settings of the exporters.
+	Defaults map[string]interface{} `mapstructure:"defaults"`
 }
 
 // Deprecated: [v0.52.0] will be removed soon.
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationconverter // import "go.opentelemetry.io/collector/confmap/converter/migrationconverter"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// VersionKey is the top-level configuration key holding the version of the configuration structure.
+// The configurations without it are considered to be at version 0.
+const VersionKey = "config_version"
+
+// Change transforms the configuration, given as a map, and returns a description of every
+// transformation applied, logged as warnings so the users update their configuration.
+type Change func(conf map[string]interface{}) ([]string, error)
+
+// Migration transforms the configuration from the previous version to Version.
+type Migration struct {
+	// Version is the version of the configuration structure after the migration.
+	Version int
+	// Changes are applied in the given order.
+	Changes []Change
+}
+
+type converter struct {
+	logger     *zap.Logger
+	migrations []Migration
+}
+
+// New returns a confmap.Converter, that applies to the configuration the migrations with a version greater than
+// the VersionKey of the configuration, in the order of their versions, then removes the VersionKey.
+//
+// Notice: This API is experimental.
+func New(logger *zap.Logger, migrations []Migration) confmap.Converter {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &converter{logger: logger, migrations: sorted}
+}
+
+func (c *converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	version, err := configVersion(conf.Get(VersionKey))
+	if err != nil {
+		return err
+	}
+	if latest := c.latestVersion(); version > latest {
+		return fmt.Errorf("%s %d is newer than the latest supported version %d", VersionKey, version, latest)
+	}
+
+	out := conf.ToStringMap()
+	delete(out, VersionKey)
+	for _, m := range c.migrations {
+		if m.Version <= version {
+			continue
+		}
+		for _, change := range m.Changes {
+			warnings, err := change(out)
+			if err != nil {
+				return fmt.Errorf("failed to migrate the configuration to version %d: %w", m.Version, err)
+			}
+			for _, w := range warnings {
+				c.logger.Warn("Deprecated configuration migrated, update the configuration",
+					zap.Int("version", m.Version), zap.String("change", w))
+			}
+		}
+	}
+	*conf = *confmap.NewFromStringMap(out)
+	return nil
+}
+
+func (c *converter) latestVersion() int {
+	if len(c.migrations) == 0 {
+		return 0
+	}
+	return c.migrations[len(c.migrations)-1].Version
+}
+
+func configVersion(v interface{}) (int, error) {
+	switch version := v.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return version, nil
+	case string:
+		if n, err := strconv.Atoi(version); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%s must be an integer, got %v", VersionKey, v)
+}
+
+// RenameKey returns a Change moving the value of the key "from" to the key "to", both in the confmap.KeyDelimiter
+// notation, e.g. "exporters::otlp/*::compression_level" to "exporters::otlp/*::compression::level". A "*" element
+// matches any key, and a "<type>/*" element matches the component IDs of the given type. The wildcards of "to" in
+// the positions of the wildcards of "from" are replaced by the matched keys. Renaming a key to a key already set
+// is an error.
+func RenameKey(from, to string) Change {
+	fromPath := strings.Split(from, confmap.KeyDelimiter)
+	toPath := strings.Split(to, confmap.KeyDelimiter)
+	return func(conf map[string]interface{}) ([]string, error) {
+		var warnings []string
+		for _, matched := range matchPath(conf, fromPath, nil) {
+			target := make([]string, len(toPath))
+			copy(target, toPath)
+			for i := range target {
+				if i < len(fromPath) && isWildcard(fromPath[i]) && isWildcard(target[i]) {
+					target[i] = matched[i]
+				}
+			}
+			source := strings.Join(matched, confmap.KeyDelimiter)
+			dest := strings.Join(target, confmap.KeyDelimiter)
+			if _, ok := lookup(conf, target); ok {
+				return nil, fmt.Errorf("cannot rename %q to %q, %q is already set", source, dest, dest)
+			}
+			value := remove(conf, matched)
+			if err := set(conf, target, value); err != nil {
+				return nil, fmt.Errorf("cannot rename %q to %q: %w", source, dest, err)
+			}
+			warnings = append(warnings, fmt.Sprintf("%q is renamed to %q", source, dest))
+		}
+		return warnings, nil
+	}
+}
+
+func isWildcard(elem string) bool {
+	return elem == "*" || strings.HasSuffix(elem, "/*")
+}
+
+func matchElem(pattern, key string) bool {
+	switch {
+	case pattern == "*":
+		return true
+	case strings.HasSuffix(pattern, "/*"):
+		typ := strings.TrimSuffix(pattern, "/*")
+		return key == typ || strings.HasPrefix(key, typ+"/")
+	default:
+		return key == pattern
+	}
+}
+
+// matchPath returns the paths of the set keys of conf matching the pattern, sorted for a deterministic order.
+func matchPath(conf map[string]interface{}, pattern []string, prefix []string) [][]string {
+	keys := make([]string, 0, len(conf))
+	for k := range conf {
+		if matchElem(pattern[0], k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var matches [][]string
+	for _, k := range keys {
+		path := append(append([]string{}, prefix...), k)
+		if len(pattern) == 1 {
+			matches = append(matches, path)
+			continue
+		}
+		if sub, ok := conf[k].(map[string]interface{}); ok {
+			matches = append(matches, matchPath(sub, pattern[1:], path)...)
+		}
+	}
+	return matches
+}
+
+func lookup(conf map[string]interface{}, path []string) (interface{}, bool) {
+	for _, k := range path[:len(path)-1] {
+		sub, ok := conf[k].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		conf = sub
+	}
+	v, ok := conf[path[len(path)-1]]
+	return v, ok
+}
+
+func remove(conf map[string]interface{}, path []string) interface{} {
+	for _, k := range path[:len(path)-1] {
+		conf = conf[k].(map[string]interface{})
+	}
+	v := conf[path[len(path)-1]]
+	delete(conf, path[len(path)-1])
+	return v
+}
+
+func set(conf map[string]interface{}, path []string, value interface{}) error {
+	for i, k := range path[:len(path)-1] {
+		switch sub := conf[k].(type) {
+		case map[string]interface{}:
+			conf = sub
+		case nil:
+			m := map[string]interface{}{}
+			conf[k] = m
+			conf = m
+		default:
+			return fmt.Errorf("%q is not a map", strings.Join(path[:i+1], confmap.KeyDelimiter))
+		}
+	}
+	conf[path[len(path)-1]] = value
+	return nil
+}
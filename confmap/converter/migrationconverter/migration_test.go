@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrationconverter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+var testMigrations = []Migration{
+	{
+		Version: 2,
+		Changes: []Change{RenameKey("service::old_telemetry", "service::telemetry")},
+	},
+	{
+		Version: 1,
+		Changes: []Change{RenameKey("exporters::otlp/*::compression_level", "exporters::otlp/*::compression::level")},
+	},
+}
+
+func TestConvert(t *testing.T) {
+	conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	expected, err := confmaptest.LoadConf(filepath.Join("testdata", "expected.yaml"))
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.WarnLevel)
+	require.NoError(t, New(zap.New(core), testMigrations).Convert(context.Background(), conf))
+	assert.Equal(t, expected.ToStringMap(), conf.ToStringMap())
+
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, int64(1), logs.All()[0].ContextMap()["version"])
+	assert.Equal(t, `"exporters::otlp::compression_level" is renamed to "exporters::otlp::compression::level"`, logs.All()[0].ContextMap()["change"])
+	assert.Equal(t, int64(2), logs.All()[1].ContextMap()["version"])
+}
+
+func TestConvertSkipsAppliedMigrations(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		VersionKey: "1",
+		"exporters": map[string]interface{}{
+			"otlp": map[string]interface{}{"compression_level": 5},
+		},
+		"service": map[string]interface{}{
+			"old_telemetry": map[string]interface{}{"metrics": "none"},
+		},
+	})
+	require.NoError(t, New(nil, testMigrations).Convert(context.Background(), conf))
+	assert.Equal(t, map[string]interface{}{
+		"exporters": map[string]interface{}{
+			"otlp": map[string]interface{}{"compression_level": 5},
+		},
+		"service": map[string]interface{}{
+			"telemetry": map[string]interface{}{"metrics": "none"},
+		},
+	}, conf.ToStringMap())
+}
+
+func TestConvertErrors(t *testing.T) {
+	var testCases = []struct {
+		name string
+		conf map[string]interface{}
+	}{
+		{
+			name: "newer_version",
+			conf: map[string]interface{}{VersionKey: 3},
+		},
+		{
+			name: "invalid_version",
+			conf: map[string]interface{}{VersionKey: "v1"},
+		},
+		{
+			name: "already_set",
+			conf: map[string]interface{}{
+				"service": map[string]interface{}{
+					"old_telemetry": map[string]interface{}{},
+					"telemetry":     map[string]interface{}{},
+				},
+			},
+		},
+		{
+			name: "not_a_map",
+			conf: map[string]interface{}{
+				"exporters": map[string]interface{}{
+					"otlp": map[string]interface{}{"compression_level": 5, "compression": "gzip"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(tt.conf)
+			assert.Error(t, New(nil, testMigrations).Convert(context.Background(), conf))
+		})
+	}
+}
+
+func TestConvertNoMigrations(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		VersionKey:  0,
+		"receivers": map[string]interface{}{"nop": nil},
+	})
+	require.NoError(t, New(nil, nil).Convert(context.Background(), conf))
+	assert.Equal(t, map[string]interface{}{"receivers": map[string]interface{}{"nop": nil}}, conf.ToStringMap())
+}
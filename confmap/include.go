@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"context"
+	"fmt"
+)
+
+// IncludeKey is the key of the maps of a configuration including the configurations retrieved from a URI,
+// or from a list of URIs merged in the given order, e.g. `$include: file:pipelines/team-a.yaml`.
+// The other keys of the map are merged over the included configurations.
+const IncludeKey = "$include"
+
+// resolveIncludes returns the value with the includes of its maps resolved recursively.
+func (mr *Resolver) resolveIncludes(ctx context.Context, value interface{}, stack []string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			if key == IncludeKey {
+				continue
+			}
+			resolved, err := mr.resolveIncludes(ctx, val, stack)
+			if err != nil {
+				return nil, err
+			}
+			ret[key] = resolved
+		}
+		include, ok := v[IncludeKey]
+		if !ok {
+			return ret, nil
+		}
+		uris, err := includeURIs(include)
+		if err != nil {
+			return nil, err
+		}
+		included := New()
+		for _, uri := range uris {
+			conf, err := mr.retrieve(ctx, uri, stack)
+			if err != nil {
+				return nil, err
+			}
+			if err = included.Merge(conf); err != nil {
+				return nil, err
+			}
+		}
+		if err = included.Merge(NewFromStringMap(ret)); err != nil {
+			return nil, err
+		}
+		return included.ToStringMap(), nil
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, val := range v {
+			resolved, err := mr.resolveIncludes(ctx, val, stack)
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = resolved
+		}
+		return ret, nil
+	default:
+		return value, nil
+	}
+}
+
+// includeURIs returns the URIs of the value of an IncludeKey, a URI or a list of URIs.
+func includeURIs(include interface{}) ([]string, error) {
+	switch v := include.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		uris := make([]string, 0, len(v))
+		for _, uri := range v {
+			s, ok := uri.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %q value %v: expected a URI or a list of URIs", IncludeKey, include)
+			}
+			uris = append(uris, s)
+		}
+		return uris, nil
+	default:
+		return nil, fmt.Errorf("invalid %q value %v: expected a URI or a list of URIs", IncludeKey, include)
+	}
+}
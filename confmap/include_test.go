@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confmap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMapProvider(confs map[string]map[string]interface{}) Provider {
+	return newFakeProvider("map", func(_ context.Context, uri string, _ WatcherFunc) (Retrieved, error) {
+		conf, ok := confs[uri]
+		if !ok {
+			return Retrieved{}, fmt.Errorf("unknown uri %q", uri)
+		}
+		return NewRetrieved(conf)
+	})
+}
+
+func TestResolverIncludes(t *testing.T) {
+	provider := newMapProvider(map[string]map[string]interface{}{
+		"map:main": {
+			"receivers": map[string]interface{}{"otlp": nil},
+			"service": map[string]interface{}{
+				"pipelines": map[string]interface{}{
+					IncludeKey: []interface{}{"map:team-a", "map:team-b"},
+					"logs":     map[string]interface{}{"receivers": []interface{}{"otlp"}},
+				},
+			},
+		},
+		"map:team-a": {
+			"traces": map[string]interface{}{
+				IncludeKey:  "map:defaults",
+				"receivers": []interface{}{"otlp"},
+			},
+		},
+		"map:team-b": {
+			"metrics": map[string]interface{}{"receivers": []interface{}{"otlp"}, "exporters": []interface{}{"logging"}},
+		},
+		"map:defaults": {
+			"processors": []interface{}{"batch"},
+			"exporters":  []interface{}{"otlp"},
+		},
+	})
+	resolver, err := NewResolver(ResolverSettings{URIs: []string{"map:main"}, Providers: makeMapProvidersMap(provider)})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"receivers": map[string]interface{}{"otlp": nil},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers":  []interface{}{"otlp"},
+					"processors": []interface{}{"batch"},
+					"exporters":  []interface{}{"otlp"},
+				},
+				"metrics": map[string]interface{}{"receivers": []interface{}{"otlp"}, "exporters": []interface{}{"logging"}},
+				"logs":    map[string]interface{}{"receivers": []interface{}{"otlp"}},
+			},
+		},
+	}, conf.ToStringMap())
+	assert.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestResolverIncludeOverride(t *testing.T) {
+	provider := newMapProvider(map[string]map[string]interface{}{
+		"map:main": {
+			"exporters": map[string]interface{}{
+				IncludeKey: "map:exporters",
+				"otlp":     map[string]interface{}{"endpoint": "localhost:4317"},
+			},
+		},
+		"map:exporters": {
+			"otlp": map[string]interface{}{"endpoint": "backend:4317", "compression": "gzip"},
+		},
+	})
+	resolver, err := NewResolver(ResolverSettings{URIs: []string{"map:main"}, Providers: makeMapProvidersMap(provider)})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"endpoint": "localhost:4317", "compression": "gzip"}, conf.Get("exporters::otlp"))
+}
+
+func TestResolverIncludeErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		confs       map[string]map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name: "cycle",
+			confs: map[string]map[string]interface{}{
+				"map:main": {"receivers": map[string]interface{}{IncludeKey: "map:a"}},
+				"map:a":    {"otlp": map[string]interface{}{IncludeKey: "map:b"}},
+				"map:b":    {IncludeKey: "map:a"},
+			},
+			expectedErr: "include cycle: map:main -> map:a -> map:b -> map:a",
+		},
+		{
+			name: "self",
+			confs: map[string]map[string]interface{}{
+				"map:main": {IncludeKey: "map:main"},
+			},
+			expectedErr: "include cycle: map:main -> map:main",
+		},
+		{
+			name: "unknown_uri",
+			confs: map[string]map[string]interface{}{
+				"map:main": {IncludeKey: "map:unknown"},
+			},
+			expectedErr: `unknown uri "map:unknown"`,
+		},
+		{
+			name: "unsupported_scheme",
+			confs: map[string]map[string]interface{}{
+				"map:main": {IncludeKey: "env:INCLUDE"},
+			},
+			expectedErr: `scheme "env" is not supported for uri "env:INCLUDE"`,
+		},
+		{
+			name: "invalid_value",
+			confs: map[string]map[string]interface{}{
+				"map:main": {IncludeKey: []interface{}{"map:a", 1}},
+			},
+			expectedErr: `invalid "$include" value [map:a 1]: expected a URI or a list of URIs`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, err := NewResolver(ResolverSettings{URIs: []string{"map:main"}, Providers: makeMapProvidersMap(newMapProvider(tt.confs))})
+			require.NoError(t, err)
+			_, err = resolver.Resolve(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestResolverIncludeSameURITwice(t *testing.T) {
+	provider := newMapProvider(map[string]map[string]interface{}{
+		"map:main": {
+			"processors": map[string]interface{}{IncludeKey: "map:batch"},
+			"extensions": map[string]interface{}{IncludeKey: "map:batch"},
+		},
+		"map:batch": {"batch": nil},
+	})
+	resolver, err := NewResolver(ResolverSettings{URIs: []string{"map:main"}, Providers: makeMapProvidersMap(provider)})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"batch": nil}, conf.Get("processors"))
+	assert.Equal(t, map[string]interface{}{"batch": nil}, conf.Get("extensions"))
+}
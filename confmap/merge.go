@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confmap // import "go.opentelemetry.io/collector/confmap"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/knadh/koanf/maps"
+)
+
+// ListMergeStrategy defines how a list of a Conf is merged with the list of the same key of the merged Conf.
+type ListMergeStrategy string
+
+const (
+	// ListMergeReplace replaces the list with the merged list, the default strategy.
+	ListMergeReplace ListMergeStrategy = "replace"
+	// ListMergeAppend appends the elements of the merged list that are not already in the list,
+	// e.g. to add receivers to a pipeline.
+	ListMergeAppend ListMergeStrategy = "append"
+
+	mergeByKeyPrefix = "merge_by_key:"
+)
+
+// ListMergeByKey returns the ListMergeStrategy merging the maps of the lists having the same value for the key,
+// the merged map taking precedence, and appending the other elements of the merged list. Its text form is
+// "merge_by_key:<key>".
+func ListMergeByKey(key string) ListMergeStrategy {
+	return ListMergeStrategy(mergeByKeyPrefix + key)
+}
+
+// Validate checks that the ListMergeStrategy is known.
+func (s ListMergeStrategy) Validate() error {
+	switch {
+	case s == ListMergeReplace, s == ListMergeAppend:
+		return nil
+	case strings.HasPrefix(string(s), mergeByKeyPrefix) && len(s) > len(mergeByKeyPrefix):
+		return nil
+	}
+	return fmt.Errorf("unknown list merge strategy %q, must be %q, %q or %q", s, ListMergeReplace, ListMergeAppend, mergeByKeyPrefix+"<key>")
+}
+
+// MergeWithListStrategies merges the input given configuration into the existing config like Merge, merging
+// the lists with the ListMergeStrategy of their key instead of replacing them. The strategies are keyed by
+// the keys of the lists, "*" matching any key between two KeyDelimiter, e.g. "service::pipelines::*::receivers",
+// the most specific pattern matching a key being used.
+// Note that the given map may be modified.
+func (l *Conf) MergeWithListStrategies(in *Conf, strategies map[string]ListMergeStrategy) error {
+	if len(strategies) == 0 {
+		return l.Merge(in)
+	}
+	for pattern, strategy := range strategies {
+		if err := strategy.Validate(); err != nil {
+			return fmt.Errorf("invalid list merge strategy for %q: %w", pattern, err)
+		}
+	}
+
+	flat := in.k.All()
+	merged := false
+	for key, val := range flat {
+		list, ok := toList(val)
+		if !ok {
+			continue
+		}
+		strategy := listMergeStrategy(strategies, key)
+		if strategy == ListMergeReplace {
+			continue
+		}
+		prev, ok := toList(l.k.Get(key))
+		if !ok {
+			continue
+		}
+		flat[key] = mergeLists(prev, list, strategy)
+		merged = true
+	}
+	if !merged {
+		return l.Merge(in)
+	}
+	return l.Merge(NewFromStringMap(maps.Unflatten(flat, KeyDelimiter)))
+}
+
+// listMergeStrategy returns the strategy of the pattern matching the key with the fewest "*", the first
+// one in the lexical order for the patterns with as many "*", or ListMergeReplace.
+func listMergeStrategy(strategies map[string]ListMergeStrategy, key string) ListMergeStrategy {
+	if strategy, ok := strategies[key]; ok {
+		return strategy
+	}
+	keyParts := strings.Split(key, KeyDelimiter)
+	var match string
+	matchWildcards := 0
+	for pattern := range strategies {
+		patternParts := strings.Split(pattern, KeyDelimiter)
+		if !keyMatches(patternParts, keyParts) {
+			continue
+		}
+		wildcards := 0
+		for _, part := range patternParts {
+			if part == "*" {
+				wildcards++
+			}
+		}
+		if match == "" || wildcards < matchWildcards || (wildcards == matchWildcards && pattern < match) {
+			match, matchWildcards = pattern, wildcards
+		}
+	}
+	if match == "" {
+		return ListMergeReplace
+	}
+	return strategies[match]
+}
+
+func keyMatches(pattern, key []string) bool {
+	if len(pattern) != len(key) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != key[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// toList returns the elements of the value if it is a slice.
+func toList(val interface{}) ([]interface{}, bool) {
+	if list, ok := val.([]interface{}); ok {
+		return list, true
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, true
+}
+
+func mergeLists(prev, list []interface{}, strategy ListMergeStrategy) []interface{} {
+	ret := append([]interface{}(nil), prev...)
+	key := strings.TrimPrefix(string(strategy), mergeByKeyPrefix)
+	for _, elem := range list {
+		if strategy == ListMergeAppend {
+			if !containsElement(ret, elem) {
+				ret = append(ret, elem)
+			}
+			continue
+		}
+		if i := indexByKey(ret, key, elem); i >= 0 {
+			m := maps.Copy(ret[i].(map[string]interface{}))
+			maps.Merge(maps.Copy(elem.(map[string]interface{})), m)
+			ret[i] = m
+			continue
+		}
+		ret = append(ret, elem)
+	}
+	return ret
+}
+
+func containsElement(list []interface{}, elem interface{}) bool {
+	for _, e := range list {
+		if reflect.DeepEqual(e, elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexByKey returns the index of the map of the list with the same value for the key as the element,
+// or -1 if the element is not a map with the key or no map matches it.
+func indexByKey(list []interface{}, key string, elem interface{}) int {
+	em, ok := elem.(map[string]interface{})
+	if !ok {
+		return -1
+	}
+	val, ok := em[key]
+	if !ok {
+		return -1
+	}
+	for i, e := range list {
+		if m, ok := e.(map[string]interface{}); ok {
+			if v, ok := m[key]; ok && reflect.DeepEqual(v, val) {
+				return i
+			}
+		}
+	}
+	return -1
+}
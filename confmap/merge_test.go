@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeWithListStrategies(t *testing.T) {
+	base := map[string]interface{}{
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []interface{}{"otlp"},
+					"exporters": []interface{}{"otlp"},
+				},
+			},
+		},
+		"processors": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"actions": []interface{}{
+					map[string]interface{}{"key": "env", "value": "dev", "action": "insert"},
+					map[string]interface{}{"key": "team", "value": "core", "action": "insert"},
+				},
+			},
+		},
+	}
+	overlay := map[string]interface{}{
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []interface{}{"jaeger", "otlp"},
+					"exporters": []interface{}{"logging"},
+				},
+			},
+		},
+		"processors": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"actions": []interface{}{
+					map[string]interface{}{"key": "env", "value": "prod"},
+					map[string]interface{}{"key": "region", "value": "eu", "action": "insert"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		strategies map[string]ListMergeStrategy
+		receivers  []interface{}
+		exporters  []interface{}
+		actions    []interface{}
+	}{
+		{
+			name:      "replace",
+			receivers: []interface{}{"jaeger", "otlp"},
+			exporters: []interface{}{"logging"},
+			actions: []interface{}{
+				map[string]interface{}{"key": "env", "value": "prod"},
+				map[string]interface{}{"key": "region", "value": "eu", "action": "insert"},
+			},
+		},
+		{
+			name: "append",
+			strategies: map[string]ListMergeStrategy{
+				"service::pipelines::*::receivers": ListMergeAppend,
+			},
+			receivers: []interface{}{"otlp", "jaeger"},
+			exporters: []interface{}{"logging"},
+			actions: []interface{}{
+				map[string]interface{}{"key": "env", "value": "prod"},
+				map[string]interface{}{"key": "region", "value": "eu", "action": "insert"},
+			},
+		},
+		{
+			name: "append_all_pipeline_lists",
+			strategies: map[string]ListMergeStrategy{
+				"service::pipelines::*::exporters": ListMergeReplace,
+				"service::pipelines::*::*":         ListMergeAppend,
+			},
+			receivers: []interface{}{"otlp", "jaeger"},
+			exporters: []interface{}{"logging"},
+			actions: []interface{}{
+				map[string]interface{}{"key": "env", "value": "prod"},
+				map[string]interface{}{"key": "region", "value": "eu", "action": "insert"},
+			},
+		},
+		{
+			name: "merge_by_key",
+			strategies: map[string]ListMergeStrategy{
+				"processors::attributes::actions": ListMergeByKey("key"),
+			},
+			receivers: []interface{}{"jaeger", "otlp"},
+			exporters: []interface{}{"logging"},
+			actions: []interface{}{
+				map[string]interface{}{"key": "env", "value": "prod", "action": "insert"},
+				map[string]interface{}{"key": "team", "value": "core", "action": "insert"},
+				map[string]interface{}{"key": "region", "value": "eu", "action": "insert"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := NewFromStringMap(base)
+			require.NoError(t, conf.MergeWithListStrategies(NewFromStringMap(overlay), tt.strategies))
+			assert.Equal(t, tt.receivers, conf.Get("service::pipelines::traces::receivers"))
+			assert.Equal(t, tt.exporters, conf.Get("service::pipelines::traces::exporters"))
+			assert.Equal(t, tt.actions, conf.Get("processors::attributes::actions"))
+		})
+	}
+}
+
+func TestMergeWithListStrategiesNewList(t *testing.T) {
+	conf := NewFromStringMap(map[string]interface{}{"receivers": map[string]interface{}{"otlp": nil}})
+	require.NoError(t, conf.MergeWithListStrategies(
+		NewFromStringMap(map[string]interface{}{"exporters": []interface{}{"otlp"}}),
+		map[string]ListMergeStrategy{"exporters": ListMergeAppend}))
+	assert.Equal(t, []interface{}{"otlp"}, conf.Get("exporters"))
+	assert.True(t, conf.IsSet("receivers::otlp"))
+}
+
+func TestMergeWithListStrategiesInvalid(t *testing.T) {
+	conf := New()
+	assert.Error(t, conf.MergeWithListStrategies(New(), map[string]ListMergeStrategy{"exporters": "prepend"}))
+	assert.Error(t, conf.MergeWithListStrategies(New(), map[string]ListMergeStrategy{"exporters": ListMergeByKey("")}))
+}
+
+func TestListMergeStrategyValidate(t *testing.T) {
+	assert.NoError(t, ListMergeReplace.Validate())
+	assert.NoError(t, ListMergeAppend.Validate())
+	assert.NoError(t, ListMergeByKey("name").Validate())
+	assert.NoError(t, ListMergeStrategy("merge_by_key:name").Validate())
+	assert.Error(t, ListMergeStrategy("").Validate())
+	assert.Error(t, ListMergeStrategy("merge_by_key:").Validate())
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sprovider // import "go.opentelemetry.io/collector/confmap/provider/k8sprovider"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	minResyncInterval = time.Second
+	maxResyncInterval = 30 * time.Second
+)
+
+var errNotFound = errors.New("not found")
+
+// configMap is the subset of the Kubernetes ConfigMap used by the provider.
+type configMap struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+}
+
+// status is the subset of the Kubernetes Status returned by the API server on failures.
+type status struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// watchEvent is an event of a watch, the object being a configMap, or a status on ERROR events.
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// client accesses the ConfigMaps through the Kubernetes API server.
+type client struct {
+	cfg        *restConfig
+	httpClient *http.Client
+	// resyncInterval is the initial interval between the retries of a failed watch.
+	resyncInterval time.Duration
+}
+
+func newClient(cfg *restConfig) *client {
+	return &client{
+		cfg: cfg,
+		// No timeout since the watches are long-lived requests, the requests are bound by their context.
+		httpClient:     &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: cfg.tlsConfig}},
+		resyncInterval: minResyncInterval,
+	}
+}
+
+func (c *client) do(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	u := c.cfg.host + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	token, err := c.cfg.bearerToken()
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	var st status
+	if json.NewDecoder(resp.Body).Decode(&st) != nil || st.Message == "" {
+		st.Message = resp.Status
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", st.Message, errNotFound)
+	}
+	return nil, errors.New(st.Message)
+}
+
+// getConfigMap returns the ConfigMap, an error wrapping errNotFound if it does not exist.
+func (c *client) getConfigMap(ctx context.Context, ref configMapRef) (*configMap, error) {
+	resp, err := c.do(ctx, ref.path(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the ConfigMap %s/%s: %w", ref.namespace, ref.name, err)
+	}
+	defer resp.Body.Close()
+	cm := &configMap{}
+	if err = json.NewDecoder(resp.Body).Decode(cm); err != nil {
+		return nil, fmt.Errorf("unable to decode the ConfigMap %s/%s: %w", ref.namespace, ref.name, err)
+	}
+	return cm, nil
+}
+
+// watch calls the watcher once the value of the key of the ConfigMap differs from data, the ConfigMap
+// being watched from the given resource version. The ConfigMap or the key being deleted is a change,
+// retrieving the configuration again reporting the error. The failed watches are retried after resyncing the
+// ConfigMap, so that the failures of the API server do not stop the collector. Returns once ctx is done.
+func (c *client) watch(ctx context.Context, ref configMapRef, data string, resourceVersion string, watcher confmap.WatcherFunc) {
+	interval := c.resyncInterval
+	for {
+		changed, rv, err := c.watchOnce(ctx, ref, data, resourceVersion)
+		if ctx.Err() != nil {
+			return
+		}
+		if changed {
+			watcher(&confmap.ChangeEvent{})
+			return
+		}
+		if err == nil {
+			// The API server closed the watch, continue from the last seen resource version.
+			resourceVersion = rv
+			interval = c.resyncInterval
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > maxResyncInterval {
+			interval = maxResyncInterval
+		}
+
+		// Resync since the events missed during the failure may not be watchable anymore.
+		cm, err := c.getConfigMap(ctx, ref)
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case errors.Is(err, errNotFound):
+			watcher(&confmap.ChangeEvent{})
+			return
+		case err != nil:
+			continue
+		}
+		if value, ok := cm.Data[ref.key]; !ok || value != data {
+			watcher(&confmap.ChangeEvent{})
+			return
+		}
+		resourceVersion = cm.Metadata.ResourceVersion
+	}
+}
+
+// watchOnce watches the ConfigMap until its key changes, returning true, or the API server closes the watch,
+// returning the last seen resource version.
+func (c *client) watchOnce(ctx context.Context, ref configMapRef, data string, resourceVersion string) (bool, string, error) {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("fieldSelector", "metadata.name="+ref.name)
+	query.Set("resourceVersion", resourceVersion)
+	query.Set("allowWatchBookmarks", "true")
+	resp, err := c.do(ctx, ref.listPath(), query)
+	if err != nil {
+		return false, resourceVersion, err
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var event watchEvent
+		if err = dec.Decode(&event); err != nil {
+			if errors.Is(err, io.EOF) {
+				return false, resourceVersion, nil
+			}
+			return false, resourceVersion, err
+		}
+		switch event.Type {
+		case "ERROR":
+			var st status
+			if err = json.Unmarshal(event.Object, &st); err != nil || st.Message == "" {
+				return false, resourceVersion, errors.New("watch failed")
+			}
+			return false, resourceVersion, errors.New(st.Message)
+		case "DELETED":
+			return true, resourceVersion, nil
+		}
+		var cm configMap
+		if err = json.Unmarshal(event.Object, &cm); err != nil {
+			return false, resourceVersion, err
+		}
+		resourceVersion = cm.Metadata.ResourceVersion
+		if event.Type == "BOOKMARK" {
+			continue
+		}
+		if value, ok := cm.Data[ref.key]; !ok || value != data {
+			return true, resourceVersion, nil
+		}
+	}
+}
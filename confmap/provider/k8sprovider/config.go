@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sprovider // import "go.opentelemetry.io/collector/confmap/provider/k8sprovider"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// serviceAccountDir is the directory where the service account credentials are mounted in the pods.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// restConfig is the configuration to access the Kubernetes API server.
+type restConfig struct {
+	// host is the URL of the API server, e.g. "https://10.0.0.1:443".
+	host string
+	// token is the bearer token, or tokenFile the file it is read from before each request,
+	// the service account tokens being rotated.
+	token     string
+	tokenFile string
+	tlsConfig *tls.Config
+}
+
+// bearerToken returns the bearer token authenticating the requests, empty if none.
+func (c *restConfig) bearerToken() (string, error) {
+	if c.tokenFile == "" {
+		return c.token, nil
+	}
+	token, err := ioutil.ReadFile(filepath.Clean(c.tokenFile))
+	if err != nil {
+		return "", fmt.Errorf("unable to read the token file: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// loadRESTConfig returns the in-cluster configuration when running in a pod,
+// the configuration of the current context of the kubeconfig file otherwise.
+func loadRESTConfig() (*restConfig, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host != "" && port != "" {
+		return inClusterConfig(host, port, serviceAccountDir)
+	}
+	path, err := kubeconfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return kubeconfigRESTConfig(path)
+}
+
+// inClusterConfig returns the configuration of the service account mounted in saDir.
+func inClusterConfig(host, port, saDir string) (*restConfig, error) {
+	tokenFile := filepath.Join(saDir, "token")
+	if _, err := os.Stat(tokenFile); err != nil {
+		return nil, fmt.Errorf("unable to load the in-cluster configuration: %w", err)
+	}
+	caPEM, err := ioutil.ReadFile(filepath.Join(saDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the in-cluster configuration: %w", err)
+	}
+	tlsCfg, err := newTLSConfig(caPEM, nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	return &restConfig{
+		host:      "https://" + net.JoinHostPort(host, port),
+		tokenFile: tokenFile,
+		tlsConfig: tlsCfg,
+	}, nil
+}
+
+// kubeconfigPath returns the first file of the KUBECONFIG environment variable, ~/.kube/config by default.
+func kubeconfigPath() (string, error) {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) != 0 && paths[0] != "" {
+		return paths[0], nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the kubeconfig file: %w", err)
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// kubeconfig is the subset of the kubeconfig file format used to access the API server.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string      `yaml:"token"`
+			TokenFile             string      `yaml:"tokenFile"`
+			ClientCertificate     string      `yaml:"client-certificate"`
+			ClientCertificateData string      `yaml:"client-certificate-data"`
+			ClientKey             string      `yaml:"client-key"`
+			ClientKeyData         string      `yaml:"client-key-data"`
+			Exec                  interface{} `yaml:"exec"`
+			AuthProvider          interface{} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// kubeconfigRESTConfig returns the configuration of the current context of the kubeconfig file.
+// The relative paths of the file are relative to its directory.
+func kubeconfigRESTConfig(path string) (*restConfig, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the kubeconfig file: %w", err)
+	}
+	var kc kubeconfig
+	if err = yaml.Unmarshal(content, &kc); err != nil {
+		return nil, fmt.Errorf("unable to parse the kubeconfig file %v: %w", path, err)
+	}
+
+	var clusterName, userName string
+	found := false
+	for _, c := range kc.Contexts {
+		if c.Name == kc.CurrentContext {
+			clusterName, userName, found = c.Context.Cluster, c.Context.User, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("current context %q not found in the kubeconfig file %v", kc.CurrentContext, path)
+	}
+
+	dir := filepath.Dir(path)
+	cfg := &restConfig{}
+	var caPEM, certPEM, keyPEM []byte
+	insecure := false
+	found = false
+	for _, c := range kc.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		found = true
+		cfg.host = strings.TrimSuffix(c.Cluster.Server, "/")
+		insecure = c.Cluster.InsecureSkipTLSVerify
+		if caPEM, err = fileOrData(dir, c.Cluster.CertificateAuthority, c.Cluster.CertificateAuthorityData); err != nil {
+			return nil, err
+		}
+		break
+	}
+	if !found || cfg.host == "" {
+		return nil, fmt.Errorf("server of the cluster %q not found in the kubeconfig file %v", clusterName, path)
+	}
+
+	for _, u := range kc.Users {
+		if u.Name != userName {
+			continue
+		}
+		if u.User.Exec != nil || u.User.AuthProvider != nil {
+			return nil, fmt.Errorf("user %q of the kubeconfig file %v: exec and auth-provider credentials are not supported", userName, path)
+		}
+		cfg.token = u.User.Token
+		if u.User.TokenFile != "" {
+			cfg.tokenFile = resolvePath(dir, u.User.TokenFile)
+		}
+		if certPEM, err = fileOrData(dir, u.User.ClientCertificate, u.User.ClientCertificateData); err != nil {
+			return nil, err
+		}
+		if keyPEM, err = fileOrData(dir, u.User.ClientKey, u.User.ClientKeyData); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	if cfg.tlsConfig, err = newTLSConfig(caPEM, certPEM, keyPEM, insecure); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// fileOrData returns the content of the file if set, the base64 decoded data otherwise.
+func fileOrData(dir, file, data string) ([]byte, error) {
+	if file != "" {
+		content, err := ioutil.ReadFile(filepath.Clean(resolvePath(dir, file)))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the kubeconfig file %v: %w", file, err)
+		}
+		return content, nil
+	}
+	if data == "" {
+		return nil, nil
+	}
+	content, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 data in the kubeconfig file: %w", err)
+	}
+	return content, nil
+}
+
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// newTLSConfig returns the TLS configuration verifying the server with the CA if set, the system pool otherwise,
+// and authenticating with the client certificate if set.
+func newTLSConfig(caPEM, certPEM, keyPEM []byte, insecure bool) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The verification of the server is disabled on purpose, when so configured in the kubeconfig file.
+		InsecureSkipVerify: insecure, // #nosec G402
+	}
+	if len(caPEM) != 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("failed to load the certificate authority of the API server")
+		}
+		tlsCfg.RootCAs = pool
+	}
+	if len(certPEM) != 0 || len(keyPEM) != 0 {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sprovider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeconfigRESTConfig(t *testing.T) {
+	cfg, err := kubeconfigRESTConfig(filepath.Join("testdata", "kubeconfig.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com:6443", cfg.host)
+	token, err := cfg.bearerToken()
+	require.NoError(t, err)
+	assert.Equal(t, "dev-token", token)
+	assert.NotNil(t, cfg.tlsConfig.RootCAs)
+	assert.Len(t, cfg.tlsConfig.Certificates, 1)
+	assert.False(t, cfg.tlsConfig.InsecureSkipVerify)
+}
+
+func TestKubeconfigRESTConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "non-existent", file: "non-existent.yaml"},
+		{name: "exec credentials", file: "kubeconfig-exec.yaml"},
+		{name: "missing context", file: "kubeconfig-no-context.yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := kubeconfigRESTConfig(filepath.Join("testdata", tt.file))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKubeconfigPath(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join("testdata", "kubeconfig.yaml")+string(filepath.ListSeparator)+"other.yaml")
+	path, err := kubeconfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("testdata", "kubeconfig.yaml"), path)
+
+	t.Setenv("KUBECONFIG", "")
+	path, err = kubeconfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(".kube", "config"), path[len(path)-len(filepath.Join(".kube", "config")):])
+}
+
+func TestInClusterConfig(t *testing.T) {
+	saDir := t.TempDir()
+	ca, err := os.ReadFile(filepath.Join("testdata", "ca.crt"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(saDir, "ca.crt"), ca, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(saDir, "token"), []byte("token-1\n"), 0600))
+
+	cfg, err := inClusterConfig("10.0.0.1", "443", saDir)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1:443", cfg.host)
+	assert.NotNil(t, cfg.tlsConfig.RootCAs)
+	token, err := cfg.bearerToken()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The rotated token is read on the next request.
+	require.NoError(t, os.WriteFile(filepath.Join(saDir, "token"), []byte("token-2"), 0600))
+	token, err = cfg.bearerToken()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+
+	cfg, err = inClusterConfig("fd00::1", "443", saDir)
+	require.NoError(t, err)
+	assert.Equal(t, "https://[fd00::1]:443", cfg.host)
+}
+
+func TestInClusterConfigErrors(t *testing.T) {
+	saDir := t.TempDir()
+	_, err := inClusterConfig("10.0.0.1", "443", saDir)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(saDir, "token"), []byte("token"), 0600))
+	_, err = inClusterConfig("10.0.0.1", "443", saDir)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(saDir, "ca.crt"), []byte("invalid"), 0600))
+	_, err = inClusterConfig("10.0.0.1", "443", saDir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sprovider // import "go.opentelemetry.io/collector/confmap/provider/k8sprovider"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/internal"
+)
+
+const schemeName = "k8s"
+
+type provider struct {
+	loadConfig func() (*restConfig, error)
+	// client is created on the first Retrieve, so that the provider can be registered outside of Kubernetes.
+	client *client
+}
+
+// New returns a new confmap.Provider that reads the configuration from a key of a Kubernetes ConfigMap,
+// and watches the ConfigMap for updates.
+//
+// This Provider supports "k8s" scheme, and can be called with a "uri" that follows:
+//
+//	k8s-uri	= "k8s://" namespace "/" name "#" key
+//
+// The API server is accessed with the service account of the pod when running in Kubernetes,
+// with the current context of the kubeconfig file otherwise, the first file of the KUBECONFIG
+// environment variable or ~/.kube/config. The service account needs the "get" and "watch"
+// permissions on the ConfigMap.
+//
+// Examples:
+// `k8s://observability/otelcol#config.yaml` - the "config.yaml" key of the "otelcol" ConfigMap of the "observability" namespace.
+func New() confmap.Provider {
+	return &provider{loadConfig: loadRESTConfig}
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return confmap.Retrieved{}, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	ref, err := parseURI(uri)
+	if err != nil {
+		return confmap.Retrieved{}, err
+	}
+
+	if p.client == nil {
+		cfg, err := p.loadConfig()
+		if err != nil {
+			return confmap.Retrieved{}, err
+		}
+		p.client = newClient(cfg)
+	}
+
+	cm, err := p.client.getConfigMap(ctx, ref)
+	if err != nil {
+		return confmap.Retrieved{}, err
+	}
+	data, ok := cm.Data[ref.key]
+	if !ok {
+		return confmap.Retrieved{}, fmt.Errorf("key %q not found in the ConfigMap %s/%s", ref.key, ref.namespace, ref.name)
+	}
+	if watcher == nil {
+		return internal.NewRetrievedFromYAML([]byte(data))
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	ret, err := internal.NewRetrievedFromYAML([]byte(data), confmap.WithRetrievedClose(func(ctx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}))
+	if err != nil {
+		cancel()
+		return confmap.Retrieved{}, err
+	}
+	go func() {
+		defer close(done)
+		p.client.watch(watchCtx, ref, data, cm.Metadata.ResourceVersion, watcher)
+	}()
+	return ret, nil
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (p *provider) Shutdown(context.Context) error {
+	if p.client != nil {
+		p.client.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// configMapRef references a key of a ConfigMap.
+type configMapRef struct {
+	namespace string
+	name      string
+	key       string
+}
+
+func parseURI(uri string) (configMapRef, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return configMapRef{}, fmt.Errorf("invalid uri %q: %w", uri, err)
+	}
+	ref := configMapRef{namespace: u.Host, name: strings.TrimPrefix(u.Path, "/"), key: u.Fragment}
+	if ref.namespace == "" || ref.name == "" || strings.Contains(ref.name, "/") || ref.key == "" {
+		return configMapRef{}, fmt.Errorf("invalid uri %q: expected \"k8s://namespace/name#key\"", uri)
+	}
+	return ref, nil
+}
+
+// path returns the API path of the ConfigMap.
+func (r configMapRef) path() string {
+	return r.listPath() + "/" + url.PathEscape(r.name)
+}
+
+// listPath returns the API path of the ConfigMaps of the namespace.
+func (r configMapRef) listPath() string {
+	return "/api/v1/namespaces/" + url.PathEscape(r.namespace) + "/configmaps"
+}
@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const testURI = "k8s://observability/otelcol#config.yaml"
+
+// fakeAPIServer serves the "otelcol" ConfigMap of the "observability" namespace, the watches streaming the events
+// sent to the events channel. The watch is closed after an ERROR event, as the API server does, or when an event is nil.
+type fakeAPIServer struct {
+	*httptest.Server
+	events chan *watchEvent
+
+	mu      sync.Mutex
+	data    map[string]string
+	rv      int
+	watches []string
+}
+
+func newFakeAPIServer(t *testing.T, data map[string]string) *fakeAPIServer {
+	s := &fakeAPIServer{events: make(chan *watchEvent, 10), data: data, rv: 1}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/namespaces/observability/configmaps/otelcol", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			writeStatus(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.data == nil {
+			writeStatus(w, http.StatusNotFound, `configmaps "otelcol" not found`)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s.configMapLocked())
+	})
+	mux.HandleFunc("/api/v1/namespaces/observability/configmaps", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("watch"))
+		assert.Equal(t, "metadata.name=otelcol", r.URL.Query().Get("fieldSelector"))
+		s.mu.Lock()
+		s.watches = append(s.watches, r.URL.Query().Get("resourceVersion"))
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event := <-s.events:
+				if event == nil {
+					return
+				}
+				_ = json.NewEncoder(w).Encode(event)
+				w.(http.Flusher).Flush()
+				if event.Type == "ERROR" {
+					return
+				}
+			}
+		}
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func writeStatus(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(status{Message: message, Code: code})
+}
+
+func (s *fakeAPIServer) configMapLocked() configMap {
+	var cm configMap
+	cm.Metadata.ResourceVersion = strconv.Itoa(s.rv)
+	cm.Data = s.data
+	return cm
+}
+
+// update updates the ConfigMap, returning the MODIFIED event.
+func (s *fakeAPIServer) update(data map[string]string) *watchEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+	s.rv++
+	object, _ := json.Marshal(s.configMapLocked())
+	return &watchEvent{Type: "MODIFIED", Object: object}
+}
+
+func (s *fakeAPIServer) watchResourceVersions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.watches...)
+}
+
+func newTestProvider(s *fakeAPIServer) *provider {
+	return &provider{loadConfig: func() (*restConfig, error) {
+		return &restConfig{host: s.URL, token: "test-token"}, nil
+	}}
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	kp := New()
+	_, err := kp.Retrieve(context.Background(), "file:config.yaml", nil)
+	assert.Error(t, err)
+	assert.NoError(t, kp.Shutdown(context.Background()))
+}
+
+func TestInvalidURI(t *testing.T) {
+	for _, uri := range []string{
+		"k8s:",
+		"k8s://observability",
+		"k8s://observability/otelcol",
+		"k8s://observability/otelcol#",
+		"k8s:///otelcol#config.yaml",
+		"k8s://observability/otel/col#config.yaml",
+	} {
+		t.Run(uri, func(t *testing.T) {
+			_, err := parseURI(uri)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	ref, err := parseURI(testURI)
+	require.NoError(t, err)
+	assert.Equal(t, configMapRef{namespace: "observability", name: "otelcol", key: "config.yaml"}, ref)
+	assert.Equal(t, "/api/v1/namespaces/observability/configmaps/otelcol", ref.path())
+}
+
+func TestRetrieve(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"config.yaml": "exporters:\n  otlp:\n    endpoint: localhost:4317\n"})
+	kp := newTestProvider(s)
+	ret, err := kp.Retrieve(context.Background(), testURI, nil)
+	require.NoError(t, err)
+	retMap, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, confmap.NewFromStringMap(map[string]interface{}{"exporters::otlp::endpoint": "localhost:4317"}), retMap)
+	assert.NoError(t, ret.Close(context.Background()))
+	assert.NoError(t, kp.Shutdown(context.Background()))
+}
+
+func TestRetrieveErrors(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"other.yaml": "", "invalid.yaml": "[invalid"})
+	kp := newTestProvider(s)
+
+	_, err := kp.Retrieve(context.Background(), testURI, nil)
+	assert.EqualError(t, err, `key "config.yaml" not found in the ConfigMap observability/otelcol`)
+	_, err = kp.Retrieve(context.Background(), "k8s://observability/otelcol#invalid.yaml", nil)
+	assert.Error(t, err)
+	_, err = kp.Retrieve(context.Background(), "k8s://observability/other#config.yaml", nil)
+	assert.Error(t, err)
+
+	s.update(nil)
+	_, err = kp.Retrieve(context.Background(), testURI, nil)
+	assert.True(t, errors.Is(err, errNotFound))
+	assert.NoError(t, kp.Shutdown(context.Background()))
+}
+
+func TestRetrieveUnauthorized(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"config.yaml": ""})
+	kp := &provider{loadConfig: func() (*restConfig, error) {
+		return &restConfig{host: s.URL, token: "invalid"}, nil
+	}}
+	_, err := kp.Retrieve(context.Background(), testURI, nil)
+	assert.EqualError(t, err, "unable to get the ConfigMap observability/otelcol: Unauthorized")
+}
+
+func TestRetrieveLoadConfigError(t *testing.T) {
+	kp := &provider{loadConfig: func() (*restConfig, error) {
+		return nil, errors.New("no configuration")
+	}}
+	_, err := kp.Retrieve(context.Background(), testURI, nil)
+	assert.EqualError(t, err, "no configuration")
+}
+
+// retrieveWatched retrieves testURI, returning the channel of the change events.
+func retrieveWatched(t *testing.T, kp *provider) (confmap.Retrieved, chan *confmap.ChangeEvent) {
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := kp.Retrieve(context.Background(), testURI, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+	return ret, events
+}
+
+func TestWatchModified(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"config.yaml": "receivers: {}", "other.yaml": ""})
+	kp := newTestProvider(s)
+	ret, events := retrieveWatched(t, kp)
+
+	// Changing another key is not a change.
+	s.events <- s.update(map[string]string{"config.yaml": "receivers: {}", "other.yaml": "changed"})
+	select {
+	case <-events:
+		t.Fatal("unexpected change event")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.events <- s.update(map[string]string{"config.yaml": "exporters: {}"})
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change event")
+	}
+	assert.Equal(t, []string{"1"}, s.watchResourceVersions())
+	assert.NoError(t, ret.Close(context.Background()))
+	assert.NoError(t, kp.Shutdown(context.Background()))
+}
+
+func TestWatchDeleted(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"config.yaml": "receivers: {}"})
+	kp := newTestProvider(s)
+	ret, events := retrieveWatched(t, kp)
+
+	s.events <- &watchEvent{Type: "DELETED", Object: json.RawMessage(`{}`)}
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change event")
+	}
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestWatchReconnects(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"config.yaml": "receivers: {}"})
+	kp := newTestProvider(s)
+	ret, events := retrieveWatched(t, kp)
+
+	// The watch closed by the API server continues from the last seen resource version.
+	s.events <- &watchEvent{Type: "BOOKMARK", Object: json.RawMessage(`{"metadata":{"resourceVersion":"5"}}`)}
+	s.events <- nil
+	require.Eventually(t, func() bool { return len(s.watchResourceVersions()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"1", "5"}, s.watchResourceVersions())
+
+	s.events <- s.update(map[string]string{"config.yaml": "exporters: {}"})
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change event")
+	}
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestWatchResyncsAfterError(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"config.yaml": "receivers: {}"})
+	kp := newTestProvider(s)
+	kp.client = newClient(&restConfig{host: s.URL, token: "test-token"})
+	kp.client.resyncInterval = time.Millisecond
+	ret, events := retrieveWatched(t, kp)
+
+	// The resync after the error finds the ConfigMap unchanged, and watches from its resource version.
+	s.update(map[string]string{"config.yaml": "receivers: {}"})
+	s.events <- &watchEvent{Type: "ERROR", Object: json.RawMessage(`{"message":"too old resource version","code":410}`)}
+	require.Eventually(t, func() bool { return len(s.watchResourceVersions()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"1", "2"}, s.watchResourceVersions())
+
+	// The resync after the error finds the ConfigMap changed.
+	s.update(map[string]string{"config.yaml": "exporters: {}"})
+	s.events <- &watchEvent{Type: "ERROR", Object: json.RawMessage(`{"message":"too old resource version","code":410}`)}
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change event")
+	}
+	assert.NoError(t, ret.Close(context.Background()))
+}
+
+func TestCloseStopsWatch(t *testing.T) {
+	s := newFakeAPIServer(t, map[string]string{"config.yaml": "receivers: {}"})
+	kp := newTestProvider(s)
+	ret, events := retrieveWatched(t, kp)
+	require.Eventually(t, func() bool { return len(s.watchResourceVersions()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, ret.Close(context.Background()))
+	s.events <- s.update(map[string]string{"config.yaml": "exporters: {}"})
+	select {
+	case <-events:
+		t.Fatal("unexpected change event")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.NoError(t, kp.Shutdown(context.Background()))
+}
@@ -31,9 +31,10 @@ var driverLetterRegexp = regexp.MustCompile("^[A-z]:")
 
 // Resolver resolves a configuration as a Conf.
 type Resolver struct {
-	uris       []string
-	providers  map[string]Provider
-	converters []Converter
+	uris                []string
+	providers           map[string]Provider
+	converters          []Converter
+	listMergeStrategies map[string]ListMergeStrategy
 
 	sync.Mutex
 	closers []CloseFunc
@@ -52,6 +53,10 @@ type ResolverSettings struct {
 
 	// MapConverters is a slice of Converter.
 	Converters []Converter
+
+	// ListMergeStrategies are the ListMergeStrategy merging the lists of the configurations retrieved from
+	// the URIs, keyed by the keys of the lists, see Conf.MergeWithListStrategies. The lists are replaced by default.
+	ListMergeStrategies map[string]ListMergeStrategy
 }
 
 // NewResolver returns a new Resolver that resolves configuration from multiple URIs.
@@ -91,12 +96,20 @@ func NewResolver(set ResolverSettings) (*Resolver, error) {
 	}
 	convertersCopy := make([]Converter, len(set.Converters))
 	copy(convertersCopy, set.Converters)
+	strategiesCopy := make(map[string]ListMergeStrategy, len(set.ListMergeStrategies))
+	for k, v := range set.ListMergeStrategies {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid map resolver config: list merge strategy for %q: %w", k, err)
+		}
+		strategiesCopy[k] = v
+	}
 
 	return &Resolver{
-		uris:       urisCopy,
-		providers:  providersCopy,
-		converters: convertersCopy,
-		watcher:    make(chan error, 1),
+		uris:                urisCopy,
+		providers:           providersCopy,
+		converters:          convertersCopy,
+		listMergeStrategies: strategiesCopy,
+		watcher:             make(chan error, 1),
 	}, nil
 }
 
@@ -112,31 +125,13 @@ func (mr *Resolver) Resolve(ctx context.Context) (*Conf, error) {
 	// Retrieves individual configurations from all URIs in the given order, and merge them in retMap.
 	retMap := New()
 	for _, uri := range mr.uris {
-		// For backwards compatibility:
-		// - empty url scheme means "file".
-		// - "^[A-z]:" also means "file"
-		scheme := "file"
-		if idx := strings.Index(uri, ":"); idx != -1 && !driverLetterRegexp.MatchString(uri) {
-			scheme = uri[:idx]
-		} else {
-			uri = scheme + ":" + uri
-		}
-		p, ok := mr.providers[scheme]
-		if !ok {
-			return nil, fmt.Errorf("scheme %q is not supported for uri %q", scheme, uri)
-		}
-		ret, err := p.Retrieve(ctx, uri, mr.onChange)
+		retCfgMap, err := mr.retrieve(ctx, uri, nil)
 		if err != nil {
 			return nil, err
 		}
-		retCfgMap, err := ret.AsConf()
-		if err != nil {
-			return nil, err
-		}
-		if err = retMap.Merge(retCfgMap); err != nil {
+		if err = retMap.MergeWithListStrategies(retCfgMap, mr.listMergeStrategies); err != nil {
 			return nil, err
 		}
-		mr.closers = append(mr.closers, ret.Close)
 	}
 
 	// Apply the converters in the given order.
@@ -176,6 +171,43 @@ func (mr *Resolver) Shutdown(ctx context.Context) error {
 	return errs
 }
 
+// retrieve returns the configuration retrieved from the URI with its includes resolved, the stack being the URIs
+// including it.
+func (mr *Resolver) retrieve(ctx context.Context, uri string, stack []string) (*Conf, error) {
+	// For backwards compatibility:
+	// - empty url scheme means "file".
+	// - "^[A-z]:" also means "file"
+	scheme := "file"
+	if idx := strings.Index(uri, ":"); idx != -1 && !driverLetterRegexp.MatchString(uri) {
+		scheme = uri[:idx]
+	} else {
+		uri = scheme + ":" + uri
+	}
+	for _, including := range stack {
+		if including == uri {
+			return nil, fmt.Errorf("include cycle: %s -> %s", strings.Join(stack, " -> "), uri)
+		}
+	}
+	p, ok := mr.providers[scheme]
+	if !ok {
+		return nil, fmt.Errorf("scheme %q is not supported for uri %q", scheme, uri)
+	}
+	ret, err := p.Retrieve(ctx, uri, mr.onChange)
+	if err != nil {
+		return nil, err
+	}
+	mr.closers = append(mr.closers, ret.Close)
+	retCfgMap, err := ret.AsConf()
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := mr.resolveIncludes(ctx, retCfgMap.ToStringMap(), append(stack, uri))
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve the includes of uri %q: %w", uri, err)
+	}
+	return NewFromStringMap(resolved.(map[string]interface{})), nil
+}
+
 func (mr *Resolver) onChange(event *ChangeEvent) {
 	mr.watcher <- event.Error
 }
@@ -313,3 +313,33 @@ func makeMapProvidersMap(providers ...Provider) map[string]Provider {
 	}
 	return ret
 }
+
+func TestResolverListMergeStrategies(t *testing.T) {
+	base := newFakeProvider("base", func(context.Context, string, WatcherFunc) (Retrieved, error) {
+		return NewRetrieved(map[string]interface{}{"service": map[string]interface{}{"pipelines": map[string]interface{}{
+			"traces": map[string]interface{}{"receivers": []interface{}{"otlp"}}}}})
+	})
+	overlay := newFakeProvider("overlay", func(context.Context, string, WatcherFunc) (Retrieved, error) {
+		return NewRetrieved(map[string]interface{}{"service": map[string]interface{}{"pipelines": map[string]interface{}{
+			"traces": map[string]interface{}{"receivers": []interface{}{"jaeger"}}}}})
+	})
+	resolver, err := NewResolver(ResolverSettings{
+		URIs:                []string{"base:", "overlay:"},
+		Providers:           makeMapProvidersMap(base, overlay),
+		ListMergeStrategies: map[string]ListMergeStrategy{"service::pipelines::*::receivers": ListMergeAppend},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"otlp", "jaeger"}, conf.Get("service::pipelines::traces::receivers"))
+	assert.NoError(t, resolver.Shutdown(context.Background()))
+}
+
+func TestResolverInvalidListMergeStrategy(t *testing.T) {
+	_, err := NewResolver(ResolverSettings{
+		URIs:                []string{filepath.Join("testdata", "config.yaml")},
+		Providers:           makeMapProvidersMap(newFileProvider(t)),
+		ListMergeStrategies: map[string]ListMergeStrategy{"service::pipelines::*::receivers": "prepend"},
+	})
+	assert.Error(t, err)
+}
@@ -26,6 +26,11 @@ type Capabilities struct {
 	// does not modify the data it MUST set this flag to false. If the processor creates
 	// a copy of the data before modifying then this flag can be safely set to false.
 	MutatesData bool
+
+	// MaxRequestBytes is the preferred maximum size in bytes of the data consumed at once, as encoded
+	// in OTLP protobuf, e.g. the maximum size of the messages accepted by the destination of an exporter.
+	// The batch processor splits the batches larger than this size. Zero means no preferred maximum size.
+	MaxRequestBytes int
 }
 
 type baseConsumer interface {
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumerack // import "go.opentelemetry.io/collector/consumer/consumerack"
+
+import (
+	"context"
+	"sync"
+)
+
+type ctxKey struct{}
+
+// Ack is the acknowledgment of the data consumed with a context, pending until Done is called and all the
+// holds are released. It completes with the first error reported by Done or by the holds, if any.
+type Ack struct {
+	mu         sync.Mutex
+	pending    int
+	err        error
+	done       chan struct{}
+	onComplete []func(error)
+}
+
+// New returns a pending Ack.
+func New() *Ack {
+	return &Ack{pending: 1, done: make(chan struct{})}
+}
+
+// NewContext returns a copy of the context carrying the Ack.
+func NewContext(ctx context.Context, ack *Ack) context.Context {
+	return context.WithValue(ctx, ctxKey{}, ack)
+}
+
+// FromContext returns the Ack carried by the context, if any.
+func FromContext(ctx context.Context) (*Ack, bool) {
+	ack, ok := ctx.Value(ctxKey{}).(*Ack)
+	return ack, ok && ack != nil
+}
+
+// Hold holds the Ack carried by the context, if any, returning the function releasing the hold with the result
+// of the asynchronous processing of the data. Returns nil if the context carries no Ack.
+func Hold(ctx context.Context) func(error) {
+	if ack, ok := FromContext(ctx); ok {
+		return ack.Hold()
+	}
+	return nil
+}
+
+// Hold holds the Ack, returning the function releasing the hold with the result of the asynchronous processing
+// of the data. Only the first call of the returned function releases the hold.
+func (a *Ack) Hold() func(error) {
+	a.mu.Lock()
+	a.pending++
+	a.mu.Unlock()
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { a.release(err) })
+	}
+}
+
+// Done reports the result of the synchronous consumption of the data, the Ack completing once the holds are
+// released. Must be called once.
+func (a *Ack) Done(err error) {
+	a.release(err)
+}
+
+// OnComplete registers the function called with the result of the Ack once completed, right away if the Ack
+// is already completed.
+func (a *Ack) OnComplete(f func(error)) {
+	a.mu.Lock()
+	if a.pending > 0 {
+		a.onComplete = append(a.onComplete, f)
+		a.mu.Unlock()
+		return
+	}
+	err := a.err
+	a.mu.Unlock()
+	f(err)
+}
+
+// Wait blocks until the Ack completes, returning its result, or until the context is done, returning the error
+// of the context.
+func (a *Ack) Wait(ctx context.Context) error {
+	select {
+	case <-a.done:
+		return a.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Ack) release(err error) {
+	a.mu.Lock()
+	if a.err == nil {
+		a.err = err
+	}
+	a.pending--
+	if a.pending > 0 {
+		a.mu.Unlock()
+		return
+	}
+	onComplete := a.onComplete
+	a.onComplete = nil
+	close(a.done)
+	a.mu.Unlock()
+	for _, f := range onComplete {
+		f(a.err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumerack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAckDone(t *testing.T) {
+	ack := New()
+	ack.Done(nil)
+	assert.NoError(t, ack.Wait(context.Background()))
+
+	ack = New()
+	ack.Done(errors.New("my error"))
+	assert.EqualError(t, ack.Wait(context.Background()), "my error")
+}
+
+func TestAckHold(t *testing.T) {
+	ack := New()
+	ctx := NewContext(context.Background(), ack)
+	release1 := Hold(ctx)
+	release2 := Hold(ctx)
+	ack.Done(nil)
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, ack.Wait(waitCtx), context.DeadlineExceeded)
+
+	release1(errors.New("first error"))
+	// Only the first release of a hold counts.
+	release1(nil)
+	assert.ErrorIs(t, ack.Wait(waitCtx), context.DeadlineExceeded)
+
+	release2(errors.New("second error"))
+	assert.EqualError(t, ack.Wait(context.Background()), "first error")
+}
+
+func TestAckOnComplete(t *testing.T) {
+	ack := New()
+	release := ack.Hold()
+	var results []error
+	ack.OnComplete(func(err error) { results = append(results, err) })
+	ack.Done(nil)
+	assert.Empty(t, results)
+	release(errors.New("my error"))
+	assert.Equal(t, []error{errors.New("my error")}, results)
+
+	// Registered after the completion.
+	ack.OnComplete(func(err error) { results = append(results, err) })
+	assert.Len(t, results, 2)
+	assert.EqualError(t, results[1], "my error")
+}
+
+func TestHoldWithoutAck(t *testing.T) {
+	assert.Nil(t, Hold(context.Background()))
+	assert.Nil(t, Hold(NewContext(context.Background(), nil)))
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package consumerack tracks the acknowledgment of the data consumed with a
+// context, so that a receiver responds to its client only once the data is
+// durably accepted by the pipeline, rather than as soon as the next consumer
+// returns.
+//
+// A receiver creates an Ack with New, stores it in the context passed to the
+// next consumer with NewContext, calls Ack.Done with the result of the consumer
+// and waits for the Ack with Ack.Wait before responding.
+//
+// The consumers handing the data over asynchronously, e.g. processors batching
+// the data or exporters with in-memory queues, call Hold with the context before
+// returning, and release the hold with the result of the asynchronous processing
+// once the data is durably accepted, e.g. exported, or persisted by the queue of
+// an exporter. The consumers handling the data synchronously, or persisting it
+// before returning, do not need to do anything.
+package consumerack // import "go.opentelemetry.io/collector/consumer/consumerack"
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+)
+
+// Rejected is an error reporting that only a subset of the consumed items, spans, metric data points
+// or log records, were rejected, the other items being accepted.
+type Rejected struct {
+	error
+	rejected int
+}
+
+// NewRejected creates a Rejected reporting the number of items rejected out of the consumed ones.
+func NewRejected(err error, rejected int) error {
+	return Rejected{
+		error:    err,
+		rejected: rejected,
+	}
+}
+
+// Rejected returns the number of rejected items.
+func (err Rejected) Rejected() int {
+	return err.rejected
+}
+
+// Unwrap returns the wrapped error for functions Is and As in standard package errors.
+func (err Rejected) Unwrap() error {
+	return err.error
+}
+
+// RejectedItems returns the number of items rejected by err out of the total consumed items, the other
+// items being accepted. This is the count of a Rejected error, else the number of items of the failed
+// data of a Traces, Metrics or Logs error, else all the items for a non-nil error.
+// The result is capped to total.
+func RejectedItems(err error, total int) int {
+	if err == nil {
+		return 0
+	}
+	rejected := total
+	var (
+		rejectedErr Rejected
+		tracesErr   Traces
+		metricsErr  Metrics
+		logsErr     Logs
+	)
+	switch {
+	case errors.As(err, &rejectedErr):
+		rejected = rejectedErr.Rejected()
+	case errors.As(err, &tracesErr):
+		rejected = tracesErr.GetTraces().SpanCount()
+	case errors.As(err, &metricsErr):
+		rejected = metricsErr.GetMetrics().DataPointCount()
+	case errors.As(err, &logsErr):
+		rejected = logsErr.GetLogs().LogRecordCount()
+	}
+	if rejected > total {
+		return total
+	}
+	if rejected < 0 {
+		return 0
+	}
+	return rejected
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestRejected(t *testing.T) {
+	err := errors.New("some error")
+	rejectedErr := NewRejected(err, 3)
+	assert.Equal(t, err.Error(), rejectedErr.Error())
+	var target Rejected
+	assert.False(t, errors.As(err, &target))
+	require.True(t, errors.As(NewPermanent(rejectedErr), &target))
+	assert.Equal(t, 3, target.Rejected())
+	assert.True(t, errors.Is(rejectedErr, err))
+}
+
+func TestRejectedItems(t *testing.T) {
+	err := errors.New("some error")
+	assert.Equal(t, 0, RejectedItems(nil, 10))
+	assert.Equal(t, 10, RejectedItems(err, 10))
+	assert.Equal(t, 3, RejectedItems(NewRejected(err, 3), 10))
+	assert.Equal(t, 3, RejectedItems(NewPermanent(NewRejected(err, 3)), 10))
+	assert.Equal(t, 10, RejectedItems(NewRejected(err, 20), 10))
+	assert.Equal(t, 0, RejectedItems(NewRejected(err, -1), 10))
+	assert.Equal(t, 2, RejectedItems(NewTraces(err, testdata.GenerateTraces(2)), 10))
+	assert.Equal(t, 4, RejectedItems(NewMetrics(err, testdata.GenerateMetrics(2)), 10))
+	assert.Equal(t, 2, RejectedItems(NewLogs(err, testdata.GenerateLogs(2)), 10))
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumertest // import "go.opentelemetry.io/collector/consumer/consumertest"
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	// ErrInjected is the error returned by the calls failing at random, if Faults.Err is not set.
+	ErrInjected = errors.New("injected error")
+	// ErrCapacityExceeded is the error returned by the calls exceeding Faults.Capacity.
+	ErrCapacityExceeded = errors.New("capacity exceeded")
+)
+
+// Faults configures the faults injected by the faulty sinks, to test the behavior of the callers
+// with a slow or failing next consumer. The zero value injects no fault.
+type Faults struct {
+	// Latency returns the delay of each call, e.g. UniformLatency or ExponentialLatency. The calls
+	// return the context error if the context is done first. No delay if nil.
+	Latency func() time.Duration
+	// ErrorRatio is the fraction of the calls, chosen at random, failing with Err after their delay.
+	ErrorRatio float64
+	// Err is the error returned by the calls failing at random, ErrInjected if nil.
+	// Wrap it with consumererror.NewPermanent to test the permanent errors.
+	Err error
+	// Capacity is the maximum number of spans, metric data points or log records consumed concurrently.
+	// The calls exceeding it fail right away with ErrCapacityExceeded, a non-permanent error.
+	// No limit if zero.
+	Capacity int
+}
+
+// UniformLatency returns latencies uniformly distributed between minLatency and maxLatency.
+func UniformLatency(minLatency, maxLatency time.Duration) func() time.Duration {
+	return func() time.Duration {
+		return minLatency + time.Duration(rand.Int63n(int64(maxLatency-minLatency)+1))
+	}
+}
+
+// ExponentialLatency returns latencies exponentially distributed with the given mean, i.e. mostly short
+// latencies with a long tail.
+func ExponentialLatency(mean time.Duration) func() time.Duration {
+	return func() time.Duration {
+		return time.Duration(rand.ExpFloat64() * float64(mean))
+	}
+}
+
+// faultInjector injects the Faults into the calls of a faulty sink.
+type faultInjector struct {
+	faults Faults
+
+	mu         sync.Mutex
+	inFlight   int
+	errorCount int
+}
+
+// inject applies the faults to a call consuming count items, returning the error of the call if any.
+func (fi *faultInjector) inject(ctx context.Context, count int) error {
+	fi.mu.Lock()
+	if fi.faults.Capacity > 0 && fi.inFlight+count > fi.faults.Capacity {
+		fi.errorCount++
+		fi.mu.Unlock()
+		return ErrCapacityExceeded
+	}
+	fi.inFlight += count
+	fi.mu.Unlock()
+
+	err := fi.wait(ctx)
+	if err == nil && fi.faults.ErrorRatio > 0 && rand.Float64() < fi.faults.ErrorRatio {
+		err = fi.faults.Err
+		if err == nil {
+			err = ErrInjected
+		}
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.inFlight -= count
+	if err != nil {
+		fi.errorCount++
+	}
+	return err
+}
+
+func (fi *faultInjector) wait(ctx context.Context) error {
+	if fi.faults.Latency == nil {
+		return nil
+	}
+	timer := time.NewTimer(fi.faults.Latency())
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ErrorCount returns the number of calls which failed since last Reset.
+func (fi *faultInjector) ErrorCount() int {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.errorCount
+}
+
+func (fi *faultInjector) reset() {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.errorCount = 0
+}
+
+// FaultyTracesSink is a TracesSink injecting Faults into the calls, only storing the traces of
+// the successful calls.
+type FaultyTracesSink struct {
+	TracesSink
+	*faultInjector
+}
+
+var _ consumer.Traces = (*FaultyTracesSink)(nil)
+
+// NewFaultyTracesSink returns a FaultyTracesSink injecting the faults.
+func NewFaultyTracesSink(faults Faults) *FaultyTracesSink {
+	return &FaultyTracesSink{faultInjector: &faultInjector{faults: faults}}
+}
+
+// ConsumeTraces stores traces to this sink, unless a fault is injected.
+func (fts *FaultyTracesSink) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if err := fts.inject(ctx, td.SpanCount()); err != nil {
+		return err
+	}
+	return fts.TracesSink.ConsumeTraces(ctx, td)
+}
+
+// Reset deletes any stored data and the error count.
+func (fts *FaultyTracesSink) Reset() {
+	fts.TracesSink.Reset()
+	fts.reset()
+}
+
+// FaultyMetricsSink is a MetricsSink injecting Faults into the calls, only storing the metrics of
+// the successful calls.
+type FaultyMetricsSink struct {
+	MetricsSink
+	*faultInjector
+}
+
+var _ consumer.Metrics = (*FaultyMetricsSink)(nil)
+
+// NewFaultyMetricsSink returns a FaultyMetricsSink injecting the faults.
+func NewFaultyMetricsSink(faults Faults) *FaultyMetricsSink {
+	return &FaultyMetricsSink{faultInjector: &faultInjector{faults: faults}}
+}
+
+// ConsumeMetrics stores metrics to this sink, unless a fault is injected.
+func (fms *FaultyMetricsSink) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if err := fms.inject(ctx, md.DataPointCount()); err != nil {
+		return err
+	}
+	return fms.MetricsSink.ConsumeMetrics(ctx, md)
+}
+
+// Reset deletes any stored data and the error count.
+func (fms *FaultyMetricsSink) Reset() {
+	fms.MetricsSink.Reset()
+	fms.reset()
+}
+
+// FaultyLogsSink is a LogsSink injecting Faults into the calls, only storing the logs of
+// the successful calls.
+type FaultyLogsSink struct {
+	LogsSink
+	*faultInjector
+}
+
+var _ consumer.Logs = (*FaultyLogsSink)(nil)
+
+// NewFaultyLogsSink returns a FaultyLogsSink injecting the faults.
+func NewFaultyLogsSink(faults Faults) *FaultyLogsSink {
+	return &FaultyLogsSink{faultInjector: &faultInjector{faults: faults}}
+}
+
+// ConsumeLogs stores logs to this sink, unless a fault is injected.
+func (fls *FaultyLogsSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if err := fls.inject(ctx, ld.LogRecordCount()); err != nil {
+		return err
+	}
+	return fls.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+// Reset deletes any stored data and the error count.
+func (fls *FaultyLogsSink) Reset() {
+	fls.LogsSink.Reset()
+	fls.reset()
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumertest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestFaultyTracesSinkNoFault(t *testing.T) {
+	sink := NewFaultyTracesSink(Faults{})
+	require.NoError(t, sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, 2, sink.SpanCount())
+	assert.Equal(t, 0, sink.ErrorCount())
+}
+
+func TestFaultyTracesSinkErrors(t *testing.T) {
+	sink := NewFaultyTracesSink(Faults{ErrorRatio: 1})
+	err := sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	assert.ErrorIs(t, err, ErrInjected)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 0, sink.SpanCount())
+	assert.Equal(t, 1, sink.ErrorCount())
+
+	sink.Reset()
+	assert.Equal(t, 0, sink.ErrorCount())
+
+	permanent := consumererror.NewPermanent(errors.New("rejected"))
+	sink = NewFaultyTracesSink(Faults{ErrorRatio: 1, Err: permanent})
+	assert.Equal(t, permanent, sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+}
+
+func TestFaultyTracesSinkErrorRatio(t *testing.T) {
+	sink := NewFaultyTracesSink(Faults{ErrorRatio: 0.5})
+	for i := 0; i < 1000; i++ {
+		_ = sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	}
+	assert.Equal(t, 1000, sink.SpanCount()+sink.ErrorCount())
+	assert.InDelta(t, 500, sink.ErrorCount(), 100)
+}
+
+func TestFaultyTracesSinkLatency(t *testing.T) {
+	sink := NewFaultyTracesSink(Faults{Latency: UniformLatency(20*time.Millisecond, 30*time.Millisecond)})
+	start := time.Now()
+	require.NoError(t, sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// The calls are interrupted by the context.
+	sink = NewFaultyTracesSink(Faults{Latency: UniformLatency(time.Hour, time.Hour)})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, sink.ConsumeTraces(ctx, testdata.GenerateTraces(1)), context.DeadlineExceeded)
+	assert.Equal(t, 0, sink.SpanCount())
+	assert.Equal(t, 1, sink.ErrorCount())
+}
+
+func TestFaultyTracesSinkCapacity(t *testing.T) {
+	release := make(chan struct{})
+	sink := NewFaultyTracesSink(Faults{
+		Latency: func() time.Duration {
+			<-release
+			return 0
+		},
+		Capacity: 3,
+	})
+
+	// The first call holds 2 of the 3 spans of capacity while waiting.
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	}()
+	require.Eventually(t, func() bool {
+		sink.faultInjector.mu.Lock()
+		defer sink.faultInjector.mu.Unlock()
+		return sink.inFlight == 2
+	}, time.Second, time.Millisecond)
+
+	err := sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	assert.ErrorIs(t, err, ErrCapacityExceeded)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	close(release)
+	wg.Wait()
+	require.NoError(t, sink.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	assert.Equal(t, 5, sink.SpanCount())
+	assert.Equal(t, 1, sink.ErrorCount())
+}
+
+func TestExponentialLatency(t *testing.T) {
+	latency := ExponentialLatency(time.Millisecond)
+	var total time.Duration
+	for i := 0; i < 1000; i++ {
+		d := latency()
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		total += d
+	}
+	assert.InDelta(t, float64(time.Millisecond), float64(total/1000), float64(300*time.Microsecond))
+}
+
+func TestFaultyMetricsSink(t *testing.T) {
+	sink := NewFaultyMetricsSink(Faults{Capacity: 1})
+	assert.ErrorIs(t, sink.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), ErrCapacityExceeded)
+	sink = NewFaultyMetricsSink(Faults{})
+	require.NoError(t, sink.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Equal(t, 2, sink.DataPointCount())
+	sink.Reset()
+	assert.Equal(t, 0, sink.DataPointCount())
+}
+
+func TestFaultyLogsSink(t *testing.T) {
+	sink := NewFaultyLogsSink(Faults{ErrorRatio: 1})
+	assert.ErrorIs(t, sink.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), ErrInjected)
+	assert.Equal(t, 1, sink.ErrorCount())
+	sink = NewFaultyLogsSink(Faults{})
+	require.NoError(t, sink.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Equal(t, 1, sink.LogRecordCount())
+	sink.Reset()
+	assert.Equal(t, 0, sink.LogRecordCount())
+}
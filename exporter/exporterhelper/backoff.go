@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	// BackoffStrategyExponential multiplies the interval by the multiplier after each retry.
+	BackoffStrategyExponential = "exponential"
+	// BackoffStrategyConstant keeps the initial interval between all the retries.
+	BackoffStrategyConstant = "constant"
+	// BackoffStrategyFibonacci grows the interval as the Fibonacci sequence of the initial interval,
+	// more slowly than the exponential strategy.
+	BackoffStrategyFibonacci = "fibonacci"
+)
+
+const (
+	// JitterNone waits for the exact intervals, e.g. for the backends with their own rate limiting.
+	JitterNone = "none"
+	// JitterFull waits for a random duration between 0 and the interval.
+	JitterFull = "full"
+	// JitterEqual waits for half the interval plus a random duration up to the other half.
+	JitterEqual = "equal"
+	// JitterDecorrelated waits for a random duration between the initial interval and three times the
+	// previous wait, growing independently of the strategy.
+	JitterDecorrelated = "decorrelated"
+)
+
+// defaultRandomizationFactor is the randomization of the intervals without jitter setting, the intervals
+// being randomized by up to 50% in both directions.
+const defaultRandomizationFactor = 0.5
+
+// defaultMultiplier is the multiplier of the exponential strategy without multiplier setting.
+const defaultMultiplier = 1.5
+
+// BackoffSettings defines the backoff between the retries of the batches failed with a class of errors,
+// overriding the one of the RetrySettings. The unset fields keep the values of the RetrySettings.
+type BackoffSettings struct {
+	// Strategy is the growth of the interval between the retries, one of "exponential", "constant" or "fibonacci".
+	Strategy string `mapstructure:"strategy"`
+	// InitialInterval the time to wait after the first failure before retrying.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval is the upper bound on backoff interval.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+	// Multiplier is the growth factor of the interval of the exponential strategy.
+	Multiplier float64 `mapstructure:"multiplier"`
+	// Jitter is the randomization of the intervals, one of "none", "full", "equal" or "decorrelated".
+	// By default, the intervals are randomized by up to 50% in both directions.
+	Jitter string `mapstructure:"jitter"`
+}
+
+// retryErrorClasses are the classes of errors whose backoff can be overridden, the error types of
+// obsreport.ErrorType which are retried.
+var retryErrorClasses = map[string]bool{
+	obsreport.ErrorTypeThrottled:         true,
+	obsreport.ErrorTypeConnectionRefused: true,
+	obsreport.ErrorTypeTimeout:           true,
+	obsreport.ErrorTypeRetryable:         true,
+}
+
+// validate checks if the BackoffSettings configuration is valid.
+func (bs BackoffSettings) validate() error {
+	switch bs.Strategy {
+	case "", BackoffStrategyExponential, BackoffStrategyConstant, BackoffStrategyFibonacci:
+	default:
+		return fmt.Errorf("unsupported backoff strategy %q, must be one of %q, %q or %q",
+			bs.Strategy, BackoffStrategyExponential, BackoffStrategyConstant, BackoffStrategyFibonacci)
+	}
+	switch bs.Jitter {
+	case "", JitterNone, JitterFull, JitterEqual, JitterDecorrelated:
+	default:
+		return fmt.Errorf("unsupported jitter %q, must be one of %q, %q, %q or %q",
+			bs.Jitter, JitterNone, JitterFull, JitterEqual, JitterDecorrelated)
+	}
+	if bs.InitialInterval < 0 {
+		return errors.New("initial_interval must not be negative")
+	}
+	if bs.MaxInterval < 0 {
+		return errors.New("max_interval must not be negative")
+	}
+	if bs.Multiplier != 0 && bs.Multiplier < 1 {
+		return errors.New("multiplier must be at least 1")
+	}
+	return nil
+}
+
+// merge returns the settings with the unset fields set to the ones of base.
+func (bs BackoffSettings) merge(base BackoffSettings) BackoffSettings {
+	if bs.Strategy == "" {
+		bs.Strategy = base.Strategy
+	}
+	if bs.InitialInterval == 0 {
+		bs.InitialInterval = base.InitialInterval
+	}
+	if bs.MaxInterval == 0 {
+		bs.MaxInterval = base.MaxInterval
+	}
+	if bs.Multiplier == 0 {
+		bs.Multiplier = base.Multiplier
+	}
+	if bs.Jitter == "" {
+		bs.Jitter = base.Jitter
+	}
+	return bs
+}
+
+// retryBackoff computes the intervals between the retries of a request.
+type retryBackoff struct {
+	cfg BackoffSettings
+	// retries is the number of intervals returned.
+	retries int
+	// fibPrev and fibCur are the last terms of the Fibonacci sequence of the fibonacci strategy.
+	fibPrev, fibCur float64
+	// prev is the last interval returned, grown by the decorrelated jitter.
+	prev time.Duration
+	rand *rand.Rand
+}
+
+func newRetryBackoff(cfg BackoffSettings) *retryBackoff {
+	if cfg.Multiplier == 0 {
+		cfg.Multiplier = defaultMultiplier
+	}
+	return &retryBackoff{
+		cfg:    cfg,
+		fibCur: 1,
+		prev:   cfg.InitialInterval,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+}
+
+// next returns the interval to wait before the next retry.
+func (b *retryBackoff) next() time.Duration {
+	interval := b.interval()
+	b.retries++
+
+	switch b.cfg.Jitter {
+	case JitterNone:
+	case JitterFull:
+		interval = time.Duration(b.rand.Float64() * float64(interval))
+	case JitterEqual:
+		interval = interval/2 + time.Duration(b.rand.Float64()*float64(interval/2))
+	case JitterDecorrelated:
+		upper := 3 * b.prev
+		if upper < b.cfg.InitialInterval {
+			upper = b.cfg.InitialInterval
+		}
+		interval = b.cfg.InitialInterval + time.Duration(b.rand.Float64()*float64(upper-b.cfg.InitialInterval))
+		if b.cfg.MaxInterval > 0 && interval > b.cfg.MaxInterval {
+			interval = b.cfg.MaxInterval
+		}
+	default:
+		delta := defaultRandomizationFactor * float64(interval)
+		interval = time.Duration(float64(interval) - delta + b.rand.Float64()*2*delta)
+	}
+	b.prev = interval
+	return interval
+}
+
+// interval returns the interval of the strategy before the jitter, capped to the max interval.
+func (b *retryBackoff) interval() time.Duration {
+	var interval float64
+	switch b.cfg.Strategy {
+	case BackoffStrategyConstant:
+		interval = float64(b.cfg.InitialInterval)
+	case BackoffStrategyFibonacci:
+		interval = float64(b.cfg.InitialInterval) * b.fibCur
+		b.fibPrev, b.fibCur = b.fibCur, b.fibPrev+b.fibCur
+	default:
+		interval = float64(b.cfg.InitialInterval) * math.Pow(b.cfg.Multiplier, float64(b.retries))
+	}
+	if b.cfg.MaxInterval > 0 && interval > float64(b.cfg.MaxInterval) {
+		return b.cfg.MaxInterval
+	}
+	if interval >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(interval)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+func nextIntervals(b *retryBackoff, n int) []time.Duration {
+	intervals := make([]time.Duration, n)
+	for i := range intervals {
+		intervals[i] = b.next()
+	}
+	return intervals
+}
+
+func TestRetryBackoffStrategies(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  BackoffSettings
+		want []time.Duration
+	}{
+		{
+			name: "exponential_default_multiplier",
+			cfg:  BackoffSettings{InitialInterval: time.Second, MaxInterval: 5 * time.Second, Jitter: JitterNone},
+			want: []time.Duration{time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond, 3375 * time.Millisecond, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name: "exponential",
+			cfg:  BackoffSettings{Strategy: BackoffStrategyExponential, InitialInterval: time.Second, MaxInterval: 10 * time.Second, Multiplier: 2, Jitter: JitterNone},
+			want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second},
+		},
+		{
+			name: "constant",
+			cfg:  BackoffSettings{Strategy: BackoffStrategyConstant, InitialInterval: time.Second, MaxInterval: 10 * time.Second, Jitter: JitterNone},
+			want: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name: "fibonacci",
+			cfg:  BackoffSettings{Strategy: BackoffStrategyFibonacci, InitialInterval: time.Second, MaxInterval: 6 * time.Second, Jitter: JitterNone},
+			want: []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 6 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextIntervals(newRetryBackoff(tt.cfg), len(tt.want)))
+		})
+	}
+}
+
+func TestRetryBackoffJitter(t *testing.T) {
+	cfg := BackoffSettings{Strategy: BackoffStrategyConstant, InitialInterval: time.Second, MaxInterval: 10 * time.Second}
+	for i, interval := range nextIntervals(newRetryBackoff(cfg), 100) {
+		assert.GreaterOrEqual(t, interval, 500*time.Millisecond, i)
+		assert.LessOrEqual(t, interval, 1500*time.Millisecond, i)
+	}
+
+	cfg.Jitter = JitterFull
+	for i, interval := range nextIntervals(newRetryBackoff(cfg), 100) {
+		assert.GreaterOrEqual(t, interval, time.Duration(0), i)
+		assert.LessOrEqual(t, interval, time.Second, i)
+	}
+
+	cfg.Jitter = JitterEqual
+	for i, interval := range nextIntervals(newRetryBackoff(cfg), 100) {
+		assert.GreaterOrEqual(t, interval, 500*time.Millisecond, i)
+		assert.LessOrEqual(t, interval, time.Second, i)
+	}
+
+	cfg.Jitter = JitterDecorrelated
+	b := newRetryBackoff(cfg)
+	prev := cfg.InitialInterval
+	for i := 0; i < 100; i++ {
+		interval := b.next()
+		assert.GreaterOrEqual(t, interval, cfg.InitialInterval, i)
+		assert.LessOrEqual(t, interval, 3*prev, i)
+		assert.LessOrEqual(t, interval, cfg.MaxInterval, i)
+		prev = interval
+	}
+}
+
+func TestRetrySettingsOverrides(t *testing.T) {
+	cfg := RetrySettings{
+		Enabled:         true,
+		InitialInterval: time.Second,
+		MaxInterval:     time.Minute,
+		Jitter:          JitterNone,
+		Overrides: map[string]BackoffSettings{
+			obsreport.ErrorTypeConnectionRefused: {Strategy: BackoffStrategyConstant, InitialInterval: 100 * time.Millisecond},
+		},
+	}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, []time.Duration{time.Second, 1500 * time.Millisecond}, nextIntervals(cfg.newBackoff(obsreport.ErrorTypeThrottled), 2))
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 100 * time.Millisecond}, nextIntervals(cfg.newBackoff(obsreport.ErrorTypeConnectionRefused), 2))
+}
+
+func TestRetrySettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RetrySettings
+		wantErr string
+	}{
+		{
+			name: "default",
+			cfg:  NewDefaultRetrySettings(),
+		},
+		{
+			name: "disabled",
+			cfg:  RetrySettings{Enabled: false, Strategy: "linear"},
+		},
+		{
+			name:    "strategy",
+			cfg:     RetrySettings{Enabled: true, Strategy: "linear"},
+			wantErr: `unsupported backoff strategy "linear", must be one of "exponential", "constant" or "fibonacci"`,
+		},
+		{
+			name:    "jitter",
+			cfg:     RetrySettings{Enabled: true, Jitter: "half"},
+			wantErr: `unsupported jitter "half", must be one of "none", "full", "equal" or "decorrelated"`,
+		},
+		{
+			name:    "multiplier",
+			cfg:     RetrySettings{Enabled: true, Multiplier: 0.5},
+			wantErr: "multiplier must be at least 1",
+		},
+		{
+			name:    "override_class",
+			cfg:     RetrySettings{Enabled: true, Overrides: map[string]BackoffSettings{"permanent": {}}},
+			wantErr: `unsupported error class "permanent" in overrides, must be one of "throttled", "connection_refused", "timeout" or "retryable"`,
+		},
+		{
+			name:    "override",
+			cfg:     RetrySettings{Enabled: true, Overrides: map[string]BackoffSettings{"throttled": {InitialInterval: -time.Second}}},
+			wantErr: `overrides "throttled": initial_interval must not be negative`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
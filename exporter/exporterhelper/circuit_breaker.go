@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerSettings defines configuration for the circuit breaker, which stops sending batches to a
+// persistently failing backend for a cooldown period instead of retrying them.
+type CircuitBreakerSettings struct {
+	// Enabled indicates whether to stop sending batches when the failure ratio is reached.
+	Enabled bool `mapstructure:"enabled"`
+	// FailureRatio is the ratio of failed sends in the window from which the circuit opens.
+	FailureRatio float64 `mapstructure:"failure_ratio"`
+	// MinRequests is the minimum number of sends in the window for the circuit to open.
+	MinRequests int `mapstructure:"min_requests"`
+	// Window is the duration of the windows over which the failure ratio is computed.
+	Window time.Duration `mapstructure:"window"`
+	// Cooldown is the time to wait after the circuit opens before a trial send.
+	Cooldown time.Duration `mapstructure:"cooldown"`
+	// FailFast indicates whether to fail the sends immediately while the circuit is open, the batches being
+	// handled as when their retries are exhausted, instead of waiting for the cooldown in the retry loop.
+	FailFast bool `mapstructure:"fail_fast"`
+}
+
+// NewDefaultCircuitBreakerSettings returns the default settings for CircuitBreakerSettings.
+func NewDefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		Enabled:      false,
+		FailureRatio: 0.5,
+		MinRequests:  10,
+		Window:       30 * time.Second,
+		Cooldown:     30 * time.Second,
+	}
+}
+
+// Validate checks if the CircuitBreakerSettings configuration is valid
+func (cbCfg *CircuitBreakerSettings) Validate() error {
+	if !cbCfg.Enabled {
+		return nil
+	}
+
+	if cbCfg.FailureRatio <= 0 || cbCfg.FailureRatio > 1 {
+		return errors.New("circuit breaker failure ratio must be in (0, 1]")
+	}
+	if cbCfg.MinRequests <= 0 {
+		return errors.New("circuit breaker min requests must be positive")
+	}
+	if cbCfg.Window <= 0 {
+		return errors.New("circuit breaker window must be positive")
+	}
+	if cbCfg.Cooldown <= 0 {
+		return errors.New("circuit breaker cooldown must be positive")
+	}
+
+	return nil
+}
+
+// isCircuitOpen returns true if the request failed with err was not sent because the circuit was open.
+func isCircuitOpen(err error) bool {
+	return errors.Is(err, errCircuitOpen)
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half_open"
+	}
+	return "closed"
+}
+
+// circuitBreaker tracks the failure ratio of the sends of an exporter. It opens when the ratio is reached in a
+// window, rejecting the sends for the cooldown period. A single trial send is then allowed while half-open,
+// closing the circuit on success, or opening it again on failure.
+type circuitBreaker struct {
+	cfg    CircuitBreakerSettings
+	logger *zap.Logger
+	now    func() time.Time
+	// stateChangesEntries are indexed by the circuitState entered.
+	stateChangesEntries [3]*metric.Int64CumulativeEntry
+
+	mu          sync.Mutex
+	state       circuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	trial       bool
+	// changed is closed and replaced on every state change.
+	changed chan struct{}
+}
+
+func newCircuitBreaker(id config.ComponentID, cbCfg CircuitBreakerSettings, logger *zap.Logger, insts *instruments) *circuitBreaker {
+	cb := &circuitBreaker{
+		cfg:     cbCfg,
+		logger:  logger,
+		now:     time.Now,
+		changed: make(chan struct{}),
+	}
+	for _, s := range []circuitState{circuitClosed, circuitOpen, circuitHalfOpen} {
+		cb.stateChangesEntries[s], _ = insts.circuitBreakerStateChanges.GetEntry(
+			metricdata.NewLabelValue(id.String()), metricdata.NewLabelValue(s.String()))
+	}
+	return cb
+}
+
+// allow returns true if a send can be attempted, or else the delay after which it should be attempted again.
+func (cb *circuitBreaker) allow() (time.Duration, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		if remaining := cb.cfg.Cooldown - cb.now().Sub(cb.openedAt); remaining > 0 {
+			return remaining, false
+		}
+		cb.setState(circuitHalfOpen)
+		cb.trial = true
+		return 0, true
+	case circuitHalfOpen:
+		if cb.trial {
+			return cb.cfg.Cooldown, false
+		}
+		cb.trial = true
+		return 0, true
+	}
+	return 0, true
+}
+
+// record records the outcome of an allowed send.
+func (cb *circuitBreaker) record(success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		// The send was allowed before the circuit opened.
+		return
+	case circuitHalfOpen:
+		cb.trial = false
+		if !success {
+			cb.open()
+			return
+		}
+		cb.windowStart, cb.requests, cb.failures = cb.now(), 0, 0
+		cb.setState(circuitClosed)
+		return
+	}
+
+	now := cb.now()
+	if now.Sub(cb.windowStart) >= cb.cfg.Window {
+		cb.windowStart, cb.requests, cb.failures = now, 0, 0
+	}
+	cb.requests++
+	if success {
+		return
+	}
+	cb.failures++
+	if cb.requests >= cb.cfg.MinRequests && float64(cb.failures) >= cb.cfg.FailureRatio*float64(cb.requests) {
+		cb.logger.Warn(
+			"Circuit breaker opened, the sends are stopped for the cooldown period.",
+			zap.Int("failures", cb.failures),
+			zap.Int("requests", cb.requests),
+			zap.Duration("cooldown", cb.cfg.Cooldown),
+		)
+		cb.open()
+	}
+}
+
+func (cb *circuitBreaker) open() {
+	cb.openedAt = cb.now()
+	cb.setState(circuitOpen)
+}
+
+func (cb *circuitBreaker) setState(state circuitState) {
+	switch {
+	case state == circuitHalfOpen:
+		cb.logger.Info("Circuit breaker half-open, trying a send.")
+	case state == circuitClosed:
+		cb.logger.Info("Circuit breaker closed, the sends are resumed.")
+	case cb.state == circuitHalfOpen:
+		cb.logger.Warn("Circuit breaker trial send failed, the sends are stopped for the cooldown period.",
+			zap.Duration("cooldown", cb.cfg.Cooldown))
+	}
+	cb.state = state
+	close(cb.changed)
+	cb.changed = make(chan struct{})
+	if entry := cb.stateChangesEntries[state]; entry != nil {
+		entry.Inc(1)
+	}
+}
+
+// wait blocks while the circuit is open, or half-open during the trial send, so that the queue consumers
+// leave the batches in the queue, or until stopCh is closed. It returns immediately if cb is nil.
+func (cb *circuitBreaker) wait(stopCh <-chan struct{}) {
+	if cb == nil {
+		return
+	}
+	for {
+		cb.mu.Lock()
+		state, trial, changed := cb.state, cb.trial, cb.changed
+		remaining := cb.cfg.Cooldown - cb.now().Sub(cb.openedAt)
+		cb.mu.Unlock()
+
+		switch {
+		case state == circuitClosed, state == circuitOpen && remaining <= 0, state == circuitHalfOpen && !trial:
+			return
+		case state == circuitOpen:
+			timer := time.NewTimer(remaining)
+			select {
+			case <-timer.C:
+			case <-changed:
+				timer.Stop()
+			case <-stopCh:
+				timer.Stop()
+				return
+			}
+		default:
+			select {
+			case <-changed:
+			case <-stopCh:
+				return
+			}
+		}
+	}
+}
+
+// failFast returns true if the sends fail immediately while the circuit is open. It returns false if cb is nil.
+func (cb *circuitBreaker) failFast() bool {
+	return cb != nil && cb.cfg.FailFast
+}
+
+// circuitBreakerSender is a request sender that rejects the requests while the circuit is open, with a
+// throttle error delaying their retry until the end of the cooldown. The permanent errors are not counted
+// as failures, the backend having processed the request.
+type circuitBreakerSender struct {
+	breaker    *circuitBreaker
+	nextSender requestSender
+}
+
+// send implements the requestSender interface
+func (cs *circuitBreakerSender) send(req request) error {
+	delay, ok := cs.breaker.allow()
+	if !ok {
+		return NewThrottleRetry(errCircuitOpen, delay)
+	}
+	err := cs.nextSender.send(req)
+	cs.breaker.record(err == nil || consumererror.IsPermanent(err))
+	return err
+}
@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestCircuitBreakerSettings_Validate(t *testing.T) {
+	cbCfg := NewDefaultCircuitBreakerSettings()
+	assert.NoError(t, cbCfg.Validate())
+
+	cbCfg.Enabled = true
+	assert.NoError(t, cbCfg.Validate())
+
+	cbCfg.FailureRatio = 1.5
+	assert.EqualError(t, cbCfg.Validate(), "circuit breaker failure ratio must be in (0, 1]")
+
+	cbCfg = NewDefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	cbCfg.MinRequests = 0
+	assert.EqualError(t, cbCfg.Validate(), "circuit breaker min requests must be positive")
+
+	cbCfg = NewDefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	cbCfg.Window = 0
+	assert.EqualError(t, cbCfg.Validate(), "circuit breaker window must be positive")
+
+	cbCfg = NewDefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	cbCfg.Cooldown = 0
+	assert.EqualError(t, cbCfg.Validate(), "circuit breaker cooldown must be positive")
+
+	// Not validated when disabled.
+	cbCfg.Enabled = false
+	assert.NoError(t, cbCfg.Validate())
+}
+
+func newTestCircuitBreaker(insts *instruments) (*circuitBreaker, *time.Time) {
+	cbCfg := NewDefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	cbCfg.MinRequests = 2
+	cbCfg.Window = 10 * time.Second
+	cbCfg.Cooldown = 5 * time.Second
+	now := time.Unix(1000, 0)
+	cb := newCircuitBreaker(defaultExporterCfg.ID(), cbCfg, zap.NewNop(), insts)
+	cb.now = func() time.Time { return now }
+	return cb, &now
+}
+
+func tagsForCircuitBreakerView(exporter config.ComponentID, state circuitState) []tag.Tag {
+	return append(tagsForExporterView(exporter), tag.Tag{Key: tag.MustNewKey("state"), Value: state.String()})
+}
+
+func TestCircuitBreaker_StateChanges(t *testing.T) {
+	insts := newInstruments(metric.NewRegistry())
+	cb, now := newTestCircuitBreaker(insts)
+
+	// Not opened below the minimum number of requests, nor on success.
+	cb.record(false)
+	cb.record(true)
+	_, ok := cb.allow()
+	assert.True(t, ok)
+
+	cb.record(false)
+	delay, ok := cb.allow()
+	assert.False(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	*now = now.Add(2 * time.Second)
+	delay, ok = cb.allow()
+	assert.False(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	// A single trial send is allowed after the cooldown, its failure opening the circuit again.
+	*now = now.Add(3 * time.Second)
+	_, ok = cb.allow()
+	assert.True(t, ok)
+	_, ok = cb.allow()
+	assert.False(t, ok)
+	cb.record(false)
+	delay, ok = cb.allow()
+	assert.False(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	*now = now.Add(5 * time.Second)
+	_, ok = cb.allow()
+	assert.True(t, ok)
+	cb.record(true)
+	_, ok = cb.allow()
+	assert.True(t, ok)
+
+	// The failures of the previous window are forgotten once closed.
+	cb.record(false)
+	_, ok = cb.allow()
+	assert.True(t, ok)
+
+	id := defaultExporterCfg.ID()
+	assert.True(t, checkValueForProducer(t, insts.registry, tagsForCircuitBreakerView(id, circuitOpen), 2, "exporter/circuit_breaker_state_changes"))
+	assert.True(t, checkValueForProducer(t, insts.registry, tagsForCircuitBreakerView(id, circuitHalfOpen), 2, "exporter/circuit_breaker_state_changes"))
+	assert.True(t, checkValueForProducer(t, insts.registry, tagsForCircuitBreakerView(id, circuitClosed), 1, "exporter/circuit_breaker_state_changes"))
+}
+
+func TestCircuitBreaker_Window(t *testing.T) {
+	cb, now := newTestCircuitBreaker(newInstruments(metric.NewRegistry()))
+
+	cb.record(false)
+	*now = now.Add(10 * time.Second)
+	cb.record(false)
+	_, ok := cb.allow()
+	assert.True(t, ok)
+
+	cb.record(false)
+	_, ok = cb.allow()
+	assert.False(t, ok)
+}
+
+func TestCircuitBreaker_Wait(t *testing.T) {
+	cb, now := newTestCircuitBreaker(newInstruments(metric.NewRegistry()))
+
+	// Does not block when closed, or when nil.
+	cb.wait(nil)
+	(*circuitBreaker)(nil).wait(nil)
+
+	cb.record(false)
+	cb.record(false)
+	stopCh := make(chan struct{})
+	close(stopCh)
+	cb.wait(stopCh)
+
+	// Blocks during the trial send until its outcome is recorded.
+	*now = now.Add(5 * time.Second)
+	_, ok := cb.allow()
+	require.True(t, ok)
+	done := make(chan struct{})
+	go func() {
+		cb.wait(make(chan struct{}))
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("wait returned during the trial send")
+	case <-time.After(10 * time.Millisecond):
+	}
+	cb.record(true)
+	<-done
+}
+
+func TestQueuedRetry_CircuitBreaker(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Enabled = false
+	rCfg := NewDefaultRetrySettings()
+	rCfg.Enabled = false
+	cbCfg := NewDefaultCircuitBreakerSettings()
+	cbCfg.Enabled = true
+	cbCfg.MinRequests = 2
+	cbCfg.FailureRatio = 0.6
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithQueue(qCfg), WithCircuitBreaker(cbCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	// The permanent errors are not counted as failures.
+	mockR := newMockRequest(context.Background(), 2, consumererror.NewPermanent(errors.New("bad data")))
+	require.Error(t, be.sender.send(mockR))
+	for i := 0; i < 2; i++ {
+		mockR = newMockRequest(context.Background(), 2, errors.New("transient error"))
+		require.Error(t, be.sender.send(mockR))
+		mockR.checkNumRequests(t, 1)
+	}
+
+	mockR = newMockRequest(context.Background(), 2, nil)
+	err := be.sender.send(mockR)
+	assert.True(t, isCircuitOpen(err))
+	mockR.checkNumRequests(t, 0)
+}
+
+func TestRetrySender_CircuitBreakerFailFast(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(newInstruments(metric.NewRegistry()))
+	cb.record(false)
+	cb.record(false)
+
+	var temporaryFailures int
+	rs := &retrySender{
+		cfg:        NewDefaultRetrySettings(),
+		nextSender: &circuitBreakerSender{breaker: cb, nextSender: &timeoutSender{}},
+		stopCh:     make(chan struct{}),
+		logger:     zap.NewNop(),
+		onTemporaryFailure: func(_ *zap.Logger, _ request, err error) error {
+			temporaryFailures++
+			return err
+		},
+		obsrep:  newRetryObsExporter(defaultExporterCfg.ID(), config.TracesDataType, newInstruments(metric.NewRegistry())),
+		breaker: cb,
+	}
+
+	// Without fail fast, the retry waits for the end of the cooldown.
+	close(rs.stopCh)
+	mockR := newMockRequest(context.Background(), 2, nil)
+	err := rs.send(mockR)
+	assert.Contains(t, err.Error(), "interrupted due to shutdown")
+	assert.True(t, isCircuitOpen(err))
+	assert.Zero(t, temporaryFailures)
+
+	cb.cfg.FailFast = true
+	err = rs.send(mockR)
+	assert.True(t, isCircuitOpen(err))
+	assert.Equal(t, 1, temporaryFailures)
+	mockR.checkNumRequests(t, 0)
+}
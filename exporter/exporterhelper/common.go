@@ -16,12 +16,17 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"go.uber.org/multierr"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerack"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/internal/memorypressure"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -50,6 +55,27 @@ type request interface {
 	onError(error) request
 	// Returns the count of spans/metric points or log records.
 	count() int
+	// enqueuedTime returns the time the data of the request was first accepted by the exporter,
+	// or the zero time if unknown.
+	enqueuedTime() time.Time
+	// setEnqueuedTime sets the time the data of the request was first accepted by the exporter.
+	setEnqueuedTime(enqueued time.Time)
+	// setIdempotencyKey sets the key identifying the request across its retries.
+	setIdempotencyKey(key string)
+	// partitionKey returns the key of the partition of the request when the ordered delivery is enabled.
+	partitionKey() uint64
+	// setPartitionKey sets the key of the partition of the request.
+	setPartitionKey(key uint64)
+	// holdAck holds the consumerack.Ack of the context of the request, if any, until releaseAck is called.
+	holdAck()
+	// releaseAck releases the hold of holdAck with the result of the processing of the request.
+	releaseAck(err error)
+	// bytesSize returns the estimated size in bytes of the data of the request, its marshaled size.
+	bytesSize() int
+	// accountBytes records the bytes in the memorypressure.Account until unaccountBytes is called.
+	accountBytes(account *memorypressure.Account, bytes int64)
+	// unaccountBytes removes the bytes recorded by accountBytes, if any, from the memorypressure.Account.
+	unaccountBytes()
 
 	// PersistentRequest provides interface with additional capabilities required by persistent queue
 	internal.PersistentRequest
@@ -63,7 +89,13 @@ type requestSender interface {
 // baseRequest is a base implementation for the request.
 type baseRequest struct {
 	ctx                        context.Context
+	enqueued                   time.Time
+	idempotencyKey             string
+	partition                  uint64
 	processingFinishedCallback func()
+	ackRelease                 func(error)
+	account                    *memorypressure.Account
+	accountedBytes             int64
 }
 
 func (req *baseRequest) context() context.Context {
@@ -74,6 +106,48 @@ func (req *baseRequest) setContext(ctx context.Context) {
 	req.ctx = ctx
 }
 
+func (req *baseRequest) enqueuedTime() time.Time {
+	return req.enqueued
+}
+
+func (req *baseRequest) setEnqueuedTime(enqueued time.Time) {
+	req.enqueued = enqueued
+}
+
+func (req *baseRequest) setIdempotencyKey(key string) {
+	req.idempotencyKey = key
+}
+
+func (req *baseRequest) partitionKey() uint64 {
+	return req.partition
+}
+
+func (req *baseRequest) setPartitionKey(key uint64) {
+	req.partition = key
+}
+
+func (req *baseRequest) holdAck() {
+	req.ackRelease = consumerack.Hold(req.ctx)
+}
+
+func (req *baseRequest) releaseAck(err error) {
+	if req.ackRelease != nil {
+		req.ackRelease(err)
+	}
+}
+
+func (req *baseRequest) accountBytes(account *memorypressure.Account, bytes int64) {
+	req.account, req.accountedBytes = account, bytes
+	account.Add(bytes)
+}
+
+func (req *baseRequest) unaccountBytes() {
+	if req.account != nil {
+		req.account.Add(-req.accountedBytes)
+		req.account, req.accountedBytes = nil, 0
+	}
+}
+
 func (req *baseRequest) SetOnProcessingFinished(callback func()) {
 	req.processingFinishedCallback = callback
 }
@@ -92,6 +166,12 @@ type baseSettings struct {
 	TimeoutSettings
 	QueueSettings
 	RetrySettings
+	WALSettings
+	DeadLetterSettings
+	CircuitBreakerSettings
+	idempotencyKeys bool
+	onSuccess       []SuccessFunc
+	onFailure       []FailureFunc
 }
 
 // fromOptions returns the internal options starting from the default and applying all configured options.
@@ -155,6 +235,56 @@ func WithQueue(queueSettings QueueSettings) Option {
 	}
 }
 
+// WithWAL overrides the default WALSettings for an exporter.
+// The default WALSettings is to disable the write-ahead log.
+func WithWAL(walSettings WALSettings) Option {
+	return func(o *baseSettings) {
+		o.WALSettings = walSettings
+	}
+}
+
+// WithDeadLetter overrides the default DeadLetterSettings for an exporter.
+// The default DeadLetterSettings is to drop the data failed permanently.
+func WithDeadLetter(deadLetterSettings DeadLetterSettings) Option {
+	return func(o *baseSettings) {
+		o.DeadLetterSettings = deadLetterSettings
+	}
+}
+
+// WithCircuitBreaker overrides the default CircuitBreakerSettings for an exporter.
+// The default CircuitBreakerSettings is to disable the circuit breaker.
+func WithCircuitBreaker(circuitBreakerSettings CircuitBreakerSettings) Option {
+	return func(o *baseSettings) {
+		o.CircuitBreakerSettings = circuitBreakerSettings
+	}
+}
+
+// SuccessFunc is called with the context of a request exported successfully and its number of items,
+// i.e. spans, metric data points or log records.
+type SuccessFunc func(ctx context.Context, items int)
+
+// FailureFunc is called with the context of a request failed to be exported, its number of items,
+// i.e. spans, metric data points or log records, and the error.
+type FailureFunc func(ctx context.Context, items int, err error)
+
+// WithOnSuccess adds a callback called after every request exported successfully, e.g. to implement a custom
+// accounting or to acknowledge the data to an upstream tracker. The callbacks are called in the order of the options.
+func WithOnSuccess(onSuccess SuccessFunc) Option {
+	return func(o *baseSettings) {
+		o.onSuccess = append(o.onSuccess, onSuccess)
+	}
+}
+
+// WithOnFailure adds a callback called after every request failed to be exported, once the retries are exhausted
+// or the error is permanent, whether the request is then dropped or sent to the dead letter exporter. The requests
+// rejected by a full sending queue are not reported, their error being returned to the caller. The callbacks are
+// called in the order of the options.
+func WithOnFailure(onFailure FailureFunc) Option {
+	return func(o *baseSettings) {
+		o.onFailure = append(o.onFailure, onFailure)
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -164,6 +294,14 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 	}
 }
 
+// newRequestIdempotencyKey returns the idempotency key of a new request, empty if disabled.
+func (bs *baseSettings) newRequestIdempotencyKey() string {
+	if !bs.idempotencyKeys {
+		return ""
+	}
+	return newIdempotencyKey()
+}
+
 // baseExporter contains common fields between different exporter types.
 type baseExporter struct {
 	component.StartFunc
@@ -171,6 +309,10 @@ type baseExporter struct {
 	obsrep   *obsExporter
 	sender   requestSender
 	qrSender *queuedRetrySender
+	wSender  *walSender
+	dlSender *deadLetterSender
+	// shutdownOnce makes the repeated Shutdown calls no-ops, the senders can only be stopped once.
+	shutdownOnce sync.Once
 }
 
 func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings, bs *baseSettings, signal config.DataType, reqUnmarshaler internal.RequestUnmarshaler) *baseExporter {
@@ -181,22 +323,82 @@ func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings,
 		ExporterID:             cfg.ID(),
 		ExporterCreateSettings: set,
 	}, globalInstruments)
-	be.qrSender = newQueuedRetrySender(cfg.ID(), signal, bs.QueueSettings, bs.RetrySettings, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, set.Logger)
+	var nextSender requestSender = &timeoutSender{cfg: bs.TimeoutSettings}
+	var breaker *circuitBreaker
+	if bs.CircuitBreakerSettings.Enabled {
+		breaker = newCircuitBreaker(cfg.ID(), bs.CircuitBreakerSettings, set.Logger, globalInstruments)
+		nextSender = &circuitBreakerSender{breaker: breaker, nextSender: nextSender}
+	}
+	queueUnmarshaler := reqUnmarshaler
+	if bs.WALSettings.Enabled {
+		be.wSender = newWALSender(cfg.ID(), signal, bs.WALSettings, reqUnmarshaler, nil, set.Logger)
+		// The persistent queue must restore the index of the write-ahead log records to acknowledge them.
+		queueUnmarshaler = be.wSender.unmarshalQueuedRequest
+	}
+	be.qrSender = newQueuedRetrySender(cfg.ID(), signal, bs.QueueSettings, bs.RetrySettings, queueUnmarshaler, nextSender, set.Logger)
 	be.sender = be.qrSender
+	if breaker != nil {
+		be.qrSender.breaker = breaker
+		// The consumer sender of a new queuedRetrySender is its retrySender, not wrapped yet.
+		be.qrSender.consumerSender.(*retrySender).breaker = breaker
+	}
+	if bs.DeadLetterSettings.enabled() {
+		be.dlSender = newDeadLetterSender(cfg.ID(), signal, bs.DeadLetterSettings, set.Logger, globalInstruments)
+		be.qrSender.consumerSender.(*retrySender).deadLetter = be.dlSender
+	}
+	// The write-ahead log already keeps the requests not exported before the shutdown.
+	if bs.QueueSettings.Enabled && bs.QueueSettings.SpillOnShutdown && !bs.WALSettings.Enabled {
+		be.qrSender.handoff = newQueueHandoff(cfg.ID(), signal, bs.QueueSettings, reqUnmarshaler, set.Logger)
+	}
+	if len(bs.onSuccess) > 0 || len(bs.onFailure) > 0 {
+		be.wrapConsumerSender(func(nextSender requestSender) requestSender {
+			return &callbackSender{onSuccess: bs.onSuccess, onFailure: bs.onFailure, nextSender: nextSender}
+		})
+	}
+	if be.wSender != nil {
+		be.wSender.nextSender = be.qrSender
+		be.qrSender.durable = true
+		be.wrapConsumerSender(be.wSender.wrapConsumerSender)
+		be.sender = be.wSender
+	}
 	be.StartFunc = func(ctx context.Context, host component.Host) error {
 		// First start the wrapped exporter.
 		if err := bs.StartFunc.Start(ctx, host); err != nil {
 			return err
 		}
 
+		if be.dlSender != nil {
+			if err := be.dlSender.start(host); err != nil {
+				return err
+			}
+		}
+
 		// If no error then start the queuedRetrySender.
-		return be.qrSender.start(ctx, host)
+		if err := be.qrSender.start(ctx, host); err != nil {
+			return err
+		}
+
+		// Last open the write-ahead log, replaying the not acknowledged requests.
+		if be.wSender != nil {
+			return be.wSender.start()
+		}
+		return nil
 	}
 	be.ShutdownFunc = func(ctx context.Context) error {
-		// First shutdown the queued retry sender
-		be.qrSender.shutdown()
-		// Last shutdown the wrapped exporter itself.
-		return bs.ShutdownFunc.Shutdown(ctx)
+		var errs error
+		be.shutdownOnce.Do(func() {
+			if be.wSender != nil {
+				be.wSender.beginShutdown()
+			}
+			// First shutdown the queued retry sender
+			errs = be.qrSender.shutdown(ctx)
+			if be.wSender != nil {
+				errs = multierr.Append(errs, be.wSender.shutdown())
+			}
+			// Last shutdown the wrapped exporter itself.
+			errs = multierr.Append(errs, bs.ShutdownFunc.Shutdown(ctx))
+		})
+		return errs
 	}
 	return be
 }
@@ -207,6 +409,29 @@ func (be *baseExporter) wrapConsumerSender(f func(consumer requestSender) reques
 	be.qrSender.consumerSender = f(be.qrSender.consumerSender)
 }
 
+// callbackSender is a request sender calling the success or failure callbacks with the result of the next sender.
+type callbackSender struct {
+	onSuccess  []SuccessFunc
+	onFailure  []FailureFunc
+	nextSender requestSender
+}
+
+// send implements the requestSender interface
+func (cs *callbackSender) send(req request) error {
+	items := req.count()
+	err := cs.nextSender.send(req)
+	if err != nil {
+		for _, onFailure := range cs.onFailure {
+			onFailure(req.context(), items, err)
+		}
+		return err
+	}
+	for _, onSuccess := range cs.onSuccess {
+		onSuccess(req.context(), items)
+	}
+	return nil
+}
+
 // timeoutSender is a request sender that adds a `timeout` to every request that passes this sender.
 type timeoutSender struct {
 	cfg TimeoutSettings
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// DeadLetterSettings defines the routing of the data failed permanently, or whose max_elapsed_time expired,
+// to another exporter instead of dropping it.
+type DeadLetterSettings struct {
+	// Exporter is the ID of the exporter receiving the failed data, e.g. a file exporter. It must be used by a
+	// pipeline of the same data type. Disabled when empty.
+	Exporter config.ComponentID `mapstructure:"exporter"`
+}
+
+func (dCfg *DeadLetterSettings) enabled() bool {
+	return dCfg.Exporter != config.ComponentID{}
+}
+
+// deadLettered is the error of the requests sent to the dead letter exporter.
+type deadLettered struct {
+	error
+}
+
+func (d deadLettered) Unwrap() error {
+	return d.error
+}
+
+// isDeadLettered returns true if the request failed with err was sent to the dead letter exporter.
+func isDeadLettered(err error) bool {
+	return errors.As(err, &deadLettered{})
+}
+
+// deadLetterSender sends the failed requests to the dead letter exporter.
+type deadLetterSender struct {
+	id         config.ComponentID
+	exporterID config.ComponentID
+	signal     config.DataType
+	logger     *zap.Logger
+	// next is the consumer.Traces, consumer.Metrics or consumer.Logs of the dead letter exporter, set at start.
+	next interface{}
+	// itemsEntry is nil when the data type of the exporter is unknown.
+	itemsEntry *metric.Int64CumulativeEntry
+}
+
+func newDeadLetterSender(id config.ComponentID, signal config.DataType, dCfg DeadLetterSettings, logger *zap.Logger, insts *instruments) *deadLetterSender {
+	ds := &deadLetterSender{
+		id:         id,
+		exporterID: dCfg.Exporter,
+		signal:     signal,
+		logger:     logger,
+	}
+	labelValue := metricdata.NewLabelValue(id.String())
+	switch signal {
+	case config.TracesDataType:
+		ds.itemsEntry, _ = insts.deadLetterTraceSpans.GetEntry(labelValue)
+	case config.MetricsDataType:
+		ds.itemsEntry, _ = insts.deadLetterMetricPoints.GetEntry(labelValue)
+	case config.LogsDataType:
+		ds.itemsEntry, _ = insts.deadLetterLogRecords.GetEntry(labelValue)
+	}
+	return ds
+}
+
+// start looks up the dead letter exporter, which must be used by a pipeline of the data type of the exporter.
+func (ds *deadLetterSender) start(host component.Host) error {
+	if ds.exporterID == ds.id {
+		return fmt.Errorf("the dead_letter exporter of %q cannot be itself", ds.id)
+	}
+	exp, ok := host.GetExporters()[ds.signal][ds.exporterID]
+	if !ok {
+		return fmt.Errorf("the dead_letter exporter %q of %q is not used by a %s pipeline", ds.exporterID, ds.id, ds.signal)
+	}
+	ds.next = exp
+	return nil
+}
+
+// send sends the request failed with err to the dead letter exporter, and returns err wrapped in a deadLettered
+// error on success, or err if the request could not be sent.
+func (ds *deadLetterSender) send(req request, err error) error {
+	data := req
+	if wr, ok := req.(*walRequest); ok {
+		data = wr.request
+	}
+	var dlErr error
+	switch r := data.(type) {
+	case *tracesRequest:
+		dlErr = ds.next.(consumer.Traces).ConsumeTraces(req.context(), r.td)
+	case *metricsRequest:
+		dlErr = ds.next.(consumer.Metrics).ConsumeMetrics(req.context(), r.md)
+	case *logsRequest:
+		dlErr = ds.next.(consumer.Logs).ConsumeLogs(req.context(), r.ld)
+	default:
+		dlErr = fmt.Errorf("unsupported request type %T", req)
+	}
+	if dlErr != nil {
+		ds.logger.Error(
+			"Exporting failed. Sending to the dead_letter exporter failed. Dropping data.",
+			zap.Error(err),
+			zap.NamedError("dead_letter_error", dlErr),
+			zap.Int("dropped_items", req.count()),
+		)
+		return err
+	}
+	if ds.itemsEntry != nil {
+		ds.itemsEntry.Inc(int64(req.count()))
+	}
+	ds.logger.Warn(
+		"Exporting failed. Data sent to the dead_letter exporter.",
+		zap.Error(err),
+		zap.String("dead_letter_exporter", ds.exporterID.String()),
+		zap.Int("dead_letter_items", req.count()),
+	)
+	return deadLettered{error: err}
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var deadLetterExporterID = config.NewComponentIDWithName("file", "dlq")
+
+// sinkExporter is an exporter consuming the traces and logs with the given consumers.
+type sinkExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	traces consumer.Traces
+	logs   consumer.Logs
+}
+
+func (se *sinkExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (se *sinkExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return se.traces.ConsumeTraces(ctx, td)
+}
+
+func (se *sinkExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return se.logs.ConsumeLogs(ctx, ld)
+}
+
+// exportersHost is a host with the given exporters.
+type exportersHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func newDeadLetterHost(exp component.Exporter) component.Host {
+	return exportersHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.TracesDataType: {deadLetterExporterID: exp},
+			config.LogsDataType:   {deadLetterExporterID: exp},
+		},
+	}
+}
+
+func (h exportersHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+func TestDeadLetter_PermanentError(t *testing.T) {
+	id := config.NewComponentIDWithName("test", "dead_letter_permanent")
+	cfg := config.NewExporterSettings(id)
+	sink := new(consumertest.TracesSink)
+	te, err := NewTracesExporter(&cfg, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(consumererror.NewPermanent(errors.New("bad request"))),
+		WithDeadLetter(DeadLetterSettings{Exporter: deadLetterExporterID}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(&sinkExporter{traces: sink})))
+	t.Cleanup(func() { require.NoError(t, te.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(2)
+	// The data sent to the dead letter exporter is handled.
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+	checkValueForGlobalManager(t, tagsForExporterView(id), 2, "exporter/dead_letter_spans")
+}
+
+func TestDeadLetter_MaxElapsedTime(t *testing.T) {
+	id := config.NewComponentIDWithName("test", "dead_letter_expired")
+	cfg := config.NewExporterSettings(id)
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = time.Millisecond
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	sink := new(consumertest.LogsSink)
+	le, err := NewLogsExporter(&cfg, componenttest.NewNopExporterCreateSettings(), newPushLogsData(errors.New("unavailable")),
+		WithRetry(rCfg), WithQueue(qCfg), WithDeadLetter(DeadLetterSettings{Exporter: deadLetterExporterID}))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), newDeadLetterHost(&sinkExporter{logs: sink})))
+
+	ld := testdata.GenerateLogs(3)
+	require.NoError(t, le.ConsumeLogs(context.Background(), ld))
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, time.Second, time.Millisecond)
+	require.NoError(t, le.Shutdown(context.Background()))
+	checkValueForGlobalManager(t, tagsForExporterView(id), 3, "exporter/dead_letter_log_records")
+}
+
+func TestDeadLetter_ConsumeError(t *testing.T) {
+	cfg := config.NewExporterSettings(config.NewComponentIDWithName("test", "dead_letter_error"))
+	sink := &sinkExporter{traces: consumertest.NewErr(errors.New("disk full"))}
+	permanentErr := consumererror.NewPermanent(errors.New("bad request"))
+	te, err := NewTracesExporter(&cfg, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(permanentErr),
+		WithDeadLetter(DeadLetterSettings{Exporter: deadLetterExporterID}))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), newDeadLetterHost(sink)))
+	t.Cleanup(func() { require.NoError(t, te.Shutdown(context.Background())) })
+
+	assert.Equal(t, permanentErr, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+}
+
+func TestDeadLetter_StartErrors(t *testing.T) {
+	cfg := config.NewExporterSettings(config.NewComponentIDWithName("test", "dead_letter_start"))
+	te, err := NewTracesExporter(&cfg, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(nil),
+		WithDeadLetter(DeadLetterSettings{Exporter: config.NewComponentID("unknown")}))
+	require.NoError(t, err)
+	assert.Error(t, te.Start(context.Background(), newDeadLetterHost(&sinkExporter{})))
+
+	te, err = NewTracesExporter(&cfg, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(nil),
+		WithDeadLetter(DeadLetterSettings{Exporter: cfg.ID()}))
+	require.NoError(t, err)
+	assert.Error(t, te.Start(context.Background(), newDeadLetterHost(&sinkExporter{})))
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// idempotencyKeyCtxKey is the key of the idempotency key of the request in the context passed to the pusher.
+type idempotencyKeyCtxKey struct{}
+
+// IdempotencyKeyFromContext returns the idempotency key of the request being exported, only set
+// by the exporters created WithIdempotencyKeys. The key is unique per request and stable across
+// its retries, including after a restart when the request is persisted in the queue or the WAL.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key, ok
+}
+
+func contextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// WithIdempotencyKeys enables or disables the generation of an idempotency key per request, available
+// to the pusher through IdempotencyKeyFromContext, so the destination can deduplicate the retried requests.
+// The default is to not generate idempotency keys.
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(o *baseSettings) {
+		o.idempotencyKeys = enabled
+	}
+}
+
+func newIdempotencyKey() string {
+	return uuid.NewString()
+}
+
+// partialIdempotencyKey returns the key of a request retrying the items which failed in a request with the
+// given key. The retried items are a different request, the destination having accepted the others.
+func partialIdempotencyKey(key string) string {
+	if key == "" {
+		return ""
+	}
+	return newIdempotencyKey()
+}
+
+const (
+	// persistedRequestMarker starts the persisted requests carrying an idempotency key or an enqueued time.
+	// It can't start a serialized protobuf message, since it would be the tag of a field with the invalid
+	// wire type 7, so the requests persisted as the raw protobuf message are still readable.
+	persistedRequestMarker byte = 0xff
+	// persistedRequestVersionKey only carries the idempotency key, persistedRequestVersion also carries
+	// the enqueued time of the request. The requests persisted with either version are readable.
+	persistedRequestVersionKey byte = 1
+	persistedRequestVersion    byte = 2
+	// persistedRequestVersionWAL prefixes a persisted request with the index of its record in the write-ahead log.
+	persistedRequestVersionWAL byte = 3
+)
+
+var errInvalidPersistedRequest = errors.New("invalid persisted request")
+
+// marshalPersistedRequest prefixes the serialized data of a request with its enqueued time and its
+// idempotency key, if any, so that the max elapsed time of the retries still accounts from the time
+// the request was first enqueued once it is read back from the persistent queue or the WAL.
+func marshalPersistedRequest(key string, enqueued time.Time, data []byte) []byte {
+	if key == "" && enqueued.IsZero() {
+		return data
+	}
+	var enqueuedNano uint64
+	if !enqueued.IsZero() {
+		enqueuedNano = uint64(enqueued.UnixNano())
+	}
+	buf := make([]byte, 0, 2+2*binary.MaxVarintLen64+len(key)+len(data))
+	buf = append(buf, persistedRequestMarker, persistedRequestVersion)
+	buf = appendUvarint(buf, enqueuedNano)
+	buf = appendUvarint(buf, uint64(len(key)))
+	buf = append(buf, key...)
+	return append(buf, data...)
+}
+
+// unmarshalPersistedRequest returns the idempotency key, the enqueued time and the serialized data of
+// a persisted request. The enqueued time is zero if it was not persisted with the request.
+func unmarshalPersistedRequest(buf []byte) (string, time.Time, []byte, error) {
+	if len(buf) == 0 || buf[0] != persistedRequestMarker {
+		return "", time.Time{}, buf, nil
+	}
+	// The index of the write-ahead log record is only relevant to the write-ahead log.
+	if _, data, ok, err := unmarshalWALPersistedRequest(buf); ok || err != nil {
+		if err != nil {
+			return "", time.Time{}, nil, err
+		}
+		return unmarshalPersistedRequest(data)
+	}
+	if len(buf) < 2 || (buf[1] != persistedRequestVersionKey && buf[1] != persistedRequestVersion) {
+		return "", time.Time{}, nil, errInvalidPersistedRequest
+	}
+	version := buf[1]
+	buf = buf[2:]
+	var enqueued time.Time
+	if version == persistedRequestVersion {
+		enqueuedNano, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return "", time.Time{}, nil, errInvalidPersistedRequest
+		}
+		if enqueuedNano != 0 {
+			enqueued = time.Unix(0, int64(enqueuedNano))
+		}
+		buf = buf[n:]
+	}
+	keyLen, n := binary.Uvarint(buf)
+	if n <= 0 || keyLen > uint64(len(buf)-n) {
+		return "", time.Time{}, nil, errInvalidPersistedRequest
+	}
+	return string(buf[n : n+int(keyLen)]), enqueued, buf[n+int(keyLen):], nil
+}
+
+// marshalWALPersistedRequest prefixes the persisted data of a request with the index of its record in the
+// write-ahead log, so the request read back from the persistent queue can still be acknowledged.
+func marshalWALPersistedRequest(index uint64, data []byte) []byte {
+	buf := make([]byte, 0, 2+binary.MaxVarintLen64+len(data))
+	buf = append(buf, persistedRequestMarker, persistedRequestVersionWAL)
+	buf = appendUvarint(buf, index)
+	return append(buf, data...)
+}
+
+// unmarshalWALPersistedRequest returns the index of the write-ahead log record and the persisted data of
+// a request, ok being false if the request was persisted without index.
+func unmarshalWALPersistedRequest(buf []byte) (index uint64, data []byte, ok bool, err error) {
+	if len(buf) < 2 || buf[0] != persistedRequestMarker || buf[1] != persistedRequestVersionWAL {
+		return 0, buf, false, nil
+	}
+	index, n := binary.Uvarint(buf[2:])
+	if n <= 0 {
+		return 0, nil, false, errInvalidPersistedRequest
+	}
+	return index, buf[2+n:], true, nil
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestPersistedRequest(t *testing.T) {
+	data, err := tracesMarshaler.MarshalTraces(testdata.GenerateTraces(2))
+	require.NoError(t, err)
+
+	// Without key nor enqueued time the data is persisted as is.
+	assert.Equal(t, data, marshalPersistedRequest("", time.Time{}, data))
+	key, enqueued, got, err := unmarshalPersistedRequest(data)
+	require.NoError(t, err)
+	assert.Empty(t, key)
+	assert.True(t, enqueued.IsZero())
+	assert.Equal(t, data, got)
+
+	now := time.Now()
+	buf := marshalPersistedRequest("6ba7b810-9dad-11d1-80b4-00c04fd430c8", now, data)
+	key, enqueued, got, err = unmarshalPersistedRequest(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", key)
+	assert.True(t, now.Equal(enqueued))
+	assert.Equal(t, data, got)
+
+	key, enqueued, got, err = unmarshalPersistedRequest(marshalPersistedRequest("", now, data))
+	require.NoError(t, err)
+	assert.Empty(t, key)
+	assert.True(t, now.Equal(enqueued))
+	assert.Equal(t, data, got)
+
+	key, enqueued, got, err = unmarshalPersistedRequest(marshalPersistedRequest("key", time.Time{}, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "key", key)
+	assert.True(t, enqueued.IsZero())
+	assert.Empty(t, got)
+
+	// The requests persisted with only the idempotency key are still readable.
+	key, enqueued, got, err = unmarshalPersistedRequest(append([]byte{persistedRequestMarker, persistedRequestVersionKey, 3, 'k', 'e', 'y'}, data...))
+	require.NoError(t, err)
+	assert.Equal(t, "key", key)
+	assert.True(t, enqueued.IsZero())
+	assert.Equal(t, data, got)
+
+	// The index of the write-ahead log record is skipped without the write-ahead log.
+	walBuf := marshalWALPersistedRequest(42, buf)
+	index, got, ok, err := unmarshalWALPersistedRequest(walBuf)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), index)
+	assert.Equal(t, buf, got)
+	key, enqueued, got, err = unmarshalPersistedRequest(walBuf)
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", key)
+	assert.True(t, now.Equal(enqueued))
+	assert.Equal(t, data, got)
+	_, got, ok, err = unmarshalWALPersistedRequest(data)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, data, got)
+
+	for _, invalid := range [][]byte{
+		{persistedRequestMarker},
+		{persistedRequestMarker, persistedRequestVersionWAL + 1, 0, 0},
+		{persistedRequestMarker, persistedRequestVersionWAL},
+		{persistedRequestMarker, persistedRequestVersionWAL, 1, persistedRequestMarker},
+		{persistedRequestMarker, persistedRequestVersion},
+		{persistedRequestMarker, persistedRequestVersion, 10},
+		{persistedRequestMarker, persistedRequestVersion, 10, 10, 'k'},
+		{persistedRequestMarker, persistedRequestVersionKey, 10, 'k'},
+	} {
+		_, _, _, err = unmarshalPersistedRequest(invalid)
+		assert.ErrorIs(t, err, errInvalidPersistedRequest)
+	}
+}
+
+func TestTracesRequest_IdempotencyKey(t *testing.T) {
+	td := testdata.GenerateTraces(2)
+	req := newTracesRequest(context.Background(), td, func(ctx context.Context, _ ptrace.Traces) error {
+		key, ok := IdempotencyKeyFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "key", key)
+		return nil
+	})
+	req.setIdempotencyKey("key")
+	require.NoError(t, req.export(context.Background()))
+
+	// The key survives the persistence of the request.
+	buf, err := req.Marshal()
+	require.NoError(t, err)
+	persisted, err := newTraceRequestUnmarshalerFunc(nil)(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "key", persisted.(*tracesRequest).idempotencyKey)
+	assert.True(t, req.enqueuedTime().Equal(persisted.(*tracesRequest).enqueuedTime()))
+	assert.Equal(t, td, persisted.(*tracesRequest).td)
+
+	// The items retried after a partial failure are a new request.
+	partial := req.onError(consumererror.NewTraces(errors.New("some error"), ptrace.NewTraces()))
+	assert.NotEmpty(t, partial.(*tracesRequest).idempotencyKey)
+	assert.NotEqual(t, "key", partial.(*tracesRequest).idempotencyKey)
+
+	// Without key, none is set in the context nor generated for the partial request.
+	req = newTracesRequest(context.Background(), td, func(ctx context.Context, _ ptrace.Traces) error {
+		_, ok := IdempotencyKeyFromContext(ctx)
+		assert.False(t, ok)
+		return nil
+	})
+	require.NoError(t, req.export(context.Background()))
+	partial = req.onError(consumererror.NewTraces(errors.New("some error"), ptrace.NewTraces()))
+	assert.Empty(t, partial.(*tracesRequest).idempotencyKey)
+}
+
+func TestTracesExporter_WithIdempotencyKeys(t *testing.T) {
+	rCfg := RetrySettings{Enabled: true, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, MaxElapsedTime: time.Minute}
+	var keys []string
+	pusher := func(ctx context.Context, _ ptrace.Traces) error {
+		key, ok := IdempotencyKeyFromContext(ctx)
+		require.True(t, ok)
+		keys = append(keys, key)
+		if len(keys)%3 != 0 {
+			return errors.New("transient error")
+		}
+		return nil
+	}
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, componenttest.NewNopExporterCreateSettings(), pusher, WithRetry(rCfg), WithIdempotencyKeys(true))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, te.Shutdown(context.Background())) })
+
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	// The key is stable across the retries of a request, and unique per request.
+	require.Len(t, keys, 6)
+	assert.Equal(t, []string{keys[0], keys[0], keys[0]}, keys[:3])
+	assert.Equal(t, []string{keys[3], keys[3], keys[3]}, keys[3:])
+	assert.NotEqual(t, keys[0], keys[3])
+}
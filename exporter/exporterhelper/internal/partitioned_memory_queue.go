@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2019 The Jaeger Authors.
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"sync"
+
+	"go.uber.org/atomic"
+)
+
+// partitionedMemoryQueue is a bounded memory queue delivering the items of the same partition sequentially,
+// in the order they were produced, while the different partitions are delivered in parallel. Every partition
+// is consumed by its own goroutine, the capacity being shared by all the partitions.
+type partitionedMemoryQueue struct {
+	stopWG        sync.WaitGroup
+	size          *atomic.Uint32
+	stopped       *atomic.Bool
+	partitions    []chan interface{}
+	partitionFunc func(item interface{}) uint64
+	onDroppedItem func(item interface{})
+	capacity      uint32
+}
+
+// NewPartitionedMemoryQueue constructs a new queue of the specified capacity with the given number of partitions,
+// the partition of the items being selected by the partitionFunc, and with an optional callback for dropped items.
+func NewPartitionedMemoryQueue(numPartitions int, capacity int, partitionFunc func(item interface{}) uint64, onDroppedItem func(item interface{})) ProducerConsumerQueue {
+	if numPartitions < 1 {
+		numPartitions = 1
+	}
+	partitions := make([]chan interface{}, numPartitions)
+	for i := range partitions {
+		partitions[i] = make(chan interface{}, capacity)
+	}
+	return &partitionedMemoryQueue{
+		size:          atomic.NewUint32(0),
+		stopped:       atomic.NewBool(false),
+		partitions:    partitions,
+		partitionFunc: partitionFunc,
+		onDroppedItem: onDroppedItem,
+		capacity:      uint32(capacity),
+	}
+}
+
+// StartConsumers starts one goroutine per partition consuming its items and passing them into the
+// consumer callback, the number of consumers being the number of partitions.
+func (q *partitionedMemoryQueue) StartConsumers(_ int, callback func(item interface{})) {
+	var startWG sync.WaitGroup
+	for _, partition := range q.partitions {
+		q.stopWG.Add(1)
+		startWG.Add(1)
+		go func(items chan interface{}) {
+			startWG.Done()
+			defer q.stopWG.Done()
+			for item := range items {
+				q.size.Sub(1)
+				callback(item)
+			}
+		}(partition)
+	}
+	startWG.Wait()
+}
+
+// Produce is used by the producer to submit new item to its partition. Returns false in case of queue overflow.
+func (q *partitionedMemoryQueue) Produce(item interface{}) bool {
+	if q.stopped.Load() || q.size.Load() >= q.capacity {
+		if q.onDroppedItem != nil {
+			q.onDroppedItem(item)
+		}
+		return false
+	}
+
+	q.size.Add(1)
+	select {
+	case q.partitions[q.partitionFunc(item)%uint64(len(q.partitions))] <- item:
+		return true
+	default:
+		// should not happen, as overflows should have been captured earlier
+		q.size.Sub(1)
+		if q.onDroppedItem != nil {
+			q.onDroppedItem(item)
+		}
+		return false
+	}
+}
+
+// Stop stops all consumers and releases the partitions channels. It blocks until all consumers have stopped.
+func (q *partitionedMemoryQueue) Stop() {
+	q.stopped.Store(true) // disable producer
+	for _, partition := range q.partitions {
+		close(partition)
+	}
+	q.stopWG.Wait()
+}
+
+// Size returns the current size of the queue, all partitions included.
+func (q *partitionedMemoryQueue) Size() int {
+	return int(q.size.Load())
+}
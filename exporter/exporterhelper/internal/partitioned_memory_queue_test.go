@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// Copyright (c) 2019 The Jaeger Authors.
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+package internal
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type partitionedItem struct {
+	partition uint64
+	seq       int
+}
+
+func partitionOf(item interface{}) uint64 {
+	return item.(partitionedItem).partition
+}
+
+func TestPartitionedMemoryQueueOrdering(t *testing.T) {
+	const numPartitions = 4
+	const numItems = 100
+	q := NewPartitionedMemoryQueue(numPartitions, numPartitions*numItems, partitionOf, nil)
+
+	var mu sync.Mutex
+	consumed := map[uint64][]int{}
+	var wg sync.WaitGroup
+	wg.Add(numPartitions * numItems)
+	q.StartConsumers(numPartitions, func(item interface{}) {
+		pi := item.(partitionedItem)
+		// Slow down the consumers to let the items of the partitions be interleaved.
+		time.Sleep(time.Duration(pi.seq%3) * time.Microsecond)
+		mu.Lock()
+		consumed[pi.partition] = append(consumed[pi.partition], pi.seq)
+		mu.Unlock()
+		wg.Done()
+	})
+
+	for seq := 0; seq < numItems; seq++ {
+		for p := uint64(0); p < numPartitions; p++ {
+			require.True(t, q.Produce(partitionedItem{partition: p, seq: seq}))
+		}
+	}
+	wg.Wait()
+	q.Stop()
+
+	require.Len(t, consumed, numPartitions)
+	for p, seqs := range consumed {
+		require.Len(t, seqs, numItems, "partition %d", p)
+		for i, seq := range seqs {
+			assert.Equal(t, i, seq, "partition %d", p)
+		}
+	}
+}
+
+func TestPartitionedMemoryQueueParallelPartitions(t *testing.T) {
+	q := NewPartitionedMemoryQueue(2, 10, partitionOf, nil)
+
+	blocked := make(chan struct{})
+	consumed := make(chan partitionedItem, 10)
+	q.StartConsumers(2, func(item interface{}) {
+		pi := item.(partitionedItem)
+		if pi.partition == 0 {
+			<-blocked
+		}
+		consumed <- pi
+	})
+
+	require.True(t, q.Produce(partitionedItem{partition: 0, seq: 0}))
+	require.True(t, q.Produce(partitionedItem{partition: 0, seq: 1}))
+	require.True(t, q.Produce(partitionedItem{partition: 1, seq: 0}))
+	require.True(t, q.Produce(partitionedItem{partition: 3, seq: 1}))
+
+	// The other partition is delivered while the first one is blocked.
+	assert.Equal(t, partitionedItem{partition: 1, seq: 0}, <-consumed)
+	assert.Equal(t, partitionedItem{partition: 3, seq: 1}, <-consumed)
+	// The first item of the blocked partition is being consumed.
+	assert.Eventually(t, func() bool { return q.Size() == 1 }, time.Second, time.Millisecond)
+
+	close(blocked)
+	assert.Equal(t, partitionedItem{partition: 0, seq: 0}, <-consumed)
+	assert.Equal(t, partitionedItem{partition: 0, seq: 1}, <-consumed)
+	q.Stop()
+	assert.Equal(t, 0, q.Size())
+}
+
+func TestPartitionedMemoryQueueFull(t *testing.T) {
+	var dropped []interface{}
+	q := NewPartitionedMemoryQueue(2, 2, partitionOf, func(item interface{}) {
+		dropped = append(dropped, item)
+	})
+
+	// The capacity is shared by the partitions.
+	assert.True(t, q.Produce(partitionedItem{partition: 0, seq: 0}))
+	assert.True(t, q.Produce(partitionedItem{partition: 1, seq: 0}))
+	assert.False(t, q.Produce(partitionedItem{partition: 0, seq: 1}))
+	assert.Equal(t, 2, q.Size())
+	assert.Equal(t, []interface{}{partitionedItem{partition: 0, seq: 1}}, dropped)
+
+	q.StartConsumers(2, func(item interface{}) {})
+	q.Stop()
+	assert.False(t, q.Produce(partitionedItem{partition: 0, seq: 2}))
+	assert.Len(t, dropped, 2)
+}
@@ -0,0 +1,387 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	walSegmentPrefix = "wal-"
+	walSegmentSuffix = ".log"
+	walAckFileName   = "wal.ack"
+
+	// walRecordHeaderSize is the size of the header preceding every record data:
+	// the record index (8 bytes), the data length (4 bytes) and the data CRC32 (4 bytes).
+	walRecordHeaderSize = 16
+)
+
+// walSegmentSize is the size from which the records are appended to a new segment.
+var walSegmentSize int64 = 16 << 20
+
+var errWALClosed = errors.New("write-ahead log is closed")
+
+// WALRecord is a record stored in the WAL.
+type WALRecord struct {
+	Index uint64
+	Data  []byte
+}
+
+// walSegment is a file of the log, named after the index of its first record.
+type walSegment struct {
+	firstIndex uint64
+	path       string
+}
+
+// WAL is a write-ahead log of records. Every appended record is synced to disk before
+// Append returns, and stays in the log until acknowledged. Records that were appended
+// but not acknowledged before the WAL was closed are returned by Pending once reopened.
+//
+// Records are acknowledged in any order. The index of the first not acknowledged record
+// is persisted, so that acknowledged records are not returned by Pending. The log is split
+// in segments of about walSegmentSize bytes, removed once all their records are acknowledged,
+// so that the log does not grow while records are always in flight.
+type WAL struct {
+	dir string
+
+	mu sync.Mutex
+	// segments are the full segments preceding the active one, oldest first.
+	segments []walSegment
+	// active is the segment the records are appended to.
+	active walSegment
+	file   *os.File
+	size   int64
+	// nextIndex is the index of the next appended record.
+	nextIndex uint64
+	// firstUnacked is the index of the first record that was not acknowledged.
+	firstUnacked uint64
+	// acked contains the acknowledged records indexes greater than firstUnacked.
+	acked map[uint64]struct{}
+	// pending are the records loaded when the WAL was opened and not acknowledged.
+	pending []WALRecord
+}
+
+// OpenWAL opens, or creates if it does not exist, the WAL stored in the given directory.
+func OpenWAL(dir string) (*WAL, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create write-ahead log directory: %w", err)
+	}
+
+	firstUnacked, err := readWALAckIndex(filepath.Join(dir, walAckFileName))
+	if err != nil {
+		return nil, err
+	}
+	segments, err := listWALSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WAL{
+		dir:          dir,
+		nextIndex:    firstUnacked,
+		firstUnacked: firstUnacked,
+		acked:        make(map[uint64]struct{}),
+	}
+	var validSize int64
+	for _, segment := range segments {
+		var records []WALRecord
+		if records, validSize, err = readWALSegment(segment.path); err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			if r.Index >= firstUnacked {
+				w.pending = append(w.pending, r)
+			}
+			if r.Index >= w.nextIndex {
+				w.nextIndex = r.Index + 1
+			}
+		}
+	}
+
+	if len(segments) == 0 {
+		segments = []walSegment{newWALSegment(dir, w.nextIndex)}
+		validSize = 0
+	}
+	w.segments = segments[:len(segments)-1]
+	w.active = segments[len(segments)-1]
+	if w.file, err = os.OpenFile(w.active.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600); err != nil {
+		return nil, fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+	// Drop any partially written record at the end of the log, e.g. after a crash while appending.
+	if err = w.file.Truncate(validSize); err != nil {
+		_ = w.file.Close()
+		return nil, fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+	w.size = validSize
+	if err = w.removeAckedSegments(); err != nil {
+		_ = w.file.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Pending returns the records that were not acknowledged when the WAL was last closed.
+// Subsequent calls return no records.
+func (w *WAL) Pending() []WALRecord {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	pending := w.pending
+	w.pending = nil
+	return pending
+}
+
+// Append adds a record with the given data to the log, and syncs it to disk.
+// Returns the index of the record to use to acknowledge it.
+func (w *WAL) Append(data []byte) (uint64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return 0, errWALClosed
+	}
+	if w.size >= walSegmentSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	index := w.nextIndex
+	buf := make([]byte, walRecordHeaderSize+len(data))
+	binary.LittleEndian.PutUint64(buf[0:8], index)
+	binary.LittleEndian.PutUint32(buf[8:12], uint32(len(data)))
+	binary.LittleEndian.PutUint32(buf[12:16], crc32.ChecksumIEEE(data))
+	copy(buf[walRecordHeaderSize:], data)
+
+	if _, err := w.file.Write(buf); err != nil {
+		return 0, fmt.Errorf("failed to write to write-ahead log: %w", err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+	w.size += int64(len(buf))
+	w.nextIndex++
+	return index, nil
+}
+
+// rotate closes the active segment and creates a new one starting at the next index.
+func (w *WAL) rotate() error {
+	segment := newWALSegment(w.dir, w.nextIndex)
+	file, err := os.OpenFile(segment.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create write-ahead log segment: %w", err)
+	}
+	if err = w.file.Close(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to close write-ahead log segment: %w", err)
+	}
+	w.segments = append(w.segments, w.active)
+	w.active = segment
+	w.file = file
+	w.size = 0
+	return nil
+}
+
+// Ack acknowledges the record with the given index, which will not be returned by Pending anymore.
+func (w *WAL) Ack(index uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return errWALClosed
+	}
+	if index < w.firstUnacked || index >= w.nextIndex {
+		return nil
+	}
+
+	w.acked[index] = struct{}{}
+	if index != w.firstUnacked {
+		return nil
+	}
+	for {
+		if _, ok := w.acked[w.firstUnacked]; !ok {
+			break
+		}
+		delete(w.acked, w.firstUnacked)
+		w.firstUnacked++
+	}
+	if err := writeWALAckIndex(filepath.Join(w.dir, walAckFileName), w.firstUnacked); err != nil {
+		return err
+	}
+	if err := w.removeAckedSegments(); err != nil {
+		return err
+	}
+
+	if w.firstUnacked == w.nextIndex && w.size > 0 {
+		// All records are acknowledged, the content of the active segment is not needed anymore.
+		if err := w.file.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+		}
+		w.size = 0
+	}
+	return nil
+}
+
+// removeAckedSegments removes the full segments whose records are all acknowledged, i.e. the
+// segments followed by a segment starting at or before the first not acknowledged record.
+func (w *WAL) removeAckedSegments() error {
+	for len(w.segments) > 0 {
+		next := w.active
+		if len(w.segments) > 1 {
+			next = w.segments[1]
+		}
+		if next.firstIndex > w.firstUnacked {
+			return nil
+		}
+		if err := os.Remove(w.segments[0].path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove write-ahead log segment: %w", err)
+		}
+		w.segments = w.segments[1:]
+	}
+	return nil
+}
+
+// Close closes the WAL, records that were not acknowledged are kept.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func newWALSegment(dir string, firstIndex uint64) walSegment {
+	return walSegment{
+		firstIndex: firstIndex,
+		path:       filepath.Join(dir, fmt.Sprintf("%s%020d%s", walSegmentPrefix, firstIndex, walSegmentSuffix)),
+	}
+}
+
+// listWALSegments returns the segments of the log stored in the directory, oldest first.
+func listWALSegments(dir string) ([]walSegment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list write-ahead log segments: %w", err)
+	}
+	var segments []walSegment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, walSegmentPrefix) || !strings.HasSuffix(name, walSegmentSuffix) {
+			continue
+		}
+		firstIndex, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, walSegmentPrefix), walSegmentSuffix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid write-ahead log segment %q", name)
+		}
+		segments = append(segments, newWALSegment(dir, firstIndex))
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].firstIndex < segments[j].firstIndex })
+	return segments, nil
+}
+
+// readWALSegment reads all the valid records of the segment file, and returns them along
+// with the size of the file containing valid records.
+func readWALSegment(path string) ([]WALRecord, int64, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read write-ahead log: %w", err)
+	}
+	defer file.Close()
+	return readWALRecords(file)
+}
+
+// readWALRecords reads all the valid records in the file, and returns them along
+// with the size of the file containing valid records.
+func readWALRecords(file *os.File) ([]WALRecord, int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, fmt.Errorf("failed to read write-ahead log: %w", err)
+	}
+
+	var records []WALRecord
+	var validSize int64
+	reader := bufio.NewReader(file)
+	header := make([]byte, walRecordHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, validSize, nil
+			}
+			return nil, 0, fmt.Errorf("failed to read write-ahead log: %w", err)
+		}
+		data := make([]byte, binary.LittleEndian.Uint32(header[8:12]))
+		if _, err := io.ReadFull(reader, data); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, validSize, nil
+			}
+			return nil, 0, fmt.Errorf("failed to read write-ahead log: %w", err)
+		}
+		if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(header[12:16]) {
+			// Corrupted record, consider the rest of the log as not written.
+			return records, validSize, nil
+		}
+		records = append(records, WALRecord{Index: binary.LittleEndian.Uint64(header[0:8]), Data: data})
+		validSize += int64(walRecordHeaderSize + len(data))
+	}
+}
+
+func readWALAckIndex(path string) (uint64, error) {
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read write-ahead log acknowledgments: %w", err)
+	}
+	if len(buf) != 8 {
+		return 0, fmt.Errorf("invalid write-ahead log acknowledgments file %q", path)
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// writeWALAckIndex atomically replaces the persisted index of the first not acknowledged record.
+func writeWALAckIndex(path string, index uint64) error {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, index)
+
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write write-ahead log acknowledgments: %w", err)
+	}
+	if _, err = file.Write(buf); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write write-ahead log acknowledgments: %w", err)
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write write-ahead log acknowledgments: %w", err)
+	}
+	return nil
+}
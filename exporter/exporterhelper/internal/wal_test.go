@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAL_ReplayNotAcked(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Empty(t, wal.Pending())
+
+	for _, data := range []string{"first", "second", "third"} {
+		_, err = wal.Append([]byte(data))
+		require.NoError(t, err)
+	}
+	// Acknowledge out of order, only the first record stays in the log.
+	require.NoError(t, wal.Ack(2))
+	require.NoError(t, wal.Ack(1))
+	require.NoError(t, wal.Close())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []WALRecord{{Index: 0, Data: []byte("first")}, {Index: 1, Data: []byte("second")}, {Index: 2, Data: []byte("third")}}, wal.Pending())
+	require.NoError(t, wal.Close())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	pending := wal.Pending()
+	assert.Len(t, pending, 3)
+	assert.Empty(t, wal.Pending())
+	require.NoError(t, wal.Ack(0))
+	require.NoError(t, wal.Ack(1))
+	require.NoError(t, wal.Close())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []WALRecord{{Index: 2, Data: []byte("third")}}, wal.Pending())
+	index, err := wal.Append([]byte("fourth"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, index)
+	require.NoError(t, wal.Close())
+}
+
+func TestWAL_TruncateWhenAllAcked(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := OpenWAL(dir)
+	require.NoError(t, err)
+
+	index, err := wal.Append([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, wal.Ack(index))
+	// Acknowledging twice or unknown records is a no-op.
+	require.NoError(t, wal.Ack(index))
+	require.NoError(t, wal.Ack(10))
+	require.NoError(t, wal.Close())
+
+	info, err := os.Stat(newWALSegment(dir, 0).path)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, info.Size())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Empty(t, wal.Pending())
+	index, err = wal.Append([]byte("data"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, index)
+	require.NoError(t, wal.Close())
+}
+
+func TestWAL_RemoveAckedSegments(t *testing.T) {
+	segmentSize := walSegmentSize
+	walSegmentSize = 2 * (walRecordHeaderSize + 4)
+	t.Cleanup(func() { walSegmentSize = segmentSize })
+
+	dir := t.TempDir()
+	wal, err := OpenWAL(dir)
+	require.NoError(t, err)
+
+	// Under a steady load a record is always in flight, the acknowledged segments are removed anyway.
+	inFlight, err := wal.Append([]byte("data"))
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		var index uint64
+		index, err = wal.Append([]byte("data"))
+		require.NoError(t, err)
+		require.NoError(t, wal.Ack(inFlight))
+		inFlight = index
+	}
+	segments, err := listWALSegments(dir)
+	require.NoError(t, err)
+	// Only the segment of the record in flight is kept.
+	assert.Equal(t, []walSegment{newWALSegment(dir, 10)}, segments)
+	require.NoError(t, wal.Close())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []WALRecord{{Index: 10, Data: []byte("data")}}, wal.Pending())
+	index, err := wal.Append([]byte("next"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, index)
+	require.NoError(t, wal.Ack(10))
+	require.NoError(t, wal.Ack(11))
+	segments, err = listWALSegments(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []walSegment{newWALSegment(dir, 10)}, segments)
+	require.NoError(t, wal.Close())
+}
+
+func TestWAL_DropTornRecord(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := OpenWAL(dir)
+	require.NoError(t, err)
+	_, err = wal.Append([]byte("complete"))
+	require.NoError(t, err)
+	_, err = wal.Append([]byte("torn"))
+	require.NoError(t, err)
+	require.NoError(t, wal.Close())
+
+	path := newWALSegment(dir, 0).path
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-2))
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []WALRecord{{Index: 0, Data: []byte("complete")}}, wal.Pending())
+	index, err := wal.Append([]byte("next"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, index)
+	require.NoError(t, wal.Close())
+
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []WALRecord{{Index: 0, Data: []byte("complete")}, {Index: 1, Data: []byte("next")}}, wal.Pending())
+	require.NoError(t, wal.Close())
+}
+
+func TestWAL_Closed(t *testing.T) {
+	wal, err := OpenWAL(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, wal.Close())
+	require.NoError(t, wal.Close())
+
+	_, err = wal.Append([]byte("data"))
+	assert.ErrorIs(t, err, errWALClosed)
+	assert.ErrorIs(t, wal.Ack(0), errWALClosed)
+}
+
+func TestWAL_InvalidAckFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, walAckFileName), []byte("invalid"), 0600))
+	_, err := OpenWAL(dir)
+	assert.Error(t, err)
+}
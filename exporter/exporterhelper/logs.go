@@ -17,6 +17,9 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"time"
+
+	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,6 +30,7 @@ import (
 )
 
 var logsMarshaler = plog.NewProtoMarshaler()
+var logsSizer = logsMarshaler.(plog.Sizer)
 var logsUnmarshaler = plog.NewProtoUnmarshaler()
 
 type logsRequest struct {
@@ -37,7 +41,7 @@ type logsRequest struct {
 
 func newLogsRequest(ctx context.Context, ld plog.Logs, pusher consumer.ConsumeLogsFunc) request {
 	return &logsRequest{
-		baseRequest: baseRequest{ctx: ctx},
+		baseRequest: baseRequest{ctx: ctx, enqueued: time.Now()},
 		ld:          ld,
 		pusher:      pusher,
 	}
@@ -45,34 +49,56 @@ func newLogsRequest(ctx context.Context, ld plog.Logs, pusher consumer.ConsumeLo
 
 func newLogsRequestUnmarshalerFunc(pusher consumer.ConsumeLogsFunc) internal.RequestUnmarshaler {
 	return func(bytes []byte) (internal.PersistentRequest, error) {
-		logs, err := logsUnmarshaler.UnmarshalLogs(bytes)
+		key, enqueued, data, err := unmarshalPersistedRequest(bytes)
+		if err != nil {
+			return nil, err
+		}
+		logs, err := logsUnmarshaler.UnmarshalLogs(data)
 		if err != nil {
 			return nil, err
 		}
-		return newLogsRequest(context.Background(), logs, pusher), nil
+		req := newLogsRequest(context.Background(), logs, pusher)
+		req.setIdempotencyKey(key)
+		// The requests persisted without enqueued time are accounted from the time they are read.
+		if !enqueued.IsZero() {
+			req.setEnqueuedTime(enqueued)
+		}
+		return req, nil
 	}
 }
 
 func (req *logsRequest) onError(err error) request {
 	var logError consumererror.Logs
 	if errors.As(err, &logError) {
-		return newLogsRequest(req.ctx, logError.GetLogs(), req.pusher)
+		return &logsRequest{
+			baseRequest: baseRequest{ctx: req.ctx, enqueued: req.enqueued, idempotencyKey: partialIdempotencyKey(req.idempotencyKey), partition: req.partition},
+			ld:          logError.GetLogs(),
+			pusher:      req.pusher,
+		}
 	}
 	return req
 }
 
 func (req *logsRequest) export(ctx context.Context) error {
-	return req.pusher(ctx, req.ld)
+	return req.pusher(contextWithIdempotencyKey(ctx, req.idempotencyKey), req.ld)
 }
 
 func (req *logsRequest) Marshal() ([]byte, error) {
-	return logsMarshaler.MarshalLogs(req.ld)
+	data, err := logsMarshaler.MarshalLogs(req.ld)
+	if err != nil {
+		return nil, err
+	}
+	return marshalPersistedRequest(req.idempotencyKey, req.enqueued, data), nil
 }
 
 func (req *logsRequest) count() int {
 	return req.ld.LogRecordCount()
 }
 
+func (req *logsRequest) bytesSize() int {
+	return logsSizer.LogsSize(req.ld)
+}
+
 type logsExporter struct {
 	*baseExporter
 	consumer.Logs
@@ -98,6 +124,9 @@ func NewLogsExporter(
 	}
 
 	bs := fromOptions(options...)
+	if err := bs.RetrySettings.Validate(); err != nil {
+		return nil, err
+	}
 	be := newBaseExporter(cfg, set, bs, config.LogsDataType, newLogsRequestUnmarshalerFunc(pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &logsExporterWithObservability{
@@ -106,13 +135,33 @@ func NewLogsExporter(
 		}
 	})
 
-	lc, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+	send := func(ctx context.Context, ld plog.Logs, partition uint64) error {
 		req := newLogsRequest(ctx, ld, pusher)
+		req.setIdempotencyKey(bs.newRequestIdempotencyKey())
+		req.setPartitionKey(partition)
 		err := be.sender.send(req)
 		if errors.Is(err, errSendingQueueIsFull) {
 			be.obsrep.recordLogsEnqueueFailure(req.context(), int64(req.count()))
 		}
 		return err
+	}
+	lc, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if !bs.QueueSettings.orderedDelivery() {
+			return send(ctx, ld, 0)
+		}
+		// Every resource is sent in its own request, in the partition of the resource.
+		rss := ld.ResourceLogs()
+		if rss.Len() == 1 {
+			return send(ctx, ld, resourcePartitionKey(rss.At(0).Resource()))
+		}
+		var errs error
+		for i := 0; i < rss.Len(); i++ {
+			// The data is copied since it may be shared with other consumers.
+			rld := plog.NewLogs()
+			rss.At(i).CopyTo(rld.ResourceLogs().AppendEmpty())
+			errs = multierr.Append(errs, send(ctx, rld, resourcePartitionKey(rss.At(i).Resource())))
+		}
+		return errs
 	}, bs.consumerOptions...)
 
 	return &logsExporter{
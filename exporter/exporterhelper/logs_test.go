@@ -51,11 +51,9 @@ func TestLogsRequest(t *testing.T) {
 	lr := newLogsRequest(context.Background(), testdata.GenerateLogs(1), nil)
 
 	logErr := consumererror.NewLogs(errors.New("some error"), plog.NewLogs())
-	assert.EqualValues(
-		t,
-		newLogsRequest(context.Background(), plog.NewLogs(), nil),
-		lr.onError(logErr),
-	)
+	partial := lr.onError(logErr)
+	assert.EqualValues(t, plog.NewLogs(), partial.(*logsRequest).ld)
+	assert.Equal(t, lr.enqueuedTime(), partial.enqueuedTime())
 }
 
 func TestLogsExporter_InvalidName(t *testing.T) {
@@ -76,6 +74,14 @@ func TestLogsExporter_NilPushLogsData(t *testing.T) {
 	require.Equal(t, errNilPushLogsData, err)
 }
 
+func TestLogsExporter_InvalidRetrySettings(t *testing.T) {
+	rCfg := NewDefaultRetrySettings()
+	rCfg.Strategy = "linear"
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(nil), WithRetry(rCfg))
+	require.Nil(t, le)
+	require.EqualError(t, err, `unsupported backoff strategy "linear", must be one of "exponential", "constant" or "fibonacci"`)
+}
+
 func TestLogsExporter_Default(t *testing.T) {
 	ld := plog.NewLogs()
 	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(nil))
@@ -17,6 +17,9 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"time"
+
+	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,6 +30,7 @@ import (
 )
 
 var metricsMarshaler = pmetric.NewProtoMarshaler()
+var metricsSizer = metricsMarshaler.(pmetric.Sizer)
 var metricsUnmarshaler = pmetric.NewProtoUnmarshaler()
 
 type metricsRequest struct {
@@ -37,7 +41,7 @@ type metricsRequest struct {
 
 func newMetricsRequest(ctx context.Context, md pmetric.Metrics, pusher consumer.ConsumeMetricsFunc) request {
 	return &metricsRequest{
-		baseRequest: baseRequest{ctx: ctx},
+		baseRequest: baseRequest{ctx: ctx, enqueued: time.Now()},
 		md:          md,
 		pusher:      pusher,
 	}
@@ -45,35 +49,57 @@ func newMetricsRequest(ctx context.Context, md pmetric.Metrics, pusher consumer.
 
 func newMetricsRequestUnmarshalerFunc(pusher consumer.ConsumeMetricsFunc) internal.RequestUnmarshaler {
 	return func(bytes []byte) (internal.PersistentRequest, error) {
-		metrics, err := metricsUnmarshaler.UnmarshalMetrics(bytes)
+		key, enqueued, data, err := unmarshalPersistedRequest(bytes)
+		if err != nil {
+			return nil, err
+		}
+		metrics, err := metricsUnmarshaler.UnmarshalMetrics(data)
 		if err != nil {
 			return nil, err
 		}
-		return newMetricsRequest(context.Background(), metrics, pusher), nil
+		req := newMetricsRequest(context.Background(), metrics, pusher)
+		req.setIdempotencyKey(key)
+		// The requests persisted without enqueued time are accounted from the time they are read.
+		if !enqueued.IsZero() {
+			req.setEnqueuedTime(enqueued)
+		}
+		return req, nil
 	}
 }
 
 func (req *metricsRequest) onError(err error) request {
 	var metricsError consumererror.Metrics
 	if errors.As(err, &metricsError) {
-		return newMetricsRequest(req.ctx, metricsError.GetMetrics(), req.pusher)
+		return &metricsRequest{
+			baseRequest: baseRequest{ctx: req.ctx, enqueued: req.enqueued, idempotencyKey: partialIdempotencyKey(req.idempotencyKey), partition: req.partition},
+			md:          metricsError.GetMetrics(),
+			pusher:      req.pusher,
+		}
 	}
 	return req
 }
 
 func (req *metricsRequest) export(ctx context.Context) error {
-	return req.pusher(ctx, req.md)
+	return req.pusher(contextWithIdempotencyKey(ctx, req.idempotencyKey), req.md)
 }
 
 // Marshal provides serialization capabilities required by persistent queue
 func (req *metricsRequest) Marshal() ([]byte, error) {
-	return metricsMarshaler.MarshalMetrics(req.md)
+	data, err := metricsMarshaler.MarshalMetrics(req.md)
+	if err != nil {
+		return nil, err
+	}
+	return marshalPersistedRequest(req.idempotencyKey, req.enqueued, data), nil
 }
 
 func (req *metricsRequest) count() int {
 	return req.md.DataPointCount()
 }
 
+func (req *metricsRequest) bytesSize() int {
+	return metricsSizer.MetricsSize(req.md)
+}
+
 type metricsExporter struct {
 	*baseExporter
 	consumer.Metrics
@@ -99,6 +125,9 @@ func NewMetricsExporter(
 	}
 
 	bs := fromOptions(options...)
+	if err := bs.RetrySettings.Validate(); err != nil {
+		return nil, err
+	}
 	be := newBaseExporter(cfg, set, bs, config.MetricsDataType, newMetricsRequestUnmarshalerFunc(pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &metricsSenderWithObservability{
@@ -107,13 +136,33 @@ func NewMetricsExporter(
 		}
 	})
 
-	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+	send := func(ctx context.Context, md pmetric.Metrics, partition uint64) error {
 		req := newMetricsRequest(ctx, md, pusher)
+		req.setIdempotencyKey(bs.newRequestIdempotencyKey())
+		req.setPartitionKey(partition)
 		err := be.sender.send(req)
 		if errors.Is(err, errSendingQueueIsFull) {
 			be.obsrep.recordMetricsEnqueueFailure(req.context(), int64(req.count()))
 		}
 		return err
+	}
+	mc, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if !bs.QueueSettings.orderedDelivery() {
+			return send(ctx, md, 0)
+		}
+		// Every resource is sent in its own request, in the partition of the resource.
+		rss := md.ResourceMetrics()
+		if rss.Len() == 1 {
+			return send(ctx, md, resourcePartitionKey(rss.At(0).Resource()))
+		}
+		var errs error
+		for i := 0; i < rss.Len(); i++ {
+			// The data is copied since it may be shared with other consumers.
+			rmd := pmetric.NewMetrics()
+			rss.At(i).CopyTo(rmd.ResourceMetrics().AppendEmpty())
+			errs = multierr.Append(errs, send(ctx, rmd, resourcePartitionKey(rss.At(i).Resource())))
+		}
+		return errs
 	}, bs.consumerOptions...)
 
 	return &metricsExporter{
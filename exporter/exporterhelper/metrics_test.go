@@ -50,11 +50,9 @@ func TestMetricsRequest(t *testing.T) {
 	mr := newMetricsRequest(context.Background(), testdata.GenerateMetrics(1), nil)
 
 	metricsErr := consumererror.NewMetrics(errors.New("some error"), pmetric.NewMetrics())
-	assert.EqualValues(
-		t,
-		newMetricsRequest(context.Background(), pmetric.NewMetrics(), nil),
-		mr.onError(metricsErr),
-	)
+	partial := mr.onError(metricsErr)
+	assert.EqualValues(t, pmetric.NewMetrics(), partial.(*metricsRequest).md)
+	assert.Equal(t, mr.enqueuedTime(), partial.enqueuedTime())
 }
 
 func TestMetricsExporter_InvalidName(t *testing.T) {
@@ -75,6 +73,14 @@ func TestMetricsExporter_NilPushMetricsData(t *testing.T) {
 	require.Equal(t, errNilPushMetricsData, err)
 }
 
+func TestMetricsExporter_InvalidRetrySettings(t *testing.T) {
+	rCfg := NewDefaultRetrySettings()
+	rCfg.Strategy = "linear"
+	me, err := NewMetricsExporter(&fakeMetricsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushMetricsData(nil), WithRetry(rCfg))
+	require.Nil(t, me)
+	require.EqualError(t, err, `unsupported backoff strategy "linear", must be one of "exponential", "constant" or "fibonacci"`)
+}
+
 func TestMetricsExporter_Default(t *testing.T) {
 	md := pmetric.NewMetrics()
 	me, err := NewMetricsExporter(&fakeMetricsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushMetricsData(nil))
@@ -16,11 +16,13 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
 
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 )
@@ -43,6 +45,15 @@ type instruments struct {
 	failedToEnqueueTraceSpans   *metric.Int64Cumulative
 	failedToEnqueueMetricPoints *metric.Int64Cumulative
 	failedToEnqueueLogRecords   *metric.Int64Cumulative
+	sendRetries                 *metric.Int64Cumulative
+	retryBackoffTime            *metric.Int64Cumulative
+	retryExpiredTraceSpans      *metric.Int64Cumulative
+	retryExpiredMetricPoints    *metric.Int64Cumulative
+	retryExpiredLogRecords      *metric.Int64Cumulative
+	deadLetterTraceSpans        *metric.Int64Cumulative
+	deadLetterMetricPoints      *metric.Int64Cumulative
+	deadLetterLogRecords        *metric.Int64Cumulative
+	circuitBreakerStateChanges  *metric.Int64Cumulative
 }
 
 func newInstruments(registry *metric.Registry) *instruments {
@@ -58,24 +69,116 @@ func newInstruments(registry *metric.Registry) *instruments {
 	insts.failedToEnqueueTraceSpans, _ = registry.AddInt64Cumulative(
 		obsmetrics.ExporterKey+"/enqueue_failed_spans",
 		metric.WithDescription("Number of spans failed to be added to the sending queue."),
-		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, obsmetrics.ErrorTypeKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
 	insts.failedToEnqueueMetricPoints, _ = registry.AddInt64Cumulative(
 		obsmetrics.ExporterKey+"/enqueue_failed_metric_points",
 		metric.WithDescription("Number of metric points failed to be added to the sending queue."),
-		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, obsmetrics.ErrorTypeKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
 	insts.failedToEnqueueLogRecords, _ = registry.AddInt64Cumulative(
 		obsmetrics.ExporterKey+"/enqueue_failed_log_records",
 		metric.WithDescription("Number of log records failed to be added to the sending queue."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, obsmetrics.ErrorTypeKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.sendRetries, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/send_retries",
+		metric.WithDescription("Number of retries of the requests failed to be sent."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.retryBackoffTime, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/retry_backoff_time",
+		metric.WithDescription("Cumulative time waited before retrying the requests failed to be sent."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitMilliseconds))
+
+	insts.retryExpiredTraceSpans, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/retry_expired_spans",
+		metric.WithDescription("Number of spans dropped because max_elapsed_time expired before they were sent."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.retryExpiredMetricPoints, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/retry_expired_metric_points",
+		metric.WithDescription("Number of metric points dropped because max_elapsed_time expired before they were sent."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.retryExpiredLogRecords, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/retry_expired_log_records",
+		metric.WithDescription("Number of log records dropped because max_elapsed_time expired before they were sent."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.deadLetterTraceSpans, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/dead_letter_spans",
+		metric.WithDescription("Number of spans failed to be sent and sent to the dead_letter exporter instead."),
 		metric.WithLabelKeys(obsmetrics.ExporterKey),
 		metric.WithUnit(metricdata.UnitDimensionless))
 
+	insts.deadLetterMetricPoints, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/dead_letter_metric_points",
+		metric.WithDescription("Number of metric points failed to be sent and sent to the dead_letter exporter instead."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.deadLetterLogRecords, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/dead_letter_log_records",
+		metric.WithDescription("Number of log records failed to be sent and sent to the dead_letter exporter instead."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.circuitBreakerStateChanges, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/circuit_breaker_state_changes",
+		metric.WithDescription("Number of times the circuit breaker entered the state."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, "state"),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
 	return insts
 }
 
+// retryObsExporter is a helper to add observability to the retries of an exporter.
+type retryObsExporter struct {
+	sendRetriesEntry      *metric.Int64CumulativeEntry
+	retryBackoffTimeEntry *metric.Int64CumulativeEntry
+	// retryExpiredItemsEntry is nil when the data type of the exporter is unknown.
+	retryExpiredItemsEntry *metric.Int64CumulativeEntry
+}
+
+// newRetryObsExporter creates a new observability helper for the retries of the exporter of the given data type.
+func newRetryObsExporter(id config.ComponentID, signal config.DataType, insts *instruments) *retryObsExporter {
+	labelValue := metricdata.NewLabelValue(id.String())
+	rob := &retryObsExporter{}
+	rob.sendRetriesEntry, _ = insts.sendRetries.GetEntry(labelValue)
+	rob.retryBackoffTimeEntry, _ = insts.retryBackoffTime.GetEntry(labelValue)
+	switch signal {
+	case config.TracesDataType:
+		rob.retryExpiredItemsEntry, _ = insts.retryExpiredTraceSpans.GetEntry(labelValue)
+	case config.MetricsDataType:
+		rob.retryExpiredItemsEntry, _ = insts.retryExpiredMetricPoints.GetEntry(labelValue)
+	case config.LogsDataType:
+		rob.retryExpiredItemsEntry, _ = insts.retryExpiredLogRecords.GetEntry(labelValue)
+	}
+	return rob
+}
+
+// recordRetry records a retry of a request after the given backoff delay.
+func (rob *retryObsExporter) recordRetry(backoffDelay time.Duration) {
+	rob.sendRetriesEntry.Inc(1)
+	rob.retryBackoffTimeEntry.Inc(backoffDelay.Milliseconds())
+}
+
+// recordExpired records number of items dropped because max_elapsed_time expired.
+func (rob *retryObsExporter) recordExpired(numItems int64) {
+	if rob.retryExpiredItemsEntry != nil {
+		rob.retryExpiredItemsEntry.Inc(numItems)
+	}
+}
+
 // obsExporter is a helper to add observability to a component.Exporter.
 type obsExporter struct {
 	*obsreport.Exporter
@@ -87,9 +190,11 @@ type obsExporter struct {
 // newObsExporter creates a new observability exporter.
 func newObsExporter(cfg obsreport.ExporterSettings, insts *instruments) *obsExporter {
 	labelValue := metricdata.NewLabelValue(cfg.ExporterID.String())
-	failedToEnqueueTraceSpansEntry, _ := insts.failedToEnqueueTraceSpans.GetEntry(labelValue)
-	failedToEnqueueMetricPointsEntry, _ := insts.failedToEnqueueMetricPoints.GetEntry(labelValue)
-	failedToEnqueueLogRecordsEntry, _ := insts.failedToEnqueueLogRecords.GetEntry(labelValue)
+	// The data can only fail to be enqueued because the sending queue is full.
+	errorTypeValue := metricdata.NewLabelValue(obsreport.ErrorTypeQueueFull)
+	failedToEnqueueTraceSpansEntry, _ := insts.failedToEnqueueTraceSpans.GetEntry(labelValue, errorTypeValue)
+	failedToEnqueueMetricPointsEntry, _ := insts.failedToEnqueueMetricPoints.GetEntry(labelValue, errorTypeValue)
+	failedToEnqueueLogRecordsEntry, _ := insts.failedToEnqueueLogRecords.GetEntry(labelValue, errorTypeValue)
 
 	return &obsExporter{
 		Exporter:                         obsreport.NewExporter(cfg),
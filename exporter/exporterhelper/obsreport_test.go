@@ -24,6 +24,7 @@ import (
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -53,24 +54,33 @@ func TestExportEnqueueFailure(t *testing.T) {
 	metricPoints := int64(21)
 	obsrep.recordMetricsEnqueueFailure(context.Background(), metricPoints)
 	checkExporterEnqueueFailedMetricsStats(t, insts, exporter, metricPoints)
+
+	// The data failed to be enqueued is not counted as failed to send, it was never sent.
+	require.Error(t, obsreporttest.CheckExporterSendFailed(tt, exporter, config.LogsDataType, obsreport.ErrorTypeQueueFull, logRecords))
+	require.Error(t, obsreporttest.CheckExporterSendFailed(tt, exporter, config.LogsDataType, obsreport.ErrorTypeRetryable, logRecords))
 }
 
 // checkExporterEnqueueFailedTracesStats checks that reported number of spans failed to enqueue match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func checkExporterEnqueueFailedTracesStats(t *testing.T, insts *instruments, exporter config.ComponentID, spans int64) {
-	checkValueForProducer(t, insts.registry, tagsForExporterView(exporter), spans, "exporter/enqueue_failed_spans")
+	require.True(t, checkValueForProducer(t, insts.registry, tagsForEnqueueFailedView(exporter), spans, "exporter/enqueue_failed_spans"))
 }
 
 // checkExporterEnqueueFailedMetricsStats checks that reported number of metric points failed to enqueue match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func checkExporterEnqueueFailedMetricsStats(t *testing.T, insts *instruments, exporter config.ComponentID, metricPoints int64) {
-	checkValueForProducer(t, insts.registry, tagsForExporterView(exporter), metricPoints, "exporter/enqueue_failed_metric_points")
+	require.True(t, checkValueForProducer(t, insts.registry, tagsForEnqueueFailedView(exporter), metricPoints, "exporter/enqueue_failed_metric_points"))
 }
 
 // checkExporterEnqueueFailedLogsStats checks that reported number of log records failed to enqueue match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func checkExporterEnqueueFailedLogsStats(t *testing.T, insts *instruments, exporter config.ComponentID, logRecords int64) {
-	checkValueForProducer(t, insts.registry, tagsForExporterView(exporter), logRecords, "exporter/enqueue_failed_log_records")
+	require.True(t, checkValueForProducer(t, insts.registry, tagsForEnqueueFailedView(exporter), logRecords, "exporter/enqueue_failed_log_records"))
+}
+
+// tagsForEnqueueFailedView returns the tags that are needed for the enqueue failed views.
+func tagsForEnqueueFailedView(exporter config.ComponentID) []tag.Tag {
+	return append(tagsForExporterView(exporter), tag.Tag{Key: tag.MustNewKey(obsmetrics.ErrorTypeKey), Value: obsreport.ErrorTypeQueueFull})
 }
 
 // tagsForExporterView returns the tags that are needed for the exporter views.
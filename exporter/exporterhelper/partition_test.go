@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestResourcePartitionKey(t *testing.T) {
+	res1 := pcommon.NewResource()
+	res1.Attributes().InsertString("service.name", "svc")
+	res1.Attributes().InsertString("host.name", "host")
+	res2 := pcommon.NewResource()
+	res2.Attributes().InsertString("host.name", "host")
+	res2.Attributes().InsertString("service.name", "svc")
+	res3 := pcommon.NewResource()
+	res3.Attributes().InsertString("service.name", "svc")
+	res3.Attributes().InsertString("host.name", "other")
+
+	// The key does not depend on the order of the attributes.
+	assert.Equal(t, resourcePartitionKey(res1), resourcePartitionKey(res2))
+	assert.NotEqual(t, resourcePartitionKey(res1), resourcePartitionKey(res3))
+	assert.NotEqual(t, resourcePartitionKey(res1), resourcePartitionKey(pcommon.NewResource()))
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"sync"
+
+	"go.uber.org/atomic"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+var (
+	errNoStorageClient        = errors.New("no storage client extension found")
+	errMultipleStorageClients = errors.New("multiple storage extensions found")
+)
+
+const (
+	// handoffCountKey is the storage key of the number of spilled requests,
+	// the requests being stored with the keys handoffKeyPrefix followed by their index.
+	handoffCountKey  = "handoff_count"
+	handoffKeyPrefix = "handoff_"
+)
+
+func getStorageClient(ctx context.Context, host component.Host, id config.ComponentID, signal config.DataType) (*storage.Client, error) {
+	var storageExtension storage.Extension
+	for _, ext := range host.GetExtensions() {
+		if se, ok := ext.(storage.Extension); ok {
+			if storageExtension != nil {
+				return nil, errMultipleStorageClients
+			}
+			storageExtension = se
+		}
+	}
+
+	if storageExtension == nil {
+		return nil, errNoStorageClient
+	}
+
+	client, err := storageExtension.GetClient(ctx, component.KindExporter, id, string(signal))
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, err
+}
+
+// queueHandoff spills the requests remaining in the in-memory queue to the storage extension on shutdown,
+// and restores them in the queue at the next start, so that restarts do not lose the queued requests.
+//
+// The requests interrupted by the shutdown while being retried are spilled as well, possibly resending
+// the data exported by partially successful attempts.
+type queueHandoff struct {
+	id          config.ComponentID
+	signal      config.DataType
+	unmarshaler internal.RequestUnmarshaler
+	logger      *zap.Logger
+	// maxSpilled is the maximum number of requests spilled on shutdown, the requests in the queue and
+	// the ones interrupted by the shutdown.
+	maxSpilled uint64
+
+	client   storage.Client
+	stopping *atomic.Bool
+
+	// mu protects spilled, appended concurrently by the queue consumers.
+	mu      sync.Mutex
+	spilled []spilledRequest
+}
+
+// spilledRequest is a request waiting to be written to the storage, its consumerack.Ack being held until written.
+type spilledRequest struct {
+	req  request
+	data []byte
+}
+
+func newQueueHandoff(id config.ComponentID, signal config.DataType, qCfg QueueSettings, reqUnmarshaler internal.RequestUnmarshaler, logger *zap.Logger) *queueHandoff {
+	return &queueHandoff{
+		id:          id,
+		signal:      signal,
+		unmarshaler: reqUnmarshaler,
+		logger:      logger,
+		maxSpilled:  uint64(qCfg.QueueSize + qCfg.NumConsumers),
+		stopping:    atomic.NewBool(false),
+	}
+}
+
+// start gets the storage client and restores in the queue the requests spilled at the last shutdown.
+// Must be invoked after the queue consumers are started.
+func (qh *queueHandoff) start(ctx context.Context, host component.Host, queue internal.ProducerConsumerQueue) error {
+	client, err := getStorageClient(ctx, host, qh.id, qh.signal)
+	if err != nil {
+		return err
+	}
+	qh.client = *client
+
+	countBytes, err := qh.client.Get(ctx, handoffCountKey)
+	if err != nil || countBytes == nil {
+		return err
+	}
+	count, _ := binary.Uvarint(countBytes)
+	// The stored count is not trusted to allocate the operations, the requests beyond the ones which can
+	// be spilled are ignored.
+	if count > qh.maxSpilled {
+		qh.logger.Warn("Ignoring the requests spilled at the last shutdown beyond the sending_queue size",
+			zap.Uint64("requests", count), zap.Uint64("max_requests", qh.maxSpilled))
+		count = qh.maxSpilled
+	}
+
+	ops := make([]storage.Operation, 0, count+1)
+	for i := uint64(0); i < count; i++ {
+		ops = append(ops, storage.GetOperation(handoffKey(i)))
+	}
+	if err = qh.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+
+	restored := 0
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		req, err := qh.unmarshaler(op.Value)
+		if err != nil {
+			qh.logger.Error("Dropping invalid request spilled at the last shutdown", zap.Error(err))
+			continue
+		}
+		if !queue.Produce(req) {
+			qh.logger.Error("Dropping request spilled at the last shutdown because sending_queue is full. Try increasing queue_size.",
+				zap.Int("dropped_items", req.(request).count()))
+			continue
+		}
+		restored++
+	}
+	qh.logger.Info("Restored requests spilled at the last shutdown", zap.Int("requests", restored))
+
+	// The requests are deleted once in the queue, so that they are at least in memory.
+	for i := range ops {
+		ops[i] = storage.DeleteOperation(handoffKey(uint64(i)))
+	}
+	return qh.client.Batch(ctx, append(ops, storage.DeleteOperation(handoffCountKey))...)
+}
+
+// beginShutdown makes the queue consumers spill the requests instead of sending them.
+func (qh *queueHandoff) beginShutdown() {
+	qh.stopping.Store(true)
+}
+
+// spill keeps the request to be written to the storage on shutdown, returning false if not shutting down.
+// The consumerack.Ack of a spilled request is released once the request is written to the storage.
+func (qh *queueHandoff) spill(req request) bool {
+	if !qh.stopping.Load() {
+		return false
+	}
+	data, err := req.Marshal()
+	if err != nil {
+		qh.logger.Error("Dropping request which cannot be spilled to the storage", zap.Error(err), zap.Int("dropped_items", req.count()))
+		req.releaseAck(err)
+		return true
+	}
+	qh.mu.Lock()
+	qh.spilled = append(qh.spilled, spilledRequest{req: req, data: data})
+	qh.mu.Unlock()
+	return true
+}
+
+// spillInterrupted spills the request which failed to be sent while shutting down, unless the error is permanent.
+// Returns true if the request was spilled.
+func (qh *queueHandoff) spillInterrupted(req request, err error) bool {
+	if err == nil || consumererror.IsPermanent(err) {
+		return false
+	}
+	return qh.spill(req)
+}
+
+// shutdown writes the spilled requests to the storage and closes the storage client.
+// Must be invoked after the queue consumers are stopped.
+func (qh *queueHandoff) shutdown(ctx context.Context) error {
+	if qh.client == nil {
+		for _, sr := range qh.spilled {
+			sr.req.releaseAck(errNoStorageClient)
+		}
+		return nil
+	}
+	var err error
+	if len(qh.spilled) != 0 {
+		ops := make([]storage.Operation, 0, len(qh.spilled)+1)
+		for i, sr := range qh.spilled {
+			ops = append(ops, storage.SetOperation(handoffKey(uint64(i)), sr.data))
+		}
+		countBytes := make([]byte, binary.MaxVarintLen64)
+		countBytes = countBytes[:binary.PutUvarint(countBytes, uint64(len(qh.spilled)))]
+		if err = qh.client.Batch(ctx, append(ops, storage.SetOperation(handoffCountKey, countBytes))...); err == nil {
+			qh.logger.Info("Spilled the sending_queue to the storage", zap.Int("requests", len(qh.spilled)))
+		}
+		// The requests are only durably accepted once written.
+		for _, sr := range qh.spilled {
+			sr.req.releaseAck(err)
+		}
+	}
+	return multierr.Append(err, qh.client.Close(ctx))
+}
+
+func handoffKey(index uint64) string {
+	return handoffKeyPrefix + strconv.FormatUint(index, 10)
+}
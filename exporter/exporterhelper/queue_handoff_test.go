@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumerack"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// memoryStorage is a storage extension whose clients share the same map, surviving the restarts of the exporters.
+type memoryStorage struct {
+	mu sync.Mutex
+	st map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{st: map[string][]byte{}}
+}
+
+func (ms *memoryStorage) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (ms *memoryStorage) Shutdown(context.Context) error {
+	return nil
+}
+
+func (ms *memoryStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return ms, nil
+}
+
+func (ms *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.st[key], nil
+}
+
+func (ms *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.st[key] = value
+	return nil
+}
+
+func (ms *memoryStorage) Delete(_ context.Context, key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.st, key)
+	return nil
+}
+
+func (ms *memoryStorage) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value, _ = ms.Get(ctx, op.Key)
+		case storage.Set:
+			_ = ms.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			_ = ms.Delete(ctx, op.Key)
+		}
+	}
+	return nil
+}
+
+func (ms *memoryStorage) Close(context.Context) error {
+	return nil
+}
+
+var errStorageFailed = errors.New("storage failed")
+
+// failingStorage is a storage client failing the batches of operations.
+type failingStorage struct {
+	*memoryStorage
+}
+
+func (fs *failingStorage) Batch(context.Context, ...storage.Operation) error {
+	return errStorageFailed
+}
+
+func (ms *memoryStorage) len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return len(ms.st)
+}
+
+// storageHost is a host with the given storage extensions.
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func newStorageHost(extensions ...storage.Extension) component.Host {
+	h := storageHost{Host: componenttest.NewNopHost(), extensions: map[config.ComponentID]component.Extension{}}
+	for i, ext := range extensions {
+		h.extensions[config.NewComponentIDWithName("storage", string(rune('a'+i)))] = ext
+	}
+	return h
+}
+
+func (h storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestQueueHandoff_SpillAndRestore(t *testing.T) {
+	st := newMemoryStorage()
+	host := newStorageHost(st)
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.SpillOnShutdown = true
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Second
+
+	// The consumer is blocked, retrying the first logs until the shutdown, the other logs remain in the queue.
+	attempted := atomic.NewBool(false)
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, plog.Logs) error {
+		attempted.Store(true)
+		return errors.New("transient error")
+	}, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), host))
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Eventually(t, attempted.Load, time.Second, 10*time.Millisecond)
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+	require.NoError(t, le.Shutdown(context.Background()))
+	// The 3 requests and their count.
+	assert.Equal(t, 4, st.len())
+
+	var mu sync.Mutex
+	var received []int
+	le, err = NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(_ context.Context, ld plog.Logs) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, ld.LogRecordCount())
+		return nil
+	}, WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), host))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 3
+	}, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []int{1, 2, 3}, received)
+	assert.Equal(t, 0, st.len())
+	require.NoError(t, le.Shutdown(context.Background()))
+	assert.Equal(t, 0, st.len())
+}
+
+func TestQueueHandoff_RestoreCountBeyondQueueSize(t *testing.T) {
+	st := newMemoryStorage()
+	data, err := newLogsRequest(context.Background(), testdata.GenerateLogs(2), nil).Marshal()
+	require.NoError(t, err)
+	countBytes := make([]byte, binary.MaxVarintLen64)
+	countBytes = countBytes[:binary.PutUvarint(countBytes, math.MaxUint64)]
+	require.NoError(t, st.Set(context.Background(), handoffKey(0), data))
+	require.NoError(t, st.Set(context.Background(), handoffCountKey, countBytes))
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.SpillOnShutdown = true
+	received := atomic.NewInt64(0)
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(_ context.Context, ld plog.Logs) error {
+		received.Add(int64(ld.LogRecordCount()))
+		return nil
+	}, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), newStorageHost(st)))
+	assert.Eventually(t, func() bool { return received.Load() == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, st.len())
+	require.NoError(t, le.Shutdown(context.Background()))
+}
+
+func TestQueueHandoff_AckReleasedOnceWritten(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		client  storage.Client
+		wantErr error
+	}{
+		{name: "written", client: newMemoryStorage()},
+		{name: "write_failed", client: &failingStorage{memoryStorage: newMemoryStorage()}, wantErr: errStorageFailed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			qh := newQueueHandoff(fakeLogsExporterConfig.ID(), config.LogsDataType, NewDefaultQueueSettings(), newLogsRequestUnmarshalerFunc(nil), componenttest.NewNopExporterCreateSettings().Logger)
+			qh.client = tt.client
+			qh.beginShutdown()
+
+			ack := consumerack.New()
+			req := newLogsRequest(consumerack.NewContext(context.Background(), ack), testdata.GenerateLogs(1), nil)
+			req.holdAck()
+			ack.Done(nil)
+			completed := atomic.NewBool(false)
+			ack.OnComplete(func(error) { completed.Store(true) })
+
+			// The request is only accepted once written to the storage.
+			require.True(t, qh.spill(req))
+			assert.False(t, completed.Load())
+			assert.ErrorIs(t, qh.shutdown(context.Background()), tt.wantErr)
+			assert.True(t, completed.Load())
+			assert.ErrorIs(t, ack.Wait(context.Background()), tt.wantErr)
+		})
+	}
+}
+
+func TestQueueHandoff_PermanentErrorNotSpilled(t *testing.T) {
+	qh := newQueueHandoff(fakeLogsExporterConfig.ID(), config.LogsDataType, NewDefaultQueueSettings(), newLogsRequestUnmarshalerFunc(nil), componenttest.NewNopExporterCreateSettings().Logger)
+	qh.beginShutdown()
+
+	req := newLogsRequest(context.Background(), testdata.GenerateLogs(1), nil)
+	qh.spillInterrupted(req, consumererror.NewPermanent(errors.New("bad data")))
+	qh.spillInterrupted(req, nil)
+	assert.Empty(t, qh.spilled)
+	qh.spillInterrupted(req, errors.New("interrupted due to shutdown"))
+	assert.Len(t, qh.spilled, 1)
+}
+
+func TestQueueHandoff_NotShuttingDown(t *testing.T) {
+	qh := newQueueHandoff(fakeLogsExporterConfig.ID(), config.LogsDataType, NewDefaultQueueSettings(), newLogsRequestUnmarshalerFunc(nil), componenttest.NewNopExporterCreateSettings().Logger)
+	assert.False(t, qh.spill(newLogsRequest(context.Background(), testdata.GenerateLogs(1), nil)))
+	assert.Empty(t, qh.spilled)
+}
+
+func TestQueueHandoff_StorageErrors(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.SpillOnShutdown = true
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(nil), WithQueue(qCfg))
+	require.NoError(t, err)
+	assert.ErrorIs(t, le.Start(context.Background(), componenttest.NewNopHost()), errNoStorageClient)
+
+	le, err = NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(nil), WithQueue(qCfg))
+	require.NoError(t, err)
+	assert.ErrorIs(t, le.Start(context.Background(), newStorageHost(newMemoryStorage(), newMemoryStorage())), errMultipleStorageClients)
+}
+
+func TestQueueHandoff_IgnoredWithWAL(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.SpillOnShutdown = true
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(nil),
+		WithQueue(qCfg), WithWAL(WALSettings{Enabled: true, Directory: t.TempDir()}))
+	require.NoError(t, err)
+	// No storage extension is needed.
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, le.Shutdown(context.Background()))
+}
@@ -20,13 +20,14 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport"
 )
 
 var (
@@ -34,7 +35,8 @@ var (
 )
 
 // RetrySettings defines configuration for retrying batches in case of export failure.
-// The current supported strategy is exponential backoff.
+// The interval between the retries grows with an exponential, constant or fibonacci strategy,
+// randomized by a jitter, and can be overridden per class of errors.
 type RetrySettings struct {
 	// Enabled indicates whether to not retry sending batches in case of export failure.
 	Enabled bool `mapstructure:"enabled"`
@@ -43,9 +45,20 @@ type RetrySettings struct {
 	// MaxInterval is the upper bound on backoff interval. Once this value is reached the delay between
 	// consecutive retries will always be `MaxInterval`.
 	MaxInterval time.Duration `mapstructure:"max_interval"`
-	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch.
-	// Once this value is reached, the data is discarded.
+	// MaxElapsedTime is the maximum amount of time (including retries) spent trying to send a request/batch,
+	// accounted from the time the data was enqueued. Once this value is reached, the data is discarded.
 	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+	// Strategy is the growth of the interval between the retries, one of "exponential" (default),
+	// "constant" or "fibonacci".
+	Strategy string `mapstructure:"strategy"`
+	// Multiplier is the growth factor of the interval of the exponential strategy, 1.5 by default.
+	Multiplier float64 `mapstructure:"multiplier"`
+	// Jitter is the randomization of the intervals, one of "none", "full", "equal" or "decorrelated".
+	// By default, the intervals are randomized by up to 50% in both directions.
+	Jitter string `mapstructure:"jitter"`
+	// Overrides are the backoffs of the classes of errors retried differently, keyed by the error types
+	// recorded by obsreport: "throttled", "connection_refused", "timeout" or "retryable".
+	Overrides map[string]BackoffSettings `mapstructure:"overrides"`
 }
 
 // NewDefaultRetrySettings returns the default settings for RetrySettings.
@@ -58,6 +71,47 @@ func NewDefaultRetrySettings() RetrySettings {
 	}
 }
 
+// Validate checks if the RetrySettings configuration is valid. The exporters created with invalid
+// RetrySettings fail to be created, even if their configuration does not call Validate.
+func (rCfg *RetrySettings) Validate() error {
+	if !rCfg.Enabled {
+		return nil
+	}
+	if err := rCfg.backoffSettings().validate(); err != nil {
+		return err
+	}
+	for class, override := range rCfg.Overrides {
+		if !retryErrorClasses[class] {
+			return fmt.Errorf("unsupported error class %q in overrides, must be one of %q, %q, %q or %q", class,
+				obsreport.ErrorTypeThrottled, obsreport.ErrorTypeConnectionRefused, obsreport.ErrorTypeTimeout, obsreport.ErrorTypeRetryable)
+		}
+		if err := override.validate(); err != nil {
+			return fmt.Errorf("overrides %q: %w", class, err)
+		}
+	}
+	return nil
+}
+
+// backoffSettings returns the backoff of the errors without override.
+func (rCfg *RetrySettings) backoffSettings() BackoffSettings {
+	return BackoffSettings{
+		Strategy:        rCfg.Strategy,
+		InitialInterval: rCfg.InitialInterval,
+		MaxInterval:     rCfg.MaxInterval,
+		Multiplier:      rCfg.Multiplier,
+		Jitter:          rCfg.Jitter,
+	}
+}
+
+// newBackoff returns the backoff of the retries of the errors of the class.
+func (rCfg *RetrySettings) newBackoff(class string) *retryBackoff {
+	bs := rCfg.backoffSettings()
+	if override, ok := rCfg.Overrides[class]; ok {
+		bs = override.merge(bs)
+	}
+	return newRetryBackoff(bs)
+}
+
 func createSampledLogger(logger *zap.Logger) *zap.Logger {
 	if logger.Core().Enabled(zapcore.DebugLevel) {
 		// Debugging is enabled. Don't do any sampling.
@@ -81,6 +135,10 @@ func createSampledLogger(logger *zap.Logger) *zap.Logger {
 func (qrs *queuedRetrySender) send(req request) error {
 	if !qrs.cfg.Enabled {
 		err := qrs.consumerSender.send(req)
+		if isDeadLettered(err) {
+			// The data is handled by the dead letter exporter, the caller must not send it again.
+			return nil
+		}
 		if err != nil {
 			qrs.logger.Error(
 				"Exporting failed. Dropping data. Try enabling sending_queue to survive temporary failures.",
@@ -94,13 +152,26 @@ func (qrs *queuedRetrySender) send(req request) error {
 	// The grpc/http based receivers will cancel the request context after this function returns.
 	req.setContext(noCancellationContext{Context: req.context()})
 
+	// The data is only durably accepted once exported, unless persisted before being queued.
+	if !qrs.durable {
+		req.holdAck()
+	}
+
+	// The memory limiters account for the data held by the in-memory queue.
+	if qrs.account != nil {
+		req.accountBytes(qrs.account, int64(req.bytesSize()))
+	}
+
 	span := trace.SpanFromContext(req.context())
 	if !qrs.queue.Produce(req) {
+		req.unaccountBytes()
+		req.releaseAck(errSendingQueueIsFull)
 		qrs.logger.Error(
 			"Dropping data because sending_queue is full. Try increasing queue_size.",
 			zap.Int("dropped_items", req.count()),
 		)
-		span.AddEvent("Dropped item, sending_queue is full.", trace.WithAttributes(qrs.traceAttributes...))
+		span.AddEvent("Dropped item, sending_queue is full.", trace.WithAttributes(
+			append(qrs.traceAttributes, attribute.String(obsmetrics.ErrorTypeKey, obsreport.ErrorTypeQueueFull))...))
 		return errSendingQueueIsFull
 	}
 
@@ -122,6 +193,11 @@ func (t throttleRetry) Unwrap() error {
 	return t.err
 }
 
+// ErrorType returns the error type recorded by obsreport for the failed sends.
+func (t throttleRetry) ErrorType() string {
+	return obsreport.ErrorTypeThrottled
+}
+
 // NewThrottleRetry creates a new throttle retry error.
 func NewThrottleRetry(err error, delay time.Duration) error {
 	return throttleRetry{
@@ -139,12 +215,20 @@ type retrySender struct {
 	stopCh             chan struct{}
 	logger             *zap.Logger
 	onTemporaryFailure onRequestHandlingFinishedFunc
+	obsrep             *retryObsExporter
+	// deadLetter receives the requests failed permanently or expired, nil if disabled.
+	deadLetter *deadLetterSender
+	// breaker is the circuit breaker of the next sender, nil if disabled.
+	breaker *circuitBreaker
 }
 
 // send implements the requestSender interface
 func (rs *retrySender) send(req request) error {
 	if !rs.cfg.Enabled {
 		err := rs.nextSender.send(req)
+		if err != nil && rs.deadLetter != nil && consumererror.IsPermanent(err) {
+			return rs.deadLetter.send(req, err)
+		}
 		if err != nil {
 			rs.logger.Error(
 				"Exporting failed. Try enabling retry_on_failure config option to retry on retryable errors",
@@ -154,18 +238,15 @@ func (rs *retrySender) send(req request) error {
 		return err
 	}
 
-	// Do not use NewExponentialBackOff since it calls Reset and the code here must
-	// call Reset after changing the InitialInterval (this saves an unnecessary call to Now).
-	expBackoff := backoff.ExponentialBackOff{
-		InitialInterval:     rs.cfg.InitialInterval,
-		RandomizationFactor: backoff.DefaultRandomizationFactor,
-		Multiplier:          backoff.DefaultMultiplier,
-		MaxInterval:         rs.cfg.MaxInterval,
-		MaxElapsedTime:      rs.cfg.MaxElapsedTime,
-		Stop:                backoff.Stop,
-		Clock:               backoff.SystemClock,
+	// The intervals of each class of errors grow independently, e.g. the throttling of the destination does
+	// not lengthen the retries of the refused connections when it restarts.
+	backoffs := make(map[string]*retryBackoff)
+	// The max elapsed time is accounted from the time the request was enqueued rather than from its first send,
+	// so that the time spent in the queue is not allowed on top of the max elapsed time.
+	enqueued := req.enqueuedTime()
+	if enqueued.IsZero() {
+		enqueued = time.Now()
 	}
-	expBackoff.Reset()
 	span := trace.SpanFromContext(req.context())
 	retryNum := int64(0)
 	for {
@@ -178,8 +259,16 @@ func (rs *retrySender) send(req request) error {
 			return nil
 		}
 
+		// Do not wait for the end of the cooldown if the circuit breaker fails fast.
+		if rs.breaker.failFast() && isCircuitOpen(err) {
+			return rs.onTemporaryFailure(rs.logger, req, err)
+		}
+
 		// Immediately drop data on permanent errors.
 		if consumererror.IsPermanent(err) {
+			if rs.deadLetter != nil {
+				return rs.deadLetter.send(req, err)
+			}
 			rs.logger.Error(
 				"Exporting failed. The error is not retryable. Dropping data.",
 				zap.Error(err),
@@ -192,10 +281,22 @@ func (rs *retrySender) send(req request) error {
 		// failed to process.
 		req = req.onError(err)
 
-		backoffDelay := expBackoff.NextBackOff()
-		if backoffDelay == backoff.Stop {
-			// throw away the batch
+		class := obsreport.ErrorType(err)
+		classBackoff, ok := backoffs[class]
+		if !ok {
+			classBackoff = rs.cfg.newBackoff(class)
+			backoffs[class] = classBackoff
+		}
+		backoffDelay := classBackoff.next()
+		if rs.cfg.MaxElapsedTime != 0 && time.Since(enqueued)+backoffDelay > rs.cfg.MaxElapsedTime {
 			err = fmt.Errorf("max elapsed time expired %w", err)
+			if rs.deadLetter != nil {
+				if dlErr := rs.deadLetter.send(req, err); isDeadLettered(dlErr) {
+					return dlErr
+				}
+			}
+			// throw away the batch
+			rs.obsrep.recordExpired(int64(req.count()))
 			return rs.onTemporaryFailure(rs.logger, req, err)
 		}
 
@@ -218,6 +319,7 @@ func (rs *retrySender) send(req request) error {
 			zap.String("interval", backoffDelayStr),
 		)
 		retryNum++
+		rs.obsrep.recordRetry(backoffDelay)
 
 		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
 		select {
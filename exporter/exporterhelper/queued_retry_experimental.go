@@ -29,7 +29,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
-	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/internal/memorypressure"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -44,8 +44,14 @@ type QueueSettings struct {
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// OrderedDelivery indicates whether to deliver the batches of the same resource sequentially, in the order they
+	// were enqueued, while the batches of different resources are delivered in parallel by the consumers.
+	OrderedDelivery bool `mapstructure:"ordered_delivery"`
 	// PersistentStorageEnabled describes whether persistence via a file storage extension is enabled
 	PersistentStorageEnabled bool `mapstructure:"persistent_storage_enabled"`
+	// SpillOnShutdown indicates whether to write the batches remaining in the in-memory queue to the storage
+	// extension on shutdown, and to restore them at the next start. Ignored when the write-ahead log is enabled.
+	SpillOnShutdown bool `mapstructure:"spill_on_shutdown"`
 }
 
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
@@ -62,6 +68,19 @@ func NewDefaultQueueSettings() QueueSettings {
 	}
 }
 
+// orderedDelivery returns true if the batches are queued in partitions delivered sequentially.
+func (qCfg *QueueSettings) orderedDelivery() bool {
+	return qCfg.Enabled && qCfg.OrderedDelivery
+}
+
+// newMemoryQueue returns the in-memory queue of the batches, partitioned when the ordered delivery is enabled.
+func (qCfg *QueueSettings) newMemoryQueue() internal.ProducerConsumerQueue {
+	if qCfg.orderedDelivery() {
+		return internal.NewPartitionedMemoryQueue(qCfg.NumConsumers, qCfg.QueueSize, requestPartitionKey, func(item interface{}) {})
+	}
+	return internal.NewBoundedMemoryQueue(qCfg.QueueSize, func(item interface{}) {})
+}
+
 // Validate checks if the QueueSettings configuration is valid
 func (qCfg *QueueSettings) Validate() error {
 	if !qCfg.Enabled {
@@ -72,14 +91,18 @@ func (qCfg *QueueSettings) Validate() error {
 		return errors.New("queue size must be positive")
 	}
 
+	if qCfg.SpillOnShutdown && qCfg.PersistentStorageEnabled {
+		return errors.New("spill on shutdown cannot be enabled with the persistent storage")
+	}
+
+	// The persistent queue puts the failed batches back to the end of the queue.
+	if qCfg.OrderedDelivery && qCfg.PersistentStorageEnabled {
+		return errors.New("ordered delivery cannot be enabled with the persistent storage")
+	}
+
 	return nil
 }
 
-var (
-	errNoStorageClient        = errors.New("no storage client extension found")
-	errMultipleStorageClients = errors.New("multiple storage extensions found")
-)
-
 type queuedRetrySender struct {
 	id                 config.ComponentID
 	signal             config.DataType
@@ -91,6 +114,15 @@ type queuedRetrySender struct {
 	logger             *zap.Logger
 	requeuingEnabled   bool
 	requestUnmarshaler internal.RequestUnmarshaler
+	handoff            *queueHandoff
+	// breaker pauses the consumers while the circuit is open, nil if disabled.
+	breaker *circuitBreaker
+	// durable is true if the requests are persisted before being queued, by the write-ahead log or the
+	// persistent queue, their consumerack.Ack not being held until exported.
+	durable bool
+	// account records the bytes of the requests held by the in-memory queue for the memory limiters,
+	// nil for the persistent queue.
+	account *memorypressure.Account
 }
 
 func (qrs *queuedRetrySender) fullName() string {
@@ -113,6 +145,7 @@ func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg Qu
 		traceAttributes:    []attribute.KeyValue{traceAttr},
 		logger:             sampledLogger,
 		requestUnmarshaler: reqUnmarshaler,
+		durable:            qCfg.PersistentStorageEnabled,
 	}
 
 	qrs.consumerSender = &retrySender{
@@ -123,39 +156,18 @@ func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg Qu
 		logger:         sampledLogger,
 		// Following three functions actually depend on queuedRetrySender
 		onTemporaryFailure: qrs.onTemporaryFailure,
+		obsrep:             newRetryObsExporter(id, signal, globalInstruments),
 	}
 
 	if !qCfg.PersistentStorageEnabled {
-		qrs.queue = internal.NewBoundedMemoryQueue(qrs.cfg.QueueSize, func(item interface{}) {})
+		qrs.queue = qrs.cfg.newMemoryQueue()
+		qrs.account = &memorypressure.Account{}
 	}
 	// The Persistent Queue is initialized separately as it needs extra information about the component
 
 	return qrs
 }
 
-func getStorageClient(ctx context.Context, host component.Host, id config.ComponentID, signal config.DataType) (*storage.Client, error) {
-	var storageExtension storage.Extension
-	for _, ext := range host.GetExtensions() {
-		if se, ok := ext.(storage.Extension); ok {
-			if storageExtension != nil {
-				return nil, errMultipleStorageClients
-			}
-			storageExtension = se
-		}
-	}
-
-	if storageExtension == nil {
-		return nil, errNoStorageClient
-	}
-
-	client, err := storageExtension.GetClient(ctx, component.KindExporter, id, string(signal))
-	if err != nil {
-		return nil, err
-	}
-
-	return &client, err
-}
-
 // initializePersistentQueue uses extra information for initialization available from component.Host
 func (qrs *queuedRetrySender) initializePersistentQueue(ctx context.Context, host component.Host) error {
 	if qrs.cfg.PersistentStorageEnabled {
@@ -207,10 +219,34 @@ func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) er
 
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
 		req := item.(request)
-		_ = qrs.consumerSender.send(req)
+		qrs.breaker.wait(qrs.retryStopCh)
+		var err error
+		// The handoff releases the consumerack.Ack of the spilled requests.
+		spilled := false
+		if qrs.handoff == nil {
+			err = qrs.consumerSender.send(req)
+		} else if spilled = qrs.handoff.spill(req); !spilled {
+			err = qrs.consumerSender.send(req)
+			spilled = qrs.handoff.spillInterrupted(req, err)
+		}
 		req.OnProcessingFinished()
+		req.unaccountBytes()
+		if isDeadLettered(err) {
+			// The data is handled by the dead letter exporter.
+			err = nil
+		}
+		if !spilled {
+			req.releaseAck(err)
+		}
 	})
 
+	// Restore the requests spilled at the last shutdown.
+	if qrs.handoff != nil {
+		if err := qrs.handoff.start(ctx, host, qrs.queue); err != nil {
+			return fmt.Errorf("failed to restore the spilled sending_queue: %w", err)
+		}
+	}
+
 	// Start reporting queue length metric
 	if qrs.cfg.Enabled {
 		err := globalInstruments.queueSize.UpsertEntry(func() int64 {
@@ -225,7 +261,7 @@ func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) er
 }
 
 // shutdown is invoked during service shutdown.
-func (qrs *queuedRetrySender) shutdown() {
+func (qrs *queuedRetrySender) shutdown(ctx context.Context) error {
 	// Cleanup queue metrics reporting
 	if qrs.cfg.Enabled {
 		_ = globalInstruments.queueSize.UpsertEntry(func() int64 {
@@ -233,6 +269,11 @@ func (qrs *queuedRetrySender) shutdown() {
 		}, metricdata.NewLabelValue(qrs.fullName()))
 	}
 
+	// Spill the remaining requests instead of sending them.
+	if qrs.handoff != nil {
+		qrs.handoff.beginShutdown()
+	}
+
 	// First Stop the retry goroutines, so that unblocks the queue numWorkers.
 	close(qrs.retryStopCh)
 
@@ -241,4 +282,12 @@ func (qrs *queuedRetrySender) shutdown() {
 	if qrs.queue != nil {
 		qrs.queue.Stop()
 	}
+	if qrs.account != nil {
+		qrs.account.Reset()
+	}
+
+	if qrs.handoff != nil {
+		return qrs.handoff.shutdown(ctx)
+	}
+	return nil
 }
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//go:build enable_unstable
+// +build enable_unstable
+
+package exporterhelper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestPersistentQueue_EnqueuedTime(t *testing.T) {
+	client := &mapStorageClient{st: map[string][]byte{}}
+	enqueued := time.Now().Add(-time.Hour)
+	req := newTracesRequest(context.Background(), testdata.GenerateTraces(2), nil)
+	req.setEnqueuedTime(enqueued)
+	req.setIdempotencyKey("key")
+
+	pq := internal.NewPersistentQueue(context.Background(), "foo", 10, zap.NewNop(), client, newTraceRequestUnmarshalerFunc(nil))
+	got := make(chan request, 1)
+	pq.StartConsumers(1, func(item interface{}) {
+		got <- item.(request)
+	})
+	t.Cleanup(pq.Stop)
+
+	// The request read back from the storage keeps the time it was first enqueued.
+	require.True(t, pq.Produce(req))
+
+	select {
+	case persisted := <-got:
+		assert.True(t, enqueued.Equal(persisted.enqueuedTime()))
+		assert.Equal(t, "key", persisted.(*tracesRequest).idempotencyKey)
+		assert.Equal(t, req.count(), persisted.count())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the persisted request was not consumed")
+	}
+}
+
+func TestPersistentQueue_WALAcked(t *testing.T) {
+	wCfg := WALSettings{Enabled: true, Directory: t.TempDir()}
+	qCfg := NewDefaultQueueSettings()
+	qCfg.PersistentStorageEnabled = true
+	host := newStorageHost(newMemoryStorage())
+
+	received := atomic.NewInt64(0)
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, plog.Logs) error {
+		received.Inc()
+		return nil
+	}, WithWAL(wCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), host))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}
+	assert.Eventually(t, func() bool { return received.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	// The requests read back from the persistent queue are acknowledged, truncating the segments.
+	segments, err := filepath.Glob(filepath.Join(wCfg.Directory, "fake_logs_exporter_with_name_logs", "wal-*.log"))
+	require.NoError(t, err)
+	require.NotEmpty(t, segments)
+	for _, segment := range segments {
+		info, err := os.Stat(segment)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, info.Size())
+	}
+	assert.Empty(t, openLogsExporterWAL(t, wCfg.Directory).Pending())
+}
+
+type mapStorageClient struct {
+	st  map[string][]byte
+	mux sync.Mutex
+}
+
+func (m *mapStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return m.st[key], nil
+}
+
+func (m *mapStorageClient) Set(_ context.Context, key string, value []byte) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.st[key] = value
+	return nil
+}
+
+func (m *mapStorageClient) Delete(_ context.Context, key string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	delete(m.st, key)
+	return nil
+}
+
+func (m *mapStorageClient) Close(context.Context) error {
+	return nil
+}
+
+func (m *mapStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = m.st[op.Key]
+		case storage.Set:
+			m.st[op.Key] = op.Value
+		case storage.Delete:
+			delete(m.st, op.Key)
+		}
+	}
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/internal/memorypressure"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
@@ -43,6 +44,12 @@ type QueueSettings struct {
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// OrderedDelivery indicates whether to deliver the batches of the same resource sequentially, in the order they
+	// were enqueued, while the batches of different resources are delivered in parallel by the consumers.
+	OrderedDelivery bool `mapstructure:"ordered_delivery"`
+	// SpillOnShutdown indicates whether to write the batches remaining in the queue to the storage extension
+	// on shutdown, and to restore them at the next start. Ignored when the write-ahead log is enabled.
+	SpillOnShutdown bool `mapstructure:"spill_on_shutdown"`
 }
 
 // NewDefaultQueueSettings returns the default settings for QueueSettings.
@@ -58,6 +65,19 @@ func NewDefaultQueueSettings() QueueSettings {
 	}
 }
 
+// orderedDelivery returns true if the batches are queued in partitions delivered sequentially.
+func (qCfg *QueueSettings) orderedDelivery() bool {
+	return qCfg.Enabled && qCfg.OrderedDelivery
+}
+
+// newMemoryQueue returns the in-memory queue of the batches, partitioned when the ordered delivery is enabled.
+func (qCfg *QueueSettings) newMemoryQueue() internal.ProducerConsumerQueue {
+	if qCfg.orderedDelivery() {
+		return internal.NewPartitionedMemoryQueue(qCfg.NumConsumers, qCfg.QueueSize, requestPartitionKey, func(item interface{}) {})
+	}
+	return internal.NewBoundedMemoryQueue(qCfg.QueueSize, func(item interface{}) {})
+}
+
 // Validate checks if the QueueSettings configuration is valid
 func (qCfg *QueueSettings) Validate() error {
 	if !qCfg.Enabled {
@@ -79,9 +99,18 @@ type queuedRetrySender struct {
 	retryStopCh     chan struct{}
 	traceAttributes []attribute.KeyValue
 	logger          *zap.Logger
+	handoff         *queueHandoff
+	// breaker pauses the consumers while the circuit is open, nil if disabled.
+	breaker *circuitBreaker
+	// durable is true if the requests are persisted before being queued, by the write-ahead log or the
+	// persistent queue, their consumerack.Ack not being held until exported.
+	durable bool
+	// account records the bytes of the requests held by the in-memory queue for the memory limiters,
+	// nil for the persistent queue.
+	account *memorypressure.Account
 }
 
-func newQueuedRetrySender(id config.ComponentID, _ config.DataType, qCfg QueueSettings, rCfg RetrySettings, _ internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg QueueSettings, rCfg RetrySettings, _ internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := attribute.String(obsmetrics.ExporterKey, id.String())
@@ -95,8 +124,10 @@ func newQueuedRetrySender(id config.ComponentID, _ config.DataType, qCfg QueueSe
 			stopCh:             retryStopCh,
 			logger:             sampledLogger,
 			onTemporaryFailure: onTemporaryFailure,
+			obsrep:             newRetryObsExporter(id, signal, globalInstruments),
 		},
-		queue:           internal.NewBoundedMemoryQueue(qCfg.QueueSize, func(item interface{}) {}),
+		queue:           qCfg.newMemoryQueue(),
+		account:         &memorypressure.Account{},
 		retryStopCh:     retryStopCh,
 		traceAttributes: []attribute.KeyValue{traceAttr},
 		logger:          sampledLogger,
@@ -113,13 +144,37 @@ func onTemporaryFailure(logger *zap.Logger, req request, err error) error {
 }
 
 // start is invoked during service startup.
-func (qrs *queuedRetrySender) start(context.Context, component.Host) error {
+func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) error {
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
 		req := item.(request)
-		_ = qrs.consumerSender.send(req)
+		qrs.breaker.wait(qrs.retryStopCh)
+		var err error
+		// The handoff releases the consumerack.Ack of the spilled requests.
+		spilled := false
+		if qrs.handoff == nil {
+			err = qrs.consumerSender.send(req)
+		} else if spilled = qrs.handoff.spill(req); !spilled {
+			err = qrs.consumerSender.send(req)
+			spilled = qrs.handoff.spillInterrupted(req, err)
+		}
 		req.OnProcessingFinished()
+		req.unaccountBytes()
+		if isDeadLettered(err) {
+			// The data is handled by the dead letter exporter.
+			err = nil
+		}
+		if !spilled {
+			req.releaseAck(err)
+		}
 	})
 
+	// Restore the requests spilled at the last shutdown.
+	if qrs.handoff != nil {
+		if err := qrs.handoff.start(ctx, host, qrs.queue); err != nil {
+			return fmt.Errorf("failed to restore the spilled sending_queue: %w", err)
+		}
+	}
+
 	// Start reporting queue length metric
 	if qrs.cfg.Enabled {
 		err := globalInstruments.queueSize.UpsertEntry(func() int64 {
@@ -134,7 +189,7 @@ func (qrs *queuedRetrySender) start(context.Context, component.Host) error {
 }
 
 // shutdown is invoked during service shutdown.
-func (qrs *queuedRetrySender) shutdown() {
+func (qrs *queuedRetrySender) shutdown(ctx context.Context) error {
 	// Cleanup queue metrics reporting
 	if qrs.cfg.Enabled {
 		_ = globalInstruments.queueSize.UpsertEntry(func() int64 {
@@ -142,6 +197,11 @@ func (qrs *queuedRetrySender) shutdown() {
 		}, metricdata.NewLabelValue(qrs.fullName))
 	}
 
+	// Spill the remaining requests instead of sending them.
+	if qrs.handoff != nil {
+		qrs.handoff.beginShutdown()
+	}
+
 	// First Stop the retry goroutines, so that unblocks the queue numWorkers.
 	close(qrs.retryStopCh)
 
@@ -150,4 +210,10 @@ func (qrs *queuedRetrySender) shutdown() {
 	if qrs.queue != nil {
 		qrs.queue.Stop()
 	}
+	qrs.account.Reset()
+
+	if qrs.handoff != nil {
+		return qrs.handoff.shutdown(ctx)
+	}
+	return nil
 }
@@ -24,15 +24,21 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
 	"go.opencensus.io/tag"
 	"go.uber.org/atomic"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumerack"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/internal/memorypressure"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -177,6 +183,70 @@ func TestQueuedRetry_DoNotPreserveCancellation(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_Ack(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.Enabled = false
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The Ack completes once the request is exported.
+	ack := consumerack.New()
+	mockR := newMockRequest(consumerack.NewContext(context.Background(), ack), 2, nil)
+	require.NoError(t, be.sender.send(mockR))
+	ack.Done(nil)
+	assert.NoError(t, ack.Wait(waitCtx))
+	mockR.checkNumRequests(t, 1)
+
+	// The Ack completes with the error of the export.
+	ack = consumerack.New()
+	mockR = newMockRequest(consumerack.NewContext(context.Background(), ack), 2, errors.New("transient error"))
+	require.NoError(t, be.sender.send(mockR))
+	ack.Done(nil)
+	assert.EqualError(t, ack.Wait(waitCtx), "transient error")
+
+	// The Ack is not held when the requests are persisted before being queued.
+	be.qrSender.durable = true
+	ack = consumerack.New()
+	mockR = newMockRequest(consumerack.NewContext(context.Background(), ack), 2, nil)
+	require.NoError(t, be.sender.send(mockR))
+	assert.Nil(t, mockR.ackRelease)
+	ack.Done(nil)
+	assert.NoError(t, ack.Wait(waitCtx))
+}
+
+func TestQueuedRetry_AccountBytes(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	rCfg := NewDefaultRetrySettings()
+	rCfg.Enabled = false
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	base := memorypressure.AccountedBytes()
+
+	// The requests are accounted while queued, before the consumers are started.
+	mockR := newMockRequest(context.Background(), 2, nil)
+	require.NoError(t, be.sender.send(mockR))
+	assert.Equal(t, int64(200), be.qrSender.account.Bytes())
+	assert.Equal(t, base+200, memorypressure.AccountedBytes())
+
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+	mockR.checkNumRequests(t, 1)
+	assert.Eventually(t, func() bool {
+		return be.qrSender.account.Bytes() == 0
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, base, memorypressure.AccountedBytes())
+}
+
 func TestQueuedRetry_MaxElapsedTime(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.NumConsumers = 1
@@ -216,6 +286,49 @@ func TestQueuedRetry_MaxElapsedTime(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestRetrySender_MaxElapsedTimeFromEnqueuedTime(t *testing.T) {
+	insts := newInstruments(metric.NewRegistry())
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Millisecond
+	rCfg.MaxElapsedTime = time.Minute
+	rs := &retrySender{
+		cfg:                rCfg,
+		nextSender:         &timeoutSender{},
+		stopCh:             make(chan struct{}),
+		logger:             zap.NewNop(),
+		onTemporaryFailure: func(_ *zap.Logger, _ request, err error) error { return err },
+		obsrep:             newRetryObsExporter(defaultExporterCfg.ID(), config.TracesDataType, insts),
+	}
+
+	// The request has been waiting in the queue for longer than the max elapsed time, so it is not retried.
+	req := &mockErrorRequest{baseRequest: baseRequest{ctx: context.Background(), enqueued: time.Now().Add(-time.Hour)}}
+	err := rs.send(req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max elapsed time expired")
+	assert.True(t, checkValueForProducer(t, insts.registry, tagsForExporterView(defaultExporterCfg.ID()), 7, "exporter/retry_expired_spans"))
+	assert.True(t, checkValueForProducer(t, insts.registry, tagsForExporterView(defaultExporterCfg.ID()), 0, "exporter/send_retries"))
+}
+
+func TestRetrySender_RecordRetries(t *testing.T) {
+	insts := newInstruments(metric.NewRegistry())
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 0
+	rs := &retrySender{
+		cfg:                rCfg,
+		nextSender:         &timeoutSender{},
+		stopCh:             make(chan struct{}),
+		logger:             zap.NewNop(),
+		onTemporaryFailure: func(_ *zap.Logger, _ request, err error) error { return err },
+		obsrep:             newRetryObsExporter(defaultExporterCfg.ID(), config.LogsDataType, insts),
+	}
+
+	mockR := newMockRequest(context.Background(), 2, errors.New("transient error"))
+	require.NoError(t, rs.send(mockR))
+	mockR.checkNumRequests(t, 2)
+	assert.True(t, checkValueForProducer(t, insts.registry, tagsForExporterView(defaultExporterCfg.ID()), 1, "exporter/send_retries"))
+	assert.True(t, checkValueForProducer(t, insts.registry, tagsForExporterView(defaultExporterCfg.ID()), 0, "exporter/retry_backoff_time"))
+}
+
 type wrappedError struct {
 	error
 }
@@ -238,6 +351,7 @@ func TestQueuedRetry_ThrottleError(t *testing.T) {
 	})
 
 	retry := NewThrottleRetry(errors.New("throttle error"), 100*time.Millisecond)
+	assert.Equal(t, obsreport.ErrorTypeThrottled, obsreport.ErrorType(wrappedError{retry}))
 	mockR := newMockRequest(context.Background(), 2, wrappedError{retry})
 	start := time.Now()
 	ocs.run(func() {
@@ -399,6 +513,10 @@ func (mer *mockErrorRequest) count() int {
 	return 7
 }
 
+func (mer *mockErrorRequest) bytesSize() int {
+	return 0
+}
+
 func newErrorRequest(ctx context.Context) request {
 	return &mockErrorRequest{
 		baseRequest: baseRequest{ctx: ctx},
@@ -449,6 +567,10 @@ func (m *mockRequest) count() int {
 	return m.cnt
 }
 
+func (m *mockRequest) bytesSize() int {
+	return 100 * m.cnt
+}
+
 func newMockRequest(ctx context.Context, cnt int, consumeError error) *mockRequest {
 	return &mockRequest{
 		baseRequest:  baseRequest{ctx: ctx},
@@ -517,10 +639,13 @@ func checkValueForGlobalManager(t *testing.T, wantTags []tag.Tag, value int64, v
 // checkValueForProducer checks that the given metrics with wantTags is reported by the metric producer
 func checkValueForProducer(t *testing.T, producer metricproducer.Producer, wantTags []tag.Tag, value int64, vName string) bool {
 	for _, metric := range producer.Read() {
-		if metric.Descriptor.Name == vName && len(metric.TimeSeries) > 0 {
-			lastValue := metric.TimeSeries[len(metric.TimeSeries)-1]
-			if tagsMatchLabelKeys(wantTags, metric.Descriptor.LabelKeys, lastValue.LabelValues) {
-				require.Equal(t, value, lastValue.Points[len(lastValue.Points)-1].Value.(int64))
+		if metric.Descriptor.Name != vName {
+			continue
+		}
+		// The time series of the other exporters are reported in no particular order.
+		for _, ts := range metric.TimeSeries {
+			if tagsMatchLabelKeys(wantTags, metric.Descriptor.LabelKeys, ts.LabelValues) {
+				require.Equal(t, value, ts.Points[len(ts.Points)-1].Value.(int64))
 				return true
 			}
 		}
@@ -17,6 +17,9 @@ package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporte
 import (
 	"context"
 	"errors"
+	"time"
+
+	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -27,6 +30,7 @@ import (
 )
 
 var tracesMarshaler = ptrace.NewProtoMarshaler()
+var tracesSizer = tracesMarshaler.(ptrace.Sizer)
 var tracesUnmarshaler = ptrace.NewProtoUnmarshaler()
 
 type tracesRequest struct {
@@ -37,7 +41,7 @@ type tracesRequest struct {
 
 func newTracesRequest(ctx context.Context, td ptrace.Traces, pusher consumer.ConsumeTracesFunc) request {
 	return &tracesRequest{
-		baseRequest: baseRequest{ctx: ctx},
+		baseRequest: baseRequest{ctx: ctx, enqueued: time.Now()},
 		td:          td,
 		pusher:      pusher,
 	}
@@ -45,35 +49,58 @@ func newTracesRequest(ctx context.Context, td ptrace.Traces, pusher consumer.Con
 
 func newTraceRequestUnmarshalerFunc(pusher consumer.ConsumeTracesFunc) internal.RequestUnmarshaler {
 	return func(bytes []byte) (internal.PersistentRequest, error) {
-		traces, err := tracesUnmarshaler.UnmarshalTraces(bytes)
+		key, enqueued, data, err := unmarshalPersistedRequest(bytes)
+		if err != nil {
+			return nil, err
+		}
+		traces, err := tracesUnmarshaler.UnmarshalTraces(data)
 		if err != nil {
 			return nil, err
 		}
-		return newTracesRequest(context.Background(), traces, pusher), nil
+		req := newTracesRequest(context.Background(), traces, pusher)
+		req.setIdempotencyKey(key)
+		// The requests persisted without enqueued time are accounted from the time they are read.
+		if !enqueued.IsZero() {
+			req.setEnqueuedTime(enqueued)
+		}
+		return req, nil
 	}
 }
 
 // Marshal provides serialization capabilities required by persistent queue
 func (req *tracesRequest) Marshal() ([]byte, error) {
-	return tracesMarshaler.MarshalTraces(req.td)
+	data, err := tracesMarshaler.MarshalTraces(req.td)
+	if err != nil {
+		return nil, err
+	}
+	return marshalPersistedRequest(req.idempotencyKey, req.enqueued, data), nil
 }
 
 func (req *tracesRequest) onError(err error) request {
 	var traceError consumererror.Traces
 	if errors.As(err, &traceError) {
-		return newTracesRequest(req.ctx, traceError.GetTraces(), req.pusher)
+		// The partial request keeps the enqueued time of the original request, see retrySender.
+		return &tracesRequest{
+			baseRequest: baseRequest{ctx: req.ctx, enqueued: req.enqueued, idempotencyKey: partialIdempotencyKey(req.idempotencyKey), partition: req.partition},
+			td:          traceError.GetTraces(),
+			pusher:      req.pusher,
+		}
 	}
 	return req
 }
 
 func (req *tracesRequest) export(ctx context.Context) error {
-	return req.pusher(ctx, req.td)
+	return req.pusher(contextWithIdempotencyKey(ctx, req.idempotencyKey), req.td)
 }
 
 func (req *tracesRequest) count() int {
 	return req.td.SpanCount()
 }
 
+func (req *tracesRequest) bytesSize() int {
+	return tracesSizer.TracesSize(req.td)
+}
+
 type traceExporter struct {
 	*baseExporter
 	consumer.Traces
@@ -100,6 +127,9 @@ func NewTracesExporter(
 	}
 
 	bs := fromOptions(options...)
+	if err := bs.RetrySettings.Validate(); err != nil {
+		return nil, err
+	}
 	be := newBaseExporter(cfg, set, bs, config.TracesDataType, newTraceRequestUnmarshalerFunc(pusher))
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &tracesExporterWithObservability{
@@ -108,13 +138,33 @@ func NewTracesExporter(
 		}
 	})
 
-	tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+	send := func(ctx context.Context, td ptrace.Traces, partition uint64) error {
 		req := newTracesRequest(ctx, td, pusher)
+		req.setIdempotencyKey(bs.newRequestIdempotencyKey())
+		req.setPartitionKey(partition)
 		err := be.sender.send(req)
 		if errors.Is(err, errSendingQueueIsFull) {
 			be.obsrep.recordTracesEnqueueFailure(req.context(), int64(req.count()))
 		}
 		return err
+	}
+	tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if !bs.QueueSettings.orderedDelivery() {
+			return send(ctx, td, 0)
+		}
+		// Every resource is sent in its own request, in the partition of the resource.
+		rss := td.ResourceSpans()
+		if rss.Len() == 1 {
+			return send(ctx, td, resourcePartitionKey(rss.At(0).Resource()))
+		}
+		var errs error
+		for i := 0; i < rss.Len(); i++ {
+			// The data is copied since it may be shared with other consumers.
+			rtd := ptrace.NewTraces()
+			rss.At(i).CopyTo(rtd.ResourceSpans().AppendEmpty())
+			errs = multierr.Append(errs, send(ctx, rtd, resourcePartitionKey(rss.At(i).Resource())))
+		}
+		return errs
 	}, bs.consumerOptions...)
 
 	return &traceExporter{
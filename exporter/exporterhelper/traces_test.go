@@ -16,6 +16,8 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,7 +52,10 @@ func TestTracesRequest(t *testing.T) {
 	mr := newTracesRequest(context.Background(), testdata.GenerateTraces(1), nil)
 
 	traceErr := consumererror.NewTraces(errors.New("some error"), ptrace.NewTraces())
-	assert.EqualValues(t, newTracesRequest(context.Background(), ptrace.NewTraces(), nil), mr.onError(traceErr))
+	partial := mr.onError(traceErr)
+	assert.EqualValues(t, ptrace.NewTraces(), partial.(*tracesRequest).td)
+	// The enqueued time of the original request is kept, so that retrying the partial request does not exceed max_elapsed_time.
+	assert.Equal(t, mr.enqueuedTime(), partial.enqueuedTime())
 }
 
 func TestTracesExporter_InvalidName(t *testing.T) {
@@ -71,6 +76,14 @@ func TestTracesExporter_NilPushTraceData(t *testing.T) {
 	require.Equal(t, errNilPushTraceData, err)
 }
 
+func TestTracesExporter_InvalidRetrySettings(t *testing.T) {
+	rCfg := NewDefaultRetrySettings()
+	rCfg.Strategy = "linear"
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(nil), WithRetry(rCfg))
+	require.Nil(t, te)
+	require.EqualError(t, err, `unsupported backoff strategy "linear", must be one of "exponential", "constant" or "fibonacci"`)
+}
+
 func TestTracesExporter_Default(t *testing.T) {
 	td := ptrace.NewTraces()
 	te, err := NewTracesExporter(&fakeTracesExporterConfig, componenttest.NewNopExporterCreateSettings(), newTraceDataPusher(nil))
@@ -145,6 +158,51 @@ func TestTracesExporter_WithRecordEnqueueFailedMetrics(t *testing.T) {
 	checkExporterEnqueueFailedTracesStats(t, globalInstruments, fakeTracesExporterName, int64(10))
 }
 
+func TestTracesExporter_WithOrderedDelivery(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string][]string{}
+	pusher := func(ctx context.Context, td ptrace.Traces) error {
+		// Every request holds the spans of a single resource.
+		require.Equal(t, 1, td.ResourceSpans().Len())
+		rs := td.ResourceSpans().At(0)
+		svc, _ := rs.Resource().Attributes().Get("service.name")
+		mu.Lock()
+		defer mu.Unlock()
+		spans := rs.ScopeSpans().At(0).Spans()
+		for i := 0; i < spans.Len(); i++ {
+			received[svc.StringVal()] = append(received[svc.StringVal()], spans.At(i).Name())
+		}
+		return nil
+	}
+
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 2
+	qCfg.OrderedDelivery = true
+	te, err := NewTracesExporter(&fakeTracesExporterConfig, componenttest.NewNopExporterCreateSettings(), pusher, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	const numBatches = 20
+	for i := 0; i < numBatches; i++ {
+		td := ptrace.NewTraces()
+		for _, svc := range []string{"svc-a", "svc-b", "svc-c"} {
+			rs := td.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().InsertString("service.name", svc)
+			rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(fmt.Sprintf("span-%d", i))
+		}
+		require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	}
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	require.Len(t, received, 3)
+	for svc, names := range received {
+		require.Len(t, names, numBatches, svc)
+		for i, name := range names {
+			assert.Equal(t, fmt.Sprintf("span-%d", i), name, svc)
+		}
+	}
+}
+
 func TestTracesExporter_WithSpan(t *testing.T) {
 	set := componenttest.NewNopExporterCreateSettings()
 	sr := new(tracetest.SpanRecorder)
@@ -259,3 +317,34 @@ func checkWrapSpanForTracesExporter(t *testing.T, sr *tracetest.SpanRecorder, tr
 		require.Containsf(t, sd.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(failedToSendSpans)}, "SpanData %v", sd)
 	}
 }
+
+func TestTracesExporter_WithCallbacks(t *testing.T) {
+	want := errors.New("export failed")
+	var successes, failures []int
+	var failureErrs []error
+	te, err := NewTracesExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(),
+		func(_ context.Context, td ptrace.Traces) error {
+			if td.SpanCount() == 3 {
+				return consumererror.NewPermanent(want)
+			}
+			return nil
+		},
+		WithOnSuccess(func(_ context.Context, items int) { successes = append(successes, items) }),
+		WithOnFailure(func(_ context.Context, items int, err error) {
+			failures = append(failures, items)
+			failureErrs = append(failureErrs, err)
+		}),
+		WithOnFailure(func(_ context.Context, items int, err error) { failures = append(failures, -items) }))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, te.Shutdown(context.Background())) })
+
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.Error(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	assert.Equal(t, []int{2, 1}, successes)
+	assert.Equal(t, []int{3, -3}, failures)
+	require.Len(t, failureErrs, 1)
+	assert.ErrorIs(t, failureErrs[0], want)
+}
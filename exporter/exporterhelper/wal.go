@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper // import "go.opentelemetry.io/collector/exporter/exporterhelper"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+)
+
+// WALSettings defines configuration for the write-ahead log, which persists every batch
+// before it is accepted and removes it once its processing is finished.
+type WALSettings struct {
+	// Enabled indicates whether to write batches to the write-ahead log before accepting them.
+	Enabled bool `mapstructure:"enabled"`
+	// Directory is the directory where the write-ahead log files are stored.
+	Directory string `mapstructure:"directory"`
+}
+
+// Validate checks if the WALSettings configuration is valid
+func (wCfg *WALSettings) Validate() error {
+	if !wCfg.Enabled {
+		return nil
+	}
+
+	if wCfg.Directory == "" {
+		return errors.New("write-ahead log directory must be specified")
+	}
+
+	return nil
+}
+
+// walReplayRetryInterval is the interval between the attempts to replay a request refused by a full queue.
+var walReplayRetryInterval = time.Second
+
+// walRequest is a request that was written to the write-ahead log.
+type walRequest struct {
+	request
+	index uint64
+}
+
+// Marshal implements the request interface, persisting the index of the record with the request so that
+// the request read back from the persistent queue is acknowledged in the write-ahead log once sent.
+// The requests still in the persistent queue at the shutdown are replayed from the write-ahead log as well.
+func (wr *walRequest) Marshal() ([]byte, error) {
+	data, err := wr.request.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return marshalWALPersistedRequest(wr.index, data), nil
+}
+
+// walSender is a request sender that writes every request to the write-ahead log before
+// forwarding it, and acknowledges it once its processing is finished.
+//
+// Requests whose processing was interrupted by the shutdown are not acknowledged,
+// and are replayed when the exporter starts again. The replayed requests refused by
+// a full queue are retried until accepted, or kept for the next start.
+type walSender struct {
+	dir         string
+	nextSender  requestSender
+	unmarshaler internal.RequestUnmarshaler
+	logger      *zap.Logger
+
+	wal      *internal.WAL
+	stopping *atomic.Bool
+	stopCh   chan struct{}
+	replayWG sync.WaitGroup
+}
+
+func newWALSender(id config.ComponentID, signal config.DataType, wCfg WALSettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *walSender {
+	return &walSender{
+		dir:         filepath.Join(wCfg.Directory, strings.ReplaceAll(id.String(), "/", "_")+"_"+string(signal)),
+		nextSender:  nextSender,
+		unmarshaler: reqUnmarshaler,
+		logger:      logger,
+		stopping:    atomic.NewBool(false),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// start opens the write-ahead log, and replays in background the requests not acknowledged before the last shutdown.
+// Must be invoked after the next sender is started.
+func (ws *walSender) start() error {
+	wal, err := internal.OpenWAL(ws.dir)
+	if err != nil {
+		return err
+	}
+	ws.wal = wal
+
+	pending := wal.Pending()
+	if len(pending) == 0 {
+		return nil
+	}
+	ws.logger.Info("Replaying requests from the write-ahead log", zap.Int("requests", len(pending)))
+	ws.replayWG.Add(1)
+	go func() {
+		defer ws.replayWG.Done()
+		for _, record := range pending {
+			if ws.stopping.Load() {
+				return
+			}
+			req, err := ws.unmarshaler(record.Data)
+			if err != nil {
+				ws.logger.Error("Dropping invalid request from the write-ahead log", zap.Error(err))
+				ws.ack(record.Index)
+				continue
+			}
+			if !ws.replay(&walRequest{request: req.(request), index: record.Index}) {
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// replay sends the request, retrying while the queue is full. Returns false if the shutdown
+// started before the request was accepted, the request being kept in the write-ahead log.
+func (ws *walSender) replay(wr *walRequest) bool {
+	for {
+		if err := ws.nextSender.send(wr); !errors.Is(err, errSendingQueueIsFull) {
+			return true
+		}
+		select {
+		case <-ws.stopCh:
+			return false
+		case <-time.After(walReplayRetryInterval):
+		}
+	}
+}
+
+// send implements the requestSender interface
+func (ws *walSender) send(req request) error {
+	data, err := req.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal request for the write-ahead log: %w", err)
+	}
+	index, err := ws.wal.Append(data)
+	if err != nil {
+		return err
+	}
+
+	err = ws.nextSender.send(&walRequest{request: req, index: index})
+	if errors.Is(err, errSendingQueueIsFull) {
+		// The request was rejected, the caller is responsible to retry it.
+		ws.ack(index)
+	}
+	return err
+}
+
+// wrapConsumerSender returns a sender acknowledging the requests sent by the given consumer sender.
+func (ws *walSender) wrapConsumerSender(consumerSender requestSender) requestSender {
+	return &walAckSender{ws: ws, nextSender: consumerSender}
+}
+
+// unmarshalQueuedRequest is the unmarshaler of the persistent queue, restoring the walRequest of the
+// requests persisted with the index of their record.
+func (ws *walSender) unmarshalQueuedRequest(buf []byte) (internal.PersistentRequest, error) {
+	index, data, ok, err := unmarshalWALPersistedRequest(buf)
+	if err != nil {
+		return nil, err
+	}
+	req, err := ws.unmarshaler(data)
+	if err != nil || !ok {
+		return req, err
+	}
+	return &walRequest{request: req.(request), index: index}, nil
+}
+
+func (ws *walSender) ack(index uint64) {
+	if err := ws.wal.Ack(index); err != nil {
+		ws.logger.Error("Failed to acknowledge request in the write-ahead log", zap.Error(err))
+	}
+}
+
+// beginShutdown stops acknowledging failed requests, so that requests interrupted by the shutdown are kept.
+func (ws *walSender) beginShutdown() {
+	ws.stopping.Store(true)
+	close(ws.stopCh)
+}
+
+// shutdown waits for the replay to finish and closes the write-ahead log.
+// Must be invoked after the next sender is shutdown.
+func (ws *walSender) shutdown() error {
+	ws.replayWG.Wait()
+	if ws.wal == nil {
+		return nil
+	}
+	return ws.wal.Close()
+}
+
+// walAckSender is a request sender that acknowledges the write-ahead log requests once sent.
+type walAckSender struct {
+	ws         *walSender
+	nextSender requestSender
+}
+
+// send implements the requestSender interface
+func (was *walAckSender) send(req request) error {
+	err := was.nextSender.send(req)
+	wr, ok := req.(*walRequest)
+	if !ok {
+		return err
+	}
+	if err != nil && was.ws.stopping.Load() {
+		return err
+	}
+	was.ws.ack(wr.index)
+	return err
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestWALSettings_Validate(t *testing.T) {
+	wCfg := WALSettings{}
+	assert.NoError(t, wCfg.Validate())
+
+	wCfg.Enabled = true
+	assert.EqualError(t, wCfg.Validate(), "write-ahead log directory must be specified")
+
+	wCfg.Directory = t.TempDir()
+	assert.NoError(t, wCfg.Validate())
+}
+
+func TestWAL_AckOnDelivery(t *testing.T) {
+	wCfg := WALSettings{Enabled: true, Directory: t.TempDir()}
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(nil), WithWAL(wCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	assert.Empty(t, openLogsExporterWAL(t, wCfg.Directory).Pending())
+}
+
+func TestWAL_ReplayInterruptedOnShutdown(t *testing.T) {
+	wCfg := WALSettings{Enabled: true, Directory: t.TempDir()}
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Second
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+
+	attempted := atomic.NewBool(false)
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, plog.Logs) error {
+		attempted.Store(true)
+		return errors.New("transient error")
+	}, WithWAL(wCfg), WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	assert.Eventually(t, attempted.Load, time.Second, 10*time.Millisecond)
+	// Shutdown interrupts the retries, the logs must be kept in the write-ahead log.
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	received := make(chan plog.Logs, 1)
+	le, err = NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(_ context.Context, ld plog.Logs) error {
+		received <- ld
+		return nil
+	}, WithWAL(wCfg), WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	select {
+	case ld := <-received:
+		assert.Equal(t, testdata.GenerateLogs(2), ld)
+	case <-time.After(5 * time.Second):
+		t.Fatal("logs were not replayed from the write-ahead log")
+	}
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	assert.Empty(t, openLogsExporterWAL(t, wCfg.Directory).Pending())
+}
+
+func TestWAL_ReplayRetriesFullQueue(t *testing.T) {
+	retryInterval := walReplayRetryInterval
+	walReplayRetryInterval = 10 * time.Millisecond
+	t.Cleanup(func() { walReplayRetryInterval = retryInterval })
+
+	wCfg := WALSettings{Enabled: true, Directory: t.TempDir()}
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 10 * time.Second
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, plog.Logs) error {
+		return errors.New("transient error")
+	}, WithWAL(wCfg), WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	// The queue holds a single request while the first one is blocked, the third one is
+	// refused until the queue has room again.
+	qCfg.QueueSize = 1
+	unblock := make(chan struct{})
+	received := atomic.NewInt64(0)
+	le, err = NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), func(context.Context, plog.Logs) error {
+		<-unblock
+		received.Inc()
+		return nil
+	}, WithWAL(wCfg), WithRetry(rCfg), WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	time.Sleep(5 * walReplayRetryInterval)
+	close(unblock)
+	assert.Eventually(t, func() bool { return received.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	assert.Empty(t, openLogsExporterWAL(t, wCfg.Directory).Pending())
+}
+
+func TestWAL_AckOnDropped(t *testing.T) {
+	wCfg := WALSettings{Enabled: true, Directory: t.TempDir()}
+	wantErr := errors.New("my_error")
+	le, err := NewLogsExporter(&fakeLogsExporterConfig, componenttest.NewNopExporterCreateSettings(), newPushLogsData(wantErr), WithWAL(wCfg))
+	require.NoError(t, err)
+	require.NoError(t, le.Start(context.Background(), componenttest.NewNopHost()))
+	// The error is returned to the caller, which is responsible to retry the logs.
+	assert.Equal(t, wantErr, le.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, le.Shutdown(context.Background()))
+
+	assert.Empty(t, openLogsExporterWAL(t, wCfg.Directory).Pending())
+}
+
+// openLogsExporterWAL opens the write-ahead log used by the fake logs exporter.
+func openLogsExporterWAL(t *testing.T, dir string) *internal.WAL {
+	wal, err := internal.OpenWAL(filepath.Join(dir, "fake_logs_exporter_with_name_logs"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, wal.Close()) })
+	return wal
+}
@@ -80,6 +80,10 @@ func TestLoggingExporterErrors(t *testing.T) {
 	assert.Equal(t, errWant, le.pushLogs(context.Background(), plog.NewLogs()))
 }
 
+func TestLoggingExporterLifecycle(t *testing.T) {
+	componenttest.CheckLifecycle(t, NewFactory())
+}
+
 type errMarshaler struct {
 	err error
 }
@@ -16,29 +16,109 @@ package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexpor
 
 import (
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 // Config defines configuration for OpenCensus exporter.
 type Config struct {
-	config.ExporterSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
-	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+	config.ExporterSettings           `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	exporterhelper.TimeoutSettings    `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings      `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings      `mapstructure:"retry_on_failure"`
+	exporterhelper.WALSettings        `mapstructure:"wal"`
+	exporterhelper.DeadLetterSettings `mapstructure:"dead_letter"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// The endpoint to send traces to. If omitted the Endpoint will be used.
+	TracesEndpoint string `mapstructure:"traces_endpoint"`
+
+	// The endpoint to send metrics to. If omitted the Endpoint will be used.
+	MetricsEndpoint string `mapstructure:"metrics_endpoint"`
+
+	// The endpoint to send logs to. If omitted the Endpoint will be used.
+	LogsEndpoint string `mapstructure:"logs_endpoint"`
+
+	// The headers sent with the traces, in addition to Headers and replacing the ones with the same names.
+	TracesHeaders map[string]configopaque.String `mapstructure:"traces_headers"`
+
+	// The headers sent with the metrics, in addition to Headers and replacing the ones with the same names.
+	MetricsHeaders map[string]configopaque.String `mapstructure:"metrics_headers"`
+
+	// The headers sent with the logs, in addition to Headers and replacing the ones with the same names.
+	LogsHeaders map[string]configopaque.String `mapstructure:"logs_headers"`
+
+	// MaxRequestSizeMiB is the maximum size (in MiB) of the requests, advertised through the capabilities
+	// of the exporter for the batch processor to split the larger batches, e.g. to fit the maximum size of
+	// the messages accepted by the server. Zero disables the limit.
+	MaxRequestSizeMiB uint64 `mapstructure:"max_request_size_mib"`
+
+	// StreamProtocol is the ID of the extension providing an alternative wire protocol, see Streamer,
+	// negotiated on the connection, the data being exported with unary OTLP if the server does not
+	// support it. If nil the data is exported with unary OTLP.
+	// Experimental: *NOTE* this option is subject to change or removal in the future.
+	StreamProtocol *config.ComponentID `mapstructure:"stream_protocol"`
 }
 
 var _ config.Exporter = (*Config)(nil)
+var _ config.ExtensionDependent = (*Config)(nil)
+
+// ExtensionDependencies returns the IDs of the authenticator and stream protocol extensions of the exporter.
+func (cfg *Config) ExtensionDependencies() []config.ComponentID {
+	ids := cfg.GRPCClientSettings.ExtensionDependencies()
+	if cfg.StreamProtocol != nil {
+		ids = append(ids, *cfg.StreamProtocol)
+	}
+	return ids
+}
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
 	if err := cfg.QueueSettings.Validate(); err != nil {
 		return fmt.Errorf("queue settings has invalid configuration: %w", err)
 	}
+	if err := cfg.RetrySettings.Validate(); err != nil {
+		return fmt.Errorf("retry settings has invalid configuration: %w", err)
+	}
+	if err := cfg.WALSettings.Validate(); err != nil {
+		return fmt.Errorf("wal settings has invalid configuration: %w", err)
+	}
 
 	return nil
 }
+
+// signalClientSettings returns the client settings of a signal, with the endpoint and the headers
+// of the signal, if any, replacing the common ones.
+func (cfg *Config) signalClientSettings(endpoint string, headers map[string]configopaque.String) configgrpc.GRPCClientSettings {
+	settings := cfg.GRPCClientSettings
+	if endpoint != "" {
+		settings.Endpoint = endpoint
+	}
+	if len(headers) > 0 {
+		settings.Headers = make(map[string]configopaque.String, len(cfg.Headers)+len(headers))
+		for k, v := range cfg.Headers {
+			settings.Headers[k] = v
+		}
+		for k, v := range headers {
+			// The header names are case insensitive.
+			for name := range settings.Headers {
+				if strings.EqualFold(name, k) {
+					delete(settings.Headers, name)
+				}
+			}
+			settings.Headers[k] = v
+		}
+	}
+	return settings
+}
+
+// capabilities returns the capabilities of the exporter, advertising the maximum size of the requests.
+func (cfg *Config) capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false, MaxRequestBytes: int(cfg.MaxRequestSizeMiB) * 1024 * 1024}
+}
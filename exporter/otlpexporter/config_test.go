@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/servicetest"
@@ -64,8 +65,12 @@ func TestLoadConfig(t *testing.T) {
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			WALSettings: exporterhelper.WALSettings{
+				Enabled:   true,
+				Directory: "/var/lib/otelcol/wal",
+			},
 			GRPCClientSettings: configgrpc.GRPCClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
 					"header1":                "234",
 					"another":                "somevalue",
@@ -87,5 +92,10 @@ func TestLoadConfig(t *testing.T) {
 				BalancerName:    "round_robin",
 				Auth:            &configauth.Authentication{AuthenticatorID: config.NewComponentID("nop")},
 			},
+			LogsEndpoint: "1.2.3.5:1234",
+			LogsHeaders: map[string]configopaque.String{
+				"header1": "567",
+			},
+			MaxRequestSizeMiB: 16,
 		})
 }
@@ -19,8 +19,10 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sync"
 	"time"
 
+	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -29,6 +31,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -40,8 +44,8 @@ import (
 )
 
 type exporter struct {
-	// Input configuration.
-	config *Config
+	// Input configuration, of the exported signal.
+	clientSettings configgrpc.GRPCClientSettings
 
 	// gRPC clients and connection.
 	traceExporter  ptraceotlp.Client
@@ -51,6 +55,15 @@ type exporter struct {
 	metadata       metadata.MD
 	callOptions    []grpc.CallOption
 
+	// Alternative wire protocol, if configured, negotiated on the first export.
+	streamProtocol    *config.ComponentID
+	streamer          Streamer
+	streamCtx         context.Context
+	streamCancel      context.CancelFunc
+	streamMu          sync.Mutex
+	stream            Stream
+	streamUnsupported bool
+
 	settings component.TelemetrySettings
 
 	// Default user-agent header.
@@ -59,60 +72,92 @@ type exporter struct {
 
 // Crete new exporter and start it. The exporter will begin connecting but
 // this function may return before the connection is established.
-func newExporter(cfg config.Exporter, set component.ExporterCreateSettings) (*exporter, error) {
+// The signal endpoint and headers, if any, replace the common ones.
+func newExporter(cfg config.Exporter, set component.ExporterCreateSettings, endpoint string, headers map[string]configopaque.String) (*exporter, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Endpoint == "" {
+	clientSettings := oCfg.signalClientSettings(endpoint, headers)
+	if clientSettings.Endpoint == "" {
 		return nil, errors.New("OTLP exporter config requires an Endpoint")
 	}
 
 	userAgent := fmt.Sprintf("%s/%s (%s/%s)",
 		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
 
-	return &exporter{config: oCfg, settings: set.TelemetrySettings, userAgent: userAgent}, nil
+	return &exporter{
+		clientSettings: clientSettings,
+		streamProtocol: oCfg.StreamProtocol,
+		settings:       set.TelemetrySettings,
+		userAgent:      userAgent,
+	}, nil
 }
 
 // start actually creates the gRPC connection. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *exporter) start(ctx context.Context, host component.Host) (err error) {
-	dialOpts, err := e.config.GRPCClientSettings.ToDialOptions(host, e.settings)
+	if e.streamProtocol != nil {
+		if e.streamer, err = getStreamer(host.GetExtensions(), *e.streamProtocol); err != nil {
+			return err
+		}
+		e.streamCtx, e.streamCancel = context.WithCancel(context.Background())
+	}
+
+	dialOpts, err := e.clientSettings.ToDialOptions(host, e.settings)
 	if err != nil {
 		return err
 	}
 	dialOpts = append(dialOpts, grpc.WithUserAgent(e.userAgent))
 
-	if e.clientConn, err = grpc.DialContext(ctx, e.config.GRPCClientSettings.SanitizedEndpoint(), dialOpts...); err != nil {
+	if e.clientConn, err = grpc.DialContext(ctx, e.clientSettings.SanitizedEndpoint(), dialOpts...); err != nil {
 		return err
 	}
 
 	e.traceExporter = ptraceotlp.NewClient(e.clientConn)
 	e.metricExporter = pmetricotlp.NewClient(e.clientConn)
 	e.logExporter = plogotlp.NewClient(e.clientConn)
-	e.metadata = metadata.New(e.config.GRPCClientSettings.Headers)
+	e.metadata = metadata.New(configopaque.MapToStrings(e.clientSettings.Headers))
 	e.callOptions = []grpc.CallOption{
-		grpc.WaitForReady(e.config.GRPCClientSettings.WaitForReady),
+		grpc.WaitForReady(e.clientSettings.WaitForReady),
 	}
 
 	return
 }
 
 func (e *exporter) shutdown(context.Context) error {
+	if err := e.closeStream(); err != nil {
+		e.settings.Logger.Warn("Failed to close the stream", zap.Error(err))
+	}
 	return e.clientConn.Close()
 }
 
 func (e *exporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	if stream := e.getStream(); stream != nil {
+		if err := stream.SendTraces(e.enhanceContext(ctx), td); !e.fallBack(stream, err) {
+			return processError(err)
+		}
+	}
 	req := ptraceotlp.NewRequestFromTraces(td)
 	_, err := e.traceExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
 	return processError(err)
 }
 
 func (e *exporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if stream := e.getStream(); stream != nil {
+		if err := stream.SendMetrics(e.enhanceContext(ctx), md); !e.fallBack(stream, err) {
+			return processError(err)
+		}
+	}
 	req := pmetricotlp.NewRequestFromMetrics(md)
 	_, err := e.metricExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
 	return processError(err)
 }
 
 func (e *exporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	if stream := e.getStream(); stream != nil {
+		if err := stream.SendLogs(e.enhanceContext(ctx), ld); !e.fallBack(stream, err) {
+			return processError(err)
+		}
+	}
 	req := plogotlp.NewRequestFromLogs(ld)
 	_, err := e.logExporter.Export(e.enhanceContext(ctx), req, e.callOptions...)
 	return processError(err)
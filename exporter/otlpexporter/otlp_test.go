@@ -37,6 +37,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -213,7 +214,7 @@ func TestSendTraces(t *testing.T) {
 		TLSSetting: configtls.TLSClientSetting{
 			Insecure: true,
 		},
-		Headers: map[string]string{
+		Headers: map[string]configopaque.String{
 			"header": "header-value",
 		},
 	}
@@ -270,6 +271,52 @@ func TestSendTraces(t *testing.T) {
 	require.Contains(t, md.Get("User-Agent")[0], "Collector/1.2.3test")
 }
 
+func TestSendTracesSignalSettings(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	// The traces endpoint and headers replace the common ones.
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: "localhost:1",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Headers: map[string]configopaque.String{
+			"Header": "header-value",
+			"other":  "other-value",
+		},
+	}
+	cfg.TracesEndpoint = ln.Addr().String()
+	cfg.TracesHeaders = map[string]configopaque.String{
+		"header": "traces-value",
+	}
+	cfg.MetricsHeaders = map[string]configopaque.String{
+		"header": "metrics-value",
+	}
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Eventually(t, func() bool {
+		return rcv.requestCount.Load() > 0
+	}, 10*time.Second, 5*time.Millisecond)
+
+	md := rcv.GetMetadata()
+	assert.Equal(t, []string{"traces-value"}, md.Get("header"))
+	assert.Equal(t, []string{"other-value"}, md.Get("other"))
+	// The common headers are not modified.
+	assert.Equal(t, configopaque.String("header-value"), cfg.Headers["Header"])
+}
+
 func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -359,7 +406,7 @@ func TestSendMetrics(t *testing.T) {
 		TLSSetting: configtls.TLSClientSetting{
 			Insecure: true,
 		},
-		Headers: map[string]string{
+		Headers: map[string]configopaque.String{
 			"header": "header-value",
 		},
 	}
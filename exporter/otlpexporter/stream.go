@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpexporter // import "go.opentelemetry.io/collector/exporter/otlpexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	// ErrStreamUnsupported is returned by a Streamer or a Stream when the server does not support the
+	// protocol, or not for the data, for the exporter to fall back to the unary OTLP export.
+	ErrStreamUnsupported = errors.New("stream protocol not supported")
+
+	errStreamerNotFound = errors.New("stream protocol extension not found")
+	errNotStreamer      = errors.New("extension is not a stream protocol")
+)
+
+// Streamer is the interface of the extensions providing an alternative wire protocol to the OTLP
+// exporter, e.g. a streaming or columnar variant of OTLP with a higher compression, negotiated on
+// the gRPC connection of the exporter. The extension is configured with stream_protocol.
+// Experimental: *NOTE* this interface is subject to change or removal in the future.
+type Streamer interface {
+	component.Extension
+
+	// NewStream negotiates the protocol with the server on the connection, on the first export.
+	// It returns ErrStreamUnsupported, or an error with the codes.Unimplemented gRPC status, if the
+	// server does not support the protocol, the exporter then using the unary OTLP export until it
+	// is restarted. The negotiation is retried on the next export after other errors.
+	// The context carries the headers of the exporter, and is canceled when it shuts down.
+	NewStream(ctx context.Context, conn *grpc.ClientConn, callOptions ...grpc.CallOption) (Stream, error)
+}
+
+// Stream sends the data with the protocol of a Streamer. It must be safe for concurrent use, and
+// return the gRPC status of the errors, handled as the ones of the unary OTLP export, e.g. retried
+// if Unavailable. The data is sent with the unary OTLP export if it returns ErrStreamUnsupported.
+// Experimental: *NOTE* this interface is subject to change or removal in the future.
+type Stream interface {
+	SendTraces(ctx context.Context, td ptrace.Traces) error
+	SendMetrics(ctx context.Context, md pmetric.Metrics) error
+	SendLogs(ctx context.Context, ld plog.Logs) error
+
+	// Close closes the stream, when the exporter shuts down or falls back to the unary OTLP export.
+	Close() error
+}
+
+// getStreamer returns the extension with the given ID, if it is a Streamer.
+func getStreamer(extensions map[config.ComponentID]component.Extension, id config.ComponentID) (Streamer, error) {
+	ext, found := extensions[id]
+	if !found {
+		return nil, fmt.Errorf("failed to resolve stream protocol %q: %w", id, errStreamerNotFound)
+	}
+	streamer, ok := ext.(Streamer)
+	if !ok {
+		return nil, fmt.Errorf("failed to resolve stream protocol %q: %w", id, errNotStreamer)
+	}
+	return streamer, nil
+}
+
+func isStreamUnsupported(err error) bool {
+	return errors.Is(err, ErrStreamUnsupported) || status.Code(err) == codes.Unimplemented
+}
+
+// getStream returns the stream of the Streamer, negotiating it if needed, or nil if the data
+// must be sent with the unary OTLP export.
+func (e *exporter) getStream() Stream {
+	if e.streamer == nil {
+		return nil
+	}
+	e.streamMu.Lock()
+	defer e.streamMu.Unlock()
+	if e.stream != nil || e.streamUnsupported {
+		return e.stream
+	}
+
+	stream, err := e.streamer.NewStream(e.enhanceContext(e.streamCtx), e.clientConn, e.callOptions...)
+	switch {
+	case err == nil:
+		e.stream = stream
+		e.settings.Logger.Info("Exporting with the stream protocol")
+	case isStreamUnsupported(err):
+		e.streamUnsupported = true
+		e.settings.Logger.Info("Stream protocol not supported by the server, exporting with unary OTLP", zap.Error(err))
+	default:
+		e.settings.Logger.Warn("Failed to negotiate the stream protocol, exporting with unary OTLP", zap.Error(err))
+	}
+	return e.stream
+}
+
+// fallBack returns whether the data must be sent with the unary OTLP export after the error
+// of the stream, closing the stream if the server does not support it.
+func (e *exporter) fallBack(stream Stream, err error) bool {
+	if err == nil || !isStreamUnsupported(err) {
+		return false
+	}
+	e.streamMu.Lock()
+	defer e.streamMu.Unlock()
+	if e.stream == stream {
+		e.stream = nil
+		e.streamUnsupported = true
+		e.settings.Logger.Info("Stream protocol not supported by the server, exporting with unary OTLP", zap.Error(err))
+		if closeErr := stream.Close(); closeErr != nil {
+			e.settings.Logger.Warn("Failed to close the stream", zap.Error(closeErr))
+		}
+	}
+	return true
+}
+
+// closeStream closes the stream, if any, when the exporter shuts down.
+func (e *exporter) closeStream() error {
+	if e.streamer == nil {
+		return nil
+	}
+	e.streamCancel()
+	e.streamMu.Lock()
+	defer e.streamMu.Unlock()
+	if e.stream == nil {
+		return nil
+	}
+	err := e.stream.Close()
+	e.stream = nil
+	return err
+}
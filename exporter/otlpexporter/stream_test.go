@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type mockStreamer struct {
+	component.Extension
+	newErr  error
+	sendErr error
+
+	mu       sync.Mutex
+	streams  int
+	sent     int
+	closed   int
+	metadata metadata.MD
+}
+
+func (s *mockStreamer) NewStream(ctx context.Context, _ *grpc.ClientConn, _ ...grpc.CallOption) (Stream, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.newErr != nil {
+		return nil, s.newErr
+	}
+	s.streams++
+	s.metadata, _ = metadata.FromOutgoingContext(ctx)
+	return s, nil
+}
+
+func (s *mockStreamer) send() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent++
+	return nil
+}
+
+func (s *mockStreamer) SendTraces(context.Context, ptrace.Traces) error    { return s.send() }
+func (s *mockStreamer) SendMetrics(context.Context, pmetric.Metrics) error { return s.send() }
+func (s *mockStreamer) SendLogs(context.Context, plog.Logs) error          { return s.send() }
+
+func (s *mockStreamer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed++
+	return nil
+}
+
+type streamerHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *streamerHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func startStreamTracesExporter(t *testing.T, streamer component.Extension) (component.TracesExporter, *mockTracesReceiver) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	rcv, err := otlpTracesReceiverOnGRPCServer(ln, false)
+	require.NoError(t, err)
+	t.Cleanup(rcv.srv.GracefulStop)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint:   ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+		Headers:    map[string]configopaque.String{"header": "value"},
+	}
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+	id := config.NewComponentID("stream")
+	cfg.StreamProtocol = &id
+
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	host := &streamerHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: streamer},
+	}
+	require.NoError(t, exp.Start(context.Background(), host))
+	return exp, rcv
+}
+
+func TestStreamProtocol(t *testing.T) {
+	streamer := &mockStreamer{}
+	exp, rcv := startStreamTracesExporter(t, streamer)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Equal(t, 1, streamer.streams)
+	assert.Equal(t, 2, streamer.sent)
+	assert.Equal(t, 1, streamer.closed)
+	assert.Equal(t, []string{"value"}, streamer.metadata.Get("header"))
+	assert.EqualValues(t, 0, rcv.requestCount.Load())
+}
+
+func TestStreamProtocolNotSupported(t *testing.T) {
+	streamer := &mockStreamer{newErr: ErrStreamUnsupported}
+	exp, rcv := startStreamTracesExporter(t, streamer)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Equal(t, 0, streamer.streams)
+	assert.EqualValues(t, 1, rcv.requestCount.Load())
+}
+
+func TestStreamProtocolNotSupportedForData(t *testing.T) {
+	streamer := &mockStreamer{sendErr: ErrStreamUnsupported}
+	exp, rcv := startStreamTracesExporter(t, streamer)
+
+	// The data is sent with unary OTLP after the stream is closed, and the stream is not negotiated again.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Equal(t, 1, streamer.streams)
+	assert.Equal(t, 1, streamer.closed)
+	assert.EqualValues(t, 2, rcv.requestCount.Load())
+}
+
+func TestStreamProtocolSendError(t *testing.T) {
+	streamer := &mockStreamer{sendErr: errors.New("invalid data")}
+	exp, rcv := startStreamTracesExporter(t, streamer)
+
+	err := exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	assert.True(t, consumererror.IsPermanent(err))
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.EqualValues(t, 0, rcv.requestCount.Load())
+}
+
+func TestStreamProtocolNotFound(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:1"
+	id := config.NewComponentID("stream")
+	cfg.StreamProtocol = &id
+
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.ErrorIs(t, exp.Start(context.Background(), componenttest.NewNopHost()), errStreamerNotFound)
+
+	host := &streamerHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{}},
+	}
+	assert.ErrorIs(t, exp.Start(context.Background(), host), errNotStreamer)
+}
@@ -16,18 +16,25 @@ package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlph
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
 
 // Config defines configuration for OTLP/HTTP exporter.
 type Config struct {
-	config.ExporterSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
-	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+	config.ExporterSettings           `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPClientSettings     `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings      `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings      `mapstructure:"retry_on_failure"`
+	exporterhelper.WALSettings        `mapstructure:"wal"`
+	exporterhelper.DeadLetterSettings `mapstructure:"dead_letter"`
 
 	// The URL to send traces to. If omitted the Endpoint + "/v1/traces" will be used.
 	TracesEndpoint string `mapstructure:"traces_endpoint"`
@@ -37,14 +44,75 @@ type Config struct {
 
 	// The URL to send logs to. If omitted the Endpoint + "/v1/logs" will be used.
 	LogsEndpoint string `mapstructure:"logs_endpoint"`
+
+	// PathVariables are the static values of the variables of the endpoint paths, such as {tenant} in
+	// "https://example.com/tenants/{tenant}/v1/traces", used when the client metadata of the exported
+	// data has no value for a variable.
+	PathVariables map[string]string `mapstructure:"path_variables"`
+
+	// The headers sent with the traces, in addition to Headers and replacing the ones with the same names.
+	TracesHeaders map[string]configopaque.String `mapstructure:"traces_headers"`
+
+	// The headers sent with the metrics, in addition to Headers and replacing the ones with the same names.
+	MetricsHeaders map[string]configopaque.String `mapstructure:"metrics_headers"`
+
+	// The headers sent with the logs, in addition to Headers and replacing the ones with the same names.
+	LogsHeaders map[string]configopaque.String `mapstructure:"logs_headers"`
+
+	// UseThrottleHints enables delaying requests proactively, before the destination
+	// starts rejecting them, when it reports through the "X-RateLimit-Remaining" and
+	// "X-RateLimit-Reset" headers that the rate limit was reached.
+	UseThrottleHints bool `mapstructure:"use_throttle_hints"`
+
+	// IdempotencyKeyHeader is the name of the header set to a key identifying the request, unique per
+	// request and stable across its retries, so the destination can deduplicate the retried requests.
+	// Empty (the default) disables the idempotency keys.
+	IdempotencyKeyHeader string `mapstructure:"idempotency_key_header"`
+
+	// StrictResponseValidation enables checking that the content type and body of the responses comply with
+	// the OTLP/HTTP specification, logging and counting the violations, e.g. of an endpoint answering with an
+	// HTML error page. The result of the export is not changed by the violations.
+	StrictResponseValidation bool `mapstructure:"strict_response_validation"`
 }
 
 var _ config.Exporter = (*Config)(nil)
+var _ config.ExtensionDependent = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
 	if cfg.Endpoint == "" && cfg.TracesEndpoint == "" && cfg.MetricsEndpoint == "" && cfg.LogsEndpoint == "" {
 		return errors.New("at least one endpoint must be specified")
 	}
+	if cfg.IdempotencyKeyHeader != "" && !httpguts.ValidHeaderFieldName(cfg.IdempotencyKeyHeader) {
+		return fmt.Errorf("idempotency_key_header %q is not a valid header name", cfg.IdempotencyKeyHeader)
+	}
+	if err := cfg.RetrySettings.Validate(); err != nil {
+		return fmt.Errorf("retry settings has invalid configuration: %w", err)
+	}
+	if err := cfg.WALSettings.Validate(); err != nil {
+		return fmt.Errorf("wal settings has invalid configuration: %w", err)
+	}
 	return nil
 }
+
+// signalClientSettings returns the client settings of a signal, with the headers of the signal,
+// if any, replacing the common ones.
+func (cfg *Config) signalClientSettings(headers map[string]configopaque.String) confighttp.HTTPClientSettings {
+	settings := cfg.HTTPClientSettings
+	if len(headers) > 0 {
+		settings.Headers = make(map[string]configopaque.String, len(cfg.Headers)+len(headers))
+		for k, v := range cfg.Headers {
+			settings.Headers[k] = v
+		}
+		for k, v := range headers {
+			// The header names are case insensitive.
+			for name := range settings.Headers {
+				if strings.EqualFold(name, k) {
+					delete(settings.Headers, name)
+				}
+			}
+			settings.Headers[k] = v
+		}
+	}
+	return settings
+}
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/servicetest"
@@ -66,8 +67,12 @@ func TestLoadConfig(t *testing.T) {
 				NumConsumers: 2,
 				QueueSize:    10,
 			},
+			WALSettings: exporterhelper.WALSettings{
+				Enabled:   true,
+				Directory: "/var/lib/otelcol/wal",
+			},
 			HTTPClientSettings: confighttp.HTTPClientSettings{
-				Headers: map[string]string{
+				Headers: map[string]configopaque.String{
 					"can you have a . here?": "F0000000-0000-0000-0000-000000000000",
 					"header1":                "234",
 					"another":                "somevalue",
@@ -81,10 +86,27 @@ func TestLoadConfig(t *testing.T) {
 					},
 					Insecure: true,
 				},
-				ReadBufferSize:  123,
-				WriteBufferSize: 345,
-				Timeout:         time.Second * 10,
-				Compression:     "gzip",
+				ReadBufferSize:   123,
+				WriteBufferSize:  345,
+				Timeout:          time.Second * 10,
+				Compression:      "gzip",
+				TransportRetries: 2,
+			},
+			UseThrottleHints:         true,
+			IdempotencyKeyHeader:     "Idempotency-Key",
+			StrictResponseValidation: true,
+			LogsHeaders: map[string]configopaque.String{
+				"header1": "567",
 			},
 		})
 }
+
+func TestConfigValidateIdempotencyKeyHeader(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "https://example.com:4318"
+	cfg.IdempotencyKeyHeader = "Idempotency-Key"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.IdempotencyKeyHeader = "Idempotency Key"
+	assert.EqualError(t, cfg.Validate(), `idempotency_key_header "Idempotency Key" is not a valid header name`)
+}
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -52,28 +53,32 @@ func createDefaultConfig() config.Exporter {
 		HTTPClientSettings: confighttp.HTTPClientSettings{
 			Endpoint: "",
 			Timeout:  30 * time.Second,
-			Headers:  map[string]string{},
+			Headers:  map[string]configopaque.String{},
 			// Default to gzip compression
 			Compression: configcompression.Gzip,
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
+			// Retry the idempotent requests on a connection closed by the destination.
+			TransportRetries: 1,
 		},
 	}
 }
 
-func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string) (string, error) {
+func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string) (*urlTemplate, error) {
+	var signalURL string
 	switch {
 	case signalOverrideURL != "":
 		_, err := url.Parse(signalOverrideURL)
 		if err != nil {
-			return "", fmt.Errorf("%s_endpoint must be a valid URL", signalName)
+			return nil, fmt.Errorf("%s_endpoint must be a valid URL", signalName)
 		}
-		return signalOverrideURL, nil
+		signalURL = signalOverrideURL
 	case oCfg.Endpoint == "":
-		return "", fmt.Errorf("either endpoint or %s_endpoint must be specified", signalName)
+		return nil, fmt.Errorf("either endpoint or %s_endpoint must be specified", signalName)
 	default:
-		return oCfg.Endpoint + "/v1/" + signalName, nil
+		signalURL = oCfg.Endpoint + "/v1/" + signalName
 	}
+	return newURLTemplate(signalURL, oCfg.PathVariables)
 }
 
 func createTracesExporter(
@@ -81,11 +86,11 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(cfg, set, oCfg.TracesHeaders)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.tracesURL, err = composeSignalURL(oCfg, oCfg.TracesEndpoint, "traces")
 	if err != nil {
@@ -101,7 +106,10 @@ func createTracesExporter(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithWAL(oCfg.WALSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithIdempotencyKeys(oCfg.IdempotencyKeyHeader != ""))
 }
 
 func createMetricsExporter(
@@ -109,11 +117,11 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(cfg, set, oCfg.MetricsHeaders)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.metricsURL, err = composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics")
 	if err != nil {
@@ -129,7 +137,10 @@ func createMetricsExporter(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithWAL(oCfg.WALSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithIdempotencyKeys(oCfg.IdempotencyKeyHeader != ""))
 }
 
 func createLogsExporter(
@@ -137,11 +148,11 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	oce, err := newExporter(cfg, set)
+	oCfg := cfg.(*Config)
+	oce, err := newExporter(cfg, set, oCfg.LogsHeaders)
 	if err != nil {
 		return nil, err
 	}
-	oCfg := cfg.(*Config)
 
 	oce.logsURL, err = composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs")
 	if err != nil {
@@ -157,5 +168,8 @@ func createLogsExporter(
 		// explicitly disable since we rely on http.Client timeout logic.
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
-		exporterhelper.WithQueue(oCfg.QueueSettings))
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithWAL(oCfg.WALSettings),
+		exporterhelper.WithDeadLetter(oCfg.DeadLetterSettings),
+		exporterhelper.WithIdempotencyKeys(oCfg.IdempotencyKeyHeader != ""))
 }
@@ -33,6 +33,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -45,13 +47,18 @@ import (
 
 type exporter struct {
 	// Input configuration.
-	config     *Config
-	client     *http.Client
-	tracesURL  string
-	metricsURL string
-	logsURL    string
-	logger     *zap.Logger
-	settings   component.TelemetrySettings
+	config *Config
+	// clientSettings are the client settings of the exported signal.
+	clientSettings confighttp.HTTPClientSettings
+	client         *http.Client
+	tracesURL      *urlTemplate
+	metricsURL     *urlTemplate
+	logsURL        *urlTemplate
+	logger         *zap.Logger
+	settings       component.TelemetrySettings
+	throttler      *throttler
+	// validator checks the responses in the strict response validation mode, nil otherwise.
+	validator *responseValidator
 	// Default user-agent header.
 	userAgent string
 }
@@ -61,8 +68,8 @@ const (
 	maxHTTPResponseReadBytes = 64 * 1024
 )
 
-// Create new exporter.
-func newExporter(cfg config.Exporter, set component.ExporterCreateSettings) (*exporter, error) {
+// Create new exporter. The signal headers, if any, replace the common ones.
+func newExporter(cfg config.Exporter, set component.ExporterCreateSettings, headers map[string]configopaque.String) (*exporter, error) {
 	oCfg := cfg.(*Config)
 
 	if oCfg.Endpoint != "" {
@@ -76,18 +83,24 @@ func newExporter(cfg config.Exporter, set component.ExporterCreateSettings) (*ex
 		set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
 
 	// client construction is deferred to start
-	return &exporter{
-		config:    oCfg,
-		logger:    set.Logger,
-		userAgent: userAgent,
-		settings:  set.TelemetrySettings,
-	}, nil
+	e := &exporter{
+		config:         oCfg,
+		clientSettings: oCfg.signalClientSettings(headers),
+		logger:         set.Logger,
+		userAgent:      userAgent,
+		settings:       set.TelemetrySettings,
+		throttler:      newThrottler(oCfg.ID().String(), globalInstruments),
+	}
+	if oCfg.StrictResponseValidation {
+		e.validator = newResponseValidator(oCfg.ID().String(), set.Logger, globalInstruments)
+	}
+	return e, nil
 }
 
 // start actually creates the HTTP client. The client construction is deferred till this point as this
 // is the only place we get hold of Extensions which are required to construct auth round tripper.
 func (e *exporter) start(_ context.Context, host component.Host) error {
-	client, err := e.config.HTTPClientSettings.ToClient(host.GetExtensions(), e.settings)
+	client, err := e.clientSettings.ToClient(host.GetExtensions(), e.settings)
 	if err != nil {
 		return err
 	}
@@ -102,7 +115,13 @@ func (e *exporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.tracesURL, request)
+	url, err := e.tracesURL.expand(ctx)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.export(ctx, url, request, func(body []byte) error {
+		return ptraceotlp.NewResponse().UnmarshalProto(body)
+	})
 }
 
 func (e *exporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
@@ -111,7 +130,13 @@ func (e *exporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	return e.export(ctx, e.metricsURL, request)
+	url, err := e.metricsURL.expand(ctx)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.export(ctx, url, request, func(body []byte) error {
+		return pmetricotlp.NewResponse().UnmarshalProto(body)
+	})
 }
 
 func (e *exporter) pushLogs(ctx context.Context, ld plog.Logs) error {
@@ -121,10 +146,26 @@ func (e *exporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 		return consumererror.NewPermanent(err)
 	}
 
-	return e.export(ctx, e.logsURL, request)
+	url, err := e.logsURL.expand(ctx)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.export(ctx, url, request, func(body []byte) error {
+		return plogotlp.NewResponse().UnmarshalProto(body)
+	})
 }
 
-func (e *exporter) export(ctx context.Context, url string, request []byte) error {
+// export sends the protobuf request to the url, the body of the successful responses being the
+// ExportServiceResponse of the signal decoded by unmarshalResponse.
+func (e *exporter) export(ctx context.Context, url string, request []byte, unmarshalResponse func([]byte) error) error {
+	if e.config.UseThrottleHints {
+		if delay := e.throttler.delay(time.Now()); delay > 0 {
+			e.throttler.recordThrottled()
+			return exporterhelper.NewThrottleRetry(
+				fmt.Errorf("request to %s delayed, destination reported its rate limit was reached", url), delay)
+		}
+	}
+
 	e.logger.Debug("Preparing to make HTTP request", zap.String("url", url))
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(request))
 	if err != nil {
@@ -132,6 +173,9 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	}
 	req.Header.Set("Content-Type", "application/x-protobuf")
 	req.Header.Set("User-Agent", e.userAgent)
+	if key, ok := exporterhelper.IdempotencyKeyFromContext(ctx); ok && e.config.IdempotencyKeyHeader != "" {
+		req.Header.Set(e.config.IdempotencyKeyHeader, key)
+	}
 
 	resp, err := e.client.Do(req)
 	if err != nil {
@@ -145,11 +189,33 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	}()
 
 	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
-		// Request is successful.
+		// Request is successful. Read the body so that the trailers are available.
+		if e.validator != nil {
+			e.validator.validate(url, resp, readResponseBody(resp), unmarshalResponse)
+		} else {
+			io.CopyN(ioutil.Discard, resp.Body, maxHTTPResponseReadBytes) // nolint:errcheck
+		}
+		now := time.Now()
+		e.throttler.record(parseThrottleHints(resp, now), now)
 		return nil
 	}
 
-	respStatus := readResponse(resp)
+	var respStatus *status.Status
+	if resp.StatusCode >= 400 && resp.StatusCode <= 599 {
+		// Request failed. Read the body. OTLP spec says:
+		// "Response body for all HTTP 4xx and HTTP 5xx responses MUST be a
+		// Protobuf-encoded Status message that describes the problem."
+		respBody := readResponseBody(resp)
+		respStatus = decodeStatus(respBody)
+		if e.validator != nil {
+			e.validator.validate(url, resp, respBody, func(body []byte) error {
+				return proto.Unmarshal(body, &status.Status{})
+			})
+		}
+	}
+	now := time.Now()
+	hints := parseThrottleHints(resp, now)
+	e.throttler.record(hints, now)
 
 	// Format the error message. Use the status if it is present in the response.
 	var formattedErr error
@@ -174,6 +240,10 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 				retryAfter = seconds
 			}
 		}
+		if retryAfter == 0 && e.config.UseThrottleHints {
+			retryAfter = int(hints.reset.Round(time.Second) / time.Second)
+		}
+		e.throttler.recordThrottled()
 		// Indicate to our caller to pause for the specified number of seconds.
 		return exporterhelper.NewThrottleRetry(formattedErr, time.Duration(retryAfter)*time.Second)
 	}
@@ -187,29 +257,31 @@ func (e *exporter) export(ctx context.Context, url string, request []byte) error
 	return formattedErr
 }
 
-// Read the response and decode the status.Status from the body.
-// Returns nil if the response is empty or cannot be decoded.
-func readResponse(resp *http.Response) *status.Status {
-	var respStatus *status.Status
-	if resp.StatusCode >= 400 && resp.StatusCode <= 599 {
-		// Request failed. Read the body. OTLP spec says:
-		// "Response body for all HTTP 4xx and HTTP 5xx responses MUST be a
-		// Protobuf-encoded Status message that describes the problem."
-		maxRead := resp.ContentLength
-		if maxRead == -1 || maxRead > maxHTTPResponseReadBytes {
-			maxRead = maxHTTPResponseReadBytes
-		}
-		respBytes := make([]byte, maxRead)
-		n, err := io.ReadFull(resp.Body, respBytes)
-		if err == nil && n > 0 {
-			// Decode it as Status struct. See https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures
-			respStatus = &status.Status{}
-			err = proto.Unmarshal(respBytes, respStatus)
-			if err != nil {
-				respStatus = nil
-			}
-		}
+// readResponseBody reads the body of the response, up to maxHTTPResponseReadBytes.
+// Returns nil if the body cannot be read.
+func readResponseBody(resp *http.Response) []byte {
+	maxRead := resp.ContentLength
+	if maxRead == -1 || maxRead > maxHTTPResponseReadBytes {
+		maxRead = maxHTTPResponseReadBytes
 	}
+	respBytes := make([]byte, maxRead)
+	n, err := io.ReadFull(resp.Body, respBytes)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	return respBytes[:n]
+}
 
+// decodeStatus decodes the status.Status from the body of a failed response.
+// Returns nil if the body is empty or cannot be decoded.
+func decodeStatus(respBytes []byte) *status.Status {
+	if len(respBytes) == 0 {
+		return nil
+	}
+	// Decode it as Status struct. See https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#failures
+	respStatus := &status.Status{}
+	if err := proto.Unmarshal(respBytes, respStatus); err != nil {
+		return nil
+	}
 	return respStatus
 }
@@ -39,6 +39,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -490,7 +491,7 @@ func TestUserAgent(t *testing.T) {
 
 	tests := []struct {
 		name       string
-		headers    map[string]string
+		headers    map[string]configopaque.String
 		expectedUA string
 	}{
 		{
@@ -499,12 +500,12 @@ func TestUserAgent(t *testing.T) {
 		},
 		{
 			name:       "custom_user_agent",
-			headers:    map[string]string{"User-Agent": "My Custom Agent"},
+			headers:    map[string]configopaque.String{"User-Agent": "My Custom Agent"},
 			expectedUA: "My Custom Agent",
 		},
 		{
 			name:       "custom_user_agent_lowercase",
-			headers:    map[string]string{"user-agent": "My Custom Agent"},
+			headers:    map[string]configopaque.String{"user-agent": "My Custom Agent"},
 			expectedUA: "My Custom Agent",
 		},
 	}
@@ -645,3 +646,93 @@ func TestUserAgent(t *testing.T) {
 		}
 	})
 }
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		keys = append(keys, request.Header.Get("Idempotency-Key"))
+		// Reject the first attempt of each request.
+		if len(keys)%2 == 1 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &Config{
+		ExporterSettings:     config.NewExporterSettings(config.NewComponentID(typeStr)),
+		TracesEndpoint:       srv.URL + "/v1/traces",
+		RetrySettings:        exporterhelper.RetrySettings{Enabled: true, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, MaxElapsedTime: time.Minute},
+		IdempotencyKeyHeader: "Idempotency-Key",
+	}
+	exp, err := createTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, exp)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	// The key is stable across the retries of a request, and unique per request.
+	require.Len(t, keys, 4)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, keys[2], keys[3])
+	assert.NotEqual(t, keys[0], keys[2])
+}
+
+func TestIdempotencyKeyHeaderDisabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Empty(t, request.Header.Get("Idempotency-Key"))
+		writer.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		TracesEndpoint:   srv.URL + "/v1/traces",
+	}
+	exp, err := createTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, exp)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+}
+
+func TestSignalHeaders(t *testing.T) {
+	headers := map[string]http.Header{}
+	srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		headers[request.URL.Path] = request.Header
+		writer.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: srv.URL,
+			Headers: map[string]configopaque.String{
+				"Authorization": "common-token",
+				"Other":         "other-value",
+			},
+		},
+		TracesHeaders: map[string]configopaque.String{
+			"authorization": "traces-token",
+		},
+	}
+	set := componenttest.NewNopExporterCreateSettings()
+	texp, err := createTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, texp)
+	mexp, err := createMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, mexp)
+
+	require.NoError(t, texp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, mexp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+
+	// The signal headers replace the common ones, only for their signal.
+	assert.Equal(t, "traces-token", headers["/v1/traces"].Get("Authorization"))
+	assert.Equal(t, "other-value", headers["/v1/traces"].Get("Other"))
+	assert.Equal(t, "common-token", headers["/v1/metrics"].Get("Authorization"))
+	assert.Equal(t, "other-value", headers["/v1/metrics"].Get("Other"))
+}
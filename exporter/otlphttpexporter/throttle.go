@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+const (
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+
+	// minEpochSeconds is used to tell apart rate limit reset values expressed as
+	// a unix timestamp from values expressed as a number of seconds.
+	minEpochSeconds = 1_000_000_000
+)
+
+var (
+	globalInstruments = newInstruments(metric.NewRegistry())
+)
+
+func init() {
+	metricproducer.GlobalManager().AddProducer(globalInstruments.registry)
+}
+
+type instruments struct {
+	registry           *metric.Registry
+	rateLimitRemaining *metric.Int64Gauge
+	throttledRequests  *metric.Int64Cumulative
+	responseViolations *metric.Int64Cumulative
+}
+
+func newInstruments(registry *metric.Registry) *instruments {
+	insts := &instruments{
+		registry: registry,
+	}
+	insts.rateLimitRemaining, _ = registry.AddInt64Gauge(
+		obsmetrics.ExporterKey+"/ratelimit_remaining",
+		metric.WithDescription("Number of requests remaining in the current rate limit window as reported by the destination."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.throttledRequests, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/throttled_requests",
+		metric.WithDescription("Number of requests throttled by the destination or delayed because of its rate limit hints."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	insts.responseViolations, _ = registry.AddInt64Cumulative(
+		obsmetrics.ExporterKey+"/response_violations",
+		metric.WithDescription("Number of responses of the destination violating the OTLP/HTTP specification."),
+		metric.WithLabelKeys(obsmetrics.ExporterKey, violationKey),
+		metric.WithUnit(metricdata.UnitDimensionless))
+
+	return insts
+}
+
+// throttleHints are the rate limiting hints reported by the destination in a response.
+type throttleHints struct {
+	// remaining is the number of requests remaining in the current window, -1 if unknown.
+	remaining int64
+	// reset is the time until the current window resets, 0 if unknown.
+	reset time.Duration
+}
+
+// parseThrottleHints extracts the rate limiting hints from the response headers and trailers.
+// Trailers are only available once the response body was fully read.
+func parseThrottleHints(resp *http.Response, now time.Time) throttleHints {
+	hints := throttleHints{remaining: -1}
+	for _, h := range []http.Header{resp.Header, resp.Trailer} {
+		if val := h.Get(headerRateLimitRemaining); val != "" {
+			if remaining, err := strconv.ParseInt(val, 10, 64); err == nil && remaining >= 0 {
+				hints.remaining = remaining
+			}
+		}
+		if val := h.Get(headerRateLimitReset); val != "" {
+			if reset, ok := parseDelay(val, now); ok {
+				hints.reset = reset
+			}
+		}
+	}
+	return hints
+}
+
+// parseDelay parses a delay expressed either as a number of seconds, a unix timestamp
+// in seconds or an HTTP date. Delays in the past are returned as 0.
+func parseDelay(val string, now time.Time) (time.Duration, bool) {
+	if seconds, err := strconv.ParseInt(val, 10, 64); err == nil {
+		if seconds >= minEpochSeconds {
+			return nonNegative(time.Unix(seconds, 0).Sub(now)), true
+		}
+		if seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		return 0, false
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		return nonNegative(t.Sub(now)), true
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// throttler tracks the rate limiting hints reported by the destination for one exporter.
+type throttler struct {
+	remainingEntry *metric.Int64GaugeEntry
+	throttledEntry *metric.Int64CumulativeEntry
+
+	mu sync.Mutex
+	// throttledUntil is the time until which no request should be sent, as
+	// derived from the last received hints.
+	throttledUntil time.Time
+}
+
+func newThrottler(exporterID string, insts *instruments) *throttler {
+	labelValue := metricdata.NewLabelValue(exporterID)
+	remainingEntry, _ := insts.rateLimitRemaining.GetEntry(labelValue)
+	throttledEntry, _ := insts.throttledRequests.GetEntry(labelValue)
+	return &throttler{
+		remainingEntry: remainingEntry,
+		throttledEntry: throttledEntry,
+	}
+}
+
+// record updates the state and metrics with the hints received in a response.
+func (t *throttler) record(hints throttleHints, now time.Time) {
+	if hints.remaining >= 0 {
+		t.remainingEntry.Set(hints.remaining)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if hints.remaining == 0 && hints.reset > 0 {
+		t.throttledUntil = now.Add(hints.reset)
+	}
+}
+
+// recordThrottled records a request throttled by, or delayed because of, the destination.
+func (t *throttler) recordThrottled() {
+	t.throttledEntry.Inc(1)
+}
+
+// delay returns how long to wait before sending the next request, 0 if it can be sent immediately.
+func (t *throttler) delay(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return nonNegative(t.throttledUntil.Sub(now))
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newHeader(kv ...string) http.Header {
+	h := http.Header{}
+	for i := 0; i < len(kv); i += 2 {
+		h.Set(kv[i], kv[i+1])
+	}
+	return h
+}
+
+func TestParseThrottleHints(t *testing.T) {
+	now := time.Unix(1_600_000_000, 0)
+	tests := []struct {
+		name     string
+		header   http.Header
+		trailer  http.Header
+		expected throttleHints
+	}{
+		{
+			name:     "none",
+			expected: throttleHints{remaining: -1},
+		},
+		{
+			name:     "seconds",
+			header:   newHeader(headerRateLimitRemaining, "10", headerRateLimitReset, "30"),
+			expected: throttleHints{remaining: 10, reset: 30 * time.Second},
+		},
+		{
+			name:     "epoch",
+			header:   newHeader(headerRateLimitRemaining, "0", headerRateLimitReset, "1600000005"),
+			expected: throttleHints{remaining: 0, reset: 5 * time.Second},
+		},
+		{
+			name:     "http-date",
+			header:   newHeader(headerRateLimitReset, now.Add(time.Minute).UTC().Format(http.TimeFormat)),
+			expected: throttleHints{remaining: -1, reset: time.Minute},
+		},
+		{
+			name:     "past",
+			header:   newHeader(headerRateLimitReset, "1500000000"),
+			expected: throttleHints{remaining: -1, reset: 0},
+		},
+		{
+			name:     "invalid",
+			header:   newHeader(headerRateLimitRemaining, "-1", headerRateLimitReset, "soon"),
+			expected: throttleHints{remaining: -1},
+		},
+		{
+			name:     "trailer",
+			header:   newHeader(headerRateLimitRemaining, "10"),
+			trailer:  newHeader(headerRateLimitRemaining, "0", headerRateLimitReset, "1"),
+			expected: throttleHints{remaining: 0, reset: time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: tt.header, Trailer: tt.trailer}
+			assert.Equal(t, tt.expected, parseThrottleHints(resp, now))
+		})
+	}
+}
+
+func TestThrottler(t *testing.T) {
+	now := time.Now()
+	thr := newThrottler("otlphttp", newInstruments(metric.NewRegistry()))
+	assert.Zero(t, thr.delay(now))
+
+	thr.record(throttleHints{remaining: 1, reset: time.Minute}, now)
+	assert.Zero(t, thr.delay(now))
+
+	thr.record(throttleHints{remaining: 0, reset: time.Minute}, now)
+	assert.Equal(t, time.Minute, thr.delay(now))
+	assert.Equal(t, 30*time.Second, thr.delay(now.Add(30*time.Second)))
+	assert.Zero(t, thr.delay(now.Add(2*time.Minute)))
+}
+
+func TestUseThrottleHints(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		writer.Header().Set(headerRateLimitRemaining, "0")
+		writer.Header().Set(headerRateLimitReset, "60")
+		writer.WriteHeader(http.StatusOK)
+	})
+	srv := http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(func() { require.NoError(t, srv.Close()) })
+
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		TracesEndpoint:   fmt.Sprintf("http://%s/v1/traces", addr),
+		UseThrottleHints: true,
+	}
+	exp, err := createTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, exp)
+
+	// The first request succeeds, and the destination reports the rate limit was reached.
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Equal(t, 1, requests)
+
+	// The second request is delayed without contacting the destination.
+	err = exp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	require.Error(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Contains(t, err.Error(), fmt.Sprintf("request to http://%s/v1/traces delayed, destination reported its rate limit was reached", addr))
+	assert.IsType(t, exporterhelper.NewThrottleRetry(nil, 0), err)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// urlTemplate is the URL of a signal, whose path may contain variables between braces, such as
+// {tenant} in "https://example.com/tenants/{tenant}/v1/traces".
+type urlTemplate struct {
+	// parts alternates the literal parts of the URL and the names of the variables,
+	// starting with a literal part.
+	parts []string
+	// values are the static values of the variables, used when the client metadata has none.
+	values map[string]string
+}
+
+func newURLTemplate(rawURL string, values map[string]string) (*urlTemplate, error) {
+	// The variables are only supported in the path, after the scheme and the host.
+	pathStart := strings.Index(rawURL, "://")
+	if pathStart >= 0 {
+		if slash := strings.Index(rawURL[pathStart+3:], "/"); slash >= 0 {
+			pathStart += 3 + slash
+		} else {
+			pathStart = len(rawURL)
+		}
+	}
+
+	t := &urlTemplate{values: values}
+	rest := rawURL
+	for {
+		open := strings.Index(rest, "{")
+		if open < 0 {
+			break
+		}
+		length := strings.Index(rest[open:], "}")
+		if length < 0 {
+			return nil, fmt.Errorf("unterminated variable in %q", rawURL)
+		}
+		name := rest[open+1 : open+length]
+		if name == "" || strings.ContainsAny(name, "{/") {
+			return nil, fmt.Errorf("invalid variable %q in %q", name, rawURL)
+		}
+		if len(rawURL)-len(rest)+open < pathStart {
+			return nil, fmt.Errorf("variable %q is not in the path of %q", name, rawURL)
+		}
+		t.parts = append(t.parts, rest[:open], name)
+		rest = rest[open+length+1:]
+	}
+	t.parts = append(t.parts, rest)
+	return t, nil
+}
+
+// expand returns the URL with the variables replaced by the values of the client metadata
+// of the same name, or by their static values.
+func (t *urlTemplate) expand(ctx context.Context) (string, error) {
+	if len(t.parts) == 1 {
+		return t.parts[0], nil
+	}
+	md := client.FromContext(ctx).Metadata
+	var sb strings.Builder
+	for i, part := range t.parts {
+		if i%2 == 0 {
+			sb.WriteString(part)
+			continue
+		}
+		value, ok := metadataValue(md, part)
+		if !ok {
+			value, ok = t.values[part]
+		}
+		if !ok {
+			return "", fmt.Errorf("no value for the path variable %q", part)
+		}
+		sb.WriteString(url.PathEscape(value))
+	}
+	return sb.String(), nil
+}
+
+// metadataValue returns the first value of the key in the client metadata, looking up the
+// key as is, lower-cased as collected by the gRPC receivers, and canonicalized as collected
+// by the HTTP receivers.
+func metadataValue(md client.Metadata, key string) (string, bool) {
+	for _, k := range []string{key, strings.ToLower(key), http.CanonicalHeaderKey(key)} {
+		if vals := md.Get(k); len(vals) > 0 {
+			return vals[0], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+func TestNewURLTemplateInvalid(t *testing.T) {
+	for _, rawURL := range []string{
+		"https://example.com/tenants/{tenant/v1/traces",
+		"https://example.com/tenants/{}/v1/traces",
+		"https://example.com/tenants/{a/b}/v1/traces",
+		"https://{host}.example.com/v1/traces",
+	} {
+		_, err := newURLTemplate(rawURL, nil)
+		assert.Error(t, err, rawURL)
+	}
+}
+
+func TestURLTemplateExpand(t *testing.T) {
+	tmpl, err := newURLTemplate("https://example.com/tenants/{tenant}/v1/{signal}", map[string]string{"tenant": "default", "signal": "traces"})
+	require.NoError(t, err)
+
+	url, err := tmpl.expand(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/tenants/default/v1/traces", url)
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"Tenant": {"a b/c", "ignored"}}),
+	})
+	url, err = tmpl.expand(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/tenants/a%20b%2Fc/v1/traces", url)
+
+	tmpl, err = newURLTemplate("https://example.com/tenants/{tenant}/v1/traces", nil)
+	require.NoError(t, err)
+	_, err = tmpl.expand(context.Background())
+	assert.Error(t, err)
+
+	tmpl, err = newURLTemplate("https://example.com/v1/traces", nil)
+	require.NoError(t, err)
+	url, err = tmpl.expand(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/v1/traces", url)
+}
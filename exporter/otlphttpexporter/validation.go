@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter // import "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"go.opencensus.io/metric"
+	"go.opencensus.io/metric/metricdata"
+	"go.uber.org/zap"
+)
+
+const (
+	// violationKey is the label of the kind of response violation.
+	violationKey = "violation"
+
+	protobufContentType = "application/x-protobuf"
+
+	// maxLoggedBodyBytes is the number of bytes of the body of the responses logged with their violations.
+	maxLoggedBodyBytes = 512
+)
+
+// responseViolation is a kind of violation of the OTLP/HTTP specification by a response.
+type responseViolation string
+
+const (
+	// violationContentType is a response without the content type of the request.
+	violationContentType responseViolation = "content_type"
+	// violationBody is a response whose body is not the ExportServiceResponse of the signal for the
+	// successful requests, or a Status for the failed requests.
+	violationBody responseViolation = "body"
+)
+
+// responseValidator checks that the responses of the destination comply with the OTLP/HTTP specification:
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#otlphttp-response
+type responseValidator struct {
+	logger  *zap.Logger
+	entries map[responseViolation]*metric.Int64CumulativeEntry
+}
+
+func newResponseValidator(exporterID string, logger *zap.Logger, insts *instruments) *responseValidator {
+	v := &responseValidator{
+		logger:  logger,
+		entries: make(map[responseViolation]*metric.Int64CumulativeEntry),
+	}
+	for _, violation := range []responseViolation{violationContentType, violationBody} {
+		v.entries[violation], _ = insts.responseViolations.GetEntry(
+			metricdata.NewLabelValue(exporterID), metricdata.NewLabelValue(string(violation)))
+	}
+	return v
+}
+
+// validate checks the response to a protobuf request and its body, decoded with unmarshal.
+func (v *responseValidator) validate(url string, resp *http.Response, body []byte, unmarshal func([]byte) error) {
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != protobufContentType {
+		v.report(violationContentType, url, resp, body,
+			fmt.Errorf("content type %q is not the %q content type of the request", contentType, protobufContentType))
+		return
+	}
+	if err := unmarshal(body); err != nil {
+		v.report(violationBody, url, resp, body, fmt.Errorf("invalid body: %w", err))
+	}
+}
+
+func (v *responseValidator) report(violation responseViolation, url string, resp *http.Response, body []byte, err error) {
+	v.entries[violation].Inc(1)
+	if len(body) > maxLoggedBodyBytes {
+		body = body[:maxLoggedBodyBytes]
+	}
+	v.logger.Warn("Response violating the OTLP/HTTP specification",
+		zap.String("url", url),
+		zap.Int("status_code", resp.StatusCode),
+		zap.String(violationKey, string(violation)),
+		zap.Error(err),
+		zap.ByteString("body", body))
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// violations returns the number of response violations recorded in the registry, by violation.
+func violations(t *testing.T, registry *metric.Registry) map[string]int64 {
+	ret := make(map[string]int64)
+	for _, m := range registry.Read() {
+		if m.Descriptor.Name != "exporter/response_violations" {
+			continue
+		}
+		for _, ts := range m.TimeSeries {
+			require.Len(t, ts.LabelValues, 2)
+			ret[ts.LabelValues[1].Value] = ts.Points[0].Value.(int64)
+		}
+	}
+	return ret
+}
+
+func TestResponseValidator(t *testing.T) {
+	validResponse, err := ptraceotlp.NewResponse().MarshalProto()
+	require.NoError(t, err)
+	unmarshal := func(body []byte) error {
+		return ptraceotlp.NewResponse().UnmarshalProto(body)
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		violation   string
+	}{
+		{
+			name:        "valid",
+			contentType: "application/x-protobuf",
+			body:        validResponse,
+		},
+		{
+			name:        "valid_with_parameters",
+			contentType: "application/x-protobuf; charset=utf-8",
+		},
+		{
+			name:        "html",
+			contentType: "text/html",
+			body:        []byte("<html><body>Bad Gateway</body></html>"),
+			violation:   "content_type",
+		},
+		{
+			name:      "missing_content_type",
+			violation: "content_type",
+		},
+		{
+			name:        "invalid_body",
+			contentType: "application/x-protobuf",
+			body:        []byte("<html>"),
+			violation:   "body",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := metric.NewRegistry()
+			core, logs := observer.New(zapcore.WarnLevel)
+			v := newResponseValidator("otlphttp", zap.New(core), newInstruments(registry))
+
+			resp := &http.Response{StatusCode: http.StatusOK, Header: newHeader("Content-Type", tt.contentType)}
+			if tt.contentType == "" {
+				resp.Header = http.Header{}
+			}
+			v.validate("http://localhost/v1/traces", resp, tt.body, unmarshal)
+
+			if tt.violation == "" {
+				assert.Zero(t, logs.Len())
+				assert.Equal(t, map[string]int64{"content_type": 0, "body": 0}, violations(t, registry))
+				return
+			}
+			require.Equal(t, 1, logs.Len())
+			fields := logs.All()[0].ContextMap()
+			assert.Equal(t, tt.violation, fields["violation"])
+			assert.Equal(t, string(tt.body), fields["body"])
+			assert.EqualValues(t, 1, violations(t, registry)[tt.violation])
+		})
+	}
+}
+
+func TestStrictResponseValidation(t *testing.T) {
+	statusBody, err := proto.Marshal(&status.Status{Message: "unavailable"})
+	require.NoError(t, err)
+	responses := []struct {
+		statusCode  int
+		contentType string
+		body        []byte
+	}{
+		{http.StatusOK, "text/html", []byte("<html>Login</html>")},
+		{http.StatusOK, "application/x-protobuf", nil},
+		{http.StatusBadRequest, "application/x-protobuf", statusBody},
+		{http.StatusBadRequest, "text/html", []byte("<html>Bad Request</html>")},
+	}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		resp := responses[requests]
+		requests++
+		writer.Header().Set("Content-Type", resp.contentType)
+		writer.WriteHeader(resp.statusCode)
+		_, _ = writer.Write(resp.body)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := &Config{
+		ExporterSettings:         config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "strict")),
+		TracesEndpoint:           srv.URL + "/v1/traces",
+		StrictResponseValidation: true,
+	}
+	set := componenttest.NewNopExporterCreateSettings()
+	core, logs := observer.New(zapcore.WarnLevel)
+	set.Logger = zap.New(core)
+	exp, err := createTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	startAndCleanup(t, exp)
+
+	// The violations do not change the results of the exports.
+	assert.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Error(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Error(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	violationLogs := logs.FilterMessage("Response violating the OTLP/HTTP specification").All()
+	require.Len(t, violationLogs, 2)
+	assert.Equal(t, "<html>Login</html>", violationLogs[0].ContextMap()["body"])
+	assert.EqualValues(t, http.StatusOK, violationLogs[0].ContextMap()["status_code"])
+	assert.Equal(t, "<html>Bad Request</html>", violationLogs[1].ContextMap()["body"])
+	assert.EqualValues(t, http.StatusBadRequest, violationLogs[1].ContextMap()["status_code"])
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debuguiextension // import "go.opentelemetry.io/collector/extension/debuguiextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration of the extension serving the recent data of the pipelines.
+type Config struct {
+	config.ExtensionSettings      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// BufferSize is the number of the last spans, metric points or log records kept for each pipeline.
+	BufferSize int `mapstructure:"buffer_size"`
+
+	// SamplingRatio is the fraction of the batches received by the pipelines that are kept, between 0 and 1.
+	// If unset all the batches are kept.
+	SamplingRatio float64 `mapstructure:"sampling_ratio"`
+
+	// RedactedAttributes are the keys of the attributes whose values are replaced before being served,
+	// e.g. the attributes with credentials or personal data.
+	RedactedAttributes []string `mapstructure:"redacted_attributes"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"debugui\" extension")
+	}
+	if cfg.BufferSize <= 0 {
+		return fmt.Errorf("invalid buffer size %d, must be positive", cfg.BufferSize)
+	}
+	if cfg.SamplingRatio < 0 || cfg.SamplingRatio > 1 {
+		return fmt.Errorf("invalid sampling ratio %v, must be between 0 and 1", cfg.SamplingRatio)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debuguiextension // import "go.opentelemetry.io/collector/extension/debuguiextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const samplesPath = "/samples"
+
+var (
+	tracesMarshaler  = ptrace.NewJSONMarshaler()
+	metricsMarshaler = pmetric.NewJSONMarshaler()
+	logsMarshaler    = plog.NewJSONMarshaler()
+)
+
+type debugUIExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	redactor  redactor
+	server    *http.Server
+	stopCh    chan struct{}
+
+	mu    sync.Mutex
+	rings map[config.ComponentID]*ring
+}
+
+var _ component.PipelineDataObserver = (*debugUIExtension)(nil)
+
+func (due *debugUIExtension) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(samplesPath, due.handleSamples)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := due.config.ToListener()
+	if err != nil {
+		return err
+	}
+	due.server, err = due.config.ToServer(host, due.telemetry, mux)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	due.telemetry.Logger.Info("Starting debug UI extension", zap.String("endpoint", due.config.Endpoint))
+	due.stopCh = make(chan struct{})
+	go func() {
+		defer close(due.stopCh)
+
+		if errHTTP := due.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (due *debugUIExtension) Shutdown(context.Context) error {
+	if due.server == nil {
+		return nil
+	}
+	err := due.server.Close()
+	if due.stopCh != nil {
+		<-due.stopCh
+	}
+	return err
+}
+
+// DataObserver observes the output of the receivers, keeping the samples of each pipeline in
+// a ring shared by its receivers, and kept when the pipelines are rebuilt.
+func (due *debugUIExtension) DataObserver(point component.ObservationPoint) component.DataObserver {
+	if point.Kind != component.KindReceiver {
+		return nil
+	}
+	due.mu.Lock()
+	defer due.mu.Unlock()
+	r, ok := due.rings[point.Pipeline]
+	if !ok {
+		r = newRing(due.config.BufferSize)
+		due.rings[point.Pipeline] = r
+	}
+	ratio := due.config.SamplingRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+	return &observer{ext: due, ring: r, receiver: point.Component, ratio: ratio}
+}
+
+// observer keeps a sample of the batches received by a pipeline from a receiver.
+type observer struct {
+	ext      *debugUIExtension
+	ring     *ring
+	receiver config.ComponentID
+	ratio    float64
+	// count is the number of batches received, it determines which batches are sampled.
+	count uint64
+}
+
+var _ component.DataObserver = (*observer)(nil)
+
+// Sample returns true for the batches kept evenly at the sampling ratio.
+func (o *observer) Sample() bool {
+	n := atomic.AddUint64(&o.count, 1)
+	return uint64(float64(n)*o.ratio) != uint64(float64(n-1)*o.ratio)
+}
+
+func (o *observer) ObserveTraces(_ context.Context, td ptrace.Traces) {
+	now := time.Now()
+	for _, s := range splitTraces(td, o.ext.config.BufferSize, o.ext.redactor) {
+		o.ring.add(sample{time: now, receiver: o.receiver, data: s})
+	}
+}
+
+func (o *observer) ObserveMetrics(_ context.Context, md pmetric.Metrics) {
+	now := time.Now()
+	for _, s := range splitMetrics(md, o.ext.config.BufferSize, o.ext.redactor) {
+		o.ring.add(sample{time: now, receiver: o.receiver, data: s})
+	}
+}
+
+func (o *observer) ObserveLogs(_ context.Context, ld plog.Logs) {
+	now := time.Now()
+	for _, s := range splitLogs(ld, o.ext.config.BufferSize, o.ext.redactor) {
+		o.ring.add(sample{time: now, receiver: o.receiver, data: s})
+	}
+}
+
+type sampleResponse struct {
+	Time     time.Time       `json:"time"`
+	Receiver string          `json:"receiver"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// handleSamples returns the samples of the pipelines, keyed by pipeline ID, or only the ones of the
+// pipeline of the "pipeline" query parameter. The data of the samples is encoded as OTLP/JSON.
+func (due *debugUIExtension) handleSamples(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rings := make(map[string]*ring)
+	due.mu.Lock()
+	for id, rg := range due.rings {
+		rings[id.String()] = rg
+	}
+	due.mu.Unlock()
+
+	if pipeline := r.URL.Query().Get("pipeline"); pipeline != "" {
+		rg, ok := rings[pipeline]
+		if !ok {
+			http.Error(w, "unknown pipeline "+pipeline, http.StatusNotFound)
+			return
+		}
+		rings = map[string]*ring{pipeline: rg}
+	}
+
+	resp := make(map[string][]sampleResponse, len(rings))
+	for id, rg := range rings {
+		samples := rg.list()
+		resp[id] = make([]sampleResponse, 0, len(samples))
+		for _, s := range samples {
+			data, err := marshalSample(s)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			resp[id] = append(resp[id], sampleResponse{Time: s.time, Receiver: s.receiver.String(), Data: data})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func marshalSample(s sample) ([]byte, error) {
+	switch data := s.data.(type) {
+	case ptrace.Traces:
+		return tracesMarshaler.MarshalTraces(data)
+	case pmetric.Metrics:
+		return metricsMarshaler.MarshalMetrics(data)
+	case plog.Logs:
+		return logsMarshaler.MarshalLogs(data)
+	}
+	return nil, errors.New("unknown sample data")
+}
+
+func newServer(cfg *Config, telemetry component.TelemetrySettings) *debugUIExtension {
+	return &debugUIExtension{
+		config:    cfg,
+		telemetry: telemetry,
+		redactor:  newRedactor(cfg.RedactedAttributes),
+		rings:     make(map[config.ComponentID]*ring),
+	}
+}
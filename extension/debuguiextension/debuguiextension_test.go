@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debuguiextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestDebugUIExtensionUsage(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.BufferSize = 3
+
+	ext := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	assert.Nil(t, ext.DataObserver(component.ObservationPoint{
+		Pipeline:  config.NewComponentID("traces"),
+		Kind:      component.KindExporter,
+		Component: config.NewComponentID("otlp"),
+	}))
+	traces := ext.DataObserver(component.ObservationPoint{
+		Pipeline:  config.NewComponentID("traces"),
+		Kind:      component.KindReceiver,
+		Component: config.NewComponentID("otlp"),
+	})
+	require.NotNil(t, traces)
+	logs := ext.DataObserver(component.ObservationPoint{
+		Pipeline:  config.NewComponentID("logs"),
+		Kind:      component.KindReceiver,
+		Component: config.NewComponentIDWithName("otlp", "2"),
+	})
+	require.NotNil(t, logs)
+
+	assert.True(t, traces.Sample())
+	traces.ObserveTraces(context.Background(), testdata.GenerateTraces(2))
+	traces.ObserveTraces(context.Background(), testdata.GenerateTraces(2))
+	logs.ObserveLogs(context.Background(), testdata.GenerateLogs(1))
+
+	resp, err := http.Get("http://" + cfg.Endpoint + "/samples")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var samples map[string][]sampleResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&samples))
+	require.Len(t, samples["traces"], 3)
+	require.Len(t, samples["logs"], 1)
+
+	td, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces(samples["traces"][2].Data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, td.SpanCount())
+	assert.Equal(t, "otlp", samples["traces"][2].Receiver)
+	ld, err := plog.NewJSONUnmarshaler().UnmarshalLogs(samples["logs"][0].Data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.LogRecordCount())
+	assert.Equal(t, "otlp/2", samples["logs"][0].Receiver)
+
+	resp, err = http.Get("http://" + cfg.Endpoint + "/samples?pipeline=logs")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	samples = nil
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&samples))
+	assert.Len(t, samples, 1)
+	assert.Len(t, samples["logs"], 1)
+
+	resp, err = http.Get("http://" + cfg.Endpoint + "/samples?pipeline=metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestDebugUIExtensionSampling(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SamplingRatio = 0.25
+
+	ext := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	observer := ext.DataObserver(component.ObservationPoint{
+		Pipeline:  config.NewComponentID("traces"),
+		Kind:      component.KindReceiver,
+		Component: config.NewComponentID("otlp"),
+	})
+	sampled := 0
+	for i := 0; i < 100; i++ {
+		if observer.Sample() {
+			sampled++
+		}
+	}
+	assert.Equal(t, 25, sampled)
+}
+
+func TestDebugUIExtensionPortAlreadyInUse(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	ext := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	other := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	assert.Error(t, other.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, other.Shutdown(context.Background()))
+}
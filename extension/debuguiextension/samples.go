@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debuguiextension // import "go.opentelemetry.io/collector/extension/debuguiextension"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// redactedValue replaces the values of the redacted attributes.
+const redactedValue = "<redacted>"
+
+// sample is a span, metric point or log record received by a pipeline, stored as a ptrace.Traces,
+// pmetric.Metrics or plog.Logs with its resource and scope.
+type sample struct {
+	time     time.Time
+	receiver config.ComponentID
+	data     interface{}
+}
+
+// ring keeps the last samples of a pipeline.
+type ring struct {
+	mu      sync.Mutex
+	samples []sample
+	// next is the index of the next sample, the oldest one once the ring is full.
+	next int
+	full bool
+}
+
+func newRing(size int) *ring {
+	return &ring{samples: make([]sample, size)}
+}
+
+func (r *ring) add(s sample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples[r.next] = s
+	r.next++
+	if r.next == len(r.samples) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// list returns the samples, from the oldest to the newest.
+func (r *ring) list() []sample {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]sample(nil), r.samples[:r.next]...)
+	}
+	return append(append([]sample(nil), r.samples[r.next:]...), r.samples[:r.next]...)
+}
+
+// redactor replaces the values of the denied attributes.
+type redactor map[string]struct{}
+
+func newRedactor(keys []string) redactor {
+	r := make(redactor, len(keys))
+	for _, k := range keys {
+		r[k] = struct{}{}
+	}
+	return r
+}
+
+func (r redactor) redact(attrs pcommon.Map) {
+	if len(r) == 0 {
+		return
+	}
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if _, ok := r[k]; ok {
+			v.SetStringVal(redactedValue)
+		}
+		return true
+	})
+}
+
+// splitTraces returns the last limit spans of the traces, each in its own ptrace.Traces.
+func splitTraces(td ptrace.Traces, limit int, r redactor) []ptrace.Traces {
+	skip := td.SpanCount() - limit
+	var ret []ptrace.Traces
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if skip > 0 {
+					skip--
+					continue
+				}
+				out := ptrace.NewTraces()
+				outRs := out.ResourceSpans().AppendEmpty()
+				outRs.SetSchemaUrl(rs.SchemaUrl())
+				rs.Resource().CopyTo(outRs.Resource())
+				r.redact(outRs.Resource().Attributes())
+				outSs := outRs.ScopeSpans().AppendEmpty()
+				outSs.SetSchemaUrl(ss.SchemaUrl())
+				ss.Scope().CopyTo(outSs.Scope())
+				span := outSs.Spans().AppendEmpty()
+				spans.At(k).CopyTo(span)
+				r.redact(span.Attributes())
+				for e := 0; e < span.Events().Len(); e++ {
+					r.redact(span.Events().At(e).Attributes())
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					r.redact(span.Links().At(l).Attributes())
+				}
+				ret = append(ret, out)
+			}
+		}
+	}
+	return ret
+}
+
+// splitMetrics returns the last limit data points of the metrics, each in its own pmetric.Metrics
+// with a copy of its metric.
+func splitMetrics(md pmetric.Metrics, limit int, r redactor) []pmetric.Metrics {
+	skip := md.DataPointCount() - limit
+	var ret []pmetric.Metrics
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				// emit appends a pmetric.Metrics with a copy of the metric without its data points,
+				// returning the metric to which the data point is added.
+				emit := func() pmetric.Metric {
+					out := pmetric.NewMetrics()
+					outRm := out.ResourceMetrics().AppendEmpty()
+					outRm.SetSchemaUrl(rm.SchemaUrl())
+					rm.Resource().CopyTo(outRm.Resource())
+					r.redact(outRm.Resource().Attributes())
+					outSm := outRm.ScopeMetrics().AppendEmpty()
+					outSm.SetSchemaUrl(sm.SchemaUrl())
+					sm.Scope().CopyTo(outSm.Scope())
+					outM := outSm.Metrics().AppendEmpty()
+					outM.SetName(m.Name())
+					outM.SetDescription(m.Description())
+					outM.SetUnit(m.Unit())
+					outM.SetDataType(m.DataType())
+					ret = append(ret, out)
+					return outM
+				}
+				switch m.DataType() {
+				case pmetric.MetricDataTypeGauge:
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if skip > 0 {
+							skip--
+							continue
+						}
+						dp := emit().Gauge().DataPoints().AppendEmpty()
+						dps.At(l).CopyTo(dp)
+						r.redact(dp.Attributes())
+						redactExemplars(dp.Exemplars(), r)
+					}
+				case pmetric.MetricDataTypeSum:
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if skip > 0 {
+							skip--
+							continue
+						}
+						sum := emit().Sum()
+						sum.SetAggregationTemporality(m.Sum().AggregationTemporality())
+						sum.SetIsMonotonic(m.Sum().IsMonotonic())
+						dp := sum.DataPoints().AppendEmpty()
+						dps.At(l).CopyTo(dp)
+						r.redact(dp.Attributes())
+						redactExemplars(dp.Exemplars(), r)
+					}
+				case pmetric.MetricDataTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if skip > 0 {
+							skip--
+							continue
+						}
+						histogram := emit().Histogram()
+						histogram.SetAggregationTemporality(m.Histogram().AggregationTemporality())
+						dp := histogram.DataPoints().AppendEmpty()
+						dps.At(l).CopyTo(dp)
+						r.redact(dp.Attributes())
+						redactExemplars(dp.Exemplars(), r)
+					}
+				case pmetric.MetricDataTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if skip > 0 {
+							skip--
+							continue
+						}
+						histogram := emit().ExponentialHistogram()
+						histogram.SetAggregationTemporality(m.ExponentialHistogram().AggregationTemporality())
+						dp := histogram.DataPoints().AppendEmpty()
+						dps.At(l).CopyTo(dp)
+						r.redact(dp.Attributes())
+						redactExemplars(dp.Exemplars(), r)
+					}
+				case pmetric.MetricDataTypeSummary:
+					dps := m.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						if skip > 0 {
+							skip--
+							continue
+						}
+						dp := emit().Summary().DataPoints().AppendEmpty()
+						dps.At(l).CopyTo(dp)
+						r.redact(dp.Attributes())
+					}
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func redactExemplars(exemplars pmetric.ExemplarSlice, r redactor) {
+	for i := 0; i < exemplars.Len(); i++ {
+		r.redact(exemplars.At(i).FilteredAttributes())
+	}
+}
+
+// splitLogs returns the last limit log records of the logs, each in its own plog.Logs.
+func splitLogs(ld plog.Logs, limit int, r redactor) []plog.Logs {
+	skip := ld.LogRecordCount() - limit
+	var ret []plog.Logs
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if skip > 0 {
+					skip--
+					continue
+				}
+				out := plog.NewLogs()
+				outRl := out.ResourceLogs().AppendEmpty()
+				outRl.SetSchemaUrl(rl.SchemaUrl())
+				rl.Resource().CopyTo(outRl.Resource())
+				r.redact(outRl.Resource().Attributes())
+				outSl := outRl.ScopeLogs().AppendEmpty()
+				outSl.SetSchemaUrl(sl.SchemaUrl())
+				sl.Scope().CopyTo(outSl.Scope())
+				lr := outSl.LogRecords().AppendEmpty()
+				lrs.At(k).CopyTo(lr)
+				r.redact(lr.Attributes())
+				ret = append(ret, out)
+			}
+		}
+	}
+	return ret
+}
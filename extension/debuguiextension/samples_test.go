@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debuguiextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestRing(t *testing.T) {
+	r := newRing(3)
+	assert.Empty(t, r.list())
+
+	for _, name := range []string{"a", "b"} {
+		r.add(sample{receiver: config.NewComponentID(config.Type(name))})
+	}
+	assert.Equal(t, []sample{
+		{receiver: config.NewComponentID("a")},
+		{receiver: config.NewComponentID("b")},
+	}, r.list())
+
+	for _, name := range []string{"c", "d", "e"} {
+		r.add(sample{receiver: config.NewComponentID(config.Type(name))})
+	}
+	assert.Equal(t, []sample{
+		{receiver: config.NewComponentID("c")},
+		{receiver: config.NewComponentID("d")},
+		{receiver: config.NewComponentID("e")},
+	}, r.list())
+}
+
+func TestSplitTraces(t *testing.T) {
+	td := testdata.GenerateTraces(5)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(4).SetName("last")
+
+	samples := splitTraces(td, 2, newRedactor([]string{"resource-attr", "span-event-attr"}))
+	require.Len(t, samples, 2)
+	for _, s := range samples {
+		assert.Equal(t, 1, s.SpanCount())
+	}
+	rs := samples[1].ResourceSpans().At(0)
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "last", span.Name())
+	assert.Equal(t, map[string]interface{}{"resource-attr": redactedValue}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"span-event-attr": redactedValue}, span.Events().At(0).Attributes().AsRaw())
+
+	// The observed traces are not modified.
+	assert.Equal(t, "resource-attr-val-1", td.ResourceSpans().At(0).Resource().Attributes().AsRaw()["resource-attr"])
+	assert.Len(t, splitTraces(td, 10, nil), 5)
+}
+
+func TestSplitMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsAllTypes()
+	sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2)
+
+	samples := splitMetrics(md, 100, newRedactor([]string{"label-1"}))
+	require.Len(t, samples, md.DataPointCount())
+	var sumSamples int
+	for _, s := range samples {
+		require.Equal(t, 1, s.DataPointCount())
+		m := s.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+		if m.Name() != sum.Name() {
+			continue
+		}
+		assert.Equal(t, sum.Sum().IsMonotonic(), m.Sum().IsMonotonic())
+		assert.Equal(t, sum.Sum().AggregationTemporality(), m.Sum().AggregationTemporality())
+		assert.Equal(t, sum.Sum().DataPoints().At(sumSamples).IntVal(), m.Sum().DataPoints().At(0).IntVal())
+		if _, ok := m.Sum().DataPoints().At(0).Attributes().Get("label-1"); ok {
+			assert.Equal(t, redactedValue, m.Sum().DataPoints().At(0).Attributes().AsRaw()["label-1"])
+		}
+		sumSamples++
+	}
+	assert.Equal(t, sum.Sum().DataPoints().Len(), sumSamples)
+
+	assert.Len(t, splitMetrics(md, 3, nil), 3)
+}
+
+func TestSplitLogs(t *testing.T) {
+	ld := testdata.GenerateLogs(5)
+
+	samples := splitLogs(ld, 3, newRedactor([]string{"resource-attr"}))
+	require.Len(t, samples, 3)
+	for _, s := range samples {
+		assert.Equal(t, 1, s.LogRecordCount())
+		assert.Equal(t, redactedValue, s.ResourceLogs().At(0).Resource().Attributes().AsRaw()["resource-attr"])
+	}
+	assert.Equal(t, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(4).Body().AsString(),
+		samples[2].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().AsString())
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loglevelextension // import "go.opentelemetry.io/collector/extension/loglevelextension"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const logLevelsPath = "/loglevels"
+
+// logLevelsHost is the host serving the log levels of the components.
+type logLevelsHost interface {
+	RegisterLogLevels(mux *http.ServeMux, pattern string)
+}
+
+type logLevelExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	stopCh    chan struct{}
+}
+
+func (lle *logLevelExtension) Start(_ context.Context, host component.Host) error {
+	llHost, ok := host.(logLevelsHost)
+	if !ok {
+		return errors.New("the host does not serve the log levels")
+	}
+	mux := http.NewServeMux()
+	llHost.RegisterLogLevels(mux, logLevelsPath)
+
+	if lle.config.Auth == nil {
+		lle.telemetry.Logger.Warn("The log levels can be changed by any client, configure \"auth\" to authenticate them")
+	}
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := lle.config.ToListener()
+	if err != nil {
+		return err
+	}
+	lle.server, err = lle.config.ToServer(host, lle.telemetry, mux)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	lle.telemetry.Logger.Info("Starting log level extension", zap.String("endpoint", lle.config.Endpoint))
+	lle.stopCh = make(chan struct{})
+	go func() {
+		defer close(lle.stopCh)
+
+		if errHTTP := lle.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (lle *logLevelExtension) Shutdown(context.Context) error {
+	if lle.server == nil {
+		return nil
+	}
+	err := lle.server.Close()
+	if lle.stopCh != nil {
+		<-lle.stopCh
+	}
+	return err
+}
+
+func newServer(config *Config, telemetry component.TelemetrySettings) *logLevelExtension {
+	return &logLevelExtension{
+		config:    config,
+		telemetry: telemetry,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension // import "go.opentelemetry.io/collector/extension/memorylimiterextension"
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
+)
+
+type memoryLimiterExtension struct {
+	limiter *memorylimiter.MemoryLimiter
+}
+
+func newMemoryLimiter(cfg *Config, logger *zap.Logger) (*memoryLimiterExtension, error) {
+	limiter, err := memorylimiter.NewMemoryLimiter(&memorylimiter.Config{
+		CheckInterval:         cfg.CheckInterval,
+		MemoryLimitMiB:        cfg.MemoryLimitMiB,
+		MemorySpikeLimitMiB:   cfg.MemorySpikeLimitMiB,
+		MemoryLimitPercentage: cfg.MemoryLimitPercentage,
+		MemorySpikePercentage: cfg.MemorySpikePercentage,
+		QueueLimitMiB:         cfg.QueueLimitMiB,
+	}, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryLimiterExtension{limiter: limiter}, nil
+}
+
+func (ml *memoryLimiterExtension) Start(ctx context.Context, host component.Host) error {
+	return ml.limiter.Start(ctx, host)
+}
+
+func (ml *memoryLimiterExtension) Shutdown(ctx context.Context) error {
+	return ml.limiter.Shutdown(ctx)
+}
+
+// MustRefuse returns whether the receivers must refuse the incoming requests.
+func (ml *memoryLimiterExtension) MustRefuse() bool {
+	return ml.limiter.MustRefuse()
+}
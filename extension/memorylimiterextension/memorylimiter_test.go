@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiterextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
+)
+
+func TestMemoryLimiterExtension(t *testing.T) {
+	tests := []struct {
+		name           string
+		memoryLimitMiB uint32
+		mustRefuse     bool
+	}{
+		{
+			name:           "below_limit",
+			memoryLimitMiB: 1 << 20,
+			mustRefuse:     false,
+		},
+		{
+			name:           "above_limit",
+			memoryLimitMiB: 4,
+			mustRefuse:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Kept alive until the end of the test to be above the low limit.
+			allocated := make([]byte, 8*1024*1024)
+			ml, err := newMemoryLimiter(&Config{
+				CheckInterval:  10 * time.Millisecond,
+				MemoryLimitMiB: tt.memoryLimitMiB,
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			// The receivers find the extension as a memory limiter.
+			limiter, err := memorylimiter.GetLimiter(map[config.ComponentID]component.Extension{
+				config.NewComponentID(typeStr): ml,
+			}, config.NewComponentID(typeStr))
+			require.NoError(t, err)
+
+			require.NoError(t, ml.Start(context.Background(), componenttest.NewNopHost()))
+			if tt.mustRefuse {
+				assert.Eventually(t, limiter.MustRefuse, 5*time.Second, 10*time.Millisecond)
+			} else {
+				time.Sleep(50 * time.Millisecond)
+				assert.False(t, limiter.MustRefuse())
+			}
+			assert.NoError(t, ml.Shutdown(context.Background()))
+			assert.Len(t, allocated, 8*1024*1024)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension // import "go.opentelemetry.io/collector/extension/pprofextension"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration of the extension serving the profiling data of the collector.
+type Config struct {
+	config.ExtensionSettings      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// BlockProfileFraction is the rate of the blocking events reported in the blocking profile,
+	// see runtime.SetBlockProfileRate. If zero the blocking events are not profiled.
+	BlockProfileFraction int `mapstructure:"block_profile_fraction"`
+
+	// MutexProfileFraction is the fraction of the mutex contention events reported in the mutex profile,
+	// see runtime.SetMutexProfileFraction. If zero the mutex contention events are not profiled.
+	MutexProfileFraction int `mapstructure:"mutex_profile_fraction"`
+
+	// Directory is where the profiles captured on demand are written. If empty the captured profiles are not written.
+	Directory string `mapstructure:"directory"`
+
+	// Export configures the endpoint the profiles captured on demand are pushed to. If nil the captured
+	// profiles are not pushed.
+	Export *confighttp.HTTPClientSettings `mapstructure:"export"`
+
+	// MaxCaptureDuration is the maximum duration of the CPU profiles captured on demand.
+	MaxCaptureDuration time.Duration `mapstructure:"max_capture_duration"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"pprof\" extension")
+	}
+	if cfg.BlockProfileFraction < 0 {
+		return fmt.Errorf("invalid block profile fraction %d, must not be negative", cfg.BlockProfileFraction)
+	}
+	if cfg.MutexProfileFraction < 0 {
+		return fmt.Errorf("invalid mutex profile fraction %d, must not be negative", cfg.MutexProfileFraction)
+	}
+	if cfg.Export != nil && cfg.Export.Endpoint == "" {
+		return errors.New("\"export\" requires an \"endpoint\"")
+	}
+	if cfg.MaxCaptureDuration <= 0 {
+		return fmt.Errorf("invalid max capture duration %v, must be positive", cfg.MaxCaptureDuration)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension // import "go.opentelemetry.io/collector/extension/pprofextension"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"strconv"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	capturePath  = "/debug/pprof/capture"
+	cpuProfile   = "cpu"
+	profileQuery = "profile"
+	secondsQuery = "seconds"
+
+	// profileTypeHeader is the header of the profiles pushed to the export endpoint with their type.
+	profileTypeHeader = "X-Profile-Type"
+
+	defaultCaptureDuration = 30 * time.Second
+)
+
+type pprofExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	client    *http.Client
+	stopCh    chan struct{}
+	// shutdownCh interrupts the CPU profiles being captured on shutdown.
+	shutdownCh chan struct{}
+	now        func() time.Time
+}
+
+// captureResponse is the JSON response of the capture endpoint.
+type captureResponse struct {
+	Profile  string `json:"profile"`
+	File     string `json:"file,omitempty"`
+	Exported bool   `json:"exported"`
+}
+
+func newServer(config *Config, telemetry component.TelemetrySettings) *pprofExtension {
+	return &pprofExtension{
+		config:     config,
+		telemetry:  telemetry,
+		shutdownCh: make(chan struct{}),
+		now:        time.Now,
+	}
+}
+
+func (pe *pprofExtension) Start(_ context.Context, host component.Host) error {
+	if pe.config.Directory != "" {
+		if err := os.MkdirAll(pe.config.Directory, 0700); err != nil {
+			return fmt.Errorf("failed to create the profiles directory: %w", err)
+		}
+	}
+	if pe.config.Export != nil {
+		client, err := pe.config.Export.ToClientWithHost(host, pe.telemetry)
+		if err != nil {
+			return err
+		}
+		pe.client = client
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc(capturePath, pe.handleCapture)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := pe.config.ToListener()
+	if err != nil {
+		return err
+	}
+	pe.server, err = pe.config.ToServer(host, pe.telemetry, mux)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	runtime.SetBlockProfileRate(pe.config.BlockProfileFraction)
+	runtime.SetMutexProfileFraction(pe.config.MutexProfileFraction)
+
+	pe.telemetry.Logger.Info("Starting pprof extension", zap.String("endpoint", pe.config.Endpoint))
+	pe.stopCh = make(chan struct{})
+	go func() {
+		defer close(pe.stopCh)
+
+		if errHTTP := pe.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (pe *pprofExtension) Shutdown(context.Context) error {
+	if pe.server == nil {
+		return nil
+	}
+	select {
+	case <-pe.shutdownCh:
+	default:
+		close(pe.shutdownCh)
+	}
+	err := pe.server.Close()
+	if pe.stopCh != nil {
+		<-pe.stopCh
+	}
+	runtime.SetBlockProfileRate(0)
+	runtime.SetMutexProfileFraction(0)
+	return err
+}
+
+// handleCapture captures the profile of the request, e.g. POST /debug/pprof/capture?profile=cpu&seconds=60,
+// writes it to the directory and pushes it to the export endpoint, responding with the file and export result.
+// The CPU profile is captured for the duration, the other profiles, e.g. heap or goroutine, are a snapshot.
+func (pe *pprofExtension) handleCapture(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if pe.config.Directory == "" && pe.client == nil {
+		http.Error(w, "neither a directory nor an export endpoint is configured for the captured profiles", http.StatusBadRequest)
+		return
+	}
+
+	profile := r.URL.Query().Get(profileQuery)
+	if profile == "" {
+		profile = cpuProfile
+	}
+	duration := defaultCaptureDuration
+	if s := r.URL.Query().Get(secondsQuery); s != "" {
+		seconds, err := strconv.Atoi(s)
+		if err != nil || seconds <= 0 {
+			http.Error(w, fmt.Sprintf("invalid seconds %q, must be a positive integer", s), http.StatusBadRequest)
+			return
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+	if duration > pe.config.MaxCaptureDuration {
+		http.Error(w, fmt.Sprintf("capture duration %v exceeds the maximum of %v", duration, pe.config.MaxCaptureDuration), http.StatusBadRequest)
+		return
+	}
+
+	data, status, err := pe.capture(r.Context(), profile, duration)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	resp := captureResponse{Profile: profile}
+	name := fmt.Sprintf("%s-%s.pb.gz", profile, pe.now().UTC().Format("20060102T150405Z"))
+	var errs error
+	if pe.config.Directory != "" {
+		resp.File = filepath.Join(pe.config.Directory, name)
+		if err = os.WriteFile(resp.File, data, 0600); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to write the profile: %w", err))
+			resp.File = ""
+		}
+	}
+	if pe.client != nil {
+		if err = pe.export(r.Context(), profile, name, data); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to export the profile: %w", err))
+		} else {
+			resp.Exported = true
+		}
+	}
+	if errs != nil {
+		pe.telemetry.Logger.Warn("Failed to save the captured profile", zap.String("profile", profile), zap.Error(errs))
+		http.Error(w, errs.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pe.telemetry.Logger.Info("Captured profile", zap.String("profile", profile), zap.String("file", resp.File), zap.Bool("exported", resp.Exported))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// capture returns the profile in the pprof format, or the error and its HTTP status.
+func (pe *pprofExtension) capture(ctx context.Context, profile string, duration time.Duration) ([]byte, int, error) {
+	var buf bytes.Buffer
+	if profile != cpuProfile {
+		p := runtimepprof.Lookup(profile)
+		if p == nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("unknown profile %q", profile)
+		}
+		if err := p.WriteTo(&buf, 0); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return buf.Bytes(), http.StatusOK, nil
+	}
+
+	if err := runtimepprof.StartCPUProfile(&buf); err != nil {
+		return nil, http.StatusConflict, fmt.Errorf("cannot capture the CPU profile: %w", err)
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	case <-pe.shutdownCh:
+	}
+	runtimepprof.StopCPUProfile()
+	if ctx.Err() != nil {
+		return nil, http.StatusServiceUnavailable, ctx.Err()
+	}
+	return buf.Bytes(), http.StatusOK, nil
+}
+
+// export pushes the profile in the pprof format to the export endpoint.
+func (pe *pprofExtension) export(ctx context.Context, profile, name string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pe.config.Export.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set(profileTypeHeader, profile)
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	resp, err := pe.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("export endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func startTestExtension(t *testing.T, cfg *Config) string {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	ext := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	ext.now = func() time.Time { return time.Date(2022, 6, 1, 12, 30, 0, 0, time.UTC) }
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	return "http://" + cfg.Endpoint
+}
+
+func TestPprofExtensionEndpoints(t *testing.T) {
+	url := startTestExtension(t, createDefaultConfig().(*Config))
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/goroutine?debug=1", "/debug/vars"} {
+		resp, err := http.Get(url + path)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+		require.NoError(t, resp.Body.Close())
+	}
+}
+
+func TestPprofExtensionCaptureToDirectory(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = filepath.Join(t.TempDir(), "profiles")
+	url := startTestExtension(t, cfg)
+
+	resp, err := http.Post(url+capturePath+"?profile=heap", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var got captureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	file := filepath.Join(cfg.Directory, "heap-20220601T123000Z.pb.gz")
+	assert.Equal(t, captureResponse{Profile: "heap", File: file}, got)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	// The profiles are gzipped.
+	assert.Equal(t, []byte{0x1f, 0x8b}, data[:2])
+}
+
+func TestPprofExtensionCaptureExport(t *testing.T) {
+	var profileType string
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		profileType = r.Header.Get(profileTypeHeader)
+		var err error
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Export = &confighttp.HTTPClientSettings{Endpoint: srv.URL}
+	url := startTestExtension(t, cfg)
+
+	resp, err := http.Post(url+capturePath+"?seconds=1", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var got captureResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	assert.Equal(t, captureResponse{Profile: "cpu", Exported: true}, got)
+	assert.Equal(t, "cpu", profileType)
+	assert.NotEmpty(t, body)
+}
+
+func TestPprofExtensionCaptureErrors(t *testing.T) {
+	url := startTestExtension(t, createDefaultConfig().(*Config))
+	resp, err := http.Post(url+capturePath, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.MaxCaptureDuration = time.Minute
+	url = startTestExtension(t, cfg)
+	tests := []struct {
+		method         string
+		query          string
+		expectedStatus int
+	}{
+		{method: http.MethodGet, query: "?profile=heap", expectedStatus: http.StatusMethodNotAllowed},
+		{method: http.MethodPost, query: "?profile=unknown", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodPost, query: "?seconds=-1", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodPost, query: "?seconds=120", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, url+capturePath+tt.query, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expectedStatus, resp.StatusCode, tt.query)
+		require.NoError(t, resp.Body.Close())
+	}
+}
+
+func TestPprofExtensionShutdownInterruptsCapture(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	ext := newServer(cfg, componenttest.NewNopTelemetrySettings())
+	close(ext.shutdownCh)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, status, err := ext.capture(context.Background(), cpuProfile, time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the capture was not interrupted")
+	}
+}
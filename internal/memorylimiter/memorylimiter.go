@@ -0,0 +1,353 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memorylimiter monitors the memory usage of the process, for the memory_limiter
+// processor and extension to refuse data while it is above the configured limits.
+package memorylimiter // import "go.opentelemetry.io/collector/internal/memorylimiter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/ballastextension"
+	"go.opentelemetry.io/collector/internal/iruntime"
+	"go.opentelemetry.io/collector/internal/memorypressure"
+)
+
+const (
+	mibBytes = 1024 * 1024
+)
+
+var (
+	// Construction errors
+
+	ErrCheckIntervalOutOfRange = errors.New(
+		"checkInterval must be greater than zero")
+
+	ErrLimitOutOfRange = errors.New(
+		"memAllocLimit or memoryLimitPercentage must be greater than zero")
+
+	ErrMemSpikeLimitOutOfRange = errors.New(
+		"memSpikeLimit must be smaller than memAllocLimit")
+
+	ErrPercentageLimitOutOfRange = errors.New(
+		"memoryLimitPercentage and memorySpikePercentage must be greater than zero and less than or equal to hundred",
+	)
+
+	ErrShutdownNotStarted = errors.New("no existing monitoring routine is running")
+
+	errLimiterNotFound = errors.New("memory limiter not found")
+	errNotLimiter      = errors.New("requested extension is not a memory limiter")
+)
+
+// make it overridable by tests
+var getMemoryFn = iruntime.TotalMemory
+
+// Config defines the limits of the MemoryLimiter, see the memory_limiter processor
+// for the description of the settings.
+type Config struct {
+	CheckInterval         time.Duration
+	MemoryLimitMiB        uint32
+	MemorySpikeLimitMiB   uint32
+	MemoryLimitPercentage uint32
+	MemorySpikePercentage uint32
+	QueueLimitMiB         uint32
+}
+
+// Limiter is implemented by the components refusing data while the memory usage is
+// above the soft limit, such as the memory_limiter extension.
+type Limiter interface {
+	// MustRefuse returns whether the incoming data must be refused.
+	MustRefuse() bool
+}
+
+// GetLimiter returns the extension with the given ID, if it is a Limiter.
+func GetLimiter(extensions map[config.ComponentID]component.Extension, id config.ComponentID) (Limiter, error) {
+	ext, found := extensions[id]
+	if !found {
+		return nil, fmt.Errorf("failed to resolve memory limiter %q: %w", id, errLimiterNotFound)
+	}
+	limiter, ok := ext.(Limiter)
+	if !ok {
+		return nil, fmt.Errorf("failed to resolve memory limiter %q: %w", id, errNotLimiter)
+	}
+	return limiter, nil
+}
+
+// MemoryLimiter checks the memory usage periodically once started, and reports the memory
+// pressure while the usage is above the soft limit.
+type MemoryLimiter struct {
+	usageChecker memUsageChecker
+
+	memCheckWait time.Duration
+	// getBallastSize returns the size of the ballast, read when checking the memory usage
+	// since the ballast extension may be started after this memory limiter.
+	getBallastSize func() uint64
+
+	// mustRefuse is used atomically to indicate when data should be refused.
+	mustRefuse *atomic.Bool
+
+	// queueLimit is the limit of the bytes of the data held by the components, e.g. the
+	// sending queues of the exporters, accounted in memorypressure, 0 if unlimited.
+	queueLimit int64
+	// queueLimitLogged is whether the accounted bytes were above the queue limit at the last check.
+	queueLimitLogged bool
+
+	ticker *time.Ticker
+
+	lastGCDone time.Time
+
+	// The function to read the mem values is set as a reference to help with
+	// testing different values.
+	readMemStatsFn func(m *runtime.MemStats)
+
+	// Fields used for logging.
+	logger                 *zap.Logger
+	configMismatchedLogged bool
+
+	refCounterLock sync.Mutex
+	refCounter     int
+}
+
+// Minimum interval between forced GC when in soft limited mode. We don't want to
+// do GCs too frequently since it is a CPU-heavy operation.
+const minGCIntervalWhenSoftLimited = 10 * time.Second
+
+// NewMemoryLimiter returns a new MemoryLimiter, which must be started to check the memory usage.
+func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
+	if cfg.CheckInterval <= 0 {
+		return nil, ErrCheckIntervalOutOfRange
+	}
+	if cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+		return nil, ErrLimitOutOfRange
+	}
+
+	usageChecker, err := getMemUsageChecker(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Memory limiter configured",
+		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
+		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
+		zap.Uint32("queue_limit_mib", cfg.QueueLimitMiB),
+		zap.Duration("check_interval", cfg.CheckInterval))
+
+	return &MemoryLimiter{
+		usageChecker:   *usageChecker,
+		memCheckWait:   cfg.CheckInterval,
+		getBallastSize: func() uint64 { return 0 },
+		ticker:         time.NewTicker(cfg.CheckInterval),
+		readMemStatsFn: runtime.ReadMemStats,
+		logger:         logger,
+		mustRefuse:     atomic.NewBool(false),
+		queueLimit:     int64(cfg.QueueLimitMiB) * mibBytes,
+	}, nil
+}
+
+func getMemUsageChecker(cfg *Config, logger *zap.Logger) (*memUsageChecker, error) {
+	memAllocLimit := uint64(cfg.MemoryLimitMiB) * mibBytes
+	memSpikeLimit := uint64(cfg.MemorySpikeLimitMiB) * mibBytes
+	if cfg.MemoryLimitMiB != 0 {
+		return newFixedMemUsageChecker(memAllocLimit, memSpikeLimit)
+	}
+	totalMemory, err := getMemoryFn()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total memory, use fixed memory settings (limit_mib): %w", err)
+	}
+	logger.Info("Using percentage memory limiter",
+		zap.Uint64("total_memory_mib", totalMemory/mibBytes),
+		zap.Uint32("limit_percentage", cfg.MemoryLimitPercentage),
+		zap.Uint32("spike_limit_percentage", cfg.MemorySpikePercentage))
+	return newPercentageMemUsageChecker(totalMemory, uint64(cfg.MemoryLimitPercentage), uint64(cfg.MemorySpikePercentage))
+}
+
+// Start starts checking the memory usage, accounting for the ballast of the ballast extension
+// if any. It may be called several times, the memory usage being checked until Shutdown
+// is called as many times.
+func (ml *MemoryLimiter) Start(_ context.Context, host component.Host) error {
+	for _, extension := range host.GetExtensions() {
+		if ext, ok := extension.(*ballastextension.MemoryBallast); ok {
+			ml.getBallastSize = ext.GetBallastSize
+			break
+		}
+	}
+	ml.startMonitoring()
+	return nil
+}
+
+// Shutdown stops checking the memory usage once called as many times as Start.
+func (ml *MemoryLimiter) Shutdown(context.Context) error {
+	ml.refCounterLock.Lock()
+	defer ml.refCounterLock.Unlock()
+
+	if ml.refCounter == 0 {
+		return ErrShutdownNotStarted
+	} else if ml.refCounter == 1 {
+		ml.ticker.Stop()
+		memorypressure.Report(ml, false)
+	}
+	ml.refCounter--
+	return nil
+}
+
+// MustRefuse returns whether the memory usage was above the soft limit at the last check, or
+// whether the data held by the components is currently above the queue limit.
+func (ml *MemoryLimiter) MustRefuse() bool {
+	return ml.mustRefuse.Load() || ml.aboveQueueLimit()
+}
+
+func (ml *MemoryLimiter) aboveQueueLimit() bool {
+	return ml.queueLimit > 0 && memorypressure.AccountedBytes() >= ml.queueLimit
+}
+
+func (ml *MemoryLimiter) readMemStats() *runtime.MemStats {
+	ms := &runtime.MemStats{}
+	ml.readMemStatsFn(ms)
+	// If proper configured ms.Alloc should be at least the ballast size but since
+	// a misconfiguration is possible check for that here.
+	ballastSize := ml.getBallastSize()
+	if ms.Alloc >= ballastSize {
+		ms.Alloc -= ballastSize
+	} else if !ml.configMismatchedLogged {
+		// This indicates misconfiguration. Log it once.
+		ml.configMismatchedLogged = true
+		ml.logger.Warn(`"size_mib" in ballast extension is likely incorrectly configured.`)
+	}
+
+	return ms
+}
+
+// startMonitoring starts a single ticker'd goroutine per instance
+// that will check memory usage every checkInterval period.
+func (ml *MemoryLimiter) startMonitoring() {
+	ml.refCounterLock.Lock()
+	defer ml.refCounterLock.Unlock()
+
+	ml.refCounter++
+	if ml.refCounter == 1 {
+		go func() {
+			for range ml.ticker.C {
+				ml.checkMemLimits()
+			}
+		}()
+	}
+}
+
+func memstatToZapField(ms *runtime.MemStats) zap.Field {
+	return zap.Uint64("cur_mem_mib", ms.Alloc/mibBytes)
+}
+
+func (ml *MemoryLimiter) doGCandReadMemStats() *runtime.MemStats {
+	runtime.GC()
+	ml.lastGCDone = time.Now()
+	ms := ml.readMemStats()
+	ml.logger.Info("Memory usage after GC.", memstatToZapField(ms))
+	return ms
+}
+
+func (ml *MemoryLimiter) checkMemLimits() {
+	ms := ml.readMemStats()
+
+	ml.logger.Debug("Currently used memory.", memstatToZapField(ms),
+		zap.Int64("queued_mib", memorypressure.AccountedBytes()/mibBytes))
+
+	if aboveQueueLimit := ml.aboveQueueLimit(); aboveQueueLimit != ml.queueLimitLogged {
+		ml.queueLimitLogged = aboveQueueLimit
+		if aboveQueueLimit {
+			ml.logger.Warn("Queued data is above queue limit. Refusing data.",
+				zap.Int64("queued_mib", memorypressure.AccountedBytes()/mibBytes))
+		} else {
+			ml.logger.Info("Queued data back within queue limit.")
+		}
+	}
+
+	if ml.usageChecker.aboveHardLimit(ms) {
+		ml.logger.Warn("Memory usage is above hard limit. Forcing a GC.", memstatToZapField(ms))
+		ms = ml.doGCandReadMemStats()
+	}
+
+	// Remember current refusing state.
+	wasRefusing := ml.mustRefuse.Load()
+
+	// Check if the memory usage is above the soft limit.
+	mustRefuse := ml.usageChecker.aboveSoftLimit(ms)
+
+	if wasRefusing && !mustRefuse {
+		// Was previously refusing but enough memory is available now, no need to limit.
+		ml.logger.Info("Memory usage back within limits. Resuming normal operation.", memstatToZapField(ms))
+	}
+
+	if !wasRefusing && mustRefuse {
+		// We are above soft limit, do a GC if it wasn't done recently and see if
+		// it brings memory usage below the soft limit.
+		if time.Since(ml.lastGCDone) > minGCIntervalWhenSoftLimited {
+			ml.logger.Info("Memory usage is above soft limit. Forcing a GC.", memstatToZapField(ms))
+			ms = ml.doGCandReadMemStats()
+			// Check the limit again to see if GC helped.
+			mustRefuse = ml.usageChecker.aboveSoftLimit(ms)
+		}
+
+		if mustRefuse {
+			ml.logger.Warn("Memory usage is above soft limit. Dropping data.", memstatToZapField(ms))
+		}
+	}
+
+	ml.mustRefuse.Store(mustRefuse)
+	// Let the other components, e.g. the batch processor, release memory while refusing.
+	memorypressure.Report(ml, mustRefuse)
+}
+
+type memUsageChecker struct {
+	memAllocLimit uint64
+	memSpikeLimit uint64
+}
+
+func (d memUsageChecker) aboveSoftLimit(ms *runtime.MemStats) bool {
+	return ms.Alloc >= d.memAllocLimit-d.memSpikeLimit
+}
+
+func (d memUsageChecker) aboveHardLimit(ms *runtime.MemStats) bool {
+	return ms.Alloc >= d.memAllocLimit
+}
+
+func newFixedMemUsageChecker(memAllocLimit, memSpikeLimit uint64) (*memUsageChecker, error) {
+	if memSpikeLimit >= memAllocLimit {
+		return nil, ErrMemSpikeLimitOutOfRange
+	}
+	if memSpikeLimit == 0 {
+		// If spike limit is unspecified use 20% of mem limit.
+		memSpikeLimit = memAllocLimit / 5
+	}
+	return &memUsageChecker{
+		memAllocLimit: memAllocLimit,
+		memSpikeLimit: memSpikeLimit,
+	}, nil
+}
+
+func newPercentageMemUsageChecker(totalMemory uint64, percentageLimit, percentageSpike uint64) (*memUsageChecker, error) {
+	if percentageLimit > 100 || percentageLimit <= 0 || percentageSpike > 100 || percentageSpike <= 0 {
+		return nil, ErrPercentageLimitOutOfRange
+	}
+	return newFixedMemUsageChecker(percentageLimit*totalMemory/100, percentageSpike*totalMemory/100)
+}
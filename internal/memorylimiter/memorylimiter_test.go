@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorylimiter
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/ballastextension"
+	"go.opentelemetry.io/collector/internal/iruntime"
+	"go.opentelemetry.io/collector/internal/memorypressure"
+)
+
+func TestNewMemoryLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name:    "zero_checkInterval",
+			wantErr: ErrCheckIntervalOutOfRange,
+		},
+		{
+			name: "zero_memAllocLimit",
+			cfg: Config{
+				CheckInterval: 100 * time.Millisecond,
+			},
+			wantErr: ErrLimitOutOfRange,
+		},
+		{
+			name: "memSpikeLimit_gt_memAllocLimit",
+			cfg: Config{
+				CheckInterval:       100 * time.Millisecond,
+				MemoryLimitMiB:      1,
+				MemorySpikeLimitMiB: 2,
+			},
+			wantErr: ErrMemSpikeLimitOutOfRange,
+		},
+		{
+			name: "success",
+			cfg: Config{
+				CheckInterval:  100 * time.Millisecond,
+				MemoryLimitMiB: 1024,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMemoryLimiter(&tt.cfg, zap.NewNop())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, got.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, got.Shutdown(context.Background()))
+			assert.ErrorIs(t, got.Shutdown(context.Background()), ErrShutdownNotStarted)
+		})
+	}
+}
+
+// TestMemoryPressureResponse manipulates results from querying memory and
+// check expected side effects.
+func TestMemoryPressureResponse(t *testing.T) {
+	var currentMemAlloc uint64
+	var ballastSize uint64
+	ml := &MemoryLimiter{
+		usageChecker: memUsageChecker{
+			memAllocLimit: 1024,
+		},
+		getBallastSize: func() uint64 { return ballastSize },
+		mustRefuse:     atomic.NewBool(false),
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = currentMemAlloc
+		},
+		logger: zap.NewNop(),
+	}
+
+	// Below memAllocLimit.
+	currentMemAlloc = 800
+	ml.checkMemLimits()
+	assert.False(t, ml.MustRefuse())
+	assert.False(t, memorypressure.UnderPressure())
+
+	// Above memAllocLimit.
+	currentMemAlloc = 1800
+	ml.checkMemLimits()
+	assert.True(t, ml.MustRefuse())
+	assert.True(t, memorypressure.UnderPressure())
+
+	// Check ballast effect
+	ballastSize = 1000
+
+	// Below memAllocLimit accounting for ballast.
+	currentMemAlloc = 800 + ballastSize
+	ml.checkMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// Above memAllocLimit even accountiing for ballast.
+	currentMemAlloc = 1800 + ballastSize
+	ml.checkMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// Restore ballast to default.
+	ballastSize = 0
+
+	// Check spike limit
+	ml.usageChecker.memSpikeLimit = 512
+
+	// Below memSpikeLimit.
+	currentMemAlloc = 500
+	ml.checkMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// Above memSpikeLimit.
+	currentMemAlloc = 550
+	ml.checkMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// The memory pressure is no longer reported once shut down.
+	ml.refCounter = 1
+	ml.ticker = time.NewTicker(time.Minute)
+	assert.NoError(t, ml.Shutdown(context.Background()))
+	assert.False(t, memorypressure.UnderPressure())
+}
+
+func TestQueueLimit(t *testing.T) {
+	ml, err := NewMemoryLimiter(&Config{
+		CheckInterval:  time.Minute,
+		MemoryLimitMiB: 1024,
+		QueueLimitMiB:  1,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	ml.readMemStatsFn = func(ms *runtime.MemStats) {}
+
+	account := &memorypressure.Account{}
+	defer account.Reset()
+	ml.checkMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// The queue limit is enforced without waiting for the next check.
+	account.Add(mibBytes)
+	assert.True(t, ml.MustRefuse())
+	ml.checkMemLimits()
+	assert.True(t, ml.MustRefuse())
+	// The heap is not under pressure.
+	assert.False(t, memorypressure.UnderPressure())
+
+	account.Add(-1)
+	assert.False(t, ml.MustRefuse())
+}
+
+func TestGetDecision(t *testing.T) {
+	t.Run("fixed_limit", func(t *testing.T) {
+		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, &memUsageChecker{
+			memAllocLimit: 100 * mibBytes,
+			memSpikeLimit: 20 * mibBytes,
+		}, d)
+	})
+	t.Run("fixed_limit_error", func(t *testing.T) {
+		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 20, MemorySpikeLimitMiB: 100}, zap.NewNop())
+		require.Error(t, err)
+		assert.Nil(t, d)
+	})
+
+	t.Cleanup(func() {
+		getMemoryFn = iruntime.TotalMemory
+	})
+	getMemoryFn = func() (uint64, error) {
+		return 100 * mibBytes, nil
+	}
+	t.Run("percentage_limit", func(t *testing.T) {
+		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 50, MemorySpikePercentage: 10}, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, &memUsageChecker{
+			memAllocLimit: 50 * mibBytes,
+			memSpikeLimit: 10 * mibBytes,
+		}, d)
+	})
+	t.Run("percentage_limit_error", func(t *testing.T) {
+		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 101, MemorySpikePercentage: 10}, zap.NewNop())
+		require.Error(t, err)
+		assert.Nil(t, d)
+		d, err = getMemUsageChecker(&Config{MemoryLimitPercentage: 99, MemorySpikePercentage: 101}, zap.NewNop())
+		require.Error(t, err)
+		assert.Nil(t, d)
+	})
+}
+
+func TestDropDecision(t *testing.T) {
+	decison1000Limit30Spike30, err := newPercentageMemUsageChecker(1000, 60, 30)
+	require.NoError(t, err)
+	decison1000Limit60Spike50, err := newPercentageMemUsageChecker(1000, 60, 50)
+	require.NoError(t, err)
+	decison1000Limit40Spike20, err := newPercentageMemUsageChecker(1000, 40, 20)
+	require.NoError(t, err)
+	decison1000Limit40Spike60, err := newPercentageMemUsageChecker(1000, 40, 60)
+	require.Error(t, err)
+	assert.Nil(t, decison1000Limit40Spike60)
+
+	tests := []struct {
+		name         string
+		usageChecker memUsageChecker
+		ms           *runtime.MemStats
+		shouldDrop   bool
+	}{
+		{
+			name:         "should drop over limit",
+			usageChecker: *decison1000Limit30Spike30,
+			ms:           &runtime.MemStats{Alloc: 600},
+			shouldDrop:   true,
+		},
+		{
+			name:         "should not drop",
+			usageChecker: *decison1000Limit30Spike30,
+			ms:           &runtime.MemStats{Alloc: 100},
+			shouldDrop:   false,
+		},
+		{
+			name: "should not drop spike, fixed usageChecker",
+			usageChecker: memUsageChecker{
+				memAllocLimit: 600,
+				memSpikeLimit: 500,
+			},
+			ms:         &runtime.MemStats{Alloc: 300},
+			shouldDrop: true,
+		},
+		{
+			name:         "should drop, spike, percentage usageChecker",
+			usageChecker: *decison1000Limit60Spike50,
+			ms:           &runtime.MemStats{Alloc: 300},
+			shouldDrop:   true,
+		},
+		{
+			name:         "should drop, spike, percentage usageChecker",
+			usageChecker: *decison1000Limit40Spike20,
+			ms:           &runtime.MemStats{Alloc: 250},
+			shouldDrop:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shouldDrop := test.usageChecker.aboveSoftLimit(test.ms)
+			assert.Equal(t, test.shouldDrop, shouldDrop)
+		})
+	}
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestStartUsesBallastSize(t *testing.T) {
+	ctx := context.Background()
+	ballastExtFactory := ballastextension.NewFactory()
+	ballastExtCfg := ballastExtFactory.CreateDefaultConfig().(*ballastextension.Config)
+	ballastExtCfg.SizeMiB = 100
+	ballastExt, err := ballastExtFactory.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), ballastExtCfg)
+	require.NoError(t, err)
+
+	ml, err := NewMemoryLimiter(&Config{CheckInterval: time.Minute, MemoryLimitMiB: 1024}, zap.NewNop())
+	require.NoError(t, err)
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{ballastExtCfg.ID(): ballastExt},
+	}
+	require.NoError(t, ml.Start(ctx, host))
+	defer func() { assert.NoError(t, ml.Shutdown(ctx)) }()
+
+	// The ballast size is read once the ballast extension is started, whatever the start order.
+	assert.Zero(t, ml.getBallastSize())
+	require.NoError(t, ballastExt.Start(ctx, host))
+	defer func() { assert.NoError(t, ballastExt.Shutdown(ctx)) }()
+	assert.Equal(t, uint64(100*mibBytes), ml.getBallastSize())
+}
+
+type limiterExtension struct {
+	component.Extension
+	limiter *MemoryLimiter
+}
+
+func (e *limiterExtension) MustRefuse() bool {
+	return e.limiter.MustRefuse()
+}
+
+func TestGetLimiter(t *testing.T) {
+	ml, err := NewMemoryLimiter(&Config{CheckInterval: time.Minute, MemoryLimitMiB: 1024}, zap.NewNop())
+	require.NoError(t, err)
+	limiterID := config.NewComponentID("memory_limiter")
+	otherID := config.NewComponentID("other")
+	extensions := map[config.ComponentID]component.Extension{
+		limiterID: &limiterExtension{limiter: ml},
+		otherID:   struct{ component.Extension }{},
+	}
+
+	limiter, err := GetLimiter(extensions, limiterID)
+	require.NoError(t, err)
+	assert.False(t, limiter.MustRefuse())
+
+	_, err = GetLimiter(extensions, otherID)
+	assert.ErrorIs(t, err, errNotLimiter)
+
+	_, err = GetLimiter(extensions, config.NewComponentID("missing"))
+	assert.ErrorIs(t, err, errLimiterNotFound)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorypressure // import "go.opentelemetry.io/collector/internal/memorypressure"
+
+import (
+	"go.uber.org/atomic"
+)
+
+// accountedBytes is the sum of the bytes of all the Accounts.
+var accountedBytes = atomic.NewInt64(0)
+
+// Account records the estimated size in bytes of the data held by a component, e.g. the
+// requests in the sending queue of an exporter, for the memory limiters to refuse data while
+// the data held by the components, not only the heap, is above their limit.
+// The zero value is an empty Account.
+type Account struct {
+	bytes atomic.Int64
+}
+
+// Add adds the bytes, negative when the data is released, to the Account.
+func (a *Account) Add(bytes int64) {
+	a.bytes.Add(bytes)
+	accountedBytes.Add(bytes)
+}
+
+// Bytes returns the bytes recorded by the Account.
+func (a *Account) Bytes() int64 {
+	return a.bytes.Load()
+}
+
+// Reset releases all the bytes recorded by the Account, e.g. when the component is shut down.
+func (a *Account) Reset() {
+	accountedBytes.Sub(a.bytes.Swap(0))
+}
+
+// AccountedBytes returns the sum of the bytes recorded by all the Accounts.
+func AccountedBytes() int64 {
+	return accountedBytes.Load()
+}
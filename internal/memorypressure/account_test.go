@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorypressure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccount(t *testing.T) {
+	base := AccountedBytes()
+	a, b := &Account{}, &Account{}
+
+	a.Add(100)
+	b.Add(50)
+	assert.Equal(t, int64(100), a.Bytes())
+	assert.Equal(t, base+150, AccountedBytes())
+
+	a.Add(-40)
+	assert.Equal(t, int64(60), a.Bytes())
+	assert.Equal(t, base+110, AccountedBytes())
+
+	a.Reset()
+	assert.Equal(t, int64(0), a.Bytes())
+	assert.Equal(t, base+50, AccountedBytes())
+
+	b.Reset()
+	assert.Equal(t, base, AccountedBytes())
+}
@@ -0,0 +1,77 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memorypressure shares the memory pressure detected by the memory_limiter processors
+// with the components able to reduce their memory usage under pressure, and the memory used by
+// the data held by the components with the memory limiters.
+package memorypressure // import "go.opentelemetry.io/collector/internal/memorypressure"
+
+import (
+	"sync"
+
+	"go.uber.org/atomic"
+)
+
+var (
+	mu sync.Mutex
+	// sources are the sources currently reporting memory pressure.
+	sources = map[interface{}]struct{}{}
+	// listeners are the channels notified when the memory comes under pressure.
+	listeners = map[chan struct{}]struct{}{}
+	// underPressure is whether sources is not empty, read without locking.
+	underPressure = atomic.NewBool(false)
+)
+
+// Report records whether the memory is under pressure according to the source, the memory
+// being under pressure while any source reports it. The listeners are notified when the
+// memory comes under pressure.
+func Report(source interface{}, pressure bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if pressure {
+		sources[source] = struct{}{}
+	} else {
+		delete(sources, source)
+	}
+	was := underPressure.Swap(len(sources) > 0)
+	if was || len(sources) == 0 {
+		return
+	}
+	for c := range listeners {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// UnderPressure returns whether any source reports memory pressure.
+func UnderPressure() bool {
+	return underPressure.Load()
+}
+
+// Notify returns a channel receiving a value each time the memory comes under pressure, and
+// the function stopping the notifications. A notification is dropped when the previous one
+// was not received yet.
+func Notify() (<-chan struct{}, func()) {
+	c := make(chan struct{}, 1)
+	mu.Lock()
+	listeners[c] = struct{}{}
+	mu.Unlock()
+	return c, func() {
+		mu.Lock()
+		delete(listeners, c)
+		mu.Unlock()
+	}
+}
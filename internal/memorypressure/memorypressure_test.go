@@ -0,0 +1,56 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memorypressure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	c, stop := Notify()
+	defer stop()
+	assert.False(t, UnderPressure())
+
+	Report("a", true)
+	assert.True(t, UnderPressure())
+	assert.Len(t, c, 1)
+
+	// Already under pressure, no new notification.
+	Report("b", true)
+	Report("a", true)
+	assert.Len(t, c, 1)
+	<-c
+
+	Report("a", false)
+	assert.True(t, UnderPressure())
+	Report("b", false)
+	assert.False(t, UnderPressure())
+	assert.Len(t, c, 0)
+
+	Report("b", true)
+	assert.Len(t, c, 1)
+	Report("b", false)
+	assert.False(t, UnderPressure())
+}
+
+func TestNotifyStop(t *testing.T) {
+	c, stop := Notify()
+	stop()
+	Report("a", true)
+	defer Report("a", false)
+	assert.Len(t, c, 0)
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreportconfig // import "go.opentelemetry.io/collector/internal/obsreportconfig"
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
+)
+
+// Exemplar is the last measurement of a series of the collector's own metrics recorded within a sampled
+// span, linking the metrics to the traces of the collector, e.g. a spike of failures to a failed export.
+type Exemplar struct {
+	Value     float64
+	Timestamp time.Time
+	TraceID   trace.TraceID
+	SpanID    trace.SpanID
+	// Tags are the values of the tags of the views of the measure, keyed by the names of the tags.
+	Tags map[string]string
+}
+
+var (
+	exemplarsEnabled = atomic.NewBool(false)
+
+	exemplarsMu sync.Mutex
+	// exemplars are the last exemplars keyed by the name of their measure, and then by their tags.
+	exemplars = make(map[string]map[string]Exemplar)
+
+	exemplarTagKeysOnce sync.Once
+	// exemplarTagKeys are the tag keys of the views of the measures, keyed by the names of the measures.
+	exemplarTagKeys map[string][]tag.Key
+)
+
+// SetExemplarsEnabled enables or disables the recording of the exemplars, the recorded exemplars being
+// removed when disabled.
+func SetExemplarsEnabled(enabled bool) {
+	exemplarsEnabled.Store(enabled)
+	if !enabled {
+		exemplarsMu.Lock()
+		exemplars = make(map[string]map[string]Exemplar)
+		exemplarsMu.Unlock()
+	}
+}
+
+// RecordExemplars records the measurements as the last exemplars of their series, identified by the tags of
+// the context with the mutators applied, if the exemplars are enabled and the span of the context is sampled.
+func RecordExemplars(ctx context.Context, mutators []tag.Mutator, ms ...stats.Measurement) {
+	if !exemplarsEnabled.Load() {
+		return
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsSampled() {
+		return
+	}
+	tagCtx, err := tag.New(ctx, mutators...)
+	if err != nil {
+		return
+	}
+	tagMap := tag.FromContext(tagCtx)
+
+	exemplarTagKeysOnce.Do(func() {
+		exemplarTagKeys = make(map[string][]tag.Key)
+		for _, v := range allViews().Views {
+			exemplarTagKeys[v.Measure.Name()] = appendTagKeys(exemplarTagKeys[v.Measure.Name()], v.TagKeys)
+		}
+	})
+
+	now := time.Now()
+	exemplarsMu.Lock()
+	defer exemplarsMu.Unlock()
+	for _, m := range ms {
+		name := m.Measure().Name()
+		keys, ok := exemplarTagKeys[name]
+		if !ok {
+			continue
+		}
+		tags := make(map[string]string, len(keys))
+		seriesKey := make([]string, 0, len(keys))
+		for _, k := range keys {
+			val, _ := tagMap.Value(k)
+			tags[k.Name()] = val
+			seriesKey = append(seriesKey, val)
+		}
+		if exemplars[name] == nil {
+			exemplars[name] = make(map[string]Exemplar)
+		}
+		exemplars[name][strings.Join(seriesKey, "\x00")] = Exemplar{
+			Value:     m.Value(),
+			Timestamp: now,
+			TraceID:   sc.TraceID(),
+			SpanID:    sc.SpanID(),
+			Tags:      tags,
+		}
+	}
+}
+
+// Exemplars returns the last exemplars of the series of the measure.
+func Exemplars(measure string) []Exemplar {
+	exemplarsMu.Lock()
+	defer exemplarsMu.Unlock()
+	ret := make([]Exemplar, 0, len(exemplars[measure]))
+	for _, e := range exemplars[measure] {
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+// appendTagKeys appends the keys missing from keys.
+func appendTagKeys(keys []tag.Key, add []tag.Key) []tag.Key {
+	for _, k := range add {
+		found := false
+		for _, existing := range keys {
+			if existing == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
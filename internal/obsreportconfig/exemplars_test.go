@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreportconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+func TestRecordExemplars(t *testing.T) {
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID := trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+	sampledCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	notSampledCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	mutators := []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, "otlp")}
+	measure := obsmetrics.ExporterSentSpans
+
+	RecordExemplars(sampledCtx, mutators, measure.M(10))
+	assert.Empty(t, Exemplars(measure.Name()), "exemplars are disabled")
+
+	SetExemplarsEnabled(true)
+	RecordExemplars(notSampledCtx, mutators, measure.M(10))
+	assert.Empty(t, Exemplars(measure.Name()), "span is not sampled")
+
+	RecordExemplars(sampledCtx, mutators, measure.M(10))
+	RecordExemplars(sampledCtx, mutators, measure.M(20))
+	RecordExemplars(sampledCtx, []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, "otlp/2")}, measure.M(30))
+	exemplars := Exemplars(measure.Name())
+	require.Len(t, exemplars, 2)
+	byExporter := make(map[string]Exemplar)
+	for _, e := range exemplars {
+		byExporter[e.Tags[obsmetrics.ExporterKey]] = e
+	}
+	assert.Equal(t, float64(20), byExporter["otlp"].Value)
+	assert.Equal(t, traceID, byExporter["otlp"].TraceID)
+	assert.Equal(t, spanID, byExporter["otlp"].SpanID)
+	assert.Equal(t, float64(30), byExporter["otlp/2"].Value)
+
+	SetExemplarsEnabled(false)
+	assert.Empty(t, Exemplars(measure.Name()))
+}
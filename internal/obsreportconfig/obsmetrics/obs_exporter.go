@@ -22,6 +22,8 @@ import (
 const (
 	// ExporterKey used to identify exporters in metrics and traces.
 	ExporterKey = "exporter"
+	// ErrorTypeKey used to identify the type of error of failed sends in metrics and traces.
+	ErrorTypeKey = "error_type"
 
 	// SentSpansKey used to track spans sent by exporters.
 	SentSpansKey = "sent_spans"
@@ -40,7 +42,8 @@ const (
 )
 
 var (
-	TagKeyExporter, _ = tag.NewKey(ExporterKey)
+	TagKeyExporter, _  = tag.NewKey(ExporterKey)
+	TagKeyErrorType, _ = tag.NewKey(ErrorTypeKey)
 
 	ExporterPrefix                 = ExporterKey + NameSep
 	ExportTraceDataOperationSuffix = NameSep + "traces"
@@ -76,4 +79,8 @@ var (
 		ExporterPrefix+FailedToSendLogRecordsKey,
 		"Number of log records in failed attempts to send to destination.",
 		stats.UnitDimensionless)
+	ExporterRecoveredPanics = stats.Int64(
+		ExporterPrefix+RecoveredPanicsKey,
+		"Number of panics of the exporter recovered in its consume calls.",
+		stats.UnitDimensionless)
 )
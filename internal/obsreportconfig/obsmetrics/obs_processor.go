@@ -31,6 +31,9 @@ const (
 
 	// DroppedLogRecordsKey is the key used to identify log records dropped by the Collector.
 	DroppedLogRecordsKey = "dropped_log_records"
+
+	// RecoveredPanicsKey is the key used to identify the panics of the components recovered by the Collector.
+	RecoveredPanicsKey = "recovered_panics"
 )
 
 var (
@@ -76,4 +79,8 @@ var (
 		ProcessorPrefix+DroppedLogRecordsKey,
 		"Number of log records that were dropped.",
 		stats.UnitDimensionless)
+	ProcessorRecoveredPanics = stats.Int64(
+		ProcessorPrefix+RecoveredPanicsKey,
+		"Number of panics of the processor recovered in its consume calls.",
+		stats.UnitDimensionless)
 )
@@ -26,6 +26,8 @@ const (
 	TransportKey = "transport"
 	// FormatKey used to identify the format of the data received.
 	FormatKey = "format"
+	// DataTypeKey used to identify the data type of the requests received.
+	DataTypeKey = "data_type"
 
 	// AcceptedSpansKey used to identify spans accepted by the Collector.
 	AcceptedSpansKey = "accepted_spans"
@@ -43,11 +45,23 @@ const (
 	// RefusedLogRecordsKey used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// RequestSizeKey used to identify the size of the requests received by the Collector.
+	RequestSizeKey = "request_size"
+	// RequestDecodeDurationKey used to identify the duration of the decoding of the requests.
+	RequestDecodeDurationKey = "request_decode_duration"
+	// RequestCompressionRatioKey used to identify the compression ratio of the compressed requests.
+	RequestCompressionRatioKey = "request_compression_ratio"
+	// OversizedRequestsKey used to identify the requests rejected because of the size of their body.
+	OversizedRequestsKey = "oversized_requests"
+	// ActiveConnectionsKey used to identify the number of connections open to a receiver.
+	ActiveConnectionsKey = "active_connections"
 )
 
 var (
 	TagKeyReceiver, _  = tag.NewKey(ReceiverKey)
 	TagKeyTransport, _ = tag.NewKey(TransportKey)
+	TagKeyDataType, _  = tag.NewKey(DataTypeKey)
 
 	ReceiverPrefix                  = ReceiverKey + NameSep
 	ReceiveTraceDataOperationSuffix = NameSep + "TraceDataReceived"
@@ -83,4 +97,28 @@ var (
 		ReceiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
 		stats.UnitDimensionless)
+
+	// Receiver request metrics, per data type and transport.
+	ReceiverRequestSize = stats.Int64(
+		ReceiverPrefix+RequestSizeKey,
+		"Size of the received request bodies, after decompression.",
+		stats.UnitBytes)
+	ReceiverRequestDecodeDuration = stats.Float64(
+		ReceiverPrefix+RequestDecodeDurationKey,
+		"Duration of the decoding of the received request bodies.",
+		stats.UnitMilliseconds)
+	ReceiverRequestCompressionRatio = stats.Float64(
+		ReceiverPrefix+RequestCompressionRatioKey,
+		"Ratio of the decompressed to the compressed size of the received compressed request bodies.",
+		stats.UnitDimensionless)
+	ReceiverOversizedRequests = stats.Int64(
+		ReceiverPrefix+OversizedRequestsKey,
+		"Number of requests rejected because their body exceeds the maximum size.",
+		stats.UnitDimensionless)
+
+	// Receiver connection metrics, per transport.
+	ReceiverActiveConnections = stats.Int64(
+		ReceiverPrefix+ActiveConnectionsKey,
+		"Number of connections open to the receiver.",
+		stats.UnitDimensionless)
 )
@@ -29,6 +29,20 @@ const (
 	// ErroredMetricPointsKey used to identify metric points errored (i.e.
 	// unable to be scraped) by the Collector.
 	ErroredMetricPointsKey = "errored_metric_points"
+	// FailedMetricsKey used to identify the names of the metrics which failed
+	// to be scraped, when detailed by the scraper.
+	FailedMetricsKey = "failed_metrics"
+	// MetricKey used to identify the metric of a scrape failure.
+	MetricKey = "metric"
+	// FailedKey used to identify the number of failures of a metric.
+	FailedKey = "failed"
+	// ScrapeFailedEventName is the name of the span event recording the failure to scrape a metric.
+	ScrapeFailedEventName = "metric_scrape_failed"
+	// StaleScrapesKey used to identify the scrapes returning the same data points,
+	// values and timestamps, as the previous scrape of the scraper.
+	StaleScrapesKey = "stale_scrapes"
+	// StaleKey used to identify a stale scrape in traces.
+	StaleKey = "stale"
 )
 
 const (
@@ -47,4 +61,12 @@ var (
 		ScraperPrefix+ErroredMetricPointsKey,
 		"Number of metric points that were unable to be scraped.",
 		stats.UnitDimensionless)
+	ScraperStaleScrapes = stats.Int64(
+		ScraperPrefix+StaleScrapesKey,
+		"Number of scrapes returning the same data points as the previous scrape, e.g. from a frozen source.",
+		stats.UnitDimensionless)
+	ScraperRecoveredPanics = stats.Int64(
+		ScraperPrefix+RecoveredPanicsKey,
+		"Number of panics of the scraper recovered in its scrapes.",
+		stats.UnitDimensionless)
 )
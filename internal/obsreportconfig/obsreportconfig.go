@@ -26,6 +26,12 @@ import (
 
 var (
 	globalLevel = atomic.NewInt32(int32(configtelemetry.LevelBasic))
+
+	// The aggregations of the receiver request views, from 1KiB to 64MiB for the sizes.
+	requestSizeAggregation             = view.Distribution(1<<10, 4<<10, 16<<10, 64<<10, 256<<10, 1<<20, 4<<20, 16<<20, 64<<20)
+	requestDecodeDurationAggregation   = view.Distribution(0.1, 0.5, 1, 5, 10, 50, 100, 500, 1000, 5000)
+	requestCompressionRatioAggregation = view.Distribution(1, 2, 3, 5, 10, 20, 50, 100)
+	activeConnectionsAggregation       = view.LastValue()
 )
 
 // ObsMetrics wraps OpenCensus View for Collector observability metrics
@@ -71,10 +77,54 @@ func allViews() *ObsMetrics {
 	}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	// Receiver request views.
+	requestTagKeys := []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyDataType}
+	views = append(views,
+		&view.View{
+			Name:        obsmetrics.ReceiverRequestSize.Name(),
+			Description: obsmetrics.ReceiverRequestSize.Description(),
+			TagKeys:     requestTagKeys,
+			Measure:     obsmetrics.ReceiverRequestSize,
+			Aggregation: requestSizeAggregation,
+		},
+		&view.View{
+			Name:        obsmetrics.ReceiverRequestDecodeDuration.Name(),
+			Description: obsmetrics.ReceiverRequestDecodeDuration.Description(),
+			TagKeys:     requestTagKeys,
+			Measure:     obsmetrics.ReceiverRequestDecodeDuration,
+			Aggregation: requestDecodeDurationAggregation,
+		},
+		&view.View{
+			Name:        obsmetrics.ReceiverRequestCompressionRatio.Name(),
+			Description: obsmetrics.ReceiverRequestCompressionRatio.Description(),
+			TagKeys:     requestTagKeys,
+			Measure:     obsmetrics.ReceiverRequestCompressionRatio,
+			Aggregation: requestCompressionRatioAggregation,
+		},
+		&view.View{
+			Name:        obsmetrics.ReceiverOversizedRequests.Name(),
+			Description: obsmetrics.ReceiverOversizedRequests.Description(),
+			TagKeys:     requestTagKeys,
+			Measure:     obsmetrics.ReceiverOversizedRequests,
+			Aggregation: view.Sum(),
+		},
+	)
+
+	// Receiver connection views.
+	views = append(views, &view.View{
+		Name:        obsmetrics.ReceiverActiveConnections.Name(),
+		Description: obsmetrics.ReceiverActiveConnections.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport},
+		Measure:     obsmetrics.ReceiverActiveConnections,
+		Aggregation: activeConnectionsAggregation,
+	})
+
 	// Scraper views.
 	measures = []*stats.Int64Measure{
 		obsmetrics.ScraperScrapedMetricPoints,
 		obsmetrics.ScraperErroredMetricPoints,
+		obsmetrics.ScraperStaleScrapes,
+		obsmetrics.ScraperRecoveredPanics,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyScraper}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
@@ -82,15 +132,21 @@ func allViews() *ObsMetrics {
 	// Exporter views.
 	measures = []*stats.Int64Measure{
 		obsmetrics.ExporterSentSpans,
-		obsmetrics.ExporterFailedToSendSpans,
 		obsmetrics.ExporterSentMetricPoints,
-		obsmetrics.ExporterFailedToSendMetricPoints,
 		obsmetrics.ExporterSentLogRecords,
-		obsmetrics.ExporterFailedToSendLogRecords,
+		obsmetrics.ExporterRecoveredPanics,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyExporter}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	measures = []*stats.Int64Measure{
+		obsmetrics.ExporterFailedToSendSpans,
+		obsmetrics.ExporterFailedToSendMetricPoints,
+		obsmetrics.ExporterFailedToSendLogRecords,
+	}
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyErrorType}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
 	errorNumberView := &view.View{
 		Name:        obsmetrics.ExporterPrefix + "send_failed_requests",
 		Description: "number of times exporters failed to send requests to the destination",
@@ -110,6 +166,7 @@ func allViews() *ObsMetrics {
 		obsmetrics.ProcessorAcceptedLogRecords,
 		obsmetrics.ProcessorRefusedLogRecords,
 		obsmetrics.ProcessorDroppedLogRecords,
+		obsmetrics.ProcessorRecoveredPanics,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
@@ -61,9 +61,7 @@ func fillSpanOne(span ptrace.Span) {
 	ev1.SetName("event")
 	ev1.SetDroppedAttributesCount(2)
 	span.SetDroppedEventsCount(1)
-	status := span.Status()
-	status.SetCode(ptrace.StatusCodeError)
-	status.SetMessage("status-cancelled")
+	span.SetStatusError("status-cancelled")
 }
 
 func fillSpanTwo(span ptrace.Span) {
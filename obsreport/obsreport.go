@@ -15,8 +15,14 @@
 package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 )
 
 func recordError(span trace.Span, err error) {
@@ -24,3 +30,11 @@ func recordError(span trace.Span, err error) {
 		span.SetStatus(codes.Error, err.Error())
 	}
 }
+
+// recordWithTags records the measurements as stats.RecordWithTags, and as the exemplars of their series if
+// the span of the operation is sampled, for the metrics to link to the trace of the operation.
+func recordWithTags(ctx context.Context, mutators []tag.Mutator, ms ...stats.Measurement) {
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(ctx, mutators, ms...)
+	obsreportconfig.RecordExemplars(ctx, mutators, ms...)
+}
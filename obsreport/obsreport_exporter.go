@@ -16,19 +16,41 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"errors"
+	"syscall"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
+// Error types recorded as the error_type attribute of the failed sends and of the data refused by the sending queue.
+const (
+	// ErrorTypeTimeout is the error type of sends that failed because of a timeout.
+	ErrorTypeTimeout = "timeout"
+	// ErrorTypeThrottled is the error type of sends that the destination throttled.
+	ErrorTypeThrottled = "throttled"
+	// ErrorTypeConnectionRefused is the error type of sends that failed because the destination refused
+	// the connection or was unavailable, e.g. while it restarts.
+	ErrorTypeConnectionRefused = "connection_refused"
+	// ErrorTypePermanent is the error type of sends that failed with a permanent error, see consumererror.IsPermanent.
+	ErrorTypePermanent = "permanent"
+	// ErrorTypeRetryable is the error type of sends that failed with any other error, which can be retried.
+	ErrorTypeRetryable = "retryable"
+	// ErrorTypeQueueFull is the error type of the data that could not be added to the sending queue.
+	ErrorTypeQueueFull = "queue_full"
+)
+
 // Exporter is a helper to add observability to a component.Exporter.
 type Exporter struct {
 	level          configtelemetry.Level
@@ -64,7 +86,7 @@ func (exp *Exporter) StartTracesOp(ctx context.Context) context.Context {
 // EndTracesOp completes the export operation that was started with StartTracesOp.
 func (exp *Exporter) EndTracesOp(ctx context.Context, numSpans int, err error) {
 	numSent, numFailedToSend := toNumItems(numSpans, err)
-	exp.recordMetrics(ctx, numSent, numFailedToSend, obsmetrics.ExporterSentSpans, obsmetrics.ExporterFailedToSendSpans)
+	exp.recordMetrics(ctx, numSent, numFailedToSend, err, obsmetrics.ExporterSentSpans, obsmetrics.ExporterFailedToSendSpans)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
 }
 
@@ -79,7 +101,7 @@ func (exp *Exporter) StartMetricsOp(ctx context.Context) context.Context {
 // StartMetricsOp.
 func (exp *Exporter) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
 	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	exp.recordMetrics(ctx, numSent, numFailedToSend, obsmetrics.ExporterSentMetricPoints, obsmetrics.ExporterFailedToSendMetricPoints)
+	exp.recordMetrics(ctx, numSent, numFailedToSend, err, obsmetrics.ExporterSentMetricPoints, obsmetrics.ExporterFailedToSendMetricPoints)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
 }
 
@@ -93,7 +115,7 @@ func (exp *Exporter) StartLogsOp(ctx context.Context) context.Context {
 // EndLogsOp completes the export operation that was started with StartLogsOp.
 func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	exp.recordMetrics(ctx, numSent, numFailedToSend, obsmetrics.ExporterSentLogRecords, obsmetrics.ExporterFailedToSendLogRecords)
+	exp.recordMetrics(ctx, numSent, numFailedToSend, err, obsmetrics.ExporterSentLogRecords, obsmetrics.ExporterFailedToSendLogRecords)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
 }
 
@@ -105,15 +127,15 @@ func (exp *Exporter) startOp(ctx context.Context, operationSuffix string) contex
 	return ctx
 }
 
-func (exp *Exporter) recordMetrics(ctx context.Context, numSent, numFailedToSend int64, sentMeasure, failedToSendMeasure *stats.Int64Measure) {
+func (exp *Exporter) recordMetrics(ctx context.Context, numSent, numFailedToSend int64, err error, sentMeasure, failedToSendMeasure *stats.Int64Measure) {
 	if obsreportconfig.Level() == configtelemetry.LevelNone {
 		return
 	}
-	// Ignore the error for now. This should not happen.
 	if numFailedToSend > 0 {
-		_ = stats.RecordWithTags(ctx, exp.mutators, sentMeasure.M(numSent), failedToSendMeasure.M(numFailedToSend))
+		mutators := append([]tag.Mutator{tag.Upsert(obsmetrics.TagKeyErrorType, ErrorType(err), tag.WithTTL(tag.TTLNoPropagation))}, exp.mutators...)
+		recordWithTags(ctx, mutators, sentMeasure.M(numSent), failedToSendMeasure.M(numFailedToSend))
 	} else {
-		_ = stats.RecordWithTags(ctx, exp.mutators, sentMeasure.M(numSent))
+		recordWithTags(ctx, exp.mutators, sentMeasure.M(numSent))
 	}
 }
 
@@ -125,11 +147,39 @@ func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sen
 			attribute.Int64(sentItemsKey, numSent),
 			attribute.Int64(failedToSendItemsKey, numFailedToSend),
 		)
+		if err != nil {
+			span.SetAttributes(attribute.String(obsmetrics.ErrorTypeKey, ErrorType(err)))
+		}
 		recordError(span, err)
 	}
 	span.End()
 }
 
+// ErrorType returns the error type of the error of a failed send, one of the ErrorType constants.
+// Errors can define their error type by implementing an `ErrorType() string` method, e.g. the throttling
+// errors of the exporterhelper. Otherwise, permanent errors are classified as ErrorTypePermanent, errors
+// caused by a deadline or timeout as ErrorTypeTimeout, refused connections and the gRPC Unavailable status,
+// returned by the gRPC clients failing to connect, as ErrorTypeConnectionRefused and any other error as
+// ErrorTypeRetryable.
+func ErrorType(err error) string {
+	var typedErr interface{ ErrorType() string }
+	if errors.As(err, &typedErr) {
+		return typedErr.ErrorType()
+	}
+	if consumererror.IsPermanent(err) {
+		return ErrorTypePermanent
+	}
+	var timeoutErr interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &timeoutErr) && timeoutErr.Timeout()) {
+		return ErrorTypeTimeout
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.Is(err, syscall.ECONNREFUSED) || (errors.As(err, &grpcErr) && grpcErr.GRPCStatus().Code() == codes.Unavailable) {
+		return ErrorTypeConnectionRefused
+	}
+	return ErrorTypeRetryable
+}
+
 func toNumItems(numExportedItems int, err error) (int64, int64) {
 	if err != nil {
 		return 0, int64(numExportedItems)
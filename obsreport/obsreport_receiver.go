@@ -16,6 +16,7 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -25,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
@@ -119,6 +121,57 @@ func (rec *Receiver) EndMetricsOp(
 	rec.endOp(receiverCtx, format, numReceivedPoints, err, config.MetricsDataType)
 }
 
+// RequestStats are the statistics of a request received by a receiver.
+type RequestStats struct {
+	// Size is the size in bytes of the request body, after decompression.
+	Size int
+	// CompressedSize is the size in bytes of the request body as received, 0 if it was not compressed.
+	CompressedSize int
+	// DecodeDuration is the duration of the decoding of the request body, 0 if unknown.
+	DecodeDuration time.Duration
+}
+
+// RecordRequest records the size, the compression ratio and the decoding duration of a request
+// of the data type, distinguishing the traffic of many small requests from the one of few large
+// requests.
+func (rec *Receiver) RecordRequest(ctx context.Context, dataType config.DataType, rs RequestStats) {
+	if obsreportconfig.Level() == configtelemetry.LevelNone {
+		return
+	}
+	measurements := []stats.Measurement{obsmetrics.ReceiverRequestSize.M(int64(rs.Size))}
+	if rs.CompressedSize > 0 {
+		measurements = append(measurements, obsmetrics.ReceiverRequestCompressionRatio.M(float64(rs.Size)/float64(rs.CompressedSize)))
+	}
+	if rs.DecodeDuration > 0 {
+		measurements = append(measurements, obsmetrics.ReceiverRequestDecodeDuration.M(float64(rs.DecodeDuration)/float64(time.Millisecond)))
+	}
+	mutators := make([]tag.Mutator, 0, len(rec.mutators)+1)
+	mutators = append(mutators, rec.mutators...)
+	mutators = append(mutators, tag.Upsert(obsmetrics.TagKeyDataType, string(dataType), tag.WithTTL(tag.TTLNoPropagation)))
+	recordWithTags(ctx, mutators, measurements...)
+}
+
+// RecordOversizedRequest records a request of the data type rejected because its body exceeds the
+// maximum size configured for the receiver.
+func (rec *Receiver) RecordOversizedRequest(ctx context.Context, dataType config.DataType) {
+	if obsreportconfig.Level() == configtelemetry.LevelNone {
+		return
+	}
+	mutators := make([]tag.Mutator, 0, len(rec.mutators)+1)
+	mutators = append(mutators, rec.mutators...)
+	mutators = append(mutators, tag.Upsert(obsmetrics.TagKeyDataType, string(dataType), tag.WithTTL(tag.TTLNoPropagation)))
+	recordWithTags(ctx, mutators, obsmetrics.ReceiverOversizedRequests.M(1))
+}
+
+// RecordActiveConnections records the number of connections open to the receiver, e.g. to check
+// that the connections of the clients are spread across the replicas of a gateway.
+func (rec *Receiver) RecordActiveConnections(ctx context.Context, active int64) {
+	if obsreportconfig.Level() == configtelemetry.LevelNone {
+		return
+	}
+	_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverActiveConnections.M(active))
+}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context with the created span.
 func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
@@ -144,7 +197,8 @@ func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string
 	return ctx
 }
 
-// endOp records the observability signals at the end of an operation.
+// endOp records the observability signals at the end of an operation. The items rejected by err,
+// see consumererror.RejectedItems, are counted as refused and the other ones as accepted.
 func (rec *Receiver) endOp(
 	receiverCtx context.Context,
 	format string,
@@ -152,12 +206,8 @@ func (rec *Receiver) endOp(
 	err error,
 	dataType config.DataType,
 ) {
-	numAccepted := numReceivedItems
-	numRefused := 0
-	if err != nil {
-		numAccepted = 0
-		numRefused = numReceivedItems
-	}
+	numRefused := consumererror.RejectedItems(err, numReceivedItems)
+	numAccepted := numReceivedItems - numRefused
 
 	span := trace.SpanFromContext(receiverCtx)
 
@@ -175,8 +225,9 @@ func (rec *Receiver) endOp(
 			refusedMeasure = obsmetrics.ReceiverRefusedLogRecords
 		}
 
-		stats.Record(
+		recordWithTags(
 			receiverCtx,
+			nil,
 			acceptedMeasure.M(int64(numAccepted)),
 			refusedMeasure.M(int64(numRefused)))
 	}
@@ -77,10 +77,12 @@ func (s *Scraper) EndMetricsOp(
 	err error,
 ) {
 	numErroredMetrics := 0
+	var metricErrs []scrapererror.MetricError
 	if err != nil {
 		var partialErr scrapererror.PartialScrapeError
 		if errors.As(err, &partialErr) {
 			numErroredMetrics = partialErr.Failed
+			metricErrs = scrapererror.MetricErrors(partialErr)
 		} else {
 			numErroredMetrics = numScrapedMetrics
 			numScrapedMetrics = 0
@@ -90,8 +92,9 @@ func (s *Scraper) EndMetricsOp(
 	span := trace.SpanFromContext(scraperCtx)
 
 	if obsreportconfig.Level() != configtelemetry.LevelNone {
-		stats.Record(
+		recordWithTags(
 			scraperCtx,
+			nil,
 			obsmetrics.ScraperScrapedMetricPoints.M(int64(numScrapedMetrics)),
 			obsmetrics.ScraperErroredMetricPoints.M(int64(numErroredMetrics)))
 	}
@@ -103,8 +106,49 @@ func (s *Scraper) EndMetricsOp(
 			attribute.Int64(obsmetrics.ScrapedMetricPointsKey, int64(numScrapedMetrics)),
 			attribute.Int64(obsmetrics.ErroredMetricPointsKey, int64(numErroredMetrics)),
 		)
+		recordMetricErrors(span, metricErrs)
 		recordError(span, err)
 	}
 
 	span.End()
 }
+
+// RecordStaleScrape records that the scrape operation started with StartMetricsOp returned the
+// same data points, values and timestamps, as the previous scrape of the scraper, e.g. because the
+// scraped source is frozen, as opposed to failing. It must be called before EndMetricsOp.
+func (s *Scraper) RecordStaleScrape(scraperCtx context.Context) {
+	if obsreportconfig.Level() != configtelemetry.LevelNone {
+		stats.Record(scraperCtx, obsmetrics.ScraperStaleScrapes.M(1))
+	}
+
+	span := trace.SpanFromContext(scraperCtx)
+	if span.IsRecording() {
+		span.SetAttributes(attribute.Bool(obsmetrics.StaleKey, true))
+	}
+}
+
+// RecordRecoveredPanic records that the scraper panicked in the scrape operation started with
+// StartMetricsOp, the panic being recovered. It must be called before EndMetricsOp.
+func (s *Scraper) RecordRecoveredPanic(scraperCtx context.Context) {
+	if obsreportconfig.Level() != configtelemetry.LevelNone {
+		stats.Record(scraperCtx, obsmetrics.ScraperRecoveredPanics.M(1))
+	}
+}
+
+// recordMetricErrors records the names of the metrics which failed to be scraped as span attribute,
+// and the detail of each failure as span event.
+func recordMetricErrors(span trace.Span, metricErrs []scrapererror.MetricError) {
+	if len(metricErrs) == 0 {
+		return
+	}
+	metrics := make([]string, 0, len(metricErrs))
+	for _, me := range metricErrs {
+		metrics = append(metrics, me.Metric)
+		span.AddEvent(obsmetrics.ScrapeFailedEventName, trace.WithAttributes(
+			attribute.String(obsmetrics.MetricKey, me.Metric),
+			attribute.Int64(obsmetrics.FailedKey, int64(me.Failed)),
+			attribute.String("error", me.Err.Error()),
+		))
+	}
+	span.SetAttributes(attribute.StringSlice(obsmetrics.FailedMetricsKey, metrics))
+}
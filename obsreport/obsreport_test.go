@@ -17,15 +17,23 @@ package obsreport
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
@@ -99,6 +107,82 @@ func TestReceiveTraceDataOp(t *testing.T) {
 	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, receiver, transport, int64(acceptedSpans), int64(refusedSpans)))
 }
 
+func TestReceiveTraceDataOpPartiallyRejected(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec := NewReceiver(ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 13, consumererror.NewRejected(errFake, 3))
+
+	spans := tt.SpanRecorder.Ended()
+	require.Equal(t, 1, len(spans))
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(10)})
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(3)})
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.NoError(t, obsreporttest.CheckReceiverTraces(tt, receiver, transport, 10, 3))
+}
+
+func TestReceiveRecordRequest(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec := NewReceiver(ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	rec.RecordRequest(context.Background(), config.TracesDataType, RequestStats{Size: 1000, DecodeDuration: time.Millisecond})
+	rec.RecordRequest(context.Background(), config.TracesDataType, RequestStats{Size: 4000, CompressedSize: 1000})
+	rec.RecordRequest(context.Background(), config.LogsDataType, RequestStats{Size: 10})
+
+	require.NoError(t, obsreporttest.CheckReceiverRequests(tt, receiver, transport, config.TracesDataType, 2, 5000, 1))
+	require.NoError(t, obsreporttest.CheckReceiverRequests(tt, receiver, transport, config.LogsDataType, 1, 10, 0))
+	assert.Error(t, obsreporttest.CheckReceiverRequests(tt, receiver, transport, config.MetricsDataType, 1, 10, 0))
+}
+
+func TestReceiveRecordOversizedRequest(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec := NewReceiver(ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	rec.RecordOversizedRequest(context.Background(), config.TracesDataType)
+	rec.RecordOversizedRequest(context.Background(), config.TracesDataType)
+	rec.RecordOversizedRequest(context.Background(), config.LogsDataType)
+
+	require.NoError(t, obsreporttest.CheckReceiverOversizedRequests(tt, receiver, transport, config.TracesDataType, 2))
+	require.NoError(t, obsreporttest.CheckReceiverOversizedRequests(tt, receiver, transport, config.LogsDataType, 1))
+	assert.Error(t, obsreporttest.CheckReceiverOversizedRequests(tt, receiver, transport, config.MetricsDataType, 1))
+}
+
+func TestReceiveRecordActiveConnections(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec := NewReceiver(ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	rec.RecordActiveConnections(context.Background(), 2)
+	rec.RecordActiveConnections(context.Background(), 1)
+
+	require.NoError(t, obsreporttest.CheckReceiverActiveConnections(tt, receiver, transport, 1))
+	assert.Error(t, obsreporttest.CheckReceiverActiveConnections(tt, receiver, "grpc", 1))
+}
+
 func TestReceiveLogsOp(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry()
 	require.NoError(t, err)
@@ -254,6 +338,86 @@ func TestScrapeMetricsDataOp(t *testing.T) {
 	require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiver, scraper, int64(scrapedMetricPoints), int64(erroredMetricPoints)))
 }
 
+func TestScrapeMetricsDataOpStale(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	for i := 0; i < 3; i++ {
+		scrp := NewScraper(ScraperSettings{
+			ReceiverID:             receiver,
+			Scraper:                scraper,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		ctx := scrp.StartMetricsOp(context.Background())
+		if i > 0 {
+			scrp.RecordStaleScrape(ctx)
+		}
+		scrp.EndMetricsOp(ctx, 10, nil)
+	}
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 3)
+	assert.NotContains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.StaleKey, Value: attribute.BoolValue(true)})
+	assert.Contains(t, spans[1].Attributes(), attribute.KeyValue{Key: obsmetrics.StaleKey, Value: attribute.BoolValue(true)})
+	require.NoError(t, obsreporttest.CheckScraperStaleScrapes(tt, receiver, scraper, 2))
+}
+
+func TestScrapeMetricsDataOpRecoveredPanic(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	scrp := NewScraper(ScraperSettings{
+		ReceiverID:             receiver,
+		Scraper:                scraper,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	ctx := scrp.StartMetricsOp(context.Background())
+	scrp.RecordRecoveredPanic(ctx)
+	scrp.EndMetricsOp(ctx, 0, errFake)
+
+	require.NoError(t, obsreporttest.CheckScraperRecoveredPanics(tt, receiver, scraper, 1))
+}
+
+func TestScrapeMetricsDataOpWithMetricErrors(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	var errs scrapererror.ScrapeErrors
+	errs.AddPartialMetric("system.cpu.time", 1, errors.New("endpoint a unavailable"))
+	errs.AddPartialMetric("system.memory.usage", 1, errors.New("permission denied"))
+	errs.AddPartialMetric("system.cpu.time", 1, errors.New("endpoint b unavailable"))
+
+	scrp := NewScraper(ScraperSettings{
+		ReceiverID:             receiver,
+		Scraper:                scraper,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	ctx := scrp.StartMetricsOp(context.Background())
+	scrp.EndMetricsOp(ctx, 10, errs.Combine())
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 1)
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(3)})
+	require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.FailedMetricsKey, Value: attribute.StringSliceValue([]string{"system.cpu.time", "system.memory.usage"})})
+
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, obsmetrics.ScrapeFailedEventName, events[0].Name)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String(obsmetrics.MetricKey, "system.cpu.time"),
+		attribute.Int64(obsmetrics.FailedKey, 2),
+		attribute.String("error", "endpoint a unavailable; endpoint b unavailable"),
+	}, events[0].Attributes)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String(obsmetrics.MetricKey, "system.memory.usage"),
+		attribute.Int64(obsmetrics.FailedKey, 1),
+		attribute.String("error", "permission denied"),
+	}, events[1].Attributes)
+}
+
 func TestExportTraceDataOp(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry()
 	require.NoError(t, err)
@@ -294,6 +458,7 @@ func TestExportTraceDataOp(t *testing.T) {
 			failedToSendSpans += params[i].items
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(0)})
 			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErrorTypeKey, Value: attribute.StringValue(ErrorTypeRetryable)})
 			assert.Equal(t, codes.Error, span.Status().Code)
 			assert.Equal(t, params[i].err.Error(), span.Status().Description)
 		default:
@@ -302,6 +467,60 @@ func TestExportTraceDataOp(t *testing.T) {
 	}
 
 	require.NoError(t, obsreporttest.CheckExporterTraces(tt, exporter, int64(sentSpans), int64(failedToSendSpans)))
+	require.NoError(t, obsreporttest.CheckExporterSendFailed(tt, exporter, config.TracesDataType, ErrorTypeRetryable, int64(failedToSendSpans)))
+}
+
+func TestExportErrorTypes(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep := NewExporter(ExporterSettings{
+		Level:                  configtelemetry.LevelNormal,
+		ExporterID:             exporter,
+		ExporterCreateSettings: tt.ToExporterCreateSettings(),
+	})
+
+	obsrep.EndLogsOp(obsrep.StartLogsOp(context.Background()), 3, consumererror.NewPermanent(errFake))
+	obsrep.EndLogsOp(obsrep.StartLogsOp(context.Background()), 5, fmt.Errorf("push failed: %w", context.DeadlineExceeded))
+	obsrep.EndLogsOp(obsrep.StartLogsOp(context.Background()), 7, errFake)
+
+	require.NoError(t, obsreporttest.CheckExporterLogs(tt, exporter, 0, 15))
+	require.NoError(t, obsreporttest.CheckExporterSendFailed(tt, exporter, config.LogsDataType, ErrorTypePermanent, 3))
+	require.NoError(t, obsreporttest.CheckExporterSendFailed(tt, exporter, config.LogsDataType, ErrorTypeTimeout, 5))
+	require.NoError(t, obsreporttest.CheckExporterSendFailed(tt, exporter, config.LogsDataType, ErrorTypeRetryable, 7))
+}
+
+type typedError struct{}
+
+func (typedError) Error() string     { return "typed" }
+func (typedError) ErrorType() string { return ErrorTypeThrottled }
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+func TestErrorType(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "typed", err: fmt.Errorf("wrapped: %w", typedError{}), want: ErrorTypeThrottled},
+		{name: "permanent", err: consumererror.NewPermanent(errFake), want: ErrorTypePermanent},
+		{name: "deadline", err: context.DeadlineExceeded, want: ErrorTypeTimeout},
+		{name: "timeout", err: fmt.Errorf("post: %w", timeoutError{}), want: ErrorTypeTimeout},
+		{name: "connection_refused", err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, want: ErrorTypeConnectionRefused},
+		{name: "grpc_unavailable", err: fmt.Errorf("export: %w", status.Error(grpccodes.Unavailable, "connection refused")), want: ErrorTypeConnectionRefused},
+		{name: "grpc_other", err: status.Error(grpccodes.ResourceExhausted, "exhausted"), want: ErrorTypeRetryable},
+		{name: "other", err: errFake, want: ErrorTypeRetryable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorType(tt.err))
+		})
+	}
 }
 
 func TestExportMetricsOp(t *testing.T) {
@@ -17,6 +17,7 @@ package obsreporttest // import "go.opentelemetry.io/collector/obsreport/obsrepo
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 
@@ -44,7 +45,9 @@ var (
 	scraperTag, _   = tag.NewKey("scraper")
 	transportTag, _ = tag.NewKey("transport")
 	exporterTag, _  = tag.NewKey("exporter")
+	errorTypeTag, _ = tag.NewKey("error_type")
 	processorTag, _ = tag.NewKey("processor")
+	dataTypeTag, _  = tag.NewKey("data_type")
 )
 
 type TestTelemetry struct {
@@ -108,7 +111,7 @@ func CheckExporterTraces(_ TestTelemetry, exporter config.ComponentID, sentSpans
 	if sendFailedSpans > 0 {
 		return multierr.Combine(
 			checkValueForView(exporterTags, sentSpans, "exporter/sent_spans"),
-			checkValueForView(exporterTags, sendFailedSpans, "exporter/send_failed_spans"))
+			checkSumForView(exporterTags, sendFailedSpans, "exporter/send_failed_spans"))
 	}
 	return checkValueForView(exporterTags, sentSpans, "exporter/sent_spans")
 }
@@ -120,7 +123,7 @@ func CheckExporterMetrics(_ TestTelemetry, exporter config.ComponentID, sentMetr
 	if sendFailedMetricsPoints > 0 {
 		return multierr.Combine(
 			checkValueForView(exporterTags, sentMetricsPoints, "exporter/sent_metric_points"),
-			checkValueForView(exporterTags, sendFailedMetricsPoints, "exporter/send_failed_metric_points"))
+			checkSumForView(exporterTags, sendFailedMetricsPoints, "exporter/send_failed_metric_points"))
 	}
 	return checkValueForView(exporterTags, sentMetricsPoints, "exporter/sent_metric_points")
 }
@@ -132,11 +135,27 @@ func CheckExporterLogs(_ TestTelemetry, exporter config.ComponentID, sentLogReco
 	if sendFailedLogRecords > 0 {
 		return multierr.Combine(
 			checkValueForView(exporterTags, sentLogRecords, "exporter/sent_log_records"),
-			checkValueForView(exporterTags, sendFailedLogRecords, "exporter/send_failed_log_records"))
+			checkSumForView(exporterTags, sendFailedLogRecords, "exporter/send_failed_log_records"))
 	}
 	return checkValueForView(exporterTags, sentLogRecords, "exporter/sent_log_records")
 }
 
+// CheckExporterSendFailed checks that for the current exported values the number of items of the given data type the
+// exporter failed to send with the given error type, see obsreport.ErrorType, match the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckExporterSendFailed(_ TestTelemetry, exporter config.ComponentID, dataType config.DataType, errorType string, sendFailed int64) error {
+	tags := append(tagsForExporterView(exporter), tag.Tag{Key: errorTypeTag, Value: errorType})
+	switch dataType {
+	case config.TracesDataType:
+		return checkValueForView(tags, sendFailed, "exporter/send_failed_spans")
+	case config.MetricsDataType:
+		return checkValueForView(tags, sendFailed, "exporter/send_failed_metric_points")
+	case config.LogsDataType:
+		return checkValueForView(tags, sendFailed, "exporter/send_failed_log_records")
+	}
+	return fmt.Errorf("unsupported data type %q", dataType)
+}
+
 // CheckProcessorTraces checks that for the current exported values for trace exporter metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func CheckProcessorTraces(_ TestTelemetry, processor config.ComponentID, acceptedSpans, refusedSpans, droppedSpans int64) error {
@@ -194,6 +213,33 @@ func CheckReceiverMetrics(_ TestTelemetry, receiver config.ComponentID, protocol
 		checkValueForView(receiverTags, droppedMetricPoints, "receiver/refused_metric_points"))
 }
 
+// CheckReceiverRequests checks that for the current exported values of the request metrics of the receiver for
+// the data type, the number of requests, their total size and the number of compressed requests match the given values.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckReceiverRequests(_ TestTelemetry, receiver config.ComponentID, protocol string, dataType config.DataType, requests, totalSize, compressedRequests int64) error {
+	requestTags := append(tagsForReceiverView(receiver, protocol), tag.Tag{Key: dataTypeTag, Value: string(dataType)})
+	err := checkDistributionForView(requestTags, requests, float64(totalSize), "receiver/request_size")
+	if compressedRequests == 0 {
+		return err
+	}
+	return multierr.Append(err, checkDistributionForView(requestTags, compressedRequests, -1, "receiver/request_compression_ratio"))
+}
+
+// CheckReceiverOversizedRequests checks that the current exported value of the oversized requests metric of the
+// receiver for the data type matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckReceiverOversizedRequests(_ TestTelemetry, receiver config.ComponentID, protocol string, dataType config.DataType, oversizedRequests int64) error {
+	requestTags := append(tagsForReceiverView(receiver, protocol), tag.Tag{Key: dataTypeTag, Value: string(dataType)})
+	return checkValueForView(requestTags, oversizedRequests, "receiver/oversized_requests")
+}
+
+// CheckReceiverActiveConnections checks that the current exported value of the active connections metric of the
+// receiver for the transport matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckReceiverActiveConnections(_ TestTelemetry, receiver config.ComponentID, protocol string, activeConnections int64) error {
+	return checkLastValueForView(tagsForReceiverView(receiver, protocol), activeConnections, "receiver/active_connections")
+}
+
 // CheckScraperMetrics checks that for the current exported values for metrics scraper metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func CheckScraperMetrics(_ TestTelemetry, receiver config.ComponentID, scraper config.ComponentID, scrapedMetricPoints, erroredMetricPoints int64) error {
@@ -203,6 +249,34 @@ func CheckScraperMetrics(_ TestTelemetry, receiver config.ComponentID, scraper c
 		checkValueForView(scraperTags, erroredMetricPoints, "scraper/errored_metric_points"))
 }
 
+// CheckScraperStaleScrapes checks that for the current exported value of the stale scrapes metric of the scraper
+// matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckScraperStaleScrapes(_ TestTelemetry, receiver config.ComponentID, scraper config.ComponentID, staleScrapes int64) error {
+	return checkValueForView(tagsForScraperView(receiver, scraper), staleScrapes, "scraper/stale_scrapes")
+}
+
+// CheckScraperRecoveredPanics checks that for the current exported value of the recovered panics metric of the
+// scraper matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckScraperRecoveredPanics(_ TestTelemetry, receiver config.ComponentID, scraper config.ComponentID, recoveredPanics int64) error {
+	return checkValueForView(tagsForScraperView(receiver, scraper), recoveredPanics, "scraper/recovered_panics")
+}
+
+// CheckProcessorRecoveredPanics checks that for the current exported value of the recovered panics metric of the
+// processor matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckProcessorRecoveredPanics(_ TestTelemetry, processor config.ComponentID, recoveredPanics int64) error {
+	return checkValueForView(tagsForProcessorView(processor), recoveredPanics, "processor/recovered_panics")
+}
+
+// CheckExporterRecoveredPanics checks that for the current exported value of the recovered panics metric of the
+// exporter matches the given value.
+// When this function is called it is required to also call SetupTelemetry as first thing.
+func CheckExporterRecoveredPanics(_ TestTelemetry, exporter config.ComponentID, recoveredPanics int64) error {
+	return checkValueForView(tagsForExporterView(exporter), recoveredPanics, "exporter/recovered_panics")
+}
+
 // checkValueForView checks that for the current exported value in the view with the given name
 // for {LegacyTagKeyReceiver: receiverName} is equal to "value".
 func checkValueForView(wantTags []tag.Tag, value int64, vName string) error {
@@ -228,6 +302,97 @@ func checkValueForView(wantTags []tag.Tag, value int64, vName string) error {
 	return fmt.Errorf("[%s]: could not find tags, wantTags: %s in rows %v", vName, wantTags, rows)
 }
 
+// checkLastValueForView checks that the last value recorded in the view with the given name for the given tags
+// is equal to "value".
+func checkLastValueForView(wantTags []tag.Tag, value int64, vName string) error {
+	sortTags(wantTags)
+
+	rows, err := view.RetrieveData(vName)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		sortTags(row.Tags)
+		if reflect.DeepEqual(wantTags, row.Tags) {
+			lastValue := row.Data.(*view.LastValueData)
+			if float64(value) != lastValue.Value {
+				return fmt.Errorf("[%s]: values did no match, wanted %f got %f", vName, float64(value), lastValue.Value)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("[%s]: could not find tags, wantTags: %s in rows %v", vName, wantTags, rows)
+}
+
+// checkDistributionForView checks that for the current exported distribution in the view with the given name
+// for the given tags, the count and, if not negative, the sum of the values are equal to the given ones.
+func checkDistributionForView(wantTags []tag.Tag, count int64, sum float64, vName string) error {
+	sortTags(wantTags)
+
+	rows, err := view.RetrieveData(vName)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		sortTags(row.Tags)
+		if reflect.DeepEqual(wantTags, row.Tags) {
+			dist := row.Data.(*view.DistributionData)
+			if count != dist.Count {
+				return fmt.Errorf("[%s]: counts did no match, wanted %d got %d", vName, count, dist.Count)
+			}
+			if gotSum := dist.Mean * float64(dist.Count); sum >= 0 && math.Abs(sum-gotSum) > 1e-6 {
+				return fmt.Errorf("[%s]: sums did no match, wanted %f got %f", vName, sum, gotSum)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("[%s]: could not find tags, wantTags: %s in rows %v", vName, wantTags, rows)
+}
+
+// checkSumForView checks that for the current exported values in the view with the given name
+// the sum of the values of the rows having all the given tags is equal to "value".
+func checkSumForView(wantTags []tag.Tag, value int64, vName string) error {
+	rows, err := view.RetrieveData(vName)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	sum := float64(0)
+	for _, row := range rows {
+		if hasTags(row.Tags, wantTags) {
+			found = true
+			sum += row.Data.(*view.SumData).Value
+		}
+	}
+	if !found {
+		return fmt.Errorf("[%s]: could not find tags, wantTags: %s in rows %v", vName, wantTags, rows)
+	}
+	if float64(value) != sum {
+		return fmt.Errorf("[%s]: values did no match, wanted %f got %f", vName, float64(value), sum)
+	}
+	return nil
+}
+
+// hasTags returns whether all the wantTags are in the tags.
+func hasTags(tags []tag.Tag, wantTags []tag.Tag) bool {
+	for _, want := range wantTags {
+		found := false
+		for _, t := range tags {
+			if t == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // tagsForReceiverView returns the tags that are needed for the receiver views.
 func tagsForReceiverView(receiver config.ComponentID, transport string) []tag.Tag {
 	tags := make([]tag.Tag, 0, 2)
@@ -21,7 +21,13 @@ import (
 
 const accessorSliceTemplate = `// ${fieldName} returns the ${originFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return new${returnType}(&(*ms.orig).${originFieldName})
+	return new${returnType}(&(*ms.orig).${originFieldName}, ms.state)
+}
+
+// Has${fieldName} returns true if the ${originFieldName} associated with this ${structName} is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms ${structName}) Has${fieldName}() bool {
+	return len((*ms.orig).${originFieldName}) > 0
 }`
 
 const accessorsSliceTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -30,11 +36,18 @@ const accessorsSliceTestTemplate = `func Test${structName}_${fieldName}(t *testi
 	fillTest${returnType}(ms.${fieldName}())
 	testVal${fieldName} := generateTest${returnType}()
 	assert.EqualValues(t, testVal${fieldName}, ms.${fieldName}())
+}
+
+func Test${structName}_Has${fieldName}(t *testing.T) {
+	ms := New${structName}()
+	assert.False(t, ms.Has${fieldName}())
+	fillTest${returnType}(ms.${fieldName}())
+	assert.True(t, ms.Has${fieldName}())
 }`
 
 const accessorsMessageValueTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) ${fieldName}() ${returnType} {
-	return new${returnType}(&(*ms.orig).${originFieldName})
+	return new${returnType}(&(*ms.orig).${originFieldName}, ms.state)
 }`
 
 const accessorsMessageValueTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -50,6 +63,7 @@ ${extraComment}func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 ${extraComment}func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.AssertMutable()
 	(*ms.orig).${originFieldName} = v
 }`
 
@@ -68,6 +82,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.AssertMutable()
 	(*ms.orig).${originFieldName} = v.value
 }`
 
@@ -92,7 +107,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 	if !ok {
 		return ${returnType}{}
 	}
-	return new${returnType}(v.${originFieldName})
+	return new${returnType}(v.${originFieldName}, ms.state)
 }`
 
 const accessorsOneOfMessageTestTemplate = `func Test${structName}_${fieldName}(t *testing.T) {
@@ -122,6 +137,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.AssertMutable()
 	(*ms.orig).${originOneOfFieldName} = &${originStructType}{
 		${originFieldName}: v,
 	}
@@ -133,6 +149,8 @@ const accessorsPrimitiveTestTemplate = `func Test${structName}_${fieldName}(t *t
 	testVal${fieldName} := ${testValue}
 	ms.Set${fieldName}(testVal${fieldName})
 	assert.EqualValues(t, testVal${fieldName}, ms.${fieldName}())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { new${structName}(&${originStructName}{}, &sharedState).Set${fieldName}(testVal${fieldName}) })
 }`
 
 const accessorsPrimitiveTypedTemplate = `// ${fieldName} returns the ${lowerFieldName} associated with this ${structName}.
@@ -142,6 +160,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.AssertMutable()
 	(*ms.orig).${originFieldName} = ${rawType}(v)
 }`
 
@@ -152,6 +171,7 @@ func (ms ${structName}) ${fieldName}() ${returnType} {
 
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.AssertMutable()
 	(*ms.orig).${originFieldName} = v.orig
 }`
 
@@ -166,6 +186,7 @@ func (ms ${structName}) Has${fieldName}() bool {
 }
 // Set${fieldName} replaces the ${lowerFieldName} associated with this ${structName}.
 func (ms ${structName}) Set${fieldName}(v ${returnType}) {
+	ms.state.AssertMutable()
 	(*ms.orig).${fieldName}_ = &${originStructType}{${fieldName}: v}
 }`
 
@@ -315,6 +336,8 @@ func (pf *primitiveField) generateAccessorsTest(ms baseStruct, sb *strings.Build
 		switch name {
 		case "structName":
 			return ms.getName()
+		case "originStructName":
+			return ms.(*messageValueStruct).originFullName
 		case "defaultVal":
 			return pf.defaultVal
 		case "fieldName":
@@ -375,6 +398,8 @@ func (ptf *primitiveTypedField) generateAccessorsTest(ms baseStruct, sb *strings
 		switch name {
 		case "structName":
 			return ms.getName()
+		case "originStructName":
+			return ms.(*messageValueStruct).originFullName
 		case "defaultVal":
 			return ptf.defaultVal
 		case "fieldName":
@@ -431,6 +456,8 @@ func (ptf *primitiveStructField) generateAccessorsTest(ms baseStruct, sb *string
 		switch name {
 		case "structName":
 			return ms.getName()
+		case "originStructName":
+			return ms.(*messageValueStruct).originFullName
 		case "defaultVal":
 			return ptf.defaultVal
 		case "fieldName":
@@ -487,6 +514,8 @@ func (psf *primitiveSliceField) generateAccessorsTest(ms baseStruct, sb *strings
 		switch name {
 		case "structName":
 			return ms.getName()
+		case "originStructName":
+			return ms.(*messageValueStruct).originFullName
 		case "defaultVal":
 			return psf.defaultVal
 		case "fieldName":
@@ -662,6 +691,8 @@ func (opv *oneOfPrimitiveValue) generateTests(ms baseStruct, _ *oneOfField, sb *
 		switch name {
 		case "structName":
 			return ms.getName()
+		case "originStructName":
+			return ms.(*messageValueStruct).originFullName
 		case "defaultVal":
 			return opv.defaultVal
 		case "fieldName":
@@ -812,6 +843,8 @@ func (opv *optionalPrimitiveValue) generateAccessorsTest(ms baseStruct, sb *stri
 		switch name {
 		case "structName":
 			return ms.getName()
+		case "originStructName":
+			return ms.(*messageValueStruct).originFullName
 		case "defaultVal":
 			return opv.defaultVal
 		case "fieldName":
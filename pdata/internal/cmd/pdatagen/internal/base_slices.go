@@ -23,6 +23,8 @@ const commonSliceTemplate = `
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ${structName}) MoveAndAppendTo(dest ${structName}) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -35,6 +37,7 @@ func (es ${structName}) MoveAndAppendTo(dest ${structName}) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ${structName}) RemoveIf(f func(${elementName}) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -95,6 +98,17 @@ func Test${structName}_RemoveIf(t *testing.T) {
 		return pos%3 == 0
 	})
 	assert.Equal(t, 5, filtered.Len())
+}
+
+func Test${structName}_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := new${structName}(&[]${elementOrigin}{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(New${structName}()) })
+	assert.Panics(t, func() { New${structName}().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ${elementName}) bool { return true }) })
+	assert.Panics(t, func() { New${structName}().CopyTo(es) })
 }`
 
 const commonSliceGenerateTest = `func generateTest${structName}() ${structName} {
@@ -134,18 +148,20 @@ const slicePtrTemplate = `// ${structName} logically represents a slice of ${ele
 type ${structName} struct {
 	// orig points to the slice ${originName} field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*${originName}
+	orig  *[]*${originName}
+	state *State
 }
 
-func new${structName}(orig *[]*${originName}) ${structName} {
-	return ${structName}{orig}
+func new${structName}(orig *[]*${originName}, state *State) ${structName} {
+	return ${structName}{orig: orig, state: state}
 }
 
 // New${structName} creates a ${structName} with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func New${structName}() ${structName} {
 	orig := []*${originName}(nil)
-	return ${structName}{&orig}
+	state := StateMutable
+	return new${structName}(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -163,17 +179,18 @@ func (es ${structName}) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ${structName}) At(ix int) ${elementName} {
-	return new${elementName}((*es.orig)[ix])
+	return new${elementName}((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ${structName}) CopyTo(dest ${structName}) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			new${elementName}((*es.orig)[i]).CopyTo(new${elementName}((*dest.orig)[i]))
+			new${elementName}((*es.orig)[i], es.state).CopyTo(new${elementName}((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -181,7 +198,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 	wrappers := make([]*${originName}, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		new${elementName}((*es.orig)[i]).CopyTo(new${elementName}(wrappers[i]))
+		new${elementName}((*es.orig)[i], es.state).CopyTo(new${elementName}(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -198,6 +215,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 //       // Here should set all the values for e.
 //   }
 func (es ${structName}) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -211,6 +229,7 @@ func (es ${structName}) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ${elementName}.
 // It returns the newly added ${elementName}.
 func (es ${structName}) AppendEmpty() ${elementName} {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &${originName}{})
 	return es.At(es.Len() - 1)
 }
@@ -225,6 +244,7 @@ func (es ${structName}) AppendEmpty() ${elementName} {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ${structName}) Sort(less func(a, b ${elementName}) bool) ${structName} {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -233,11 +253,12 @@ func (es ${structName}) Sort(less func(a, b ${elementName}) bool) ${structName}
 const slicePtrTestTemplate = `func Test${structName}(t *testing.T) {
 	es := New${structName}()
 	assert.EqualValues(t, 0, es.Len())
-	es = new${structName}(&[]*${originName}{})
+	state := StateMutable
+	es = new${structName}(&[]*${originName}{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := new${elementName}(&${originName}{})
+	emptyVal := new${elementName}(&${originName}{}, &state)
 	testVal := generateTest${elementName}()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -307,18 +328,20 @@ const sliceValueTemplate = `// ${structName} logically represents a slice of ${e
 type ${structName} struct {
 	// orig points to the slice ${originName} field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]${originName}
+	orig  *[]${originName}
+	state *State
 }
 
-func new${structName}(orig *[]${originName}) ${structName} {
-	return ${structName}{orig}
+func new${structName}(orig *[]${originName}, state *State) ${structName} {
+	return ${structName}{orig: orig, state: state}
 }
 
 // New${structName} creates a ${structName} with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func New${structName}() ${structName} {
 	orig := []${originName}(nil)
-	return ${structName}{&orig}
+	state := StateMutable
+	return new${structName}(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -336,11 +359,12 @@ func (es ${structName}) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ${structName}) At(ix int) ${elementName} {
-	return new${elementName}(&(*es.orig)[ix])
+	return new${elementName}(&(*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ${structName}) CopyTo(dest ${structName}) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
@@ -350,7 +374,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 	}
 
 	for i := range *es.orig {
-		new${elementName}(&(*es.orig)[i]).CopyTo(new${elementName}(&(*dest.orig)[i]))
+		new${elementName}(&(*es.orig)[i], es.state).CopyTo(new${elementName}(&(*dest.orig)[i], dest.state))
 	}
 }
 
@@ -366,6 +390,7 @@ func (es ${structName}) CopyTo(dest ${structName}) {
 //       // Here should set all the values for e.
 //   }
 func (es ${structName}) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -379,6 +404,7 @@ func (es ${structName}) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ${elementName}.
 // It returns the newly added ${elementName}.
 func (es ${structName}) AppendEmpty() ${elementName} {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, ${originName}{})
 	return es.At(es.Len() - 1)
 }`
@@ -386,11 +412,12 @@ func (es ${structName}) AppendEmpty() ${elementName} {
 const sliceValueTestTemplate = `func Test${structName}(t *testing.T) {
 	es := New${structName}()
 	assert.EqualValues(t, 0, es.Len())
-	es = new${structName}(&[]${originName}{})
+	state := StateMutable
+	es = new${structName}(&[]${originName}{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := new${elementName}(&${originName}{})
+	emptyVal := new${elementName}(&${originName}{}, &state)
 	testVal := generateTest${elementName}()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -478,6 +505,8 @@ func (ss *sliceOfPtrs) templateFields() func(name string) string {
 			return ss.element.structName
 		case "originName":
 			return ss.element.originFullName
+		case "elementOrigin":
+			return "*" + ss.element.originFullName
 		default:
 			panic(name)
 		}
@@ -533,6 +562,8 @@ func (ss *sliceOfValues) templateFields() func(name string) string {
 			return ss.element.structName
 		case "originName":
 			return ss.element.originFullName
+		case "elementOrigin":
+			return ss.element.originFullName
 		default:
 			panic(name)
 		}
@@ -27,11 +27,12 @@ const messageValueTemplate = `${description}
 // Must use New${structName} function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ${structName} struct {
-	orig *${originName}
+	orig  *${originName}
+	state *State
 }
 
-func new${structName}(orig *${originName}) ${structName} {
-	return ${structName}{orig: orig}
+func new${structName}(orig *${originName}, state *State) ${structName} {
+	return ${structName}{orig: orig, state: state}
 }
 
 // New${structName} creates a new empty ${structName}.
@@ -39,18 +40,22 @@ func new${structName}(orig *${originName}) ${structName} {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func New${structName}() ${structName} {
-	return new${structName}(&${originName}{})
+	state := StateMutable
+	return new${structName}(&${originName}{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ${structName}) MoveTo(dest ${structName}) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = ${originName}{}
 }`
 
 const messageValueCopyToHeaderTemplate = `// CopyTo copies all properties from the current struct to the dest.
-func (ms ${structName}) CopyTo(dest ${structName}) {`
+func (ms ${structName}) CopyTo(dest ${structName}) {
+	dest.state.AssertMutable()`
 
 const messageValueCopyToFooterTemplate = `}`
 
@@ -61,6 +66,9 @@ func Test${structName}_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.EqualValues(t, New${structName}(), ms)
 	assert.EqualValues(t, generateTest${structName}(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(new${structName}(&${originName}{}, &sharedState)) })
+	assert.Panics(t, func() { new${structName}(&${originName}{}, &sharedState).MoveTo(dest) })
 }
 
 func Test${structName}_CopyTo(t *testing.T) {
@@ -71,6 +79,8 @@ func Test${structName}_CopyTo(t *testing.T) {
 	orig = generateTest${structName}()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(new${structName}(&${originName}{}, &sharedState)) })
 }`
 
 const messageValueGenerateTestTemplate = `func generateTest${structName}() ${structName} {
@@ -164,6 +174,8 @@ func (ms *messageValueStruct) generateTests(sb *strings.Builder) {
 		switch name {
 		case "structName":
 			return ms.structName
+		case "originName":
+			return ms.originFullName
 		default:
 			panic(name)
 		}
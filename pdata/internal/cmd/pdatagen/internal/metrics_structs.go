@@ -382,6 +382,13 @@ var exponentialHistogramDataPoint = &messageValueStruct{
 			defaultVal:       "float64(0.0)",
 			testVal:          "float64(182.55)",
 		},
+		&primitiveField{
+			fieldName:       "ZeroThreshold",
+			originFieldName: "ZeroThreshold",
+			returnType:      "float64",
+			defaultVal:      "float64(0.0)",
+			testVal:         "float64(0.001)",
+		},
 	},
 }
 
@@ -140,6 +140,7 @@ var span = &messageValueStruct{
 			originFieldName: "Status",
 			returnMessage:   spanStatus,
 		},
+		spanFlagsField,
 	},
 }
 
@@ -178,6 +179,7 @@ var spanLink = &messageValueStruct{
 		traceStateField,
 		attributes,
 		droppedAttributesCount,
+		spanFlagsField,
 	},
 }
 
@@ -238,6 +240,15 @@ var traceStateField = &primitiveTypedField{
 	testVal:         `TraceState("congo=congos")`,
 }
 
+var spanFlagsField = &primitiveTypedField{
+	fieldName:       "Flags",
+	originFieldName: "Flags",
+	returnType:      "SpanFlags",
+	rawType:         "uint32",
+	defaultVal:      "SpanFlagsNone",
+	testVal:         "SpanFlags(0x301)",
+}
+
 var droppedAttributesCount = &primitiveField{
 	fieldName:       "DroppedAttributesCount",
 	originFieldName: "DroppedAttributesCount",
@@ -83,46 +83,52 @@ func (avt ValueType) String() string {
 // Important: zero-initialized instance is not valid for use. All Value functions below must
 // be called only on instances that are created via NewValue+ functions.
 type Value struct {
-	orig *otlpcommon.AnyValue
+	orig  *otlpcommon.AnyValue
+	state *State
 }
 
-func newValue(orig *otlpcommon.AnyValue) Value {
-	return Value{orig}
+func newValue(orig *otlpcommon.AnyValue, state *State) Value {
+	return Value{orig: orig, state: state}
+}
+
+func newMutableValue(orig *otlpcommon.AnyValue) Value {
+	state := StateMutable
+	return newValue(orig, &state)
 }
 
 // NewValueEmpty creates a new Value with an empty value.
 func NewValueEmpty() Value {
-	return Value{orig: &otlpcommon.AnyValue{}}
+	return newMutableValue(&otlpcommon.AnyValue{})
 }
 
 // NewValueString creates a new Value with the given string value.
 func NewValueString(v string) Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: v}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: v}})
 }
 
 // NewValueInt creates a new Value with the given int64 value.
 func NewValueInt(v int64) Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: v}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_IntValue{IntValue: v}})
 }
 
 // NewValueDouble creates a new Value with the given float64 value.
 func NewValueDouble(v float64) Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_DoubleValue{DoubleValue: v}})
 }
 
 // NewValueBool creates a new Value with the given bool value.
 func NewValueBool(v bool) Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: v}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BoolValue{BoolValue: v}})
 }
 
 // NewValueMap creates a new Value of map type.
 func NewValueMap() Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{}}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: &otlpcommon.KeyValueList{}}})
 }
 
 // NewValueSlice creates a new Value of array type.
 func NewValueSlice() Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{}}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{}}})
 }
 
 // NewValueMBytes creates a new Value with the given []byte value.
@@ -130,15 +136,30 @@ func NewValueSlice() Value {
 // across multiple attributes is forbidden.
 // Deprecated: [0.54.0] Use NewValueBytes instead.
 func NewValueMBytes(v []byte) Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: v}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: v}})
 }
 
 // NewValueBytes creates a new Value with the given ImmutableByteSlice value.
 func NewValueBytes(v ImmutableByteSlice) Value {
-	return Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: v.value}}}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_BytesValue{BytesValue: v.value}})
+}
+
+// NewValueBytesSlice creates a new Value of array type with a bytes value for each of the given []byte values.
+func NewValueBytesSlice(v [][]byte) Value {
+	origs := make([]otlpcommon.AnyValue, len(v))
+	for i, bv := range v {
+		NewValueBytes(NewImmutableByteSlice(bv)).copyTo(&origs[i])
+	}
+	return newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: &otlpcommon.ArrayValue{Values: origs}}})
 }
 
-func newValueFromRaw(iv interface{}) Value {
+// NewValueFromRaw creates a new Value from the given raw value, the inverse of Value.AsRaw:
+// NewValueFromRaw(v.AsRaw()) is equal to v for every Value. In addition to the types returned by
+// Value.AsRaw, the other Go integer and float types, the typed slices and maps of these types, and
+// the pdata ImmutableByteSlice, Value, Map and Slice types are converted to the Value of the same type.
+// The unsigned integers greater than math.MaxInt64 overflow, and the values of other types are
+// converted to a string describing their type.
+func NewValueFromRaw(iv interface{}) Value {
 	switch tv := iv.(type) {
 	case nil:
 		return NewValueEmpty()
@@ -172,6 +193,8 @@ func newValueFromRaw(iv interface{}) Value {
 		return NewValueBool(tv)
 	case []byte:
 		return NewValueBytes(NewImmutableByteSlice(tv))
+	case ImmutableByteSlice:
+		return NewValueBytes(tv)
 	case map[string]interface{}:
 		mv := NewValueMap()
 		NewMapFromRaw(tv).CopyTo(mv.MapVal())
@@ -180,6 +203,68 @@ func newValueFromRaw(iv interface{}) Value {
 		av := NewValueSlice()
 		NewSliceFromRaw(tv).CopyTo(av.SliceVal())
 		return av
+	case Value:
+		v := NewValueEmpty()
+		tv.CopyTo(v)
+		return v
+	case Map:
+		mv := NewValueMap()
+		tv.CopyTo(mv.MapVal())
+		return mv
+	case Slice:
+		av := NewValueSlice()
+		tv.CopyTo(av.SliceVal())
+		return av
+	case [][]byte:
+		return NewValueBytesSlice(tv)
+	case []string:
+		av := NewValueSlice()
+		sv := av.SliceVal()
+		sv.EnsureCapacity(len(tv))
+		for _, e := range tv {
+			sv.AppendEmpty().SetStringVal(e)
+		}
+		return av
+	case []int64:
+		av := NewValueSlice()
+		sv := av.SliceVal()
+		sv.EnsureCapacity(len(tv))
+		for _, e := range tv {
+			sv.AppendEmpty().SetIntVal(e)
+		}
+		return av
+	case []int:
+		av := NewValueSlice()
+		sv := av.SliceVal()
+		sv.EnsureCapacity(len(tv))
+		for _, e := range tv {
+			sv.AppendEmpty().SetIntVal(int64(e))
+		}
+		return av
+	case []float64:
+		av := NewValueSlice()
+		sv := av.SliceVal()
+		sv.EnsureCapacity(len(tv))
+		for _, e := range tv {
+			sv.AppendEmpty().SetDoubleVal(e)
+		}
+		return av
+	case []bool:
+		av := NewValueSlice()
+		sv := av.SliceVal()
+		sv.EnsureCapacity(len(tv))
+		for _, e := range tv {
+			sv.AppendEmpty().SetBoolVal(e)
+		}
+		return av
+	case map[string]string:
+		mv := NewValueMap()
+		m := mv.MapVal()
+		m.EnsureCapacity(len(tv))
+		for k, e := range tv {
+			m.UpsertString(k, e)
+		}
+		return mv
 	default:
 		return NewValueString(fmt.Sprintf("<Invalid value type %T>", tv))
 	}
@@ -248,7 +333,7 @@ func (v Value) MapVal() Map {
 	if kvlist == nil {
 		return Map{}
 	}
-	return newMap(&kvlist.Values)
+	return newMap(&kvlist.Values, v.state)
 }
 
 // SliceVal returns the slice value associated with this Value.
@@ -261,7 +346,7 @@ func (v Value) SliceVal() Slice {
 	if arr == nil {
 		return Slice{}
 	}
-	return newSlice(&arr.Values)
+	return newSlice(&arr.Values, v.state)
 }
 
 // MBytesVal returns the []byte value associated with this Value.
@@ -284,6 +369,7 @@ func (v Value) BytesVal() ImmutableByteSlice {
 // it also changes the type to be ValueTypeString.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetStringVal(sv string) {
+	v.state.AssertMutable()
 	v.orig.Value = &otlpcommon.AnyValue_StringValue{StringValue: sv}
 }
 
@@ -291,6 +377,7 @@ func (v Value) SetStringVal(sv string) {
 // it also changes the type to be ValueTypeInt.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetIntVal(iv int64) {
+	v.state.AssertMutable()
 	v.orig.Value = &otlpcommon.AnyValue_IntValue{IntValue: iv}
 }
 
@@ -298,6 +385,7 @@ func (v Value) SetIntVal(iv int64) {
 // it also changes the type to be ValueTypeDouble.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetDoubleVal(dv float64) {
+	v.state.AssertMutable()
 	v.orig.Value = &otlpcommon.AnyValue_DoubleValue{DoubleValue: dv}
 }
 
@@ -305,6 +393,7 @@ func (v Value) SetDoubleVal(dv float64) {
 // it also changes the type to be ValueTypeBool.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetBoolVal(bv bool) {
+	v.state.AssertMutable()
 	v.orig.Value = &otlpcommon.AnyValue_BoolValue{BoolValue: bv}
 }
 
@@ -315,6 +404,7 @@ func (v Value) SetBoolVal(bv bool) {
 // across multiple attributes is forbidden.
 // Deprecated: [0.54.0] Use SetBytesVal() instead.
 func (v Value) SetMBytesVal(bv []byte) {
+	v.state.AssertMutable()
 	v.orig.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: bv}
 }
 
@@ -322,11 +412,14 @@ func (v Value) SetMBytesVal(bv []byte) {
 // it also changes the type to be ValueTypeBytes.
 // Calling this function on zero-initialized Value will cause a panic.
 func (v Value) SetBytesVal(bv ImmutableByteSlice) {
+	v.state.AssertMutable()
 	v.orig.Value = &otlpcommon.AnyValue_BytesValue{BytesValue: bv.value}
 }
 
 // copyTo copies the value to Value. Will panic if dest is nil.
+// The callers must ensure that dest is mutable.
 func (v Value) copyTo(dest *otlpcommon.AnyValue) {
+	destState := StateMutable
 	switch ov := v.orig.Value.(type) {
 	case *otlpcommon.AnyValue_KvlistValue:
 		kv, ok := dest.Value.(*otlpcommon.AnyValue_KvlistValue)
@@ -339,7 +432,7 @@ func (v Value) copyTo(dest *otlpcommon.AnyValue) {
 			return
 		}
 		// Deep copy to dest.
-		newMap(&ov.KvlistValue.Values).CopyTo(newMap(&kv.KvlistValue.Values))
+		newMap(&ov.KvlistValue.Values, v.state).CopyTo(newMap(&kv.KvlistValue.Values, &destState))
 	case *otlpcommon.AnyValue_ArrayValue:
 		av, ok := dest.Value.(*otlpcommon.AnyValue_ArrayValue)
 		if !ok {
@@ -351,7 +444,7 @@ func (v Value) copyTo(dest *otlpcommon.AnyValue) {
 			return
 		}
 		// Deep copy to dest.
-		newSlice(&ov.ArrayValue.Values).CopyTo(newSlice(&av.ArrayValue.Values))
+		newSlice(&ov.ArrayValue.Values, v.state).CopyTo(newSlice(&av.ArrayValue.Values, &destState))
 	case *otlpcommon.AnyValue_BytesValue:
 		bv, ok := dest.Value.(*otlpcommon.AnyValue_BytesValue)
 		if !ok {
@@ -368,6 +461,7 @@ func (v Value) copyTo(dest *otlpcommon.AnyValue) {
 
 // CopyTo copies the attribute to a destination.
 func (v Value) CopyTo(dest Value) {
+	dest.state.AssertMutable()
 	v.copyTo(dest.orig)
 }
 
@@ -403,14 +497,14 @@ func (v Value) Equal(av Value) bool {
 
 		for i, val := range avv {
 			val := val
-			newAv := newValue(&vv[i])
+			newAv := newValue(&vv[i], av.state)
 
 			// According to the specification, array values must be scalar.
 			if avType := newAv.Type(); avType == ValueTypeSlice || avType == ValueTypeMap {
 				return false
 			}
 
-			if !newAv.Equal(newValue(&val)) {
+			if !newAv.Equal(newValue(&val, av.state)) {
 				return false
 			}
 		}
@@ -422,7 +516,7 @@ func (v Value) Equal(av Value) bool {
 			return false
 		}
 
-		m := newMap(&avv)
+		m := newMap(&avv, av.state)
 
 		for _, val := range cc {
 			newAv, ok := m.Get(val.Key)
@@ -430,7 +524,7 @@ func (v Value) Equal(av Value) bool {
 				return false
 			}
 
-			if !newAv.Equal(newValue(&val.Value)) {
+			if !newAv.Equal(newValue(&val.Value, av.state)) {
 				return false
 			}
 		}
@@ -509,7 +603,11 @@ func float64AsString(f float64) string {
 	return string(b)
 }
 
-func (v Value) asRaw() interface{} {
+// AsRaw converts the Value to its raw Go value: nil, string, bool, float64, int64, []byte,
+// map[string]interface{} or []interface{} with raw values. The conversion is lossless, the Value
+// being restored with NewValueFromRaw, but note that encoding the raw value, e.g. to JSON, may not be:
+// the []byte values become base64 strings, and the int64 values may become float64 when decoded.
+func (v Value) AsRaw() interface{} {
 	switch v.Type() {
 	case ValueTypeEmpty:
 		return nil
@@ -522,7 +620,11 @@ func (v Value) asRaw() interface{} {
 	case ValueTypeInt:
 		return v.IntVal()
 	case ValueTypeBytes:
-		return v.BytesVal().AsRaw()
+		if raw := v.BytesVal().AsRaw(); raw != nil {
+			return raw
+		}
+		// The empty bytes must not be confused with the empty Value.
+		return []byte{}
 	case ValueTypeMap:
 		return v.MapVal().AsRaw()
 	case ValueTypeSlice:
@@ -533,28 +635,28 @@ func (v Value) asRaw() interface{} {
 
 func newAttributeKeyValueString(k string, v string) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newMutableValue(&orig.Value)
 	akv.SetStringVal(v)
 	return orig
 }
 
 func newAttributeKeyValueInt(k string, v int64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newMutableValue(&orig.Value)
 	akv.SetIntVal(v)
 	return orig
 }
 
 func newAttributeKeyValueDouble(k string, v float64) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newMutableValue(&orig.Value)
 	akv.SetDoubleVal(v)
 	return orig
 }
 
 func newAttributeKeyValueBool(k string, v bool) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newMutableValue(&orig.Value)
 	akv.SetBoolVal(v)
 	return orig
 }
@@ -572,50 +674,54 @@ func newAttributeKeyValue(k string, av Value) otlpcommon.KeyValue {
 
 func newAttributeKeyValueBytes(k string, v ImmutableByteSlice) otlpcommon.KeyValue {
 	orig := otlpcommon.KeyValue{Key: k}
-	akv := Value{&orig.Value}
+	akv := newMutableValue(&orig.Value)
 	akv.SetBytesVal(v)
 	return orig
 }
 
 // Map stores a map of string keys to elements of Value type.
 type Map struct {
-	orig *[]otlpcommon.KeyValue
+	orig  *[]otlpcommon.KeyValue
+	state *State
 }
 
 // NewMap creates a Map with 0 elements.
 func NewMap() Map {
 	orig := []otlpcommon.KeyValue(nil)
-	return Map{&orig}
+	state := StateMutable
+	return newMap(&orig, &state)
 }
 
 // NewMapFromRaw creates a Map with values from the given map[string]interface{}.
 func NewMapFromRaw(rawMap map[string]interface{}) Map {
 	if len(rawMap) == 0 {
-		kv := []otlpcommon.KeyValue(nil)
-		return Map{&kv}
+		return NewMap()
 	}
 	origs := make([]otlpcommon.KeyValue, len(rawMap))
 	ix := 0
 	for k, iv := range rawMap {
 		origs[ix].Key = k
-		newValueFromRaw(iv).copyTo(&origs[ix].Value)
+		NewValueFromRaw(iv).copyTo(&origs[ix].Value)
 		ix++
 	}
-	return Map{&origs}
+	state := StateMutable
+	return newMap(&origs, &state)
 }
 
-func newMap(orig *[]otlpcommon.KeyValue) Map {
-	return Map{orig}
+func newMap(orig *[]otlpcommon.KeyValue, state *State) Map {
+	return Map{orig: orig, state: state}
 }
 
 // Clear erases any existing entries in this Map instance.
 func (m Map) Clear() {
+	m.state.AssertMutable()
 	*m.orig = nil
 }
 
 // EnsureCapacity increases the capacity of this Map instance, if necessary,
 // to ensure that it can hold at least the number of elements specified by the capacity argument.
 func (m Map) EnsureCapacity(capacity int) {
+	m.state.AssertMutable()
 	if capacity <= cap(*m.orig) {
 		return
 	}
@@ -635,15 +741,16 @@ func (m Map) Get(key string) (Value, bool) {
 	for i := range *m.orig {
 		akv := &(*m.orig)[i]
 		if akv.Key == key {
-			return Value{&akv.Value}, true
+			return newValue(&akv.Value, m.state), true
 		}
 	}
-	return Value{nil}, false
+	return newValue(nil, m.state), false
 }
 
 // Remove removes the entry associated with the key and returns true if the key
 // was present in the map, otherwise returns false.
 func (m Map) Remove(key string) bool {
+	m.state.AssertMutable()
 	for i := range *m.orig {
 		akv := &(*m.orig)[i]
 		if akv.Key == key {
@@ -657,10 +764,11 @@ func (m Map) Remove(key string) bool {
 
 // RemoveIf removes the entries for which the function in question returns true
 func (m Map) RemoveIf(f func(string, Value) bool) {
+	m.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*m.orig); i++ {
 		akv := &(*m.orig)[i]
-		if f(akv.Key, Value{&akv.Value}) {
+		if f(akv.Key, newValue(&akv.Value, m.state)) {
 			continue
 		}
 		if newLen == i {
@@ -682,6 +790,7 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 // Important: this function should not be used if the caller has access to
 // the raw value to avoid an extra allocation.
 func (m Map) Insert(k string, v Value) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValue(k, v))
 	}
@@ -690,6 +799,7 @@ func (m Map) Insert(k string, v Value) {
 // InsertNull adds a null Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (m Map) InsertNull(k string) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValueNull(k))
 	}
@@ -698,6 +808,7 @@ func (m Map) InsertNull(k string) {
 // InsertString adds the string Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (m Map) InsertString(k string, v string) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValueString(k, v))
 	}
@@ -706,6 +817,7 @@ func (m Map) InsertString(k string, v string) {
 // InsertInt adds the int Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (m Map) InsertInt(k string, v int64) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValueInt(k, v))
 	}
@@ -714,6 +826,7 @@ func (m Map) InsertInt(k string, v int64) {
 // InsertDouble adds the double Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (m Map) InsertDouble(k string, v float64) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValueDouble(k, v))
 	}
@@ -722,6 +835,7 @@ func (m Map) InsertDouble(k string, v float64) {
 // InsertBool adds the bool Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (m Map) InsertBool(k string, v bool) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValueBool(k, v))
 	}
@@ -733,6 +847,7 @@ func (m Map) InsertBool(k string, v bool) {
 // across multiple attributes is forbidden.
 // Deprecated: [0.54.0] Use InsertBytes instead.
 func (m Map) InsertMBytes(k string, v []byte) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValueBytes(k, ImmutableByteSlice{value: v}))
 	}
@@ -741,6 +856,7 @@ func (m Map) InsertMBytes(k string, v []byte) {
 // InsertBytes adds the ImmutableByteSlice Value to the map when the key does not exist.
 // No action is applied to the map where the key already exists.
 func (m Map) InsertBytes(k string, v ImmutableByteSlice) {
+	m.state.AssertMutable()
 	if _, existing := m.Get(k); !existing {
 		*m.orig = append(*m.orig, newAttributeKeyValueBytes(k, v))
 	}
@@ -754,6 +870,7 @@ func (m Map) InsertBytes(k string, v ImmutableByteSlice) {
 // Important: this function should not be used if the caller has access to
 // the raw value to avoid an extra allocation.
 func (m Map) Update(k string, v Value) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		v.copyTo(av.orig)
 	}
@@ -819,6 +936,7 @@ func (m Map) UpdateBytes(k string, v ImmutableByteSlice) {
 // Important: this function should not be used if the caller has access to
 // the raw value to avoid an extra allocation.
 func (m Map) Upsert(k string, v Value) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		v.copyTo(av.orig)
 	} else {
@@ -830,6 +948,7 @@ func (m Map) Upsert(k string, v Value) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) UpsertString(k string, v string) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetStringVal(v)
 	} else {
@@ -841,6 +960,7 @@ func (m Map) UpsertString(k string, v string) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) UpsertInt(k string, v int64) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetIntVal(v)
 	} else {
@@ -852,6 +972,7 @@ func (m Map) UpsertInt(k string, v int64) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) UpsertDouble(k string, v float64) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetDoubleVal(v)
 	} else {
@@ -863,6 +984,7 @@ func (m Map) UpsertDouble(k string, v float64) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) UpsertBool(k string, v bool) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetBoolVal(v)
 	} else {
@@ -877,6 +999,7 @@ func (m Map) UpsertBool(k string, v bool) {
 // across multiple attributes is forbidden.
 // Deprecated: [0.54.0] Use UpsertBytes instead.
 func (m Map) UpsertMBytes(k string, v []byte) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetBytesVal(ImmutableByteSlice{value: v})
 	} else {
@@ -888,6 +1011,7 @@ func (m Map) UpsertMBytes(k string, v []byte) {
 // inserted to the map that did not originally have the key. The key/value is
 // updated to the map where the key already existed.
 func (m Map) UpsertBytes(k string, v ImmutableByteSlice) {
+	m.state.AssertMutable()
 	if av, existing := m.Get(k); existing {
 		av.SetBytesVal(v)
 	} else {
@@ -899,6 +1023,7 @@ func (m Map) UpsertBytes(k string, v ImmutableByteSlice) {
 // Returns the same instance to allow nicer code like:
 //   assert.EqualValues(t, expected.Sort(), actual.Sort())
 func (m Map) Sort() Map {
+	m.state.AssertMutable()
 	// Intention is to move the nil values at the end.
 	sort.SliceStable(*m.orig, func(i, j int) bool {
 		return (*m.orig)[i].Key < (*m.orig)[j].Key
@@ -924,7 +1049,7 @@ func (m Map) Len() int {
 func (m Map) Range(f func(k string, v Value) bool) {
 	for i := range *m.orig {
 		kv := &(*m.orig)[i]
-		if !f(kv.Key, Value{&kv.Value}) {
+		if !f(kv.Key, newValue(&kv.Value, m.state)) {
 			break
 		}
 	}
@@ -932,6 +1057,7 @@ func (m Map) Range(f func(k string, v Value) bool) {
 
 // CopyTo copies all elements from the current map to the dest.
 func (m Map) CopyTo(dest Map) {
+	dest.state.AssertMutable()
 	newLen := len(*m.orig)
 	oldCap := cap(*dest.orig)
 	if newLen <= oldCap {
@@ -941,7 +1067,7 @@ func (m Map) CopyTo(dest Map) {
 			akv := &(*m.orig)[i]
 			destAkv := &(*dest.orig)[i]
 			destAkv.Key = akv.Key
-			Value{&akv.Value}.copyTo(&destAkv.Value)
+			newValue(&akv.Value, m.state).copyTo(&destAkv.Value)
 		}
 		return
 	}
@@ -951,7 +1077,7 @@ func (m Map) CopyTo(dest Map) {
 	for i := range *m.orig {
 		akv := &(*m.orig)[i]
 		origs[i].Key = akv.Key
-		Value{&akv.Value}.copyTo(&origs[i].Value)
+		newValue(&akv.Value, m.state).copyTo(&origs[i].Value)
 	}
 	*dest.orig = origs
 }
@@ -960,7 +1086,7 @@ func (m Map) CopyTo(dest Map) {
 func (m Map) AsRaw() map[string]interface{} {
 	rawMap := make(map[string]interface{})
 	m.Range(func(k string, v Value) bool {
-		rawMap[k] = v.asRaw()
+		rawMap[k] = v.AsRaw()
 		return true
 	})
 	return rawMap
@@ -969,21 +1095,35 @@ func (m Map) AsRaw() map[string]interface{} {
 // NewSliceFromRaw creates a Slice with values from the given []interface{}.
 func NewSliceFromRaw(rawSlice []interface{}) Slice {
 	if len(rawSlice) == 0 {
-		v := []otlpcommon.AnyValue(nil)
-		return Slice{&v}
+		return NewSlice()
 	}
 	origs := make([]otlpcommon.AnyValue, len(rawSlice))
 	for ix, iv := range rawSlice {
-		newValueFromRaw(iv).copyTo(&origs[ix])
+		NewValueFromRaw(iv).copyTo(&origs[ix])
 	}
-	return Slice{&origs}
+	state := StateMutable
+	return newSlice(&origs, &state)
 }
 
 // AsRaw converts the Slice to a standard go slice.
 func (es Slice) AsRaw() []interface{} {
 	rawSlice := make([]interface{}, 0, es.Len())
 	for i := 0; i < es.Len(); i++ {
-		rawSlice = append(rawSlice, es.At(i).asRaw())
+		rawSlice = append(rawSlice, es.At(i).AsRaw())
 	}
 	return rawSlice
 }
+
+// AsBytesSlice returns the []byte values of the Slice, and false if any of its values is not of
+// ValueTypeBytes, e.g. to read a Slice created with NewValueBytesSlice.
+func (es Slice) AsBytesSlice() ([][]byte, bool) {
+	ret := make([][]byte, 0, es.Len())
+	for i := 0; i < es.Len(); i++ {
+		v := es.At(i)
+		if v.Type() != ValueTypeBytes {
+			return nil, false
+		}
+		ret = append(ret, v.AsRaw().([]byte))
+	}
+	return ret, true
+}
@@ -153,7 +153,7 @@ func TestAttributeValueMap(t *testing.T) {
 
 	// Test nil KvlistValue case for MapVal() func.
 	orig := &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: nil}}
-	m1 = Value{orig: orig}
+	m1 = newMutableValue(orig)
 	assert.EqualValues(t, Map{}, m1.MapVal())
 }
 
@@ -179,6 +179,29 @@ func TestNilOrigSetAttributeValue(t *testing.T) {
 	assert.Equal(t, []byte{1, 2, 3}, av.BytesVal().AsRaw())
 }
 
+func TestValueReadOnly(t *testing.T) {
+	state := StateReadOnly
+	av := newValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "v"}}, &state)
+
+	assert.EqualValues(t, ValueTypeString, av.Type())
+	assert.EqualValues(t, "v", av.StringVal())
+	assert.EqualValues(t, "v", av.AsString())
+
+	assert.Panics(t, func() { av.SetStringVal("abc") })
+	assert.Panics(t, func() { av.SetIntVal(123) })
+	assert.Panics(t, func() { av.SetDoubleVal(1.23) })
+	assert.Panics(t, func() { av.SetBoolVal(true) })
+	assert.Panics(t, func() { av.SetBytesVal(NewImmutableByteSlice([]byte{1, 2, 3})) })
+	assert.Panics(t, func() { NewValueString("v").CopyTo(av) })
+
+	// Copying from a read-only value is allowed.
+	dest := NewValueEmpty()
+	av.CopyTo(dest)
+	assert.EqualValues(t, "v", dest.StringVal())
+	dest.SetStringVal("abc")
+	assert.EqualValues(t, "v", av.StringVal())
+}
+
 func TestAttributeValueEqual(t *testing.T) {
 	av1 := NewValueEmpty()
 	av2 := NewValueEmpty()
@@ -271,7 +294,7 @@ func TestNilMap(t *testing.T) {
 
 	val, exist := NewMap().Get("test_key")
 	assert.False(t, exist)
-	assert.EqualValues(t, Value{nil}, val)
+	assert.EqualValues(t, newMutableValue(nil), val)
 
 	insertMap := NewMap()
 	insertMap.Insert("k", NewValueString("v"))
@@ -369,9 +392,8 @@ func TestMapWithEmpty(t *testing.T) {
 			Value: otlpcommon.AnyValue{Value: nil},
 		},
 	}
-	sm := Map{
-		orig: &origWithNil,
-	}
+	state := StateMutable
+	sm := newMap(&origWithNil, &state)
 	val, exist := sm.Get("test_key")
 	assert.True(t, exist)
 	assert.EqualValues(t, ValueTypeString, val.Type())
@@ -556,7 +578,7 @@ func TestMapWithEmpty(t *testing.T) {
 	assert.False(t, exist)
 
 	// Test Sort
-	assert.EqualValues(t, Map{orig: &origWithNil}, sm.Sort())
+	assert.EqualValues(t, newMap(&origWithNil, &state), sm.Sort())
 }
 
 func TestMapIterationNil(t *testing.T) {
@@ -586,7 +608,7 @@ func TestMap_Range(t *testing.T) {
 	assert.Equal(t, 1, calls)
 
 	am.Range(func(k string, v Value) bool {
-		assert.Equal(t, rawMap[k], v.asRaw())
+		assert.Equal(t, rawMap[k], v.AsRaw())
 		delete(rawMap, k)
 		return true
 	})
@@ -614,24 +636,25 @@ func TestMap_InitFromRaw(t *testing.T) {
 		newAttributeKeyValueBytes("k_bytes", NewImmutableByteSlice([]byte{1, 2, 3})),
 	}
 	am = NewMapFromRaw(rawMap)
-	assert.EqualValues(t, Map{orig: &rawOrig}.Sort(), am.Sort())
+	state := StateMutable
+	assert.EqualValues(t, newMap(&rawOrig, &state).Sort(), am.Sort())
 }
 
 func TestAttributeValue_CopyTo(t *testing.T) {
 	// Test nil KvlistValue case for MapVal() func.
 	dest := NewValueEmpty()
 	orig := &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_KvlistValue{KvlistValue: nil}}
-	Value{orig: orig}.CopyTo(dest)
+	newMutableValue(orig).CopyTo(dest)
 	assert.Nil(t, dest.orig.Value.(*otlpcommon.AnyValue_KvlistValue).KvlistValue)
 
 	// Test nil ArrayValue case for SliceVal() func.
 	dest = NewValueEmpty()
 	orig = &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}}
-	Value{orig: orig}.CopyTo(dest)
+	newMutableValue(orig).CopyTo(dest)
 	assert.Nil(t, dest.orig.Value.(*otlpcommon.AnyValue_ArrayValue).ArrayValue)
 
 	// Test copy empty value.
-	Value{orig: &otlpcommon.AnyValue{}}.CopyTo(dest)
+	newMutableValue(&otlpcommon.AnyValue{}).CopyTo(dest)
 	assert.Nil(t, dest.orig.Value)
 }
 
@@ -685,9 +708,8 @@ func TestMap_Update(t *testing.T) {
 			Value: otlpcommon.AnyValue{Value: nil},
 		},
 	}
-	sm := Map{
-		orig: &origWithNil,
-	}
+	state := StateMutable
+	sm := newMap(&origWithNil, &state)
 
 	av, exists := sm.Get("test_key")
 	assert.True(t, exists)
@@ -767,6 +789,42 @@ func TestMap_RemoveIf(t *testing.T) {
 	assert.False(t, exists)
 }
 
+func TestMapReadOnly(t *testing.T) {
+	state := StateReadOnly
+	am := newMap(&[]otlpcommon.KeyValue{
+		{Key: "k1", Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "v1"}}},
+	}, &state)
+
+	assert.EqualValues(t, 1, am.Len())
+	v, ok := am.Get("k1")
+	assert.True(t, ok)
+	assert.EqualValues(t, "v1", v.StringVal())
+	assert.Panics(t, func() { v.SetStringVal("v2") })
+	am.Range(func(k string, v Value) bool {
+		assert.Panics(t, func() { v.SetStringVal("v2") })
+		return true
+	})
+
+	assert.Panics(t, func() { am.Clear() })
+	assert.Panics(t, func() { am.EnsureCapacity(2) })
+	assert.Panics(t, func() { am.Insert("k2", NewValueString("v2")) })
+	assert.Panics(t, func() { am.InsertString("k2", "v2") })
+	assert.Panics(t, func() { am.Update("k1", NewValueString("v2")) })
+	assert.Panics(t, func() { am.UpdateString("k1", "v2") })
+	assert.Panics(t, func() { am.Upsert("k1", NewValueString("v2")) })
+	assert.Panics(t, func() { am.UpsertString("k1", "v2") })
+	assert.Panics(t, func() { am.Remove("k1") })
+	assert.Panics(t, func() { am.RemoveIf(func(string, Value) bool { return true }) })
+	assert.Panics(t, func() { am.Sort() })
+	assert.Panics(t, func() { NewMap().CopyTo(am) })
+
+	// Copying from a read-only map is allowed.
+	dest := NewMap()
+	am.CopyTo(dest)
+	dest.UpsertString("k1", "v2")
+	assert.EqualValues(t, map[string]interface{}{"k1": "v1"}, am.AsRaw())
+}
+
 func BenchmarkAttributeValue_CopyTo(b *testing.B) {
 	av := NewValueString("k")
 	c := NewValueInt(123)
@@ -810,9 +868,8 @@ func BenchmarkMap_Range(b *testing.B) {
 			Value: otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_StringValue{StringValue: "v" + strconv.Itoa(i)}},
 		}
 	}
-	am := Map{
-		orig: &rawOrig,
-	}
+	state := StateMutable
+	am := newMap(&rawOrig, &state)
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		numEls := 0
@@ -996,7 +1053,7 @@ func TestAttributeValueSlice(t *testing.T) {
 	assert.EqualValues(t, "somestr", v.StringVal())
 
 	// Test nil values case for SliceVal() func.
-	a1 = Value{orig: &otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}}}
+	a1 = newMutableValue(&otlpcommon.AnyValue{Value: &otlpcommon.AnyValue_ArrayValue{ArrayValue: nil}})
 	assert.EqualValues(t, Slice{}, a1.SliceVal())
 }
 
@@ -1005,9 +1062,8 @@ func TestAttributeSliceWithNilValues(t *testing.T) {
 		{},
 		{Value: &otlpcommon.AnyValue_StringValue{StringValue: "test_value"}},
 	}
-	sm := Slice{
-		orig: &origWithNil,
-	}
+	state := StateMutable
+	sm := newSlice(&origWithNil, &state)
 
 	val := sm.At(0)
 	assert.EqualValues(t, ValueTypeEmpty, val.Type())
@@ -1155,7 +1211,7 @@ func TestValueAsRaw(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			actual := test.input.asRaw()
+			actual := test.input.AsRaw()
 			assert.Equal(t, test.expected, actual)
 		})
 	}
@@ -1309,15 +1365,144 @@ func TestNewValueFromRaw(t *testing.T) {
 				return s
 			})(),
 		},
+		{
+			name:     "immutable_bytes",
+			input:    NewImmutableByteSlice([]byte{1, 2, 3}),
+			expected: NewValueBytes(NewImmutableByteSlice([]byte{1, 2, 3})),
+		},
+		{
+			name:     "value",
+			input:    NewValueInt(12),
+			expected: NewValueInt(12),
+		},
+		{
+			name:     "pdata_map",
+			input:    NewMapFromRaw(map[string]interface{}{"k": "v"}),
+			expected: NewValueFromRaw(map[string]interface{}{"k": "v"}),
+		},
+		{
+			name:     "pdata_slice",
+			input:    NewSliceFromRaw([]interface{}{"v1", int64(2)}),
+			expected: NewValueFromRaw([]interface{}{"v1", int64(2)}),
+		},
+		{
+			name:     "bytes_slice",
+			input:    [][]byte{{1}, {2, 3}},
+			expected: NewValueFromRaw([]interface{}{[]byte{1}, []byte{2, 3}}),
+		},
+		{
+			name:     "string_slice",
+			input:    []string{"v1", "v2"},
+			expected: NewValueFromRaw([]interface{}{"v1", "v2"}),
+		},
+		{
+			name:     "int64_slice",
+			input:    []int64{1, 2},
+			expected: NewValueFromRaw([]interface{}{int64(1), int64(2)}),
+		},
+		{
+			name:     "int_slice",
+			input:    []int{1, 2},
+			expected: NewValueFromRaw([]interface{}{int64(1), int64(2)}),
+		},
+		{
+			name:     "float64_slice",
+			input:    []float64{1.5, 2.5},
+			expected: NewValueFromRaw([]interface{}{1.5, 2.5}),
+		},
+		{
+			name:     "bool_slice",
+			input:    []bool{true, false},
+			expected: NewValueFromRaw([]interface{}{true, false}),
+		},
+		{
+			name:     "string_map",
+			input:    map[string]string{"k": "v"},
+			expected: NewValueFromRaw(map[string]interface{}{"k": "v"}),
+		},
+		{
+			name:     "invalid",
+			input:    struct{}{},
+			expected: NewValueString("<Invalid value type struct {}>"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := newValueFromRaw(tt.input)
+			actual := NewValueFromRaw(tt.input)
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
 }
 
+func TestValueRawRoundTrip(t *testing.T) {
+	values := map[string]Value{
+		"empty":        NewValueEmpty(),
+		"string":       NewValueString("text"),
+		"empty_string": NewValueString(""),
+		"int":          NewValueInt(-12),
+		"double":       NewValueDouble(1.5),
+		"bool":         NewValueBool(true),
+		"bytes":        NewValueBytes(NewImmutableByteSlice([]byte{0, 1, 255})),
+		"empty_bytes":  NewValueBytes(NewImmutableByteSlice(nil)),
+		"bytes_slice":  NewValueBytesSlice([][]byte{{1}, {}, {2, 3}}),
+		"map":          simpleValueMap(),
+		"slice":        simpleValueSlice(),
+		"empty_map":    NewValueMap(),
+		"empty_slice":  NewValueSlice(),
+	}
+	nested := NewValueMap()
+	for k, v := range values {
+		nested.MapVal().Upsert(k, v)
+		// Value.Equal only supports the slices of scalar values.
+		if v.Type() != ValueTypeMap && v.Type() != ValueTypeSlice {
+			nestedSlice := NewValueSlice()
+			v.CopyTo(nestedSlice.SliceVal().AppendEmpty())
+			nested.MapVal().Upsert(k+"_in_slice", nestedSlice)
+		}
+	}
+	values["nested"] = nested
+
+	for name, v := range values {
+		t.Run(name, func(t *testing.T) {
+			restored := NewValueFromRaw(v.AsRaw())
+			assert.Equal(t, v.Type(), restored.Type())
+			assert.True(t, v.Equal(restored), "%v restored as %v", v.AsString(), restored.AsString())
+		})
+	}
+
+	m := nested.MapVal()
+	assert.True(t, mapsEqual(m, NewMapFromRaw(m.AsRaw())))
+}
+
+// mapsEqual returns whether the maps have the same keys with equal values, regardless of their order.
+func mapsEqual(expected, actual Map) bool {
+	if expected.Len() != actual.Len() {
+		return false
+	}
+	equal := true
+	expected.Range(func(k string, v Value) bool {
+		av, ok := actual.Get(k)
+		equal = ok && v.Equal(av)
+		return equal
+	})
+	return equal
+}
+
+func TestSliceAsBytesSlice(t *testing.T) {
+	v := NewValueBytesSlice([][]byte{{1, 2}, nil, {3}})
+	assert.Equal(t, ValueTypeSlice, v.Type())
+	bs, ok := v.SliceVal().AsBytesSlice()
+	assert.True(t, ok)
+	assert.Equal(t, [][]byte{{1, 2}, {}, {3}}, bs)
+
+	bs, ok = NewSlice().AsBytesSlice()
+	assert.True(t, ok)
+	assert.Empty(t, bs)
+
+	_, ok = NewSliceFromRaw([]interface{}{[]byte{1}, "text"}).AsBytesSlice()
+	assert.False(t, ok)
+}
+
 func simpleValueMap() Value {
 	ret := NewValueMap()
 	attrMap := ret.MapVal()
@@ -1455,6 +1455,13 @@ type ExponentialHistogramDataPoint struct {
 	// Types that are valid to be assigned to Max_:
 	//	*ExponentialHistogramDataPoint_Max
 	Max_ isExponentialHistogramDataPoint_Max_ `protobuf_oneof:"max_"`
+	// ZeroThreshold may be optionally set to convey the width of the zero
+	// region. Where the zero region is defined as the closed interval
+	// [-ZeroThreshold, ZeroThreshold].
+	// When ZeroThreshold is 0, zero count bucket stores values that cannot be
+	// expressed using the standard exponential formula as well as values that
+	// have been rounded to zero.
+	ZeroThreshold float64 `protobuf:"fixed64,14,opt,name=zero_threshold,json=zeroThreshold,proto3" json:"zero_threshold,omitempty"`
 }
 
 func (m *ExponentialHistogramDataPoint) Reset()         { *m = ExponentialHistogramDataPoint{} }
@@ -1630,6 +1637,13 @@ func (m *ExponentialHistogramDataPoint) GetMax() float64 {
 	return 0
 }
 
+func (m *ExponentialHistogramDataPoint) GetZeroThreshold() float64 {
+	if m != nil {
+		return m.ZeroThreshold
+	}
+	return 0
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ExponentialHistogramDataPoint) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -2041,103 +2055,104 @@ func init() {
 }
 
 var fileDescriptor_3c3112f9fa006917 = []byte{
-	// 1532 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x5b, 0x4f, 0x1b, 0x49,
-	0x16, 0x76, 0xfb, 0xee, 0x63, 0x03, 0x4e, 0x2d, 0x4b, 0x5a, 0x48, 0x10, 0xc7, 0xd9, 0x0d, 0x6c,
-	0x14, 0xd9, 0x1b, 0xb2, 0xf7, 0x55, 0xa4, 0xd8, 0xd8, 0x80, 0x09, 0xb7, 0x14, 0x26, 0x52, 0xa2,
-	0x28, 0xad, 0xc2, 0x2e, 0x9c, 0x52, 0xba, 0xbb, 0xbc, 0xdd, 0xd5, 0x08, 0xf6, 0x27, 0xac, 0xf6,
-	0x21, 0xda, 0xdf, 0xb1, 0x3f, 0x62, 0x1f, 0xf3, 0x98, 0x7d, 0x1b, 0x8d, 0x66, 0xa2, 0x19, 0xf2,
-	0x30, 0x23, 0xcd, 0x3f, 0x98, 0xa7, 0x51, 0x55, 0x77, 0xe3, 0x0b, 0x06, 0xc3, 0x24, 0x23, 0x25,
-	0x4f, 0x54, 0x9d, 0x3e, 0xe7, 0x3b, 0xb7, 0xaf, 0xea, 0x14, 0x86, 0xbb, 0xbc, 0x4b, 0x6d, 0x41,
-	0x4d, 0x6a, 0x51, 0xe1, 0x1c, 0x97, 0xbb, 0x0e, 0x17, 0xbc, 0x2c, 0xd7, 0xac, 0xe5, 0x96, 0x0f,
-	0xef, 0x85, 0xcb, 0x92, 0xfa, 0x80, 0xe6, 0x07, 0xb4, 0x7d, 0x61, 0x29, 0x54, 0x39, 0xbc, 0x37,
-	0x3b, 0xdd, 0xe1, 0x1d, 0xee, 0x63, 0xc8, 0x95, 0xaf, 0x30, 0x7b, 0x67, 0x94, 0x8f, 0x16, 0xb7,
-	0x2c, 0x6e, 0x4b, 0x17, 0xfe, 0x2a, 0xd0, 0x2d, 0x8d, 0xd2, 0x75, 0xa8, 0xcb, 0x3d, 0xa7, 0x45,
-	0xa5, 0x76, 0xb8, 0xf6, 0xf5, 0x8b, 0x0c, 0xb2, 0x9b, 0xbe, 0xff, 0x1a, 0x11, 0x04, 0x3d, 0x83,
-	0x7c, 0xa8, 0x60, 0x04, 0x71, 0xe9, 0x5a, 0x21, 0xb6, 0x98, 0x5d, 0x2a, 0x97, 0x2e, 0x8e, 0xbd,
-	0x84, 0x03, 0xbb, 0x00, 0x0e, 0x4f, 0x39, 0x83, 0x82, 0xe2, 0xd7, 0x51, 0x98, 0x1a, 0x52, 0x42,
-	0x8f, 0x20, 0x1d, 0xaa, 0xe9, 0x5a, 0x41, 0x5b, 0xcc, 0x2e, 0xfd, 0x6e, 0xa4, 0x9f, 0xd3, 0xa8,
-	0xfb, 0x1c, 0x55, 0xe3, 0x6f, 0xde, 0xdd, 0x88, 0xe0, 0x53, 0x00, 0xf4, 0x18, 0x26, 0xdc, 0x16,
-	0xef, 0xf6, 0x22, 0x8f, 0xaa, 0xc8, 0xef, 0x8e, 0x8b, 0x7c, 0x57, 0x1a, 0x85, 0x61, 0xe7, 0xdc,
-	0xbe, 0x1d, 0xfa, 0x97, 0x06, 0x37, 0x98, 0xed, 0x0a, 0xc7, 0xb3, 0xa8, 0x2d, 0x88, 0x60, 0xdc,
-	0x36, 0x4c, 0xb6, 0xef, 0x10, 0xe7, 0xf8, 0xd4, 0xcb, 0xf7, 0x29, 0xe5, 0xe6, 0xc1, 0x38, 0x37,
-	0x8d, 0x41, 0x9c, 0x0d, 0x1f, 0x26, 0xf0, 0x54, 0x8d, 0xea, 0x1a, 0x9e, 0x63, 0x17, 0xa9, 0xa0,
-	0x39, 0x00, 0xb7, 0xf5, 0x92, 0x5a, 0xc4, 0xf0, 0x1c, 0x53, 0x8f, 0x15, 0xb4, 0xc5, 0x0c, 0xce,
-	0xf8, 0x92, 0x3d, 0xc7, 0x2c, 0xfe, 0x4f, 0x83, 0x5c, 0x7f, 0x2a, 0x68, 0x1b, 0x12, 0x2a, 0x99,
-	0xa0, 0xb2, 0xf7, 0x47, 0x06, 0x18, 0xb0, 0xe7, 0x6c, 0x7c, 0x0a, 0x2a, 0xa8, 0xb1, 0x8f, 0x83,
-	0x1e, 0x42, 0x6a, 0xb0, 0xb4, 0xb7, 0xc7, 0xe5, 0xec, 0x87, 0x82, 0x43, 0xb3, 0x71, 0x29, 0xfc,
-	0xa8, 0xc1, 0xdc, 0x85, 0x65, 0x42, 0x02, 0xae, 0x9f, 0xd3, 0x8f, 0x20, 0xcb, 0x3f, 0x5e, 0x2d,
-	0xcb, 0x00, 0x3e, 0xc8, 0x73, 0x66, 0x74, 0x03, 0x7e, 0xf1, 0xc4, 0xff, 0x16, 0xd5, 0xb5, 0xe2,
-	0xb7, 0x31, 0x48, 0xfa, 0x66, 0x08, 0x41, 0xdc, 0x26, 0x96, 0xdf, 0xb8, 0x0c, 0x56, 0x6b, 0x54,
-	0x80, 0x6c, 0x9b, 0xba, 0x2d, 0x87, 0x75, 0x65, 0x64, 0x7a, 0x54, 0x7d, 0xea, 0x17, 0x49, 0x2b,
-	0xcf, 0x66, 0x22, 0x40, 0x57, 0x6b, 0xf4, 0x00, 0x12, 0x1d, 0xe2, 0x75, 0xa8, 0x9e, 0x50, 0xd5,
-	0xf9, 0xed, 0xb8, 0xb8, 0x57, 0xa5, 0xf2, 0x5a, 0x04, 0xfb, 0x56, 0xe8, 0xcf, 0x10, 0x73, 0x3d,
-	0x4b, 0x4f, 0x29, 0xe3, 0x5b, 0x63, 0x0f, 0x92, 0x67, 0xad, 0x45, 0xb0, 0xb4, 0x40, 0x0d, 0xc8,
-	0xbc, 0x64, 0xae, 0xe0, 0x1d, 0x87, 0x58, 0x7a, 0xe6, 0x82, 0x93, 0xdd, 0x67, 0xbe, 0x16, 0x1a,
-	0xac, 0x45, 0x70, 0xcf, 0x1a, 0xbd, 0x82, 0x5f, 0xd3, 0xa3, 0x2e, 0xb7, 0xa9, 0x2d, 0x18, 0x31,
-	0x8d, 0x1e, 0x2c, 0x28, 0xd8, 0x3f, 0x8c, 0x83, 0xad, 0xf7, 0x8c, 0xfb, 0x3d, 0x4c, 0xd3, 0x11,
-	0x72, 0xb4, 0x0c, 0x29, 0xd7, 0xb3, 0x2c, 0xc9, 0xa7, 0xac, 0x82, 0x5f, 0xb8, 0x44, 0xd2, 0x52,
-	0x7d, 0x2d, 0x82, 0x43, 0xcb, 0x6a, 0x12, 0xe2, 0x6d, 0x22, 0xc8, 0x7a, 0x3c, 0x1d, 0xcf, 0x27,
-	0xd6, 0xe3, 0xe9, 0x64, 0x3e, 0xb5, 0x1e, 0x4f, 0xa7, 0xf3, 0x99, 0xe2, 0x53, 0x48, 0xa8, 0x0a,
-	0xa3, 0x1d, 0xc8, 0x4a, 0x15, 0xa3, 0xcb, 0x99, 0x2d, 0x2e, 0x7d, 0xc7, 0x6e, 0x79, 0xd6, 0x3e,
-	0x75, 0xe4, 0x4d, 0xbd, 0x23, 0xed, 0x30, 0xb4, 0xc3, 0xa5, 0x5b, 0xfc, 0x41, 0x83, 0xd8, 0xae,
-	0x67, 0x7d, 0x7c, 0x64, 0xc4, 0xe1, 0x3a, 0xe9, 0x74, 0x1c, 0xda, 0xf1, 0xcf, 0x9b, 0xa0, 0x56,
-	0x97, 0x3b, 0xc4, 0x64, 0xe2, 0x58, 0xb1, 0x70, 0x72, 0xe9, 0x4f, 0xe3, 0xd0, 0x2b, 0x3d, 0xf3,
-	0x66, 0xcf, 0x1a, 0xcf, 0x90, 0x91, 0x72, 0x74, 0x13, 0x72, 0xcc, 0x35, 0x2c, 0x6e, 0x73, 0xc1,
-	0x6d, 0xd6, 0x52, 0x84, 0x4e, 0xe3, 0x2c, 0x73, 0x37, 0x43, 0x51, 0xf1, 0xff, 0x1a, 0x64, 0x7a,
-	0x5d, 0xdb, 0x1d, 0x95, 0xf3, 0xd2, 0xa5, 0xf9, 0xf6, 0x69, 0xa4, 0x5d, 0xfc, 0x4e, 0x83, 0xe9,
-	0x51, 0x64, 0x45, 0x2f, 0x46, 0xa5, 0xf7, 0xe0, 0xe7, 0xf0, 0xfe, 0x13, 0xc9, 0xf4, 0x39, 0xa4,
-	0x82, 0x63, 0x83, 0x1e, 0x8f, 0xca, 0xed, 0xf7, 0x97, 0x3c, 0x74, 0xa3, 0x4f, 0xc2, 0x49, 0x14,
-	0xa6, 0x86, 0xf8, 0x8c, 0x36, 0x01, 0x88, 0x10, 0x0e, 0xdb, 0xf7, 0x04, 0x75, 0x75, 0x7f, 0x62,
-	0x2f, 0x8c, 0x19, 0x15, 0x8f, 0xe8, 0xf1, 0x13, 0x62, 0x7a, 0xe1, 0x10, 0xec, 0x03, 0x40, 0x65,
-	0x98, 0x76, 0x05, 0x71, 0x84, 0x21, 0x98, 0x45, 0x0d, 0xcf, 0x66, 0x47, 0x86, 0x4d, 0x6c, 0xae,
-	0xca, 0x95, 0xc4, 0xd7, 0xd4, 0xb7, 0x26, 0xb3, 0xe8, 0x9e, 0xcd, 0x8e, 0xb6, 0x88, 0xcd, 0xd1,
-	0x6f, 0x60, 0x72, 0x48, 0x35, 0xa6, 0x54, 0x73, 0xa2, 0x5f, 0x6b, 0x0e, 0x32, 0xc4, 0x35, 0xda,
-	0xdc, 0xdb, 0x37, 0xa9, 0x1e, 0x2f, 0x68, 0x8b, 0xda, 0x5a, 0x04, 0xa7, 0x89, 0x5b, 0x53, 0x12,
-	0x74, 0x1d, 0x92, 0xc4, 0x35, 0x98, 0x2d, 0xf4, 0x64, 0x41, 0x5b, 0xcc, 0xcb, 0x6b, 0x9a, 0xb8,
-	0x0d, 0x5b, 0xa0, 0x0d, 0xc8, 0xd0, 0x23, 0x6a, 0x75, 0x4d, 0xe2, 0xb8, 0x7a, 0x42, 0x25, 0xb7,
-	0x38, 0x9e, 0x1e, 0xbe, 0x41, 0x90, 0x5d, 0x0f, 0x00, 0x4d, 0x43, 0xe2, 0xc0, 0x24, 0x1d, 0x57,
-	0x4f, 0x17, 0xb4, 0xc5, 0x09, 0xec, 0x6f, 0xaa, 0x29, 0x48, 0x1c, 0xca, 0x6a, 0xac, 0xc7, 0xd3,
-	0x5a, 0x3e, 0x5a, 0xfc, 0x2a, 0x06, 0xe8, 0x2c, 0xad, 0x86, 0xea, 0x9c, 0xf9, 0x44, 0xeb, 0x3c,
-	0x0d, 0x89, 0x16, 0xf7, 0x6c, 0xa1, 0x6a, 0x9c, 0xc4, 0xfe, 0x06, 0x21, 0x7f, 0xd8, 0x25, 0x82,
-	0xba, 0xab, 0x39, 0x76, 0x0b, 0x26, 0xf6, 0xbd, 0xd6, 0x2b, 0x2a, 0x0c, 0xa5, 0xe3, 0xea, 0xc9,
-	0x42, 0x4c, 0xc2, 0xf9, 0xc2, 0x65, 0x25, 0x43, 0x0b, 0x30, 0x45, 0x8f, 0xba, 0x26, 0x6b, 0x31,
-	0x61, 0xec, 0x73, 0xcf, 0x6e, 0xfb, 0x0c, 0xd3, 0xf0, 0x64, 0x28, 0xae, 0x2a, 0xe9, 0x60, 0x9f,
-	0xd2, 0x1f, 0xad, 0x4f, 0xd0, 0xd7, 0x27, 0x99, 0x85, 0xc5, 0x6c, 0x35, 0xbd, 0xb4, 0x35, 0x0d,
-	0xcb, 0x8d, 0x92, 0x91, 0x23, 0x3d, 0xa7, 0x64, 0x51, 0x2c, 0x37, 0x72, 0x48, 0xb9, 0x9e, 0x65,
-	0xc8, 0xbf, 0x16, 0xb3, 0xfd, 0xbf, 0xe4, 0xc8, 0x08, 0xda, 0x7b, 0x92, 0x80, 0xb9, 0x0b, 0x2f,
-	0x90, 0xa1, 0x4e, 0x6b, 0x9f, 0x7d, 0xa7, 0xa7, 0xe5, 0x6b, 0x99, 0x98, 0x54, 0x9d, 0xad, 0x6b,
-	0xd8, 0xdf, 0xc8, 0x77, 0xdb, 0x3f, 0xa9, 0xc3, 0xfd, 0xee, 0xab, 0x77, 0x50, 0x12, 0x67, 0xa4,
-	0x44, 0xb5, 0x1e, 0x75, 0x20, 0xdd, 0xe5, 0x2e, 0x13, 0xec, 0x90, 0xaa, 0xd3, 0x92, 0x5d, 0xaa,
-	0x7f, 0xd0, 0xb5, 0x5c, 0xaa, 0x2a, 0x5e, 0xb9, 0xe1, 0xff, 0x36, 0x21, 0xb8, 0x74, 0x64, 0xab,
-	0x8b, 0xf4, 0x90, 0x06, 0xcf, 0xa9, 0x8f, 0xeb, 0x28, 0x04, 0x3f, 0x87, 0x54, 0x03, 0xc4, 0xcd,
-	0x7e, 0x28, 0x71, 0x03, 0x8a, 0xe6, 0x46, 0x50, 0x74, 0xa2, 0x8f, 0xa2, 0xb3, 0x2b, 0x90, 0x0a,
-	0xc2, 0x44, 0x33, 0x90, 0xe4, 0x07, 0x07, 0x2e, 0x15, 0xea, 0x4d, 0x7c, 0x0d, 0x07, 0xbb, 0xb3,
-	0xe7, 0x53, 0xbe, 0xcf, 0xe3, 0x83, 0xe7, 0xf3, 0x3c, 0xaa, 0x17, 0xff, 0x1b, 0x83, 0xfc, 0xf0,
-	0x24, 0xf9, 0x4c, 0x26, 0xc5, 0x68, 0x5e, 0xe7, 0xfb, 0x78, 0xed, 0xb3, 0x9a, 0xc1, 0xd4, 0x3f,
-	0x3c, 0x62, 0x0b, 0x66, 0x52, 0x43, 0x5d, 0xdf, 0xfe, 0x0d, 0x96, 0x5d, 0x7a, 0x78, 0xd5, 0x11,
-	0x5b, 0x52, 0x19, 0x56, 0xc4, 0xe3, 0x00, 0x0e, 0x4f, 0x86, 0xc0, 0xea, 0xc3, 0x39, 0x63, 0x63,
-	0x76, 0x19, 0xa6, 0x86, 0x0c, 0xd1, 0x2c, 0xa4, 0x43, 0x53, 0xd5, 0x4d, 0x0d, 0x9f, 0xee, 0x25,
-	0x88, 0x0a, 0x53, 0xd5, 0x47, 0xc3, 0x03, 0x23, 0xe7, 0x75, 0x0c, 0xd2, 0x21, 0xa9, 0xd0, 0x0b,
-	0xf8, 0xd5, 0x01, 0x33, 0x05, 0x75, 0x68, 0xdb, 0xf8, 0xd0, 0x7e, 0xa1, 0x10, 0xa9, 0xd2, 0xeb,
-	0xdb, 0xd9, 0x36, 0x44, 0xc7, 0x0d, 0xec, 0xd8, 0xe5, 0x07, 0xf6, 0x53, 0x48, 0xb9, 0x5d, 0x62,
-	0x1b, 0xac, 0xad, 0x1a, 0x98, 0xab, 0x3e, 0x94, 0x81, 0x7c, 0xf9, 0xee, 0xc6, 0x5f, 0x3a, 0x7c,
-	0x28, 0x76, 0xc6, 0xcb, 0x2d, 0x6e, 0x9a, 0xb4, 0x25, 0xb8, 0x53, 0xee, 0xca, 0x67, 0x4e, 0x99,
-	0xd9, 0x82, 0x3a, 0x36, 0x31, 0xcb, 0x72, 0x57, 0xda, 0xed, 0x12, 0xbb, 0x51, 0xc3, 0x49, 0x09,
-	0xd8, 0x68, 0xa3, 0xe7, 0x90, 0x16, 0x0e, 0x69, 0x51, 0x89, 0x9d, 0x50, 0xd8, 0x95, 0x00, 0xfb,
-	0xaf, 0x57, 0xc7, 0x6e, 0x4a, 0xa4, 0x46, 0x0d, 0xa7, 0x14, 0x64, 0xa3, 0x3d, 0xf4, 0x0a, 0xb8,
-	0xf3, 0x6f, 0x0d, 0x66, 0x46, 0xbf, 0xfd, 0xd0, 0x02, 0xdc, 0xaa, 0xac, 0xae, 0xe2, 0xfa, 0x6a,
-	0xa5, 0xd9, 0xd8, 0xde, 0x32, 0x9a, 0xf5, 0xcd, 0x9d, 0x6d, 0x5c, 0xd9, 0x68, 0x34, 0x9f, 0x1a,
-	0x7b, 0x5b, 0xbb, 0x3b, 0xf5, 0xe5, 0xc6, 0x4a, 0xa3, 0x5e, 0xcb, 0x47, 0xd0, 0x4d, 0x98, 0x3b,
-	0x4f, 0xb1, 0x56, 0xdf, 0x68, 0x56, 0xf2, 0x1a, 0xba, 0x0d, 0xc5, 0xf3, 0x54, 0x96, 0xf7, 0x36,
-	0xf7, 0x36, 0x2a, 0xcd, 0xc6, 0x93, 0x7a, 0x3e, 0x7a, 0xe7, 0xef, 0x30, 0x79, 0xca, 0xd7, 0x15,
-	0x75, 0x71, 0x4d, 0x40, 0x66, 0x65, 0xa3, 0xb2, 0x6a, 0x6c, 0x6d, 0x6f, 0xd5, 0xf3, 0x11, 0x34,
-	0x0b, 0x33, 0xc1, 0xd6, 0xc0, 0xf5, 0xe5, 0x6d, 0x5c, 0xab, 0xd7, 0x8c, 0x27, 0x95, 0x8d, 0xbd,
-	0x7a, 0x5e, 0xab, 0xfe, 0x47, 0x7b, 0x73, 0x32, 0xaf, 0xbd, 0x3d, 0x99, 0xd7, 0xbe, 0x39, 0x99,
-	0xd7, 0x5e, 0xbf, 0x9f, 0x8f, 0xbc, 0x7d, 0x3f, 0x1f, 0xf9, 0xe2, 0xfd, 0x7c, 0x04, 0x6e, 0x32,
-	0x3e, 0xe6, 0xb8, 0x54, 0x73, 0xc1, 0x2f, 0x14, 0x3b, 0xf2, 0xc3, 0x8e, 0xf6, 0xac, 0x7e, 0xe5,
-	0x62, 0xfb, 0x3f, 0xd6, 0x75, 0xa8, 0xdd, 0xf7, 0xfb, 0xe1, 0x7e, 0x52, 0x09, 0xef, 0xff, 0x14,
-	0x00, 0x00, 0xff, 0xff, 0xab, 0x2c, 0xf9, 0xc6, 0x68, 0x14, 0x00, 0x00,
+	// 1545 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x5b, 0x4f, 0x1b, 0x47,
+	0x14, 0xf6, 0xfa, 0xee, 0x63, 0x03, 0xce, 0x94, 0x92, 0x15, 0x12, 0xc4, 0x71, 0x9a, 0x40, 0xa3,
+	0xc8, 0x6e, 0x48, 0xef, 0x55, 0xa4, 0xd8, 0xd8, 0x80, 0x09, 0xb7, 0x0c, 0x26, 0x52, 0xa2, 0x28,
+	0xab, 0xc1, 0x1e, 0xcc, 0x28, 0x7b, 0x71, 0x77, 0x67, 0x11, 0xf4, 0x1f, 0xb4, 0xea, 0x43, 0xd4,
+	0xdf, 0xd1, 0x1f, 0xd1, 0xc7, 0x3c, 0xa6, 0x6f, 0x55, 0xd5, 0x46, 0x2d, 0x79, 0x68, 0xa5, 0xfe,
+	0x83, 0x3e, 0x55, 0x33, 0xbb, 0x8b, 0x2f, 0x18, 0x0c, 0x4d, 0x22, 0x25, 0x4f, 0x9e, 0x39, 0x73,
+	0xce, 0x77, 0xee, 0x73, 0xc6, 0x0b, 0x37, 0xac, 0x36, 0x35, 0x39, 0xd5, 0xa9, 0x41, 0xb9, 0x7d,
+	0x50, 0x6c, 0xdb, 0x16, 0xb7, 0x8a, 0x62, 0xcd, 0x1a, 0x4e, 0x71, 0xef, 0x66, 0xb0, 0x2c, 0xc8,
+	0x03, 0x34, 0xdd, 0xc3, 0xed, 0x11, 0x0b, 0x01, 0xcb, 0xde, 0xcd, 0xc9, 0xf1, 0x96, 0xd5, 0xb2,
+	0x3c, 0x0c, 0xb1, 0xf2, 0x18, 0x26, 0xaf, 0x0f, 0xd2, 0xd1, 0xb0, 0x0c, 0xc3, 0x32, 0x85, 0x0a,
+	0x6f, 0xe5, 0xf3, 0x16, 0x06, 0xf1, 0xda, 0xd4, 0xb1, 0x5c, 0xbb, 0x41, 0x05, 0x77, 0xb0, 0xf6,
+	0xf8, 0xf3, 0x0c, 0xd2, 0xab, 0x9e, 0xfe, 0x0a, 0xe1, 0x04, 0x3d, 0x84, 0x6c, 0xc0, 0xa0, 0xf9,
+	0x76, 0xa9, 0x4a, 0x2e, 0x32, 0x9b, 0x9e, 0x2b, 0x16, 0x4e, 0xb7, 0xbd, 0x80, 0x7d, 0x39, 0x1f,
+	0x0e, 0x8f, 0xd9, 0xbd, 0x84, 0xfc, 0xef, 0x61, 0x18, 0xeb, 0x63, 0x42, 0x77, 0x21, 0x19, 0xb0,
+	0xa9, 0x4a, 0x4e, 0x99, 0x4d, 0xcf, 0x7d, 0x38, 0x50, 0xcf, 0x91, 0xd5, 0x5d, 0x8a, 0xca, 0xd1,
+	0x67, 0x2f, 0x2e, 0x85, 0xf0, 0x11, 0x00, 0xba, 0x07, 0x23, 0x4e, 0xc3, 0x6a, 0x77, 0x2c, 0x0f,
+	0x4b, 0xcb, 0x6f, 0x0c, 0xb3, 0x7c, 0x53, 0x08, 0x05, 0x66, 0x67, 0x9c, 0xae, 0x1d, 0xfa, 0x4e,
+	0x81, 0x4b, 0xcc, 0x74, 0xb8, 0xed, 0x1a, 0xd4, 0xe4, 0x84, 0x33, 0xcb, 0xd4, 0x74, 0xb6, 0x6d,
+	0x13, 0xfb, 0xe0, 0x48, 0xcb, 0xdf, 0x09, 0xa9, 0xe6, 0xf6, 0x30, 0x35, 0xb5, 0x5e, 0x9c, 0x15,
+	0x0f, 0xc6, 0xd7, 0x54, 0x0e, 0xab, 0x0a, 0x9e, 0x62, 0xa7, 0xb1, 0xa0, 0x29, 0x00, 0xa7, 0xb1,
+	0x4b, 0x0d, 0xa2, 0xb9, 0xb6, 0xae, 0x46, 0x72, 0xca, 0x6c, 0x0a, 0xa7, 0x3c, 0xca, 0x96, 0xad,
+	0xe7, 0x7f, 0x52, 0x20, 0xd3, 0xed, 0x0a, 0x5a, 0x87, 0x98, 0x74, 0xc6, 0x8f, 0xec, 0xad, 0x81,
+	0x06, 0xfa, 0xd5, 0x73, 0xdc, 0x3e, 0x09, 0xe5, 0xc7, 0xd8, 0xc3, 0x41, 0x77, 0x20, 0xd1, 0x1b,
+	0xda, 0x6b, 0xc3, 0x7c, 0xf6, 0x4c, 0xc1, 0x09, 0xe3, 0x6c, 0x2e, 0xfc, 0xab, 0xc0, 0xd4, 0xa9,
+	0x61, 0x42, 0x1c, 0x2e, 0x9e, 0x90, 0x0f, 0xdf, 0xcb, 0x4f, 0xce, 0xe7, 0xa5, 0x0f, 0xef, 0xfb,
+	0x39, 0x31, 0x38, 0x01, 0x6f, 0xdc, 0xf1, 0x2f, 0xc3, 0xaa, 0x92, 0xff, 0x33, 0x02, 0x71, 0x4f,
+	0x0c, 0x21, 0x88, 0x9a, 0xc4, 0xf0, 0x12, 0x97, 0xc2, 0x72, 0x8d, 0x72, 0x90, 0x6e, 0x52, 0xa7,
+	0x61, 0xb3, 0xb6, 0xb0, 0x4c, 0x0d, 0xcb, 0xa3, 0x6e, 0x92, 0x90, 0x72, 0x4d, 0xc6, 0x7d, 0x74,
+	0xb9, 0x46, 0xb7, 0x21, 0xd6, 0x22, 0x6e, 0x8b, 0xaa, 0x31, 0x19, 0x9d, 0xab, 0xc3, 0xec, 0x5e,
+	0x14, 0xcc, 0x4b, 0x21, 0xec, 0x49, 0xa1, 0xcf, 0x20, 0xe2, 0xb8, 0x86, 0x9a, 0x90, 0xc2, 0x57,
+	0x86, 0x36, 0x92, 0x6b, 0x2c, 0x85, 0xb0, 0x90, 0x40, 0x35, 0x48, 0xed, 0x32, 0x87, 0x5b, 0x2d,
+	0x9b, 0x18, 0x6a, 0xea, 0x94, 0xce, 0xee, 0x12, 0x5f, 0x0a, 0x04, 0x96, 0x42, 0xb8, 0x23, 0x8d,
+	0x9e, 0xc0, 0xfb, 0x74, 0xbf, 0x6d, 0x99, 0xd4, 0xe4, 0x8c, 0xe8, 0x5a, 0x07, 0x16, 0x24, 0xec,
+	0xc7, 0xc3, 0x60, 0xab, 0x1d, 0xe1, 0x6e, 0x0d, 0xe3, 0x74, 0x00, 0x1d, 0xcd, 0x43, 0xc2, 0x71,
+	0x0d, 0x43, 0xd4, 0x53, 0x5a, 0xc2, 0xcf, 0x9c, 0xc1, 0x69, 0xc1, 0xbe, 0x14, 0xc2, 0x81, 0x64,
+	0x39, 0x0e, 0xd1, 0x26, 0xe1, 0x64, 0x39, 0x9a, 0x8c, 0x66, 0x63, 0xcb, 0xd1, 0x64, 0x3c, 0x9b,
+	0x58, 0x8e, 0x26, 0x93, 0xd9, 0x54, 0xfe, 0x01, 0xc4, 0x64, 0x84, 0xd1, 0x06, 0xa4, 0x05, 0x8b,
+	0xd6, 0xb6, 0x98, 0xc9, 0xcf, 0x7c, 0xc7, 0xae, 0xb9, 0xc6, 0x36, 0xb5, 0xc5, 0x4d, 0xbd, 0x21,
+	0xe4, 0x30, 0x34, 0x83, 0xa5, 0x93, 0xff, 0x47, 0x81, 0xc8, 0xa6, 0x6b, 0xbc, 0x7e, 0x64, 0x64,
+	0xc1, 0x45, 0xd2, 0x6a, 0xd9, 0xb4, 0xe5, 0xf5, 0x1b, 0xa7, 0x46, 0xdb, 0xb2, 0x89, 0xce, 0xf8,
+	0x81, 0xac, 0xc2, 0xd1, 0xb9, 0x4f, 0x87, 0xa1, 0x97, 0x3a, 0xe2, 0xf5, 0x8e, 0x34, 0x9e, 0x20,
+	0x03, 0xe9, 0xe8, 0x32, 0x64, 0x98, 0xa3, 0x19, 0x96, 0x69, 0x71, 0xcb, 0x64, 0x0d, 0x59, 0xd0,
+	0x49, 0x9c, 0x66, 0xce, 0x6a, 0x40, 0xca, 0xff, 0xac, 0x40, 0xaa, 0x93, 0xb5, 0xcd, 0x41, 0x3e,
+	0xcf, 0x9d, 0xb9, 0xde, 0xde, 0x0e, 0xb7, 0xf3, 0x7f, 0x29, 0x30, 0x3e, 0xa8, 0x58, 0xd1, 0xe3,
+	0x41, 0xee, 0xdd, 0xfe, 0x3f, 0x75, 0xff, 0x96, 0x78, 0xfa, 0x08, 0x12, 0x7e, 0xdb, 0xa0, 0x7b,
+	0x83, 0x7c, 0xfb, 0xe8, 0x8c, 0x4d, 0x37, 0xb8, 0x13, 0x0e, 0xc3, 0x30, 0xd6, 0x57, 0xcf, 0x68,
+	0x15, 0x80, 0x70, 0x6e, 0xb3, 0x6d, 0x97, 0x53, 0x47, 0xf5, 0x26, 0xf6, 0xcc, 0x90, 0x51, 0x71,
+	0x97, 0x1e, 0xdc, 0x27, 0xba, 0x1b, 0x0c, 0xc1, 0x2e, 0x00, 0x54, 0x84, 0x71, 0x87, 0x13, 0x9b,
+	0x6b, 0x9c, 0x19, 0x54, 0x73, 0x4d, 0xb6, 0xaf, 0x99, 0xc4, 0xb4, 0x64, 0xb8, 0xe2, 0xf8, 0x82,
+	0x3c, 0xab, 0x33, 0x83, 0x6e, 0x99, 0x6c, 0x7f, 0x8d, 0x98, 0x16, 0xfa, 0x00, 0x46, 0xfb, 0x58,
+	0x23, 0x92, 0x35, 0xc3, 0xbb, 0xb9, 0xa6, 0x20, 0x45, 0x1c, 0xad, 0x69, 0xb9, 0xdb, 0x3a, 0x55,
+	0xa3, 0x39, 0x65, 0x56, 0x59, 0x0a, 0xe1, 0x24, 0x71, 0x2a, 0x92, 0x82, 0x2e, 0x42, 0x9c, 0x38,
+	0x1a, 0x33, 0xb9, 0x1a, 0xcf, 0x29, 0xb3, 0x59, 0x71, 0x4d, 0x13, 0xa7, 0x66, 0x72, 0xb4, 0x02,
+	0x29, 0xba, 0x4f, 0x8d, 0xb6, 0x4e, 0x6c, 0x47, 0x8d, 0x49, 0xe7, 0x66, 0x87, 0x97, 0x87, 0x27,
+	0xe0, 0x7b, 0xd7, 0x01, 0x40, 0xe3, 0x10, 0xdb, 0xd1, 0x49, 0xcb, 0x51, 0x93, 0x39, 0x65, 0x76,
+	0x04, 0x7b, 0x9b, 0x72, 0x02, 0x62, 0x7b, 0x22, 0x1a, 0xcb, 0xd1, 0xa4, 0x92, 0x0d, 0xe7, 0x7f,
+	0x8b, 0x00, 0x3a, 0x5e, 0x56, 0x7d, 0x71, 0x4e, 0xbd, 0xa5, 0x71, 0x1e, 0x87, 0x58, 0xc3, 0x72,
+	0x4d, 0x2e, 0x63, 0x1c, 0xc7, 0xde, 0x06, 0x21, 0x6f, 0xd8, 0xc5, 0xfc, 0xb8, 0x8b, 0x0d, 0xba,
+	0x02, 0x23, 0xdb, 0x6e, 0xe3, 0x09, 0xe5, 0x9a, 0xe4, 0x71, 0xd4, 0x78, 0x2e, 0x22, 0xe0, 0x3c,
+	0xe2, 0xbc, 0xa4, 0xa1, 0x19, 0x18, 0xa3, 0xfb, 0x6d, 0x9d, 0x35, 0x18, 0xd7, 0xb6, 0x2d, 0xd7,
+	0x6c, 0x7a, 0x15, 0xa6, 0xe0, 0xd1, 0x80, 0x5c, 0x96, 0xd4, 0xde, 0x3c, 0x25, 0x5f, 0x5b, 0x9e,
+	0xa0, 0x2b, 0x4f, 0xc2, 0x0b, 0x83, 0x99, 0x72, 0x7a, 0x29, 0x4b, 0x0a, 0x16, 0x1b, 0x49, 0x23,
+	0xfb, 0x6a, 0x46, 0xd2, 0xc2, 0x58, 0x6c, 0xc4, 0x90, 0x72, 0x5c, 0x43, 0x13, 0xbf, 0x06, 0x33,
+	0xbd, 0x5f, 0xb2, 0xaf, 0xf9, 0xe9, 0xfd, 0x36, 0x0e, 0x53, 0xa7, 0x5e, 0x20, 0x7d, 0x99, 0x56,
+	0xde, 0xf9, 0x4c, 0x8f, 0x8b, 0xd7, 0x32, 0xd1, 0xa9, 0xec, 0xad, 0x0b, 0xd8, 0xdb, 0x88, 0x77,
+	0xdb, 0x37, 0xd4, 0xb6, 0xbc, 0xec, 0xcb, 0x77, 0x50, 0x1c, 0xa7, 0x04, 0x45, 0xa6, 0x1e, 0xb5,
+	0x20, 0xd9, 0xb6, 0x1c, 0xc6, 0xd9, 0x1e, 0x95, 0xdd, 0x92, 0x9e, 0xab, 0xbe, 0xd2, 0xb5, 0x5c,
+	0x28, 0xcb, 0xba, 0x72, 0x82, 0xff, 0x36, 0x01, 0xb8, 0x50, 0x64, 0xca, 0x8b, 0x74, 0x8f, 0xaa,
+	0xa9, 0x37, 0xa0, 0x28, 0x00, 0x3f, 0xa1, 0xa8, 0x7a, 0x0a, 0x37, 0xfd, 0xaa, 0x85, 0xeb, 0x97,
+	0x68, 0x66, 0x40, 0x89, 0x8e, 0x74, 0x95, 0x28, 0xba, 0x0a, 0xa3, 0x32, 0xf8, 0x7c, 0xd7, 0xa6,
+	0xce, 0xae, 0xa5, 0x37, 0xd5, 0x51, 0x71, 0x8c, 0x47, 0x04, 0xb5, 0x1e, 0x10, 0x27, 0x17, 0x20,
+	0xe1, 0x7b, 0x83, 0x26, 0x20, 0x6e, 0xed, 0xec, 0x38, 0x94, 0xcb, 0xa7, 0xf3, 0x05, 0xec, 0xef,
+	0x8e, 0xb7, 0xb1, 0x78, 0xc6, 0x47, 0x7b, 0xdb, 0xf8, 0xa4, 0x8e, 0xc8, 0xff, 0x18, 0x81, 0x6c,
+	0xff, 0xc0, 0x79, 0x47, 0x06, 0xca, 0xe0, 0xf2, 0xcf, 0x76, 0x95, 0xbf, 0x57, 0xfc, 0x0c, 0xc6,
+	0xbe, 0x76, 0x89, 0xc9, 0x99, 0x4e, 0x35, 0x79, 0xcb, 0x7b, 0x17, 0x5d, 0x7a, 0xee, 0xce, 0x79,
+	0x27, 0x71, 0x41, 0x7a, 0x58, 0xe2, 0xf7, 0x7c, 0x38, 0x3c, 0x1a, 0x00, 0xcb, 0x83, 0x13, 0xa6,
+	0xcb, 0xe4, 0x3c, 0x8c, 0xf5, 0x09, 0xa2, 0x49, 0x48, 0x06, 0xa2, 0x32, 0x9b, 0x0a, 0x3e, 0xda,
+	0x0b, 0x10, 0x69, 0xa6, 0x8c, 0x8f, 0x82, 0x7b, 0x26, 0xd3, 0xd3, 0x08, 0x24, 0x83, 0xda, 0x43,
+	0x8f, 0xe1, 0xbd, 0x1d, 0xa6, 0x73, 0x6a, 0xd3, 0xa6, 0xf6, 0xaa, 0xf9, 0x42, 0x01, 0x52, 0xa9,
+	0x93, 0xb7, 0xe3, 0x69, 0x08, 0x0f, 0x9b, 0xeb, 0x91, 0xb3, 0xcf, 0xf5, 0x07, 0x90, 0x70, 0xda,
+	0xc4, 0xd4, 0x58, 0x53, 0x26, 0x30, 0x53, 0xbe, 0x23, 0x0c, 0xf9, 0xf5, 0xc5, 0xa5, 0xcf, 0x5b,
+	0x56, 0x9f, 0xed, 0x4c, 0x7c, 0x12, 0xd2, 0x75, 0xda, 0xe0, 0x96, 0x5d, 0x6c, 0x8b, 0xd7, 0x50,
+	0x91, 0x99, 0x9c, 0xda, 0x26, 0xd1, 0x8b, 0x62, 0x57, 0xd8, 0x6c, 0x13, 0xb3, 0x56, 0xc1, 0x71,
+	0x01, 0x58, 0x6b, 0xa2, 0x47, 0x90, 0xe4, 0x36, 0x69, 0x50, 0x81, 0x1d, 0x93, 0xd8, 0x25, 0x1f,
+	0xfb, 0x8b, 0xf3, 0x63, 0xd7, 0x05, 0x52, 0xad, 0x82, 0x13, 0x12, 0xb2, 0xd6, 0xec, 0x7b, 0x2c,
+	0x5c, 0xff, 0x5e, 0x81, 0x89, 0xc1, 0x4f, 0x44, 0x34, 0x03, 0x57, 0x4a, 0x8b, 0x8b, 0xb8, 0xba,
+	0x58, 0xaa, 0xd7, 0xd6, 0xd7, 0xb4, 0x7a, 0x75, 0x75, 0x63, 0x1d, 0x97, 0x56, 0x6a, 0xf5, 0x07,
+	0xda, 0xd6, 0xda, 0xe6, 0x46, 0x75, 0xbe, 0xb6, 0x50, 0xab, 0x56, 0xb2, 0x21, 0x74, 0x19, 0xa6,
+	0x4e, 0x62, 0xac, 0x54, 0x57, 0xea, 0xa5, 0xac, 0x82, 0xae, 0x41, 0xfe, 0x24, 0x96, 0xf9, 0xad,
+	0xd5, 0xad, 0x95, 0x52, 0xbd, 0x76, 0xbf, 0x9a, 0x0d, 0x5f, 0xff, 0x0a, 0x46, 0x8f, 0xea, 0x75,
+	0x41, 0xde, 0x6f, 0x23, 0x90, 0x5a, 0x58, 0x29, 0x2d, 0x6a, 0x6b, 0xeb, 0x6b, 0xd5, 0x6c, 0x08,
+	0x4d, 0xc2, 0x84, 0xbf, 0xd5, 0x70, 0x75, 0x7e, 0x1d, 0x57, 0xaa, 0x15, 0xed, 0x7e, 0x69, 0x65,
+	0xab, 0x9a, 0x55, 0xca, 0x3f, 0x28, 0xcf, 0x0e, 0xa7, 0x95, 0xe7, 0x87, 0xd3, 0xca, 0x1f, 0x87,
+	0xd3, 0xca, 0xd3, 0x97, 0xd3, 0xa1, 0xe7, 0x2f, 0xa7, 0x43, 0xbf, 0xbc, 0x9c, 0x0e, 0xc1, 0x65,
+	0x66, 0x0d, 0x69, 0x97, 0x72, 0xc6, 0xff, 0x90, 0xb1, 0x21, 0x0e, 0x36, 0x94, 0x87, 0xd5, 0x73,
+	0x07, 0xdb, 0xfb, 0xa6, 0xd7, 0xa2, 0x66, 0xd7, 0x67, 0xc6, 0xed, 0xb8, 0x24, 0xde, 0xfa, 0x6f,
+	0x00, 0x69, 0x3f, 0xa0, 0x54, 0x8f, 0x14, 0x00, 0x00,
 }
 
 func (m *MetricsData) Marshal() (dAtA []byte, err error) {
@@ -3005,6 +3020,12 @@ func (m *ExponentialHistogramDataPoint) MarshalToSizedBuffer(dAtA []byte) (int,
 	_ = i
 	var l int
 	_ = l
+	if m.ZeroThreshold != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ZeroThreshold))))
+		i--
+		dAtA[i] = 0x71
+	}
 	if m.Max_ != nil {
 		{
 			size := m.Max_.Size()
@@ -3856,6 +3877,9 @@ func (m *ExponentialHistogramDataPoint) Size() (n int) {
 	if m.Max_ != nil {
 		n += m.Max_.Size()
 	}
+	if m.ZeroThreshold != 0 {
+		n += 9
+	}
 	return n
 }
 
@@ -6150,6 +6174,17 @@ func (m *ExponentialHistogramDataPoint) Unmarshal(dAtA []byte) error {
 			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
 			m.Max_ = &ExponentialHistogramDataPoint_Max{float64(math.Float64frombits(v))}
+		case 14:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZeroThreshold", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ZeroThreshold = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetrics(dAtA[iNdEx:])
@@ -449,6 +449,11 @@ type Span struct {
 	// The `span_id` of this span's parent span. If this is a root span, then this
 	// field must be empty. The ID is an 8-byte array.
 	ParentSpanId go_opentelemetry_io_collector_pdata_internal_data.SpanID `protobuf:"bytes,4,opt,name=parent_span_id,json=parentSpanId,proto3,customtype=go.opentelemetry.io/collector/pdata/internal/data.SpanID" json:"parent_span_id"`
+	// Flags, a bit field. 8 least significant bits are the trace flags as
+	// defined in W3C Trace Context specification. Bits 8 and 9 represent the
+	// 3 states of whether a span's parent is remote. The remaining bits are
+	// reserved and MUST be set to 0.
+	Flags uint32 `protobuf:"fixed32,16,opt,name=flags,proto3" json:"flags,omitempty"`
 	// A description of the span's operation.
 	//
 	// For example, the name can be a qualified method name or a file name
@@ -552,6 +557,13 @@ func (m *Span) GetTraceState() string {
 	return ""
 }
 
+func (m *Span) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
 func (m *Span) GetName() string {
 	if m != nil {
 		return m.Name
@@ -726,6 +738,11 @@ type Span_Link struct {
 	// dropped_attributes_count is the number of dropped attributes. If the value is 0,
 	// then no attributes were dropped.
 	DroppedAttributesCount uint32 `protobuf:"varint,5,opt,name=dropped_attributes_count,json=droppedAttributesCount,proto3" json:"dropped_attributes_count,omitempty"`
+	// Flags, a bit field. 8 least significant bits are the trace flags as
+	// defined in W3C Trace Context specification. Bits 8 and 9 represent the
+	// 3 states of whether the link is remote. The remaining bits are
+	// reserved and MUST be set to 0.
+	Flags uint32 `protobuf:"fixed32,6,opt,name=flags,proto3" json:"flags,omitempty"`
 }
 
 func (m *Span_Link) Reset()         { *m = Span_Link{} }
@@ -782,6 +799,13 @@ func (m *Span_Link) GetDroppedAttributesCount() uint32 {
 	return 0
 }
 
+func (m *Span_Link) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
 // The Status type defines a logical error model that is suitable for different
 // programming environments, including REST APIs and RPC APIs.
 type Status struct {
@@ -856,72 +880,74 @@ func init() {
 }
 
 var fileDescriptor_5c407ac9c675a601 = []byte{
-	// 1040 bytes of a gzipped FileDescriptorProto
+	// 1059 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xcf, 0x6f, 0x1b, 0x45,
-	0x14, 0xf6, 0x38, 0x6b, 0x27, 0x79, 0x49, 0xdc, 0xcd, 0x90, 0x86, 0x25, 0xa5, 0x8e, 0x65, 0x55,
+	0x14, 0xf6, 0x38, 0x6b, 0x3b, 0x7d, 0x49, 0xdc, 0xcd, 0x90, 0x86, 0x25, 0xa5, 0x8e, 0x65, 0x55,
 	0xc2, 0x50, 0xc9, 0xa6, 0xa9, 0x90, 0x52, 0x24, 0x44, 0x63, 0x7b, 0x91, 0x96, 0xa4, 0x76, 0x34,
-	0xb6, 0x2b, 0x81, 0x90, 0x96, 0x8d, 0x77, 0x08, 0xab, 0xd8, 0xb3, 0xd6, 0xee, 0x38, 0x6a, 0x6e,
-	0x88, 0x33, 0x07, 0xae, 0xfc, 0x23, 0xdc, 0xb8, 0x57, 0x70, 0xe9, 0x11, 0x71, 0xa8, 0x50, 0x72,
-	0x81, 0x1b, 0x7f, 0x02, 0x9a, 0x1f, 0x9b, 0x78, 0x43, 0x6a, 0x37, 0x12, 0xb9, 0xf4, 0x62, 0xcf,
-	0xbe, 0x1f, 0xdf, 0xfb, 0xde, 0x37, 0x6f, 0x46, 0x03, 0x95, 0x70, 0x44, 0x19, 0xa7, 0x03, 0x3a,
-	0xa4, 0x3c, 0x3a, 0xa9, 0x8d, 0xa2, 0x90, 0x87, 0x35, 0x1e, 0x79, 0x7d, 0x5a, 0x3b, 0x7e, 0xa0,
-	0x16, 0x55, 0x69, 0xc4, 0xef, 0xa6, 0x22, 0x95, 0xb1, 0xaa, 0x02, 0x8e, 0x1f, 0x6c, 0xac, 0x1d,
-	0x86, 0x87, 0xa1, 0xca, 0x16, 0x2b, 0xe5, 0xde, 0xf8, 0xe0, 0x2a, 0xf4, 0x7e, 0x38, 0x1c, 0x86,
-	0x4c, 0xc0, 0xab, 0x95, 0x8e, 0xad, 0x5e, 0x15, 0x1b, 0xd1, 0x38, 0x1c, 0x47, 0x8a, 0x4c, 0xb2,
-	0x56, 0xf1, 0xe5, 0xaf, 0x01, 0xba, 0xa2, 0x7a, 0xdc, 0xf4, 0xb8, 0x87, 0x09, 0x14, 0x12, 0xbf,
-	0x1b, 0x8f, 0x3c, 0x16, 0x5b, 0xa8, 0x34, 0x57, 0x59, 0xda, 0xba, 0x5f, 0x9d, 0x46, 0xbb, 0x4a,
-	0x74, 0x4e, 0x47, 0xa4, 0x90, 0x95, 0x68, 0xf2, 0xb3, 0xfc, 0x5b, 0x16, 0x56, 0x52, 0x01, 0x78,
-	0x17, 0x16, 0x92, 0x10, 0x0b, 0x95, 0x50, 0x65, 0x69, 0xeb, 0xfd, 0x2b, 0xf1, 0xcf, 0xa9, 0x4e,
-	0x94, 0xa8, 0x1b, 0xcf, 0x5f, 0x6e, 0x66, 0xc8, 0x39, 0x00, 0x76, 0x60, 0x29, 0xee, 0x87, 0xa3,
-	0x84, 0x6f, 0x56, 0xf2, 0xad, 0x4c, 0xe7, 0xdb, 0x11, 0x09, 0x8a, 0x2c, 0xc4, 0xe7, 0x6b, 0xfc,
-	0x3d, 0x82, 0xbb, 0x01, 0x8b, 0x79, 0x34, 0x1e, 0x52, 0xc6, 0x3d, 0x1e, 0x84, 0xcc, 0x1d, 0x04,
-	0x07, 0x91, 0x17, 0x9d, 0x68, 0xf4, 0xbf, 0xe6, 0x25, 0xfc, 0xa3, 0xe9, 0xf0, 0x4e, 0x1a, 0x63,
-	0x4f, 0x41, 0xc8, 0x1a, 0xf5, 0xac, 0x85, 0xc8, 0x9d, 0xe0, 0xd5, 0x01, 0xf8, 0x2e, 0x40, 0xdc,
-	0xff, 0x96, 0x0e, 0x3d, 0x77, 0x1c, 0x0d, 0xac, 0xb9, 0x12, 0xaa, 0x2c, 0x92, 0x45, 0x65, 0xe9,
-	0x45, 0x83, 0xf2, 0xcf, 0x08, 0xe0, 0x82, 0x3e, 0x6e, 0x43, 0x4e, 0x36, 0xa0, 0x75, 0x7c, 0x78,
-	0x25, 0x31, 0x3d, 0x20, 0xff, 0x65, 0x26, 0x81, 0xb4, 0xa2, 0x0a, 0x07, 0x6f, 0x43, 0x6e, 0x52,
-	0xc8, 0xf2, 0x0c, 0x21, 0x47, 0x1e, 0x23, 0x2a, 0x61, 0x16, 0xf1, 0x7f, 0x10, 0xdc, 0x99, 0x22,
-	0x0c, 0xe6, 0xf0, 0xf6, 0x2b, 0xb4, 0xd7, 0xbd, 0x7d, 0x74, 0xbd, 0xde, 0x34, 0xb8, 0xee, 0x6e,
-	0xfd, 0x6a, 0xc9, 0x6f, 0xac, 0xdd, 0x8f, 0xb3, 0x16, 0x2a, 0x7f, 0xb7, 0x02, 0x86, 0x48, 0xc1,
-	0x5f, 0xc1, 0x82, 0xc4, 0x70, 0x03, 0x5f, 0x36, 0xb3, 0x5c, 0xdf, 0x11, 0xac, 0xfe, 0x78, 0xb9,
-	0xf9, 0xe8, 0x30, 0xbc, 0x54, 0x32, 0x10, 0x47, 0x7b, 0x30, 0xa0, 0x7d, 0x1e, 0x46, 0xb5, 0x91,
-	0xef, 0x71, 0xaf, 0x16, 0x30, 0x4e, 0x23, 0xe6, 0x0d, 0x6a, 0xe2, 0xab, 0x2a, 0x8f, 0xab, 0xd3,
-	0x24, 0xf3, 0x12, 0xd2, 0xf1, 0xf1, 0x17, 0x30, 0x2f, 0x28, 0x09, 0xf0, 0xac, 0x04, 0x7f, 0xac,
-	0xc1, 0xb7, 0xaf, 0x0f, 0x2e, 0xe8, 0x3a, 0x4d, 0x92, 0x17, 0x80, 0x8e, 0x8f, 0x37, 0x61, 0x49,
-	0x11, 0x8f, 0xb9, 0xc7, 0xa9, 0xee, 0x12, 0xa4, 0xa9, 0x23, 0x2c, 0xf8, 0x1b, 0x28, 0x8c, 0xbc,
-	0x88, 0x32, 0xee, 0x26, 0x14, 0x8c, 0xff, 0x89, 0xc2, 0xb2, 0xc2, 0xed, 0x28, 0x22, 0x18, 0x0c,
-	0xe6, 0x0d, 0xa9, 0x95, 0x93, 0x0c, 0xe4, 0x1a, 0x7f, 0x0a, 0xc6, 0x51, 0xc0, 0x7c, 0x2b, 0x5f,
-	0x42, 0x95, 0xc2, 0xac, 0x2b, 0x4a, 0xe0, 0xc8, 0x9f, 0xdd, 0x80, 0xf9, 0x44, 0x26, 0xe2, 0x1a,
-	0xac, 0xc5, 0xdc, 0x8b, 0xb8, 0xcb, 0x83, 0x21, 0x75, 0xc7, 0x2c, 0x78, 0xe6, 0x32, 0x8f, 0x85,
-	0xd6, 0x7c, 0x09, 0x55, 0xf2, 0x64, 0x55, 0xfa, 0xba, 0xc1, 0x90, 0xf6, 0x58, 0xf0, 0xac, 0xe5,
-	0xb1, 0x10, 0xdf, 0x07, 0x4c, 0x99, 0x7f, 0x39, 0x7c, 0x41, 0x86, 0xdf, 0xa2, 0xcc, 0x4f, 0x05,
-	0x3f, 0x01, 0xf0, 0x38, 0x8f, 0x82, 0x83, 0x31, 0xa7, 0xb1, 0xb5, 0x28, 0xe7, 0xeb, 0xbd, 0x19,
-	0x33, 0xbc, 0x4b, 0x4f, 0x9e, 0x7a, 0x83, 0x71, 0x72, 0x26, 0x27, 0x00, 0xf0, 0x36, 0x58, 0x7e,
-	0x14, 0x8e, 0x46, 0xd4, 0x77, 0x2f, 0xac, 0x6e, 0x3f, 0x1c, 0x33, 0x6e, 0x41, 0x09, 0x55, 0x56,
-	0xc8, 0xba, 0xf6, 0xef, 0x9c, 0xbb, 0x1b, 0xc2, 0x8b, 0x1f, 0x43, 0x9e, 0x1e, 0x53, 0xc6, 0x63,
-	0x6b, 0xe9, 0xb5, 0x2e, 0x47, 0xa1, 0x94, 0x2d, 0x12, 0x88, 0xce, 0xc3, 0x1f, 0xc2, 0x5a, 0x52,
-	0x5b, 0x59, 0x74, 0xdd, 0x65, 0x59, 0x17, 0x6b, 0x9f, 0xcc, 0xd1, 0x35, 0x3f, 0x81, 0xdc, 0x20,
-	0x60, 0x47, 0xb1, 0xb5, 0x32, 0xa5, 0xef, 0x74, 0xc9, 0xbd, 0x80, 0x1d, 0x11, 0x95, 0x85, 0xab,
-	0xf0, 0x56, 0x52, 0x50, 0x1a, 0x74, 0xbd, 0x82, 0xac, 0xb7, 0xaa, 0x5d, 0x22, 0x41, 0x97, 0xab,
-	0x43, 0x5e, 0x4c, 0xe8, 0x38, 0xb6, 0x6e, 0xc9, 0xbb, 0xe2, 0xde, 0x8c, 0x7a, 0x32, 0x56, 0x8b,
-	0xac, 0x33, 0x37, 0x7e, 0x45, 0x90, 0x93, 0x2d, 0xe0, 0x7b, 0x50, 0xb8, 0xb4, 0xc5, 0x48, 0x6e,
-	0xf1, 0x32, 0x9f, 0xdc, 0xdf, 0x64, 0x24, 0xb3, 0x13, 0x23, 0x99, 0xde, 0xf3, 0xb9, 0x9b, 0xdc,
-	0x73, 0x63, 0xda, 0x9e, 0x6f, 0xfc, 0x9d, 0x05, 0x43, 0xe8, 0xf3, 0x06, 0x5f, 0x3d, 0x69, 0xad,
-	0x8d, 0x9b, 0xd4, 0x3a, 0x37, 0x4d, 0xeb, 0xf2, 0x4f, 0x08, 0x16, 0x92, 0x9b, 0x05, 0xbf, 0x03,
-	0xb7, 0x3b, 0xfb, 0x3b, 0x2d, 0x77, 0xd7, 0x69, 0x35, 0xdd, 0x5e, 0xab, 0xb3, 0x6f, 0x37, 0x9c,
-	0xcf, 0x1c, 0xbb, 0x69, 0x66, 0xf0, 0x3a, 0xe0, 0x0b, 0x97, 0xd3, 0xea, 0xda, 0xa4, 0xb5, 0xb3,
-	0x67, 0x22, 0xbc, 0x06, 0xe6, 0x85, 0xbd, 0x63, 0x93, 0xa7, 0x36, 0x31, 0xb3, 0x69, 0x6b, 0x63,
-	0xcf, 0xb1, 0x5b, 0x5d, 0x73, 0x2e, 0x8d, 0xb1, 0x4f, 0xda, 0xcd, 0x5e, 0xc3, 0x26, 0xa6, 0x91,
-	0xb6, 0x37, 0xda, 0xad, 0x4e, 0xef, 0x89, 0x4d, 0xcc, 0x5c, 0xf9, 0x17, 0x04, 0x79, 0x35, 0xed,
-	0xd8, 0x82, 0xf9, 0x21, 0x8d, 0x63, 0xef, 0x30, 0x19, 0xd9, 0xe4, 0x13, 0x37, 0xc0, 0xe8, 0x87,
-	0xbe, 0xd2, 0xb8, 0xb0, 0x55, 0x7b, 0x9d, 0xb3, 0xa3, 0xff, 0x1a, 0xa1, 0x4f, 0x89, 0x4c, 0x2e,
-	0xb7, 0x00, 0x2e, 0x6c, 0xf8, 0x36, 0xac, 0x76, 0xba, 0x3b, 0xdd, 0x5e, 0xc7, 0x6d, 0xb4, 0x9b,
-	0xb6, 0x10, 0xc2, 0xee, 0x9a, 0x19, 0x8c, 0xa1, 0x30, 0x69, 0x6e, 0xef, 0x9a, 0xe8, 0x72, 0xa8,
-	0x4d, 0x48, 0x9b, 0x98, 0xd9, 0xcf, 0x8d, 0x05, 0x64, 0x66, 0xeb, 0x3f, 0xa0, 0xe7, 0xa7, 0x45,
-	0xf4, 0xe2, 0xb4, 0x88, 0xfe, 0x3c, 0x2d, 0xa2, 0x1f, 0xcf, 0x8a, 0x99, 0x17, 0x67, 0xc5, 0xcc,
-	0xef, 0x67, 0xc5, 0x0c, 0x6c, 0x06, 0xe1, 0x54, 0xa6, 0x75, 0xf5, 0xb0, 0xdd, 0x17, 0xc6, 0x7d,
-	0xf4, 0x65, 0xe3, 0xda, 0x13, 0xa9, 0x1e, 0xcf, 0x87, 0x94, 0x9d, 0xbf, 0xe4, 0x0f, 0xf2, 0xd2,
-	0xf4, 0xf0, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x56, 0xa5, 0xef, 0x0c, 0xf0, 0x0b, 0x00, 0x00,
+	0xb6, 0x2b, 0x81, 0x90, 0x96, 0x8d, 0x77, 0x6a, 0x56, 0xb1, 0x67, 0xad, 0xdd, 0x71, 0xd4, 0x5c,
+	0x39, 0x73, 0xe0, 0xca, 0xff, 0xc0, 0x99, 0x1b, 0xf7, 0x0a, 0x2e, 0x3d, 0x22, 0x0e, 0x15, 0x4a,
+	0x2e, 0x1c, 0xf9, 0x0f, 0x40, 0xf3, 0x63, 0x1d, 0x3b, 0xa4, 0x4e, 0x22, 0x91, 0x0b, 0x17, 0x7b,
+	0xe6, 0x7b, 0xef, 0x7d, 0xef, 0x7b, 0x6f, 0xde, 0x8c, 0x16, 0xca, 0xe1, 0x88, 0x32, 0x4e, 0x07,
+	0x74, 0x48, 0x79, 0x74, 0x5c, 0x1d, 0x45, 0x21, 0x0f, 0xab, 0x3c, 0xf2, 0x7a, 0xb4, 0x7a, 0xf4,
+	0x50, 0x2d, 0x2a, 0x12, 0xc4, 0xef, 0xce, 0x78, 0x2a, 0xb0, 0xa2, 0x1c, 0x8e, 0x1e, 0x6e, 0xac,
+	0xf5, 0xc3, 0x7e, 0xa8, 0xa2, 0xc5, 0x4a, 0x99, 0x37, 0x3e, 0xb8, 0x88, 0xbd, 0x17, 0x0e, 0x87,
+	0x21, 0x13, 0xf4, 0x6a, 0xa5, 0x7d, 0x2b, 0x17, 0xf9, 0x46, 0x34, 0x0e, 0xc7, 0x91, 0x12, 0x93,
+	0xac, 0x95, 0x7f, 0xe9, 0x6b, 0x80, 0x8e, 0xc8, 0x1e, 0x37, 0x3c, 0xee, 0x61, 0x02, 0xf9, 0xc4,
+	0xee, 0xc6, 0x23, 0x8f, 0xc5, 0x16, 0x2a, 0x2e, 0x94, 0x97, 0xb6, 0x1e, 0x54, 0xe6, 0xc9, 0xae,
+	0x10, 0x1d, 0xd3, 0x16, 0x21, 0x64, 0x25, 0x9a, 0xde, 0x96, 0x7e, 0x4d, 0xc3, 0xca, 0x8c, 0x03,
+	0xde, 0x85, 0xc5, 0xc4, 0xc5, 0x42, 0x45, 0x54, 0x5e, 0xda, 0x7a, 0xff, 0x42, 0xfe, 0x89, 0xd4,
+	0xa9, 0x14, 0x35, 0xe3, 0xe5, 0xeb, 0xcd, 0x14, 0x99, 0x10, 0x60, 0x07, 0x96, 0xe2, 0x5e, 0x38,
+	0x4a, 0xf4, 0xa6, 0xa5, 0xde, 0xf2, 0x7c, 0xbd, 0x6d, 0x11, 0xa0, 0xc4, 0x42, 0x3c, 0x59, 0xe3,
+	0x6f, 0x11, 0xdc, 0x0b, 0x58, 0xcc, 0xa3, 0xf1, 0x90, 0x32, 0xee, 0xf1, 0x20, 0x64, 0xee, 0x20,
+	0x38, 0x88, 0xbc, 0xe8, 0x58, 0xb3, 0xff, 0x99, 0x93, 0xf4, 0x8f, 0xe7, 0xd3, 0x3b, 0xb3, 0x1c,
+	0x7b, 0x8a, 0x42, 0xe6, 0xa8, 0xa5, 0x2d, 0x44, 0xee, 0x06, 0x6f, 0x76, 0xc0, 0xf7, 0x00, 0xe2,
+	0xde, 0x37, 0x74, 0xe8, 0xb9, 0xe3, 0x68, 0x60, 0x2d, 0x14, 0x51, 0xf9, 0x16, 0xb9, 0xa5, 0x90,
+	0x6e, 0x34, 0x28, 0xfd, 0x84, 0x00, 0xce, 0xe4, 0xe3, 0x16, 0x64, 0x64, 0x01, 0xba, 0x8f, 0x8f,
+	0x2e, 0x14, 0xa6, 0x07, 0xe4, 0xdf, 0xca, 0x24, 0x91, 0xee, 0xa8, 0xe2, 0xc1, 0xdb, 0x90, 0x99,
+	0x6e, 0x64, 0xe9, 0x92, 0x46, 0x8e, 0x3c, 0x46, 0x32, 0xf1, 0x55, 0x84, 0xff, 0x85, 0xe0, 0xee,
+	0x9c, 0xc6, 0x60, 0x0e, 0x6f, 0xbf, 0xa1, 0xf7, 0xba, 0xb6, 0x8f, 0xae, 0x57, 0x9b, 0x26, 0xd7,
+	0xd5, 0xad, 0x5f, 0xdc, 0xf2, 0x1b, 0x2b, 0xf7, 0xe3, 0xb4, 0x85, 0x4a, 0x3f, 0xae, 0x80, 0x21,
+	0x42, 0xf0, 0x57, 0xb0, 0x28, 0x39, 0xdc, 0xc0, 0x97, 0xc5, 0x2c, 0xd7, 0x76, 0x84, 0xaa, 0xdf,
+	0x5f, 0x6f, 0x3e, 0xee, 0x87, 0xe7, 0x52, 0x06, 0xe2, 0x6a, 0x0f, 0x06, 0xb4, 0xc7, 0xc3, 0xa8,
+	0x3a, 0xf2, 0x3d, 0xee, 0x55, 0x03, 0xc6, 0x69, 0xc4, 0xbc, 0x41, 0x55, 0xec, 0x2a, 0xf2, 0xba,
+	0x3a, 0x0d, 0x92, 0x93, 0x94, 0x8e, 0x8f, 0xbf, 0x80, 0x9c, 0x90, 0x24, 0xc8, 0xd3, 0x92, 0xfc,
+	0x89, 0x26, 0xdf, 0xbe, 0x3e, 0xb9, 0x90, 0xeb, 0x34, 0x48, 0x56, 0x10, 0x3a, 0x3e, 0xde, 0x84,
+	0x25, 0x25, 0x3c, 0xe6, 0x1e, 0xa7, 0xba, 0x4a, 0x90, 0x50, 0x5b, 0x20, 0xf8, 0x39, 0xe4, 0x47,
+	0x5e, 0x44, 0x19, 0x77, 0x13, 0x09, 0xc6, 0x7f, 0x24, 0x61, 0x59, 0xf1, 0xb6, 0x95, 0x90, 0x35,
+	0xc8, 0x3c, 0x1f, 0x78, 0xfd, 0xd8, 0x32, 0x8b, 0xa8, 0x9c, 0x23, 0x6a, 0x83, 0x31, 0x18, 0xcc,
+	0x1b, 0x52, 0x2b, 0x23, 0x75, 0xc9, 0x35, 0xfe, 0x14, 0x8c, 0xc3, 0x80, 0xf9, 0x56, 0xb6, 0x88,
+	0xca, 0xf9, 0xcb, 0x1e, 0x2e, 0xc1, 0x2e, 0x7f, 0x76, 0x03, 0xe6, 0x13, 0x19, 0x88, 0xab, 0xb0,
+	0x16, 0x73, 0x2f, 0xe2, 0x2e, 0x0f, 0x86, 0xd4, 0x1d, 0xb3, 0xe0, 0x85, 0xcb, 0x3c, 0x16, 0x5a,
+	0xb9, 0x22, 0x2a, 0x67, 0xc9, 0xaa, 0xb4, 0x75, 0x82, 0x21, 0xed, 0xb2, 0xe0, 0x45, 0xd3, 0x63,
+	0x21, 0x7e, 0x00, 0x98, 0x32, 0xff, 0xbc, 0xfb, 0xa2, 0x74, 0xbf, 0x4d, 0x99, 0x3f, 0xe3, 0xfc,
+	0x14, 0xc0, 0xe3, 0x3c, 0x0a, 0x0e, 0xc6, 0x9c, 0xc6, 0xd6, 0x2d, 0x39, 0x75, 0xef, 0x5d, 0x32,
+	0xd9, 0xbb, 0xf4, 0xf8, 0x99, 0x37, 0x18, 0x27, 0x37, 0x75, 0x8a, 0x00, 0x6f, 0x83, 0xe5, 0x47,
+	0xe1, 0x68, 0x44, 0x7d, 0xf7, 0x0c, 0x75, 0x7b, 0xe1, 0x98, 0x71, 0x0b, 0x8a, 0xa8, 0xbc, 0x42,
+	0xd6, 0xb5, 0x7d, 0x67, 0x62, 0xae, 0x0b, 0x2b, 0x7e, 0x02, 0x59, 0x7a, 0x44, 0x19, 0x8f, 0xad,
+	0xa5, 0x2b, 0x3d, 0x99, 0xa2, 0x53, 0xb6, 0x08, 0x20, 0x3a, 0x0e, 0x7f, 0x08, 0x6b, 0x49, 0x6e,
+	0x85, 0xe8, 0xbc, 0xcb, 0x32, 0x2f, 0xd6, 0x36, 0x19, 0xa3, 0x73, 0x7e, 0x02, 0x99, 0x41, 0xc0,
+	0x0e, 0x63, 0x6b, 0x65, 0x4e, 0xdd, 0xb3, 0x29, 0xf7, 0x02, 0x76, 0x48, 0x54, 0x14, 0xae, 0xc0,
+	0x5b, 0x49, 0x42, 0x09, 0xe8, 0x7c, 0x79, 0x99, 0x6f, 0x55, 0x9b, 0x44, 0x80, 0x4e, 0x57, 0x83,
+	0xac, 0x98, 0xdb, 0x71, 0x6c, 0xdd, 0x96, 0x2f, 0xc8, 0xfd, 0x4b, 0xf2, 0x49, 0x5f, 0xdd, 0x64,
+	0x1d, 0xb9, 0xf1, 0x0b, 0x82, 0x8c, 0x2c, 0x01, 0xdf, 0x87, 0xfc, 0xb9, 0x23, 0x46, 0xf2, 0x88,
+	0x97, 0xf9, 0xf4, 0xf9, 0x26, 0x23, 0x99, 0x9e, 0x1a, 0xc9, 0xd9, 0x33, 0x5f, 0xb8, 0xc9, 0x33,
+	0x37, 0xe6, 0x9d, 0xf9, 0xc6, 0xdf, 0x69, 0x30, 0x44, 0x7f, 0xfe, 0xc7, 0x0f, 0xd2, 0x6c, 0xaf,
+	0x8d, 0x9b, 0xec, 0x75, 0x66, 0xee, 0xfd, 0x9a, 0xbc, 0x58, 0xd9, 0xa9, 0x17, 0xab, 0xf4, 0x03,
+	0x82, 0xc5, 0xe4, 0xbd, 0xc1, 0xef, 0xc0, 0x9d, 0xf6, 0xfe, 0x4e, 0xd3, 0xdd, 0x75, 0x9a, 0x0d,
+	0xb7, 0xdb, 0x6c, 0xef, 0xdb, 0x75, 0xe7, 0x33, 0xc7, 0x6e, 0x98, 0x29, 0xbc, 0x0e, 0xf8, 0xcc,
+	0xe4, 0x34, 0x3b, 0x36, 0x69, 0xee, 0xec, 0x99, 0x08, 0xaf, 0x81, 0x79, 0x86, 0xb7, 0x6d, 0xf2,
+	0xcc, 0x26, 0x66, 0x7a, 0x16, 0xad, 0xef, 0x39, 0x76, 0xb3, 0x63, 0x2e, 0xcc, 0x72, 0xec, 0x93,
+	0x56, 0xa3, 0x5b, 0xb7, 0x89, 0x69, 0xcc, 0xe2, 0xf5, 0x56, 0xb3, 0xdd, 0x7d, 0x6a, 0x13, 0x33,
+	0x53, 0xfa, 0x19, 0x41, 0x56, 0xdd, 0x01, 0x6c, 0x41, 0x6e, 0x48, 0xe3, 0xd8, 0xeb, 0x27, 0x83,
+	0x9c, 0x6c, 0x71, 0x1d, 0x8c, 0x5e, 0xe8, 0xab, 0xce, 0xe7, 0xb7, 0xaa, 0x57, 0xb9, 0x51, 0xfa,
+	0xaf, 0x1e, 0xfa, 0x94, 0xc8, 0xe0, 0x52, 0x13, 0xe0, 0x0c, 0xc3, 0x77, 0x60, 0xb5, 0xdd, 0xd9,
+	0xe9, 0x74, 0xdb, 0x6e, 0xbd, 0xd5, 0xb0, 0x45, 0x23, 0xec, 0x8e, 0x99, 0xc2, 0x18, 0xf2, 0xd3,
+	0x70, 0x6b, 0xd7, 0x44, 0xe7, 0x5d, 0x6d, 0x42, 0x5a, 0xc4, 0x4c, 0x7f, 0x6e, 0x2c, 0x22, 0x33,
+	0x5d, 0xfb, 0x0e, 0xbd, 0x3c, 0x29, 0xa0, 0x57, 0x27, 0x05, 0xf4, 0xc7, 0x49, 0x01, 0x7d, 0x7f,
+	0x5a, 0x48, 0xbd, 0x3a, 0x2d, 0xa4, 0x7e, 0x3b, 0x2d, 0xa4, 0x60, 0x33, 0x08, 0xe7, 0x2a, 0xad,
+	0xa9, 0x8f, 0xe0, 0x7d, 0x01, 0xee, 0xa3, 0x2f, 0xeb, 0xd7, 0x9e, 0x53, 0xf5, 0xa1, 0xdd, 0xa7,
+	0x6c, 0xf2, 0xd5, 0x7f, 0x90, 0x95, 0xd0, 0xa3, 0x7f, 0x06, 0x00, 0x5e, 0x16, 0xdf, 0x21, 0x1c,
+	0x0c, 0x00, 0x00,
 }
 
 func (m *TracesData) Marshal() (dAtA []byte, err error) {
@@ -1159,6 +1185,14 @@ func (m *Span) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Flags != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Flags))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x85
+	}
 	{
 		size, err := m.Status.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -1365,6 +1399,12 @@ func (m *Span_Link) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Flags != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(m.Flags))
+		i--
+		dAtA[i] = 0x35
+	}
 	if m.DroppedAttributesCount != 0 {
 		i = encodeVarintTrace(dAtA, i, uint64(m.DroppedAttributesCount))
 		i--
@@ -1602,6 +1642,9 @@ func (m *Span) Size() (n int) {
 	}
 	l = m.Status.Size()
 	n += 1 + l + sovTrace(uint64(l))
+	if m.Flags != 0 {
+		n += 6
+	}
 	return n
 }
 
@@ -1653,6 +1696,9 @@ func (m *Span_Link) Size() (n int) {
 	if m.DroppedAttributesCount != 0 {
 		n += 1 + sovTrace(uint64(m.DroppedAttributesCount))
 	}
+	if m.Flags != 0 {
+		n += 5
+	}
 	return n
 }
 
@@ -2666,6 +2712,16 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 16:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			m.Flags = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flags = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTrace(dAtA[iNdEx:])
@@ -3012,6 +3068,16 @@ func (m *Span_Link) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			m.Flags = 0
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flags = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTrace(dAtA[iNdEx:])
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"math"
+
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+// Downscale reduces the scale of the ExponentialHistogramDataPoint to the given scale, merging
+// the counts of the 2^(Scale()-scale) adjacent buckets of each bucket of the new scale, e.g. to
+// reduce the number of buckets. Does nothing if the scale is not smaller than Scale().
+func (ms ExponentialHistogramDataPoint) Downscale(scale int32) {
+	ms.state.AssertMutable()
+	if scale >= ms.orig.Scale {
+		return
+	}
+	shift := ms.orig.Scale - scale
+	downscaleBuckets(&ms.orig.Positive, shift)
+	downscaleBuckets(&ms.orig.Negative, shift)
+	ms.orig.Scale = scale
+}
+
+// Merge merges the observations of the src ExponentialHistogramDataPoint into this one, e.g. to
+// aggregate the points of several streams, or the consecutive delta points of a stream:
+//
+//   - the scale becomes the smaller scale of both points, the other point being downscaled,
+//   - the zero threshold becomes the larger zero threshold of both points, the counts of the
+//     buckets whose upper bound is not greater than it being added to the zero count,
+//   - the counts, zero counts, sums and bucket counts are added, the sum being unset if one of
+//     the points has observations but no sum,
+//   - the min and max become the min and max of both points, being unset if one of the points
+//     has observations but no min, respectively max,
+//   - the start timestamp becomes the earliest one, and the timestamp the latest one,
+//   - the exemplars of src are appended.
+//
+// The attributes and flags of this point are kept, and src is not modified.
+func (ms ExponentialHistogramDataPoint) Merge(src ExponentialHistogramDataPoint) {
+	ms.state.AssertMutable()
+	// The bucket counts are shared by the immutable slices, and are never modified in place:
+	// modifying the offsets of a shallow copy of src leaves it unmodified.
+	dest, orig := ms.orig, *src.orig
+
+	if orig.Scale < dest.Scale {
+		ms.Downscale(orig.Scale)
+	} else if orig.Scale > dest.Scale {
+		shift := orig.Scale - dest.Scale
+		downscaleBuckets(&orig.Positive, shift)
+		downscaleBuckets(&orig.Negative, shift)
+	}
+
+	zeroThreshold := math.Max(dest.ZeroThreshold, orig.ZeroThreshold)
+	dest.ZeroCount += collapseZeroBuckets(&dest.Positive, dest.Scale, zeroThreshold) +
+		collapseZeroBuckets(&dest.Negative, dest.Scale, zeroThreshold)
+	orig.ZeroCount += collapseZeroBuckets(&orig.Positive, dest.Scale, zeroThreshold) +
+		collapseZeroBuckets(&orig.Negative, dest.Scale, zeroThreshold)
+	dest.ZeroThreshold = zeroThreshold
+
+	mergeBuckets(&dest.Positive, orig.Positive)
+	mergeBuckets(&dest.Negative, orig.Negative)
+
+	switch {
+	case orig.Count == 0:
+	case dest.Count == 0:
+		dest.Sum_, dest.Min_, dest.Max_ = orig.Sum_, orig.Min_, orig.Max_
+	default:
+		if ms.HasSum() && src.HasSum() {
+			dest.Sum_ = &otlpmetrics.ExponentialHistogramDataPoint_Sum{Sum: ms.Sum() + src.Sum()}
+		} else {
+			dest.Sum_ = nil
+		}
+		if ms.HasMin() && src.HasMin() {
+			dest.Min_ = &otlpmetrics.ExponentialHistogramDataPoint_Min{Min: math.Min(ms.Min(), src.Min())}
+		} else {
+			dest.Min_ = nil
+		}
+		if ms.HasMax() && src.HasMax() {
+			dest.Max_ = &otlpmetrics.ExponentialHistogramDataPoint_Max{Max: math.Max(ms.Max(), src.Max())}
+		} else {
+			dest.Max_ = nil
+		}
+	}
+	dest.Count += orig.Count
+	dest.ZeroCount += orig.ZeroCount
+
+	if orig.StartTimeUnixNano != 0 && (dest.StartTimeUnixNano == 0 || orig.StartTimeUnixNano < dest.StartTimeUnixNano) {
+		dest.StartTimeUnixNano = orig.StartTimeUnixNano
+	}
+	if orig.TimeUnixNano > dest.TimeUnixNano {
+		dest.TimeUnixNano = orig.TimeUnixNano
+	}
+
+	exemplars := src.Exemplars()
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplars.At(i).CopyTo(ms.Exemplars().AppendEmpty())
+	}
+}
+
+// downscaleBuckets reduces the scale of the buckets by shift, the bucket of the index i
+// becoming the bucket of the index i>>shift.
+func downscaleBuckets(buckets *otlpmetrics.ExponentialHistogramDataPoint_Buckets, shift int32) {
+	offset := buckets.Offset >> shift
+	if len(buckets.BucketCounts) == 0 {
+		buckets.Offset = offset
+		return
+	}
+	last := (buckets.Offset + int32(len(buckets.BucketCounts)) - 1) >> shift
+	counts := make([]uint64, last-offset+1)
+	for i, count := range buckets.BucketCounts {
+		counts[((buckets.Offset+int32(i))>>shift)-offset] += count
+	}
+	buckets.Offset = offset
+	buckets.BucketCounts = counts
+}
+
+// collapseZeroBuckets removes the lowest buckets whose upper bound is not greater than the zero
+// threshold, returning the sum of their counts.
+func collapseZeroBuckets(buckets *otlpmetrics.ExponentialHistogramDataPoint_Buckets, scale int32, zeroThreshold float64) uint64 {
+	var zeroCount uint64
+	n := 0
+	for n < len(buckets.BucketCounts) && bucketUpperBound(scale, buckets.Offset+int32(n)) <= zeroThreshold {
+		zeroCount += buckets.BucketCounts[n]
+		n++
+	}
+	if n > 0 {
+		buckets.Offset += int32(n)
+		buckets.BucketCounts = buckets.BucketCounts[n:]
+	}
+	return zeroCount
+}
+
+// bucketUpperBound returns the upper bound of the absolute values of the bucket of the index,
+// base^(index+1) with base = 2^(2^-scale).
+func bucketUpperBound(scale int32, index int32) float64 {
+	return math.Exp2(math.Ldexp(float64(index)+1, -int(scale)))
+}
+
+// mergeBuckets adds the counts of the src buckets to the dest buckets of the same scale.
+func mergeBuckets(dest *otlpmetrics.ExponentialHistogramDataPoint_Buckets, src otlpmetrics.ExponentialHistogramDataPoint_Buckets) {
+	if len(src.BucketCounts) == 0 {
+		return
+	}
+	if len(dest.BucketCounts) == 0 {
+		dest.Offset = src.Offset
+		dest.BucketCounts = src.BucketCounts
+		return
+	}
+	offset := dest.Offset
+	if src.Offset < offset {
+		offset = src.Offset
+	}
+	end := dest.Offset + int32(len(dest.BucketCounts))
+	if srcEnd := src.Offset + int32(len(src.BucketCounts)); srcEnd > end {
+		end = srcEnd
+	}
+	counts := make([]uint64, end-offset)
+	for i, count := range dest.BucketCounts {
+		counts[dest.Offset-offset+int32(i)] += count
+	}
+	for i, count := range src.BucketCounts {
+		counts[src.Offset-offset+int32(i)] += count
+	}
+	dest.Offset = offset
+	dest.BucketCounts = counts
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+func newTestExponentialHistogramDataPoint(scale int32, count uint64, zeroCount uint64, posOffset int32, posCounts []uint64) ExponentialHistogramDataPoint {
+	dp := NewExponentialHistogramDataPoint()
+	dp.SetScale(scale)
+	dp.SetCount(count)
+	dp.SetZeroCount(zeroCount)
+	dp.Positive().SetOffset(posOffset)
+	dp.Positive().SetBucketCounts(NewImmutableUInt64Slice(posCounts))
+	return dp
+}
+
+func TestExponentialHistogramDataPoint_ZeroThresholdProto(t *testing.T) {
+	orig := &otlpmetrics.ExponentialHistogramDataPoint{Count: 2, ZeroCount: 2, ZeroThreshold: 0.001}
+	buf, err := orig.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, orig.Size(), len(buf))
+
+	decoded := &otlpmetrics.ExponentialHistogramDataPoint{}
+	require.NoError(t, decoded.Unmarshal(buf))
+	assert.Equal(t, orig, decoded)
+}
+
+func TestExponentialHistogramDataPoint_Downscale(t *testing.T) {
+	dp := newTestExponentialHistogramDataPoint(2, 15, 0, -3, []uint64{1, 2, 3, 4, 5})
+	dp.Negative().SetOffset(3)
+	dp.Negative().SetBucketCounts(NewImmutableUInt64Slice([]uint64{1, 1}))
+
+	dp.Downscale(3)
+	assert.Equal(t, int32(2), dp.Scale())
+	assert.Equal(t, int32(-3), dp.Positive().Offset())
+
+	dp.Downscale(1)
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, int32(-2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 5, 9}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1, 1}, dp.Negative().BucketCounts().AsRaw())
+}
+
+func TestExponentialHistogramDataPoint_Merge(t *testing.T) {
+	dest := newTestExponentialHistogramDataPoint(1, 3, 1, 0, []uint64{1, 1})
+	dest.SetStartTimestamp(20)
+	dest.SetTimestamp(30)
+	dest.SetSum(10)
+	dest.SetMin(1)
+	dest.SetMax(5)
+	dest.Attributes().InsertString("key", "dest")
+
+	src := newTestExponentialHistogramDataPoint(1, 7, 0, 1, []uint64{2, 2})
+	src.Negative().SetOffset(-1)
+	src.Negative().SetBucketCounts(NewImmutableUInt64Slice([]uint64{3}))
+	src.SetStartTimestamp(10)
+	src.SetTimestamp(25)
+	src.SetSum(20)
+	src.SetMin(-2)
+	src.SetMax(8)
+	src.Attributes().InsertString("key", "src")
+	src.Exemplars().AppendEmpty().SetDoubleVal(8)
+	srcCopy := NewExponentialHistogramDataPoint()
+	src.CopyTo(srcCopy)
+
+	dest.Merge(src)
+	assert.Equal(t, int32(1), dest.Scale())
+	assert.Equal(t, uint64(10), dest.Count())
+	assert.Equal(t, uint64(1), dest.ZeroCount())
+	assert.Equal(t, int32(0), dest.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3, 2}, dest.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-1), dest.Negative().Offset())
+	assert.Equal(t, []uint64{3}, dest.Negative().BucketCounts().AsRaw())
+	assert.Equal(t, 30.0, dest.Sum())
+	assert.Equal(t, -2.0, dest.Min())
+	assert.Equal(t, 8.0, dest.Max())
+	assert.Equal(t, Timestamp(10), dest.StartTimestamp())
+	assert.Equal(t, Timestamp(30), dest.Timestamp())
+	assert.Equal(t, 1, dest.Exemplars().Len())
+	v, _ := dest.Attributes().Get("key")
+	assert.Equal(t, "dest", v.StringVal())
+	assert.Equal(t, srcCopy, src)
+}
+
+func TestExponentialHistogramDataPoint_MergeScales(t *testing.T) {
+	dest := newTestExponentialHistogramDataPoint(0, 1, 0, 0, []uint64{1})
+	src := newTestExponentialHistogramDataPoint(1, 2, 0, 0, []uint64{1, 1})
+
+	dest.Merge(src)
+	assert.Equal(t, int32(0), dest.Scale())
+	assert.Equal(t, uint64(3), dest.Count())
+	assert.Equal(t, int32(0), dest.Positive().Offset())
+	assert.Equal(t, []uint64{3}, dest.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(1), src.Scale())
+	assert.Equal(t, []uint64{1, 1}, src.Positive().BucketCounts().AsRaw())
+
+	dest = newTestExponentialHistogramDataPoint(1, 2, 0, 0, []uint64{1, 1})
+	dest.Merge(newTestExponentialHistogramDataPoint(0, 1, 0, 0, []uint64{1}))
+	assert.Equal(t, int32(0), dest.Scale())
+	assert.Equal(t, []uint64{3}, dest.Positive().BucketCounts().AsRaw())
+}
+
+func TestExponentialHistogramDataPoint_MergeZeroThreshold(t *testing.T) {
+	// The bucket 0 of the scale 0 is (1, 2], the bucket 1 is (2, 4].
+	dest := newTestExponentialHistogramDataPoint(0, 4, 1, 0, []uint64{1, 2})
+	src := newTestExponentialHistogramDataPoint(0, 1, 1, 0, nil)
+	src.SetZeroThreshold(2)
+
+	dest.Merge(src)
+	assert.Equal(t, 2.0, dest.ZeroThreshold())
+	assert.Equal(t, uint64(5), dest.Count())
+	assert.Equal(t, uint64(3), dest.ZeroCount())
+	assert.Equal(t, int32(1), dest.Positive().Offset())
+	assert.Equal(t, []uint64{2}, dest.Positive().BucketCounts().AsRaw())
+}
+
+func TestExponentialHistogramDataPoint_MergeOptionalFields(t *testing.T) {
+	empty := NewExponentialHistogramDataPoint()
+	src := newTestExponentialHistogramDataPoint(0, 1, 0, 0, []uint64{1})
+	src.SetSum(1.5)
+	src.SetMin(1.5)
+	src.SetMax(1.5)
+	empty.Merge(src)
+	assert.Equal(t, 1.5, empty.Sum())
+	assert.Equal(t, 1.5, empty.Min())
+	assert.Equal(t, 1.5, empty.Max())
+
+	empty.Merge(NewExponentialHistogramDataPoint())
+	assert.True(t, empty.HasSum())
+	assert.True(t, empty.HasMin())
+	assert.True(t, empty.HasMax())
+
+	empty.Merge(newTestExponentialHistogramDataPoint(0, 1, 0, 0, []uint64{1}))
+	assert.Equal(t, uint64(2), empty.Count())
+	assert.False(t, empty.HasSum())
+	assert.False(t, empty.HasMin())
+	assert.False(t, empty.HasMax())
+}
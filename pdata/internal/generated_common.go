@@ -29,11 +29,12 @@ import (
 // Must use NewInstrumentationScope function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type InstrumentationScope struct {
-	orig *otlpcommon.InstrumentationScope
+	orig  *otlpcommon.InstrumentationScope
+	state *State
 }
 
-func newInstrumentationScope(orig *otlpcommon.InstrumentationScope) InstrumentationScope {
-	return InstrumentationScope{orig: orig}
+func newInstrumentationScope(orig *otlpcommon.InstrumentationScope, state *State) InstrumentationScope {
+	return InstrumentationScope{orig: orig, state: state}
 }
 
 // NewInstrumentationScope creates a new empty InstrumentationScope.
@@ -41,12 +42,15 @@ func newInstrumentationScope(orig *otlpcommon.InstrumentationScope) Instrumentat
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewInstrumentationScope() InstrumentationScope {
-	return newInstrumentationScope(&otlpcommon.InstrumentationScope{})
+	state := StateMutable
+	return newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms InstrumentationScope) MoveTo(dest InstrumentationScope) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpcommon.InstrumentationScope{}
 }
@@ -58,6 +62,7 @@ func (ms InstrumentationScope) Name() string {
 
 // SetName replaces the name associated with this InstrumentationScope.
 func (ms InstrumentationScope) SetName(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).Name = v
 }
 
@@ -68,11 +73,13 @@ func (ms InstrumentationScope) Version() string {
 
 // SetVersion replaces the version associated with this InstrumentationScope.
 func (ms InstrumentationScope) SetVersion(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).Version = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms InstrumentationScope) CopyTo(dest InstrumentationScope) {
+	dest.state.AssertMutable()
 	dest.SetName(ms.Name())
 	dest.SetVersion(ms.Version())
 }
@@ -87,18 +94,20 @@ func (ms InstrumentationScope) CopyTo(dest InstrumentationScope) {
 type Slice struct {
 	// orig points to the slice otlpcommon.AnyValue field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]otlpcommon.AnyValue
+	orig  *[]otlpcommon.AnyValue
+	state *State
 }
 
-func newSlice(orig *[]otlpcommon.AnyValue) Slice {
-	return Slice{orig}
+func newSlice(orig *[]otlpcommon.AnyValue, state *State) Slice {
+	return Slice{orig: orig, state: state}
 }
 
 // NewSlice creates a Slice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSlice() Slice {
 	orig := []otlpcommon.AnyValue(nil)
-	return Slice{&orig}
+	state := StateMutable
+	return newSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -116,11 +125,12 @@ func (es Slice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es Slice) At(ix int) Value {
-	return newValue(&(*es.orig)[ix])
+	return newValue(&(*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es Slice) CopyTo(dest Slice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
@@ -130,7 +140,7 @@ func (es Slice) CopyTo(dest Slice) {
 	}
 
 	for i := range *es.orig {
-		newValue(&(*es.orig)[i]).CopyTo(newValue(&(*dest.orig)[i]))
+		newValue(&(*es.orig)[i], es.state).CopyTo(newValue(&(*dest.orig)[i], dest.state))
 	}
 }
 
@@ -146,6 +156,7 @@ func (es Slice) CopyTo(dest Slice) {
 //       // Here should set all the values for e.
 //   }
 func (es Slice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -159,6 +170,7 @@ func (es Slice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty Value.
 // It returns the newly added Value.
 func (es Slice) AppendEmpty() Value {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, otlpcommon.AnyValue{})
 	return es.At(es.Len() - 1)
 }
@@ -166,6 +178,8 @@ func (es Slice) AppendEmpty() Value {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es Slice) MoveAndAppendTo(dest Slice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -178,6 +192,7 @@ func (es Slice) MoveAndAppendTo(dest Slice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es Slice) RemoveIf(f func(Value) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
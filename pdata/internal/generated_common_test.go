@@ -31,6 +31,9 @@ func TestInstrumentationScope_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewInstrumentationScope(), ms)
 	assert.EqualValues(t, generateTestInstrumentationScope(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &sharedState)) })
+	assert.Panics(t, func() { newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &sharedState).MoveTo(dest) })
 }
 
 func TestInstrumentationScope_CopyTo(t *testing.T) {
@@ -41,6 +44,8 @@ func TestInstrumentationScope_CopyTo(t *testing.T) {
 	orig = generateTestInstrumentationScope()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &sharedState)) })
 }
 
 func TestInstrumentationScope_Name(t *testing.T) {
@@ -49,6 +54,8 @@ func TestInstrumentationScope_Name(t *testing.T) {
 	testValName := "test_name"
 	ms.SetName(testValName)
 	assert.EqualValues(t, testValName, ms.Name())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &sharedState).SetName(testValName) })
 }
 
 func TestInstrumentationScope_Version(t *testing.T) {
@@ -57,16 +64,21 @@ func TestInstrumentationScope_Version(t *testing.T) {
 	testValVersion := "test_version"
 	ms.SetVersion(testValVersion)
 	assert.EqualValues(t, testValVersion, ms.Version())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newInstrumentationScope(&otlpcommon.InstrumentationScope{}, &sharedState).SetVersion(testValVersion)
+	})
 }
 
 func TestSlice(t *testing.T) {
 	es := NewSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSlice(&[]otlpcommon.AnyValue{})
+	state := StateMutable
+	es = newSlice(&[]otlpcommon.AnyValue{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newValue(&otlpcommon.AnyValue{})
+	emptyVal := newValue(&otlpcommon.AnyValue{}, &state)
 	testVal := generateTestValue()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -160,6 +172,17 @@ func TestSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newSlice(&[]otlpcommon.AnyValue{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewSlice()) })
+	assert.Panics(t, func() { NewSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el Value) bool { return true }) })
+	assert.Panics(t, func() { NewSlice().CopyTo(es) })
+}
+
 func generateTestInstrumentationScope() InstrumentationScope {
 	tv := NewInstrumentationScope()
 	fillTestInstrumentationScope(tv)
@@ -33,18 +33,20 @@ import (
 type ResourceLogsSlice struct {
 	// orig points to the slice otlplogs.ResourceLogs field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlplogs.ResourceLogs
+	orig  *[]*otlplogs.ResourceLogs
+	state *State
 }
 
-func newResourceLogsSlice(orig *[]*otlplogs.ResourceLogs) ResourceLogsSlice {
-	return ResourceLogsSlice{orig}
+func newResourceLogsSlice(orig *[]*otlplogs.ResourceLogs, state *State) ResourceLogsSlice {
+	return ResourceLogsSlice{orig: orig, state: state}
 }
 
 // NewResourceLogsSlice creates a ResourceLogsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceLogsSlice() ResourceLogsSlice {
 	orig := []*otlplogs.ResourceLogs(nil)
-	return ResourceLogsSlice{&orig}
+	state := StateMutable
+	return newResourceLogsSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -62,17 +64,18 @@ func (es ResourceLogsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceLogsSlice) At(ix int) ResourceLogs {
-	return newResourceLogs((*es.orig)[ix])
+	return newResourceLogs((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newResourceLogs((*es.orig)[i]).CopyTo(newResourceLogs((*dest.orig)[i]))
+			newResourceLogs((*es.orig)[i], es.state).CopyTo(newResourceLogs((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -80,7 +83,7 @@ func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 	wrappers := make([]*otlplogs.ResourceLogs, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newResourceLogs((*es.orig)[i]).CopyTo(newResourceLogs(wrappers[i]))
+		newResourceLogs((*es.orig)[i], es.state).CopyTo(newResourceLogs(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -97,6 +100,7 @@ func (es ResourceLogsSlice) CopyTo(dest ResourceLogsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceLogsSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -110,6 +114,7 @@ func (es ResourceLogsSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ResourceLogs.
 // It returns the newly added ResourceLogs.
 func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlplogs.ResourceLogs{})
 	return es.At(es.Len() - 1)
 }
@@ -124,6 +129,7 @@ func (es ResourceLogsSlice) AppendEmpty() ResourceLogs {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceLogsSlice) Sort(less func(a, b ResourceLogs) bool) ResourceLogsSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -131,6 +137,8 @@ func (es ResourceLogsSlice) Sort(less func(a, b ResourceLogs) bool) ResourceLogs
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceLogsSlice) MoveAndAppendTo(dest ResourceLogsSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -143,6 +151,7 @@ func (es ResourceLogsSlice) MoveAndAppendTo(dest ResourceLogsSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -168,11 +177,12 @@ func (es ResourceLogsSlice) RemoveIf(f func(ResourceLogs) bool) {
 // Must use NewResourceLogs function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ResourceLogs struct {
-	orig *otlplogs.ResourceLogs
+	orig  *otlplogs.ResourceLogs
+	state *State
 }
 
-func newResourceLogs(orig *otlplogs.ResourceLogs) ResourceLogs {
-	return ResourceLogs{orig: orig}
+func newResourceLogs(orig *otlplogs.ResourceLogs, state *State) ResourceLogs {
+	return ResourceLogs{orig: orig, state: state}
 }
 
 // NewResourceLogs creates a new empty ResourceLogs.
@@ -180,19 +190,22 @@ func newResourceLogs(orig *otlplogs.ResourceLogs) ResourceLogs {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResourceLogs() ResourceLogs {
-	return newResourceLogs(&otlplogs.ResourceLogs{})
+	state := StateMutable
+	return newResourceLogs(&otlplogs.ResourceLogs{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ResourceLogs) MoveTo(dest ResourceLogs) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.ResourceLogs{}
 }
 
 // Resource returns the resource associated with this ResourceLogs.
 func (ms ResourceLogs) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return newResource(&(*ms.orig).Resource, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceLogs.
@@ -202,16 +215,24 @@ func (ms ResourceLogs) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceLogs.
 func (ms ResourceLogs) SetSchemaUrl(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // ScopeLogs returns the ScopeLogs associated with this ResourceLogs.
 func (ms ResourceLogs) ScopeLogs() ScopeLogsSlice {
-	return newScopeLogsSlice(&(*ms.orig).ScopeLogs)
+	return newScopeLogsSlice(&(*ms.orig).ScopeLogs, ms.state)
+}
+
+// HasScopeLogs returns true if the ScopeLogs associated with this ResourceLogs is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms ResourceLogs) HasScopeLogs() bool {
+	return len((*ms.orig).ScopeLogs) > 0
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
+	dest.state.AssertMutable()
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeLogs().CopyTo(dest.ScopeLogs())
@@ -227,18 +248,20 @@ func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
 type ScopeLogsSlice struct {
 	// orig points to the slice otlplogs.ScopeLogs field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlplogs.ScopeLogs
+	orig  *[]*otlplogs.ScopeLogs
+	state *State
 }
 
-func newScopeLogsSlice(orig *[]*otlplogs.ScopeLogs) ScopeLogsSlice {
-	return ScopeLogsSlice{orig}
+func newScopeLogsSlice(orig *[]*otlplogs.ScopeLogs, state *State) ScopeLogsSlice {
+	return ScopeLogsSlice{orig: orig, state: state}
 }
 
 // NewScopeLogsSlice creates a ScopeLogsSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewScopeLogsSlice() ScopeLogsSlice {
 	orig := []*otlplogs.ScopeLogs(nil)
-	return ScopeLogsSlice{&orig}
+	state := StateMutable
+	return newScopeLogsSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -256,17 +279,18 @@ func (es ScopeLogsSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ScopeLogsSlice) At(ix int) ScopeLogs {
-	return newScopeLogs((*es.orig)[ix])
+	return newScopeLogs((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newScopeLogs((*es.orig)[i]).CopyTo(newScopeLogs((*dest.orig)[i]))
+			newScopeLogs((*es.orig)[i], es.state).CopyTo(newScopeLogs((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -274,7 +298,7 @@ func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
 	wrappers := make([]*otlplogs.ScopeLogs, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newScopeLogs((*es.orig)[i]).CopyTo(newScopeLogs(wrappers[i]))
+		newScopeLogs((*es.orig)[i], es.state).CopyTo(newScopeLogs(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -291,6 +315,7 @@ func (es ScopeLogsSlice) CopyTo(dest ScopeLogsSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ScopeLogsSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -304,6 +329,7 @@ func (es ScopeLogsSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ScopeLogs.
 // It returns the newly added ScopeLogs.
 func (es ScopeLogsSlice) AppendEmpty() ScopeLogs {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlplogs.ScopeLogs{})
 	return es.At(es.Len() - 1)
 }
@@ -318,6 +344,7 @@ func (es ScopeLogsSlice) AppendEmpty() ScopeLogs {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ScopeLogsSlice) Sort(less func(a, b ScopeLogs) bool) ScopeLogsSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -325,6 +352,8 @@ func (es ScopeLogsSlice) Sort(less func(a, b ScopeLogs) bool) ScopeLogsSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ScopeLogsSlice) MoveAndAppendTo(dest ScopeLogsSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -337,6 +366,7 @@ func (es ScopeLogsSlice) MoveAndAppendTo(dest ScopeLogsSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ScopeLogsSlice) RemoveIf(f func(ScopeLogs) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -362,11 +392,12 @@ func (es ScopeLogsSlice) RemoveIf(f func(ScopeLogs) bool) {
 // Must use NewScopeLogs function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ScopeLogs struct {
-	orig *otlplogs.ScopeLogs
+	orig  *otlplogs.ScopeLogs
+	state *State
 }
 
-func newScopeLogs(orig *otlplogs.ScopeLogs) ScopeLogs {
-	return ScopeLogs{orig: orig}
+func newScopeLogs(orig *otlplogs.ScopeLogs, state *State) ScopeLogs {
+	return ScopeLogs{orig: orig, state: state}
 }
 
 // NewScopeLogs creates a new empty ScopeLogs.
@@ -374,19 +405,22 @@ func newScopeLogs(orig *otlplogs.ScopeLogs) ScopeLogs {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewScopeLogs() ScopeLogs {
-	return newScopeLogs(&otlplogs.ScopeLogs{})
+	state := StateMutable
+	return newScopeLogs(&otlplogs.ScopeLogs{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ScopeLogs) MoveTo(dest ScopeLogs) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.ScopeLogs{}
 }
 
 // Scope returns the scope associated with this ScopeLogs.
 func (ms ScopeLogs) Scope() InstrumentationScope {
-	return newInstrumentationScope(&(*ms.orig).Scope)
+	return newInstrumentationScope(&(*ms.orig).Scope, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this ScopeLogs.
@@ -396,16 +430,24 @@ func (ms ScopeLogs) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this ScopeLogs.
 func (ms ScopeLogs) SetSchemaUrl(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // LogRecords returns the LogRecords associated with this ScopeLogs.
 func (ms ScopeLogs) LogRecords() LogRecordSlice {
-	return newLogRecordSlice(&(*ms.orig).LogRecords)
+	return newLogRecordSlice(&(*ms.orig).LogRecords, ms.state)
+}
+
+// HasLogRecords returns true if the LogRecords associated with this ScopeLogs is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms ScopeLogs) HasLogRecords() bool {
+	return len((*ms.orig).LogRecords) > 0
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ScopeLogs) CopyTo(dest ScopeLogs) {
+	dest.state.AssertMutable()
 	ms.Scope().CopyTo(dest.Scope())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.LogRecords().CopyTo(dest.LogRecords())
@@ -421,18 +463,20 @@ func (ms ScopeLogs) CopyTo(dest ScopeLogs) {
 type LogRecordSlice struct {
 	// orig points to the slice otlplogs.LogRecord field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlplogs.LogRecord
+	orig  *[]*otlplogs.LogRecord
+	state *State
 }
 
-func newLogRecordSlice(orig *[]*otlplogs.LogRecord) LogRecordSlice {
-	return LogRecordSlice{orig}
+func newLogRecordSlice(orig *[]*otlplogs.LogRecord, state *State) LogRecordSlice {
+	return LogRecordSlice{orig: orig, state: state}
 }
 
 // NewLogRecordSlice creates a LogRecordSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewLogRecordSlice() LogRecordSlice {
 	orig := []*otlplogs.LogRecord(nil)
-	return LogRecordSlice{&orig}
+	state := StateMutable
+	return newLogRecordSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -450,17 +494,18 @@ func (es LogRecordSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es LogRecordSlice) At(ix int) LogRecord {
-	return newLogRecord((*es.orig)[ix])
+	return newLogRecord((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newLogRecord((*es.orig)[i]).CopyTo(newLogRecord((*dest.orig)[i]))
+			newLogRecord((*es.orig)[i], es.state).CopyTo(newLogRecord((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -468,7 +513,7 @@ func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
 	wrappers := make([]*otlplogs.LogRecord, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newLogRecord((*es.orig)[i]).CopyTo(newLogRecord(wrappers[i]))
+		newLogRecord((*es.orig)[i], es.state).CopyTo(newLogRecord(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -485,6 +530,7 @@ func (es LogRecordSlice) CopyTo(dest LogRecordSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es LogRecordSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -498,6 +544,7 @@ func (es LogRecordSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty LogRecord.
 // It returns the newly added LogRecord.
 func (es LogRecordSlice) AppendEmpty() LogRecord {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlplogs.LogRecord{})
 	return es.At(es.Len() - 1)
 }
@@ -512,6 +559,7 @@ func (es LogRecordSlice) AppendEmpty() LogRecord {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es LogRecordSlice) Sort(less func(a, b LogRecord) bool) LogRecordSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -519,6 +567,8 @@ func (es LogRecordSlice) Sort(less func(a, b LogRecord) bool) LogRecordSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es LogRecordSlice) MoveAndAppendTo(dest LogRecordSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -531,6 +581,7 @@ func (es LogRecordSlice) MoveAndAppendTo(dest LogRecordSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es LogRecordSlice) RemoveIf(f func(LogRecord) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -557,11 +608,12 @@ func (es LogRecordSlice) RemoveIf(f func(LogRecord) bool) {
 // Must use NewLogRecord function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type LogRecord struct {
-	orig *otlplogs.LogRecord
+	orig  *otlplogs.LogRecord
+	state *State
 }
 
-func newLogRecord(orig *otlplogs.LogRecord) LogRecord {
-	return LogRecord{orig: orig}
+func newLogRecord(orig *otlplogs.LogRecord, state *State) LogRecord {
+	return LogRecord{orig: orig, state: state}
 }
 
 // NewLogRecord creates a new empty LogRecord.
@@ -569,12 +621,15 @@ func newLogRecord(orig *otlplogs.LogRecord) LogRecord {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewLogRecord() LogRecord {
-	return newLogRecord(&otlplogs.LogRecord{})
+	state := StateMutable
+	return newLogRecord(&otlplogs.LogRecord{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms LogRecord) MoveTo(dest LogRecord) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlplogs.LogRecord{}
 }
@@ -586,6 +641,7 @@ func (ms LogRecord) ObservedTimestamp() Timestamp {
 
 // SetObservedTimestamp replaces the observedtimestamp associated with this LogRecord.
 func (ms LogRecord) SetObservedTimestamp(v Timestamp) {
+	ms.state.AssertMutable()
 	(*ms.orig).ObservedTimeUnixNano = uint64(v)
 }
 
@@ -596,6 +652,7 @@ func (ms LogRecord) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this LogRecord.
 func (ms LogRecord) SetTimestamp(v Timestamp) {
+	ms.state.AssertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -606,6 +663,7 @@ func (ms LogRecord) TraceID() TraceID {
 
 // SetTraceID replaces the traceid associated with this LogRecord.
 func (ms LogRecord) SetTraceID(v TraceID) {
+	ms.state.AssertMutable()
 	(*ms.orig).TraceId = v.orig
 }
 
@@ -616,6 +674,7 @@ func (ms LogRecord) SpanID() SpanID {
 
 // SetSpanID replaces the spanid associated with this LogRecord.
 func (ms LogRecord) SetSpanID(v SpanID) {
+	ms.state.AssertMutable()
 	(*ms.orig).SpanId = v.orig
 }
 
@@ -626,6 +685,7 @@ func (ms LogRecord) Flags() uint32 {
 
 // SetFlags replaces the flags associated with this LogRecord.
 func (ms LogRecord) SetFlags(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).Flags = uint32(v)
 }
 
@@ -636,6 +696,7 @@ func (ms LogRecord) SeverityText() string {
 
 // SetSeverityText replaces the severitytext associated with this LogRecord.
 func (ms LogRecord) SetSeverityText(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).SeverityText = v
 }
 
@@ -646,17 +707,24 @@ func (ms LogRecord) SeverityNumber() SeverityNumber {
 
 // SetSeverityNumber replaces the severitynumber associated with this LogRecord.
 func (ms LogRecord) SetSeverityNumber(v SeverityNumber) {
+	ms.state.AssertMutable()
 	(*ms.orig).SeverityNumber = otlplogs.SeverityNumber(v)
 }
 
 // Body returns the body associated with this LogRecord.
 func (ms LogRecord) Body() Value {
-	return newValue(&(*ms.orig).Body)
+	return newValue(&(*ms.orig).Body, ms.state)
 }
 
 // Attributes returns the Attributes associated with this LogRecord.
 func (ms LogRecord) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return newMap(&(*ms.orig).Attributes, ms.state)
+}
+
+// HasAttributes returns true if the Attributes associated with this LogRecord is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms LogRecord) HasAttributes() bool {
+	return len((*ms.orig).Attributes) > 0
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this LogRecord.
@@ -666,11 +734,13 @@ func (ms LogRecord) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this LogRecord.
 func (ms LogRecord) SetDroppedAttributesCount(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms LogRecord) CopyTo(dest LogRecord) {
+	dest.state.AssertMutable()
 	dest.SetObservedTimestamp(ms.ObservedTimestamp())
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetTraceID(ms.TraceID())
@@ -28,11 +28,12 @@ import (
 func TestResourceLogsSlice(t *testing.T) {
 	es := NewResourceLogsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{})
+	state := StateMutable
+	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newResourceLogs(&otlplogs.ResourceLogs{})
+	emptyVal := newResourceLogs(&otlplogs.ResourceLogs{}, &state)
 	testVal := generateTestResourceLogs()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -135,12 +136,26 @@ func TestResourceLogsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceLogsSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewResourceLogsSlice()) })
+	assert.Panics(t, func() { NewResourceLogsSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ResourceLogs) bool { return true }) })
+	assert.Panics(t, func() { NewResourceLogsSlice().CopyTo(es) })
+}
+
 func TestResourceLogs_MoveTo(t *testing.T) {
 	ms := generateTestResourceLogs()
 	dest := NewResourceLogs()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewResourceLogs(), ms)
 	assert.EqualValues(t, generateTestResourceLogs(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newResourceLogs(&otlplogs.ResourceLogs{}, &sharedState)) })
+	assert.Panics(t, func() { newResourceLogs(&otlplogs.ResourceLogs{}, &sharedState).MoveTo(dest) })
 }
 
 func TestResourceLogs_CopyTo(t *testing.T) {
@@ -151,6 +166,8 @@ func TestResourceLogs_CopyTo(t *testing.T) {
 	orig = generateTestResourceLogs()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newResourceLogs(&otlplogs.ResourceLogs{}, &sharedState)) })
 }
 
 func TestResourceLogs_Resource(t *testing.T) {
@@ -165,6 +182,8 @@ func TestResourceLogs_SchemaUrl(t *testing.T) {
 	testValSchemaUrl := "https://opentelemetry.io/schemas/1.5.0"
 	ms.SetSchemaUrl(testValSchemaUrl)
 	assert.EqualValues(t, testValSchemaUrl, ms.SchemaUrl())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newResourceLogs(&otlplogs.ResourceLogs{}, &sharedState).SetSchemaUrl(testValSchemaUrl) })
 }
 
 func TestResourceLogs_ScopeLogs(t *testing.T) {
@@ -175,14 +194,22 @@ func TestResourceLogs_ScopeLogs(t *testing.T) {
 	assert.EqualValues(t, testValScopeLogs, ms.ScopeLogs())
 }
 
+func TestResourceLogs_HasScopeLogs(t *testing.T) {
+	ms := NewResourceLogs()
+	assert.False(t, ms.HasScopeLogs())
+	fillTestScopeLogsSlice(ms.ScopeLogs())
+	assert.True(t, ms.HasScopeLogs())
+}
+
 func TestScopeLogsSlice(t *testing.T) {
 	es := NewScopeLogsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newScopeLogsSlice(&[]*otlplogs.ScopeLogs{})
+	state := StateMutable
+	es = newScopeLogsSlice(&[]*otlplogs.ScopeLogs{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newScopeLogs(&otlplogs.ScopeLogs{})
+	emptyVal := newScopeLogs(&otlplogs.ScopeLogs{}, &state)
 	testVal := generateTestScopeLogs()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -285,12 +312,26 @@ func TestScopeLogsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeLogsSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newScopeLogsSlice(&[]*otlplogs.ScopeLogs{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewScopeLogsSlice()) })
+	assert.Panics(t, func() { NewScopeLogsSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ScopeLogs) bool { return true }) })
+	assert.Panics(t, func() { NewScopeLogsSlice().CopyTo(es) })
+}
+
 func TestScopeLogs_MoveTo(t *testing.T) {
 	ms := generateTestScopeLogs()
 	dest := NewScopeLogs()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewScopeLogs(), ms)
 	assert.EqualValues(t, generateTestScopeLogs(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newScopeLogs(&otlplogs.ScopeLogs{}, &sharedState)) })
+	assert.Panics(t, func() { newScopeLogs(&otlplogs.ScopeLogs{}, &sharedState).MoveTo(dest) })
 }
 
 func TestScopeLogs_CopyTo(t *testing.T) {
@@ -301,6 +342,8 @@ func TestScopeLogs_CopyTo(t *testing.T) {
 	orig = generateTestScopeLogs()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newScopeLogs(&otlplogs.ScopeLogs{}, &sharedState)) })
 }
 
 func TestScopeLogs_Scope(t *testing.T) {
@@ -315,6 +358,8 @@ func TestScopeLogs_SchemaUrl(t *testing.T) {
 	testValSchemaUrl := "https://opentelemetry.io/schemas/1.5.0"
 	ms.SetSchemaUrl(testValSchemaUrl)
 	assert.EqualValues(t, testValSchemaUrl, ms.SchemaUrl())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newScopeLogs(&otlplogs.ScopeLogs{}, &sharedState).SetSchemaUrl(testValSchemaUrl) })
 }
 
 func TestScopeLogs_LogRecords(t *testing.T) {
@@ -325,14 +370,22 @@ func TestScopeLogs_LogRecords(t *testing.T) {
 	assert.EqualValues(t, testValLogRecords, ms.LogRecords())
 }
 
+func TestScopeLogs_HasLogRecords(t *testing.T) {
+	ms := NewScopeLogs()
+	assert.False(t, ms.HasLogRecords())
+	fillTestLogRecordSlice(ms.LogRecords())
+	assert.True(t, ms.HasLogRecords())
+}
+
 func TestLogRecordSlice(t *testing.T) {
 	es := NewLogRecordSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newLogRecordSlice(&[]*otlplogs.LogRecord{})
+	state := StateMutable
+	es = newLogRecordSlice(&[]*otlplogs.LogRecord{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newLogRecord(&otlplogs.LogRecord{})
+	emptyVal := newLogRecord(&otlplogs.LogRecord{}, &state)
 	testVal := generateTestLogRecord()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -435,12 +488,26 @@ func TestLogRecordSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestLogRecordSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newLogRecordSlice(&[]*otlplogs.LogRecord{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewLogRecordSlice()) })
+	assert.Panics(t, func() { NewLogRecordSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el LogRecord) bool { return true }) })
+	assert.Panics(t, func() { NewLogRecordSlice().CopyTo(es) })
+}
+
 func TestLogRecord_MoveTo(t *testing.T) {
 	ms := generateTestLogRecord()
 	dest := NewLogRecord()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewLogRecord(), ms)
 	assert.EqualValues(t, generateTestLogRecord(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newLogRecord(&otlplogs.LogRecord{}, &sharedState)) })
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).MoveTo(dest) })
 }
 
 func TestLogRecord_CopyTo(t *testing.T) {
@@ -451,6 +518,8 @@ func TestLogRecord_CopyTo(t *testing.T) {
 	orig = generateTestLogRecord()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newLogRecord(&otlplogs.LogRecord{}, &sharedState)) })
 }
 
 func TestLogRecord_ObservedTimestamp(t *testing.T) {
@@ -459,6 +528,10 @@ func TestLogRecord_ObservedTimestamp(t *testing.T) {
 	testValObservedTimestamp := Timestamp(1234567890)
 	ms.SetObservedTimestamp(testValObservedTimestamp)
 	assert.EqualValues(t, testValObservedTimestamp, ms.ObservedTimestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetObservedTimestamp(testValObservedTimestamp)
+	})
 }
 
 func TestLogRecord_Timestamp(t *testing.T) {
@@ -467,6 +540,8 @@ func TestLogRecord_Timestamp(t *testing.T) {
 	testValTimestamp := Timestamp(1234567890)
 	ms.SetTimestamp(testValTimestamp)
 	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetTimestamp(testValTimestamp) })
 }
 
 func TestLogRecord_TraceID(t *testing.T) {
@@ -475,6 +550,8 @@ func TestLogRecord_TraceID(t *testing.T) {
 	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
 	ms.SetTraceID(testValTraceID)
 	assert.EqualValues(t, testValTraceID, ms.TraceID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetTraceID(testValTraceID) })
 }
 
 func TestLogRecord_SpanID(t *testing.T) {
@@ -483,6 +560,8 @@ func TestLogRecord_SpanID(t *testing.T) {
 	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
 	ms.SetSpanID(testValSpanID)
 	assert.EqualValues(t, testValSpanID, ms.SpanID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetSpanID(testValSpanID) })
 }
 
 func TestLogRecord_Flags(t *testing.T) {
@@ -491,6 +570,8 @@ func TestLogRecord_Flags(t *testing.T) {
 	testValFlags := uint32(0x01)
 	ms.SetFlags(testValFlags)
 	assert.EqualValues(t, testValFlags, ms.Flags())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetFlags(testValFlags) })
 }
 
 func TestLogRecord_SeverityText(t *testing.T) {
@@ -499,6 +580,8 @@ func TestLogRecord_SeverityText(t *testing.T) {
 	testValSeverityText := "INFO"
 	ms.SetSeverityText(testValSeverityText)
 	assert.EqualValues(t, testValSeverityText, ms.SeverityText())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetSeverityText(testValSeverityText) })
 }
 
 func TestLogRecord_SeverityNumber(t *testing.T) {
@@ -507,6 +590,8 @@ func TestLogRecord_SeverityNumber(t *testing.T) {
 	testValSeverityNumber := SeverityNumberINFO
 	ms.SetSeverityNumber(testValSeverityNumber)
 	assert.EqualValues(t, testValSeverityNumber, ms.SeverityNumber())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetSeverityNumber(testValSeverityNumber) })
 }
 
 func TestLogRecord_Body(t *testing.T) {
@@ -523,12 +608,23 @@ func TestLogRecord_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestLogRecord_HasAttributes(t *testing.T) {
+	ms := NewLogRecord()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestLogRecord_DroppedAttributesCount(t *testing.T) {
 	ms := NewLogRecord()
 	assert.EqualValues(t, uint32(0), ms.DroppedAttributesCount())
 	testValDroppedAttributesCount := uint32(17)
 	ms.SetDroppedAttributesCount(testValDroppedAttributesCount)
 	assert.EqualValues(t, testValDroppedAttributesCount, ms.DroppedAttributesCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newLogRecord(&otlplogs.LogRecord{}, &sharedState).SetDroppedAttributesCount(testValDroppedAttributesCount)
+	})
 }
 
 func generateTestResourceLogsSlice() ResourceLogsSlice {
@@ -28,11 +28,12 @@ import (
 func TestResourceMetricsSlice(t *testing.T) {
 	es := NewResourceMetricsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{})
+	state := StateMutable
+	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newResourceMetrics(&otlpmetrics.ResourceMetrics{})
+	emptyVal := newResourceMetrics(&otlpmetrics.ResourceMetrics{}, &state)
 	testVal := generateTestResourceMetrics()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -135,12 +136,26 @@ func TestResourceMetricsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceMetricsSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewResourceMetricsSlice()) })
+	assert.Panics(t, func() { NewResourceMetricsSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ResourceMetrics) bool { return true }) })
+	assert.Panics(t, func() { NewResourceMetricsSlice().CopyTo(es) })
+}
+
 func TestResourceMetrics_MoveTo(t *testing.T) {
 	ms := generateTestResourceMetrics()
 	dest := NewResourceMetrics()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewResourceMetrics(), ms)
 	assert.EqualValues(t, generateTestResourceMetrics(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newResourceMetrics(&otlpmetrics.ResourceMetrics{}, &sharedState)) })
+	assert.Panics(t, func() { newResourceMetrics(&otlpmetrics.ResourceMetrics{}, &sharedState).MoveTo(dest) })
 }
 
 func TestResourceMetrics_CopyTo(t *testing.T) {
@@ -151,6 +166,8 @@ func TestResourceMetrics_CopyTo(t *testing.T) {
 	orig = generateTestResourceMetrics()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newResourceMetrics(&otlpmetrics.ResourceMetrics{}, &sharedState)) })
 }
 
 func TestResourceMetrics_Resource(t *testing.T) {
@@ -165,6 +182,10 @@ func TestResourceMetrics_SchemaUrl(t *testing.T) {
 	testValSchemaUrl := "https://opentelemetry.io/schemas/1.5.0"
 	ms.SetSchemaUrl(testValSchemaUrl)
 	assert.EqualValues(t, testValSchemaUrl, ms.SchemaUrl())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newResourceMetrics(&otlpmetrics.ResourceMetrics{}, &sharedState).SetSchemaUrl(testValSchemaUrl)
+	})
 }
 
 func TestResourceMetrics_ScopeMetrics(t *testing.T) {
@@ -175,14 +196,22 @@ func TestResourceMetrics_ScopeMetrics(t *testing.T) {
 	assert.EqualValues(t, testValScopeMetrics, ms.ScopeMetrics())
 }
 
+func TestResourceMetrics_HasScopeMetrics(t *testing.T) {
+	ms := NewResourceMetrics()
+	assert.False(t, ms.HasScopeMetrics())
+	fillTestScopeMetricsSlice(ms.ScopeMetrics())
+	assert.True(t, ms.HasScopeMetrics())
+}
+
 func TestScopeMetricsSlice(t *testing.T) {
 	es := NewScopeMetricsSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{})
+	state := StateMutable
+	es = newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newScopeMetrics(&otlpmetrics.ScopeMetrics{})
+	emptyVal := newScopeMetrics(&otlpmetrics.ScopeMetrics{}, &state)
 	testVal := generateTestScopeMetrics()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -285,12 +314,26 @@ func TestScopeMetricsSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeMetricsSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newScopeMetricsSlice(&[]*otlpmetrics.ScopeMetrics{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewScopeMetricsSlice()) })
+	assert.Panics(t, func() { NewScopeMetricsSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ScopeMetrics) bool { return true }) })
+	assert.Panics(t, func() { NewScopeMetricsSlice().CopyTo(es) })
+}
+
 func TestScopeMetrics_MoveTo(t *testing.T) {
 	ms := generateTestScopeMetrics()
 	dest := NewScopeMetrics()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewScopeMetrics(), ms)
 	assert.EqualValues(t, generateTestScopeMetrics(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newScopeMetrics(&otlpmetrics.ScopeMetrics{}, &sharedState)) })
+	assert.Panics(t, func() { newScopeMetrics(&otlpmetrics.ScopeMetrics{}, &sharedState).MoveTo(dest) })
 }
 
 func TestScopeMetrics_CopyTo(t *testing.T) {
@@ -301,6 +344,8 @@ func TestScopeMetrics_CopyTo(t *testing.T) {
 	orig = generateTestScopeMetrics()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newScopeMetrics(&otlpmetrics.ScopeMetrics{}, &sharedState)) })
 }
 
 func TestScopeMetrics_Scope(t *testing.T) {
@@ -315,6 +360,8 @@ func TestScopeMetrics_SchemaUrl(t *testing.T) {
 	testValSchemaUrl := "https://opentelemetry.io/schemas/1.5.0"
 	ms.SetSchemaUrl(testValSchemaUrl)
 	assert.EqualValues(t, testValSchemaUrl, ms.SchemaUrl())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newScopeMetrics(&otlpmetrics.ScopeMetrics{}, &sharedState).SetSchemaUrl(testValSchemaUrl) })
 }
 
 func TestScopeMetrics_Metrics(t *testing.T) {
@@ -325,14 +372,22 @@ func TestScopeMetrics_Metrics(t *testing.T) {
 	assert.EqualValues(t, testValMetrics, ms.Metrics())
 }
 
+func TestScopeMetrics_HasMetrics(t *testing.T) {
+	ms := NewScopeMetrics()
+	assert.False(t, ms.HasMetrics())
+	fillTestMetricSlice(ms.Metrics())
+	assert.True(t, ms.HasMetrics())
+}
+
 func TestMetricSlice(t *testing.T) {
 	es := NewMetricSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newMetricSlice(&[]*otlpmetrics.Metric{})
+	state := StateMutable
+	es = newMetricSlice(&[]*otlpmetrics.Metric{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newMetric(&otlpmetrics.Metric{})
+	emptyVal := newMetric(&otlpmetrics.Metric{}, &state)
 	testVal := generateTestMetric()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -435,12 +490,26 @@ func TestMetricSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestMetricSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newMetricSlice(&[]*otlpmetrics.Metric{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewMetricSlice()) })
+	assert.Panics(t, func() { NewMetricSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el Metric) bool { return true }) })
+	assert.Panics(t, func() { NewMetricSlice().CopyTo(es) })
+}
+
 func TestMetric_MoveTo(t *testing.T) {
 	ms := generateTestMetric()
 	dest := NewMetric()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewMetric(), ms)
 	assert.EqualValues(t, generateTestMetric(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).MoveTo(dest) })
 }
 
 func TestMetric_CopyTo(t *testing.T) {
@@ -451,6 +520,8 @@ func TestMetric_CopyTo(t *testing.T) {
 	orig = generateTestMetric()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newMetric(&otlpmetrics.Metric{}, &sharedState)) })
 }
 
 func TestMetric_Name(t *testing.T) {
@@ -459,6 +530,8 @@ func TestMetric_Name(t *testing.T) {
 	testValName := "test_name"
 	ms.SetName(testValName)
 	assert.EqualValues(t, testValName, ms.Name())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetName(testValName) })
 }
 
 func TestMetric_Description(t *testing.T) {
@@ -467,6 +540,8 @@ func TestMetric_Description(t *testing.T) {
 	testValDescription := "test_description"
 	ms.SetDescription(testValDescription)
 	assert.EqualValues(t, testValDescription, ms.Description())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetDescription(testValDescription) })
 }
 
 func TestMetric_Unit(t *testing.T) {
@@ -475,6 +550,8 @@ func TestMetric_Unit(t *testing.T) {
 	testValUnit := "1"
 	ms.SetUnit(testValUnit)
 	assert.EqualValues(t, testValUnit, ms.Unit())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newMetric(&otlpmetrics.Metric{}, &sharedState).SetUnit(testValUnit) })
 }
 
 func TestMetricDataType(t *testing.T) {
@@ -589,6 +666,9 @@ func TestGauge_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewGauge(), ms)
 	assert.EqualValues(t, generateTestGauge(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newGauge(&otlpmetrics.Gauge{}, &sharedState)) })
+	assert.Panics(t, func() { newGauge(&otlpmetrics.Gauge{}, &sharedState).MoveTo(dest) })
 }
 
 func TestGauge_CopyTo(t *testing.T) {
@@ -599,6 +679,8 @@ func TestGauge_CopyTo(t *testing.T) {
 	orig = generateTestGauge()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newGauge(&otlpmetrics.Gauge{}, &sharedState)) })
 }
 
 func TestGauge_DataPoints(t *testing.T) {
@@ -609,12 +691,22 @@ func TestGauge_DataPoints(t *testing.T) {
 	assert.EqualValues(t, testValDataPoints, ms.DataPoints())
 }
 
+func TestGauge_HasDataPoints(t *testing.T) {
+	ms := NewGauge()
+	assert.False(t, ms.HasDataPoints())
+	fillTestNumberDataPointSlice(ms.DataPoints())
+	assert.True(t, ms.HasDataPoints())
+}
+
 func TestSum_MoveTo(t *testing.T) {
 	ms := generateTestSum()
 	dest := NewSum()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewSum(), ms)
 	assert.EqualValues(t, generateTestSum(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSum(&otlpmetrics.Sum{}, &sharedState)) })
+	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSum_CopyTo(t *testing.T) {
@@ -625,6 +717,8 @@ func TestSum_CopyTo(t *testing.T) {
 	orig = generateTestSum()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSum(&otlpmetrics.Sum{}, &sharedState)) })
 }
 
 func TestSum_AggregationTemporality(t *testing.T) {
@@ -633,6 +727,10 @@ func TestSum_AggregationTemporality(t *testing.T) {
 	testValAggregationTemporality := MetricAggregationTemporalityCumulative
 	ms.SetAggregationTemporality(testValAggregationTemporality)
 	assert.EqualValues(t, testValAggregationTemporality, ms.AggregationTemporality())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newSum(&otlpmetrics.Sum{}, &sharedState).SetAggregationTemporality(testValAggregationTemporality)
+	})
 }
 
 func TestSum_IsMonotonic(t *testing.T) {
@@ -641,6 +739,8 @@ func TestSum_IsMonotonic(t *testing.T) {
 	testValIsMonotonic := true
 	ms.SetIsMonotonic(testValIsMonotonic)
 	assert.EqualValues(t, testValIsMonotonic, ms.IsMonotonic())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSum(&otlpmetrics.Sum{}, &sharedState).SetIsMonotonic(testValIsMonotonic) })
 }
 
 func TestSum_DataPoints(t *testing.T) {
@@ -651,12 +751,22 @@ func TestSum_DataPoints(t *testing.T) {
 	assert.EqualValues(t, testValDataPoints, ms.DataPoints())
 }
 
+func TestSum_HasDataPoints(t *testing.T) {
+	ms := NewSum()
+	assert.False(t, ms.HasDataPoints())
+	fillTestNumberDataPointSlice(ms.DataPoints())
+	assert.True(t, ms.HasDataPoints())
+}
+
 func TestHistogram_MoveTo(t *testing.T) {
 	ms := generateTestHistogram()
 	dest := NewHistogram()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewHistogram(), ms)
 	assert.EqualValues(t, generateTestHistogram(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newHistogram(&otlpmetrics.Histogram{}, &sharedState)) })
+	assert.Panics(t, func() { newHistogram(&otlpmetrics.Histogram{}, &sharedState).MoveTo(dest) })
 }
 
 func TestHistogram_CopyTo(t *testing.T) {
@@ -667,6 +777,8 @@ func TestHistogram_CopyTo(t *testing.T) {
 	orig = generateTestHistogram()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newHistogram(&otlpmetrics.Histogram{}, &sharedState)) })
 }
 
 func TestHistogram_AggregationTemporality(t *testing.T) {
@@ -675,6 +787,10 @@ func TestHistogram_AggregationTemporality(t *testing.T) {
 	testValAggregationTemporality := MetricAggregationTemporalityCumulative
 	ms.SetAggregationTemporality(testValAggregationTemporality)
 	assert.EqualValues(t, testValAggregationTemporality, ms.AggregationTemporality())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newHistogram(&otlpmetrics.Histogram{}, &sharedState).SetAggregationTemporality(testValAggregationTemporality)
+	})
 }
 
 func TestHistogram_DataPoints(t *testing.T) {
@@ -685,12 +801,22 @@ func TestHistogram_DataPoints(t *testing.T) {
 	assert.EqualValues(t, testValDataPoints, ms.DataPoints())
 }
 
+func TestHistogram_HasDataPoints(t *testing.T) {
+	ms := NewHistogram()
+	assert.False(t, ms.HasDataPoints())
+	fillTestHistogramDataPointSlice(ms.DataPoints())
+	assert.True(t, ms.HasDataPoints())
+}
+
 func TestExponentialHistogram_MoveTo(t *testing.T) {
 	ms := generateTestExponentialHistogram()
 	dest := NewExponentialHistogram()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewExponentialHistogram(), ms)
 	assert.EqualValues(t, generateTestExponentialHistogram(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newExponentialHistogram(&otlpmetrics.ExponentialHistogram{}, &sharedState)) })
+	assert.Panics(t, func() { newExponentialHistogram(&otlpmetrics.ExponentialHistogram{}, &sharedState).MoveTo(dest) })
 }
 
 func TestExponentialHistogram_CopyTo(t *testing.T) {
@@ -701,6 +827,8 @@ func TestExponentialHistogram_CopyTo(t *testing.T) {
 	orig = generateTestExponentialHistogram()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newExponentialHistogram(&otlpmetrics.ExponentialHistogram{}, &sharedState)) })
 }
 
 func TestExponentialHistogram_AggregationTemporality(t *testing.T) {
@@ -709,6 +837,10 @@ func TestExponentialHistogram_AggregationTemporality(t *testing.T) {
 	testValAggregationTemporality := MetricAggregationTemporalityCumulative
 	ms.SetAggregationTemporality(testValAggregationTemporality)
 	assert.EqualValues(t, testValAggregationTemporality, ms.AggregationTemporality())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogram(&otlpmetrics.ExponentialHistogram{}, &sharedState).SetAggregationTemporality(testValAggregationTemporality)
+	})
 }
 
 func TestExponentialHistogram_DataPoints(t *testing.T) {
@@ -719,12 +851,22 @@ func TestExponentialHistogram_DataPoints(t *testing.T) {
 	assert.EqualValues(t, testValDataPoints, ms.DataPoints())
 }
 
+func TestExponentialHistogram_HasDataPoints(t *testing.T) {
+	ms := NewExponentialHistogram()
+	assert.False(t, ms.HasDataPoints())
+	fillTestExponentialHistogramDataPointSlice(ms.DataPoints())
+	assert.True(t, ms.HasDataPoints())
+}
+
 func TestSummary_MoveTo(t *testing.T) {
 	ms := generateTestSummary()
 	dest := NewSummary()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewSummary(), ms)
 	assert.EqualValues(t, generateTestSummary(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSummary(&otlpmetrics.Summary{}, &sharedState)) })
+	assert.Panics(t, func() { newSummary(&otlpmetrics.Summary{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSummary_CopyTo(t *testing.T) {
@@ -735,6 +877,8 @@ func TestSummary_CopyTo(t *testing.T) {
 	orig = generateTestSummary()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSummary(&otlpmetrics.Summary{}, &sharedState)) })
 }
 
 func TestSummary_DataPoints(t *testing.T) {
@@ -745,14 +889,22 @@ func TestSummary_DataPoints(t *testing.T) {
 	assert.EqualValues(t, testValDataPoints, ms.DataPoints())
 }
 
+func TestSummary_HasDataPoints(t *testing.T) {
+	ms := NewSummary()
+	assert.False(t, ms.HasDataPoints())
+	fillTestSummaryDataPointSlice(ms.DataPoints())
+	assert.True(t, ms.HasDataPoints())
+}
+
 func TestNumberDataPointSlice(t *testing.T) {
 	es := NewNumberDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{})
+	state := StateMutable
+	es = newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newNumberDataPoint(&otlpmetrics.NumberDataPoint{})
+	emptyVal := newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &state)
 	testVal := generateTestNumberDataPoint()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -855,12 +1007,26 @@ func TestNumberDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestNumberDataPointSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newNumberDataPointSlice(&[]*otlpmetrics.NumberDataPoint{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewNumberDataPointSlice()) })
+	assert.Panics(t, func() { NewNumberDataPointSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el NumberDataPoint) bool { return true }) })
+	assert.Panics(t, func() { NewNumberDataPointSlice().CopyTo(es) })
+}
+
 func TestNumberDataPoint_MoveTo(t *testing.T) {
 	ms := generateTestNumberDataPoint()
 	dest := NewNumberDataPoint()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewNumberDataPoint(), ms)
 	assert.EqualValues(t, generateTestNumberDataPoint(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState)) })
+	assert.Panics(t, func() { newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState).MoveTo(dest) })
 }
 
 func TestNumberDataPoint_CopyTo(t *testing.T) {
@@ -871,6 +1037,8 @@ func TestNumberDataPoint_CopyTo(t *testing.T) {
 	orig = generateTestNumberDataPoint()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState)) })
 }
 
 func TestNumberDataPoint_Attributes(t *testing.T) {
@@ -881,12 +1049,23 @@ func TestNumberDataPoint_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestNumberDataPoint_HasAttributes(t *testing.T) {
+	ms := NewNumberDataPoint()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestNumberDataPoint_StartTimestamp(t *testing.T) {
 	ms := NewNumberDataPoint()
 	assert.EqualValues(t, Timestamp(0), ms.StartTimestamp())
 	testValStartTimestamp := Timestamp(1234567890)
 	ms.SetStartTimestamp(testValStartTimestamp)
 	assert.EqualValues(t, testValStartTimestamp, ms.StartTimestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState).SetStartTimestamp(testValStartTimestamp)
+	})
 }
 
 func TestNumberDataPoint_Timestamp(t *testing.T) {
@@ -895,6 +1074,10 @@ func TestNumberDataPoint_Timestamp(t *testing.T) {
 	testValTimestamp := Timestamp(1234567890)
 	ms.SetTimestamp(testValTimestamp)
 	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState).SetTimestamp(testValTimestamp)
+	})
 }
 
 func TestNumberDataPointValueType(t *testing.T) {
@@ -913,6 +1096,10 @@ func TestNumberDataPoint_DoubleVal(t *testing.T) {
 	testValDoubleVal := float64(17.13)
 	ms.SetDoubleVal(testValDoubleVal)
 	assert.EqualValues(t, testValDoubleVal, ms.DoubleVal())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState).SetDoubleVal(testValDoubleVal)
+	})
 }
 
 func TestNumberDataPoint_IntVal(t *testing.T) {
@@ -921,6 +1108,8 @@ func TestNumberDataPoint_IntVal(t *testing.T) {
 	testValIntVal := int64(17)
 	ms.SetIntVal(testValIntVal)
 	assert.EqualValues(t, testValIntVal, ms.IntVal())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState).SetIntVal(testValIntVal) })
 }
 
 func TestNumberDataPoint_Exemplars(t *testing.T) {
@@ -931,22 +1120,32 @@ func TestNumberDataPoint_Exemplars(t *testing.T) {
 	assert.EqualValues(t, testValExemplars, ms.Exemplars())
 }
 
+func TestNumberDataPoint_HasExemplars(t *testing.T) {
+	ms := NewNumberDataPoint()
+	assert.False(t, ms.HasExemplars())
+	fillTestExemplarSlice(ms.Exemplars())
+	assert.True(t, ms.HasExemplars())
+}
+
 func TestNumberDataPoint_Flags(t *testing.T) {
 	ms := NewNumberDataPoint()
 	assert.EqualValues(t, MetricDataPointFlagsNone, ms.Flags())
 	testValFlags := MetricDataPointFlagsNone
 	ms.SetFlags(testValFlags)
 	assert.EqualValues(t, testValFlags, ms.Flags())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newNumberDataPoint(&otlpmetrics.NumberDataPoint{}, &sharedState).SetFlags(testValFlags) })
 }
 
 func TestHistogramDataPointSlice(t *testing.T) {
 	es := NewHistogramDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{})
+	state := StateMutable
+	es = newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{})
+	emptyVal := newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &state)
 	testVal := generateTestHistogramDataPoint()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1049,12 +1248,26 @@ func TestHistogramDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestHistogramDataPointSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newHistogramDataPointSlice(&[]*otlpmetrics.HistogramDataPoint{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewHistogramDataPointSlice()) })
+	assert.Panics(t, func() { NewHistogramDataPointSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el HistogramDataPoint) bool { return true }) })
+	assert.Panics(t, func() { NewHistogramDataPointSlice().CopyTo(es) })
+}
+
 func TestHistogramDataPoint_MoveTo(t *testing.T) {
 	ms := generateTestHistogramDataPoint()
 	dest := NewHistogramDataPoint()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewHistogramDataPoint(), ms)
 	assert.EqualValues(t, generateTestHistogramDataPoint(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState)) })
+	assert.Panics(t, func() { newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).MoveTo(dest) })
 }
 
 func TestHistogramDataPoint_CopyTo(t *testing.T) {
@@ -1065,6 +1278,8 @@ func TestHistogramDataPoint_CopyTo(t *testing.T) {
 	orig = generateTestHistogramDataPoint()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState)) })
 }
 
 func TestHistogramDataPoint_Attributes(t *testing.T) {
@@ -1075,12 +1290,23 @@ func TestHistogramDataPoint_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestHistogramDataPoint_HasAttributes(t *testing.T) {
+	ms := NewHistogramDataPoint()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestHistogramDataPoint_StartTimestamp(t *testing.T) {
 	ms := NewHistogramDataPoint()
 	assert.EqualValues(t, Timestamp(0), ms.StartTimestamp())
 	testValStartTimestamp := Timestamp(1234567890)
 	ms.SetStartTimestamp(testValStartTimestamp)
 	assert.EqualValues(t, testValStartTimestamp, ms.StartTimestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetStartTimestamp(testValStartTimestamp)
+	})
 }
 
 func TestHistogramDataPoint_Timestamp(t *testing.T) {
@@ -1089,6 +1315,10 @@ func TestHistogramDataPoint_Timestamp(t *testing.T) {
 	testValTimestamp := Timestamp(1234567890)
 	ms.SetTimestamp(testValTimestamp)
 	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetTimestamp(testValTimestamp)
+	})
 }
 
 func TestHistogramDataPoint_Count(t *testing.T) {
@@ -1097,6 +1327,8 @@ func TestHistogramDataPoint_Count(t *testing.T) {
 	testValCount := uint64(17)
 	ms.SetCount(testValCount)
 	assert.EqualValues(t, testValCount, ms.Count())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetCount(testValCount) })
 }
 
 func TestHistogramDataPoint_Sum(t *testing.T) {
@@ -1105,6 +1337,8 @@ func TestHistogramDataPoint_Sum(t *testing.T) {
 	testValSum := float64(17.13)
 	ms.SetSum(testValSum)
 	assert.EqualValues(t, testValSum, ms.Sum())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetSum(testValSum) })
 }
 
 func TestHistogramDataPoint_BucketCounts(t *testing.T) {
@@ -1113,6 +1347,10 @@ func TestHistogramDataPoint_BucketCounts(t *testing.T) {
 	testValBucketCounts := NewImmutableUInt64Slice([]uint64{1, 2, 3})
 	ms.SetBucketCounts(testValBucketCounts)
 	assert.EqualValues(t, testValBucketCounts, ms.BucketCounts())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetBucketCounts(testValBucketCounts)
+	})
 }
 
 func TestHistogramDataPoint_ExplicitBounds(t *testing.T) {
@@ -1121,6 +1359,10 @@ func TestHistogramDataPoint_ExplicitBounds(t *testing.T) {
 	testValExplicitBounds := NewImmutableFloat64Slice([]float64{1, 2, 3})
 	ms.SetExplicitBounds(testValExplicitBounds)
 	assert.EqualValues(t, testValExplicitBounds, ms.ExplicitBounds())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetExplicitBounds(testValExplicitBounds)
+	})
 }
 
 func TestHistogramDataPoint_Exemplars(t *testing.T) {
@@ -1131,12 +1373,21 @@ func TestHistogramDataPoint_Exemplars(t *testing.T) {
 	assert.EqualValues(t, testValExemplars, ms.Exemplars())
 }
 
+func TestHistogramDataPoint_HasExemplars(t *testing.T) {
+	ms := NewHistogramDataPoint()
+	assert.False(t, ms.HasExemplars())
+	fillTestExemplarSlice(ms.Exemplars())
+	assert.True(t, ms.HasExemplars())
+}
+
 func TestHistogramDataPoint_Flags(t *testing.T) {
 	ms := NewHistogramDataPoint()
 	assert.EqualValues(t, MetricDataPointFlagsNone, ms.Flags())
 	testValFlags := MetricDataPointFlagsNone
 	ms.SetFlags(testValFlags)
 	assert.EqualValues(t, testValFlags, ms.Flags())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetFlags(testValFlags) })
 }
 
 func TestHistogramDataPoint_Min(t *testing.T) {
@@ -1145,6 +1396,8 @@ func TestHistogramDataPoint_Min(t *testing.T) {
 	testValMin := float64(9.23)
 	ms.SetMin(testValMin)
 	assert.EqualValues(t, testValMin, ms.Min())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetMin(testValMin) })
 }
 
 func TestHistogramDataPoint_Max(t *testing.T) {
@@ -1153,16 +1406,19 @@ func TestHistogramDataPoint_Max(t *testing.T) {
 	testValMax := float64(182.55)
 	ms.SetMax(testValMax)
 	assert.EqualValues(t, testValMax, ms.Max())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newHistogramDataPoint(&otlpmetrics.HistogramDataPoint{}, &sharedState).SetMax(testValMax) })
 }
 
 func TestExponentialHistogramDataPointSlice(t *testing.T) {
 	es := NewExponentialHistogramDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newExponentialHistogramDataPointSlice(&[]*otlpmetrics.ExponentialHistogramDataPoint{})
+	state := StateMutable
+	es = newExponentialHistogramDataPointSlice(&[]*otlpmetrics.ExponentialHistogramDataPoint{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{})
+	emptyVal := newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &state)
 	testVal := generateTestExponentialHistogramDataPoint()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1265,12 +1521,30 @@ func TestExponentialHistogramDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestExponentialHistogramDataPointSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newExponentialHistogramDataPointSlice(&[]*otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewExponentialHistogramDataPointSlice()) })
+	assert.Panics(t, func() { NewExponentialHistogramDataPointSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ExponentialHistogramDataPoint) bool { return true }) })
+	assert.Panics(t, func() { NewExponentialHistogramDataPointSlice().CopyTo(es) })
+}
+
 func TestExponentialHistogramDataPoint_MoveTo(t *testing.T) {
 	ms := generateTestExponentialHistogramDataPoint()
 	dest := NewExponentialHistogramDataPoint()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewExponentialHistogramDataPoint(), ms)
 	assert.EqualValues(t, generateTestExponentialHistogramDataPoint(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		ms.MoveTo(newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState))
+	})
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).MoveTo(dest)
+	})
 }
 
 func TestExponentialHistogramDataPoint_CopyTo(t *testing.T) {
@@ -1281,6 +1555,10 @@ func TestExponentialHistogramDataPoint_CopyTo(t *testing.T) {
 	orig = generateTestExponentialHistogramDataPoint()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		ms.CopyTo(newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState))
+	})
 }
 
 func TestExponentialHistogramDataPoint_Attributes(t *testing.T) {
@@ -1291,12 +1569,23 @@ func TestExponentialHistogramDataPoint_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestExponentialHistogramDataPoint_HasAttributes(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestExponentialHistogramDataPoint_StartTimestamp(t *testing.T) {
 	ms := NewExponentialHistogramDataPoint()
 	assert.EqualValues(t, Timestamp(0), ms.StartTimestamp())
 	testValStartTimestamp := Timestamp(1234567890)
 	ms.SetStartTimestamp(testValStartTimestamp)
 	assert.EqualValues(t, testValStartTimestamp, ms.StartTimestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetStartTimestamp(testValStartTimestamp)
+	})
 }
 
 func TestExponentialHistogramDataPoint_Timestamp(t *testing.T) {
@@ -1305,6 +1594,10 @@ func TestExponentialHistogramDataPoint_Timestamp(t *testing.T) {
 	testValTimestamp := Timestamp(1234567890)
 	ms.SetTimestamp(testValTimestamp)
 	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetTimestamp(testValTimestamp)
+	})
 }
 
 func TestExponentialHistogramDataPoint_Count(t *testing.T) {
@@ -1313,6 +1606,10 @@ func TestExponentialHistogramDataPoint_Count(t *testing.T) {
 	testValCount := uint64(17)
 	ms.SetCount(testValCount)
 	assert.EqualValues(t, testValCount, ms.Count())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetCount(testValCount)
+	})
 }
 
 func TestExponentialHistogramDataPoint_Sum(t *testing.T) {
@@ -1321,6 +1618,10 @@ func TestExponentialHistogramDataPoint_Sum(t *testing.T) {
 	testValSum := float64(17.13)
 	ms.SetSum(testValSum)
 	assert.EqualValues(t, testValSum, ms.Sum())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetSum(testValSum)
+	})
 }
 
 func TestExponentialHistogramDataPoint_Scale(t *testing.T) {
@@ -1329,6 +1630,10 @@ func TestExponentialHistogramDataPoint_Scale(t *testing.T) {
 	testValScale := int32(4)
 	ms.SetScale(testValScale)
 	assert.EqualValues(t, testValScale, ms.Scale())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetScale(testValScale)
+	})
 }
 
 func TestExponentialHistogramDataPoint_ZeroCount(t *testing.T) {
@@ -1337,6 +1642,10 @@ func TestExponentialHistogramDataPoint_ZeroCount(t *testing.T) {
 	testValZeroCount := uint64(201)
 	ms.SetZeroCount(testValZeroCount)
 	assert.EqualValues(t, testValZeroCount, ms.ZeroCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetZeroCount(testValZeroCount)
+	})
 }
 
 func TestExponentialHistogramDataPoint_Positive(t *testing.T) {
@@ -1359,12 +1668,23 @@ func TestExponentialHistogramDataPoint_Exemplars(t *testing.T) {
 	assert.EqualValues(t, testValExemplars, ms.Exemplars())
 }
 
+func TestExponentialHistogramDataPoint_HasExemplars(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	assert.False(t, ms.HasExemplars())
+	fillTestExemplarSlice(ms.Exemplars())
+	assert.True(t, ms.HasExemplars())
+}
+
 func TestExponentialHistogramDataPoint_Flags(t *testing.T) {
 	ms := NewExponentialHistogramDataPoint()
 	assert.EqualValues(t, MetricDataPointFlagsNone, ms.Flags())
 	testValFlags := MetricDataPointFlagsNone
 	ms.SetFlags(testValFlags)
 	assert.EqualValues(t, testValFlags, ms.Flags())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetFlags(testValFlags)
+	})
 }
 
 func TestExponentialHistogramDataPoint_Min(t *testing.T) {
@@ -1373,6 +1693,10 @@ func TestExponentialHistogramDataPoint_Min(t *testing.T) {
 	testValMin := float64(9.23)
 	ms.SetMin(testValMin)
 	assert.EqualValues(t, testValMin, ms.Min())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetMin(testValMin)
+	})
 }
 
 func TestExponentialHistogramDataPoint_Max(t *testing.T) {
@@ -1381,6 +1705,22 @@ func TestExponentialHistogramDataPoint_Max(t *testing.T) {
 	testValMax := float64(182.55)
 	ms.SetMax(testValMax)
 	assert.EqualValues(t, testValMax, ms.Max())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetMax(testValMax)
+	})
+}
+
+func TestExponentialHistogramDataPoint_ZeroThreshold(t *testing.T) {
+	ms := NewExponentialHistogramDataPoint()
+	assert.EqualValues(t, float64(0.0), ms.ZeroThreshold())
+	testValZeroThreshold := float64(0.001)
+	ms.SetZeroThreshold(testValZeroThreshold)
+	assert.EqualValues(t, testValZeroThreshold, ms.ZeroThreshold())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newExponentialHistogramDataPoint(&otlpmetrics.ExponentialHistogramDataPoint{}, &sharedState).SetZeroThreshold(testValZeroThreshold)
+	})
 }
 
 func TestBuckets_MoveTo(t *testing.T) {
@@ -1389,6 +1729,9 @@ func TestBuckets_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewBuckets(), ms)
 	assert.EqualValues(t, generateTestBuckets(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newBuckets(&otlpmetrics.ExponentialHistogramDataPoint_Buckets{}, &sharedState)) })
+	assert.Panics(t, func() { newBuckets(&otlpmetrics.ExponentialHistogramDataPoint_Buckets{}, &sharedState).MoveTo(dest) })
 }
 
 func TestBuckets_CopyTo(t *testing.T) {
@@ -1399,6 +1742,8 @@ func TestBuckets_CopyTo(t *testing.T) {
 	orig = generateTestBuckets()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newBuckets(&otlpmetrics.ExponentialHistogramDataPoint_Buckets{}, &sharedState)) })
 }
 
 func TestBuckets_Offset(t *testing.T) {
@@ -1407,6 +1752,10 @@ func TestBuckets_Offset(t *testing.T) {
 	testValOffset := int32(909)
 	ms.SetOffset(testValOffset)
 	assert.EqualValues(t, testValOffset, ms.Offset())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newBuckets(&otlpmetrics.ExponentialHistogramDataPoint_Buckets{}, &sharedState).SetOffset(testValOffset)
+	})
 }
 
 func TestBuckets_BucketCounts(t *testing.T) {
@@ -1415,16 +1764,21 @@ func TestBuckets_BucketCounts(t *testing.T) {
 	testValBucketCounts := NewImmutableUInt64Slice([]uint64{1, 2, 3})
 	ms.SetBucketCounts(testValBucketCounts)
 	assert.EqualValues(t, testValBucketCounts, ms.BucketCounts())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newBuckets(&otlpmetrics.ExponentialHistogramDataPoint_Buckets{}, &sharedState).SetBucketCounts(testValBucketCounts)
+	})
 }
 
 func TestSummaryDataPointSlice(t *testing.T) {
 	es := NewSummaryDataPointSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{})
+	state := StateMutable
+	es = newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{})
+	emptyVal := newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &state)
 	testVal := generateTestSummaryDataPoint()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1527,12 +1881,26 @@ func TestSummaryDataPointSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSummaryDataPointSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newSummaryDataPointSlice(&[]*otlpmetrics.SummaryDataPoint{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewSummaryDataPointSlice()) })
+	assert.Panics(t, func() { NewSummaryDataPointSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el SummaryDataPoint) bool { return true }) })
+	assert.Panics(t, func() { NewSummaryDataPointSlice().CopyTo(es) })
+}
+
 func TestSummaryDataPoint_MoveTo(t *testing.T) {
 	ms := generateTestSummaryDataPoint()
 	dest := NewSummaryDataPoint()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewSummaryDataPoint(), ms)
 	assert.EqualValues(t, generateTestSummaryDataPoint(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState)) })
+	assert.Panics(t, func() { newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSummaryDataPoint_CopyTo(t *testing.T) {
@@ -1543,6 +1911,8 @@ func TestSummaryDataPoint_CopyTo(t *testing.T) {
 	orig = generateTestSummaryDataPoint()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState)) })
 }
 
 func TestSummaryDataPoint_Attributes(t *testing.T) {
@@ -1553,12 +1923,23 @@ func TestSummaryDataPoint_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestSummaryDataPoint_HasAttributes(t *testing.T) {
+	ms := NewSummaryDataPoint()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestSummaryDataPoint_StartTimestamp(t *testing.T) {
 	ms := NewSummaryDataPoint()
 	assert.EqualValues(t, Timestamp(0), ms.StartTimestamp())
 	testValStartTimestamp := Timestamp(1234567890)
 	ms.SetStartTimestamp(testValStartTimestamp)
 	assert.EqualValues(t, testValStartTimestamp, ms.StartTimestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState).SetStartTimestamp(testValStartTimestamp)
+	})
 }
 
 func TestSummaryDataPoint_Timestamp(t *testing.T) {
@@ -1567,6 +1948,10 @@ func TestSummaryDataPoint_Timestamp(t *testing.T) {
 	testValTimestamp := Timestamp(1234567890)
 	ms.SetTimestamp(testValTimestamp)
 	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState).SetTimestamp(testValTimestamp)
+	})
 }
 
 func TestSummaryDataPoint_Count(t *testing.T) {
@@ -1575,6 +1960,8 @@ func TestSummaryDataPoint_Count(t *testing.T) {
 	testValCount := uint64(17)
 	ms.SetCount(testValCount)
 	assert.EqualValues(t, testValCount, ms.Count())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState).SetCount(testValCount) })
 }
 
 func TestSummaryDataPoint_Sum(t *testing.T) {
@@ -1583,6 +1970,8 @@ func TestSummaryDataPoint_Sum(t *testing.T) {
 	testValSum := float64(17.13)
 	ms.SetSum(testValSum)
 	assert.EqualValues(t, testValSum, ms.Sum())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState).SetSum(testValSum) })
 }
 
 func TestSummaryDataPoint_QuantileValues(t *testing.T) {
@@ -1593,22 +1982,32 @@ func TestSummaryDataPoint_QuantileValues(t *testing.T) {
 	assert.EqualValues(t, testValQuantileValues, ms.QuantileValues())
 }
 
+func TestSummaryDataPoint_HasQuantileValues(t *testing.T) {
+	ms := NewSummaryDataPoint()
+	assert.False(t, ms.HasQuantileValues())
+	fillTestValueAtQuantileSlice(ms.QuantileValues())
+	assert.True(t, ms.HasQuantileValues())
+}
+
 func TestSummaryDataPoint_Flags(t *testing.T) {
 	ms := NewSummaryDataPoint()
 	assert.EqualValues(t, MetricDataPointFlagsNone, ms.Flags())
 	testValFlags := MetricDataPointFlagsNone
 	ms.SetFlags(testValFlags)
 	assert.EqualValues(t, testValFlags, ms.Flags())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSummaryDataPoint(&otlpmetrics.SummaryDataPoint{}, &sharedState).SetFlags(testValFlags) })
 }
 
 func TestValueAtQuantileSlice(t *testing.T) {
 	es := NewValueAtQuantileSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newValueAtQuantileSlice(&[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
+	state := StateMutable
+	es = newValueAtQuantileSlice(&[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{})
+	emptyVal := newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &state)
 	testVal := generateTestValueAtQuantile()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1711,12 +2110,26 @@ func TestValueAtQuantileSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestValueAtQuantileSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newValueAtQuantileSlice(&[]*otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewValueAtQuantileSlice()) })
+	assert.Panics(t, func() { NewValueAtQuantileSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ValueAtQuantile) bool { return true }) })
+	assert.Panics(t, func() { NewValueAtQuantileSlice().CopyTo(es) })
+}
+
 func TestValueAtQuantile_MoveTo(t *testing.T) {
 	ms := generateTestValueAtQuantile()
 	dest := NewValueAtQuantile()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewValueAtQuantile(), ms)
 	assert.EqualValues(t, generateTestValueAtQuantile(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &sharedState)) })
+	assert.Panics(t, func() { newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &sharedState).MoveTo(dest) })
 }
 
 func TestValueAtQuantile_CopyTo(t *testing.T) {
@@ -1727,6 +2140,8 @@ func TestValueAtQuantile_CopyTo(t *testing.T) {
 	orig = generateTestValueAtQuantile()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &sharedState)) })
 }
 
 func TestValueAtQuantile_Quantile(t *testing.T) {
@@ -1735,6 +2150,10 @@ func TestValueAtQuantile_Quantile(t *testing.T) {
 	testValQuantile := float64(17.13)
 	ms.SetQuantile(testValQuantile)
 	assert.EqualValues(t, testValQuantile, ms.Quantile())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &sharedState).SetQuantile(testValQuantile)
+	})
 }
 
 func TestValueAtQuantile_Value(t *testing.T) {
@@ -1743,16 +2162,21 @@ func TestValueAtQuantile_Value(t *testing.T) {
 	testValValue := float64(17.13)
 	ms.SetValue(testValValue)
 	assert.EqualValues(t, testValValue, ms.Value())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newValueAtQuantile(&otlpmetrics.SummaryDataPoint_ValueAtQuantile{}, &sharedState).SetValue(testValValue)
+	})
 }
 
 func TestExemplarSlice(t *testing.T) {
 	es := NewExemplarSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newExemplarSlice(&[]otlpmetrics.Exemplar{})
+	state := StateMutable
+	es = newExemplarSlice(&[]otlpmetrics.Exemplar{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newExemplar(&otlpmetrics.Exemplar{})
+	emptyVal := newExemplar(&otlpmetrics.Exemplar{}, &state)
 	testVal := generateTestExemplar()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -1846,12 +2270,26 @@ func TestExemplarSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestExemplarSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newExemplarSlice(&[]otlpmetrics.Exemplar{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewExemplarSlice()) })
+	assert.Panics(t, func() { NewExemplarSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el Exemplar) bool { return true }) })
+	assert.Panics(t, func() { NewExemplarSlice().CopyTo(es) })
+}
+
 func TestExemplar_MoveTo(t *testing.T) {
 	ms := generateTestExemplar()
 	dest := NewExemplar()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewExemplar(), ms)
 	assert.EqualValues(t, generateTestExemplar(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newExemplar(&otlpmetrics.Exemplar{}, &sharedState)) })
+	assert.Panics(t, func() { newExemplar(&otlpmetrics.Exemplar{}, &sharedState).MoveTo(dest) })
 }
 
 func TestExemplar_CopyTo(t *testing.T) {
@@ -1862,6 +2300,8 @@ func TestExemplar_CopyTo(t *testing.T) {
 	orig = generateTestExemplar()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newExemplar(&otlpmetrics.Exemplar{}, &sharedState)) })
 }
 
 func TestExemplar_Timestamp(t *testing.T) {
@@ -1870,6 +2310,8 @@ func TestExemplar_Timestamp(t *testing.T) {
 	testValTimestamp := Timestamp(1234567890)
 	ms.SetTimestamp(testValTimestamp)
 	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newExemplar(&otlpmetrics.Exemplar{}, &sharedState).SetTimestamp(testValTimestamp) })
 }
 
 func TestExemplarValueType(t *testing.T) {
@@ -1888,6 +2330,8 @@ func TestExemplar_DoubleVal(t *testing.T) {
 	testValDoubleVal := float64(17.13)
 	ms.SetDoubleVal(testValDoubleVal)
 	assert.EqualValues(t, testValDoubleVal, ms.DoubleVal())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newExemplar(&otlpmetrics.Exemplar{}, &sharedState).SetDoubleVal(testValDoubleVal) })
 }
 
 func TestExemplar_IntVal(t *testing.T) {
@@ -1896,6 +2340,8 @@ func TestExemplar_IntVal(t *testing.T) {
 	testValIntVal := int64(17)
 	ms.SetIntVal(testValIntVal)
 	assert.EqualValues(t, testValIntVal, ms.IntVal())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newExemplar(&otlpmetrics.Exemplar{}, &sharedState).SetIntVal(testValIntVal) })
 }
 
 func TestExemplar_FilteredAttributes(t *testing.T) {
@@ -1906,12 +2352,21 @@ func TestExemplar_FilteredAttributes(t *testing.T) {
 	assert.EqualValues(t, testValFilteredAttributes, ms.FilteredAttributes())
 }
 
+func TestExemplar_HasFilteredAttributes(t *testing.T) {
+	ms := NewExemplar()
+	assert.False(t, ms.HasFilteredAttributes())
+	fillTestMap(ms.FilteredAttributes())
+	assert.True(t, ms.HasFilteredAttributes())
+}
+
 func TestExemplar_TraceID(t *testing.T) {
 	ms := NewExemplar()
 	assert.EqualValues(t, NewTraceID([16]byte{}), ms.TraceID())
 	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
 	ms.SetTraceID(testValTraceID)
 	assert.EqualValues(t, testValTraceID, ms.TraceID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newExemplar(&otlpmetrics.Exemplar{}, &sharedState).SetTraceID(testValTraceID) })
 }
 
 func TestExemplar_SpanID(t *testing.T) {
@@ -1920,6 +2375,8 @@ func TestExemplar_SpanID(t *testing.T) {
 	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
 	ms.SetSpanID(testValSpanID)
 	assert.EqualValues(t, testValSpanID, ms.SpanID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newExemplar(&otlpmetrics.Exemplar{}, &sharedState).SetSpanID(testValSpanID) })
 }
 
 func generateTestResourceMetricsSlice() ResourceMetricsSlice {
@@ -2153,6 +2610,7 @@ func fillTestExponentialHistogramDataPoint(tv ExponentialHistogramDataPoint) {
 	tv.SetFlags(MetricDataPointFlagsNone)
 	tv.SetMin(float64(9.23))
 	tv.SetMax(float64(182.55))
+	tv.SetZeroThreshold(float64(0.001))
 }
 
 func generateTestBuckets() Buckets {
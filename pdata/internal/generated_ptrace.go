@@ -33,18 +33,20 @@ import (
 type ResourceSpansSlice struct {
 	// orig points to the slice otlptrace.ResourceSpans field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.ResourceSpans
+	orig  *[]*otlptrace.ResourceSpans
+	state *State
 }
 
-func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans) ResourceSpansSlice {
-	return ResourceSpansSlice{orig}
+func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans, state *State) ResourceSpansSlice {
+	return ResourceSpansSlice{orig: orig, state: state}
 }
 
 // NewResourceSpansSlice creates a ResourceSpansSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewResourceSpansSlice() ResourceSpansSlice {
 	orig := []*otlptrace.ResourceSpans(nil)
-	return ResourceSpansSlice{&orig}
+	state := StateMutable
+	return newResourceSpansSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -62,17 +64,18 @@ func (es ResourceSpansSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ResourceSpansSlice) At(ix int) ResourceSpans {
-	return newResourceSpans((*es.orig)[ix])
+	return newResourceSpans((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newResourceSpans((*es.orig)[i]).CopyTo(newResourceSpans((*dest.orig)[i]))
+			newResourceSpans((*es.orig)[i], es.state).CopyTo(newResourceSpans((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -80,7 +83,7 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 	wrappers := make([]*otlptrace.ResourceSpans, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newResourceSpans((*es.orig)[i]).CopyTo(newResourceSpans(wrappers[i]))
+		newResourceSpans((*es.orig)[i], es.state).CopyTo(newResourceSpans(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -97,6 +100,7 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ResourceSpansSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -110,6 +114,7 @@ func (es ResourceSpansSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ResourceSpans.
 // It returns the newly added ResourceSpans.
 func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlptrace.ResourceSpans{})
 	return es.At(es.Len() - 1)
 }
@@ -124,6 +129,7 @@ func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ResourceSpansSlice) Sort(less func(a, b ResourceSpans) bool) ResourceSpansSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -131,6 +137,8 @@ func (es ResourceSpansSlice) Sort(less func(a, b ResourceSpans) bool) ResourceSp
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -143,6 +151,7 @@ func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -168,11 +177,12 @@ func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 // Must use NewResourceSpans function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ResourceSpans struct {
-	orig *otlptrace.ResourceSpans
+	orig  *otlptrace.ResourceSpans
+	state *State
 }
 
-func newResourceSpans(orig *otlptrace.ResourceSpans) ResourceSpans {
-	return ResourceSpans{orig: orig}
+func newResourceSpans(orig *otlptrace.ResourceSpans, state *State) ResourceSpans {
+	return ResourceSpans{orig: orig, state: state}
 }
 
 // NewResourceSpans creates a new empty ResourceSpans.
@@ -180,19 +190,22 @@ func newResourceSpans(orig *otlptrace.ResourceSpans) ResourceSpans {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResourceSpans() ResourceSpans {
-	return newResourceSpans(&otlptrace.ResourceSpans{})
+	state := StateMutable
+	return newResourceSpans(&otlptrace.ResourceSpans{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ResourceSpans) MoveTo(dest ResourceSpans) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.ResourceSpans{}
 }
 
 // Resource returns the resource associated with this ResourceSpans.
 func (ms ResourceSpans) Resource() Resource {
-	return newResource(&(*ms.orig).Resource)
+	return newResource(&(*ms.orig).Resource, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceSpans.
@@ -202,16 +215,24 @@ func (ms ResourceSpans) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceSpans.
 func (ms ResourceSpans) SetSchemaUrl(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // ScopeSpans returns the ScopeSpans associated with this ResourceSpans.
 func (ms ResourceSpans) ScopeSpans() ScopeSpansSlice {
-	return newScopeSpansSlice(&(*ms.orig).ScopeSpans)
+	return newScopeSpansSlice(&(*ms.orig).ScopeSpans, ms.state)
+}
+
+// HasScopeSpans returns true if the ScopeSpans associated with this ResourceSpans is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms ResourceSpans) HasScopeSpans() bool {
+	return len((*ms.orig).ScopeSpans) > 0
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
+	dest.state.AssertMutable()
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeSpans().CopyTo(dest.ScopeSpans())
@@ -227,18 +248,20 @@ func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
 type ScopeSpansSlice struct {
 	// orig points to the slice otlptrace.ScopeSpans field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.ScopeSpans
+	orig  *[]*otlptrace.ScopeSpans
+	state *State
 }
 
-func newScopeSpansSlice(orig *[]*otlptrace.ScopeSpans) ScopeSpansSlice {
-	return ScopeSpansSlice{orig}
+func newScopeSpansSlice(orig *[]*otlptrace.ScopeSpans, state *State) ScopeSpansSlice {
+	return ScopeSpansSlice{orig: orig, state: state}
 }
 
 // NewScopeSpansSlice creates a ScopeSpansSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewScopeSpansSlice() ScopeSpansSlice {
 	orig := []*otlptrace.ScopeSpans(nil)
-	return ScopeSpansSlice{&orig}
+	state := StateMutable
+	return newScopeSpansSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -256,17 +279,18 @@ func (es ScopeSpansSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es ScopeSpansSlice) At(ix int) ScopeSpans {
-	return newScopeSpans((*es.orig)[ix])
+	return newScopeSpans((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es ScopeSpansSlice) CopyTo(dest ScopeSpansSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newScopeSpans((*es.orig)[i]).CopyTo(newScopeSpans((*dest.orig)[i]))
+			newScopeSpans((*es.orig)[i], es.state).CopyTo(newScopeSpans((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -274,7 +298,7 @@ func (es ScopeSpansSlice) CopyTo(dest ScopeSpansSlice) {
 	wrappers := make([]*otlptrace.ScopeSpans, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newScopeSpans((*es.orig)[i]).CopyTo(newScopeSpans(wrappers[i]))
+		newScopeSpans((*es.orig)[i], es.state).CopyTo(newScopeSpans(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -291,6 +315,7 @@ func (es ScopeSpansSlice) CopyTo(dest ScopeSpansSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es ScopeSpansSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -304,6 +329,7 @@ func (es ScopeSpansSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty ScopeSpans.
 // It returns the newly added ScopeSpans.
 func (es ScopeSpansSlice) AppendEmpty() ScopeSpans {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlptrace.ScopeSpans{})
 	return es.At(es.Len() - 1)
 }
@@ -318,6 +344,7 @@ func (es ScopeSpansSlice) AppendEmpty() ScopeSpans {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es ScopeSpansSlice) Sort(less func(a, b ScopeSpans) bool) ScopeSpansSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -325,6 +352,8 @@ func (es ScopeSpansSlice) Sort(less func(a, b ScopeSpans) bool) ScopeSpansSlice
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es ScopeSpansSlice) MoveAndAppendTo(dest ScopeSpansSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -337,6 +366,7 @@ func (es ScopeSpansSlice) MoveAndAppendTo(dest ScopeSpansSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es ScopeSpansSlice) RemoveIf(f func(ScopeSpans) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -362,11 +392,12 @@ func (es ScopeSpansSlice) RemoveIf(f func(ScopeSpans) bool) {
 // Must use NewScopeSpans function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ScopeSpans struct {
-	orig *otlptrace.ScopeSpans
+	orig  *otlptrace.ScopeSpans
+	state *State
 }
 
-func newScopeSpans(orig *otlptrace.ScopeSpans) ScopeSpans {
-	return ScopeSpans{orig: orig}
+func newScopeSpans(orig *otlptrace.ScopeSpans, state *State) ScopeSpans {
+	return ScopeSpans{orig: orig, state: state}
 }
 
 // NewScopeSpans creates a new empty ScopeSpans.
@@ -374,19 +405,22 @@ func newScopeSpans(orig *otlptrace.ScopeSpans) ScopeSpans {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewScopeSpans() ScopeSpans {
-	return newScopeSpans(&otlptrace.ScopeSpans{})
+	state := StateMutable
+	return newScopeSpans(&otlptrace.ScopeSpans{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms ScopeSpans) MoveTo(dest ScopeSpans) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.ScopeSpans{}
 }
 
 // Scope returns the scope associated with this ScopeSpans.
 func (ms ScopeSpans) Scope() InstrumentationScope {
-	return newInstrumentationScope(&(*ms.orig).Scope)
+	return newInstrumentationScope(&(*ms.orig).Scope, ms.state)
 }
 
 // SchemaUrl returns the schemaurl associated with this ScopeSpans.
@@ -396,16 +430,24 @@ func (ms ScopeSpans) SchemaUrl() string {
 
 // SetSchemaUrl replaces the schemaurl associated with this ScopeSpans.
 func (ms ScopeSpans) SetSchemaUrl(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).SchemaUrl = v
 }
 
 // Spans returns the Spans associated with this ScopeSpans.
 func (ms ScopeSpans) Spans() SpanSlice {
-	return newSpanSlice(&(*ms.orig).Spans)
+	return newSpanSlice(&(*ms.orig).Spans, ms.state)
+}
+
+// HasSpans returns true if the Spans associated with this ScopeSpans is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms ScopeSpans) HasSpans() bool {
+	return len((*ms.orig).Spans) > 0
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms ScopeSpans) CopyTo(dest ScopeSpans) {
+	dest.state.AssertMutable()
 	ms.Scope().CopyTo(dest.Scope())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.Spans().CopyTo(dest.Spans())
@@ -421,18 +463,20 @@ func (ms ScopeSpans) CopyTo(dest ScopeSpans) {
 type SpanSlice struct {
 	// orig points to the slice otlptrace.Span field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.Span
+	orig  *[]*otlptrace.Span
+	state *State
 }
 
-func newSpanSlice(orig *[]*otlptrace.Span) SpanSlice {
-	return SpanSlice{orig}
+func newSpanSlice(orig *[]*otlptrace.Span, state *State) SpanSlice {
+	return SpanSlice{orig: orig, state: state}
 }
 
 // NewSpanSlice creates a SpanSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanSlice() SpanSlice {
 	orig := []*otlptrace.Span(nil)
-	return SpanSlice{&orig}
+	state := StateMutable
+	return newSpanSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -450,17 +494,18 @@ func (es SpanSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanSlice) At(ix int) Span {
-	return newSpan((*es.orig)[ix])
+	return newSpan((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanSlice) CopyTo(dest SpanSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newSpan((*es.orig)[i]).CopyTo(newSpan((*dest.orig)[i]))
+			newSpan((*es.orig)[i], es.state).CopyTo(newSpan((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -468,7 +513,7 @@ func (es SpanSlice) CopyTo(dest SpanSlice) {
 	wrappers := make([]*otlptrace.Span, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newSpan((*es.orig)[i]).CopyTo(newSpan(wrappers[i]))
+		newSpan((*es.orig)[i], es.state).CopyTo(newSpan(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -485,6 +530,7 @@ func (es SpanSlice) CopyTo(dest SpanSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -498,6 +544,7 @@ func (es SpanSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty Span.
 // It returns the newly added Span.
 func (es SpanSlice) AppendEmpty() Span {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlptrace.Span{})
 	return es.At(es.Len() - 1)
 }
@@ -512,6 +559,7 @@ func (es SpanSlice) AppendEmpty() Span {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanSlice) Sort(less func(a, b Span) bool) SpanSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -519,6 +567,8 @@ func (es SpanSlice) Sort(less func(a, b Span) bool) SpanSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanSlice) MoveAndAppendTo(dest SpanSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -531,6 +581,7 @@ func (es SpanSlice) MoveAndAppendTo(dest SpanSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanSlice) RemoveIf(f func(Span) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -557,11 +608,12 @@ func (es SpanSlice) RemoveIf(f func(Span) bool) {
 // Must use NewSpan function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type Span struct {
-	orig *otlptrace.Span
+	orig  *otlptrace.Span
+	state *State
 }
 
-func newSpan(orig *otlptrace.Span) Span {
-	return Span{orig: orig}
+func newSpan(orig *otlptrace.Span, state *State) Span {
+	return Span{orig: orig, state: state}
 }
 
 // NewSpan creates a new empty Span.
@@ -569,12 +621,15 @@ func newSpan(orig *otlptrace.Span) Span {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSpan() Span {
-	return newSpan(&otlptrace.Span{})
+	state := StateMutable
+	return newSpan(&otlptrace.Span{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Span) MoveTo(dest Span) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.Span{}
 }
@@ -586,6 +641,7 @@ func (ms Span) TraceID() TraceID {
 
 // SetTraceID replaces the traceid associated with this Span.
 func (ms Span) SetTraceID(v TraceID) {
+	ms.state.AssertMutable()
 	(*ms.orig).TraceId = v.orig
 }
 
@@ -596,6 +652,7 @@ func (ms Span) SpanID() SpanID {
 
 // SetSpanID replaces the spanid associated with this Span.
 func (ms Span) SetSpanID(v SpanID) {
+	ms.state.AssertMutable()
 	(*ms.orig).SpanId = v.orig
 }
 
@@ -606,6 +663,7 @@ func (ms Span) TraceState() TraceState {
 
 // SetTraceState replaces the tracestate associated with this Span.
 func (ms Span) SetTraceState(v TraceState) {
+	ms.state.AssertMutable()
 	(*ms.orig).TraceState = string(v)
 }
 
@@ -616,6 +674,7 @@ func (ms Span) ParentSpanID() SpanID {
 
 // SetParentSpanID replaces the parentspanid associated with this Span.
 func (ms Span) SetParentSpanID(v SpanID) {
+	ms.state.AssertMutable()
 	(*ms.orig).ParentSpanId = v.orig
 }
 
@@ -626,6 +685,7 @@ func (ms Span) Name() string {
 
 // SetName replaces the name associated with this Span.
 func (ms Span) SetName(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).Name = v
 }
 
@@ -636,6 +696,7 @@ func (ms Span) Kind() SpanKind {
 
 // SetKind replaces the kind associated with this Span.
 func (ms Span) SetKind(v SpanKind) {
+	ms.state.AssertMutable()
 	(*ms.orig).Kind = otlptrace.Span_SpanKind(v)
 }
 
@@ -646,6 +707,7 @@ func (ms Span) StartTimestamp() Timestamp {
 
 // SetStartTimestamp replaces the starttimestamp associated with this Span.
 func (ms Span) SetStartTimestamp(v Timestamp) {
+	ms.state.AssertMutable()
 	(*ms.orig).StartTimeUnixNano = uint64(v)
 }
 
@@ -656,12 +718,19 @@ func (ms Span) EndTimestamp() Timestamp {
 
 // SetEndTimestamp replaces the endtimestamp associated with this Span.
 func (ms Span) SetEndTimestamp(v Timestamp) {
+	ms.state.AssertMutable()
 	(*ms.orig).EndTimeUnixNano = uint64(v)
 }
 
 // Attributes returns the Attributes associated with this Span.
 func (ms Span) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return newMap(&(*ms.orig).Attributes, ms.state)
+}
+
+// HasAttributes returns true if the Attributes associated with this Span is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms Span) HasAttributes() bool {
+	return len((*ms.orig).Attributes) > 0
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this Span.
@@ -671,12 +740,19 @@ func (ms Span) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this Span.
 func (ms Span) SetDroppedAttributesCount(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // Events returns the Events associated with this Span.
 func (ms Span) Events() SpanEventSlice {
-	return newSpanEventSlice(&(*ms.orig).Events)
+	return newSpanEventSlice(&(*ms.orig).Events, ms.state)
+}
+
+// HasEvents returns true if the Events associated with this Span is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms Span) HasEvents() bool {
+	return len((*ms.orig).Events) > 0
 }
 
 // DroppedEventsCount returns the droppedeventscount associated with this Span.
@@ -686,12 +762,19 @@ func (ms Span) DroppedEventsCount() uint32 {
 
 // SetDroppedEventsCount replaces the droppedeventscount associated with this Span.
 func (ms Span) SetDroppedEventsCount(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).DroppedEventsCount = v
 }
 
 // Links returns the Links associated with this Span.
 func (ms Span) Links() SpanLinkSlice {
-	return newSpanLinkSlice(&(*ms.orig).Links)
+	return newSpanLinkSlice(&(*ms.orig).Links, ms.state)
+}
+
+// HasLinks returns true if the Links associated with this Span is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms Span) HasLinks() bool {
+	return len((*ms.orig).Links) > 0
 }
 
 // DroppedLinksCount returns the droppedlinkscount associated with this Span.
@@ -701,16 +784,29 @@ func (ms Span) DroppedLinksCount() uint32 {
 
 // SetDroppedLinksCount replaces the droppedlinkscount associated with this Span.
 func (ms Span) SetDroppedLinksCount(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).DroppedLinksCount = v
 }
 
 // Status returns the status associated with this Span.
 func (ms Span) Status() SpanStatus {
-	return newSpanStatus(&(*ms.orig).Status)
+	return newSpanStatus(&(*ms.orig).Status, ms.state)
+}
+
+// Flags returns the flags associated with this Span.
+func (ms Span) Flags() SpanFlags {
+	return SpanFlags((*ms.orig).Flags)
+}
+
+// SetFlags replaces the flags associated with this Span.
+func (ms Span) SetFlags(v SpanFlags) {
+	ms.state.AssertMutable()
+	(*ms.orig).Flags = uint32(v)
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Span) CopyTo(dest Span) {
+	dest.state.AssertMutable()
 	dest.SetTraceID(ms.TraceID())
 	dest.SetSpanID(ms.SpanID())
 	dest.SetTraceState(ms.TraceState())
@@ -726,6 +822,7 @@ func (ms Span) CopyTo(dest Span) {
 	ms.Links().CopyTo(dest.Links())
 	dest.SetDroppedLinksCount(ms.DroppedLinksCount())
 	ms.Status().CopyTo(dest.Status())
+	dest.SetFlags(ms.Flags())
 }
 
 // SpanEventSlice logically represents a slice of SpanEvent.
@@ -738,18 +835,20 @@ func (ms Span) CopyTo(dest Span) {
 type SpanEventSlice struct {
 	// orig points to the slice otlptrace.Span_Event field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.Span_Event
+	orig  *[]*otlptrace.Span_Event
+	state *State
 }
 
-func newSpanEventSlice(orig *[]*otlptrace.Span_Event) SpanEventSlice {
-	return SpanEventSlice{orig}
+func newSpanEventSlice(orig *[]*otlptrace.Span_Event, state *State) SpanEventSlice {
+	return SpanEventSlice{orig: orig, state: state}
 }
 
 // NewSpanEventSlice creates a SpanEventSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanEventSlice() SpanEventSlice {
 	orig := []*otlptrace.Span_Event(nil)
-	return SpanEventSlice{&orig}
+	state := StateMutable
+	return newSpanEventSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -767,17 +866,18 @@ func (es SpanEventSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanEventSlice) At(ix int) SpanEvent {
-	return newSpanEvent((*es.orig)[ix])
+	return newSpanEvent((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newSpanEvent((*es.orig)[i]).CopyTo(newSpanEvent((*dest.orig)[i]))
+			newSpanEvent((*es.orig)[i], es.state).CopyTo(newSpanEvent((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -785,7 +885,7 @@ func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
 	wrappers := make([]*otlptrace.Span_Event, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newSpanEvent((*es.orig)[i]).CopyTo(newSpanEvent(wrappers[i]))
+		newSpanEvent((*es.orig)[i], es.state).CopyTo(newSpanEvent(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -802,6 +902,7 @@ func (es SpanEventSlice) CopyTo(dest SpanEventSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanEventSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -815,6 +916,7 @@ func (es SpanEventSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty SpanEvent.
 // It returns the newly added SpanEvent.
 func (es SpanEventSlice) AppendEmpty() SpanEvent {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlptrace.Span_Event{})
 	return es.At(es.Len() - 1)
 }
@@ -829,6 +931,7 @@ func (es SpanEventSlice) AppendEmpty() SpanEvent {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanEventSlice) Sort(less func(a, b SpanEvent) bool) SpanEventSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -836,6 +939,8 @@ func (es SpanEventSlice) Sort(less func(a, b SpanEvent) bool) SpanEventSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanEventSlice) MoveAndAppendTo(dest SpanEventSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -848,6 +953,7 @@ func (es SpanEventSlice) MoveAndAppendTo(dest SpanEventSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -874,11 +980,12 @@ func (es SpanEventSlice) RemoveIf(f func(SpanEvent) bool) {
 // Must use NewSpanEvent function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type SpanEvent struct {
-	orig *otlptrace.Span_Event
+	orig  *otlptrace.Span_Event
+	state *State
 }
 
-func newSpanEvent(orig *otlptrace.Span_Event) SpanEvent {
-	return SpanEvent{orig: orig}
+func newSpanEvent(orig *otlptrace.Span_Event, state *State) SpanEvent {
+	return SpanEvent{orig: orig, state: state}
 }
 
 // NewSpanEvent creates a new empty SpanEvent.
@@ -886,12 +993,15 @@ func newSpanEvent(orig *otlptrace.Span_Event) SpanEvent {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSpanEvent() SpanEvent {
-	return newSpanEvent(&otlptrace.Span_Event{})
+	state := StateMutable
+	return newSpanEvent(&otlptrace.Span_Event{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms SpanEvent) MoveTo(dest SpanEvent) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.Span_Event{}
 }
@@ -903,6 +1013,7 @@ func (ms SpanEvent) Timestamp() Timestamp {
 
 // SetTimestamp replaces the timestamp associated with this SpanEvent.
 func (ms SpanEvent) SetTimestamp(v Timestamp) {
+	ms.state.AssertMutable()
 	(*ms.orig).TimeUnixNano = uint64(v)
 }
 
@@ -913,12 +1024,19 @@ func (ms SpanEvent) Name() string {
 
 // SetName replaces the name associated with this SpanEvent.
 func (ms SpanEvent) SetName(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).Name = v
 }
 
 // Attributes returns the Attributes associated with this SpanEvent.
 func (ms SpanEvent) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return newMap(&(*ms.orig).Attributes, ms.state)
+}
+
+// HasAttributes returns true if the Attributes associated with this SpanEvent is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms SpanEvent) HasAttributes() bool {
+	return len((*ms.orig).Attributes) > 0
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this SpanEvent.
@@ -928,11 +1046,13 @@ func (ms SpanEvent) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this SpanEvent.
 func (ms SpanEvent) SetDroppedAttributesCount(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms SpanEvent) CopyTo(dest SpanEvent) {
+	dest.state.AssertMutable()
 	dest.SetTimestamp(ms.Timestamp())
 	dest.SetName(ms.Name())
 	ms.Attributes().CopyTo(dest.Attributes())
@@ -949,18 +1069,20 @@ func (ms SpanEvent) CopyTo(dest SpanEvent) {
 type SpanLinkSlice struct {
 	// orig points to the slice otlptrace.Span_Link field contained somewhere else.
 	// We use pointer-to-slice to be able to modify it in functions like EnsureCapacity.
-	orig *[]*otlptrace.Span_Link
+	orig  *[]*otlptrace.Span_Link
+	state *State
 }
 
-func newSpanLinkSlice(orig *[]*otlptrace.Span_Link) SpanLinkSlice {
-	return SpanLinkSlice{orig}
+func newSpanLinkSlice(orig *[]*otlptrace.Span_Link, state *State) SpanLinkSlice {
+	return SpanLinkSlice{orig: orig, state: state}
 }
 
 // NewSpanLinkSlice creates a SpanLinkSlice with 0 elements.
 // Can use "EnsureCapacity" to initialize with a given capacity.
 func NewSpanLinkSlice() SpanLinkSlice {
 	orig := []*otlptrace.Span_Link(nil)
-	return SpanLinkSlice{&orig}
+	state := StateMutable
+	return newSpanLinkSlice(&orig, &state)
 }
 
 // Len returns the number of elements in the slice.
@@ -978,17 +1100,18 @@ func (es SpanLinkSlice) Len() int {
 //       ... // Do something with the element
 //   }
 func (es SpanLinkSlice) At(ix int) SpanLink {
-	return newSpanLink((*es.orig)[ix])
+	return newSpanLink((*es.orig)[ix], es.state)
 }
 
 // CopyTo copies all elements from the current slice to the dest.
 func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
+	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
-			newSpanLink((*es.orig)[i]).CopyTo(newSpanLink((*dest.orig)[i]))
+			newSpanLink((*es.orig)[i], es.state).CopyTo(newSpanLink((*dest.orig)[i], dest.state))
 		}
 		return
 	}
@@ -996,7 +1119,7 @@ func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
 	wrappers := make([]*otlptrace.Span_Link, srcLen)
 	for i := range *es.orig {
 		wrappers[i] = &origs[i]
-		newSpanLink((*es.orig)[i]).CopyTo(newSpanLink(wrappers[i]))
+		newSpanLink((*es.orig)[i], es.state).CopyTo(newSpanLink(wrappers[i], dest.state))
 	}
 	*dest.orig = wrappers
 }
@@ -1013,6 +1136,7 @@ func (es SpanLinkSlice) CopyTo(dest SpanLinkSlice) {
 //       // Here should set all the values for e.
 //   }
 func (es SpanLinkSlice) EnsureCapacity(newCap int) {
+	es.state.AssertMutable()
 	oldCap := cap(*es.orig)
 	if newCap <= oldCap {
 		return
@@ -1026,6 +1150,7 @@ func (es SpanLinkSlice) EnsureCapacity(newCap int) {
 // AppendEmpty will append to the end of the slice an empty SpanLink.
 // It returns the newly added SpanLink.
 func (es SpanLinkSlice) AppendEmpty() SpanLink {
+	es.state.AssertMutable()
 	*es.orig = append(*es.orig, &otlptrace.Span_Link{})
 	return es.At(es.Len() - 1)
 }
@@ -1040,6 +1165,7 @@ func (es SpanLinkSlice) AppendEmpty() SpanLink {
 //   }
 //   assert.EqualValues(t, expected.Sort(lessFunc), actual.Sort(lessFunc))
 func (es SpanLinkSlice) Sort(less func(a, b SpanLink) bool) SpanLinkSlice {
+	es.state.AssertMutable()
 	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
 	return es
 }
@@ -1047,6 +1173,8 @@ func (es SpanLinkSlice) Sort(less func(a, b SpanLink) bool) SpanLinkSlice {
 // MoveAndAppendTo moves all elements from the current slice and appends them to the dest.
 // The current slice will be cleared.
 func (es SpanLinkSlice) MoveAndAppendTo(dest SpanLinkSlice) {
+	es.state.AssertMutable()
+	dest.state.AssertMutable()
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -1059,6 +1187,7 @@ func (es SpanLinkSlice) MoveAndAppendTo(dest SpanLinkSlice) {
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
 func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
+	es.state.AssertMutable()
 	newLen := 0
 	for i := 0; i < len(*es.orig); i++ {
 		if f(es.At(i)) {
@@ -1086,11 +1215,12 @@ func (es SpanLinkSlice) RemoveIf(f func(SpanLink) bool) {
 // Must use NewSpanLink function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type SpanLink struct {
-	orig *otlptrace.Span_Link
+	orig  *otlptrace.Span_Link
+	state *State
 }
 
-func newSpanLink(orig *otlptrace.Span_Link) SpanLink {
-	return SpanLink{orig: orig}
+func newSpanLink(orig *otlptrace.Span_Link, state *State) SpanLink {
+	return SpanLink{orig: orig, state: state}
 }
 
 // NewSpanLink creates a new empty SpanLink.
@@ -1098,12 +1228,15 @@ func newSpanLink(orig *otlptrace.Span_Link) SpanLink {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSpanLink() SpanLink {
-	return newSpanLink(&otlptrace.Span_Link{})
+	state := StateMutable
+	return newSpanLink(&otlptrace.Span_Link{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms SpanLink) MoveTo(dest SpanLink) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.Span_Link{}
 }
@@ -1115,6 +1248,7 @@ func (ms SpanLink) TraceID() TraceID {
 
 // SetTraceID replaces the traceid associated with this SpanLink.
 func (ms SpanLink) SetTraceID(v TraceID) {
+	ms.state.AssertMutable()
 	(*ms.orig).TraceId = v.orig
 }
 
@@ -1125,6 +1259,7 @@ func (ms SpanLink) SpanID() SpanID {
 
 // SetSpanID replaces the spanid associated with this SpanLink.
 func (ms SpanLink) SetSpanID(v SpanID) {
+	ms.state.AssertMutable()
 	(*ms.orig).SpanId = v.orig
 }
 
@@ -1135,12 +1270,19 @@ func (ms SpanLink) TraceState() TraceState {
 
 // SetTraceState replaces the tracestate associated with this SpanLink.
 func (ms SpanLink) SetTraceState(v TraceState) {
+	ms.state.AssertMutable()
 	(*ms.orig).TraceState = string(v)
 }
 
 // Attributes returns the Attributes associated with this SpanLink.
 func (ms SpanLink) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return newMap(&(*ms.orig).Attributes, ms.state)
+}
+
+// HasAttributes returns true if the Attributes associated with this SpanLink is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms SpanLink) HasAttributes() bool {
+	return len((*ms.orig).Attributes) > 0
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this SpanLink.
@@ -1150,16 +1292,30 @@ func (ms SpanLink) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this SpanLink.
 func (ms SpanLink) SetDroppedAttributesCount(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
+// Flags returns the flags associated with this SpanLink.
+func (ms SpanLink) Flags() SpanFlags {
+	return SpanFlags((*ms.orig).Flags)
+}
+
+// SetFlags replaces the flags associated with this SpanLink.
+func (ms SpanLink) SetFlags(v SpanFlags) {
+	ms.state.AssertMutable()
+	(*ms.orig).Flags = uint32(v)
+}
+
 // CopyTo copies all properties from the current struct to the dest.
 func (ms SpanLink) CopyTo(dest SpanLink) {
+	dest.state.AssertMutable()
 	dest.SetTraceID(ms.TraceID())
 	dest.SetSpanID(ms.SpanID())
 	dest.SetTraceState(ms.TraceState())
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
+	dest.SetFlags(ms.Flags())
 }
 
 // SpanStatus is an optional final status for this span. Semantically, when Status was not
@@ -1171,11 +1327,12 @@ func (ms SpanLink) CopyTo(dest SpanLink) {
 // Must use NewSpanStatus function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type SpanStatus struct {
-	orig *otlptrace.Status
+	orig  *otlptrace.Status
+	state *State
 }
 
-func newSpanStatus(orig *otlptrace.Status) SpanStatus {
-	return SpanStatus{orig: orig}
+func newSpanStatus(orig *otlptrace.Status, state *State) SpanStatus {
+	return SpanStatus{orig: orig, state: state}
 }
 
 // NewSpanStatus creates a new empty SpanStatus.
@@ -1183,12 +1340,15 @@ func newSpanStatus(orig *otlptrace.Status) SpanStatus {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewSpanStatus() SpanStatus {
-	return newSpanStatus(&otlptrace.Status{})
+	state := StateMutable
+	return newSpanStatus(&otlptrace.Status{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms SpanStatus) MoveTo(dest SpanStatus) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlptrace.Status{}
 }
@@ -1200,6 +1360,7 @@ func (ms SpanStatus) Code() StatusCode {
 
 // SetCode replaces the code associated with this SpanStatus.
 func (ms SpanStatus) SetCode(v StatusCode) {
+	ms.state.AssertMutable()
 	(*ms.orig).Code = otlptrace.Status_StatusCode(v)
 }
 
@@ -1210,11 +1371,13 @@ func (ms SpanStatus) Message() string {
 
 // SetMessage replaces the message associated with this SpanStatus.
 func (ms SpanStatus) SetMessage(v string) {
+	ms.state.AssertMutable()
 	(*ms.orig).Message = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms SpanStatus) CopyTo(dest SpanStatus) {
+	dest.state.AssertMutable()
 	dest.SetCode(ms.Code())
 	dest.SetMessage(ms.Message())
 }
@@ -28,11 +28,12 @@ import (
 func TestResourceSpansSlice(t *testing.T) {
 	es := NewResourceSpansSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{})
+	state := StateMutable
+	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newResourceSpans(&otlptrace.ResourceSpans{})
+	emptyVal := newResourceSpans(&otlptrace.ResourceSpans{}, &state)
 	testVal := generateTestResourceSpans()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -135,12 +136,26 @@ func TestResourceSpansSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestResourceSpansSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewResourceSpansSlice()) })
+	assert.Panics(t, func() { NewResourceSpansSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ResourceSpans) bool { return true }) })
+	assert.Panics(t, func() { NewResourceSpansSlice().CopyTo(es) })
+}
+
 func TestResourceSpans_MoveTo(t *testing.T) {
 	ms := generateTestResourceSpans()
 	dest := NewResourceSpans()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewResourceSpans(), ms)
 	assert.EqualValues(t, generateTestResourceSpans(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newResourceSpans(&otlptrace.ResourceSpans{}, &sharedState)) })
+	assert.Panics(t, func() { newResourceSpans(&otlptrace.ResourceSpans{}, &sharedState).MoveTo(dest) })
 }
 
 func TestResourceSpans_CopyTo(t *testing.T) {
@@ -151,6 +166,8 @@ func TestResourceSpans_CopyTo(t *testing.T) {
 	orig = generateTestResourceSpans()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newResourceSpans(&otlptrace.ResourceSpans{}, &sharedState)) })
 }
 
 func TestResourceSpans_Resource(t *testing.T) {
@@ -165,6 +182,8 @@ func TestResourceSpans_SchemaUrl(t *testing.T) {
 	testValSchemaUrl := "https://opentelemetry.io/schemas/1.5.0"
 	ms.SetSchemaUrl(testValSchemaUrl)
 	assert.EqualValues(t, testValSchemaUrl, ms.SchemaUrl())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newResourceSpans(&otlptrace.ResourceSpans{}, &sharedState).SetSchemaUrl(testValSchemaUrl) })
 }
 
 func TestResourceSpans_ScopeSpans(t *testing.T) {
@@ -175,14 +194,22 @@ func TestResourceSpans_ScopeSpans(t *testing.T) {
 	assert.EqualValues(t, testValScopeSpans, ms.ScopeSpans())
 }
 
+func TestResourceSpans_HasScopeSpans(t *testing.T) {
+	ms := NewResourceSpans()
+	assert.False(t, ms.HasScopeSpans())
+	fillTestScopeSpansSlice(ms.ScopeSpans())
+	assert.True(t, ms.HasScopeSpans())
+}
+
 func TestScopeSpansSlice(t *testing.T) {
 	es := NewScopeSpansSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newScopeSpansSlice(&[]*otlptrace.ScopeSpans{})
+	state := StateMutable
+	es = newScopeSpansSlice(&[]*otlptrace.ScopeSpans{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newScopeSpans(&otlptrace.ScopeSpans{})
+	emptyVal := newScopeSpans(&otlptrace.ScopeSpans{}, &state)
 	testVal := generateTestScopeSpans()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -285,12 +312,26 @@ func TestScopeSpansSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestScopeSpansSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newScopeSpansSlice(&[]*otlptrace.ScopeSpans{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewScopeSpansSlice()) })
+	assert.Panics(t, func() { NewScopeSpansSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el ScopeSpans) bool { return true }) })
+	assert.Panics(t, func() { NewScopeSpansSlice().CopyTo(es) })
+}
+
 func TestScopeSpans_MoveTo(t *testing.T) {
 	ms := generateTestScopeSpans()
 	dest := NewScopeSpans()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewScopeSpans(), ms)
 	assert.EqualValues(t, generateTestScopeSpans(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newScopeSpans(&otlptrace.ScopeSpans{}, &sharedState)) })
+	assert.Panics(t, func() { newScopeSpans(&otlptrace.ScopeSpans{}, &sharedState).MoveTo(dest) })
 }
 
 func TestScopeSpans_CopyTo(t *testing.T) {
@@ -301,6 +342,8 @@ func TestScopeSpans_CopyTo(t *testing.T) {
 	orig = generateTestScopeSpans()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newScopeSpans(&otlptrace.ScopeSpans{}, &sharedState)) })
 }
 
 func TestScopeSpans_Scope(t *testing.T) {
@@ -315,6 +358,8 @@ func TestScopeSpans_SchemaUrl(t *testing.T) {
 	testValSchemaUrl := "https://opentelemetry.io/schemas/1.5.0"
 	ms.SetSchemaUrl(testValSchemaUrl)
 	assert.EqualValues(t, testValSchemaUrl, ms.SchemaUrl())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newScopeSpans(&otlptrace.ScopeSpans{}, &sharedState).SetSchemaUrl(testValSchemaUrl) })
 }
 
 func TestScopeSpans_Spans(t *testing.T) {
@@ -325,14 +370,22 @@ func TestScopeSpans_Spans(t *testing.T) {
 	assert.EqualValues(t, testValSpans, ms.Spans())
 }
 
+func TestScopeSpans_HasSpans(t *testing.T) {
+	ms := NewScopeSpans()
+	assert.False(t, ms.HasSpans())
+	fillTestSpanSlice(ms.Spans())
+	assert.True(t, ms.HasSpans())
+}
+
 func TestSpanSlice(t *testing.T) {
 	es := NewSpanSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSpanSlice(&[]*otlptrace.Span{})
+	state := StateMutable
+	es = newSpanSlice(&[]*otlptrace.Span{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSpan(&otlptrace.Span{})
+	emptyVal := newSpan(&otlptrace.Span{}, &state)
 	testVal := generateTestSpan()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -435,12 +488,26 @@ func TestSpanSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newSpanSlice(&[]*otlptrace.Span{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewSpanSlice()) })
+	assert.Panics(t, func() { NewSpanSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el Span) bool { return true }) })
+	assert.Panics(t, func() { NewSpanSlice().CopyTo(es) })
+}
+
 func TestSpan_MoveTo(t *testing.T) {
 	ms := generateTestSpan()
 	dest := NewSpan()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewSpan(), ms)
 	assert.EqualValues(t, generateTestSpan(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSpan(&otlptrace.Span{}, &sharedState)) })
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSpan_CopyTo(t *testing.T) {
@@ -451,6 +518,8 @@ func TestSpan_CopyTo(t *testing.T) {
 	orig = generateTestSpan()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSpan(&otlptrace.Span{}, &sharedState)) })
 }
 
 func TestSpan_TraceID(t *testing.T) {
@@ -459,6 +528,8 @@ func TestSpan_TraceID(t *testing.T) {
 	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
 	ms.SetTraceID(testValTraceID)
 	assert.EqualValues(t, testValTraceID, ms.TraceID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetTraceID(testValTraceID) })
 }
 
 func TestSpan_SpanID(t *testing.T) {
@@ -467,6 +538,8 @@ func TestSpan_SpanID(t *testing.T) {
 	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
 	ms.SetSpanID(testValSpanID)
 	assert.EqualValues(t, testValSpanID, ms.SpanID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetSpanID(testValSpanID) })
 }
 
 func TestSpan_TraceState(t *testing.T) {
@@ -475,6 +548,8 @@ func TestSpan_TraceState(t *testing.T) {
 	testValTraceState := TraceState("congo=congos")
 	ms.SetTraceState(testValTraceState)
 	assert.EqualValues(t, testValTraceState, ms.TraceState())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetTraceState(testValTraceState) })
 }
 
 func TestSpan_ParentSpanID(t *testing.T) {
@@ -483,6 +558,8 @@ func TestSpan_ParentSpanID(t *testing.T) {
 	testValParentSpanID := NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1})
 	ms.SetParentSpanID(testValParentSpanID)
 	assert.EqualValues(t, testValParentSpanID, ms.ParentSpanID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetParentSpanID(testValParentSpanID) })
 }
 
 func TestSpan_Name(t *testing.T) {
@@ -491,6 +568,8 @@ func TestSpan_Name(t *testing.T) {
 	testValName := "test_name"
 	ms.SetName(testValName)
 	assert.EqualValues(t, testValName, ms.Name())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetName(testValName) })
 }
 
 func TestSpan_Kind(t *testing.T) {
@@ -499,6 +578,8 @@ func TestSpan_Kind(t *testing.T) {
 	testValKind := SpanKindServer
 	ms.SetKind(testValKind)
 	assert.EqualValues(t, testValKind, ms.Kind())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetKind(testValKind) })
 }
 
 func TestSpan_StartTimestamp(t *testing.T) {
@@ -507,6 +588,8 @@ func TestSpan_StartTimestamp(t *testing.T) {
 	testValStartTimestamp := Timestamp(1234567890)
 	ms.SetStartTimestamp(testValStartTimestamp)
 	assert.EqualValues(t, testValStartTimestamp, ms.StartTimestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetStartTimestamp(testValStartTimestamp) })
 }
 
 func TestSpan_EndTimestamp(t *testing.T) {
@@ -515,6 +598,8 @@ func TestSpan_EndTimestamp(t *testing.T) {
 	testValEndTimestamp := Timestamp(1234567890)
 	ms.SetEndTimestamp(testValEndTimestamp)
 	assert.EqualValues(t, testValEndTimestamp, ms.EndTimestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetEndTimestamp(testValEndTimestamp) })
 }
 
 func TestSpan_Attributes(t *testing.T) {
@@ -525,12 +610,23 @@ func TestSpan_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestSpan_HasAttributes(t *testing.T) {
+	ms := NewSpan()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestSpan_DroppedAttributesCount(t *testing.T) {
 	ms := NewSpan()
 	assert.EqualValues(t, uint32(0), ms.DroppedAttributesCount())
 	testValDroppedAttributesCount := uint32(17)
 	ms.SetDroppedAttributesCount(testValDroppedAttributesCount)
 	assert.EqualValues(t, testValDroppedAttributesCount, ms.DroppedAttributesCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newSpan(&otlptrace.Span{}, &sharedState).SetDroppedAttributesCount(testValDroppedAttributesCount)
+	})
 }
 
 func TestSpan_Events(t *testing.T) {
@@ -541,12 +637,21 @@ func TestSpan_Events(t *testing.T) {
 	assert.EqualValues(t, testValEvents, ms.Events())
 }
 
+func TestSpan_HasEvents(t *testing.T) {
+	ms := NewSpan()
+	assert.False(t, ms.HasEvents())
+	fillTestSpanEventSlice(ms.Events())
+	assert.True(t, ms.HasEvents())
+}
+
 func TestSpan_DroppedEventsCount(t *testing.T) {
 	ms := NewSpan()
 	assert.EqualValues(t, uint32(0), ms.DroppedEventsCount())
 	testValDroppedEventsCount := uint32(17)
 	ms.SetDroppedEventsCount(testValDroppedEventsCount)
 	assert.EqualValues(t, testValDroppedEventsCount, ms.DroppedEventsCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetDroppedEventsCount(testValDroppedEventsCount) })
 }
 
 func TestSpan_Links(t *testing.T) {
@@ -557,12 +662,21 @@ func TestSpan_Links(t *testing.T) {
 	assert.EqualValues(t, testValLinks, ms.Links())
 }
 
+func TestSpan_HasLinks(t *testing.T) {
+	ms := NewSpan()
+	assert.False(t, ms.HasLinks())
+	fillTestSpanLinkSlice(ms.Links())
+	assert.True(t, ms.HasLinks())
+}
+
 func TestSpan_DroppedLinksCount(t *testing.T) {
 	ms := NewSpan()
 	assert.EqualValues(t, uint32(0), ms.DroppedLinksCount())
 	testValDroppedLinksCount := uint32(17)
 	ms.SetDroppedLinksCount(testValDroppedLinksCount)
 	assert.EqualValues(t, testValDroppedLinksCount, ms.DroppedLinksCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetDroppedLinksCount(testValDroppedLinksCount) })
 }
 
 func TestSpan_Status(t *testing.T) {
@@ -571,14 +685,25 @@ func TestSpan_Status(t *testing.T) {
 	assert.EqualValues(t, generateTestSpanStatus(), ms.Status())
 }
 
+func TestSpan_Flags(t *testing.T) {
+	ms := NewSpan()
+	assert.EqualValues(t, SpanFlagsNone, ms.Flags())
+	testValFlags := SpanFlags(0x301)
+	ms.SetFlags(testValFlags)
+	assert.EqualValues(t, testValFlags, ms.Flags())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpan(&otlptrace.Span{}, &sharedState).SetFlags(testValFlags) })
+}
+
 func TestSpanEventSlice(t *testing.T) {
 	es := NewSpanEventSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSpanEventSlice(&[]*otlptrace.Span_Event{})
+	state := StateMutable
+	es = newSpanEventSlice(&[]*otlptrace.Span_Event{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSpanEvent(&otlptrace.Span_Event{})
+	emptyVal := newSpanEvent(&otlptrace.Span_Event{}, &state)
 	testVal := generateTestSpanEvent()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -681,12 +806,26 @@ func TestSpanEventSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanEventSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newSpanEventSlice(&[]*otlptrace.Span_Event{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewSpanEventSlice()) })
+	assert.Panics(t, func() { NewSpanEventSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el SpanEvent) bool { return true }) })
+	assert.Panics(t, func() { NewSpanEventSlice().CopyTo(es) })
+}
+
 func TestSpanEvent_MoveTo(t *testing.T) {
 	ms := generateTestSpanEvent()
 	dest := NewSpanEvent()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewSpanEvent(), ms)
 	assert.EqualValues(t, generateTestSpanEvent(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSpanEvent(&otlptrace.Span_Event{}, &sharedState)) })
+	assert.Panics(t, func() { newSpanEvent(&otlptrace.Span_Event{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSpanEvent_CopyTo(t *testing.T) {
@@ -697,6 +836,8 @@ func TestSpanEvent_CopyTo(t *testing.T) {
 	orig = generateTestSpanEvent()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSpanEvent(&otlptrace.Span_Event{}, &sharedState)) })
 }
 
 func TestSpanEvent_Timestamp(t *testing.T) {
@@ -705,6 +846,8 @@ func TestSpanEvent_Timestamp(t *testing.T) {
 	testValTimestamp := Timestamp(1234567890)
 	ms.SetTimestamp(testValTimestamp)
 	assert.EqualValues(t, testValTimestamp, ms.Timestamp())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanEvent(&otlptrace.Span_Event{}, &sharedState).SetTimestamp(testValTimestamp) })
 }
 
 func TestSpanEvent_Name(t *testing.T) {
@@ -713,6 +856,8 @@ func TestSpanEvent_Name(t *testing.T) {
 	testValName := "test_name"
 	ms.SetName(testValName)
 	assert.EqualValues(t, testValName, ms.Name())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanEvent(&otlptrace.Span_Event{}, &sharedState).SetName(testValName) })
 }
 
 func TestSpanEvent_Attributes(t *testing.T) {
@@ -723,22 +868,34 @@ func TestSpanEvent_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestSpanEvent_HasAttributes(t *testing.T) {
+	ms := NewSpanEvent()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestSpanEvent_DroppedAttributesCount(t *testing.T) {
 	ms := NewSpanEvent()
 	assert.EqualValues(t, uint32(0), ms.DroppedAttributesCount())
 	testValDroppedAttributesCount := uint32(17)
 	ms.SetDroppedAttributesCount(testValDroppedAttributesCount)
 	assert.EqualValues(t, testValDroppedAttributesCount, ms.DroppedAttributesCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newSpanEvent(&otlptrace.Span_Event{}, &sharedState).SetDroppedAttributesCount(testValDroppedAttributesCount)
+	})
 }
 
 func TestSpanLinkSlice(t *testing.T) {
 	es := NewSpanLinkSlice()
 	assert.EqualValues(t, 0, es.Len())
-	es = newSpanLinkSlice(&[]*otlptrace.Span_Link{})
+	state := StateMutable
+	es = newSpanLinkSlice(&[]*otlptrace.Span_Link{}, &state)
 	assert.EqualValues(t, 0, es.Len())
 
 	es.EnsureCapacity(7)
-	emptyVal := newSpanLink(&otlptrace.Span_Link{})
+	emptyVal := newSpanLink(&otlptrace.Span_Link{}, &state)
 	testVal := generateTestSpanLink()
 	assert.EqualValues(t, 7, cap(*es.orig))
 	for i := 0; i < es.Len(); i++ {
@@ -841,12 +998,26 @@ func TestSpanLinkSlice_RemoveIf(t *testing.T) {
 	assert.Equal(t, 5, filtered.Len())
 }
 
+func TestSpanLinkSlice_ReadOnly(t *testing.T) {
+	sharedState := StateReadOnly
+	es := newSpanLinkSlice(&[]*otlptrace.Span_Link{}, &sharedState)
+	assert.Panics(t, func() { es.EnsureCapacity(2) })
+	assert.Panics(t, func() { es.AppendEmpty() })
+	assert.Panics(t, func() { es.MoveAndAppendTo(NewSpanLinkSlice()) })
+	assert.Panics(t, func() { NewSpanLinkSlice().MoveAndAppendTo(es) })
+	assert.Panics(t, func() { es.RemoveIf(func(el SpanLink) bool { return true }) })
+	assert.Panics(t, func() { NewSpanLinkSlice().CopyTo(es) })
+}
+
 func TestSpanLink_MoveTo(t *testing.T) {
 	ms := generateTestSpanLink()
 	dest := NewSpanLink()
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewSpanLink(), ms)
 	assert.EqualValues(t, generateTestSpanLink(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSpanLink(&otlptrace.Span_Link{}, &sharedState)) })
+	assert.Panics(t, func() { newSpanLink(&otlptrace.Span_Link{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSpanLink_CopyTo(t *testing.T) {
@@ -857,6 +1028,8 @@ func TestSpanLink_CopyTo(t *testing.T) {
 	orig = generateTestSpanLink()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSpanLink(&otlptrace.Span_Link{}, &sharedState)) })
 }
 
 func TestSpanLink_TraceID(t *testing.T) {
@@ -865,6 +1038,8 @@ func TestSpanLink_TraceID(t *testing.T) {
 	testValTraceID := NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1})
 	ms.SetTraceID(testValTraceID)
 	assert.EqualValues(t, testValTraceID, ms.TraceID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanLink(&otlptrace.Span_Link{}, &sharedState).SetTraceID(testValTraceID) })
 }
 
 func TestSpanLink_SpanID(t *testing.T) {
@@ -873,6 +1048,8 @@ func TestSpanLink_SpanID(t *testing.T) {
 	testValSpanID := NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
 	ms.SetSpanID(testValSpanID)
 	assert.EqualValues(t, testValSpanID, ms.SpanID())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanLink(&otlptrace.Span_Link{}, &sharedState).SetSpanID(testValSpanID) })
 }
 
 func TestSpanLink_TraceState(t *testing.T) {
@@ -881,6 +1058,8 @@ func TestSpanLink_TraceState(t *testing.T) {
 	testValTraceState := TraceState("congo=congos")
 	ms.SetTraceState(testValTraceState)
 	assert.EqualValues(t, testValTraceState, ms.TraceState())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanLink(&otlptrace.Span_Link{}, &sharedState).SetTraceState(testValTraceState) })
 }
 
 func TestSpanLink_Attributes(t *testing.T) {
@@ -891,12 +1070,33 @@ func TestSpanLink_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestSpanLink_HasAttributes(t *testing.T) {
+	ms := NewSpanLink()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestSpanLink_DroppedAttributesCount(t *testing.T) {
 	ms := NewSpanLink()
 	assert.EqualValues(t, uint32(0), ms.DroppedAttributesCount())
 	testValDroppedAttributesCount := uint32(17)
 	ms.SetDroppedAttributesCount(testValDroppedAttributesCount)
 	assert.EqualValues(t, testValDroppedAttributesCount, ms.DroppedAttributesCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newSpanLink(&otlptrace.Span_Link{}, &sharedState).SetDroppedAttributesCount(testValDroppedAttributesCount)
+	})
+}
+
+func TestSpanLink_Flags(t *testing.T) {
+	ms := NewSpanLink()
+	assert.EqualValues(t, SpanFlagsNone, ms.Flags())
+	testValFlags := SpanFlags(0x301)
+	ms.SetFlags(testValFlags)
+	assert.EqualValues(t, testValFlags, ms.Flags())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanLink(&otlptrace.Span_Link{}, &sharedState).SetFlags(testValFlags) })
 }
 
 func TestSpanStatus_MoveTo(t *testing.T) {
@@ -905,6 +1105,9 @@ func TestSpanStatus_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewSpanStatus(), ms)
 	assert.EqualValues(t, generateTestSpanStatus(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newSpanStatus(&otlptrace.Status{}, &sharedState)) })
+	assert.Panics(t, func() { newSpanStatus(&otlptrace.Status{}, &sharedState).MoveTo(dest) })
 }
 
 func TestSpanStatus_CopyTo(t *testing.T) {
@@ -915,6 +1118,8 @@ func TestSpanStatus_CopyTo(t *testing.T) {
 	orig = generateTestSpanStatus()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newSpanStatus(&otlptrace.Status{}, &sharedState)) })
 }
 
 func TestSpanStatus_Code(t *testing.T) {
@@ -923,6 +1128,8 @@ func TestSpanStatus_Code(t *testing.T) {
 	testValCode := StatusCode(1)
 	ms.SetCode(testValCode)
 	assert.EqualValues(t, testValCode, ms.Code())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanStatus(&otlptrace.Status{}, &sharedState).SetCode(testValCode) })
 }
 
 func TestSpanStatus_Message(t *testing.T) {
@@ -931,6 +1138,8 @@ func TestSpanStatus_Message(t *testing.T) {
 	testValMessage := "cancelled"
 	ms.SetMessage(testValMessage)
 	assert.EqualValues(t, testValMessage, ms.Message())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { newSpanStatus(&otlptrace.Status{}, &sharedState).SetMessage(testValMessage) })
 }
 
 func generateTestResourceSpansSlice() ResourceSpansSlice {
@@ -1021,6 +1230,7 @@ func fillTestSpan(tv Span) {
 	fillTestSpanLinkSlice(tv.Links())
 	tv.SetDroppedLinksCount(uint32(17))
 	fillTestSpanStatus(tv.Status())
+	tv.SetFlags(SpanFlags(0x301))
 }
 
 func generateTestSpanEventSlice() SpanEventSlice {
@@ -1076,6 +1286,7 @@ func fillTestSpanLink(tv SpanLink) {
 	tv.SetTraceState(TraceState("congo=congos"))
 	fillTestMap(tv.Attributes())
 	tv.SetDroppedAttributesCount(uint32(17))
+	tv.SetFlags(SpanFlags(0x301))
 }
 
 func generateTestSpanStatus() SpanStatus {
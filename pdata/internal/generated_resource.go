@@ -29,11 +29,12 @@ import (
 // Must use NewResource function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type Resource struct {
-	orig *otlpresource.Resource
+	orig  *otlpresource.Resource
+	state *State
 }
 
-func newResource(orig *otlpresource.Resource) Resource {
-	return Resource{orig: orig}
+func newResource(orig *otlpresource.Resource, state *State) Resource {
+	return Resource{orig: orig, state: state}
 }
 
 // NewResource creates a new empty Resource.
@@ -41,19 +42,28 @@ func newResource(orig *otlpresource.Resource) Resource {
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
 // OR directly access the member if this is embedded in another struct.
 func NewResource() Resource {
-	return newResource(&otlpresource.Resource{})
+	state := StateMutable
+	return newResource(&otlpresource.Resource{}, &state)
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value
 func (ms Resource) MoveTo(dest Resource) {
+	ms.state.AssertMutable()
+	dest.state.AssertMutable()
 	*dest.orig = *ms.orig
 	*ms.orig = otlpresource.Resource{}
 }
 
 // Attributes returns the Attributes associated with this Resource.
 func (ms Resource) Attributes() Map {
-	return newMap(&(*ms.orig).Attributes)
+	return newMap(&(*ms.orig).Attributes, ms.state)
+}
+
+// HasAttributes returns true if the Attributes associated with this Resource is not empty,
+// allowing to skip the empty ones without wrapping them.
+func (ms Resource) HasAttributes() bool {
+	return len((*ms.orig).Attributes) > 0
 }
 
 // DroppedAttributesCount returns the droppedattributescount associated with this Resource.
@@ -63,11 +73,13 @@ func (ms Resource) DroppedAttributesCount() uint32 {
 
 // SetDroppedAttributesCount replaces the droppedattributescount associated with this Resource.
 func (ms Resource) SetDroppedAttributesCount(v uint32) {
+	ms.state.AssertMutable()
 	(*ms.orig).DroppedAttributesCount = v
 }
 
 // CopyTo copies all properties from the current struct to the dest.
 func (ms Resource) CopyTo(dest Resource) {
+	dest.state.AssertMutable()
 	ms.Attributes().CopyTo(dest.Attributes())
 	dest.SetDroppedAttributesCount(ms.DroppedAttributesCount())
 }
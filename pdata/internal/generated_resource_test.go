@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	otlpresource "go.opentelemetry.io/collector/pdata/internal/data/protogen/resource/v1"
 )
 
 func TestResource_MoveTo(t *testing.T) {
@@ -29,6 +31,9 @@ func TestResource_MoveTo(t *testing.T) {
 	ms.MoveTo(dest)
 	assert.EqualValues(t, NewResource(), ms)
 	assert.EqualValues(t, generateTestResource(), dest)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.MoveTo(newResource(&otlpresource.Resource{}, &sharedState)) })
+	assert.Panics(t, func() { newResource(&otlpresource.Resource{}, &sharedState).MoveTo(dest) })
 }
 
 func TestResource_CopyTo(t *testing.T) {
@@ -39,6 +44,8 @@ func TestResource_CopyTo(t *testing.T) {
 	orig = generateTestResource()
 	orig.CopyTo(ms)
 	assert.EqualValues(t, orig, ms)
+	sharedState := StateReadOnly
+	assert.Panics(t, func() { ms.CopyTo(newResource(&otlpresource.Resource{}, &sharedState)) })
 }
 
 func TestResource_Attributes(t *testing.T) {
@@ -49,12 +56,23 @@ func TestResource_Attributes(t *testing.T) {
 	assert.EqualValues(t, testValAttributes, ms.Attributes())
 }
 
+func TestResource_HasAttributes(t *testing.T) {
+	ms := NewResource()
+	assert.False(t, ms.HasAttributes())
+	fillTestMap(ms.Attributes())
+	assert.True(t, ms.HasAttributes())
+}
+
 func TestResource_DroppedAttributesCount(t *testing.T) {
 	ms := NewResource()
 	assert.EqualValues(t, uint32(0), ms.DroppedAttributesCount())
 	testValDroppedAttributesCount := uint32(17)
 	ms.SetDroppedAttributesCount(testValDroppedAttributesCount)
 	assert.EqualValues(t, testValDroppedAttributesCount, ms.DroppedAttributesCount())
+	sharedState := StateReadOnly
+	assert.Panics(t, func() {
+		newResource(&otlpresource.Resource{}, &sharedState).SetDroppedAttributesCount(testValDroppedAttributesCount)
+	})
 }
 
 func generateTestResource() Resource {
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestChainUnaryClient(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			calls = append(calls, name)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	invoker := ChainUnaryClient([]grpc.UnaryClientInterceptor{interceptor("first"), interceptor("second")},
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls = append(calls, "invoker")
+			assert.Equal(t, "/service/Method", method)
+			assert.Equal(t, "request", req)
+			return nil
+		})
+	assert.NoError(t, invoker(context.Background(), "/service/Method", "request", nil, nil))
+	assert.Equal(t, []string{"first", "second", "invoker"}, calls)
+}
+
+func TestChainUnaryServer(t *testing.T) {
+	var calls []string
+	info := &grpc.UnaryServerInfo{FullMethod: "/service/Method"}
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, i *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			assert.Same(t, info, i)
+			return handler(ctx, req)
+		}
+	}
+	handler := ChainUnaryServer([]grpc.UnaryServerInterceptor{interceptor("first"), interceptor("second")}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls = append(calls, "handler")
+			return req.(string) + " response", nil
+		})
+	rsp, err := handler(context.Background(), "request")
+	assert.NoError(t, err)
+	assert.Equal(t, "request response", rsp)
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
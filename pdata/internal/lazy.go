@@ -0,0 +1,364 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/gogoproto"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var errInvalidProto = errors.New("invalid OTLP protobuf encoding")
+
+// lazyOrig holds the OTLP protobuf encoding a Traces, Metrics or Logs was lazily unmarshaled from.
+// The encoding is only decoded into the orig message when the data is first accessed, and is kept as the
+// encoding of the data, to marshal it without encoding it again, until the data is accessed as mutable.
+// It is shared by all the copies of the wrapper, and by the clones of the data while still valid.
+type lazyOrig struct {
+	mu      sync.Mutex
+	buf     []byte
+	decoded bool
+	// count is the number of items of the data, spans, metrics or log records, computed from the encoding.
+	count int
+	// dataPointCount is the number of data points of the metrics, computed from the encoding.
+	dataPointCount int
+}
+
+// decode calls unmarshal with the encoding on the first call. The encoding is dropped if it fails
+// to be decoded, unmarshal resetting the orig message, so that it is not sent instead of the data.
+func (l *lazyOrig) decode(unmarshal func(buf []byte) error) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.decoded {
+		l.decoded = true
+		if err := unmarshal(l.buf); err != nil {
+			l.buf = nil
+		}
+	}
+}
+
+// access decodes the encoding, and drops it if the data is accessed as mutable.
+func (l *lazyOrig) access(unmarshal func(buf []byte) error, mutable bool) {
+	if l == nil {
+		return
+	}
+	l.decode(unmarshal)
+	if mutable {
+		l.mu.Lock()
+		l.buf = nil
+		l.mu.Unlock()
+	}
+}
+
+// drop drops the encoding without decoding it, the orig message being reset.
+func (l *lazyOrig) drop() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.decoded = true
+	l.buf = nil
+}
+
+// raw returns the encoding if it is still the encoding of the data.
+func (l *lazyOrig) raw() ([]byte, bool) {
+	if l == nil {
+		return nil, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf, l.buf != nil
+}
+
+// clone returns a new lazyOrig holding the encoding if it is still the encoding of the data, nil otherwise.
+func (l *lazyOrig) clone() *lazyOrig {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buf == nil {
+		return nil
+	}
+	return &lazyOrig{buf: l.buf, count: l.count, dataPointCount: l.dataPointCount}
+}
+
+// counts returns the counts computed from the encoding if not decoded yet.
+func (l *lazyOrig) counts() (count int, dataPointCount int, ok bool) {
+	if l == nil {
+		return 0, 0, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count, l.dataPointCount, !l.decoded
+}
+
+// countFields counts the length delimited fields at the end of path in the protobuf encoded buf, going
+// down the nested messages of the fields of each level of path. The numbers of a level are alternatives
+// in order of precedence, e.g. a field and its deprecated predecessor, only the fields of the first
+// number present in a message being counted. It returns an error if buf is not a valid encoding down to
+// the counted fields.
+func countFields(buf []byte, path ...[]protowire.Number) (int, error) {
+	counts := make([]int, len(path[0]))
+	present := make([]bool, len(path[0]))
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return 0, errInvalidProto
+		}
+		buf = buf[n:]
+		alt := -1
+		for i, pathNum := range path[0] {
+			if num == pathNum {
+				alt = i
+				break
+			}
+		}
+		if alt < 0 || typ != protowire.BytesType {
+			if n = protowire.ConsumeFieldValue(num, typ, buf); n < 0 {
+				return 0, errInvalidProto
+			}
+			buf = buf[n:]
+			continue
+		}
+		val, n := protowire.ConsumeBytes(buf)
+		if n < 0 {
+			return 0, errInvalidProto
+		}
+		buf = buf[n:]
+		present[alt] = true
+		if len(path) == 1 {
+			counts[alt]++
+			continue
+		}
+		count, err := countFields(val, path[1:]...)
+		if err != nil {
+			return 0, err
+		}
+		counts[alt] += count
+	}
+	for i := range present {
+		if present[i] {
+			return counts[i], nil
+		}
+	}
+	return 0, nil
+}
+
+// protoField is the expected encoding of a field of a message.
+type protoField struct {
+	typ protowire.Type
+	// packed is set for the repeated scalar fields, also accepted as a single length delimited field.
+	packed bool
+	// size is the length of the bytes fields of a fixed size, the trace and span IDs, also accepted empty.
+	size int
+	// message is the schema of the message fields.
+	message *protoSchema
+}
+
+// protoSchema is the expected encoding of the fields of a message, built from the descriptors of the
+// generated OTLP messages to validate the encoding of the lazily unmarshaled data without decoding it.
+type protoSchema struct {
+	fields map[protowire.Number]protoField
+}
+
+// lazySchema builds the protoSchema of msg on the first use.
+type lazySchema struct {
+	once   sync.Once
+	msg    descriptor.Message
+	schema *protoSchema
+}
+
+func (l *lazySchema) get() *protoSchema {
+	l.once.Do(func() {
+		fd, md := descriptor.ForMessage(l.msg)
+		b := &protoSchemaBuilder{
+			files:    map[string]bool{},
+			messages: map[string]*descriptor.DescriptorProto{},
+			schemas:  map[string]*protoSchema{},
+		}
+		b.addFile(fd)
+		l.schema = b.schema("." + fd.GetPackage() + "." + md.GetName())
+	})
+	return l.schema
+}
+
+type protoSchemaBuilder struct {
+	files    map[string]bool
+	messages map[string]*descriptor.DescriptorProto
+	schemas  map[string]*protoSchema
+}
+
+// addFile adds the messages of the file and of its dependencies registered by the generated code.
+func (b *protoSchemaBuilder) addFile(fd *descriptor.FileDescriptorProto) {
+	b.files[fd.GetName()] = true
+	b.addMessages("."+fd.GetPackage(), fd.MessageType)
+	for _, dep := range fd.Dependency {
+		if b.files[dep] {
+			continue
+		}
+		if depFd := registeredFile(dep); depFd != nil {
+			b.addFile(depFd)
+		}
+	}
+}
+
+func (b *protoSchemaBuilder) addMessages(prefix string, mds []*descriptor.DescriptorProto) {
+	for _, md := range mds {
+		name := prefix + "." + md.GetName()
+		b.messages[name] = md
+		b.addMessages(name, md.NestedType)
+	}
+}
+
+// schema returns the schema of the message of the given fully qualified name.
+func (b *protoSchemaBuilder) schema(name string) *protoSchema {
+	if s, ok := b.schemas[name]; ok {
+		return s
+	}
+	s := &protoSchema{fields: map[protowire.Number]protoField{}}
+	// Registered before the fields, for the recursive messages, e.g. AnyValue.
+	b.schemas[name] = s
+	md := b.messages[name]
+	if md == nil {
+		return s
+	}
+	for _, fd := range md.Field {
+		var f protoField
+		switch fd.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_DOUBLE, descriptor.FieldDescriptorProto_TYPE_FIXED64, descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+			f.typ = protowire.Fixed64Type
+		case descriptor.FieldDescriptorProto_TYPE_FLOAT, descriptor.FieldDescriptorProto_TYPE_FIXED32, descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+			f.typ = protowire.Fixed32Type
+		case descriptor.FieldDescriptorProto_TYPE_STRING:
+			f.typ = protowire.BytesType
+		case descriptor.FieldDescriptorProto_TYPE_BYTES:
+			f.typ = protowire.BytesType
+			switch customType := gogoproto.GetCustomType(fd); {
+			case strings.HasSuffix(customType, "data.TraceID"):
+				f.size = 16
+			case strings.HasSuffix(customType, "data.SpanID"):
+				f.size = 8
+			}
+		case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+			f.typ = protowire.BytesType
+			f.message = b.schema(fd.GetTypeName())
+		case descriptor.FieldDescriptorProto_TYPE_GROUP:
+			f.typ = protowire.StartGroupType
+		default:
+			f.typ = protowire.VarintType
+		}
+		f.packed = fd.IsRepeated() && f.typ != protowire.BytesType && f.typ != protowire.StartGroupType
+		s.fields[protowire.Number(fd.GetNumber())] = f
+	}
+	return s
+}
+
+// registeredFile returns the descriptor of the file registered by the generated code, nil if not registered.
+func registeredFile(name string) *descriptor.FileDescriptorProto {
+	gz := proto.FileDescriptor(name)
+	if gz == nil {
+		return nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil
+	}
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil
+	}
+	fd := &descriptor.FileDescriptorProto{}
+	if err = proto.Unmarshal(buf, fd); err != nil {
+		return nil
+	}
+	return fd
+}
+
+// validateFields returns an error if buf is not a valid encoding of the message of the schema, as decoded
+// by the generated code: the fields of the schema must have their wire type, the unknown fields are skipped.
+func validateFields(buf []byte, s *protoSchema) error {
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return errInvalidProto
+		}
+		buf = buf[n:]
+		f, known := s.fields[num]
+		switch {
+		case !known || (typ == f.typ && typ != protowire.BytesType):
+			n = protowire.ConsumeFieldValue(num, typ, buf)
+		case typ == protowire.BytesType && (typ == f.typ || f.packed):
+			var val []byte
+			if val, n = protowire.ConsumeBytes(buf); n >= 0 {
+				if err := validateBytes(val, f); err != nil {
+					return err
+				}
+			}
+		default:
+			return errInvalidProto
+		}
+		if n < 0 {
+			return errInvalidProto
+		}
+		buf = buf[n:]
+	}
+	return nil
+}
+
+// validateBytes validates the value of a length delimited field.
+func validateBytes(val []byte, f protoField) error {
+	switch {
+	case f.packed:
+		for len(val) > 0 {
+			n := protowire.ConsumeFieldValue(0, f.typ, val)
+			if n < 0 {
+				return errInvalidProto
+			}
+			val = val[n:]
+		}
+	case f.message != nil:
+		return validateFields(val, f.message)
+	case f.size > 0 && len(val) != 0 && len(val) != f.size:
+		return errInvalidProto
+	}
+	return nil
+}
+
+// RawMessage is an OTLP request already encoded in protobuf, which the gRPC proto codec sends as is.
+type RawMessage struct {
+	Buf []byte
+}
+
+func (m *RawMessage) Reset() { m.Buf = nil }
+
+func (m *RawMessage) String() string { return "RawMessage" }
+
+func (*RawMessage) ProtoMessage() {}
+
+// Marshal returns the encoded request.
+func (m *RawMessage) Marshal() ([]byte, error) { return m.Buf, nil }
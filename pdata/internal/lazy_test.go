@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+func generateTestTraces() Traces {
+	td := NewTraces()
+	generateTestResourceSpansSlice().CopyTo(td.ResourceSpans())
+	return td
+}
+
+func TestUnmarshalTracesLazy(t *testing.T) {
+	expected := generateTestTraces()
+	buf, err := TracesToOtlp(expected).Marshal()
+	require.NoError(t, err)
+
+	td, err := UnmarshalTracesLazy(buf)
+	require.NoError(t, err)
+	assert.Equal(t, expected.SpanCount(), td.SpanCount())
+	assert.Len(t, td.orig.ResourceSpans, 0, "counting the spans must not decode the encoding")
+	raw, ok := TracesRawProto(td)
+	assert.True(t, ok)
+	assert.Equal(t, buf, raw)
+
+	// Read-only accesses decode the encoding and keep it.
+	td.MarkReadOnly()
+	assert.Equal(t, expected.ResourceSpans().Len(), td.ResourceSpans().Len())
+	assert.Equal(t, expected.orig.ResourceSpans, td.orig.ResourceSpans)
+	_, ok = TracesRawProto(td)
+	assert.True(t, ok)
+}
+
+func TestUnmarshalTracesLazyMutable(t *testing.T) {
+	expected := generateTestTraces()
+	buf, err := TracesToOtlp(expected).Marshal()
+	require.NoError(t, err)
+
+	td, err := UnmarshalTracesLazy(buf)
+	require.NoError(t, err)
+	assert.Equal(t, expected.ResourceSpans(), td.ResourceSpans())
+	_, ok := TracesRawProto(td)
+	assert.False(t, ok, "the encoding must be dropped once accessed as mutable")
+	assert.Equal(t, expected.SpanCount(), td.SpanCount())
+}
+
+func TestUnmarshalTracesLazyClone(t *testing.T) {
+	expected := generateTestTraces()
+	buf, err := TracesToOtlp(expected).Marshal()
+	require.NoError(t, err)
+
+	td, err := UnmarshalTracesLazy(buf)
+	require.NoError(t, err)
+	clone := td.Clone()
+	raw, ok := TracesRawProto(clone)
+	assert.True(t, ok)
+	assert.Equal(t, buf, raw)
+
+	// Modifying the clone neither modifies nor invalidates the original.
+	clone.ResourceSpans().AppendEmpty()
+	assert.Equal(t, expected.ResourceSpans().Len()+1, clone.ResourceSpans().Len())
+	_, ok = TracesRawProto(td)
+	assert.True(t, ok)
+	td.MarkReadOnly()
+	assert.Equal(t, expected.ResourceSpans().Len(), td.ResourceSpans().Len())
+}
+
+func TestUnmarshalTracesLazyMoveTo(t *testing.T) {
+	expected := generateTestTraces()
+	buf, err := TracesToOtlp(expected).Marshal()
+	require.NoError(t, err)
+
+	td, err := UnmarshalTracesLazy(buf)
+	require.NoError(t, err)
+	dest := NewTraces()
+	td.MoveTo(dest)
+	assert.Equal(t, expected, dest)
+	assert.Equal(t, 0, td.SpanCount())
+	_, ok := TracesRawProto(td)
+	assert.False(t, ok)
+}
+
+func TestUnmarshalTracesLazyPool(t *testing.T) {
+	buf, err := TracesToOtlp(generateTestTraces()).Marshal()
+	require.NoError(t, err)
+
+	td, err := UnmarshalTracesLazy(buf)
+	require.NoError(t, err)
+	pool := NewTracesPool()
+	pool.Put(td)
+	_, ok := TracesRawProto(td)
+	assert.False(t, ok)
+	assert.Equal(t, 0, pool.Get().SpanCount())
+}
+
+func TestUnmarshalTracesLazyInstrumentationLibrary(t *testing.T) {
+	orig := &otlpcollectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			InstrumentationLibrarySpans: []*otlptrace.InstrumentationLibrarySpans{{ //nolint:staticcheck // SA1019 ignore this!
+				Spans: []*otlptrace.Span{{Name: "span1"}, {Name: "span2"}},
+			}},
+		}},
+	}
+	buf, err := orig.Marshal()
+	require.NoError(t, err)
+
+	td, err := UnmarshalTracesLazy(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.SpanCount())
+	assert.Equal(t, 1, td.ResourceSpans().At(0).ScopeSpans().Len())
+	assert.Equal(t, "span2", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Name())
+}
+
+func TestUnmarshalTracesLazyInvalid(t *testing.T) {
+	_, err := UnmarshalTracesLazy([]byte{0x0a, 0x05, 0x12})
+	assert.Error(t, err)
+
+	// The encoding is validated down to the fields of the spans.
+	buf := bytesField(1, bytesField(2, bytesField(2, []byte{0xff})))
+	_, err = UnmarshalTracesLazy(buf)
+	assert.ErrorIs(t, err, errInvalidProto)
+}
+
+func TestValidateFields(t *testing.T) {
+	span := func(fields ...[]byte) []byte {
+		var buf []byte
+		for _, f := range fields {
+			buf = append(buf, f...)
+		}
+		return bytesField(1, bytesField(2, bytesField(2, buf)))
+	}
+	keyValue := func(value []byte) []byte {
+		return bytesField(9, append(bytesField(1, []byte("key")), bytesField(2, value)...))
+	}
+	validate := func(buf []byte) error {
+		return validateFields(buf, tracesSchema.get())
+	}
+
+	assert.NoError(t, validate(nil))
+	assert.NoError(t, validate(span(bytesField(1, make([]byte, 16)), bytesField(2, make([]byte, 8)), bytesField(5, []byte("name")))))
+	// The empty IDs are decoded as the zero IDs.
+	assert.NoError(t, validate(span(bytesField(1, nil), bytesField(2, nil))))
+	// The unknown fields are skipped.
+	assert.NoError(t, validate(span(protowire.AppendVarint(protowire.AppendTag(nil, 100, protowire.VarintType), 1))))
+	// The recursive messages are validated at every level.
+	assert.NoError(t, validate(span(keyValue(bytesField(5, bytesField(1, bytesField(1, []byte("value"))))))))
+
+	for name, buf := range map[string][]byte{
+		"trace id size":            span(bytesField(1, make([]byte, 15))),
+		"span id size":             span(bytesField(4, make([]byte, 16))),
+		"wire type":                span(protowire.AppendVarint(protowire.AppendTag(nil, 5, protowire.VarintType), 1)),
+		"fixed size":               span(append(protowire.AppendTag(nil, 7, protowire.Fixed64Type), 1, 2, 3)),
+		"nested message":           span(bytesField(15, []byte{0xff})),
+		"recursive message":        span(keyValue(bytesField(5, bytesField(1, bytesField(5, []byte{0xff}))))),
+		"unknown field":            span(protowire.AppendTag(nil, 100, protowire.Fixed32Type)),
+		"end group without start":  span(protowire.AppendTag(nil, 100, protowire.EndGroupType)),
+		"length beyond the buffer": append(protowire.AppendTag(nil, 1, protowire.BytesType), 10),
+	} {
+		assert.ErrorIs(t, validate(buf), errInvalidProto, name)
+	}
+}
+
+func TestValidateFieldsPacked(t *testing.T) {
+	dataPoint := func(buf []byte) []byte {
+		// resource_metrics, scope_metrics, metrics, histogram, data_points.
+		return bytesField(1, bytesField(2, bytesField(2, bytesField(9, bytesField(1, buf)))))
+	}
+	validate := func(buf []byte) error {
+		return validateFields(buf, metricsSchema.get())
+	}
+
+	// The repeated scalar fields are accepted packed or not, as decoded by the generated code.
+	var packed []byte
+	packed = protowire.AppendFixed64(packed, 1)
+	packed = protowire.AppendFixed64(packed, 2)
+	assert.NoError(t, validate(dataPoint(bytesField(6, packed))))
+	assert.NoError(t, validate(dataPoint(protowire.AppendFixed64(protowire.AppendTag(nil, 6, protowire.Fixed64Type), 1))))
+	assert.ErrorIs(t, validate(dataPoint(bytesField(6, packed[:10]))), errInvalidProto)
+}
+
+func TestLazyOrigDecodeError(t *testing.T) {
+	l := &lazyOrig{buf: []byte{0x0a}}
+	l.decode(func([]byte) error { return errInvalidProto })
+	// The encoding is dropped, not sent instead of the reset data.
+	_, ok := l.raw()
+	assert.False(t, ok)
+}
+
+func bytesField(num protowire.Number, val []byte) []byte {
+	return protowire.AppendBytes(protowire.AppendTag(nil, num, protowire.BytesType), val)
+}
+
+func TestUnmarshalMetricsLazy(t *testing.T) {
+	expected := NewMetrics()
+	generateTestResourceMetricsSlice().CopyTo(expected.ResourceMetrics())
+	buf, err := MetricsToOtlp(expected).Marshal()
+	require.NoError(t, err)
+
+	md, err := UnmarshalMetricsLazy(buf)
+	require.NoError(t, err)
+	assert.Equal(t, expected.MetricCount(), md.MetricCount())
+	assert.Equal(t, expected.DataPointCount(), md.DataPointCount())
+	assert.Len(t, md.orig.ResourceMetrics, 0, "counting the metrics must not decode the encoding")
+	_, ok := MetricsRawProto(md.Clone())
+	assert.True(t, ok)
+
+	assert.Equal(t, expected.ResourceMetrics(), md.ResourceMetrics())
+	_, ok = MetricsRawProto(md)
+	assert.False(t, ok)
+	assert.Equal(t, expected.DataPointCount(), md.DataPointCount())
+
+	_, err = UnmarshalMetricsLazy([]byte{0x0a, 0x05, 0x12})
+	assert.Error(t, err)
+}
+
+func TestUnmarshalLogsLazy(t *testing.T) {
+	expected := NewLogs()
+	generateTestResourceLogsSlice().CopyTo(expected.ResourceLogs())
+	buf, err := LogsToOtlp(expected).Marshal()
+	require.NoError(t, err)
+
+	ld, err := UnmarshalLogsLazy(buf)
+	require.NoError(t, err)
+	assert.Equal(t, expected.LogRecordCount(), ld.LogRecordCount())
+	assert.Len(t, ld.orig.ResourceLogs, 0, "counting the log records must not decode the encoding")
+	_, ok := LogsRawProto(ld.Clone())
+	assert.True(t, ok)
+
+	assert.Equal(t, expected.ResourceLogs(), ld.ResourceLogs())
+	_, ok = LogsRawProto(ld)
+	assert.False(t, ok)
+	assert.Equal(t, expected.LogRecordCount(), ld.LogRecordCount())
+
+	_, err = UnmarshalLogsLazy([]byte{0x0a, 0x05, 0x12})
+	assert.Error(t, err)
+}
+
+func TestCountFields(t *testing.T) {
+	scope := func(n int) []byte {
+		var buf []byte
+		for i := 0; i < n; i++ {
+			buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+			buf = protowire.AppendBytes(buf, nil)
+		}
+		return buf
+	}
+	var res []byte
+	res = protowire.AppendTag(res, 3, protowire.VarintType)
+	res = protowire.AppendVarint(res, 42)
+	res = protowire.AppendTag(res, 1000, protowire.BytesType)
+	res = protowire.AppendBytes(res, scope(5))
+	res = protowire.AppendTag(res, 2, protowire.BytesType)
+	res = protowire.AppendBytes(res, scope(2))
+	res = protowire.AppendTag(res, 2, protowire.BytesType)
+	res = protowire.AppendBytes(res, scope(1))
+	buf := protowire.AppendTag(nil, 1, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, res)
+
+	// The fields of the deprecated number are ignored if the preferred one is present.
+	count, err := countFields(buf, tracesSpansPath...)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = countFields(nil, tracesSpansPath...)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -15,63 +15,137 @@
 package internal // import "go.opentelemetry.io/collector/pdata/internal"
 
 import (
+	"google.golang.org/protobuf/encoding/protowire"
+
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
 	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
+// logRecordsPath is the path of the log records in the encoding of the ExportLogsServiceRequest and LogsData:
+// resource_logs, scope_logs or the deprecated instrumentation_library_logs, then log_records.
+var logRecordsPath = [][]protowire.Number{{1}, {2, 1000}, {2}}
+
+// logsSchema is the schema validating the encoding of the lazily unmarshaled Logs.
+var logsSchema = &lazySchema{msg: &otlpcollectorlog.ExportLogsServiceRequest{}}
+
 // LogsToOtlp internal helper to convert Logs to otlp request representation.
 func LogsToOtlp(mw Logs) *otlpcollectorlog.ExportLogsServiceRequest {
-	return mw.orig
+	return mw.getOrig(true)
 }
 
 // LogsFromOtlp internal helper to convert otlp request representation to Logs.
 func LogsFromOtlp(orig *otlpcollectorlog.ExportLogsServiceRequest) Logs {
-	return Logs{orig: orig}
+	return newLogs(orig)
 }
 
 // LogsToProto internal helper to convert Logs to protobuf representation.
 func LogsToProto(l Logs) otlplogs.LogsData {
 	return otlplogs.LogsData{
-		ResourceLogs: l.orig.ResourceLogs,
+		ResourceLogs: l.getOrig(false).ResourceLogs,
 	}
 }
 
 // LogsFromProto internal helper to convert protobuf representation to Logs.
 func LogsFromProto(orig otlplogs.LogsData) Logs {
-	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{
+	return newLogs(&otlpcollectorlog.ExportLogsServiceRequest{
 		ResourceLogs: orig.ResourceLogs,
-	}}
+	})
+}
+
+// UnmarshalLogsLazy internal helper to lazily unmarshal Logs from OTLP protobuf bytes, validating the encoding
+// without decoding it. The bytes are decoded when the Logs is first accessed, and must not be modified.
+func UnmarshalLogsLazy(buf []byte) (Logs, error) {
+	if err := validateFields(buf, logsSchema.get()); err != nil {
+		return NewLogs(), err
+	}
+	logRecordCount, err := countFields(buf, logRecordsPath...)
+	if err != nil {
+		return NewLogs(), err
+	}
+	ld := NewLogs()
+	ld.lazy = &lazyOrig{buf: buf, count: logRecordCount}
+	return ld, nil
+}
+
+// LogsRawProto internal helper to get the OTLP protobuf bytes Logs was lazily unmarshaled from,
+// if still the encoding of the Logs.
+func LogsRawProto(ld Logs) ([]byte, bool) {
+	return ld.lazy.raw()
 }
 
 // Logs is the top-level struct that is propagated through the logs pipeline.
 // Use NewLogs to create new instance, zero-initialized instance is not valid for use.
 type Logs struct {
-	orig *otlpcollectorlog.ExportLogsServiceRequest
+	orig  *otlpcollectorlog.ExportLogsServiceRequest
+	state *State
+	// lazy is set if the Logs was lazily unmarshaled.
+	lazy *lazyOrig
+}
+
+// getOrig returns orig, decoding the encoding the Logs was lazily unmarshaled from if any.
+// The encoding is dropped if orig is accessed as mutable.
+func (ld Logs) getOrig(mutable bool) *otlpcollectorlog.ExportLogsServiceRequest {
+	if ld.lazy != nil {
+		ld.lazy.access(ld.unmarshalOrig, mutable)
+	}
+	return ld.orig
+}
+
+func (ld Logs) unmarshalOrig(buf []byte) error {
+	if err := ld.orig.Unmarshal(buf); err != nil {
+		// Not expected, the encoding being validated when lazily unmarshaled.
+		*ld.orig = otlpcollectorlog.ExportLogsServiceRequest{}
+		return err
+	}
+	otlp.InstrumentationLibraryLogsToScope(ld.orig.ResourceLogs)
+	return nil
+}
+
+// resourceLogs returns the ResourceLogsSlice for the read-only accesses of this package,
+// keeping the lazily unmarshaled encoding.
+func (ld Logs) resourceLogs() ResourceLogsSlice {
+	return newResourceLogsSlice(&ld.getOrig(false).ResourceLogs, ld.state)
+}
+
+func newLogs(orig *otlpcollectorlog.ExportLogsServiceRequest) Logs {
+	state := StateMutable
+	return Logs{orig: orig, state: &state}
 }
 
 // NewLogs creates a new Logs struct.
 func NewLogs() Logs {
-	return Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{}}
+	return newLogs(&otlpcollectorlog.ExportLogsServiceRequest{})
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value.
 func (ld Logs) MoveTo(dest Logs) {
-	*dest.orig = *ld.orig
+	ld.state.AssertMutable()
+	dest.state.AssertMutable()
+	*dest.getOrig(true) = *ld.getOrig(true)
 	*ld.orig = otlpcollectorlog.ExportLogsServiceRequest{}
 }
 
-// Clone returns a copy of Logs.
+// Clone returns a copy of Logs, the copy is mutable even if the current Logs is read-only.
 func (ld Logs) Clone() Logs {
 	cloneLd := NewLogs()
-	ld.ResourceLogs().CopyTo(cloneLd.ResourceLogs())
+	if lazy := ld.lazy.clone(); lazy != nil {
+		// The encoding is not modified, the clone decodes it independently.
+		cloneLd.lazy = lazy
+		return cloneLd
+	}
+	ld.resourceLogs().CopyTo(cloneLd.ResourceLogs())
 	return cloneLd
 }
 
 // LogRecordCount calculates the total number of log records.
 func (ld Logs) LogRecordCount() int {
+	if logCount, _, ok := ld.lazy.counts(); ok {
+		return logCount
+	}
 	logCount := 0
-	rss := ld.ResourceLogs()
+	rss := ld.resourceLogs()
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
 		ill := rs.ScopeLogs()
@@ -85,7 +159,18 @@ func (ld Logs) LogRecordCount() int {
 
 // ResourceLogs returns the ResourceLogsSlice associated with this Logs.
 func (ld Logs) ResourceLogs() ResourceLogsSlice {
-	return newResourceLogsSlice(&ld.orig.ResourceLogs)
+	return newResourceLogsSlice(&ld.getOrig(*ld.state == StateMutable).ResourceLogs, ld.state)
+}
+
+// IsReadOnly returns true if this Logs instance is read-only.
+func (ld Logs) IsReadOnly() bool {
+	return *ld.state == StateReadOnly
+}
+
+// MarkReadOnly marks the Logs as shared so that no further modifications can be done on it,
+// allowing multiple consumers to share it without copying. Any modification then panics.
+func (ld Logs) MarkReadOnly() {
+	*ld.state = StateReadOnly
 }
 
 // SeverityNumber represents severity number of a log record.
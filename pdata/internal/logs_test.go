@@ -52,17 +52,17 @@ func TestLogRecordCount(t *testing.T) {
 
 func TestLogRecordCountWithEmpty(t *testing.T) {
 	assert.Zero(t, NewLogs().LogRecordCount())
-	assert.Zero(t, Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{
+	assert.Zero(t, newLogs(&otlpcollectorlog.ExportLogsServiceRequest{
 		ResourceLogs: []*otlplogs.ResourceLogs{{}},
-	}}.LogRecordCount())
-	assert.Zero(t, Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{
+	}).LogRecordCount())
+	assert.Zero(t, newLogs(&otlpcollectorlog.ExportLogsServiceRequest{
 		ResourceLogs: []*otlplogs.ResourceLogs{
 			{
 				ScopeLogs: []*otlplogs.ScopeLogs{{}},
 			},
 		},
-	}}.LogRecordCount())
-	assert.Equal(t, 1, Logs{orig: &otlpcollectorlog.ExportLogsServiceRequest{
+	}).LogRecordCount())
+	assert.Equal(t, 1, newLogs(&otlpcollectorlog.ExportLogsServiceRequest{
 		ResourceLogs: []*otlplogs.ResourceLogs{
 			{
 				ScopeLogs: []*otlplogs.ScopeLogs{
@@ -72,7 +72,7 @@ func TestLogRecordCountWithEmpty(t *testing.T) {
 				},
 			},
 		},
-	}}.LogRecordCount())
+	}).LogRecordCount())
 }
 
 func TestToFromLogOtlp(t *testing.T) {
@@ -130,6 +130,28 @@ func TestLogsClone(t *testing.T) {
 	assert.EqualValues(t, logs, logs.Clone())
 }
 
+func TestLogsReadOnly(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	assert.False(t, logs.IsReadOnly())
+	logs.MarkReadOnly()
+	assert.True(t, logs.IsReadOnly())
+
+	rs := logs.ResourceLogs().At(0)
+	assert.Panics(t, func() { rs.Resource().Attributes().InsertString("k", "v") })
+	assert.Panics(t, func() { rs.ScopeLogs().At(0).LogRecords().At(0).Body().SetStringVal("body") })
+	assert.Panics(t, func() { logs.ResourceLogs().AppendEmpty() })
+	assert.Panics(t, func() { logs.MoveTo(NewLogs()) })
+	assert.Panics(t, func() { NewLogs().MoveTo(logs) })
+
+	// The clone is mutable and equal to the read-only data.
+	clone := logs.Clone()
+	assert.False(t, clone.IsReadOnly())
+	assert.EqualValues(t, logs.orig, clone.orig)
+	clone.ResourceLogs().AppendEmpty()
+	assert.Equal(t, logs.ResourceLogs().Len()+1, clone.ResourceLogs().Len())
+}
+
 func BenchmarkLogsClone(b *testing.B) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
@@ -15,68 +15,161 @@
 package internal // import "go.opentelemetry.io/collector/pdata/internal"
 
 import (
+	"google.golang.org/protobuf/encoding/protowire"
+
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
 	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+)
+
+var (
+	// metricsPath is the path of the metrics in the encoding of the ExportMetricsServiceRequest and MetricsData:
+	// resource_metrics, scope_metrics or the deprecated instrumentation_library_metrics, then metrics.
+	metricsPath = [][]protowire.Number{{1}, {2, 1000}, {2}}
+	// dataPointsPath is the path of the data points in the encoding of the metrics: gauge, sum, histogram,
+	// exponential_histogram or summary, then data_points.
+	dataPointsPath = append(metricsPath, []protowire.Number{5, 7, 9, 10, 11}, []protowire.Number{1})
+	// metricsSchema is the schema validating the encoding of the lazily unmarshaled Metrics.
+	metricsSchema = &lazySchema{msg: &otlpcollectormetrics.ExportMetricsServiceRequest{}}
 )
 
 // MetricsToOtlp internal helper to convert Metrics to otlp request representation.
 func MetricsToOtlp(mw Metrics) *otlpcollectormetrics.ExportMetricsServiceRequest {
-	return mw.orig
+	return mw.getOrig(true)
 }
 
 // MetricsFromOtlp internal helper to convert otlp request representation to Metrics.
 func MetricsFromOtlp(orig *otlpcollectormetrics.ExportMetricsServiceRequest) Metrics {
-	return Metrics{orig: orig}
+	return newMetrics(orig)
 }
 
 // MetricsToProto internal helper to convert Metrics to protobuf representation.
 func MetricsToProto(l Metrics) otlpmetrics.MetricsData {
 	return otlpmetrics.MetricsData{
-		ResourceMetrics: l.orig.ResourceMetrics,
+		ResourceMetrics: l.getOrig(false).ResourceMetrics,
 	}
 }
 
 // MetricsFromProto internal helper to convert protobuf representation to Metrics.
 func MetricsFromProto(orig otlpmetrics.MetricsData) Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
+	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: orig.ResourceMetrics,
-	}}
+	})
+}
+
+// UnmarshalMetricsLazy internal helper to lazily unmarshal Metrics from OTLP protobuf bytes, validating the encoding
+// without decoding it. The bytes are decoded when the Metrics is first accessed, and must not be modified.
+func UnmarshalMetricsLazy(buf []byte) (Metrics, error) {
+	if err := validateFields(buf, metricsSchema.get()); err != nil {
+		return NewMetrics(), err
+	}
+	metricCount, err := countFields(buf, metricsPath...)
+	if err != nil {
+		return NewMetrics(), err
+	}
+	dataPointCount, err := countFields(buf, dataPointsPath...)
+	if err != nil {
+		return NewMetrics(), err
+	}
+	md := NewMetrics()
+	md.lazy = &lazyOrig{buf: buf, count: metricCount, dataPointCount: dataPointCount}
+	return md, nil
+}
+
+// MetricsRawProto internal helper to get the OTLP protobuf bytes Metrics was lazily unmarshaled from,
+// if still the encoding of the Metrics.
+func MetricsRawProto(md Metrics) ([]byte, bool) {
+	return md.lazy.raw()
 }
 
 // Metrics is the top-level struct that is propagated through the metrics pipeline.
 // Use NewMetrics to create new instance, zero-initialized instance is not valid for use.
 type Metrics struct {
-	orig *otlpcollectormetrics.ExportMetricsServiceRequest
+	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
+	state *State
+	// lazy is set if the Metrics was lazily unmarshaled.
+	lazy *lazyOrig
+}
+
+// getOrig returns orig, decoding the encoding the Metrics was lazily unmarshaled from if any.
+// The encoding is dropped if orig is accessed as mutable.
+func (md Metrics) getOrig(mutable bool) *otlpcollectormetrics.ExportMetricsServiceRequest {
+	if md.lazy != nil {
+		md.lazy.access(md.unmarshalOrig, mutable)
+	}
+	return md.orig
+}
+
+func (md Metrics) unmarshalOrig(buf []byte) error {
+	if err := md.orig.Unmarshal(buf); err != nil {
+		// Not expected, the encoding being validated when lazily unmarshaled.
+		*md.orig = otlpcollectormetrics.ExportMetricsServiceRequest{}
+		return err
+	}
+	otlp.InstrumentationLibraryMetricsToScope(md.orig.ResourceMetrics)
+	return nil
+}
+
+// resourceMetrics returns the ResourceMetricsSlice for the read-only accesses of this package,
+// keeping the lazily unmarshaled encoding.
+func (md Metrics) resourceMetrics() ResourceMetricsSlice {
+	return newResourceMetricsSlice(&md.getOrig(false).ResourceMetrics, md.state)
+}
+
+func newMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest) Metrics {
+	state := StateMutable
+	return Metrics{orig: orig, state: &state}
 }
 
 // NewMetrics creates a new Metrics struct.
 func NewMetrics() Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}}
+	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{})
 }
 
-// Clone returns a copy of MetricData.
+// Clone returns a copy of Metrics, the copy is mutable even if the current Metrics is read-only.
 func (md Metrics) Clone() Metrics {
 	cloneMd := NewMetrics()
-	md.ResourceMetrics().CopyTo(cloneMd.ResourceMetrics())
+	if lazy := md.lazy.clone(); lazy != nil {
+		// The encoding is not modified, the clone decodes it independently.
+		cloneMd.lazy = lazy
+		return cloneMd
+	}
+	md.resourceMetrics().CopyTo(cloneMd.ResourceMetrics())
 	return cloneMd
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value.
 func (md Metrics) MoveTo(dest Metrics) {
-	*dest.orig = *md.orig
+	md.state.AssertMutable()
+	dest.state.AssertMutable()
+	*dest.getOrig(true) = *md.getOrig(true)
 	*md.orig = otlpcollectormetrics.ExportMetricsServiceRequest{}
 }
 
 // ResourceMetrics returns the ResourceMetricsSlice associated with this Metrics.
 func (md Metrics) ResourceMetrics() ResourceMetricsSlice {
-	return newResourceMetricsSlice(&md.orig.ResourceMetrics)
+	return newResourceMetricsSlice(&md.getOrig(*md.state == StateMutable).ResourceMetrics, md.state)
+}
+
+// IsReadOnly returns true if this Metrics instance is read-only.
+func (md Metrics) IsReadOnly() bool {
+	return *md.state == StateReadOnly
+}
+
+// MarkReadOnly marks the Metrics as shared so that no further modifications can be done on it,
+// allowing multiple consumers to share it without copying. Any modification then panics.
+func (md Metrics) MarkReadOnly() {
+	*md.state = StateReadOnly
 }
 
 // MetricCount calculates the total number of metrics.
 func (md Metrics) MetricCount() int {
+	if metricCount, _, ok := md.lazy.counts(); ok {
+		return metricCount
+	}
 	metricCount := 0
-	rms := md.ResourceMetrics()
+	rms := md.resourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
 		ilms := rm.ScopeMetrics()
@@ -90,7 +183,10 @@ func (md Metrics) MetricCount() int {
 
 // DataPointCount calculates the total number of data points.
 func (md Metrics) DataPointCount() (dataPointCount int) {
-	rms := md.ResourceMetrics()
+	if _, dataPointCount, ok := md.lazy.counts(); ok {
+		return dataPointCount
+	}
+	rms := md.resourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		rm := rms.At(i)
 		ilms := rm.ScopeMetrics()
@@ -151,6 +247,7 @@ func (mdt MetricDataType) String() string {
 // SetDataType clears any existing data and initialize it with an empty data of the given type.
 // Calling this function on zero-initialized Metric will cause a panic.
 func (ms Metric) SetDataType(ty MetricDataType) {
+	ms.state.AssertMutable()
 	switch ty {
 	case MetricDataTypeGauge:
 		ms.orig.Data = &otlpmetrics.Metric_Gauge{Gauge: &otlpmetrics.Gauge{}}
@@ -294,6 +391,7 @@ func (ms HistogramDataPoint) MBucketCounts() []uint64 {
 // SetMBucketCounts replaces the bucketcounts associated with this HistogramDataPoint.
 // Deprecated: [0.54.0] Use SetBucketCounts instead.
 func (ms HistogramDataPoint) SetMBucketCounts(v []uint64) {
+	ms.state.AssertMutable()
 	ms.orig.BucketCounts = v
 }
 
@@ -306,6 +404,7 @@ func (ms HistogramDataPoint) MExplicitBounds() []float64 {
 // SetMExplicitBounds replaces the explicitbounds associated with this HistogramDataPoint.
 // Deprecated: [0.54.0] Use SetExplicitBounds instead.
 func (ms HistogramDataPoint) SetMExplicitBounds(v []float64) {
+	ms.state.AssertMutable()
 	ms.orig.ExplicitBounds = v
 }
 
@@ -318,5 +417,6 @@ func (ms Buckets) MBucketCounts() []uint64 {
 // SetMBucketCounts replaces the bucketcounts associated with this Buckets.
 // Deprecated: [0.54.0] Use SetBucketCounts instead.
 func (ms Buckets) SetMBucketCounts(v []uint64) {
+	ms.state.AssertMutable()
 	ms.orig.BucketCounts = v
 }
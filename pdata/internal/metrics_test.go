@@ -234,7 +234,7 @@ func TestMetricsMoveTo(t *testing.T) {
 }
 
 func TestOtlpToInternalReadOnly(t *testing.T) {
-	md := Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
+	md := newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
 			{
 				Resource: generateTestProtoResource(),
@@ -246,7 +246,7 @@ func TestOtlpToInternalReadOnly(t *testing.T) {
 				},
 			},
 		},
-	}}
+	})
 	resourceMetrics := md.ResourceMetrics()
 	assert.EqualValues(t, 1, resourceMetrics.Len())
 
@@ -688,6 +688,28 @@ func TestMetricsClone(t *testing.T) {
 	assert.EqualValues(t, metrics, metrics.Clone())
 }
 
+func TestMetricsReadOnly(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	assert.False(t, metrics.IsReadOnly())
+	metrics.MarkReadOnly()
+	assert.True(t, metrics.IsReadOnly())
+
+	rs := metrics.ResourceMetrics().At(0)
+	assert.Panics(t, func() { rs.Resource().Attributes().InsertString("k", "v") })
+	assert.Panics(t, func() { rs.ScopeMetrics().At(0).Metrics().At(0).SetDataType(MetricDataTypeSum) })
+	assert.Panics(t, func() { metrics.ResourceMetrics().AppendEmpty() })
+	assert.Panics(t, func() { metrics.MoveTo(NewMetrics()) })
+	assert.Panics(t, func() { NewMetrics().MoveTo(metrics) })
+
+	// The clone is mutable and equal to the read-only data.
+	clone := metrics.Clone()
+	assert.False(t, clone.IsReadOnly())
+	assert.EqualValues(t, metrics.orig, clone.orig)
+	clone.ResourceMetrics().AppendEmpty()
+	assert.Equal(t, metrics.ResourceMetrics().Len()+1, clone.ResourceMetrics().Len())
+}
+
 func TestMetricsDataPointFlags(t *testing.T) {
 	gauge := generateTestGauge()
 
@@ -955,23 +977,23 @@ func generateTestProtoDoubleHistogramMetric() *otlpmetrics.Metric {
 }
 
 func generateMetricsEmptyResource() Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
+	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{{}},
-	}}
+	})
 }
 
 func generateMetricsEmptyInstrumentation() Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
+	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
 			{
 				ScopeMetrics: []*otlpmetrics.ScopeMetrics{{}},
 			},
 		},
-	}}
+	})
 }
 
 func generateMetricsEmptyMetrics() Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
+	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
 			{
 				ScopeMetrics: []*otlpmetrics.ScopeMetrics{
@@ -981,11 +1003,11 @@ func generateMetricsEmptyMetrics() Metrics {
 				},
 			},
 		},
-	}}
+	})
 }
 
 func generateMetricsEmptyDataPoints() Metrics {
-	return Metrics{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{
+	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
 			{
 				ScopeMetrics: []*otlpmetrics.ScopeMetrics{
@@ -1005,5 +1027,5 @@ func generateMetricsEmptyDataPoints() Metrics {
 				},
 			},
 		},
-	}}
+	})
 }
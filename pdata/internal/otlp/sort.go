@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp // import "go.opentelemetry.io/collector/pdata/internal/otlp"
+
+import (
+	"sort"
+
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+// SortTracesAttributes sorts by key all the attributes in the resource spans, including nested maps,
+// so that identical payloads are marshaled to identical bytes.
+func SortTracesAttributes(rss []*otlptrace.ResourceSpans) {
+	for _, rs := range rss {
+		sortKeyValues(rs.Resource.Attributes)
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				sortKeyValues(s.Attributes)
+				for _, e := range s.Events {
+					sortKeyValues(e.Attributes)
+				}
+				for _, l := range s.Links {
+					sortKeyValues(l.Attributes)
+				}
+			}
+		}
+	}
+}
+
+// SortMetricsAttributes sorts by key all the attributes in the resource metrics, including nested maps,
+// so that identical payloads are marshaled to identical bytes.
+func SortMetricsAttributes(rms []*otlpmetrics.ResourceMetrics) {
+	for _, rm := range rms {
+		sortKeyValues(rm.Resource.Attributes)
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				switch data := m.Data.(type) {
+				case *otlpmetrics.Metric_Gauge:
+					sortNumberDataPoints(data.Gauge.DataPoints)
+				case *otlpmetrics.Metric_Sum:
+					sortNumberDataPoints(data.Sum.DataPoints)
+				case *otlpmetrics.Metric_Histogram:
+					for _, dp := range data.Histogram.DataPoints {
+						sortKeyValues(dp.Attributes)
+						sortExemplars(dp.Exemplars)
+					}
+				case *otlpmetrics.Metric_ExponentialHistogram:
+					for _, dp := range data.ExponentialHistogram.DataPoints {
+						sortKeyValues(dp.Attributes)
+						sortExemplars(dp.Exemplars)
+					}
+				case *otlpmetrics.Metric_Summary:
+					for _, dp := range data.Summary.DataPoints {
+						sortKeyValues(dp.Attributes)
+					}
+				}
+			}
+		}
+	}
+}
+
+// SortLogsAttributes sorts by key all the attributes in the resource logs, including nested maps
+// and map bodies, so that identical payloads are marshaled to identical bytes.
+func SortLogsAttributes(rls []*otlplogs.ResourceLogs) {
+	for _, rl := range rls {
+		sortKeyValues(rl.Resource.Attributes)
+		for _, sl := range rl.ScopeLogs {
+			for _, lr := range sl.LogRecords {
+				sortKeyValues(lr.Attributes)
+				sortAnyValue(&lr.Body)
+			}
+		}
+	}
+}
+
+func sortNumberDataPoints(dps []*otlpmetrics.NumberDataPoint) {
+	for _, dp := range dps {
+		sortKeyValues(dp.Attributes)
+		sortExemplars(dp.Exemplars)
+	}
+}
+
+func sortExemplars(exs []otlpmetrics.Exemplar) {
+	for i := range exs {
+		sortKeyValues(exs[i].FilteredAttributes)
+	}
+}
+
+func sortKeyValues(kvs []otlpcommon.KeyValue) {
+	sort.SliceStable(kvs, func(i, j int) bool {
+		return kvs[i].Key < kvs[j].Key
+	})
+	for i := range kvs {
+		sortAnyValue(&kvs[i].Value)
+	}
+}
+
+func sortAnyValue(av *otlpcommon.AnyValue) {
+	switch v := av.Value.(type) {
+	case *otlpcommon.AnyValue_KvlistValue:
+		if v.KvlistValue != nil {
+			sortKeyValues(v.KvlistValue.Values)
+		}
+	case *otlpcommon.AnyValue_ArrayValue:
+		if v.ArrayValue != nil {
+			for i := range v.ArrayValue.Values {
+				sortAnyValue(&v.ArrayValue.Values[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"sync"
+
+	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+)
+
+// TracesPool is an opt-in pool of Traces, reusing the top-level messages and the backing arrays
+// of their ResourceSpansSlice. Receivers creating Traces at a high rate get them from the pool, and
+// the exporters owning them put them back once sent. It is safe for concurrent use.
+type TracesPool struct {
+	pool sync.Pool
+}
+
+// NewTracesPool returns a new empty TracesPool.
+func NewTracesPool() *TracesPool {
+	return &TracesPool{}
+}
+
+// Get returns an empty Traces, reused from the pool if possible.
+func (p *TracesPool) Get() Traces {
+	if orig, ok := p.pool.Get().(*otlpcollectortrace.ExportTraceServiceRequest); ok {
+		return newTraces(orig)
+	}
+	return NewTraces()
+}
+
+// Put returns the Traces to the pool, which must not be used after by the caller nor by anyone
+// it was passed to. The read-only Traces are shared by several consumers, so they are not pooled.
+func (p *TracesPool) Put(td Traces) {
+	if td.IsReadOnly() {
+		return
+	}
+	// A lazily unmarshaled encoding must not be decoded into the reused message.
+	td.lazy.drop()
+	rss := td.orig.ResourceSpans
+	// Release the references to the resources, only the backing array is reused.
+	for i := range rss {
+		rss[i] = nil
+	}
+	*td.orig = otlpcollectortrace.ExportTraceServiceRequest{ResourceSpans: rss[:0]}
+	// Detach the Traces from the pooled message, so that modifying it through stale references panics.
+	td.MarkReadOnly()
+	p.pool.Put(td.orig)
+}
+
+// MetricsPool is the equivalent of TracesPool for Metrics.
+type MetricsPool struct {
+	pool sync.Pool
+}
+
+// NewMetricsPool returns a new empty MetricsPool.
+func NewMetricsPool() *MetricsPool {
+	return &MetricsPool{}
+}
+
+// Get returns an empty Metrics, reused from the pool if possible.
+func (p *MetricsPool) Get() Metrics {
+	if orig, ok := p.pool.Get().(*otlpcollectormetrics.ExportMetricsServiceRequest); ok {
+		return newMetrics(orig)
+	}
+	return NewMetrics()
+}
+
+// Put returns the Metrics to the pool, which must not be used after by the caller nor by anyone
+// it was passed to. The read-only Metrics are shared by several consumers, so they are not pooled.
+func (p *MetricsPool) Put(md Metrics) {
+	if md.IsReadOnly() {
+		return
+	}
+	// A lazily unmarshaled encoding must not be decoded into the reused message.
+	md.lazy.drop()
+	rms := md.orig.ResourceMetrics
+	// Release the references to the resources, only the backing array is reused.
+	for i := range rms {
+		rms[i] = nil
+	}
+	*md.orig = otlpcollectormetrics.ExportMetricsServiceRequest{ResourceMetrics: rms[:0]}
+	// Detach the Metrics from the pooled message, so that modifying it through stale references panics.
+	md.MarkReadOnly()
+	p.pool.Put(md.orig)
+}
+
+// LogsPool is the equivalent of TracesPool for Logs.
+type LogsPool struct {
+	pool sync.Pool
+}
+
+// NewLogsPool returns a new empty LogsPool.
+func NewLogsPool() *LogsPool {
+	return &LogsPool{}
+}
+
+// Get returns an empty Logs, reused from the pool if possible.
+func (p *LogsPool) Get() Logs {
+	if orig, ok := p.pool.Get().(*otlpcollectorlog.ExportLogsServiceRequest); ok {
+		return newLogs(orig)
+	}
+	return NewLogs()
+}
+
+// Put returns the Logs to the pool, which must not be used after by the caller nor by anyone
+// it was passed to. The read-only Logs are shared by several consumers, so they are not pooled.
+func (p *LogsPool) Put(ld Logs) {
+	if ld.IsReadOnly() {
+		return
+	}
+	// A lazily unmarshaled encoding must not be decoded into the reused message.
+	ld.lazy.drop()
+	rls := ld.orig.ResourceLogs
+	// Release the references to the resources, only the backing array is reused.
+	for i := range rls {
+		rls[i] = nil
+	}
+	*ld.orig = otlpcollectorlog.ExportLogsServiceRequest{ResourceLogs: rls[:0]}
+	// Detach the Logs from the pooled message, so that modifying it through stale references panics.
+	ld.MarkReadOnly()
+	p.pool.Put(ld.orig)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracesPool(t *testing.T) {
+	pool := NewTracesPool()
+	td := pool.Get()
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	fillTestResourceSpansSlice(td.ResourceSpans())
+	rs := td.ResourceSpans().At(0)
+	pool.Put(td)
+
+	// The stale references cannot modify the pooled message.
+	assert.Panics(t, func() { td.ResourceSpans().AppendEmpty() })
+	assert.Panics(t, func() { rs.Resource().Attributes().InsertString("k", "v") })
+
+	td = pool.Get()
+	assert.False(t, td.IsReadOnly())
+	assert.Equal(t, 0, td.ResourceSpans().Len())
+	td.ResourceSpans().AppendEmpty()
+	assert.Equal(t, 1, td.ResourceSpans().Len())
+
+	// The read-only Traces are shared, so they are not pooled.
+	shared := NewTraces()
+	fillTestResourceSpansSlice(shared.ResourceSpans())
+	shared.MarkReadOnly()
+	n := shared.ResourceSpans().Len()
+	pool.Put(shared)
+	assert.Equal(t, n, shared.ResourceSpans().Len())
+}
+
+func TestMetricsPool(t *testing.T) {
+	pool := NewMetricsPool()
+	md := pool.Get()
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	fillTestResourceMetricsSlice(md.ResourceMetrics())
+	pool.Put(md)
+	assert.Panics(t, func() { md.ResourceMetrics().AppendEmpty() })
+
+	md = pool.Get()
+	assert.False(t, md.IsReadOnly())
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	shared := NewMetrics()
+	fillTestResourceMetricsSlice(shared.ResourceMetrics())
+	shared.MarkReadOnly()
+	n := shared.ResourceMetrics().Len()
+	pool.Put(shared)
+	assert.Equal(t, n, shared.ResourceMetrics().Len())
+}
+
+func TestLogsPool(t *testing.T) {
+	pool := NewLogsPool()
+	ld := pool.Get()
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+	fillTestResourceLogsSlice(ld.ResourceLogs())
+	pool.Put(ld)
+	assert.Panics(t, func() { ld.ResourceLogs().AppendEmpty() })
+
+	ld = pool.Get()
+	assert.False(t, ld.IsReadOnly())
+	assert.Equal(t, 0, ld.ResourceLogs().Len())
+
+	shared := NewLogs()
+	fillTestResourceLogsSlice(shared.ResourceLogs())
+	shared.MarkReadOnly()
+	n := shared.ResourceLogs().Len()
+	pool.Put(shared)
+	assert.Equal(t, n, shared.ResourceLogs().Len())
+}
+
+func BenchmarkTracesPool(b *testing.B) {
+	pool := NewTracesPool()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		td := pool.Get()
+		td.ResourceSpans().EnsureCapacity(10)
+		pool.Put(td)
+	}
+}
+
+func BenchmarkTracesNoPool(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		td := NewTraces()
+		td.ResourceSpans().EnsureCapacity(10)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+// State defines an ownership state of pdata messages, shared by all the messages of a Traces, Metrics or Logs.
+type State int32
+
+const (
+	// StateMutable indicates that the data is exclusive to the current consumer and can be modified.
+	StateMutable State = iota
+	// StateReadOnly indicates that the data is shared with other consumers and must not be modified.
+	// Consumers mutating the data must work on a copy, see Traces.Clone, Metrics.Clone or Logs.Clone.
+	StateReadOnly
+)
+
+// AssertMutable panics if the state is not StateMutable.
+func (state *State) AssertMutable() {
+	if *state != StateMutable {
+		panic("invalid access to shared data")
+	}
+}
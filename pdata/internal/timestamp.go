@@ -36,3 +36,54 @@ func (ts Timestamp) AsTime() time.Time {
 func (ts Timestamp) String() string {
 	return ts.AsTime().String()
 }
+
+// Add returns the timestamp ts+d.
+func (ts Timestamp) Add(d time.Duration) Timestamp {
+	return Timestamp(uint64(int64(ts) + int64(d)))
+}
+
+// Sub returns the duration ts-u.
+func (ts Timestamp) Sub(u Timestamp) time.Duration {
+	return time.Duration(int64(ts - u))
+}
+
+// Truncate returns the result of rounding ts down to a multiple of d since the UNIX Epoch,
+// that is the start of the interval of length d containing ts.
+// If d <= 0, Truncate returns ts unchanged.
+func (ts Timestamp) Truncate(d time.Duration) Timestamp {
+	if d <= 0 {
+		return ts
+	}
+	return ts - ts%Timestamp(d)
+}
+
+// Align returns the result of rounding ts up to a multiple of d since the UNIX Epoch,
+// ts being returned unchanged if it already is a multiple of d.
+// If d <= 0, Align returns ts unchanged.
+func (ts Timestamp) Align(d time.Duration) Timestamp {
+	if d <= 0 {
+		return ts
+	}
+	if r := ts % Timestamp(d); r != 0 {
+		return ts + Timestamp(d) - r
+	}
+	return ts
+}
+
+// Bucket returns the index of the interval of length d containing ts,
+// counting the intervals since the UNIX Epoch. It panics if d <= 0.
+func (ts Timestamp) Bucket(d time.Duration) uint64 {
+	if d <= 0 {
+		panic("non-positive bucket duration")
+	}
+	return uint64(ts) / uint64(d)
+}
+
+// NewTimestampFromBucket returns the start of the interval of length d with the given index,
+// the reverse of Timestamp.Bucket. It panics if d <= 0.
+func NewTimestampFromBucket(bucket uint64, d time.Duration) Timestamp {
+	if d <= 0 {
+		panic("non-positive bucket duration")
+	}
+	return Timestamp(bucket * uint64(d))
+}
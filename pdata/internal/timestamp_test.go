@@ -36,3 +36,78 @@ func TestZeroTimestamp(t *testing.T) {
 	assert.Zero(t, NewTimestampFromTime(time.Unix(0, 0).UTC()))
 	assert.Equal(t, "1970-01-01 00:00:00 +0000 UTC", Timestamp(0).String())
 }
+
+func TestTimestampAddSub(t *testing.T) {
+	ts := Timestamp(10 * time.Second)
+	assert.Equal(t, Timestamp(15*time.Second), ts.Add(5*time.Second))
+	assert.Equal(t, Timestamp(5*time.Second), ts.Add(-5*time.Second))
+	assert.Equal(t, 5*time.Second, ts.Sub(Timestamp(5*time.Second)))
+	assert.Equal(t, -5*time.Second, ts.Sub(Timestamp(15*time.Second)))
+}
+
+func TestTimestampTruncateAlign(t *testing.T) {
+	tests := []struct {
+		name     string
+		ts       Timestamp
+		d        time.Duration
+		truncate Timestamp
+		align    Timestamp
+	}{
+		{
+			name:     "zero",
+			ts:       0,
+			d:        time.Minute,
+			truncate: 0,
+			align:    0,
+		},
+		{
+			name:     "aligned",
+			ts:       Timestamp(2 * time.Minute),
+			d:        time.Minute,
+			truncate: Timestamp(2 * time.Minute),
+			align:    Timestamp(2 * time.Minute),
+		},
+		{
+			name:     "one_nanosecond_after",
+			ts:       Timestamp(2*time.Minute + 1),
+			d:        time.Minute,
+			truncate: Timestamp(2 * time.Minute),
+			align:    Timestamp(3 * time.Minute),
+		},
+		{
+			name:     "one_nanosecond_before",
+			ts:       Timestamp(2*time.Minute - 1),
+			d:        time.Minute,
+			truncate: Timestamp(time.Minute),
+			align:    Timestamp(2 * time.Minute),
+		},
+		{
+			name:     "non_positive_duration",
+			ts:       Timestamp(2*time.Minute + 1),
+			d:        0,
+			truncate: Timestamp(2*time.Minute + 1),
+			align:    Timestamp(2*time.Minute + 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.truncate, tt.ts.Truncate(tt.d))
+			assert.Equal(t, tt.align, tt.ts.Align(tt.d))
+		})
+	}
+
+	// Truncating a time.Time since the UNIX Epoch gives the same result.
+	t1 := time.Date(2020, 03, 24, 1, 13, 23, 789, time.UTC)
+	assert.Equal(t, t1.Truncate(time.Hour), NewTimestampFromTime(t1).Truncate(time.Hour).AsTime())
+}
+
+func TestTimestampBucket(t *testing.T) {
+	ts := Timestamp(2*time.Minute + 30*time.Second)
+	assert.Equal(t, uint64(2), ts.Bucket(time.Minute))
+	assert.Equal(t, uint64(150), ts.Bucket(time.Second))
+	assert.Equal(t, Timestamp(2*time.Minute), NewTimestampFromBucket(ts.Bucket(time.Minute), time.Minute))
+	assert.Equal(t, ts.Truncate(time.Minute), NewTimestampFromBucket(ts.Bucket(time.Minute), time.Minute))
+
+	assert.Panics(t, func() { ts.Bucket(0) })
+	assert.Panics(t, func() { NewTimestampFromBucket(1, -time.Second) })
+}
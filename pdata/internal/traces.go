@@ -15,64 +15,138 @@
 package internal // import "go.opentelemetry.io/collector/pdata/internal"
 
 import (
+	"google.golang.org/protobuf/encoding/protowire"
+
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
 	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
 )
 
+// tracesSpansPath is the path of the spans in the encoding of the ExportTraceServiceRequest and TracesData:
+// resource_spans, scope_spans or the deprecated instrumentation_library_spans, then spans.
+var tracesSpansPath = [][]protowire.Number{{1}, {2, 1000}, {2}}
+
+// tracesSchema is the schema validating the encoding of the lazily unmarshaled Traces.
+var tracesSchema = &lazySchema{msg: &otlpcollectortrace.ExportTraceServiceRequest{}}
+
 // TracesToOtlp internal helper to convert Traces to otlp request representation.
 func TracesToOtlp(mw Traces) *otlpcollectortrace.ExportTraceServiceRequest {
-	return mw.orig
+	return mw.getOrig(true)
 }
 
 // TracesFromOtlp internal helper to convert otlp request representation to Traces.
 func TracesFromOtlp(orig *otlpcollectortrace.ExportTraceServiceRequest) Traces {
-	return Traces{orig: orig}
+	return newTraces(orig)
 }
 
 // TracesToProto internal helper to convert Traces to protobuf representation.
 func TracesToProto(mw Traces) otlptrace.TracesData {
 	return otlptrace.TracesData{
-		ResourceSpans: mw.orig.ResourceSpans,
+		ResourceSpans: mw.getOrig(false).ResourceSpans,
 	}
 }
 
 // TracesFromProto internal helper to convert protobuf representation to Traces.
 func TracesFromProto(orig otlptrace.TracesData) Traces {
-	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{
+	return newTraces(&otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: orig.ResourceSpans,
-	}}
+	})
+}
+
+// UnmarshalTracesLazy internal helper to lazily unmarshal Traces from OTLP protobuf bytes, validating the encoding
+// without decoding it. The bytes are decoded when the Traces is first accessed, and must not be modified.
+func UnmarshalTracesLazy(buf []byte) (Traces, error) {
+	if err := validateFields(buf, tracesSchema.get()); err != nil {
+		return NewTraces(), err
+	}
+	spanCount, err := countFields(buf, tracesSpansPath...)
+	if err != nil {
+		return NewTraces(), err
+	}
+	td := NewTraces()
+	td.lazy = &lazyOrig{buf: buf, count: spanCount}
+	return td, nil
+}
+
+// TracesRawProto internal helper to get the OTLP protobuf bytes Traces was lazily unmarshaled from,
+// if still the encoding of the Traces.
+func TracesRawProto(td Traces) ([]byte, bool) {
+	return td.lazy.raw()
 }
 
 // Traces is the top-level struct that is propagated through the traces pipeline.
 // Use NewTraces to create new instance, zero-initialized instance is not valid for use.
 type Traces struct {
 	// When marhsal/unmarshal unless it is in the request for otlp protocol, convert to otlptrace.TracesData.
-	orig *otlpcollectortrace.ExportTraceServiceRequest
+	orig  *otlpcollectortrace.ExportTraceServiceRequest
+	state *State
+	// lazy is set if the Traces was lazily unmarshaled.
+	lazy *lazyOrig
+}
+
+// getOrig returns orig, decoding the encoding the Traces was lazily unmarshaled from if any.
+// The encoding is dropped if orig is accessed as mutable.
+func (td Traces) getOrig(mutable bool) *otlpcollectortrace.ExportTraceServiceRequest {
+	if td.lazy != nil {
+		td.lazy.access(td.unmarshalOrig, mutable)
+	}
+	return td.orig
+}
+
+func (td Traces) unmarshalOrig(buf []byte) error {
+	if err := td.orig.Unmarshal(buf); err != nil {
+		// Not expected, the encoding being validated when lazily unmarshaled.
+		*td.orig = otlpcollectortrace.ExportTraceServiceRequest{}
+		return err
+	}
+	otlp.InstrumentationLibrarySpansToScope(td.orig.ResourceSpans)
+	return nil
+}
+
+// resourceSpans returns the ResourceSpansSlice for the read-only accesses of this package,
+// keeping the lazily unmarshaled encoding.
+func (td Traces) resourceSpans() ResourceSpansSlice {
+	return newResourceSpansSlice(&td.getOrig(false).ResourceSpans, td.state)
+}
+
+func newTraces(orig *otlpcollectortrace.ExportTraceServiceRequest) Traces {
+	state := StateMutable
+	return Traces{orig: orig, state: &state}
 }
 
 // NewTraces creates a new Traces struct.
 func NewTraces() Traces {
-	return Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{}}
+	return newTraces(&otlpcollectortrace.ExportTraceServiceRequest{})
 }
 
 // MoveTo moves all properties from the current struct to dest
 // resetting the current instance to its zero value.
 func (td Traces) MoveTo(dest Traces) {
-	*dest.orig = *td.orig
+	td.state.AssertMutable()
+	dest.state.AssertMutable()
+	*dest.getOrig(true) = *td.getOrig(true)
 	*td.orig = otlpcollectortrace.ExportTraceServiceRequest{}
 }
 
-// Clone returns a copy of Traces.
+// Clone returns a copy of Traces, the copy is mutable even if the current Traces is read-only.
 func (td Traces) Clone() Traces {
 	cloneTd := NewTraces()
-	td.ResourceSpans().CopyTo(cloneTd.ResourceSpans())
+	if lazy := td.lazy.clone(); lazy != nil {
+		// The encoding is not modified, the clone decodes it independently.
+		cloneTd.lazy = lazy
+		return cloneTd
+	}
+	td.resourceSpans().CopyTo(cloneTd.ResourceSpans())
 	return cloneTd
 }
 
 // SpanCount calculates the total number of spans.
 func (td Traces) SpanCount() int {
+	if spanCount, _, ok := td.lazy.counts(); ok {
+		return spanCount
+	}
 	spanCount := 0
-	rss := td.ResourceSpans()
+	rss := td.resourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		rs := rss.At(i)
 		ilss := rs.ScopeSpans()
@@ -83,9 +157,76 @@ func (td Traces) SpanCount() int {
 	return spanCount
 }
 
+// ErrorSpanCount calculates the total number of spans with an error status.
+func (td Traces) ErrorSpanCount() int {
+	errorSpanCount := 0
+	rss := td.resourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		errorSpanCount += rss.At(i).ErrorSpanCount()
+	}
+	return errorSpanCount
+}
+
+// ErrorSpanCountPerResource calculates the number of spans with an error status of each ResourceSpans,
+// the counts being in the order of the ResourceSpansSlice.
+func (td Traces) ErrorSpanCountPerResource() []int {
+	rss := td.resourceSpans()
+	counts := make([]int, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		counts[i] = rss.At(i).ErrorSpanCount()
+	}
+	return counts
+}
+
 // ResourceSpans returns the ResourceSpansSlice associated with this Metrics.
 func (td Traces) ResourceSpans() ResourceSpansSlice {
-	return newResourceSpansSlice(&td.orig.ResourceSpans)
+	return newResourceSpansSlice(&td.getOrig(*td.state == StateMutable).ResourceSpans, td.state)
+}
+
+// IsReadOnly returns true if this Traces instance is read-only.
+func (td Traces) IsReadOnly() bool {
+	return *td.state == StateReadOnly
+}
+
+// MarkReadOnly marks the Traces as shared so that no further modifications can be done on it,
+// allowing multiple consumers to share it without copying. Any modification then panics.
+func (td Traces) MarkReadOnly() {
+	*td.state = StateReadOnly
+}
+
+// ErrorSpanCount calculates the number of spans with an error status of the ResourceSpans.
+func (ms ResourceSpans) ErrorSpanCount() int {
+	errorSpanCount := 0
+	ilss := ms.ScopeSpans()
+	for i := 0; i < ilss.Len(); i++ {
+		spans := ilss.At(i).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			if spans.At(j).IsError() {
+				errorSpanCount++
+			}
+		}
+	}
+	return errorSpanCount
+}
+
+// IsError returns true if the status of the span is StatusCodeError.
+func (ms Span) IsError() bool {
+	return ms.Status().Code() == StatusCodeError
+}
+
+// SetStatusError sets the status of the span to StatusCodeError with the given message.
+func (ms Span) SetStatusError(message string) {
+	status := ms.Status()
+	status.SetCode(StatusCodeError)
+	status.SetMessage(message)
+}
+
+// SetStatusOk sets the status of the span to StatusCodeOk, clearing the message which is only
+// meaningful for errors.
+func (ms Span) SetStatusOk() {
+	status := ms.Status()
+	status.SetCode(StatusCodeOk)
+	status.SetMessage("")
 }
 
 // TraceState is a string representing the tracestate in w3c-trace-context format: https://www.w3.org/TR/trace-context/#tracestate-header
@@ -96,6 +237,72 @@ const (
 	TraceStateEmpty TraceState = ""
 )
 
+// SpanFlags is a bit field of a span or a link. The 8 least significant bits are the trace flags as
+// defined in W3C Trace Context specification, bits 8 and 9 represent whether the parent span,
+// for a span, or the linked span, for a link, is remote. The remaining bits are reserved.
+type SpanFlags uint32
+
+const (
+	// SpanFlagsNone is the default SpanFlags.
+	SpanFlagsNone SpanFlags = 0
+
+	// spanFlagsSampledMask is the W3C Trace Context sampled trace flag.
+	spanFlagsSampledMask SpanFlags = 0x01
+	// spanFlagsTraceFlagsMask are the W3C Trace Context trace flags.
+	spanFlagsTraceFlagsMask SpanFlags = 0xff
+	// spanFlagsHasIsRemoteMask is set when it is known whether the span is remote.
+	spanFlagsHasIsRemoteMask SpanFlags = 0x100
+	// spanFlagsIsRemoteMask is set when the span is remote, only valid along spanFlagsHasIsRemoteMask.
+	spanFlagsIsRemoteMask SpanFlags = 0x200
+)
+
+// TraceFlags returns the W3C Trace Context trace flags.
+func (sf SpanFlags) TraceFlags() uint8 {
+	return uint8(sf & spanFlagsTraceFlagsMask)
+}
+
+// WithTraceFlags returns the SpanFlags with the W3C Trace Context trace flags replaced by the given ones.
+func (sf SpanFlags) WithTraceFlags(traceFlags uint8) SpanFlags {
+	return sf&^spanFlagsTraceFlagsMask | SpanFlags(traceFlags)
+}
+
+// IsSampled returns true if the W3C Trace Context sampled trace flag is set.
+func (sf SpanFlags) IsSampled() bool {
+	return sf&spanFlagsSampledMask != 0
+}
+
+// WithIsSampled returns the SpanFlags with the W3C Trace Context sampled trace flag set to the given value.
+func (sf SpanFlags) WithIsSampled(sampled bool) SpanFlags {
+	if sampled {
+		return sf | spanFlagsSampledMask
+	}
+	return sf &^ spanFlagsSampledMask
+}
+
+// HasIsRemote returns true if it is known whether the parent span, for a span, or the linked span, for a link, is remote.
+func (sf SpanFlags) HasIsRemote() bool {
+	return sf&spanFlagsHasIsRemoteMask != 0
+}
+
+// IsRemote returns true if the parent span, for a span, or the linked span, for a link, is known to be remote.
+func (sf SpanFlags) IsRemote() bool {
+	return sf.HasIsRemote() && sf&spanFlagsIsRemoteMask != 0
+}
+
+// WithIsRemote returns the SpanFlags recording whether the parent span, for a span, or the linked span,
+// for a link, is remote.
+func (sf SpanFlags) WithIsRemote(remote bool) SpanFlags {
+	if remote {
+		return sf | spanFlagsHasIsRemoteMask | spanFlagsIsRemoteMask
+	}
+	return sf&^spanFlagsIsRemoteMask | spanFlagsHasIsRemoteMask
+}
+
+// WithoutIsRemote returns the SpanFlags recording that it is unknown whether the span is remote.
+func (sf SpanFlags) WithoutIsRemote() SpanFlags {
+	return sf &^ (spanFlagsHasIsRemoteMask | spanFlagsIsRemoteMask)
+}
+
 // SpanKind is the type of span. Can be used to specify additional relationships between spans
 // in addition to a parent/child relationship.
 type SpanKind int32
@@ -19,6 +19,7 @@ import (
 
 	gogoproto "github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	goproto "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -51,17 +52,17 @@ func TestSpanCount(t *testing.T) {
 }
 
 func TestSpanCountWithEmpty(t *testing.T) {
-	assert.EqualValues(t, 0, Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{
+	assert.EqualValues(t, 0, newTraces(&otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: []*otlptrace.ResourceSpans{{}},
-	}}.SpanCount())
-	assert.EqualValues(t, 0, Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{
+	}).SpanCount())
+	assert.EqualValues(t, 0, newTraces(&otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: []*otlptrace.ResourceSpans{
 			{
 				ScopeSpans: []*otlptrace.ScopeSpans{{}},
 			},
 		},
-	}}.SpanCount())
-	assert.EqualValues(t, 1, Traces{orig: &otlpcollectortrace.ExportTraceServiceRequest{
+	}).SpanCount())
+	assert.EqualValues(t, 1, newTraces(&otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: []*otlptrace.ResourceSpans{
 			{
 				ScopeSpans: []*otlptrace.ScopeSpans{
@@ -71,7 +72,45 @@ func TestSpanCountWithEmpty(t *testing.T) {
 				},
 			},
 		},
-	}}.SpanCount())
+	}).SpanCount())
+}
+
+func TestSpanStatusHelpers(t *testing.T) {
+	span := NewSpan()
+	assert.False(t, span.IsError())
+
+	span.SetStatusError("connection refused")
+	assert.True(t, span.IsError())
+	assert.Equal(t, StatusCodeError, span.Status().Code())
+	assert.Equal(t, "connection refused", span.Status().Message())
+
+	span.SetStatusOk()
+	assert.False(t, span.IsError())
+	assert.Equal(t, StatusCodeOk, span.Status().Code())
+	assert.Equal(t, "", span.Status().Message())
+}
+
+func TestErrorSpanCount(t *testing.T) {
+	td := NewTraces()
+	assert.Equal(t, 0, td.ErrorSpanCount())
+	assert.Equal(t, []int{}, td.ErrorSpanCountPerResource())
+
+	rs := td.ResourceSpans().AppendEmpty()
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetStatusError("first")
+	spans.AppendEmpty().SetStatusOk()
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetStatusError("second")
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetStatusError("third")
+
+	assert.Equal(t, 2, rs.ErrorSpanCount())
+	assert.Equal(t, 3, td.ErrorSpanCount())
+	assert.Equal(t, []int{2, 0, 1}, td.ErrorSpanCountPerResource())
+
+	// The counts do not modify the data, so they can be used on read-only data.
+	td.MarkReadOnly()
+	assert.Equal(t, 3, td.ErrorSpanCount())
+	assert.Panics(t, func() { td.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0).SetStatusError("error") })
 }
 
 func TestToFromOtlp(t *testing.T) {
@@ -131,6 +170,28 @@ func TestTracesClone(t *testing.T) {
 	assert.EqualValues(t, traces, traces.Clone())
 }
 
+func TestTracesReadOnly(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	assert.False(t, traces.IsReadOnly())
+	traces.MarkReadOnly()
+	assert.True(t, traces.IsReadOnly())
+
+	rs := traces.ResourceSpans().At(0)
+	assert.Panics(t, func() { rs.Resource().Attributes().InsertString("k", "v") })
+	assert.Panics(t, func() { rs.ScopeSpans().At(0).Spans().At(0).SetName("name") })
+	assert.Panics(t, func() { traces.ResourceSpans().AppendEmpty() })
+	assert.Panics(t, func() { traces.MoveTo(NewTraces()) })
+	assert.Panics(t, func() { NewTraces().MoveTo(traces) })
+
+	// The clone is mutable and equal to the read-only data.
+	clone := traces.Clone()
+	assert.False(t, clone.IsReadOnly())
+	assert.EqualValues(t, traces.orig, clone.orig)
+	clone.ResourceSpans().AppendEmpty()
+	assert.Equal(t, traces.ResourceSpans().Len()+1, clone.ResourceSpans().Len())
+}
+
 func BenchmarkTracesClone(b *testing.B) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
@@ -142,3 +203,57 @@ func BenchmarkTracesClone(b *testing.B) {
 		}
 	}
 }
+
+func TestSpanFlags(t *testing.T) {
+	flags := SpanFlagsNone
+	assert.False(t, flags.IsSampled())
+	assert.False(t, flags.HasIsRemote())
+	assert.False(t, flags.IsRemote())
+	assert.EqualValues(t, 0, flags.TraceFlags())
+
+	flags = flags.WithIsSampled(true)
+	assert.True(t, flags.IsSampled())
+	assert.EqualValues(t, 1, flags.TraceFlags())
+	assert.False(t, flags.HasIsRemote())
+
+	flags = flags.WithIsRemote(false)
+	assert.True(t, flags.HasIsRemote())
+	assert.False(t, flags.IsRemote())
+	assert.Equal(t, SpanFlags(0x101), flags)
+
+	flags = flags.WithIsRemote(true)
+	assert.True(t, flags.HasIsRemote())
+	assert.True(t, flags.IsRemote())
+	assert.True(t, flags.IsSampled())
+	assert.Equal(t, SpanFlags(0x301), flags)
+
+	flags = flags.WithTraceFlags(0x02)
+	assert.False(t, flags.IsSampled())
+	assert.EqualValues(t, 0x02, flags.TraceFlags())
+	assert.True(t, flags.IsRemote())
+
+	flags = flags.WithoutIsRemote().WithIsSampled(false)
+	assert.False(t, flags.HasIsRemote())
+	assert.False(t, flags.IsRemote())
+	assert.Equal(t, SpanFlags(0x02), flags)
+
+	// The remote bit is only valid along the has remote bit.
+	assert.False(t, SpanFlags(0x200).IsRemote())
+}
+
+func TestSpanFlagsWireCompatibility(t *testing.T) {
+	td := NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetFlags(SpanFlags(0x301))
+	span.Links().AppendEmpty().SetFlags(SpanFlags(0x100))
+
+	orig := TracesToProto(td)
+	wire, err := orig.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, orig.Size(), len(wire))
+
+	var got otlptrace.TracesData
+	require.NoError(t, got.Unmarshal(wire))
+	assert.EqualValues(t, 0x301, got.ResourceSpans[0].ScopeSpans[0].Spans[0].Flags)
+	assert.EqualValues(t, 0x100, got.ResourceSpans[0].ScopeSpans[0].Spans[0].Links[0].Flags)
+}
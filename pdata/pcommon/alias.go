@@ -76,6 +76,12 @@ var (
 	// NewValueBytes creates a new Value with the given ImmutableByteSlice value.
 	NewValueBytes = internal.NewValueBytes
 
+	// NewValueBytesSlice creates a new Value of array type with a bytes value for each of the given []byte values.
+	NewValueBytesSlice = internal.NewValueBytesSlice
+
+	// NewValueFromRaw creates a new Value from the given raw value, the inverse of Value.AsRaw.
+	NewValueFromRaw = internal.NewValueFromRaw
+
 	// NewValueMBytes creates a new Value with the given []byte value.
 	// The caller must ensure the []byte passed in is not modified after the call is made, sharing the data
 	// across multiple attributes is forbidden.
@@ -92,4 +98,7 @@ var (
 
 	// NewMapFromRaw creates a Map with values from the given map[string]interface{}.
 	NewMapFromRaw = internal.NewMapFromRaw
+
+	// NewSliceFromRaw creates a Slice with values from the given []interface{}.
+	NewSliceFromRaw = internal.NewSliceFromRaw
 )
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import "unicode/utf8"
+
+// AttributeLimits are the limits of the attributes of a span, span event, span link or log record,
+// equivalent to the attribute limits of the OpenTelemetry SDKs.
+type AttributeLimits struct {
+	// MaxCount is the maximum number of attributes, the attributes beyond it being removed. 0 means no limit.
+	MaxCount int
+	// MaxValueLength is the maximum length of the string values, in characters, and of the bytes values, in
+	// bytes, including the values in slices and maps. The longer values are truncated. 0 means no limit.
+	MaxValueLength int
+}
+
+// Apply removes the attributes beyond MaxCount, keeping the first ones, and truncates the values longer than
+// MaxValueLength. Returns the number of removed attributes and of truncated values.
+func (l AttributeLimits) Apply(attrs Map) (dropped int, truncated int) {
+	if l.MaxCount > 0 && attrs.Len() > l.MaxCount {
+		count := 0
+		attrs.RemoveIf(func(string, Value) bool {
+			count++
+			return count > l.MaxCount
+		})
+		dropped = count - l.MaxCount
+	}
+	if l.MaxValueLength > 0 {
+		attrs.Range(func(_ string, v Value) bool {
+			truncated += TruncateValue(v, l.MaxValueLength)
+			return true
+		})
+	}
+	return dropped, truncated
+}
+
+// TruncateValue truncates the string value to maxLength characters, or the bytes value to maxLength bytes,
+// or else the values of the slice or map value. Returns the number of truncated values.
+func TruncateValue(v Value, maxLength int) int {
+	truncated := 0
+	switch v.Type() {
+	case ValueTypeString:
+		s := v.StringVal()
+		if len(s) <= maxLength || utf8.RuneCountInString(s) <= maxLength {
+			return 0
+		}
+		end, count := 0, 0
+		for end = range s {
+			if count == maxLength {
+				break
+			}
+			count++
+		}
+		v.SetStringVal(s[:end])
+		truncated++
+	case ValueTypeBytes:
+		b := v.BytesVal()
+		if b.Len() <= maxLength {
+			return 0
+		}
+		v.SetBytesVal(NewImmutableByteSlice(b.AsRaw()[:maxLength]))
+		truncated++
+	case ValueTypeSlice:
+		s := v.SliceVal()
+		for i := 0; i < s.Len(); i++ {
+			truncated += TruncateValue(s.At(i), maxLength)
+		}
+	case ValueTypeMap:
+		v.MapVal().Range(func(_ string, mv Value) bool {
+			truncated += TruncateValue(mv, maxLength)
+			return true
+		})
+	}
+	return truncated
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeLimitsApply(t *testing.T) {
+	newAttrs := func() Map {
+		attrs := NewMap()
+		attrs.InsertString("a", "héllo world")
+		attrs.InsertInt("b", 1)
+		attrs.InsertBytes("c", NewImmutableByteSlice([]byte{1, 2, 3, 4, 5, 6}))
+		nested := NewValueSlice()
+		nested.SliceVal().AppendEmpty().SetStringVal("short")
+		nested.SliceVal().AppendEmpty().SetStringVal("a longer value")
+		attrs.Insert("d", nested)
+		return attrs
+	}
+
+	tests := []struct {
+		name          string
+		limits        AttributeLimits
+		wantDropped   int
+		wantTruncated int
+		want          map[string]interface{}
+	}{
+		{
+			name:   "no_limits",
+			limits: AttributeLimits{},
+			want: map[string]interface{}{
+				"a": "héllo world",
+				"b": int64(1),
+				"c": []byte{1, 2, 3, 4, 5, 6},
+				"d": []interface{}{"short", "a longer value"},
+			},
+		},
+		{
+			name:        "max_count",
+			limits:      AttributeLimits{MaxCount: 2},
+			wantDropped: 2,
+			want: map[string]interface{}{
+				"a": "héllo world",
+				"b": int64(1),
+			},
+		},
+		{
+			name:          "max_value_length",
+			limits:        AttributeLimits{MaxValueLength: 5},
+			wantTruncated: 3,
+			want: map[string]interface{}{
+				"a": "héllo",
+				"b": int64(1),
+				"c": []byte{1, 2, 3, 4, 5},
+				"d": []interface{}{"short", "a lon"},
+			},
+		},
+		{
+			name:          "both",
+			limits:        AttributeLimits{MaxCount: 1, MaxValueLength: 2},
+			wantDropped:   3,
+			wantTruncated: 1,
+			want: map[string]interface{}{
+				"a": "hé",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := newAttrs()
+			dropped, truncated := tt.limits.Apply(attrs)
+			assert.Equal(t, tt.wantDropped, dropped)
+			assert.Equal(t, tt.wantTruncated, truncated)
+			assert.Equal(t, tt.want, attrs.AsRaw())
+		})
+	}
+}
+
+func TestTruncateValue(t *testing.T) {
+	v := NewValueMap()
+	v.MapVal().InsertString("k", "日本語のテキスト")
+	v.MapVal().InsertString("short", "ok")
+	assert.Equal(t, 1, TruncateValue(v, 3))
+	assert.Equal(t, map[string]interface{}{"k": "日本語", "short": "ok"}, v.MapVal().AsRaw())
+
+	s := NewValueString("abc")
+	assert.Equal(t, 0, TruncateValue(s, 3))
+	assert.Equal(t, "abc", s.StringVal())
+}
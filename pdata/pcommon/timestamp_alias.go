@@ -22,3 +22,7 @@ type Timestamp = internal.Timestamp
 
 // NewTimestampFromTime constructs a new Timestamp from the provided time.Time.
 var NewTimestampFromTime = internal.NewTimestampFromTime
+
+// NewTimestampFromBucket returns the start of the interval of length d with the given index,
+// the reverse of Timestamp.Bucket. It panics if d <= 0.
+var NewTimestampFromBucket = internal.NewTimestampFromBucket
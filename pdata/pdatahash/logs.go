@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatahash // import "go.opentelemetry.io/collector/pdata/pdatahash"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// LogRecord returns the hash of the log record, including its body.
+func LogRecord(lr plog.LogRecord) uint64 {
+	h := newHasher()
+	h.writeTimestamp(lr.ObservedTimestamp())
+	h.writeTimestamp(lr.Timestamp())
+	h.writeTraceID(lr.TraceID())
+	h.writeSpanID(lr.SpanID())
+	h.writeUint64(uint64(lr.Flags()))
+	h.writeString(lr.SeverityText())
+	h.writeInt64(int64(lr.SeverityNumber()))
+	h.writeValue(lr.Body())
+	h.writeMap(lr.Attributes())
+	h.writeUint64(uint64(lr.DroppedAttributesCount()))
+	return h.sum
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatahash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newTestLogRecord() plog.LogRecord {
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(1000)
+	lr.SetSeverityNumber(plog.SeverityNumberINFO)
+	lr.SetSeverityText("INFO")
+	lr.Body().SetStringVal("message")
+	lr.Attributes().InsertString("a", "1")
+	lr.Attributes().InsertString("b", "2")
+	return lr
+}
+
+func TestLogRecord(t *testing.T) {
+	lr1 := newTestLogRecord()
+	lr2 := newTestLogRecord()
+	lr2.Attributes().Clear()
+	lr2.Attributes().InsertString("b", "2")
+	lr2.Attributes().InsertString("a", "1")
+	assert.Equal(t, LogRecord(lr1), LogRecord(lr2))
+
+	modifications := map[string]func(plog.LogRecord){
+		"timestamp": func(lr plog.LogRecord) { lr.SetObservedTimestamp(2000) },
+		"severity":  func(lr plog.LogRecord) { lr.SetSeverityNumber(plog.SeverityNumberWARN) },
+		"body":      func(lr plog.LogRecord) { lr.Body().SetStringVal("other") },
+		"body type": func(lr plog.LogRecord) { lr.Body().SetBytesVal(lr.Body().BytesVal()) },
+		"attribute": func(lr plog.LogRecord) { lr.Attributes().InsertString("c", "3") },
+		"flags":     func(lr plog.LogRecord) { lr.SetFlags(1) },
+	}
+	for name, modify := range modifications {
+		t.Run(name, func(t *testing.T) {
+			lr := newTestLogRecord()
+			modify(lr)
+			assert.NotEqual(t, LogRecord(lr1), LogRecord(lr))
+		})
+	}
+}
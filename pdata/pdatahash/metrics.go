@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatahash // import "go.opentelemetry.io/collector/pdata/pdatahash"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// NumberDataPoint returns the hash of the data point, including its exemplars.
+func NumberDataPoint(dp pmetric.NumberDataPoint) uint64 {
+	h := newHasher()
+	h.writeMap(dp.Attributes())
+	h.writeTimestamp(dp.StartTimestamp())
+	h.writeTimestamp(dp.Timestamp())
+	h.writeByte(byte(dp.ValueType()))
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		h.writeInt64(dp.IntVal())
+	case pmetric.NumberDataPointValueTypeDouble:
+		h.writeFloat64(dp.DoubleVal())
+	}
+	h.writeExemplars(dp.Exemplars())
+	h.writeUint64(uint64(dp.Flags()))
+	return h.sum
+}
+
+// HistogramDataPoint returns the hash of the data point, including its exemplars.
+func HistogramDataPoint(dp pmetric.HistogramDataPoint) uint64 {
+	h := newHasher()
+	h.writeMap(dp.Attributes())
+	h.writeTimestamp(dp.StartTimestamp())
+	h.writeTimestamp(dp.Timestamp())
+	h.writeUint64(dp.Count())
+	h.writeOptionalFloat64(dp.HasSum(), dp.Sum())
+	h.writeUint64s(dp.BucketCounts())
+	bounds := dp.ExplicitBounds()
+	h.writeUint64(uint64(bounds.Len()))
+	for i := 0; i < bounds.Len(); i++ {
+		h.writeFloat64(bounds.At(i))
+	}
+	h.writeExemplars(dp.Exemplars())
+	h.writeUint64(uint64(dp.Flags()))
+	h.writeOptionalFloat64(dp.HasMin(), dp.Min())
+	h.writeOptionalFloat64(dp.HasMax(), dp.Max())
+	return h.sum
+}
+
+// ExponentialHistogramDataPoint returns the hash of the data point, including its exemplars.
+func ExponentialHistogramDataPoint(dp pmetric.ExponentialHistogramDataPoint) uint64 {
+	h := newHasher()
+	h.writeMap(dp.Attributes())
+	h.writeTimestamp(dp.StartTimestamp())
+	h.writeTimestamp(dp.Timestamp())
+	h.writeUint64(dp.Count())
+	h.writeOptionalFloat64(dp.HasSum(), dp.Sum())
+	h.writeInt64(int64(dp.Scale()))
+	h.writeUint64(dp.ZeroCount())
+	h.writeInt64(int64(dp.Positive().Offset()))
+	h.writeUint64s(dp.Positive().BucketCounts())
+	h.writeInt64(int64(dp.Negative().Offset()))
+	h.writeUint64s(dp.Negative().BucketCounts())
+	h.writeExemplars(dp.Exemplars())
+	h.writeUint64(uint64(dp.Flags()))
+	h.writeOptionalFloat64(dp.HasMin(), dp.Min())
+	h.writeOptionalFloat64(dp.HasMax(), dp.Max())
+	return h.sum
+}
+
+// SummaryDataPoint returns the hash of the data point, including its quantiles.
+func SummaryDataPoint(dp pmetric.SummaryDataPoint) uint64 {
+	h := newHasher()
+	h.writeMap(dp.Attributes())
+	h.writeTimestamp(dp.StartTimestamp())
+	h.writeTimestamp(dp.Timestamp())
+	h.writeUint64(dp.Count())
+	h.writeFloat64(dp.Sum())
+	quantiles := dp.QuantileValues()
+	h.writeUint64(uint64(quantiles.Len()))
+	for i := 0; i < quantiles.Len(); i++ {
+		h.writeFloat64(quantiles.At(i).Quantile())
+		h.writeFloat64(quantiles.At(i).Value())
+	}
+	h.writeUint64(uint64(dp.Flags()))
+	return h.sum
+}
+
+// writeOptionalFloat64 writes whether the value is set, and its value if set.
+func (h *hasher) writeOptionalFloat64(has bool, v float64) {
+	h.writeBool(has)
+	if has {
+		h.writeFloat64(v)
+	}
+}
+
+func (h *hasher) writeUint64s(s pcommon.ImmutableUInt64Slice) {
+	h.writeUint64(uint64(s.Len()))
+	for i := 0; i < s.Len(); i++ {
+		h.writeUint64(s.At(i))
+	}
+}
+
+func (h *hasher) writeExemplars(exemplars pmetric.ExemplarSlice) {
+	h.writeUint64(uint64(exemplars.Len()))
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		h.writeTimestamp(e.Timestamp())
+		h.writeByte(byte(e.ValueType()))
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			h.writeInt64(e.IntVal())
+		case pmetric.ExemplarValueTypeDouble:
+			h.writeFloat64(e.DoubleVal())
+		}
+		h.writeMap(e.FilteredAttributes())
+		h.writeTraceID(e.TraceID())
+		h.writeSpanID(e.SpanID())
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatahash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNumberDataPoint(t *testing.T) {
+	newDataPoint := func() pmetric.NumberDataPoint {
+		dp := pmetric.NewNumberDataPoint()
+		dp.SetTimestamp(1000)
+		dp.SetIntVal(1)
+		dp.Attributes().InsertString("a", "1")
+		dp.Attributes().InsertString("b", "2")
+		dp.Exemplars().AppendEmpty().SetDoubleVal(1)
+		return dp
+	}
+	dp1 := newDataPoint()
+	dp2 := pmetric.NewNumberDataPoint()
+	dp2.Attributes().InsertString("b", "2")
+	dp2.Attributes().InsertString("a", "1")
+	dp2.SetTimestamp(1000)
+	dp2.SetIntVal(1)
+	dp2.Exemplars().AppendEmpty().SetDoubleVal(1)
+	assert.Equal(t, NumberDataPoint(dp1), NumberDataPoint(dp2))
+
+	modifications := map[string]func(pmetric.NumberDataPoint){
+		"value":      func(dp pmetric.NumberDataPoint) { dp.SetIntVal(2) },
+		"value type": func(dp pmetric.NumberDataPoint) { dp.SetDoubleVal(1) },
+		"start":      func(dp pmetric.NumberDataPoint) { dp.SetStartTimestamp(1) },
+		"exemplar":   func(dp pmetric.NumberDataPoint) { dp.Exemplars().At(0).SetIntVal(1) },
+		"flags": func(dp pmetric.NumberDataPoint) {
+			dp.SetFlags(pmetric.NewMetricDataPointFlags(pmetric.MetricDataPointFlagNoRecordedValue))
+		},
+	}
+	for name, modify := range modifications {
+		t.Run(name, func(t *testing.T) {
+			dp := newDataPoint()
+			modify(dp)
+			assert.NotEqual(t, NumberDataPoint(dp1), NumberDataPoint(dp))
+		})
+	}
+}
+
+func TestHistogramDataPoint(t *testing.T) {
+	newDataPoint := func() pmetric.HistogramDataPoint {
+		dp := pmetric.NewHistogramDataPoint()
+		dp.SetCount(3)
+		dp.SetSum(6)
+		dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+		dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{1}))
+		return dp
+	}
+	dp1 := newDataPoint()
+	assert.Equal(t, HistogramDataPoint(dp1), HistogramDataPoint(newDataPoint()))
+
+	modifications := map[string]func(pmetric.HistogramDataPoint){
+		"count": func(dp pmetric.HistogramDataPoint) { dp.SetCount(4) },
+		"buckets": func(dp pmetric.HistogramDataPoint) {
+			dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{2, 1}))
+		},
+		"bounds": func(dp pmetric.HistogramDataPoint) {
+			dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{2}))
+		},
+		"min": func(dp pmetric.HistogramDataPoint) { dp.SetMin(0) },
+		"max": func(dp pmetric.HistogramDataPoint) { dp.SetMax(3) },
+	}
+	for name, modify := range modifications {
+		t.Run(name, func(t *testing.T) {
+			dp := newDataPoint()
+			modify(dp)
+			assert.NotEqual(t, HistogramDataPoint(dp1), HistogramDataPoint(dp))
+		})
+	}
+}
+
+func TestExponentialHistogramDataPoint(t *testing.T) {
+	newDataPoint := func() pmetric.ExponentialHistogramDataPoint {
+		dp := pmetric.NewExponentialHistogramDataPoint()
+		dp.SetCount(3)
+		dp.SetScale(1)
+		dp.Positive().SetOffset(1)
+		dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+		return dp
+	}
+	dp1 := newDataPoint()
+	assert.Equal(t, ExponentialHistogramDataPoint(dp1), ExponentialHistogramDataPoint(newDataPoint()))
+
+	modifications := map[string]func(pmetric.ExponentialHistogramDataPoint){
+		"scale":    func(dp pmetric.ExponentialHistogramDataPoint) { dp.SetScale(2) },
+		"zero":     func(dp pmetric.ExponentialHistogramDataPoint) { dp.SetZeroCount(1) },
+		"offset":   func(dp pmetric.ExponentialHistogramDataPoint) { dp.Positive().SetOffset(2) },
+		"negative": func(dp pmetric.ExponentialHistogramDataPoint) { dp.Negative().SetOffset(1) },
+		"sum":      func(dp pmetric.ExponentialHistogramDataPoint) { dp.SetSum(0) },
+	}
+	for name, modify := range modifications {
+		t.Run(name, func(t *testing.T) {
+			dp := newDataPoint()
+			modify(dp)
+			assert.NotEqual(t, ExponentialHistogramDataPoint(dp1), ExponentialHistogramDataPoint(dp))
+		})
+	}
+}
+
+func TestSummaryDataPoint(t *testing.T) {
+	newDataPoint := func() pmetric.SummaryDataPoint {
+		dp := pmetric.NewSummaryDataPoint()
+		dp.SetCount(3)
+		dp.SetSum(6)
+		q := dp.QuantileValues().AppendEmpty()
+		q.SetQuantile(0.5)
+		q.SetValue(2)
+		return dp
+	}
+	dp1 := newDataPoint()
+	assert.Equal(t, SummaryDataPoint(dp1), SummaryDataPoint(newDataPoint()))
+
+	dp2 := newDataPoint()
+	dp2.QuantileValues().At(0).SetValue(3)
+	assert.NotEqual(t, SummaryDataPoint(dp1), SummaryDataPoint(dp2))
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pdatahash provides a deterministic hash of the pdata resources, instrumentation scopes,
+// spans, metric data points and log records, so that the components grouping, deduplicating or
+// identifying telemetry all agree on the same hash.
+//
+// The hash is the 64-bit FNV-1a hash of a canonical encoding of all the fields, where:
+//   - the entries of the maps, e.g. the attributes, are sorted by key, so the hash does not depend on their order;
+//   - the order of the elements of the slices, e.g. the span events, is significant;
+//   - strings and byte slices are prefixed by their length, values by their type;
+//   - numbers are encoded as 8 little-endian bytes, floats as their IEEE 754 binary representation.
+//
+// The hash is stable across processes and versions of the collector, but it is not a cryptographic hash:
+// equal hashes of different data are unlikely but possible.
+package pdatahash // import "go.opentelemetry.io/collector/pdata/pdatahash"
+
+import (
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	offset64 uint64 = 14695981039346656037
+	prime64  uint64 = 1099511628211
+)
+
+// hasher computes the FNV-1a hash of the written data.
+type hasher struct {
+	sum uint64
+}
+
+func newHasher() *hasher {
+	return &hasher{sum: offset64}
+}
+
+func (h *hasher) writeByte(b byte) {
+	h.sum ^= uint64(b)
+	h.sum *= prime64
+}
+
+func (h *hasher) writeUint64(v uint64) {
+	for i := 0; i < 8; i++ {
+		h.writeByte(byte(v))
+		v >>= 8
+	}
+}
+
+func (h *hasher) writeInt64(v int64) {
+	h.writeUint64(uint64(v))
+}
+
+func (h *hasher) writeFloat64(v float64) {
+	h.writeUint64(math.Float64bits(v))
+}
+
+func (h *hasher) writeBool(v bool) {
+	if v {
+		h.writeByte(1)
+	} else {
+		h.writeByte(0)
+	}
+}
+
+func (h *hasher) writeString(s string) {
+	h.writeUint64(uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h.writeByte(s[i])
+	}
+}
+
+func (h *hasher) writeBytes(b []byte) {
+	h.writeUint64(uint64(len(b)))
+	for _, c := range b {
+		h.writeByte(c)
+	}
+}
+
+func (h *hasher) writeTimestamp(ts pcommon.Timestamp) {
+	h.writeUint64(uint64(ts))
+}
+
+func (h *hasher) writeTraceID(id pcommon.TraceID) {
+	b := id.Bytes()
+	for _, c := range b {
+		h.writeByte(c)
+	}
+}
+
+func (h *hasher) writeSpanID(id pcommon.SpanID) {
+	b := id.Bytes()
+	for _, c := range b {
+		h.writeByte(c)
+	}
+}
+
+func (h *hasher) writeMap(m pcommon.Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	h.writeUint64(uint64(len(keys)))
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		h.writeString(k)
+		h.writeValue(v)
+	}
+}
+
+func (h *hasher) writeValue(v pcommon.Value) {
+	h.writeByte(byte(v.Type()))
+	switch v.Type() {
+	case pcommon.ValueTypeString:
+		h.writeString(v.StringVal())
+	case pcommon.ValueTypeInt:
+		h.writeInt64(v.IntVal())
+	case pcommon.ValueTypeDouble:
+		h.writeFloat64(v.DoubleVal())
+	case pcommon.ValueTypeBool:
+		h.writeBool(v.BoolVal())
+	case pcommon.ValueTypeMap:
+		h.writeMap(v.MapVal())
+	case pcommon.ValueTypeSlice:
+		s := v.SliceVal()
+		h.writeUint64(uint64(s.Len()))
+		for i := 0; i < s.Len(); i++ {
+			h.writeValue(s.At(i))
+		}
+	case pcommon.ValueTypeBytes:
+		h.writeBytes(v.BytesVal().AsRaw())
+	}
+}
+
+// Map returns the hash of the map, independent of the order of its entries.
+func Map(m pcommon.Map) uint64 {
+	h := newHasher()
+	h.writeMap(m)
+	return h.sum
+}
+
+// Value returns the hash of the value, including its type.
+func Value(v pcommon.Value) uint64 {
+	h := newHasher()
+	h.writeValue(v)
+	return h.sum
+}
+
+// Resource returns the hash of the resource, independent of the order of its attributes.
+func Resource(r pcommon.Resource) uint64 {
+	h := newHasher()
+	h.writeMap(r.Attributes())
+	h.writeUint64(uint64(r.DroppedAttributesCount()))
+	return h.sum
+}
+
+// Scope returns the hash of the instrumentation scope.
+func Scope(s pcommon.InstrumentationScope) uint64 {
+	h := newHasher()
+	h.writeString(s.Name())
+	h.writeString(s.Version())
+	return h.sum
+}
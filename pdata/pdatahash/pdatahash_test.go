@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatahash
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestHasherIsFNV1a(t *testing.T) {
+	h := newHasher()
+	h.writeString("hello")
+	h.writeUint64(42)
+
+	expected := fnv.New64a()
+	_, _ = expected.Write([]byte{5, 0, 0, 0, 0, 0, 0, 0, 'h', 'e', 'l', 'l', 'o', 42, 0, 0, 0, 0, 0, 0, 0})
+	assert.Equal(t, expected.Sum64(), h.sum)
+}
+
+func TestMapOrderIndependent(t *testing.T) {
+	m1 := pcommon.NewMap()
+	m1.InsertString("a", "1")
+	m1.InsertInt("b", 2)
+	m1.Insert("nested", pcommon.NewValueMap())
+	nested, _ := m1.Get("nested")
+	nested.MapVal().InsertBool("x", true)
+	nested.MapVal().InsertDouble("y", 1.5)
+
+	m2 := pcommon.NewMap()
+	m2.Insert("nested", pcommon.NewValueMap())
+	nested, _ = m2.Get("nested")
+	nested.MapVal().InsertDouble("y", 1.5)
+	nested.MapVal().InsertBool("x", true)
+	m2.InsertInt("b", 2)
+	m2.InsertString("a", "1")
+
+	assert.Equal(t, Map(m1), Map(m2))
+
+	m2.UpsertInt("b", 3)
+	assert.NotEqual(t, Map(m1), Map(m2))
+}
+
+func TestMapStable(t *testing.T) {
+	// The hash is well-defined, it must not change across versions.
+	assert.Equal(t, uint64(0xa8c7f832281a39c5), Map(pcommon.NewMap()))
+
+	m := pcommon.NewMap()
+	m.InsertString("service.name", "svc")
+	m.InsertInt("count", 1)
+	assert.Equal(t, uint64(0x2695b018d1c5a532), Map(m))
+}
+
+func TestValueDistinguishesTypes(t *testing.T) {
+	values := []pcommon.Value{
+		pcommon.NewValueEmpty(),
+		pcommon.NewValueString(""),
+		pcommon.NewValueString("1"),
+		pcommon.NewValueInt(1),
+		pcommon.NewValueDouble(1),
+		pcommon.NewValueBool(true),
+		pcommon.NewValueBytes(pcommon.NewImmutableByteSlice([]byte("1"))),
+		pcommon.NewValueMap(),
+		pcommon.NewValueSlice(),
+	}
+	hashes := make(map[uint64]pcommon.Value, len(values))
+	for _, v := range values {
+		h := Value(v)
+		if prev, ok := hashes[h]; ok {
+			t.Errorf("%v and %v have the same hash", prev.AsString(), v.AsString())
+		}
+		hashes[h] = v
+	}
+}
+
+func TestValueSlice(t *testing.T) {
+	newSlice := func(strs ...string) pcommon.Value {
+		v := pcommon.NewValueSlice()
+		for _, s := range strs {
+			v.SliceVal().AppendEmpty().SetStringVal(s)
+		}
+		return v
+	}
+	assert.Equal(t, Value(newSlice("a", "b")), Value(newSlice("a", "b")))
+	// The order of the elements is significant.
+	assert.NotEqual(t, Value(newSlice("a", "b")), Value(newSlice("b", "a")))
+	// The strings are length prefixed, so their boundaries are significant.
+	assert.NotEqual(t, Value(newSlice("ab", "c")), Value(newSlice("a", "bc")))
+}
+
+func TestResource(t *testing.T) {
+	r1 := pcommon.NewResource()
+	r1.Attributes().InsertString("service.name", "svc")
+	r1.Attributes().InsertString("host.name", "host")
+	r2 := pcommon.NewResource()
+	r2.Attributes().InsertString("host.name", "host")
+	r2.Attributes().InsertString("service.name", "svc")
+	assert.Equal(t, Resource(r1), Resource(r2))
+
+	r2.SetDroppedAttributesCount(1)
+	assert.NotEqual(t, Resource(r1), Resource(r2))
+}
+
+func TestScope(t *testing.T) {
+	s1 := pcommon.NewInstrumentationScope()
+	s1.SetName("lib")
+	s1.SetVersion("1.0")
+	s2 := pcommon.NewInstrumentationScope()
+	s1.CopyTo(s2)
+	assert.Equal(t, Scope(s1), Scope(s2))
+
+	s2.SetVersion("1.1")
+	assert.NotEqual(t, Scope(s1), Scope(s2))
+
+	// The fields boundaries are significant.
+	s1.SetName("lib1")
+	s1.SetVersion(".0")
+	s2.SetName("lib")
+	s2.SetVersion("1.0")
+	assert.NotEqual(t, Scope(s1), Scope(s2))
+}
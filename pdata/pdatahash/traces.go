@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatahash // import "go.opentelemetry.io/collector/pdata/pdatahash"
+
+import (
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Span returns the hash of the span, including its events and links.
+func Span(s ptrace.Span) uint64 {
+	h := newHasher()
+	h.writeTraceID(s.TraceID())
+	h.writeSpanID(s.SpanID())
+	h.writeString(string(s.TraceState()))
+	h.writeSpanID(s.ParentSpanID())
+	h.writeString(s.Name())
+	h.writeInt64(int64(s.Kind()))
+	h.writeTimestamp(s.StartTimestamp())
+	h.writeTimestamp(s.EndTimestamp())
+	h.writeMap(s.Attributes())
+	h.writeUint64(uint64(s.DroppedAttributesCount()))
+
+	events := s.Events()
+	h.writeUint64(uint64(events.Len()))
+	for i := 0; i < events.Len(); i++ {
+		e := events.At(i)
+		h.writeTimestamp(e.Timestamp())
+		h.writeString(e.Name())
+		h.writeMap(e.Attributes())
+		h.writeUint64(uint64(e.DroppedAttributesCount()))
+	}
+	h.writeUint64(uint64(s.DroppedEventsCount()))
+
+	links := s.Links()
+	h.writeUint64(uint64(links.Len()))
+	for i := 0; i < links.Len(); i++ {
+		l := links.At(i)
+		h.writeTraceID(l.TraceID())
+		h.writeSpanID(l.SpanID())
+		h.writeString(string(l.TraceState()))
+		h.writeMap(l.Attributes())
+		h.writeUint64(uint64(l.DroppedAttributesCount()))
+		h.writeUint64(uint64(l.Flags()))
+	}
+	h.writeUint64(uint64(s.DroppedLinksCount()))
+
+	h.writeInt64(int64(s.Status().Code()))
+	h.writeString(s.Status().Message())
+	h.writeUint64(uint64(s.Flags()))
+	return h.sum
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pdatahash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestSpan() ptrace.Span {
+	s := ptrace.NewSpan()
+	s.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	s.SetSpanID(pcommon.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	s.SetName("operation")
+	s.SetKind(ptrace.SpanKindServer)
+	s.SetStartTimestamp(1000)
+	s.SetEndTimestamp(2000)
+	s.Attributes().InsertString("http.method", "GET")
+	s.Attributes().InsertInt("http.status_code", 200)
+	e := s.Events().AppendEmpty()
+	e.SetName("event")
+	e.SetTimestamp(1500)
+	l := s.Links().AppendEmpty()
+	l.SetTraceID(pcommon.NewTraceID([16]byte{16}))
+	s.Status().SetCode(ptrace.StatusCodeOk)
+	return s
+}
+
+func TestSpan(t *testing.T) {
+	s1 := newTestSpan()
+	s2 := newTestSpan()
+	assert.Equal(t, Span(s1), Span(s2))
+
+	// The attributes order is not significant.
+	s2.Attributes().Clear()
+	s2.Attributes().InsertInt("http.status_code", 200)
+	s2.Attributes().InsertString("http.method", "GET")
+	assert.Equal(t, Span(s1), Span(s2))
+
+	modifications := map[string]func(ptrace.Span){
+		"trace id":    func(s ptrace.Span) { s.SetTraceID(pcommon.NewTraceID([16]byte{2})) },
+		"span id":     func(s ptrace.Span) { s.SetSpanID(pcommon.NewSpanID([8]byte{2})) },
+		"parent":      func(s ptrace.Span) { s.SetParentSpanID(pcommon.NewSpanID([8]byte{2})) },
+		"trace state": func(s ptrace.Span) { s.SetTraceState("k=v") },
+		"name":        func(s ptrace.Span) { s.SetName("other") },
+		"kind":        func(s ptrace.Span) { s.SetKind(ptrace.SpanKindClient) },
+		"end":         func(s ptrace.Span) { s.SetEndTimestamp(3000) },
+		"attribute":   func(s ptrace.Span) { s.Attributes().UpsertString("http.method", "POST") },
+		"event":       func(s ptrace.Span) { s.Events().At(0).SetName("other") },
+		"new event":   func(s ptrace.Span) { s.Events().AppendEmpty() },
+		"link":        func(s ptrace.Span) { s.Links().At(0).Attributes().InsertBool("k", true) },
+		"status":      func(s ptrace.Span) { s.Status().SetMessage("message") },
+		"dropped":     func(s ptrace.Span) { s.SetDroppedLinksCount(1) },
+	}
+	for name, modify := range modifications {
+		t.Run(name, func(t *testing.T) {
+			s := newTestSpan()
+			modify(s)
+			assert.NotEqual(t, Span(s1), Span(s))
+		})
+	}
+}
+
+func BenchmarkSpan(b *testing.B) {
+	s := newTestSpan()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Span(s)
+	}
+}
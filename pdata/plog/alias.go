@@ -25,6 +25,13 @@ type Logs = internal.Logs
 // NewLogs creates a new Logs struct.
 var NewLogs = internal.NewLogs
 
+// LogsPool is an opt-in pool of Logs, reusing the top-level messages and the backing arrays
+// of their resource slices. It is safe for concurrent use.
+type LogsPool = internal.LogsPool
+
+// NewLogsPool returns a new empty LogsPool.
+var NewLogsPool = internal.NewLogsPool
+
 // SeverityNumber represents severity number of a log record.
 type SeverityNumber = internal.SeverityNumber
 
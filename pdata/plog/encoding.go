@@ -14,6 +14,12 @@
 
 package plog // import "go.opentelemetry.io/collector/pdata/plog"
 
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+)
+
 // Marshaler marshals pdata.Logs into bytes.
 type Marshaler interface {
 	// MarshalLogs the given pdata.Logs into bytes.
@@ -34,3 +40,37 @@ type Sizer interface {
 	// LogsSize returns the size in bytes of a marshaled Logs.
 	LogsSize(ld Logs) int
 }
+
+// MarshalerOption configures a Marshaler returned by NewProtoMarshaler or NewJSONMarshaler.
+type MarshalerOption func(*marshalerSettings)
+
+type marshalerSettings struct {
+	deterministic bool
+}
+
+// WithDeterministicMarshaling makes the Marshaler produce identical bytes for identical payloads,
+// independently of the order in which the attributes were inserted. The attributes of a copy of
+// the payload are sorted by key before marshaling, the marshaled Logs is not modified.
+func WithDeterministicMarshaling() MarshalerOption {
+	return func(s *marshalerSettings) {
+		s.deterministic = true
+	}
+}
+
+func newMarshalerSettings(opts []MarshalerOption) marshalerSettings {
+	var s marshalerSettings
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// toProto returns the proto representation of ld, with sorted attributes if deterministic is set.
+func (s marshalerSettings) toProto(ld Logs) otlplogs.LogsData {
+	if !s.deterministic {
+		return internal.LogsToProto(ld)
+	}
+	pb := internal.LogsToProto(ld.Clone())
+	otlp.SortLogsAttributes(pb.ResourceLogs)
+	return pb
+}
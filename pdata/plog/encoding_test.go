@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestDeterministicMarshaling(t *testing.T) {
+	newLogs := func(keys ...string) Logs {
+		ld := NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		body := pcommon.NewValueMap()
+		for _, k := range keys {
+			rl.Resource().Attributes().InsertString(k, "value")
+			lr.Attributes().InsertString(k, "value")
+			body.MapVal().InsertString(k, "value")
+		}
+		body.CopyTo(lr.Body())
+		return ld
+	}
+
+	for _, marshaler := range []Marshaler{
+		NewProtoMarshaler(WithDeterministicMarshaling()),
+		NewJSONMarshaler(WithDeterministicMarshaling()),
+	} {
+		ld1 := newLogs("a", "b", "c")
+		ld2 := newLogs("c", "a", "b")
+		buf1, err := marshaler.MarshalLogs(ld1)
+		require.NoError(t, err)
+		buf2, err := marshaler.MarshalLogs(ld2)
+		require.NoError(t, err)
+		assert.Equal(t, buf1, buf2)
+
+		// The marshaled Logs must not be modified.
+		var keys []string
+		ld2.ResourceLogs().At(0).Resource().Attributes().Range(func(k string, _ pcommon.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.Equal(t, []string{"c", "a", "b"}, keys)
+	}
+}
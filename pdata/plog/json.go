@@ -25,21 +25,22 @@ import (
 )
 
 // NewJSONMarshaler returns a Marshaler. Marshals to OTLP json bytes.
-func NewJSONMarshaler() Marshaler {
-	return newJSONMarshaler()
+func NewJSONMarshaler(opts ...MarshalerOption) Marshaler {
+	return newJSONMarshaler(opts...)
 }
 
 type jsonMarshaler struct {
 	delegate jsonpb.Marshaler
+	settings marshalerSettings
 }
 
-func newJSONMarshaler() *jsonMarshaler {
-	return &jsonMarshaler{delegate: jsonpb.Marshaler{}}
+func newJSONMarshaler(opts ...MarshalerOption) *jsonMarshaler {
+	return &jsonMarshaler{delegate: jsonpb.Marshaler{}, settings: newMarshalerSettings(opts)}
 }
 
 func (e *jsonMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
 	buf := bytes.Buffer{}
-	pb := internal.LogsToProto(ld)
+	pb := e.settings.toProto(ld)
 	err := e.delegate.Marshal(&buf, &pb)
 	return buf.Bytes(), err
 }
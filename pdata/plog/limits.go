@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// ApplyLimits enforces the attribute limits on the log record, equivalent to the log record limits of the
+// OpenTelemetry SDKs, adding the number of removed attributes to its dropped attributes count.
+// Returns the numbers of removed attributes and of truncated values.
+func ApplyLimits(lr LogRecord, limits pcommon.AttributeLimits) (dropped int, truncated int) {
+	dropped, truncated = limits.Apply(lr.Attributes())
+	lr.SetDroppedAttributesCount(lr.DroppedAttributesCount() + uint32(dropped))
+	return dropped, truncated
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestApplyLimits(t *testing.T) {
+	lr := NewLogRecord()
+	lr.SetDroppedAttributesCount(1)
+	lr.Attributes().InsertString("a", "value")
+	lr.Attributes().InsertString("b", "value")
+	lr.Body().SetStringVal("a long body")
+
+	dropped, truncated := ApplyLimits(lr, pcommon.AttributeLimits{MaxCount: 1, MaxValueLength: 2})
+	assert.Equal(t, 1, dropped)
+	assert.Equal(t, 1, truncated)
+	assert.Equal(t, map[string]interface{}{"a": "va"}, lr.Attributes().AsRaw())
+	assert.EqualValues(t, 2, lr.DroppedAttributesCount())
+	assert.Equal(t, "a long body", lr.Body().StringVal())
+}
@@ -20,25 +20,33 @@ import (
 )
 
 // NewProtoMarshaler returns a Marshaler. Marshals to OTLP binary protobuf bytes.
-func NewProtoMarshaler() Marshaler {
-	return newPbMarshaler()
+func NewProtoMarshaler(opts ...MarshalerOption) Marshaler {
+	return newPbMarshaler(opts...)
 }
 
 // TODO(#3842): Figure out how we want to represent/return *Sizers.
-type pbMarshaler struct{}
+type pbMarshaler struct {
+	settings marshalerSettings
+}
 
-func newPbMarshaler() *pbMarshaler {
-	return &pbMarshaler{}
+func newPbMarshaler(opts ...MarshalerOption) *pbMarshaler {
+	return &pbMarshaler{settings: newMarshalerSettings(opts)}
 }
 
 var _ Sizer = (*pbMarshaler)(nil)
 
 func (e *pbMarshaler) MarshalLogs(ld Logs) ([]byte, error) {
-	pb := internal.LogsToProto(ld)
+	if buf, ok := internal.LogsRawProto(ld); ok && !e.settings.deterministic {
+		return buf, nil
+	}
+	pb := e.settings.toProto(ld)
 	return pb.Marshal()
 }
 
 func (e *pbMarshaler) LogsSize(ld Logs) int {
+	if buf, ok := internal.LogsRawProto(ld); ok {
+		return len(buf)
+	}
 	pb := internal.LogsToProto(ld)
 	return pb.Size()
 }
@@ -59,3 +67,18 @@ func (d *pbUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
 	err := pb.Unmarshal(buf)
 	return internal.LogsFromProto(pb), err
 }
+
+type lazyPbUnmarshaler struct{}
+
+// NewLazyProtoUnmarshaler returns a model.Unmarshaler unmarshaling from OTLP binary protobuf bytes lazily,
+// for the pipelines forwarding the data without inspecting it. The bytes are validated without being decoded,
+// and decoded when the Logs is first accessed. Until the Logs is accessed as mutable, the bytes are reused
+// by the Marshaler returned by NewProtoMarshaler and by the OTLP requests instead of encoding it again.
+// The bytes must not be modified after unmarshaling.
+func NewLazyProtoUnmarshaler() Unmarshaler {
+	return &lazyPbUnmarshaler{}
+}
+
+func (d *lazyPbUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
+	return internal.UnmarshalLogsLazy(buf)
+}
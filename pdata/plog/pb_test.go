@@ -50,6 +50,31 @@ func TestProtoSizer_withNil(t *testing.T) {
 	assert.Equal(t, 0, sizer.LogsSize(NewLogs()))
 }
 
+func TestLazyProtoUnmarshaler(t *testing.T) {
+	ld := NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetSeverityText("foo")
+	buf, err := NewProtoMarshaler().MarshalLogs(ld)
+	require.NoError(t, err)
+
+	lazy, err := NewLazyProtoUnmarshaler().UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lazy.LogRecordCount())
+
+	// The unmodified bytes are reused, except by the deterministic marshaler.
+	got, err := NewProtoMarshaler().MarshalLogs(lazy)
+	require.NoError(t, err)
+	assert.Same(t, &buf[0], &got[0])
+	assert.Equal(t, len(buf), NewProtoMarshaler().(Sizer).LogsSize(lazy))
+	got, err = NewProtoMarshaler(WithDeterministicMarshaling()).MarshalLogs(lazy)
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+}
+
+func TestLazyProtoUnmarshaler_error(t *testing.T) {
+	_, err := NewLazyProtoUnmarshaler().UnmarshalLogs([]byte("+$%"))
+	assert.Error(t, err)
+}
+
 func BenchmarkLogsToProto(b *testing.B) {
 	marshaler := NewProtoMarshaler()
 	logs := generateBenchmarkLogs(128)
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plogotlp // import "go.opentelemetry.io/collector/pdata/plog/plogotlp"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Merge moves the log records of other to the end of lr, leaving other empty.
+// The resources and scopes of the two requests are appended as they are, without deduplication.
+func (lr Request) Merge(other Request) {
+	other.logs.ResourceLogs().MoveAndAppendTo(lr.logs.ResourceLogs())
+}
+
+// Split splits lr into requests of at most maxItems log records each, preserving the order of the log records.
+// The log records of the first requests are moved out of lr, the last returned request being lr itself
+// with the remaining log records. If maxItems <= 0 or lr has no more than maxItems log records, only lr is returned.
+func (lr Request) Split(maxItems int) []Request {
+	if maxItems <= 0 {
+		return []Request{lr}
+	}
+	var reqs []Request
+	for count := lr.logs.LogRecordCount(); count > maxItems; count -= maxItems {
+		reqs = append(reqs, NewRequestFromLogs(splitLogs(maxItems, lr.logs)))
+	}
+	return append(reqs, lr)
+}
+
+// splitLogs removes size log records from src and returns them in new logs, src having more than size log records.
+func splitLogs(size int, src plog.Logs) plog.Logs {
+	totalCopiedLogRecords := 0
+	dest := plog.NewLogs()
+
+	src.ResourceLogs().RemoveIf(func(srcRl plog.ResourceLogs) bool {
+		// If we are done skip everything else.
+		if totalCopiedLogRecords == size {
+			return false
+		}
+
+		// If it fully fits
+		srcRlLRC := resourceLogRecordCount(srcRl)
+		if (totalCopiedLogRecords + srcRlLRC) <= size {
+			totalCopiedLogRecords += srcRlLRC
+			srcRl.MoveTo(dest.ResourceLogs().AppendEmpty())
+			return true
+		}
+
+		destRl := dest.ResourceLogs().AppendEmpty()
+		srcRl.Resource().CopyTo(destRl.Resource())
+		destRl.SetSchemaUrl(srcRl.SchemaUrl())
+		srcRl.ScopeLogs().RemoveIf(func(srcSl plog.ScopeLogs) bool {
+			// If we are done skip everything else.
+			if totalCopiedLogRecords == size {
+				return false
+			}
+
+			// If possible to move all log records do that.
+			srcSlLRC := srcSl.LogRecords().Len()
+			if size-totalCopiedLogRecords >= srcSlLRC {
+				totalCopiedLogRecords += srcSlLRC
+				srcSl.MoveTo(destRl.ScopeLogs().AppendEmpty())
+				return true
+			}
+
+			destSl := destRl.ScopeLogs().AppendEmpty()
+			srcSl.Scope().CopyTo(destSl.Scope())
+			destSl.SetSchemaUrl(srcSl.SchemaUrl())
+			srcSl.LogRecords().RemoveIf(func(srcLr plog.LogRecord) bool {
+				// If we are done skip everything else.
+				if totalCopiedLogRecords == size {
+					return false
+				}
+				srcLr.MoveTo(destSl.LogRecords().AppendEmpty())
+				totalCopiedLogRecords++
+				return true
+			})
+			return false
+		})
+		return srcRl.ScopeLogs().Len() == 0
+	})
+
+	return dest
+}
+
+// resourceLogRecordCount returns the number of log records in the plog.ResourceLogs.
+func resourceLogRecordCount(rl plog.ResourceLogs) (count int) {
+	for k := 0; k < rl.ScopeLogs().Len(); k++ {
+		count += rl.ScopeLogs().At(k).LogRecords().Len()
+	}
+	return
+}
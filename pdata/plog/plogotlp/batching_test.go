@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plogotlp
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// newBatchingTestRequest returns a request with a resource per element of logsPerScope,
+// each with a scope per given log record count, the log record bodies being numbered in order.
+func newBatchingTestRequest(logsPerScope ...[]int) Request {
+	ld := plog.NewLogs()
+	n := 0
+	for i, scopes := range logsPerScope {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("resource", strconv.Itoa(i))
+		rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+		for j, count := range scopes {
+			sl := rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName("scope" + strconv.Itoa(j))
+			for k := 0; k < count; k++ {
+				sl.LogRecords().AppendEmpty().Body().SetStringVal("log" + strconv.Itoa(n))
+				n++
+			}
+		}
+	}
+	return NewRequestFromLogs(ld)
+}
+
+// logBodies returns the bodies of the log records of the request, with the resource and scope of each log record.
+func logBodies(req Request) []string {
+	var bodies []string
+	rls := req.Logs().ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		res, _ := rls.At(i).Resource().Attributes().Get("resource")
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				bodies = append(bodies, res.StringVal()+"/"+sls.At(j).Scope().Name()+"/"+lrs.At(k).Body().StringVal())
+			}
+		}
+	}
+	return bodies
+}
+
+func TestRequestMerge(t *testing.T) {
+	req := newBatchingTestRequest([]int{2})
+	other := newBatchingTestRequest([]int{1}, []int{1, 1})
+	req.Merge(other)
+
+	assert.Equal(t, 5, req.Logs().LogRecordCount())
+	assert.Equal(t, 3, req.Logs().ResourceLogs().Len())
+	assert.Equal(t, []string{"0/scope0/log0", "0/scope0/log1", "0/scope0/log0", "1/scope0/log1", "1/scope1/log2"}, logBodies(req))
+	assert.Equal(t, 0, other.Logs().LogRecordCount())
+
+	// Merging an empty request is a no-op.
+	req.Merge(NewRequest())
+	assert.Equal(t, 5, req.Logs().LogRecordCount())
+}
+
+func TestRequestSplit(t *testing.T) {
+	req := newBatchingTestRequest([]int{3, 2}, []int{4})
+	expected := logBodies(req)
+
+	reqs := req.Split(4)
+	require.Len(t, reqs, 3)
+	assert.Equal(t, 4, reqs[0].Logs().LogRecordCount())
+	assert.Equal(t, 4, reqs[1].Logs().LogRecordCount())
+	assert.Equal(t, 1, reqs[2].Logs().LogRecordCount())
+	assert.Equal(t, req, reqs[2])
+
+	var actual []string
+	for _, r := range reqs {
+		actual = append(actual, logBodies(r)...)
+		rls := r.Logs().ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			assert.Equal(t, "https://opentelemetry.io/schemas/1.9.0", rls.At(i).SchemaUrl())
+		}
+	}
+	assert.Equal(t, expected, actual)
+	// The second request gets the end of the first resource and the beginning of the second one.
+	assert.Equal(t, []string{"0/scope1/log4", "1/scope0/log5", "1/scope0/log6", "1/scope0/log7"}, logBodies(reqs[1]))
+}
+
+func TestRequestSplitNoop(t *testing.T) {
+	for _, maxItems := range []int{-1, 0, 5, 10} {
+		req := newBatchingTestRequest([]int{3, 2})
+		reqs := req.Split(maxItems)
+		require.Len(t, reqs, 1)
+		assert.Equal(t, req, reqs[0])
+		assert.Equal(t, 5, reqs[0].Logs().LogRecordCount())
+	}
+	assert.Len(t, NewRequest().Split(1), 1)
+}
@@ -27,6 +27,9 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
+// exportMethod is the full name of the Export method of the OTLP gRPC service.
+const exportMethod = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+
 var jsonMarshaler = &jsonpb.Marshaler{}
 var jsonUnmarshaler = &jsonpb.Unmarshaler{}
 
@@ -67,39 +70,43 @@ func (lr Response) UnmarshalJSON(data []byte) error {
 // Request represents the request for gRPC/HTTP client/server.
 // It's a wrapper for plog.Logs data.
 type Request struct {
-	orig *otlpcollectorlog.ExportLogsServiceRequest
+	logs plog.Logs
 }
 
 // NewRequest returns an empty Request.
 func NewRequest() Request {
-	return Request{orig: &otlpcollectorlog.ExportLogsServiceRequest{}}
+	return Request{logs: plog.NewLogs()}
 }
 
 // NewRequestFromLogs returns a Request from plog.Logs.
 // Because Request is a wrapper for plog.Logs,
 // any changes to the provided Logs struct will be reflected in the Request and vice versa.
 func NewRequestFromLogs(l plog.Logs) Request {
-	return Request{orig: internal.LogsToOtlp(l)}
+	return Request{logs: l}
 }
 
 // MarshalProto marshals Request into proto bytes.
 func (lr Request) MarshalProto() ([]byte, error) {
-	return lr.orig.Marshal()
+	if buf, ok := internal.LogsRawProto(lr.logs); ok {
+		return buf, nil
+	}
+	return lr.orig().Marshal()
 }
 
 // UnmarshalProto unmarshalls Request from proto bytes.
 func (lr Request) UnmarshalProto(data []byte) error {
-	if err := lr.orig.Unmarshal(data); err != nil {
+	orig := lr.orig()
+	if err := orig.Unmarshal(data); err != nil {
 		return err
 	}
-	otlp.InstrumentationLibraryLogsToScope(lr.orig.ResourceLogs)
+	otlp.InstrumentationLibraryLogsToScope(orig.ResourceLogs)
 	return nil
 }
 
 // MarshalJSON marshals Request into JSON bytes.
 func (lr Request) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
-	if err := jsonMarshaler.Marshal(&buf, lr.orig); err != nil {
+	if err := jsonMarshaler.Marshal(&buf, lr.orig()); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
@@ -107,15 +114,21 @@ func (lr Request) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls Request from JSON bytes.
 func (lr Request) UnmarshalJSON(data []byte) error {
-	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), lr.orig); err != nil {
+	orig := lr.orig()
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), orig); err != nil {
 		return err
 	}
-	otlp.InstrumentationLibraryLogsToScope(lr.orig.ResourceLogs)
+	otlp.InstrumentationLibraryLogsToScope(orig.ResourceLogs)
 	return nil
 }
 
 func (lr Request) Logs() plog.Logs {
-	return internal.LogsFromOtlp(lr.orig)
+	return lr.logs
+}
+
+// orig returns the request message, decoding the logs if lazily unmarshaled.
+func (lr Request) orig() *otlpcollectorlog.ExportLogsServiceRequest {
+	return internal.LogsToOtlp(lr.logs)
 }
 
 // Client is the client API for OTLP-GRPC Logs service.
@@ -131,16 +144,79 @@ type Client interface {
 
 type logsClient struct {
 	rawClient otlpcollectorlog.LogsServiceClient
+	cc        *grpc.ClientConn
+	invoker   grpc.UnaryInvoker
+	callOpts  []grpc.CallOption
+}
+
+// ClientOption configures the Client returned by NewClientWithOptions.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	interceptors []grpc.UnaryClientInterceptor
+	callOpts     []grpc.CallOption
+}
+
+// WithUnaryClientInterceptors adds the interceptors called around the exports, the first one being
+// the outermost one. The interceptors receive the Request as the request and a *Response as the reply.
+func WithUnaryClientInterceptors(interceptors ...grpc.UnaryClientInterceptor) ClientOption {
+	return func(o *clientOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+// WithCallOptions adds the call options of all the exports, preceding the call options of each export.
+func WithCallOptions(opts ...grpc.CallOption) ClientOption {
+	return func(o *clientOptions) {
+		o.callOpts = append(o.callOpts, opts...)
+	}
 }
 
 // NewClient returns a new Client connected using the given connection.
 func NewClient(cc *grpc.ClientConn) Client {
-	return &logsClient{rawClient: otlpcollectorlog.NewLogsServiceClient(cc)}
+	return NewClientWithOptions(cc)
+}
+
+// NewClientWithOptions returns a new Client connected using the given connection, configured by the
+// options, e.g. to inject interceptors without dialing the connection with them.
+func NewClientWithOptions(cc *grpc.ClientConn, opts ...ClientOption) Client {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &logsClient{rawClient: otlpcollectorlog.NewLogsServiceClient(cc), cc: cc, callOpts: o.callOpts}
+	c.invoker = internal.ChainUnaryClient(o.interceptors, c.invoke)
+	return c
 }
 
+// Export implements the Client interface.
 func (c *logsClient) Export(ctx context.Context, request Request, opts ...grpc.CallOption) (Response, error) {
-	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
-	return Response{orig: rsp}, err
+	if len(c.callOpts) > 0 {
+		opts = append(c.callOpts[:len(c.callOpts):len(c.callOpts)], opts...)
+	}
+	var rsp Response
+	err := c.invoker(ctx, exportMethod, request, &rsp, c.cc, opts...)
+	return rsp, err
+}
+
+// invoke sends the Request, setting the *Response on success.
+func (c *logsClient) invoke(ctx context.Context, _ string, req, reply interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+	request := req.(Request)
+	if buf, ok := internal.LogsRawProto(request.logs); ok {
+		// Send the bytes the logs were lazily unmarshaled from as is.
+		rsp := &otlpcollectorlog.ExportLogsServiceResponse{}
+		if err := c.cc.Invoke(ctx, exportMethod, &internal.RawMessage{Buf: buf}, rsp, opts...); err != nil {
+			return err
+		}
+		reply.(*Response).orig = rsp
+		return nil
+	}
+	rsp, err := c.rawClient.Export(ctx, request.orig(), opts...)
+	if err != nil {
+		return err
+	}
+	reply.(*Response).orig = rsp
+	return nil
 }
 
 // Server is the server API for OTLP gRPC LogsService service.
@@ -152,17 +228,49 @@ type Server interface {
 	Export(context.Context, Request) (Response, error)
 }
 
+// ServerOption configures the Server registered by RegisterServerWithOptions.
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+// WithUnaryServerInterceptors adds the interceptors called around the exports, after the interceptors
+// of the grpc.Server, the first one being the outermost one. The interceptors receive the Request as
+// the request, and return the Response.
+func WithUnaryServerInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
 // RegisterServer registers the Server to the grpc.Server.
 func RegisterServer(s *grpc.Server, srv Server) {
-	otlpcollectorlog.RegisterLogsServiceServer(s, &rawLogsServer{srv: srv})
+	RegisterServerWithOptions(s, srv)
+}
+
+// RegisterServerWithOptions registers the Server to the grpc.Server, configured by the options.
+func RegisterServerWithOptions(s *grpc.Server, srv Server, opts ...ServerOption) {
+	var o serverOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	handler := internal.ChainUnaryServer(o.interceptors, &grpc.UnaryServerInfo{Server: srv, FullMethod: exportMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.Export(ctx, req.(Request))
+		})
+	otlpcollectorlog.RegisterLogsServiceServer(s, &rawLogsServer{handler: handler})
 }
 
 type rawLogsServer struct {
-	srv Server
+	handler grpc.UnaryHandler
 }
 
 func (s rawLogsServer) Export(ctx context.Context, request *otlpcollectorlog.ExportLogsServiceRequest) (*otlpcollectorlog.ExportLogsServiceResponse, error) {
 	otlp.InstrumentationLibraryLogsToScope(request.ResourceLogs)
-	rsp, err := s.srv.Export(ctx, Request{orig: request})
-	return rsp.orig, err
+	rsp, err := s.handler(ctx, Request{logs: internal.LogsFromOtlp(request)})
+	if r, ok := rsp.(Response); ok {
+		return r.orig, err
+	}
+	return nil, err
 }
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plogutil provides utilities to process the log records across batches, e.g. for the
+// components de-duplicating or rate limiting the logs.
+package plogutil // import "go.opentelemetry.io/collector/pdata/plog/plogutil"
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// DefaultCountAttribute is the default attribute of the de-duplicated log records with their number of duplicates.
+const DefaultCountAttribute = "log.record.count"
+
+// DedupSettings configures a Deduplicator.
+type DedupSettings struct {
+	// Attributes are the keys of the attributes of the log records identifying the duplicates with their body,
+	// resource and scope. The other attributes are the ones of the first log record.
+	Attributes []string
+	// CountAttribute is the attribute set on the de-duplicated log records with their number of duplicates,
+	// DefaultCountAttribute if empty.
+	CountAttribute string
+	// Window is the duration during which the duplicates of a log record are aggregated before it is flushed.
+	Window time.Duration
+}
+
+// Deduplicator aggregates the duplicates of the log records received within a time window: a log record with the
+// same body, selected attributes, resource and scope as a previous one is dropped, incrementing the count attribute
+// of the previous one, which is returned by Flush once its window elapsed. A Deduplicator is not safe for concurrent use.
+type Deduplicator struct {
+	settings DedupSettings
+	now      func() time.Time
+
+	records map[string]*dedupRecord
+	// order is the keys of the records in the order of their first log record.
+	order  []string
+	scopes map[string]*dedupScope
+}
+
+type dedupScope struct {
+	key               string
+	resourceKey       string
+	resource          pcommon.Resource
+	resourceSchemaURL string
+	scope             pcommon.InstrumentationScope
+	scopeSchemaURL    string
+	records           int
+}
+
+type dedupRecord struct {
+	scope *dedupScope
+	first time.Time
+	count int64
+	lr    plog.LogRecord
+}
+
+// NewDeduplicator returns a Deduplicator with the settings.
+func NewDeduplicator(settings DedupSettings) *Deduplicator {
+	if settings.CountAttribute == "" {
+		settings.CountAttribute = DefaultCountAttribute
+	}
+	return &Deduplicator{
+		settings: settings,
+		now:      time.Now,
+		records:  make(map[string]*dedupRecord),
+		scopes:   make(map[string]*dedupScope),
+	}
+}
+
+// Add aggregates the log records of the logs. The log records already having the count attribute, e.g. de-duplicated
+// by a previous collector, count as their number of duplicates.
+func (d *Deduplicator) Add(ld plog.Logs) {
+	now := d.now()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceKey := attributesKey(rl.Resource().Attributes()) + "\x00" + rl.SchemaUrl()
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			scopeKey := resourceKey + "\x00" + sl.Scope().Name() + "\x00" + sl.Scope().Version() + "\x00" + sl.SchemaUrl()
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				key := scopeKey + "\x00" + d.recordKey(lr)
+				count := recordCount(lr, d.settings.CountAttribute)
+				if rec, ok := d.records[key]; ok {
+					rec.count += count
+					if lr.ObservedTimestamp() > rec.lr.ObservedTimestamp() {
+						rec.lr.SetObservedTimestamp(lr.ObservedTimestamp())
+					}
+					continue
+				}
+
+				scope, ok := d.scopes[scopeKey]
+				if !ok {
+					scope = &dedupScope{
+						key:               scopeKey,
+						resourceKey:       resourceKey,
+						resource:          pcommon.NewResource(),
+						resourceSchemaURL: rl.SchemaUrl(),
+						scope:             pcommon.NewInstrumentationScope(),
+						scopeSchemaURL:    sl.SchemaUrl(),
+					}
+					rl.Resource().CopyTo(scope.resource)
+					sl.Scope().CopyTo(scope.scope)
+					d.scopes[scopeKey] = scope
+				}
+				scope.records++
+				rec := &dedupRecord{scope: scope, first: now, count: count, lr: plog.NewLogRecord()}
+				lr.CopyTo(rec.lr)
+				d.records[key] = rec
+				d.order = append(d.order, key)
+			}
+		}
+	}
+}
+
+// Flush returns the de-duplicated log records whose window elapsed, with their count attribute, and removes them.
+// The log records keep the timestamp of the first duplicate and the latest observed timestamp of the duplicates.
+func (d *Deduplicator) Flush() plog.Logs {
+	deadline := d.now().Add(-d.settings.Window)
+	return d.flush(func(rec *dedupRecord) bool { return !rec.first.After(deadline) })
+}
+
+// FlushAll returns all the de-duplicated log records, e.g. on shutdown, and removes them.
+func (d *Deduplicator) FlushAll() plog.Logs {
+	return d.flush(func(*dedupRecord) bool { return true })
+}
+
+// Len returns the number of de-duplicated log records not flushed yet.
+func (d *Deduplicator) Len() int {
+	return len(d.order)
+}
+
+func (d *Deduplicator) flush(expired func(*dedupRecord) bool) plog.Logs {
+	ld := plog.NewLogs()
+	rls := make(map[string]plog.ResourceLogs)
+	sls := make(map[*dedupScope]plog.ScopeLogs)
+	remaining := d.order[:0]
+	for _, key := range d.order {
+		rec := d.records[key]
+		if !expired(rec) {
+			remaining = append(remaining, key)
+			continue
+		}
+
+		sl, ok := sls[rec.scope]
+		if !ok {
+			rl, found := rls[rec.scope.resourceKey]
+			if !found {
+				rl = ld.ResourceLogs().AppendEmpty()
+				rec.scope.resource.CopyTo(rl.Resource())
+				rl.SetSchemaUrl(rec.scope.resourceSchemaURL)
+				rls[rec.scope.resourceKey] = rl
+			}
+			sl = rl.ScopeLogs().AppendEmpty()
+			rec.scope.scope.CopyTo(sl.Scope())
+			sl.SetSchemaUrl(rec.scope.scopeSchemaURL)
+			sls[rec.scope] = sl
+		}
+		lr := sl.LogRecords().AppendEmpty()
+		rec.lr.MoveTo(lr)
+		lr.Attributes().UpsertInt(d.settings.CountAttribute, rec.count)
+
+		delete(d.records, key)
+		rec.scope.records--
+		if rec.scope.records == 0 {
+			delete(d.scopes, rec.scope.key)
+		}
+	}
+	for i := len(remaining); i < len(d.order); i++ {
+		d.order[i] = ""
+	}
+	d.order = remaining
+	return ld
+}
+
+// recordKey returns a string identifying the duplicates of the log record, made of its body and selected attributes.
+func (d *Deduplicator) recordKey(lr plog.LogRecord) string {
+	var sb strings.Builder
+	sb.WriteString(lr.Body().Type().String())
+	sb.WriteByte('\x01')
+	sb.WriteString(lr.Body().AsString())
+	for _, k := range d.settings.Attributes {
+		sb.WriteByte('\x02')
+		if v, ok := lr.Attributes().Get(k); ok {
+			sb.WriteString(v.Type().String())
+			sb.WriteByte('\x01')
+			sb.WriteString(v.AsString())
+		}
+	}
+	return sb.String()
+}
+
+// recordCount returns the number of duplicates of the log record, the value of its count attribute if positive.
+func recordCount(lr plog.LogRecord, countAttribute string) int64 {
+	if v, ok := lr.Attributes().Get(countAttribute); ok && v.Type() == pcommon.ValueTypeInt && v.IntVal() > 0 {
+		return v.IntVal()
+	}
+	return 1
+}
+
+// attributesKey returns a string identifying the attributes, independently of their order.
+func attributesKey(attrs pcommon.Map) string {
+	kvs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		kvs = append(kvs, k+"\x01"+v.Type().String()+"\x01"+v.AsString())
+		return true
+	})
+	sort.Strings(kvs)
+	return strings.Join(kvs, "\x02")
+}
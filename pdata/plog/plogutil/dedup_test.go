@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plogutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newTestScopeLogs(ld plog.Logs, service string) plog.ScopeLogs {
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("service.name", service)
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("app")
+	return sl
+}
+
+func appendLogRecord(sl plog.ScopeLogs, body string, path string, observed pcommon.Timestamp) plog.LogRecord {
+	lr := sl.LogRecords().AppendEmpty()
+	lr.Body().SetStringVal(body)
+	lr.Attributes().InsertString("http.path", path)
+	lr.Attributes().InsertString("request.id", body+path+observed.String())
+	lr.SetTimestamp(observed)
+	lr.SetObservedTimestamp(observed)
+	return lr
+}
+
+func TestDeduplicator(t *testing.T) {
+	now := time.Unix(100, 0)
+	d := NewDeduplicator(DedupSettings{Attributes: []string{"http.path"}, Window: 10 * time.Second})
+	d.now = func() time.Time { return now }
+
+	ld := plog.NewLogs()
+	sl := newTestScopeLogs(ld, "frontend")
+	appendLogRecord(sl, "timeout", "/a", 1)
+	appendLogRecord(sl, "timeout", "/b", 2)
+	appendLogRecord(sl, "timeout", "/a", 3)
+	sl = newTestScopeLogs(ld, "backend")
+	appendLogRecord(sl, "timeout", "/a", 4)
+	d.Add(ld)
+	assert.Equal(t, 3, d.Len())
+
+	now = now.Add(5 * time.Second)
+	ld = plog.NewLogs()
+	sl = newTestScopeLogs(ld, "frontend")
+	appendLogRecord(sl, "timeout", "/a", 5).Attributes().InsertInt(DefaultCountAttribute, 3)
+	appendLogRecord(sl, "refused", "/a", 6)
+	d.Add(ld)
+	assert.Equal(t, 4, d.Len())
+	assert.Equal(t, 0, d.Flush().LogRecordCount())
+
+	now = now.Add(5 * time.Second)
+	flushed := d.Flush()
+	assert.Equal(t, 1, d.Len())
+	require.Equal(t, 2, flushed.ResourceLogs().Len())
+
+	rl := flushed.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "frontend"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.9.0", rl.SchemaUrl())
+	require.Equal(t, 1, rl.ScopeLogs().Len())
+	assert.Equal(t, "app", rl.ScopeLogs().At(0).Scope().Name())
+	lrs := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, lrs.Len())
+	assert.Equal(t, "timeout", lrs.At(0).Body().StringVal())
+	assert.Equal(t, pcommon.Timestamp(1), lrs.At(0).Timestamp())
+	assert.Equal(t, pcommon.Timestamp(5), lrs.At(0).ObservedTimestamp())
+	assert.Equal(t, map[string]interface{}{
+		"http.path":           "/a",
+		"request.id":          "timeout/a" + pcommon.Timestamp(1).String(),
+		DefaultCountAttribute: int64(5),
+	}, lrs.At(0).Attributes().AsRaw())
+	count, ok := lrs.At(1).Attributes().Get(DefaultCountAttribute)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), count.IntVal())
+
+	rl = flushed.ResourceLogs().At(1)
+	assert.Equal(t, map[string]interface{}{"service.name": "backend"}, rl.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rl.ScopeLogs().At(0).LogRecords().Len())
+
+	flushed = d.FlushAll()
+	assert.Equal(t, 0, d.Len())
+	require.Equal(t, 1, flushed.LogRecordCount())
+	assert.Equal(t, "refused", flushed.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().StringVal())
+	assert.Empty(t, d.records)
+	assert.Empty(t, d.scopes)
+}
+
+func TestDeduplicatorCountAttribute(t *testing.T) {
+	d := NewDeduplicator(DedupSettings{CountAttribute: "count"})
+
+	ld := plog.NewLogs()
+	sl := newTestScopeLogs(ld, "frontend")
+	appendLogRecord(sl, "timeout", "/a", 1)
+	appendLogRecord(sl, "timeout", "/b", 2)
+	d.Add(ld)
+	assert.Equal(t, 1, d.Len())
+
+	flushed := d.Flush()
+	require.Equal(t, 1, flushed.LogRecordCount())
+	count, ok := flushed.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("count")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), count.IntVal())
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLogsRaw(t *testing.T) {
+	ld := NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().UpsertString("host.name", "testHost")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("name")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(1000)
+	lr.SetSeverityNumber(SeverityNumberERROR)
+	lr.Body().SetStringVal("error")
+	lr.Attributes().UpsertInt("http.status_code", 500)
+	lr.SetTraceID(pcommon.NewTraceID([16]byte{1, 2, 3}))
+
+	raw, err := AsRaw(ld)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{"attributes": []interface{}{
+				map[string]interface{}{"key": "host.name", "value": map[string]interface{}{"stringValue": "testHost"}},
+			}},
+			"scopeLogs": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": "name"},
+				"logRecords": []interface{}{map[string]interface{}{
+					"timeUnixNano":   "1000",
+					"severityNumber": "SEVERITY_NUMBER_ERROR",
+					"body":           map[string]interface{}{"stringValue": "error"},
+					"attributes": []interface{}{
+						map[string]interface{}{"key": "http.status_code", "value": map[string]interface{}{"intValue": "500"}},
+					},
+					"traceId": "01020300000000000000000000000000",
+					"spanId":  "",
+				}},
+			}},
+		}},
+	}, raw)
+
+	got, err := FromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+}
+
+func TestLogsFromRawNumbers(t *testing.T) {
+	got, err := FromRaw(map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"scopeLogs": []interface{}{map[string]interface{}{
+				"logRecords": []interface{}{map[string]interface{}{"timeUnixNano": 1000, "severityNumber": 17}},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, got.LogRecordCount())
+	lr := got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.Timestamp(1000), lr.Timestamp())
+	assert.Equal(t, SeverityNumberERROR, lr.SeverityNumber())
+
+	_, err = FromRaw(map[string]interface{}{"resourceLogs": "invalid"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// TraceIDSamplingHash returns the 32-bit murmur3 hash of the trace ID bytes with the given seed.
+// This is the hash used by trace samplers hashing the trace ID, so that sampling the log records
+// with it makes the same decisions as sampling the traces.
+func TraceIDSamplingHash(traceID pcommon.TraceID, seed uint32) uint32 {
+	b := traceID.Bytes()
+	return murmur3Sum32(b[:], seed)
+}
+
+// SamplingHash returns a hash identifying the log record for sampling decisions, computed with the given seed.
+// The hash is computed from the trace ID if set, so that log records are sampled consistently with their trace.
+// Otherwise it is computed from the string representation of the first attribute present among the given keys.
+// Returns false if the log record has neither a trace ID nor any of the attributes.
+func SamplingHash(lr LogRecord, seed uint32, attributeKeys ...string) (uint32, bool) {
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		return TraceIDSamplingHash(traceID, seed), true
+	}
+	for _, key := range attributeKeys {
+		if val, ok := lr.Attributes().Get(key); ok {
+			return murmur3Sum32([]byte(val.AsString()), seed), true
+		}
+	}
+	return 0, false
+}
+
+const (
+	murmur3C1 uint32 = 0xcc9e2d51
+	murmur3C2 uint32 = 0x1b873593
+)
+
+// murmur3Sum32 returns the 32-bit murmur3 (x86 variant) hash of the data.
+func murmur3Sum32(data []byte, seed uint32) uint32 {
+	h := seed
+	nblocks := len(data) / 4
+	for i := 0; i < nblocks; i++ {
+		k := binary.LittleEndian.Uint32(data[i*4:])
+		k *= murmur3C1
+		k = bits.RotateLeft32(k, 15)
+		k *= murmur3C2
+
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	tail := data[nblocks*4:]
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= murmur3C1
+		k = bits.RotateLeft32(k, 15)
+		k *= murmur3C2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMurmur3Sum32(t *testing.T) {
+	tests := []struct {
+		data string
+		seed uint32
+		want uint32
+	}{
+		{data: "", seed: 0, want: 0},
+		{data: "", seed: 1, want: 0x514e28b7},
+		{data: "", seed: 0xffffffff, want: 0x81f16f39},
+		{data: "\x00\x00\x00\x00", seed: 0, want: 0x2362f9de},
+		{data: "aaaa", seed: 0x9747b28c, want: 0x5a97808a},
+		{data: "aaa", seed: 0x9747b28c, want: 0x283e0130},
+		{data: "aa", seed: 0x9747b28c, want: 0x5d211726},
+		{data: "a", seed: 0x9747b28c, want: 0x7fa09ea6},
+		{data: "Hello, world!", seed: 0x9747b28c, want: 0x24884cba},
+		{data: "The quick brown fox jumps over the lazy dog", seed: 0x9747b28c, want: 0x2fa826cd},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, murmur3Sum32([]byte(tt.data), tt.seed), "data %q, seed %d", tt.data, tt.seed)
+	}
+}
+
+func TestSamplingHash(t *testing.T) {
+	traceID := pcommon.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	lr := NewLogRecord()
+	lr.Attributes().UpsertString("request.id", "abc")
+	lr.Attributes().UpsertInt("user.id", 42)
+
+	hash, ok := SamplingHash(lr, 1, "user.id", "request.id")
+	assert.True(t, ok)
+	assert.Equal(t, murmur3Sum32([]byte("42"), 1), hash)
+
+	hash, ok = SamplingHash(lr, 1, "missing", "request.id")
+	assert.True(t, ok)
+	assert.Equal(t, murmur3Sum32([]byte("abc"), 1), hash)
+
+	_, ok = SamplingHash(lr, 1, "missing")
+	assert.False(t, ok)
+
+	// The trace ID takes precedence over the attributes.
+	lr.SetTraceID(traceID)
+	hash, ok = SamplingHash(lr, 1, "user.id")
+	assert.True(t, ok)
+	assert.Equal(t, TraceIDSamplingHash(traceID, 1), hash)
+	assert.NotEqual(t, TraceIDSamplingHash(traceID, 1), TraceIDSamplingHash(traceID, 2))
+}
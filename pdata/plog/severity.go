@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import "strings"
+
+// SeverityScheme is a scheme of severity levels used by a third-party log format.
+type SeverityScheme int32
+
+const (
+	// SeveritySchemeSyslog is the scheme of the syslog severities (RFC 5424), e.g. "err" or "notice".
+	SeveritySchemeSyslog SeverityScheme = iota + 1
+	// SeveritySchemeZap is the scheme of the go.uber.org/zap levels, e.g. "dpanic".
+	SeveritySchemeZap
+	// SeveritySchemeLogrus is the scheme of the github.com/sirupsen/logrus levels, e.g. "warning".
+	SeveritySchemeLogrus
+	// SeveritySchemeWindowsEvent is the scheme of the Windows event log levels, e.g. "Information".
+	SeveritySchemeWindowsEvent
+)
+
+// severityLevel is a level of a SeverityScheme, covering the severity numbers up to maxNumber.
+type severityLevel struct {
+	text      string
+	maxNumber SeverityNumber
+}
+
+type severityScheme struct {
+	// parse maps the lower case texts, including the aliases, to their severity number.
+	parse map[string]SeverityNumber
+	// levels are the levels in increasing severity.
+	levels []severityLevel
+}
+
+// severitySchemes maps the syslog and zap levels as specified by the appendix B of the OpenTelemetry logs data model,
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/logs/data-model.md#appendix-b-severitynumber-example-mappings.
+var severitySchemes = map[SeverityScheme]severityScheme{
+	SeveritySchemeSyslog: {
+		parse: map[string]SeverityNumber{
+			"debug":         SeverityNumberDEBUG,
+			"info":          SeverityNumberINFO,
+			"informational": SeverityNumberINFO,
+			"notice":        SeverityNumberINFO2,
+			"warning":       SeverityNumberWARN,
+			"warn":          SeverityNumberWARN,
+			"err":           SeverityNumberERROR,
+			"error":         SeverityNumberERROR,
+			"crit":          SeverityNumberERROR2,
+			"critical":      SeverityNumberERROR2,
+			"alert":         SeverityNumberERROR3,
+			"emerg":         SeverityNumberFATAL,
+			"emergency":     SeverityNumberFATAL,
+			"panic":         SeverityNumberFATAL,
+		},
+		levels: []severityLevel{
+			{text: "debug", maxNumber: SeverityNumberDEBUG4},
+			{text: "info", maxNumber: SeverityNumberINFO},
+			{text: "notice", maxNumber: SeverityNumberINFO4},
+			{text: "warning", maxNumber: SeverityNumberWARN4},
+			{text: "err", maxNumber: SeverityNumberERROR},
+			{text: "crit", maxNumber: SeverityNumberERROR2},
+			{text: "alert", maxNumber: SeverityNumberERROR4},
+			{text: "emerg", maxNumber: SeverityNumberFATAL4},
+		},
+	},
+	SeveritySchemeZap: {
+		parse: map[string]SeverityNumber{
+			"debug":  SeverityNumberDEBUG,
+			"info":   SeverityNumberINFO,
+			"warn":   SeverityNumberWARN,
+			"error":  SeverityNumberERROR,
+			"dpanic": SeverityNumberERROR2,
+			"panic":  SeverityNumberERROR3,
+			"fatal":  SeverityNumberFATAL,
+		},
+		levels: []severityLevel{
+			{text: "debug", maxNumber: SeverityNumberDEBUG4},
+			{text: "info", maxNumber: SeverityNumberINFO4},
+			{text: "warn", maxNumber: SeverityNumberWARN4},
+			{text: "error", maxNumber: SeverityNumberERROR},
+			{text: "dpanic", maxNumber: SeverityNumberERROR2},
+			{text: "panic", maxNumber: SeverityNumberERROR4},
+			{text: "fatal", maxNumber: SeverityNumberFATAL4},
+		},
+	},
+	SeveritySchemeLogrus: {
+		parse: map[string]SeverityNumber{
+			"trace":   SeverityNumberTRACE,
+			"debug":   SeverityNumberDEBUG,
+			"info":    SeverityNumberINFO,
+			"warning": SeverityNumberWARN,
+			"warn":    SeverityNumberWARN,
+			"error":   SeverityNumberERROR,
+			"fatal":   SeverityNumberFATAL,
+			"panic":   SeverityNumberFATAL2,
+		},
+		levels: []severityLevel{
+			{text: "trace", maxNumber: SeverityNumberTRACE4},
+			{text: "debug", maxNumber: SeverityNumberDEBUG4},
+			{text: "info", maxNumber: SeverityNumberINFO4},
+			{text: "warning", maxNumber: SeverityNumberWARN4},
+			{text: "error", maxNumber: SeverityNumberERROR4},
+			{text: "fatal", maxNumber: SeverityNumberFATAL},
+			{text: "panic", maxNumber: SeverityNumberFATAL4},
+		},
+	},
+	SeveritySchemeWindowsEvent: {
+		parse: map[string]SeverityNumber{
+			"verbose":     SeverityNumberDEBUG,
+			"information": SeverityNumberINFO,
+			"info":        SeverityNumberINFO,
+			"warning":     SeverityNumberWARN,
+			"error":       SeverityNumberERROR,
+			"critical":    SeverityNumberFATAL,
+		},
+		levels: []severityLevel{
+			{text: "Verbose", maxNumber: SeverityNumberDEBUG4},
+			{text: "Information", maxNumber: SeverityNumberINFO4},
+			{text: "Warning", maxNumber: SeverityNumberWARN4},
+			{text: "Error", maxNumber: SeverityNumberERROR4},
+			{text: "Critical", maxNumber: SeverityNumberFATAL4},
+		},
+	},
+}
+
+// ParseSeverityText returns the severity number of the severity text of the given scheme, ignoring the case
+// and the surrounding whitespaces. The case is folded for ASCII letters only, so that the parsing does not depend
+// on the locale, e.g. the Turkish dotted "İNFO" is not "info".
+// Returns false if the text is not a severity of the scheme.
+func ParseSeverityText(scheme SeverityScheme, text string) (SeverityNumber, bool) {
+	s, ok := severitySchemes[scheme]
+	if !ok {
+		return SeverityNumberUNDEFINED, false
+	}
+	sn, ok := s.parse[asciiToLower(strings.TrimSpace(text))]
+	return sn, ok
+}
+
+// SeverityText returns the severity text of the given scheme for the severity number, the level of the scheme
+// covering the severity number, e.g. "err" for the syslog scheme and SeverityNumberERROR.
+// Returns false for SeverityNumberUNDEFINED, unknown severity numbers or schemes.
+func SeverityText(scheme SeverityScheme, number SeverityNumber) (string, bool) {
+	i, ok := severityLevelIndex(scheme, number)
+	if !ok {
+		return "", false
+	}
+	return severitySchemes[scheme].levels[i].text, true
+}
+
+// syslogLevels are the syslog severity keywords indexed by their numerical code.
+var syslogLevels = [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// SeverityNumberFromSyslogLevel returns the severity number of the numerical code of a syslog severity,
+// from 0 (emergency) to 7 (debug). Returns false if the code is out of range.
+func SeverityNumberFromSyslogLevel(level int) (SeverityNumber, bool) {
+	if level < 0 || level >= len(syslogLevels) {
+		return SeverityNumberUNDEFINED, false
+	}
+	return ParseSeverityText(SeveritySchemeSyslog, syslogLevels[level])
+}
+
+// SyslogLevel returns the numerical code of the syslog severity of the severity number, from 0 (emergency)
+// to 7 (debug). Returns false for SeverityNumberUNDEFINED or unknown severity numbers.
+func SyslogLevel(number SeverityNumber) (int, bool) {
+	i, ok := severityLevelIndex(SeveritySchemeSyslog, number)
+	if !ok {
+		return 0, false
+	}
+	// The syslog codes decrease with the severity.
+	return len(syslogLevels) - 1 - i, true
+}
+
+// SeverityNumberFromWindowsEventLevel returns the severity number of a Windows event level, from 1 (critical)
+// to 5 (verbose). The level 0 (log always) is mapped to SeverityNumberINFO, the events logged regardless of
+// the level being informational. Returns false if the level is out of range.
+func SeverityNumberFromWindowsEventLevel(level int) (SeverityNumber, bool) {
+	levels := severitySchemes[SeveritySchemeWindowsEvent].levels
+	switch {
+	case level == 0:
+		return SeverityNumberINFO, true
+	case level < 0 || level > len(levels):
+		return SeverityNumberUNDEFINED, false
+	}
+	return ParseSeverityText(SeveritySchemeWindowsEvent, levels[len(levels)-level].text)
+}
+
+// WindowsEventLevel returns the Windows event level of the severity number, from 1 (critical) to 5 (verbose).
+// Returns false for SeverityNumberUNDEFINED or unknown severity numbers.
+func WindowsEventLevel(number SeverityNumber) (int, bool) {
+	i, ok := severityLevelIndex(SeveritySchemeWindowsEvent, number)
+	if !ok {
+		return 0, false
+	}
+	// The Windows event levels decrease with the severity.
+	return len(severitySchemes[SeveritySchemeWindowsEvent].levels) - i, true
+}
+
+// severityLevelIndex returns the index of the level of the scheme covering the severity number.
+func severityLevelIndex(scheme SeverityScheme, number SeverityNumber) (int, bool) {
+	s, ok := severitySchemes[scheme]
+	if !ok || number <= SeverityNumberUNDEFINED {
+		return 0, false
+	}
+	for i, l := range s.levels {
+		if number <= l.maxNumber {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// asciiToLower returns s with the ASCII upper case letters mapped to lower case, leaving the other runes unchanged.
+func asciiToLower(s string) string {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; 'A' <= c && c <= 'Z' {
+			b := []byte(s)
+			for j := i; j < len(b); j++ {
+				if c := b[j]; 'A' <= c && c <= 'Z' {
+					b[j] = c + 'a' - 'A'
+				}
+			}
+			return string(b)
+		}
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeverityText(t *testing.T) {
+	tests := []struct {
+		scheme SeverityScheme
+		text   string
+		want   SeverityNumber
+		ok     bool
+	}{
+		{scheme: SeveritySchemeSyslog, text: "debug", want: SeverityNumberDEBUG, ok: true},
+		{scheme: SeveritySchemeSyslog, text: "notice", want: SeverityNumberINFO2, ok: true},
+		{scheme: SeveritySchemeSyslog, text: "ERR", want: SeverityNumberERROR, ok: true},
+		{scheme: SeveritySchemeSyslog, text: " Crit\n", want: SeverityNumberERROR2, ok: true},
+		{scheme: SeveritySchemeSyslog, text: "alert", want: SeverityNumberERROR3, ok: true},
+		{scheme: SeveritySchemeSyslog, text: "emergency", want: SeverityNumberFATAL, ok: true},
+		{scheme: SeveritySchemeSyslog, text: "trace", want: SeverityNumberUNDEFINED},
+		{scheme: SeveritySchemeZap, text: "Info", want: SeverityNumberINFO, ok: true},
+		{scheme: SeveritySchemeZap, text: "dpanic", want: SeverityNumberERROR2, ok: true},
+		{scheme: SeveritySchemeZap, text: "panic", want: SeverityNumberERROR3, ok: true},
+		{scheme: SeveritySchemeZap, text: "FATAL", want: SeverityNumberFATAL, ok: true},
+		{scheme: SeveritySchemeZap, text: "warning", want: SeverityNumberUNDEFINED},
+		{scheme: SeveritySchemeLogrus, text: "trace", want: SeverityNumberTRACE, ok: true},
+		{scheme: SeveritySchemeLogrus, text: "warning", want: SeverityNumberWARN, ok: true},
+		{scheme: SeveritySchemeLogrus, text: "warn", want: SeverityNumberWARN, ok: true},
+		{scheme: SeveritySchemeLogrus, text: "panic", want: SeverityNumberFATAL2, ok: true},
+		{scheme: SeveritySchemeWindowsEvent, text: "Information", want: SeverityNumberINFO, ok: true},
+		{scheme: SeveritySchemeWindowsEvent, text: "CRITICAL", want: SeverityNumberFATAL, ok: true},
+		{scheme: SeveritySchemeWindowsEvent, text: "verbose", want: SeverityNumberDEBUG, ok: true},
+		// Only the ASCII letters are case folded.
+		{scheme: SeveritySchemeZap, text: "İnfo", want: SeverityNumberUNDEFINED},
+		{scheme: SeveritySchemeZap, text: "", want: SeverityNumberUNDEFINED},
+		{scheme: SeverityScheme(0), text: "info", want: SeverityNumberUNDEFINED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseSeverityText(tt.scheme, tt.text)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSeverityText(t *testing.T) {
+	tests := []struct {
+		scheme SeverityScheme
+		number SeverityNumber
+		want   string
+		ok     bool
+	}{
+		{scheme: SeveritySchemeSyslog, number: SeverityNumberTRACE, want: "debug", ok: true},
+		{scheme: SeveritySchemeSyslog, number: SeverityNumberINFO, want: "info", ok: true},
+		{scheme: SeveritySchemeSyslog, number: SeverityNumberINFO3, want: "notice", ok: true},
+		{scheme: SeveritySchemeSyslog, number: SeverityNumberERROR, want: "err", ok: true},
+		{scheme: SeveritySchemeSyslog, number: SeverityNumberERROR4, want: "alert", ok: true},
+		{scheme: SeveritySchemeSyslog, number: SeverityNumberFATAL2, want: "emerg", ok: true},
+		{scheme: SeveritySchemeZap, number: SeverityNumberTRACE2, want: "debug", ok: true},
+		{scheme: SeveritySchemeZap, number: SeverityNumberERROR2, want: "dpanic", ok: true},
+		{scheme: SeveritySchemeZap, number: SeverityNumberFATAL4, want: "fatal", ok: true},
+		{scheme: SeveritySchemeLogrus, number: SeverityNumberTRACE3, want: "trace", ok: true},
+		{scheme: SeveritySchemeLogrus, number: SeverityNumberWARN2, want: "warning", ok: true},
+		{scheme: SeveritySchemeLogrus, number: SeverityNumberFATAL, want: "fatal", ok: true},
+		{scheme: SeveritySchemeLogrus, number: SeverityNumberFATAL3, want: "panic", ok: true},
+		{scheme: SeveritySchemeWindowsEvent, number: SeverityNumberDEBUG, want: "Verbose", ok: true},
+		{scheme: SeveritySchemeWindowsEvent, number: SeverityNumberERROR3, want: "Error", ok: true},
+		{scheme: SeveritySchemeZap, number: SeverityNumberUNDEFINED},
+		{scheme: SeveritySchemeZap, number: SeverityNumber(100)},
+		{scheme: SeverityScheme(0), number: SeverityNumberINFO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number.String(), func(t *testing.T) {
+			got, ok := SeverityText(tt.scheme, tt.number)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSeverityTextRoundTrip(t *testing.T) {
+	for scheme, s := range severitySchemes {
+		for _, l := range s.levels {
+			number, ok := ParseSeverityText(scheme, l.text)
+			assert.True(t, ok, l.text)
+			text, ok := SeverityText(scheme, number)
+			assert.True(t, ok, l.text)
+			assert.Equal(t, l.text, text)
+		}
+	}
+}
+
+func TestSyslogLevel(t *testing.T) {
+	want := []SeverityNumber{
+		SeverityNumberFATAL,
+		SeverityNumberERROR3,
+		SeverityNumberERROR2,
+		SeverityNumberERROR,
+		SeverityNumberWARN,
+		SeverityNumberINFO2,
+		SeverityNumberINFO,
+		SeverityNumberDEBUG,
+	}
+	for level, number := range want {
+		got, ok := SeverityNumberFromSyslogLevel(level)
+		assert.True(t, ok)
+		assert.Equal(t, number, got)
+		gotLevel, ok := SyslogLevel(number)
+		assert.True(t, ok)
+		assert.Equal(t, level, gotLevel)
+	}
+
+	_, ok := SeverityNumberFromSyslogLevel(-1)
+	assert.False(t, ok)
+	_, ok = SeverityNumberFromSyslogLevel(8)
+	assert.False(t, ok)
+	level, ok := SyslogLevel(SeverityNumberTRACE)
+	assert.True(t, ok)
+	assert.Equal(t, 7, level)
+	_, ok = SyslogLevel(SeverityNumberUNDEFINED)
+	assert.False(t, ok)
+}
+
+func TestWindowsEventLevel(t *testing.T) {
+	want := map[int]SeverityNumber{
+		1: SeverityNumberFATAL,
+		2: SeverityNumberERROR,
+		3: SeverityNumberWARN,
+		4: SeverityNumberINFO,
+		5: SeverityNumberDEBUG,
+	}
+	for level, number := range want {
+		got, ok := SeverityNumberFromWindowsEventLevel(level)
+		assert.True(t, ok)
+		assert.Equal(t, number, got)
+		gotLevel, ok := WindowsEventLevel(number)
+		assert.True(t, ok)
+		assert.Equal(t, level, gotLevel)
+	}
+
+	number, ok := SeverityNumberFromWindowsEventLevel(0)
+	assert.True(t, ok)
+	assert.Equal(t, SeverityNumberINFO, number)
+	_, ok = SeverityNumberFromWindowsEventLevel(6)
+	assert.False(t, ok)
+	_, ok = SeverityNumberFromWindowsEventLevel(-1)
+	assert.False(t, ok)
+	_, ok = WindowsEventLevel(SeverityNumberUNDEFINED)
+	assert.False(t, ok)
+}
@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"math"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// DropAttributes removes the attributes with the given keys from the data points of the metric, e.g. to
+// reduce its cardinality, and merges the points left with the same attributes into the first of them:
+//
+//   - the values of the sums are added,
+//   - the counts, sums and buckets of the histograms, exponential histograms and summaries are added, and
+//     their minimums and maximums combined,
+//   - the gauges keep the value of the latest point.
+//
+// The merged points span from the earliest start timestamp to the latest timestamp of the points, and
+// keep the exemplars of all of them. The histogram points with different bounds, and the exponential
+// histogram points with different scales or zero thresholds, are not merged. The quantiles of the merged
+// summary points are removed since they cannot be aggregated.
+// Returns false, leaving the metric unchanged, if none of the data points has one of the attributes.
+func DropAttributes(metric Metric, keys ...string) bool {
+	switch metric.DataType() {
+	case MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		if !removeAttributes(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() }, keys) {
+			return false
+		}
+		merged := make(map[string]NumberDataPoint)
+		dps.RemoveIf(func(dp NumberDataPoint) bool {
+			key := attributesKey(dp.Attributes())
+			first, ok := merged[key]
+			if !ok {
+				merged[key] = dp
+				return false
+			}
+			mergeGaugeDataPoint(first, dp)
+			return true
+		})
+	case MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		if !removeAttributes(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() }, keys) {
+			return false
+		}
+		merged := make(map[string]NumberDataPoint)
+		dps.RemoveIf(func(dp NumberDataPoint) bool {
+			key := attributesKey(dp.Attributes())
+			first, ok := merged[key]
+			if !ok {
+				merged[key] = dp
+				return false
+			}
+			mergeSumDataPoint(first, dp)
+			return true
+		})
+	case MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		if !removeAttributes(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() }, keys) {
+			return false
+		}
+		merged := make(map[string]HistogramDataPoint)
+		dps.RemoveIf(func(dp HistogramDataPoint) bool {
+			key := attributesKey(dp.Attributes()) + "\x00" + optionalsKey(dp.HasSum(), dp.HasMin(), dp.HasMax())
+			for i := 0; i < dp.ExplicitBounds().Len(); i++ {
+				key += "\x00" + strconv.FormatFloat(dp.ExplicitBounds().At(i), 'g', -1, 64)
+			}
+			first, ok := merged[key]
+			if !ok {
+				merged[key] = dp
+				return false
+			}
+			mergeHistogramDataPoint(first, dp)
+			return true
+		})
+	case MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		if !removeAttributes(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() }, keys) {
+			return false
+		}
+		merged := make(map[string]ExponentialHistogramDataPoint)
+		dps.RemoveIf(func(dp ExponentialHistogramDataPoint) bool {
+			key := attributesKey(dp.Attributes()) + "\x00" + optionalsKey(dp.HasSum(), dp.HasMin(), dp.HasMax()) +
+				"\x00" + strconv.Itoa(int(dp.Scale())) + "\x00" + strconv.FormatFloat(dp.ZeroThreshold(), 'g', -1, 64)
+			first, ok := merged[key]
+			if !ok {
+				merged[key] = dp
+				return false
+			}
+			mergeExponentialHistogramDataPoint(first, dp)
+			return true
+		})
+	case MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		if !removeAttributes(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() }, keys) {
+			return false
+		}
+		merged := make(map[string]SummaryDataPoint)
+		dps.RemoveIf(func(dp SummaryDataPoint) bool {
+			key := attributesKey(dp.Attributes())
+			first, ok := merged[key]
+			if !ok {
+				merged[key] = dp
+				return false
+			}
+			mergeSummaryDataPoint(first, dp)
+			return true
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// removeAttributes removes the attributes with the keys from the n attribute maps, returning whether
+// any attribute was removed.
+func removeAttributes(n int, attributes func(i int) pcommon.Map, keys []string) bool {
+	removed := false
+	for i := 0; i < n; i++ {
+		attrs := attributes(i)
+		for _, key := range keys {
+			if attrs.Remove(key) {
+				removed = true
+			}
+		}
+	}
+	return removed
+}
+
+// optionalsKey identifies the optional fields set on a histogram point, the points with different
+// optional fields not being merged since these fields cannot be unset.
+func optionalsKey(hasSum, hasMin, hasMax bool) string {
+	return strconv.FormatBool(hasSum) + strconv.FormatBool(hasMin) + strconv.FormatBool(hasMax)
+}
+
+// mergedTimestamps returns the earliest start timestamp and the latest timestamp of two points.
+func mergedTimestamps(dstStart, dstTime, srcStart, srcTime pcommon.Timestamp) (pcommon.Timestamp, pcommon.Timestamp) {
+	if srcStart != 0 && (dstStart == 0 || srcStart < dstStart) {
+		dstStart = srcStart
+	}
+	if srcTime > dstTime {
+		dstTime = srcTime
+	}
+	return dstStart, dstTime
+}
+
+func mergeGaugeDataPoint(dst, src NumberDataPoint) {
+	if src.Timestamp() < dst.Timestamp() {
+		return
+	}
+	src.CopyTo(dst)
+}
+
+func mergeSumDataPoint(dst, src NumberDataPoint) {
+	switch {
+	case dst.ValueType() == NumberDataPointValueTypeInt && src.ValueType() == NumberDataPointValueTypeInt:
+		dst.SetIntVal(dst.IntVal() + src.IntVal())
+	default:
+		dst.SetDoubleVal(numberValue(dst) + numberValue(src))
+	}
+	start, ts := mergedTimestamps(dst.StartTimestamp(), dst.Timestamp(), src.StartTimestamp(), src.Timestamp())
+	dst.SetStartTimestamp(start)
+	dst.SetTimestamp(ts)
+	src.Exemplars().MoveAndAppendTo(dst.Exemplars())
+}
+
+func numberValue(dp NumberDataPoint) float64 {
+	if dp.ValueType() == NumberDataPointValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
+
+func mergeHistogramDataPoint(dst, src HistogramDataPoint) {
+	dst.SetCount(dst.Count() + src.Count())
+	if dst.HasSum() {
+		dst.SetSum(dst.Sum() + src.Sum())
+	}
+	if dst.HasMin() {
+		dst.SetMin(math.Min(dst.Min(), src.Min()))
+	}
+	if dst.HasMax() {
+		dst.SetMax(math.Max(dst.Max(), src.Max()))
+	}
+	bucketCounts := dst.BucketCounts().AsRaw()
+	for i := 0; i < len(bucketCounts) && i < src.BucketCounts().Len(); i++ {
+		bucketCounts[i] += src.BucketCounts().At(i)
+	}
+	dst.SetBucketCounts(pcommon.NewImmutableUInt64Slice(bucketCounts))
+	start, ts := mergedTimestamps(dst.StartTimestamp(), dst.Timestamp(), src.StartTimestamp(), src.Timestamp())
+	dst.SetStartTimestamp(start)
+	dst.SetTimestamp(ts)
+	src.Exemplars().MoveAndAppendTo(dst.Exemplars())
+}
+
+func mergeExponentialHistogramDataPoint(dst, src ExponentialHistogramDataPoint) {
+	dst.SetCount(dst.Count() + src.Count())
+	if dst.HasSum() {
+		dst.SetSum(dst.Sum() + src.Sum())
+	}
+	if dst.HasMin() {
+		dst.SetMin(math.Min(dst.Min(), src.Min()))
+	}
+	if dst.HasMax() {
+		dst.SetMax(math.Max(dst.Max(), src.Max()))
+	}
+	dst.SetZeroCount(dst.ZeroCount() + src.ZeroCount())
+	mergeBuckets(dst.Positive(), src.Positive())
+	mergeBuckets(dst.Negative(), src.Negative())
+	start, ts := mergedTimestamps(dst.StartTimestamp(), dst.Timestamp(), src.StartTimestamp(), src.Timestamp())
+	dst.SetStartTimestamp(start)
+	dst.SetTimestamp(ts)
+	src.Exemplars().MoveAndAppendTo(dst.Exemplars())
+}
+
+// mergeBuckets adds the counts of the src buckets to the dst buckets of the same scale, extending
+// the dst buckets to cover the range of both.
+func mergeBuckets(dst, src Buckets) {
+	if src.BucketCounts().Len() == 0 {
+		return
+	}
+	if dst.BucketCounts().Len() == 0 {
+		src.CopyTo(dst)
+		return
+	}
+	lo, hi := dst.Offset(), dst.Offset()+int32(dst.BucketCounts().Len())
+	if src.Offset() < lo {
+		lo = src.Offset()
+	}
+	if srcHi := src.Offset() + int32(src.BucketCounts().Len()); srcHi > hi {
+		hi = srcHi
+	}
+	counts := make([]uint64, hi-lo)
+	for _, b := range []Buckets{dst, src} {
+		for i := 0; i < b.BucketCounts().Len(); i++ {
+			counts[int(b.Offset()-lo)+i] += b.BucketCounts().At(i)
+		}
+	}
+	dst.SetOffset(lo)
+	dst.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+}
+
+func mergeSummaryDataPoint(dst, src SummaryDataPoint) {
+	dst.SetCount(dst.Count() + src.Count())
+	dst.SetSum(dst.Sum() + src.Sum())
+	dst.QuantileValues().RemoveIf(func(ValueAtQuantile) bool { return true })
+	start, ts := mergedTimestamps(dst.StartTimestamp(), dst.Timestamp(), src.StartTimestamp(), src.Timestamp())
+	dst.SetStartTimestamp(start)
+	dst.SetTimestamp(ts)
+}
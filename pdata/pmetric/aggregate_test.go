@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestDropAttributes_Sum(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	for i, host := range []string{"a", "b", "c"} {
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(10 - i))
+		dp.SetTimestamp(pcommon.Timestamp(20 + i))
+		dp.SetIntVal(int64(i + 1))
+		dp.Attributes().InsertString("host", host)
+		dp.Attributes().InsertString("method", "GET")
+		dp.Exemplars().AppendEmpty().SetIntVal(int64(i))
+	}
+	other := m.Sum().DataPoints().AppendEmpty()
+	other.SetDoubleVal(0.5)
+	other.Attributes().InsertString("host", "a")
+	other.Attributes().InsertString("method", "POST")
+
+	require.True(t, DropAttributes(m, "host"))
+	dps := m.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+
+	dp := dps.At(0)
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, dp.Attributes().AsRaw())
+	assert.Equal(t, int64(6), dp.IntVal())
+	assert.Equal(t, pcommon.Timestamp(8), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(22), dp.Timestamp())
+	assert.Equal(t, 3, dp.Exemplars().Len())
+
+	assert.Equal(t, map[string]interface{}{"method": "POST"}, dps.At(1).Attributes().AsRaw())
+	assert.Equal(t, 0.5, dps.At(1).DoubleVal())
+
+	assert.False(t, DropAttributes(m, "host"))
+}
+
+func TestDropAttributes_SumMixedValueTypes(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeSum)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetIntVal(2)
+	dp.Attributes().InsertString("host", "a")
+	dp = m.Sum().DataPoints().AppendEmpty()
+	dp.SetDoubleVal(0.5)
+	dp.Attributes().InsertString("host", "b")
+
+	require.True(t, DropAttributes(m, "host"))
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	assert.Equal(t, NumberDataPointValueTypeDouble, m.Sum().DataPoints().At(0).ValueType())
+	assert.Equal(t, 2.5, m.Sum().DataPoints().At(0).DoubleVal())
+}
+
+func TestDropAttributes_Gauge(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeGauge)
+	for i, ts := range []pcommon.Timestamp{20, 30, 10} {
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetDoubleVal(float64(i))
+		dp.Attributes().InsertInt("pid", int64(i))
+	}
+
+	require.True(t, DropAttributes(m, "pid"))
+	require.Equal(t, 1, m.Gauge().DataPoints().Len())
+	dp := m.Gauge().DataPoints().At(0)
+	assert.Equal(t, 1.0, dp.DoubleVal())
+	assert.Equal(t, pcommon.Timestamp(30), dp.Timestamp())
+	assert.Equal(t, 0, dp.Attributes().Len())
+}
+
+func TestDropAttributes_Histogram(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeHistogram)
+	for i, bounds := range [][]float64{{1, 10}, {1, 10}, {1, 5}} {
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		dp.SetCount(uint64(3 * (i + 1)))
+		dp.SetSum(float64(10 * (i + 1)))
+		dp.SetMin(float64(i))
+		dp.SetMax(float64(5 * (i + 1)))
+		dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+		dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{uint64(i + 1), uint64(i + 1), uint64(i + 1)}))
+		dp.Attributes().InsertInt("pid", int64(i))
+	}
+
+	require.True(t, DropAttributes(m, "pid"))
+	dps := m.Histogram().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.Equal(t, 30.0, dp.Sum())
+	assert.Equal(t, 0.0, dp.Min())
+	assert.Equal(t, 10.0, dp.Max())
+	assert.Equal(t, []uint64{3, 3, 3}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, []float64{1, 5}, dps.At(1).ExplicitBounds().AsRaw())
+}
+
+func TestDropAttributes_ExponentialHistogram(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeExponentialHistogram)
+	dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(4)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(2)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2}))
+	dp.Attributes().InsertString("host", "a")
+	dp = m.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(5)
+	dp.SetZeroCount(2)
+	dp.Positive().SetOffset(0)
+	dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 0, 1}))
+	dp.Negative().SetOffset(-1)
+	dp.Negative().SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1}))
+	dp.Attributes().InsertString("host", "b")
+
+	require.True(t, DropAttributes(m, "host"))
+	require.Equal(t, 1, m.ExponentialHistogram().DataPoints().Len())
+	dp = m.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(9), dp.Count())
+	assert.Equal(t, uint64(3), dp.ZeroCount())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 0, 2, 2}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+}
+
+func TestDropAttributes_Summary(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeSummary)
+	for i := 0; i < 2; i++ {
+		dp := m.Summary().DataPoints().AppendEmpty()
+		dp.SetCount(10)
+		dp.SetSum(100)
+		dp.QuantileValues().AppendEmpty().SetQuantile(0.5)
+		dp.Attributes().InsertInt("pid", int64(i))
+	}
+
+	require.True(t, DropAttributes(m, "pid"))
+	require.Equal(t, 1, m.Summary().DataPoints().Len())
+	dp := m.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(20), dp.Count())
+	assert.Equal(t, 200.0, dp.Sum())
+	assert.Equal(t, 0, dp.QuantileValues().Len())
+}
@@ -23,6 +23,13 @@ type Metrics = internal.Metrics
 // NewMetrics creates a new Metrics struct.
 var NewMetrics = internal.NewMetrics
 
+// MetricsPool is an opt-in pool of Metrics, reusing the top-level messages and the backing arrays
+// of their resource slices. It is safe for concurrent use.
+type MetricsPool = internal.MetricsPool
+
+// NewMetricsPool returns a new empty MetricsPool.
+var NewMetricsPool = internal.NewMetricsPool
+
 // MetricDataType specifies the type of data in a Metric.
 type MetricDataType = internal.MetricDataType
 
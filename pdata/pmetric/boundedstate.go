@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// StateSettings bound the memory usage of a BoundedState.
+type StateSettings struct {
+	// TTL is the duration after which the streams which were not updated are evicted.
+	// Zero disables the expiry.
+	TTL time.Duration
+
+	// MaxStreams is the maximum number of streams stored, the least recently updated streams
+	// being evicted to store new ones. Zero disables the limit.
+	MaxStreams int
+}
+
+// BoundedState is an in-memory DeltaState and CumulativeState safe for concurrent use, evicting
+// the streams which were not updated for the TTL, and the least recently updated streams when
+// the number of streams exceeds MaxStreams.
+type BoundedState struct {
+	settings StateSettings
+	now      func() time.Time
+
+	mu sync.Mutex
+	// lru orders the streams from the most to the least recently updated.
+	lru     *list.List
+	streams map[string]*list.Element
+}
+
+var _ DeltaState = (*BoundedState)(nil)
+var _ CumulativeState = (*BoundedState)(nil)
+
+type boundedStream struct {
+	key     string
+	value   CumulativeValue
+	updated time.Time
+}
+
+// NewBoundedState returns a BoundedState with the settings.
+func NewBoundedState(settings StateSettings) *BoundedState {
+	return &BoundedState{
+		settings: settings,
+		now:      time.Now,
+		lru:      list.New(),
+		streams:  map[string]*list.Element{},
+	}
+}
+
+// Load returns the last value stored for the stream identified by the key, if not evicted.
+func (s *BoundedState) Load(key string) (CumulativeValue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpired()
+	elem, ok := s.streams[key]
+	if !ok {
+		return CumulativeValue{}, false
+	}
+	return elem.Value.(*boundedStream).value, true
+}
+
+// Store stores the last value of the stream identified by the key, evicting the least recently
+// updated streams if the number of streams exceeds MaxStreams.
+func (s *BoundedState) Store(key string, value CumulativeValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if elem, ok := s.streams[key]; ok {
+		stream := elem.Value.(*boundedStream)
+		stream.value = value
+		stream.updated = now
+		s.lru.MoveToFront(elem)
+	} else {
+		s.streams[key] = s.lru.PushFront(&boundedStream{key: key, value: value, updated: now})
+	}
+	s.evictExpired()
+	for s.settings.MaxStreams > 0 && s.lru.Len() > s.settings.MaxStreams {
+		s.remove(s.lru.Back())
+	}
+}
+
+// Len returns the number of streams stored.
+func (s *BoundedState) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictExpired()
+	return s.lru.Len()
+}
+
+// evictExpired removes the streams which were not updated for the TTL, the least recently updated first.
+func (s *BoundedState) evictExpired() {
+	if s.settings.TTL <= 0 {
+		return
+	}
+	expiry := s.now().Add(-s.settings.TTL)
+	for elem := s.lru.Back(); elem != nil && !elem.Value.(*boundedStream).updated.After(expiry); elem = s.lru.Back() {
+		s.remove(elem)
+	}
+}
+
+func (s *BoundedState) remove(elem *list.Element) {
+	s.lru.Remove(elem)
+	delete(s.streams, elem.Value.(*boundedStream).key)
+}
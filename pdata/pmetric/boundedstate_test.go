@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedStateTTL(t *testing.T) {
+	now := time.Unix(0, 0)
+	state := NewBoundedState(StateSettings{TTL: time.Minute})
+	state.now = func() time.Time { return now }
+
+	state.Store("a", CumulativeValue{IntVal: 1})
+	now = now.Add(30 * time.Second)
+	state.Store("b", CumulativeValue{IntVal: 2})
+
+	value, ok := state.Load("a")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), value.IntVal)
+
+	// Loading a stream does not refresh it.
+	now = now.Add(31 * time.Second)
+	_, ok = state.Load("a")
+	assert.False(t, ok)
+	_, ok = state.Load("b")
+	assert.True(t, ok)
+	assert.Equal(t, 1, state.Len())
+
+	// Storing a stream refreshes it.
+	state.Store("b", CumulativeValue{IntVal: 3})
+	now = now.Add(59 * time.Second)
+	value, ok = state.Load("b")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), value.IntVal)
+}
+
+func TestBoundedStateMaxStreams(t *testing.T) {
+	state := NewBoundedState(StateSettings{MaxStreams: 2})
+
+	state.Store("a", CumulativeValue{IntVal: 1})
+	state.Store("b", CumulativeValue{IntVal: 2})
+	state.Store("a", CumulativeValue{IntVal: 3})
+	state.Store("c", CumulativeValue{IntVal: 4})
+
+	// The least recently updated stream is evicted.
+	assert.Equal(t, 2, state.Len())
+	_, ok := state.Load("b")
+	assert.False(t, ok)
+	value, ok := state.Load("a")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), value.IntVal)
+	_, ok = state.Load("c")
+	assert.True(t, ok)
+}
+
+func TestBoundedStateUnbounded(t *testing.T) {
+	state := NewBoundedState(StateSettings{})
+	for i := 0; i < 100; i++ {
+		state.Store(strconv.Itoa(i), CumulativeValue{})
+	}
+	assert.Equal(t, 100, state.Len())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+// ConvertGaugeToSum converts the gauge metric to a cumulative sum with the given monotonicity,
+// keeping its data points. Gauge values are the current values of the measured streams, which
+// are valid cumulative sums but not deltas. Returns false, leaving the metric unchanged, if the
+// metric is not a gauge.
+func ConvertGaugeToSum(metric Metric, isMonotonic bool) bool {
+	if metric.DataType() != MetricDataTypeGauge {
+		return false
+	}
+	dps := NewNumberDataPointSlice()
+	metric.Gauge().DataPoints().MoveAndAppendTo(dps)
+	metric.SetDataType(MetricDataTypeSum)
+	sum := metric.Sum()
+	sum.SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	sum.SetIsMonotonic(isMonotonic)
+	dps.MoveAndAppendTo(sum.DataPoints())
+	return true
+}
+
+// ConvertSumToGauge converts the cumulative sum metric to a gauge, keeping its data points.
+// Returns false, leaving the metric unchanged, if the metric is not a cumulative sum since
+// the values of a delta sum are changes over the reporting intervals and not current values.
+func ConvertSumToGauge(metric Metric) bool {
+	if metric.DataType() != MetricDataTypeSum || metric.Sum().AggregationTemporality() != MetricAggregationTemporalityCumulative {
+		return false
+	}
+	dps := NewNumberDataPointSlice()
+	metric.Sum().DataPoints().MoveAndAppendTo(dps)
+	metric.SetDataType(MetricDataTypeGauge)
+	dps.MoveAndAppendTo(metric.Gauge().DataPoints())
+	return true
+}
+
+// ConvertSummaryToHistogram converts the summary metric to a cumulative histogram, for the backends
+// not supporting summaries. The histograms are approximate: the reported quantiles become the bucket
+// bounds, and the number of observations in each bucket is estimated from the count and the quantiles.
+// Returns false, leaving the metric unchanged, if the metric is not a summary.
+func ConvertSummaryToHistogram(metric Metric) bool {
+	if metric.DataType() != MetricDataTypeSummary {
+		return false
+	}
+	sdps := NewSummaryDataPointSlice()
+	metric.Summary().DataPoints().MoveAndAppendTo(sdps)
+	metric.SetDataType(MetricDataTypeHistogram)
+	histogram := metric.Histogram()
+	// The count and sum of the summaries are cumulative.
+	histogram.SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	hdps := histogram.DataPoints()
+	hdps.EnsureCapacity(sdps.Len())
+	for i := 0; i < sdps.Len(); i++ {
+		summaryToHistogramDataPoint(sdps.At(i), hdps.AppendEmpty())
+	}
+	return true
+}
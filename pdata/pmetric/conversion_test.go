@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertGaugeToSum(t *testing.T) {
+	m := NewMetric()
+	m.SetName("queue_size")
+	m.SetDataType(MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(10)
+	dp.Attributes().InsertString("queue", "a")
+
+	require.True(t, ConvertGaugeToSum(m, false))
+	assert.Equal(t, MetricDataTypeSum, m.DataType())
+	assert.Equal(t, "queue_size", m.Name())
+	assert.Equal(t, MetricAggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	assert.False(t, m.Sum().IsMonotonic())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	assert.Equal(t, int64(10), m.Sum().DataPoints().At(0).IntVal())
+	queue, ok := m.Sum().DataPoints().At(0).Attributes().Get("queue")
+	require.True(t, ok)
+	assert.Equal(t, "a", queue.StringVal())
+
+	assert.False(t, ConvertGaugeToSum(m, false))
+}
+
+func TestConvertSumToGauge(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+	m.Sum().DataPoints().AppendEmpty().SetDoubleVal(1.5)
+
+	assert.False(t, ConvertSumToGauge(m))
+	assert.Equal(t, MetricDataTypeSum, m.DataType())
+
+	m.Sum().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	require.True(t, ConvertSumToGauge(m))
+	assert.Equal(t, MetricDataTypeGauge, m.DataType())
+	require.Equal(t, 1, m.Gauge().DataPoints().Len())
+	assert.Equal(t, 1.5, m.Gauge().DataPoints().At(0).DoubleVal())
+
+	assert.False(t, ConvertSumToGauge(m))
+}
+
+func TestConvertSummaryToHistogram(t *testing.T) {
+	m := NewMetric()
+	m.SetName("latency")
+	m.SetDataType(MetricDataTypeSummary)
+	dp := m.Summary().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(1)
+	dp.SetTimestamp(2)
+	dp.SetCount(100)
+	dp.SetSum(2500)
+	dp.Attributes().InsertString("route", "/a")
+	for _, qv := range [][2]float64{{1, 200}, {0, 1}, {0.5, 20}, {0.9, 80}, {0.95, 80}, {0.99, 150}} {
+		v := dp.QuantileValues().AppendEmpty()
+		v.SetQuantile(qv[0])
+		v.SetValue(qv[1])
+	}
+	m.Summary().DataPoints().AppendEmpty()
+
+	require.True(t, ConvertSummaryToHistogram(m))
+	assert.Equal(t, MetricDataTypeHistogram, m.DataType())
+	assert.Equal(t, "latency", m.Name())
+	assert.Equal(t, MetricAggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+	require.Equal(t, 2, m.Histogram().DataPoints().Len())
+
+	hdp := m.Histogram().DataPoints().At(0)
+	assert.EqualValues(t, 1, hdp.StartTimestamp())
+	assert.EqualValues(t, 2, hdp.Timestamp())
+	assert.EqualValues(t, 100, hdp.Count())
+	assert.Equal(t, 2500.0, hdp.Sum())
+	assert.Equal(t, 1.0, hdp.Min())
+	assert.Equal(t, 200.0, hdp.Max())
+	route, ok := hdp.Attributes().Get("route")
+	require.True(t, ok)
+	assert.Equal(t, "/a", route.StringVal())
+	// The 0.9 and 0.95 quantiles having the same value share a bound.
+	assert.Equal(t, []float64{1, 20, 80, 150}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{0, 50, 45, 4, 1}, hdp.BucketCounts().AsRaw())
+
+	// Without quantiles all the observations are in a single bucket.
+	hdp = m.Histogram().DataPoints().At(1)
+	assert.Empty(t, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{0}, hdp.BucketCounts().AsRaw())
+	assert.False(t, hdp.HasMin())
+
+	assert.False(t, ConvertSummaryToHistogram(m))
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// CumulativeState stores the cumulative values accumulated from the deltas of the streams converted to
+// cumulative temporality. Implementations may evict the streams which are not updated anymore to bound
+// their memory usage, the next point of an evicted stream being handled as the first point of a stream.
+type CumulativeState interface {
+	// Load returns the cumulative value stored for the stream identified by the key.
+	Load(key string) (CumulativeValue, bool)
+	// Store stores the cumulative value of the stream identified by the key.
+	Store(key string, value CumulativeValue)
+}
+
+// ConvertDeltaToCumulative converts the delta sums and histograms of the metrics to cumulative temporality,
+// e.g. for the backends only supporting cumulative metrics, the values accumulated since the first point of
+// the streams being tracked by the state, see NewBoundedState. The first point of a stream, and the first
+// histogram point whose buckets changed, starts a new cumulative stream at its start timestamp.
+// Points which are not newer than the last point of their stream are removed.
+// The metrics, scopes and resources left without data points are removed.
+func ConvertDeltaToCumulative(md Metrics, state CumulativeState) {
+	removeMetricsIf(md, func(m Metric, metricKey string) bool {
+		switch m.DataType() {
+		case MetricDataTypeSum:
+			if m.Sum().AggregationTemporality() != MetricAggregationTemporalityDelta {
+				return false
+			}
+			sumToCumulative(m.Sum(), metricKey, state)
+			return m.Sum().DataPoints().Len() == 0
+		case MetricDataTypeHistogram:
+			if m.Histogram().AggregationTemporality() != MetricAggregationTemporalityDelta {
+				return false
+			}
+			histogramToCumulative(m.Histogram(), metricKey, state)
+			return m.Histogram().DataPoints().Len() == 0
+		}
+		return false
+	})
+}
+
+func sumToCumulative(sum Sum, metricKey string, state CumulativeState) {
+	sum.SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	sum.DataPoints().RemoveIf(func(dp NumberDataPoint) bool {
+		key := metricKey + "\x00" + attributesKey(dp.Attributes())
+		prev, ok := state.Load(key)
+		if ok && dp.Timestamp() <= prev.Timestamp {
+			return true
+		}
+		if ok && prev.ValueType == dp.ValueType() {
+			dp.SetStartTimestamp(prev.StartTimestamp)
+			switch dp.ValueType() {
+			case NumberDataPointValueTypeInt:
+				dp.SetIntVal(prev.IntVal + dp.IntVal())
+			case NumberDataPointValueTypeDouble:
+				dp.SetDoubleVal(prev.DoubleVal + dp.DoubleVal())
+			}
+		}
+		state.Store(key, CumulativeValue{
+			StartTimestamp: dp.StartTimestamp(),
+			Timestamp:      dp.Timestamp(),
+			ValueType:      dp.ValueType(),
+			IntVal:         dp.IntVal(),
+			DoubleVal:      dp.DoubleVal(),
+		})
+		return false
+	})
+}
+
+func histogramToCumulative(histogram Histogram, metricKey string, state CumulativeState) {
+	histogram.SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	histogram.DataPoints().RemoveIf(func(dp HistogramDataPoint) bool {
+		key := metricKey + "\x00" + attributesKey(dp.Attributes())
+		prev, ok := state.Load(key)
+		if ok && dp.Timestamp() <= prev.Timestamp {
+			return true
+		}
+		if ok && equalBuckets(prev, dp) {
+			dp.SetStartTimestamp(prev.StartTimestamp)
+			dp.SetCount(prev.Count + dp.Count())
+			if dp.HasSum() {
+				dp.SetSum(prev.Sum + dp.Sum())
+			}
+			if prev.HasMin && dp.HasMin() && prev.Min < dp.Min() {
+				dp.SetMin(prev.Min)
+			}
+			if prev.HasMax && dp.HasMax() && prev.Max > dp.Max() {
+				dp.SetMax(prev.Max)
+			}
+			bucketCounts := dp.BucketCounts().AsRaw()
+			for i := range bucketCounts {
+				bucketCounts[i] += prev.BucketCounts[i]
+			}
+			dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(bucketCounts))
+		}
+		state.Store(key, CumulativeValue{
+			StartTimestamp: dp.StartTimestamp(),
+			Timestamp:      dp.Timestamp(),
+			Count:          dp.Count(),
+			Sum:            dp.Sum(),
+			BucketCounts:   dp.BucketCounts().AsRaw(),
+			ExplicitBounds: dp.ExplicitBounds().AsRaw(),
+			HasMin:         dp.HasMin(),
+			Min:            dp.Min(),
+			HasMax:         dp.HasMax(),
+			Max:            dp.Max(),
+		})
+		return false
+	})
+}
+
+// equalBuckets returns whether the histogram point has the same buckets as the cumulative value.
+func equalBuckets(value CumulativeValue, dp HistogramDataPoint) bool {
+	if len(value.BucketCounts) != dp.BucketCounts().Len() || len(value.ExplicitBounds) != dp.ExplicitBounds().Len() {
+		return false
+	}
+	for i := range value.ExplicitBounds {
+		if value.ExplicitBounds[i] != dp.ExplicitBounds().At(i) {
+			return false
+		}
+	}
+	return true
+}
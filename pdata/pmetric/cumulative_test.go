@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newDeltaSum(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) Metrics {
+	md := newCumulativeSum(true, start, ts, val)
+	sumMetric(md).Sum().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+	return md
+}
+
+func sumMetric(md Metrics) Metric {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+}
+
+func TestConvertDeltaToCumulativeSum(t *testing.T) {
+	state := NewBoundedState(StateSettings{})
+
+	md := newDeltaSum(0, 10, 5)
+	ConvertDeltaToCumulative(md, state)
+	assert.Equal(t, MetricAggregationTemporalityCumulative, sumMetric(md).Sum().AggregationTemporality())
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	assert.Equal(t, int64(5), sumDataPoints(md).At(0).IntVal())
+
+	md = newDeltaSum(10, 20, 3)
+	ConvertDeltaToCumulative(md, state)
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	dp := sumDataPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+	assert.Equal(t, int64(8), dp.IntVal())
+
+	// Points which are not newer than the last point are removed, along with the empty metric, scope and resource.
+	md = newDeltaSum(10, 20, 3)
+	ConvertDeltaToCumulative(md, state)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	// A change of the value type starts a new stream.
+	md = newDeltaSum(20, 30, 0)
+	sumDataPoints(md).At(0).SetDoubleVal(1.5)
+	ConvertDeltaToCumulative(md, state)
+	dp = sumDataPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, 1.5, dp.DoubleVal())
+
+	md = newDeltaSum(30, 40, 0)
+	sumDataPoints(md).At(0).SetDoubleVal(2)
+	ConvertDeltaToCumulative(md, state)
+	dp = sumDataPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, 3.5, dp.DoubleVal())
+}
+
+func TestConvertDeltaToCumulativeStreams(t *testing.T) {
+	state := NewBoundedState(StateSettings{})
+
+	ConvertDeltaToCumulative(newDeltaSum(0, 10, 5), state)
+	md := newDeltaSum(0, 10, 7)
+	sumDataPoints(md).At(0).Attributes().UpsertString("path", "/other")
+	ConvertDeltaToCumulative(md, state)
+	assert.Equal(t, int64(7), sumDataPoints(md).At(0).IntVal())
+	assert.Equal(t, 2, state.Len())
+
+	// The cumulative metrics are left unchanged.
+	md = newCumulativeSum(true, 0, 20, 1)
+	ConvertDeltaToCumulative(md, state)
+	assert.Equal(t, int64(1), sumDataPoints(md).At(0).IntVal())
+	assert.Equal(t, 2, state.Len())
+}
+
+func TestConvertDeltaToCumulativeHistogram(t *testing.T) {
+	state := NewBoundedState(StateSettings{})
+
+	newDeltaHistogram := func(ts pcommon.Timestamp, count uint64, sum float64, min float64, bucketCounts []uint64) Metrics {
+		md := newCumulativeHistogram(ts, count, sum, bucketCounts)
+		m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+		m.Histogram().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+		m.Histogram().DataPoints().At(0).SetStartTimestamp(ts - 10)
+		m.Histogram().DataPoints().At(0).SetMin(min)
+		return md
+	}
+
+	ConvertDeltaToCumulative(newDeltaHistogram(10, 2, 12, 2, []uint64{1, 1}), state)
+
+	md := newDeltaHistogram(20, 3, 18, 3, []uint64{1, 2})
+	ConvertDeltaToCumulative(md, state)
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, MetricAggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, float64(30), dp.Sum())
+	assert.Equal(t, float64(2), dp.Min())
+	assert.Equal(t, float64(10), dp.Max())
+	assert.Equal(t, []uint64{2, 3}, dp.BucketCounts().AsRaw())
+
+	// Changed buckets start a new stream.
+	md = newDeltaHistogram(30, 1, 1, 1, []uint64{1, 0, 0})
+	m = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	m.Histogram().DataPoints().At(0).SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{5, 10}))
+	ConvertDeltaToCumulative(md, state)
+	dp = m.Histogram().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, uint64(1), dp.Count())
+	assert.Equal(t, []uint64{1, 0, 0}, dp.BucketCounts().AsRaw())
+}
@@ -14,6 +14,12 @@
 
 package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+)
+
 // Marshaler marshals pmetric.Metrics into bytes.
 type Marshaler interface {
 	// MarshalMetrics the given pmetric.Metrics into bytes.
@@ -33,3 +39,37 @@ type Sizer interface {
 	// MetricsSize returns the size in bytes of a marshaled Metrics.
 	MetricsSize(md Metrics) int
 }
+
+// MarshalerOption configures a Marshaler returned by NewProtoMarshaler or NewJSONMarshaler.
+type MarshalerOption func(*marshalerSettings)
+
+type marshalerSettings struct {
+	deterministic bool
+}
+
+// WithDeterministicMarshaling makes the Marshaler produce identical bytes for identical payloads,
+// independently of the order in which the attributes were inserted. The attributes of a copy of
+// the payload are sorted by key before marshaling, the marshaled Metrics is not modified.
+func WithDeterministicMarshaling() MarshalerOption {
+	return func(s *marshalerSettings) {
+		s.deterministic = true
+	}
+}
+
+func newMarshalerSettings(opts []MarshalerOption) marshalerSettings {
+	var s marshalerSettings
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// toProto returns the proto representation of md, with sorted attributes if deterministic is set.
+func (s marshalerSettings) toProto(md Metrics) otlpmetrics.MetricsData {
+	if !s.deterministic {
+		return internal.MetricsToProto(md)
+	}
+	pb := internal.MetricsToProto(md.Clone())
+	otlp.SortMetricsAttributes(pb.ResourceMetrics)
+	return pb
+}
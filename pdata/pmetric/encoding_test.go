@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestDeterministicMarshaling(t *testing.T) {
+	newMetrics := func(keys ...string) Metrics {
+		md := NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+		gauge := ms.AppendEmpty()
+		gauge.SetDataType(MetricDataTypeGauge)
+		gdp := gauge.Gauge().DataPoints().AppendEmpty()
+		hist := ms.AppendEmpty()
+		hist.SetDataType(MetricDataTypeHistogram)
+		hdp := hist.Histogram().DataPoints().AppendEmpty()
+		ex := gdp.Exemplars().AppendEmpty()
+		for _, k := range keys {
+			rm.Resource().Attributes().InsertString(k, "value")
+			gdp.Attributes().InsertString(k, "value")
+			ex.FilteredAttributes().InsertString(k, "value")
+			hdp.Attributes().InsertString(k, "value")
+		}
+		return md
+	}
+
+	for _, marshaler := range []Marshaler{
+		NewProtoMarshaler(WithDeterministicMarshaling()),
+		NewJSONMarshaler(WithDeterministicMarshaling()),
+	} {
+		md1 := newMetrics("a", "b", "c")
+		md2 := newMetrics("c", "a", "b")
+		buf1, err := marshaler.MarshalMetrics(md1)
+		require.NoError(t, err)
+		buf2, err := marshaler.MarshalMetrics(md2)
+		require.NoError(t, err)
+		assert.Equal(t, buf1, buf2)
+
+		// The marshaled Metrics must not be modified.
+		var keys []string
+		md2.ResourceMetrics().At(0).Resource().Attributes().Range(func(k string, _ pcommon.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.Equal(t, []string{"c", "a", "b"}, keys)
+	}
+}
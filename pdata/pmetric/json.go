@@ -25,21 +25,23 @@ import (
 )
 
 // NewJSONMarshaler returns a model.Marshaler. Marshals to OTLP json bytes.
-func NewJSONMarshaler() Marshaler {
-	return newJSONMarshaler()
+func NewJSONMarshaler(opts ...MarshalerOption) Marshaler {
+	return newJSONMarshaler(opts...)
 }
 
 type jsonMarshaler struct {
 	delegate jsonpb.Marshaler
+	settings marshalerSettings
 }
 
-func newJSONMarshaler() *jsonMarshaler {
-	return &jsonMarshaler{delegate: jsonpb.Marshaler{}}
+func newJSONMarshaler(opts ...MarshalerOption) *jsonMarshaler {
+	return &jsonMarshaler{delegate: jsonpb.Marshaler{}, settings: newMarshalerSettings(opts)}
 }
 
 func (e *jsonMarshaler) MarshalMetrics(md Metrics) ([]byte, error) {
 	buf := bytes.Buffer{}
-	err := e.delegate.Marshal(&buf, internal.MetricsToOtlp(md))
+	pb := e.settings.toProto(md)
+	err := e.delegate.Marshal(&buf, &pb)
 	return buf.Bytes(), err
 }
 
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// TruncateAttributeValues truncates the attribute values of the data points of the metric longer than
+// maxLength, as pcommon.TruncateValue. Unlike spans and log records, the attributes of the data points
+// are not limited in number since they identify the streams, see DropAttributes to reduce them.
+// Returns the number of truncated values.
+func TruncateAttributeValues(metric Metric, maxLength int) int {
+	truncated := 0
+	truncate := func(n int, attributes func(i int) pcommon.Map) {
+		for i := 0; i < n; i++ {
+			attributes(i).Range(func(_ string, v pcommon.Value) bool {
+				truncated += pcommon.TruncateValue(v, maxLength)
+				return true
+			})
+		}
+	}
+	switch metric.DataType() {
+	case MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		truncate(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() })
+	case MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		truncate(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() })
+	case MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		truncate(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() })
+	case MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		truncate(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() })
+	case MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		truncate(dps.Len(), func(i int) pcommon.Map { return dps.At(i).Attributes() })
+	}
+	return truncated
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateAttributeValues(t *testing.T) {
+	m := NewMetric()
+	m.SetDataType(MetricDataTypeHistogram)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("a", "value")
+	dp.Attributes().InsertString("b", "ok")
+	dp.Attributes().InsertInt("c", 12345)
+	m.Histogram().DataPoints().AppendEmpty().Attributes().InsertString("a", "other")
+
+	assert.Equal(t, 2, TruncateAttributeValues(m, 3))
+	assert.Equal(t, map[string]interface{}{"a": "val", "b": "ok", "c": int64(12345)}, dp.Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"a": "oth"}, m.Histogram().DataPoints().At(1).Attributes().AsRaw())
+}
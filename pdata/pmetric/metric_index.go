@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+// metricKey identifies the metrics of a MetricSlice.
+type metricKey struct {
+	name     string
+	dataType MetricDataType
+}
+
+// MetricSliceIndex looks up the metrics of a MetricSlice by name and data type in constant time,
+// instead of scanning the slice, for the processors aggregating points into existing metrics.
+// The index is built lazily on the first lookup, and the metrics appended to the slice afterwards
+// are indexed on the next lookup. The index must be discarded when the metrics are removed,
+// reordered or renamed, e.g. with MetricSlice.RemoveIf or MetricSlice.Sort.
+// If several metrics have the same name and data type, the first one is returned.
+// A MetricSliceIndex is not safe for concurrent use.
+type MetricSliceIndex struct {
+	slice   MetricSlice
+	index   map[metricKey]int
+	indexed int
+}
+
+// NewMetricSliceIndex returns a MetricSliceIndex of the metrics of the slice.
+func NewMetricSliceIndex(ms MetricSlice) *MetricSliceIndex {
+	return &MetricSliceIndex{slice: ms}
+}
+
+// update indexes the metrics appended to the slice since the last lookup,
+// and rebuilds the index if metrics were removed.
+func (idx *MetricSliceIndex) update() {
+	if idx.index == nil || idx.slice.Len() < idx.indexed {
+		idx.index = make(map[metricKey]int, idx.slice.Len())
+		idx.indexed = 0
+	}
+	for ; idx.indexed < idx.slice.Len(); idx.indexed++ {
+		m := idx.slice.At(idx.indexed)
+		key := metricKey{name: m.Name(), dataType: m.DataType()}
+		if _, ok := idx.index[key]; !ok {
+			idx.index[key] = idx.indexed
+		}
+	}
+}
+
+// Get returns the metric with the given name and data type, and true if found.
+func (idx *MetricSliceIndex) Get(name string, dataType MetricDataType) (Metric, bool) {
+	idx.update()
+	i, ok := idx.index[metricKey{name: name, dataType: dataType}]
+	if !ok {
+		return Metric{}, false
+	}
+	return idx.slice.At(i), true
+}
+
+// Upsert returns the metric with the given name and data type, appending a new metric
+// with the name and the data type to the slice if not found.
+func (idx *MetricSliceIndex) Upsert(name string, dataType MetricDataType) Metric {
+	if m, ok := idx.Get(name, dataType); ok {
+		return m
+	}
+	m := idx.slice.AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(dataType)
+	idx.index[metricKey{name: name, dataType: dataType}] = idx.indexed
+	idx.indexed++
+	return m
+}
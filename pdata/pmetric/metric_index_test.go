@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricSliceIndex(t *testing.T) {
+	ms := NewMetricSlice()
+	m := ms.AppendEmpty()
+	m.SetName("requests")
+	m.SetDataType(MetricDataTypeSum)
+	m = ms.AppendEmpty()
+	m.SetName("requests")
+	m.SetDataType(MetricDataTypeGauge)
+	m = ms.AppendEmpty()
+	m.SetName("requests")
+	m.SetDataType(MetricDataTypeSum)
+	m.SetDescription("duplicate")
+
+	idx := NewMetricSliceIndex(ms)
+	got, ok := idx.Get("requests", MetricDataTypeSum)
+	require.True(t, ok)
+	// The first metric is returned for duplicates.
+	assert.Equal(t, ms.At(0), got)
+	got, ok = idx.Get("requests", MetricDataTypeGauge)
+	require.True(t, ok)
+	assert.Equal(t, ms.At(1), got)
+	_, ok = idx.Get("requests", MetricDataTypeHistogram)
+	assert.False(t, ok)
+	_, ok = idx.Get("unknown", MetricDataTypeSum)
+	assert.False(t, ok)
+
+	// The metrics appended to the slice after the index is built are found.
+	m = ms.AppendEmpty()
+	m.SetName("latency")
+	m.SetDataType(MetricDataTypeHistogram)
+	got, ok = idx.Get("latency", MetricDataTypeHistogram)
+	require.True(t, ok)
+	assert.Equal(t, ms.At(3), got)
+
+	// The index is rebuilt if metrics were removed.
+	ms.RemoveIf(func(m Metric) bool { return m.DataType() == MetricDataTypeGauge })
+	_, ok = idx.Get("requests", MetricDataTypeGauge)
+	assert.False(t, ok)
+	got, ok = idx.Get("latency", MetricDataTypeHistogram)
+	require.True(t, ok)
+	assert.Equal(t, ms.At(2), got)
+}
+
+func TestMetricSliceIndexUpsert(t *testing.T) {
+	ms := NewMetricSlice()
+	idx := NewMetricSliceIndex(ms)
+
+	m := idx.Upsert("requests", MetricDataTypeSum)
+	assert.Equal(t, "requests", m.Name())
+	assert.Equal(t, MetricDataTypeSum, m.DataType())
+	m.Sum().DataPoints().AppendEmpty().SetIntVal(1)
+	require.Equal(t, 1, ms.Len())
+
+	// The existing metric is returned.
+	m = idx.Upsert("requests", MetricDataTypeSum)
+	assert.Equal(t, 1, m.Sum().DataPoints().Len())
+	assert.Equal(t, 1, ms.Len())
+
+	// A metric with the same name and another data type is a different metric.
+	m = idx.Upsert("requests", MetricDataTypeGauge)
+	assert.Equal(t, MetricDataTypeGauge, m.DataType())
+	assert.Equal(t, 2, ms.Len())
+
+	got, ok := idx.Get("requests", MetricDataTypeGauge)
+	require.True(t, ok)
+	assert.Equal(t, ms.At(1), got)
+}
+
+func generateMetricSlice(n int) MetricSlice {
+	ms := NewMetricSlice()
+	ms.EnsureCapacity(n)
+	for i := 0; i < n; i++ {
+		m := ms.AppendEmpty()
+		m.SetName("metric_" + strconv.Itoa(i))
+		m.SetDataType(MetricDataTypeSum)
+	}
+	return ms
+}
+
+func BenchmarkMetricSliceLinearScan(b *testing.B) {
+	ms := generateMetricSlice(1000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		name := "metric_" + strconv.Itoa(n%1000)
+		for i := 0; i < ms.Len(); i++ {
+			if m := ms.At(i); m.Name() == name && m.DataType() == MetricDataTypeSum {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkMetricSliceIndex(b *testing.B) {
+	ms := generateMetricSlice(1000)
+	idx := NewMetricSliceIndex(ms)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, ok := idx.Get("metric_"+strconv.Itoa(n%1000), MetricDataTypeSum); !ok {
+			b.Fail()
+		}
+	}
+}
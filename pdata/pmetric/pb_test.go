@@ -49,6 +49,31 @@ func TestProtoSizer_withNil(t *testing.T) {
 	assert.Equal(t, 0, sizer.MetricsSize(NewMetrics()))
 }
 
+func TestLazyProtoUnmarshaler(t *testing.T) {
+	md := NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("foo")
+	buf, err := NewProtoMarshaler().MarshalMetrics(md)
+	require.NoError(t, err)
+
+	lazy, err := NewLazyProtoUnmarshaler().UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lazy.MetricCount())
+
+	// The unmodified bytes are reused, except by the deterministic marshaler.
+	got, err := NewProtoMarshaler().MarshalMetrics(lazy)
+	require.NoError(t, err)
+	assert.Same(t, &buf[0], &got[0])
+	assert.Equal(t, len(buf), NewProtoMarshaler().(Sizer).MetricsSize(lazy))
+	got, err = NewProtoMarshaler(WithDeterministicMarshaling()).MarshalMetrics(lazy)
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+}
+
+func TestLazyProtoUnmarshaler_error(t *testing.T) {
+	_, err := NewLazyProtoUnmarshaler().UnmarshalMetrics([]byte("+$%"))
+	assert.Error(t, err)
+}
+
 func BenchmarkMetricsToProto(b *testing.B) {
 	marshaler := NewProtoMarshaler()
 	metrics := generateBenchmarkMetrics(128)
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetricotlp
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// newBatchingTestRequest returns a request with a gauge of 3 data points, a cumulative monotonic sum
+// of 4 data points and a histogram of 2 data points per resource.
+func newBatchingTestRequest(resources int) Request {
+	md := pmetric.NewMetrics()
+	for i := 0; i < resources; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("resource", strconv.Itoa(i))
+		rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("scope")
+
+		gauge := sm.Metrics().AppendEmpty()
+		gauge.SetName("gauge")
+		gauge.SetDataType(pmetric.MetricDataTypeGauge)
+		for k := 0; k < 3; k++ {
+			gauge.Gauge().DataPoints().AppendEmpty().SetIntVal(int64(k))
+		}
+
+		sum := sm.Metrics().AppendEmpty()
+		sum.SetName("sum")
+		sum.SetUnit("By")
+		sum.SetDataType(pmetric.MetricDataTypeSum)
+		sum.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		sum.Sum().SetIsMonotonic(true)
+		for k := 0; k < 4; k++ {
+			sum.Sum().DataPoints().AppendEmpty().SetIntVal(int64(k))
+		}
+
+		histogram := sm.Metrics().AppendEmpty()
+		histogram.SetName("histogram")
+		histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+		for k := 0; k < 2; k++ {
+			histogram.Histogram().DataPoints().AppendEmpty().SetCount(uint64(k))
+		}
+	}
+	return NewRequestFromMetrics(md)
+}
+
+// metricPoints returns the name and the data point count of each metric of the request, with its resource.
+func metricPoints(req Request) []string {
+	var points []string
+	rms := req.Metrics().ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		res, _ := rms.At(i).Resource().Attributes().Get("resource")
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				points = append(points, res.StringVal()+"/"+ms.At(k).Name()+"/"+strconv.Itoa(metricDPC(ms.At(k))))
+			}
+		}
+	}
+	return points
+}
+
+func TestRequestMerge(t *testing.T) {
+	req := newBatchingTestRequest(1)
+	other := newBatchingTestRequest(2)
+	req.Merge(other)
+
+	assert.Equal(t, 27, req.Metrics().DataPointCount())
+	assert.Equal(t, 3, req.Metrics().ResourceMetrics().Len())
+	assert.Equal(t, 0, other.Metrics().DataPointCount())
+
+	// Merging an empty request is a no-op.
+	req.Merge(NewRequest())
+	assert.Equal(t, 27, req.Metrics().DataPointCount())
+}
+
+func TestRequestSplit(t *testing.T) {
+	req := newBatchingTestRequest(2)
+
+	reqs := req.Split(4)
+	require.Len(t, reqs, 5)
+	assert.Equal(t, req, reqs[4])
+	expected := [][]string{
+		{"0/gauge/3", "0/sum/1"},
+		{"0/sum/3", "0/histogram/1"},
+		{"0/histogram/1", "1/gauge/3"},
+		{"1/sum/4"},
+		{"1/histogram/2"},
+	}
+	for i, r := range reqs {
+		assert.Equal(t, expected[i], metricPoints(r), "request %d", i)
+		rms := r.Metrics().ResourceMetrics()
+		for j := 0; j < rms.Len(); j++ {
+			assert.Equal(t, "https://opentelemetry.io/schemas/1.9.0", rms.At(j).SchemaUrl())
+			assert.Equal(t, "scope", rms.At(j).ScopeMetrics().At(0).Scope().Name())
+		}
+	}
+
+	// The properties of the split sum are kept in both requests.
+	for i, idx := range []int{1, 0} {
+		sum := reqs[i].Metrics().ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(idx)
+		assert.Equal(t, "sum", sum.Name())
+		assert.Equal(t, "By", sum.Unit())
+		assert.Equal(t, pmetric.MetricDataTypeSum, sum.DataType())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, sum.Sum().AggregationTemporality())
+		assert.True(t, sum.Sum().IsMonotonic())
+	}
+	// The data points keep their order.
+	sumPoints := reqs[1].Metrics().ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	assert.Equal(t, int64(1), sumPoints.At(0).IntVal())
+	assert.Equal(t, int64(3), sumPoints.At(2).IntVal())
+}
+
+func TestRequestSplitNoop(t *testing.T) {
+	for _, maxItems := range []int{-1, 0, 9, 10} {
+		req := newBatchingTestRequest(1)
+		reqs := req.Split(maxItems)
+		require.Len(t, reqs, 1)
+		assert.Equal(t, req, reqs[0])
+		assert.Equal(t, 9, reqs[0].Metrics().DataPointCount())
+	}
+	assert.Len(t, NewRequest().Split(1), 1)
+}
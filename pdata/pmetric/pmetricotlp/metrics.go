@@ -27,6 +27,9 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
+// exportMethod is the full name of the Export method of the OTLP gRPC service.
+const exportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+
 var jsonMarshaler = &jsonpb.Marshaler{}
 var jsonUnmarshaler = &jsonpb.Unmarshaler{}
 
@@ -67,35 +70,38 @@ func (mr Response) UnmarshalJSON(data []byte) error {
 // Request represents the request for gRPC/HTTP client/server.
 // It's a wrapper for pmetric.Metrics data.
 type Request struct {
-	orig *otlpcollectormetrics.ExportMetricsServiceRequest
+	metrics pmetric.Metrics
 }
 
 // NewRequest returns an empty Request.
 func NewRequest() Request {
-	return Request{orig: &otlpcollectormetrics.ExportMetricsServiceRequest{}}
+	return Request{metrics: pmetric.NewMetrics()}
 }
 
 // NewRequestFromMetrics returns a Request from pmetric.Metrics.
 // Because Request is a wrapper for pmetric.Metrics,
 // any changes to the provided Metrics struct will be reflected in the Request and vice versa.
 func NewRequestFromMetrics(m pmetric.Metrics) Request {
-	return Request{orig: internal.MetricsToOtlp(m)}
+	return Request{metrics: m}
 }
 
 // MarshalProto marshals Request into proto bytes.
 func (mr Request) MarshalProto() ([]byte, error) {
-	return mr.orig.Marshal()
+	if buf, ok := internal.MetricsRawProto(mr.metrics); ok {
+		return buf, nil
+	}
+	return mr.orig().Marshal()
 }
 
 // UnmarshalProto unmarshalls Request from proto bytes.
 func (mr Request) UnmarshalProto(data []byte) error {
-	return mr.orig.Unmarshal(data)
+	return mr.orig().Unmarshal(data)
 }
 
 // MarshalJSON marshals Request into JSON bytes.
 func (mr Request) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
-	if err := jsonMarshaler.Marshal(&buf, mr.orig); err != nil {
+	if err := jsonMarshaler.Marshal(&buf, mr.orig()); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
@@ -103,15 +109,21 @@ func (mr Request) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls Request from JSON bytes.
 func (mr Request) UnmarshalJSON(data []byte) error {
-	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), mr.orig); err != nil {
+	orig := mr.orig()
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), orig); err != nil {
 		return err
 	}
-	otlp.InstrumentationLibraryMetricsToScope(mr.orig.ResourceMetrics)
+	otlp.InstrumentationLibraryMetricsToScope(orig.ResourceMetrics)
 	return nil
 }
 
 func (mr Request) Metrics() pmetric.Metrics {
-	return internal.MetricsFromOtlp(mr.orig)
+	return mr.metrics
+}
+
+// orig returns the request message, decoding the metrics if lazily unmarshaled.
+func (mr Request) orig() *otlpcollectormetrics.ExportMetricsServiceRequest {
+	return internal.MetricsToOtlp(mr.metrics)
 }
 
 // Client is the client API for OTLP-GRPC Metrics service.
@@ -127,16 +139,79 @@ type Client interface {
 
 type metricsClient struct {
 	rawClient otlpcollectormetrics.MetricsServiceClient
+	cc        *grpc.ClientConn
+	invoker   grpc.UnaryInvoker
+	callOpts  []grpc.CallOption
+}
+
+// ClientOption configures the Client returned by NewClientWithOptions.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	interceptors []grpc.UnaryClientInterceptor
+	callOpts     []grpc.CallOption
+}
+
+// WithUnaryClientInterceptors adds the interceptors called around the exports, the first one being
+// the outermost one. The interceptors receive the Request as the request and a *Response as the reply.
+func WithUnaryClientInterceptors(interceptors ...grpc.UnaryClientInterceptor) ClientOption {
+	return func(o *clientOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+// WithCallOptions adds the call options of all the exports, preceding the call options of each export.
+func WithCallOptions(opts ...grpc.CallOption) ClientOption {
+	return func(o *clientOptions) {
+		o.callOpts = append(o.callOpts, opts...)
+	}
 }
 
 // NewClient returns a new Client connected using the given connection.
 func NewClient(cc *grpc.ClientConn) Client {
-	return &metricsClient{rawClient: otlpcollectormetrics.NewMetricsServiceClient(cc)}
+	return NewClientWithOptions(cc)
+}
+
+// NewClientWithOptions returns a new Client connected using the given connection, configured by the
+// options, e.g. to inject interceptors without dialing the connection with them.
+func NewClientWithOptions(cc *grpc.ClientConn, opts ...ClientOption) Client {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &metricsClient{rawClient: otlpcollectormetrics.NewMetricsServiceClient(cc), cc: cc, callOpts: o.callOpts}
+	c.invoker = internal.ChainUnaryClient(o.interceptors, c.invoke)
+	return c
 }
 
+// Export implements the Client interface.
 func (c *metricsClient) Export(ctx context.Context, request Request, opts ...grpc.CallOption) (Response, error) {
-	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
-	return Response{orig: rsp}, err
+	if len(c.callOpts) > 0 {
+		opts = append(c.callOpts[:len(c.callOpts):len(c.callOpts)], opts...)
+	}
+	var rsp Response
+	err := c.invoker(ctx, exportMethod, request, &rsp, c.cc, opts...)
+	return rsp, err
+}
+
+// invoke sends the Request, setting the *Response on success.
+func (c *metricsClient) invoke(ctx context.Context, _ string, req, reply interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+	request := req.(Request)
+	if buf, ok := internal.MetricsRawProto(request.metrics); ok {
+		// Send the bytes the metrics were lazily unmarshaled from as is.
+		rsp := &otlpcollectormetrics.ExportMetricsServiceResponse{}
+		if err := c.cc.Invoke(ctx, exportMethod, &internal.RawMessage{Buf: buf}, rsp, opts...); err != nil {
+			return err
+		}
+		reply.(*Response).orig = rsp
+		return nil
+	}
+	rsp, err := c.rawClient.Export(ctx, request.orig(), opts...)
+	if err != nil {
+		return err
+	}
+	reply.(*Response).orig = rsp
+	return nil
 }
 
 // Server is the server API for OTLP gRPC MetricsService service.
@@ -148,17 +223,49 @@ type Server interface {
 	Export(context.Context, Request) (Response, error)
 }
 
+// ServerOption configures the Server registered by RegisterServerWithOptions.
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+// WithUnaryServerInterceptors adds the interceptors called around the exports, after the interceptors
+// of the grpc.Server, the first one being the outermost one. The interceptors receive the Request as
+// the request, and return the Response.
+func WithUnaryServerInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
 // RegisterServer registers the Server to the grpc.Server.
 func RegisterServer(s *grpc.Server, srv Server) {
-	otlpcollectormetrics.RegisterMetricsServiceServer(s, &rawMetricsServer{srv: srv})
+	RegisterServerWithOptions(s, srv)
+}
+
+// RegisterServerWithOptions registers the Server to the grpc.Server, configured by the options.
+func RegisterServerWithOptions(s *grpc.Server, srv Server, opts ...ServerOption) {
+	var o serverOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	handler := internal.ChainUnaryServer(o.interceptors, &grpc.UnaryServerInfo{Server: srv, FullMethod: exportMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.Export(ctx, req.(Request))
+		})
+	otlpcollectormetrics.RegisterMetricsServiceServer(s, &rawMetricsServer{handler: handler})
 }
 
 type rawMetricsServer struct {
-	srv Server
+	handler grpc.UnaryHandler
 }
 
 func (s rawMetricsServer) Export(ctx context.Context, request *otlpcollectormetrics.ExportMetricsServiceRequest) (*otlpcollectormetrics.ExportMetricsServiceResponse, error) {
 	otlp.InstrumentationLibraryMetricsToScope(request.ResourceMetrics)
-	rsp, err := s.srv.Export(ctx, Request{orig: request})
-	return rsp.orig, err
+	rsp, err := s.handler(ctx, Request{metrics: internal.MetricsFromOtlp(request)})
+	if r, ok := rsp.(Response); ok {
+		return r.orig, err
+	}
+	return nil, err
 }
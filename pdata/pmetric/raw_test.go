@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRaw(t *testing.T) {
+	md := NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetDataType(MetricDataTypeSum)
+	m.Sum().SetIsMonotonic(true)
+	m.Sum().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(1000)
+	dp.SetIntVal(5)
+
+	raw, err := AsRaw(md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"resourceMetrics": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{},
+			"scopeMetrics": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{},
+				"metrics": []interface{}{map[string]interface{}{
+					"name": "requests",
+					"sum": map[string]interface{}{
+						"dataPoints": []interface{}{
+							map[string]interface{}{"timeUnixNano": "1000", "asInt": "5"},
+						},
+						"aggregationTemporality": "AGGREGATION_TEMPORALITY_CUMULATIVE",
+						"isMonotonic":            true,
+					},
+				}},
+			}},
+		}},
+	}, raw)
+
+	got, err := FromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// quantileValue is a valid quantile of a summary data point.
+type quantileValue struct {
+	quantile float64
+	value    float64
+}
+
+// sortedQuantileValues returns the quantiles of the summary data point sorted by quantile,
+// skipping the NaN values and the quantiles not in the [0, 1] range.
+func sortedQuantileValues(dp SummaryDataPoint) []quantileValue {
+	qvs := dp.QuantileValues()
+	sorted := make([]quantileValue, 0, qvs.Len())
+	for i := 0; i < qvs.Len(); i++ {
+		qv := qvs.At(i)
+		if !(qv.Quantile() >= 0 && qv.Quantile() <= 1) || math.IsNaN(qv.Value()) {
+			continue
+		}
+		sorted = append(sorted, quantileValue{quantile: qv.Quantile(), value: qv.Value()})
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].quantile < sorted[j].quantile })
+	return sorted
+}
+
+// SummaryQuantileValue returns the value of the summary data point at the given quantile, e.g. 0.99
+// for the 99th percentile. Returns false if the quantile is not reported by the data point.
+func SummaryQuantileValue(dp SummaryDataPoint, quantile float64) (float64, bool) {
+	qvs := dp.QuantileValues()
+	for i := 0; i < qvs.Len(); i++ {
+		if qvs.At(i).Quantile() == quantile {
+			return qvs.At(i).Value(), true
+		}
+	}
+	return 0, false
+}
+
+// InterpolateSummaryQuantile returns the value of the summary data point at the given quantile,
+// linearly interpolated between the closest reported quantiles below and above it. Returns false
+// if the quantile is out of the range of the reported quantiles, the values out of it being unknown.
+func InterpolateSummaryQuantile(dp SummaryDataPoint, quantile float64) (float64, bool) {
+	qvs := sortedQuantileValues(dp)
+	// The index of the first reported quantile greater than or equal to the given one.
+	i := sort.Search(len(qvs), func(i int) bool { return qvs[i].quantile >= quantile })
+	switch {
+	case i == len(qvs):
+		return 0, false
+	case qvs[i].quantile == quantile:
+		return qvs[i].value, true
+	case i == 0:
+		return 0, false
+	}
+	lower, upper := qvs[i-1], qvs[i]
+	return lower.value + (upper.value-lower.value)*(quantile-lower.quantile)/(upper.quantile-lower.quantile), true
+}
+
+// summaryToHistogramDataPoint sets the approximate histogram of the summary data point to the
+// histogram data point. Each reported quantile q with the value v, except the maximum, becomes
+// the upper bound v of a bucket, round(q * count) observations being less than or equal to v.
+func summaryToHistogramDataPoint(sdp SummaryDataPoint, hdp HistogramDataPoint) {
+	sdp.Attributes().CopyTo(hdp.Attributes())
+	hdp.SetStartTimestamp(sdp.StartTimestamp())
+	hdp.SetTimestamp(sdp.Timestamp())
+	hdp.SetFlags(sdp.Flags())
+	hdp.SetCount(sdp.Count())
+	hdp.SetSum(sdp.Sum())
+
+	var bounds []float64
+	// The number of observations less than or equal to each bound.
+	var cumulativeCounts []uint64
+	for _, qv := range sortedQuantileValues(sdp) {
+		if sdp.Count() > 0 {
+			switch qv.quantile {
+			case 0:
+				hdp.SetMin(qv.value)
+			case 1:
+				hdp.SetMax(qv.value)
+			}
+		}
+		if qv.quantile == 1 {
+			// All the observations are less than or equal to the maximum, in the overflow bucket.
+			continue
+		}
+		count := uint64(math.Round(qv.quantile * float64(sdp.Count())))
+		if n := len(bounds); n > 0 && qv.value <= bounds[n-1] {
+			// The bounds must be strictly increasing, the values of the higher quantiles being
+			// counted in the bucket of the greater bound.
+			if qv.value == bounds[n-1] {
+				cumulativeCounts[n-1] = count
+			}
+			continue
+		}
+		bounds = append(bounds, qv.value)
+		cumulativeCounts = append(cumulativeCounts, count)
+	}
+
+	bucketCounts := make([]uint64, len(bounds)+1)
+	var previous uint64
+	for i, count := range cumulativeCounts {
+		bucketCounts[i] = count - previous
+		previous = count
+	}
+	bucketCounts[len(bounds)] = sdp.Count() - previous
+	hdp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+	hdp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(bucketCounts))
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSummaryDataPoint(quantileValues ...float64) SummaryDataPoint {
+	dp := NewSummaryDataPoint()
+	for i := 0; i+1 < len(quantileValues); i += 2 {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(quantileValues[i])
+		qv.SetValue(quantileValues[i+1])
+	}
+	return dp
+}
+
+func TestSummaryQuantileValue(t *testing.T) {
+	dp := newTestSummaryDataPoint(0.5, 10, 0.99, 50)
+
+	v, ok := SummaryQuantileValue(dp, 0.99)
+	assert.True(t, ok)
+	assert.Equal(t, 50.0, v)
+
+	_, ok = SummaryQuantileValue(dp, 0.9)
+	assert.False(t, ok)
+}
+
+func TestInterpolateSummaryQuantile(t *testing.T) {
+	// Unsorted quantiles, with invalid ones.
+	dp := newTestSummaryDataPoint(0.9, 30, 0.5, 10, 1.5, 100, 0.7, math.NaN(), 0.1, 2)
+
+	tests := []struct {
+		name     string
+		quantile float64
+		value    float64
+		ok       bool
+	}{
+		{name: "reported", quantile: 0.5, value: 10, ok: true},
+		{name: "interpolated", quantile: 0.75, value: 22.5, ok: true},
+		{name: "interpolated_skipping_invalid", quantile: 0.7, value: 20, ok: true},
+		{name: "lowest", quantile: 0.1, value: 2, ok: true},
+		{name: "below_range", quantile: 0.05},
+		{name: "above_range", quantile: 0.95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := InterpolateSummaryQuantile(dp, tt.quantile)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.value, v, 1e-9)
+		})
+	}
+
+	_, ok := InterpolateSummaryQuantile(NewSummaryDataPoint(), 0.5)
+	assert.False(t, ok)
+}
@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// CumulativeValue is the last cumulative value of a stream, from which the next delta is computed.
+type CumulativeValue struct {
+	StartTimestamp pcommon.Timestamp
+	Timestamp      pcommon.Timestamp
+
+	// ValueType, IntVal and DoubleVal are the value of the sum data points.
+	ValueType NumberDataPointValueType
+	IntVal    int64
+	DoubleVal float64
+
+	// Count, Sum, BucketCounts and ExplicitBounds are the values of the histogram data points.
+	Count          uint64
+	Sum            float64
+	BucketCounts   []uint64
+	ExplicitBounds []float64
+
+	// Min and Max are the min and max of the histogram data points converted to cumulative temporality,
+	// if HasMin and HasMax.
+	HasMin bool
+	Min    float64
+	HasMax bool
+	Max    float64
+}
+
+// DeltaState stores the last cumulative values of the streams converted to delta temporality.
+// Implementations may evict the streams which are not updated anymore to bound their memory
+// usage, the next point of an evicted stream being handled as the first point of a stream.
+type DeltaState interface {
+	// Load returns the last cumulative value stored for the stream identified by the key.
+	Load(key string) (CumulativeValue, bool)
+	// Store stores the last cumulative value of the stream identified by the key.
+	Store(key string, value CumulativeValue)
+}
+
+type mapDeltaState struct {
+	mu     sync.Mutex
+	values map[string]CumulativeValue
+}
+
+// NewDeltaState returns an in-memory DeltaState safe for concurrent use, which never evicts the streams.
+func NewDeltaState() DeltaState {
+	return &mapDeltaState{values: map[string]CumulativeValue{}}
+}
+
+func (s *mapDeltaState) Load(key string) (CumulativeValue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+func (s *mapDeltaState) Store(key string, value CumulativeValue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// ConvertCumulativeToDelta converts the cumulative sums and histograms of the metrics to delta temporality,
+// the last values of the streams being tracked by the state. The first point of a stream, and the first point
+// after a reset of the stream, is kept as the delta since its start timestamp if set, otherwise it is removed
+// since the change it represents is unknown. Points older than the last point of their stream are removed.
+// The histogram deltas have no min and max since they cannot be computed from the cumulative values.
+// The metrics, scopes and resources left without data points are removed.
+func ConvertCumulativeToDelta(md Metrics, state DeltaState) {
+	removeMetricsIf(md, func(m Metric, metricKey string) bool {
+		switch m.DataType() {
+		case MetricDataTypeSum:
+			if m.Sum().AggregationTemporality() != MetricAggregationTemporalityCumulative {
+				return false
+			}
+			sumToDelta(m.Sum(), metricKey, state)
+			return m.Sum().DataPoints().Len() == 0
+		case MetricDataTypeHistogram:
+			if m.Histogram().AggregationTemporality() != MetricAggregationTemporalityCumulative {
+				return false
+			}
+			histogramToDelta(m.Histogram(), metricKey, state)
+			return m.Histogram().DataPoints().Len() == 0
+		}
+		return false
+	})
+}
+
+// removeMetricsIf removes the metrics for which f returns true, f being passed the key identifying the
+// metric with its resource and scope, and the scopes and resources left without metrics.
+func removeMetricsIf(md Metrics, f func(m Metric, metricKey string) bool) {
+	md.ResourceMetrics().RemoveIf(func(rm ResourceMetrics) bool {
+		resourceKey := attributesKey(rm.Resource().Attributes())
+		rm.ScopeMetrics().RemoveIf(func(sm ScopeMetrics) bool {
+			scopeKey := resourceKey + "\x00" + sm.Scope().Name() + "\x00" + sm.Scope().Version()
+			sm.Metrics().RemoveIf(func(m Metric) bool {
+				return f(m, scopeKey+"\x00"+m.Name()+"\x00"+m.Unit()+"\x00"+m.DataType().String())
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+func sumToDelta(sum Sum, metricKey string, state DeltaState) {
+	sum.SetAggregationTemporality(MetricAggregationTemporalityDelta)
+	sum.DataPoints().RemoveIf(func(dp NumberDataPoint) bool {
+		key := metricKey + "\x00" + attributesKey(dp.Attributes())
+		cur := CumulativeValue{
+			StartTimestamp: dp.StartTimestamp(),
+			Timestamp:      dp.Timestamp(),
+			ValueType:      dp.ValueType(),
+			IntVal:         dp.IntVal(),
+			DoubleVal:      dp.DoubleVal(),
+		}
+		prev, ok := state.Load(key)
+		if ok && cur.Timestamp <= prev.Timestamp {
+			return true
+		}
+		state.Store(key, cur)
+		if !ok || isSumReset(prev, cur, sum.IsMonotonic()) {
+			return !hasStartTimestamp(cur)
+		}
+		dp.SetStartTimestamp(prev.Timestamp)
+		switch cur.ValueType {
+		case NumberDataPointValueTypeInt:
+			dp.SetIntVal(cur.IntVal - prev.IntVal)
+		case NumberDataPointValueTypeDouble:
+			dp.SetDoubleVal(cur.DoubleVal - prev.DoubleVal)
+		}
+		return false
+	})
+}
+
+func isSumReset(prev, cur CumulativeValue, isMonotonic bool) bool {
+	if prev.StartTimestamp != cur.StartTimestamp || prev.ValueType != cur.ValueType {
+		return true
+	}
+	return isMonotonic && (cur.IntVal < prev.IntVal || cur.DoubleVal < prev.DoubleVal)
+}
+
+func histogramToDelta(histogram Histogram, metricKey string, state DeltaState) {
+	histogram.SetAggregationTemporality(MetricAggregationTemporalityDelta)
+	deltas := NewHistogramDataPointSlice()
+	dps := histogram.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := metricKey + "\x00" + attributesKey(dp.Attributes())
+		cur := CumulativeValue{
+			StartTimestamp: dp.StartTimestamp(),
+			Timestamp:      dp.Timestamp(),
+			Count:          dp.Count(),
+			Sum:            dp.Sum(),
+			BucketCounts:   dp.BucketCounts().AsRaw(),
+			ExplicitBounds: dp.ExplicitBounds().AsRaw(),
+		}
+		prev, ok := state.Load(key)
+		if ok && cur.Timestamp <= prev.Timestamp {
+			continue
+		}
+		state.Store(key, cur)
+		if !ok || isHistogramReset(prev, cur) {
+			if hasStartTimestamp(cur) {
+				dp.CopyTo(deltas.AppendEmpty())
+			}
+			continue
+		}
+
+		delta := deltas.AppendEmpty()
+		dp.Attributes().CopyTo(delta.Attributes())
+		delta.SetStartTimestamp(prev.Timestamp)
+		delta.SetTimestamp(cur.Timestamp)
+		delta.SetCount(cur.Count - prev.Count)
+		if dp.HasSum() {
+			delta.SetSum(cur.Sum - prev.Sum)
+		}
+		bucketCounts := make([]uint64, len(cur.BucketCounts))
+		for j := range bucketCounts {
+			bucketCounts[j] = cur.BucketCounts[j] - prev.BucketCounts[j]
+		}
+		delta.SetBucketCounts(pcommon.NewImmutableUInt64Slice(bucketCounts))
+		delta.SetExplicitBounds(dp.ExplicitBounds())
+		dp.Exemplars().CopyTo(delta.Exemplars())
+		delta.SetFlags(dp.Flags())
+	}
+	dps.RemoveIf(func(HistogramDataPoint) bool { return true })
+	deltas.MoveAndAppendTo(dps)
+}
+
+func isHistogramReset(prev, cur CumulativeValue) bool {
+	if prev.StartTimestamp != cur.StartTimestamp || cur.Count < prev.Count ||
+		len(prev.BucketCounts) != len(cur.BucketCounts) || len(prev.ExplicitBounds) != len(cur.ExplicitBounds) {
+		return true
+	}
+	for i := range cur.ExplicitBounds {
+		if cur.ExplicitBounds[i] != prev.ExplicitBounds[i] {
+			return true
+		}
+	}
+	for i := range cur.BucketCounts {
+		if cur.BucketCounts[i] < prev.BucketCounts[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasStartTimestamp returns whether the cumulative value is known to be the change since its start timestamp.
+func hasStartTimestamp(value CumulativeValue) bool {
+	return value.StartTimestamp != 0 && value.StartTimestamp < value.Timestamp
+}
+
+// attributesKey returns a string identifying the attributes, independently of their order.
+func attributesKey(attrs pcommon.Map) string {
+	kvs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		kvs = append(kvs, k+"\x01"+v.Type().String()+"\x01"+v.AsString())
+		return true
+	})
+	sort.Strings(kvs)
+	return strings.Join(kvs, "\x02")
+}
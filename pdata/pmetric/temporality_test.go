@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newCumulativeSum(isMonotonic bool, start pcommon.Timestamp, ts pcommon.Timestamp, val int64) Metrics {
+	md := NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "svc")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetDataType(MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	m.Sum().SetIsMonotonic(isMonotonic)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().InsertString("path", "/")
+	return md
+}
+
+func sumDataPoints(md Metrics) NumberDataPointSlice {
+	return md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+}
+
+func TestConvertCumulativeToDeltaSum(t *testing.T) {
+	state := NewDeltaState()
+
+	// The first point without start timestamp is removed, along with the empty metric, scope and resource.
+	md := newCumulativeSum(true, 0, 10, 5)
+	ConvertCumulativeToDelta(md, state)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = newCumulativeSum(true, 0, 20, 8)
+	ConvertCumulativeToDelta(md, state)
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, MetricAggregationTemporalityDelta, m.Sum().AggregationTemporality())
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	dp := sumDataPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+	assert.Equal(t, int64(3), dp.IntVal())
+
+	// Out of order points are removed.
+	md = newCumulativeSum(true, 0, 15, 7)
+	ConvertCumulativeToDelta(md, state)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	// A decreasing monotonic sum is a reset, without start timestamp the point is removed.
+	md = newCumulativeSum(true, 0, 30, 2)
+	ConvertCumulativeToDelta(md, state)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = newCumulativeSum(true, 0, 40, 6)
+	ConvertCumulativeToDelta(md, state)
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	assert.Equal(t, int64(4), sumDataPoints(md).At(0).IntVal())
+}
+
+func TestConvertCumulativeToDeltaSumStartTimestamp(t *testing.T) {
+	state := NewDeltaState()
+
+	// The first point with a start timestamp is the delta since its start.
+	md := newCumulativeSum(true, 5, 10, 5)
+	ConvertCumulativeToDelta(md, state)
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	dp := sumDataPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(5), dp.StartTimestamp())
+	assert.Equal(t, int64(5), dp.IntVal())
+
+	// A new start timestamp is a reset.
+	md = newCumulativeSum(true, 15, 20, 1)
+	ConvertCumulativeToDelta(md, state)
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	dp = sumDataPoints(md).At(0)
+	assert.Equal(t, pcommon.Timestamp(15), dp.StartTimestamp())
+	assert.Equal(t, int64(1), dp.IntVal())
+}
+
+func TestConvertCumulativeToDeltaNonMonotonicSum(t *testing.T) {
+	state := NewDeltaState()
+	ConvertCumulativeToDelta(newCumulativeSum(false, 0, 10, 5), state)
+	md := newCumulativeSum(false, 0, 20, 2)
+	ConvertCumulativeToDelta(md, state)
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	assert.Equal(t, int64(-3), sumDataPoints(md).At(0).IntVal())
+}
+
+func TestConvertCumulativeToDeltaStreams(t *testing.T) {
+	state := NewDeltaState()
+	ConvertCumulativeToDelta(newCumulativeSum(true, 0, 10, 5), state)
+
+	// A different resource is a different stream.
+	md := newCumulativeSum(true, 0, 20, 8)
+	md.ResourceMetrics().At(0).Resource().Attributes().UpsertString("service.name", "other")
+	ConvertCumulativeToDelta(md, state)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	// Different data point attributes are a different stream.
+	md = newCumulativeSum(true, 0, 20, 8)
+	sumDataPoints(md).At(0).Attributes().UpsertString("path", "/other")
+	ConvertCumulativeToDelta(md, state)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md = newCumulativeSum(true, 0, 20, 8)
+	ConvertCumulativeToDelta(md, state)
+	require.Equal(t, 1, sumDataPoints(md).Len())
+	assert.Equal(t, int64(3), sumDataPoints(md).At(0).IntVal())
+}
+
+func TestConvertCumulativeToDeltaIgnoresOtherMetrics(t *testing.T) {
+	md := NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty()
+	gauge.SetDataType(MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().SetIntVal(1)
+	delta := ms.AppendEmpty()
+	delta.SetDataType(MetricDataTypeSum)
+	delta.Sum().SetAggregationTemporality(MetricAggregationTemporalityDelta)
+	delta.Sum().DataPoints().AppendEmpty().SetIntVal(1)
+
+	expected := md.Clone()
+	ConvertCumulativeToDelta(md, NewDeltaState())
+	assert.Equal(t, expected, md)
+}
+
+func newCumulativeHistogram(ts pcommon.Timestamp, count uint64, sum float64, bucketCounts []uint64) Metrics {
+	md := NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	m.SetDataType(MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(MetricAggregationTemporalityCumulative)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(1)
+	dp.SetTimestamp(ts)
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetMin(1)
+	dp.SetMax(10)
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{5}))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(bucketCounts))
+	return md
+}
+
+func TestConvertCumulativeToDeltaHistogram(t *testing.T) {
+	state := NewDeltaState()
+
+	md := newCumulativeHistogram(10, 2, 12, []uint64{1, 1})
+	ConvertCumulativeToDelta(md, state)
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, MetricAggregationTemporalityDelta, m.Histogram().AggregationTemporality())
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(2), dp.Count())
+	assert.True(t, dp.HasMin())
+
+	md = newCumulativeHistogram(20, 5, 30, []uint64{2, 3})
+	ConvertCumulativeToDelta(md, state)
+	m = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp = m.Histogram().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(10), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(20), dp.Timestamp())
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, float64(18), dp.Sum())
+	assert.Equal(t, []uint64{1, 2}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, []float64{5}, dp.ExplicitBounds().AsRaw())
+	assert.False(t, dp.HasMin())
+	assert.False(t, dp.HasMax())
+
+	// A decreasing bucket count is a reset, the point being the delta since its start timestamp.
+	md = newCumulativeHistogram(30, 6, 31, []uint64{0, 6})
+	ConvertCumulativeToDelta(md, state)
+	m = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp = m.Histogram().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, []uint64{0, 6}, dp.BucketCounts().AsRaw())
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// unitScale is the scale of a unit relative to the base unit of its dimension.
+type unitScale struct {
+	dimension string
+	scale     float64
+}
+
+// unitScales are the scales of the supported units, in the UCUM case-sensitive
+// codes recommended by the OpenTelemetry semantic conventions.
+var unitScales = map[string]unitScale{
+	"ns":  {dimension: "time", scale: 1e-9},
+	"us":  {dimension: "time", scale: 1e-6},
+	"ms":  {dimension: "time", scale: 1e-3},
+	"s":   {dimension: "time", scale: 1},
+	"min": {dimension: "time", scale: 60},
+	"h":   {dimension: "time", scale: 3600},
+	"d":   {dimension: "time", scale: 86400},
+
+	"By":   {dimension: "bytes", scale: 1},
+	"KBy":  {dimension: "bytes", scale: 1e3},
+	"MBy":  {dimension: "bytes", scale: 1e6},
+	"GBy":  {dimension: "bytes", scale: 1e9},
+	"TBy":  {dimension: "bytes", scale: 1e12},
+	"KiBy": {dimension: "bytes", scale: 1 << 10},
+	"MiBy": {dimension: "bytes", scale: 1 << 20},
+	"GiBy": {dimension: "bytes", scale: 1 << 30},
+	"TiBy": {dimension: "bytes", scale: 1 << 40},
+
+	"1": {dimension: "ratio", scale: 1},
+	"%": {dimension: "ratio", scale: 1e-2},
+}
+
+// unitAliases maps the common non UCUM spellings of the units to their UCUM code.
+var unitAliases = map[string]string{
+	"nanoseconds":  "ns",
+	"microseconds": "us",
+	"milliseconds": "ms",
+	"seconds":      "s",
+	"sec":          "s",
+	"minutes":      "min",
+	"hours":        "h",
+	"days":         "d",
+	"bytes":        "By",
+	"B":            "By",
+	"KB":           "KBy",
+	"MB":           "MBy",
+	"GB":           "GBy",
+	"TB":           "TBy",
+	"KiB":          "KiBy",
+	"MiB":          "MiBy",
+	"GiB":          "GiBy",
+	"TiB":          "TiBy",
+	"percent":      "%",
+}
+
+// CanonicalUnit returns the UCUM code of the unit if it is one of the common non UCUM
+// spellings, for example "seconds" or "MiB", and the unit unchanged otherwise.
+func CanonicalUnit(unit string) string {
+	if canonical, ok := unitAliases[unit]; ok {
+		return canonical
+	}
+	return unit
+}
+
+// UnitScaleFactor returns the factor by which the values in the from unit must be multiplied
+// to be expressed in the to unit. Returns false if the units are unknown or of different dimensions.
+func UnitScaleFactor(from, to string) (float64, bool) {
+	fromScale, ok := unitScales[CanonicalUnit(from)]
+	if !ok {
+		return 0, false
+	}
+	toScale, ok := unitScales[CanonicalUnit(to)]
+	if !ok || fromScale.dimension != toScale.dimension {
+		return 0, false
+	}
+	return fromScale.scale / toScale.scale, true
+}
+
+// NormalizeUnit converts the values of the metric from its unit to the given unit, for example
+// from "ms" to "s", and sets the unit of the metric. Integer values are converted to double values
+// unless the conversion factor is an integer. Returns false, leaving the metric unchanged, if the
+// units cannot be converted or if the metric is an exponential histogram, its buckets being defined
+// by the scale and not by explicit bounds.
+func NormalizeUnit(metric Metric, unit string) bool {
+	if metric.Unit() == unit {
+		return true
+	}
+	factor, ok := UnitScaleFactor(metric.Unit(), unit)
+	if !ok {
+		return false
+	}
+	switch metric.DataType() {
+	case MetricDataTypeGauge:
+		scaleNumberDataPoints(metric.Gauge().DataPoints(), factor)
+	case MetricDataTypeSum:
+		scaleNumberDataPoints(metric.Sum().DataPoints(), factor)
+	case MetricDataTypeHistogram:
+		scaleHistogramDataPoints(metric.Histogram().DataPoints(), factor)
+	case MetricDataTypeSummary:
+		scaleSummaryDataPoints(metric.Summary().DataPoints(), factor)
+	case MetricDataTypeExponentialHistogram:
+		return false
+	}
+	metric.SetUnit(unit)
+	return true
+}
+
+func scaleNumberDataPoints(dps NumberDataPointSlice, factor float64) {
+	intFactor := factor >= 1 && factor == math.Trunc(factor)
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch dp.ValueType() {
+		case NumberDataPointValueTypeInt:
+			if intFactor {
+				dp.SetIntVal(dp.IntVal() * int64(factor))
+			} else {
+				dp.SetDoubleVal(float64(dp.IntVal()) * factor)
+			}
+		case NumberDataPointValueTypeDouble:
+			dp.SetDoubleVal(dp.DoubleVal() * factor)
+		}
+	}
+}
+
+func scaleHistogramDataPoints(dps HistogramDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.HasSum() {
+			dp.SetSum(dp.Sum() * factor)
+		}
+		if dp.HasMin() {
+			dp.SetMin(dp.Min() * factor)
+		}
+		if dp.HasMax() {
+			dp.SetMax(dp.Max() * factor)
+		}
+		bounds := dp.ExplicitBounds().AsRaw()
+		for j := range bounds {
+			bounds[j] *= factor
+		}
+		dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice(bounds))
+	}
+}
+
+func scaleSummaryDataPoints(dps SummaryDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetSum(dp.Sum() * factor)
+		qvs := dp.QuantileValues()
+		for j := 0; j < qvs.Len(); j++ {
+			qvs.At(j).SetValue(qvs.At(j).Value() * factor)
+		}
+	}
+}
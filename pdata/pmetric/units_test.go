@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pmetric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestUnitScaleFactor(t *testing.T) {
+	tests := []struct {
+		from   string
+		to     string
+		factor float64
+		ok     bool
+	}{
+		{from: "ms", to: "s", factor: 1e-3, ok: true},
+		{from: "s", to: "ms", factor: 1e3, ok: true},
+		{from: "milliseconds", to: "s", factor: 1e-3, ok: true},
+		{from: "h", to: "min", factor: 60, ok: true},
+		{from: "MiB", to: "KiBy", factor: 1024, ok: true},
+		{from: "%", to: "1", factor: 1e-2, ok: true},
+		{from: "s", to: "By", ok: false},
+		{from: "unknown", to: "s", ok: false},
+		{from: "s", to: "unknown", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"_to_"+tt.to, func(t *testing.T) {
+			factor, ok := UnitScaleFactor(tt.from, tt.to)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.factor, factor, 1e-12)
+		})
+	}
+}
+
+func TestCanonicalUnit(t *testing.T) {
+	assert.Equal(t, "s", CanonicalUnit("seconds"))
+	assert.Equal(t, "MiBy", CanonicalUnit("MiB"))
+	assert.Equal(t, "ms", CanonicalUnit("ms"))
+	assert.Equal(t, "{requests}", CanonicalUnit("{requests}"))
+}
+
+func TestNormalizeUnitGauge(t *testing.T) {
+	m := NewMetric()
+	m.SetUnit("ms")
+	m.SetDataType(MetricDataTypeGauge)
+	m.Gauge().DataPoints().AppendEmpty().SetIntVal(1500)
+	m.Gauge().DataPoints().AppendEmpty().SetDoubleVal(250)
+
+	require.True(t, NormalizeUnit(m, "s"))
+	assert.Equal(t, "s", m.Unit())
+	dps := m.Gauge().DataPoints()
+	assert.Equal(t, NumberDataPointValueTypeDouble, dps.At(0).ValueType())
+	assert.InDelta(t, 1.5, dps.At(0).DoubleVal(), 1e-9)
+	assert.InDelta(t, 0.25, dps.At(1).DoubleVal(), 1e-9)
+
+	// Integer values stay integers with an integer factor.
+	m.Gauge().DataPoints().At(0).SetIntVal(2)
+	require.True(t, NormalizeUnit(m, "ms"))
+	assert.Equal(t, NumberDataPointValueTypeInt, dps.At(0).ValueType())
+	assert.Equal(t, int64(2000), dps.At(0).IntVal())
+}
+
+func TestNormalizeUnitSum(t *testing.T) {
+	m := NewMetric()
+	m.SetUnit("KiBy")
+	m.SetDataType(MetricDataTypeSum)
+	m.Sum().DataPoints().AppendEmpty().SetIntVal(3)
+
+	require.True(t, NormalizeUnit(m, "By"))
+	assert.Equal(t, "By", m.Unit())
+	assert.Equal(t, int64(3072), m.Sum().DataPoints().At(0).IntVal())
+}
+
+func TestNormalizeUnitHistogram(t *testing.T) {
+	m := NewMetric()
+	m.SetUnit("ms")
+	m.SetDataType(MetricDataTypeHistogram)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetCount(3)
+	dp.SetSum(3000)
+	dp.SetMin(500)
+	dp.SetMax(1500)
+	dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{1000, 2000}))
+	dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice([]uint64{1, 2, 0}))
+
+	require.True(t, NormalizeUnit(m, "s"))
+	assert.InDelta(t, 3, dp.Sum(), 1e-9)
+	assert.InDelta(t, 0.5, dp.Min(), 1e-9)
+	assert.InDelta(t, 1.5, dp.Max(), 1e-9)
+	assert.InDeltaSlice(t, []float64{1, 2}, dp.ExplicitBounds().AsRaw(), 1e-9)
+	assert.Equal(t, []uint64{1, 2, 0}, dp.BucketCounts().AsRaw())
+}
+
+func TestNormalizeUnitSummary(t *testing.T) {
+	m := NewMetric()
+	m.SetUnit("s")
+	m.SetDataType(MetricDataTypeSummary)
+	dp := m.Summary().DataPoints().AppendEmpty()
+	dp.SetSum(2)
+	qv := dp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(0.25)
+
+	require.True(t, NormalizeUnit(m, "ms"))
+	assert.InDelta(t, 2000, dp.Sum(), 1e-9)
+	assert.InDelta(t, 0.5, qv.Quantile(), 1e-9)
+	assert.InDelta(t, 250, qv.Value(), 1e-9)
+}
+
+func TestNormalizeUnitUnsupported(t *testing.T) {
+	m := NewMetric()
+	m.SetUnit("ms")
+	m.SetDataType(MetricDataTypeGauge)
+	m.Gauge().DataPoints().AppendEmpty().SetIntVal(1)
+	assert.False(t, NormalizeUnit(m, "By"))
+	assert.Equal(t, "ms", m.Unit())
+	assert.Equal(t, int64(1), m.Gauge().DataPoints().At(0).IntVal())
+
+	m.SetDataType(MetricDataTypeExponentialHistogram)
+	assert.False(t, NormalizeUnit(m, "s"))
+	assert.Equal(t, "ms", m.Unit())
+
+	// Same unit is a no-op, even if unknown.
+	m.SetUnit("{requests}")
+	assert.True(t, NormalizeUnit(m, "{requests}"))
+}
@@ -0,0 +1,314 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pschema translates the pdata between the versions of a telemetry schema, following the
+// schema files of https://github.com/open-telemetry/oteps/blob/main/text/0152-telemetry-schemas.md,
+// so that the components can normalize the data to a target version of the semantic conventions.
+//
+// The version of the data is read from the SchemaUrl of the scope, or of the resource when the scope has none.
+// Only the renames of the attributes, span event names and metric names are supported.
+package pschema // import "go.opentelemetry.io/collector/pdata/pschema"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is a version of a schema, the last element of its schema URL, e.g. 1.7.0 for
+// https://opentelemetry.io/schemas/1.7.0.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a version in the "major.minor.patch" form.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid schema version %q", s)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid schema version %q", s)
+		}
+		nums[i] = n
+	}
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+// Compare returns -1, 0 or 1 if v is respectively lower than, equal to or greater than o.
+func (v Version) Compare(o Version) int {
+	for _, d := range [3]int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// SplitSchemaURL splits the schema URL into the URL of the schema family and the version,
+// e.g. "https://opentelemetry.io/schemas" and 1.7.0 for "https://opentelemetry.io/schemas/1.7.0".
+func SplitSchemaURL(schemaURL string) (string, Version, error) {
+	i := strings.LastIndex(schemaURL, "/")
+	if i < 0 {
+		return "", Version{}, fmt.Errorf("invalid schema URL %q", schemaURL)
+	}
+	v, err := ParseVersion(schemaURL[i+1:])
+	if err != nil {
+		return "", Version{}, err
+	}
+	return schemaURL[:i], v, nil
+}
+
+// Schema is a parsed schema file, holding the changes of every version of a schema family.
+type Schema struct {
+	family string
+	// versions are sorted by increasing version.
+	versions []versionChanges
+}
+
+// versionChanges are the changes from the previous version to version.
+type versionChanges struct {
+	version    Version
+	all        []change
+	resources  []change
+	spans      []change
+	spanEvents []change
+	metrics    []change
+	logs       []change
+	// reverse is true for the reversed changes, which apply the section changes before the changes of all.
+	reverse bool
+}
+
+// change is a single change of a schema file section.
+type change struct {
+	// attributes are the renamed attributes, from the old to the new name.
+	attributes map[string]string
+	// names are the renamed span events or metrics, from the old to the new name.
+	names map[string]string
+	// applyTo are the names of the spans or metrics whose attributes are renamed, all if empty.
+	applyTo map[string]bool
+	// applyToEvents are the names of the span events whose attributes are renamed, all if empty.
+	applyToEvents map[string]bool
+}
+
+// reversed returns the change reverting c.
+func (c change) reversed() change {
+	return change{
+		attributes:    reverseMap(c.attributes),
+		names:         reverseMap(c.names),
+		applyTo:       c.applyTo,
+		applyToEvents: c.applyToEvents,
+	}
+}
+
+func reverseMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	r := make(map[string]string, len(m))
+	for k, v := range m {
+		r[v] = k
+	}
+	return r
+}
+
+type fileFormat struct {
+	FileFormat string                   `yaml:"file_format"`
+	SchemaURL  string                   `yaml:"schema_url"`
+	Versions   map[string]versionFormat `yaml:"versions"`
+}
+
+type versionFormat struct {
+	All        sectionFormat `yaml:"all"`
+	Resources  sectionFormat `yaml:"resources"`
+	Spans      sectionFormat `yaml:"spans"`
+	SpanEvents sectionFormat `yaml:"span_events"`
+	Metrics    sectionFormat `yaml:"metrics"`
+	Logs       sectionFormat `yaml:"logs"`
+}
+
+type sectionFormat struct {
+	Changes []changeFormat `yaml:"changes"`
+}
+
+type changeFormat struct {
+	RenameAttributes *struct {
+		AttributeMap  map[string]string `yaml:"attribute_map"`
+		ApplyToSpans  []string          `yaml:"apply_to_spans"`
+		ApplyToEvents []string          `yaml:"apply_to_events"`
+		ApplyToMetric []string          `yaml:"apply_to_metrics"`
+	} `yaml:"rename_attributes"`
+	RenameEvents *struct {
+		NameMap map[string]string `yaml:"name_map"`
+	} `yaml:"rename_events"`
+	RenameMetrics map[string]string `yaml:"rename_metrics"`
+}
+
+// Parse parses a schema file in the YAML format, with the 1.x.x file format.
+func Parse(data []byte) (*Schema, error) {
+	var f fileFormat
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid schema file: %w", err)
+	}
+	format, err := ParseVersion(f.FileFormat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file format: %w", err)
+	}
+	if format.Major != 1 {
+		return nil, fmt.Errorf("unsupported schema file format %s", format)
+	}
+	if f.SchemaURL == "" {
+		return nil, errors.New("schema_url must be specified")
+	}
+	family, _, err := SplitSchemaURL(f.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schema{family: family}
+	for name, vf := range f.Versions {
+		v, err := ParseVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		s.versions = append(s.versions, versionChanges{
+			version:    v,
+			all:        toChanges(vf.All),
+			resources:  toChanges(vf.Resources),
+			spans:      toChanges(vf.Spans),
+			spanEvents: toChanges(vf.SpanEvents),
+			metrics:    toChanges(vf.Metrics),
+			logs:       toChanges(vf.Logs),
+		})
+	}
+	sort.Slice(s.versions, func(i, j int) bool { return s.versions[i].version.Compare(s.versions[j].version) < 0 })
+	return s, nil
+}
+
+func toChanges(sf sectionFormat) []change {
+	changes := make([]change, 0, len(sf.Changes))
+	for _, cf := range sf.Changes {
+		if ra := cf.RenameAttributes; ra != nil {
+			applyTo := toSet(ra.ApplyToSpans)
+			if len(ra.ApplyToMetric) > 0 {
+				applyTo = toSet(ra.ApplyToMetric)
+			}
+			changes = append(changes, change{
+				attributes:    ra.AttributeMap,
+				applyTo:       applyTo,
+				applyToEvents: toSet(ra.ApplyToEvents),
+			})
+		}
+		if re := cf.RenameEvents; re != nil {
+			changes = append(changes, change{names: re.NameMap})
+		}
+		if cf.RenameMetrics != nil {
+			changes = append(changes, change{names: cf.RenameMetrics})
+		}
+	}
+	return changes
+}
+
+func toSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
+
+// Family returns the URL of the schema family, e.g. "https://opentelemetry.io/schemas".
+func (s *Schema) Family() string {
+	return s.family
+}
+
+// hasVersion returns whether the schema file defines the version.
+func (s *Schema) hasVersion(v Version) bool {
+	for _, vc := range s.versions {
+		if vc.version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// changesBetween returns the version changes to apply, in order, to translate from the version to the target.
+// Downgrading applies the reversed changes of the versions in decreasing order.
+func (s *Schema) changesBetween(from, to Version) []versionChanges {
+	var out []versionChanges
+	switch from.Compare(to) {
+	case -1:
+		for _, vc := range s.versions {
+			if vc.version.Compare(from) > 0 && vc.version.Compare(to) <= 0 {
+				out = append(out, vc)
+			}
+		}
+	case 1:
+		for i := len(s.versions) - 1; i >= 0; i-- {
+			vc := s.versions[i]
+			if vc.version.Compare(to) > 0 && vc.version.Compare(from) <= 0 {
+				out = append(out, vc.reversed())
+			}
+		}
+	}
+	return out
+}
+
+// reversed returns the changes reverting vc, in the reverse order.
+func (vc versionChanges) reversed() versionChanges {
+	return versionChanges{
+		version:    vc.version,
+		all:        reverseChanges(vc.all),
+		resources:  reverseChanges(vc.resources),
+		spans:      reverseChanges(vc.spans),
+		spanEvents: reverseChanges(vc.spanEvents),
+		metrics:    reverseChanges(vc.metrics),
+		logs:       reverseChanges(vc.logs),
+		reverse:    true,
+	}
+}
+
+// section returns the changes of the section and of all, in the order to apply them.
+func (vc versionChanges) section(changes []change) []change {
+	out := make([]change, 0, len(vc.all)+len(changes))
+	if vc.reverse {
+		return append(append(out, changes...), vc.all...)
+	}
+	return append(append(out, vc.all...), changes...)
+}
+
+func reverseChanges(changes []change) []change {
+	out := make([]change, len(changes))
+	for i, c := range changes {
+		out[len(changes)-1-i] = c.reversed()
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("1.12.3")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 12, Patch: 3}, v)
+	assert.Equal(t, "1.12.3", v.String())
+
+	for _, s := range []string{"", "1.2", "1.2.3.4", "1.a.3", "1.-2.3"} {
+		_, err = ParseVersion(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	assert.Equal(t, 0, Version{1, 2, 3}.Compare(Version{1, 2, 3}))
+	assert.Equal(t, -1, Version{1, 2, 3}.Compare(Version{1, 10, 0}))
+	assert.Equal(t, 1, Version{2, 0, 0}.Compare(Version{1, 10, 10}))
+	assert.Equal(t, -1, Version{1, 2, 3}.Compare(Version{1, 2, 4}))
+}
+
+func TestSplitSchemaURL(t *testing.T) {
+	family, v, err := SplitSchemaURL("https://opentelemetry.io/schemas/1.7.0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas", family)
+	assert.Equal(t, Version{1, 7, 0}, v)
+
+	_, _, err = SplitSchemaURL("1.7.0")
+	assert.Error(t, err)
+	_, _, err = SplitSchemaURL("https://opentelemetry.io/schemas/latest")
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+	s, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas", s.Family())
+	require.Len(t, s.versions, 3)
+	assert.Equal(t, Version{1, 0, 0}, s.versions[0].version)
+	assert.Equal(t, Version{1, 2, 0}, s.versions[2].version)
+	assert.Len(t, s.versions[2].metrics, 2)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, data := range []string{
+		"file_format: [",
+		"schema_url: https://opentelemetry.io/schemas/1.0.0",
+		"file_format: 2.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0",
+		"file_format: 1.0.0",
+		"file_format: 1.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0\nversions:\n  latest:\n",
+	} {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pschema // import "go.opentelemetry.io/collector/pdata/pschema"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Translator translates the pdata to a target version of a schema. The data of other schema families,
+// without schema URL, or with a version not defined by the schema file, is left unchanged.
+type Translator struct {
+	schema    *Schema
+	target    Version
+	targetURL string
+}
+
+// NewTranslator returns a Translator to the target version, which must be defined by the schema.
+func NewTranslator(schema *Schema, target string) (*Translator, error) {
+	v, err := ParseVersion(target)
+	if err != nil {
+		return nil, err
+	}
+	if !schema.hasVersion(v) {
+		return nil, fmt.Errorf("schema %s has no version %s", schema.family, v)
+	}
+	return &Translator{
+		schema:    schema,
+		target:    v,
+		targetURL: schema.family + "/" + v.String(),
+	}, nil
+}
+
+// TargetSchemaURL returns the schema URL of the translated data.
+func (t *Translator) TargetSchemaURL() string {
+	return t.targetURL
+}
+
+// changesFor returns the changes translating the data of the schema URL, and false if it cannot be translated.
+func (t *Translator) changesFor(schemaURL string) ([]versionChanges, bool) {
+	if schemaURL == "" {
+		return nil, false
+	}
+	family, v, err := SplitSchemaURL(schemaURL)
+	if err != nil || family != t.schema.family || !t.schema.hasVersion(v) {
+		return nil, false
+	}
+	return t.schema.changesBetween(v, t.target), true
+}
+
+// translateResource translates the resource and returns the changes for its scopes without schema URL.
+func (t *Translator) translateResource(res pcommon.Resource, schemaURL string, setSchemaURL func(string)) []versionChanges {
+	changes, ok := t.changesFor(schemaURL)
+	if !ok {
+		return nil
+	}
+	for _, vc := range changes {
+		for _, c := range vc.section(vc.resources) {
+			renameAttributes(res.Attributes(), c.attributes)
+		}
+	}
+	setSchemaURL(t.targetURL)
+	return changes
+}
+
+// scopeChanges returns the changes for a scope, and translates its schema URL.
+func (t *Translator) scopeChanges(resourceChanges []versionChanges, schemaURL string, setSchemaURL func(string)) []versionChanges {
+	if schemaURL == "" {
+		return resourceChanges
+	}
+	changes, ok := t.changesFor(schemaURL)
+	if !ok {
+		return nil
+	}
+	setSchemaURL(t.targetURL)
+	return changes
+}
+
+// TranslateTraces translates the traces in place.
+func (t *Translator) TranslateTraces(td ptrace.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resourceChanges := t.translateResource(rs.Resource(), rs.SchemaUrl(), rs.SetSchemaUrl)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			changes := t.scopeChanges(resourceChanges, ss.SchemaUrl(), ss.SetSchemaUrl)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				translateSpan(spans.At(k), changes)
+			}
+		}
+	}
+}
+
+func translateSpan(span ptrace.Span, changes []versionChanges) {
+	for _, vc := range changes {
+		for _, c := range vc.section(vc.spans) {
+			if c.applyTo == nil || c.applyTo[span.Name()] {
+				renameAttributes(span.Attributes(), c.attributes)
+			}
+		}
+		events := span.Events()
+		for _, c := range vc.section(vc.spanEvents) {
+			if c.applyTo != nil && !c.applyTo[span.Name()] {
+				continue
+			}
+			for i := 0; i < events.Len(); i++ {
+				event := events.At(i)
+				if newName, ok := c.names[event.Name()]; ok {
+					event.SetName(newName)
+				}
+				if c.applyToEvents == nil || c.applyToEvents[event.Name()] {
+					renameAttributes(event.Attributes(), c.attributes)
+				}
+			}
+		}
+	}
+}
+
+// TranslateMetrics translates the metrics in place.
+func (t *Translator) TranslateMetrics(md pmetric.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceChanges := t.translateResource(rm.Resource(), rm.SchemaUrl(), rm.SetSchemaUrl)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			changes := t.scopeChanges(resourceChanges, sm.SchemaUrl(), sm.SetSchemaUrl)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				translateMetric(metrics.At(k), changes)
+			}
+		}
+	}
+}
+
+func translateMetric(metric pmetric.Metric, changes []versionChanges) {
+	for _, vc := range changes {
+		for _, c := range vc.section(vc.metrics) {
+			if newName, ok := c.names[metric.Name()]; ok {
+				metric.SetName(newName)
+			}
+			if c.attributes != nil && (c.applyTo == nil || c.applyTo[metric.Name()]) {
+				rangeDataPointAttributes(metric, func(attrs pcommon.Map) {
+					renameAttributes(attrs, c.attributes)
+				})
+			}
+		}
+	}
+}
+
+func rangeDataPointAttributes(metric pmetric.Metric, f func(pcommon.Map)) {
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).Attributes())
+		}
+	}
+}
+
+// TranslateLogs translates the logs in place.
+func (t *Translator) TranslateLogs(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceChanges := t.translateResource(rl.Resource(), rl.SchemaUrl(), rl.SetSchemaUrl)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			changes := t.scopeChanges(resourceChanges, sl.SchemaUrl(), sl.SetSchemaUrl)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				for _, vc := range changes {
+					for _, c := range vc.section(vc.logs) {
+						renameAttributes(lrs.At(k).Attributes(), c.attributes)
+					}
+				}
+			}
+		}
+	}
+}
+
+// renameAttributes renames the attributes from the old to the new names of renames. The attributes whose
+// new name is already set are left unchanged.
+func renameAttributes(attrs pcommon.Map, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+	// Copy the values before renaming any attribute, so that swapped names are handled.
+	values := map[string]pcommon.Value{}
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		newName, ok := renames[k]
+		if !ok {
+			return true
+		}
+		if _, exists := attrs.Get(newName); exists {
+			if _, renamed := renames[newName]; !renamed {
+				return true
+			}
+		}
+		value := pcommon.NewValueEmpty()
+		v.CopyTo(value)
+		values[k] = value
+		return true
+	})
+	for oldName := range values {
+		attrs.Remove(oldName)
+	}
+	for oldName, value := range values {
+		attrs.Upsert(renames[oldName], value)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestTranslator(t *testing.T, target string) *Translator {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+	s, err := Parse(data)
+	require.NoError(t, err)
+	tr, err := NewTranslator(s, target)
+	require.NoError(t, err)
+	return tr
+}
+
+func TestNewTranslatorUnknownVersion(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+	s, err := Parse(data)
+	require.NoError(t, err)
+	_, err = NewTranslator(s, "1.3.0")
+	assert.Error(t, err)
+	_, err = NewTranslator(s, "latest")
+	assert.Error(t, err)
+}
+
+func TestTranslateTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+	rs.Resource().Attributes().InsertString("k8s.cluster.name", "prod")
+	rs.Resource().Attributes().InsertString("telemetry.auto.version", "1.0")
+	ss := rs.ScopeSpans().AppendEmpty()
+	get := ss.Spans().AppendEmpty()
+	get.SetName("GET")
+	get.Attributes().InsertInt("http.status", 200)
+	get.Attributes().InsertString("k8s.cluster.name", "prod")
+	event := get.Events().AppendEmpty()
+	event.SetName("exception.thrown")
+	post := ss.Spans().AppendEmpty()
+	post.SetName("POST")
+	post.Attributes().InsertInt("http.status", 500)
+
+	tr := newTestTranslator(t, "1.2.0")
+	tr.TranslateTraces(td)
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.2.0", rs.SchemaUrl())
+	assert.Equal(t, "", ss.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes.cluster.name": "prod",
+		"telemetry.auto_version":  "1.0",
+	}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"http.status_code":        int64(200),
+		"kubernetes.cluster.name": "prod",
+	}, get.Attributes().AsRaw())
+	assert.Equal(t, "exception", event.Name())
+	assert.Equal(t, map[string]interface{}{"http.status": int64(500)}, post.Attributes().AsRaw())
+
+	// Translating back to 1.0.0 reverts the changes.
+	newTestTranslator(t, "1.0.0").TranslateTraces(td)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.0.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{
+		"k8s.cluster.name":       "prod",
+		"telemetry.auto.version": "1.0",
+	}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"http.status":      int64(200),
+		"k8s.cluster.name": "prod",
+	}, get.Attributes().AsRaw())
+	assert.Equal(t, "exception.thrown", event.Name())
+}
+
+func TestTranslateTracesUnknownSchema(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, schemaURL := range []string{"", "https://example.com/schemas/1.0.0", "https://opentelemetry.io/schemas/0.9.0"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl(schemaURL)
+		rs.Resource().Attributes().InsertString("k8s.cluster.name", "prod")
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("k8s.cluster.name", "prod")
+	}
+	expected := td.Clone()
+
+	newTestTranslator(t, "1.2.0").TranslateTraces(td)
+	assert.Equal(t, expected, td)
+}
+
+func TestTranslateMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("https://example.com/schemas/1.0.0")
+	rm.Resource().Attributes().InsertString("k8s.cluster.name", "prod")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	// The schema URL of the scope overrides the one of the resource.
+	sm.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("container.cpu.usage.total")
+	m.SetDataType(pmetric.MetricDataTypeSum)
+	m.Sum().DataPoints().AppendEmpty().Attributes().InsertString("state", "idle")
+	other := sm.Metrics().AppendEmpty()
+	other.SetName("memory.usage")
+	other.SetDataType(pmetric.MetricDataTypeGauge)
+	other.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("state", "used")
+
+	newTestTranslator(t, "1.2.0").TranslateMetrics(md)
+
+	assert.Equal(t, "https://example.com/schemas/1.0.0", rm.SchemaUrl())
+	assert.Equal(t, map[string]interface{}{"k8s.cluster.name": "prod"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.2.0", sm.SchemaUrl())
+	assert.Equal(t, "cpu.usage.total", m.Name())
+	assert.Equal(t, map[string]interface{}{"cpu.state": "idle"}, m.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "memory.usage", other.Name())
+	assert.Equal(t, map[string]interface{}{"state": "used"}, other.Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTranslateLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().InsertString("process.stacktrace", "main()")
+	lr.Attributes().InsertString("kubernetes.cluster.name", "prod")
+
+	newTestTranslator(t, "1.2.0").TranslateLogs(ld)
+	assert.Equal(t, map[string]interface{}{
+		"exception.stacktrace":    "main()",
+		"kubernetes.cluster.name": "prod",
+	}, lr.Attributes().AsRaw())
+
+	newTestTranslator(t, "1.0.0").TranslateLogs(ld)
+	assert.Equal(t, map[string]interface{}{
+		"process.stacktrace": "main()",
+		"k8s.cluster.name":   "prod",
+	}, lr.Attributes().AsRaw())
+}
+
+func TestRenameAttributes(t *testing.T) {
+	attrs := pcommon.NewMapFromRaw(map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4})
+	renameAttributes(attrs, map[string]string{"a": "b", "b": "a", "c": "d"})
+	// Swapped names are renamed, renaming c to the existing d is skipped.
+	assert.Equal(t, map[string]interface{}{"a": int64(2), "b": int64(1), "c": int64(3), "d": int64(4)}, attrs.AsRaw())
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptest // import "go.opentelemetry.io/collector/pdata/ptest"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// GenerateLogs returns logs with the configured number of resources, each with the configured number of
+// log records with a random string body, every tenth record having the error severity.
+func (g *Generator) GenerateLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.EnsureCapacity(g.set.ResourceCount)
+	for i := 0; i < g.set.ResourceCount; i++ {
+		rl := rls.AppendEmpty()
+		g.fillAttributes(rl.Resource().Attributes(), "resource")
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(scopeName)
+		lrs := sl.LogRecords()
+		lrs.EnsureCapacity(g.set.ItemCount)
+		for j := 0; j < g.set.ItemCount; j++ {
+			g.fillLogRecord(lrs.AppendEmpty())
+		}
+	}
+	return ld
+}
+
+func (g *Generator) fillLogRecord(lr plog.LogRecord) {
+	ts := g.nextTimestamp()
+	lr.SetTimestamp(ts)
+	lr.SetObservedTimestamp(ts)
+	lr.SetTraceID(g.traceID())
+	lr.SetSpanID(g.spanID())
+	if g.rand.Intn(10) == 0 {
+		lr.SetSeverityNumber(plog.SeverityNumberERROR)
+		lr.SetSeverityText("ERROR")
+	} else {
+		lr.SetSeverityNumber(plog.SeverityNumberINFO)
+		lr.SetSeverityText("INFO")
+	}
+	lr.Body().SetStringVal(g.randomString())
+	g.fillAttributes(lr.Attributes(), "log")
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateLogs(t *testing.T) {
+	set := NewDefaultSettings()
+	set.ResourceCount = 2
+	set.ItemCount = 3
+	set.StringSize = 100
+	g, err := NewGenerator(set)
+	require.NoError(t, err)
+
+	ld := g.GenerateLogs()
+	require.Equal(t, 2, ld.ResourceLogs().Len())
+	assert.Equal(t, 6, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(2)
+	assert.Len(t, lr.Body().StringVal(), 100)
+	assert.NotEmpty(t, lr.SeverityText())
+	assert.NotZero(t, lr.Timestamp())
+	assert.Equal(t, 5, lr.Attributes().Len())
+}
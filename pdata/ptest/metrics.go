@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptest // import "go.opentelemetry.io/collector/pdata/ptest"
+
+import (
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// metricDataTypes are the data types of the generated metrics, in turn.
+var metricDataTypes = []pmetric.MetricDataType{
+	pmetric.MetricDataTypeGauge,
+	pmetric.MetricDataTypeSum,
+	pmetric.MetricDataTypeHistogram,
+	pmetric.MetricDataTypeExponentialHistogram,
+	pmetric.MetricDataTypeSummary,
+}
+
+// GenerateMetrics returns metrics with the configured number of resources, each with the configured number
+// of metrics with the configured number of data points. The metrics are in turn gauges, cumulative monotonic
+// sums, cumulative histograms, cumulative exponential histograms and summaries.
+func (g *Generator) GenerateMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rms := md.ResourceMetrics()
+	rms.EnsureCapacity(g.set.ResourceCount)
+	for i := 0; i < g.set.ResourceCount; i++ {
+		rm := rms.AppendEmpty()
+		g.fillAttributes(rm.Resource().Attributes(), "resource")
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		ms := sm.Metrics()
+		ms.EnsureCapacity(g.set.ItemCount)
+		for j := 0; j < g.set.ItemCount; j++ {
+			g.fillMetric(ms.AppendEmpty(), j)
+		}
+	}
+	return md
+}
+
+func (g *Generator) fillMetric(m pmetric.Metric, idx int) {
+	dt := metricDataTypes[idx%len(metricDataTypes)]
+	m.SetName("metric-" + strconv.Itoa(idx))
+	m.SetUnit("1")
+	m.SetDataType(dt)
+	start := pcommon.NewTimestampFromTime(startTime)
+	switch dt {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < g.set.DataPointCount; i++ {
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(g.nextTimestamp())
+			dp.SetDoubleVal(g.rand.Float64() * 100)
+			g.fillAttributes(dp.Attributes(), "dp")
+		}
+	case pmetric.MetricDataTypeSum:
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		m.Sum().SetIsMonotonic(true)
+		dps := m.Sum().DataPoints()
+		for i := 0; i < g.set.DataPointCount; i++ {
+			dp := dps.AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(g.nextTimestamp())
+			dp.SetIntVal(g.rand.Int63n(1000))
+			g.fillAttributes(dp.Attributes(), "dp")
+		}
+	case pmetric.MetricDataTypeHistogram:
+		m.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < g.set.DataPointCount; i++ {
+			dp := dps.AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(g.nextTimestamp())
+			counts := g.bucketCounts(4)
+			dp.SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+			dp.SetExplicitBounds(pcommon.NewImmutableFloat64Slice([]float64{1, 10, 100}))
+			dp.SetCount(sum(counts))
+			dp.SetSum(float64(dp.Count()) * 10)
+			g.fillAttributes(dp.Attributes(), "dp")
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		m.ExponentialHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < g.set.DataPointCount; i++ {
+			dp := dps.AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(g.nextTimestamp())
+			counts := g.bucketCounts(4)
+			dp.SetScale(1)
+			dp.Positive().SetBucketCounts(pcommon.NewImmutableUInt64Slice(counts))
+			dp.SetCount(sum(counts))
+			dp.SetSum(float64(dp.Count()) * 10)
+			g.fillAttributes(dp.Attributes(), "dp")
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < g.set.DataPointCount; i++ {
+			dp := dps.AppendEmpty()
+			dp.SetStartTimestamp(start)
+			dp.SetTimestamp(g.nextTimestamp())
+			dp.SetCount(uint64(g.rand.Int63n(1000)))
+			dp.SetSum(float64(dp.Count()) * 10)
+			median := dp.QuantileValues().AppendEmpty()
+			median.SetQuantile(0.5)
+			median.SetValue(10)
+			g.fillAttributes(dp.Attributes(), "dp")
+		}
+	}
+}
+
+// bucketCounts returns n random bucket counts.
+func (g *Generator) bucketCounts(n int) []uint64 {
+	counts := make([]uint64, n)
+	for i := range counts {
+		counts[i] = uint64(g.rand.Int63n(100))
+	}
+	return counts
+}
+
+func sum(counts []uint64) uint64 {
+	var ret uint64
+	for _, c := range counts {
+		ret += c
+	}
+	return ret
+}
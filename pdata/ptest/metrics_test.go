@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestGenerateMetrics(t *testing.T) {
+	set := NewDefaultSettings()
+	set.ItemCount = 5
+	set.DataPointCount = 2
+	g, err := NewGenerator(set)
+	require.NoError(t, err)
+
+	md := g.GenerateMetrics()
+	assert.Equal(t, 5, md.MetricCount())
+	assert.Equal(t, 10, md.DataPointCount())
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		assert.Equal(t, metricDataTypes[i], ms.At(i).DataType())
+	}
+
+	hdp := ms.At(2).Histogram().DataPoints().At(0)
+	assert.Equal(t, len(hdp.ExplicitBounds().AsRaw())+1, len(hdp.BucketCounts().AsRaw()))
+	assert.Equal(t, sum(hdp.BucketCounts().AsRaw()), hdp.Count())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, ms.At(3).ExponentialHistogram().AggregationTemporality())
+	assert.Equal(t, 5, ms.At(4).Summary().DataPoints().At(1).Attributes().Len())
+}
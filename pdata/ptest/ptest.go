@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ptest generates traces, metrics and logs for the tests and benchmarks of the components,
+// with a configurable number of resources, items and attributes, attribute cardinality and string
+// sizes. The data is generated from a seeded source of randomness, the same settings generating
+// the same data.
+package ptest // import "go.opentelemetry.io/collector/pdata/ptest"
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// scopeName is the name of the instrumentation scope of the generated data.
+const scopeName = "go.opentelemetry.io/collector/pdata/ptest"
+
+// startTime is the timestamp of the first generated item, the following items being one
+// millisecond apart, so that the generated data does not depend on the current time.
+var startTime = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Settings defines the shape of the data generated by a Generator.
+type Settings struct {
+	// Seed seeds the randomness of the generated IDs and values.
+	Seed int64
+
+	// ResourceCount is the number of resources of the generated data, each with a single scope.
+	ResourceCount int
+
+	// ItemCount is the number of spans, metrics or log records per resource.
+	ItemCount int
+
+	// DataPointCount is the number of data points per metric.
+	DataPointCount int
+
+	// AttributeCount is the number of attributes of the resources, spans, data points and log records.
+	AttributeCount int
+
+	// AttributeCardinality is the number of distinct values of each attribute, or 0 for all the values
+	// to be distinct.
+	AttributeCardinality int
+
+	// StringSize is the minimum size of the string attribute values, and the size of the span names
+	// and log bodies.
+	StringSize int
+}
+
+// NewDefaultSettings returns the Settings generating one resource with 10 items of 5 attributes each,
+// drawing the attribute values among 10 values of 16 characters.
+func NewDefaultSettings() Settings {
+	return Settings{
+		Seed:                 1,
+		ResourceCount:        1,
+		ItemCount:            10,
+		DataPointCount:       1,
+		AttributeCount:       5,
+		AttributeCardinality: 10,
+		StringSize:           16,
+	}
+}
+
+// Validate checks the Settings are valid.
+func (set Settings) Validate() error {
+	if set.ResourceCount < 0 || set.ItemCount < 0 || set.DataPointCount < 0 || set.AttributeCount < 0 {
+		return errors.New("the resource, item, data point and attribute counts must not be negative")
+	}
+	if set.AttributeCardinality < 0 {
+		return errors.New("the attribute cardinality must not be negative")
+	}
+	if set.StringSize < 0 {
+		return errors.New("the string size must not be negative")
+	}
+	return nil
+}
+
+// Generator generates traces, metrics and logs with the shape defined by its Settings. The successive calls
+// generate different data, the IDs, values and timestamps continuing from the previous calls.
+// A Generator is not safe for concurrent use.
+type Generator struct {
+	set  Settings
+	rand *rand.Rand
+	// next is the index of the next generated item, setting its timestamp.
+	next int
+	// nextValue is the index of the next attribute value when the values are all distinct.
+	nextValue int
+}
+
+// NewGenerator returns a Generator of data with the given Settings.
+func NewGenerator(set Settings) (*Generator, error) {
+	if err := set.Validate(); err != nil {
+		return nil, err
+	}
+	return &Generator{set: set, rand: rand.New(rand.NewSource(set.Seed))}, nil // #nosec G404 -- not used for security
+}
+
+// nextTimestamp returns the timestamp of the next generated item.
+func (g *Generator) nextTimestamp() pcommon.Timestamp {
+	ts := pcommon.NewTimestampFromTime(startTime.Add(time.Duration(g.next) * time.Millisecond))
+	g.next++
+	return ts
+}
+
+// fillAttributes inserts the configured number of attributes, prefixed with the given prefix, in dest.
+func (g *Generator) fillAttributes(dest pcommon.Map, prefix string) {
+	dest.EnsureCapacity(g.set.AttributeCount)
+	for i := 0; i < g.set.AttributeCount; i++ {
+		var idx int
+		if g.set.AttributeCardinality > 0 {
+			idx = g.rand.Intn(g.set.AttributeCardinality)
+		} else {
+			idx = g.nextValue
+			g.nextValue++
+		}
+		dest.InsertString(prefix+"-attr-"+strconv.Itoa(i), g.attributeValue(idx))
+	}
+}
+
+// attributeValue returns the string attribute value of the given index, padded to the string size.
+func (g *Generator) attributeValue(idx int) string {
+	v := "value-" + strconv.Itoa(idx)
+	if len(v) >= g.set.StringSize {
+		return v
+	}
+	b := make([]byte, g.set.StringSize)
+	copy(b, v)
+	for i := len(v); i < len(b); i++ {
+		b[i] = '-'
+	}
+	return string(b)
+}
+
+const letters = "abcdefghijklmnopqrstuvwxyz"
+
+// randomString returns a random string of lowercase letters of the string size.
+func (g *Generator) randomString() string {
+	b := make([]byte, g.set.StringSize)
+	for i := range b {
+		b[i] = letters[g.rand.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func (g *Generator) traceID() pcommon.TraceID {
+	var id [16]byte
+	_, _ = g.rand.Read(id[:])
+	return pcommon.NewTraceID(id)
+}
+
+func (g *Generator) spanID() pcommon.SpanID {
+	var id [8]byte
+	_, _ = g.rand.Read(id[:])
+	return pcommon.NewSpanID(id)
+}
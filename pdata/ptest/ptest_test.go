@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSettingsValidate(t *testing.T) {
+	assert.NoError(t, NewDefaultSettings().Validate())
+
+	set := NewDefaultSettings()
+	set.ItemCount = -1
+	assert.EqualError(t, set.Validate(), "the resource, item, data point and attribute counts must not be negative")
+
+	set = NewDefaultSettings()
+	set.AttributeCardinality = -1
+	assert.EqualError(t, set.Validate(), "the attribute cardinality must not be negative")
+
+	set = NewDefaultSettings()
+	set.StringSize = -1
+	assert.EqualError(t, set.Validate(), "the string size must not be negative")
+	_, err := NewGenerator(set)
+	assert.Error(t, err)
+}
+
+func TestGeneratorSeed(t *testing.T) {
+	newGenerator := func(seed int64) *Generator {
+		set := NewDefaultSettings()
+		set.Seed = seed
+		g, err := NewGenerator(set)
+		require.NoError(t, err)
+		return g
+	}
+
+	g1, g2 := newGenerator(1), newGenerator(1)
+	assert.Equal(t, g1.GenerateTraces(), g2.GenerateTraces())
+	assert.Equal(t, g1.GenerateMetrics(), g2.GenerateMetrics())
+	assert.Equal(t, g1.GenerateLogs(), g2.GenerateLogs())
+
+	// The successive calls and the other seeds generate other data.
+	assert.NotEqual(t, g1.GenerateTraces(), newGenerator(1).GenerateTraces())
+	assert.NotEqual(t, newGenerator(1).GenerateLogs(), newGenerator(2).GenerateLogs())
+}
+
+func TestAttributeCardinality(t *testing.T) {
+	values := func(cardinality int) map[string]struct{} {
+		set := NewDefaultSettings()
+		set.ItemCount = 100
+		set.AttributeCount = 1
+		set.AttributeCardinality = cardinality
+		set.StringSize = 20
+		g, err := NewGenerator(set)
+		require.NoError(t, err)
+
+		ret := map[string]struct{}{}
+		spans := g.GenerateTraces().ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < spans.Len(); i++ {
+			spans.At(i).Attributes().Range(func(k string, v pcommon.Value) bool {
+				assert.Equal(t, "span-attr-0", k)
+				assert.Len(t, v.StringVal(), 20)
+				ret[v.StringVal()] = struct{}{}
+				return true
+			})
+		}
+		return ret
+	}
+
+	assert.Len(t, values(3), 3)
+	assert.Len(t, values(0), 100)
+}
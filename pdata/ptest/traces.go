@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptest // import "go.opentelemetry.io/collector/pdata/ptest"
+
+import (
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// GenerateTraces returns traces with the configured number of resources, each with the configured number
+// of server spans of a new trace, every tenth span having an error status.
+func (g *Generator) GenerateTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rss := td.ResourceSpans()
+	rss.EnsureCapacity(g.set.ResourceCount)
+	for i := 0; i < g.set.ResourceCount; i++ {
+		rs := rss.AppendEmpty()
+		g.fillAttributes(rs.Resource().Attributes(), "resource")
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(scopeName)
+		spans := ss.Spans()
+		spans.EnsureCapacity(g.set.ItemCount)
+		for j := 0; j < g.set.ItemCount; j++ {
+			g.fillSpan(spans.AppendEmpty())
+		}
+	}
+	return td
+}
+
+func (g *Generator) fillSpan(span ptrace.Span) {
+	span.SetTraceID(g.traceID())
+	span.SetSpanID(g.spanID())
+	span.SetName(g.randomString())
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(g.nextTimestamp())
+	span.SetEndTimestamp(g.nextTimestamp())
+	g.fillAttributes(span.Attributes(), "span")
+	if g.rand.Intn(10) == 0 {
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("error")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTraces(t *testing.T) {
+	set := NewDefaultSettings()
+	set.ResourceCount = 2
+	set.ItemCount = 3
+	g, err := NewGenerator(set)
+	require.NoError(t, err)
+
+	td := g.GenerateTraces()
+	require.Equal(t, 2, td.ResourceSpans().Len())
+	assert.Equal(t, 6, td.SpanCount())
+	rs := td.ResourceSpans().At(0)
+	assert.Equal(t, 5, rs.Resource().Attributes().Len())
+	assert.Equal(t, scopeName, rs.ScopeSpans().At(0).Scope().Name())
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.False(t, span.TraceID().IsEmpty())
+	assert.False(t, span.SpanID().IsEmpty())
+	assert.Len(t, span.Name(), 16)
+	assert.Less(t, span.StartTimestamp(), span.EndTimestamp())
+	assert.Equal(t, 5, span.Attributes().Len())
+}
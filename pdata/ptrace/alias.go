@@ -25,6 +25,13 @@ type Traces = internal.Traces
 // NewTraces creates a new Traces struct.
 var NewTraces = internal.NewTraces
 
+// TracesPool is an opt-in pool of Traces, reusing the top-level messages and the backing arrays
+// of their resource slices. It is safe for concurrent use.
+type TracesPool = internal.TracesPool
+
+// NewTracesPool returns a new empty TracesPool.
+var NewTracesPool = internal.NewTracesPool
+
 // TraceState is a string representing the tracestate in w3c-trace-context format: https://www.w3.org/TR/trace-context/#tracestate-header
 type TraceState = internal.TraceState
 
@@ -33,6 +40,16 @@ const (
 	TraceStateEmpty = internal.TraceStateEmpty
 )
 
+// SpanFlags is a bit field of a span or a link. The 8 least significant bits are the trace flags as
+// defined in W3C Trace Context specification, bits 8 and 9 represent whether the parent span,
+// for a span, or the linked span, for a link, is remote. The remaining bits are reserved.
+type SpanFlags = internal.SpanFlags
+
+const (
+	// SpanFlagsNone is the default SpanFlags.
+	SpanFlagsNone = internal.SpanFlagsNone
+)
+
 // SpanKind is the type of span. Can be used to specify additional relationships between spans
 // in addition to a parent/child relationship.
 type SpanKind = internal.SpanKind
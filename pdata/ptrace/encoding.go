@@ -14,6 +14,12 @@
 
 package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 
+import (
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+)
+
 // Marshaler marshals pdata.Traces into bytes.
 type Marshaler interface {
 	// MarshalTraces the given pdata.Traces into bytes.
@@ -34,3 +40,37 @@ type Sizer interface {
 	// TracesSize returns the size in bytes of a marshaled Traces.
 	TracesSize(td Traces) int
 }
+
+// MarshalerOption configures a Marshaler returned by NewProtoMarshaler or NewJSONMarshaler.
+type MarshalerOption func(*marshalerSettings)
+
+type marshalerSettings struct {
+	deterministic bool
+}
+
+// WithDeterministicMarshaling makes the Marshaler produce identical bytes for identical payloads,
+// independently of the order in which the attributes were inserted. The attributes of a copy of
+// the payload are sorted by key before marshaling, the marshaled Traces is not modified.
+func WithDeterministicMarshaling() MarshalerOption {
+	return func(s *marshalerSettings) {
+		s.deterministic = true
+	}
+}
+
+func newMarshalerSettings(opts []MarshalerOption) marshalerSettings {
+	var s marshalerSettings
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// toProto returns the proto representation of td, with sorted attributes if deterministic is set.
+func (s marshalerSettings) toProto(td Traces) otlptrace.TracesData {
+	if !s.deterministic {
+		return internal.TracesToProto(td)
+	}
+	pb := internal.TracesToProto(td.Clone())
+	otlp.SortTracesAttributes(pb.ResourceSpans)
+	return pb
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestDeterministicMarshaling(t *testing.T) {
+	newTraces := func(keys ...string) Traces {
+		td := NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		event := span.Events().AppendEmpty()
+		link := span.Links().AppendEmpty()
+		nested := pcommon.NewValueMap()
+		for _, k := range keys {
+			nested.MapVal().InsertString(k, "value")
+		}
+		span.Attributes().Insert("nested", nested)
+		for _, k := range keys {
+			rs.Resource().Attributes().InsertString(k, "value")
+			span.Attributes().InsertString(k, "value")
+			event.Attributes().InsertString(k, "value")
+			link.Attributes().InsertString(k, "value")
+		}
+		return td
+	}
+
+	for _, marshaler := range []Marshaler{
+		NewProtoMarshaler(WithDeterministicMarshaling()),
+		NewJSONMarshaler(WithDeterministicMarshaling()),
+	} {
+		td1 := newTraces("a", "b", "c")
+		td2 := newTraces("c", "a", "b")
+		buf1, err := marshaler.MarshalTraces(td1)
+		require.NoError(t, err)
+		buf2, err := marshaler.MarshalTraces(td2)
+		require.NoError(t, err)
+		assert.Equal(t, buf1, buf2)
+
+		// The marshaled Traces must not be modified.
+		var keys []string
+		td2.ResourceSpans().At(0).Resource().Attributes().Range(func(k string, _ pcommon.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.Equal(t, []string{"c", "a", "b"}, keys)
+	}
+}
@@ -28,21 +28,22 @@ import (
 )
 
 // NewJSONMarshaler returns a model.Marshaler. Marshals to OTLP json bytes.
-func NewJSONMarshaler() Marshaler {
-	return newJSONMarshaler()
+func NewJSONMarshaler(opts ...MarshalerOption) Marshaler {
+	return newJSONMarshaler(opts...)
 }
 
 type jsonMarshaler struct {
 	delegate jsonpb.Marshaler
+	settings marshalerSettings
 }
 
-func newJSONMarshaler() *jsonMarshaler {
-	return &jsonMarshaler{delegate: jsonpb.Marshaler{}}
+func newJSONMarshaler(opts ...MarshalerOption) *jsonMarshaler {
+	return &jsonMarshaler{delegate: jsonpb.Marshaler{}, settings: newMarshalerSettings(opts)}
 }
 
 func (e *jsonMarshaler) MarshalTraces(td Traces) ([]byte, error) {
 	buf := bytes.Buffer{}
-	pb := internal.TracesToProto(td)
+	pb := e.settings.toProto(td)
 	err := e.delegate.Marshal(&buf, &pb)
 	return buf.Bytes(), err
 }
@@ -196,6 +197,8 @@ func readSpan(iter *jsoniter.Iterator) *otlptrace.Span {
 			})
 		case "droppedLinksCount", "dropped_links_count":
 			sp.DroppedLinksCount = iter.ReadUint32()
+		case "flags":
+			sp.Flags = iter.ReadUint32()
 		case "status":
 			iter.ReadObjectCB(func(iter *jsoniter.Iterator, f string) bool {
 				switch f {
@@ -238,6 +241,8 @@ func readSpanLink(iter *jsoniter.Iterator) *otlptrace.Span_Link {
 			})
 		case "droppedAttributesCount", "dropped_attributes_count":
 			link.DroppedAttributesCount = iter.ReadUint32()
+		case "flags":
+			link.Flags = iter.ReadUint32()
 		default:
 			iter.ReportError("readSpanLink", fmt.Sprintf("unknown field:%v", f))
 		}
@@ -88,6 +88,7 @@ var tracesOTLPFull = func() Traces {
 	sp.SetEndTimestamp(internal.NewTimestampFromTime(time.Now()))
 	sp.SetParentSpanID(spanID)
 	sp.SetTraceState("state")
+	sp.SetFlags(SpanFlagsNone.WithIsSampled(true).WithIsRemote(true))
 	sp.Status().SetCode(internal.StatusCodeOk)
 	sp.Status().SetMessage("message")
 	// Add attributes.
@@ -120,6 +121,7 @@ var tracesOTLPFull = func() Traces {
 	link.SetTraceID(traceID)
 	link.SetSpanID(spanID)
 	link.SetDroppedAttributesCount(1)
+	link.SetFlags(SpanFlagsNone.WithIsRemote(false))
 	link.Attributes().UpsertString("string", "value")
 	link.Attributes().UpsertBool("bool", true)
 	link.Attributes().UpsertInt("int", 1)
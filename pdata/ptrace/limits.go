@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// SpanLimits are the limits of a span, equivalent to the span limits of the OpenTelemetry SDKs,
+// e.g. for a gateway to enforce them on the spans of the applications without SDK limits.
+type SpanLimits struct {
+	// Attributes are the limits of the attributes of the span. Their MaxValueLength also limits the
+	// values of the attributes of the events and links.
+	Attributes pcommon.AttributeLimits
+	// MaxEvents is the maximum number of events, the events beyond it being removed. 0 means no limit.
+	MaxEvents int
+	// MaxLinks is the maximum number of links, the links beyond it being removed. 0 means no limit.
+	MaxLinks int
+	// MaxAttributesPerEvent is the maximum number of attributes of an event. 0 means no limit.
+	MaxAttributesPerEvent int
+	// MaxAttributesPerLink is the maximum number of attributes of a link. 0 means no limit.
+	MaxAttributesPerLink int
+}
+
+// SpanLimitsResult counts the data removed or truncated by SpanLimits.Apply.
+type SpanLimitsResult struct {
+	// DroppedAttributes is the number of attributes removed from the span, its events and its links.
+	DroppedAttributes int
+	// DroppedEvents is the number of events removed from the span.
+	DroppedEvents int
+	// DroppedLinks is the number of links removed from the span.
+	DroppedLinks int
+	// TruncatedValues is the number of truncated attribute values of the span, its events and its links.
+	TruncatedValues int
+}
+
+// Apply enforces the limits on the span, keeping the first attributes, events and links. The numbers of
+// removed attributes, events and links are added to the dropped counts of the span, events and links, as
+// recorded by the SDKs. Returns the numbers of removed and truncated data.
+func (l SpanLimits) Apply(span Span) SpanLimitsResult {
+	var res SpanLimitsResult
+	dropped, truncated := l.Attributes.Apply(span.Attributes())
+	span.SetDroppedAttributesCount(span.DroppedAttributesCount() + uint32(dropped))
+	res.DroppedAttributes += dropped
+	res.TruncatedValues += truncated
+
+	events := span.Events()
+	if l.MaxEvents > 0 && events.Len() > l.MaxEvents {
+		res.DroppedEvents = events.Len() - l.MaxEvents
+		count := 0
+		events.RemoveIf(func(SpanEvent) bool {
+			count++
+			return count > l.MaxEvents
+		})
+		span.SetDroppedEventsCount(span.DroppedEventsCount() + uint32(res.DroppedEvents))
+	}
+	eventLimits := pcommon.AttributeLimits{MaxCount: l.MaxAttributesPerEvent, MaxValueLength: l.Attributes.MaxValueLength}
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		dropped, truncated = eventLimits.Apply(event.Attributes())
+		event.SetDroppedAttributesCount(event.DroppedAttributesCount() + uint32(dropped))
+		res.DroppedAttributes += dropped
+		res.TruncatedValues += truncated
+	}
+
+	links := span.Links()
+	if l.MaxLinks > 0 && links.Len() > l.MaxLinks {
+		res.DroppedLinks = links.Len() - l.MaxLinks
+		count := 0
+		links.RemoveIf(func(SpanLink) bool {
+			count++
+			return count > l.MaxLinks
+		})
+		span.SetDroppedLinksCount(span.DroppedLinksCount() + uint32(res.DroppedLinks))
+	}
+	linkLimits := pcommon.AttributeLimits{MaxCount: l.MaxAttributesPerLink, MaxValueLength: l.Attributes.MaxValueLength}
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		dropped, truncated = linkLimits.Apply(link.Attributes())
+		link.SetDroppedAttributesCount(link.DroppedAttributesCount() + uint32(dropped))
+		res.DroppedAttributes += dropped
+		res.TruncatedValues += truncated
+	}
+	return res
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSpanLimitsApply(t *testing.T) {
+	span := NewSpan()
+	span.SetDroppedAttributesCount(1)
+	span.Attributes().InsertString("a", "value")
+	span.Attributes().InsertString("b", "value")
+	span.Attributes().InsertString("c", "value")
+	for _, name := range []string{"e1", "e2", "e3"} {
+		event := span.Events().AppendEmpty()
+		event.SetName(name)
+		event.Attributes().InsertString("x", "value")
+		event.Attributes().InsertString("y", "value")
+	}
+	span.SetDroppedEventsCount(2)
+	for i := 0; i < 2; i++ {
+		link := span.Links().AppendEmpty()
+		link.Attributes().InsertString("x", "value")
+		link.Attributes().InsertString("y", "value")
+	}
+
+	res := SpanLimits{
+		Attributes:            pcommon.AttributeLimits{MaxCount: 2, MaxValueLength: 3},
+		MaxEvents:             2,
+		MaxLinks:              1,
+		MaxAttributesPerEvent: 1,
+	}.Apply(span)
+
+	assert.Equal(t, SpanLimitsResult{
+		DroppedAttributes: 3,
+		DroppedEvents:     1,
+		DroppedLinks:      1,
+		TruncatedValues:   6,
+	}, res)
+	assert.Equal(t, map[string]interface{}{"a": "val", "b": "val"}, span.Attributes().AsRaw())
+	assert.EqualValues(t, 2, span.DroppedAttributesCount())
+	assert.EqualValues(t, 3, span.DroppedEventsCount())
+	assert.EqualValues(t, 1, span.DroppedLinksCount())
+
+	assert.Equal(t, 2, span.Events().Len())
+	for i, name := range []string{"e1", "e2"} {
+		event := span.Events().At(i)
+		assert.Equal(t, name, event.Name())
+		assert.Equal(t, map[string]interface{}{"x": "val"}, event.Attributes().AsRaw())
+		assert.EqualValues(t, 1, event.DroppedAttributesCount())
+	}
+
+	assert.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, map[string]interface{}{"x": "val", "y": "val"}, span.Links().At(0).Attributes().AsRaw())
+	assert.EqualValues(t, 0, span.Links().At(0).DroppedAttributesCount())
+}
+
+func TestSpanLimitsApplyNoLimits(t *testing.T) {
+	span := NewSpan()
+	span.Attributes().InsertString("a", "value")
+	span.Events().AppendEmpty().Attributes().InsertString("x", "value")
+	span.Links().AppendEmpty()
+
+	expected := NewSpan()
+	span.CopyTo(expected)
+	assert.Equal(t, SpanLimitsResult{}, SpanLimits{}.Apply(span))
+	assert.Equal(t, expected, span)
+}
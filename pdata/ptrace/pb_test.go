@@ -50,6 +50,31 @@ func TestProtoSizer_withNil(t *testing.T) {
 	assert.Equal(t, 0, sizer.TracesSize(NewTraces()))
 }
 
+func TestLazyProtoUnmarshaler(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("foo")
+	buf, err := NewProtoMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+
+	lazy, err := NewLazyProtoUnmarshaler().UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lazy.SpanCount())
+
+	// The unmodified bytes are reused, except by the deterministic marshaler.
+	got, err := NewProtoMarshaler().MarshalTraces(lazy)
+	require.NoError(t, err)
+	assert.Same(t, &buf[0], &got[0])
+	assert.Equal(t, len(buf), NewProtoMarshaler().(Sizer).TracesSize(lazy))
+	got, err = NewProtoMarshaler(WithDeterministicMarshaling()).MarshalTraces(lazy)
+	require.NoError(t, err)
+	assert.Equal(t, buf, got)
+}
+
+func TestLazyProtoUnmarshaler_error(t *testing.T) {
+	_, err := NewLazyProtoUnmarshaler().UnmarshalTraces([]byte("+$%"))
+	assert.Error(t, err)
+}
+
 func BenchmarkTracesToProto(b *testing.B) {
 	marshaler := NewProtoMarshaler()
 	traces := generateBenchmarkTraces(128)
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptraceotlp // import "go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+
+import (
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Merge moves the spans of other to the end of tr, leaving other empty.
+// The resources and scopes of the two requests are appended as they are, without deduplication.
+func (tr Request) Merge(other Request) {
+	other.traces.ResourceSpans().MoveAndAppendTo(tr.traces.ResourceSpans())
+}
+
+// Split splits tr into requests of at most maxItems spans each, preserving the order of the spans.
+// The spans of the first requests are moved out of tr, the last returned request being tr itself
+// with the remaining spans. If maxItems <= 0 or tr has no more than maxItems spans, only tr is returned.
+func (tr Request) Split(maxItems int) []Request {
+	if maxItems <= 0 {
+		return []Request{tr}
+	}
+	var reqs []Request
+	for count := tr.traces.SpanCount(); count > maxItems; count -= maxItems {
+		reqs = append(reqs, NewRequestFromTraces(splitTraces(maxItems, tr.traces)))
+	}
+	return append(reqs, tr)
+}
+
+// splitTraces removes size spans from src and returns them in new traces, src having more than size spans.
+func splitTraces(size int, src ptrace.Traces) ptrace.Traces {
+	totalCopiedSpans := 0
+	dest := ptrace.NewTraces()
+
+	src.ResourceSpans().RemoveIf(func(srcRs ptrace.ResourceSpans) bool {
+		// If we are done skip everything else.
+		if totalCopiedSpans == size {
+			return false
+		}
+
+		// If it fully fits
+		srcRsSC := resourceSpanCount(srcRs)
+		if (totalCopiedSpans + srcRsSC) <= size {
+			totalCopiedSpans += srcRsSC
+			srcRs.MoveTo(dest.ResourceSpans().AppendEmpty())
+			return true
+		}
+
+		destRs := dest.ResourceSpans().AppendEmpty()
+		srcRs.Resource().CopyTo(destRs.Resource())
+		destRs.SetSchemaUrl(srcRs.SchemaUrl())
+		srcRs.ScopeSpans().RemoveIf(func(srcSs ptrace.ScopeSpans) bool {
+			// If we are done skip everything else.
+			if totalCopiedSpans == size {
+				return false
+			}
+
+			// If possible to move all spans do that.
+			srcSsSC := srcSs.Spans().Len()
+			if size-totalCopiedSpans >= srcSsSC {
+				totalCopiedSpans += srcSsSC
+				srcSs.MoveTo(destRs.ScopeSpans().AppendEmpty())
+				return true
+			}
+
+			destSs := destRs.ScopeSpans().AppendEmpty()
+			srcSs.Scope().CopyTo(destSs.Scope())
+			destSs.SetSchemaUrl(srcSs.SchemaUrl())
+			srcSs.Spans().RemoveIf(func(srcSpan ptrace.Span) bool {
+				// If we are done skip everything else.
+				if totalCopiedSpans == size {
+					return false
+				}
+				srcSpan.MoveTo(destSs.Spans().AppendEmpty())
+				totalCopiedSpans++
+				return true
+			})
+			return false
+		})
+		return srcRs.ScopeSpans().Len() == 0
+	})
+
+	return dest
+}
+
+// resourceSpanCount returns the number of spans in the ptrace.ResourceSpans.
+func resourceSpanCount(rs ptrace.ResourceSpans) (count int) {
+	for k := 0; k < rs.ScopeSpans().Len(); k++ {
+		count += rs.ScopeSpans().At(k).Spans().Len()
+	}
+	return
+}
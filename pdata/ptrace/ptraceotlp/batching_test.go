@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptraceotlp
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// newBatchingTestRequest returns a request with a resource per element of spansPerScope,
+// each with a scope per given span count, the spans being named in order.
+func newBatchingTestRequest(spansPerScope ...[]int) Request {
+	td := ptrace.NewTraces()
+	n := 0
+	for i, scopes := range spansPerScope {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("resource", strconv.Itoa(i))
+		rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+		for j, count := range scopes {
+			ss := rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName("scope" + strconv.Itoa(j))
+			for k := 0; k < count; k++ {
+				ss.Spans().AppendEmpty().SetName("span" + strconv.Itoa(n))
+				n++
+			}
+		}
+	}
+	return NewRequestFromTraces(td)
+}
+
+// spanNames returns the names of the spans of the request, with the resource and scope of each span.
+func spanNames(req Request) []string {
+	var names []string
+	rss := req.Traces().ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		res, _ := rss.At(i).Resource().Attributes().Get("resource")
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				names = append(names, res.StringVal()+"/"+sss.At(j).Scope().Name()+"/"+spans.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func TestRequestMerge(t *testing.T) {
+	req := newBatchingTestRequest([]int{2})
+	other := newBatchingTestRequest([]int{1}, []int{1, 1})
+	req.Merge(other)
+
+	assert.Equal(t, 5, req.Traces().SpanCount())
+	assert.Equal(t, 3, req.Traces().ResourceSpans().Len())
+	assert.Equal(t, []string{"0/scope0/span0", "0/scope0/span1", "0/scope0/span0", "1/scope0/span1", "1/scope1/span2"}, spanNames(req))
+	assert.Equal(t, 0, other.Traces().SpanCount())
+
+	// Merging an empty request is a no-op.
+	req.Merge(NewRequest())
+	assert.Equal(t, 5, req.Traces().SpanCount())
+}
+
+func TestRequestSplit(t *testing.T) {
+	req := newBatchingTestRequest([]int{3, 2}, []int{4})
+	expected := spanNames(req)
+
+	reqs := req.Split(4)
+	require.Len(t, reqs, 3)
+	assert.Equal(t, 4, reqs[0].Traces().SpanCount())
+	assert.Equal(t, 4, reqs[1].Traces().SpanCount())
+	assert.Equal(t, 1, reqs[2].Traces().SpanCount())
+	assert.Equal(t, req, reqs[2])
+
+	var actual []string
+	for _, r := range reqs {
+		actual = append(actual, spanNames(r)...)
+		rss := r.Traces().ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			assert.Equal(t, "https://opentelemetry.io/schemas/1.9.0", rss.At(i).SchemaUrl())
+		}
+	}
+	assert.Equal(t, expected, actual)
+	// The second request gets the end of the first resource and the beginning of the second one.
+	assert.Equal(t, []string{"0/scope1/span4", "1/scope0/span5", "1/scope0/span6", "1/scope0/span7"}, spanNames(reqs[1]))
+}
+
+func TestRequestSplitNoop(t *testing.T) {
+	for _, maxItems := range []int{-1, 0, 5, 10} {
+		req := newBatchingTestRequest([]int{3, 2})
+		reqs := req.Split(maxItems)
+		require.Len(t, reqs, 1)
+		assert.Equal(t, req, reqs[0])
+		assert.Equal(t, 5, reqs[0].Traces().SpanCount())
+	}
+	assert.Len(t, NewRequest().Split(1), 1)
+}
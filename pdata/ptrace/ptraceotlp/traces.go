@@ -27,6 +27,9 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
+// exportMethod is the full name of the Export method of the OTLP gRPC service.
+const exportMethod = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+
 var jsonMarshaler = &jsonpb.Marshaler{}
 var jsonUnmarshaler = &jsonpb.Unmarshaler{}
 
@@ -67,39 +70,43 @@ func (tr Response) UnmarshalJSON(data []byte) error {
 // Request represents the request for gRPC/HTTP client/server.
 // It's a wrapper for ptrace.Traces data.
 type Request struct {
-	orig *otlpcollectortrace.ExportTraceServiceRequest
+	traces ptrace.Traces
 }
 
 // NewRequest returns an empty Request.
 func NewRequest() Request {
-	return Request{orig: &otlpcollectortrace.ExportTraceServiceRequest{}}
+	return Request{traces: ptrace.NewTraces()}
 }
 
 // NewRequestFromTraces returns a Request from ptrace.Traces.
 // Because Request is a wrapper for ptrace.Traces,
 // any changes to the provided Traces struct will be reflected in the Request and vice versa.
 func NewRequestFromTraces(t ptrace.Traces) Request {
-	return Request{orig: internal.TracesToOtlp(t)}
+	return Request{traces: t}
 }
 
 // MarshalProto marshals Request into proto bytes.
 func (tr Request) MarshalProto() ([]byte, error) {
-	return tr.orig.Marshal()
+	if buf, ok := internal.TracesRawProto(tr.traces); ok {
+		return buf, nil
+	}
+	return tr.orig().Marshal()
 }
 
 // UnmarshalProto unmarshalls Request from proto bytes.
 func (tr Request) UnmarshalProto(data []byte) error {
-	if err := tr.orig.Unmarshal(data); err != nil {
+	orig := tr.orig()
+	if err := orig.Unmarshal(data); err != nil {
 		return err
 	}
-	otlp.InstrumentationLibrarySpansToScope(tr.orig.ResourceSpans)
+	otlp.InstrumentationLibrarySpansToScope(orig.ResourceSpans)
 	return nil
 }
 
 // MarshalJSON marshals Request into JSON bytes.
 func (tr Request) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
-	if err := jsonMarshaler.Marshal(&buf, tr.orig); err != nil {
+	if err := jsonMarshaler.Marshal(&buf, tr.orig()); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
@@ -107,15 +114,21 @@ func (tr Request) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshalls Request from JSON bytes.
 func (tr Request) UnmarshalJSON(data []byte) error {
-	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), tr.orig); err != nil {
+	orig := tr.orig()
+	if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(data), orig); err != nil {
 		return err
 	}
-	otlp.InstrumentationLibrarySpansToScope(tr.orig.ResourceSpans)
+	otlp.InstrumentationLibrarySpansToScope(orig.ResourceSpans)
 	return nil
 }
 
 func (tr Request) Traces() ptrace.Traces {
-	return internal.TracesFromOtlp(tr.orig)
+	return tr.traces
+}
+
+// orig returns the request message, decoding the traces if lazily unmarshaled.
+func (tr Request) orig() *otlpcollectortrace.ExportTraceServiceRequest {
+	return internal.TracesToOtlp(tr.traces)
 }
 
 // Client is the client API for OTLP-GRPC Traces service.
@@ -131,20 +144,82 @@ type Client interface {
 
 type tracesClient struct {
 	rawClient otlpcollectortrace.TraceServiceClient
+	cc        *grpc.ClientConn
+	invoker   grpc.UnaryInvoker
+	callOpts  []grpc.CallOption
+}
+
+// ClientOption configures the Client returned by NewClientWithOptions.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	interceptors []grpc.UnaryClientInterceptor
+	callOpts     []grpc.CallOption
+}
+
+// WithUnaryClientInterceptors adds the interceptors called around the exports, the first one being
+// the outermost one. The interceptors receive the Request as the request and a *Response as the reply.
+func WithUnaryClientInterceptors(interceptors ...grpc.UnaryClientInterceptor) ClientOption {
+	return func(o *clientOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+// WithCallOptions adds the call options of all the exports, preceding the call options of each export.
+func WithCallOptions(opts ...grpc.CallOption) ClientOption {
+	return func(o *clientOptions) {
+		o.callOpts = append(o.callOpts, opts...)
+	}
 }
 
 // NewClient returns a new Client connected using the given connection.
 func NewClient(cc *grpc.ClientConn) Client {
-	return &tracesClient{rawClient: otlpcollectortrace.NewTraceServiceClient(cc)}
+	return NewClientWithOptions(cc)
+}
+
+// NewClientWithOptions returns a new Client connected using the given connection, configured by the
+// options, e.g. to inject interceptors without dialing the connection with them.
+func NewClientWithOptions(cc *grpc.ClientConn, opts ...ClientOption) Client {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &tracesClient{rawClient: otlpcollectortrace.NewTraceServiceClient(cc), cc: cc, callOpts: o.callOpts}
+	c.invoker = internal.ChainUnaryClient(o.interceptors, c.invoke)
+	return c
 }
 
 // Export implements the Client interface.
 func (c *tracesClient) Export(ctx context.Context, request Request, opts ...grpc.CallOption) (Response, error) {
-	rsp, err := c.rawClient.Export(ctx, request.orig, opts...)
-	return Response{orig: rsp}, err
+	if len(c.callOpts) > 0 {
+		opts = append(c.callOpts[:len(c.callOpts):len(c.callOpts)], opts...)
+	}
+	var rsp Response
+	err := c.invoker(ctx, exportMethod, request, &rsp, c.cc, opts...)
+	return rsp, err
 }
 
-// Server is the server API for OTLP gRPC TracesService service.
+// invoke sends the Request, setting the *Response on success.
+func (c *tracesClient) invoke(ctx context.Context, _ string, req, reply interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+	request := req.(Request)
+	if buf, ok := internal.TracesRawProto(request.traces); ok {
+		// Send the bytes the traces were lazily unmarshaled from as is.
+		rsp := &otlpcollectortrace.ExportTraceServiceResponse{}
+		if err := c.cc.Invoke(ctx, exportMethod, &internal.RawMessage{Buf: buf}, rsp, opts...); err != nil {
+			return err
+		}
+		reply.(*Response).orig = rsp
+		return nil
+	}
+	rsp, err := c.rawClient.Export(ctx, request.orig(), opts...)
+	if err != nil {
+		return err
+	}
+	reply.(*Response).orig = rsp
+	return nil
+}
+
+// Server is the server API for OTLP gRPC TraceService service.
 type Server interface {
 	// Export is called every time a new request is received.
 	//
@@ -153,17 +228,49 @@ type Server interface {
 	Export(context.Context, Request) (Response, error)
 }
 
+// ServerOption configures the Server registered by RegisterServerWithOptions.
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+// WithUnaryServerInterceptors adds the interceptors called around the exports, after the interceptors
+// of the grpc.Server, the first one being the outermost one. The interceptors receive the Request as
+// the request, and return the Response.
+func WithUnaryServerInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(o *serverOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
 // RegisterServer registers the Server to the grpc.Server.
 func RegisterServer(s *grpc.Server, srv Server) {
-	otlpcollectortrace.RegisterTraceServiceServer(s, &rawTracesServer{srv: srv})
+	RegisterServerWithOptions(s, srv)
+}
+
+// RegisterServerWithOptions registers the Server to the grpc.Server, configured by the options.
+func RegisterServerWithOptions(s *grpc.Server, srv Server, opts ...ServerOption) {
+	var o serverOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	handler := internal.ChainUnaryServer(o.interceptors, &grpc.UnaryServerInfo{Server: srv, FullMethod: exportMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.Export(ctx, req.(Request))
+		})
+	otlpcollectortrace.RegisterTraceServiceServer(s, &rawTracesServer{handler: handler})
 }
 
 type rawTracesServer struct {
-	srv Server
+	handler grpc.UnaryHandler
 }
 
 func (s rawTracesServer) Export(ctx context.Context, request *otlpcollectortrace.ExportTraceServiceRequest) (*otlpcollectortrace.ExportTraceServiceResponse, error) {
 	otlp.InstrumentationLibrarySpansToScope(request.ResourceSpans)
-	rsp, err := s.srv.Export(ctx, Request{orig: request})
-	return rsp.orig, err
+	rsp, err := s.handler(ctx, Request{traces: internal.TracesFromOtlp(request)})
+	if r, ok := rsp.(Response); ok {
+		return r.orig, err
+	}
+	return nil, err
 }
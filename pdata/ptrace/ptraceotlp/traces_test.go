@@ -28,6 +28,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
@@ -188,6 +189,54 @@ func TestGrpc(t *testing.T) {
 	assert.Equal(t, NewResponse(), resp)
 }
 
+func TestRequestLazy(t *testing.T) {
+	buf, err := generateTracesRequest().MarshalProto()
+	require.NoError(t, err)
+	td, err := ptrace.NewLazyProtoUnmarshaler().UnmarshalTraces(buf)
+	require.NoError(t, err)
+
+	// The lazily unmarshaled bytes are marshaled as is.
+	got, err := NewRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	assert.Same(t, &buf[0], &got[0])
+}
+
+func TestGrpcLazy(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterServer(s, &fakeTracesServer{t: t})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	buf, err := generateTracesRequest().MarshalProto()
+	require.NoError(t, err)
+	td, err := ptrace.NewLazyProtoUnmarshaler().UnmarshalTraces(buf)
+	require.NoError(t, err)
+	traceClient := NewClient(cc)
+	resp, err := traceClient.Export(context.Background(), NewRequestFromTraces(td))
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+}
+
 func TestGrpcTransition(t *testing.T) {
 	lis := bufconn.Listen(1024 * 1024)
 	s := grpc.NewServer()
@@ -217,7 +266,7 @@ func TestGrpcTransition(t *testing.T) {
 	logClient := NewClient(cc)
 
 	req := generateTracesRequestWithInstrumentationLibrary()
-	otlp.InstrumentationLibrarySpansToScope(req.orig.ResourceSpans)
+	otlp.InstrumentationLibrarySpansToScope(req.orig().ResourceSpans)
 	resp, err := logClient.Export(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, NewResponse(), resp)
@@ -302,6 +351,108 @@ func TestGrpcError(t *testing.T) {
 	assert.Equal(t, Response{}, resp)
 }
 
+func TestGrpcInterceptors(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(call string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	}
+
+	serverInterceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			record(name)
+			assert.Equal(t, "/opentelemetry.proto.collector.trace.v1.TraceService/Export", info.FullMethod)
+			assert.Equal(t, generateTracesRequest(), req)
+			assert.NoError(t, grpc.SetHeader(ctx, metadata.Pairs("interceptor", name)))
+			rsp, err := handler(ctx, req)
+			assert.Equal(t, NewResponse(), rsp)
+			return rsp, err
+		}
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterServerWithOptions(s, &fakeTracesServer{t: t},
+		WithUnaryServerInterceptors(serverInterceptor("server1"), serverInterceptor("server2")))
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	clientInterceptor := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			record(name)
+			assert.Equal(t, "/opentelemetry.proto.collector.trace.v1.TraceService/Export", method)
+			assert.Equal(t, generateTracesRequest(), req)
+			// The call options of the client precede the ones of the export.
+			assert.Len(t, opts, 2)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	var header metadata.MD
+	client := NewClientWithOptions(cc,
+		WithUnaryClientInterceptors(clientInterceptor("client1"), clientInterceptor("client2")),
+		WithCallOptions(grpc.Header(&header)))
+
+	resp, err := client.Export(context.Background(), generateTracesRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	assert.Equal(t, NewResponse(), resp)
+	assert.Equal(t, []string{"client1", "client2", "server1", "server2"}, calls)
+	assert.Equal(t, []string{"server1", "server2"}, header.Get("interceptor"))
+}
+
+func TestGrpcInterceptorError(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterServerWithOptions(s, &fakeTracesServer{t: t},
+		WithUnaryServerInterceptors(func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+			return nil, status.Error(codes.PermissionDenied, "denied")
+		}))
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	resp, err := NewClientWithOptions(cc).Export(context.Background(), generateTracesRequest())
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, Response{}, resp)
+}
+
 type fakeTracesServer struct {
 	t   *testing.T
 	err error
@@ -320,11 +471,11 @@ func generateTracesRequest() Request {
 
 func generateTracesRequestWithInstrumentationLibrary() Request {
 	tr := generateTracesRequest()
-	tr.orig.ResourceSpans[0].InstrumentationLibrarySpans = []*v1.InstrumentationLibrarySpans{ //nolint:staticcheck // SA1019 ignore this!
+	tr.orig().ResourceSpans[0].InstrumentationLibrarySpans = []*v1.InstrumentationLibrarySpans{ //nolint:staticcheck // SA1019 ignore this!
 		{
-			Spans: tr.orig.ResourceSpans[0].ScopeSpans[0].Spans,
+			Spans: tr.orig().ResourceSpans[0].ScopeSpans[0].Spans,
 		},
 	}
-	tr.orig.ResourceSpans[0].ScopeSpans = []*v1.ScopeSpans{}
+	tr.orig().ResourceSpans[0].ScopeSpans = []*v1.ScopeSpans{}
 	return tr
 }
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ptraceutil provides utilities to reassemble the traces from their spans and to compute
+// trace-level properties, e.g. for the components sampling or aggregating whole traces.
+package ptraceutil // import "go.opentelemetry.io/collector/pdata/ptrace/ptraceutil"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// GroupByTrace returns copies of the spans of the batches grouped by trace ID, with their resources and scopes.
+// The spans of a trace sharing a resource and scope in a batch share them in the returned traces too, while the
+// spans of different batches, e.g. received separately, are appended in the order of the batches.
+func GroupByTrace(tds ...ptrace.Traces) map[pcommon.TraceID]ptrace.Traces {
+	traces := make(map[pcommon.TraceID]ptrace.Traces)
+	for _, td := range tds {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			groupResourceSpans(traces, rss.At(i))
+		}
+	}
+	return traces
+}
+
+func groupResourceSpans(traces map[pcommon.TraceID]ptrace.Traces, rs ptrace.ResourceSpans) {
+	destRss := make(map[pcommon.TraceID]ptrace.ResourceSpans)
+	sss := rs.ScopeSpans()
+	for i := 0; i < sss.Len(); i++ {
+		ss := sss.At(i)
+		destSss := make(map[pcommon.TraceID]ptrace.ScopeSpans)
+		spans := ss.Spans()
+		for j := 0; j < spans.Len(); j++ {
+			span := spans.At(j)
+			traceID := span.TraceID()
+			destSs, ok := destSss[traceID]
+			if !ok {
+				destRs, found := destRss[traceID]
+				if !found {
+					td, exists := traces[traceID]
+					if !exists {
+						td = ptrace.NewTraces()
+						traces[traceID] = td
+					}
+					destRs = td.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(destRs.Resource())
+					destRs.SetSchemaUrl(rs.SchemaUrl())
+					destRss[traceID] = destRs
+				}
+				destSs = destRs.ScopeSpans().AppendEmpty()
+				ss.Scope().CopyTo(destSs.Scope())
+				destSs.SetSchemaUrl(ss.SchemaUrl())
+				destSss[traceID] = destSs
+			}
+			span.CopyTo(destSs.Spans().AppendEmpty())
+		}
+	}
+}
+
+// rangeSpans calls f for each span of td, until f returns false.
+func rangeSpans(td ptrace.Traces, f func(span ptrace.Span) bool) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if !f(spans.At(k)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RootSpans returns the spans of td without parent span, usually the single root span of a complete trace.
+func RootSpans(td ptrace.Traces) []ptrace.Span {
+	var roots []ptrace.Span
+	rangeSpans(td, func(span ptrace.Span) bool {
+		if span.ParentSpanID().IsEmpty() {
+			roots = append(roots, span)
+		}
+		return true
+	})
+	return roots
+}
+
+// TimeRange returns the earliest start timestamp and the latest end timestamp of the spans of td,
+// both zero if td has no spans.
+func TimeRange(td ptrace.Traces) (start pcommon.Timestamp, end pcommon.Timestamp) {
+	first := true
+	rangeSpans(td, func(span ptrace.Span) bool {
+		if first || span.StartTimestamp() < start {
+			start = span.StartTimestamp()
+		}
+		if first || span.EndTimestamp() > end {
+			end = span.EndTimestamp()
+		}
+		first = false
+		return true
+	})
+	return start, end
+}
+
+// Duration returns the duration of the trace of the spans of td, from the earliest start timestamp
+// to the latest end timestamp of its spans.
+func Duration(td ptrace.Traces) time.Duration {
+	start, end := TimeRange(td)
+	return end.Sub(start)
+}
+
+// HasError returns true if the status of any span of td is ptrace.StatusCodeError.
+func HasError(td ptrace.Traces) bool {
+	hasError := false
+	rangeSpans(td, func(span ptrace.Span) bool {
+		hasError = span.IsError()
+		return !hasError
+	})
+	return hasError
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptraceutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	traceID1 = pcommon.NewTraceID([16]byte{1})
+	traceID2 = pcommon.NewTraceID([16]byte{2})
+)
+
+func appendSpan(ss ptrace.ScopeSpans, traceID pcommon.TraceID, spanID byte, parentID byte, start, end pcommon.Timestamp) ptrace.Span {
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(pcommon.NewSpanID([8]byte{spanID}))
+	if parentID != 0 {
+		span.SetParentSpanID(pcommon.NewSpanID([8]byte{parentID}))
+	}
+	span.SetStartTimestamp(start)
+	span.SetEndTimestamp(end)
+	return span
+}
+
+func newTestScopeSpans(td ptrace.Traces, service string, scope string) ptrace.ScopeSpans {
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", service)
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.9.0")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scope)
+	return ss
+}
+
+func TestGroupByTrace(t *testing.T) {
+	td1 := ptrace.NewTraces()
+	ss := newTestScopeSpans(td1, "frontend", "http")
+	appendSpan(ss, traceID1, 1, 0, 100, 500)
+	appendSpan(ss, traceID2, 2, 0, 100, 200)
+	appendSpan(ss, traceID1, 3, 1, 200, 300)
+	ss = newTestScopeSpans(td1, "backend", "grpc")
+	appendSpan(ss, traceID1, 4, 3, 210, 290)
+
+	td2 := ptrace.NewTraces()
+	ss = newTestScopeSpans(td2, "database", "sql")
+	appendSpan(ss, traceID1, 5, 4, 220, 280)
+
+	traces := GroupByTrace(td1, td2)
+	require.Len(t, traces, 2)
+	assert.Equal(t, 5, td1.SpanCount()+td2.SpanCount(), "the batches are not modified")
+
+	trace1 := traces[traceID1]
+	assert.Equal(t, 4, trace1.SpanCount())
+	require.Equal(t, 3, trace1.ResourceSpans().Len())
+	services := []string{"frontend", "backend", "database"}
+	spanCounts := []int{2, 1, 1}
+	for i, service := range services {
+		rs := trace1.ResourceSpans().At(i)
+		serviceName, ok := rs.Resource().Attributes().Get("service.name")
+		require.True(t, ok)
+		assert.Equal(t, service, serviceName.StringVal())
+		assert.Equal(t, "https://opentelemetry.io/schemas/1.9.0", rs.SchemaUrl())
+		require.Equal(t, 1, rs.ScopeSpans().Len())
+		assert.Equal(t, spanCounts[i], rs.ScopeSpans().At(0).Spans().Len())
+	}
+	assert.Equal(t, "http", trace1.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, pcommon.NewSpanID([8]byte{3}), trace1.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).SpanID())
+
+	trace2 := traces[traceID2]
+	assert.Equal(t, 1, trace2.SpanCount())
+	assert.Equal(t, pcommon.NewSpanID([8]byte{2}), trace2.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SpanID())
+
+	assert.Empty(t, GroupByTrace())
+	assert.Empty(t, GroupByTrace(ptrace.NewTraces()))
+}
+
+func TestRootSpans(t *testing.T) {
+	td := ptrace.NewTraces()
+	ss := newTestScopeSpans(td, "frontend", "http")
+	appendSpan(ss, traceID1, 2, 1, 200, 300)
+	appendSpan(ss, traceID1, 1, 0, 100, 500)
+
+	roots := RootSpans(td)
+	require.Len(t, roots, 1)
+	assert.Equal(t, pcommon.NewSpanID([8]byte{1}), roots[0].SpanID())
+
+	assert.Empty(t, RootSpans(ptrace.NewTraces()))
+}
+
+func TestTimeRangeAndDuration(t *testing.T) {
+	td := ptrace.NewTraces()
+	start, end := TimeRange(td)
+	assert.Zero(t, start)
+	assert.Zero(t, end)
+	assert.Zero(t, Duration(td))
+
+	ss := newTestScopeSpans(td, "frontend", "http")
+	appendSpan(ss, traceID1, 2, 1, 2000, 3000)
+	appendSpan(ss, traceID1, 1, 0, 1000, 2500)
+	appendSpan(ss, traceID1, 3, 1, 1500, 3500)
+
+	start, end = TimeRange(td)
+	assert.Equal(t, pcommon.Timestamp(1000), start)
+	assert.Equal(t, pcommon.Timestamp(3500), end)
+	assert.Equal(t, 2500*time.Nanosecond, Duration(td))
+}
+
+func TestHasError(t *testing.T) {
+	td := ptrace.NewTraces()
+	assert.False(t, HasError(td))
+
+	ss := newTestScopeSpans(td, "frontend", "http")
+	appendSpan(ss, traceID1, 1, 0, 100, 500).Status().SetCode(ptrace.StatusCodeOk)
+	appendSpan(ss, traceID1, 2, 1, 200, 300)
+	assert.False(t, HasError(td))
+
+	appendSpan(ss, traceID1, 3, 1, 200, 300).Status().SetCode(ptrace.StatusCodeError)
+	assert.True(t, HasError(td))
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"encoding/json"
+)
+
+// AsRaw returns the traces as the generic maps, slices and values of their OTLP JSON representation, e.g. for a
+// scripting or transform layer, or to compare them with a golden file in YAML or JSON. The 64-bit integers are
+// strings, and the IDs hex strings, as in OTLP JSON.
+func AsRaw(td Traces) (map[string]interface{}, error) {
+	buf, err := newJSONMarshaler().MarshalTraces(td)
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]interface{}{}
+	if err = json.Unmarshal(buf, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// FromRaw returns the traces of the generic maps, slices and values of their OTLP JSON representation, as returned by
+// AsRaw or decoded from YAML or JSON. The 64-bit integers can also be numbers. The maps must have string keys.
+func FromRaw(raw map[string]interface{}) (Traces, error) {
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return Traces{}, err
+	}
+	return NewJSONUnmarshaler().UnmarshalTraces(buf)
+}
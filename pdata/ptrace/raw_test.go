@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ptrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTracesRaw(t *testing.T) {
+	td := NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().UpsertString("host.name", "testHost")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("testSpan")
+	span.SetKind(SpanKindServer)
+	span.SetTraceID(pcommon.NewTraceID([16]byte{1}))
+	span.SetSpanID(pcommon.NewSpanID([8]byte{2}))
+	span.SetStartTimestamp(1000)
+	span.Attributes().UpsertDouble("ratio", 0.5)
+	span.Status().SetCode(StatusCodeError)
+
+	raw, err := AsRaw(td)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{"attributes": []interface{}{
+				map[string]interface{}{"key": "host.name", "value": map[string]interface{}{"stringValue": "testHost"}},
+			}},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{},
+				"spans": []interface{}{map[string]interface{}{
+					"traceId":           "01000000000000000000000000000000",
+					"spanId":            "0200000000000000",
+					"parentSpanId":      "",
+					"name":              "testSpan",
+					"kind":              "SPAN_KIND_SERVER",
+					"startTimeUnixNano": "1000",
+					"attributes": []interface{}{
+						map[string]interface{}{"key": "ratio", "value": map[string]interface{}{"doubleValue": 0.5}},
+					},
+					"status": map[string]interface{}{"code": "STATUS_CODE_ERROR"},
+				}},
+			}},
+		}},
+	}, raw)
+
+	got, err := FromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/consumerack"
+)
+
+// batchAcks tracks the consumerack.Ack holds of the items added to the batch, releasing each of them once
+// the exports of all the batches containing data of the item are acknowledged by the next consumer. It is
+// only accessed by the processing goroutine.
+type batchAcks struct {
+	// pending are the acknowledgments of the items with a hold, in the order of the items.
+	pending []pendingAck
+	// added and sentCount are the numbers of units added to and sent from the batch.
+	added     int64
+	sentCount int64
+}
+
+// pendingAck is the acknowledgment of the units [start, end) of the batch, completed once all of them are
+// sent and their exports acknowledged.
+type pendingAck struct {
+	start int64
+	end   int64
+	ack   *consumerack.Ack
+}
+
+// add adds the item of count units to the batch, release releasing its hold, nil if none.
+func (ba *batchAcks) add(count int, release func(error)) {
+	if release != nil {
+		ack := consumerack.New()
+		ack.OnComplete(release)
+		ba.pending = append(ba.pending, pendingAck{start: ba.added, end: ba.added + int64(count), ack: ack})
+	}
+	ba.added += int64(count)
+}
+
+// exportContext returns the context of the export of the next batch, carrying a new Ack when the batch may
+// contain items with a hold, nil otherwise.
+func (ba *batchAcks) exportContext(ctx context.Context) (context.Context, *consumerack.Ack) {
+	if len(ba.pending) == 0 {
+		return ctx, nil
+	}
+	ack := consumerack.New()
+	return consumerack.NewContext(ctx, ack), ack
+}
+
+// sent records that the next count units of the batch were exported with the error, exportAck being the
+// Ack of the context of the export.
+func (ba *batchAcks) sent(count int, exportAck *consumerack.Ack, err error) {
+	ba.sentCount += int64(count)
+	if exportAck == nil {
+		return
+	}
+	done := 0
+	for _, p := range ba.pending {
+		if p.start >= ba.sentCount {
+			break
+		}
+		exportAck.OnComplete(p.ack.Hold())
+		if p.end <= ba.sentCount {
+			p.ack.Done(nil)
+			done++
+		}
+	}
+	ba.pending = ba.pending[done:]
+	exportAck.Done(err)
+}
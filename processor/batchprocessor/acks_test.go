@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerack"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestBatchAcks(t *testing.T) {
+	var ba batchAcks
+	var results []string
+	release := func(name string) func(error) {
+		return func(err error) {
+			if err != nil {
+				name += ": " + err.Error()
+			}
+			results = append(results, name)
+		}
+	}
+	ba.add(3, release("first"))
+	ba.add(2, nil)
+	ba.add(4, release("second"))
+
+	// The first export contains the first item and a part of the second one.
+	ctx, exportAck := ba.exportContext(context.Background())
+	release1 := consumerack.Hold(ctx)
+	ba.sent(6, exportAck, nil)
+	assert.Empty(t, results)
+	release1(nil)
+	assert.Equal(t, []string{"first"}, results)
+
+	// The second one contains the rest of the second item, failing.
+	ctx, exportAck = ba.exportContext(context.Background())
+	ba.sent(3, exportAck, errors.New("my error"))
+	assert.Equal(t, []string{"first", "second: my error"}, results)
+	assert.Empty(t, ba.pending)
+
+	// No Ack without holds.
+	ba.add(1, nil)
+	ctx, exportAck = ba.exportContext(context.Background())
+	assert.Nil(t, exportAck)
+	assert.Nil(t, consumerack.Hold(ctx))
+	ba.sent(1, exportAck, nil)
+}
+
+func TestBatchProcessorAcks(t *testing.T) {
+	holds := make(chan func(error), 10)
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		holds <- consumerack.Hold(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Timeout = time.Hour
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, next, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, batcher.Shutdown(context.Background())) })
+
+	ack := consumerack.New()
+	require.NoError(t, batcher.ConsumeTraces(consumerack.NewContext(context.Background(), ack), testdata.GenerateTraces(5)))
+	ack.Done(nil)
+	waitCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, ack.Wait(waitCtx), context.DeadlineExceeded)
+
+	// The Ack completes once the batch is sent and acknowledged by the next consumer.
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(5)))
+	release := <-holds
+	require.NotNil(t, release)
+	assert.ErrorIs(t, ack.Wait(waitCtx), context.DeadlineExceeded)
+	release(errors.New("my error"))
+	assert.EqualError(t, ack.Wait(context.Background()), "my error")
+}
+
+func TestBatchProcessorWithoutAcks(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(15)))
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, 15, sink.SpanCount())
+	assert.Empty(t, batcher.acks.pending)
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// adaptiveWindow is the number of sends to the next consumer after which the batch size is adjusted.
+const adaptiveWindow = 10
+
+// adaptiveBatcher adjusts the batch size within the bounds of the AdaptiveSettings, growing it
+// additively while the sends to the next consumer are fast and successful enough, and halving it
+// otherwise, so that the batches settle at the largest size the next consumer handles.
+type adaptiveBatcher struct {
+	// size is accessed atomically, read by the callers enqueuing the items.
+	size int64
+
+	cfg  AdaptiveSettings
+	step int64
+
+	sends   int
+	errors  int
+	latency time.Duration
+}
+
+func newAdaptiveBatcher(cfg AdaptiveSettings, sendBatchSize uint32) *adaptiveBatcher {
+	ab := &adaptiveBatcher{
+		cfg:  cfg,
+		step: int64(cfg.MaxSendBatchSize-cfg.MinSendBatchSize) / 16,
+	}
+	if ab.step < 1 {
+		ab.step = 1
+	}
+	ab.size = ab.clamp(int64(sendBatchSize))
+	return ab
+}
+
+// sendBatchSize returns the current batch size.
+func (ab *adaptiveBatcher) sendBatchSize() int {
+	return int(atomic.LoadInt64(&ab.size))
+}
+
+// timeout returns the timeout of the current batch size, proportional to its position between the
+// bounds of the batch size, the larger batches taking longer to fill.
+func (ab *adaptiveBatcher) timeout() time.Duration {
+	minSize, maxSize := int64(ab.cfg.MinSendBatchSize), int64(ab.cfg.MaxSendBatchSize)
+	if maxSize == minSize {
+		return ab.cfg.MinTimeout
+	}
+	ratio := float64(atomic.LoadInt64(&ab.size)-minSize) / float64(maxSize-minSize)
+	return ab.cfg.MinTimeout + time.Duration(ratio*float64(ab.cfg.MaxTimeout-ab.cfg.MinTimeout))
+}
+
+// observe records the duration and the error of a send to the next consumer, adjusting the batch size
+// at the end of each window of sends. Returns whether the batch size changed.
+func (ab *adaptiveBatcher) observe(latency time.Duration, err error) bool {
+	ab.sends++
+	ab.latency += latency
+	if err != nil {
+		ab.errors++
+	}
+	if ab.sends < adaptiveWindow {
+		return false
+	}
+	avgLatency := ab.latency / time.Duration(ab.sends)
+	errorRate := float64(ab.errors) / float64(ab.sends)
+	ab.sends, ab.errors, ab.latency = 0, 0, 0
+
+	prev := atomic.LoadInt64(&ab.size)
+	size := prev + ab.step
+	if avgLatency > ab.cfg.TargetLatency || errorRate > ab.cfg.MaxErrorRate {
+		size = prev / 2
+	}
+	size = ab.clamp(size)
+	atomic.StoreInt64(&ab.size, size)
+	return size != prev
+}
+
+func (ab *adaptiveBatcher) clamp(size int64) int64 {
+	if size < int64(ab.cfg.MinSendBatchSize) {
+		return int64(ab.cfg.MinSendBatchSize)
+	}
+	if size > int64(ab.cfg.MaxSendBatchSize) {
+		return int64(ab.cfg.MaxSendBatchSize)
+	}
+	return size
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestAdaptiveSettings() AdaptiveSettings {
+	return AdaptiveSettings{
+		Enabled:          true,
+		MinSendBatchSize: 100,
+		MaxSendBatchSize: 1700,
+		MinTimeout:       100 * time.Millisecond,
+		MaxTimeout:       1700 * time.Millisecond,
+		TargetLatency:    time.Second,
+		MaxErrorRate:     0.2,
+	}
+}
+
+func observeWindow(ab *adaptiveBatcher, latency time.Duration, errs int) bool {
+	changed := false
+	for i := 0; i < adaptiveWindow; i++ {
+		var err error
+		if i < errs {
+			err = errors.New("my_error")
+		}
+		changed = ab.observe(latency, err)
+	}
+	return changed
+}
+
+func TestAdaptiveBatcherInitialSize(t *testing.T) {
+	cfg := newTestAdaptiveSettings()
+	assert.Equal(t, 500, newAdaptiveBatcher(cfg, 500).sendBatchSize())
+	assert.Equal(t, 100, newAdaptiveBatcher(cfg, 10).sendBatchSize())
+	assert.Equal(t, 1700, newAdaptiveBatcher(cfg, 8192).sendBatchSize())
+}
+
+func TestAdaptiveBatcherTimeout(t *testing.T) {
+	cfg := newTestAdaptiveSettings()
+	assert.Equal(t, 100*time.Millisecond, newAdaptiveBatcher(cfg, 100).timeout())
+	assert.Equal(t, 900*time.Millisecond, newAdaptiveBatcher(cfg, 900).timeout())
+	assert.Equal(t, 1700*time.Millisecond, newAdaptiveBatcher(cfg, 1700).timeout())
+
+	cfg.MaxSendBatchSize = 100
+	assert.Equal(t, 100*time.Millisecond, newAdaptiveBatcher(cfg, 100).timeout())
+}
+
+func TestAdaptiveBatcherGrows(t *testing.T) {
+	ab := newAdaptiveBatcher(newTestAdaptiveSettings(), 1000)
+
+	// The size is only adjusted at the end of the window.
+	for i := 0; i < adaptiveWindow-1; i++ {
+		assert.False(t, ab.observe(time.Millisecond, nil))
+	}
+	assert.Equal(t, 1000, ab.sendBatchSize())
+	assert.True(t, ab.observe(time.Millisecond, nil))
+	assert.Equal(t, 1100, ab.sendBatchSize())
+
+	// A few errors below the max error rate do not prevent growing, up to the max size.
+	for i := 0; i < 6; i++ {
+		assert.True(t, observeWindow(ab, time.Millisecond, 2))
+	}
+	assert.Equal(t, 1700, ab.sendBatchSize())
+	assert.False(t, observeWindow(ab, time.Millisecond, 0))
+	assert.Equal(t, 1700, ab.sendBatchSize())
+}
+
+func TestAdaptiveBatcherShrinks(t *testing.T) {
+	ab := newAdaptiveBatcher(newTestAdaptiveSettings(), 1600)
+
+	assert.True(t, observeWindow(ab, 2*time.Second, 0))
+	assert.Equal(t, 800, ab.sendBatchSize())
+	assert.True(t, observeWindow(ab, time.Millisecond, 3))
+	assert.Equal(t, 400, ab.sendBatchSize())
+	assert.True(t, observeWindow(ab, 2*time.Second, 0))
+	assert.True(t, observeWindow(ab, 2*time.Second, 0))
+	assert.Equal(t, 100, ab.sendBatchSize())
+	assert.False(t, observeWindow(ab, 2*time.Second, 0))
+	assert.Equal(t, 100, ab.sendBatchSize())
+}
+
+// slowTraces is a consumer.Traces taking the delay to consume the traces.
+type slowTraces struct {
+	consumertest.TracesSink
+	delay int64
+}
+
+func (st *slowTraces) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (st *slowTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	time.Sleep(time.Duration(atomic.LoadInt64(&st.delay)))
+	return st.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestBatchProcessorAdaptive(t *testing.T) {
+	sink := new(slowTraces)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	cfg.Adaptive = AdaptiveSettings{
+		Enabled:          true,
+		MinSendBatchSize: 10,
+		MaxSendBatchSize: 170,
+		MinTimeout:       time.Hour,
+		MaxTimeout:       time.Hour,
+		TargetLatency:    10 * time.Millisecond,
+		MaxErrorRate:     0.1,
+	}
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The fast consumer lets the batches grow by 10 spans every 10 batches.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+	}
+	require.Eventually(t, func() bool { return sink.SpanCount() == 100 }, time.Second, time.Millisecond)
+	assert.Equal(t, 20, batcher.adaptive.sendBatchSize())
+
+	// The slow consumer halves them.
+	atomic.StoreInt64(&sink.delay, int64(20*time.Millisecond))
+	sink.Reset()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(20)))
+	}
+	require.Eventually(t, func() bool { return sink.SpanCount() == 200 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, 10, batcher.adaptive.sendBatchSize())
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+}
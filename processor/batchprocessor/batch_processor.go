@@ -15,18 +15,23 @@
 package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
 
 import (
+	"container/heap"
 	"context"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerack"
+	"go.opentelemetry.io/collector/internal/memorypressure"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -40,19 +45,57 @@ import (
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
+// - a memory_limiter processor reports memory pressure, when cfg.MemoryPressureSendBatchSize is set.
+//   The batches are then limited to cfg.MemoryPressureSendBatchSize until the pressure ends.
+//
+// When cfg.Adaptive is enabled, the batch size and timeout are adjusted within its bounds based
+// on the latency and the errors of the next consumer.
+//
+// The consumerack.Ack of the incoming data is held until the batches containing it are acknowledged
+// by the next consumer.
+//
+// The incoming data is enqueued without locks into one of the shards, in a round-robin
+// fashion, so that concurrent callers do not wait on each other nor on the exports.
+// A single goroutine merges the shards into the batch, in the enqueuing order, and sends it.
 type batchProcessor struct {
+	// lastSeq and pending are accessed atomically, kept first for the 64-bit alignment.
+	// lastSeq is the sequence number of the last enqueued item, also selecting its shard.
+	lastSeq uint64
+	// pending is the number of items enqueued into the shards or the batch, not yet sent.
+	pending int64
+	// maxPending is the number of pending items above which the callers wait for the
+	// batch to be sent, applying backpressure when the next consumer is slower.
+	maxPending int64
+
 	logger           *zap.Logger
 	exportCtx        context.Context
 	timer            *time.Timer
 	timeout          time.Duration
 	sendBatchSize    int
 	sendBatchMaxSize int
-
-	newItem chan interface{}
-	batch   batch
-
-	shutdownC  chan struct{}
-	goroutines sync.WaitGroup
+	// pressureBatchSize is the batch size and max size under memory pressure, 0 if the pressure is ignored.
+	pressureBatchSize int
+	// pressureC is notified when the memory comes under pressure, nil if the pressure is ignored.
+	pressureC    <-chan struct{}
+	stopPressure func()
+	// adaptive adjusts the batch size and timeout, nil if they are fixed.
+	adaptive *adaptiveBatcher
+
+	shards []shard
+	// reordered holds the drained items following an item not yet pushed into its shard,
+	// nextSeq being the sequence number of the next item to add to the batch.
+	reordered shardItems
+	nextSeq   uint64
+	flushC    chan struct{}
+	sentMu    sync.Mutex
+	sentC     *sync.Cond
+	batch     batch
+	// acks are the acknowledgments of the items of the batch, in the order of the items.
+	acks batchAcks
+
+	shutdownC    chan struct{}
+	shutdownOnce sync.Once
+	goroutines   sync.WaitGroup
 
 	telemetryLevel configtelemetry.Level
 }
@@ -64,10 +107,69 @@ type batch interface {
 	// itemCount returns the size of the current batch
 	itemCount() int
 
+	// countItems returns the size of the item, without adding it to the current batch
+	countItems(item interface{}) int
+
 	// add item to the current batch
 	add(item interface{})
 }
 
+// shardItem is an item enqueued into a shard.
+type shardItem struct {
+	// seq is the sequence number of the item, ordering the items of all the shards.
+	seq   uint64
+	item  interface{}
+	count int
+	// release releases the hold of the consumerack.Ack of the item, nil if none.
+	release func(error)
+	next    *shardItem
+}
+
+// shard is a lock-free stack of the enqueued items, drained all at once by the processing goroutine.
+type shard struct {
+	// head holds the last enqueued *shardItem, nil when the shard is empty.
+	head atomic.Value
+	// Pad the shards to different cache lines, avoiding false sharing between the callers.
+	_ [64]byte
+}
+
+func (s *shard) push(si *shardItem) {
+	for {
+		head := s.head.Load()
+		si.next = head.(*shardItem)
+		if s.head.CompareAndSwap(head, si) {
+			return
+		}
+	}
+}
+
+// drain removes all the items from the shard, returned in the enqueuing order.
+func (s *shard) drain() *shardItem {
+	var first *shardItem
+	for si := s.head.Swap((*shardItem)(nil)).(*shardItem); si != nil; {
+		next := si.next
+		si.next = first
+		first = si
+		si = next
+	}
+	return first
+}
+
+// shardItems is a heap of the items ordered by their sequence numbers.
+type shardItems []*shardItem
+
+func (h shardItems) Len() int            { return len(h) }
+func (h shardItems) Less(i, j int) bool  { return h[i].seq < h[j].seq }
+func (h shardItems) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *shardItems) Push(x interface{}) { *h = append(*h, x.(*shardItem)) }
+func (h *shardItems) Pop() interface{} {
+	old := *h
+	si := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return si
+}
+
 var _ consumer.Traces = (*batchProcessor)(nil)
 var _ consumer.Metrics = (*batchProcessor)(nil)
 var _ consumer.Logs = (*batchProcessor)(nil)
@@ -77,18 +179,44 @@ func newBatchProcessor(set component.ProcessorCreateSettings, cfg *Config, batch
 	if err != nil {
 		return nil, err
 	}
-	return &batchProcessor{
+	shards := make([]shard, runtime.NumCPU())
+	for i := range shards {
+		shards[i].head.Store((*shardItem)(nil))
+	}
+	// Allow each shard to hold about a full batch before blocking the callers.
+	maxBatchSize := cfg.SendBatchSize
+	if cfg.SendBatchMaxSize > maxBatchSize {
+		maxBatchSize = cfg.SendBatchMaxSize
+	}
+	if cfg.Adaptive.Enabled && cfg.Adaptive.MaxSendBatchSize > maxBatchSize {
+		maxBatchSize = cfg.Adaptive.MaxSendBatchSize
+	}
+	if maxBatchSize == 0 {
+		maxBatchSize = 1
+	}
+	bp := &batchProcessor{
+		maxPending: int64(len(shards)) * int64(maxBatchSize),
+
 		logger:         set.Logger,
 		exportCtx:      exportCtx,
 		telemetryLevel: telemetryLevel,
 
-		sendBatchSize:    int(cfg.SendBatchSize),
-		sendBatchMaxSize: int(cfg.SendBatchMaxSize),
-		timeout:          cfg.Timeout,
-		newItem:          make(chan interface{}, runtime.NumCPU()),
-		batch:            batch,
-		shutdownC:        make(chan struct{}, 1),
-	}, nil
+		sendBatchSize:     int(cfg.SendBatchSize),
+		sendBatchMaxSize:  int(cfg.SendBatchMaxSize),
+		pressureBatchSize: int(cfg.MemoryPressureSendBatchSize),
+		timeout:           cfg.Timeout,
+		shards:            shards,
+		nextSeq:           1,
+		flushC:            make(chan struct{}, 1),
+		batch:             batch,
+		shutdownC:         make(chan struct{}, 1),
+	}
+	if cfg.Adaptive.Enabled {
+		bp.adaptive = newAdaptiveBatcher(cfg.Adaptive, cfg.SendBatchSize)
+		bp.timeout = bp.adaptive.timeout()
+	}
+	bp.sentC = sync.NewCond(&bp.sentMu)
+	return bp, nil
 }
 
 func (bp *batchProcessor) Capabilities() consumer.Capabilities {
@@ -97,6 +225,9 @@ func (bp *batchProcessor) Capabilities() consumer.Capabilities {
 
 // Start is invoked during service startup.
 func (bp *batchProcessor) Start(context.Context, component.Host) error {
+	if bp.pressureBatchSize > 0 {
+		bp.pressureC, bp.stopPressure = memorypressure.Notify()
+	}
 	bp.goroutines.Add(1)
 	go bp.startProcessingCycle()
 	return nil
@@ -104,10 +235,14 @@ func (bp *batchProcessor) Start(context.Context, component.Host) error {
 
 // Shutdown is invoked during service shutdown.
 func (bp *batchProcessor) Shutdown(context.Context) error {
-	close(bp.shutdownC)
+	// Repeated calls are no-ops.
+	bp.shutdownOnce.Do(func() { close(bp.shutdownC) })
 
 	// Wait until all goroutines are done.
 	bp.goroutines.Wait()
+	if bp.stopPressure != nil {
+		bp.stopPressure()
+	}
 	return nil
 }
 
@@ -117,15 +252,9 @@ func (bp *batchProcessor) startProcessingCycle() {
 	for {
 		select {
 		case <-bp.shutdownC:
-		DONE:
-			for {
-				select {
-				case item := <-bp.newItem:
-					bp.processItem(item)
-				default:
-					break DONE
-				}
-			}
+			bp.drainShards()
+			// No more items are enqueued, the items following a missing one are not held anymore.
+			bp.drainReordered(true)
 			// This is the close of the channel
 			if bp.batch.itemCount() > 0 {
 				// TODO: Set a timeout on sendTraces or
@@ -133,12 +262,21 @@ func (bp *batchProcessor) startProcessingCycle() {
 				bp.sendItems(statTimeoutTriggerSend)
 			}
 			return
-		case item := <-bp.newItem:
-			if item == nil {
-				continue
+		case <-bp.flushC:
+			if bp.drainShards() {
+				bp.stopTimer()
+				bp.resetTimer()
 			}
-			bp.processItem(item)
+		case <-bp.pressureC:
+			// Send the batches right away instead of holding them, worsening the memory spike.
+			bp.drainShards()
+			for bp.batch.itemCount() > 0 {
+				bp.sendItems(statMemoryPressureTriggerSend)
+			}
+			bp.stopTimer()
+			bp.resetTimer()
 		case <-bp.timer.C:
+			bp.drainShards()
 			if bp.batch.itemCount() > 0 {
 				bp.sendItems(statTimeoutTriggerSend)
 			}
@@ -147,18 +285,48 @@ func (bp *batchProcessor) startProcessingCycle() {
 	}
 }
 
-func (bp *batchProcessor) processItem(item interface{}) {
+// drainShards merges the items of all the shards into the batch in their enqueuing order, sending
+// it each time it reaches the batch size. Returns whether the batch was sent.
+//
+// An item may be drained before an item enqueued earlier into another shard, when the shards are
+// drained between the sequence number and the push of the earlier item. The later items are then
+// held until the earlier item is drained, so that the items of a caller are never reordered.
+func (bp *batchProcessor) drainShards() bool {
+	for i := range bp.shards {
+		for si := bp.shards[i].drain(); si != nil; {
+			next := si.next
+			si.next = nil
+			heap.Push(&bp.reordered, si)
+			si = next
+		}
+	}
+	return bp.drainReordered(false)
+}
+
+// drainReordered adds the drained items to the batch up to the first missing sequence number,
+// or all of them when force is set. Returns whether the batch was sent.
+func (bp *batchProcessor) drainReordered(force bool) bool {
+	sent := false
+	for bp.reordered.Len() > 0 && (force || bp.reordered[0].seq == bp.nextSeq) {
+		si := heap.Pop(&bp.reordered).(*shardItem)
+		bp.nextSeq = si.seq + 1
+		bp.acks.add(si.count, si.release)
+		if bp.processItem(si.item) {
+			sent = true
+		}
+	}
+	return sent
+}
+
+func (bp *batchProcessor) processItem(item interface{}) bool {
 	bp.batch.add(item)
 	sent := false
-	for bp.batch.itemCount() >= bp.sendBatchSize {
+	sendBatchSize, _ := bp.batchSizes()
+	for bp.batch.itemCount() >= sendBatchSize {
 		sent = true
 		bp.sendItems(statBatchSizeTriggerSend)
 	}
-
-	if sent {
-		bp.stopTimer()
-		bp.resetTimer()
-	}
+	return sent
 }
 
 func (bp *batchProcessor) stopTimer() {
@@ -173,7 +341,21 @@ func (bp *batchProcessor) resetTimer() {
 
 func (bp *batchProcessor) sendItems(triggerMeasure *stats.Int64Measure) {
 	detailed := bp.telemetryLevel == configtelemetry.LevelDetailed
-	sent, bytes, err := bp.batch.export(bp.exportCtx, bp.sendBatchMaxSize, detailed)
+	_, sendBatchMaxSize := bp.batchSizes()
+	exportCtx, exportAck := bp.acks.exportContext(bp.exportCtx)
+	start := time.Now()
+	sent, bytes, err := bp.batch.export(exportCtx, sendBatchMaxSize, detailed)
+	bp.acks.sent(sent, exportAck, err)
+	if bp.adaptive != nil && bp.adaptive.observe(time.Since(start), err) {
+		bp.timeout = bp.adaptive.timeout()
+		bp.logger.Debug("Adjusted the batch size", zap.Int("send_batch_size", bp.adaptive.sendBatchSize()), zap.Duration("timeout", bp.timeout))
+		stats.Record(bp.exportCtx, statAdaptiveSendBatchSize.M(int64(bp.adaptive.sendBatchSize())))
+	}
+	// Wake up the callers waiting for the pending items to be sent.
+	atomic.AddInt64(&bp.pending, -int64(sent))
+	bp.sentMu.Lock()
+	bp.sentC.Broadcast()
+	bp.sentMu.Unlock()
 	if err != nil {
 		bp.logger.Warn("Sender failed", zap.Error(err))
 	} else {
@@ -185,22 +367,60 @@ func (bp *batchProcessor) sendItems(triggerMeasure *stats.Int64Measure) {
 	}
 }
 
+// batchSizes returns the batch size and max size, reduced while the memory is under pressure, the
+// batch size being adjusted when adaptive.
+func (bp *batchProcessor) batchSizes() (sendBatchSize int, sendBatchMaxSize int) {
+	if bp.pressureBatchSize > 0 && memorypressure.UnderPressure() {
+		return bp.pressureBatchSize, bp.pressureBatchSize
+	}
+	if bp.adaptive != nil {
+		return bp.adaptive.sendBatchSize(), bp.sendBatchMaxSize
+	}
+	return bp.sendBatchSize, bp.sendBatchMaxSize
+}
+
+// enqueue adds the item to one of the shards, notifying the processing goroutine when the
+// pending items reach the batch size. It blocks while there are more than maxPending items.
+func (bp *batchProcessor) enqueue(ctx context.Context, item interface{}) {
+	count := bp.batch.countItems(item)
+	if count == 0 {
+		return
+	}
+	si := &shardItem{seq: atomic.AddUint64(&bp.lastSeq, 1), item: item, count: count, release: consumerack.Hold(ctx)}
+	bp.shards[si.seq%uint64(len(bp.shards))].push(si)
+	pending := atomic.AddInt64(&bp.pending, int64(count))
+	sendBatchSize, _ := bp.batchSizes()
+	if pending < int64(sendBatchSize) {
+		return
+	}
+	select {
+	case bp.flushC <- struct{}{}:
+	default:
+	}
+	if pending > bp.maxPending {
+		bp.sentMu.Lock()
+		for atomic.LoadInt64(&bp.pending) > bp.maxPending {
+			bp.sentC.Wait()
+		}
+		bp.sentMu.Unlock()
+	}
+}
+
 // ConsumeTraces implements TracesProcessor
-func (bp *batchProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
-	bp.newItem <- td
+func (bp *batchProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	bp.enqueue(ctx, td)
 	return nil
 }
 
 // ConsumeMetrics implements MetricsProcessor
-func (bp *batchProcessor) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
-	// First thing is convert into a different internal format
-	bp.newItem <- md
+func (bp *batchProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	bp.enqueue(ctx, md)
 	return nil
 }
 
 // ConsumeLogs implements LogsProcessor
-func (bp *batchProcessor) ConsumeLogs(_ context.Context, ld plog.Logs) error {
-	bp.newItem <- ld
+func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	bp.enqueue(ctx, ld)
 	return nil
 }
 
@@ -230,6 +450,10 @@ func newBatchTraces(nextConsumer consumer.Traces) *batchTraces {
 	return &batchTraces{nextConsumer: nextConsumer, traceData: ptrace.NewTraces(), sizer: ptrace.NewProtoMarshaler().(ptrace.Sizer)}
 }
 
+func (bt *batchTraces) countItems(item interface{}) int {
+	return item.(ptrace.Traces).SpanCount()
+}
+
 // add updates current batchTraces by adding new TraceData object
 func (bt *batchTraces) add(item interface{}) {
 	td := item.(ptrace.Traces)
@@ -256,12 +480,35 @@ func (bt *batchTraces) export(ctx context.Context, sendBatchMaxSize int, returnB
 		bt.traceData = ptrace.NewTraces()
 		bt.spanCount = 0
 	}
+	if maxBytes := bt.nextConsumer.Capabilities().MaxRequestBytes; maxBytes > 0 {
+		bytes, err := bt.consumeWithinBytes(ctx, req, maxBytes)
+		return sent, bytes, err
+	}
 	if returnBytes {
 		bytes = bt.sizer.TracesSize(req)
 	}
 	return sent, bytes, bt.nextConsumer.ConsumeTraces(ctx, req)
 }
 
+// consumeWithinBytes sends the data in requests of at most maxBytes, except for the single spans
+// larger than maxBytes, and returns the size of the requests.
+func (bt *batchTraces) consumeWithinBytes(ctx context.Context, req ptrace.Traces, maxBytes int) (int, error) {
+	size := bt.sizer.TracesSize(req)
+	count := req.SpanCount()
+	if size <= maxBytes || count <= 1 {
+		return size, bt.nextConsumer.ConsumeTraces(ctx, req)
+	}
+	n := splitCount(count, size, maxBytes)
+	var bytes int
+	var errs error
+	for remaining := count; remaining > 0; remaining -= n {
+		b, err := bt.consumeWithinBytes(ctx, splitTraces(n, req), maxBytes)
+		bytes += b
+		errs = multierr.Append(errs, err)
+	}
+	return bytes, errs
+}
+
 func (bt *batchTraces) itemCount() int {
 	return bt.spanCount
 }
@@ -291,16 +538,43 @@ func (bm *batchMetrics) export(ctx context.Context, sendBatchMaxSize int, return
 		bm.metricData = pmetric.NewMetrics()
 		bm.dataPointCount = 0
 	}
+	if maxBytes := bm.nextConsumer.Capabilities().MaxRequestBytes; maxBytes > 0 {
+		bytes, err := bm.consumeWithinBytes(ctx, req, maxBytes)
+		return sent, bytes, err
+	}
 	if returnBytes {
 		bytes = bm.sizer.MetricsSize(req)
 	}
 	return sent, bytes, bm.nextConsumer.ConsumeMetrics(ctx, req)
 }
 
+// consumeWithinBytes sends the data in requests of at most maxBytes, except for the single data points
+// larger than maxBytes, and returns the size of the requests.
+func (bm *batchMetrics) consumeWithinBytes(ctx context.Context, req pmetric.Metrics, maxBytes int) (int, error) {
+	size := bm.sizer.MetricsSize(req)
+	count := req.DataPointCount()
+	if size <= maxBytes || count <= 1 {
+		return size, bm.nextConsumer.ConsumeMetrics(ctx, req)
+	}
+	n := splitCount(count, size, maxBytes)
+	var bytes int
+	var errs error
+	for remaining := count; remaining > 0; remaining -= n {
+		b, err := bm.consumeWithinBytes(ctx, splitMetrics(n, req), maxBytes)
+		bytes += b
+		errs = multierr.Append(errs, err)
+	}
+	return bytes, errs
+}
+
 func (bm *batchMetrics) itemCount() int {
 	return bm.dataPointCount
 }
 
+func (bm *batchMetrics) countItems(item interface{}) int {
+	return item.(pmetric.Metrics).DataPointCount()
+}
+
 func (bm *batchMetrics) add(item interface{}) {
 	md := item.(pmetric.Metrics)
 
@@ -337,16 +611,43 @@ func (bl *batchLogs) export(ctx context.Context, sendBatchMaxSize int, returnByt
 		bl.logData = plog.NewLogs()
 		bl.logCount = 0
 	}
+	if maxBytes := bl.nextConsumer.Capabilities().MaxRequestBytes; maxBytes > 0 {
+		bytes, err := bl.consumeWithinBytes(ctx, req, maxBytes)
+		return sent, bytes, err
+	}
 	if returnBytes {
 		bytes = bl.sizer.LogsSize(req)
 	}
 	return sent, bytes, bl.nextConsumer.ConsumeLogs(ctx, req)
 }
 
+// consumeWithinBytes sends the data in requests of at most maxBytes, except for the single log records
+// larger than maxBytes, and returns the size of the requests.
+func (bl *batchLogs) consumeWithinBytes(ctx context.Context, req plog.Logs, maxBytes int) (int, error) {
+	size := bl.sizer.LogsSize(req)
+	count := req.LogRecordCount()
+	if size <= maxBytes || count <= 1 {
+		return size, bl.nextConsumer.ConsumeLogs(ctx, req)
+	}
+	n := splitCount(count, size, maxBytes)
+	var bytes int
+	var errs error
+	for remaining := count; remaining > 0; remaining -= n {
+		b, err := bl.consumeWithinBytes(ctx, splitLogs(n, req), maxBytes)
+		bytes += b
+		errs = multierr.Append(errs, err)
+	}
+	return bytes, errs
+}
+
 func (bl *batchLogs) itemCount() int {
 	return bl.logCount
 }
 
+func (bl *batchLogs) countItems(item interface{}) int {
+	return item.(plog.Logs).LogRecordCount()
+}
+
 func (bl *batchLogs) add(item interface{}) {
 	ld := item.(plog.Logs)
 
@@ -357,3 +658,13 @@ func (bl *batchLogs) add(item interface{}) {
 	bl.logCount += newLogsCount
 	ld.ResourceLogs().MoveAndAppendTo(bl.logData.ResourceLogs())
 }
+
+// splitCount returns the number of items of the requests split to fit maxBytes, estimated from
+// the average size of the items, the requests larger than maxBytes being split again.
+func splitCount(count int, size int, maxBytes int) int {
+	n := int(int64(count) * int64(maxBytes) / int64(size))
+	if n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The benchmarks consume the requests from GOMAXPROCS goroutines, reporting the throughput in
+// items per second. Run with -cpu to compare the throughput for different numbers of callers.
+
+var benchmarkItemsPerRequest = []int{10, 100, 1000}
+
+func BenchmarkBatchProcessorTraces(b *testing.B) {
+	for _, spansPerRequest := range benchmarkItemsPerRequest {
+		b.Run(fmt.Sprintf("%d_spans", spansPerRequest), func(b *testing.B) {
+			batcher, err := newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), consumertest.NewNop(),
+				createDefaultConfig().(*Config), configtelemetry.LevelBasic)
+			require.NoError(b, err)
+			// The requests are generated beforehand since the batch processor mutates them.
+			tds := make([]ptrace.Traces, b.N)
+			for i := range tds {
+				tds[i] = testdata.GenerateTraces(spansPerRequest)
+			}
+			runParallel(b, batcher, spansPerRequest, func(i int) error {
+				return batcher.ConsumeTraces(context.Background(), tds[i])
+			})
+		})
+	}
+}
+
+func BenchmarkBatchProcessorMetrics(b *testing.B) {
+	for _, metricsPerRequest := range benchmarkItemsPerRequest {
+		b.Run(fmt.Sprintf("%d_metrics", metricsPerRequest), func(b *testing.B) {
+			batcher, err := newBatchMetricsProcessor(componenttest.NewNopProcessorCreateSettings(), consumertest.NewNop(),
+				createDefaultConfig().(*Config), configtelemetry.LevelBasic)
+			require.NoError(b, err)
+			mds := make([]pmetric.Metrics, b.N)
+			for i := range mds {
+				mds[i] = testdata.GenerateMetrics(metricsPerRequest)
+			}
+			// Each generated metric has 2 data points.
+			runParallel(b, batcher, 2*metricsPerRequest, func(i int) error {
+				return batcher.ConsumeMetrics(context.Background(), mds[i])
+			})
+		})
+	}
+}
+
+func BenchmarkBatchProcessorLogs(b *testing.B) {
+	for _, logsPerRequest := range benchmarkItemsPerRequest {
+		b.Run(fmt.Sprintf("%d_logs", logsPerRequest), func(b *testing.B) {
+			batcher, err := newBatchLogsProcessor(componenttest.NewNopProcessorCreateSettings(), consumertest.NewNop(),
+				createDefaultConfig().(*Config), configtelemetry.LevelBasic)
+			require.NoError(b, err)
+			lds := make([]plog.Logs, b.N)
+			for i := range lds {
+				lds[i] = testdata.GenerateLogs(logsPerRequest)
+			}
+			runParallel(b, batcher, logsPerRequest, func(i int) error {
+				return batcher.ConsumeLogs(context.Background(), lds[i])
+			})
+		})
+	}
+}
+
+// runParallel consumes the b.N requests, consume being called with the index of the request.
+func runParallel(b *testing.B, batcher *batchProcessor, itemsPerRequest int, consume func(i int) error) {
+	require.NoError(b, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	next := int64(-1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	start := time.Now()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := consume(int(atomic.AddInt64(&next, 1))); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	require.NoError(b, batcher.Shutdown(context.Background()))
+	b.StopTimer()
+	b.ReportMetric(float64(b.N*itemsPerRequest)/time.Since(start).Seconds(), "items/s")
+}
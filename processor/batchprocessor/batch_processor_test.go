@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/memorypressure"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -233,6 +234,43 @@ func TestBatchProcessorSentBySize_withMaxSize(t *testing.T) {
 	assert.Equal(t, sendBatchMaxSize, int(distData.Max))
 }
 
+func TestBatchTracesMaxRequestBytes(t *testing.T) {
+	sizer := ptrace.NewProtoMarshaler().(ptrace.Sizer)
+	td := testdata.GenerateTraces(100)
+	maxBytes := sizer.TracesSize(td) / 7
+
+	sink := new(consumertest.TracesSink)
+	next, err := consumer.NewTraces(sink.ConsumeTraces, consumer.WithCapabilities(consumer.Capabilities{MaxRequestBytes: maxBytes}))
+	require.NoError(t, err)
+	bt := newBatchTraces(next)
+	bt.add(td)
+	sent, _, err := bt.export(context.Background(), 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 100, sent)
+	assert.Equal(t, 100, sink.SpanCount())
+	assert.Greater(t, len(sink.AllTraces()), 1)
+	for _, req := range sink.AllTraces() {
+		assert.LessOrEqual(t, sizer.TracesSize(req), maxBytes)
+	}
+	// The order of the spans is kept.
+	assert.Equal(t, "operationA", sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestBatchTracesMaxRequestBytesSingleSpan(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	next, err := consumer.NewTraces(sink.ConsumeTraces, consumer.WithCapabilities(consumer.Capabilities{MaxRequestBytes: 1}))
+	require.NoError(t, err)
+	bt := newBatchTraces(next)
+	bt.add(testdata.GenerateTraces(3))
+	sent, _, err := bt.export(context.Background(), 2, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	// The spans larger than the max request size are sent one by one.
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 1, sink.AllTraces()[0].SpanCount())
+	assert.Equal(t, 1, bt.itemCount())
+}
+
 func TestBatchProcessorSentByTimeout(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -283,6 +321,68 @@ func TestBatchProcessorSentByTimeout(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorSentByMemoryPressure(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.MemoryPressureSendBatchSize = 10
+	cfg.Timeout = time.Hour
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { memorypressure.Report(t, false) })
+
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(25)))
+	assert.Never(t, func() bool { return sink.SpanCount() > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+
+	// The pending batch is sent right away, split into the reduced batch size.
+	memorypressure.Report(t, true)
+	require.Eventually(t, func() bool { return sink.SpanCount() == 25 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int{10, 10, 5}, spanCounts(sink.AllTraces()))
+
+	// The reduced batch size triggers the sends while under pressure.
+	sink.Reset()
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(25)))
+	require.Eventually(t, func() bool { return sink.SpanCount() == 20 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int{10, 10}, spanCounts(sink.AllTraces()))
+
+	memorypressure.Report(t, false)
+	sink.Reset()
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(25)))
+	assert.Never(t, func() bool { return sink.SpanCount() > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, []int{30}, spanCounts(sink.AllTraces()))
+}
+
+func TestBatchProcessorIgnoresMemoryPressure(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 1000
+	cfg.Timeout = time.Hour
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelBasic)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { memorypressure.Report(t, false) })
+
+	require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(25)))
+	memorypressure.Report(t, true)
+	assert.Never(t, func() bool { return sink.SpanCount() > 0 }, 50*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, []int{25}, spanCounts(sink.AllTraces()))
+}
+
+func spanCounts(tds []ptrace.Traces) []int {
+	counts := make([]int, 0, len(tds))
+	for _, td := range tds {
+		counts = append(counts, td.SpanCount())
+	}
+	return counts
+}
+
 func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	cfg := Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
@@ -444,6 +544,30 @@ func TestBatchMetrics_UnevenBatchMaxSize(t *testing.T) {
 	require.Equal(t, remainingDataPointCount, batchMetrics.dataPointCount)
 }
 
+func TestBatchMetricsMaxRequestBytes(t *testing.T) {
+	sizer := pmetric.NewProtoMarshaler().(pmetric.Sizer)
+	md := testdata.GenerateMetrics(100)
+	maxBytes := sizer.MetricsSize(md) / 5
+
+	sink := new(consumertest.MetricsSink)
+	next, err := consumer.NewMetrics(sink.ConsumeMetrics, consumer.WithCapabilities(consumer.Capabilities{MaxRequestBytes: maxBytes}))
+	require.NoError(t, err)
+	bm := newBatchMetrics(next)
+	dataPoints := md.DataPointCount()
+	bm.add(md)
+	sent, bytes, err := bm.export(context.Background(), 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, dataPoints, sent)
+	assert.Equal(t, dataPoints, sink.DataPointCount())
+	assert.Greater(t, len(sink.AllMetrics()), 1)
+	total := 0
+	for _, req := range sink.AllMetrics() {
+		assert.LessOrEqual(t, sizer.MetricsSize(req), maxBytes)
+		total += sizer.MetricsSize(req)
+	}
+	assert.Equal(t, total, bytes)
+}
+
 func TestBatchMetricsProcessor_Timeout(t *testing.T) {
 	cfg := Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
@@ -741,6 +865,26 @@ func TestBatchLogProcessor_BatchSize(t *testing.T) {
 	assert.Equal(t, size, int(distData.Sum()))
 }
 
+func TestBatchLogsMaxRequestBytes(t *testing.T) {
+	sizer := plog.NewProtoMarshaler().(plog.Sizer)
+	ld := testdata.GenerateLogs(100)
+	maxBytes := sizer.LogsSize(ld) / 3
+
+	sink := new(consumertest.LogsSink)
+	next, err := consumer.NewLogs(sink.ConsumeLogs, consumer.WithCapabilities(consumer.Capabilities{MaxRequestBytes: maxBytes}))
+	require.NoError(t, err)
+	bl := newBatchLogs(next)
+	bl.add(ld)
+	sent, _, err := bl.export(context.Background(), 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 100, sent)
+	assert.Equal(t, 100, sink.LogRecordCount())
+	assert.Greater(t, len(sink.AllLogs()), 1)
+	for _, req := range sink.AllLogs() {
+		assert.LessOrEqual(t, sizer.LogsSize(req), maxBytes)
+	}
+}
+
 func TestBatchLogsProcessor_Timeout(t *testing.T) {
 	cfg := Config{
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
@@ -839,7 +983,152 @@ func logsReceivedBySeverityText(lds []plog.Logs) map[string]plog.LogRecord {
 	return logsReceivedBySeverityText
 }
 
+func TestBatchProcessorConcurrentConsumers(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 100
+	cfg.SendBatchMaxSize = 128
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	consumers := 8
+	requestCount := 100
+	spansPerRequest := 7
+	wg := sync.WaitGroup{}
+	for i := 0; i < consumers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for requestNum := 0; requestNum < requestCount; requestNum++ {
+				assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(spansPerRequest)))
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, consumers*requestCount*spansPerRequest, sink.SpanCount())
+	for _, td := range sink.AllTraces() {
+		assert.LessOrEqual(t, td.SpanCount(), int(cfg.SendBatchMaxSize))
+	}
+}
+
+func TestBatchProcessorBackpressure(t *testing.T) {
+	release := make(chan struct{})
+	sink := &blockingTracesSink{release: release}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first batch blocks in the sink, the following requests accumulate until maxPending.
+	requestCount := int(batcher.maxPending/10) + 2
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for requestNum := 0; requestNum < requestCount; requestNum++ {
+			assert.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(10)))
+		}
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("ConsumeTraces did not block while the pending spans exceed the limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	<-done
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	assert.Equal(t, requestCount*10, sink.SpanCount())
+}
+
+func TestShardDrainOrder(t *testing.T) {
+	s := &shard{}
+	s.head.Store((*shardItem)(nil))
+	assert.Nil(t, s.drain())
+
+	for i := 0; i < 3; i++ {
+		s.push(&shardItem{item: i, count: 1})
+	}
+	var items []interface{}
+	for si := s.drain(); si != nil; si = si.next {
+		items = append(items, si.item)
+	}
+	assert.Equal(t, []interface{}{0, 1, 2}, items)
+	assert.Nil(t, s.drain())
+}
+
+func TestDrainShardsOrder(t *testing.T) {
+	rb := &recordingBatch{}
+	bp, err := newBatchProcessor(componenttest.NewNopProcessorCreateSettings(), createDefaultConfig().(*Config), rb, configtelemetry.LevelBasic)
+	require.NoError(t, err)
+
+	// The items of different shards are merged in their enqueuing order.
+	for _, seq := range []uint64{3, 1, 4, 2} {
+		bp.shards[seq%uint64(len(bp.shards))].push(&shardItem{seq: seq, item: seq, count: 1})
+	}
+	bp.drainShards()
+	assert.Equal(t, []interface{}{uint64(1), uint64(2), uint64(3), uint64(4)}, rb.items)
+
+	// The items following an item not yet pushed into its shard are held until it is.
+	bp.shards[6%uint64(len(bp.shards))].push(&shardItem{seq: 6, item: uint64(6), count: 1})
+	bp.drainShards()
+	assert.Len(t, rb.items, 4)
+	bp.shards[5%uint64(len(bp.shards))].push(&shardItem{seq: 5, item: uint64(5), count: 1})
+	bp.drainShards()
+	assert.Equal(t, []interface{}{uint64(1), uint64(2), uint64(3), uint64(4), uint64(5), uint64(6)}, rb.items)
+
+	// On shutdown, the held items are added regardless of the missing ones.
+	bp.shards[8%uint64(len(bp.shards))].push(&shardItem{seq: 8, item: uint64(8), count: 1})
+	bp.drainShards()
+	bp.drainReordered(true)
+	assert.Equal(t, []interface{}{uint64(1), uint64(2), uint64(3), uint64(4), uint64(5), uint64(6), uint64(8)}, rb.items)
+}
+
+// recordingBatch records the added items, its item count never reaching the batch size.
+type recordingBatch struct {
+	items []interface{}
+}
+
+func (rb *recordingBatch) export(context.Context, int, bool) (int, int, error) {
+	sent := len(rb.items)
+	rb.items = nil
+	return sent, 0, nil
+}
+
+func (rb *recordingBatch) itemCount() int {
+	return 0
+}
+
+func (rb *recordingBatch) countItems(interface{}) int {
+	return 1
+}
+
+func (rb *recordingBatch) add(item interface{}) {
+	rb.items = append(rb.items, item)
+}
+
+// blockingTracesSink blocks the exports until release is closed.
+type blockingTracesSink struct {
+	consumertest.TracesSink
+	release chan struct{}
+}
+
+func (s *blockingTracesSink) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	<-s.release
+	return s.TracesSink.ConsumeTraces(ctx, td)
+}
+
 func TestShutdown(t *testing.T) {
 	factory := NewFactory()
 	componenttest.VerifyProcessorShutdown(t, factory, factory.CreateDefaultConfig())
 }
+
+func TestLifecycle(t *testing.T) {
+	componenttest.CheckLifecycle(t, NewFactory())
+}
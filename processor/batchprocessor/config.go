@@ -16,6 +16,7 @@ package batchprocessor // import "go.opentelemetry.io/collector/processor/batchp
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -35,14 +36,138 @@ type Config struct {
 	// Larger batches are split into smaller units.
 	// Default value is 0, that means no maximum size.
 	SendBatchMaxSize uint32 `mapstructure:"send_batch_max_size"`
+
+	// MemoryPressureSendBatchSize is the size and maximum size of the batches while a memory_limiter processor
+	// reports memory pressure, the current batch being sent as soon as the pressure is reported.
+	// It must be smaller than or equal to SendBatchSize.
+	// Default value is 0, that means the memory pressure is ignored.
+	MemoryPressureSendBatchSize uint32 `mapstructure:"memory_pressure_send_batch_size"`
+
+	// Adaptive adjusts the batch size and timeout to the latency and errors of the next consumer.
+	Adaptive AdaptiveSettings `mapstructure:"adaptive"`
+
+	// Traces, Metrics and Logs override the batch sizes and timeout above for the traces, metrics
+	// and logs pipelines, so that one processor can batch each signal differently.
+	Traces  SignalSettings `mapstructure:"traces"`
+	Metrics SignalSettings `mapstructure:"metrics"`
+	Logs    SignalSettings `mapstructure:"logs"`
+}
+
+// SignalSettings overrides the batch sizes and timeout of the Config for one signal, the unset
+// fields inheriting the values of the Config.
+type SignalSettings struct {
+	Timeout                     *time.Duration `mapstructure:"timeout"`
+	SendBatchSize               *uint32        `mapstructure:"send_batch_size"`
+	SendBatchMaxSize            *uint32        `mapstructure:"send_batch_max_size"`
+	MemoryPressureSendBatchSize *uint32        `mapstructure:"memory_pressure_send_batch_size"`
+}
+
+// AdaptiveSettings defines the bounds within which the batch size and timeout are adjusted, starting
+// from SendBatchSize and Timeout: the batch size grows while the next consumer completes within the
+// TargetLatency with an error rate lower than MaxErrorRate, and is halved otherwise, the timeout
+// following the batch size proportionally between MinTimeout and MaxTimeout.
+type AdaptiveSettings struct {
+	// Enabled indicates whether the batch size and timeout are adjusted.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinSendBatchSize and MaxSendBatchSize are the bounds of the batch size.
+	MinSendBatchSize uint32 `mapstructure:"min_send_batch_size"`
+	MaxSendBatchSize uint32 `mapstructure:"max_send_batch_size"`
+
+	// MinTimeout and MaxTimeout are the bounds of the timeout.
+	MinTimeout time.Duration `mapstructure:"min_timeout"`
+	MaxTimeout time.Duration `mapstructure:"max_timeout"`
+
+	// TargetLatency is the average duration of the sends to the next consumer above which the batch size
+	// is reduced.
+	TargetLatency time.Duration `mapstructure:"target_latency"`
+
+	// MaxErrorRate is the ratio of the sends to the next consumer failing above which the batch size is
+	// reduced.
+	MaxErrorRate float64 `mapstructure:"max_error_rate"`
 }
 
 var _ config.Processor = (*Config)(nil)
 
+// forSignal returns the configuration of the processor of the data type, with the settings of
+// the signal applied.
+func (cfg *Config) forSignal(dataType config.DataType) *Config {
+	var ss SignalSettings
+	switch dataType {
+	case config.TracesDataType:
+		ss = cfg.Traces
+	case config.MetricsDataType:
+		ss = cfg.Metrics
+	case config.LogsDataType:
+		ss = cfg.Logs
+	}
+
+	ret := *cfg
+	if ss.Timeout != nil {
+		ret.Timeout = *ss.Timeout
+	}
+	if ss.SendBatchSize != nil {
+		ret.SendBatchSize = *ss.SendBatchSize
+	}
+	if ss.SendBatchMaxSize != nil {
+		ret.SendBatchMaxSize = *ss.SendBatchMaxSize
+	}
+	if ss.MemoryPressureSendBatchSize != nil {
+		ret.MemoryPressureSendBatchSize = *ss.MemoryPressureSendBatchSize
+	}
+	return &ret
+}
+
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	if err := cfg.validateBatch(); err != nil {
+		return err
+	}
+	for _, dataType := range []config.DataType{config.TracesDataType, config.MetricsDataType, config.LogsDataType} {
+		if err := cfg.forSignal(dataType).validateBatch(); err != nil {
+			return fmt.Errorf("%s: %w", dataType, err)
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) validateBatch() error {
 	if cfg.SendBatchMaxSize > 0 && cfg.SendBatchMaxSize < cfg.SendBatchSize {
 		return errors.New("send_batch_max_size must be greater or equal to send_batch_size")
 	}
+	if cfg.MemoryPressureSendBatchSize > cfg.SendBatchSize {
+		return errors.New("memory_pressure_send_batch_size must be smaller or equal to send_batch_size")
+	}
+	if cfg.Adaptive.Enabled {
+		if err := cfg.Adaptive.Validate(); err != nil {
+			return fmt.Errorf("adaptive: %w", err)
+		}
+		if cfg.SendBatchMaxSize > 0 && cfg.SendBatchMaxSize < cfg.Adaptive.MaxSendBatchSize {
+			return errors.New("send_batch_max_size must be greater or equal to adaptive::max_send_batch_size")
+		}
+	}
+	return nil
+}
+
+// Validate checks if the adaptive settings are valid
+func (aCfg *AdaptiveSettings) Validate() error {
+	if aCfg.MinSendBatchSize == 0 {
+		return errors.New("min_send_batch_size must be positive")
+	}
+	if aCfg.MaxSendBatchSize < aCfg.MinSendBatchSize {
+		return errors.New("max_send_batch_size must be greater or equal to min_send_batch_size")
+	}
+	if aCfg.MinTimeout <= 0 {
+		return errors.New("min_timeout must be positive")
+	}
+	if aCfg.MaxTimeout < aCfg.MinTimeout {
+		return errors.New("max_timeout must be greater or equal to min_timeout")
+	}
+	if aCfg.TargetLatency <= 0 {
+		return errors.New("target_latency must be positive")
+	}
+	if aCfg.MaxErrorRate < 0 || aCfg.MaxErrorRate > 1 {
+		return errors.New("max_error_rate must be between 0 and 1")
+	}
 	return nil
 }
@@ -53,7 +53,80 @@ func TestLoadConfig(t *testing.T) {
 			SendBatchSize:     sendBatchSize,
 			SendBatchMaxSize:  sendBatchMaxSize,
 			Timeout:           timeout,
+			Adaptive:          factory.CreateDefaultConfig().(*Config).Adaptive,
 		})
+
+	p2 := cfg.Processors[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, p2,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "3")),
+			SendBatchSize:     defaultSendBatchSize,
+			Timeout:           defaultTimeout,
+			Adaptive: AdaptiveSettings{
+				Enabled:          true,
+				MinSendBatchSize: 1000,
+				MaxSendBatchSize: 20000,
+				MinTimeout:       defaultAdaptiveMinTimeout,
+				MaxTimeout:       5 * time.Second,
+				TargetLatency:    defaultAdaptiveTargetLatency,
+				MaxErrorRate:     defaultAdaptiveMaxErrorRate,
+			},
+		})
+
+	p3 := cfg.Processors[config.NewComponentIDWithName(typeStr, "4")]
+	tracesTimeout := time.Second
+	tracesSendBatchSize := uint32(4096)
+	logsSendBatchSize := uint32(16384)
+	logsSendBatchMaxSize := uint32(32768)
+	assert.Equal(t, p3,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "4")),
+			SendBatchSize:     defaultSendBatchSize,
+			Timeout:           defaultTimeout,
+			Adaptive:          factory.CreateDefaultConfig().(*Config).Adaptive,
+			Traces: SignalSettings{
+				Timeout:       &tracesTimeout,
+				SendBatchSize: &tracesSendBatchSize,
+			},
+			Logs: SignalSettings{
+				SendBatchSize:    &logsSendBatchSize,
+				SendBatchMaxSize: &logsSendBatchMaxSize,
+			},
+		})
+}
+
+func TestConfig_ForSignal(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchMaxSize = 10000
+	timeout := time.Second
+	sendBatchSize := uint32(100)
+	sendBatchMaxSize := uint32(0)
+	cfg.Traces = SignalSettings{Timeout: &timeout, SendBatchSize: &sendBatchSize}
+	cfg.Logs = SignalSettings{SendBatchMaxSize: &sendBatchMaxSize}
+
+	traces := cfg.forSignal(config.TracesDataType)
+	assert.Equal(t, time.Second, traces.Timeout)
+	assert.Equal(t, uint32(100), traces.SendBatchSize)
+	assert.Equal(t, uint32(10000), traces.SendBatchMaxSize)
+
+	metrics := cfg.forSignal(config.MetricsDataType)
+	assert.Equal(t, defaultTimeout, metrics.Timeout)
+	assert.Equal(t, defaultSendBatchSize, metrics.SendBatchSize)
+	assert.Equal(t, uint32(10000), metrics.SendBatchMaxSize)
+
+	logs := cfg.forSignal(config.LogsDataType)
+	assert.Equal(t, defaultSendBatchSize, logs.SendBatchSize)
+	assert.Equal(t, uint32(0), logs.SendBatchMaxSize)
+
+	// The processor configuration is left unchanged.
+	assert.Equal(t, defaultSendBatchSize, cfg.SendBatchSize)
+}
+
+func TestValidateConfig_InvalidSignalBatchSize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sendBatchMaxSize := uint32(100)
+	cfg.Metrics.SendBatchMaxSize = &sendBatchMaxSize
+	assert.EqualError(t, cfg.Validate(), "metrics: send_batch_max_size must be greater or equal to send_batch_size")
 }
 
 func TestValidateConfig_DefaultBatchMaxSize(t *testing.T) {
@@ -83,3 +156,78 @@ func TestValidateConfig_InvalidBatchSize(t *testing.T) {
 	}
 	assert.Error(t, cfg.Validate())
 }
+
+func TestValidateConfig_InvalidMemoryPressureBatchSize(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings:           config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "2")),
+		SendBatchSize:               100,
+		MemoryPressureSendBatchSize: 1000,
+	}
+	assert.EqualError(t, cfg.Validate(), "memory_pressure_send_batch_size must be smaller or equal to send_batch_size")
+}
+
+func TestValidateConfig_Adaptive(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{
+			name:   "disabled",
+			modify: func(cfg *Config) { cfg.Adaptive = AdaptiveSettings{} },
+		},
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:     "zero_min_send_batch_size",
+			modify:   func(cfg *Config) { cfg.Adaptive.MinSendBatchSize = 0 },
+			expected: "adaptive: min_send_batch_size must be positive",
+		},
+		{
+			name:     "invalid_max_send_batch_size",
+			modify:   func(cfg *Config) { cfg.Adaptive.MaxSendBatchSize = 100 },
+			expected: "adaptive: max_send_batch_size must be greater or equal to min_send_batch_size",
+		},
+		{
+			name:     "zero_min_timeout",
+			modify:   func(cfg *Config) { cfg.Adaptive.MinTimeout = 0 },
+			expected: "adaptive: min_timeout must be positive",
+		},
+		{
+			name:     "invalid_max_timeout",
+			modify:   func(cfg *Config) { cfg.Adaptive.MaxTimeout = time.Millisecond },
+			expected: "adaptive: max_timeout must be greater or equal to min_timeout",
+		},
+		{
+			name:     "zero_target_latency",
+			modify:   func(cfg *Config) { cfg.Adaptive.TargetLatency = 0 },
+			expected: "adaptive: target_latency must be positive",
+		},
+		{
+			name:     "invalid_max_error_rate",
+			modify:   func(cfg *Config) { cfg.Adaptive.MaxErrorRate = 1.5 },
+			expected: "adaptive: max_error_rate must be between 0 and 1",
+		},
+		{
+			name: "small_send_batch_max_size",
+			modify: func(cfg *Config) {
+				cfg.SendBatchMaxSize = 10000
+			},
+			expected: "send_batch_max_size must be greater or equal to adaptive::max_send_batch_size",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Adaptive.Enabled = true
+			tt.modify(cfg)
+			if tt.expected == "" {
+				assert.NoError(t, cfg.Validate())
+				return
+			}
+			assert.EqualError(t, cfg.Validate(), tt.expected)
+		})
+	}
+}
@@ -29,6 +29,13 @@ const (
 
 	defaultSendBatchSize = uint32(8192)
 	defaultTimeout       = 200 * time.Millisecond
+
+	defaultAdaptiveMinSendBatchSize = uint32(512)
+	defaultAdaptiveMaxSendBatchSize = uint32(16384)
+	defaultAdaptiveMinTimeout       = 50 * time.Millisecond
+	defaultAdaptiveMaxTimeout       = 2 * time.Second
+	defaultAdaptiveTargetLatency    = time.Second
+	defaultAdaptiveMaxErrorRate     = 0.05
 )
 
 // NewFactory returns a new factory for the Batch processor.
@@ -46,6 +53,14 @@ func createDefaultConfig() config.Processor {
 		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
 		SendBatchSize:     defaultSendBatchSize,
 		Timeout:           defaultTimeout,
+		Adaptive: AdaptiveSettings{
+			MinSendBatchSize: defaultAdaptiveMinSendBatchSize,
+			MaxSendBatchSize: defaultAdaptiveMaxSendBatchSize,
+			MinTimeout:       defaultAdaptiveMinTimeout,
+			MaxTimeout:       defaultAdaptiveMaxTimeout,
+			TargetLatency:    defaultAdaptiveTargetLatency,
+			MaxErrorRate:     defaultAdaptiveMaxErrorRate,
+		},
 	}
 }
 
@@ -56,7 +71,7 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (component.TracesProcessor, error) {
 	level := set.MetricsLevel
-	return newBatchTracesProcessor(set, nextConsumer, cfg.(*Config), level)
+	return newBatchTracesProcessor(set, nextConsumer, cfg.(*Config).forSignal(config.TracesDataType), level)
 }
 
 func createMetricsProcessor(
@@ -66,7 +81,7 @@ func createMetricsProcessor(
 	nextConsumer consumer.Metrics,
 ) (component.MetricsProcessor, error) {
 	level := set.MetricsLevel
-	return newBatchMetricsProcessor(set, nextConsumer, cfg.(*Config), level)
+	return newBatchMetricsProcessor(set, nextConsumer, cfg.(*Config).forSignal(config.MetricsDataType), level)
 }
 
 func createLogsProcessor(
@@ -76,5 +91,5 @@ func createLogsProcessor(
 	nextConsumer consumer.Logs,
 ) (component.LogsProcessor, error) {
 	level := set.MetricsLevel
-	return newBatchLogsProcessor(set, nextConsumer, cfg.(*Config), level)
+	return newBatchLogsProcessor(set, nextConsumer, cfg.(*Config).forSignal(config.LogsDataType), level)
 }
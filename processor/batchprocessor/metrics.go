@@ -24,11 +24,13 @@ import (
 )
 
 var (
-	processorTagKey          = tag.MustNewKey(obsmetrics.ProcessorKey)
-	statBatchSizeTriggerSend = stats.Int64("batch_size_trigger_send", "Number of times the batch was sent due to a size trigger", stats.UnitDimensionless)
-	statTimeoutTriggerSend   = stats.Int64("timeout_trigger_send", "Number of times the batch was sent due to a timeout trigger", stats.UnitDimensionless)
-	statBatchSendSize        = stats.Int64("batch_send_size", "Number of units in the batch", stats.UnitDimensionless)
-	statBatchSendSizeBytes   = stats.Int64("batch_send_size_bytes", "Number of bytes in batch that was sent", stats.UnitBytes)
+	processorTagKey               = tag.MustNewKey(obsmetrics.ProcessorKey)
+	statBatchSizeTriggerSend      = stats.Int64("batch_size_trigger_send", "Number of times the batch was sent due to a size trigger", stats.UnitDimensionless)
+	statTimeoutTriggerSend        = stats.Int64("timeout_trigger_send", "Number of times the batch was sent due to a timeout trigger", stats.UnitDimensionless)
+	statMemoryPressureTriggerSend = stats.Int64("memory_pressure_trigger_send", "Number of times the batch was sent due to a memory pressure trigger", stats.UnitDimensionless)
+	statBatchSendSize             = stats.Int64("batch_send_size", "Number of units in the batch", stats.UnitDimensionless)
+	statBatchSendSizeBytes        = stats.Int64("batch_send_size_bytes", "Number of bytes in batch that was sent", stats.UnitBytes)
+	statAdaptiveSendBatchSize     = stats.Int64("adaptive_send_batch_size", "Batch size adjusted to the latency and errors of the next consumer", stats.UnitDimensionless)
 )
 
 // MetricViews returns the metrics views related to batching
@@ -51,6 +53,14 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countMemoryPressureTriggerSendView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statMemoryPressureTriggerSend.Name()),
+		Measure:     statMemoryPressureTriggerSend,
+		Description: statMemoryPressureTriggerSend.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.Sum(),
+	}
+
 	distributionBatchSendSizeView := &view.View{
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statBatchSendSize.Name()),
 		Measure:     statBatchSendSize,
@@ -69,10 +79,20 @@ func MetricViews() []*view.View {
 			1000_000, 2000_000, 3000_000, 4000_000, 5000_000, 6000_000, 7000_000, 8000_000, 9000_000),
 	}
 
+	lastValueAdaptiveSendBatchSizeView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statAdaptiveSendBatchSize.Name()),
+		Measure:     statAdaptiveSendBatchSize,
+		Description: statAdaptiveSendBatchSize.Description(),
+		TagKeys:     processorTagKeys,
+		Aggregation: view.LastValue(),
+	}
+
 	return []*view.View{
 		countBatchSizeTriggerSendView,
 		countTimeoutTriggerSendView,
+		countMemoryPressureTriggerSendView,
 		distributionBatchSendSizeView,
 		distributionBatchSendSizeBytesView,
+		lastValueAdaptiveSendBatchSizeView,
 	}
 }
@@ -24,8 +24,10 @@ func TestBatchProcessorMetrics(t *testing.T) {
 	viewNames := []string{
 		"batch_size_trigger_send",
 		"timeout_trigger_send",
+		"memory_pressure_trigger_send",
 		"batch_send_size",
 		"batch_send_size_bytes",
+		"adaptive_send_batch_size",
 	}
 	views := MetricViews()
 	for i, viewName := range viewNames {
@@ -47,6 +47,11 @@ type Config struct {
 	// MemorySpikePercentage is the maximum, in percents against the total memory,
 	// spike expected between the measurements of memory usage.
 	MemorySpikePercentage uint32 `mapstructure:"spike_limit_percentage"`
+
+	// QueueLimitMiB is the maximum amount of data, in MiB, held by the components, e.g. the
+	// sending queues of the exporters, above which the data is refused. Defaults to zero,
+	// so the data held by the components is not limited.
+	QueueLimitMiB uint32 `mapstructure:"queue_limit_mib"`
 }
 
 var _ config.Processor = (*Config)(nil)
@@ -52,5 +52,6 @@ func TestLoadConfig(t *testing.T) {
 			CheckInterval:       5 * time.Second,
 			MemoryLimitMiB:      4000,
 			MemorySpikeLimitMiB: 500,
+			QueueLimitMiB:       1000,
 		})
 }
@@ -16,27 +16,20 @@ package memorylimiterprocessor
 
 import (
 	"context"
-	"runtime"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/atomic"
-	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
-	"go.opentelemetry.io/collector/config"
-	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
-	"go.opentelemetry.io/collector/extension/ballastextension"
-	"go.opentelemetry.io/collector/internal/iruntime"
-	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/internal/memorylimiter"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
-	"go.opentelemetry.io/collector/processor/processorhelper"
 )
 
 func TestNew(t *testing.T) {
@@ -57,7 +50,7 @@ func TestNew(t *testing.T) {
 			args: args{
 				nextConsumer: sink,
 			},
-			wantErr: errCheckIntervalOutOfRange,
+			wantErr: memorylimiter.ErrCheckIntervalOutOfRange,
 		},
 		{
 			name: "zero_memAllocLimit",
@@ -65,7 +58,7 @@ func TestNew(t *testing.T) {
 				nextConsumer:  sink,
 				checkInterval: 100 * time.Millisecond,
 			},
-			wantErr: errLimitOutOfRange,
+			wantErr: memorylimiter.ErrLimitOutOfRange,
 		},
 		{
 			name: "memSpikeLimit_gt_memAllocLimit",
@@ -75,7 +68,7 @@ func TestNew(t *testing.T) {
 				memoryLimitMiB:      1,
 				memorySpikeLimitMiB: 2,
 			},
-			wantErr: errMemSpikeLimitOutOfRange,
+			wantErr: memorylimiter.ErrMemSpikeLimitOutOfRange,
 		},
 		{
 			name: "success",
@@ -104,353 +97,61 @@ func TestNew(t *testing.T) {
 	}
 }
 
-// TestMetricsMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
-func TestMetricsMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
-	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		forceDrop: atomic.NewBool(false),
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
-			Level:       configtelemetry.LevelNone,
-			ProcessorID: config.NewComponentID(typeStr),
-		}),
-
-		logger: zap.NewNop(),
+// allocatedMiB is kept alive while checking that the processors above their limit refuse data.
+const allocatedMiB = 8
+
+// newTestConfig returns a configuration whose limit is far above the memory usage if
+// refusing is false, and below the memory kept alive by the tests if refusing is true.
+func newTestConfig(refusing bool) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CheckInterval = 10 * time.Millisecond
+	cfg.MemoryLimitMiB = 1 << 20
+	if refusing {
+		cfg.MemoryLimitMiB = allocatedMiB / 2
 	}
-	mp, err := processorhelper.NewMetricsProcessor(
-		&Config{
-			ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
-		},
-		consumertest.NewNop(),
-		ml.processMetrics,
-		processorhelper.WithCapabilities(processorCapabilities),
-		processorhelper.WithShutdown(ml.shutdown))
-	require.NoError(t, err)
-
-	ctx := context.Background()
-	md := pmetric.NewMetrics()
-
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
-	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
-
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
-
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
-
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
-
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
-	assert.NoError(t, mp.ConsumeMetrics(ctx, md))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, mp.ConsumeMetrics(ctx, md))
-
+	return cfg
 }
 
-// TestTraceMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
-func TestTraceMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
-	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		forceDrop: atomic.NewBool(false),
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
-			Level:       configtelemetry.LevelNone,
-			ProcessorID: config.NewComponentID(typeStr),
-		}),
-		logger: zap.NewNop(),
-	}
-	tp, err := processorhelper.NewTracesProcessor(
-		&Config{
-			ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
-		},
-		consumertest.NewNop(),
-		ml.processTraces,
-		processorhelper.WithCapabilities(processorCapabilities),
-		processorhelper.WithShutdown(ml.shutdown))
-	require.NoError(t, err)
-
+// checkMemoryPressureResponse checks that the processor accepts data within the limit, then
+// refuses it once the memory usage is above the limit.
+func checkMemoryPressureResponse(t *testing.T, create func(cfg *Config) (component.Processor, func() error)) {
 	ctx := context.Background()
-	td := ptrace.NewTraces()
-
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
-	assert.NoError(t, tp.ConsumeTraces(ctx, td))
-
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
-
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.NoError(t, tp.ConsumeTraces(ctx, td))
-
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
-
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
-	assert.NoError(t, tp.ConsumeTraces(ctx, td))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, tp.ConsumeTraces(ctx, td))
 
+	p, consume := create(newTestConfig(false))
+	require.NoError(t, p.Start(ctx, componenttest.NewNopHost()))
+	assert.NoError(t, consume())
+	assert.NoError(t, p.Shutdown(ctx))
+
+	allocated := make([]byte, allocatedMiB*1024*1024)
+	p, consume = create(newTestConfig(true))
+	require.NoError(t, p.Start(ctx, componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		return consume() == errForcedDrop
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, p.Shutdown(ctx))
+	assert.Len(t, allocated, allocatedMiB*1024*1024)
 }
 
-// TestLogMemoryPressureResponse manipulates results from querying memory and
-// check expected side effects.
-func TestLogMemoryPressureResponse(t *testing.T) {
-	var currentMemAlloc uint64
-	ml := &memoryLimiter{
-		usageChecker: memUsageChecker{
-			memAllocLimit: 1024,
-		},
-		forceDrop: atomic.NewBool(false),
-		readMemStatsFn: func(ms *runtime.MemStats) {
-			ms.Alloc = currentMemAlloc
-		},
-		obsrep: obsreport.NewProcessor(obsreport.ProcessorSettings{
-			Level:       configtelemetry.LevelNone,
-			ProcessorID: config.NewComponentID(typeStr),
-		}),
-		logger: zap.NewNop(),
-	}
-	lp, err := processorhelper.NewLogsProcessor(
-		&Config{
-			ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
-		},
-		consumertest.NewNop(),
-		ml.processLogs,
-		processorhelper.WithCapabilities(processorCapabilities),
-		processorhelper.WithShutdown(ml.shutdown))
-	require.NoError(t, err)
-
-	ctx := context.Background()
-	ld := plog.NewLogs()
-
-	// Below memAllocLimit.
-	currentMemAlloc = 800
-	ml.checkMemLimits()
-	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
-
-	// Above memAllocLimit.
-	currentMemAlloc = 1800
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
-
-	// Check ballast effect
-	ml.ballastSize = 1000
-
-	// Below memAllocLimit accounting for ballast.
-	currentMemAlloc = 800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
-
-	// Above memAllocLimit even accountiing for ballast.
-	currentMemAlloc = 1800 + ml.ballastSize
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
-
-	// Restore ballast to default.
-	ml.ballastSize = 0
-
-	// Check spike limit
-	ml.usageChecker.memSpikeLimit = 512
-
-	// Below memSpikeLimit.
-	currentMemAlloc = 500
-	ml.checkMemLimits()
-	assert.NoError(t, lp.ConsumeLogs(ctx, ld))
-
-	// Above memSpikeLimit.
-	currentMemAlloc = 550
-	ml.checkMemLimits()
-	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
-}
-
-func TestGetDecision(t *testing.T) {
-	t.Run("fixed_limit", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 100, MemorySpikeLimitMiB: 20}, zap.NewNop())
+func TestMetricsMemoryPressureResponse(t *testing.T) {
+	checkMemoryPressureResponse(t, func(cfg *Config) (component.Processor, func() error) {
+		mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
 		require.NoError(t, err)
-		assert.Equal(t, &memUsageChecker{
-			memAllocLimit: 100 * mibBytes,
-			memSpikeLimit: 20 * mibBytes,
-		}, d)
-	})
-	t.Run("fixed_limit_error", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitMiB: 20, MemorySpikeLimitMiB: 100}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
+		return mp, func() error { return mp.ConsumeMetrics(context.Background(), pmetric.NewMetrics()) }
 	})
+}
 
-	t.Cleanup(func() {
-		getMemoryFn = iruntime.TotalMemory
-	})
-	getMemoryFn = func() (uint64, error) {
-		return 100 * mibBytes, nil
-	}
-	t.Run("percentage_limit", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 50, MemorySpikePercentage: 10}, zap.NewNop())
+func TestTraceMemoryPressureResponse(t *testing.T) {
+	checkMemoryPressureResponse(t, func(cfg *Config) (component.Processor, func() error) {
+		tp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
 		require.NoError(t, err)
-		assert.Equal(t, &memUsageChecker{
-			memAllocLimit: 50 * mibBytes,
-			memSpikeLimit: 10 * mibBytes,
-		}, d)
+		return tp, func() error { return tp.ConsumeTraces(context.Background(), ptrace.NewTraces()) }
 	})
-	t.Run("percentage_limit_error", func(t *testing.T) {
-		d, err := getMemUsageChecker(&Config{MemoryLimitPercentage: 101, MemorySpikePercentage: 10}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-		d, err = getMemUsageChecker(&Config{MemoryLimitPercentage: 99, MemorySpikePercentage: 101}, zap.NewNop())
-		require.Error(t, err)
-		assert.Nil(t, d)
-	})
-}
-
-func TestDropDecision(t *testing.T) {
-	decison1000Limit30Spike30, err := newPercentageMemUsageChecker(1000, 60, 30)
-	require.NoError(t, err)
-	decison1000Limit60Spike50, err := newPercentageMemUsageChecker(1000, 60, 50)
-	require.NoError(t, err)
-	decison1000Limit40Spike20, err := newPercentageMemUsageChecker(1000, 40, 20)
-	require.NoError(t, err)
-	decison1000Limit40Spike60, err := newPercentageMemUsageChecker(1000, 40, 60)
-	require.Error(t, err)
-	assert.Nil(t, decison1000Limit40Spike60)
-
-	tests := []struct {
-		name         string
-		usageChecker memUsageChecker
-		ms           *runtime.MemStats
-		shouldDrop   bool
-	}{
-		{
-			name:         "should drop over limit",
-			usageChecker: *decison1000Limit30Spike30,
-			ms:           &runtime.MemStats{Alloc: 600},
-			shouldDrop:   true,
-		},
-		{
-			name:         "should not drop",
-			usageChecker: *decison1000Limit30Spike30,
-			ms:           &runtime.MemStats{Alloc: 100},
-			shouldDrop:   false,
-		},
-		{
-			name: "should not drop spike, fixed usageChecker",
-			usageChecker: memUsageChecker{
-				memAllocLimit: 600,
-				memSpikeLimit: 500,
-			},
-			ms:         &runtime.MemStats{Alloc: 300},
-			shouldDrop: true,
-		},
-		{
-			name:         "should drop, spike, percentage usageChecker",
-			usageChecker: *decison1000Limit60Spike50,
-			ms:           &runtime.MemStats{Alloc: 300},
-			shouldDrop:   true,
-		},
-		{
-			name:         "should drop, spike, percentage usageChecker",
-			usageChecker: *decison1000Limit40Spike20,
-			ms:           &runtime.MemStats{Alloc: 250},
-			shouldDrop:   true,
-		},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			shouldDrop := test.usageChecker.aboveSoftLimit(test.ms)
-			assert.Equal(t, test.shouldDrop, shouldDrop)
-		})
-	}
 }
 
-func TestBallastSizeMiB(t *testing.T) {
-	ctx := context.Background()
-	ballastExtFactory := ballastextension.NewFactory()
-	ballastExtCfg := ballastExtFactory.CreateDefaultConfig().(*ballastextension.Config)
-	ballastExtCfg.SizeMiB = 100
-	extCreateSet := componenttest.NewNopExtensionCreateSettings()
-
-	tests := []struct {
-		name                          string
-		ballastExtBallastSizeSetting  uint64
-		expectedMemLimiterBallastSize uint64
-		expectResult                  bool
-	}{
-		{
-			name:                          "ballast size matched",
-			ballastExtBallastSizeSetting:  100,
-			expectedMemLimiterBallastSize: 100,
-			expectResult:                  true,
-		},
-		{
-			name:                          "ballast size not matched",
-			ballastExtBallastSizeSetting:  1000,
-			expectedMemLimiterBallastSize: 100,
-			expectResult:                  false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ballastExtCfg.SizeMiB = tt.ballastExtBallastSizeSetting
-			ballastExt, _ := ballastExtFactory.CreateExtension(ctx, extCreateSet, ballastExtCfg)
-			require.NoError(t, ballastExt.Start(ctx, nil))
-			assert.Equal(t, tt.expectResult, tt.expectedMemLimiterBallastSize*mibBytes == ballastExt.(*ballastextension.MemoryBallast).GetBallastSize())
-		})
-	}
+func TestLogMemoryPressureResponse(t *testing.T) {
+	checkMemoryPressureResponse(t, func(cfg *Config) (component.Processor, func() error) {
+		lp, err := NewFactory().CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+		require.NoError(t, err)
+		return lp, func() error { return lp.ConsumeLogs(context.Background(), plog.NewLogs()) }
+	})
 }
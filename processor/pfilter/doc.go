@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pfilter provides boolean expressions matching spans, log records and metrics,
+// to be reused by the components filtering telemetry.
+//
+// An expression compares paths with literals, and combines the comparisons with the
+// "and", "or" and "not" operators and parentheses:
+//
+//	resource.attributes["service.name"] == "checkout" and (attributes["http.status_code"] >= 500 or name =~ "^GET ")
+//
+// The supported comparison operators are ==, !=, <, <=, >, >=, =~ (matches the regular
+// expression) and !~ (does not match the regular expression). Literals are double-quoted
+// strings, integers, floats, true, false and nil. Comparing a path with nil checks whether
+// the value is set.
+//
+// The supported paths are:
+//   - resource.attributes["key"]: the resource attributes.
+//   - scope.name, scope.version: the instrumentation scope name and version.
+//   - attributes["key"]: the span or log record attributes, always nil for metrics.
+//   - name: the span or metric name.
+//   - kind, status.code: the span kind and status code, e.g. "SPAN_KIND_SERVER" or "STATUS_CODE_ERROR".
+//   - body, severity_text, severity_number: the log record body, severity text and number.
+//   - description, unit, type: the metric description, unit and type, e.g. "Gauge" or "Sum".
+//
+// Paths that do not apply to the matched telemetry are nil.
+package pfilter // import "go.opentelemetry.io/collector/processor/pfilter"
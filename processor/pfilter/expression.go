@@ -0,0 +1,359 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfilter // import "go.opentelemetry.io/collector/processor/pfilter"
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Expression is a parsed boolean expression matching spans, log records or metrics.
+// Use Parse to create new instances, an Expression is safe for concurrent use.
+type Expression struct {
+	expr string
+	root node
+}
+
+// Parse parses the given expression, see the package documentation for its syntax.
+func Parse(expr string) (*Expression, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, newSyntaxError(tok.offset, "unexpected %q", tok.text)
+	}
+	return &Expression{expr: expr, root: root}, nil
+}
+
+// String returns the expression as passed to Parse.
+func (e *Expression) String() string {
+	return e.expr
+}
+
+// node is a node of the expression tree.
+type node interface {
+	eval(ctx evalContext) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(ctx evalContext) bool {
+	return n.left.eval(ctx) && n.right.eval(ctx)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(ctx evalContext) bool {
+	return n.left.eval(ctx) || n.right.eval(ctx)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(ctx evalContext) bool {
+	return !n.operand.eval(ctx)
+}
+
+// attributesPaths are the paths of the attributes maps, followed by the attribute key between brackets.
+var attributesPaths = map[string]pathKind{
+	"resource.attributes": pathResourceAttributes,
+	"attributes":          pathAttributes,
+}
+
+// fieldPaths are the paths of the fields.
+var fieldPaths = map[string]bool{
+	"scope.name":      true,
+	"scope.version":   true,
+	"name":            true,
+	"kind":            true,
+	"status.code":     true,
+	"body":            true,
+	"severity_text":   true,
+	"severity_number": true,
+	"description":     true,
+	"unit":            true,
+	"type":            true,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && tok.text == keyword
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isKeyword("not") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, newSyntaxError(tok.offset, "expected \")\"")
+		}
+		return n, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	pth, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+
+	opTok := p.next()
+	if opTok.kind != tokenOperator {
+		return nil, newSyntaxError(opTok.offset, "expected a comparison operator")
+	}
+
+	litTok := p.next()
+	lit, err := parseLiteral(litTok)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := comparisonNode{path: pth, op: opTok.text, literal: lit}
+	switch cmp.op {
+	case "=~", "!~":
+		pattern, ok := lit.(string)
+		if !ok {
+			return nil, newSyntaxError(litTok.offset, "operator %q requires a string regular expression", cmp.op)
+		}
+		if cmp.regexp, err = regexp.Compile(pattern); err != nil {
+			return nil, newSyntaxError(litTok.offset, "invalid regular expression: %v", err)
+		}
+	case "<", "<=", ">", ">=":
+		switch lit.(type) {
+		case string, int64, float64:
+		default:
+			return nil, newSyntaxError(litTok.offset, "operator %q requires a string or number", cmp.op)
+		}
+	}
+	return cmp, nil
+}
+
+func (p *parser) parsePath() (path, error) {
+	tok := p.next()
+	if tok.kind != tokenIdent {
+		return path{}, newSyntaxError(tok.offset, "expected a path")
+	}
+	if kind, ok := attributesPaths[tok.text]; ok {
+		if open := p.next(); open.kind != tokenLBracket {
+			return path{}, newSyntaxError(open.offset, "expected \"[\" after %q", tok.text)
+		}
+		key := p.next()
+		if key.kind != tokenString {
+			return path{}, newSyntaxError(key.offset, "expected a string attribute key")
+		}
+		if closing := p.next(); closing.kind != tokenRBracket {
+			return path{}, newSyntaxError(closing.offset, "expected \"]\"")
+		}
+		return path{kind: kind, name: key.text}, nil
+	}
+	if fieldPaths[tok.text] {
+		return path{kind: pathField, name: tok.text}, nil
+	}
+	return path{}, newSyntaxError(tok.offset, "unknown path %q", tok.text)
+}
+
+func parseLiteral(tok token) (interface{}, error) {
+	switch tok.kind {
+	case tokenString:
+		return tok.text, nil
+	case tokenNumber:
+		if i, err := strconv.ParseInt(tok.text, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, newSyntaxError(tok.offset, "invalid number %q", tok.text)
+		}
+		return f, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "nil":
+			return nil, nil
+		}
+	}
+	return nil, newSyntaxError(tok.offset, "expected a literal")
+}
+
+// comparisonNode compares the value of a path with a literal.
+type comparisonNode struct {
+	path    path
+	op      string
+	literal interface{}
+	regexp  *regexp.Regexp
+}
+
+func (n comparisonNode) eval(ctx evalContext) bool {
+	val := ctx.value(n.path)
+	switch n.op {
+	case "==":
+		return equal(val, n.literal)
+	case "!=":
+		return !equal(val, n.literal)
+	case "=~":
+		s, ok := val.(string)
+		return ok && n.regexp.MatchString(s)
+	case "!~":
+		s, ok := val.(string)
+		return !ok || !n.regexp.MatchString(s)
+	}
+
+	cmp, ok := compare(val, n.literal)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// equal returns whether the values are equal, integers and floats are compared numerically.
+func equal(a, b interface{}) bool {
+	if cmp, ok := compare(a, b); ok {
+		return cmp == 0
+	}
+	return a == b
+}
+
+// compare compares two numbers or two strings, returns false if the values are not comparable.
+func compare(a, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(av, bv), true
+	case int64:
+		switch bv := b.(type) {
+		case int64:
+			return compareInts(av, bv), true
+		case float64:
+			return compareFloats(float64(av), bv), true
+		}
+	case float64:
+		switch bv := b.(type) {
+		case int64:
+			return compareFloats(av, float64(bv)), true
+		case float64:
+			return compareFloats(av, bv), true
+		}
+	}
+	return 0, false
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{
+			expr:    ``,
+			wantErr: "invalid filter expression at offset 0: expected a path",
+		},
+		{
+			expr:    `unknown == 1`,
+			wantErr: `invalid filter expression at offset 0: unknown path "unknown"`,
+		},
+		{
+			expr:    `attributes == 1`,
+			wantErr: `invalid filter expression at offset 11: expected "[" after "attributes"`,
+		},
+		{
+			expr:    `attributes[1] == 1`,
+			wantErr: "invalid filter expression at offset 11: expected a string attribute key",
+		},
+		{
+			expr:    `attributes["a" == 1`,
+			wantErr: `invalid filter expression at offset 15: expected "]"`,
+		},
+		{
+			expr:    `name "x"`,
+			wantErr: "invalid filter expression at offset 5: expected a comparison operator",
+		},
+		{
+			expr:    `name == and`,
+			wantErr: "invalid filter expression at offset 8: expected a literal",
+		},
+		{
+			expr:    `name =~ 1`,
+			wantErr: `invalid filter expression at offset 8: operator "=~" requires a string regular expression`,
+		},
+		{
+			expr:    `name =~ "("`,
+			wantErr: "invalid filter expression at offset 8: invalid regular expression: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			expr:    `name < true`,
+			wantErr: `invalid filter expression at offset 7: operator "<" requires a string or number`,
+		},
+		{
+			expr:    `severity_number == 1.2.3`,
+			wantErr: `invalid filter expression at offset 19: invalid number "1.2.3"`,
+		},
+		{
+			expr:    `(name == "a"`,
+			wantErr: `invalid filter expression at offset 12: expected ")"`,
+		},
+		{
+			expr:    `name == "a" name == "b"`,
+			wantErr: `invalid filter expression at offset 12: unexpected "name"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMatchSpan(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().UpsertString("service.name", "checkout")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("http")
+	scope.SetVersion("1.0.0")
+	span := ptrace.NewSpan()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Attributes().UpsertInt("http.status_code", 503)
+	span.Attributes().UpsertDouble("ratio", 0.5)
+	span.Attributes().UpsertBool("retried", true)
+	span.Attributes().Upsert("empty", pcommon.NewValueEmpty())
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `resource.attributes["service.name"] == "checkout"`, want: true},
+		{expr: `resource.attributes["service.name"] != "checkout"`, want: false},
+		{expr: `resource.attributes["missing"] == nil`, want: true},
+		{expr: `resource.attributes["service.name"] != nil`, want: true},
+		{expr: `scope.name == "http" and scope.version >= "1.0.0"`, want: true},
+		{expr: `name =~ "^GET "`, want: true},
+		{expr: `name !~ "^GET "`, want: false},
+		{expr: `attributes["missing"] !~ "x"`, want: true},
+		{expr: `attributes["missing"] =~ ".*"`, want: false},
+		{expr: `attributes["http.status_code"] >= 500`, want: true},
+		{expr: `attributes["http.status_code"] < 500`, want: false},
+		{expr: `attributes["http.status_code"] == 503.0`, want: true},
+		{expr: `attributes["http.status_code"] == "503"`, want: false},
+		{expr: `attributes["http.status_code"] > "5"`, want: false},
+		{expr: `attributes["ratio"] > 0 and attributes["ratio"] <= 0.5`, want: true},
+		{expr: `attributes["retried"] == true`, want: true},
+		{expr: `attributes["empty"] == nil`, want: true},
+		{expr: `kind == "SPAN_KIND_SERVER" and status.code == "STATUS_CODE_ERROR"`, want: true},
+		{expr: `not (kind == "SPAN_KIND_SERVER")`, want: false},
+		{expr: `name == "other" or attributes["retried"] == true`, want: true},
+		{expr: `name == "other" or name == "GET /cart" and attributes["retried"] == false`, want: false},
+		{expr: `body == nil and severity_number == nil and type == nil`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expr, expr.String())
+			assert.Equal(t, tt.want, expr.MatchSpan(span, scope, resource))
+		})
+	}
+}
+
+func TestMatchLogRecord(t *testing.T) {
+	lr := plog.NewLogRecord()
+	lr.Body().SetStringVal("connection refused")
+	lr.SetSeverityText("ERROR")
+	lr.SetSeverityNumber(plog.SeverityNumberERROR)
+	lr.Attributes().UpsertString("peer", "db")
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `body =~ "refused"`, want: true},
+		{expr: `severity_text == "ERROR"`, want: true},
+		{expr: `severity_number >= 17`, want: true},
+		{expr: `severity_number < 17`, want: false},
+		{expr: `attributes["peer"] == "db"`, want: true},
+		{expr: `name == nil and kind == nil`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.MatchLogRecord(lr, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+		})
+	}
+
+	// Non primitive bodies are compared as their string representation.
+	lr.Body().SetIntVal(10)
+	expr, err := Parse(`body == 10`)
+	require.NoError(t, err)
+	assert.True(t, expr.MatchLogRecord(lr, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	pcommon.NewValueBytes(pcommon.NewImmutableByteSlice([]byte{1})).CopyTo(lr.Body())
+	expr, err = Parse(`body == "AQ=="`)
+	require.NoError(t, err)
+	assert.True(t, expr.MatchLogRecord(lr, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+}
+
+func TestMatchMetric(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("http.server.duration")
+	metric.SetDescription("duration of the requests")
+	metric.SetUnit("ms")
+	metric.SetDataType(pmetric.MetricDataTypeHistogram)
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `name == "http.server.duration"`, want: true},
+		{expr: `description =~ "requests$"`, want: true},
+		{expr: `unit == "ms"`, want: true},
+		{expr: `type == "Histogram"`, want: true},
+		{expr: `attributes["any"] == nil`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.MatchMetric(metric, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize(`attributes["a \"b\""] >= -1.5e3 and (name =~ "^x" or not kind!=nil)`)
+	require.NoError(t, err)
+	var kinds []tokenKind
+	var texts []string
+	for _, tok := range tokens {
+		kinds = append(kinds, tok.kind)
+		texts = append(texts, tok.text)
+	}
+	assert.Equal(t, []tokenKind{
+		tokenIdent, tokenLBracket, tokenString, tokenRBracket, tokenOperator, tokenNumber, tokenIdent,
+		tokenLParen, tokenIdent, tokenOperator, tokenString, tokenIdent, tokenIdent, tokenIdent, tokenOperator, tokenIdent, tokenRParen,
+		tokenEOF,
+	}, kinds)
+	assert.Equal(t, []string{
+		"attributes", "[", `a "b"`, "]", ">=", "-1.5e3", "and",
+		"(", "name", "=~", "^x", "or", "not", "kind", "!=", "nil", ")",
+		"",
+	}, texts)
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	_, err := tokenize(`name == "unterminated`)
+	assert.EqualError(t, err, "invalid filter expression at offset 8: unterminated string")
+
+	_, err = tokenize(`name = "x"`)
+	assert.EqualError(t, err, `invalid filter expression at offset 5: unexpected character '='`)
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfilter // import "go.opentelemetry.io/collector/processor/pfilter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type pathKind int
+
+const (
+	pathField pathKind = iota
+	pathResourceAttributes
+	pathAttributes
+)
+
+// path identifies the value compared by a comparison.
+type path struct {
+	kind pathKind
+	// name is the attribute key for the attributes paths, the field path otherwise.
+	name string
+}
+
+// evalContext gives access to the values of the matched telemetry.
+type evalContext interface {
+	// value returns the value of the path, nil if not set or not applicable.
+	value(p path) interface{}
+}
+
+// MatchSpan returns whether the span, in the given scope and resource, matches the expression.
+func (e *Expression) MatchSpan(span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	return e.root.eval(spanContext{span: span, scopeContext: scopeContext{scope: scope, resource: resource}})
+}
+
+// MatchLogRecord returns whether the log record, in the given scope and resource, matches the expression.
+func (e *Expression) MatchLogRecord(lr plog.LogRecord, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	return e.root.eval(logRecordContext{lr: lr, scopeContext: scopeContext{scope: scope, resource: resource}})
+}
+
+// MatchMetric returns whether the metric, in the given scope and resource, matches the expression.
+func (e *Expression) MatchMetric(metric pmetric.Metric, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool {
+	return e.root.eval(metricContext{metric: metric, scopeContext: scopeContext{scope: scope, resource: resource}})
+}
+
+// scopeContext gives access to the resource and scope values, common to all telemetry.
+type scopeContext struct {
+	scope    pcommon.InstrumentationScope
+	resource pcommon.Resource
+}
+
+func (ctx scopeContext) value(p path) interface{} {
+	switch p.kind {
+	case pathResourceAttributes:
+		return attributeValue(ctx.resource.Attributes(), p.name)
+	case pathField:
+		switch p.name {
+		case "scope.name":
+			return ctx.scope.Name()
+		case "scope.version":
+			return ctx.scope.Version()
+		}
+	}
+	return nil
+}
+
+type spanContext struct {
+	scopeContext
+	span ptrace.Span
+}
+
+func (ctx spanContext) value(p path) interface{} {
+	switch p.kind {
+	case pathAttributes:
+		return attributeValue(ctx.span.Attributes(), p.name)
+	case pathField:
+		switch p.name {
+		case "name":
+			return ctx.span.Name()
+		case "kind":
+			return ctx.span.Kind().String()
+		case "status.code":
+			return ctx.span.Status().Code().String()
+		}
+	}
+	return ctx.scopeContext.value(p)
+}
+
+type logRecordContext struct {
+	scopeContext
+	lr plog.LogRecord
+}
+
+func (ctx logRecordContext) value(p path) interface{} {
+	switch p.kind {
+	case pathAttributes:
+		return attributeValue(ctx.lr.Attributes(), p.name)
+	case pathField:
+		switch p.name {
+		case "body":
+			return convertValue(ctx.lr.Body())
+		case "severity_text":
+			return ctx.lr.SeverityText()
+		case "severity_number":
+			return int64(ctx.lr.SeverityNumber())
+		}
+	}
+	return ctx.scopeContext.value(p)
+}
+
+type metricContext struct {
+	scopeContext
+	metric pmetric.Metric
+}
+
+func (ctx metricContext) value(p path) interface{} {
+	if p.kind == pathField {
+		switch p.name {
+		case "name":
+			return ctx.metric.Name()
+		case "description":
+			return ctx.metric.Description()
+		case "unit":
+			return ctx.metric.Unit()
+		case "type":
+			return ctx.metric.DataType().String()
+		}
+	}
+	return ctx.scopeContext.value(p)
+}
+
+func attributeValue(attrs pcommon.Map, key string) interface{} {
+	val, ok := attrs.Get(key)
+	if !ok {
+		return nil
+	}
+	return convertValue(val)
+}
+
+// convertValue converts the value to the type of the expression literals, maps, slices and
+// bytes are converted to their string representation.
+func convertValue(val pcommon.Value) interface{} {
+	switch val.Type() {
+	case pcommon.ValueTypeEmpty:
+		return nil
+	case pcommon.ValueTypeString:
+		return val.StringVal()
+	case pcommon.ValueTypeInt:
+		return val.IntVal()
+	case pcommon.ValueTypeDouble:
+		return val.DoubleVal()
+	case pcommon.ValueTypeBool:
+		return val.BoolVal()
+	}
+	return val.AsString()
+}
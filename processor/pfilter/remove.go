@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfilter // import "go.opentelemetry.io/collector/processor/pfilter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// RemoveSpans removes the spans matching the expression, along with the scopes and
+// resources left without spans. Returns the number of removed spans.
+func (e *Expression) RemoveSpans(td ptrace.Traces) int {
+	removed := 0
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if e.MatchSpan(span, ss.Scope(), rs.Resource()) {
+					removed++
+					return true
+				}
+				return false
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return removed
+}
+
+// RemoveLogRecords removes the log records matching the expression, along with the scopes
+// and resources left without log records. Returns the number of removed log records.
+func (e *Expression) RemoveLogRecords(ld plog.Logs) int {
+	removed := 0
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				if e.MatchLogRecord(lr, sl.Scope(), rl.Resource()) {
+					removed++
+					return true
+				}
+				return false
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return removed
+}
+
+// RemoveMetrics removes the metrics matching the expression, along with the scopes and
+// resources left without metrics. Returns the number of removed metrics.
+func (e *Expression) RemoveMetrics(md pmetric.Metrics) int {
+	removed := 0
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+				if e.MatchMetric(metric, sm.Scope(), rm.Resource()) {
+					removed++
+					return true
+				}
+				return false
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return removed
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestRemoveSpans(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"frontend", "checkout"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().UpsertString("service.name", service)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Spans().AppendEmpty().SetName("health")
+		ss.Spans().AppendEmpty().SetName("GET /cart")
+	}
+	expr, err := Parse(`resource.attributes["service.name"] == "frontend" or name == "health"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, expr.RemoveSpans(td))
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	rs := td.ResourceSpans().At(0)
+	service, _ := rs.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.StringVal())
+	require.Equal(t, 1, rs.ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, "GET /cart", rs.ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestRemoveLogRecords(t *testing.T) {
+	ld := plog.NewLogs()
+	sls := ld.ResourceLogs().AppendEmpty().ScopeLogs()
+	debug := sls.AppendEmpty()
+	debug.Scope().SetName("debug")
+	debug.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberDEBUG)
+	mixed := sls.AppendEmpty()
+	mixed.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberDEBUG)
+	mixed.LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberERROR)
+
+	expr, err := Parse(`severity_number < 9`)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, expr.RemoveLogRecords(ld))
+	require.Equal(t, 1, ld.ResourceLogs().At(0).ScopeLogs().Len())
+	lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, lrs.Len())
+	assert.Equal(t, plog.SeverityNumberERROR, lrs.At(0).SeverityNumber())
+}
+
+func TestRemoveMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetName("runtime.gc.count")
+	ms.AppendEmpty().SetName("http.server.duration")
+
+	expr, err := Parse(`name =~ "^runtime\\."`)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, expr.RemoveMetrics(md))
+	require.Equal(t, 1, ms.Len())
+	assert.Equal(t, "http.server.duration", ms.At(0).Name())
+
+	expr, err = Parse(`name != nil`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expr.RemoveMetrics(md))
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	// numHashBuckets and bitMaskHashBuckets are the number of buckets the sampling hashes are distributed in,
+	// the same as used by the trace probabilistic samplers so that the sampling decisions are consistent.
+	numHashBuckets        = 0x4000
+	bitMaskHashBuckets    = numHashBuckets - 1
+	percentageScaleFactor = numHashBuckets / 100.0
+)
+
+// SampleLogRecordFunc is a helper function that returns true if the log record, in the given scope
+// and resource, is sampled and must be kept.
+type SampleLogRecordFunc func(ctx context.Context, lr plog.LogRecord, scope pcommon.InstrumentationScope, resource pcommon.Resource) bool
+
+// NewLogsSamplingProcessFunc creates a ProcessLogsFunc removing the log records which are not sampled,
+// along with the scopes and resources left without log records. When no log record is sampled the
+// processing is skipped, see ErrSkipProcessingData.
+func NewLogsSamplingProcessFunc(sample SampleLogRecordFunc) ProcessLogsFunc {
+	return func(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+		ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+			rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+				sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+					return !sample(ctx, lr, sl.Scope(), rl.Resource())
+				})
+				return sl.LogRecords().Len() == 0
+			})
+			return rl.ScopeLogs().Len() == 0
+		})
+		if ld.ResourceLogs().Len() == 0 {
+			return ld, ErrSkipProcessingData
+		}
+		return ld, nil
+	}
+}
+
+// NewProbabilisticLogRecordSampler creates a SampleLogRecordFunc sampling the given percentage of the log records,
+// based on their plog.SamplingHash computed with the given seed and attribute keys. Using the same seed as the
+// trace probabilistic sampler samples the log records of the sampled traces. Log records without any hash source
+// are always sampled.
+func NewProbabilisticLogRecordSampler(samplingPercentage float32, hashSeed uint32, attributeKeys ...string) SampleLogRecordFunc {
+	scaledSamplingRate := uint32(samplingPercentage * percentageScaleFactor)
+	return func(_ context.Context, lr plog.LogRecord, _ pcommon.InstrumentationScope, _ pcommon.Resource) bool {
+		hash, ok := plog.SamplingHash(lr, hashSeed, attributeKeys...)
+		if !ok {
+			return true
+		}
+		return hash&bitMaskHashBuckets < scaledSamplingRate
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processorhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLogsSamplingProcessFunc(t *testing.T) {
+	ld := plog.NewLogs()
+	sls := ld.ResourceLogs().AppendEmpty().ScopeLogs()
+	dropped := sls.AppendEmpty()
+	dropped.LogRecords().AppendEmpty().SetSeverityText("DEBUG")
+	mixed := sls.AppendEmpty()
+	mixed.LogRecords().AppendEmpty().SetSeverityText("DEBUG")
+	mixed.LogRecords().AppendEmpty().SetSeverityText("ERROR")
+
+	processFunc := NewLogsSamplingProcessFunc(func(_ context.Context, lr plog.LogRecord, _ pcommon.InstrumentationScope, _ pcommon.Resource) bool {
+		return lr.SeverityText() == "ERROR"
+	})
+	got, err := processFunc(context.Background(), ld)
+	require.NoError(t, err)
+	require.Equal(t, 1, got.LogRecordCount())
+	assert.Equal(t, "ERROR", got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+
+	got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetSeverityText("DEBUG")
+	_, err = processFunc(context.Background(), got)
+	assert.ErrorIs(t, err, ErrSkipProcessingData)
+}
+
+func TestLogsSamplingProcessor(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	lp, err := NewLogsProcessor(&testLogsCfg, sink, NewLogsSamplingProcessFunc(NewProbabilisticLogRecordSampler(0, 0)))
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTraceID(pcommon.NewTraceID([16]byte{1}))
+	assert.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 0, sink.LogRecordCount())
+}
+
+func TestProbabilisticLogRecordSampler(t *testing.T) {
+	const seed = 42
+	all := NewProbabilisticLogRecordSampler(100, seed)
+	none := NewProbabilisticLogRecordSampler(0, seed)
+	half := NewProbabilisticLogRecordSampler(50, seed, "request.id")
+	scope, resource := pcommon.NewInstrumentationScope(), pcommon.NewResource()
+
+	sampled := 0
+	lr := plog.NewLogRecord()
+	for i := 0; i < 1000; i++ {
+		lr.SetTraceID(pcommon.NewTraceID([16]byte{byte(i), byte(i >> 8), 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		assert.True(t, all(context.Background(), lr, scope, resource))
+		assert.False(t, none(context.Background(), lr, scope, resource))
+		hash := plog.TraceIDSamplingHash(lr.TraceID(), seed)
+		decision := half(context.Background(), lr, scope, resource)
+		// The decision is the one a trace sampler with the same seed makes on the trace ID.
+		assert.Equal(t, hash&bitMaskHashBuckets < uint32(50*percentageScaleFactor), decision)
+		if decision {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 500, sampled, 60)
+
+	// Log records without trace ID nor attribute are always sampled.
+	assert.True(t, none(context.Background(), plog.NewLogRecord(), scope, resource))
+	withAttribute := plog.NewLogRecord()
+	withAttribute.Attributes().UpsertString("request.id", "abc")
+	assert.Equal(t, half(context.Background(), withAttribute, scope, resource), half(context.Background(), withAttribute, scope, resource))
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// authResourceAttributes stamps the authentication data of the client as resource attributes.
+type authResourceAttributes map[string]string
+
+// stamp upserts the attributes of the client.AuthData of the context in the resource. The values which are
+// neither strings nor lists of strings are stamped as their string representation.
+func (a authResourceAttributes) stamp(ctx context.Context, resource pcommon.Resource) {
+	auth := client.FromContext(ctx).Auth
+	if auth == nil {
+		return
+	}
+	for authAttribute, resourceAttribute := range a {
+		switch val := auth.GetAttribute(authAttribute).(type) {
+		case nil:
+		case string:
+			resource.Attributes().UpsertString(resourceAttribute, val)
+		case []string:
+			slice := pcommon.NewValueSlice()
+			for _, str := range val {
+				slice.SliceVal().AppendEmpty().SetStringVal(str)
+			}
+			resource.Attributes().Upsert(resourceAttribute, slice)
+		default:
+			resource.Attributes().UpsertString(resourceAttribute, fmt.Sprint(val))
+		}
+	}
+}
+
+func (a authResourceAttributes) traces(next consumer.Traces) (consumer.Traces, error) {
+	if len(a) == 0 {
+		return next, nil
+	}
+	return consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			a.stamp(ctx, td.ResourceSpans().At(i).Resource())
+		}
+		return next.ConsumeTraces(ctx, td)
+	})
+}
+
+func (a authResourceAttributes) metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	if len(a) == 0 {
+		return next, nil
+	}
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			a.stamp(ctx, md.ResourceMetrics().At(i).Resource())
+		}
+		return next.ConsumeMetrics(ctx, md)
+	})
+}
+
+func (a authResourceAttributes) logs(next consumer.Logs) (consumer.Logs, error) {
+	if len(a) == 0 {
+		return next, nil
+	}
+	return consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			a.stamp(ctx, ld.ResourceLogs().At(i).Resource())
+		}
+		return next.ConsumeLogs(ctx, ld)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type testAuthData map[string]interface{}
+
+func (a testAuthData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a testAuthData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestAuthResourceAttributes(t *testing.T) {
+	attrs := authResourceAttributes{
+		client.AuthAttributeSubject: "enduser.id",
+		client.AuthAttributeTenant:  "tenant.id",
+		"groups":                    "tenant.groups",
+		"level":                     "tenant.level",
+	}
+	ctx := client.NewContext(context.Background(), client.Info{
+		Auth: testAuthData{
+			client.AuthAttributeSubject: "jdoe",
+			client.AuthAttributeTenant:  "acme",
+			"groups":                    []string{"dev", "ops"},
+			"level":                     3,
+		},
+	})
+	expected := pcommon.NewMapFromRaw(map[string]interface{}{
+		"enduser.id":    "jdoe",
+		"tenant.id":     "acme",
+		"tenant.groups": []interface{}{"dev", "ops"},
+		"tenant.level":  "3",
+		"resource-attr": "resource-attr-val-1",
+	})
+
+	tracesSink := new(consumertest.TracesSink)
+	tc, err := attrs.traces(tracesSink)
+	require.NoError(t, err)
+	require.NoError(t, tc.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	assert.Equal(t, expected.Sort(), tracesSink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Sort())
+
+	metricsSink := new(consumertest.MetricsSink)
+	mc, err := attrs.metrics(metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, mc.ConsumeMetrics(ctx, testdata.GenerateMetrics(1)))
+	assert.Equal(t, expected.Sort(), metricsSink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().Sort())
+
+	logsSink := new(consumertest.LogsSink)
+	lc, err := attrs.logs(logsSink)
+	require.NoError(t, err)
+	require.NoError(t, lc.ConsumeLogs(ctx, testdata.GenerateLogs(1)))
+	assert.Equal(t, expected.Sort(), logsSink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Sort())
+}
+
+func TestAuthResourceAttributesNoAuth(t *testing.T) {
+	attrs := authResourceAttributes{client.AuthAttributeTenant: "tenant.id"}
+	sink := new(consumertest.TracesSink)
+	tc, err := attrs.traces(sink)
+	require.NoError(t, err)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, 1, sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Len())
+
+	// Without attributes to stamp the next consumer is used as is.
+	next, err := authResourceAttributes(nil).traces(sink)
+	require.NoError(t, err)
+	assert.Same(t, sink, next)
+}
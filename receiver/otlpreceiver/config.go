@@ -41,10 +41,40 @@ type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+	// AuthResourceAttributes maps the names of the client.AuthData attributes, set by the authenticator of the
+	// protocol, to the resource attributes they are stamped as on the received telemetry, e.g. "tenant: tenant.id".
+	AuthResourceAttributes map[string]string `mapstructure:"auth_resource_attributes"`
+	// GRPCHealth registers the grpc.health.v1.Health service on the gRPC server, reporting the receiver services
+	// as serving until the receiver shuts down, so that load balancers can health check the receiver itself.
+	GRPCHealth bool `mapstructure:"grpc_health"`
+	// GRPCReflection registers the gRPC server reflection service, so that tools like grpcurl can list and call
+	// the receiver services without their proto definitions.
+	GRPCReflection bool `mapstructure:"grpc_reflection"`
+	// LazyDecoding defers decoding the HTTP protobuf requests until the telemetry is accessed, the received bytes
+	// being validated when received and forwarded as is by the OTLP exporters when no processor modified the
+	// telemetry. The gRPC requests are always decoded by the gRPC server.
+	LazyDecoding bool `mapstructure:"lazy_decoding"`
+	// DurableAck responds to the requests only once their telemetry is durably accepted by the pipeline: persisted
+	// by the exporters with a write-ahead log or a persistent queue, or exported by the other exporters, including
+	// when batched by a batch processor. The clients retrying the failed requests get at-least-once delivery.
+	DurableAck bool `mapstructure:"durable_ack"`
 }
 
 var _ config.Receiver = (*Config)(nil)
 var _ config.Unmarshallable = (*Config)(nil)
+var _ config.ExtensionDependent = (*Config)(nil)
+
+// ExtensionDependencies returns the IDs of the extensions of the gRPC and HTTP servers.
+func (cfg *Config) ExtensionDependencies() []config.ComponentID {
+	var ids []config.ComponentID
+	if cfg.GRPC != nil {
+		ids = append(ids, cfg.GRPC.ExtensionDependencies()...)
+	}
+	if cfg.HTTP != nil {
+		ids = append(ids, cfg.HTTP.ExtensionDependencies()...)
+	}
+	return ids
+}
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
@@ -52,6 +82,9 @@ func (cfg *Config) Validate() error {
 		cfg.HTTP == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.GRPC == nil && (cfg.GRPCHealth || cfg.GRPCReflection) {
+		return errors.New("grpc_health and grpc_reflection require the grpc protocol")
+	}
 	return nil
 }
 
@@ -42,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 12)
+	assert.Equal(t, len(cfg.Receivers), 16)
 
 	assert.Equal(t, cfg.Receivers[config.NewComponentID(typeStr)], factory.CreateDefaultConfig())
 
@@ -188,6 +188,66 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "authresourceattributes")],
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "authresourceattributes")),
+			Protocols: Protocols{
+				GRPC: &configgrpc.GRPCServerSettings{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "0.0.0.0:4317",
+						Transport: "tcp",
+					},
+					ReadBufferSize: 512 * 1024,
+				},
+			},
+			AuthResourceAttributes: map[string]string{
+				"subject": "enduser.id",
+				"tenant":  "tenant.id",
+			},
+		})
+
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "grpcservices")],
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "grpcservices")),
+			Protocols: Protocols{
+				GRPC: &configgrpc.GRPCServerSettings{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "0.0.0.0:4317",
+						Transport: "tcp",
+					},
+					ReadBufferSize: 512 * 1024,
+				},
+			},
+			GRPCHealth:     true,
+			GRPCReflection: true,
+		})
+
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "lazydecoding")],
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "lazydecoding")),
+			Protocols: Protocols{
+				HTTP: &confighttp.HTTPServerSettings{
+					Endpoint: "0.0.0.0:4318",
+				},
+			},
+			LazyDecoding: true,
+		})
+
+	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "durableack")],
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "durableack")),
+			Protocols: Protocols{
+				GRPC: &configgrpc.GRPCServerSettings{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "0.0.0.0:4317",
+						Transport: "tcp",
+					},
+					ReadBufferSize: 512 * 1024,
+				},
+			},
+			DurableAck: true,
+		})
+
 	assert.Equal(t, cfg.Receivers[config.NewComponentIDWithName(typeStr, "uds")],
 		&Config{
 			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "uds")),
@@ -225,3 +285,10 @@ func TestFailedLoadConfig(t *testing.T) {
 	_, err = servicetest.LoadConfigAndValidate(filepath.Join("testdata", "bad_empty_config.yaml"), factories)
 	assert.EqualError(t, err, "error reading receivers configuration for \"otlp\": empty config for OTLP receiver")
 }
+
+func TestValidateGRPCServicesWithoutGRPC(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.GRPCHealth = true
+	assert.EqualError(t, cfg.Validate(), "grpc_health and grpc_reflection require the grpc protocol")
+}
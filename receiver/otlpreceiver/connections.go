@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// connectionCounter counts the connections open to a server of the receiver, recording their number
+// with the obsreport.Receiver of the transport of the server.
+type connectionCounter struct {
+	obsrecv *obsreport.Receiver
+	active  int64
+}
+
+func newConnectionCounter(obsrecv *obsreport.Receiver) *connectionCounter {
+	return &connectionCounter{obsrecv: obsrecv}
+}
+
+func (c *connectionCounter) add(delta int64) {
+	c.obsrecv.RecordActiveConnections(context.Background(), atomic.AddInt64(&c.active, delta))
+}
+
+// httpConnState is the http.Server ConnState counting the connections of the server. The hijacked
+// connections are not reported as closed by the server, they are no longer counted once hijacked.
+func (c *connectionCounter) httpConnState(_ net.Conn, state http.ConnState) {
+	switch state {
+	case http.StateNew:
+		c.add(1)
+	case http.StateClosed, http.StateHijacked:
+		c.add(-1)
+	}
+}
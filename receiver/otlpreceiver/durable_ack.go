@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerack"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// durableAck waits for the consumerack.Ack of the data to complete, i.e. for the data held by the asynchronous
+// consumers to be persisted or exported, before returning the result of the consumption.
+type durableAck bool
+
+func (d durableAck) consume(ctx context.Context, next func(context.Context) error) error {
+	ack := consumerack.New()
+	ack.Done(next(consumerack.NewContext(ctx, ack)))
+	return ack.Wait(ctx)
+}
+
+func (d durableAck) traces(next consumer.Traces) (consumer.Traces, error) {
+	if !d {
+		return next, nil
+	}
+	return consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return d.consume(ctx, func(ctx context.Context) error {
+			return next.ConsumeTraces(ctx, td)
+		})
+	}, consumer.WithCapabilities(next.Capabilities()))
+}
+
+func (d durableAck) metrics(next consumer.Metrics) (consumer.Metrics, error) {
+	if !d {
+		return next, nil
+	}
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		return d.consume(ctx, func(ctx context.Context) error {
+			return next.ConsumeMetrics(ctx, md)
+		})
+	}, consumer.WithCapabilities(next.Capabilities()))
+}
+
+func (d durableAck) logs(next consumer.Logs) (consumer.Logs, error) {
+	if !d {
+		return next, nil
+	}
+	return consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return d.consume(ctx, func(ctx context.Context) error {
+			return next.ConsumeLogs(ctx, ld)
+		})
+	}, consumer.WithCapabilities(next.Capabilities()))
+}
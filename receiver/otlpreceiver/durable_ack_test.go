@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerack"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestDurableAckDisabled(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	tc, err := durableAck(false).traces(sink)
+	require.NoError(t, err)
+	assert.Same(t, sink, tc)
+}
+
+func TestDurableAck(t *testing.T) {
+	holds := make(chan func(error), 1)
+	hold := func(ctx context.Context) error {
+		holds <- consumerack.Hold(ctx)
+		return nil
+	}
+	tc, err := durableAck(true).traces(consumer.Traces(mustTraces(t, hold)))
+	require.NoError(t, err)
+	mc, err := durableAck(true).metrics(consumer.Metrics(mustMetrics(t, hold)))
+	require.NoError(t, err)
+	lc, err := durableAck(true).logs(consumer.Logs(mustLogs(t, hold)))
+	require.NoError(t, err)
+
+	consumes := []func(ctx context.Context) error{
+		func(ctx context.Context) error { return tc.ConsumeTraces(ctx, testdata.GenerateTraces(1)) },
+		func(ctx context.Context) error { return mc.ConsumeMetrics(ctx, testdata.GenerateMetrics(1)) },
+		func(ctx context.Context) error { return lc.ConsumeLogs(ctx, testdata.GenerateLogs(1)) },
+	}
+	for _, consume := range consumes {
+		// The consumption returns once the hold is released, with its error.
+		errC := make(chan error, 1)
+		go func() { errC <- consume(context.Background()) }()
+		release := <-holds
+		require.NotNil(t, release)
+		select {
+		case <-errC:
+			t.Fatal("returned before the hold was released")
+		case <-time.After(20 * time.Millisecond):
+		}
+		release(errors.New("my error"))
+		assert.EqualError(t, <-errC, "my error")
+
+		// The consumption is interrupted by the deadline of the request.
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		assert.ErrorIs(t, consume(ctx), context.DeadlineExceeded)
+		cancel()
+		(<-holds)(nil)
+	}
+}
+
+func mustTraces(t *testing.T, f func(ctx context.Context) error) consumer.Traces {
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error { return f(ctx) })
+	require.NoError(t, err)
+	return tc
+}
+
+func mustMetrics(t *testing.T, f func(ctx context.Context) error) consumer.Metrics {
+	mc, err := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error { return f(ctx) })
+	require.NoError(t, err)
+	return mc
+}
+
+func mustLogs(t *testing.T, f func(ctx context.Context) error) consumer.Logs {
+	lc, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error { return f(ctx) })
+	require.NoError(t, err)
+	return lc
+}
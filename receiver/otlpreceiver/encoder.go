@@ -21,8 +21,11 @@ import (
 	"github.com/gogo/protobuf/proto"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
 
@@ -33,6 +36,7 @@ const (
 
 var (
 	pbEncoder     = &protoEncoder{}
+	lazyPbEncoder = &lazyProtoEncoder{}
 	jsEncoder     = &jsonEncoder{}
 	jsonMarshaler = &jsonpb.Marshaler{}
 )
@@ -91,6 +95,26 @@ func (protoEncoder) contentType() string {
 	return pbContentType
 }
 
+// lazyProtoEncoder is a protoEncoder unmarshaling the requests lazily, see Config.LazyDecoding.
+type lazyProtoEncoder struct {
+	protoEncoder
+}
+
+func (lazyProtoEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.Request, error) {
+	td, err := ptrace.NewLazyProtoUnmarshaler().UnmarshalTraces(buf)
+	return ptraceotlp.NewRequestFromTraces(td), err
+}
+
+func (lazyProtoEncoder) unmarshalMetricsRequest(buf []byte) (pmetricotlp.Request, error) {
+	md, err := pmetric.NewLazyProtoUnmarshaler().UnmarshalMetrics(buf)
+	return pmetricotlp.NewRequestFromMetrics(md), err
+}
+
+func (lazyProtoEncoder) unmarshalLogsRequest(buf []byte) (plogotlp.Request, error) {
+	ld, err := plog.NewLazyProtoUnmarshaler().UnmarshalLogs(buf)
+	return plogotlp.NewRequestFromLogs(ld), err
+}
+
 type jsonEncoder struct{}
 
 func (jsonEncoder) unmarshalTracesRequest(buf []byte) (ptraceotlp.Request, error) {
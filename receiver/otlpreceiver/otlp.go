@@ -23,11 +23,15 @@ import (
 	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
@@ -40,6 +44,7 @@ import (
 type otlpReceiver struct {
 	cfg        *Config
 	serverGRPC *grpc.Server
+	healthGRPC *health.Server
 	httpMux    *http.ServeMux
 	serverHTTP *http.Server
 
@@ -48,6 +53,9 @@ type otlpReceiver struct {
 	logReceiver     *logs.Receiver
 	shutdownWG      sync.WaitGroup
 
+	obsrecvGRPC *obsreport.Receiver
+	obsrecvHTTP *obsreport.Receiver
+
 	settings component.ReceiverCreateSettings
 }
 
@@ -58,6 +66,16 @@ func newOtlpReceiver(cfg *Config, settings component.ReceiverCreateSettings) *ot
 	r := &otlpReceiver{
 		cfg:      cfg,
 		settings: settings,
+		obsrecvGRPC: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              "grpc",
+			ReceiverCreateSettings: settings,
+		}),
+		obsrecvHTTP: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              "http",
+			ReceiverCreateSettings: settings,
+		}),
 	}
 	if cfg.HTTP != nil {
 		r.httpMux = http.NewServeMux()
@@ -66,6 +84,14 @@ func newOtlpReceiver(cfg *Config, settings component.ReceiverCreateSettings) *ot
 	return r
 }
 
+// pbEncoder returns the encoder of the HTTP protobuf requests.
+func (r *otlpReceiver) pbEncoder() encoder {
+	if r.cfg.LazyDecoding {
+		return lazyPbEncoder
+	}
+	return pbEncoder
+}
+
 func (r *otlpReceiver) startGRPCServer(cfg *configgrpc.GRPCServerSettings, host component.Host) error {
 	r.settings.Logger.Info("Starting GRPC server on endpoint " + cfg.NetAddr.Endpoint)
 
@@ -110,6 +136,10 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 		if err != nil {
 			return err
 		}
+		opts = append(opts, grpc.StatsHandler(&grpcStatsHandler{
+			obsrecv:     r.obsrecvGRPC,
+			connections: newConnectionCounter(r.obsrecvGRPC),
+		}))
 		r.serverGRPC = grpc.NewServer(opts...)
 
 		if r.traceReceiver != nil {
@@ -124,6 +154,8 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 			plogotlp.RegisterServer(r.serverGRPC, r.logReceiver)
 		}
 
+		r.registerGRPCServices()
+
 		err = r.startGRPCServer(r.cfg.GRPC, host)
 		if err != nil {
 			return err
@@ -139,6 +171,7 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 		if err != nil {
 			return err
 		}
+		r.serverHTTP.ConnState = newConnectionCounter(r.obsrecvHTTP).httpConnState
 
 		err = r.startHTTPServer(r.cfg.HTTP, host)
 		if err != nil {
@@ -149,6 +182,21 @@ func (r *otlpReceiver) startProtocolServers(host component.Host) error {
 	return err
 }
 
+// registerGRPCServices registers the optional health and reflection services on the gRPC server,
+// after the receiver services so that the health service reports all of them.
+func (r *otlpReceiver) registerGRPCServices() {
+	if r.cfg.GRPCHealth {
+		r.healthGRPC = health.NewServer()
+		for name := range r.serverGRPC.GetServiceInfo() {
+			r.healthGRPC.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+		}
+		healthpb.RegisterHealthServer(r.serverGRPC, r.healthGRPC)
+	}
+	if r.cfg.GRPCReflection {
+		reflection.Register(r.serverGRPC)
+	}
+}
+
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(_ context.Context, host component.Host) error {
@@ -163,6 +211,11 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 		err = r.serverHTTP.Shutdown(ctx)
 	}
 
+	if r.healthGRPC != nil {
+		// Report not serving to the health checks while the in-flight requests complete.
+		r.healthGRPC.Shutdown()
+	}
+
 	if r.serverGRPC != nil {
 		r.serverGRPC.GracefulStop()
 	}
@@ -175,6 +228,13 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 	if tc == nil {
 		return component.ErrNilNextConsumer
 	}
+	tc, err := authResourceAttributes(r.cfg.AuthResourceAttributes).traces(tc)
+	if err != nil {
+		return err
+	}
+	if tc, err = durableAck(r.cfg.DurableAck).traces(tc); err != nil {
+		return err
+	}
 	r.traceReceiver = trace.New(r.cfg.ID(), tc, r.settings)
 	if r.httpMux != nil {
 		r.httpMux.HandleFunc("/v1/traces", func(resp http.ResponseWriter, req *http.Request) {
@@ -184,9 +244,9 @@ func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) error {
 			}
 			switch req.Header.Get("Content-Type") {
 			case pbContentType:
-				handleTraces(resp, req, r.traceReceiver, pbEncoder)
+				handleTraces(resp, req, r.traceReceiver, r.obsrecvHTTP, r.pbEncoder())
 			case jsonContentType:
-				handleTraces(resp, req, r.traceReceiver, jsEncoder)
+				handleTraces(resp, req, r.traceReceiver, r.obsrecvHTTP, jsEncoder)
 			default:
 				handleUnmatchedContentType(resp)
 			}
@@ -199,6 +259,13 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 	if mc == nil {
 		return component.ErrNilNextConsumer
 	}
+	mc, err := authResourceAttributes(r.cfg.AuthResourceAttributes).metrics(mc)
+	if err != nil {
+		return err
+	}
+	if mc, err = durableAck(r.cfg.DurableAck).metrics(mc); err != nil {
+		return err
+	}
 	r.metricsReceiver = metrics.New(r.cfg.ID(), mc, r.settings)
 	if r.httpMux != nil {
 		r.httpMux.HandleFunc("/v1/metrics", func(resp http.ResponseWriter, req *http.Request) {
@@ -208,9 +275,9 @@ func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
 			}
 			switch req.Header.Get("Content-Type") {
 			case pbContentType:
-				handleMetrics(resp, req, r.metricsReceiver, pbEncoder)
+				handleMetrics(resp, req, r.metricsReceiver, r.obsrecvHTTP, r.pbEncoder())
 			case jsonContentType:
-				handleMetrics(resp, req, r.metricsReceiver, jsEncoder)
+				handleMetrics(resp, req, r.metricsReceiver, r.obsrecvHTTP, jsEncoder)
 			default:
 				handleUnmatchedContentType(resp)
 			}
@@ -223,6 +290,13 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 	if lc == nil {
 		return component.ErrNilNextConsumer
 	}
+	lc, err := authResourceAttributes(r.cfg.AuthResourceAttributes).logs(lc)
+	if err != nil {
+		return err
+	}
+	if lc, err = durableAck(r.cfg.DurableAck).logs(lc); err != nil {
+		return err
+	}
 	r.logReceiver = logs.New(r.cfg.ID(), lc, r.settings)
 	if r.httpMux != nil {
 		r.httpMux.HandleFunc("/v1/logs", func(resp http.ResponseWriter, req *http.Request) {
@@ -232,9 +306,9 @@ func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) error {
 			}
 			switch req.Header.Get("Content-Type") {
 			case pbContentType:
-				handleLogs(resp, req, r.logReceiver, pbEncoder)
+				handleLogs(resp, req, r.logReceiver, r.obsrecvHTTP, r.pbEncoder())
 			case jsonContentType:
-				handleLogs(resp, req, r.logReceiver, jsEncoder)
+				handleLogs(resp, req, r.logReceiver, r.obsrecvHTTP, jsEncoder)
 			default:
 				handleUnmatchedContentType(resp)
 			}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -33,7 +34,11 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	"go.opentelemetry.io/collector/component"
@@ -437,6 +442,63 @@ func TestProtoHttp(t *testing.T) {
 	}
 }
 
+func TestProtoHttpLazyDecoding(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	cfg.LazyDecoding = true
+	tSink := &internalconsumertest.ErrOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	ocr := newReceiver(t, factory, cfg, tSink, nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	<-time.After(10 * time.Millisecond)
+
+	td := testdata.GenerateTraces(2)
+	traceBytes, err := ptrace.NewProtoMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("http://%s/v1/traces", addr)
+	for _, encoding := range []string{"", "gzip"} {
+		t.Run("Encoding_"+encoding, func(t *testing.T) {
+			tSink.Reset()
+			resp, err := http.DefaultClient.Do(createHTTPProtobufRequest(t, url, encoding, traceBytes))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Len(t, tSink.AllTraces(), 1)
+
+			// The received bytes are forwarded as is.
+			got := tSink.AllTraces()[0]
+			gotBytes, err := ptrace.NewProtoMarshaler().MarshalTraces(got)
+			require.NoError(t, err)
+			assert.Equal(t, traceBytes, gotBytes)
+			assert.Equal(t, td.ResourceSpans(), got.ResourceSpans())
+		})
+	}
+
+	// The malformed spans are refused before responding, without reaching the pipeline.
+	tSink.Reset()
+	malformed := protowire.AppendBytes(protowire.AppendTag(nil, 2, protowire.BytesType), []byte{0xff})
+	malformed = protowire.AppendBytes(protowire.AppendTag(nil, 2, protowire.BytesType), malformed)
+	malformed = protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), malformed)
+	resp, err := http.DefaultClient.Do(createHTTPProtobufRequest(t, url, "", malformed))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Empty(t, tSink.AllTraces())
+
+	tSink.Reset()
+	resp, err = http.DefaultClient.Do(createHTTPProtobufRequest(t, url, "", []byte("+$%")))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Len(t, tSink.AllTraces(), 0)
+}
+
 func createHTTPProtobufRequest(
 	t *testing.T,
 	url string,
@@ -698,6 +760,103 @@ func TestOTLPReceiverTrace_HandleNextConsumerResponse(t *testing.T) {
 	}
 }
 
+func TestOTLPReceiverRecordRequests(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(2)
+	traceBytes, err := ptrace.NewProtoMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+
+	addrGRPC := testutil.GetAvailableLocalAddress(t)
+	addrHTTP := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addrGRPC
+	cfg.HTTP.Endpoint = addrHTTP
+	ocr := newReceiver(t, factory, cfg, consumertest.NewNop(), nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	cc, err := grpc.Dial(addrGRPC, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+	client := ptraceotlp.NewClient(cc)
+	_, err = client.Export(context.Background(), ptraceotlp.NewRequestFromTraces(td))
+	require.NoError(t, err)
+	_, err = client.Export(context.Background(), ptraceotlp.NewRequestFromTraces(td), grpc.UseCompressor(grpcgzip.Name))
+	require.NoError(t, err)
+
+	url := fmt.Sprintf("http://%s/v1/traces", addrHTTP)
+	for _, encoding := range []string{"", "gzip"} {
+		body := bytes.NewBuffer(traceBytes)
+		if encoding == "gzip" {
+			body, err = compressGzip(traceBytes)
+			require.NoError(t, err)
+		}
+		req, err := http.NewRequest(http.MethodPost, url, body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		req.Header.Set("Content-Encoding", encoding)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_, err = ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	require.NoError(t, obsreporttest.CheckReceiverRequests(tt, cfg.ID(), "grpc", config.TracesDataType, 2, int64(2*len(traceBytes)), 1))
+	require.NoError(t, obsreporttest.CheckReceiverRequests(tt, cfg.ID(), "http", config.TracesDataType, 2, int64(2*len(traceBytes)), 1))
+}
+
+func TestOTLPReceiverActiveConnections(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	addrGRPC := testutil.GetAvailableLocalAddress(t)
+	addrHTTP := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addrGRPC
+	cfg.HTTP.Endpoint = addrHTTP
+	cfg.HTTP.Keepalive = &confighttp.KeepaliveServerSettings{MaxRequestsPerConnection: 2}
+	ocr := newReceiver(t, factory, cfg, consumertest.NewNop(), nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	cc, err := grpc.Dial(addrGRPC, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return obsreporttest.CheckReceiverActiveConnections(tt, cfg.ID(), "grpc", 1) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	client := &http.Client{Transport: &http.Transport{}}
+	var closed []bool
+	for i := 0; i < 3; i++ {
+		resp, err := client.Post(fmt.Sprintf("http://%s/v1/traces", addrHTTP), "application/json", bytes.NewBufferString("{}"))
+		require.NoError(t, err)
+		_, err = ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		closed = append(closed, resp.Close)
+	}
+	// The second request reaches the keepalive limit, the third one being sent on a new connection.
+	assert.Equal(t, []bool{false, true, false}, closed)
+	assert.Eventually(t, func() bool {
+		return obsreporttest.CheckReceiverActiveConnections(tt, cfg.ID(), "http", 1) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, cc.Close())
+	client.CloseIdleConnections()
+	assert.Eventually(t, func() bool {
+		return obsreporttest.CheckReceiverActiveConnections(tt, cfg.ID(), "grpc", 0) == nil &&
+			obsreporttest.CheckReceiverActiveConnections(tt, cfg.ID(), "http", 0) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestGRPCInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
@@ -767,6 +926,49 @@ func TestGRPCMaxRecvSize(t *testing.T) {
 	assert.Equal(t, td, sink.AllTraces()[0])
 }
 
+func TestGRPCHealthAndReflection(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC.NetAddr.Endpoint = addr
+	cfg.HTTP = nil
+	cfg.GRPCHealth = true
+	cfg.GRPCReflection = true
+	ocr := newReceiver(t, factory, cfg, consumertest.NewNop(), nil)
+	require.NotNil(t, ocr)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	cc, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	require.NoError(t, err)
+	defer cc.Close()
+
+	healthClient := healthpb.NewHealthClient(cc)
+	for _, service := range []string{"", "opentelemetry.proto.collector.trace.v1.TraceService"} {
+		resp, errCheck := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, errCheck)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	}
+	// The metrics service is not registered by a traces only receiver.
+	_, err = healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "opentelemetry.proto.collector.metrics.v1.MetricsService"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := reflectionpb.NewServerReflectionClient(cc).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, "opentelemetry.proto.collector.trace.v1.TraceService")
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
+
 func TestHTTPInvalidTLSCredentials(t *testing.T) {
 	cfg := &Config{
 		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
@@ -835,7 +1037,70 @@ func TestHTTPMaxRequestBodySize_OK(t *testing.T) {
 }
 
 func TestHTTPMaxRequestBodySize_TooLarge(t *testing.T) {
-	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON)-1, 400)
+	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON)-1, 413)
+}
+
+func TestOTLPReceiverOversizedRequests(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	traceBytes, err := ptrace.NewProtoMarshaler().MarshalTraces(testdata.GenerateTraces(2))
+	require.NoError(t, err)
+	compressed, err := compressGzip(traceBytes)
+	require.NoError(t, err)
+
+	addr := testutil.GetAvailableLocalAddress(t)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = addr
+	cfg.HTTP.MaxRequestBodySize = int64(compressed.Len())
+	cfg.HTTP.MaxDecompressedRequestBodySize = int64(len(traceBytes) - 1)
+	ocr := newReceiver(t, factory, cfg, consumertest.NewNop(), nil)
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	tests := []struct {
+		name     string
+		body     io.Reader
+		encoding string
+	}{
+		{
+			name: "content_length",
+			body: bytes.NewReader(traceBytes),
+		},
+		{
+			name: "chunked",
+			body: io.MultiReader(bytes.NewReader(traceBytes)),
+		},
+		{
+			name:     "decompressed",
+			body:     compressed,
+			encoding: "gzip",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/v1/traces", addr), test.body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			req.Header.Set("Content-Encoding", test.encoding)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+			errStatus := &spb.Status{}
+			require.NoError(t, proto.Unmarshal(respBytes, errStatus))
+			assert.Equal(t, int32(codes.ResourceExhausted), errStatus.Code)
+			assert.Contains(t, errStatus.Message, "split the telemetry into smaller requests")
+		})
+	}
+
+	require.NoError(t, obsreporttest.CheckReceiverOversizedRequests(tt, cfg.ID(), "http", config.TracesDataType, int64(len(tests))))
 }
 
 func newGRPCReceiver(t *testing.T, name string, endpoint string, tc consumer.Traces, mc consumer.Metrics) component.Component {
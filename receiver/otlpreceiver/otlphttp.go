@@ -15,13 +15,19 @@
 package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
@@ -32,13 +38,15 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
-func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, obsrecv *obsreport.Receiver, encoder encoder) {
+	body, ok := readAndCloseBody(resp, req, obsrecv, config.TracesDataType, encoder)
 	if !ok {
 		return
 	}
 
+	start := time.Now()
 	otlpReq, err := encoder.unmarshalTracesRequest(body)
+	recordHTTPRequest(req, obsrecv, config.TracesDataType, len(body), time.Since(start))
 	if err != nil {
 		writeError(resp, encoder, err, http.StatusBadRequest)
 		return
@@ -58,13 +66,15 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
-func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, obsrecv *obsreport.Receiver, encoder encoder) {
+	body, ok := readAndCloseBody(resp, req, obsrecv, config.MetricsDataType, encoder)
 	if !ok {
 		return
 	}
 
+	start := time.Now()
 	otlpReq, err := encoder.unmarshalMetricsRequest(body)
+	recordHTTPRequest(req, obsrecv, config.MetricsDataType, len(body), time.Since(start))
 	if err != nil {
 		writeError(resp, encoder, err, http.StatusBadRequest)
 		return
@@ -84,13 +94,15 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
-func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, encoder encoder) {
-	body, ok := readAndCloseBody(resp, req, encoder)
+func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, obsrecv *obsreport.Receiver, encoder encoder) {
+	body, ok := readAndCloseBody(resp, req, obsrecv, config.LogsDataType, encoder)
 	if !ok {
 		return
 	}
 
+	start := time.Now()
 	otlpReq, err := encoder.unmarshalLogsRequest(body)
+	recordHTTPRequest(req, obsrecv, config.LogsDataType, len(body), time.Since(start))
 	if err != nil {
 		writeError(resp, encoder, err, http.StatusBadRequest)
 		return
@@ -110,8 +122,14 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
-func readAndCloseBody(resp http.ResponseWriter, req *http.Request, encoder encoder) ([]byte, bool) {
+func readAndCloseBody(resp http.ResponseWriter, req *http.Request, obsrecv *obsreport.Receiver, dataType config.DataType, encoder encoder) ([]byte, bool) {
 	body, err := ioutil.ReadAll(req.Body)
+	if errors.Is(err, confighttp.ErrRequestBodyTooLarge) {
+		obsrecv.RecordOversizedRequest(req.Context(), dataType)
+		// Retrying the same request fails again, the clients must send the telemetry in smaller requests.
+		writeError(resp, encoder, fmt.Errorf("%v, split the telemetry into smaller requests", err), http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
 	if err != nil {
 		writeError(resp, encoder, err, http.StatusBadRequest)
 		return nil, false
@@ -166,8 +184,11 @@ func writeResponse(w http.ResponseWriter, contentType string, statusCode int, ms
 }
 
 func errorMsgToStatus(errMsg string, statusCode int) *status.Status {
-	if statusCode == http.StatusBadRequest {
+	switch statusCode {
+	case http.StatusBadRequest:
 		return status.New(codes.InvalidArgument, errMsg)
+	case http.StatusRequestEntityTooLarge:
+		return status.New(codes.ResourceExhausted, errMsg)
 	}
 	return status.New(codes.Unknown, errMsg)
 }
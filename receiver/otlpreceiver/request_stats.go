@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/stats"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// grpcDataTypes maps the full names of the gRPC export methods to their data types.
+var grpcDataTypes = map[string]config.DataType{
+	"/opentelemetry.proto.collector.trace.v1.TraceService/Export":     config.TracesDataType,
+	"/opentelemetry.proto.collector.metrics.v1.MetricsService/Export": config.MetricsDataType,
+	"/opentelemetry.proto.collector.logs.v1.LogsService/Export":       config.LogsDataType,
+}
+
+// recordHTTPRequest records the size, compression ratio and decoding duration of the body of the request.
+func recordHTTPRequest(req *http.Request, obsrecv *obsreport.Receiver, dataType config.DataType, size int, decodeDuration time.Duration) {
+	rs := obsreport.RequestStats{Size: size, DecodeDuration: decodeDuration}
+	if compressedSize, ok := confighttp.CompressedBodySize(req.Context()); ok {
+		rs.CompressedSize = int(compressedSize)
+	}
+	obsrecv.RecordRequest(req.Context(), dataType, rs)
+}
+
+type grpcRequestKey struct{}
+
+// grpcRequest is the state of an export request tracked by the grpcStatsHandler.
+type grpcRequest struct {
+	dataType   config.DataType
+	compressed bool
+}
+
+// grpcStatsHandler records the size and compression ratio of the gRPC export requests, and counts the
+// connections of the server. The decoding duration is not recorded, the messages being decoded by gRPC
+// before the payload is reported.
+type grpcStatsHandler struct {
+	obsrecv     *obsreport.Receiver
+	connections *connectionCounter
+}
+
+var _ stats.Handler = (*grpcStatsHandler)(nil)
+
+func (h *grpcStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	dataType, ok := grpcDataTypes[info.FullMethodName]
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, grpcRequestKey{}, &grpcRequest{dataType: dataType})
+}
+
+func (h *grpcStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	req, ok := ctx.Value(grpcRequestKey{}).(*grpcRequest)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.InHeader:
+		req.compressed = s.Compression != "" && s.Compression != "identity"
+	case *stats.InPayload:
+		rs := obsreport.RequestStats{Size: s.Length}
+		if req.compressed {
+			rs.CompressedSize = s.WireLength
+		}
+		h.obsrecv.RecordRequest(ctx, req.dataType, rs)
+	}
+}
+
+func (h *grpcStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *grpcStatsHandler) HandleConn(_ context.Context, s stats.ConnStats) {
+	switch s.(type) {
+	case *stats.ConnBegin:
+		h.connections.add(1)
+	case *stats.ConnEnd:
+		h.connections.add(-1)
+	}
+}
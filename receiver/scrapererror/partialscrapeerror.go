@@ -23,6 +23,16 @@ type PartialScrapeError struct {
 	Failed int
 }
 
+// MetricError is the failure to scrape a metric.
+type MetricError struct {
+	// Metric is the name of the metric which failed to be scraped.
+	Metric string
+	// Failed is the number of failed metrics, e.g. the number of endpoints the metric failed to be scraped from.
+	Failed int
+	// Err is the reason of the failure, combining the errors of all the failures of the metric.
+	Err error
+}
+
 // NewPartialScrapeError creates PartialScrapeError for failed metrics.
 // Use this error type only when a subset of data was failed to be scraped.
 func NewPartialScrapeError(err error, failed int) PartialScrapeError {
@@ -41,3 +51,27 @@ func IsPartialScrapeError(err error) bool {
 	var partialScrapeErr PartialScrapeError
 	return errors.As(err, &partialScrapeErr)
 }
+
+// metricErrors wraps an error with the detail of the failures per metric. It is referenced by pointer,
+// so that PartialScrapeError remains comparable.
+type metricErrors struct {
+	error
+	errs []MetricError
+}
+
+func (e *metricErrors) Unwrap() error {
+	return e.error
+}
+
+// MetricErrors returns the detail of the failures per metric of the PartialScrapeError wrapped by err,
+// or nil if unknown. See ScrapeErrors.AddPartialMetric.
+func MetricErrors(err error) []MetricError {
+	var partialErr PartialScrapeError
+	if !errors.As(err, &partialErr) {
+		return nil
+	}
+	if me, ok := partialErr.error.(*metricErrors); ok {
+		return me.errs
+	}
+	return nil
+}
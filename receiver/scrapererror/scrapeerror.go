@@ -15,6 +15,8 @@
 package scrapererror // import "go.opentelemetry.io/collector/receiver/scrapererror"
 
 import (
+	"fmt"
+
 	"go.uber.org/multierr"
 )
 
@@ -30,6 +32,16 @@ func (s *ScrapeErrors) AddPartial(failed int, err error) {
 	s.failedScrapeCount += failed
 }
 
+// AddPartialMetric adds a PartialScrapeError with the provided failed count and error, detailing that the
+// failure is about the given metric. The failures of the same metric are aggregated in the combined error.
+func (s *ScrapeErrors) AddPartialMetric(metric string, failed int, err error) {
+	s.errs = append(s.errs, NewPartialScrapeError(&metricErrors{
+		error: fmt.Errorf("%s: %w", metric, err),
+		errs:  []MetricError{{Metric: metric, Failed: failed, Err: err}},
+	}, failed))
+	s.failedScrapeCount += failed
+}
+
 // Add adds a regular error.
 func (s *ScrapeErrors) Add(err error) {
 	s.errs = append(s.errs, err)
@@ -39,9 +51,12 @@ func (s *ScrapeErrors) Add(err error) {
 // It will return a PartialScrapeError if at least one error in the slice is a PartialScrapeError.
 func (s *ScrapeErrors) Combine() error {
 	partialScrapeErr := false
+	var metricErrs []MetricError
+	metricIndex := make(map[string]int)
 	for _, err := range s.errs {
 		if IsPartialScrapeError(err) {
 			partialScrapeErr = true
+			metricErrs = appendMetricErrors(metricErrs, metricIndex, err)
 		}
 	}
 
@@ -50,5 +65,23 @@ func (s *ScrapeErrors) Combine() error {
 		return combined
 	}
 
+	if len(metricErrs) != 0 {
+		combined = &metricErrors{error: combined, errs: metricErrs}
+	}
 	return NewPartialScrapeError(combined, s.failedScrapeCount)
 }
+
+// appendMetricErrors aggregates the MetricErrors of the PartialScrapeError in metricErrs by metric,
+// keeping the order in which the metrics first failed. metricIndex maps the metrics to their index in metricErrs.
+func appendMetricErrors(metricErrs []MetricError, metricIndex map[string]int, err error) []MetricError {
+	for _, me := range MetricErrors(err) {
+		if i, ok := metricIndex[me.Metric]; ok {
+			metricErrs[i].Failed += me.Failed
+			metricErrs[i].Err = multierr.Append(metricErrs[i].Err, me.Err)
+			continue
+		}
+		metricIndex[me.Metric] = len(metricErrs)
+		metricErrs = append(metricErrs, me)
+	}
+	return metricErrs
+}
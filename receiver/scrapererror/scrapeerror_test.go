@@ -114,3 +114,37 @@ func TestScrapeErrorsCombine(t *testing.T) {
 		}
 	}
 }
+
+func TestScrapeErrorsAddPartialMetric(t *testing.T) {
+	errA := errors.New("endpoint a unavailable")
+	errB := errors.New("endpoint b unavailable")
+	errPerm := errors.New("permission denied")
+
+	var errs ScrapeErrors
+	errs.AddPartialMetric("system.cpu.time", 1, errA)
+	errs.AddPartialMetric("system.memory.usage", 1, errPerm)
+	errs.AddPartialMetric("system.cpu.time", 1, errB)
+	errs.Add(errors.New("bad event"))
+
+	err := errs.Combine()
+	assert.EqualError(t, err, "system.cpu.time: endpoint a unavailable; system.memory.usage: permission denied; "+
+		"system.cpu.time: endpoint b unavailable; bad event")
+	assert.True(t, IsPartialScrapeError(err))
+
+	metricErrs := MetricErrors(err)
+	assert.Len(t, metricErrs, 2)
+	assert.Equal(t, "system.cpu.time", metricErrs[0].Metric)
+	assert.Equal(t, 2, metricErrs[0].Failed)
+	assert.EqualError(t, metricErrs[0].Err, "endpoint a unavailable; endpoint b unavailable")
+	assert.Equal(t, "system.memory.usage", metricErrs[1].Metric)
+	assert.Equal(t, 1, metricErrs[1].Failed)
+	assert.Equal(t, errPerm, metricErrs[1].Err)
+}
+
+func TestMetricErrorsUnknown(t *testing.T) {
+	var errs ScrapeErrors
+	errs.AddPartial(1, errors.New("bad scrape"))
+	assert.Nil(t, MetricErrors(errs.Combine()))
+	assert.Nil(t, MetricErrors(errors.New("bad regular")))
+	assert.Nil(t, MetricErrors(nil))
+}
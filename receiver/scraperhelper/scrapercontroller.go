@@ -17,6 +17,7 @@ package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperh
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"go.uber.org/multierr"
@@ -24,7 +25,9 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
@@ -70,6 +73,15 @@ func WithTickerChannel(tickerCh <-chan time.Time) ScraperControllerOption {
 	}
 }
 
+// WithStartTimeTracking sets the start timestamps of the scraped cumulative metrics with a
+// StartTimeTracker with the settings, for the scrapers not knowing them or not detecting the
+// resets of the scraped counters.
+func WithStartTimeTracking(settings pmetric.StateSettings) ScraperControllerOption {
+	return func(o *controller) {
+		o.startTimes = NewStartTimeTracker(settings)
+	}
+}
+
 type controller struct {
 	id                 config.ComponentID
 	logger             *zap.Logger
@@ -80,6 +92,15 @@ type controller struct {
 
 	tickerCh <-chan time.Time
 
+	startTimes *StartTimeTracker
+
+	// fingerprints are the fingerprints of the last scrapes of the scrapers, detecting the stale scrapes.
+	fingerprints map[config.ComponentID]uint64
+
+	// recoverPanics is true if the host recovers from the panics of the receiver, the panics of the
+	// scrapers then being returned as scrape errors.
+	recoverPanics bool
+
 	initialized bool
 	done        chan struct{}
 	terminated  chan struct{}
@@ -116,6 +137,7 @@ func NewScraperControllerReceiver(
 			ReceiverCreateSettings: set,
 		}),
 		recvSettings: set,
+		fingerprints: map[config.ComponentID]uint64{},
 	}
 
 	for _, op := range options {
@@ -127,6 +149,9 @@ func NewScraperControllerReceiver(
 
 // Start the receiver, invoked during service start.
 func (sc *controller) Start(ctx context.Context, host component.Host) error {
+	if rh, ok := host.(interface{ RecoversPanics() bool }); ok {
+		sc.recoverPanics = rh.RecoversPanics()
+	}
 	for _, scraper := range sc.scrapers {
 		if err := scraper.Start(ctx, host); err != nil {
 			return err
@@ -191,25 +216,79 @@ func (sc *controller) scrapeMetricsAndReport(ctx context.Context) {
 			ReceiverCreateSettings: sc.recvSettings,
 		})
 		ctx = scrp.StartMetricsOp(ctx)
-		md, err := scraper.Scrape(ctx)
+		md, err := sc.scrape(ctx, scraper, scrp)
 
 		if err != nil {
 			sc.logger.Error("Error scraping metrics", zap.Error(err), zap.Stringer("scraper", scraper.ID()))
+			logMetricErrors(sc.logger, scraper.ID(), err)
 			if !scrapererror.IsPartialScrapeError(err) {
 				scrp.EndMetricsOp(ctx, 0, err)
 				continue
 			}
 		}
+		if sc.isStale(scraper.ID(), md) {
+			scrp.RecordStaleScrape(ctx)
+		}
 		scrp.EndMetricsOp(ctx, md.MetricCount(), err)
 		md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
 	}
 
+	if sc.startTimes != nil {
+		sc.startTimes.Adjust(metrics)
+	}
+
 	dataPointCount := metrics.DataPointCount()
 	ctx = sc.obsrecv.StartMetricsOp(ctx)
 	err := sc.nextConsumer.ConsumeMetrics(ctx, metrics)
 	sc.obsrecv.EndMetricsOp(ctx, "", dataPointCount, err)
 }
 
+// scrape calls the Scrape function of the scraper, returning its panic as an error if the panics
+// are recovered.
+func (sc *controller) scrape(ctx context.Context, scraper Scraper, scrp *obsreport.Scraper) (md pmetric.Metrics, err error) {
+	if sc.recoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				sc.logger.Error("Scraper panicked, recovered from the panic", zap.Stringer("scraper", scraper.ID()),
+					zap.Any("panic", r), zap.Stack("stacktrace"))
+				scrp.RecordRecoveredPanic(ctx)
+				md, err = pmetric.NewMetrics(), fmt.Errorf("scraper %q panicked: %v", scraper.ID(), r)
+			}
+		}()
+	}
+	return scraper.Scrape(ctx)
+}
+
+// isStale returns whether the scraper returned the same data points, values and timestamps,
+// as its previous scrape, e.g. because the scraped source is frozen.
+func (sc *controller) isStale(id config.ComponentID, md pmetric.Metrics) bool {
+	if obsreportconfig.Level() == configtelemetry.LevelNone {
+		return false
+	}
+	if md.DataPointCount() == 0 {
+		delete(sc.fingerprints, id)
+		return false
+	}
+	fp := fingerprint(md)
+	last, ok := sc.fingerprints[id]
+	sc.fingerprints[id] = fp
+	return ok && last == fp
+}
+
+// logMetricErrors logs at debug level the detail of each metric which failed to be scraped, if known.
+func logMetricErrors(logger *zap.Logger, scraperID config.ComponentID, err error) {
+	if !logger.Core().Enabled(zap.DebugLevel) {
+		return
+	}
+	for _, me := range scrapererror.MetricErrors(err) {
+		logger.Debug("Failed to scrape metric",
+			zap.Stringer("scraper", scraperID),
+			zap.String("metric", me.Metric),
+			zap.Int("failed", me.Failed),
+			zap.Error(me.Err))
+	}
+}
+
 // stopScraping stops the ticker
 func (sc *controller) stopScraping() {
 	close(sc.done)
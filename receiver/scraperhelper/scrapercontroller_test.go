@@ -25,6 +25,9 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -32,6 +35,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
@@ -329,6 +333,29 @@ func assertScraperViews(t *testing.T, tt obsreporttest.TestTelemetry, expectedEr
 	require.NoError(t, obsreporttest.CheckScraperMetrics(tt, config.NewComponentID("receiver"), config.NewComponentID("scraper"), expectedScraped, expectedErrored))
 }
 
+func TestLogMetricErrors(t *testing.T) {
+	var errs scrapererror.ScrapeErrors
+	errs.AddPartialMetric("system.cpu.time", 2, errors.New("endpoint unavailable"))
+	errs.AddPartial(1, errors.New("bad scrape"))
+
+	core, logs := observer.New(zap.DebugLevel)
+	logMetricErrors(zap.New(core), config.NewComponentID("scraper"), errs.Combine())
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.DebugLevel, entry.Level)
+	assert.Equal(t, "Failed to scrape metric", entry.Message)
+	assert.Equal(t, map[string]interface{}{
+		"scraper": "scraper",
+		"metric":  "system.cpu.time",
+		"failed":  int64(2),
+		"error":   "endpoint unavailable",
+	}, entry.ContextMap())
+
+	core, logs = observer.New(zap.InfoLevel)
+	logMetricErrors(zap.New(core), config.NewComponentID("scraper"), errs.Combine())
+	assert.Equal(t, 0, logs.Len())
+}
+
 func TestSingleScrapePerTick(t *testing.T) {
 	scrapeMetricsCh := make(chan int, 10)
 	tsm := &testScrapeMetrics{ch: scrapeMetricsCh}
@@ -363,3 +390,128 @@ func TestSingleScrapePerTick(t *testing.T) {
 		return
 	}
 }
+
+func TestStartTimeTracking(t *testing.T) {
+	var counter int64
+	scp, err := NewScraper("", func(context.Context) (pmetric.Metrics, error) {
+		counter++
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetDataType(pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		m.Sum().SetIsMonotonic(true)
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(counter))
+		dp.SetIntVal(counter)
+		return md, nil
+	})
+	require.NoError(t, err)
+
+	defaultCfg := NewDefaultScraperControllerSettings("")
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(
+		&defaultCfg,
+		componenttest.NewNopReceiverCreateSettings(),
+		sink,
+		AddScraper(scp),
+		WithTickerChannel(tickerCh),
+		WithStartTimeTracking(pmetric.StateSettings{}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, receiver.Shutdown(context.Background())) }()
+
+	tickerCh <- time.Now()
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 2 }, time.Second, time.Millisecond)
+	for _, md := range sink.AllMetrics() {
+		dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+		assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	}
+}
+
+func TestStaleScrapes(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	// The source is frozen for the second and third scrapes.
+	values := []int64{1, 2, 2, 2, 3}
+	scrapes := 0
+	scp, err := NewScraper("scraper", func(context.Context) (pmetric.Metrics, error) {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetDataType(pmetric.MetricDataTypeGauge)
+		dp := m.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(values[scrapes]))
+		dp.SetIntVal(values[scrapes])
+		scrapes++
+		return md, nil
+	})
+	require.NoError(t, err)
+
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(&cfg, tt.ToReceiverCreateSettings(), sink, AddScraper(scp), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	for range values {
+		tickerCh <- time.Now()
+	}
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == len(values) }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	require.NoError(t, obsreporttest.CheckScraperStaleScrapes(tt, config.NewComponentID("receiver"), config.NewComponentID("scraper"), 2))
+}
+
+// recoveringHost is a host recovering from the panics of the receivers.
+type recoveringHost struct {
+	component.Host
+}
+
+func (recoveringHost) RecoversPanics() bool {
+	return true
+}
+
+func TestRecoveredScraperPanics(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	scrapes := 0
+	scp, err := NewScraper("scraper", func(context.Context) (pmetric.Metrics, error) {
+		scrapes++
+		if scrapes == 1 {
+			panic("unexpected nil pointer")
+		}
+		md := pmetric.NewMetrics()
+		md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+		return md, nil
+	})
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.ErrorLevel)
+	set := tt.ToReceiverCreateSettings()
+	set.Logger = zap.New(core)
+	cfg := NewDefaultScraperControllerSettings("receiver")
+	tickerCh := make(chan time.Time)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := NewScraperControllerReceiver(&cfg, set, sink, AddScraper(scp), WithTickerChannel(tickerCh))
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), recoveringHost{Host: componenttest.NewNopHost()}))
+
+	tickerCh <- time.Now()
+	tickerCh <- time.Now()
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 2 }, time.Second, time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	assert.Equal(t, 0, sink.AllMetrics()[0].MetricCount())
+	assert.Equal(t, 1, sink.AllMetrics()[1].MetricCount())
+	require.Equal(t, 1, logs.FilterMessage("Scraper panicked, recovered from the panic").Len())
+	require.NoError(t, obsreporttest.CheckScraperRecoveredPanics(tt, config.NewComponentID("receiver"), config.NewComponentID("scraper"), 1))
+}
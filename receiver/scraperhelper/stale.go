@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperhelper"
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+
+	"go.opentelemetry.io/collector/pdata/pdatahash"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// fingerprint returns a hash of the data points of the metrics, including their values and
+// timestamps, equal for the scrapes returning the same data. The order of the data is significant.
+func fingerprint(md pmetric.Metrics) uint64 {
+	h := fnv.New64a()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		writeUint64(h, pdatahash.Resource(rm.Resource()))
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			writeUint64(h, pdatahash.Scope(sm.Scope()))
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				writeMetric(h, ms.At(k))
+			}
+		}
+	}
+	return h.Sum64()
+}
+
+func writeMetric(h hash.Hash64, m pmetric.Metric) {
+	writeUint64(h, uint64(len(m.Name())))
+	_, _ = h.Write([]byte(m.Name()))
+	writeUint64(h, uint64(m.DataType()))
+	switch m.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			writeUint64(h, pdatahash.NumberDataPoint(dps.At(i)))
+		}
+	case pmetric.MetricDataTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			writeUint64(h, pdatahash.NumberDataPoint(dps.At(i)))
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			writeUint64(h, pdatahash.HistogramDataPoint(dps.At(i)))
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			writeUint64(h, pdatahash.ExponentialHistogramDataPoint(dps.At(i)))
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			writeUint64(h, pdatahash.SummaryDataPoint(dps.At(i)))
+		}
+	}
+}
+
+func writeUint64(h hash.Hash64, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	_, _ = h.Write(buf[:])
+}
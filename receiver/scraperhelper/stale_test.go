@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestFingerprint(t *testing.T) {
+	md := testdata.GenerateMetricsAllTypes()
+	assert.Equal(t, fingerprint(md), fingerprint(testdata.GenerateMetricsAllTypes()))
+
+	// The values, timestamps and names of the metrics are significant.
+	changed := testdata.GenerateMetricsAllTypes()
+	changed.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).SetIntVal(1000)
+	assert.NotEqual(t, fingerprint(md), fingerprint(changed))
+
+	changed = testdata.GenerateMetricsAllTypes()
+	changed.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).SetTimestamp(1)
+	assert.NotEqual(t, fingerprint(md), fingerprint(changed))
+
+	changed = testdata.GenerateMetricsAllTypes()
+	changed.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("renamed")
+	assert.NotEqual(t, fingerprint(md), fingerprint(changed))
+}
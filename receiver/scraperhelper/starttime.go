@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper // import "go.opentelemetry.io/collector/receiver/scraperhelper"
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// StartTimeTracker tracks the start timestamps of the cumulative streams scraped by pull-style
+// receivers, which usually only know the current value of the counters of their targets:
+//
+//   - the start timestamp of a stream is the start timestamp of its first point if set, e.g. the
+//     start time of the scraped process, or else the timestamp of its first point,
+//   - a stream is reset when its value decreases, or the buckets of a histogram change, its start
+//     timestamp becoming the timestamp of the previous point, since the reset happened after it,
+//   - a start timestamp set on a point and later than the start timestamp of the stream, e.g. after
+//     a restart of the scraped process, becomes the start timestamp of the stream.
+//
+// The consumers computing rates from the cumulative values, e.g. by converting them to delta
+// temporality, then see the resets instead of negative changes.
+type StartTimeTracker struct {
+	state *pmetric.BoundedState
+}
+
+// NewStartTimeTracker returns a StartTimeTracker storing the last points of the streams in a
+// pmetric.BoundedState with the settings, the first point of an evicted stream starting a new stream.
+func NewStartTimeTracker(settings pmetric.StateSettings) *StartTimeTracker {
+	return &StartTimeTracker{state: pmetric.NewBoundedState(settings)}
+}
+
+// AdjustStartTime returns the start timestamp of the cumulative value of the stream identified by the
+// key, tracking the value for the next ones. The values of non-monotonic sums do not reset the stream.
+// The start timestamp of the stream is returned for the points not newer than the last point.
+func (t *StartTimeTracker) AdjustStartTime(key string, value pmetric.CumulativeValue, isMonotonic bool) pcommon.Timestamp {
+	prev, ok := t.state.Load(key)
+	if ok && value.Timestamp <= prev.Timestamp {
+		return prev.StartTimestamp
+	}
+
+	switch {
+	case !ok:
+		if value.StartTimestamp == 0 || value.StartTimestamp > value.Timestamp {
+			value.StartTimestamp = value.Timestamp
+		}
+	case value.StartTimestamp > prev.StartTimestamp && value.StartTimestamp <= value.Timestamp:
+	case isReset(prev, value, isMonotonic):
+		value.StartTimestamp = prev.Timestamp
+	default:
+		value.StartTimestamp = prev.StartTimestamp
+	}
+	t.state.Store(key, value)
+	return value.StartTimestamp
+}
+
+// Adjust sets the start timestamps of the data points of the cumulative sums, histograms, exponential
+// histograms and summaries of the metrics, identified by their resource, scope, metric and attributes.
+func (t *StartTimeTracker) Adjust(md pmetric.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := attributesKey(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			scopeKey := resourceKey + "\x00" + sm.Scope().Name() + "\x00" + sm.Scope().Version()
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				t.adjustMetric(m, scopeKey+"\x00"+m.Name()+"\x00"+m.Unit()+"\x00"+m.DataType().String())
+			}
+		}
+	}
+}
+
+func (t *StartTimeTracker) adjustMetric(m pmetric.Metric, metricKey string) {
+	switch m.DataType() {
+	case pmetric.MetricDataTypeSum:
+		if m.Sum().AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+			return
+		}
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(t.AdjustStartTime(metricKey+"\x00"+attributesKey(dp.Attributes()), pmetric.CumulativeValue{
+				StartTimestamp: dp.StartTimestamp(),
+				Timestamp:      dp.Timestamp(),
+				ValueType:      dp.ValueType(),
+				IntVal:         dp.IntVal(),
+				DoubleVal:      dp.DoubleVal(),
+			}, m.Sum().IsMonotonic()))
+		}
+	case pmetric.MetricDataTypeHistogram:
+		if m.Histogram().AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+			return
+		}
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(t.AdjustStartTime(metricKey+"\x00"+attributesKey(dp.Attributes()), pmetric.CumulativeValue{
+				StartTimestamp: dp.StartTimestamp(),
+				Timestamp:      dp.Timestamp(),
+				Count:          dp.Count(),
+				BucketCounts:   dp.BucketCounts().AsRaw(),
+				ExplicitBounds: dp.ExplicitBounds().AsRaw(),
+			}, true))
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		if m.ExponentialHistogram().AggregationTemporality() != pmetric.MetricAggregationTemporalityCumulative {
+			return
+		}
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(t.AdjustStartTime(metricKey+"\x00"+attributesKey(dp.Attributes()), pmetric.CumulativeValue{
+				StartTimestamp: dp.StartTimestamp(),
+				Timestamp:      dp.Timestamp(),
+				Count:          dp.Count(),
+			}, true))
+		}
+	case pmetric.MetricDataTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(t.AdjustStartTime(metricKey+"\x00"+attributesKey(dp.Attributes()), pmetric.CumulativeValue{
+				StartTimestamp: dp.StartTimestamp(),
+				Timestamp:      dp.Timestamp(),
+				Count:          dp.Count(),
+			}, true))
+		}
+	}
+}
+
+// isReset returns whether the stream was reset between the previous and the current value. The sums
+// of the histograms and summaries are not compared since they decrease with negative observations.
+func isReset(prev, cur pmetric.CumulativeValue, isMonotonic bool) bool {
+	if prev.ValueType != cur.ValueType || cur.Count < prev.Count ||
+		len(prev.BucketCounts) != len(cur.BucketCounts) || len(prev.ExplicitBounds) != len(cur.ExplicitBounds) {
+		return true
+	}
+	for i := range cur.ExplicitBounds {
+		if cur.ExplicitBounds[i] != prev.ExplicitBounds[i] {
+			return true
+		}
+	}
+	for i := range cur.BucketCounts {
+		if cur.BucketCounts[i] < prev.BucketCounts[i] {
+			return true
+		}
+	}
+	return isMonotonic && (cur.IntVal < prev.IntVal || cur.DoubleVal < prev.DoubleVal)
+}
+
+// attributesKey returns a string identifying the attributes, independently of their order.
+func attributesKey(attrs pcommon.Map) string {
+	kvs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		kvs = append(kvs, k+"\x01"+v.Type().String()+"\x01"+v.AsString())
+		return true
+	})
+	sort.Strings(kvs)
+	return strings.Join(kvs, "\x02")
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func intValue(start, ts pcommon.Timestamp, val int64) pmetric.CumulativeValue {
+	return pmetric.CumulativeValue{StartTimestamp: start, Timestamp: ts, ValueType: pmetric.NumberDataPointValueTypeInt, IntVal: val}
+}
+
+func TestAdjustStartTime(t *testing.T) {
+	type point struct {
+		value     pmetric.CumulativeValue
+		wantStart pcommon.Timestamp
+	}
+	tests := []struct {
+		name        string
+		isMonotonic bool
+		points      []point
+	}{
+		{
+			name:        "UnknownStart",
+			isMonotonic: true,
+			points: []point{
+				{value: intValue(0, 10, 5), wantStart: 10},
+				{value: intValue(0, 20, 7), wantStart: 10},
+				{value: intValue(0, 30, 7), wantStart: 10},
+			},
+		},
+		{
+			name:        "KnownStart",
+			isMonotonic: true,
+			points: []point{
+				{value: intValue(5, 10, 5), wantStart: 5},
+				{value: intValue(5, 20, 7), wantStart: 5},
+			},
+		},
+		{
+			name:        "Reset",
+			isMonotonic: true,
+			points: []point{
+				{value: intValue(0, 10, 5), wantStart: 10},
+				{value: intValue(0, 20, 7), wantStart: 10},
+				{value: intValue(0, 30, 2), wantStart: 20},
+				{value: intValue(0, 40, 4), wantStart: 20},
+			},
+		},
+		{
+			name:        "ResetWithKnownStart",
+			isMonotonic: true,
+			points: []point{
+				{value: intValue(5, 10, 5), wantStart: 5},
+				{value: intValue(5, 20, 2), wantStart: 10},
+				{value: intValue(5, 30, 4), wantStart: 10},
+			},
+		},
+		{
+			name:        "Restart",
+			isMonotonic: true,
+			points: []point{
+				{value: intValue(5, 10, 5), wantStart: 5},
+				{value: intValue(15, 20, 8), wantStart: 15},
+				{value: intValue(15, 30, 9), wantStart: 15},
+			},
+		},
+		{
+			name:        "NonMonotonic",
+			isMonotonic: false,
+			points: []point{
+				{value: intValue(0, 10, 5), wantStart: 10},
+				{value: intValue(0, 20, 2), wantStart: 10},
+			},
+		},
+		{
+			name:        "OutOfOrder",
+			isMonotonic: true,
+			points: []point{
+				{value: intValue(0, 20, 5), wantStart: 20},
+				{value: intValue(0, 10, 1), wantStart: 20},
+				{value: intValue(0, 30, 6), wantStart: 20},
+			},
+		},
+		{
+			name:        "ValueTypeChanged",
+			isMonotonic: true,
+			points: []point{
+				{value: intValue(0, 10, 5), wantStart: 10},
+				{value: pmetric.CumulativeValue{Timestamp: 20, ValueType: pmetric.NumberDataPointValueTypeDouble, DoubleVal: 6}, wantStart: 10},
+			},
+		},
+		{
+			name:        "HistogramBucketsChanged",
+			isMonotonic: true,
+			points: []point{
+				{value: pmetric.CumulativeValue{Timestamp: 10, Count: 3, BucketCounts: []uint64{1, 2}, ExplicitBounds: []float64{1}}, wantStart: 10},
+				{value: pmetric.CumulativeValue{Timestamp: 20, Count: 4, BucketCounts: []uint64{2, 2}, ExplicitBounds: []float64{1}}, wantStart: 10},
+				{value: pmetric.CumulativeValue{Timestamp: 30, Count: 5, BucketCounts: []uint64{1, 2, 2}, ExplicitBounds: []float64{1, 2}}, wantStart: 20},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewStartTimeTracker(pmetric.StateSettings{})
+			for i, p := range tt.points {
+				assert.Equal(t, p.wantStart, tracker.AdjustStartTime("key", p.value, tt.isMonotonic), "point %d", i)
+			}
+		})
+	}
+}
+
+func TestStartTimeTrackerAdjust(t *testing.T) {
+	scrape := func(ts pcommon.Timestamp, counter int64, count uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("host.name", "host")
+		ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+		m := ms.AppendEmpty()
+		m.SetName("requests")
+		m.SetDataType(pmetric.MetricDataTypeSum)
+		m.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		m.Sum().SetIsMonotonic(true)
+		for _, path := range []string{"/a", "/b"} {
+			dp := m.Sum().DataPoints().AppendEmpty()
+			dp.Attributes().InsertString("path", path)
+			dp.SetTimestamp(ts)
+			dp.SetIntVal(counter)
+		}
+
+		m = ms.AppendEmpty()
+		m.SetName("latency")
+		m.SetDataType(pmetric.MetricDataTypeSummary)
+		dp := m.Summary().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetCount(count)
+
+		m = ms.AppendEmpty()
+		m.SetName("temperature")
+		m.SetDataType(pmetric.MetricDataTypeGauge)
+		m.Gauge().DataPoints().AppendEmpty().SetTimestamp(ts)
+		return md
+	}
+	startTimes := func(md pmetric.Metrics) []pcommon.Timestamp {
+		var ret []pcommon.Timestamp
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for i := 0; i < ms.At(0).Sum().DataPoints().Len(); i++ {
+			ret = append(ret, ms.At(0).Sum().DataPoints().At(i).StartTimestamp())
+		}
+		ret = append(ret, ms.At(1).Summary().DataPoints().At(0).StartTimestamp())
+		return append(ret, ms.At(2).Gauge().DataPoints().At(0).StartTimestamp())
+	}
+
+	tracker := NewStartTimeTracker(pmetric.StateSettings{MaxStreams: 10})
+	md := scrape(10, 5, 2)
+	tracker.Adjust(md)
+	assert.Equal(t, []pcommon.Timestamp{10, 10, 10, 0}, startTimes(md))
+
+	md = scrape(20, 7, 3)
+	tracker.Adjust(md)
+	assert.Equal(t, []pcommon.Timestamp{10, 10, 10, 0}, startTimes(md))
+
+	md = scrape(30, 1, 4)
+	tracker.Adjust(md)
+	require.Equal(t, []pcommon.Timestamp{20, 20, 10, 0}, startTimes(md))
+
+	md = scrape(40, 3, 1)
+	tracker.Adjust(md)
+	assert.Equal(t, []pcommon.Timestamp{20, 20, 30, 0}, startTimes(md))
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection // import "go.opentelemetry.io/collector/resourcedetection"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
+)
+
+// envResourceAttributes is the environment variable defined by the OpenTelemetry specification
+// for the resource attributes, as a comma separated list of key=value pairs.
+const envResourceAttributes = "OTEL_RESOURCE_ATTRIBUTES"
+
+// cgroupPath is the file listing the cgroups of the process, a variable for the tests.
+var cgroupPath = "/proc/self/cgroup"
+
+// containerIDRegexp matches the 64 hexadecimal characters of the container IDs in the cgroup paths,
+// e.g. "/docker/<id>", "/kubepods/burstable/pod<uid>/<id>" or "/system.slice/docker-<id>.scope".
+var containerIDRegexp = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+// detectEnv detects the attributes of the OTEL_RESOURCE_ATTRIBUTES environment variable.
+func detectEnv(context.Context) (pcommon.Resource, error) {
+	res := pcommon.NewResource()
+	value := strings.TrimSpace(os.Getenv(envResourceAttributes))
+	if value == "" {
+		return res, nil
+	}
+	attrs := res.Attributes()
+	for _, pair := range strings.Split(value, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return res, fmt.Errorf("invalid %s pair %q, must be key=value", envResourceAttributes, pair)
+		}
+		// The values are percent encoded to allow commas and equal signs.
+		v, err := url.QueryUnescape(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return res, fmt.Errorf("invalid %s value of %q: %w", envResourceAttributes, kv[0], err)
+		}
+		attrs.UpsertString(strings.TrimSpace(kv[0]), v)
+	}
+	return res, nil
+}
+
+// detectHost detects the host.name and os.type attributes.
+func detectHost(context.Context) (pcommon.Resource, error) {
+	res := pcommon.NewResource()
+	hostname, err := os.Hostname()
+	if err != nil {
+		return res, err
+	}
+	res.Attributes().InsertString(semconv.AttributeHostName, hostname)
+	// The GOOS values match the os.type values of the semantic conventions, except for Solaris.
+	osType := runtime.GOOS
+	if osType == "illumos" {
+		osType = semconv.AttributeOSTypeSolaris
+	}
+	res.Attributes().InsertString(semconv.AttributeOSType, osType)
+	return res, nil
+}
+
+// detectContainer detects the container.id attribute from the cgroups of the process, the resource
+// being empty if the collector does not run in a container.
+func detectContainer(context.Context) (pcommon.Resource, error) {
+	res := pcommon.NewResource()
+	f, err := os.Open(cgroupPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Not a Linux host, cannot be a container.
+			return res, nil
+		}
+		return res, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := containerIDRegexp.FindStringSubmatch(strings.TrimSpace(scanner.Text())); m != nil {
+			res.Attributes().InsertString(semconv.AttributeContainerID, m[1])
+			break
+		}
+	}
+	return res, scanner.Err()
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectEnv(t *testing.T) {
+	t.Setenv(envResourceAttributes, "service.namespace=payments, deployment.environment=prod,team=a%2Cb")
+	res, err := detectEnv(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"service.namespace":      "payments",
+		"deployment.environment": "prod",
+		"team":                   "a,b",
+	}, res.Attributes().AsRaw())
+
+	t.Setenv(envResourceAttributes, "")
+	res, err = detectEnv(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+
+	t.Setenv(envResourceAttributes, "service.namespace")
+	_, err = detectEnv(context.Background())
+	assert.EqualError(t, err, `invalid OTEL_RESOURCE_ATTRIBUTES pair "service.namespace", must be key=value`)
+}
+
+func TestDetectHost(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	res, err := detectHost(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"host.name": hostname,
+		"os.type":   runtime.GOOS,
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectContainer(t *testing.T) {
+	const id = "7be92808767a46bd0f64b5862d4be8a0ad6c31e3a41d2bb35ee2ae8e399a2a25"
+	tests := []struct {
+		name   string
+		cgroup string
+		want   map[string]interface{}
+	}{
+		{
+			name:   "docker",
+			cgroup: "12:pids:/docker/" + id + "\n0::/docker/" + id + "\n",
+			want:   map[string]interface{}{"container.id": id},
+		},
+		{
+			name:   "systemd",
+			cgroup: "0::/system.slice/docker-" + id + ".scope\n",
+			want:   map[string]interface{}{"container.id": id},
+		},
+		{
+			name:   "kubernetes",
+			cgroup: "11:memory:/kubepods/burstable/pod9f069a3c-4b4b-4a53-a4ea-1dc0a4dd5b9c/" + id + "\n",
+			want:   map[string]interface{}{"container.id": id},
+		},
+		{
+			name:   "host",
+			cgroup: "0::/user.slice/user-1000.slice/session-2.scope\n",
+			want:   map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cgroup")
+			require.NoError(t, os.WriteFile(path, []byte(tt.cgroup), 0600))
+			setCgroupPath(t, path)
+
+			res, err := detectContainer(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, res.Attributes().AsRaw())
+		})
+	}
+
+	t.Run("not_linux", func(t *testing.T) {
+		setCgroupPath(t, filepath.Join(t.TempDir(), "missing"))
+		res, err := detectContainer(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, res.Attributes().Len())
+	})
+}
+
+func setCgroupPath(t *testing.T, path string) {
+	old := cgroupPath
+	cgroupPath = path
+	t.Cleanup(func() { cgroupPath = old })
+}
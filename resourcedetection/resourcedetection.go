@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcedetection detects the resource attributes of the environment the collector
+// runs in, e.g. the host name or the container ID. The detectors are registered by name, the
+// built-in "env", "host" and "container" detectors being extended by the detectors of other
+// modules, e.g. detectors querying the metadata endpoints of the cloud providers for the cloud.*
+// attributes.
+package resourcedetection // import "go.opentelemetry.io/collector/resourcedetection"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Detector detects the resource attributes of the environment the collector runs in.
+type Detector interface {
+	// Detect returns the detected attributes as a resource, which is empty if the detector
+	// does not apply to the environment, e.g. the collector does not run in a container.
+	Detect(ctx context.Context) (pcommon.Resource, error)
+}
+
+// DetectorFunc is a helper function that is similar to Detector.Detect,
+// allowing to provide custom detectors without defining a type.
+type DetectorFunc func(ctx context.Context) (pcommon.Resource, error)
+
+// Detect calls f(ctx).
+func (f DetectorFunc) Detect(ctx context.Context) (pcommon.Resource, error) {
+	return f(ctx)
+}
+
+var (
+	detectorsMu sync.RWMutex
+	detectors   = map[string]Detector{
+		"env":       DetectorFunc(detectEnv),
+		"host":      DetectorFunc(detectHost),
+		"container": DetectorFunc(detectContainer),
+	}
+)
+
+// Register registers the Detector with the given name, e.g. "ec2". It must be called before the
+// detectors are used, typically when the collector distribution is initialized.
+func Register(name string, detector Detector) error {
+	if name == "" {
+		return errors.New("detector name must not be empty")
+	}
+	detectorsMu.Lock()
+	defer detectorsMu.Unlock()
+	if _, ok := detectors[name]; ok {
+		return fmt.Errorf("detector %q is already registered", name)
+	}
+	detectors[name] = detector
+	return nil
+}
+
+// IsRegistered returns whether a Detector is registered with the given name.
+func IsRegistered(name string) bool {
+	detectorsMu.RLock()
+	defer detectorsMu.RUnlock()
+	_, ok := detectors[name]
+	return ok
+}
+
+// Detect runs the named detectors in order and merges the detected attributes, the attributes of
+// the first detectors taking precedence. The detectors failing are skipped, their errors being
+// returned along with the attributes detected by the others.
+func Detect(ctx context.Context, names []string) (pcommon.Resource, error) {
+	res := pcommon.NewResource()
+	var errs error
+	for _, name := range names {
+		detectorsMu.RLock()
+		detector, ok := detectors[name]
+		detectorsMu.RUnlock()
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("detector %q is not registered", name))
+			continue
+		}
+		detected, err := detector.Detect(ctx)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("detector %q failed: %w", name, err))
+			continue
+		}
+		Merge(res, detected)
+	}
+	return res, errs
+}
+
+// Merge inserts the attributes of from in to, the existing attributes of to taking precedence.
+func Merge(to pcommon.Resource, from pcommon.Resource) {
+	attrs := to.Attributes()
+	from.Attributes().Range(func(k string, v pcommon.Value) bool {
+		attrs.Insert(k, v)
+		return true
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func newResource(attrs map[string]string) pcommon.Resource {
+	res := pcommon.NewResource()
+	for k, v := range attrs {
+		res.Attributes().InsertString(k, v)
+	}
+	return res
+}
+
+func TestRegister(t *testing.T) {
+	detector := DetectorFunc(func(context.Context) (pcommon.Resource, error) {
+		return pcommon.NewResource(), nil
+	})
+	assert.EqualError(t, Register("", detector), "detector name must not be empty")
+	assert.EqualError(t, Register("host", detector), `detector "host" is already registered`)
+
+	assert.False(t, IsRegistered("test_register"))
+	require.NoError(t, Register("test_register", detector))
+	assert.True(t, IsRegistered("test_register"))
+}
+
+func TestDetect(t *testing.T) {
+	require.NoError(t, Register("test_first", DetectorFunc(func(context.Context) (pcommon.Resource, error) {
+		return newResource(map[string]string{"cloud.provider": "first", "cloud.region": "region"}), nil
+	})))
+	require.NoError(t, Register("test_second", DetectorFunc(func(context.Context) (pcommon.Resource, error) {
+		return newResource(map[string]string{"cloud.provider": "second", "cloud.account.id": "account"}), nil
+	})))
+	require.NoError(t, Register("test_failing", DetectorFunc(func(context.Context) (pcommon.Resource, error) {
+		return pcommon.NewResource(), errors.New("metadata endpoint unreachable")
+	})))
+
+	res, err := Detect(context.Background(), []string{"test_first", "test_failing", "test_second"})
+	assert.EqualError(t, err, `detector "test_failing" failed: metadata endpoint unreachable`)
+	// The attributes of the first detectors take precedence.
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":   "first",
+		"cloud.region":     "region",
+		"cloud.account.id": "account",
+	}, res.Attributes().AsRaw())
+
+	res, err = Detect(context.Background(), []string{"test_unknown", "test_second"})
+	assert.EqualError(t, err, `detector "test_unknown" is not registered`)
+	assert.Equal(t, 2, res.Attributes().Len())
+}
+
+func TestMerge(t *testing.T) {
+	to := newResource(map[string]string{"host.name": "configured"})
+	Merge(to, newResource(map[string]string{"host.name": "detected", "os.type": "linux"}))
+	assert.Equal(t, map[string]interface{}{"host.name": "configured", "os.type": "linux"}, to.Attributes().AsRaw())
+}
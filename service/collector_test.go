@@ -450,6 +450,8 @@ func assertZPages(t *testing.T) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/lifecyclez",
+		"/debug/lifecyclez/json",
 	}
 
 	const defaultZPagesPort = "55679"
@@ -30,8 +30,6 @@ import (
 	"golang.org/x/sys/windows/svc"
 	"golang.org/x/sys/windows/svc/eventlog"
 
-	"go.opentelemetry.io/collector/confmap"
-	"go.opentelemetry.io/collector/confmap/converter/overwritepropertiesconverter"
 	"go.opentelemetry.io/collector/service/featuregate"
 )
 
@@ -95,7 +93,7 @@ func (s *windowsService) start(elog *eventlog.Log, colErrorChannel chan error) e
 	if err := s.flags.Parse(os.Args[1:]); err != nil {
 		return err
 	}
-	featuregate.GetRegistry().Apply(gatesList)
+	featuregate.GetRegistry().ApplyFlags(gatesList)
 	var err error
 	s.col, err = newWithWindowsEventLogCore(s.settings, s.flags, elog)
 	if err != nil {
@@ -143,12 +141,7 @@ func openEventLog(serviceName string) (*eventlog.Log, error) {
 func newWithWindowsEventLogCore(set CollectorSettings, flags *flag.FlagSet, elog *eventlog.Log) (*Collector, error) {
 	if set.ConfigProvider == nil {
 		var err error
-		cfgSet := newDefaultConfigProviderSettings(getConfigFlag(flags))
-		// Append the "overwrite properties converter" as the first converter.
-		cfgSet.MapConverters = append(
-			[]confmap.Converter{overwritepropertiesconverter.New(getSetFlag(flags))},
-			cfgSet.MapConverters...)
-		set.ConfigProvider, err = NewConfigProvider(cfgSet)
+		set.ConfigProvider, err = NewConfigProvider(newFlagsConfigProviderSettings(flags))
 		if err != nil {
 			return nil, err
 		}
@@ -15,6 +15,8 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"flag"
+
 	"github.com/spf13/cobra"
 
 	"go.opentelemetry.io/collector/confmap"
@@ -30,15 +32,10 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 		Version:      set.BuildInfo.Version,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			featuregate.GetRegistry().Apply(gatesList)
+			featuregate.GetRegistry().ApplyFlags(gatesList)
 			if set.ConfigProvider == nil {
 				var err error
-				cfgSet := newDefaultConfigProviderSettings(getConfigFlag(flagSet))
-				// Append the "overwrite properties converter" as the first converter.
-				cfgSet.MapConverters = append(
-					[]confmap.Converter{overwritepropertiesconverter.New(getSetFlag(flagSet))},
-					cfgSet.MapConverters...)
-				set.ConfigProvider, err = NewConfigProvider(cfgSet)
+				set.ConfigProvider, err = NewConfigProvider(newFlagsConfigProviderSettings(flagSet))
 				if err != nil {
 					return err
 				}
@@ -52,5 +49,18 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	}
 
 	rootCmd.Flags().AddGoFlagSet(flagSet)
+	rootCmd.AddCommand(newValidateCommand(set))
+	rootCmd.AddCommand(newComponentsCommand(set))
 	return rootCmd
 }
+
+// newFlagsConfigProviderSettings returns the ConfigProviderSettings for the config locations and properties of the flags.
+func newFlagsConfigProviderSettings(flagSet *flag.FlagSet) ConfigProviderSettings {
+	cfgSet := newDefaultConfigProviderSettings(getConfigFlag(flagSet))
+	// Append the "overwrite properties converter" as the first converter.
+	cfgSet.MapConverters = append(
+		[]confmap.Converter{overwritepropertiesconverter.New(getSetFlag(flagSet))},
+		cfgSet.MapConverters...)
+	cfgSet.ListMergeStrategies = getListMergeFlag(flagSet)
+	return cfgSet
+}
@@ -23,6 +23,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
 )
 
 func TestNewCommandVersion(t *testing.T) {
@@ -48,3 +49,21 @@ func TestNewCommandInvalidComponent(t *testing.T) {
 	cmd := NewCommand(CollectorSettings{Factories: factories, ConfigProvider: cfgProvider})
 	require.Error(t, cmd.Execute())
 }
+
+func TestNewFlagsConfigProviderSettingsListMerge(t *testing.T) {
+	flagSet := flags()
+	require.NoError(t, flagSet.Parse([]string{
+		"--config=file:base.yaml",
+		"--config=file:overlay.yaml",
+		"--config-list-merge=service::pipelines::*::receivers=append",
+		"--config-list-merge=processors::attributes::actions=merge_by_key:key",
+	}))
+	cfgSet := newFlagsConfigProviderSettings(flagSet)
+	assert.Equal(t, map[string]confmap.ListMergeStrategy{
+		"service::pipelines::*::receivers": confmap.ListMergeAppend,
+		"processors::attributes::actions":  confmap.ListMergeByKey("key"),
+	}, cfgSet.ListMergeStrategies)
+
+	assert.Error(t, flags().Parse([]string{"--config-list-merge=append"}))
+	assert.Error(t, flags().Parse([]string{"--config-list-merge=service::extensions=prepend"}))
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// componentsOutput is the output of the components command.
+type componentsOutput struct {
+	BuildInfo  component.BuildInfo `yaml:"buildinfo"`
+	Receivers  []componentInfo     `yaml:"receivers"`
+	Processors []componentInfo     `yaml:"processors"`
+	Exporters  []componentInfo     `yaml:"exporters"`
+	Extensions []componentInfo     `yaml:"extensions"`
+}
+
+// componentInfo describes a component type of the components command.
+type componentInfo struct {
+	Name config.Type `yaml:"name"`
+	// Stability is the stability level of the component per supported data type, or of the extension.
+	Stability map[string]string `yaml:"stability"`
+}
+
+// newComponentsCommand constructs the components subcommand which prints the build info and the component
+// types of the collector, with their stability levels, as YAML.
+func newComponentsCommand(set CollectorSettings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "components",
+		Short: "Outputs the available components and their stability levels",
+		Long:  "Outputs the build info and the receivers, processors, exporters and extensions of the collector, with their stability levels, as YAML.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			enc := yaml.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent(2)
+			if err := enc.Encode(listComponents(set)); err != nil {
+				return err
+			}
+			return enc.Close()
+		},
+	}
+}
+
+// dataTypesFactory is implemented by the factories of the components of the pipelines.
+type dataTypesFactory interface {
+	component.Factory
+	SupportedDataTypes() []config.DataType
+}
+
+func listComponents(set CollectorSettings) componentsOutput {
+	out := componentsOutput{
+		BuildInfo:  set.BuildInfo,
+		Receivers:  []componentInfo{},
+		Processors: []componentInfo{},
+		Exporters:  []componentInfo{},
+		Extensions: []componentInfo{},
+	}
+	for _, f := range set.Factories.Receivers {
+		out.Receivers = append(out.Receivers, newDataTypesComponentInfo(f))
+	}
+	for _, f := range set.Factories.Processors {
+		out.Processors = append(out.Processors, newDataTypesComponentInfo(f))
+	}
+	for _, f := range set.Factories.Exporters {
+		out.Exporters = append(out.Exporters, newDataTypesComponentInfo(f))
+	}
+	for _, f := range set.Factories.Extensions {
+		out.Extensions = append(out.Extensions, componentInfo{
+			Name:      f.Type(),
+			Stability: map[string]string{"extension": f.ExtensionStability().String()},
+		})
+	}
+	for _, infos := range [][]componentInfo{out.Receivers, out.Processors, out.Exporters, out.Extensions} {
+		sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	}
+	return out
+}
+
+func newDataTypesComponentInfo(f dataTypesFactory) componentInfo {
+	info := componentInfo{Name: f.Type(), Stability: make(map[string]string)}
+	for _, dt := range f.SupportedDataTypes() {
+		info.Stability[string(dt)] = f.StabilityLevel(dt).String()
+	}
+	return info
+}
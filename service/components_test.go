@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+)
+
+func TestComponentsCommand(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Exporters["otlp"] = component.NewExporterFactory("otlp",
+		func() config.Exporter { return nil },
+		component.WithTracesExporterAndStabilityLevel(nil, component.StabilityLevelStable),
+		component.WithLogsExporterAndStabilityLevel(nil, component.StabilityLevelBeta))
+	factories.Extensions["zpages"] = component.NewExtensionFactoryWithStabilityLevel("zpages",
+		func() config.Extension { return nil }, nil, component.StabilityLevelAlpha)
+
+	cmd := NewCommand(CollectorSettings{
+		BuildInfo: component.BuildInfo{Command: "otelcol", Description: "OpenTelemetry Collector", Version: "1.0.0"},
+		Factories: factories,
+	})
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"components"})
+	require.NoError(t, cmd.ExecuteContext(context.Background()))
+	assert.Equal(t, `buildinfo:
+  command: otelcol
+  description: OpenTelemetry Collector
+  version: 1.0.0
+receivers:
+  - name: nop
+    stability:
+      logs: stable
+      metrics: stable
+      traces: stable
+processors:
+  - name: nop
+    stability:
+      logs: stable
+      metrics: stable
+      traces: stable
+exporters:
+  - name: nop
+    stability:
+      logs: stable
+      metrics: stable
+      traces: stable
+  - name: otlp
+    stability:
+      logs: beta
+      traces: stable
+extensions:
+  - name: nop
+    stability:
+      extension: stable
+  - name: zpages
+    stability:
+      extension: alpha
+`, out.String())
+}
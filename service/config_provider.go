@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
 	"go.opentelemetry.io/collector/confmap/provider/envprovider"
 	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/confmap/provider/k8sprovider"
 	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
 	"go.opentelemetry.io/collector/service/internal/configunmarshaler"
 )
@@ -78,12 +79,16 @@ type ConfigProviderSettings struct {
 
 	// MapConverters is a slice of confmap.Converter.
 	MapConverters []confmap.Converter
+
+	// ListMergeStrategies are the confmap.ListMergeStrategy merging the lists of the configurations retrieved
+	// from the locations, keyed by the keys of the lists. The lists are replaced by default.
+	ListMergeStrategies map[string]confmap.ListMergeStrategy
 }
 
 func newDefaultConfigProviderSettings(locations []string) ConfigProviderSettings {
 	return ConfigProviderSettings{
 		Locations:     locations,
-		MapProviders:  makeMapProvidersMap(fileprovider.New(), envprovider.New(), yamlprovider.New()),
+		MapProviders:  makeMapProvidersMap(fileprovider.New(), envprovider.New(), yamlprovider.New(), k8sprovider.New()),
 		MapConverters: []confmap.Converter{expandconverter.New()},
 	}
 }
@@ -94,7 +99,12 @@ func newDefaultConfigProviderSettings(locations []string) ConfigProviderSettings
 // 	 * Then applies all the confmap.Converter in the given order.
 // * Then unmarshalls the confmap.Conf into the service Config.
 func NewConfigProvider(set ConfigProviderSettings) (ConfigProvider, error) {
-	mr, err := confmap.NewResolver(confmap.ResolverSettings{URIs: set.Locations, Providers: set.MapProviders, Converters: set.MapConverters})
+	mr, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs:                set.Locations,
+		Providers:           set.MapProviders,
+		Converters:          set.MapConverters,
+		ListMergeStrategies: set.ListMergeStrategies,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -24,6 +24,9 @@ import (
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/recovery"
+	"go.opentelemetry.io/collector/service/watchdog"
 )
 
 var (
@@ -81,6 +84,16 @@ func (nc *nopExtConfig) Validate() error {
 	return nil
 }
 
+// dependentRecvConfig is a receiver configuration depending on extensions.
+type dependentRecvConfig struct {
+	nopRecvConfig
+	dependencies []config.ComponentID
+}
+
+func (dc *dependentRecvConfig) ExtensionDependencies() []config.ComponentID {
+	return dc.dependencies
+}
+
 func TestConfigValidate(t *testing.T) {
 	var testCases = []struct {
 		name     string // test case name (also file name containing config yaml)
@@ -101,6 +114,80 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "invalid-service-telemetry-logs-component",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Components = map[string]zapcore.Level{"otlp": zapcore.DebugLevel}
+				return cfg
+			},
+			expected: fmt.Errorf(`service telemetry has invalid configuration: %w`, errors.New(`log level component "otlp" must be <section>.<id>, e.g. "exporters.otlp"`)),
+		},
+		{
+			name: "invalid-service-telemetry-logs-component-section",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Components = map[string]zapcore.Level{"exporter.otlp": zapcore.DebugLevel}
+				return cfg
+			},
+			expected: fmt.Errorf(`service telemetry has invalid configuration: %w`, errors.New(`log level component "exporter.otlp" has an unknown section "exporter"`)),
+		},
+		{
+			name: "invalid-service-telemetry-views",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Views = []telemetry.ViewConfig{{Name: ""}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service telemetry has invalid configuration: %w`, errors.New("view name must not be empty")),
+		},
+		{
+			name: "invalid-service-telemetry-propagators",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Propagators = []string{"tracecontext", "unknown"}
+				return cfg
+			},
+			expected: fmt.Errorf(`service telemetry has invalid configuration: %w`, errors.New(`unsupported trace propagator "unknown", must be one of "tracecontext" or "baggage"`)),
+		},
+		{
+			name: "invalid-service-telemetry-resource-detector",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.ResourceDetection.Detectors = []string{"host", "unknown"}
+				return cfg
+			},
+			expected: fmt.Errorf(`service telemetry has invalid configuration: %w`, errors.New(`resource detector "unknown" is not registered`)),
+		},
+		{
+			name: "duplicate-service-telemetry-resource-detector",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.ResourceDetection.Detectors = []string{"host", "env", "host"}
+				return cfg
+			},
+			expected: fmt.Errorf(`service telemetry has invalid configuration: %w`, errors.New(`resource detector "host" is configured more than once`)),
+		},
+		{
+			name: "invalid-service-watchdog",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Watchdog = watchdog.NewDefaultConfig()
+				cfg.Service.Watchdog.Enabled = true
+				cfg.Service.Watchdog.MaxBackoff = 0
+				return cfg
+			},
+			expected: fmt.Errorf(`service watchdog has invalid configuration: %w`, errors.New(`initial_backoff must not be negative nor greater than max_backoff`)),
+		},
+		{
+			name: "invalid-service-recovery",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Recovery = recovery.Config{Components: map[string]bool{"otlp": true}}
+				return cfg
+			},
+			expected: fmt.Errorf(`service recovery has invalid configuration: %w`, errors.New(`recovery component "otlp" must be <section>.<id>, e.g. "exporters.otlp"`)),
+		},
 		{
 			name: "missing-exporters",
 			cfgFn: func() *Config {
@@ -231,6 +318,30 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`extension "nop" has invalid configuration: %w`, errInvalidExtConfig),
 		},
+		{
+			name: "enabled-extension-dependency",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Receivers[config.NewComponentID("nop")] = &dependentRecvConfig{
+					nopRecvConfig: nopRecvConfig{ReceiverSettings: config.NewReceiverSettings(config.NewComponentID("nop"))},
+					dependencies:  []config.ComponentID{config.NewComponentID("nop")},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "missing-extension-dependency",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Receivers[config.NewComponentID("nop")] = &dependentRecvConfig{
+					nopRecvConfig: nopRecvConfig{ReceiverSettings: config.NewReceiverSettings(config.NewComponentID("nop"))},
+					dependencies:  []config.ComponentID{config.NewComponentID("oidc")},
+				}
+				return cfg
+			},
+			expected: errors.New(`receiver "nop" depends on extension "oidc" which is not enabled in the service`),
+		},
 	}
 
 	for _, test := range testCases {
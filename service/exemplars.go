@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opencensus.io/stats/view"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+)
+
+// exemplarGatherer adds the exemplars recorded by obsreport to the counters and histograms gathered
+// from the views, linking each series to the trace of its last measurement recorded in a sampled span.
+type exemplarGatherer struct {
+	gatherer prometheus.Gatherer
+	// views are the views of the counters and histograms keyed by the names of their metric families.
+	views map[string]*view.View
+}
+
+func newExemplarGatherer(gatherer prometheus.Gatherer, namespace string, views []*view.View) *exemplarGatherer {
+	g := &exemplarGatherer{gatherer: gatherer, views: make(map[string]*view.View, len(views))}
+	for _, v := range views {
+		if v.Aggregation.Type != view.AggTypeSum && v.Aggregation.Type != view.AggTypeDistribution {
+			continue
+		}
+		g.views[namespace+"_"+sanitizePrometheusKey(v.Name)] = v
+	}
+	return g
+}
+
+func (g *exemplarGatherer) Gather() ([]*dto.MetricFamily, error) {
+	mfs, err := g.gatherer.Gather()
+	for _, mf := range mfs {
+		v, ok := g.views[mf.GetName()]
+		if !ok {
+			continue
+		}
+		exemplars := obsreportconfig.Exemplars(v.Measure.Name())
+		if len(exemplars) == 0 {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			e, found := lastExemplar(exemplars, v, m.GetLabel())
+			if !found {
+				continue
+			}
+			switch {
+			case m.Counter != nil:
+				m.Counter.Exemplar = toPromExemplar(e)
+			case m.Histogram != nil:
+				for _, b := range m.Histogram.GetBucket() {
+					if e.Value <= b.GetUpperBound() {
+						b.Exemplar = toPromExemplar(e)
+						break
+					}
+				}
+			}
+		}
+	}
+	return mfs, err
+}
+
+// lastExemplar returns the last of the exemplars with the values of the labels of the series, the tags
+// dropped from the view being ignored.
+func lastExemplar(exemplars []obsreportconfig.Exemplar, v *view.View, labels []*dto.LabelPair) (obsreportconfig.Exemplar, bool) {
+	values := make(map[string]string, len(labels))
+	for _, l := range labels {
+		values[l.GetName()] = l.GetValue()
+	}
+
+	var last obsreportconfig.Exemplar
+	found := false
+	for _, e := range exemplars {
+		matches := true
+		for _, k := range v.TagKeys {
+			if values[sanitizePrometheusKey(k.Name())] != e.Tags[k.Name()] {
+				matches = false
+				break
+			}
+		}
+		if matches && (!found || e.Timestamp.After(last.Timestamp)) {
+			last = e
+			found = true
+		}
+	}
+	return last, found
+}
+
+func toPromExemplar(e obsreportconfig.Exemplar) *dto.Exemplar {
+	return &dto.Exemplar{
+		Label: []*dto.LabelPair{
+			{Name: proto.String("trace_id"), Value: proto.String(e.TraceID.String())},
+			{Name: proto.String("span_id"), Value: proto.String(e.SpanID.String())},
+		},
+		Value:     proto.Float64(e.Value),
+		Timestamp: timestamppb.New(e.Timestamp),
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+func TestExemplarGatherer(t *testing.T) {
+	obsreportconfig.SetExemplarsEnabled(true)
+	defer obsreportconfig.SetExemplarsEnabled(false)
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	obsreportconfig.RecordExemplars(ctx, []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, "otlp")}, obsmetrics.ExporterSentSpans.M(5))
+	obsreportconfig.RecordExemplars(ctx, []tag.Mutator{
+		tag.Upsert(obsmetrics.TagKeyReceiver, "otlp"),
+		tag.Upsert(obsmetrics.TagKeyTransport, "http"),
+		tag.Upsert(obsmetrics.TagKeyDataType, "traces"),
+	}, obsmetrics.ReceiverRequestSize.M(3000))
+
+	views := []*view.View{
+		{
+			Name:        obsmetrics.ExporterSentSpans.Name(),
+			Measure:     obsmetrics.ExporterSentSpans,
+			TagKeys:     []tag.Key{obsmetrics.TagKeyExporter},
+			Aggregation: view.Sum(),
+		},
+		{
+			// The view drops the transport and data type tags of the measurement.
+			Name:        obsmetrics.ReceiverRequestSize.Name(),
+			Measure:     obsmetrics.ReceiverRequestSize,
+			TagKeys:     []tag.Key{obsmetrics.TagKeyReceiver},
+			Aggregation: view.Distribution(1024, 4096),
+		},
+	}
+	label := func(name, value string) *dto.LabelPair {
+		return &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)}
+	}
+	gatherer := prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return []*dto.MetricFamily{
+			{
+				Name: proto.String("otelcol_exporter_sent_spans"),
+				Metric: []*dto.Metric{
+					{Label: []*dto.LabelPair{label("exporter", "otlp")}, Counter: &dto.Counter{Value: proto.Float64(5)}},
+					{Label: []*dto.LabelPair{label("exporter", "otlp/2")}, Counter: &dto.Counter{Value: proto.Float64(1)}},
+				},
+			},
+			{
+				Name: proto.String("otelcol_receiver_request_size"),
+				Metric: []*dto.Metric{{
+					Label: []*dto.LabelPair{label("receiver", "otlp")},
+					Histogram: &dto.Histogram{Bucket: []*dto.Bucket{
+						{UpperBound: proto.Float64(1024), CumulativeCount: proto.Uint64(0)},
+						{UpperBound: proto.Float64(4096), CumulativeCount: proto.Uint64(1)},
+					}},
+				}},
+			},
+		}, nil
+	})
+
+	mfs, err := newExemplarGatherer(gatherer, "otelcol", views).Gather()
+	require.NoError(t, err)
+	require.Len(t, mfs, 2)
+
+	exemplar := mfs[0].Metric[0].Counter.GetExemplar()
+	require.NotNil(t, exemplar)
+	assert.Equal(t, float64(5), exemplar.GetValue())
+	assert.Equal(t, []*dto.LabelPair{
+		label("trace_id", "0102030405060708090a0b0c0d0e0f10"),
+		label("span_id", "0102030405060708"),
+	}, exemplar.GetLabel())
+	assert.Nil(t, mfs[0].Metric[1].Counter.GetExemplar())
+
+	buckets := mfs[1].Metric[0].Histogram.GetBucket()
+	assert.Nil(t, buckets[0].GetExemplar())
+	require.NotNil(t, buckets[1].GetExemplar())
+	assert.Equal(t, float64(3000), buckets[1].GetExemplar().GetValue())
+}
@@ -19,12 +19,50 @@ import (
 	"sync"
 )
 
+// Stage is the lifecycle stage of a Gate, see the README.
+type Stage int8
+
+const (
+	// StageAlpha is the stage of the features disabled by default.
+	StageAlpha Stage = iota
+	// StageBeta is the stage of the features enabled by default, which can still be disabled.
+	StageBeta
+	// StageStable is the stage of the features permanently enabled, whose Gate is no longer operative.
+	StageStable
+)
+
+// String returns the name of the Stage.
+func (s Stage) String() string {
+	switch s {
+	case StageAlpha:
+		return "alpha"
+	case StageBeta:
+		return "beta"
+	case StageStable:
+		return "stable"
+	}
+	return "unknown"
+}
+
+// Source is the source of the value of a Gate.
+type Source string
+
+const (
+	// SourceDefault is the source of the values of the Gates set when registered.
+	SourceDefault Source = "default"
+	// SourceFlag is the source of the values of the Gates set by the --feature-gates flag.
+	SourceFlag Source = "flag"
+	// SourceConfig is the source of the values of the Gates set by a configuration.
+	SourceConfig Source = "config"
+)
+
 // Gate represents an individual feature that may be enabled or disabled based
 // on the lifecycle state of the feature and CLI flags specified by the user.
 type Gate struct {
 	ID          string
 	Description string
 	Enabled     bool
+	Stage       Stage
 }
 
 var reg = NewRegistry()
@@ -36,27 +74,48 @@ func GetRegistry() *Registry {
 
 // NewRegistry returns a new empty Registry.
 func NewRegistry() *Registry {
-	return &Registry{gates: make(map[string]Gate)}
+	return &Registry{gates: make(map[string]Gate), sources: make(map[string]Source)}
 }
 
 type Registry struct {
-	mu    sync.RWMutex
-	gates map[string]Gate
+	mu      sync.RWMutex
+	gates   map[string]Gate
+	sources map[string]Source
 }
 
 // Apply a configuration in the form of a map of Gate identifiers to boolean values.
 // Sets only those values provided in the map, other gate values are not changed.
+// The values are recorded as set by SourceConfig.
 func (r *Registry) Apply(cfg map[string]bool) {
+	r.apply(cfg, SourceConfig)
+}
+
+// ApplyFlags applies the values of the --feature-gates flag like Apply, the values being
+// recorded as set by SourceFlag.
+func (r *Registry) ApplyFlags(flags FlagValue) {
+	r.apply(flags, SourceFlag)
+}
+
+func (r *Registry) apply(cfg map[string]bool, source Source) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	for id, val := range cfg {
 		if g, ok := r.gates[id]; ok {
 			g.Enabled = val
 			r.gates[g.ID] = g
+			r.sources[g.ID] = source
 		}
 	}
 }
 
+// Source returns the source of the value of a registered Gate, or an empty Source
+// if the Gate is not registered.
+func (r *Registry) Source(id string) Source {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sources[id]
+}
+
 // IsEnabled returns true if a registered feature gate is enabled and false otherwise.
 func (r *Registry) IsEnabled(id string) bool {
 	r.mu.RLock()
@@ -80,6 +139,7 @@ func (r *Registry) Register(g Gate) error {
 		return fmt.Errorf("attempted to add pre-existing gate %q", g.ID)
 	}
 	r.gates[g.ID] = g
+	r.sources[g.ID] = SourceDefault
 	return nil
 }
 
@@ -21,7 +21,7 @@ import (
 )
 
 func TestRegistry(t *testing.T) {
-	r := Registry{gates: map[string]Gate{}}
+	r := NewRegistry()
 
 	gate := Gate{
 		ID:          "foo",
@@ -36,11 +36,26 @@ func TestRegistry(t *testing.T) {
 	assert.Len(t, r.List(), 1)
 	assert.True(t, r.IsEnabled(gate.ID))
 
+	assert.Equal(t, SourceDefault, r.Source(gate.ID))
+
 	r.Apply(map[string]bool{gate.ID: false})
 	assert.False(t, r.IsEnabled(gate.ID))
+	assert.Equal(t, SourceConfig, r.Source(gate.ID))
+
+	r.ApplyFlags(FlagValue{gate.ID: true, "unknown": true})
+	assert.True(t, r.IsEnabled(gate.ID))
+	assert.Equal(t, SourceFlag, r.Source(gate.ID))
+	assert.Equal(t, Source(""), r.Source("unknown"))
 
 	assert.Error(t, r.Register(gate))
 	assert.Panics(t, func() {
 		r.MustRegister(gate)
 	})
 }
+
+func TestStageString(t *testing.T) {
+	assert.Equal(t, "alpha", StageAlpha.String())
+	assert.Equal(t, "beta", StageBeta.String())
+	assert.Equal(t, "stable", StageStable.String())
+	assert.Equal(t, "unknown", Stage(-1).String())
+}
@@ -16,14 +16,17 @@ package service // import "go.opentelemetry.io/collector/service"
 
 import (
 	"flag"
+	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/service/featuregate"
 )
 
 const (
-	configFlag = "config"
-	setFlag    = "set"
+	configFlag    = "config"
+	setFlag       = "set"
+	listMergeFlag = "config-list-merge"
 )
 
 var (
@@ -44,6 +47,35 @@ func (s *stringArrayValue) String() string {
 	return "[" + strings.Join(s.values, ", ") + "]"
 }
 
+// listMergeValue is the flag value of the confmap.ListMergeStrategy of the lists, set as "<key>=<strategy>".
+type listMergeValue struct {
+	strategies map[string]confmap.ListMergeStrategy
+}
+
+func (l *listMergeValue) Set(val string) error {
+	idx := strings.Index(val, "=")
+	if idx <= 0 {
+		return fmt.Errorf("invalid list merge strategy %q, must be <key>=<strategy>", val)
+	}
+	strategy := confmap.ListMergeStrategy(val[idx+1:])
+	if err := strategy.Validate(); err != nil {
+		return err
+	}
+	if l.strategies == nil {
+		l.strategies = make(map[string]confmap.ListMergeStrategy)
+	}
+	l.strategies[val[:idx]] = strategy
+	return nil
+}
+
+func (l *listMergeValue) String() string {
+	kvs := make([]string, 0, len(l.strategies))
+	for k, v := range l.strategies {
+		kvs = append(kvs, k+"="+string(v))
+	}
+	return "[" + strings.Join(kvs, ", ") + "]"
+}
+
 func flags() *flag.FlagSet {
 	flagSet := new(flag.FlagSet)
 
@@ -55,6 +87,11 @@ func flags() *flag.FlagSet {
 			" has a higher precedence. Array config properties are overridden and maps are joined, note that only a single"+
 			" (first) array property can be set e.g. -set=processors.attributes.actions.key=some_key. Example --set=processors.batch.timeout=2s")
 
+	flagSet.Var(new(listMergeValue), listMergeFlag,
+		"Strategy merging a list of the config files with the list of the same key of the previous ones, replaced by"+
+			" default: append, replace or merge_by_key:<key>. The key can use * to match any key, note that only a single"+
+			" strategy can be set per flag entry e.g. --config-list-merge=service::pipelines::*::receivers=append.")
+
 	flagSet.Var(
 		gatesList,
 		"feature-gates",
@@ -70,3 +107,7 @@ func getConfigFlag(flagSet *flag.FlagSet) []string {
 func getSetFlag(flagSet *flag.FlagSet) []string {
 	return flagSet.Lookup(setFlag).Value.(*stringArrayValue).values
 }
+
+func getListMergeFlag(flagSet *flag.FlagSet) map[string]confmap.ListMergeStrategy {
+	return flagSet.Lookup(listMergeFlag).Value.(*listMergeValue).strategies
+}
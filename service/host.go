@@ -15,10 +15,14 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"net/http"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/service/internal/extensions"
+	"go.opentelemetry.io/collector/service/internal/lifecycle"
 	"go.opentelemetry.io/collector/service/internal/pipelines"
+	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
 )
 
 var _ component.Host = (*serviceHost)(nil)
@@ -30,6 +34,8 @@ type serviceHost struct {
 
 	pipelines  *pipelines.Pipelines
 	extensions *extensions.Extensions
+	lifecycle  *lifecycle.Recorder
+	logLevels  *telemetrylogs.Levels
 }
 
 // ReportFatalError is used to report to the host that the receiver encountered
@@ -60,3 +66,9 @@ func (host *serviceHost) GetExtensions() map[config.ComponentID]component.Extens
 func (host *serviceHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
 	return host.pipelines.GetExporters()
 }
+
+// RegisterLogLevels is used by the loglevel extension to register the handler returning and
+// changing the log levels of the components.
+func (host *serviceHost) RegisterLogLevels(mux *http.ServeMux, pattern string) {
+	mux.HandleFunc(pattern, host.logLevels.HandleHTTP)
+}
@@ -52,3 +52,13 @@ func (hw *hostWrapper) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 		zpagesHost.RegisterZPages(mux, pathPrefix)
 	}
 }
+
+// RegisterLogLevels is used by the loglevel extension to register the handler of the log
+// levels from service, see RegisterZPages.
+func (hw *hostWrapper) RegisterLogLevels(mux *http.ServeMux, pattern string) {
+	if logLevelsHost, ok := hw.Host.(interface {
+		RegisterLogLevels(mux *http.ServeMux, pattern string)
+	}); ok {
+		logLevelsHost.RegisterLogLevels(mux, pattern)
+	}
+}
@@ -16,6 +16,7 @@ package components
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 
 	"go.uber.org/zap"
@@ -26,4 +27,7 @@ import (
 func Test_newHostWrapper(t *testing.T) {
 	hw := NewHostWrapper(componenttest.NewNopHost(), zap.NewNop())
 	hw.ReportFatalError(errors.New("test error"))
+	hw.(interface {
+		RegisterLogLevels(mux *http.ServeMux, pattern string)
+	}).RegisterLogLevels(http.NewServeMux(), "/loglevels")
 }
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components // import "go.opentelemetry.io/collector/service/internal/components"
+
+// LogLevelKey returns the key of the log level of a component, made of the section of the
+// component in the configuration and of its ID, e.g. "exporters.otlphttp/2" for the exporter
+// logging with the ZapKindExporter kind and the "otlphttp/2" name.
+func LogLevelKey(zapKind string, name string) string {
+	return zapKind + "s." + name
+}
@@ -15,6 +15,7 @@
 package configunmarshaler // import "go.opentelemetry.io/collector/service/internal/configunmarshaler"
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -24,7 +25,9 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service/recovery"
 	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/watchdog"
 )
 
 // These are errors that can be returned by Unmarshal(). Note that error codes are not part
@@ -51,6 +54,33 @@ type configError struct {
 	code configErrorCode
 }
 
+func (e configError) Unwrap() error {
+	return e.error
+}
+
+// pathError is an error caused by the configuration section at the given path.
+type pathError struct {
+	error
+
+	// path of the configuration section, in the confmap.KeyDelimiter notation.
+	path string
+}
+
+func (e pathError) Unwrap() error {
+	return e.error
+}
+
+// ErrorPath returns the path, in the confmap.KeyDelimiter notation, of the configuration
+// section which caused the error returned by Unmarshal, for example "receivers::otlp".
+// Returns false if the error is not specific to a configuration section.
+func ErrorPath(err error) (string, bool) {
+	var pErr pathError
+	if errors.As(err, &pErr) {
+		return pErr.path, true
+	}
+	return "", false
+}
+
 // YAML top-level configuration keys.
 const (
 	// extensionsKeyName is the configuration key name for extensions section.
@@ -65,6 +95,9 @@ const (
 	// processorsKeyName is the configuration key name for processors section.
 	processorsKeyName = "processors"
 
+	// serviceKeyName is the configuration key name for service section.
+	serviceKeyName = "service"
+
 	// pipelinesKeyName is the configuration key name for pipelines section.
 	pipelinesKeyName = "pipelines"
 )
@@ -121,19 +154,33 @@ func (ConfigUnmarshaler) Unmarshal(v *confmap.Conf, factories component.Factorie
 		}
 	}
 
-	if cfg.Exporters, err = unmarshalExporters(rawCfg.Exporters, factories.Exporters); err != nil {
+	// The service is unmarshaled first for the exporters to get the defaults of their pipelines,
+	// the errors of the exporters being reported first.
+	srv, srvErr := unmarshalService(rawCfg.Service)
+	var defaults map[config.ComponentID]map[string]interface{}
+	if srvErr == nil {
+		if defaults, err = exporterDefaults(srv.Pipelines); err != nil {
+			return nil, configError{
+				error: err,
+				code:  errUnmarshalService,
+			}
+		}
+	}
+
+	if cfg.Exporters, err = unmarshalExporters(rawCfg.Exporters, defaults, factories.Exporters); err != nil {
 		return nil, configError{
 			error: err,
 			code:  errUnmarshalExporter,
 		}
 	}
 
-	if cfg.Service, err = unmarshalService(rawCfg.Service); err != nil {
+	if srvErr != nil {
 		return nil, configError{
-			error: err,
+			error: srvErr,
 			code:  errUnmarshalService,
 		}
 	}
+	cfg.Service = srv
 
 	return &cfg, nil
 }
@@ -183,15 +230,28 @@ func unmarshalService(srvRaw map[string]interface{}) (config.Service, error) {
 			},
 			Metrics: defaultServiceTelemetryMetricsSettings(),
 		},
+		Watchdog: watchdog.NewDefaultConfig(),
+		Recovery: recovery.NewDefaultConfig(),
 	}
 
 	if err := confmap.NewFromStringMap(srvRaw).UnmarshalExact(&srv); err != nil {
-		return srv, fmt.Errorf("error reading service configuration: %w", err)
+		return srv, pathError{
+			error: fmt.Errorf("error reading service configuration: %w", err),
+			path:  serviceKeyName,
+		}
 	}
 
-	for id := range srv.Pipelines {
+	for id, pipeline := range srv.Pipelines {
 		if id.Type() != config.TracesDataType && id.Type() != config.MetricsDataType && id.Type() != config.LogsDataType {
-			return srv, fmt.Errorf("unknown %q datatype %q for %v", pipelinesKeyName, id.Type(), id)
+			return srv, pathError{
+				error: fmt.Errorf("unknown %q datatype %q for %v", pipelinesKeyName, id.Type(), id),
+				path:  serviceKeyName + confmap.KeyDelimiter + pipelinesKeyName + confmap.KeyDelimiter + id.String(),
+			}
+		}
+		if pipeline != nil {
+			if err := validatePipelineDefaults(id, pipeline); err != nil {
+				return srv, err
+			}
 		}
 	}
 	return srv, nil
@@ -243,7 +303,7 @@ func unmarshalReceivers(recvs map[config.ComponentID]map[string]interface{}, fac
 	return receivers, nil
 }
 
-func unmarshalExporters(exps map[config.ComponentID]map[string]interface{}, factories map[config.Type]component.ExporterFactory) (map[config.ComponentID]config.Exporter, error) {
+func unmarshalExporters(exps map[config.ComponentID]map[string]interface{}, defaults map[config.ComponentID]map[string]interface{}, factories map[config.Type]component.ExporterFactory) (map[config.ComponentID]config.Exporter, error) {
 	// Prepare resulting map.
 	exporters := make(map[config.ComponentID]config.Exporter)
 
@@ -259,9 +319,15 @@ func unmarshalExporters(exps map[config.ComponentID]map[string]interface{}, fact
 		exporterCfg := factory.CreateDefaultConfig()
 		exporterCfg.SetIDName(id.Name())
 
+		// Apply the defaults of the pipelines of the exporter under its user-defined config.
+		raw, err := applyDefaults(value, defaults[id], exporterCfg)
+		if err != nil {
+			return nil, errorUnmarshalError(exportersKeyName, id, err)
+		}
+
 		// Now that the default config struct is created we can Unmarshal into it,
 		// and it will apply user-defined config on top of the default.
-		if err := config.UnmarshalExporter(confmap.NewFromStringMap(value), exporterCfg); err != nil {
+		if err := config.UnmarshalExporter(confmap.NewFromStringMap(raw), exporterCfg); err != nil {
 			return nil, errorUnmarshalError(exportersKeyName, id, err)
 		}
 
@@ -300,9 +366,15 @@ func unmarshalProcessors(procs map[config.ComponentID]map[string]interface{}, fa
 }
 
 func errorUnknownType(component string, id config.ComponentID, factories []reflect.Value) error {
-	return fmt.Errorf("unknown %s type %q for %q (valid values: %v)", component, id.Type(), id, factories)
+	return pathError{
+		error: fmt.Errorf("unknown %s type %q for %q (valid values: %v)", component, id.Type(), id, factories),
+		path:  component + confmap.KeyDelimiter + id.String(),
+	}
 }
 
 func errorUnmarshalError(component string, id config.ComponentID, err error) error {
-	return fmt.Errorf("error reading %s configuration for %q: %w", component, id, err)
+	return pathError{
+		error: fmt.Errorf("error reading %s configuration for %q: %w", component, id, err),
+		path:  component + confmap.KeyDelimiter + id.String(),
+	}
 }
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -145,6 +147,7 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 
 		{name: "invalid-logs-level", expected: errUnmarshalService},
 		{name: "invalid-metrics-level", expected: errUnmarshalService},
+		{name: "invalid-pipeline-defaults", expected: errUnmarshalService, expectedMessage: "defaults"},
 	}
 
 	factories, err := componenttest.NopFactories()
@@ -170,6 +173,33 @@ func TestDecodeConfig_Invalid(t *testing.T) {
 	}
 }
 
+func TestDecodeConfig_ErrorPath(t *testing.T) {
+	var testCases = []struct {
+		name         string // test case name (also file name containing config yaml)
+		expectedPath string // expected path, empty if the error is not specific to a section
+	}{
+		{name: "unknown-receiver-type", expectedPath: "receivers::nosuchreceiver"},
+		{name: "invalid-exporter-section", expectedPath: "exporters::nop"},
+		{name: "unknown-pipeline-type", expectedPath: "service::pipelines::wrongdatatype"},
+		{name: "invalid-pipeline-defaults", expectedPath: "service::pipelines::traces::defaults"},
+		{name: "invalid-service-section", expectedPath: "service"},
+		{name: "invalid-top-level-section"},
+	}
+
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := loadConfigFile(t, filepath.Join("testdata", test.name+".yaml"), factories)
+			require.Error(t, err)
+			path, ok := ErrorPath(err)
+			assert.Equal(t, test.expectedPath != "", ok)
+			assert.Equal(t, test.expectedPath, path)
+		})
+	}
+}
+
 func TestLoadEmpty(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	assert.NoError(t, err)
@@ -214,3 +244,63 @@ func TestDefaultLoggerConfig(t *testing.T) {
 			InitialFields:     zapProdCfg.InitialFields,
 		}, cfg.Service.Telemetry.Logs)
 }
+
+type helperExporterConfig struct {
+	config.ExporterSettings        `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"`
+	QueueSettings                  exporterhelper.QueueSettings `mapstructure:"sending_queue"`
+	RetrySettings                  exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
+	Endpoint                       string                       `mapstructure:"endpoint"`
+}
+
+func newHelperExporterFactory() component.ExporterFactory {
+	return component.NewExporterFactory("helper", func() config.Exporter {
+		return &helperExporterConfig{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentID("helper")),
+			TimeoutSettings:  exporterhelper.NewDefaultTimeoutSettings(),
+			QueueSettings:    exporterhelper.NewDefaultQueueSettings(),
+			RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
+		}
+	})
+}
+
+func TestPipelineDefaults(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Exporters["helper"] = newHelperExporterFactory()
+
+	cfg, err := loadConfigFile(t, filepath.Join("testdata", "pipeline-defaults.yaml"), factories)
+	require.NoError(t, err)
+
+	expected := newHelperExporterFactory().CreateDefaultConfig().(*helperExporterConfig)
+	expected.Timeout = 20 * time.Second
+	expected.RetrySettings.MaxElapsedTime = 10 * time.Minute
+	expected.QueueSettings.QueueSize = 100
+	assert.Equal(t, expected, cfg.Exporters[config.NewComponentID("helper")])
+
+	// The settings of the exporter override the defaults.
+	expected.SetIDName("override")
+	expected.Timeout = time.Second
+	expected.RetrySettings.Enabled = false
+	assert.Equal(t, expected, cfg.Exporters[config.NewComponentIDWithName("helper", "override")])
+
+	// The exporters not used by the pipelines keep their defaults.
+	unused := newHelperExporterFactory().CreateDefaultConfig()
+	unused.SetIDName("unused")
+	assert.Equal(t, unused, cfg.Exporters[config.NewComponentIDWithName("helper", "unused")])
+
+	// The defaults are not applied to the exporters not supporting them.
+	assert.Equal(t, factories.Exporters["nop"].CreateDefaultConfig(), cfg.Exporters[config.NewComponentID("nop")])
+}
+
+func TestPipelineDefaultsConflict(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Exporters["helper"] = newHelperExporterFactory()
+
+	_, err = loadConfigFile(t, filepath.Join("testdata", "pipeline-defaults-conflict.yaml"), factories)
+	require.EqualError(t, err, `exporter "helper" is used by pipelines "metrics" and "traces" setting different defaults "timeout"`)
+	path, ok := ErrorPath(err)
+	assert.True(t, ok)
+	assert.Equal(t, "service::pipelines::traces::defaults", path)
+}
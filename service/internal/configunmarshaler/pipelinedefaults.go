@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configunmarshaler // import "go.opentelemetry.io/collector/service/internal/configunmarshaler"
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// pipelineDefaultsKeyName is the configuration key name for the exporter defaults of a pipeline.
+const pipelineDefaultsKeyName = "defaults"
+
+// pipelineDefaultsKeys are the exporterhelper settings the pipelines can set defaults for.
+var pipelineDefaultsKeys = []string{"sending_queue", "retry_on_failure", "timeout"}
+
+// validatePipelineDefaults checks that the defaults of the pipeline only set the pipelineDefaultsKeys.
+func validatePipelineDefaults(id config.ComponentID, pipeline *config.Pipeline) error {
+	for key := range pipeline.Defaults {
+		if !isPipelineDefaultsKey(key) {
+			return pathError{
+				error: fmt.Errorf("unknown key %q in the defaults of pipeline %q (valid keys: %v)", key, id, pipelineDefaultsKeys),
+				path:  serviceKeyName + confmap.KeyDelimiter + pipelinesKeyName + confmap.KeyDelimiter + id.String() + confmap.KeyDelimiter + pipelineDefaultsKeyName,
+			}
+		}
+	}
+	return nil
+}
+
+func isPipelineDefaultsKey(key string) bool {
+	for _, k := range pipelineDefaultsKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// exporterDefaults returns the defaults of the exporters set by the pipelines using them. The exporters used
+// by several pipelines get the defaults of all of them, which must not set different values for the same key.
+func exporterDefaults(pipelines map[config.ComponentID]*config.Pipeline) (map[config.ComponentID]map[string]interface{}, error) {
+	// Sort the pipelines for the errors to be deterministic.
+	ids := make([]config.ComponentID, 0, len(pipelines))
+	for id := range pipelines {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	defaults := make(map[config.ComponentID]map[string]interface{})
+	setBy := make(map[config.ComponentID]map[string]config.ComponentID)
+	for _, pipelineID := range ids {
+		pipeline := pipelines[pipelineID]
+		if pipeline == nil || len(pipeline.Defaults) == 0 {
+			continue
+		}
+		for _, expID := range pipeline.Exporters {
+			if defaults[expID] == nil {
+				defaults[expID] = make(map[string]interface{})
+				setBy[expID] = make(map[string]config.ComponentID)
+			}
+			for key, val := range pipeline.Defaults {
+				prev, ok := defaults[expID][key]
+				if ok && !reflect.DeepEqual(prev, val) {
+					return nil, pathError{
+						error: fmt.Errorf("exporter %q is used by pipelines %q and %q setting different %s %q",
+							expID, setBy[expID][key], pipelineID, pipelineDefaultsKeyName, key),
+						path: serviceKeyName + confmap.KeyDelimiter + pipelinesKeyName + confmap.KeyDelimiter + pipelineID.String() + confmap.KeyDelimiter + pipelineDefaultsKeyName,
+					}
+				}
+				defaults[expID][key] = val
+				setBy[expID][key] = pipelineID
+			}
+		}
+	}
+	return defaults, nil
+}
+
+// applyDefaults returns the raw configuration of the exporter merged over the defaults supported by its
+// configuration struct, the settings of the exporter overriding the defaults.
+func applyDefaults(value map[string]interface{}, defaults map[string]interface{}, cfg config.Exporter) (map[string]interface{}, error) {
+	supported := make(map[string]interface{})
+	keys := configKeys(reflect.TypeOf(cfg))
+	for key, val := range defaults {
+		if keys[key] {
+			supported[key] = val
+		}
+	}
+	if len(supported) == 0 {
+		return value, nil
+	}
+	conf := confmap.NewFromStringMap(supported)
+	if err := conf.Merge(confmap.NewFromStringMap(value)); err != nil {
+		return nil, err
+	}
+	return conf.ToStringMap(), nil
+}
+
+// configKeys returns the top level keys of the configuration struct type, including the keys of the squashed structs.
+func configKeys(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	keys := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return keys
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.SplitN(field.Tag.Get("mapstructure"), ",", 2)
+		name := tag[0]
+		switch {
+		case name == "-":
+		case len(tag) == 2 && strings.Contains(tag[1], "squash"):
+			for key := range configKeys(field.Type) {
+				keys[key] = true
+			}
+		case name != "":
+			keys[name] = true
+		case field.IsExported():
+			keys[strings.ToLower(field.Name)] = true
+		}
+	}
+	return keys
+}
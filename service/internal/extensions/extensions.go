@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strings"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -26,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/internal/lifecycle"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -35,15 +37,23 @@ const zExtensionName = "zextensionname"
 type Extensions struct {
 	telemetry component.TelemetrySettings
 	extMap    map[config.ComponentID]component.Extension
+	lifecycle *lifecycle.Recorder
+	// order is the order in which the extensions are started, every extension coming after the
+	// extensions it depends on. They are shut down in the reverse order.
+	order []config.ComponentID
 }
 
-// StartAll starts all extensions.
+// StartAll starts all extensions, after the extensions they depend on.
 func (bes *Extensions) StartAll(ctx context.Context, host component.Host) error {
 	bes.telemetry.Logger.Info("Starting extensions...")
-	for extID, ext := range bes.extMap {
+	for _, extID := range bes.order {
+		ext := bes.extMap[extID]
 		extLogger := extensionLogger(bes.telemetry.Logger, extID)
 		extLogger.Info("Extension is starting...")
-		if err := ext.Start(ctx, components.NewHostWrapper(host, extLogger)); err != nil {
+		comp := lifecycle.Component{Kind: components.ZapKindExtension, ID: extID}
+		if err := bes.lifecycle.Record(comp, lifecycle.PhaseStart, func() error {
+			return ext.Start(ctx, components.NewHostWrapper(host, extLogger))
+		}); err != nil {
 			return err
 		}
 		extLogger.Info("Extension started.")
@@ -51,20 +61,24 @@ func (bes *Extensions) StartAll(ctx context.Context, host component.Host) error
 	return nil
 }
 
-// ShutdownAll stops all extensions.
+// ShutdownAll stops all extensions, before the extensions they depend on.
 func (bes *Extensions) ShutdownAll(ctx context.Context) error {
 	bes.telemetry.Logger.Info("Stopping extensions...")
 	var errs error
-	for _, ext := range bes.extMap {
-		errs = multierr.Append(errs, ext.Shutdown(ctx))
+	for i := len(bes.order) - 1; i >= 0; i-- {
+		extID, ext := bes.order[i], bes.extMap[bes.order[i]]
+		comp := lifecycle.Component{Kind: components.ZapKindExtension, ID: extID}
+		errs = multierr.Append(errs, bes.lifecycle.Record(comp, lifecycle.PhaseShutdown, func() error {
+			return ext.Shutdown(ctx)
+		}))
 	}
 
 	return errs
 }
 
 func (bes *Extensions) NotifyPipelineReady() error {
-	for extID, ext := range bes.extMap {
-		if pw, ok := ext.(component.PipelineWatcher); ok {
+	for _, extID := range bes.order {
+		if pw, ok := bes.extMap[extID].(component.PipelineWatcher); ok {
 			if err := pw.Ready(); err != nil {
 				return fmt.Errorf("failed to notify extension %q: %w", extID, err)
 			}
@@ -76,8 +90,8 @@ func (bes *Extensions) NotifyPipelineReady() error {
 func (bes *Extensions) NotifyPipelineNotReady() error {
 	// Notify extensions in reverse order.
 	var errs error
-	for _, ext := range bes.extMap {
-		if pw, ok := ext.(component.PipelineWatcher); ok {
+	for i := len(bes.order) - 1; i >= 0; i-- {
+		if pw, ok := bes.extMap[bes.order[i]].(component.PipelineWatcher); ok {
 			errs = multierr.Append(errs, pw.NotReady())
 		}
 	}
@@ -92,6 +106,22 @@ func (bes *Extensions) GetExtensions() map[config.ComponentID]component.Extensio
 	return result
 }
 
+// GetDataObservers returns the extensions implementing component.PipelineDataObserver, sorted by ID.
+func (bes *Extensions) GetDataObservers() []component.PipelineDataObserver {
+	ids := make([]config.ComponentID, 0, len(bes.extMap))
+	for extID, ext := range bes.extMap {
+		if _, ok := ext.(component.PipelineDataObserver); ok {
+			ids = append(ids, extID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	observers := make([]component.PipelineDataObserver, 0, len(ids))
+	for _, extID := range ids {
+		observers = append(observers, bes.extMap[extID].(component.PipelineDataObserver))
+	}
+	return observers
+}
+
 func (bes *Extensions) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	extensionName := r.URL.Query().Get(zExtensionName)
 
@@ -129,17 +159,27 @@ type Settings struct {
 	// Factories maps extension type names in the config to the respective component.ExtensionFactory.
 	Factories map[config.Type]component.ExtensionFactory
 
-	// ServiceExtensions are the ordered list of extensions configured for the service.
+	// ServiceExtensions are the ordered list of extensions configured for the service. The extensions are
+	// started in this order, after the extensions they depend on, see config.ExtensionDependent.
 	ServiceExtensions []config.ComponentID
+
+	// Lifecycle records the build, start and shutdown events of the extensions, nothing is recorded if nil.
+	Lifecycle *lifecycle.Recorder
 }
 
 // Build builds Extensions from config.
 func Build(ctx context.Context, set Settings) (*Extensions, error) {
+	order, err := startOrder(set.ServiceExtensions, set.Configs)
+	if err != nil {
+		return nil, err
+	}
 	exts := &Extensions{
 		telemetry: set.Telemetry,
 		extMap:    make(map[config.ComponentID]component.Extension),
+		lifecycle: set.Lifecycle,
+		order:     order,
 	}
-	for _, extID := range set.ServiceExtensions {
+	for _, extID := range order {
 		extCfg, existsCfg := set.Configs[extID]
 		if !existsCfg {
 			return nil, fmt.Errorf("extension %q is not configured", extID)
@@ -156,7 +196,12 @@ func Build(ctx context.Context, set Settings) (*Extensions, error) {
 		}
 		extSet.TelemetrySettings.Logger = extensionLogger(set.Telemetry.Logger, extID)
 
-		ext, err := factory.CreateExtension(ctx, extSet, extCfg)
+		var ext component.Extension
+		comp := lifecycle.Component{Kind: components.ZapKindExtension, ID: extID}
+		err := set.Lifecycle.Record(comp, lifecycle.PhaseBuild, func() (err error) {
+			ext, err = factory.CreateExtension(ctx, extSet, extCfg)
+			return err
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create extension %q: %w", extID, err)
 		}
@@ -166,12 +211,66 @@ func Build(ctx context.Context, set Settings) (*Extensions, error) {
 			return nil, fmt.Errorf("factory for %q produced a nil extension", extID)
 		}
 
+		components.LogStabilityLevel(extSet.TelemetrySettings.Logger, factory.ExtensionStability())
 		exts.extMap[extID] = ext
 	}
 
 	return exts, nil
 }
 
+// startOrder returns the service extensions ordered so that every extension comes after the extensions it
+// depends on, the other extensions keeping the order of the service. It fails if an extension depends on an
+// extension which is not enabled in the service, or if the dependencies form a cycle.
+func startOrder(serviceExtensions []config.ComponentID, cfgs map[config.ComponentID]config.Extension) ([]config.ComponentID, error) {
+	enabled := make(map[config.ComponentID]bool, len(serviceExtensions))
+	for _, extID := range serviceExtensions {
+		enabled[extID] = true
+	}
+
+	order := make([]config.ComponentID, 0, len(serviceExtensions))
+	visited := make(map[config.ComponentID]bool, len(serviceExtensions))
+	// path is the chain of the extensions being visited, to report the cycles.
+	var path []config.ComponentID
+	var visit func(extID config.ComponentID) error
+	visit = func(extID config.ComponentID) error {
+		if visited[extID] {
+			return nil
+		}
+		for i, id := range path {
+			if id == extID {
+				cycle := make([]string, 0, len(path)-i+1)
+				for _, cid := range append(path[i:], extID) {
+					cycle = append(cycle, cid.String())
+				}
+				return fmt.Errorf("extensions dependency cycle: %s", strings.Join(cycle, " -> "))
+			}
+		}
+
+		if dependent, ok := cfgs[extID].(config.ExtensionDependent); ok {
+			path = append(path, extID)
+			for _, dep := range dependent.ExtensionDependencies() {
+				if !enabled[dep] {
+					return fmt.Errorf("extension %q depends on extension %q which is not enabled in the service", extID, dep)
+				}
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+			path = path[:len(path)-1]
+		}
+		visited[extID] = true
+		order = append(order, extID)
+		return nil
+	}
+
+	for _, extID := range serviceExtensions {
+		if err := visit(extID); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
 func extensionLogger(logger *zap.Logger, id config.ComponentID) *zap.Logger {
 	return logger.With(
 		zap.String(components.ZapKindKey, components.ZapKindExtension),
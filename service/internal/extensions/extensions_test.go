@@ -137,3 +137,150 @@ func newCreateErrorExtensionFactory() component.ExtensionFactory {
 		},
 	)
 }
+
+type observerExtension struct {
+	component.Extension
+}
+
+func (oe *observerExtension) DataObserver(component.ObservationPoint) component.DataObserver {
+	return nil
+}
+
+func TestGetDataObservers(t *testing.T) {
+	factory := componenttest.NewNopExtensionFactory()
+	nop, err := factory.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), factory.CreateDefaultConfig())
+	require.NoError(t, err)
+	obsA := &observerExtension{Extension: nop}
+	obsB := &observerExtension{Extension: nop}
+
+	exts := &Extensions{extMap: map[config.ComponentID]component.Extension{
+		config.NewComponentIDWithName("obs", "b"): obsB,
+		config.NewComponentID("nop"):              nop,
+		config.NewComponentIDWithName("obs", "a"): obsA,
+	}}
+	observers := exts.GetDataObservers()
+	require.Len(t, observers, 2)
+	assert.Same(t, obsA, observers[0])
+	assert.Same(t, obsB, observers[1])
+}
+
+type dependentExtensionConfig struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	dependsOn                []config.ComponentID
+}
+
+func (cfg *dependentExtensionConfig) ExtensionDependencies() []config.ComponentID {
+	return cfg.dependsOn
+}
+
+type recordingExtension struct {
+	id     config.ComponentID
+	events *[]string
+}
+
+func (e *recordingExtension) Start(context.Context, component.Host) error {
+	*e.events = append(*e.events, "start "+e.id.String())
+	return nil
+}
+
+func (e *recordingExtension) Shutdown(context.Context) error {
+	*e.events = append(*e.events, "shutdown "+e.id.String())
+	return nil
+}
+
+func newRecordingExtensionFactory(events *[]string) component.ExtensionFactory {
+	return component.NewExtensionFactory(
+		"dep",
+		func() config.Extension {
+			return &dependentExtensionConfig{ExtensionSettings: config.NewExtensionSettings(config.NewComponentID("dep"))}
+		},
+		func(ctx context.Context, set component.ExtensionCreateSettings, cfg config.Extension) (component.Extension, error) {
+			return &recordingExtension{id: cfg.ID(), events: events}, nil
+		},
+	)
+}
+
+func TestExtensionsDependencies(t *testing.T) {
+	idA := config.NewComponentIDWithName("dep", "a")
+	idB := config.NewComponentIDWithName("dep", "b")
+	idC := config.NewComponentIDWithName("dep", "c")
+	newConfig := func(id config.ComponentID, dependsOn ...config.ComponentID) config.Extension {
+		return &dependentExtensionConfig{ExtensionSettings: config.NewExtensionSettings(id), dependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name              string
+		configs           map[config.ComponentID]config.Extension
+		serviceExtensions []config.ComponentID
+		wantEvents        []string
+		wantErrMsg        string
+	}{
+		{
+			name: "ordered",
+			configs: map[config.ComponentID]config.Extension{
+				idA: newConfig(idA, idB),
+				idB: newConfig(idB, idC),
+				idC: newConfig(idC),
+			},
+			serviceExtensions: []config.ComponentID{idA, idB, idC},
+			wantEvents: []string{
+				"start dep/c", "start dep/b", "start dep/a",
+				"shutdown dep/a", "shutdown dep/b", "shutdown dep/c",
+			},
+		},
+		{
+			name: "service_order_without_dependencies",
+			configs: map[config.ComponentID]config.Extension{
+				idA: newConfig(idA),
+				idB: newConfig(idB),
+				idC: newConfig(idC, idA),
+			},
+			serviceExtensions: []config.ComponentID{idB, idC, idA},
+			wantEvents: []string{
+				"start dep/b", "start dep/a", "start dep/c",
+				"shutdown dep/c", "shutdown dep/a", "shutdown dep/b",
+			},
+		},
+		{
+			name: "missing_dependency",
+			configs: map[config.ComponentID]config.Extension{
+				idA: newConfig(idA, idB),
+				idB: newConfig(idB),
+			},
+			serviceExtensions: []config.ComponentID{idA},
+			wantErrMsg:        `extension "dep/a" depends on extension "dep/b" which is not enabled in the service`,
+		},
+		{
+			name: "cycle",
+			configs: map[config.ComponentID]config.Extension{
+				idA: newConfig(idA, idB),
+				idB: newConfig(idB, idC),
+				idC: newConfig(idC, idA),
+			},
+			serviceExtensions: []config.ComponentID{idA, idB, idC},
+			wantErrMsg:        "extensions dependency cycle: dep/a -> dep/b -> dep/c -> dep/a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []string
+			factory := newRecordingExtensionFactory(&events)
+			exts, err := Build(context.Background(), Settings{
+				Telemetry:         componenttest.NewNopTelemetrySettings(),
+				BuildInfo:         component.NewDefaultBuildInfo(),
+				Configs:           tt.configs,
+				Factories:         map[config.Type]component.ExtensionFactory{factory.Type(): factory},
+				ServiceExtensions: tt.serviceExtensions,
+			})
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, exts.StartAll(context.Background(), componenttest.NewNopHost()))
+			require.NoError(t, exts.ShutdownAll(context.Background()))
+			assert.Equal(t, tt.wantEvents, events)
+		})
+	}
+}
@@ -63,6 +63,9 @@ func TestLogsMultiplexingNonMutating(t *testing.T) {
 	assert.True(t, ld == p3.AllLogs()[1])
 	assert.EqualValues(t, ld, p3.AllLogs()[0])
 	assert.EqualValues(t, ld, p3.AllLogs()[1])
+
+	// The data is shared between the read-only consumers.
+	assert.True(t, ld.IsReadOnly())
 }
 
 func TestLogsMultiplexingMutating(t *testing.T) {
@@ -168,6 +171,37 @@ func TestLogsMultiplexingMixLastNonMutating(t *testing.T) {
 	assert.EqualValues(t, ld, p3.AllLogs()[1])
 }
 
+func TestLogsMultiplexingReadOnlyData(t *testing.T) {
+	p1 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p2 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+
+	lfc := NewLogs([]consumer.Logs{p1, p2})
+	ld := testdata.GenerateLogs(1)
+	ld.MarkReadOnly()
+
+	assert.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+
+	// The mutating consumers never receive the read-only data.
+	assert.True(t, ld != p1.AllLogs()[0])
+	assert.False(t, p1.AllLogs()[0].IsReadOnly())
+	assert.EqualValues(t, ld.Clone(), p1.AllLogs()[0])
+
+	assert.True(t, ld != p2.AllLogs()[0])
+	assert.False(t, p2.AllLogs()[0].IsReadOnly())
+	assert.EqualValues(t, ld.Clone(), p2.AllLogs()[0])
+}
+
+func TestLogsMaxRequestBytes(t *testing.T) {
+	withMaxRequestBytes := func(maxRequestBytes int) consumer.Logs {
+		c, err := consumer.NewLogs(consumertest.NewNop().ConsumeLogs, consumer.WithCapabilities(consumer.Capabilities{MaxRequestBytes: maxRequestBytes}))
+		assert.NoError(t, err)
+		return c
+	}
+	assert.Equal(t, 0, NewLogs([]consumer.Logs{withMaxRequestBytes(0), withMaxRequestBytes(0)}).Capabilities().MaxRequestBytes)
+	assert.Equal(t, 100, NewLogs([]consumer.Logs{withMaxRequestBytes(0), withMaxRequestBytes(100)}).Capabilities().MaxRequestBytes)
+	assert.Equal(t, 50, NewLogs([]consumer.Logs{withMaxRequestBytes(100), withMaxRequestBytes(50), withMaxRequestBytes(0)}).Capabilities().MaxRequestBytes)
+}
+
 func TestLogsWhenErrors(t *testing.T) {
 	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
 	p2 := consumertest.NewErr(errors.New("my error"))
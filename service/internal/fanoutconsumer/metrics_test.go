@@ -63,6 +63,9 @@ func TestMetricsMultiplexingNonMutating(t *testing.T) {
 	assert.True(t, md == p3.AllMetrics()[1])
 	assert.EqualValues(t, md, p3.AllMetrics()[0])
 	assert.EqualValues(t, md, p3.AllMetrics()[1])
+
+	// The data is shared between the read-only consumers.
+	assert.True(t, md.IsReadOnly())
 }
 
 func TestMetricsMultiplexingMutating(t *testing.T) {
@@ -168,6 +171,37 @@ func TestMetricsMultiplexingMixLastNonMutating(t *testing.T) {
 	assert.EqualValues(t, md, p3.AllMetrics()[1])
 }
 
+func TestMetricsMultiplexingReadOnlyData(t *testing.T) {
+	p1 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p2 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+
+	mfc := NewMetrics([]consumer.Metrics{p1, p2})
+	md := testdata.GenerateMetrics(1)
+	md.MarkReadOnly()
+
+	assert.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+
+	// The mutating consumers never receive the read-only data.
+	assert.True(t, md != p1.AllMetrics()[0])
+	assert.False(t, p1.AllMetrics()[0].IsReadOnly())
+	assert.EqualValues(t, md.Clone(), p1.AllMetrics()[0])
+
+	assert.True(t, md != p2.AllMetrics()[0])
+	assert.False(t, p2.AllMetrics()[0].IsReadOnly())
+	assert.EqualValues(t, md.Clone(), p2.AllMetrics()[0])
+}
+
+func TestMetricsMaxRequestBytes(t *testing.T) {
+	withMaxRequestBytes := func(maxRequestBytes int) consumer.Metrics {
+		c, err := consumer.NewMetrics(consumertest.NewNop().ConsumeMetrics, consumer.WithCapabilities(consumer.Capabilities{MaxRequestBytes: maxRequestBytes}))
+		assert.NoError(t, err)
+		return c
+	}
+	assert.Equal(t, 0, NewMetrics([]consumer.Metrics{withMaxRequestBytes(0), withMaxRequestBytes(0)}).Capabilities().MaxRequestBytes)
+	assert.Equal(t, 100, NewMetrics([]consumer.Metrics{withMaxRequestBytes(0), withMaxRequestBytes(100)}).Capabilities().MaxRequestBytes)
+	assert.Equal(t, 50, NewMetrics([]consumer.Metrics{withMaxRequestBytes(100), withMaxRequestBytes(50), withMaxRequestBytes(0)}).Capabilities().MaxRequestBytes)
+}
+
 func TestMetricsWhenErrors(t *testing.T) {
 	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
 	p2 := consumertest.NewErr(errors.New("my error"))
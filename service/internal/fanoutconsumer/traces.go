@@ -26,53 +26,64 @@ import (
 // NewTraces wraps multiple trace consumers in a single one.
 // It fanouts the incoming data to all the consumers, and does smart routing:
 //  * Clones only to the consumer that needs to mutate the data.
-//  * If all consumers needs to mutate the data one will get the original data.
+//  * If all consumers needs to mutate the data one will get the original mutable data.
+//  * If more than one consumer only reads the data, they share the same data marked as read-only.
 func NewTraces(tcs []consumer.Traces) consumer.Traces {
 	if len(tcs) == 1 {
 		// Don't wrap if no need to do it.
 		return tcs[0]
 	}
-	var pass []consumer.Traces
-	var clone []consumer.Traces
-	for i := 0; i < len(tcs)-1; i++ {
-		if !tcs[i].Capabilities().MutatesData {
-			pass = append(pass, tcs[i])
+	tc := &tracesConsumer{}
+	for i := 0; i < len(tcs); i++ {
+		tc.maxRequestBytes = minRequestBytes(tc.maxRequestBytes, tcs[i].Capabilities().MaxRequestBytes)
+		if tcs[i].Capabilities().MutatesData {
+			tc.mutable = append(tc.mutable, tcs[i])
 		} else {
-			clone = append(clone, tcs[i])
+			tc.readonly = append(tc.readonly, tcs[i])
 		}
 	}
-	// Give the original data to the last consumer if no other read-only consumer,
-	// otherwise put it in the right bucket. Never share the same data between
-	// a mutating and a non-mutating consumer since the non-mutating consumer may process
-	// data async and the mutating consumer may change the data before that.
-	if len(pass) == 0 || !tcs[len(tcs)-1].Capabilities().MutatesData {
-		pass = append(pass, tcs[len(tcs)-1])
-	} else {
-		clone = append(clone, tcs[len(tcs)-1])
-	}
-	return &tracesConsumer{pass: pass, clone: clone}
+	return tc
 }
 
 type tracesConsumer struct {
-	pass  []consumer.Traces
-	clone []consumer.Traces
+	mutable  []consumer.Traces
+	readonly []consumer.Traces
+	// maxRequestBytes is the smallest MaxRequestBytes of the consumers.
+	maxRequestBytes int
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
-	return consumer.Capabilities{MutatesData: false}
+	return consumer.Capabilities{MutatesData: false, MaxRequestBytes: tsc.maxRequestBytes}
 }
 
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	var errs error
-	// Initially pass to clone exporter to avoid the case where the optimization of sending
-	// the incoming data to a mutating consumer is used that may change the incoming data before
-	// cloning.
-	for _, tc := range tsc.clone {
-		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td.Clone()))
+
+	if len(tsc.mutable) > 0 {
+		// Clone the data before sending to all mutating consumers except the last one.
+		for i := 0; i < len(tsc.mutable)-1; i++ {
+			errs = multierr.Append(errs, tsc.mutable[i].ConsumeTraces(ctx, td.Clone()))
+		}
+		// Send the data as is to the last mutating consumer only if there are no read-only consumers and
+		// the data is mutable. Never share the same data between a mutating and a non-mutating consumer
+		// since the non-mutating consumer may process data async and the mutating consumer may change
+		// the data before that.
+		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
+		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
+			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, td))
+		} else {
+			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, td.Clone()))
+		}
+	}
+
+	// Mark the data as read-only if it is shared by more than one read-only consumer.
+	if len(tsc.readonly) > 1 && !td.IsReadOnly() {
+		td.MarkReadOnly()
 	}
-	for _, tc := range tsc.pass {
+	for _, tc := range tsc.readonly {
 		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td))
 	}
+
 	return errs
 }
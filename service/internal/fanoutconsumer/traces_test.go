@@ -63,6 +63,9 @@ func TestTracesMultiplexingNonMutating(t *testing.T) {
 	assert.True(t, td == p3.AllTraces()[1])
 	assert.EqualValues(t, td, p3.AllTraces()[0])
 	assert.EqualValues(t, td, p3.AllTraces()[1])
+
+	// The data is shared between the read-only consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestTracesMultiplexingMutating(t *testing.T) {
@@ -168,6 +171,37 @@ func TestTracesMultiplexingMixLastNonMutating(t *testing.T) {
 	assert.EqualValues(t, td, p3.AllTraces()[1])
 }
 
+func TestTracesMultiplexingReadOnlyData(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewTraces([]consumer.Traces{p1, p2})
+	td := testdata.GenerateTraces(1)
+	td.MarkReadOnly()
+
+	assert.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The mutating consumers never receive the read-only data.
+	assert.True(t, td != p1.AllTraces()[0])
+	assert.False(t, p1.AllTraces()[0].IsReadOnly())
+	assert.EqualValues(t, td.Clone(), p1.AllTraces()[0])
+
+	assert.True(t, td != p2.AllTraces()[0])
+	assert.False(t, p2.AllTraces()[0].IsReadOnly())
+	assert.EqualValues(t, td.Clone(), p2.AllTraces()[0])
+}
+
+func TestTracesMaxRequestBytes(t *testing.T) {
+	withMaxRequestBytes := func(maxRequestBytes int) consumer.Traces {
+		c, err := consumer.NewTraces(consumertest.NewNop().ConsumeTraces, consumer.WithCapabilities(consumer.Capabilities{MaxRequestBytes: maxRequestBytes}))
+		assert.NoError(t, err)
+		return c
+	}
+	assert.Equal(t, 0, NewTraces([]consumer.Traces{withMaxRequestBytes(0), withMaxRequestBytes(0)}).Capabilities().MaxRequestBytes)
+	assert.Equal(t, 100, NewTraces([]consumer.Traces{withMaxRequestBytes(0), withMaxRequestBytes(100)}).Capabilities().MaxRequestBytes)
+	assert.Equal(t, 50, NewTraces([]consumer.Traces{withMaxRequestBytes(100), withMaxRequestBytes(50), withMaxRequestBytes(0)}).Capabilities().MaxRequestBytes)
+}
+
 func TestTracesWhenErrors(t *testing.T) {
 	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
 	p2 := consumertest.NewErr(errors.New("my error"))
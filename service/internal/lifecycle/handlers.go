@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle // import "go.opentelemetry.io/collector/service/internal/lifecycle"
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+// jsonEvent is the representation of an Event served by HandleJSON.
+type jsonEvent struct {
+	Kind       string    `json:"kind"`
+	ID         string    `json:"id"`
+	DataType   string    `json:"data_type,omitempty"`
+	Pipeline   string    `json:"pipeline,omitempty"`
+	Phase      string    `json:"phase"`
+	Start      time.Time `json:"start"`
+	Duration   string    `json:"duration"`
+	InProgress bool      `json:"in_progress"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// HandleZPages writes the recorded events as a zPages table.
+func (r *Recorder) HandleZPages(w http.ResponseWriter, _ *http.Request) {
+	data := zpages.LifecycleEventsTableData{}
+	for _, ev := range r.Events() {
+		row := zpages.LifecycleEventsTableRowData{
+			Start:      ev.Start.UTC().Format(time.RFC3339Nano),
+			Component:  ev.Component.String(),
+			Phase:      string(ev.Phase),
+			Duration:   ev.Duration.String(),
+			InProgress: ev.InProgress,
+		}
+		if ev.Err != nil {
+			row.Error = ev.Err.Error()
+		}
+		data.Rows = append(data.Rows, row)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Lifecycle Events"})
+	zpages.WriteHTMLLifecycleEventsTable(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+// HandleJSON writes the recorded events as a JSON array.
+func (r *Recorder) HandleJSON(w http.ResponseWriter, _ *http.Request) {
+	events := r.Events()
+	out := make([]jsonEvent, 0, len(events))
+	for _, ev := range events {
+		je := jsonEvent{
+			Kind:       ev.Kind,
+			ID:         ev.ID.String(),
+			DataType:   string(ev.DataType),
+			Phase:      string(ev.Phase),
+			Start:      ev.Start.UTC(),
+			Duration:   ev.Duration.String(),
+			InProgress: ev.InProgress,
+		}
+		if ev.Pipeline != (config.ComponentID{}) {
+			je.Pipeline = ev.Pipeline.String()
+		}
+		if ev.Err != nil {
+			je.Error = ev.Err.Error()
+		}
+		out = append(out, je)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lifecycle records the build, start and shutdown events of the components of the service.
+package lifecycle // import "go.opentelemetry.io/collector/service/internal/lifecycle"
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// Phase is a phase of the lifecycle of a component.
+type Phase string
+
+const (
+	// PhaseBuild is the creation of the component by its factory.
+	PhaseBuild Phase = "build"
+	// PhaseStart is the call of the Start function of the component.
+	PhaseStart Phase = "start"
+	// PhaseShutdown is the call of the Shutdown function of the component.
+	PhaseShutdown Phase = "shutdown"
+)
+
+// Component identifies the component of an event.
+type Component struct {
+	// Kind is the kind of the component, one of the components.ZapKind* values.
+	Kind string
+	ID   config.ComponentID
+	// DataType is the data type of the receivers and exporters, empty for the other kinds.
+	DataType config.DataType
+	// Pipeline is the pipeline of the processors, the zero value for the other kinds.
+	Pipeline config.ComponentID
+}
+
+// String returns the kind and the ID of the component, followed by its data type or pipeline if any.
+func (c Component) String() string {
+	s := c.Kind + " " + c.ID.String()
+	switch {
+	case c.DataType != "":
+		s += " (" + string(c.DataType) + ")"
+	case c.Pipeline != (config.ComponentID{}):
+		s += " (pipeline " + c.Pipeline.String() + ")"
+	}
+	return s
+}
+
+// Event is a lifecycle phase of a component.
+type Event struct {
+	Component
+	Phase Phase
+	// Start is the time at which the phase started.
+	Start time.Time
+	// Duration is the duration of the phase, up to now if it is still in progress.
+	Duration time.Duration
+	// InProgress is true if the phase has not returned yet, e.g. because the component hangs.
+	InProgress bool
+	// Err is the error returned by the phase.
+	Err error
+}
+
+// Recorder records and logs the lifecycle events in the order they started.
+// The methods of a nil Recorder only run the phases, to be used when no events are recorded.
+type Recorder struct {
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewRecorder returns a new Recorder logging the events with the given logger.
+func NewRecorder(logger *zap.Logger) *Recorder {
+	return &Recorder{logger: logger}
+}
+
+// Record runs the given phase of the component, recording its duration and error, and returns the error.
+func (r *Recorder) Record(comp Component, phase Phase, run func() error) error {
+	if r == nil {
+		return run()
+	}
+
+	r.mu.Lock()
+	idx := len(r.events)
+	r.events = append(r.events, Event{Component: comp, Phase: phase, Start: time.Now(), InProgress: true})
+	start := r.events[idx].Start
+	r.mu.Unlock()
+
+	err := run()
+	duration := time.Since(start)
+
+	r.mu.Lock()
+	r.events[idx].Duration = duration
+	r.events[idx].InProgress = false
+	r.events[idx].Err = err
+	r.mu.Unlock()
+
+	fields := []zap.Field{
+		zap.String(components.ZapKindKey, comp.Kind),
+		zap.String(components.ZapNameKey, comp.ID.String()),
+		zap.String("phase", string(phase)),
+		zap.Duration("duration", duration),
+	}
+	if comp.DataType != "" {
+		fields = append(fields, zap.String(components.ZapDataTypeKey, string(comp.DataType)))
+	}
+	if comp.Pipeline != (config.ComponentID{}) {
+		fields = append(fields, zap.String(components.ZapKindPipeline, comp.Pipeline.String()))
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	r.logger.Info("Component lifecycle event", fields...)
+	return err
+}
+
+// Events returns a copy of the recorded events.
+func (r *Recorder) Events() []Event {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := make([]Event, len(r.events))
+	copy(events, r.events)
+	for i := range events {
+		if events[i].InProgress {
+			events[i].Duration = time.Since(events[i].Start)
+		}
+	}
+	return events
+}
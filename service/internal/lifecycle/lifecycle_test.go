@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+func TestRecorder(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	r := NewRecorder(zap.New(core))
+
+	exp := Component{Kind: components.ZapKindExporter, ID: config.NewComponentID("otlp"), DataType: config.TracesDataType}
+	proc := Component{Kind: components.ZapKindProcessor, ID: config.NewComponentID("batch"), Pipeline: config.NewComponentID("traces")}
+	errStart := errors.New("start failed")
+
+	assert.NoError(t, r.Record(exp, PhaseBuild, func() error { return nil }))
+	assert.Equal(t, errStart, r.Record(proc, PhaseStart, func() error {
+		time.Sleep(10 * time.Millisecond)
+		return errStart
+	}))
+
+	events := r.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, exp, events[0].Component)
+	assert.Equal(t, PhaseBuild, events[0].Phase)
+	assert.NoError(t, events[0].Err)
+	assert.False(t, events[0].InProgress)
+	assert.Equal(t, proc, events[1].Component)
+	assert.Equal(t, PhaseStart, events[1].Phase)
+	assert.Equal(t, errStart, events[1].Err)
+	assert.GreaterOrEqual(t, events[1].Duration, 10*time.Millisecond)
+	assert.False(t, events[1].Start.Before(events[0].Start))
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	fields := entries[1].ContextMap()
+	assert.Equal(t, components.ZapKindProcessor, fields[components.ZapKindKey])
+	assert.Equal(t, "batch", fields[components.ZapNameKey])
+	assert.Equal(t, "start", fields["phase"])
+	assert.Equal(t, "traces", fields[components.ZapKindPipeline])
+	assert.Equal(t, errStart.Error(), fields["error"])
+}
+
+func TestRecorderInProgress(t *testing.T) {
+	r := NewRecorder(zap.NewNop())
+	comp := Component{Kind: components.ZapKindReceiver, ID: config.NewComponentID("otlp"), DataType: config.LogsDataType}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, r.Record(comp, PhaseShutdown, func() error {
+			close(started)
+			<-release
+			return nil
+		}))
+	}()
+	<-started
+	time.Sleep(10 * time.Millisecond)
+
+	events := r.Events()
+	require.Len(t, events, 1)
+	assert.True(t, events[0].InProgress)
+	assert.GreaterOrEqual(t, events[0].Duration, 10*time.Millisecond)
+
+	close(release)
+	<-done
+	events = r.Events()
+	require.Len(t, events, 1)
+	assert.False(t, events[0].InProgress)
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	called := false
+	assert.NoError(t, r.Record(Component{}, PhaseStart, func() error {
+		called = true
+		return nil
+	}))
+	assert.True(t, called)
+	assert.Empty(t, r.Events())
+}
+
+func TestComponentString(t *testing.T) {
+	assert.Equal(t, "exporter otlp/2 (metrics)",
+		Component{Kind: components.ZapKindExporter, ID: config.NewComponentIDWithName("otlp", "2"), DataType: config.MetricsDataType}.String())
+	assert.Equal(t, "processor batch (pipeline traces/in)",
+		Component{Kind: components.ZapKindProcessor, ID: config.NewComponentID("batch"), Pipeline: config.NewComponentIDWithName("traces", "in")}.String())
+	assert.Equal(t, "extension zpages",
+		Component{Kind: components.ZapKindExtension, ID: config.NewComponentID("zpages")}.String())
+}
+
+func TestHandlers(t *testing.T) {
+	r := NewRecorder(zap.NewNop())
+	proc := Component{Kind: components.ZapKindProcessor, ID: config.NewComponentID("batch"), Pipeline: config.NewComponentID("traces")}
+	require.NoError(t, r.Record(proc, PhaseBuild, func() error { return nil }))
+	ext := Component{Kind: components.ZapKindExtension, ID: config.NewComponentID("zpages")}
+	require.Error(t, r.Record(ext, PhaseStart, func() error { return errors.New("port in use") }))
+
+	rr := httptest.NewRecorder()
+	r.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/lifecyclez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "processor batch (pipeline traces)")
+	assert.Contains(t, rr.Body.String(), "port in use")
+
+	rr = httptest.NewRecorder()
+	r.HandleJSON(rr, httptest.NewRequest(http.MethodGet, "/debug/lifecyclez/json", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var events []jsonEvent
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, "processor", events[0].Kind)
+	assert.Equal(t, "batch", events[0].ID)
+	assert.Equal(t, "traces", events[0].Pipeline)
+	assert.Equal(t, "build", events[0].Phase)
+	assert.Empty(t, events[0].Error)
+	assert.Equal(t, "extension", events[1].Kind)
+	assert.Equal(t, "start", events[1].Phase)
+	assert.Equal(t, "port in use", events[1].Error)
+}
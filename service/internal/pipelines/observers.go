@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// dataObservers returns the DataObservers of the point, skipping the extensions not observing it.
+func dataObservers(observers []component.PipelineDataObserver, point component.ObservationPoint) []component.DataObserver {
+	var ret []component.DataObserver
+	for _, o := range observers {
+		if do := o.DataObserver(point); do != nil {
+			ret = append(ret, do)
+		}
+	}
+	return ret
+}
+
+// observeTraces returns next if there is no observer, or a consumer.Traces passing a copy of the
+// sampled traces to the observers before forwarding them to next. Capabilities are the ones of next,
+// since the observers never access the forwarded traces.
+func observeTraces(next consumer.Traces, observers []component.DataObserver) consumer.Traces {
+	if len(observers) == 0 {
+		return next
+	}
+	return observedTraces{Traces: next, observers: observers}
+}
+
+type observedTraces struct {
+	consumer.Traces
+	observers []component.DataObserver
+}
+
+func (ot observedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	for _, o := range ot.observers {
+		if o.Sample() {
+			o.ObserveTraces(ctx, td.Clone())
+		}
+	}
+	return ot.Traces.ConsumeTraces(ctx, td)
+}
+
+// observeMetrics is the equivalent of observeTraces for metrics.
+func observeMetrics(next consumer.Metrics, observers []component.DataObserver) consumer.Metrics {
+	if len(observers) == 0 {
+		return next
+	}
+	return observedMetrics{Metrics: next, observers: observers}
+}
+
+type observedMetrics struct {
+	consumer.Metrics
+	observers []component.DataObserver
+}
+
+func (om observedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for _, o := range om.observers {
+		if o.Sample() {
+			o.ObserveMetrics(ctx, md.Clone())
+		}
+	}
+	return om.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// observeLogs is the equivalent of observeTraces for logs.
+func observeLogs(next consumer.Logs, observers []component.DataObserver) consumer.Logs {
+	if len(observers) == 0 {
+		return next
+	}
+	return observedLogs{Logs: next, observers: observers}
+}
+
+type observedLogs struct {
+	consumer.Logs
+	observers []component.DataObserver
+}
+
+func (ol observedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	for _, o := range ol.observers {
+		if o.Sample() {
+			o.ObserveLogs(ctx, ld.Clone())
+		}
+	}
+	return ol.Logs.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/testcomponents"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+// recordingObserver observes the given points, sampling all batches if sample is true.
+type recordingObserver struct {
+	points  map[component.ObservationPoint]bool
+	sample  bool
+	mu      sync.Mutex
+	traces  map[component.ObservationPoint][]ptrace.Traces
+	metrics map[component.ObservationPoint][]pmetric.Metrics
+	logs    map[component.ObservationPoint][]plog.Logs
+}
+
+func newRecordingObserver(sample bool, points ...component.ObservationPoint) *recordingObserver {
+	ro := &recordingObserver{
+		points:  make(map[component.ObservationPoint]bool),
+		sample:  sample,
+		traces:  make(map[component.ObservationPoint][]ptrace.Traces),
+		metrics: make(map[component.ObservationPoint][]pmetric.Metrics),
+		logs:    make(map[component.ObservationPoint][]plog.Logs),
+	}
+	for _, p := range points {
+		ro.points[p] = true
+	}
+	return ro
+}
+
+func (ro *recordingObserver) DataObserver(point component.ObservationPoint) component.DataObserver {
+	if len(ro.points) != 0 && !ro.points[point] {
+		return nil
+	}
+	return &pointObserver{ro: ro, point: point}
+}
+
+type pointObserver struct {
+	ro    *recordingObserver
+	point component.ObservationPoint
+}
+
+func (po *pointObserver) Sample() bool {
+	return po.ro.sample
+}
+
+func (po *pointObserver) ObserveTraces(_ context.Context, td ptrace.Traces) {
+	po.ro.mu.Lock()
+	defer po.ro.mu.Unlock()
+	po.ro.traces[po.point] = append(po.ro.traces[po.point], td)
+}
+
+func (po *pointObserver) ObserveMetrics(_ context.Context, md pmetric.Metrics) {
+	po.ro.mu.Lock()
+	defer po.ro.mu.Unlock()
+	po.ro.metrics[po.point] = append(po.ro.metrics[po.point], md)
+}
+
+func (po *pointObserver) ObserveLogs(_ context.Context, ld plog.Logs) {
+	po.ro.mu.Lock()
+	defer po.ro.mu.Unlock()
+	po.ro.logs[po.point] = append(po.ro.logs[po.point], ld)
+}
+
+func TestBuildWithDataObservers(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "pipelines_multi.yaml"), factories)
+	require.NoError(t, err)
+
+	recvID := config.NewComponentID("examplereceiver")
+	recv1ID := config.NewComponentIDWithName("examplereceiver", "1")
+	exp1ID := config.NewComponentIDWithName("exampleexporter", "1")
+	dataTypes := []config.DataType{config.TracesDataType, config.MetricsDataType, config.LogsDataType}
+
+	var points []component.ObservationPoint
+	for _, dt := range dataTypes {
+		points = append(points,
+			component.ObservationPoint{Pipeline: config.NewComponentID(dt), Kind: component.KindReceiver, Component: recvID},
+			component.ObservationPoint{Pipeline: config.NewComponentID(dt), Kind: component.KindExporter, Component: exp1ID})
+	}
+	sampling := newRecordingObserver(true, points...)
+	notSampling := newRecordingObserver(false)
+
+	set := toSettings(factories, cfg)
+	set.DataObservers = []component.PipelineDataObserver{sampling, notSampling}
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	for _, id := range []config.ComponentID{recvID, recvID, recv1ID} {
+		assert.NoError(t, pipelines.allReceivers[config.TracesDataType][id].(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+		assert.NoError(t, pipelines.allReceivers[config.MetricsDataType][id].(*testcomponents.ExampleReceiver).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+		assert.NoError(t, pipelines.allReceivers[config.LogsDataType][id].(*testcomponents.ExampleReceiver).ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	// Only the requested points are observed: the output of examplereceiver and the input of exampleexporter/1.
+	assert.Len(t, sampling.traces, 2)
+	assert.Len(t, sampling.metrics, 2)
+	assert.Len(t, sampling.logs, 2)
+	for _, dt := range dataTypes {
+		recvPoint := component.ObservationPoint{Pipeline: config.NewComponentID(dt), Kind: component.KindReceiver, Component: recvID}
+		expPoint := component.ObservationPoint{Pipeline: config.NewComponentID(dt), Kind: component.KindExporter, Component: exp1ID}
+		switch dt {
+		case config.TracesDataType:
+			require.Len(t, sampling.traces[recvPoint], 2)
+			require.Len(t, sampling.traces[expPoint], 3)
+			assert.Equal(t, testdata.GenerateTraces(1), sampling.traces[recvPoint][0])
+		case config.MetricsDataType:
+			require.Len(t, sampling.metrics[recvPoint], 2)
+			require.Len(t, sampling.metrics[expPoint], 3)
+			assert.Equal(t, testdata.GenerateMetrics(1), sampling.metrics[recvPoint][0])
+		case config.LogsDataType:
+			require.Len(t, sampling.logs[recvPoint], 2)
+			require.Len(t, sampling.logs[expPoint], 3)
+			assert.Equal(t, testdata.GenerateLogs(1), sampling.logs[recvPoint][0])
+		}
+	}
+
+	// The batches not sampled are not copied.
+	assert.Empty(t, notSampling.traces)
+	assert.Empty(t, notSampling.metrics)
+	assert.Empty(t, notSampling.logs)
+
+	// The observers own their copies, modifying them does not modify the exported data.
+	for _, td := range sampling.traces[component.ObservationPoint{Pipeline: config.NewComponentID(config.TracesDataType), Kind: component.KindExporter, Component: exp1ID}] {
+		td.ResourceSpans().RemoveIf(func(ptrace.ResourceSpans) bool { return true })
+	}
+	exp := pipelines.GetExporters()[config.TracesDataType][exp1ID].(*testcomponents.ExampleExporter)
+	require.Len(t, exp.Traces, 3)
+	for _, td := range exp.Traces {
+		assert.Equal(t, testdata.GenerateTraces(1), td.Clone())
+	}
+}
+
+func TestObserveNoObservers(t *testing.T) {
+	next := &testcomponents.ExampleExporter{}
+	assert.Same(t, next, observeTraces(next, nil))
+	assert.Same(t, next, observeMetrics(next, nil))
+	assert.Same(t, next, observeLogs(next, nil))
+}
@@ -26,9 +26,13 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/service/internal/lifecycle"
 	"go.opentelemetry.io/collector/service/internal/zpages"
+	"go.opentelemetry.io/collector/service/recovery"
+	"go.opentelemetry.io/collector/service/watchdog"
 )
 
 const (
@@ -63,6 +67,16 @@ type Pipelines struct {
 	allExporters map[config.DataType]map[config.ComponentID]component.Exporter
 
 	pipelines map[config.ComponentID]*builtPipeline
+
+	// supervisor restarts the wedged components, nil if the watchdog is disabled.
+	supervisor *supervisor
+
+	// recovery is the recovery policy of the components, recovering components are the processors
+	// and exporters recovering from their panics.
+	recovery   recovery.Config
+	recovering []*recoveringComponent
+
+	lifecycle *lifecycle.Recorder
 }
 
 // StartAll starts all pipelines.
@@ -76,7 +90,10 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 		for expID, exp := range expByID {
 			expLogger := exporterLogger(bps.telemetry.Logger, expID, dt)
 			expLogger.Info("Exporter is starting...")
-			if err := exp.Start(ctx, components.NewHostWrapper(host, expLogger)); err != nil {
+			comp := lifecycle.Component{Kind: components.ZapKindExporter, ID: expID, DataType: dt}
+			if err := bps.lifecycle.Record(comp, lifecycle.PhaseStart, func() error {
+				return exp.Start(ctx, components.NewHostWrapper(host, expLogger))
+			}); err != nil {
 				return err
 			}
 			expLogger.Info("Exporter started.")
@@ -88,7 +105,11 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 		for i := len(bp.processors) - 1; i >= 0; i-- {
 			procLogger := processorLogger(bps.telemetry.Logger, bp.processors[i].id, pipelineID)
 			procLogger.Info("Processor is starting...")
-			if err := bp.processors[i].comp.Start(ctx, components.NewHostWrapper(host, procLogger)); err != nil {
+			proc := bp.processors[i]
+			comp := lifecycle.Component{Kind: components.ZapKindProcessor, ID: proc.id, Pipeline: pipelineID}
+			if err := bps.lifecycle.Record(comp, lifecycle.PhaseStart, func() error {
+				return proc.comp.Start(ctx, components.NewHostWrapper(host, procLogger))
+			}); err != nil {
 				return err
 			}
 			procLogger.Info("Processor started.")
@@ -100,12 +121,20 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 		for recvID, recv := range recvByID {
 			recvLogger := receiverLogger(bps.telemetry.Logger, recvID, dt)
 			recvLogger.Info("Receiver is starting...")
-			if err := recv.Start(ctx, components.NewHostWrapper(host, recvLogger)); err != nil {
+			recvHost := components.NewHostWrapper(host, recvLogger)
+			if bps.recovery.Recovers(components.LogLevelKey(components.ZapKindReceiver, recvID.String())) {
+				recvHost = recoveringHost{Host: recvHost}
+			}
+			comp := lifecycle.Component{Kind: components.ZapKindReceiver, ID: recvID, DataType: dt}
+			if err := bps.lifecycle.Record(comp, lifecycle.PhaseStart, func() error {
+				return recv.Start(ctx, recvHost)
+			}); err != nil {
 				return err
 			}
 			recvLogger.Info("Receiver started.")
 		}
 	}
+	bps.supervisor.start()
 	return nil
 }
 
@@ -115,24 +144,34 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 // This gives senders a chance to send all their data to a not "shutdown" component.
 func (bps *Pipelines) ShutdownAll(ctx context.Context) error {
 	var errs error
+	bps.supervisor.stop()
 	bps.telemetry.Logger.Info("Stopping receivers...")
-	for _, recvByID := range bps.allReceivers {
-		for _, recv := range recvByID {
-			errs = multierr.Append(errs, recv.Shutdown(ctx))
+	for dt, recvByID := range bps.allReceivers {
+		for recvID, recv := range recvByID {
+			comp := lifecycle.Component{Kind: components.ZapKindReceiver, ID: recvID, DataType: dt}
+			errs = multierr.Append(errs, bps.lifecycle.Record(comp, lifecycle.PhaseShutdown, func() error {
+				return recv.Shutdown(ctx)
+			}))
 		}
 	}
 
 	bps.telemetry.Logger.Info("Stopping processors...")
-	for _, bp := range bps.pipelines {
+	for pipelineID, bp := range bps.pipelines {
 		for _, p := range bp.processors {
-			errs = multierr.Append(errs, p.comp.Shutdown(ctx))
+			comp := lifecycle.Component{Kind: components.ZapKindProcessor, ID: p.id, Pipeline: pipelineID}
+			errs = multierr.Append(errs, bps.lifecycle.Record(comp, lifecycle.PhaseShutdown, func() error {
+				return p.comp.Shutdown(ctx)
+			}))
 		}
 	}
 
 	bps.telemetry.Logger.Info("Stopping exporters...")
-	for _, expByID := range bps.allExporters {
-		for _, exp := range expByID {
-			errs = multierr.Append(errs, exp.Shutdown(ctx))
+	for dt, expByID := range bps.allExporters {
+		for expID, exp := range expByID {
+			comp := lifecycle.Component{Kind: components.ZapKindExporter, ID: expID, DataType: dt}
+			errs = multierr.Append(errs, bps.lifecycle.Record(comp, lifecycle.PhaseShutdown, func() error {
+				return exp.Shutdown(ctx)
+			}))
 		}
 	}
 
@@ -148,7 +187,7 @@ func (bps *Pipelines) GetExporters() map[config.DataType]map[config.ComponentID]
 
 	for dt, expByID := range bps.allExporters {
 		for expID, exp := range expByID {
-			exportersMap[dt][expID] = exp
+			exportersMap[dt][expID] = unwrapSupervised(exp)
 		}
 	}
 
@@ -172,7 +211,14 @@ func (bps *Pipelines) HandleZPages(w http.ResponseWriter, r *http.Request) {
 		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 			Name: componentKind + ": " + fullName,
 		})
-		// TODO: Add config + status info.
+		if pipelineID, err := config.NewComponentIDFromString(pipelineName); err == nil {
+			for _, rc := range bps.recovering {
+				if rc.matches(componentKind, componentName, pipelineID) {
+					zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Status", Properties: rc.status()})
+				}
+			}
+		}
+		// TODO: Add config info.
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
@@ -202,15 +248,38 @@ type Settings struct {
 
 	// PipelineConfigs is a map of config.ComponentID to config.Pipeline.
 	PipelineConfigs map[config.ComponentID]*config.Pipeline
+
+	// DataObservers are the extensions observing the output of the receivers and the input of the exporters.
+	DataObservers []component.PipelineDataObserver
+
+	// StampResource is the resource whose attributes are inserted in the resources of all the data received
+	// by the pipelines, without overriding the attributes already set. Ignored if it has no attribute.
+	StampResource pcommon.Resource
+
+	// Watchdog configures the watchdog restarting the wedged receivers, processors and exporters.
+	Watchdog watchdog.Config
+
+	// Recovery configures the components recovering from their panics.
+	Recovery recovery.Config
+
+	// Lifecycle records the build, start and shutdown events of the components, nothing is recorded if nil.
+	Lifecycle *lifecycle.Recorder
 }
 
 // Build builds all pipelines from config.
 func Build(ctx context.Context, set Settings) (*Pipelines, error) {
+	if err := validateDataTypes(set); err != nil {
+		return nil, err
+	}
+
 	exps := &Pipelines{
 		telemetry:    set.Telemetry,
 		allReceivers: make(map[config.DataType]map[config.ComponentID]component.Receiver),
 		allExporters: make(map[config.DataType]map[config.ComponentID]component.Exporter),
 		pipelines:    make(map[config.ComponentID]*builtPipeline, len(set.PipelineConfigs)),
+		supervisor:   newSupervisor(set.Watchdog),
+		recovery:     set.Recovery,
+		lifecycle:    set.Lifecycle,
 	}
 
 	receiversConsumers := make(map[config.DataType]map[config.ComponentID][]baseConsumer)
@@ -240,10 +309,23 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 				continue
 			}
 
-			exp, err := buildExporter(ctx, set.Telemetry, set.BuildInfo, set.ExporterConfigs, set.ExporterFactories, expID, pipelineID)
+			var exp component.Exporter
+			comp := lifecycle.Component{Kind: components.ZapKindExporter, ID: expID, DataType: pipelineID.Type()}
+			err := set.Lifecycle.Record(comp, lifecycle.PhaseBuild, func() (err error) {
+				exp, err = buildExporter(ctx, set.Telemetry, set.BuildInfo, set.ExporterConfigs, set.ExporterFactories, expID, pipelineID)
+				return err
+			})
 			if err != nil {
 				return nil, err
 			}
+			if exps.supervisor != nil {
+				exp = exps.supervisor.superviseExporter(set, expID, pipelineID, exp)
+			}
+			if set.Recovery.Recovers(components.LogLevelKey(components.ZapKindExporter, expID.String())) {
+				rc := newRecoveringExporter(exp, expID, pipelineID.Type(), exporterLogger(set.Telemetry.Logger, expID, pipelineID.Type()))
+				exps.recovering = append(exps.recovering, rc)
+				exp = rc.wrap(pipelineID.Type())
+			}
 
 			bp.exporters[i] = builtComponent{id: expID, comp: exp}
 			expByID[expID] = exp
@@ -252,11 +334,11 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		// Build a fan out consumer to all exporters.
 		switch pipelineID.Type() {
 		case config.TracesDataType:
-			bp.lastConsumer = buildFanOutExportersTracesConsumer(bp.exporters)
+			bp.lastConsumer = buildFanOutExportersTracesConsumer(pipelineID, bp.exporters, set.DataObservers)
 		case config.MetricsDataType:
-			bp.lastConsumer = buildFanOutExportersMetricsConsumer(bp.exporters)
+			bp.lastConsumer = buildFanOutExportersMetricsConsumer(pipelineID, bp.exporters, set.DataObservers)
 		case config.LogsDataType:
-			bp.lastConsumer = buildFanOutExportersLogsConsumer(bp.exporters)
+			bp.lastConsumer = buildFanOutExportersLogsConsumer(pipelineID, bp.exporters, set.DataObservers)
 		default:
 			return nil, fmt.Errorf("create fan-out exporter in pipeline %q, data type %q is not supported", pipelineID, pipelineID.Type())
 		}
@@ -268,10 +350,23 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		for i := len(pipeline.Processors) - 1; i >= 0; i-- {
 			procID := pipeline.Processors[i]
 
-			proc, err := buildProcessor(ctx, set.Telemetry, set.BuildInfo, set.ProcessorConfigs, set.ProcessorFactories, procID, pipelineID, bp.lastConsumer)
+			var proc component.Processor
+			comp := lifecycle.Component{Kind: components.ZapKindProcessor, ID: procID, Pipeline: pipelineID}
+			err := set.Lifecycle.Record(comp, lifecycle.PhaseBuild, func() (err error) {
+				proc, err = buildProcessor(ctx, set.Telemetry, set.BuildInfo, set.ProcessorConfigs, set.ProcessorFactories, procID, pipelineID, bp.lastConsumer)
+				return err
+			})
 			if err != nil {
 				return nil, err
 			}
+			if exps.supervisor != nil {
+				proc = exps.supervisor.superviseProcessor(set, procID, pipelineID, bp.lastConsumer, proc)
+			}
+			if set.Recovery.Recovers(components.LogLevelKey(components.ZapKindProcessor, procID.String())) {
+				rc := newRecoveringProcessor(proc, procID, pipelineID, processorLogger(set.Telemetry.Logger, procID, pipelineID))
+				exps.recovering = append(exps.recovering, rc)
+				proc = rc.wrap(pipelineID.Type())
+			}
 
 			bp.processors[i] = builtComponent{id: procID, comp: proc}
 			bp.lastConsumer = proc.(baseConsumer)
@@ -290,15 +385,18 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		default:
 			return nil, fmt.Errorf("create cap consumer in pipeline %q, data type %q is not supported", pipelineID, pipelineID.Type())
 		}
+		bp.lastConsumer = stampFirstConsumer(bp.lastConsumer, set.StampResource)
 
 		// The data type of the pipeline defines what data type each exporter is expected to receive.
 		if _, ok := receiversConsumers[pipelineID.Type()]; !ok {
 			receiversConsumers[pipelineID.Type()] = make(map[config.ComponentID][]baseConsumer)
 		}
 		recvConsByID := receiversConsumers[pipelineID.Type()]
-		// Iterate over all Receivers for this pipeline and just append the lastConsumer as a consumer for the receiver.
+		// Iterate over all Receivers for this pipeline and just append the lastConsumer as a consumer for the receiver,
+		// observed if any extension observes the output of the receiver in this pipeline.
 		for _, recvID := range pipeline.Receivers {
-			recvConsByID[recvID] = append(recvConsByID[recvID], bp.lastConsumer)
+			point := component.ObservationPoint{Pipeline: pipelineID, Kind: component.KindReceiver, Component: recvID}
+			recvConsByID[recvID] = append(recvConsByID[recvID], observeReceiverOutput(bp.lastConsumer, dataObservers(set.DataObservers, point)))
 		}
 	}
 
@@ -319,10 +417,18 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 				continue
 			}
 
-			recv, err := buildReceiver(ctx, set.Telemetry, set.BuildInfo, set.ReceiverConfigs, set.ReceiverFactories, recvID, pipelineID, receiversConsumers[pipelineID.Type()][recvID])
+			var recv component.Receiver
+			comp := lifecycle.Component{Kind: components.ZapKindReceiver, ID: recvID, DataType: pipelineID.Type()}
+			err := set.Lifecycle.Record(comp, lifecycle.PhaseBuild, func() (err error) {
+				recv, err = buildReceiver(ctx, set.Telemetry, set.BuildInfo, set.ReceiverConfigs, set.ReceiverFactories, recvID, pipelineID, receiversConsumers[pipelineID.Type()][recvID])
+				return err
+			})
 			if err != nil {
 				return nil, err
 			}
+			if exps.supervisor != nil {
+				recv = exps.supervisor.superviseReceiver(set, recvID, pipelineID, receiversConsumers[pipelineID.Type()][recvID], recv)
+			}
 
 			bp.receivers[i] = builtComponent{id: recvID, comp: recv}
 			recvByID[recvID] = recv
@@ -331,19 +437,67 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 	return exps, nil
 }
 
-func logStabilityMessage(logger *zap.Logger, sl component.StabilityLevel) {
-	switch sl {
-	case component.StabilityLevelDeprecated:
-		logger.Info("Component has been deprecated and will be removed in future releases.", zap.String(components.ZapStabilityKey, sl.String()))
-	case component.StabilityLevelUnmaintained:
-		logger.Info("Component is unmaintained and actively looking for contributors. This component will become deprecated after 6 months of remaining unmaintained", zap.String(components.ZapStabilityKey, sl.String()))
-	case component.StabilityLevelInDevelopment:
-		logger.Info("Component is under development.", zap.String(components.ZapStabilityKey, sl.String()))
-	case component.StabilityLevelAlpha, component.StabilityLevelBeta, component.StabilityLevelStable:
-		logger.Debug("Stability level", zap.String(components.ZapStabilityKey, sl.String()))
-	default:
-		logger.Info("Stability level of component undefined", zap.String(components.ZapStabilityKey, sl.String()))
+// observeReceiverOutput wraps the first consumer of a pipeline with the observers of the receiver output.
+func observeReceiverOutput(next baseConsumer, observers []component.DataObserver) baseConsumer {
+	switch c := next.(type) {
+	case consumer.Traces:
+		return observeTraces(c, observers)
+	case consumer.Metrics:
+		return observeMetrics(c, observers)
+	case consumer.Logs:
+		return observeLogs(c, observers)
+	}
+	return next
+}
+
+// dataTypesFactory is implemented by all the factories of components used in pipelines.
+type dataTypesFactory interface {
+	SupportedDataTypes() []config.DataType
+}
+
+// validateDataTypes checks, before any component is created, that all the components referenced
+// by the pipelines support the pipeline data type. All mismatches are reported at once.
+// Components without a factory are reported later, when built.
+func validateDataTypes(set Settings) error {
+	pipelineIDs := make([]config.ComponentID, 0, len(set.PipelineConfigs))
+	for pipelineID := range set.PipelineConfigs {
+		pipelineIDs = append(pipelineIDs, pipelineID)
 	}
+	sort.Slice(pipelineIDs, func(i, j int) bool {
+		return pipelineIDs[i].String() < pipelineIDs[j].String()
+	})
+
+	var errs error
+	check := func(pipelineID config.ComponentID, kind string, id config.ComponentID, factory dataTypesFactory) {
+		supported := factory.SupportedDataTypes()
+		for _, dt := range supported {
+			if dt == pipelineID.Type() {
+				return
+			}
+		}
+		errs = multierr.Append(errs, fmt.Errorf("pipeline %q references %s %q which does not support the %q data type, supported data types: %v",
+			pipelineID, kind, id, pipelineID.Type(), supported))
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		pipeline := set.PipelineConfigs[pipelineID]
+		for _, id := range pipeline.Receivers {
+			if factory, ok := set.ReceiverFactories[id.Type()]; ok {
+				check(pipelineID, "receiver", id, factory)
+			}
+		}
+		for _, id := range pipeline.Processors {
+			if factory, ok := set.ProcessorFactories[id.Type()]; ok {
+				check(pipelineID, "processor", id, factory)
+			}
+		}
+		for _, id := range pipeline.Exporters {
+			if factory, ok := set.ExporterFactories[id.Type()]; ok {
+				check(pipelineID, "exporter", id, factory)
+			}
+		}
+	}
+	return errs
 }
 
 func buildExporter(
@@ -370,7 +524,7 @@ func buildExporter(
 		BuildInfo:         buildInfo,
 	}
 	set.TelemetrySettings.Logger = exporterLogger(settings.Logger, id, pipelineID.Type())
-	logStabilityMessage(set.TelemetrySettings.Logger, factory.StabilityLevel(pipelineID.Type()))
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, factory.StabilityLevel(pipelineID.Type()))
 
 	exp, err := createExporter(ctx, set, cfg, id, pipelineID, factory)
 	if err != nil {
@@ -394,28 +548,31 @@ func createExporter(ctx context.Context, set component.ExporterCreateSettings, c
 	return nil, fmt.Errorf("error creating exporter %q in pipeline %q, data type %q is not supported", id, pipelineID, pipelineID.Type())
 }
 
-func buildFanOutExportersTracesConsumer(exporters []builtComponent) consumer.Traces {
+func buildFanOutExportersTracesConsumer(pipelineID config.ComponentID, exporters []builtComponent, observers []component.PipelineDataObserver) consumer.Traces {
 	consumers := make([]consumer.Traces, 0, len(exporters))
 	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Traces))
+		point := component.ObservationPoint{Pipeline: pipelineID, Kind: component.KindExporter, Component: exp.id}
+		consumers = append(consumers, observeTraces(exp.comp.(consumer.Traces), dataObservers(observers, point)))
 	}
 	// Create a junction point that fans out to all allExporters.
 	return fanoutconsumer.NewTraces(consumers)
 }
 
-func buildFanOutExportersMetricsConsumer(exporters []builtComponent) consumer.Metrics {
+func buildFanOutExportersMetricsConsumer(pipelineID config.ComponentID, exporters []builtComponent, observers []component.PipelineDataObserver) consumer.Metrics {
 	consumers := make([]consumer.Metrics, 0, len(exporters))
 	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Metrics))
+		point := component.ObservationPoint{Pipeline: pipelineID, Kind: component.KindExporter, Component: exp.id}
+		consumers = append(consumers, observeMetrics(exp.comp.(consumer.Metrics), dataObservers(observers, point)))
 	}
 	// Create a junction point that fans out to all allExporters.
 	return fanoutconsumer.NewMetrics(consumers)
 }
 
-func buildFanOutExportersLogsConsumer(exporters []builtComponent) consumer.Logs {
+func buildFanOutExportersLogsConsumer(pipelineID config.ComponentID, exporters []builtComponent, observers []component.PipelineDataObserver) consumer.Logs {
 	consumers := make([]consumer.Logs, 0, len(exporters))
 	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Logs))
+		point := component.ObservationPoint{Pipeline: pipelineID, Kind: component.KindExporter, Component: exp.id}
+		consumers = append(consumers, observeLogs(exp.comp.(consumer.Logs), dataObservers(observers, point)))
 	}
 	// Create a junction point that fans out to all allExporters.
 	return fanoutconsumer.NewLogs(consumers)
@@ -452,7 +609,7 @@ func buildProcessor(ctx context.Context,
 		BuildInfo:         buildInfo,
 	}
 	set.TelemetrySettings.Logger = processorLogger(settings.Logger, id, pipelineID)
-	logStabilityMessage(set.TelemetrySettings.Logger, factory.StabilityLevel(pipelineID.Type()))
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, factory.StabilityLevel(pipelineID.Type()))
 
 	proc, err := createProcessor(ctx, set, procCfg, id, pipelineID, next, factory)
 	if err != nil {
@@ -506,7 +663,7 @@ func buildReceiver(ctx context.Context,
 		BuildInfo:         buildInfo,
 	}
 	set.TelemetrySettings.Logger = receiverLogger(settings.Logger, id, pipelineID.Type())
-	logStabilityMessage(set.TelemetrySettings.Logger, factory.StabilityLevel(pipelineID.Type()))
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, factory.StabilityLevel(pipelineID.Type()))
 
 	recv, err := createReceiver(ctx, set, cfg, id, pipelineID, nexts, factory)
 	if err != nil {
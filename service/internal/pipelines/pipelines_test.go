@@ -22,9 +22,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
-	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -33,6 +32,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testcomponents"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/internal/lifecycle"
 	"go.opentelemetry.io/collector/service/servicetest"
 )
 
@@ -179,24 +180,25 @@ func TestBuild(t *testing.T) {
 				assert.True(t, logsProcessor.comp.(*testcomponents.ExampleProcessor).Stopped)
 			}
 
-			// Now verify that exporters received data, and are shutdown.
+			// Now verify that exporters received data, and are shutdown. The data may be shared
+			// read-only between the exporters, compare a mutable copy to ignore the state.
 			for _, expID := range test.exporterIDs {
 				// Validate traces.
 				traceExporter := pipelines.GetExporters()[config.TracesDataType][expID].(*testcomponents.ExampleExporter)
 				require.Len(t, traceExporter.Traces, test.expectedRequests)
-				assert.EqualValues(t, testdata.GenerateTraces(1), traceExporter.Traces[0])
+				assert.EqualValues(t, testdata.GenerateTraces(1), traceExporter.Traces[0].Clone())
 				assert.True(t, traceExporter.Stopped)
 
 				// Validate metrics.
 				metricsExporter := pipelines.GetExporters()[config.MetricsDataType][expID].(*testcomponents.ExampleExporter)
 				require.Len(t, metricsExporter.Metrics, test.expectedRequests)
-				assert.EqualValues(t, testdata.GenerateMetrics(1), metricsExporter.Metrics[0])
+				assert.EqualValues(t, testdata.GenerateMetrics(1), metricsExporter.Metrics[0].Clone())
 				assert.True(t, metricsExporter.Stopped)
 
 				// Validate logs.
 				logsExporter := pipelines.GetExporters()[config.LogsDataType][expID].(*testcomponents.ExampleExporter)
 				require.Len(t, logsExporter.Logs, test.expectedRequests)
-				assert.EqualValues(t, testdata.GenerateLogs(1), logsExporter.Logs[0])
+				assert.EqualValues(t, testdata.GenerateLogs(1), logsExporter.Logs[0].Clone())
 				assert.True(t, logsExporter.Stopped)
 			}
 		})
@@ -266,6 +268,38 @@ func TestBuildErrors(t *testing.T) {
 	}
 }
 
+func TestBuildDataTypeMismatches(t *testing.T) {
+	nopReceiverFactory := componenttest.NewNopReceiverFactory()
+	nopExporterFactory := componenttest.NewNopExporterFactory()
+	badReceiverFactory := newBadReceiverFactory()
+	badProcessorFactory := newBadProcessorFactory()
+	badExporterFactory := newBadExporterFactory()
+	factories := component.Factories{
+		Receivers: map[config.Type]component.ReceiverFactory{
+			nopReceiverFactory.Type(): nopReceiverFactory,
+			badReceiverFactory.Type(): badReceiverFactory,
+		},
+		Processors: map[config.Type]component.ProcessorFactory{
+			badProcessorFactory.Type(): badProcessorFactory,
+		},
+		Exporters: map[config.Type]component.ExporterFactory{
+			nopExporterFactory.Type(): nopExporterFactory,
+			badExporterFactory.Type(): badExporterFactory,
+		},
+	}
+
+	cfg, err := servicetest.LoadConfig(filepath.Join("testdata", "not_supported_multiple.yaml"), factories)
+	require.NoError(t, err)
+
+	_, err = Build(context.Background(), toSettings(factories, cfg))
+	require.Error(t, err)
+	assert.Equal(t, []error{
+		errors.New(`pipeline "logs" references exporter "bf" which does not support the "logs" data type, supported data types: []`),
+		errors.New(`pipeline "traces" references receiver "bf" which does not support the "traces" data type, supported data types: []`),
+		errors.New(`pipeline "traces" references processor "bf" which does not support the "traces" data type, supported data types: []`),
+	}, multierr.Errors(err))
+}
+
 func TestFailToStartAndShutdown(t *testing.T) {
 	errReceiverFactory := newErrReceiverFactory()
 	errProcessorFactory := newErrProcessorFactory()
@@ -348,34 +382,63 @@ func TestFailToStartAndShutdown(t *testing.T) {
 	}
 }
 
-func TestLogStabilityLevle(t *testing.T) {
-	tests := []struct {
-		level        zapcore.Level
-		expectedLogs int
-	}{
-		{
-			level:        zapcore.DebugLevel,
-			expectedLogs: 7,
-		},
-		{
-			level:        zapcore.InfoLevel,
-			expectedLogs: 4,
+func TestLifecycleEvents(t *testing.T) {
+	nopReceiverFactory := componenttest.NewNopReceiverFactory()
+	nopProcessorFactory := componenttest.NewNopProcessorFactory()
+	errExporterFactory := newErrExporterFactory()
+	recvID := config.NewComponentID(nopReceiverFactory.Type())
+	procID := config.NewComponentID(nopProcessorFactory.Type())
+	expID := config.NewComponentID(errExporterFactory.Type())
+	pipelineID := config.NewComponentID(config.TracesDataType)
+
+	recorder := lifecycle.NewRecorder(zap.NewNop())
+	set := Settings{
+		Telemetry:          componenttest.NewNopTelemetrySettings(),
+		BuildInfo:          component.NewDefaultBuildInfo(),
+		ReceiverFactories:  map[config.Type]component.ReceiverFactory{nopReceiverFactory.Type(): nopReceiverFactory},
+		ReceiverConfigs:    map[config.ComponentID]config.Receiver{recvID: nopReceiverFactory.CreateDefaultConfig()},
+		ProcessorFactories: map[config.Type]component.ProcessorFactory{nopProcessorFactory.Type(): nopProcessorFactory},
+		ProcessorConfigs:   map[config.ComponentID]config.Processor{procID: nopProcessorFactory.CreateDefaultConfig()},
+		ExporterFactories:  map[config.Type]component.ExporterFactory{errExporterFactory.Type(): errExporterFactory},
+		ExporterConfigs:    map[config.ComponentID]config.Exporter{expID: errExporterFactory.CreateDefaultConfig()},
+		PipelineConfigs: map[config.ComponentID]*config.Pipeline{
+			pipelineID: {
+				Receivers:  []config.ComponentID{recvID},
+				Processors: []config.ComponentID{procID},
+				Exporters:  []config.ComponentID{expID},
+			},
 		},
+		Lifecycle: recorder,
 	}
-
-	for _, tt := range tests {
-		observed, logs := observer.New(tt.level)
-		logger := zap.New(observed)
-		// ensure log levels are set correctly for each stability level
-		logStabilityMessage(logger, component.StabilityLevelUndefined)
-		logStabilityMessage(logger, component.StabilityLevelUnmaintained)
-		logStabilityMessage(logger, component.StabilityLevelDeprecated)
-		logStabilityMessage(logger, component.StabilityLevelInDevelopment)
-		logStabilityMessage(logger, component.StabilityLevelAlpha)
-		logStabilityMessage(logger, component.StabilityLevelBeta)
-		logStabilityMessage(logger, component.StabilityLevelStable)
-		require.Equal(t, tt.expectedLogs, logs.Len())
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	assert.Error(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	assert.Error(t, pipelines.ShutdownAll(context.Background()))
+
+	exp := lifecycle.Component{Kind: components.ZapKindExporter, ID: expID, DataType: config.TracesDataType}
+	proc := lifecycle.Component{Kind: components.ZapKindProcessor, ID: procID, Pipeline: pipelineID}
+	recv := lifecycle.Component{Kind: components.ZapKindReceiver, ID: recvID, DataType: config.TracesDataType}
+	type step struct {
+		comp   lifecycle.Component
+		phase  lifecycle.Phase
+		failed bool
+	}
+	expected := []step{
+		{comp: exp, phase: lifecycle.PhaseBuild},
+		{comp: proc, phase: lifecycle.PhaseBuild},
+		{comp: recv, phase: lifecycle.PhaseBuild},
+		// The failed exporter start aborts the start of the pipelines.
+		{comp: exp, phase: lifecycle.PhaseStart, failed: true},
+		{comp: recv, phase: lifecycle.PhaseShutdown},
+		{comp: proc, phase: lifecycle.PhaseShutdown},
+		{comp: exp, phase: lifecycle.PhaseShutdown, failed: true},
+	}
+	var actual []step
+	for _, ev := range recorder.Events() {
+		assert.False(t, ev.InProgress)
+		actual = append(actual, step{comp: ev.Component, phase: ev.Phase, failed: ev.Err != nil})
 	}
+	assert.Equal(t, expected, actual)
 }
 
 func newBadReceiverFactory() component.ReceiverFactory {
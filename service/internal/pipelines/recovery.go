@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// recoveringComponent recovers from the panics of the consume calls of a processor or an exporter, returning
+// them as permanent errors. The component is reported unhealthy once it panicked.
+type recoveringComponent struct {
+	component.Component
+	kind string
+	id   config.ComponentID
+	// pipeline is the pipeline of the processors, the zero value for the exporters.
+	pipeline config.ComponentID
+	// dataType is the data type of the exporters, empty for the processors.
+	dataType config.DataType
+	logger   *zap.Logger
+	measure  *stats.Int64Measure
+	tags     []tag.Mutator
+
+	mu        sync.Mutex
+	panics    int
+	lastPanic string
+	lastTime  time.Time
+}
+
+func newRecoveringProcessor(proc component.Processor, id config.ComponentID, pipelineID config.ComponentID, logger *zap.Logger) *recoveringComponent {
+	return &recoveringComponent{
+		Component: proc,
+		kind:      components.ZapKindProcessor,
+		id:        id,
+		pipeline:  pipelineID,
+		logger:    logger,
+		measure:   obsmetrics.ProcessorRecoveredPanics,
+		tags:      []tag.Mutator{tag.Upsert(obsmetrics.TagKeyProcessor, id.String(), tag.WithTTL(tag.TTLNoPropagation))},
+	}
+}
+
+func newRecoveringExporter(exp component.Exporter, id config.ComponentID, dt config.DataType, logger *zap.Logger) *recoveringComponent {
+	return &recoveringComponent{
+		Component: exp,
+		kind:      components.ZapKindExporter,
+		id:        id,
+		dataType:  dt,
+		logger:    logger,
+		measure:   obsmetrics.ExporterRecoveredPanics,
+		tags:      []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, id.String(), tag.WithTTL(tag.TTLNoPropagation))},
+	}
+}
+
+// wrap returns the recoveringComponent consuming data of type dt with the consumer of the component.
+func (rc *recoveringComponent) wrap(dt config.DataType) component.Component {
+	switch dt {
+	case config.TracesDataType:
+		return recoveringTraces{recoveringComponent: rc, next: rc.Component.(consumer.Traces)}
+	case config.MetricsDataType:
+		return recoveringMetrics{recoveringComponent: rc, next: rc.Component.(consumer.Metrics)}
+	case config.LogsDataType:
+		return recoveringLogs{recoveringComponent: rc, next: rc.Component.(consumer.Logs)}
+	}
+	return rc
+}
+
+// matches returns whether the recoveringComponent is the component of the given kind and ID in the pipeline.
+func (rc *recoveringComponent) matches(kind string, id string, pipelineID config.ComponentID) bool {
+	if rc.kind != kind || rc.id.String() != id {
+		return false
+	}
+	if rc.kind == components.ZapKindProcessor {
+		return rc.pipeline == pipelineID
+	}
+	return rc.dataType == pipelineID.Type()
+}
+
+// unwrap returns the wrapped instance of the component.
+func (rc *recoveringComponent) unwrap() component.Component {
+	return unwrapSupervised(rc.Component)
+}
+
+// recoverPanic recovers from the panic of a consume call if any, setting the error returned by the call.
+// It must be deferred by the consume calls.
+func (rc *recoveringComponent) recoverPanic(ctx context.Context, errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	rc.mu.Lock()
+	rc.panics++
+	rc.lastPanic = fmt.Sprint(r)
+	rc.lastTime = time.Now()
+	rc.mu.Unlock()
+
+	rc.logger.Error("Component panicked, recovered from the panic", zap.Any("panic", r), zap.Stack("stacktrace"))
+	if obsreportconfig.Level() != configtelemetry.LevelNone {
+		_ = stats.RecordWithTags(ctx, rc.tags, rc.measure.M(1))
+	}
+	*errp = consumererror.NewPermanent(fmt.Errorf("%s %q panicked: %v", rc.kind, rc.id, r))
+}
+
+// status returns the health of the component as zPages properties, the component being unhealthy
+// once it panicked.
+func (rc *recoveringComponent) status() [][2]string {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.panics == 0 {
+		return [][2]string{{"Status", "healthy"}, {"Recovered panics", "0"}}
+	}
+	return [][2]string{
+		{"Status", "unhealthy"},
+		{"Recovered panics", strconv.Itoa(rc.panics)},
+		{"Last panic", rc.lastPanic},
+		{"Last panic time", rc.lastTime.UTC().Format(time.RFC3339Nano)},
+	}
+}
+
+type recoveringTraces struct {
+	*recoveringComponent
+	next consumer.Traces
+}
+
+func (rt recoveringTraces) Capabilities() consumer.Capabilities {
+	return rt.next.Capabilities()
+}
+
+func (rt recoveringTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) (err error) {
+	defer rt.recoverPanic(ctx, &err)
+	return rt.next.ConsumeTraces(ctx, td)
+}
+
+type recoveringMetrics struct {
+	*recoveringComponent
+	next consumer.Metrics
+}
+
+func (rm recoveringMetrics) Capabilities() consumer.Capabilities {
+	return rm.next.Capabilities()
+}
+
+func (rm recoveringMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) (err error) {
+	defer rm.recoverPanic(ctx, &err)
+	return rm.next.ConsumeMetrics(ctx, md)
+}
+
+type recoveringLogs struct {
+	*recoveringComponent
+	next consumer.Logs
+}
+
+func (rl recoveringLogs) Capabilities() consumer.Capabilities {
+	return rl.next.Capabilities()
+}
+
+func (rl recoveringLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) (err error) {
+	defer rl.recoverPanic(ctx, &err)
+	return rl.next.ConsumeLogs(ctx, ld)
+}
+
+// recoveringHost is the host of the receivers recovering from the panics of their scrapers.
+type recoveringHost struct {
+	component.Host
+}
+
+// RecoversPanics is used by the scraper controllers to recover from the panics of the scrapers.
+func (recoveringHost) RecoversPanics() bool {
+	return true
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/recovery"
+)
+
+func TestRecoveryPanickingExporter(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	core, logs := observer.New(zapcore.ErrorLevel)
+	set := recoverySettings(recovery.Config{Enabled: true})
+	set.Telemetry.Logger = zap.New(core)
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	panicking := pipelines.pipelines[config.NewComponentID(config.TracesDataType)].lastConsumer.(consumer.Traces)
+	err = panicking.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.EqualError(t, err, `Permanent error: exporter "panicking" panicked: unexpected nil pointer`)
+	require.Equal(t, 1, logs.FilterMessage("Component panicked, recovered from the panic").Len())
+	require.NoError(t, obsreporttest.CheckExporterRecoveredPanics(tt, config.NewComponentID("panicking"), 1))
+
+	// The other pipelines keep running.
+	healthy := pipelines.pipelines[config.NewComponentIDWithName(config.TracesDataType, "healthy")].lastConsumer.(consumer.Traces)
+	assert.NoError(t, healthy.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	_, ok := pipelines.GetExporters()[config.TracesDataType][config.NewComponentID("panicking")].(*panickingComponent)
+	assert.True(t, ok)
+
+	rr := httptest.NewRecorder()
+	pipelines.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/?zpipelinename=traces&zcomponentname=panicking&zcomponentkind=exporter", nil))
+	assert.Contains(t, rr.Body.String(), "unhealthy")
+	assert.Contains(t, rr.Body.String(), "unexpected nil pointer")
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+}
+
+func TestRecoveryDisabledForComponent(t *testing.T) {
+	pipelines, err := Build(context.Background(), recoverySettings(recovery.Config{
+		Enabled:    true,
+		Components: map[string]bool{"exporters.panicking": false},
+	}))
+	require.NoError(t, err)
+	require.Len(t, pipelines.recovering, 1)
+	assert.Equal(t, config.NewComponentID("nop"), pipelines.recovering[0].id)
+
+	panicking := pipelines.pipelines[config.NewComponentID(config.TracesDataType)].lastConsumer.(consumer.Traces)
+	assert.Panics(t, func() {
+		_ = panicking.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	})
+}
+
+func TestRecoveringReceiverHost(t *testing.T) {
+	for _, recovers := range []bool{false, true} {
+		set := recoverySettings(recovery.Config{Components: map[string]bool{"receivers.panicking": recovers}})
+		pipelines, err := Build(context.Background(), set)
+		require.NoError(t, err)
+		require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+		recv := pipelines.allReceivers[config.TracesDataType][config.NewComponentID("panicking")].(*panickingComponent)
+		_, ok := recv.host.(interface{ RecoversPanics() bool })
+		assert.Equal(t, recovers, ok)
+		assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+	}
+}
+
+func TestRecoveringComponentStatus(t *testing.T) {
+	rc := newRecoveringExporter(newPanickingComponent(), config.NewComponentID("panicking"), config.TracesDataType, zap.NewNop())
+	assert.Equal(t, [][2]string{{"Status", "healthy"}, {"Recovered panics", "0"}}, rc.status())
+	assert.True(t, rc.matches("exporter", "panicking", config.NewComponentIDWithName(config.TracesDataType, "2")))
+	assert.False(t, rc.matches("exporter", "panicking", config.NewComponentID(config.MetricsDataType)))
+	assert.False(t, rc.matches("processor", "panicking", config.NewComponentID(config.TracesDataType)))
+
+	traces := rc.wrap(config.TracesDataType).(consumer.Traces)
+	assert.Error(t, traces.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Error(t, traces.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	status := rc.status()
+	require.Len(t, status, 4)
+	assert.Equal(t, [2]string{"Status", "unhealthy"}, status[0])
+	assert.Equal(t, [2]string{"Recovered panics", "2"}, status[1])
+	assert.Equal(t, [2]string{"Last panic", "unexpected nil pointer"}, status[2])
+}
+
+func recoverySettings(cfg recovery.Config) Settings {
+	panickingReceiverFactory := component.NewReceiverFactory("panicking", func() config.Receiver {
+		settings := config.NewReceiverSettings(config.NewComponentID("panicking"))
+		return &settings
+	}, component.WithTracesReceiver(func(context.Context, component.ReceiverCreateSettings, config.Receiver, consumer.Traces) (component.TracesReceiver, error) {
+		return newPanickingComponent(), nil
+	}))
+	panickingExporterFactory := component.NewExporterFactory("panicking", func() config.Exporter {
+		settings := config.NewExporterSettings(config.NewComponentID("panicking"))
+		return &settings
+	}, component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.TracesExporter, error) {
+		return newPanickingComponent(), nil
+	}))
+	nopExporterFactory := componenttest.NewNopExporterFactory()
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverFactories: map[config.Type]component.ReceiverFactory{
+			panickingReceiverFactory.Type(): panickingReceiverFactory,
+		},
+		ReceiverConfigs: map[config.ComponentID]config.Receiver{
+			config.NewComponentID(panickingReceiverFactory.Type()): panickingReceiverFactory.CreateDefaultConfig(),
+		},
+		ExporterFactories: map[config.Type]component.ExporterFactory{
+			panickingExporterFactory.Type(): panickingExporterFactory,
+			nopExporterFactory.Type():       nopExporterFactory,
+		},
+		ExporterConfigs: map[config.ComponentID]config.Exporter{
+			config.NewComponentID(panickingExporterFactory.Type()): panickingExporterFactory.CreateDefaultConfig(),
+			config.NewComponentID(nopExporterFactory.Type()):       nopExporterFactory.CreateDefaultConfig(),
+		},
+		PipelineConfigs: map[config.ComponentID]*config.Pipeline{
+			config.NewComponentID(config.TracesDataType): {
+				Receivers: []config.ComponentID{config.NewComponentID("panicking")},
+				Exporters: []config.ComponentID{config.NewComponentID("panicking")},
+			},
+			config.NewComponentIDWithName(config.TracesDataType, "healthy"): {
+				Receivers: []config.ComponentID{config.NewComponentID("panicking")},
+				Exporters: []config.ComponentID{config.NewComponentID("nop")},
+			},
+		},
+		Recovery: cfg,
+	}
+}
+
+// panickingComponent panics in its ConsumeTraces calls, recording the host it was started with.
+type panickingComponent struct {
+	consumertest.Consumer
+	host component.Host
+}
+
+func newPanickingComponent() *panickingComponent {
+	return &panickingComponent{Consumer: consumertest.NewNop()}
+}
+
+func (pc *panickingComponent) Start(_ context.Context, host component.Host) error {
+	pc.host = host
+	return nil
+}
+
+func (pc *panickingComponent) ConsumeTraces(context.Context, ptrace.Traces) error {
+	panic("unexpected nil pointer")
+}
+
+func (pc *panickingComponent) Shutdown(context.Context) error {
+	return nil
+}
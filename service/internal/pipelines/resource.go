@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/resourcedetection"
+)
+
+// hasAttributes returns whether the resource is set and has attributes.
+func hasAttributes(res pcommon.Resource) bool {
+	return res != (pcommon.Resource{}) && res.Attributes().Len() > 0
+}
+
+// stampFirstConsumer wraps the first consumer of a pipeline with a consumer inserting the attributes
+// of res in the resources of the received data, or returns next if res has no attribute.
+func stampFirstConsumer(next baseConsumer, res pcommon.Resource) baseConsumer {
+	if !hasAttributes(res) {
+		return next
+	}
+	switch c := next.(type) {
+	case consumer.Traces:
+		return stampedTraces{Traces: c, res: res}
+	case consumer.Metrics:
+		return stampedMetrics{Metrics: c, res: res}
+	case consumer.Logs:
+		return stampedLogs{Logs: c, res: res}
+	}
+	return next
+}
+
+// stampedTraces inserts the attributes of res in the resources of the traces, the existing attributes
+// taking precedence. It mutates the traces, which the fanout of the receiver copies if shared.
+type stampedTraces struct {
+	consumer.Traces
+	res pcommon.Resource
+}
+
+func (st stampedTraces) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (st stampedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		resourcedetection.Merge(rss.At(i).Resource(), st.res)
+	}
+	return st.Traces.ConsumeTraces(ctx, td)
+}
+
+// stampedMetrics is the equivalent of stampedTraces for metrics.
+type stampedMetrics struct {
+	consumer.Metrics
+	res pcommon.Resource
+}
+
+func (sm stampedMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (sm stampedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		resourcedetection.Merge(rms.At(i).Resource(), sm.res)
+	}
+	return sm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// stampedLogs is the equivalent of stampedTraces for logs.
+type stampedLogs struct {
+	consumer.Logs
+	res pcommon.Resource
+}
+
+func (sl stampedLogs) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (sl stampedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		resourcedetection.Merge(rls.At(i).Resource(), sl.res)
+	}
+	return sl.Logs.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/testcomponents"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestBuildWithStampResource(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "pipelines_multi.yaml"), factories)
+	require.NoError(t, err)
+
+	res := pcommon.NewResource()
+	res.Attributes().InsertString("host.name", "detected-host")
+	res.Attributes().InsertString("resource-attr", "detected-value")
+
+	set := toSettings(factories, cfg)
+	set.StampResource = res
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	recvID := config.NewComponentID("examplereceiver")
+	assert.NoError(t, pipelines.allReceivers[config.TracesDataType][recvID].(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.NoError(t, pipelines.allReceivers[config.MetricsDataType][recvID].(*testcomponents.ExampleReceiver).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.NoError(t, pipelines.allReceivers[config.LogsDataType][recvID].(*testcomponents.ExampleReceiver).ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	// The detected attributes are inserted, without overriding the attributes already set.
+	want := map[string]interface{}{"host.name": "detected-host", "resource-attr": "resource-attr-val-1"}
+	for _, exp := range pipelines.GetExporters()[config.TracesDataType] {
+		traces := exp.(*testcomponents.ExampleExporter).Traces
+		require.Len(t, traces, 1)
+		assert.Equal(t, want, traces[0].ResourceSpans().At(0).Resource().Attributes().AsRaw())
+	}
+	for _, exp := range pipelines.GetExporters()[config.MetricsDataType] {
+		metrics := exp.(*testcomponents.ExampleExporter).Metrics
+		require.Len(t, metrics, 1)
+		assert.Equal(t, want, metrics[0].ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	}
+	for _, exp := range pipelines.GetExporters()[config.LogsDataType] {
+		logs := exp.(*testcomponents.ExampleExporter).Logs
+		require.Len(t, logs, 1)
+		assert.Equal(t, want, logs[0].ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	}
+}
+
+func TestStampNoResource(t *testing.T) {
+	next := &testcomponents.ExampleExporter{}
+	assert.Same(t, next, stampFirstConsumer(next, pcommon.Resource{}))
+	assert.Same(t, next, stampFirstConsumer(next, pcommon.NewResource()))
+}
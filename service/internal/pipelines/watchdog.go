@@ -0,0 +1,393 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/watchdog"
+)
+
+var (
+	errStartTimeout = errors.New("the restarted instance did not start in time")
+	errMaxRestarts  = errors.New("reached the maximum number of restarts")
+)
+
+// supervisor is the watchdog of the components of the pipelines. It periodically checks whether a consume
+// call of the supervised processors and exporters is in progress for longer than the consume timeout, and
+// restarts these wedged components.
+type supervisor struct {
+	cfg    watchdog.Config
+	comps  []*supervisedComponent
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// newSupervisor returns the supervisor of the components, or nil if the watchdog is disabled.
+func newSupervisor(cfg watchdog.Config) *supervisor {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &supervisor{cfg: cfg}
+}
+
+func (s *supervisor) superviseExporter(set Settings, id config.ComponentID, pipelineID config.ComponentID, exp component.Exporter) component.Exporter {
+	return s.supervise(exp, id, pipelineID.Type(), exporterLogger(set.Telemetry.Logger, id, pipelineID.Type()), func(ctx context.Context) (component.Component, error) {
+		return buildExporter(ctx, set.Telemetry, set.BuildInfo, set.ExporterConfigs, set.ExporterFactories, id, pipelineID)
+	})
+}
+
+func (s *supervisor) superviseProcessor(set Settings, id config.ComponentID, pipelineID config.ComponentID, next baseConsumer, proc component.Processor) component.Processor {
+	return s.supervise(proc, id, pipelineID.Type(), processorLogger(set.Telemetry.Logger, id, pipelineID), func(ctx context.Context) (component.Component, error) {
+		return buildProcessor(ctx, set.Telemetry, set.BuildInfo, set.ProcessorConfigs, set.ProcessorFactories, id, pipelineID, next)
+	})
+}
+
+func (s *supervisor) superviseReceiver(set Settings, id config.ComponentID, pipelineID config.ComponentID, nexts []baseConsumer, recv component.Receiver) component.Receiver {
+	// The receivers do not consume data, only their Start calls are supervised.
+	return s.newSupervisedComponent(recv, id, receiverLogger(set.Telemetry.Logger, id, pipelineID.Type()), func(ctx context.Context) (component.Component, error) {
+		return buildReceiver(ctx, set.Telemetry, set.BuildInfo, set.ReceiverConfigs, set.ReceiverFactories, id, pipelineID, nexts)
+	})
+}
+
+// supervise wraps the processor or exporter comp consuming data of type dt, create being called to
+// recreate it when wedged.
+func (s *supervisor) supervise(comp component.Component, id config.ComponentID, dt config.DataType, logger *zap.Logger, create func(context.Context) (component.Component, error)) component.Component {
+	sc := s.newSupervisedComponent(comp, id, logger, create)
+	switch dt {
+	case config.TracesDataType:
+		return supervisedTraces{supervisedComponent: sc, caps: comp.(consumer.Traces).Capabilities()}
+	case config.MetricsDataType:
+		return supervisedMetrics{supervisedComponent: sc, caps: comp.(consumer.Metrics).Capabilities()}
+	case config.LogsDataType:
+		return supervisedLogs{supervisedComponent: sc, caps: comp.(consumer.Logs).Capabilities()}
+	}
+	return sc
+}
+
+func (s *supervisor) newSupervisedComponent(comp component.Component, id config.ComponentID, logger *zap.Logger, create func(context.Context) (component.Component, error)) *supervisedComponent {
+	sc := &supervisedComponent{
+		id:         id,
+		cfg:        s.cfg,
+		logger:     logger,
+		create:     create,
+		current:    comp,
+		inProgress: make(map[uint64]inProgressCall),
+		backoff:    s.cfg.InitialBackoff,
+	}
+	s.comps = append(s.comps, sc)
+	return sc
+}
+
+// start starts the periodic checks of the supervised components.
+func (s *supervisor) start() {
+	if s == nil {
+		return
+	}
+	s.stopCh = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.cfg.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case now := <-ticker.C:
+				for _, sc := range s.comps {
+					sc.check(now)
+				}
+			}
+		}
+	}()
+}
+
+// stop stops the periodic checks, waiting for the restart in progress if any.
+func (s *supervisor) stop() {
+	if s == nil || s.stopCh == nil {
+		return
+	}
+	close(s.stopCh)
+	s.wg.Wait()
+	s.stopCh = nil
+}
+
+// inProgressCall is a consume call of the instance of the given generation started at start.
+type inProgressCall struct {
+	generation uint64
+	start      time.Time
+}
+
+// supervisedComponent forwards the calls to the current instance of a component, which is replaced by a
+// new instance created by create when wedged.
+type supervisedComponent struct {
+	id     config.ComponentID
+	cfg    watchdog.Config
+	logger *zap.Logger
+	create func(context.Context) (component.Component, error)
+
+	mu          sync.Mutex
+	current     component.Component
+	host        component.Host
+	generation  uint64
+	lastCall    uint64
+	inProgress  map[uint64]inProgressCall
+	restarts    int
+	backoff     time.Duration
+	nextRestart time.Time
+	gaveUp      bool
+}
+
+// unwrap returns the current instance of the component.
+func (sc *supervisedComponent) unwrap() component.Component {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.current
+}
+
+// acquire records a consume call, returning the current instance and the key releasing the call.
+func (sc *supervisedComponent) acquire() (component.Component, uint64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.lastCall++
+	sc.inProgress[sc.lastCall] = inProgressCall{generation: sc.generation, start: time.Now()}
+	return sc.current, sc.lastCall
+}
+
+func (sc *supervisedComponent) release(key uint64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	delete(sc.inProgress, key)
+}
+
+// Start starts the component, restarting new instances while the Start calls do not return in time.
+func (sc *supervisedComponent) Start(ctx context.Context, host component.Host) error {
+	sc.mu.Lock()
+	sc.host = host
+	comp := sc.current
+	sc.mu.Unlock()
+
+	done, err := callWithTimeout(func() error { return comp.Start(ctx, host) }, sc.cfg.StartTimeout)
+	if done {
+		return err
+	}
+	for {
+		sc.logger.Warn("Component did not start in time, restarting it", zap.Duration("start_timeout", sc.cfg.StartTimeout))
+		if err = sc.waitBackoff(ctx); err != nil {
+			return err
+		}
+		if err = sc.restart(ctx, comp); !errors.Is(err, errStartTimeout) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("component %q did not start within %v: %w", sc.id, sc.cfg.StartTimeout, err)
+	}
+	return nil
+}
+
+// Shutdown shuts down the current instance, abandoning the call if it does not return in time.
+func (sc *supervisedComponent) Shutdown(ctx context.Context) error {
+	comp := sc.unwrap()
+	done, err := callWithTimeout(func() error { return comp.Shutdown(ctx) }, sc.cfg.ShutdownTimeout)
+	if !done {
+		return fmt.Errorf("component %q did not shut down within %v", sc.id, sc.cfg.ShutdownTimeout)
+	}
+	return err
+}
+
+// check restarts the component if a consume call of its current instance is in progress since longer
+// than the consume timeout, and the backoff since the previous restart elapsed.
+func (sc *supervisedComponent) check(now time.Time) {
+	sc.mu.Lock()
+	wedged := false
+	for _, call := range sc.inProgress {
+		if call.generation == sc.generation && now.Sub(call.start) >= sc.cfg.ConsumeTimeout {
+			wedged = true
+			break
+		}
+	}
+	if !wedged || sc.gaveUp || now.Before(sc.nextRestart) {
+		sc.mu.Unlock()
+		return
+	}
+	comp := sc.current
+	sc.mu.Unlock()
+
+	sc.logger.Warn("Component is wedged, restarting it", zap.Duration("consume_timeout", sc.cfg.ConsumeTimeout))
+	err := sc.restart(context.Background(), comp)
+	switch {
+	case errors.Is(err, errMaxRestarts):
+		sc.mu.Lock()
+		sc.gaveUp = true
+		sc.mu.Unlock()
+		sc.logger.Error("Component is wedged and reached the maximum number of restarts, leaving it as is",
+			zap.Int("max_restarts", sc.cfg.MaxRestarts))
+	case err != nil:
+		sc.logger.Error("Failed to restart wedged component", zap.Error(err))
+	}
+}
+
+// waitBackoff waits for the backoff since the previous restart to elapse.
+func (sc *supervisedComponent) waitBackoff(ctx context.Context) error {
+	sc.mu.Lock()
+	wait := time.Until(sc.nextRestart)
+	sc.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// restart creates and starts a new instance replacing the wedged one, which is then shut down in the
+// background. The wedged instance stays the current one if the new instance fails to start.
+func (sc *supervisedComponent) restart(ctx context.Context, wedged component.Component) error {
+	sc.mu.Lock()
+	if sc.restarts >= sc.cfg.MaxRestarts {
+		sc.mu.Unlock()
+		return errMaxRestarts
+	}
+	sc.restarts++
+	restart := sc.restarts
+	sc.nextRestart = time.Now().Add(sc.backoff)
+	sc.backoff *= 2
+	if sc.backoff > sc.cfg.MaxBackoff {
+		sc.backoff = sc.cfg.MaxBackoff
+	}
+	host := sc.host
+	sc.mu.Unlock()
+
+	comp, err := sc.create(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to recreate the component: %w", err)
+	}
+	done, err := callWithTimeout(func() error { return comp.Start(ctx, host) }, sc.cfg.StartTimeout)
+	if !done {
+		go sc.abandon(comp)
+		return errStartTimeout
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start the restarted instance: %w", err)
+	}
+
+	sc.mu.Lock()
+	sc.current = comp
+	sc.generation++
+	sc.mu.Unlock()
+	sc.logger.Info("Component restarted", zap.Int("restart", restart))
+	go sc.abandon(wedged)
+	return nil
+}
+
+// abandon shuts down a wedged instance, abandoning the call if it does not return in time.
+func (sc *supervisedComponent) abandon(comp component.Component) {
+	done, err := callWithTimeout(func() error { return comp.Shutdown(context.Background()) }, sc.cfg.ShutdownTimeout)
+	switch {
+	case !done:
+		sc.logger.Warn("Wedged instance did not shut down in time", zap.Duration("shutdown_timeout", sc.cfg.ShutdownTimeout))
+	case err != nil:
+		sc.logger.Warn("Failed to shut down wedged instance", zap.Error(err))
+	}
+}
+
+// callWithTimeout calls f, returning whether it returned within timeout and its error if so.
+// The call keeps running in the background once timed out.
+func callWithTimeout(f func() error, timeout time.Duration) (bool, error) {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- f()
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-errCh:
+		return true, err
+	case <-timer.C:
+		return false, nil
+	}
+}
+
+// unwrapSupervised returns the current instance of comp if supervised, or comp otherwise.
+func unwrapSupervised(comp component.Component) component.Component {
+	if sc, ok := comp.(interface{ unwrap() component.Component }); ok {
+		return sc.unwrap()
+	}
+	return comp
+}
+
+type supervisedTraces struct {
+	*supervisedComponent
+	caps consumer.Capabilities
+}
+
+func (st supervisedTraces) Capabilities() consumer.Capabilities {
+	return st.caps
+}
+
+func (st supervisedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	comp, key := st.acquire()
+	defer st.release(key)
+	return comp.(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+type supervisedMetrics struct {
+	*supervisedComponent
+	caps consumer.Capabilities
+}
+
+func (sm supervisedMetrics) Capabilities() consumer.Capabilities {
+	return sm.caps
+}
+
+func (sm supervisedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	comp, key := sm.acquire()
+	defer sm.release(key)
+	return comp.(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+type supervisedLogs struct {
+	*supervisedComponent
+	caps consumer.Capabilities
+}
+
+func (sl supervisedLogs) Capabilities() consumer.Capabilities {
+	return sl.caps
+}
+
+func (sl supervisedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	comp, key := sl.acquire()
+	defer sl.release(key)
+	return comp.(consumer.Logs).ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/watchdog"
+)
+
+func TestWatchdogRestartsWedgedExporter(t *testing.T) {
+	we := newWedgedExporters(wedge{consume: true})
+	defer close(we.unblock)
+	pipelines, err := Build(context.Background(), watchdogSettings(we, 1))
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	first := pipelines.pipelines[config.NewComponentID(config.TracesDataType)].lastConsumer.(consumer.Traces)
+	go func() {
+		_ = first.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	}()
+	expID := config.NewComponentID("wedged")
+	require.Eventually(t, func() bool {
+		return pipelines.GetExporters()[config.TracesDataType][expID] != we.get(0)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, we.count())
+
+	// The data is now consumed by the new instance, which replaces the wedged one.
+	assert.NoError(t, first.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	restarted := we.get(1)
+	assert.Equal(t, 1, restarted.consumed())
+	assert.Same(t, restarted, pipelines.GetExporters()[config.TracesDataType][expID])
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+}
+
+func TestWatchdogMaxRestarts(t *testing.T) {
+	we := newWedgedExporters(wedge{consume: true})
+	defer close(we.unblock)
+	pipelines, err := Build(context.Background(), watchdogSettings(we, 0))
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	first := pipelines.pipelines[config.NewComponentID(config.TracesDataType)].lastConsumer.(consumer.Traces)
+	go func() {
+		_ = first.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	}()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, we.count())
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+}
+
+func TestWatchdogStartTimeout(t *testing.T) {
+	we := newWedgedExporters(wedge{start: true})
+	defer close(we.unblock)
+	pipelines, err := Build(context.Background(), watchdogSettings(we, 1))
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, 2, we.count())
+	assert.True(t, we.get(1).started)
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	we = newWedgedExporters(wedge{start: true})
+	defer close(we.unblock)
+	pipelines, err = Build(context.Background(), watchdogSettings(we, 0))
+	require.NoError(t, err)
+	assert.EqualError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()),
+		`component "wedged" did not start within 50ms: reached the maximum number of restarts`)
+}
+
+func TestWatchdogShutdownTimeout(t *testing.T) {
+	we := newWedgedExporters(wedge{shutdown: true})
+	defer close(we.unblock)
+	pipelines, err := Build(context.Background(), watchdogSettings(we, 1))
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	assert.EqualError(t, pipelines.ShutdownAll(context.Background()), `component "wedged" did not shut down within 50ms`)
+}
+
+func TestWatchdogDisabled(t *testing.T) {
+	we := newWedgedExporters(wedge{})
+	set := watchdogSettings(we, 1)
+	set.Watchdog.Enabled = false
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	assert.Nil(t, pipelines.supervisor)
+	assert.Same(t, we.get(0), pipelines.GetExporters()[config.TracesDataType][config.NewComponentID("wedged")])
+}
+
+func watchdogSettings(we *wedgedExporters, maxRestarts int) Settings {
+	nopReceiverFactory := componenttest.NewNopReceiverFactory()
+	wedgedExporterFactory := we.factory()
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverFactories: map[config.Type]component.ReceiverFactory{
+			nopReceiverFactory.Type(): nopReceiverFactory,
+		},
+		ReceiverConfigs: map[config.ComponentID]config.Receiver{
+			config.NewComponentID(nopReceiverFactory.Type()): nopReceiverFactory.CreateDefaultConfig(),
+		},
+		ExporterFactories: map[config.Type]component.ExporterFactory{
+			wedgedExporterFactory.Type(): wedgedExporterFactory,
+		},
+		ExporterConfigs: map[config.ComponentID]config.Exporter{
+			config.NewComponentID(wedgedExporterFactory.Type()): wedgedExporterFactory.CreateDefaultConfig(),
+		},
+		PipelineConfigs: map[config.ComponentID]*config.Pipeline{
+			config.NewComponentID(config.TracesDataType): {
+				Receivers: []config.ComponentID{config.NewComponentID("nop")},
+				Exporters: []config.ComponentID{config.NewComponentID("wedged")},
+			},
+		},
+		Watchdog: watchdog.Config{
+			Enabled:         true,
+			CheckInterval:   10 * time.Millisecond,
+			ConsumeTimeout:  50 * time.Millisecond,
+			StartTimeout:    50 * time.Millisecond,
+			ShutdownTimeout: 50 * time.Millisecond,
+			MaxRestarts:     maxRestarts,
+		},
+	}
+}
+
+// wedgedExporters creates the instances of the "wedged" exporter, the first instance being wedged as
+// configured until unblock is closed.
+type wedgedExporters struct {
+	mu      sync.Mutex
+	wedged  wedge
+	created []*wedgedComponent
+	unblock chan struct{}
+}
+
+func newWedgedExporters(wedged wedge) *wedgedExporters {
+	return &wedgedExporters{wedged: wedged, unblock: make(chan struct{})}
+}
+
+func (we *wedgedExporters) factory() component.ExporterFactory {
+	return component.NewExporterFactory("wedged", func() config.Exporter {
+		return &struct {
+			config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentID("wedged")),
+		}
+	},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.TracesExporter, error) {
+			we.mu.Lock()
+			defer we.mu.Unlock()
+			comp := &wedgedComponent{Consumer: consumertest.NewNop(), unblock: we.unblock}
+			if len(we.created) == 0 {
+				comp.wedge = we.wedged
+			}
+			we.created = append(we.created, comp)
+			return comp, nil
+		}),
+	)
+}
+
+func (we *wedgedExporters) count() int {
+	we.mu.Lock()
+	defer we.mu.Unlock()
+	return len(we.created)
+}
+
+func (we *wedgedExporters) get(i int) *wedgedComponent {
+	we.mu.Lock()
+	defer we.mu.Unlock()
+	return we.created[i]
+}
+
+// wedge defines which calls of a wedgedComponent block.
+type wedge struct {
+	start    bool
+	consume  bool
+	shutdown bool
+}
+
+// wedgedComponent blocks its Start, ConsumeTraces or Shutdown calls until unblock is closed.
+type wedgedComponent struct {
+	consumertest.Consumer
+	wedge   wedge
+	unblock chan struct{}
+
+	mu        sync.Mutex
+	started   bool
+	consumedN int
+}
+
+func (wc *wedgedComponent) Start(context.Context, component.Host) error {
+	if wc.wedge.start {
+		<-wc.unblock
+	}
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	wc.started = true
+	return nil
+}
+
+func (wc *wedgedComponent) ConsumeTraces(context.Context, ptrace.Traces) error {
+	if wc.wedge.consume {
+		<-wc.unblock
+	}
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	wc.consumedN++
+	return nil
+}
+
+func (wc *wedgedComponent) Shutdown(context.Context) error {
+	if wc.wedge.shutdown {
+		<-wc.unblock
+	}
+	return nil
+}
+
+func (wc *wedgedComponent) consumed() int {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	return wc.consumedN
+}
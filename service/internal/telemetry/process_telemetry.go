@@ -37,6 +37,9 @@ type processMetrics struct {
 	sysMem        *metric.Int64DerivedGauge
 	cpuSeconds    *metric.Float64DerivedCumulative
 	rssMemory     *metric.Int64DerivedGauge
+	openFDs       *metric.Int64DerivedGauge
+	gcPause       *metric.Float64DerivedCumulative
+	goroutines    *metric.Int64DerivedGauge
 
 	// mu protects everything bellow.
 	mu         sync.Mutex
@@ -124,6 +127,39 @@ func RegisterProcessMetrics(registry *metric.Registry, ballastSizeBytes uint64)
 		return err
 	}
 
+	pm.openFDs, err = registry.AddInt64DerivedGauge(
+		"process/open_fds",
+		metric.WithDescription("Number of open file descriptors, zero if unsupported by the OS"),
+		metric.WithUnit(stats.UnitDimensionless))
+	if err != nil {
+		return err
+	}
+	if err = pm.openFDs.UpsertEntry(pm.updateOpenFDs); err != nil {
+		return err
+	}
+
+	pm.gcPause, err = registry.AddFloat64DerivedCumulative(
+		"process/runtime/gc_pause_total_seconds",
+		metric.WithDescription("Cumulative time the garbage collector stopped the world (see 'go doc runtime.MemStats.PauseTotalNs')"),
+		metric.WithUnit(stats.UnitSeconds))
+	if err != nil {
+		return err
+	}
+	if err = pm.gcPause.UpsertEntry(pm.updateGCPause); err != nil {
+		return err
+	}
+
+	pm.goroutines, err = registry.AddInt64DerivedGauge(
+		"process/runtime/goroutines",
+		metric.WithDescription("Number of goroutines that currently exist (see 'go doc runtime.NumGoroutine')"),
+		metric.WithUnit(stats.UnitDimensionless))
+	if err != nil {
+		return err
+	}
+	if err = pm.goroutines.UpsertEntry(pm.updateGoroutines); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -170,6 +206,25 @@ func (pm *processMetrics) updateRSSMemory() int64 {
 	return int64(mem.RSS)
 }
 
+func (pm *processMetrics) updateOpenFDs() int64 {
+	fds, err := pm.proc.NumFDs()
+	if err != nil {
+		return 0
+	}
+	return int64(fds)
+}
+
+func (pm *processMetrics) updateGCPause() float64 {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.readMemStatsIfNeeded()
+	return float64(pm.ms.PauseTotalNs) / 1e9
+}
+
+func (pm *processMetrics) updateGoroutines() int64 {
+	return int64(runtime.NumGoroutine())
+}
+
 func (pm *processMetrics) readMemStatsIfNeeded() {
 	now := time.Now()
 	// If last time we read was less than one second ago just reuse the values
@@ -34,6 +34,24 @@ var expectedMetrics = []string{
 	"process/runtime/total_sys_memory_bytes",
 	"process/cpu_seconds",
 	"process/memory/rss",
+	"process/open_fds",
+	"process/runtime/gc_pause_total_seconds",
+	"process/runtime/goroutines",
+}
+
+// cumulativeMetrics are the float64 metrics.
+var cumulativeMetrics = map[string]bool{
+	"process/uptime":                         true,
+	"process/cpu_seconds":                    true,
+	"process/runtime/gc_pause_total_seconds": true,
+}
+
+// zeroableMetrics may still be zero when running the test, or be unsupported by the OS.
+var zeroableMetrics = map[string]bool{
+	"process/uptime":                         true,
+	"process/cpu_seconds":                    true,
+	"process/runtime/gc_pause_total_seconds": true,
+	"process/open_fds":                       true,
 }
 
 func TestProcessTelemetry(t *testing.T) {
@@ -54,14 +72,13 @@ func TestProcessTelemetry(t *testing.T) {
 		require.Len(t, ts.Points, 1)
 
 		var value float64
-		if metricName == "process/uptime" || metricName == "process/cpu_seconds" {
+		if cumulativeMetrics[metricName] {
 			value = ts.Points[0].Value.(float64)
 		} else {
 			value = float64(ts.Points[0].Value.(int64))
 		}
 
-		if metricName == "process/uptime" || metricName == "process/cpu_seconds" {
-			// This likely will still be zero when running the test.
+		if zeroableMetrics[metricName] {
 			assert.True(t, value >= 0, metricName)
 			continue
 		}
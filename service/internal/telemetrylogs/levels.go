@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetrylogs // import "go.opentelemetry.io/collector/service/internal/telemetrylogs"
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// Levels are the minimum enabled levels of the collector logs: the level of the logs of the
+// collector and of the components without their own level, and the levels of the components
+// overriding it, keyed by the section of the component in the configuration and its ID,
+// e.g. "exporters.otlphttp". The levels of the components can be changed at runtime.
+type Levels struct {
+	level zapcore.Level
+
+	mu         sync.RWMutex
+	components map[string]zapcore.Level
+}
+
+// NewLevels returns the Levels configured by the LogsConfig.
+func NewLevels(cfg telemetry.LogsConfig) *Levels {
+	l := &Levels{
+		level:      cfg.Level,
+		components: make(map[string]zapcore.Level, len(cfg.Components)),
+	}
+	for key, lvl := range cfg.Components {
+		l.components[key] = lvl
+	}
+	return l
+}
+
+// Level returns the level of the logs of the components without their own level.
+func (l *Levels) Level() zapcore.Level {
+	return l.level
+}
+
+// Components returns a copy of the levels of the components overriding Level.
+func (l *Levels) Components() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	ret := make(map[string]zapcore.Level, len(l.components))
+	for key, lvl := range l.components {
+		ret[key] = lvl
+	}
+	return ret
+}
+
+// SetComponent sets the level of the logs of the component, or, if nil, removes it
+// for the logs of the component to use Level.
+func (l *Levels) SetComponent(key string, lvl *zapcore.Level) error {
+	cfg := telemetry.LogsConfig{Components: map[string]zapcore.Level{key: 0}}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lvl == nil {
+		delete(l.components, key)
+		return nil
+	}
+	l.components[key] = *lvl
+	return nil
+}
+
+func (l *Levels) enabled(key string, lvl zapcore.Level) bool {
+	if key != "" {
+		l.mu.RLock()
+		componentLvl, ok := l.components[key]
+		l.mu.RUnlock()
+		if ok {
+			return componentLvl.Enabled(lvl)
+		}
+	}
+	return l.level.Enabled(lvl)
+}
+
+type levelsResponse struct {
+	Level      zapcore.Level            `json:"level"`
+	Components map[string]zapcore.Level `json:"components"`
+}
+
+type levelRequest struct {
+	Component string         `json:"component"`
+	Level     *zapcore.Level `json:"level"`
+}
+
+// HandleHTTP returns the Levels as a JSON document, and, for the PUT requests, changes the level
+// of the component of the {"component": "exporters.otlphttp", "level": "debug"} JSON document
+// before. A null or missing level removes the level of the component.
+func (l *Levels) HandleHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req levelRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := l.SetComponent(req.Component, req.Level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(levelsResponse{Level: l.Level(), Components: l.Components()})
+}
+
+// levelCore filters the logs of the core with the Levels of the component identified by the
+// fields added with With, or with the Level if it is not identified.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+	key    string
+}
+
+func newLevelCore(core zapcore.Core, levels *Levels) zapcore.Core {
+	return &levelCore{Core: core, levels: levels}
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.levels.enabled(c.key, lvl)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	key := c.key
+	var kind, name string
+	for _, f := range fields {
+		if f.Type != zapcore.StringType {
+			continue
+		}
+		switch f.Key {
+		case components.ZapKindKey:
+			kind = f.String
+		case components.ZapNameKey:
+			name = f.String
+		}
+	}
+	if kind != "" && name != "" {
+		key = components.LogLevelKey(kind, name)
+	}
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels, key: key}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetrylogs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/service/internal/components"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func newObservedLogger(t *testing.T, cfg telemetry.LogsConfig) (*zap.Logger, *Levels, *observer.ObservedLogs) {
+	levels := NewLevels(cfg)
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger, err := NewLogger(cfg, levels, []zap.Option{zap.WrapCore(func(zapcore.Core) zapcore.Core { return core })})
+	require.NoError(t, err)
+	return logger, levels, logs
+}
+
+func exporterLogger(logger *zap.Logger, name string) *zap.Logger {
+	return logger.With(
+		zap.String(components.ZapKindKey, components.ZapKindExporter),
+		zap.String(components.ZapNameKey, name))
+}
+
+func TestLevelsComponents(t *testing.T) {
+	logger, levels, logs := newObservedLogger(t, telemetry.LogsConfig{
+		Level:    zapcore.InfoLevel,
+		Encoding: "json",
+		Components: map[string]zapcore.Level{
+			"exporters.otlphttp": zapcore.DebugLevel,
+			"exporters.otlp":     zapcore.ErrorLevel,
+		},
+	})
+
+	exporterLogger(logger, "otlphttp").With(zap.String("data_type", "traces")).Debug("otlphttp debug")
+	exporterLogger(logger, "otlp").Info("otlp info")
+	exporterLogger(logger, "otlp").Error("otlp error")
+	exporterLogger(logger, "otlp/2").Debug("otlp/2 debug")
+	exporterLogger(logger, "otlp/2").Info("otlp/2 info")
+	logger.Debug("collector debug")
+	logger.Info("collector info")
+
+	var messages []string
+	for _, entry := range logs.TakeAll() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"otlphttp debug", "otlp error", "otlp/2 info", "collector info"}, messages)
+	assert.Equal(t, zapcore.InfoLevel, levels.Level())
+}
+
+func TestLevelsSetComponent(t *testing.T) {
+	logger, levels, logs := newObservedLogger(t, telemetry.LogsConfig{Level: zapcore.InfoLevel, Encoding: "json"})
+	otlpLogger := exporterLogger(logger, "otlp")
+
+	otlpLogger.Debug("before")
+	debug := zapcore.DebugLevel
+	require.NoError(t, levels.SetComponent("exporters.otlp", &debug))
+	otlpLogger.Debug("set")
+	assert.Equal(t, map[string]zapcore.Level{"exporters.otlp": zapcore.DebugLevel}, levels.Components())
+	require.NoError(t, levels.SetComponent("exporters.otlp", nil))
+	otlpLogger.Debug("unset")
+	assert.Empty(t, levels.Components())
+
+	require.Len(t, logs.All(), 1)
+	assert.Equal(t, "set", logs.All()[0].Message)
+
+	assert.Error(t, levels.SetComponent("otlp", &debug))
+	assert.Error(t, levels.SetComponent("exporters.", &debug))
+}
+
+func TestLevelsHandleHTTP(t *testing.T) {
+	levels := NewLevels(telemetry.LogsConfig{
+		Level:      zapcore.WarnLevel,
+		Components: map[string]zapcore.Level{"receivers.otlp": zapcore.InfoLevel},
+	})
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "get",
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"level":"warn","components":{"receivers.otlp":"info"}}`,
+		},
+		{
+			name:         "set",
+			method:       http.MethodPut,
+			body:         `{"component":"exporters.otlp/2","level":"debug"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"level":"warn","components":{"exporters.otlp/2":"debug","receivers.otlp":"info"}}`,
+		},
+		{
+			name:         "unset",
+			method:       http.MethodPut,
+			body:         `{"component":"receivers.otlp"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"level":"warn","components":{"exporters.otlp/2":"debug"}}`,
+		},
+		{
+			name:         "invalid_component",
+			method:       http.MethodPut,
+			body:         `{"component":"otlp","level":"debug"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid_level",
+			method:       http.MethodPut,
+			body:         `{"component":"exporters.otlp","level":"verbose"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "method_not_allowed",
+			method:       http.MethodDelete,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			levels.HandleHTTP(rec, httptest.NewRequest(tt.method, "/loglevels", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -23,10 +23,12 @@ import (
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
-func NewLogger(cfg telemetry.LogsConfig, options []zap.Option) (*zap.Logger, error) {
+// NewLogger returns the logger of the collector, whose logs are filtered with the levels.
+func NewLogger(cfg telemetry.LogsConfig, levels *Levels, options []zap.Option) (*zap.Logger, error) {
 	// Copied from NewProductionConfig.
 	zapCfg := &zap.Config{
-		Level:       zap.NewAtomicLevelAt(cfg.Level),
+		// The logs are filtered by the levels, which can be lowered at runtime.
+		Level:       zap.NewAtomicLevelAt(zapcore.DebugLevel),
 		Development: cfg.Development,
 		Sampling: &zap.SamplingConfig{
 			Initial:    100,
@@ -46,6 +48,10 @@ func NewLogger(cfg telemetry.LogsConfig, options []zap.Option) (*zap.Logger, err
 		zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	}
 
+	// The levels are applied last, to filter the logs of the cores added by the options.
+	options = append(options[:len(options):len(options)], zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return newLevelCore(core, levels)
+	}))
 	logger, err := zapCfg.Build(options...)
 	if err != nil {
 		return nil, err
@@ -74,7 +74,7 @@ func TestGRPCLogger(t *testing.T) {
 			})
 
 			// create new collector zap logger
-			logger, err := NewLogger(test.cfg, []zap.Option{hook})
+			logger, err := NewLogger(test.cfg, NewLevels(test.cfg), []zap.Option{hook})
 			assert.NoError(t, err)
 
 			// create colGRPCLogger
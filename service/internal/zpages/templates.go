@@ -55,6 +55,10 @@ var (
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
+
+	//go:embed templates/lifecycle_events_table.html
+	lifecycleEventsTableBytes    []byte
+	lifecycleEventsTableTemplate = parseTemplate("lifecycle_events_table", lifecycleEventsTableBytes)
 )
 
 func parseTemplate(name string, bytes []byte) *template.Template {
@@ -169,7 +173,9 @@ type FeatureGateTableData struct {
 // FeatureGateTableRowData contains data for one row in feature gate table template.
 type FeatureGateTableRowData struct {
 	ID          string
+	Stage       string
 	Enabled     bool
+	Source      string
 	Description string
 }
 
@@ -179,3 +185,25 @@ func WriteHTMLFeaturesTable(w io.Writer, ftd FeatureGateTableData) {
 		log.Printf("zpages: executing template: %v", err)
 	}
 }
+
+// LifecycleEventsTableData contains data for the lifecycle events table template.
+type LifecycleEventsTableData struct {
+	Rows []LifecycleEventsTableRowData
+}
+
+// LifecycleEventsTableRowData contains data for one row in the lifecycle events table template.
+type LifecycleEventsTableRowData struct {
+	Start      string
+	Component  string
+	Phase      string
+	Duration   string
+	InProgress bool
+	Error      string
+}
+
+// WriteHTMLLifecycleEventsTable writes a table of the build, start and shutdown events of the components.
+func WriteHTMLLifecycleEventsTable(w io.Writer, ltd LifecycleEventsTableData) {
+	if err := lifecycleEventsTableTemplate.Execute(w, ltd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
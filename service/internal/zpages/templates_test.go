@@ -84,11 +84,31 @@ func TestNoCrash(t *testing.T) {
 		WriteHTMLFeaturesTable(buf, FeatureGateTableData{Rows: []FeatureGateTableRowData{
 			{
 				ID:          "test",
+				Stage:       "alpha",
 				Enabled:     false,
+				Source:      "default",
 				Description: "test gate",
 			},
 		}})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLLifecycleEventsTable(buf, LifecycleEventsTableData{Rows: []LifecycleEventsTableRowData{
+			{
+				Start:     "2020-03-24 01:13:23",
+				Component: "exporter otlp",
+				Phase:     "start",
+				Duration:  "1s",
+				Error:     "test error",
+			},
+			{
+				Start:      "2020-03-24 01:13:24",
+				Component:  "receiver otlp",
+				Phase:      "shutdown",
+				Duration:   "1m0s",
+				InProgress: true,
+			},
+		}})
+	})
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 }
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recovery defines the configuration of the recovery from the panics of the components
+// of the pipelines.
+package recovery // import "go.opentelemetry.io/collector/service/recovery"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config defines the configurable settings of the recovery from the panics of the components. The panics
+// of the recovering processors and exporters in their consume calls, and of the scrapers of the recovering
+// receivers, are logged, counted and returned as permanent errors instead of crashing the Collector, the
+// component being reported unhealthy while the other pipelines keep running.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type Config struct {
+	// Enabled enables the recovery from the panics of all the components, unless overridden in Components.
+	// (default = false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Components overrides Enabled for the components, keyed by the section of the component in
+	// the configuration and its ID.
+	// Example:
+	//
+	//   components:
+	//     exporters.otlphttp: true
+	//     processors.transform/unsafe: false
+	Components map[string]bool `mapstructure:"components"`
+}
+
+// NewDefaultConfig returns the default recovery Config, the panics crashing the Collector.
+func NewDefaultConfig() Config {
+	return Config{}
+}
+
+// Validate checks the recovery Config is valid.
+func (cfg *Config) Validate() error {
+	for key := range cfg.Components {
+		items := strings.SplitN(key, ".", 2)
+		if len(items) != 2 {
+			return fmt.Errorf("recovery component %q must be <section>.<id>, e.g. \"exporters.otlp\"", key)
+		}
+		switch items[0] {
+		case "receivers", "processors", "exporters":
+		default:
+			return fmt.Errorf("recovery component %q has an unknown section %q", key, items[0])
+		}
+		for _, item := range strings.SplitN(items[1], "/", 2) {
+			if strings.TrimSpace(item) == "" {
+				return fmt.Errorf("recovery component %q has an invalid id %q", key, items[1])
+			}
+		}
+	}
+	return nil
+}
+
+// Recovers returns whether the panics of the component with the key, made of its section and ID,
+// e.g. "exporters.otlphttp", are recovered.
+func (cfg *Config) Recovers(key string) bool {
+	if recovers, ok := cfg.Components[key]; ok {
+		return recovers
+	}
+	return cfg.Enabled
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		components map[string]bool
+		wantErr    string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:       "components",
+			components: map[string]bool{"exporters.otlphttp": true, "processors.batch/2": false, "receivers.hostmetrics": true},
+		},
+		{
+			name:       "no_section",
+			components: map[string]bool{"otlphttp": true},
+			wantErr:    `recovery component "otlphttp" must be <section>.<id>, e.g. "exporters.otlp"`,
+		},
+		{
+			name:       "extension",
+			components: map[string]bool{"extensions.zpages": true},
+			wantErr:    `recovery component "extensions.zpages" has an unknown section "extensions"`,
+		},
+		{
+			name:       "invalid_id",
+			components: map[string]bool{"exporters.otlp/": true},
+			wantErr:    `recovery component "exporters.otlp/" has an invalid id "otlp/"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.Components = tt.components
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigRecovers(t *testing.T) {
+	cfg := Config{Components: map[string]bool{"exporters.otlphttp": true, "processors.batch": false}}
+	assert.True(t, cfg.Recovers("exporters.otlphttp"))
+	assert.False(t, cfg.Recovers("processors.batch"))
+	assert.False(t, cfg.Recovers("exporters.otlp"))
+
+	cfg.Enabled = true
+	assert.True(t, cfg.Recovers("exporters.otlphttp"))
+	assert.False(t, cfg.Recovers("processors.batch"))
+	assert.True(t, cfg.Recovers("exporters.otlp"))
+}
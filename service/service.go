@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric/nonrecording"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/multierr"
@@ -26,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/internal"
 	"go.opentelemetry.io/collector/service/internal/extensions"
+	"go.opentelemetry.io/collector/service/internal/lifecycle"
 	"go.opentelemetry.io/collector/service/internal/pipelines"
 	"go.opentelemetry.io/collector/service/internal/telemetry"
 	"go.opentelemetry.io/collector/service/internal/telemetrylogs"
@@ -62,14 +64,26 @@ func newService(set *settings) (*service, error) {
 	}
 
 	var err error
-	if srv.telemetrySettings.Logger, err = telemetrylogs.NewLogger(set.Config.Service.Telemetry.Logs, set.LoggingOptions); err != nil {
+	srv.host.logLevels = telemetrylogs.NewLevels(set.Config.Service.Telemetry.Logs)
+	if srv.telemetrySettings.Logger, err = telemetrylogs.NewLogger(set.Config.Service.Telemetry.Logs, srv.host.logLevels, set.LoggingOptions); err != nil {
 		return nil, fmt.Errorf("failed to get logger: %w", err)
 	}
 
-	if err = srv.telemetryInitializer.init(set.BuildInfo, srv.telemetrySettings.Logger, set.Config.Service.Telemetry, set.AsyncErrorChannel); err != nil {
+	// The environment is detected first since the detected attributes are added to the own telemetry.
+	detected := detectResource(set.Config.Service.Telemetry.ResourceDetection, srv.telemetrySettings.Logger)
+
+	if err = srv.telemetryInitializer.init(set.BuildInfo, srv.telemetrySettings.Logger, set.Config.Service.Telemetry, detected, set.AsyncErrorChannel); err != nil {
 		return nil, fmt.Errorf("failed to initialize telemetry: %w", err)
 	}
 	srv.telemetrySettings.MeterProvider = srv.telemetryInitializer.mp
+	srv.host.lifecycle = lifecycle.NewRecorder(srv.telemetrySettings.Logger)
+
+	// The propagator is set globally since the components read it from otel.GetTextMapPropagator when
+	// they create their clients and servers, the exporters then inject the context of their spans
+	// in the outgoing requests.
+	if len(set.Config.Service.Telemetry.Traces.Propagators) > 0 {
+		otel.SetTextMapPropagator(newTextMapPropagator(set.Config.Service.Telemetry.Traces))
+	}
 
 	extensionsSettings := extensions.Settings{
 		Telemetry:         srv.telemetrySettings,
@@ -77,6 +91,7 @@ func newService(set *settings) (*service, error) {
 		Configs:           srv.config.Extensions,
 		Factories:         srv.host.factories.Extensions,
 		ServiceExtensions: srv.config.Service.Extensions,
+		Lifecycle:         srv.host.lifecycle,
 	}
 	if srv.host.extensions, err = extensions.Build(context.Background(), extensionsSettings); err != nil {
 		return nil, fmt.Errorf("failed build extensions: %w", err)
@@ -92,6 +107,13 @@ func newService(set *settings) (*service, error) {
 		ExporterFactories:  srv.host.factories.Exporters,
 		ExporterConfigs:    srv.config.Exporters,
 		PipelineConfigs:    srv.config.Service.Pipelines,
+		DataObservers:      srv.host.extensions.GetDataObservers(),
+		Watchdog:           srv.config.Service.Watchdog,
+		Recovery:           srv.config.Service.Recovery,
+		Lifecycle:          srv.host.lifecycle,
+	}
+	if set.Config.Service.Telemetry.ResourceDetection.StampPipelines {
+		pipelinesSettings.StampResource = detected
 	}
 	if srv.host.pipelines, err = pipelines.Build(context.Background(), pipelinesSettings); err != nil {
 		return nil, fmt.Errorf("cannot build pipelines: %w", err)
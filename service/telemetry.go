@@ -15,20 +15,27 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/google/uuid"
+	promclient "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	ocmetric "go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricproducer"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/nonrecording"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/export/aggregation"
@@ -39,9 +46,12 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/resourcedetection"
 	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/service/featuregate"
+	"go.opentelemetry.io/collector/service/telemetry"
 )
 
 // collectorTelemetry is collector's own telemetrySettings.
@@ -54,6 +64,8 @@ const (
 	// useOtelForInternalMetricsfeatureGateID is the feature gate ID that controls whether the collector uses open
 	// telemetrySettings for internal metrics.
 	useOtelForInternalMetricsfeatureGateID = "telemetry.useOtelForInternalMetrics"
+
+	defaultResourceDetectionTimeout = 5 * time.Second
 )
 
 type telemetryInitializer struct {
@@ -73,6 +85,7 @@ func newColTelemetry(registry *featuregate.Registry) *telemetryInitializer {
 		ID:          useOtelForInternalMetricsfeatureGateID,
 		Description: "controls whether the collector to uses OpenTelemetry for internal metrics",
 		Enabled:     false,
+		Stage:       featuregate.StageAlpha,
 	})
 	return &telemetryInitializer{
 		registry: registry,
@@ -80,17 +93,17 @@ func newColTelemetry(registry *featuregate.Registry) *telemetryInitializer {
 	}
 }
 
-func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap.Logger, cfg ConfigServiceTelemetry, asyncErrorChannel chan error) error {
+func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap.Logger, cfg ConfigServiceTelemetry, detected pcommon.Resource, asyncErrorChannel chan error) error {
 	var err error
 	tel.doInitOnce.Do(
 		func() {
-			err = tel.initOnce(buildInfo, logger, cfg, asyncErrorChannel)
+			err = tel.initOnce(buildInfo, logger, cfg, detected, asyncErrorChannel)
 		},
 	)
 	return err
 }
 
-func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger *zap.Logger, cfg ConfigServiceTelemetry, asyncErrorChannel chan error) error {
+func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger *zap.Logger, cfg ConfigServiceTelemetry, detected pcommon.Resource, asyncErrorChannel chan error) error {
 	if cfg.Metrics.Level == configtelemetry.LevelNone || cfg.Metrics.Address == "" {
 		logger.Info(
 			"Skipping telemetry setup.",
@@ -102,27 +115,7 @@ func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger
 
 	logger.Info("Setting up own telemetry...")
 
-	// Construct telemetry attributes from resource attributes.
-	telAttrs := map[string]string{}
-	for k, v := range cfg.Resource {
-		// nil value indicates that the attribute should not be included in the telemetry.
-		if v != nil {
-			telAttrs[k] = *v
-		}
-	}
-
-	if _, ok := cfg.Resource[semconv.AttributeServiceInstanceID]; !ok {
-		// AttributeServiceInstanceID is not specified in the config. Auto-generate one.
-		instanceUUID, _ := uuid.NewRandom()
-		instanceID := instanceUUID.String()
-		telAttrs[semconv.AttributeServiceInstanceID] = instanceID
-	}
-
-	if _, ok := cfg.Resource[semconv.AttributeServiceVersion]; !ok {
-		// AttributeServiceVersion is not specified in the config. Use the actual
-		// build version.
-		telAttrs[semconv.AttributeServiceVersion] = buildInfo.Version
-	}
+	telAttrs := telemetryAttributes(buildInfo, cfg, detected)
 
 	var pe http.Handler
 	var err error
@@ -158,6 +151,63 @@ func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger
 	return nil
 }
 
+// telemetryAttributes returns the attributes of the collector's own telemetry, the detected attributes
+// being overridden by the configured resource attributes.
+func telemetryAttributes(buildInfo component.BuildInfo, cfg ConfigServiceTelemetry, detected pcommon.Resource) map[string]string {
+	telAttrs := map[string]string{}
+	detected.Attributes().Range(func(k string, v pcommon.Value) bool {
+		telAttrs[k] = v.AsString()
+		return true
+	})
+
+	// Construct telemetry attributes from resource attributes.
+	for k, v := range cfg.Resource {
+		// nil value indicates that the attribute should not be included in the telemetry.
+		if v != nil {
+			telAttrs[k] = *v
+		} else {
+			delete(telAttrs, k)
+		}
+	}
+
+	if _, ok := cfg.Resource[semconv.AttributeServiceInstanceID]; !ok {
+		if _, found := telAttrs[semconv.AttributeServiceInstanceID]; !found {
+			// AttributeServiceInstanceID is not specified in the config. Auto-generate one.
+			instanceUUID, _ := uuid.NewRandom()
+			instanceID := instanceUUID.String()
+			telAttrs[semconv.AttributeServiceInstanceID] = instanceID
+		}
+	}
+
+	if _, ok := cfg.Resource[semconv.AttributeServiceVersion]; !ok {
+		if _, found := telAttrs[semconv.AttributeServiceVersion]; !found {
+			// AttributeServiceVersion is not specified in the config. Use the actual
+			// build version.
+			telAttrs[semconv.AttributeServiceVersion] = buildInfo.Version
+		}
+	}
+	return telAttrs
+}
+
+// detectResource runs the configured resource detectors. The failures are logged instead of failing
+// the start of the collector, the detection of the environment being best effort.
+func detectResource(cfg telemetry.ResourceDetectionConfig, logger *zap.Logger) pcommon.Resource {
+	if len(cfg.Detectors) == 0 {
+		return pcommon.NewResource()
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultResourceDetectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, err := resourcedetection.Detect(ctx, cfg.Detectors)
+	if err != nil {
+		logger.Warn("Failed to detect some resource attributes", zap.Error(err))
+	}
+	return res
+}
+
 func (tel *telemetryInitializer) initOpenCensus(cfg ConfigServiceTelemetry, telAttrs map[string]string) (http.Handler, error) {
 	tel.ocRegistry = ocmetric.NewRegistry()
 	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
@@ -166,6 +216,10 @@ func (tel *telemetryInitializer) initOpenCensus(cfg ConfigServiceTelemetry, telA
 	obsMetrics := obsreportconfig.Configure(cfg.Metrics.Level)
 	views = append(views, batchprocessor.MetricViews()...)
 	views = append(views, obsMetrics.Views...)
+	views, err := applyViewConfigs(views, cfg.Metrics.Views)
+	if err != nil {
+		return nil, err
+	}
 
 	tel.views = views
 	if err := view.Register(views...); err != nil {
@@ -175,6 +229,7 @@ func (tel *telemetryInitializer) initOpenCensus(cfg ConfigServiceTelemetry, telA
 	// Until we can use a generic metrics exporter, default to Prometheus.
 	opts := prometheus.Options{
 		Namespace: "otelcol",
+		Registry:  promclient.NewRegistry(),
 	}
 
 	opts.ConstLabels = make(map[string]string)
@@ -189,6 +244,12 @@ func (tel *telemetryInitializer) initOpenCensus(cfg ConfigServiceTelemetry, telA
 	}
 
 	view.RegisterExporter(pe)
+
+	if cfg.Metrics.Exemplars {
+		obsreportconfig.SetExemplarsEnabled(true)
+		// The exemplars are only supported by the OpenMetrics format.
+		return promhttp.HandlerFor(newExemplarGatherer(opts.Registry, opts.Namespace, views), promhttp.HandlerOpts{EnableOpenMetrics: true}), nil
+	}
 	return pe, nil
 }
 
@@ -217,6 +278,7 @@ func (tel *telemetryInitializer) initOpenTelemetry() (http.Handler, error) {
 
 func (tel *telemetryInitializer) shutdown() error {
 	metricproducer.GlobalManager().DeleteProducer(tel.ocRegistry)
+	obsreportconfig.SetExemplarsEnabled(false)
 
 	view.Unregister(tel.views...)
 
@@ -227,6 +289,54 @@ func (tel *telemetryInitializer) shutdown() error {
 	return nil
 }
 
+// applyViewConfigs returns a copy of views with the user provided customizations applied.
+func applyViewConfigs(views []*view.View, cfgs []telemetry.ViewConfig) ([]*view.View, error) {
+	if len(cfgs) == 0 {
+		return views, nil
+	}
+
+	byName := make(map[string]telemetry.ViewConfig, len(cfgs))
+	for _, c := range cfgs {
+		byName[c.Name] = c
+	}
+
+	ret := make([]*view.View, 0, len(views))
+	for _, v := range views {
+		c, ok := byName[v.Name]
+		if !ok {
+			ret = append(ret, v)
+			continue
+		}
+		delete(byName, v.Name)
+
+		nv := *v
+		if len(c.DropAttributes) > 0 {
+			drop := make(map[string]struct{}, len(c.DropAttributes))
+			for _, k := range c.DropAttributes {
+				drop[k] = struct{}{}
+			}
+			nv.TagKeys = make([]tag.Key, 0, len(v.TagKeys))
+			for _, k := range v.TagKeys {
+				if _, ok := drop[k.Name()]; !ok {
+					nv.TagKeys = append(nv.TagKeys, k)
+				}
+			}
+		}
+		if len(c.Buckets) > 0 {
+			if v.Aggregation.Type != view.AggTypeDistribution {
+				return nil, fmt.Errorf("cannot override buckets of view %q which is not a histogram", v.Name)
+			}
+			nv.Aggregation = view.Distribution(c.Buckets...)
+		}
+		ret = append(ret, &nv)
+	}
+
+	for name := range byName {
+		return nil, fmt.Errorf("telemetry view %q does not exist", name)
+	}
+	return ret, nil
+}
+
 func sanitizePrometheusKey(str string) string {
 	runeFilterMap := func(r rune) rune {
 		if unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_' {
@@ -236,3 +346,18 @@ func sanitizePrometheusKey(str string) string {
 	}
 	return strings.Map(runeFilterMap, str)
 }
+
+// newTextMapPropagator returns the composite of the configured trace propagators,
+// the names being validated by telemetry.TracesConfig.
+func newTextMapPropagator(cfg telemetry.TracesConfig) propagation.TextMapPropagator {
+	var propagators []propagation.TextMapPropagator
+	for _, name := range cfg.Propagators {
+		switch name {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...)
+}
@@ -15,21 +15,46 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/resourcedetection"
 )
 
 // Config defines the configurable settings for service telemetry.
 type Config struct {
 	Logs    LogsConfig    `mapstructure:"logs"`
 	Metrics MetricsConfig `mapstructure:"metrics"`
+	Traces  TracesConfig  `mapstructure:"traces"`
 
 	// Resource specifies user-defined attributes to include with all emitted telemetry.
 	// Note that some attributes are added automatically (e.g. service.version) even
 	// if they are not specified here. In order to suppress such attributes the
 	// attribute must be specified in this map with null YAML value (nil string pointer).
 	Resource map[string]*string `mapstructure:"resource"`
+
+	// ResourceDetection detects the attributes of the environment the collector runs in, added to the
+	// Resource attributes which take precedence over the detected ones.
+	ResourceDetection ResourceDetectionConfig `mapstructure:"resource_detection"`
+}
+
+// Validate checks the telemetry Config is valid.
+func (cfg *Config) Validate() error {
+	if err := cfg.Logs.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Metrics.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Traces.Validate(); err != nil {
+		return err
+	}
+	return cfg.ResourceDetection.Validate()
 }
 
 // LogsConfig defines the configurable settings for service telemetry logs.
@@ -40,6 +65,16 @@ type LogsConfig struct {
 	// (default = "INFO")
 	Level zapcore.Level `mapstructure:"level"`
 
+	// Components are the minimum enabled logging levels of the components overriding Level,
+	// keyed by the section of the component in the configuration and its ID.
+	// Example:
+	//
+	// 		components:
+	// 			exporters.otlphttp: debug
+	//
+	// The levels can be changed at runtime with the loglevel extension.
+	Components map[string]zapcore.Level `mapstructure:"components"`
+
 	// Development puts the logger in development mode, which changes the
 	// behavior of DPanicLevel and takes stacktraces more liberally.
 	// (default = false)
@@ -90,6 +125,28 @@ type LogsConfig struct {
 	InitialFields map[string]interface{} `mapstructure:"initial_fields"`
 }
 
+// Validate checks the LogsConfig is valid.
+func (cfg *LogsConfig) Validate() error {
+	for key := range cfg.Components {
+		items := strings.SplitN(key, ".", 2)
+		if len(items) != 2 {
+			return fmt.Errorf("log level component %q must be <section>.<id>, e.g. \"exporters.otlp\"", key)
+		}
+		switch items[0] {
+		case "receivers", "processors", "exporters", "extensions":
+		default:
+			return fmt.Errorf("log level component %q has an unknown section %q", key, items[0])
+		}
+		idItems := strings.SplitN(items[1], "/", 2)
+		for _, item := range idItems {
+			if strings.TrimSpace(item) == "" {
+				return fmt.Errorf("log level component %q has an invalid id %q", key, items[1])
+			}
+		}
+	}
+	return nil
+}
+
 // MetricsConfig exposes the common Telemetry configuration for one component.
 // Experimental: *NOTE* this structure is subject to change or removal in the future.
 type MetricsConfig struct {
@@ -102,4 +159,111 @@ type MetricsConfig struct {
 
 	// Address is the [address]:port that metrics exposition should be bound to.
 	Address string `mapstructure:"address"`
+
+	// Views allows to customize the views of the collector's own metrics, e.g. to drop
+	// high cardinality attributes or to override histogram bucket boundaries.
+	Views []ViewConfig `mapstructure:"views"`
+
+	// Exemplars enables the exemplars linking the counters and histograms of the collector's own metrics
+	// to the sampled spans of the collector's own traces, e.g. of the failed exports. The metrics are then
+	// exposed in the OpenMetrics format to the scrapers accepting it, the exemplars carrying the trace_id
+	// and span_id of the last measurement of the series recorded within a sampled span.
+	Exemplars bool `mapstructure:"exemplars"`
+}
+
+// ViewConfig defines the customizations applied to one of the collector's own metric views.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type ViewConfig struct {
+	// Name is the name of the view to customize, e.g. "receiver/accepted_spans".
+	Name string `mapstructure:"name"`
+
+	// DropAttributes is the list of attribute keys removed from the view, e.g. "transport".
+	// Data points that only differ by the dropped attributes are aggregated together.
+	DropAttributes []string `mapstructure:"drop_attributes"`
+
+	// Buckets overrides the bucket boundaries of a view with a histogram aggregation.
+	Buckets []float64 `mapstructure:"buckets"`
+}
+
+// Validate checks the MetricsConfig is valid.
+func (mc *MetricsConfig) Validate() error {
+	seen := map[string]struct{}{}
+	for _, v := range mc.Views {
+		if v.Name == "" {
+			return errors.New("view name must not be empty")
+		}
+		if _, ok := seen[v.Name]; ok {
+			return fmt.Errorf("view %q is configured more than once", v.Name)
+		}
+		seen[v.Name] = struct{}{}
+		for i := 1; i < len(v.Buckets); i++ {
+			if v.Buckets[i] <= v.Buckets[i-1] {
+				return fmt.Errorf("view %q buckets must be in strictly increasing order", v.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// TracesConfig exposes the common Telemetry configuration for the collector's own traces.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type TracesConfig struct {
+	// Propagators is the list of propagators used to inject the context of the collector's own spans
+	// in the outgoing requests, e.g. the export requests, and to extract it from the incoming requests.
+	// The possible values are:
+	//  - "tracecontext" propagates the span context in the W3C traceparent and tracestate headers;
+	//  - "baggage" propagates the W3C baggage header.
+	// By default, no context is propagated.
+	Propagators []string `mapstructure:"propagators"`
+}
+
+// Validate checks the TracesConfig is valid.
+func (tc *TracesConfig) Validate() error {
+	for _, p := range tc.Propagators {
+		if p != "tracecontext" && p != "baggage" {
+			return fmt.Errorf("unsupported trace propagator %q, must be one of \"tracecontext\" or \"baggage\"", p)
+		}
+	}
+	return nil
+}
+
+// ResourceDetectionConfig defines the detection of the resource attributes of the environment the collector runs in.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type ResourceDetectionConfig struct {
+	// Detectors is the list of detectors run in order, the attributes of the first detectors taking precedence.
+	// The built-in detectors are:
+	//  - "env" detects the attributes of the OTEL_RESOURCE_ATTRIBUTES environment variable;
+	//  - "host" detects the host.name and os.type attributes;
+	//  - "container" detects the container.id attribute.
+	// Other detectors, e.g. the cloud ones, are registered with resourcedetection.Register.
+	// By default, no attribute is detected.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Timeout is the maximum duration of the detection, the collector starting with the attributes
+	// detected so far once elapsed.
+	// (default = 5s)
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// StampPipelines inserts the detected attributes in the resources of all the data received by
+	// the pipelines, without overriding the attributes already set.
+	// (default = false)
+	StampPipelines bool `mapstructure:"stamp_pipelines"`
+}
+
+// Validate checks the ResourceDetectionConfig is valid.
+func (rdc *ResourceDetectionConfig) Validate() error {
+	seen := map[string]struct{}{}
+	for _, name := range rdc.Detectors {
+		if !resourcedetection.IsRegistered(name) {
+			return fmt.Errorf("resource detector %q is not registered", name)
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("resource detector %q is configured more than once", name)
+		}
+		seen[name] = struct{}{}
+	}
+	if rdc.Timeout < 0 {
+		return errors.New("resource detection timeout must not be negative")
+	}
+	return nil
 }
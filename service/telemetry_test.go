@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestApplyViewConfigs(t *testing.T) {
+	receiverKey := tag.MustNewKey("receiver")
+	transportKey := tag.MustNewKey("transport")
+	sumView := &view.View{
+		Name:        "receiver/accepted_spans",
+		Measure:     stats.Int64("receiver/accepted_spans", "", stats.UnitDimensionless),
+		TagKeys:     []tag.Key{receiverKey, transportKey},
+		Aggregation: view.Sum(),
+	}
+	distView := &view.View{
+		Name:        "processor/batch/batch_send_size",
+		Measure:     stats.Int64("processor/batch/batch_send_size", "", stats.UnitDimensionless),
+		TagKeys:     []tag.Key{receiverKey},
+		Aggregation: view.Distribution(10, 100),
+	}
+	views := []*view.View{sumView, distView}
+
+	got, err := applyViewConfigs(views, nil)
+	require.NoError(t, err)
+	assert.Equal(t, views, got)
+
+	got, err = applyViewConfigs(views, []telemetry.ViewConfig{
+		{Name: "receiver/accepted_spans", DropAttributes: []string{"transport"}},
+		{Name: "processor/batch/batch_send_size", Buckets: []float64{1, 2, 3}},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, []tag.Key{receiverKey}, got[0].TagKeys)
+	assert.Equal(t, []float64{1, 2, 3}, got[1].Aggregation.Buckets)
+	// Original views must not be modified.
+	assert.Equal(t, []tag.Key{receiverKey, transportKey}, sumView.TagKeys)
+	assert.Equal(t, []float64{10, 100}, distView.Aggregation.Buckets)
+
+	_, err = applyViewConfigs(views, []telemetry.ViewConfig{{Name: "receiver/accepted_spans", Buckets: []float64{1}}})
+	assert.EqualError(t, err, `cannot override buckets of view "receiver/accepted_spans" which is not a histogram`)
+
+	_, err = applyViewConfigs(views, []telemetry.ViewConfig{{Name: "unknown"}})
+	assert.EqualError(t, err, `telemetry view "unknown" does not exist`)
+}
+
+func TestNewTextMapPropagator(t *testing.T) {
+	assert.Empty(t, newTextMapPropagator(telemetry.TracesConfig{}).Fields())
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate"},
+		newTextMapPropagator(telemetry.TracesConfig{Propagators: []string{"tracecontext"}}).Fields())
+	assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"},
+		newTextMapPropagator(telemetry.TracesConfig{Propagators: []string{"tracecontext", "baggage"}}).Fields())
+}
+
+func TestTelemetryAttributes(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	buildInfo := component.BuildInfo{Version: "1.2.3"}
+	detected := pcommon.NewResource()
+	detected.Attributes().InsertString("host.name", "detected-host")
+	detected.Attributes().InsertString("os.type", "linux")
+	detected.Attributes().InsertString("container.id", "detected-container")
+	detected.Attributes().InsertString("service.instance.id", "detected-instance")
+
+	attrs := telemetryAttributes(buildInfo, ConfigServiceTelemetry{
+		Resource: map[string]*string{
+			"host.name":    strPtr("configured-host"),
+			"container.id": nil,
+		},
+	}, detected)
+	// The configured attributes take precedence over the detected ones, nil values removing them.
+	assert.Equal(t, map[string]string{
+		"host.name":           "configured-host",
+		"os.type":             "linux",
+		"service.instance.id": "detected-instance",
+		"service.version":     "1.2.3",
+	}, attrs)
+
+	attrs = telemetryAttributes(buildInfo, ConfigServiceTelemetry{}, pcommon.NewResource())
+	assert.Len(t, attrs, 2)
+	assert.NotEmpty(t, attrs["service.instance.id"])
+	assert.Equal(t, "1.2.3", attrs["service.version"])
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service/featuregate"
+	"go.opentelemetry.io/collector/service/internal/configunmarshaler"
+)
+
+var errInvalidConfig = errors.New("invalid configuration")
+
+// validationResult is the output of the validate command.
+type validationResult struct {
+	Valid  bool              `json:"valid"`
+	Errors []validationError `json:"errors"`
+}
+
+// validationError is an error of the configuration reported by the validate command.
+type validationError struct {
+	// Path of the configuration section causing the error, in the confmap.KeyDelimiter notation.
+	// Empty if the error is not specific to a section.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+	// File and Line locate the path in the configuration files, when they are local YAML files.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// newValidateCommand constructs the validate subcommand which resolves the configuration from the flags,
+// unmarshals and validates it, without starting the collector. The validation result is printed as JSON
+// and the command fails if the configuration is invalid.
+func newValidateCommand(set CollectorSettings) *cobra.Command {
+	flagSet := flags()
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validates the configuration and prints the errors as JSON",
+		Long: "Validates the configuration given by the --config and --set flags and prints the errors as JSON, " +
+			"exiting with a non-zero code if the configuration is invalid.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			featuregate.GetRegistry().ApplyFlags(gatesList)
+			cfgSet := newFlagsConfigProviderSettings(flagSet)
+			res := validateConfig(cmd.Context(), cfgSet, set.Factories)
+
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(res); err != nil {
+				return err
+			}
+			if !res.Valid {
+				return errInvalidConfig
+			}
+			return nil
+		},
+	}
+	validateCmd.Flags().AddGoFlagSet(flagSet)
+	return validateCmd
+}
+
+// validateConfig resolves, unmarshals and validates the configuration, reporting all the invalid components.
+func validateConfig(ctx context.Context, set ConfigProviderSettings, factories component.Factories) validationResult {
+	errs := resolveAndValidate(ctx, set, factories)
+	locator := newYAMLLocator(set.Locations)
+	for i := range errs {
+		if errs[i].Path != "" {
+			errs[i].File, errs[i].Line = locator.locate(errs[i].Path)
+		}
+	}
+	return validationResult{Valid: len(errs) == 0, Errors: errs}
+}
+
+func resolveAndValidate(ctx context.Context, set ConfigProviderSettings, factories component.Factories) []validationError {
+	mr, err := confmap.NewResolver(confmap.ResolverSettings{URIs: set.Locations, Providers: set.MapProviders, Converters: set.MapConverters})
+	if err != nil {
+		return []validationError{{Message: err.Error()}}
+	}
+	defer func() { _ = mr.Shutdown(ctx) }()
+
+	conf, err := mr.Resolve(ctx)
+	if err != nil {
+		return []validationError{{Message: fmt.Sprintf("cannot resolve the configuration: %v", err)}}
+	}
+
+	cfg, err := configunmarshaler.New().Unmarshal(conf, factories)
+	if err != nil {
+		path, _ := configunmarshaler.ErrorPath(err)
+		return []validationError{{Path: path, Message: fmt.Sprintf("cannot unmarshal the configuration: %v", err)}}
+	}
+
+	if errs := validateComponents(cfg); len(errs) != 0 {
+		return errs
+	}
+
+	// The components being valid, the errors are caused by missing components or by the service.
+	if err = cfg.Validate(); err != nil {
+		path := "service"
+		switch {
+		case len(cfg.Receivers) == 0:
+			path = "receivers"
+		case len(cfg.Exporters) == 0:
+			path = "exporters"
+		}
+		return []validationError{{Path: path, Message: err.Error()}}
+	}
+	return nil
+}
+
+// validateComponents returns the errors of all the invalid components, sorted by path.
+func validateComponents(cfg *Config) []validationError {
+	type componentConfig struct {
+		path string
+		cfg  interface{ Validate() error }
+	}
+	var cfgs []componentConfig
+	for id, c := range cfg.Receivers {
+		cfgs = append(cfgs, componentConfig{path: componentPath("receivers", id), cfg: c})
+	}
+	for id, c := range cfg.Processors {
+		cfgs = append(cfgs, componentConfig{path: componentPath("processors", id), cfg: c})
+	}
+	for id, c := range cfg.Exporters {
+		cfgs = append(cfgs, componentConfig{path: componentPath("exporters", id), cfg: c})
+	}
+	for id, c := range cfg.Extensions {
+		cfgs = append(cfgs, componentConfig{path: componentPath("extensions", id), cfg: c})
+	}
+	sort.Slice(cfgs, func(i, j int) bool { return cfgs[i].path < cfgs[j].path })
+
+	var errs []validationError
+	for _, c := range cfgs {
+		if err := c.cfg.Validate(); err != nil {
+			errs = append(errs, validationError{Path: c.path, Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+func componentPath(kind string, id config.ComponentID) string {
+	return kind + confmap.KeyDelimiter + id.String()
+}
+
+// yamlLocator locates the configuration paths in the local YAML files among the config locations.
+type yamlLocator struct {
+	files []string
+	docs  []*yaml.Node
+}
+
+func newYAMLLocator(locations []string) *yamlLocator {
+	l := &yamlLocator{}
+	for _, location := range locations {
+		file, ok := localFile(location)
+		if !ok {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			continue
+		}
+		var doc yaml.Node
+		if err = yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 {
+			continue
+		}
+		l.files = append(l.files, file)
+		l.docs = append(l.docs, doc.Content[0])
+	}
+	return l
+}
+
+// locate returns the file and line defining the path, the last file defining it taking
+// precedence as the config locations are merged in order. Returns a zero line if not found.
+func (l *yamlLocator) locate(path string) (string, int) {
+	for i := len(l.docs) - 1; i >= 0; i-- {
+		if line := lineOf(l.docs[i], strings.Split(path, confmap.KeyDelimiter)); line != 0 {
+			return l.files[i], line
+		}
+	}
+	return "", 0
+}
+
+// lineOf returns the line of the key at the end of the keys path in the YAML mapping node, zero if not found.
+func lineOf(node *yaml.Node, keys []string) int {
+	line := 0
+	for _, key := range keys {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return 0
+		}
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line = node.Content[i].Line
+				value = node.Content[i+1]
+				break
+			}
+		}
+		if value == nil {
+			return 0
+		}
+		node = value
+	}
+	return line
+}
+
+// localFile returns the file path of the config location if it is a local file.
+func localFile(location string) (string, bool) {
+	if strings.HasPrefix(location, "file:") {
+		return strings.TrimPrefix(location, "file:"), true
+	}
+	// Locations without scheme are files.
+	if !strings.Contains(location, ":") || filepath.IsAbs(location) {
+		return location, true
+	}
+	return "", false
+}
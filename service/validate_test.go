@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+)
+
+type invalidReceiverConfig struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+}
+
+func (cfg *invalidReceiverConfig) Validate() error {
+	return errors.New("invalid config")
+}
+
+type invalidExporterConfig struct {
+	config.ExporterSettings `mapstructure:",squash"`
+}
+
+func (cfg *invalidExporterConfig) Validate() error {
+	return errors.New("invalid config")
+}
+
+func validateTestFactories(t *testing.T) component.Factories {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Receivers["invalid"] = component.NewReceiverFactory("invalid", func() config.Receiver {
+		return &invalidReceiverConfig{ReceiverSettings: config.NewReceiverSettings(config.NewComponentID("invalid"))}
+	})
+	factories.Exporters["invalid"] = component.NewExporterFactory("invalid", func() config.Exporter {
+		return &invalidExporterConfig{ExporterSettings: config.NewExporterSettings(config.NewComponentID("invalid"))}
+	})
+	return factories
+}
+
+func executeValidate(t *testing.T, args ...string) (validationResult, error) {
+	cmd := NewCommand(CollectorSettings{Factories: validateTestFactories(t)})
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"validate"}, args...))
+	err := cmd.Execute()
+
+	var res validationResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &res))
+	return res, err
+}
+
+func TestValidateCommandValid(t *testing.T) {
+	res, err := executeValidate(t, "--config", filepath.Join("testdata", "otelcol-nop.yaml"))
+	require.NoError(t, err)
+	assert.True(t, res.Valid)
+	assert.Empty(t, res.Errors)
+}
+
+func TestValidateCommandInvalidComponents(t *testing.T) {
+	file := filepath.Join("testdata", "otelcol-validate.yaml")
+	res, err := executeValidate(t, "--config", "file:"+file)
+	assert.ErrorIs(t, err, errInvalidConfig)
+	assert.False(t, res.Valid)
+	assert.Equal(t, []validationError{
+		{Path: "exporters::invalid", Message: "invalid config", File: file, Line: 8},
+		{Path: "receivers::invalid", Message: "invalid config", File: file, Line: 3},
+		{Path: "receivers::invalid/2", Message: "invalid config", File: file, Line: 4},
+	}, res.Errors)
+}
+
+func TestValidateCommandUnmarshalError(t *testing.T) {
+	file := filepath.Join("testdata", "otelcol-validate-unknown.yaml")
+	res, err := executeValidate(t, "--config", file)
+	assert.ErrorIs(t, err, errInvalidConfig)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "receivers::unknown", res.Errors[0].Path)
+	assert.Contains(t, res.Errors[0].Message, `unknown receivers type "unknown"`)
+	assert.Equal(t, file, res.Errors[0].File)
+	assert.Equal(t, 3, res.Errors[0].Line)
+}
+
+func TestValidateCommandServiceError(t *testing.T) {
+	file := filepath.Join("testdata", "otelcol-invalid.yaml")
+	res, err := executeValidate(t, "--config", file)
+	assert.ErrorIs(t, err, errInvalidConfig)
+	assert.Equal(t, []validationError{
+		{Path: "service", Message: `pipeline "traces" references processor "invalid" which does not exist`, File: file, Line: 10},
+	}, res.Errors)
+}
+
+func TestValidateCommandSetFlag(t *testing.T) {
+	// The properties set by flags are validated, without location in the files.
+	res, err := executeValidate(t, "--config", filepath.Join("testdata", "otelcol-nop.yaml"), "--set", "receivers.invalid.endpoint=localhost:4317")
+	assert.ErrorIs(t, err, errInvalidConfig)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "receivers::invalid", res.Errors[0].Path)
+	assert.Contains(t, res.Errors[0].Message, "has invalid keys: endpoint")
+	assert.Empty(t, res.Errors[0].File)
+	assert.Zero(t, res.Errors[0].Line)
+}
+
+func TestValidateCommandResolveError(t *testing.T) {
+	res, err := executeValidate(t, "--config", "unknown:config")
+	assert.ErrorIs(t, err, errInvalidConfig)
+	require.Len(t, res.Errors, 1)
+	assert.Empty(t, res.Errors[0].Path)
+	assert.Contains(t, res.Errors[0].Message, "cannot resolve the configuration")
+}
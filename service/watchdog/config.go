@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchdog defines the configuration of the watchdog supervising the components of the
+// pipelines, which restarts the wedged components.
+package watchdog // import "go.opentelemetry.io/collector/service/watchdog"
+
+import (
+	"errors"
+	"time"
+)
+
+// Config defines the configurable settings of the watchdog of the service. A component is wedged when one
+// of its Start calls, or a call consuming data of a processor or an exporter, does not return in time. The
+// wedged components are recreated from their factory and restarted, the new instance replacing the wedged
+// one in the pipelines, while the Shutdown of the wedged instance is abandoned after ShutdownTimeout.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type Config struct {
+	// Enabled enables the watchdog.
+	// (default = false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// CheckInterval is the interval between the liveness checks of the components.
+	// (default = 10s)
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// ConsumeTimeout is the duration after which a call consuming data of a processor or an exporter
+	// which is still in progress makes the component wedged.
+	// (default = 5m)
+	ConsumeTimeout time.Duration `mapstructure:"consume_timeout"`
+
+	// StartTimeout is the duration after which a Start call which did not return makes the component wedged.
+	// (default = 1m)
+	StartTimeout time.Duration `mapstructure:"start_timeout"`
+
+	// ShutdownTimeout is the duration after which a Shutdown call which did not return is abandoned.
+	// (default = 1m)
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	// MaxRestarts is the maximum number of restarts of each component, after which a wedged component
+	// is left as is.
+	// (default = 5)
+	MaxRestarts int `mapstructure:"max_restarts"`
+
+	// InitialBackoff is the minimum duration between the first restarts of a component, doubled after
+	// each restart up to MaxBackoff.
+	// (default = 1s)
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+
+	// MaxBackoff is the maximum duration between the restarts of a component.
+	// (default = 1m)
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+// NewDefaultConfig returns the default watchdog Config, the watchdog being disabled.
+func NewDefaultConfig() Config {
+	return Config{
+		CheckInterval:   10 * time.Second,
+		ConsumeTimeout:  5 * time.Minute,
+		StartTimeout:    time.Minute,
+		ShutdownTimeout: time.Minute,
+		MaxRestarts:     5,
+		InitialBackoff:  time.Second,
+		MaxBackoff:      time.Minute,
+	}
+}
+
+// Validate checks the watchdog Config is valid.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.CheckInterval <= 0 {
+		return errors.New("check_interval must be positive")
+	}
+	if cfg.ConsumeTimeout <= 0 || cfg.StartTimeout <= 0 || cfg.ShutdownTimeout <= 0 {
+		return errors.New("consume_timeout, start_timeout and shutdown_timeout must be positive")
+	}
+	if cfg.MaxRestarts < 0 {
+		return errors.New("max_restarts must not be negative")
+	}
+	if cfg.InitialBackoff < 0 || cfg.MaxBackoff < cfg.InitialBackoff {
+		return errors.New("initial_backoff must not be negative nor greater than max_backoff")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "disabled",
+			modify: func(cfg *Config) { *cfg = Config{} },
+		},
+		{
+			name:   "default",
+			modify: func(cfg *Config) { cfg.Enabled = true },
+		},
+		{
+			name:    "check_interval",
+			modify:  func(cfg *Config) { cfg.Enabled = true; cfg.CheckInterval = 0 },
+			wantErr: "check_interval must be positive",
+		},
+		{
+			name:    "consume_timeout",
+			modify:  func(cfg *Config) { cfg.Enabled = true; cfg.ConsumeTimeout = -time.Second },
+			wantErr: "consume_timeout, start_timeout and shutdown_timeout must be positive",
+		},
+		{
+			name:    "max_restarts",
+			modify:  func(cfg *Config) { cfg.Enabled = true; cfg.MaxRestarts = -1 },
+			wantErr: "max_restarts must not be negative",
+		},
+		{
+			name:    "backoff",
+			modify:  func(cfg *Config) { cfg.Enabled = true; cfg.MaxBackoff = cfg.InitialBackoff / 2 },
+			wantErr: "initial_backoff must not be negative nor greater than max_backoff",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"net/http"
 	"path"
+	"sort"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/featuregate"
@@ -29,13 +30,18 @@ const (
 	pipelinezPath  = "pipelinez"
 	extensionzPath = "extensionz"
 	featurezPath   = "featurez"
+	lifecyclezPath = "lifecyclez"
+	// lifecycleJSONPath serves the lifecycle events in JSON, for the scripts and debug tools.
+	lifecycleJSONPath = "lifecyclez/json"
 )
 
 func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, servicezPath), host.zPagesRequest)
 	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), host.pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), host.extensions.HandleZPages)
-	mux.HandleFunc(path.Join(pathPrefix, featurezPath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, featurezPath), host.handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, lifecyclezPath), host.lifecycle.HandleZPages)
+	mux.HandleFunc(path.Join(pathPrefix, lifecycleJSONPath), host.lifecycle.HandleJSON)
 }
 
 func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
@@ -58,26 +64,36 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
 		ComponentEndpoint: featurezPath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Lifecycle Events",
+		ComponentEndpoint: lifecyclezPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
+// handleFeaturezRequest writes the feature gates with the build info, for the support engineers to see
+// the behavior enabled in a deployed binary.
+func (host *serviceHost) handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
-	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build Info", Properties: getBuildInfoProperties(host.buildInfo)})
+	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData(featuregate.GetRegistry()))
 	zpages.WriteHTMLPageFooter(w)
 }
 
-func getFeaturesTableData() zpages.FeatureGateTableData {
+func getFeaturesTableData(registry *featuregate.Registry) zpages.FeatureGateTableData {
 	data := zpages.FeatureGateTableData{}
-	for _, g := range featuregate.GetRegistry().List() {
+	for _, g := range registry.List() {
 		data.Rows = append(data.Rows, zpages.FeatureGateTableRowData{
 			ID:          g.ID,
+			Stage:       g.Stage.String(),
 			Enabled:     g.Enabled,
+			Source:      string(registry.Source(g.ID)),
 			Description: g.Description,
 		})
 	}
-
+	sort.Slice(data.Rows, func(i, j int) bool { return data.Rows[i].ID < data.Rows[j].ID })
 	return data
 }
 
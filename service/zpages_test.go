@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/service/featuregate"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+func TestGetFeaturesTableData(t *testing.T) {
+	registry := featuregate.NewRegistry()
+	registry.MustRegister(featuregate.Gate{ID: "b", Description: "gate b", Enabled: true, Stage: featuregate.StageBeta})
+	registry.MustRegister(featuregate.Gate{ID: "a", Description: "gate a"})
+	registry.ApplyFlags(featuregate.FlagValue{"a": true})
+
+	assert.Equal(t, zpages.FeatureGateTableData{Rows: []zpages.FeatureGateTableRowData{
+		{ID: "a", Stage: "alpha", Enabled: true, Source: "flag", Description: "gate a"},
+		{ID: "b", Stage: "beta", Enabled: true, Source: "default", Description: "gate b"},
+	}}, getFeaturesTableData(registry))
+}